@@ -204,6 +204,10 @@ func runApplication(deps AppDeps, cfg *platform.Config, logger *slog.Logger, r *
 		logger.Info("running in worker-only mode, HTTP server disabled")
 	}
 
+	if (role == "api" || role == "all") && svcs != nil && svcs.MetadataServer != nil {
+		startWorker(workerCtx, wg, svcs.MetadataServer)
+	}
+
 	quit := make(chan os.Signal, 1)
 	deps.NotifySignals(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
@@ -285,9 +289,39 @@ func runWorkers(ctx context.Context, wg *sync.WaitGroup, workers *setup.Workers)
 	if workers.DatabaseFailover != nil {
 		startWorker(ctx, wg, workers.DatabaseFailover)
 	}
+	if workers.DatabaseBackup != nil {
+		startWorker(ctx, wg, workers.DatabaseBackup)
+	}
+	if workers.ScheduledBackup != nil {
+		startWorker(ctx, wg, workers.ScheduledBackup)
+	}
+	if workers.DBMaintenance != nil {
+		startWorker(ctx, wg, workers.DBMaintenance)
+	}
+	if workers.DBInsights != nil {
+		startWorker(ctx, wg, workers.DBInsights)
+	}
+	if workers.CacheFailover != nil {
+		startWorker(ctx, wg, workers.CacheFailover)
+	}
+	if workers.Preemption != nil {
+		startWorker(ctx, wg, workers.Preemption)
+	}
 	if workers.Log != nil {
 		startWorker(ctx, wg, workers.Log)
 	}
+	if workers.QuotaReconciler != nil {
+		startWorker(ctx, wg, workers.QuotaReconciler)
+	}
+	if workers.SecretRotation != nil {
+		startWorker(ctx, wg, workers.SecretRotation)
+	}
+	if workers.FlowReconciler != nil {
+		startWorker(ctx, wg, workers.FlowReconciler)
+	}
+	if workers.FlowLog != nil {
+		startWorker(ctx, wg, workers.FlowLog)
+	}
 }
 
 func initTracing(logger *slog.Logger) *sdktrace.TracerProvider {
@@ -12,9 +12,17 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// defaultCacheVersions is the version used for each engine when --version
+// is not given.
+var defaultCacheVersions = map[string]string{
+	"redis":     "7.2",
+	"valkey":    "8.0",
+	"memcached": "1.6",
+}
+
 var cacheCmd = &cobra.Command{
 	Use:   "cache",
-	Short: "Manage cache instances (Redis)",
+	Short: "Manage cache instances (Redis, Valkey, Memcached)",
 }
 
 var createCacheCmd = &cobra.Command{
@@ -22,9 +30,16 @@ var createCacheCmd = &cobra.Command{
 	Short: "Create a new cache instance",
 	Run: func(cmd *cobra.Command, args []string) {
 		name, _ := cmd.Flags().GetString("name")
+		engine, _ := cmd.Flags().GetString("engine")
 		version, _ := cmd.Flags().GetString("version")
 		memory, _ := cmd.Flags().GetInt("memory")
 		vpcID, _ := cmd.Flags().GetString("vpc")
+		persistence, _ := cmd.Flags().GetString("persistence")
+		replicas, _ := cmd.Flags().GetInt("replicas")
+		failover, _ := cmd.Flags().GetBool("failover")
+		cluster, _ := cmd.Flags().GetBool("cluster")
+		shards, _ := cmd.Flags().GetInt("shards")
+		metrics, _ := cmd.Flags().GetBool("metrics")
 		wait, _ := cmd.Flags().GetBool("wait")
 
 		client := createClient(opts)
@@ -33,8 +48,24 @@ var createCacheCmd = &cobra.Command{
 			vpcPtr = &vpcID
 		}
 
-		fmt.Printf("Creating Redis cache '%s' (v%s, %dMB)...\n", name, version, memory)
-		cache, err := client.CreateCache(name, version, memory, vpcPtr)
+		if version == "" {
+			version = defaultCacheVersions[engine]
+		}
+
+		fmt.Printf("Creating %s cache '%s' (v%s, %dMB)...\n", engine, name, version, memory)
+		cache, err := client.CreateCacheWithInput(sdk.CreateCacheInput{
+			Name:            name,
+			Engine:          engine,
+			Version:         version,
+			MemoryMB:        memory,
+			VpcID:           vpcPtr,
+			Persistence:     persistence,
+			Replicas:        replicas,
+			FailoverEnabled: failover,
+			ClusterMode:     cluster,
+			Shards:          shards,
+			MetricsEnabled:  metrics,
+		})
 		if err != nil {
 			fmt.Printf("Error creating cache: %v\n", err)
 			return
@@ -97,12 +128,35 @@ var getCacheCmd = &cobra.Command{
 		fmt.Printf("Version:   %s\n", cache.Version)
 		fmt.Printf("Status:    %s\n", cache.Status)
 		fmt.Printf("Port:      %d\n", cache.Port)
+		if cache.ReaderPort != 0 {
+			fmt.Printf("Reader:    %d\n", cache.ReaderPort)
+		}
 		fmt.Printf("Memory:    %d MB\n", cache.MemoryMB)
+		if cache.Persistence != "" {
+			fmt.Printf("Persist:   %s\n", cache.Persistence)
+		}
+		if cache.ClusterMode {
+			fmt.Printf("Cluster:   %d shards\n", cache.Shards)
+		}
+		fmt.Printf("Replicas:  %d (automatic failover: %t)\n", cache.Replicas, cache.FailoverEnabled)
+		if cache.MetricsEnabled {
+			fmt.Printf("Metrics:   %d\n", cache.MetricsPort)
+		}
 		fmt.Printf("Password:  %s\n", "******** (use 'cache connection' or check secrets)")
 		if cache.VpcID != nil {
 			fmt.Printf("VPC ID:    %s\n", *cache.VpcID)
 		}
 		fmt.Printf("Created:   %s\n", cache.CreatedAt)
+
+		if len(cache.Nodes) > 0 {
+			fmt.Println("\nNodes:")
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			_, _ = fmt.Fprintln(w, "ID\tSHARD\tROLE\tSTATUS")
+			for _, n := range cache.Nodes {
+				_, _ = fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", n.ID, n.Shard, n.Role, n.Status)
+			}
+			_ = w.Flush()
+		}
 	},
 }
 
@@ -178,6 +232,70 @@ var flushCacheCmd = &cobra.Command{
 	},
 }
 
+var modifyCacheCmd = &cobra.Command{
+	Use:   "modify [id]",
+	Short: "Resize the memory or change the shard count of a cache",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var input sdk.ModifyCacheInput
+		if cmd.Flags().Changed("memory") {
+			memory, _ := cmd.Flags().GetInt("memory")
+			input.MemoryMB = &memory
+		}
+		if cmd.Flags().Changed("shards") {
+			shards, _ := cmd.Flags().GetInt("shards")
+			input.Shards = &shards
+		}
+		if input.MemoryMB == nil && input.Shards == nil {
+			fmt.Println("Nothing to modify. Use --memory or --shards.")
+			return
+		}
+
+		client := createClient(opts)
+		cacheID := resolveCacheID(args[0], client)
+		cache, err := client.ModifyCache(cacheID, input)
+		if err != nil {
+			fmt.Printf("Error modifying cache: %v\n", err)
+			return
+		}
+		fmt.Printf("Cache %s modified (%dMB", cache.Name, cache.MemoryMB)
+		if cache.ClusterMode {
+			fmt.Printf(", %d shards", cache.Shards)
+		}
+		fmt.Println(")")
+	},
+}
+
+var failoverCacheCmd = &cobra.Command{
+	Use:   "failover [id]",
+	Short: "Promote a replica of a cache to primary",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		cacheID := resolveCacheID(args[0], client)
+		if err := client.FailoverCache(cacheID); err != nil {
+			fmt.Printf("Error failing over cache: %v\n", err)
+			return
+		}
+		fmt.Println("Cache failover completed")
+	},
+}
+
+var rotateCacheCredentialsCmd = &cobra.Command{
+	Use:   "rotate-credentials [id]",
+	Short: "Replace the password of a cache",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		cacheID := resolveCacheID(args[0], client)
+		if err := client.RotateCacheCredentials(cacheID); err != nil {
+			fmt.Printf("Error rotating cache credentials: %v\n", err)
+			return
+		}
+		fmt.Println("Cache credentials rotated. Use 'cache connection' for the new connection string.")
+	},
+}
+
 // resolveCacheID resolves a cache ID or name to a full UUID.
 func resolveCacheID(idOrName string, client *sdk.Client) string {
 	if _, err := uuid.Parse(idOrName); err == nil {
@@ -197,14 +315,24 @@ func resolveCacheID(idOrName string, client *sdk.Client) string {
 
 func init() {
 	createCacheCmd.Flags().String("name", "", "Name of the cache")
-	createCacheCmd.Flags().String("version", "7.2", "Redis version")
+	createCacheCmd.Flags().String("engine", "redis", "Cache engine (redis, valkey, memcached)")
+	createCacheCmd.Flags().String("version", "", "Engine version (default 7.2 for redis, 8.0 for valkey, 1.6 for memcached)")
 	createCacheCmd.Flags().Int("memory", 128, "Memory limit in MB")
 	createCacheCmd.Flags().String("vpc", "", "VPC ID to attach to")
+	createCacheCmd.Flags().String("persistence", "none", "Persistence mode (none, rdb, aof)")
+	createCacheCmd.Flags().Int("replicas", 0, "Number of read replicas (0-5)")
+	createCacheCmd.Flags().Bool("failover", false, "Enable automatic failover to a replica")
+	createCacheCmd.Flags().Bool("cluster", false, "Create a Redis Cluster sharded across primaries")
+	createCacheCmd.Flags().Int("shards", 0, "Number of shards in cluster mode (default 3)")
+	createCacheCmd.Flags().Bool("metrics", false, "Run a Prometheus exporter next to the cache")
 	createCacheCmd.Flags().Bool("wait", false, "Wait for cache to be ready")
 	_ = createCacheCmd.MarkFlagRequired("name")
 
 	flushCacheCmd.Flags().Bool("yes", false, "Confirm flush")
 
+	modifyCacheCmd.Flags().Int("memory", 0, "New memory limit in MB")
+	modifyCacheCmd.Flags().Int("shards", 0, "New number of shards (cluster mode)")
+
 	cacheCmd.AddCommand(createCacheCmd)
 	cacheCmd.AddCommand(listCacheCmd)
 	cacheCmd.AddCommand(getCacheCmd)
@@ -212,6 +340,9 @@ func init() {
 	cacheCmd.AddCommand(connectionCacheCmd)
 	cacheCmd.AddCommand(statsCacheCmd)
 	cacheCmd.AddCommand(flushCacheCmd)
+	cacheCmd.AddCommand(modifyCacheCmd)
+	cacheCmd.AddCommand(failoverCacheCmd)
+	cacheCmd.AddCommand(rotateCacheCredentialsCmd)
 }
 
 func formatBytes(b int64) string {
@@ -219,6 +219,23 @@ func handleCaches(w http.ResponseWriter, r *http.Request) bool {
 				VpcID:    &vpcID,
 				Port:     6379,
 				MemoryMB: 128,
+				Replicas: 1,
+				Nodes: []*sdk.CacheNode{
+					{ID: "node-1", Role: "PRIMARY", Status: "RUNNING"},
+					{ID: "node-2", Role: "REPLICA", Status: "RUNNING"},
+				},
+			},
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+		return true
+	case r.Method == http.MethodPatch && r.URL.Path == pathCaches+testCacheID:
+		resp := sdk.Response[sdk.Cache]{
+			Data: sdk.Cache{
+				ID:          testCacheID,
+				Name:        testRedisMod,
+				MemoryMB:    512,
+				ClusterMode: true,
+				Shards:      6,
 			},
 		}
 		_ = json.NewEncoder(w).Encode(resp)
@@ -233,6 +250,18 @@ func handleCaches(w http.ResponseWriter, r *http.Request) bool {
 		return true
 	case r.Method == http.MethodPost && r.URL.Path == pathCaches+testCacheID+"/flush":
 		return respondNoContent(w)
+	case r.Method == http.MethodPost && r.URL.Path == pathCaches+testCacheID+"/failover":
+		resp := sdk.Response[map[string]string]{
+			Data: map[string]string{"message": "failover initiated"},
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+		return true
+	case r.Method == http.MethodPost && r.URL.Path == pathCaches+testCacheID+"/rotate-credentials":
+		resp := sdk.Response[map[string]string]{
+			Data: map[string]string{"message": "cache credentials rotated"},
+		}
+		_ = json.NewEncoder(w).Encode(resp)
+		return true
 	case r.Method == http.MethodGet && r.URL.Path == pathCaches+testCacheID+"/stats":
 		resp := sdk.Response[sdk.CacheStats]{
 			Data: sdk.CacheStats{
@@ -585,6 +614,54 @@ func TestCacheShowCommandOutput(t *testing.T) {
 	if !strings.Contains(out, "Name:      "+testRedisMod) {
 		t.Fatalf("expected cache show output, got: %s", out)
 	}
+	if !strings.Contains(out, "Replicas:  1") || !strings.Contains(out, "REPLICA") {
+		t.Fatalf("expected cache show output to list nodes, got: %s", out)
+	}
+}
+
+func TestCacheModifyCommandOutput(t *testing.T) {
+	server := setupAPIServer(t)
+	defer server.Close()
+	setAPIContext(t, server)
+
+	_ = modifyCacheCmd.Flags().Set("shards", "6")
+	t.Cleanup(func() {
+		_ = modifyCacheCmd.Flags().Set("shards", "0")
+		modifyCacheCmd.Flags().Lookup("shards").Changed = false
+	})
+
+	out := captureStdout(t, func() {
+		modifyCacheCmd.Run(modifyCacheCmd, []string{testCacheID})
+	})
+	if !strings.Contains(out, "6 shards") {
+		t.Fatalf("expected cache modify output, got: %s", out)
+	}
+}
+
+func TestCacheFailoverCommandOutput(t *testing.T) {
+	server := setupAPIServer(t)
+	defer server.Close()
+	setAPIContext(t, server)
+
+	out := captureStdout(t, func() {
+		failoverCacheCmd.Run(failoverCacheCmd, []string{testCacheID})
+	})
+	if !strings.Contains(out, "Cache failover completed") {
+		t.Fatalf("expected cache failover output, got: %s", out)
+	}
+}
+
+func TestCacheRotateCredentialsCommandOutput(t *testing.T) {
+	server := setupAPIServer(t)
+	defer server.Close()
+	setAPIContext(t, server)
+
+	out := captureStdout(t, func() {
+		rotateCacheCredentialsCmd.Run(rotateCacheCredentialsCmd, []string{testCacheID})
+	})
+	if !strings.Contains(out, "Cache credentials rotated") {
+		t.Fatalf("expected cache rotate output, got: %s", out)
+	}
 }
 
 func TestCacheDeleteCommandOutput(t *testing.T) {
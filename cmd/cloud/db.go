@@ -5,9 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/olekukonko/tablewriter"
+	"github.com/poyrazk/thecloud/pkg/sdk"
 	"github.com/spf13/cobra"
 )
 
@@ -72,6 +75,8 @@ var dbCreateCmd = &cobra.Command{
 		version, _ := cmd.Flags().GetString("version")
 		vpc, _ := cmd.Flags().GetString("vpc")
 		size, _ := cmd.Flags().GetInt("size")
+		retention, _ := cmd.Flags().GetInt("backup-retention")
+		bucket, _ := cmd.Flags().GetString("backup-bucket")
 
 		if size < 10 {
 			fmt.Printf(errorFormat, "--size must be at least 10GB")
@@ -84,7 +89,15 @@ var dbCreateCmd = &cobra.Command{
 		}
 
 		client := createClient(opts)
-		db, err := client.CreateDatabase(name, engine, version, vpcPtr, size)
+		db, err := client.CreateDatabaseWithInput(sdk.CreateDatabaseInput{
+			Name:                name,
+			Engine:              engine,
+			Version:             version,
+			VpcID:               vpcPtr,
+			AllocatedStorage:    size,
+			BackupRetentionDays: retention,
+			BackupBucket:        bucket,
+		})
 		if err != nil {
 			fmt.Printf(errorFormat, err)
 			return
@@ -138,6 +151,22 @@ var dbShowCmd = &cobra.Command{
 		fmt.Printf(detailRow, "Username:", db.Username)
 		fmt.Printf(detailRow, "VPC ID:", db.VpcID)
 		fmt.Printf(detailRow, "Created At:", db.CreatedAt)
+		if db.BackupRetentionDays > 0 {
+			fmt.Printf(detailRow, "Backups:", fmt.Sprintf("%d days in %s", db.BackupRetentionDays, db.BackupBucket))
+			if db.EarliestRestorableTime != nil && db.LatestRestorableTime != nil {
+				fmt.Printf(detailRow, "Restorable:", fmt.Sprintf("%s to %s",
+					db.EarliestRestorableTime.UTC().Format(time.RFC3339), db.LatestRestorableTime.UTC().Format(time.RFC3339)))
+			}
+		}
+		if db.MaintenanceWindow != "" {
+			fmt.Printf(detailRow, "Maintenance:", db.MaintenanceWindow+" UTC")
+		}
+		if db.PendingVersion != "" {
+			fmt.Printf(detailRow, "Pending:", "upgrade to "+db.PendingVersion)
+		}
+		if len(db.PendingParameters) > 0 {
+			fmt.Printf(detailRow, "Pending:", fmt.Sprintf("%d parameter change(s)", len(db.PendingParameters)))
+		}
 		fmt.Println(strings.Repeat("-", 40))
 		fmt.Println("")
 	},
@@ -189,6 +218,546 @@ var dbRotateCmd = &cobra.Command{
 	},
 }
 
+var dbRestoreToTimeCmd = &cobra.Command{
+	Use:   "restore-to-time [source_id]",
+	Short: "Restore a database with point-in-time recovery into a new database",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name, _ := cmd.Flags().GetString("name")
+		at, _ := cmd.Flags().GetString("time")
+		restoreTime, err := time.Parse(time.RFC3339, at)
+		if err != nil {
+			fmt.Printf(errorFormat, "--time must be an RFC 3339 timestamp, e.g. 2026-10-18T14:30:00Z")
+			return
+		}
+
+		client := createClient(opts)
+		db, err := client.RestoreDatabaseToTime(args[0], name, restoreTime)
+		if err != nil {
+			fmt.Printf(errorFormat, err)
+			return
+		}
+		if opts.JSON {
+			printJSON(db)
+			return
+		}
+		fmt.Printf("[SUCCESS] Database %s restored to %s as %s.\n", args[0], restoreTime.UTC().Format(time.RFC3339), db.ID)
+	},
+}
+
+var dbBackupRetentionCmd = &cobra.Command{
+	Use:   "backup-retention [id] [days]",
+	Short: "Change the point-in-time recovery window (0 disables it)",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		days, err := strconv.Atoi(args[1])
+		if err != nil {
+			fmt.Printf(errorFormat, "days must be a number")
+			return
+		}
+
+		client := createClient(opts)
+		if _, err := client.SetDatabaseBackupRetention(args[0], days); err != nil {
+			fmt.Printf(errorFormat, err)
+			return
+		}
+		fmt.Printf("[SUCCESS] Backup retention set to %d days.\n", days)
+	},
+}
+
+var dbBackupPolicyCmd = &cobra.Command{
+	Use:   "backup-policy",
+	Short: "Manage scheduled backups exported to a bucket",
+}
+
+var dbBackupPolicySetCmd = &cobra.Command{
+	Use:   "set [id]",
+	Short: "Create or replace the scheduled backup policy of a database",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		method, _ := cmd.Flags().GetString("method")
+		windowStart, _ := cmd.Flags().GetString("window-start")
+		windowMinutes, _ := cmd.Flags().GetInt("window-minutes")
+		frequency, _ := cmd.Flags().GetInt("frequency")
+		keep, _ := cmd.Flags().GetInt("keep")
+		days, _ := cmd.Flags().GetInt("retention-days")
+		bucket, _ := cmd.Flags().GetString("bucket")
+
+		client := createClient(opts)
+		policy, err := client.PutDatabaseBackupPolicy(args[0], sdk.DatabaseBackupPolicy{
+			Method:         strings.ToUpper(method),
+			WindowStart:    windowStart,
+			WindowMinutes:  windowMinutes,
+			FrequencyHours: frequency,
+			RetentionCount: keep,
+			RetentionDays:  days,
+			ExportBucket:   bucket,
+		})
+		if err != nil {
+			fmt.Printf(errorFormat, err)
+			return
+		}
+		printBackupPolicy(policy)
+	},
+}
+
+var dbBackupPolicyShowCmd = &cobra.Command{
+	Use:   "show [id]",
+	Short: "Show the scheduled backup policy of a database",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		policy, err := client.GetDatabaseBackupPolicy(args[0])
+		if err != nil {
+			fmt.Printf(errorFormat, err)
+			return
+		}
+		printBackupPolicy(policy)
+	},
+}
+
+var dbBackupPolicyRmCmd = &cobra.Command{
+	Use:   "rm [id]",
+	Short: "Stop scheduled backups; existing backups are kept",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		if err := client.DeleteDatabaseBackupPolicy(args[0]); err != nil {
+			fmt.Printf(errorFormat, err)
+			return
+		}
+		fmt.Println("[SUCCESS] Backup policy removed.")
+	},
+}
+
+func printBackupPolicy(p *sdk.DatabaseBackupPolicy) {
+	if opts.JSON {
+		data, _ := json.MarshalIndent(p, "", "  ")
+		fmt.Println(string(data))
+		return
+	}
+	fmt.Printf(detailRow, "Method:", p.Method)
+	fmt.Printf(detailRow, "Window:", fmt.Sprintf("%s UTC, %d minutes", p.WindowStart, p.WindowMinutes))
+	fmt.Printf(detailRow, "Frequency:", fmt.Sprintf("every %d hours", p.FrequencyHours))
+	fmt.Printf(detailRow, "Retention:", fmt.Sprintf("%d backups, %d days", p.RetentionCount, p.RetentionDays))
+	fmt.Printf(detailRow, "Bucket:", p.ExportBucket)
+	if p.NextBackupAt != nil {
+		fmt.Printf(detailRow, "Next Backup:", p.NextBackupAt.UTC().Format(time.RFC3339))
+	}
+}
+
+var dbBackupsCmd = &cobra.Command{
+	Use:   "backups [id]",
+	Short: "List the scheduled backups of a database",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		backups, err := client.ListDatabaseBackups(args[0])
+		if err != nil {
+			fmt.Printf(errorFormat, err)
+			return
+		}
+
+		if opts.JSON {
+			data, _ := json.MarshalIndent(backups, "", "  ")
+			fmt.Println(string(data))
+			return
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.Header([]string{"ID", "METHOD", "STATUS", "STARTED", "SIZE", "OBJECT"})
+		for _, b := range backups {
+			object := b.ObjectKey
+			if b.Status == "FAILED" {
+				object = b.Error
+			}
+			if err := table.Append([]string{
+				truncateID(b.ID),
+				b.Method,
+				b.Status,
+				b.StartedAt.UTC().Format(time.RFC3339),
+				strconv.FormatInt(b.SizeBytes, 10),
+				object,
+			}); err != nil {
+				fmt.Printf(errorFormat, err)
+				return
+			}
+		}
+		if err := table.Render(); err != nil {
+			fmt.Printf(errorFormat, err)
+			return
+		}
+	},
+}
+
+var dbBackupRmCmd = &cobra.Command{
+	Use:   "backup-rm [id] [backup-id]",
+	Short: "Remove a scheduled backup with its export and snapshot",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		if err := client.DeleteDatabaseBackup(args[0], args[1]); err != nil {
+			fmt.Printf(errorFormat, err)
+			return
+		}
+		fmt.Println("[SUCCESS] Backup removed.")
+	},
+}
+
+var dbMaintenanceWindowCmd = &cobra.Command{
+	Use:   "maintenance-window [id] [ddd:HH:MM]",
+	Short: "Change the weekly maintenance window (UTC), e.g. sun:03:00",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		db, err := client.SetDatabaseMaintenanceWindow(args[0], args[1])
+		if err != nil {
+			fmt.Printf(errorFormat, err)
+			return
+		}
+		fmt.Printf("[SUCCESS] Maintenance window set to %s UTC.\n", db.MaintenanceWindow)
+	},
+}
+
+var dbUpgradeCmd = &cobra.Command{
+	Use:   "upgrade [id] [version]",
+	Short: "Upgrade the engine version in the next maintenance window",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		now, _ := cmd.Flags().GetBool("apply-immediately")
+
+		client := createClient(opts)
+		db, err := client.UpgradeDatabase(args[0], args[1], now)
+		if err != nil {
+			fmt.Printf(errorFormat, err)
+			return
+		}
+		if db.PendingVersion != "" {
+			fmt.Printf("[SUCCESS] Upgrade to %s scheduled for the %s UTC maintenance window.\n", db.PendingVersion, db.MaintenanceWindow)
+			return
+		}
+		fmt.Printf("[SUCCESS] Database upgraded to %s.\n", db.Version)
+	},
+}
+
+var dbUpgradesCmd = &cobra.Command{
+	Use:   "upgrades [id]",
+	Short: "List the version upgrades of a database",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		upgrades, err := client.ListDatabaseUpgrades(args[0])
+		if err != nil {
+			fmt.Printf(errorFormat, err)
+			return
+		}
+
+		if opts.JSON {
+			data, _ := json.MarshalIndent(upgrades, "", "  ")
+			fmt.Println(string(data))
+			return
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.Header([]string{"ID", "FROM", "TO", "MAJOR", "STATUS", "STARTED", "SNAPSHOT"})
+		for _, u := range upgrades {
+			snapshot := ""
+			if u.SnapshotID != nil {
+				snapshot = truncateID(*u.SnapshotID)
+			}
+			status := u.Status
+			if u.Error != "" {
+				status += ": " + u.Error
+			}
+			if err := table.Append([]string{
+				truncateID(u.ID),
+				u.FromVersion,
+				u.ToVersion,
+				strconv.FormatBool(u.Major),
+				status,
+				u.StartedAt.UTC().Format(time.RFC3339),
+				snapshot,
+			}); err != nil {
+				fmt.Printf(errorFormat, err)
+				return
+			}
+		}
+		if err := table.Render(); err != nil {
+			fmt.Printf(errorFormat, err)
+			return
+		}
+	},
+}
+
+var dbInsightsCmd = &cobra.Command{
+	Use:   "insights [id]",
+	Short: "Show top queries, connections, cache hit ratio and replica lag",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		period, _ := cmd.Flags().GetDuration("period")
+
+		client := createClient(opts)
+		insights, err := client.GetDatabaseInsights(args[0], period)
+		if err != nil {
+			fmt.Printf(errorFormat, err)
+			return
+		}
+
+		if opts.JSON {
+			data, _ := json.MarshalIndent(insights, "", "  ")
+			fmt.Println(string(data))
+			return
+		}
+
+		if insights.CollectedAt == nil {
+			fmt.Println("No insights collected yet; samples are taken every minute.")
+			return
+		}
+		fmt.Printf(detailRow, "Collected:", insights.CollectedAt.UTC().Format(time.RFC3339))
+		fmt.Printf(detailRow, "Connections:", fmt.Sprintf("%d / %d", insights.Connections, insights.MaxConnections))
+		fmt.Printf(detailRow, "Cache Hit:", fmt.Sprintf("%.2f%%", insights.CacheHitRatio*100))
+		for _, r := range insights.ReplicaLag {
+			fmt.Printf(detailRow, "Replica Lag:", fmt.Sprintf("%s %.1fs", r.Name, r.LagSeconds))
+		}
+
+		if len(insights.TopQueries) == 0 {
+			return
+		}
+		fmt.Println()
+		table := tablewriter.NewWriter(os.Stdout)
+		table.Header([]string{"CALLS", "TOTAL MS", "MEAN MS", "ROWS", "QUERY"})
+		for _, q := range insights.TopQueries {
+			query := q.Query
+			if len(query) > 80 {
+				query = query[:77] + "..."
+			}
+			if err := table.Append([]string{
+				strconv.FormatInt(q.Calls, 10),
+				strconv.FormatFloat(q.TotalTimeMs, 'f', 1, 64),
+				strconv.FormatFloat(q.MeanTimeMs, 'f', 2, 64),
+				strconv.FormatInt(q.Rows, 10),
+				query,
+			}); err != nil {
+				fmt.Printf(errorFormat, err)
+				return
+			}
+		}
+		if err := table.Render(); err != nil {
+			fmt.Printf(errorFormat, err)
+			return
+		}
+	},
+}
+
+var dbDatabasesCmd = &cobra.Command{
+	Use:   "databases",
+	Short: "Manage the logical databases inside a database instance",
+}
+
+var dbDatabasesCreateCmd = &cobra.Command{
+	Use:   "create [id] [name]",
+	Short: "Create a logical database",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		ldb, err := client.CreateLogicalDatabase(args[0], args[1])
+		if err != nil {
+			fmt.Printf(errorFormat, err)
+			return
+		}
+		fmt.Printf("[SUCCESS] Logical database %s created.\n", ldb.Name)
+	},
+}
+
+var dbDatabasesListCmd = &cobra.Command{
+	Use:   "ls [id]",
+	Short: "List the logical databases of a database instance",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		ldbs, err := client.ListLogicalDatabases(args[0])
+		if err != nil {
+			fmt.Printf(errorFormat, err)
+			return
+		}
+
+		if opts.JSON {
+			data, _ := json.MarshalIndent(ldbs, "", "  ")
+			fmt.Println(string(data))
+			return
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.Header([]string{"NAME", "CREATED"})
+		for _, l := range ldbs {
+			if err := table.Append([]string{l.Name, l.CreatedAt.UTC().Format(time.RFC3339)}); err != nil {
+				fmt.Printf(errorFormat, err)
+				return
+			}
+		}
+		if err := table.Render(); err != nil {
+			fmt.Printf(errorFormat, err)
+			return
+		}
+	},
+}
+
+var dbDatabasesRmCmd = &cobra.Command{
+	Use:   "rm [id] [name]",
+	Short: "Drop a logical database and all of its data",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		if err := client.DeleteLogicalDatabase(args[0], args[1]); err != nil {
+			fmt.Printf(errorFormat, err)
+			return
+		}
+		fmt.Println("[SUCCESS] Logical database dropped.")
+	},
+}
+
+var dbUsersCmd = &cobra.Command{
+	Use:   "users",
+	Short: "Manage the additional users of a database instance",
+}
+
+var dbUsersCreateCmd = &cobra.Command{
+	Use:   "create [id] [username]",
+	Short: "Create a user; the password is shown only once",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		grants, err := parseDatabaseGrants(cmd)
+		if err != nil {
+			fmt.Printf(errorFormat, err)
+			return
+		}
+
+		client := createClient(opts)
+		user, err := client.CreateDatabaseUser(args[0], args[1], grants)
+		if err != nil {
+			fmt.Printf(errorFormat, err)
+			return
+		}
+		printDatabaseUserCredentials(user)
+	},
+}
+
+var dbUsersListCmd = &cobra.Command{
+	Use:   "ls [id]",
+	Short: "List the additional users of a database instance",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		users, err := client.ListDatabaseUsers(args[0])
+		if err != nil {
+			fmt.Printf(errorFormat, err)
+			return
+		}
+
+		if opts.JSON {
+			data, _ := json.MarshalIndent(users, "", "  ")
+			fmt.Println(string(data))
+			return
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.Header([]string{"USERNAME", "GRANTS", "CREDENTIAL VERSION", "UPDATED"})
+		for _, u := range users {
+			grants := make([]string, 0, len(u.Grants))
+			for _, g := range u.Grants {
+				grants = append(grants, g.Database+"="+g.Privilege)
+			}
+			if err := table.Append([]string{
+				u.Username,
+				strings.Join(grants, ", "),
+				strconv.Itoa(u.CredentialVersion),
+				u.UpdatedAt.UTC().Format(time.RFC3339),
+			}); err != nil {
+				fmt.Printf(errorFormat, err)
+				return
+			}
+		}
+		if err := table.Render(); err != nil {
+			fmt.Printf(errorFormat, err)
+			return
+		}
+	},
+}
+
+var dbUsersGrantsCmd = &cobra.Command{
+	Use:   "grants [id] [username]",
+	Short: "Replace the grants of a user; without --grant all grants are revoked",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		grants, err := parseDatabaseGrants(cmd)
+		if err != nil {
+			fmt.Printf(errorFormat, err)
+			return
+		}
+
+		client := createClient(opts)
+		if _, err := client.SetDatabaseUserGrants(args[0], args[1], grants); err != nil {
+			fmt.Printf(errorFormat, err)
+			return
+		}
+		fmt.Println("[SUCCESS] Grants updated.")
+	},
+}
+
+var dbUsersRotateCmd = &cobra.Command{
+	Use:   "rotate [id] [username]",
+	Short: "Set a new password for a user; the password is shown only once",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		user, err := client.RotateDatabaseUserCredentials(args[0], args[1])
+		if err != nil {
+			fmt.Printf(errorFormat, err)
+			return
+		}
+		printDatabaseUserCredentials(user)
+	},
+}
+
+var dbUsersRmCmd = &cobra.Command{
+	Use:   "rm [id] [username]",
+	Short: "Drop a user and remove its credentials",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		if err := client.DeleteDatabaseUser(args[0], args[1]); err != nil {
+			fmt.Printf(errorFormat, err)
+			return
+		}
+		fmt.Println("[SUCCESS] User removed.")
+	},
+}
+
+// parseDatabaseGrants reads the repeated --grant database=PRIVILEGE flag.
+func parseDatabaseGrants(cmd *cobra.Command) ([]sdk.DatabaseGrant, error) {
+	values, _ := cmd.Flags().GetStringArray("grant")
+	grants := make([]sdk.DatabaseGrant, 0, len(values))
+	for _, v := range values {
+		database, privilege, ok := strings.Cut(v, "=")
+		if !ok || database == "" || privilege == "" {
+			return nil, fmt.Errorf("invalid grant %q: use database=READ_ONLY|READ_WRITE|ALL", v)
+		}
+		grants = append(grants, sdk.DatabaseGrant{Database: database, Privilege: strings.ToUpper(privilege)})
+	}
+	return grants, nil
+}
+
+func printDatabaseUserCredentials(u *sdk.DatabaseUser) {
+	if opts.JSON {
+		data, _ := json.MarshalIndent(u, "", "  ")
+		fmt.Println(string(data))
+		return
+	}
+	fmt.Printf(detailRow, "Username:", u.Username)
+	fmt.Printf(detailRow, "Password:", u.Password)
+	fmt.Println("Store the password now; it cannot be retrieved again.")
+}
+
 func init() {
 	dbCmd.AddCommand(dbListCmd)
 	dbCmd.AddCommand(dbCreateCmd)
@@ -196,11 +765,56 @@ func init() {
 	dbCmd.AddCommand(dbRmCmd)
 	dbCmd.AddCommand(dbConnCmd)
 	dbCmd.AddCommand(dbRotateCmd)
+	dbCmd.AddCommand(dbRestoreToTimeCmd)
+	dbCmd.AddCommand(dbBackupRetentionCmd)
+	dbCmd.AddCommand(dbBackupPolicyCmd)
+	dbCmd.AddCommand(dbBackupsCmd)
+	dbCmd.AddCommand(dbBackupRmCmd)
+	dbCmd.AddCommand(dbMaintenanceWindowCmd)
+	dbCmd.AddCommand(dbUpgradeCmd)
+	dbCmd.AddCommand(dbUpgradesCmd)
+	dbCmd.AddCommand(dbInsightsCmd)
+	dbCmd.AddCommand(dbDatabasesCmd)
+	dbCmd.AddCommand(dbUsersCmd)
+	dbBackupPolicyCmd.AddCommand(dbBackupPolicySetCmd)
+	dbBackupPolicyCmd.AddCommand(dbBackupPolicyShowCmd)
+	dbBackupPolicyCmd.AddCommand(dbBackupPolicyRmCmd)
+	dbDatabasesCmd.AddCommand(dbDatabasesCreateCmd)
+	dbDatabasesCmd.AddCommand(dbDatabasesListCmd)
+	dbDatabasesCmd.AddCommand(dbDatabasesRmCmd)
+	dbUsersCmd.AddCommand(dbUsersCreateCmd)
+	dbUsersCmd.AddCommand(dbUsersListCmd)
+	dbUsersCmd.AddCommand(dbUsersGrantsCmd)
+	dbUsersCmd.AddCommand(dbUsersRotateCmd)
+	dbUsersCmd.AddCommand(dbUsersRmCmd)
 
 	dbCreateCmd.Flags().StringP("name", "n", "", "Name of the database (required)")
-	dbCreateCmd.Flags().StringP("engine", "e", "postgres", "Database engine (postgres/mysql)")
+	dbCreateCmd.Flags().StringP("engine", "e", "postgres", "Database engine (postgres/mysql/mariadb)")
 	dbCreateCmd.Flags().StringP("version", "v", "16", "Engine version")
 	dbCreateCmd.Flags().StringP("vpc", "V", "", "VPC ID to attach to")
 	dbCreateCmd.Flags().Int("size", 10, "Allocated storage in GB (minimum 10GB)")
+	dbCreateCmd.Flags().Int("backup-retention", 0, "Days of point-in-time recovery (0-35, requires --backup-bucket)")
+	dbCreateCmd.Flags().String("backup-bucket", "", "Bucket receiving base backups and archived logs")
 	_ = dbCreateCmd.MarkFlagRequired("name")
+
+	dbRestoreToTimeCmd.Flags().StringP("name", "n", "", "Name of the new database (required)")
+	dbRestoreToTimeCmd.Flags().String("time", "", "Point in time to restore to, RFC 3339 (required)")
+	_ = dbRestoreToTimeCmd.MarkFlagRequired("name")
+	_ = dbRestoreToTimeCmd.MarkFlagRequired("time")
+
+	dbBackupPolicySetCmd.Flags().String("method", "logical", "Backup method (logical/physical)")
+	dbBackupPolicySetCmd.Flags().String("window-start", "", "Start of the daily backup window, HH:MM UTC (default 03:00)")
+	dbBackupPolicySetCmd.Flags().Int("window-minutes", 0, "Length of the backup window in minutes (default 60)")
+	dbBackupPolicySetCmd.Flags().Int("frequency", 0, "Hours between backups, 1-168 (default 24)")
+	dbBackupPolicySetCmd.Flags().Int("keep", 0, "Number of completed backups to keep (0 keeps all)")
+	dbBackupPolicySetCmd.Flags().Int("retention-days", 0, "Days to keep backups (0 keeps them regardless of age)")
+	dbBackupPolicySetCmd.Flags().String("bucket", "", "Bucket receiving the exported backups (required)")
+	_ = dbBackupPolicySetCmd.MarkFlagRequired("bucket")
+
+	dbUpgradeCmd.Flags().Bool("apply-immediately", false, "Upgrade now instead of waiting for the maintenance window")
+
+	dbInsightsCmd.Flags().Duration("period", 0, "History period, e.g. 6h (default 1h, at most 168h)")
+
+	dbUsersCreateCmd.Flags().StringArray("grant", nil, "Grant as database=READ_ONLY|READ_WRITE|ALL (repeatable)")
+	dbUsersGrantsCmd.Flags().StringArray("grant", nil, "Grant as database=READ_ONLY|READ_WRITE|ALL (repeatable)")
 }
@@ -225,3 +225,250 @@ func TestDBCreateSizeValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestDBRestoreToTimeCmd(t *testing.T) {
+	var gotReq sdk.RestoreDatabaseToTimeInput
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/databases/restore" || r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&gotReq)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": map[string]interface{}{"id": "db-2", "name": gotReq.Name},
+		})
+	}))
+	defer server.Close()
+
+	oldURL := opts.APIURL
+	oldKey := opts.APIKey
+	opts.APIURL = server.URL
+	opts.APIKey = dbTestAPIKey
+	defer func() {
+		opts.APIURL = oldURL
+		opts.APIKey = oldKey
+	}()
+
+	_ = dbRestoreToTimeCmd.Flags().Set("name", "restored")
+	_ = dbRestoreToTimeCmd.Flags().Set("time", "2026-10-18T14:30:00Z")
+	out := captureStdout(t, func() {
+		dbRestoreToTimeCmd.Run(dbRestoreToTimeCmd, []string{dbTestID})
+	})
+	if !strings.Contains(out, "[SUCCESS]") {
+		t.Fatalf("expected success output, got: %s", out)
+	}
+	if gotReq.SourceDatabaseID != dbTestID || gotReq.RestoreTime.Hour() != 14 {
+		t.Fatalf("unexpected restore request: %+v", gotReq)
+	}
+
+	_ = dbRestoreToTimeCmd.Flags().Set("time", "yesterday")
+	out = captureStdout(t, func() {
+		dbRestoreToTimeCmd.Run(dbRestoreToTimeCmd, []string{dbTestID})
+	})
+	if !strings.Contains(out, "RFC 3339") {
+		t.Fatalf("expected time validation error, got: %s", out)
+	}
+}
+
+func TestDBBackupPolicySetCmd(t *testing.T) {
+	var gotReq sdk.DatabaseBackupPolicy
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/databases/"+dbTestID+"/backup-policy" || r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&gotReq)
+		gotReq.WindowStart = "03:00"
+		gotReq.WindowMinutes = 60
+		gotReq.FrequencyHours = 24
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": gotReq})
+	}))
+	defer server.Close()
+
+	oldURL := opts.APIURL
+	oldKey := opts.APIKey
+	opts.APIURL = server.URL
+	opts.APIKey = dbTestAPIKey
+	defer func() {
+		opts.APIURL = oldURL
+		opts.APIKey = oldKey
+	}()
+
+	_ = dbBackupPolicySetCmd.Flags().Set("method", "physical")
+	_ = dbBackupPolicySetCmd.Flags().Set("keep", "14")
+	_ = dbBackupPolicySetCmd.Flags().Set("bucket", "db-exports")
+	out := captureStdout(t, func() {
+		dbBackupPolicySetCmd.Run(dbBackupPolicySetCmd, []string{dbTestID})
+	})
+	if gotReq.Method != "PHYSICAL" || gotReq.RetentionCount != 14 || gotReq.ExportBucket != "db-exports" {
+		t.Fatalf("unexpected backup policy request: %+v", gotReq)
+	}
+	if !strings.Contains(out, "03:00 UTC, 60 minutes") || !strings.Contains(out, "14 backups") {
+		t.Fatalf("expected policy output, got: %s", out)
+	}
+}
+
+func TestDBBackupsCmd(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/databases/"+dbTestID+"/backups" || r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": []sdk.DatabaseAutomatedBackup{
+				{ID: "bk-1", Method: "LOGICAL", Status: "COMPLETED", ObjectKey: "databases/db-1/backups/a.dump", SizeBytes: 2048},
+				{ID: "bk-2", Method: "LOGICAL", Status: "FAILED", Error: "pg_dump: connection refused"},
+			},
+		})
+	}))
+	defer server.Close()
+
+	oldURL := opts.APIURL
+	oldKey := opts.APIKey
+	opts.APIURL = server.URL
+	opts.APIKey = dbTestAPIKey
+	defer func() {
+		opts.APIURL = oldURL
+		opts.APIKey = oldKey
+	}()
+
+	out := captureStdout(t, func() {
+		dbBackupsCmd.Run(dbBackupsCmd, []string{dbTestID})
+	})
+	if !strings.Contains(out, "a.dump") || !strings.Contains(out, "connection refused") {
+		t.Fatalf("expected backups table, got: %s", out)
+	}
+}
+
+func TestDBUpgradeCmd(t *testing.T) {
+	var gotReq map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/databases/"+dbTestID+"/upgrade" && r.Method == http.MethodPost:
+			_ = json.NewDecoder(r.Body).Decode(&gotReq)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": sdk.Database{ID: dbTestID, Version: "15", PendingVersion: "16", MaintenanceWindow: "sun:03:00"},
+			})
+		case r.URL.Path == "/databases/"+dbTestID+"/upgrades" && r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": []sdk.DatabaseUpgrade{
+					{ID: "up-1", FromVersion: "15", ToVersion: "16", Major: true, Status: "FAILED", Error: "pg_upgrade failed"},
+				},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	oldURL := opts.APIURL
+	oldKey := opts.APIKey
+	opts.APIURL = server.URL
+	opts.APIKey = dbTestAPIKey
+	defer func() {
+		opts.APIURL = oldURL
+		opts.APIKey = oldKey
+	}()
+
+	out := captureStdout(t, func() {
+		dbUpgradeCmd.Run(dbUpgradeCmd, []string{dbTestID, "16"})
+	})
+	if gotReq["version"] != "16" || gotReq["apply_immediately"] != false {
+		t.Fatalf("unexpected upgrade request: %+v", gotReq)
+	}
+	if !strings.Contains(out, "scheduled for the sun:03:00 UTC maintenance window") {
+		t.Fatalf("expected scheduled upgrade, got: %s", out)
+	}
+
+	out = captureStdout(t, func() {
+		dbUpgradesCmd.Run(dbUpgradesCmd, []string{dbTestID})
+	})
+	if !strings.Contains(out, "pg_upgrade failed") {
+		t.Fatalf("expected upgrades table, got: %s", out)
+	}
+}
+
+func TestDBUsersCreateCmd(t *testing.T) {
+	var gotReq struct {
+		Username string              `json:"username"`
+		Grants   []sdk.DatabaseGrant `json:"grants"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/databases/"+dbTestID+"/users" || r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&gotReq)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": sdk.DatabaseUser{Username: gotReq.Username, Password: "s3cret", Grants: gotReq.Grants},
+		})
+	}))
+	defer server.Close()
+
+	oldURL := opts.APIURL
+	oldKey := opts.APIKey
+	opts.APIURL = server.URL
+	opts.APIKey = dbTestAPIKey
+	defer func() {
+		opts.APIURL = oldURL
+		opts.APIKey = oldKey
+	}()
+
+	_ = dbUsersCreateCmd.Flags().Set("grant", "app=read_only")
+
+	out := captureStdout(t, func() {
+		dbUsersCreateCmd.Run(dbUsersCreateCmd, []string{dbTestID, "app_ro"})
+	})
+	if gotReq.Username != "app_ro" || len(gotReq.Grants) != 1 || gotReq.Grants[0].Privilege != "READ_ONLY" {
+		t.Fatalf("unexpected create request: %+v", gotReq)
+	}
+	if !strings.Contains(out, "s3cret") {
+		t.Fatalf("expected password in output, got: %s", out)
+	}
+}
+
+func TestDBInsightsCmd(t *testing.T) {
+	collected := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/databases/"+dbTestID+"/insights" || r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"data": sdk.DatabaseInsights{
+				DatabaseID:     dbTestID,
+				CollectedAt:    &collected,
+				Connections:    12,
+				MaxConnections: 100,
+				CacheHitRatio:  0.9876,
+				ReplicaLag:     []sdk.DatabaseReplicaLag{{Name: "appdb-replica", LagSeconds: 2.5}},
+				TopQueries:     []sdk.DatabaseQueryStat{{Query: "SELECT * FROM orders WHERE id = $1", Calls: 30, TotalTimeMs: 1500.5}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	oldURL := opts.APIURL
+	oldKey := opts.APIKey
+	opts.APIURL = server.URL
+	opts.APIKey = dbTestAPIKey
+	defer func() {
+		opts.APIURL = oldURL
+		opts.APIKey = oldKey
+	}()
+
+	out := captureStdout(t, func() {
+		dbInsightsCmd.Run(dbInsightsCmd, []string{dbTestID})
+	})
+	for _, want := range []string{"12 / 100", "98.76%", "appdb-replica 2.5s", "SELECT * FROM orders WHERE id = $1", "1500.5"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in output, got: %s", want, out)
+		}
+	}
+}
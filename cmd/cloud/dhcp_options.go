@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+var dhcpOptionsCmd = &cobra.Command{
+	Use:     "dhcp-options",
+	Aliases: []string{"dhcp"},
+	Short:   "Manage VPC DHCP options sets",
+	Long: `Manage DHCP options sets.
+
+An options set associated with a VPC configures the search domain, nameservers
+and NTP servers of instances launched in it. Nameservers in the set take
+precedence over the VPC resolver.`,
+}
+
+var dhcpOptionsCreateCmd = &cobra.Command{
+	Use:   "create [name]",
+	Short: "Create a DHCP options set",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		domainName, _ := cmd.Flags().GetString("domain-name")
+		dnsServers, _ := cmd.Flags().GetStringSlice("dns")
+		ntpServers, _ := cmd.Flags().GetStringSlice("ntp")
+
+		client := createClient(opts)
+		set, err := client.CreateDHCPOptions(cmd.Context(), args[0], domainName, dnsServers, ntpServers)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(set)
+			return
+		}
+		fmt.Printf("[SUCCESS] DHCP options set %s created.\n", set.ID)
+	},
+}
+
+var dhcpOptionsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List DHCP options sets",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		sets, err := client.ListDHCPOptions(cmd.Context())
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(sets)
+			return
+		}
+		table := tablewriter.NewWriter(os.Stdout)
+		table.Header([]string{"ID", "NAME", "DOMAIN", "DNS", "NTP"})
+		for _, s := range sets {
+			_ = table.Append([]string{
+				truncateID(s.ID.String()),
+				s.Name,
+				s.DomainName,
+				strings.Join(s.DomainNameServers, ","),
+				strings.Join(s.NTPServers, ","),
+			})
+		}
+		_ = table.Render()
+	},
+}
+
+var dhcpOptionsRmCmd = &cobra.Command{
+	Use:   "rm [id]",
+	Short: "Delete a DHCP options set",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid DHCP options ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		if err := client.DeleteDHCPOptions(cmd.Context(), id); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Printf("[SUCCESS] DHCP options set %s deleted.\n", id)
+	},
+}
+
+var dhcpOptionsAssociateCmd = &cobra.Command{
+	Use:   "associate [vpc_id] [dhcp_options_id]",
+	Short: "Associate a DHCP options set with a VPC",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		vpcID, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid VPC ID: %v\n", err)
+			return
+		}
+		optsID, err := uuid.Parse(args[1])
+		if err != nil {
+			fmt.Printf("Error: invalid DHCP options ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		if err := client.AssociateVPCDHCPOptions(cmd.Context(), vpcID, optsID); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Printf("[SUCCESS] DHCP options set %s associated with VPC %s.\n", optsID, vpcID)
+		fmt.Println("New instances in the VPC pick up the options at launch.")
+	},
+}
+
+var dhcpOptionsDisassociateCmd = &cobra.Command{
+	Use:   "disassociate [vpc_id]",
+	Short: "Revert a VPC to the default DHCP options",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		vpcID, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid VPC ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		if err := client.DisassociateVPCDHCPOptions(cmd.Context(), vpcID); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Printf("[SUCCESS] VPC %s uses the default DHCP options.\n", vpcID)
+	},
+}
+
+func init() {
+	dhcpOptionsCreateCmd.Flags().String("domain-name", "", "Search domain of instances")
+	dhcpOptionsCreateCmd.Flags().StringSlice("dns", nil, "Nameserver IPs (up to 4)")
+	dhcpOptionsCreateCmd.Flags().StringSlice("ntp", nil, "NTP server IPs (up to 4)")
+
+	dhcpOptionsCmd.AddCommand(dhcpOptionsCreateCmd)
+	dhcpOptionsCmd.AddCommand(dhcpOptionsListCmd)
+	dhcpOptionsCmd.AddCommand(dhcpOptionsRmCmd)
+	dhcpOptionsCmd.AddCommand(dhcpOptionsAssociateCmd)
+	dhcpOptionsCmd.AddCommand(dhcpOptionsDisassociateCmd)
+}
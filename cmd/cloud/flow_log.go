@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/olekukonko/tablewriter"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/pkg/sdk"
+	"github.com/spf13/cobra"
+)
+
+var flowLogCmd = &cobra.Command{
+	Use:   "flow-logs",
+	Short: "Capture VPC, subnet and instance traffic records",
+	Long: `Capture accepted and rejected traffic for a VPC, subnet or instance.
+
+Records delivered to CloudLogs can be searched with:
+  cloud logs search --resource-type flow_log --resource-id <flow-log-id>`,
+}
+
+var flowLogCreateCmd = &cobra.Command{
+	Use:   "create [vpc|subnet|instance] [resource_id]",
+	Short: "Start capturing traffic for a resource",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		resourceID, err := uuid.Parse(args[1])
+		if err != nil {
+			fmt.Printf("Error: invalid resource ID: %v\n", err)
+			return
+		}
+
+		traffic, _ := cmd.Flags().GetString("traffic")
+		bucket, _ := cmd.Flags().GetString("bucket")
+		interval, _ := cmd.Flags().GetInt("interval")
+		input := sdk.CreateFlowLogInput{
+			ResourceType:    domain.FlowLogResourceType(args[0]),
+			ResourceID:      resourceID,
+			TrafficType:     domain.FlowLogTrafficType(traffic),
+			IntervalSeconds: interval,
+		}
+		if bucket != "" {
+			input.Destination = domain.FlowLogDestinationBucket
+			input.Bucket = bucket
+		}
+
+		client := createClient(opts)
+		fl, err := client.CreateFlowLog(cmd.Context(), input)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		fmt.Printf("[SUCCESS] Flow log %s created (%s traffic every %ds to %s).\n", fl.ID, fl.TrafficType, fl.IntervalSeconds, fl.Destination)
+	},
+}
+
+var flowLogListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List flow logs",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		flowLogs, err := client.ListFlowLogs(cmd.Context())
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(flowLogs)
+			return
+		}
+		printFlowLogTable(flowLogs)
+	},
+}
+
+var flowLogGetCmd = &cobra.Command{
+	Use:   "get [id]",
+	Short: "Show a flow log and its last capture",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid flow log ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		fl, err := client.GetFlowLog(cmd.Context(), id)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(fl)
+			return
+		}
+		printFlowLogTable([]domain.FlowLog{*fl})
+		if fl.LastError != "" {
+			fmt.Printf("Last error: %s\n", fl.LastError)
+		}
+	},
+}
+
+var flowLogDeleteCmd = &cobra.Command{
+	Use:   "delete [id]",
+	Short: "Stop a flow log",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid flow log ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		if err := client.DeleteFlowLog(cmd.Context(), id); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		fmt.Println("[SUCCESS] Flow log deleted.")
+	},
+}
+
+func printFlowLogTable(flowLogs []domain.FlowLog) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header([]string{"ID", "RESOURCE", "TRAFFIC", "DESTINATION", "INTERVAL", "STATUS", "LAST CAPTURE"})
+	for _, fl := range flowLogs {
+		destination := string(fl.Destination)
+		if fl.Bucket != "" {
+			destination += ":" + fl.Bucket
+		}
+		lastCapture := "-"
+		if fl.LastCapturedAt != nil {
+			lastCapture = fl.LastCapturedAt.Format("2006-01-02 15:04:05")
+		}
+		_ = table.Append([]string{
+			truncateID(fl.ID.String()),
+			string(fl.ResourceType) + ":" + truncateID(fl.ResourceID.String()),
+			string(fl.TrafficType),
+			destination,
+			strconv.Itoa(fl.IntervalSeconds) + "s",
+			string(fl.Status),
+			lastCapture,
+		})
+	}
+	_ = table.Render()
+}
+
+func init() {
+	flowLogCreateCmd.Flags().String("traffic", "", "Traffic to capture (ACCEPT, REJECT, ALL)")
+	flowLogCreateCmd.Flags().String("bucket", "", "Deliver records to this storage bucket instead of CloudLogs")
+	flowLogCreateCmd.Flags().Int("interval", 0, "Aggregation interval in seconds (60 or 600)")
+
+	flowLogCmd.AddCommand(flowLogCreateCmd)
+	flowLogCmd.AddCommand(flowLogListCmd)
+	flowLogCmd.AddCommand(flowLogGetCmd)
+	flowLogCmd.AddCommand(flowLogDeleteCmd)
+}
@@ -143,6 +143,104 @@ var iamPolicyDetachCmd = &cobra.Command{
 	},
 }
 
+var iamResourcePolicyCmd = &cobra.Command{
+	Use:   "resource-policy",
+	Short: "Manage policies attached to buckets, queues, topics and secrets",
+}
+
+var iamResourcePolicyPutCmd = &cobra.Command{
+	Use:   "put [type] [resource_id] [json_file]",
+	Short: "Attach a resource policy from a JSON file of statements",
+	Args:  cobra.ExactArgs(3),
+	Run: func(cmd *cobra.Command, args []string) {
+		data, err := os.ReadFile(args[2])
+		if err != nil {
+			fmt.Printf("Error reading file: %v\n", err)
+			return
+		}
+
+		var statements []domain.Statement
+		if err := json.Unmarshal(data, &statements); err != nil {
+			fmt.Printf("Error parsing JSON: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		policy, err := client.PutResourcePolicy(cmd.Context(), domain.ResourcePolicyType(args[0]), args[1], statements)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		fmt.Printf("[SUCCESS] Policy attached to %s %s (ID: %s)\n", policy.ResourceType, policy.ResourceID, policy.ID)
+	},
+}
+
+var iamResourcePolicyGetCmd = &cobra.Command{
+	Use:   "get [type] [resource_id]",
+	Short: "Show the policy attached to a resource",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		policy, err := client.GetResourcePolicy(cmd.Context(), domain.ResourcePolicyType(args[0]), args[1])
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		printJSON(policy)
+	},
+}
+
+var iamResourcePolicyListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List resource policies owned by the tenant",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		policies, err := client.ListResourcePolicies(cmd.Context())
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(policies)
+			return
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.Header([]string{"TYPE", "RESOURCE", "STATEMENTS"})
+
+		for _, p := range policies {
+			if err := table.Append([]string{
+				string(p.ResourceType),
+				p.ResourceID,
+				fmt.Sprintf("%d", len(p.Statements)),
+			}); err != nil {
+				fmt.Printf("Error appending to table: %v\n", err)
+				return
+			}
+		}
+		if err := table.Render(); err != nil {
+			fmt.Printf("Error rendering table: %v\n", err)
+		}
+	},
+}
+
+var iamResourcePolicyDeleteCmd = &cobra.Command{
+	Use:   "delete [type] [resource_id]",
+	Short: "Detach the policy from a resource",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		if err := client.DeleteResourcePolicy(cmd.Context(), domain.ResourcePolicyType(args[0]), args[1]); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		fmt.Println("[SUCCESS] Resource policy deleted.")
+	},
+}
+
 func init() {
 	iamPolicyCreateCmd.Flags().String("description", "", "Policy description")
 
@@ -150,4 +248,10 @@ func init() {
 	iamCmd.AddCommand(iamPolicyCreateCmd)
 	iamCmd.AddCommand(iamPolicyAttachCmd)
 	iamCmd.AddCommand(iamPolicyDetachCmd)
+
+	iamResourcePolicyCmd.AddCommand(iamResourcePolicyPutCmd)
+	iamResourcePolicyCmd.AddCommand(iamResourcePolicyGetCmd)
+	iamResourcePolicyCmd.AddCommand(iamResourcePolicyListCmd)
+	iamResourcePolicyCmd.AddCommand(iamResourcePolicyDeleteCmd)
+	iamCmd.AddCommand(iamResourcePolicyCmd)
 }
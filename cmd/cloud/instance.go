@@ -98,6 +98,7 @@ var launchCmd = &cobra.Command{
 		subnetID, _ := cmd.Flags().GetString("subnet")
 		volumeStrs, _ := cmd.Flags().GetStringSlice("volume")
 		sshKeyID, _ := cmd.Flags().GetString("ssh-key")
+		serviceAccountID, _ := cmd.Flags().GetString("service-account")
 		wait, _ := cmd.Flags().GetBool("wait")
 
 		// Parse volume strings like "vol-name:/path"
@@ -131,7 +132,20 @@ var launchCmd = &cobra.Command{
 
 		runCmd, _ := cmd.Flags().GetStringSlice("cmd")
 		client := createClient(opts)
-		inst, err := client.LaunchInstance(name, image, ports, instanceType, vpc, subnetID, volumes, metadata, labels, sshKeyID, runCmd)
+		inst, err := client.LaunchInstanceWithInput(sdk.LaunchInstanceInput{
+			Name:             name,
+			Image:            image,
+			Ports:            ports,
+			InstanceType:     instanceType,
+			VpcID:            vpc,
+			SubnetID:         subnetID,
+			Volumes:          volumes,
+			Metadata:         metadata,
+			Labels:           labels,
+			SSHKeyID:         sshKeyID,
+			Cmd:              runCmd,
+			ServiceAccountID: serviceAccountID,
+		})
 		if err != nil {
 			fmt.Printf(fmtErrorLog, err)
 			return
@@ -448,6 +462,7 @@ func init() {
 	launchCmd.Flags().StringSliceP("metadata", "m", nil, "Metadata (key=value)")
 	launchCmd.Flags().StringSliceP("label", "l", nil, "Labels (key=value)")
 	launchCmd.Flags().String("ssh-key", "", "SSH Key ID to inject")
+	launchCmd.Flags().String("service-account", "", "Service account ID whose credentials the instance can fetch from the metadata service")
 	launchCmd.Flags().StringSlice("cmd", nil, "Command to run (e.g. --cmd 'sh' --cmd '-c' --cmd 'echo hello')")
 	launchCmd.Flags().BoolP("wait", "w", false, "Wait for instance to be RUNNING")
 	_ = launchCmd.MarkFlagRequired("name")
@@ -75,11 +75,20 @@ func init() {
 	rootCmd.AddCommand(auditCmd)
 	rootCmd.AddCommand(billingCmd)
 	rootCmd.AddCommand(tenantCmd)
+	rootCmd.AddCommand(orgCmd)
+	rootCmd.AddCommand(quotaCmd)
 	rootCmd.AddCommand(instanceTypeCmd)
 	rootCmd.AddCommand(newSSHKeyCmd(&opts))
 	rootCmd.AddCommand(vpcPeeringCmd)
+	rootCmd.AddCommand(flowLogCmd)
+	rootCmd.AddCommand(naclCmd)
 	rootCmd.AddCommand(igwCmd)
 	rootCmd.AddCommand(natGatewayCmd)
+	rootCmd.AddCommand(vpnCmd)
+	rootCmd.AddCommand(transitGatewayCmd)
+	rootCmd.AddCommand(resolverCmd)
+	rootCmd.AddCommand(dhcpOptionsCmd)
+	rootCmd.AddCommand(networkInterfaceCmd)
 	rootCmd.AddCommand(routeTableCmd)
 	rootCmd.AddCommand(configCmd)
 
@@ -0,0 +1,292 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/olekukonko/tablewriter"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/pkg/sdk"
+	"github.com/spf13/cobra"
+)
+
+var naclCmd = &cobra.Command{
+	Use:   "nacl",
+	Short: "Manage stateless subnet network ACLs",
+	Long: `Manage network ACLs: numbered allow/deny rules applied to whole subnets.
+
+Rules are evaluated in ascending rule number and the first match wins; traffic no
+rule matches is denied. ACLs are stateless, so return traffic needs its own rule.
+Every VPC has a default ACL that allows all traffic and governs subnets without
+an association.`,
+}
+
+var naclCreateCmd = &cobra.Command{
+	Use:   "create [vpc_id] [name]",
+	Short: "Create an empty network ACL",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		vpcID, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid VPC ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		acl, err := client.CreateNetworkACL(cmd.Context(), vpcID, args[1])
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		fmt.Printf("[SUCCESS] Network ACL %s created. It denies all traffic until rules are added.\n", acl.ID)
+	},
+}
+
+var naclListCmd = &cobra.Command{
+	Use:   "list [vpc_id]",
+	Short: "List the network ACLs of a VPC",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		vpcID, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid VPC ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		acls, err := client.ListNetworkACLs(cmd.Context(), vpcID)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(acls)
+			return
+		}
+		table := tablewriter.NewWriter(os.Stdout)
+		table.Header([]string{"ID", "NAME", "DEFAULT", "CREATED"})
+		for _, acl := range acls {
+			_ = table.Append([]string{
+				truncateID(acl.ID.String()),
+				acl.Name,
+				strconv.FormatBool(acl.IsDefault),
+				acl.CreatedAt.Format("2006-01-02 15:04:05"),
+			})
+		}
+		_ = table.Render()
+	},
+}
+
+var naclGetCmd = &cobra.Command{
+	Use:   "get [id]",
+	Short: "Show a network ACL's rules and subnets",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid network ACL ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		acl, err := client.GetNetworkACL(cmd.Context(), id)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(acl)
+			return
+		}
+		fmt.Printf("Network ACL %s (%s), default: %t\n", acl.Name, acl.ID, acl.IsDefault)
+		printNetworkACLRules(acl.Rules)
+		for _, a := range acl.Associations {
+			fmt.Printf("Associated subnet: %s\n", a.SubnetID)
+		}
+	},
+}
+
+var naclDeleteCmd = &cobra.Command{
+	Use:   "delete [id]",
+	Short: "Delete a custom network ACL",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid network ACL ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		if err := client.DeleteNetworkACL(cmd.Context(), id); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		fmt.Println("[SUCCESS] Network ACL deleted. Its subnets now use the default ACL.")
+	},
+}
+
+var naclAddRuleCmd = &cobra.Command{
+	Use:   "add-rule [acl_id]",
+	Short: "Add a numbered allow/deny rule",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		aclID, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid network ACL ID: %v\n", err)
+			return
+		}
+
+		number, _ := cmd.Flags().GetInt("rule-number")
+		direction, _ := cmd.Flags().GetString("direction")
+		protocol, _ := cmd.Flags().GetString("protocol")
+		portMin, _ := cmd.Flags().GetInt("port-min")
+		portMax, _ := cmd.Flags().GetInt("port-max")
+		cidr, _ := cmd.Flags().GetString("cidr")
+		action, _ := cmd.Flags().GetString("action")
+
+		client := createClient(opts)
+		rule, err := client.AddNetworkACLRule(cmd.Context(), aclID, sdk.NetworkACLRuleInput{
+			RuleNumber: number,
+			Direction:  domain.RuleDirection(direction),
+			Protocol:   protocol,
+			PortMin:    portMin,
+			PortMax:    portMax,
+			CIDR:       cidr,
+			Action:     domain.NetworkACLAction(action),
+		})
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		fmt.Printf("[SUCCESS] Rule %d added (%s).\n", rule.RuleNumber, rule.ID)
+	},
+}
+
+var naclRemoveRuleCmd = &cobra.Command{
+	Use:   "remove-rule [acl_id] [rule_id]",
+	Short: "Remove a rule",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		aclID, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid network ACL ID: %v\n", err)
+			return
+		}
+		ruleID, err := uuid.Parse(args[1])
+		if err != nil {
+			fmt.Printf("Error: invalid rule ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		if err := client.RemoveNetworkACLRule(cmd.Context(), aclID, ruleID); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		fmt.Println("[SUCCESS] Rule removed.")
+	},
+}
+
+var naclAssociateCmd = &cobra.Command{
+	Use:   "associate [acl_id] [subnet_id]",
+	Short: "Apply a network ACL to a subnet",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		aclID, subnetID, ok := parseNACLSubnetArgs(args)
+		if !ok {
+			return
+		}
+
+		client := createClient(opts)
+		if err := client.AssociateNetworkACL(cmd.Context(), aclID, subnetID); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		fmt.Println("[SUCCESS] Subnet associated.")
+	},
+}
+
+var naclDisassociateCmd = &cobra.Command{
+	Use:   "disassociate [acl_id] [subnet_id]",
+	Short: "Return a subnet to the VPC's default network ACL",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		aclID, subnetID, ok := parseNACLSubnetArgs(args)
+		if !ok {
+			return
+		}
+
+		client := createClient(opts)
+		if err := client.DisassociateNetworkACL(cmd.Context(), aclID, subnetID); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		fmt.Println("[SUCCESS] Subnet returned to the default network ACL.")
+	},
+}
+
+func parseNACLSubnetArgs(args []string) (uuid.UUID, uuid.UUID, bool) {
+	aclID, err := uuid.Parse(args[0])
+	if err != nil {
+		fmt.Printf("Error: invalid network ACL ID: %v\n", err)
+		return uuid.Nil, uuid.Nil, false
+	}
+	subnetID, err := uuid.Parse(args[1])
+	if err != nil {
+		fmt.Printf("Error: invalid subnet ID: %v\n", err)
+		return uuid.Nil, uuid.Nil, false
+	}
+	return aclID, subnetID, true
+}
+
+func printNetworkACLRules(rules []domain.NetworkACLRule) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header([]string{"RULE #", "DIRECTION", "PROTOCOL", "PORTS", "CIDR", "ACTION", "ID"})
+	for _, r := range rules {
+		ports := "all"
+		if r.PortMin > 0 {
+			ports = fmt.Sprintf("%d-%d", r.PortMin, r.PortMax)
+		}
+		_ = table.Append([]string{
+			strconv.Itoa(r.RuleNumber),
+			string(r.Direction),
+			r.Protocol,
+			ports,
+			r.CIDR,
+			string(r.Action),
+			truncateID(r.ID.String()),
+		})
+	}
+	_ = table.Render()
+}
+
+func init() {
+	naclAddRuleCmd.Flags().Int("rule-number", 0, "Evaluation order, 1-32766; lower numbers are evaluated first")
+	naclAddRuleCmd.Flags().String("direction", "ingress", "Traffic direction (ingress, egress)")
+	naclAddRuleCmd.Flags().String("protocol", "all", "Protocol (tcp, udp, icmp, all)")
+	naclAddRuleCmd.Flags().Int("port-min", 0, "First destination port (tcp/udp)")
+	naclAddRuleCmd.Flags().Int("port-max", 0, "Last destination port (tcp/udp, defaults to --port-min)")
+	naclAddRuleCmd.Flags().String("cidr", "0.0.0.0/0", "Source CIDR for ingress, destination CIDR for egress")
+	naclAddRuleCmd.Flags().String("action", "allow", "Rule action (allow, deny)")
+	_ = naclAddRuleCmd.MarkFlagRequired("rule-number")
+
+	naclCmd.AddCommand(naclCreateCmd)
+	naclCmd.AddCommand(naclListCmd)
+	naclCmd.AddCommand(naclGetCmd)
+	naclCmd.AddCommand(naclDeleteCmd)
+	naclCmd.AddCommand(naclAddRuleCmd)
+	naclCmd.AddCommand(naclRemoveRuleCmd)
+	naclCmd.AddCommand(naclAssociateCmd)
+	naclCmd.AddCommand(naclDisassociateCmd)
+}
@@ -0,0 +1,308 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/olekukonko/tablewriter"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/pkg/sdk"
+	"github.com/spf13/cobra"
+)
+
+var networkInterfaceCmd = &cobra.Command{
+	Use:     "network-interface",
+	Aliases: []string{"eni"},
+	Short:   "Manage elastic network interfaces",
+	Long: `Manage elastic network interfaces.
+
+A network interface is a secondary NIC created in a subnet. It carries one or
+more private IPs and its own security groups, is hot-plugged into a running
+instance of the same VPC as eth<device-index>, and keeps its addresses and
+Elastic IPs when moved to another instance.`,
+}
+
+var networkInterfaceCreateCmd = &cobra.Command{
+	Use:   "create [subnet_id]",
+	Short: "Create a network interface",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		subnetID, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid subnet ID: %v\n", err)
+			return
+		}
+		description, _ := cmd.Flags().GetString("description")
+		privateIPs, _ := cmd.Flags().GetStringSlice("private-ip")
+		groupIDs, err := parseUUIDFlag(cmd, "security-group")
+		if err != nil {
+			fmt.Printf("Error: invalid security group ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		ni, err := client.CreateNetworkInterface(cmd.Context(), sdk.CreateNetworkInterfaceInput{
+			SubnetID:         subnetID,
+			Description:      description,
+			PrivateIPs:       privateIPs,
+			SecurityGroupIDs: groupIDs,
+		})
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(ni)
+			return
+		}
+		fmt.Printf("[SUCCESS] Network interface %s created with IP %s.\n", ni.ID, ni.PrimaryIP())
+	},
+}
+
+var networkInterfaceListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List network interfaces",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		interfaces, err := client.ListNetworkInterfaces(cmd.Context())
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(interfaces)
+			return
+		}
+		table := tablewriter.NewWriter(os.Stdout)
+		table.Header([]string{"ID", "SUBNET", "PRIVATE IPS", "STATUS", "INSTANCE", "DEVICE"})
+		for _, ni := range interfaces {
+			instance, device := "-", "-"
+			if ni.InstanceID != nil {
+				instance = truncateID(ni.InstanceID.String())
+				device = ni.DeviceName()
+			}
+			_ = table.Append([]string{
+				truncateID(ni.ID.String()),
+				truncateID(ni.SubnetID.String()),
+				strings.Join(ni.PrivateIPs, ","),
+				string(ni.Status),
+				instance,
+				device,
+			})
+		}
+		_ = table.Render()
+	},
+}
+
+var networkInterfaceRmCmd = &cobra.Command{
+	Use:   "rm [id]",
+	Short: "Delete a detached network interface",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid network interface ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		if err := client.DeleteNetworkInterface(cmd.Context(), id); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Printf("[SUCCESS] Network interface %s deleted.\n", id)
+	},
+}
+
+var networkInterfaceAssignIPsCmd = &cobra.Command{
+	Use:   "assign-ips [id] [ip...]",
+	Short: "Assign secondary private IPs to a network interface",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid network interface ID: %v\n", err)
+			return
+		}
+		count, _ := cmd.Flags().GetInt("count")
+
+		client := createClient(opts)
+		ni, err := client.AssignPrivateIPs(cmd.Context(), id, args[1:], count)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		printNetworkInterfaceResult(ni, "Private IPs: "+strings.Join(ni.PrivateIPs, ", "))
+	},
+}
+
+var networkInterfaceUnassignIPsCmd = &cobra.Command{
+	Use:   "unassign-ips [id] [ip...]",
+	Short: "Remove secondary private IPs from a network interface",
+	Args:  cobra.MinimumNArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid network interface ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		ni, err := client.UnassignPrivateIPs(cmd.Context(), id, args[1:])
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		printNetworkInterfaceResult(ni, "Private IPs: "+strings.Join(ni.PrivateIPs, ", "))
+	},
+}
+
+var networkInterfaceSetGroupsCmd = &cobra.Command{
+	Use:   "set-groups [id] [security_group_id...]",
+	Short: "Replace the security groups of a network interface",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid network interface ID: %v\n", err)
+			return
+		}
+		groupIDs := make([]uuid.UUID, 0, len(args)-1)
+		for _, raw := range args[1:] {
+			groupID, err := uuid.Parse(raw)
+			if err != nil {
+				fmt.Printf("Error: invalid security group ID: %v\n", err)
+				return
+			}
+			groupIDs = append(groupIDs, groupID)
+		}
+
+		client := createClient(opts)
+		ni, err := client.SetNetworkInterfaceSecurityGroups(cmd.Context(), id, groupIDs)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		printNetworkInterfaceResult(ni, fmt.Sprintf("Network interface %s has %d security groups.", ni.ID, len(ni.SecurityGroupIDs)))
+	},
+}
+
+var networkInterfaceAttachCmd = &cobra.Command{
+	Use:   "attach [id] [instance_id]",
+	Short: "Hot-plug a network interface into a running instance",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, instanceID, ok := parseNetworkInterfaceArgs(args)
+		if !ok {
+			return
+		}
+		deviceIndex, _ := cmd.Flags().GetInt("device-index")
+
+		client := createClient(opts)
+		ni, err := client.AttachNetworkInterface(cmd.Context(), id, instanceID, deviceIndex)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		printNetworkInterfaceResult(ni, fmt.Sprintf("Network interface %s attached as %s.", ni.ID, ni.DeviceName()))
+	},
+}
+
+var networkInterfaceDetachCmd = &cobra.Command{
+	Use:   "detach [id]",
+	Short: "Unplug a network interface from its instance",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid network interface ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		ni, err := client.DetachNetworkInterface(cmd.Context(), id)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		printNetworkInterfaceResult(ni, fmt.Sprintf("Network interface %s detached.", ni.ID))
+	},
+}
+
+var networkInterfaceMoveCmd = &cobra.Command{
+	Use:   "move [id] [instance_id]",
+	Short: "Move a network interface and its addresses to another instance",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, instanceID, ok := parseNetworkInterfaceArgs(args)
+		if !ok {
+			return
+		}
+
+		client := createClient(opts)
+		ni, err := client.MoveNetworkInterface(cmd.Context(), id, instanceID)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		printNetworkInterfaceResult(ni, fmt.Sprintf("Network interface %s moved to instance %s as %s.", ni.ID, instanceID, ni.DeviceName()))
+	},
+}
+
+func parseNetworkInterfaceArgs(args []string) (uuid.UUID, uuid.UUID, bool) {
+	id, err := uuid.Parse(args[0])
+	if err != nil {
+		fmt.Printf("Error: invalid network interface ID: %v\n", err)
+		return uuid.Nil, uuid.Nil, false
+	}
+	instanceID, err := uuid.Parse(args[1])
+	if err != nil {
+		fmt.Printf("Error: invalid instance ID: %v\n", err)
+		return uuid.Nil, uuid.Nil, false
+	}
+	return id, instanceID, true
+}
+
+func parseUUIDFlag(cmd *cobra.Command, name string) ([]uuid.UUID, error) {
+	raw, _ := cmd.Flags().GetStringSlice(name)
+	ids := make([]uuid.UUID, 0, len(raw))
+	for _, r := range raw {
+		id, err := uuid.Parse(r)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func printNetworkInterfaceResult(ni *domain.NetworkInterface, msg string) {
+	if opts.JSON {
+		printJSON(ni)
+		return
+	}
+	fmt.Printf("[SUCCESS] %s\n", msg)
+}
+
+func init() {
+	networkInterfaceCreateCmd.Flags().String("description", "", "Description of the interface")
+	networkInterfaceCreateCmd.Flags().StringSlice("private-ip", nil, "Private IPs; the first is the primary (default: allocate one)")
+	networkInterfaceCreateCmd.Flags().StringSlice("security-group", nil, "Security group IDs")
+	networkInterfaceAssignIPsCmd.Flags().Int("count", 0, "Number of addresses to allocate from the subnet")
+	networkInterfaceAttachCmd.Flags().Int("device-index", 0, "Device index 1-"+strconv.Itoa(domain.MaxNetworkInterfaceDeviceIndex)+" (default: lowest free)")
+
+	networkInterfaceCmd.AddCommand(networkInterfaceCreateCmd)
+	networkInterfaceCmd.AddCommand(networkInterfaceListCmd)
+	networkInterfaceCmd.AddCommand(networkInterfaceRmCmd)
+	networkInterfaceCmd.AddCommand(networkInterfaceAssignIPsCmd)
+	networkInterfaceCmd.AddCommand(networkInterfaceUnassignIPsCmd)
+	networkInterfaceCmd.AddCommand(networkInterfaceSetGroupsCmd)
+	networkInterfaceCmd.AddCommand(networkInterfaceAttachCmd)
+	networkInterfaceCmd.AddCommand(networkInterfaceDetachCmd)
+	networkInterfaceCmd.AddCommand(networkInterfaceMoveCmd)
+}
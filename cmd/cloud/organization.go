@@ -0,0 +1,276 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/google/uuid"
+	"github.com/olekukonko/tablewriter"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/spf13/cobra"
+)
+
+var orgCmd = &cobra.Command{
+	Use:   "org",
+	Short: "Manage organizations, their tenants and guardrails",
+}
+
+var orgCreateCmd = &cobra.Command{
+	Use:   "create [name] [slug]",
+	Short: "Create an organization",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		org, err := client.CreateOrganization(cmd.Context(), args[0], args[1])
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		fmt.Printf("[SUCCESS] Organization %s created (ID: %s)\n", org.Name, org.ID)
+	},
+}
+
+var orgListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List organizations you own",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		orgs, err := client.ListOrganizations(cmd.Context())
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(orgs)
+			return
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.Header([]string{"ID", "NAME", "SLUG"})
+		for _, o := range orgs {
+			_ = table.Append([]string{truncateID(o.ID.String()), o.Name, o.Slug})
+		}
+		_ = table.Render()
+	},
+}
+
+var orgDeleteCmd = &cobra.Command{
+	Use:   "delete [org_id]",
+	Short: "Delete an organization",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		orgID, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid organization ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		if err := client.DeleteOrganization(cmd.Context(), orgID); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		fmt.Println("[SUCCESS] Organization deleted.")
+	},
+}
+
+var orgAddTenantCmd = &cobra.Command{
+	Use:   "add-tenant [org_id] [tenant_id]",
+	Short: "Place a tenant you own under an organization",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		orgID, tenantID, ok := parseOrgAndID(args, "tenant")
+		if !ok {
+			return
+		}
+
+		client := createClient(opts)
+		if err := client.AddOrganizationTenant(cmd.Context(), orgID, tenantID); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		fmt.Println("[SUCCESS] Tenant added to organization.")
+	},
+}
+
+var orgRemoveTenantCmd = &cobra.Command{
+	Use:   "remove-tenant [org_id] [tenant_id]",
+	Short: "Release a tenant from an organization",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		orgID, tenantID, ok := parseOrgAndID(args, "tenant")
+		if !ok {
+			return
+		}
+
+		client := createClient(opts)
+		if err := client.RemoveOrganizationTenant(cmd.Context(), orgID, tenantID); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		fmt.Println("[SUCCESS] Tenant removed from organization.")
+	},
+}
+
+var orgGuardrailCmd = &cobra.Command{
+	Use:   "guardrail",
+	Short: "Manage organization-wide deny guardrails",
+}
+
+var orgGuardrailCreateCmd = &cobra.Command{
+	Use:   "create [org_id] [name] [json_file]",
+	Short: "Create a guardrail from a JSON file of Deny statements",
+	Args:  cobra.ExactArgs(3),
+	Run: func(cmd *cobra.Command, args []string) {
+		orgID, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid organization ID: %v\n", err)
+			return
+		}
+
+		data, err := os.ReadFile(args[2])
+		if err != nil {
+			fmt.Printf("Error reading file: %v\n", err)
+			return
+		}
+
+		var statements []domain.Statement
+		if err := json.Unmarshal(data, &statements); err != nil {
+			fmt.Printf("Error parsing JSON: %v\n", err)
+			return
+		}
+
+		description, _ := cmd.Flags().GetString("description")
+		client := createClient(opts)
+		policy, err := client.CreateGuardrail(cmd.Context(), orgID, args[1], description, statements)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		fmt.Printf("[SUCCESS] Guardrail %s created (ID: %s)\n", policy.Name, policy.ID)
+	},
+}
+
+var orgGuardrailListCmd = &cobra.Command{
+	Use:   "list [org_id]",
+	Short: "List the guardrails of an organization",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		orgID, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid organization ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		policies, err := client.ListGuardrails(cmd.Context(), orgID)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(policies)
+			return
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.Header([]string{"ID", "NAME", "STATEMENTS", "DESCRIPTION"})
+		for _, p := range policies {
+			_ = table.Append([]string{truncateID(p.ID.String()), p.Name, fmt.Sprintf("%d", len(p.Statements)), p.Description})
+		}
+		_ = table.Render()
+	},
+}
+
+var orgGuardrailDeleteCmd = &cobra.Command{
+	Use:   "delete [org_id] [guardrail_id]",
+	Short: "Delete a guardrail",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		orgID, guardrailID, ok := parseOrgAndID(args, "guardrail")
+		if !ok {
+			return
+		}
+
+		client := createClient(opts)
+		if err := client.DeleteGuardrail(cmd.Context(), orgID, guardrailID); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		fmt.Println("[SUCCESS] Guardrail deleted.")
+	},
+}
+
+var orgBillingCmd = &cobra.Command{
+	Use:   "billing [org_id]",
+	Short: "Show the consolidated bill across all tenants of an organization",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		orgID, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid organization ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		summary, err := client.GetOrganizationBillingSummary(cmd.Context(), orgID, nil, nil)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(summary)
+			return
+		}
+
+		fmt.Printf("Organization Billing Summary (%s to %s)\n", summary.PeriodStart.Format("2006-01-02"), summary.PeriodEnd.Format("2006-01-02"))
+		fmt.Printf("Total Amount: %s %.2f\n", summary.Currency, summary.TotalAmount)
+		table := tablewriter.NewWriter(os.Stdout)
+		table.Header([]string{"TENANT", "AMOUNT"})
+		for _, t := range summary.Tenants {
+			_ = table.Append([]string{t.TenantID.String(), fmt.Sprintf("%.2f", t.Amount)})
+		}
+		_ = table.Render()
+	},
+}
+
+// parseOrgAndID parses an organization ID followed by a second ID argument.
+func parseOrgAndID(args []string, kind string) (uuid.UUID, uuid.UUID, bool) {
+	orgID, err := uuid.Parse(args[0])
+	if err != nil {
+		fmt.Printf("Error: invalid organization ID: %v\n", err)
+		return uuid.Nil, uuid.Nil, false
+	}
+	id, err := uuid.Parse(args[1])
+	if err != nil {
+		fmt.Printf("Error: invalid %s ID: %v\n", kind, err)
+		return uuid.Nil, uuid.Nil, false
+	}
+	return orgID, id, true
+}
+
+func init() {
+	orgGuardrailCreateCmd.Flags().String("description", "", "Guardrail description")
+
+	orgGuardrailCmd.AddCommand(orgGuardrailCreateCmd)
+	orgGuardrailCmd.AddCommand(orgGuardrailListCmd)
+	orgGuardrailCmd.AddCommand(orgGuardrailDeleteCmd)
+
+	orgCmd.AddCommand(orgCreateCmd)
+	orgCmd.AddCommand(orgListCmd)
+	orgCmd.AddCommand(orgDeleteCmd)
+	orgCmd.AddCommand(orgAddTenantCmd)
+	orgCmd.AddCommand(orgRemoveTenantCmd)
+	orgCmd.AddCommand(orgGuardrailCmd)
+	orgCmd.AddCommand(orgBillingCmd)
+}
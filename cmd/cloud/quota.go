@@ -0,0 +1,242 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/olekukonko/tablewriter"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/spf13/cobra"
+)
+
+var quotaCmd = &cobra.Command{
+	Use:   "quota",
+	Short: "View tenant quotas and request increases",
+}
+
+var quotaListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show limits and usage for the current tenant",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		quotas, err := client.ListQuotas(cmd.Context())
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(quotas)
+			return
+		}
+		printQuotaTable(quotas)
+	},
+}
+
+var quotaRequestCmd = &cobra.Command{
+	Use:   "request [resource] [limit]",
+	Short: "Request a higher limit for a resource",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		limit, err := strconv.Atoi(args[1])
+		if err != nil {
+			fmt.Printf("Error: invalid limit: %v\n", err)
+			return
+		}
+
+		reason, _ := cmd.Flags().GetString("reason")
+		client := createClient(opts)
+		req, err := client.RequestQuotaIncrease(cmd.Context(), domain.QuotaResource(args[0]), limit, reason)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		fmt.Printf("[SUCCESS] Quota increase requested (ID: %s, %s: %d -> %d)\n", req.ID, req.Resource, req.CurrentLimit, req.RequestedLimit)
+	},
+}
+
+var quotaRequestsCmd = &cobra.Command{
+	Use:   "requests",
+	Short: "List quota increase requests for the current tenant",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		reqs, err := client.ListQuotaRequests(cmd.Context())
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(reqs)
+			return
+		}
+		printQuotaRequestTable(reqs)
+	},
+}
+
+var quotaAdminCmd = &cobra.Command{
+	Use:   "admin",
+	Short: "Manage quotas across tenants (platform admin)",
+}
+
+var quotaAdminShowCmd = &cobra.Command{
+	Use:   "show [tenant_id]",
+	Short: "Show limits and usage for a tenant",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		tenantID, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid tenant ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		quotas, err := client.ListTenantQuotas(cmd.Context(), tenantID)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(quotas)
+			return
+		}
+		printQuotaTable(quotas)
+	},
+}
+
+var quotaAdminSetCmd = &cobra.Command{
+	Use:   "set [tenant_id] [resource] [limit]",
+	Short: "Override a tenant's limit for a resource",
+	Args:  cobra.ExactArgs(3),
+	Run: func(cmd *cobra.Command, args []string) {
+		tenantID, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid tenant ID: %v\n", err)
+			return
+		}
+		limit, err := strconv.Atoi(args[2])
+		if err != nil {
+			fmt.Printf("Error: invalid limit: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		if err := client.SetQuotaLimit(cmd.Context(), tenantID, domain.QuotaResource(args[1]), limit); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		fmt.Printf("[SUCCESS] Quota %s set to %d.\n", args[1], limit)
+	},
+}
+
+var quotaAdminRequestsCmd = &cobra.Command{
+	Use:   "requests",
+	Short: "List quota increase requests across tenants",
+	Run: func(cmd *cobra.Command, args []string) {
+		status, _ := cmd.Flags().GetString("status")
+		client := createClient(opts)
+		reqs, err := client.ListAllQuotaRequests(cmd.Context(), domain.QuotaRequestStatus(status))
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(reqs)
+			return
+		}
+		printQuotaRequestTable(reqs)
+	},
+}
+
+var quotaAdminApproveCmd = &cobra.Command{
+	Use:   "approve [request_id]",
+	Short: "Approve a quota increase request",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid request ID: %v\n", err)
+			return
+		}
+
+		note, _ := cmd.Flags().GetString("note")
+		client := createClient(opts)
+		if _, err := client.ApproveQuotaRequest(cmd.Context(), id, note); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		fmt.Println("[SUCCESS] Quota request approved.")
+	},
+}
+
+var quotaAdminDenyCmd = &cobra.Command{
+	Use:   "deny [request_id]",
+	Short: "Deny a quota increase request",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid request ID: %v\n", err)
+			return
+		}
+
+		note, _ := cmd.Flags().GetString("note")
+		client := createClient(opts)
+		if _, err := client.DenyQuotaRequest(cmd.Context(), id, note); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		fmt.Println("[SUCCESS] Quota request denied.")
+	},
+}
+
+func printQuotaTable(quotas []domain.ResourceQuota) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header([]string{"RESOURCE", "USED", "LIMIT", "AVAILABLE"})
+	for _, q := range quotas {
+		_ = table.Append([]string{string(q.Resource), strconv.Itoa(q.Used), strconv.Itoa(q.Limit), strconv.Itoa(q.Available())})
+	}
+	_ = table.Render()
+}
+
+func printQuotaRequestTable(reqs []domain.QuotaRequest) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header([]string{"ID", "TENANT", "RESOURCE", "CURRENT", "REQUESTED", "STATUS"})
+	for _, r := range reqs {
+		_ = table.Append([]string{
+			truncateID(r.ID.String()),
+			truncateID(r.TenantID.String()),
+			string(r.Resource),
+			strconv.Itoa(r.CurrentLimit),
+			strconv.Itoa(r.RequestedLimit),
+			string(r.Status),
+		})
+	}
+	_ = table.Render()
+}
+
+func init() {
+	quotaRequestCmd.Flags().String("reason", "", "Why the higher limit is needed")
+	quotaAdminRequestsCmd.Flags().String("status", "", "Filter by status (pending, approved, denied)")
+	quotaAdminApproveCmd.Flags().String("note", "", "Note for the requester")
+	quotaAdminDenyCmd.Flags().String("note", "", "Note for the requester")
+
+	quotaAdminCmd.AddCommand(quotaAdminShowCmd)
+	quotaAdminCmd.AddCommand(quotaAdminSetCmd)
+	quotaAdminCmd.AddCommand(quotaAdminRequestsCmd)
+	quotaAdminCmd.AddCommand(quotaAdminApproveCmd)
+	quotaAdminCmd.AddCommand(quotaAdminDenyCmd)
+
+	quotaCmd.AddCommand(quotaListCmd)
+	quotaCmd.AddCommand(quotaRequestCmd)
+	quotaCmd.AddCommand(quotaRequestsCmd)
+	quotaCmd.AddCommand(quotaAdminCmd)
+}
@@ -169,6 +169,7 @@ var routeTableDisassociateCmd = &cobra.Command{
 
 func init() {
 	routeTableCreateCmd.Flags().Bool("main", false, "Set as the main route table")
+	routeTableAddRouteCmd.Flags().String("target-id", "", "ID of the target resource (IGW, NAT gateway, peering or VPN gateway)")
 
 	routeTableCmd.AddCommand(routeTableListCmd)
 	routeTableCmd.AddCommand(routeTableCreateCmd)
@@ -4,6 +4,8 @@ package main
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
@@ -116,13 +118,174 @@ var secretsRmCmd = &cobra.Command{
 	},
 }
 
+var secretsPutCmd = &cobra.Command{
+	Use:   "put [id/name] [value]",
+	Short: "Store a new version of a secret",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		stage, _ := cmd.Flags().GetString("stage")
+		client := createClient(opts)
+		version, err := client.PutSecretValue(args[0], args[1], stage)
+		if err != nil {
+			fmt.Printf(secretsErrorFormat, err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(version)
+		} else {
+			fmt.Printf("[SUCCESS] Stored version %d of %s (%s).\n", version.Version, args[0], strings.Join(version.Stages, ","))
+		}
+	},
+}
+
+var secretsVersionsCmd = &cobra.Command{
+	Use:   "versions [id/name]",
+	Short: "List the versions of a secret and their stages",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		versions, err := client.ListSecretVersions(args[0])
+		if err != nil {
+			fmt.Printf(secretsErrorFormat, err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(versions)
+			return
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.Header([]string{"VERSION", "STAGES", "CREATED AT"})
+		for _, v := range versions {
+			table.Append([]string{
+				strconv.Itoa(v.Version),
+				strings.Join(v.Stages, ","),
+				v.CreatedAt.Format("2006-01-02 15:04:05"),
+			})
+		}
+		table.Render()
+	},
+}
+
+var secretsGetVersionCmd = &cobra.Command{
+	Use:   "get-version [id/name] [version|stage]",
+	Short: "Decrypt and show a specific version of a secret",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		version, err := client.GetSecretVersion(args[0], args[1])
+		if err != nil {
+			fmt.Printf(secretsErrorFormat, err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(version)
+		} else {
+			fmt.Printf("Version: %d\n", version.Version)
+			fmt.Printf("Stages:  %s\n", strings.Join(version.Stages, ","))
+			fmt.Printf("Value:   %s\n", version.Value)
+		}
+	},
+}
+
+var secretsStageCmd = &cobra.Command{
+	Use:   "stage [id/name] [stage] [version]",
+	Short: "Move a stage label (current or pending) to a version",
+	Args:  cobra.ExactArgs(3),
+	Run: func(cmd *cobra.Command, args []string) {
+		version, err := strconv.Atoi(args[2])
+		if err != nil {
+			fmt.Printf(secretsErrorFormat, fmt.Errorf("invalid version %q", args[2]))
+			return
+		}
+
+		client := createClient(opts)
+		if err := client.MoveSecretStage(args[0], args[1], version); err != nil {
+			fmt.Printf(secretsErrorFormat, err)
+			return
+		}
+		fmt.Printf("[SUCCESS] Stage %s now points to version %d.\n", args[1], version)
+	},
+}
+
+var secretsRotationCmd = &cobra.Command{
+	Use:   "rotation [id/name]",
+	Short: "Configure or disable scheduled rotation",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		disable, _ := cmd.Flags().GetBool("disable")
+		if disable {
+			if err := client.DisableSecretRotation(args[0]); err != nil {
+				fmt.Printf(secretsErrorFormat, err)
+				return
+			}
+			fmt.Printf("[SUCCESS] Rotation disabled for %s.\n", args[0])
+			return
+		}
+
+		functionID, _ := cmd.Flags().GetString("function")
+		days, _ := cmd.Flags().GetInt("every")
+		if functionID == "" {
+			fmt.Printf(secretsErrorFormat, "--function is required")
+			return
+		}
+		secret, err := client.ConfigureSecretRotation(args[0], functionID, days)
+		if err != nil {
+			fmt.Printf(secretsErrorFormat, err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(secret)
+		} else {
+			fmt.Printf("[SUCCESS] %s rotates every %d days.\n", secret.Name, secret.RotationIntervalDays)
+		}
+	},
+}
+
+var secretsRotateCmd = &cobra.Command{
+	Use:   "rotate [id/name]",
+	Short: "Rotate a secret now using its rotation function",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		version, err := client.RotateSecret(args[0])
+		if err != nil {
+			fmt.Printf(secretsErrorFormat, err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(version)
+		} else {
+			fmt.Printf("[SUCCESS] %s rotated to version %d.\n", args[0], version.Version)
+		}
+	},
+}
+
 func init() {
 	secretsCmd.AddCommand(secretsListCmd)
 	secretsCmd.AddCommand(secretsCreateCmd)
 	secretsCmd.AddCommand(secretsGetCmd)
 	secretsCmd.AddCommand(secretsRmCmd)
+	secretsCmd.AddCommand(secretsPutCmd)
+	secretsCmd.AddCommand(secretsVersionsCmd)
+	secretsCmd.AddCommand(secretsGetVersionCmd)
+	secretsCmd.AddCommand(secretsStageCmd)
+	secretsCmd.AddCommand(secretsRotationCmd)
+	secretsCmd.AddCommand(secretsRotateCmd)
 
 	secretsCreateCmd.Flags().StringP("name", "n", "", "Unique name of the secret")
 	secretsCreateCmd.Flags().StringP("value", "v", "", "Value to encrypt")
 	secretsCreateCmd.Flags().StringP("description", "d", "", "Optional description")
+
+	secretsPutCmd.Flags().String("stage", "current", "Stage for the new version (current or pending)")
+
+	secretsRotationCmd.Flags().String("function", "", "ID of the function that generates new values")
+	secretsRotationCmd.Flags().Int("every", 30, "Rotation interval in days")
+	secretsRotationCmd.Flags().Bool("disable", false, "Disable scheduled rotation")
 }
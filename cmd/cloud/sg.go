@@ -97,6 +97,7 @@ var sgAddRuleCmd = &cobra.Command{
 		portMax, _ := cmd.Flags().GetInt("port-max")
 		cidr, _ := cmd.Flags().GetString("cidr")
 		priority, _ := cmd.Flags().GetInt("priority")
+		sourceGroup, _ := cmd.Flags().GetString("source-group")
 
 		client := createClient(opts)
 		sgID := resolveSGID(args[0], client)
@@ -108,6 +109,14 @@ var sgAddRuleCmd = &cobra.Command{
 			CIDR:      cidr,
 			Priority:  priority,
 		}
+		if sourceGroup != "" {
+			if cmd.Flags().Changed("cidr") {
+				fmt.Printf(errFmt, "--cidr and --source-group are mutually exclusive")
+				return
+			}
+			rule.CIDR = ""
+			rule.SourceGroupID = resolveSGID(sourceGroup, client)
+		}
 
 		res, err := client.AddSecurityRule(sgID, rule)
 		if err != nil {
@@ -235,6 +244,7 @@ func init() {
 	sgAddRuleCmd.Flags().Int("port-max", 0, "Maximum port")
 	sgAddRuleCmd.Flags().String("cidr", "0.0.0.0/0", "CIDR block")
 	sgAddRuleCmd.Flags().Int("priority", 100, "Priority")
+	sgAddRuleCmd.Flags().String("source-group", "", "Match instances of this security group instead of a CIDR")
 
 	sgCmd.AddCommand(sgCreateCmd, sgListCmd, sgGetCmd, sgDeleteCmd, sgAddRuleCmd, sgRemoveRuleCmd, sgAttachCmd, sgDetachCmd)
 }
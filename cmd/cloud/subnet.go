@@ -95,12 +95,33 @@ var subnetDeleteCmd = &cobra.Command{
 	},
 }
 
+var subnetAssignIPv6Cmd = &cobra.Command{
+	Use:   "assign-ipv6 [subnet-id]",
+	Short: "Give a subnet a /64 from its VPC's IPv6 block",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		subnetID := resolveSubnetID(args[0], client)
+		cidr, _ := cmd.Flags().GetString("cidr")
+
+		subnet, err := client.AssignSubnetIPv6CIDRBlock(subnetID, cidr)
+		if err != nil {
+			fmt.Printf(subnetErrorFormat, err)
+			return
+		}
+
+		fmt.Printf("Subnet %s assigned IPv6 block %s\n", subnet.ID, subnet.IPv6CIDRBlock)
+	},
+}
+
 func init() {
 	subnetCreateCmd.Flags().String("az", "us-east-1a", "Availability zone")
+	subnetAssignIPv6Cmd.Flags().String("cidr", "", "IPv6 /64 block (next free /64 if omitted)")
 
 	subnetCmd.AddCommand(subnetListCmd)
 	subnetCmd.AddCommand(subnetCreateCmd)
 	subnetCmd.AddCommand(subnetDeleteCmd)
+	subnetCmd.AddCommand(subnetAssignIPv6Cmd)
 }
 
 // resolveSubnetID resolves a subnet ID or name to a full UUID.
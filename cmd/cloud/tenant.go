@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/olekukonko/tablewriter"
+	"github.com/poyrazk/thecloud/pkg/sdk"
 	"github.com/spf13/cobra"
 )
 
@@ -83,8 +84,196 @@ var tenantSwitchCmd = &cobra.Command{
 	},
 }
 
+var tenantInviteCmd = &cobra.Command{
+	Use:   "invite [tenant_id] [email]",
+	Short: "Invite someone to a tenant",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		role, _ := cmd.Flags().GetString("role")
+		client := createClient(opts)
+		inv, err := client.InviteTenantMember(args[0], args[1], role)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(inv)
+			return
+		}
+
+		fmt.Printf("[SUCCESS] Invited %s as %s (expires %s).\n", inv.Email, inv.Role, inv.ExpiresAt.Format("2006-01-02 15:04"))
+		fmt.Printf("Invitation token (shown once): %s\n", inv.Token)
+	},
+}
+
+var tenantInvitationsCmd = &cobra.Command{
+	Use:   "invitations [tenant_id]",
+	Short: "List pending invitations of a tenant, or your own when no tenant is given",
+	Args:  cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		var (
+			invitations []sdk.TenantInvitation
+			err         error
+		)
+		if len(args) == 1 {
+			invitations, err = client.ListTenantInvitations(args[0])
+		} else {
+			invitations, err = client.ListMyInvitations()
+		}
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(invitations)
+			return
+		}
+
+		if len(invitations) == 0 {
+			fmt.Println("No pending invitations.")
+			return
+		}
+
+		table := tablewriter.NewWriter(cmd.OutOrStdout())
+		table.Header([]string{"ID", "TENANT", "EMAIL", "ROLE", "EXPIRES AT"})
+
+		for _, inv := range invitations {
+			table.Append([]string{
+				inv.ID,
+				inv.TenantID,
+				inv.Email,
+				inv.Role,
+				inv.ExpiresAt.Format("2006-01-02 15:04"),
+			})
+		}
+		table.Render()
+	},
+}
+
+var tenantResendInviteCmd = &cobra.Command{
+	Use:   "resend-invite [tenant_id] [invitation_id]",
+	Short: "Issue a fresh token for a pending invitation",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		inv, err := client.ResendTenantInvitation(args[0], args[1])
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		fmt.Printf("[SUCCESS] Invitation for %s renewed until %s.\n", inv.Email, inv.ExpiresAt.Format("2006-01-02 15:04"))
+		fmt.Printf("Invitation token (shown once): %s\n", inv.Token)
+	},
+}
+
+var tenantRevokeInviteCmd = &cobra.Command{
+	Use:   "revoke-invite [tenant_id] [invitation_id]",
+	Short: "Revoke a pending invitation",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		if err := client.RevokeTenantInvitation(args[0], args[1]); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		fmt.Println("[SUCCESS] Invitation revoked.")
+	},
+}
+
+var tenantAcceptCmd = &cobra.Command{
+	Use:   "accept [token]",
+	Short: "Accept an invitation to join a tenant",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		member, err := client.AcceptInvitation(args[0])
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		fmt.Printf("[SUCCESS] Joined tenant %s as %s.\n", member.TenantID, member.Role)
+	},
+}
+
+var tenantDeclineCmd = &cobra.Command{
+	Use:   "decline [token]",
+	Short: "Decline an invitation to join a tenant",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		if err := client.DeclineInvitation(args[0]); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		fmt.Println("[SUCCESS] Invitation declined.")
+	},
+}
+
+var tenantSetRoleCmd = &cobra.Command{
+	Use:   "set-role [tenant_id] [user_id] [role]",
+	Short: "Change a member's role (admin or member)",
+	Args:  cobra.ExactArgs(3),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		if err := client.UpdateTenantMemberRole(args[0], args[1], args[2]); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		fmt.Printf("[SUCCESS] User %s is now %s.\n", args[1], args[2])
+	},
+}
+
+var tenantRemoveMemberCmd = &cobra.Command{
+	Use:   "remove-member [tenant_id] [user_id]",
+	Short: "Remove a member from a tenant",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		if err := client.RemoveTenantMember(args[0], args[1]); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		fmt.Printf("[SUCCESS] User %s removed.\n", args[1])
+	},
+}
+
+var tenantTransferCmd = &cobra.Command{
+	Use:   "transfer-ownership [tenant_id] [user_id]",
+	Short: "Make another member the tenant owner",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		if err := client.TransferTenantOwnership(args[0], args[1]); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		fmt.Printf("[SUCCESS] Ownership of %s transferred to %s.\n", args[0], args[1])
+	},
+}
+
 func init() {
+	tenantInviteCmd.Flags().String("role", "member", "Role to grant (admin or member)")
+
 	tenantCmd.AddCommand(tenantListCmd)
 	tenantCmd.AddCommand(tenantCreateCmd)
 	tenantCmd.AddCommand(tenantSwitchCmd)
+	tenantCmd.AddCommand(tenantInviteCmd)
+	tenantCmd.AddCommand(tenantInvitationsCmd)
+	tenantCmd.AddCommand(tenantResendInviteCmd)
+	tenantCmd.AddCommand(tenantRevokeInviteCmd)
+	tenantCmd.AddCommand(tenantAcceptCmd)
+	tenantCmd.AddCommand(tenantDeclineCmd)
+	tenantCmd.AddCommand(tenantSetRoleCmd)
+	tenantCmd.AddCommand(tenantRemoveMemberCmd)
+	tenantCmd.AddCommand(tenantTransferCmd)
 }
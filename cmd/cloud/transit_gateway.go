@@ -0,0 +1,422 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+var transitGatewayCmd = &cobra.Command{
+	Use:     "transit-gateway",
+	Aliases: []string{"tgw"},
+	Short:   "Manage transit gateways",
+	Long: `Manage transit gateways that connect many VPCs through a central hub.
+
+Each attached VPC is associated with a transit gateway route table, which
+decides where its traffic goes, and can propagate its CIDRs into route tables.
+Send traffic to the hub by adding a route with target type 'tgw' to the VPC's
+route table.`,
+}
+
+var tgwCreateCmd = &cobra.Command{
+	Use:   "create [name]",
+	Short: "Create a transit gateway",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		tgw, err := client.CreateTransitGateway(cmd.Context(), args[0])
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(tgw)
+			return
+		}
+		fmt.Printf("[SUCCESS] Transit gateway %s created.\n", tgw.ID)
+		if tgw.DefaultRouteTableID != nil {
+			fmt.Printf("Default Route Table: %s\n", tgw.DefaultRouteTableID)
+		}
+	},
+}
+
+var tgwListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List transit gateways",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		gateways, err := client.ListTransitGateways(cmd.Context())
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(gateways)
+			return
+		}
+		table := tablewriter.NewWriter(os.Stdout)
+		table.Header([]string{"ID", "NAME", "STATUS", "VNI", "BRIDGE"})
+		for _, tgw := range gateways {
+			_ = table.Append([]string{
+				truncateID(tgw.ID.String()),
+				tgw.Name,
+				string(tgw.Status),
+				strconv.Itoa(tgw.VXLANID),
+				tgw.BridgeName,
+			})
+		}
+		_ = table.Render()
+	},
+}
+
+var tgwRmCmd = &cobra.Command{
+	Use:   "rm [id]",
+	Short: "Delete a transit gateway without attachments",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid transit gateway ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		if err := client.DeleteTransitGateway(cmd.Context(), id); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Printf("[SUCCESS] Transit gateway %s deleted.\n", id)
+	},
+}
+
+var tgwAttachCmd = &cobra.Command{
+	Use:   "attach [tgw_id] [vpc_id]",
+	Short: "Attach a VPC to a transit gateway",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		tgwID, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid transit gateway ID: %v\n", err)
+			return
+		}
+		vpcID, err := uuid.Parse(args[1])
+		if err != nil {
+			fmt.Printf("Error: invalid VPC ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		att, err := client.AttachVPCToTransitGateway(cmd.Context(), tgwID, vpcID)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(att)
+			return
+		}
+		fmt.Printf("[SUCCESS] VPC %s attached (attachment %s).\n", vpcID, att.ID)
+	},
+}
+
+var tgwAttachmentsCmd = &cobra.Command{
+	Use:   "attachments [tgw_id]",
+	Short: "List the VPC attachments of a transit gateway",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		tgwID, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid transit gateway ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		attachments, err := client.ListTransitGatewayAttachments(cmd.Context(), tgwID)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(attachments)
+			return
+		}
+		table := tablewriter.NewWriter(os.Stdout)
+		table.Header([]string{"ID", "VPC", "ROUTE TABLE", "STATUS"})
+		for _, att := range attachments {
+			rt := "-"
+			if att.RouteTableID != nil {
+				rt = truncateID(att.RouteTableID.String())
+			}
+			_ = table.Append([]string{truncateID(att.ID.String()), truncateID(att.VPCID.String()), rt, string(att.Status)})
+		}
+		_ = table.Render()
+	},
+}
+
+var tgwDetachCmd = &cobra.Command{
+	Use:   "detach [attachment_id]",
+	Short: "Detach a VPC from its transit gateway",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid attachment ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		if err := client.DetachVPCFromTransitGateway(cmd.Context(), id); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Printf("[SUCCESS] Attachment %s removed.\n", id)
+	},
+}
+
+var tgwRouteTableCmd = &cobra.Command{
+	Use:   "route-table",
+	Short: "Manage transit gateway route tables",
+}
+
+var tgwRouteTableCreateCmd = &cobra.Command{
+	Use:   "create [tgw_id] [name]",
+	Short: "Create a transit gateway route table",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		tgwID, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid transit gateway ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		rt, err := client.CreateTransitGatewayRouteTable(cmd.Context(), tgwID, args[1])
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(rt)
+			return
+		}
+		fmt.Printf("[SUCCESS] Route table %s created.\n", rt.ID)
+	},
+}
+
+var tgwRouteTableShowCmd = &cobra.Command{
+	Use:   "show [route_table_id]",
+	Short: "Show the routes of a transit gateway route table",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid route table ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		rt, err := client.GetTransitGatewayRouteTable(cmd.Context(), id)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(rt)
+			return
+		}
+		fmt.Printf("Route Table: %s (%s)\n", rt.Name, rt.ID)
+		table := tablewriter.NewWriter(os.Stdout)
+		table.Header([]string{"ID", "DESTINATION", "TARGET", "TYPE"})
+		for _, route := range rt.Routes {
+			target := "blackhole"
+			if route.AttachmentID != nil {
+				target = truncateID(route.AttachmentID.String())
+			}
+			_ = table.Append([]string{truncateID(route.ID.String()), route.DestinationCIDR, target, string(route.Type)})
+		}
+		_ = table.Render()
+	},
+}
+
+var tgwRouteTableRmCmd = &cobra.Command{
+	Use:   "rm [route_table_id]",
+	Short: "Delete a transit gateway route table",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid route table ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		if err := client.DeleteTransitGatewayRouteTable(cmd.Context(), id); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Printf("[SUCCESS] Route table %s deleted.\n", id)
+	},
+}
+
+var tgwAddRouteCmd = &cobra.Command{
+	Use:   "add-route [route_table_id] [destination_cidr]",
+	Short: "Add a static route (blackhole unless --attachment is given)",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		rtID, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid route table ID: %v\n", err)
+			return
+		}
+
+		var attachmentID *uuid.UUID
+		if raw, _ := cmd.Flags().GetString("attachment"); raw != "" {
+			parsed, err := uuid.Parse(raw)
+			if err != nil {
+				fmt.Printf("Error: invalid attachment ID: %v\n", err)
+				return
+			}
+			attachmentID = &parsed
+		}
+
+		client := createClient(opts)
+		route, err := client.AddTransitGatewayRoute(cmd.Context(), rtID, args[1], attachmentID)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(route)
+			return
+		}
+		fmt.Printf("[SUCCESS] Route %s added.\n", route.ID)
+	},
+}
+
+var tgwRemoveRouteCmd = &cobra.Command{
+	Use:   "remove-route [route_table_id] [route_id]",
+	Short: "Remove a static route",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		rtID, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid route table ID: %v\n", err)
+			return
+		}
+		routeID, err := uuid.Parse(args[1])
+		if err != nil {
+			fmt.Printf("Error: invalid route ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		if err := client.RemoveTransitGatewayRoute(cmd.Context(), rtID, routeID); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Printf("[SUCCESS] Route %s removed.\n", routeID)
+	},
+}
+
+var tgwAssociateCmd = &cobra.Command{
+	Use:   "associate [route_table_id] [attachment_id]",
+	Short: "Use the route table for traffic arriving from an attachment",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		rtID, attID, ok := parseRouteTableAttachment(args)
+		if !ok {
+			return
+		}
+
+		client := createClient(opts)
+		if err := client.AssociateTransitGatewayRouteTable(cmd.Context(), rtID, attID); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Printf("[SUCCESS] Attachment %s associated with route table %s.\n", attID, rtID)
+	},
+}
+
+var tgwPropagateCmd = &cobra.Command{
+	Use:   "propagate [route_table_id] [attachment_id]",
+	Short: "Learn an attachment's VPC CIDRs into the route table",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		rtID, attID, ok := parseRouteTableAttachment(args)
+		if !ok {
+			return
+		}
+
+		client := createClient(opts)
+		if err := client.EnableTransitGatewayPropagation(cmd.Context(), rtID, attID); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Printf("[SUCCESS] Attachment %s now propagates into route table %s.\n", attID, rtID)
+	},
+}
+
+var tgwUnpropagateCmd = &cobra.Command{
+	Use:   "unpropagate [route_table_id] [attachment_id]",
+	Short: "Withdraw an attachment's propagated routes",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		rtID, attID, ok := parseRouteTableAttachment(args)
+		if !ok {
+			return
+		}
+
+		client := createClient(opts)
+		if err := client.DisableTransitGatewayPropagation(cmd.Context(), rtID, attID); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Printf("[SUCCESS] Propagation of attachment %s disabled.\n", attID)
+	},
+}
+
+func parseRouteTableAttachment(args []string) (uuid.UUID, uuid.UUID, bool) {
+	rtID, err := uuid.Parse(args[0])
+	if err != nil {
+		fmt.Printf("Error: invalid route table ID: %v\n", err)
+		return uuid.Nil, uuid.Nil, false
+	}
+	attID, err := uuid.Parse(args[1])
+	if err != nil {
+		fmt.Printf("Error: invalid attachment ID: %v\n", err)
+		return uuid.Nil, uuid.Nil, false
+	}
+	return rtID, attID, true
+}
+
+func init() {
+	tgwAddRouteCmd.Flags().String("attachment", "", "Attachment ID to forward to (omit for a blackhole route)")
+
+	tgwRouteTableCmd.AddCommand(tgwRouteTableCreateCmd)
+	tgwRouteTableCmd.AddCommand(tgwRouteTableShowCmd)
+	tgwRouteTableCmd.AddCommand(tgwRouteTableRmCmd)
+	tgwRouteTableCmd.AddCommand(tgwAddRouteCmd)
+	tgwRouteTableCmd.AddCommand(tgwRemoveRouteCmd)
+	tgwRouteTableCmd.AddCommand(tgwAssociateCmd)
+	tgwRouteTableCmd.AddCommand(tgwPropagateCmd)
+	tgwRouteTableCmd.AddCommand(tgwUnpropagateCmd)
+
+	transitGatewayCmd.AddCommand(tgwCreateCmd)
+	transitGatewayCmd.AddCommand(tgwListCmd)
+	transitGatewayCmd.AddCommand(tgwRmCmd)
+	transitGatewayCmd.AddCommand(tgwAttachCmd)
+	transitGatewayCmd.AddCommand(tgwAttachmentsCmd)
+	transitGatewayCmd.AddCommand(tgwDetachCmd)
+	transitGatewayCmd.AddCommand(tgwRouteTableCmd)
+}
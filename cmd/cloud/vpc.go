@@ -121,6 +121,9 @@ var vpcShowCmd = &cobra.Command{
 		fmt.Printf(fmtDetailRow, "ID:", vpc.ID)
 		fmt.Printf(fmtDetailRow, "Name:", vpc.Name)
 		fmt.Printf(fmtDetailRow, "CIDR:", vpc.CIDRBlock)
+		if vpc.IPv6CIDRBlock != "" {
+			fmt.Printf(fmtDetailRow, "IPv6 CIDR:", vpc.IPv6CIDRBlock)
+		}
 		fmt.Printf(fmtDetailRow, "VXLAN ID:", fmt.Sprintf("%d", vpc.VXLANID))
 		fmt.Printf(fmtDetailRow, "Network ID:", vpc.NetworkID)
 		fmt.Printf(fmtDetailRow, "Status:", vpc.Status)
@@ -129,10 +132,33 @@ var vpcShowCmd = &cobra.Command{
 	},
 }
 
+var vpcAssignIPv6Cmd = &cobra.Command{
+	Use:   "assign-ipv6 [id/name]",
+	Short: "Give a VPC a /56 IPv6 CIDR block",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		cidr, _ := cmd.Flags().GetString("cidr")
+		client := createClient(opts)
+		vpc, err := client.AssignVPCIPv6CIDRBlock(args[0], cidr)
+		if err != nil {
+			fmt.Printf(vpcErrorFormat, err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(vpc)
+			return
+		}
+		fmt.Printf("[SUCCESS] VPC %s is now dual-stack with %s\n", vpc.Name, vpc.IPv6CIDRBlock)
+	},
+}
+
 func init() {
 	vpcCreateCmd.Flags().String("cidr-block", "10.0.0.0/16", "CIDR block for the VPC")
+	vpcAssignIPv6Cmd.Flags().String("cidr", "", "IPv6 /56 block (generated from fd00::/8 if omitted)")
 	vpcCmd.AddCommand(vpcListCmd)
 	vpcCmd.AddCommand(vpcCreateCmd)
 	vpcCmd.AddCommand(vpcRmCmd)
 	vpcCmd.AddCommand(vpcShowCmd)
+	vpcCmd.AddCommand(vpcAssignIPv6Cmd)
 }
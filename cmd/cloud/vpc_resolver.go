@@ -0,0 +1,193 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+var resolverCmd = &cobra.Command{
+	Use:     "resolver",
+	Aliases: []string{"vpc-resolver"},
+	Short:   "Manage VPC DNS resolvers",
+	Long: `Manage the DNS resolvers of VPCs.
+
+Instances launched in a VPC with a resolver use it as their nameserver. The
+resolver answers the VPC's private zone and those of peered VPCs, applies
+conditional forwarding rules and sends everything else to public DNS.`,
+}
+
+var resolverCreateCmd = &cobra.Command{
+	Use:   "create [vpc_id]",
+	Short: "Create the resolver of a VPC",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		vpcID, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid VPC ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		resolver, err := client.CreateVPCResolver(cmd.Context(), vpcID)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(resolver)
+			return
+		}
+		fmt.Printf("[SUCCESS] Resolver %s created.\n", resolver.ID)
+		fmt.Printf("Nameserver: %s\n", resolver.PrivateIP)
+	},
+}
+
+var resolverListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List VPC resolvers",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		resolvers, err := client.ListVPCResolvers(cmd.Context())
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(resolvers)
+			return
+		}
+		table := tablewriter.NewWriter(os.Stdout)
+		table.Header([]string{"ID", "VPC", "STATUS", "IP"})
+		for _, r := range resolvers {
+			_ = table.Append([]string{
+				truncateID(r.ID.String()),
+				truncateID(r.VPCID.String()),
+				string(r.Status),
+				r.PrivateIP,
+			})
+		}
+		_ = table.Render()
+	},
+}
+
+var resolverRmCmd = &cobra.Command{
+	Use:   "rm [id]",
+	Short: "Delete a VPC resolver",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid resolver ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		if err := client.DeleteVPCResolver(cmd.Context(), id); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Printf("[SUCCESS] Resolver %s deleted.\n", id)
+	},
+}
+
+var resolverAddRuleCmd = &cobra.Command{
+	Use:   "add-rule [resolver_id] [domain] [target...]",
+	Short: "Forward a domain to other resolvers (targets are ip or ip:port)",
+	Args:  cobra.MinimumNArgs(3),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid resolver ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		rule, err := client.CreateResolverForwardingRule(cmd.Context(), id, args[1], args[2:])
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(rule)
+			return
+		}
+		fmt.Printf("[SUCCESS] Forwarding rule %s created for %s.\n", rule.ID, rule.DomainName)
+	},
+}
+
+var resolverRulesCmd = &cobra.Command{
+	Use:   "rules [resolver_id]",
+	Short: "List the forwarding rules of a resolver",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid resolver ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		rules, err := client.ListResolverForwardingRules(cmd.Context(), id)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(rules)
+			return
+		}
+		table := tablewriter.NewWriter(os.Stdout)
+		table.Header([]string{"ID", "DOMAIN", "TARGETS"})
+		for _, r := range rules {
+			_ = table.Append([]string{
+				truncateID(r.ID.String()),
+				r.DomainName,
+				strings.Join(r.TargetIPs, ", "),
+			})
+		}
+		_ = table.Render()
+	},
+}
+
+var resolverRmRuleCmd = &cobra.Command{
+	Use:   "rm-rule [resolver_id] [rule_id]",
+	Short: "Delete a forwarding rule",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid resolver ID: %v\n", err)
+			return
+		}
+		ruleID, err := uuid.Parse(args[1])
+		if err != nil {
+			fmt.Printf("Error: invalid rule ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		if err := client.DeleteResolverForwardingRule(cmd.Context(), id, ruleID); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Printf("[SUCCESS] Forwarding rule %s deleted.\n", ruleID)
+	},
+}
+
+func init() {
+	resolverCmd.AddCommand(resolverCreateCmd)
+	resolverCmd.AddCommand(resolverListCmd)
+	resolverCmd.AddCommand(resolverRmCmd)
+	resolverCmd.AddCommand(resolverAddRuleCmd)
+	resolverCmd.AddCommand(resolverRulesCmd)
+	resolverCmd.AddCommand(resolverRmRuleCmd)
+}
@@ -0,0 +1,327 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+var vpnCmd = &cobra.Command{
+	Use:   "vpn",
+	Short: "Manage site-to-site WireGuard VPNs",
+	Long: `Manage site-to-site VPNs between VPCs and on-premises networks.
+
+A VPN gateway runs WireGuard inside a VPC. A customer gateway describes your
+on-premises WireGuard device. A connection joins the two and routes the
+on-premises CIDRs to the VPN gateway from the VPC's main route table.`,
+}
+
+var vpnGatewayCmd = &cobra.Command{
+	Use:   "gateway",
+	Short: "Manage VPN gateways",
+}
+
+var vpnGatewayCreateCmd = &cobra.Command{
+	Use:   "create [vpc_id] [name]",
+	Short: "Launch a VPN gateway in a VPC",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		vpcID, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid VPC ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		gw, err := client.CreateVPNGateway(cmd.Context(), vpcID, args[1])
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(gw)
+			return
+		}
+		fmt.Printf("[SUCCESS] VPN gateway %s created.\n", gw.ID)
+		fmt.Printf("Public Key: %s\n", gw.PublicKey)
+		fmt.Printf("UDP Port:   %d\n", gw.Port)
+	},
+}
+
+var vpnGatewayListCmd = &cobra.Command{
+	Use:   "list [vpc_id]",
+	Short: "List the VPN gateways of a VPC",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		vpcID, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid VPC ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		gateways, err := client.ListVPNGateways(cmd.Context(), vpcID)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(gateways)
+			return
+		}
+		table := tablewriter.NewWriter(os.Stdout)
+		table.Header([]string{"ID", "NAME", "STATUS", "PORT", "PUBLIC KEY"})
+		for _, gw := range gateways {
+			_ = table.Append([]string{
+				truncateID(gw.ID.String()),
+				gw.Name,
+				string(gw.Status),
+				strconv.Itoa(gw.Port),
+				gw.PublicKey,
+			})
+		}
+		_ = table.Render()
+	},
+}
+
+var vpnGatewayRmCmd = &cobra.Command{
+	Use:   "rm [id]",
+	Short: "Delete a VPN gateway without connections",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid VPN gateway ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		if err := client.DeleteVPNGateway(cmd.Context(), id); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Printf("[SUCCESS] VPN gateway %s deleted.\n", id)
+	},
+}
+
+var customerGatewayCmd = &cobra.Command{
+	Use:   "customer-gateway",
+	Short: "Manage customer gateways (on-premises WireGuard endpoints)",
+}
+
+var customerGatewayCreateCmd = &cobra.Command{
+	Use:   "create [name] [ip_address] [public_key]",
+	Short: "Register an on-premises WireGuard endpoint",
+	Args:  cobra.ExactArgs(3),
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		cgw, err := client.CreateCustomerGateway(cmd.Context(), args[0], args[1], args[2])
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(cgw)
+			return
+		}
+		fmt.Printf("[SUCCESS] Customer gateway %s created.\n", cgw.ID)
+	},
+}
+
+var customerGatewayListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List customer gateways",
+	Run: func(cmd *cobra.Command, args []string) {
+		client := createClient(opts)
+		gateways, err := client.ListCustomerGateways(cmd.Context())
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(gateways)
+			return
+		}
+		table := tablewriter.NewWriter(os.Stdout)
+		table.Header([]string{"ID", "NAME", "IP ADDRESS", "PUBLIC KEY"})
+		for _, cgw := range gateways {
+			_ = table.Append([]string{truncateID(cgw.ID.String()), cgw.Name, cgw.IPAddress, cgw.PublicKey})
+		}
+		_ = table.Render()
+	},
+}
+
+var customerGatewayRmCmd = &cobra.Command{
+	Use:   "rm [id]",
+	Short: "Delete a customer gateway no connection uses",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid customer gateway ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		if err := client.DeleteCustomerGateway(cmd.Context(), id); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Printf("[SUCCESS] Customer gateway %s deleted.\n", id)
+	},
+}
+
+var vpnConnectionCmd = &cobra.Command{
+	Use:   "connection",
+	Short: "Manage VPN connections",
+}
+
+var vpnConnectionCreateCmd = &cobra.Command{
+	Use:   "create [vpn_gateway_id] [customer_gateway_id]",
+	Short: "Connect a VPN gateway to a customer gateway",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		gwID, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid VPN gateway ID: %v\n", err)
+			return
+		}
+		cgwID, err := uuid.Parse(args[1])
+		if err != nil {
+			fmt.Printf("Error: invalid customer gateway ID: %v\n", err)
+			return
+		}
+		cidrs, _ := cmd.Flags().GetStringSlice("remote-cidr")
+
+		client := createClient(opts)
+		conn, err := client.CreateVPNConnection(cmd.Context(), gwID, cgwID, cidrs)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(conn)
+			return
+		}
+		fmt.Printf("[SUCCESS] VPN connection %s created.\n", conn.ID)
+		fmt.Printf("Run 'cloud vpn connection config %s' for the settings of your side of the tunnel.\n", conn.ID)
+	},
+}
+
+var vpnConnectionListCmd = &cobra.Command{
+	Use:   "list [vpn_gateway_id]",
+	Short: "List the connections of a VPN gateway",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		gwID, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid VPN gateway ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		conns, err := client.ListVPNConnections(cmd.Context(), gwID)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(conns)
+			return
+		}
+		table := tablewriter.NewWriter(os.Stdout)
+		table.Header([]string{"ID", "CUSTOMER GATEWAY", "REMOTE CIDRS", "STATUS"})
+		for _, conn := range conns {
+			_ = table.Append([]string{
+				truncateID(conn.ID.String()),
+				truncateID(conn.CustomerGatewayID.String()),
+				strings.Join(conn.RemoteCIDRs, ","),
+				string(conn.Status),
+			})
+		}
+		_ = table.Render()
+	},
+}
+
+var vpnConnectionConfigCmd = &cobra.Command{
+	Use:   "config [id]",
+	Short: "Print the WireGuard settings for your side of a connection",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid VPN connection ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		cfg, err := client.GetVPNConnectionConfig(cmd.Context(), id)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+
+		if opts.JSON {
+			printJSON(cfg)
+			return
+		}
+		fmt.Println("[Peer]")
+		fmt.Printf("PublicKey = %s\n", cfg.GatewayPublicKey)
+		fmt.Printf("PresharedKey = %s\n", cfg.PreSharedKey)
+		fmt.Printf("Endpoint = <cloud-host>:%d\n", cfg.GatewayPort)
+		fmt.Printf("AllowedIPs = %s\n", strings.Join(cfg.AllowedIPs, ", "))
+	},
+}
+
+var vpnConnectionRmCmd = &cobra.Command{
+	Use:   "rm [id]",
+	Short: "Delete a VPN connection and its routes",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id, err := uuid.Parse(args[0])
+		if err != nil {
+			fmt.Printf("Error: invalid VPN connection ID: %v\n", err)
+			return
+		}
+
+		client := createClient(opts)
+		if err := client.DeleteVPNConnection(cmd.Context(), id); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			return
+		}
+		fmt.Printf("[SUCCESS] VPN connection %s deleted.\n", id)
+	},
+}
+
+func init() {
+	vpnConnectionCreateCmd.Flags().StringSlice("remote-cidr", nil, "On-premises CIDR reachable through the tunnel (repeatable)")
+	_ = vpnConnectionCreateCmd.MarkFlagRequired("remote-cidr")
+
+	vpnGatewayCmd.AddCommand(vpnGatewayCreateCmd)
+	vpnGatewayCmd.AddCommand(vpnGatewayListCmd)
+	vpnGatewayCmd.AddCommand(vpnGatewayRmCmd)
+
+	customerGatewayCmd.AddCommand(customerGatewayCreateCmd)
+	customerGatewayCmd.AddCommand(customerGatewayListCmd)
+	customerGatewayCmd.AddCommand(customerGatewayRmCmd)
+
+	vpnConnectionCmd.AddCommand(vpnConnectionCreateCmd)
+	vpnConnectionCmd.AddCommand(vpnConnectionListCmd)
+	vpnConnectionCmd.AddCommand(vpnConnectionConfigCmd)
+	vpnConnectionCmd.AddCommand(vpnConnectionRmCmd)
+
+	vpnCmd.AddCommand(vpnGatewayCmd)
+	vpnCmd.AddCommand(customerGatewayCmd)
+	vpnCmd.AddCommand(vpnConnectionCmd)
+}
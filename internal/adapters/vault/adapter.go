@@ -34,6 +34,11 @@ func NewVaultAdapter(address, token string, logger *slog.Logger) (*Adapter, erro
 	}, nil
 }
 
+// TransitKMS returns a KMS client backed by the Transit engine of the same Vault.
+func (a *Adapter) TransitKMS() *TransitKMSAdapter {
+	return NewTransitKMSAdapter(a.client)
+}
+
 // StoreSecret saves a secret at the specified path using KV v2 semantics.
 func (a *Adapter) StoreSecret(ctx context.Context, path string, data map[string]interface{}) error {
 	// KV v2 expects data to be wrapped in a "data" field
@@ -37,6 +37,7 @@ type Repositories struct {
 	Audit            ports.AuditRepository
 	User             ports.UserRepository
 	Tenant           ports.TenantRepository
+	TenantInvitation ports.TenantInvitationRepository
 	Identity         ports.IdentityRepository
 	PasswordReset    ports.PasswordResetRepository
 	RBAC             ports.RoleRepository
@@ -51,6 +52,12 @@ type Repositories struct {
 	Stack            ports.StackRepository
 	Storage          ports.StorageRepository
 	Database         ports.DatabaseRepository
+	DatabaseBackup   ports.DatabaseBackupRepository
+	BackupPolicy     ports.DatabaseBackupPolicyRepository
+	AutomatedBackup  ports.DatabaseAutomatedBackupRepository
+	DatabaseUpgrade  ports.DatabaseUpgradeRepository
+	DatabaseUser     ports.DatabaseUserRepository
+	DatabaseInsight  ports.DatabaseInsightRepository
 	Secret           ports.SecretRepository
 	Function         ports.FunctionRepository
 	FunctionSchedule ports.FunctionScheduleRepository
@@ -81,6 +88,17 @@ type Repositories struct {
 	RouteTable       ports.RouteTableRepository
 	IGW              ports.IGWRepository
 	NATGateway       ports.NATGatewayRepository
+	ResourcePolicy   ports.ResourcePolicyRepository
+	Organization     ports.OrganizationRepository
+	Quota            ports.QuotaRepository
+	FlowLog          ports.FlowLogRepository
+	NetworkACL       ports.NetworkACLRepository
+	VPN              ports.VPNRepository
+	TransitGateway   ports.TransitGatewayRepository
+	VPCResolver      ports.VPCResolverRepository
+	DHCPOptions      ports.DHCPOptionsRepository
+	InstanceMetadata ports.InstanceMetadataRepository
+	NetworkInterface ports.NetworkInterfaceRepository
 }
 
 // InitRepositories constructs repositories using the provided database clients.
@@ -89,6 +107,7 @@ func InitRepositories(db postgres.DB, rdb *redisv9.Client) *Repositories {
 		Audit:            postgres.NewAuditRepository(db),
 		User:             postgres.NewUserRepo(db),
 		Tenant:           postgres.NewTenantRepo(db),
+		TenantInvitation: postgres.NewTenantInvitationRepo(db),
 		Identity:         postgres.NewIdentityRepository(db),
 		PasswordReset:    postgres.NewPasswordResetRepository(db),
 		RBAC:             postgres.NewRBACRepository(db),
@@ -103,6 +122,12 @@ func InitRepositories(db postgres.DB, rdb *redisv9.Client) *Repositories {
 		Stack:            postgres.NewStackRepository(db),
 		Storage:          postgres.NewStorageRepository(db),
 		Database:         postgres.NewDatabaseRepository(db),
+		DatabaseBackup:   postgres.NewDatabaseBackupRepository(db),
+		BackupPolicy:     postgres.NewDatabaseBackupPolicyRepository(db),
+		AutomatedBackup:  postgres.NewDatabaseAutomatedBackupRepository(db),
+		DatabaseUpgrade:  postgres.NewDatabaseUpgradeRepository(db),
+		DatabaseUser:     postgres.NewDatabaseUserRepository(db),
+		DatabaseInsight:  postgres.NewDatabaseInsightRepository(db),
 		Secret:           postgres.NewSecretRepository(db),
 		Function:         postgres.NewFunctionRepository(db),
 		FunctionSchedule: postgres.NewPostgresFunctionScheduleRepository(db),
@@ -132,6 +157,17 @@ func InitRepositories(db postgres.DB, rdb *redisv9.Client) *Repositories {
 		RouteTable:       postgres.NewRouteTableRepository(db),
 		IGW:              postgres.NewIGWRepository(db),
 		NATGateway:       postgres.NewNATGatewayRepository(db),
+		ResourcePolicy:   postgres.NewResourcePolicyRepository(db),
+		Organization:     postgres.NewOrganizationRepo(db),
+		Quota:            postgres.NewQuotaRepo(db),
+		FlowLog:          postgres.NewFlowLogRepository(db),
+		NetworkACL:       postgres.NewNetworkACLRepository(db),
+		VPN:              postgres.NewVPNRepository(db),
+		TransitGateway:   postgres.NewTransitGatewayRepository(db),
+		VPCResolver:      postgres.NewVPCResolverRepository(db),
+		DHCPOptions:      postgres.NewDHCPOptionsRepository(db),
+		InstanceMetadata: postgres.NewInstanceMetadataRepository(db),
+		NetworkInterface: postgres.NewNetworkInterfaceRepository(db),
 	}
 }
 
@@ -183,6 +219,21 @@ type Services struct {
 	RouteTable       *services.RouteTableService
 	InternetGateway  *services.InternetGatewayService
 	NATGateway       *services.NATGatewayService
+	ResourcePolicy   ports.ResourcePolicyService
+	Organization     ports.OrganizationService
+	Quota            ports.QuotaService
+	SecretRotation   ports.SecretRotationService
+	FlowReconciler   ports.FlowReconcilerService
+	FlowLog          ports.FlowLogService
+	NetworkACL       ports.NetworkACLService
+	VPN              ports.VPNService
+	TransitGateway   ports.TransitGatewayService
+	VPCResolver      ports.VPCResolverService
+	DHCPOptions      ports.DHCPOptionsService
+	InstanceMetadata ports.InstanceMetadataService
+	NetworkInterface ports.NetworkInterfaceService
+	// MetadataServer serves InstanceMetadata; nil when METADATA_ADDR is unset.
+	MetadataServer *MetadataServer
 }
 
 // Shutdown cleanly stops all services.
@@ -213,7 +264,17 @@ type Workers struct {
 	ClusterReconciler Runner
 	Healing           Runner
 	DatabaseFailover  Runner
+	DatabaseBackup    Runner
+	ScheduledBackup   Runner
+	DBMaintenance     Runner
+	DBInsights        Runner
+	CacheFailover     Runner
+	Preemption        Runner
 	Log               Runner
+	QuotaReconciler   Runner
+	SecretRotation    Runner
+	FlowReconciler    Runner
+	FlowLog           Runner
 
 	// Parallel consumer workers (safe to run on multiple nodes)
 	Pipeline         *workers.PipelineWorker
@@ -242,7 +303,8 @@ func InitServices(c ServiceConfig) (*Services, *Workers, error) {
 	rbacSvc := initRBACServices(c)
 	auditSvc := services.NewAuditService(services.AuditServiceParams{Repo: c.Repos.Audit, RBACSvc: rbacSvc, Logger: c.Logger})
 	identitySvc := initIdentityServices(c, rbacSvc, auditSvc)
-	tenantSvc := services.NewTenantService(services.TenantServiceParams{Repo: c.Repos.Tenant, UserRepo: c.Repos.User, RBACSvc: rbacSvc, Logger: c.Logger})
+	tenantSvc := services.NewTenantService(services.TenantServiceParams{Repo: c.Repos.Tenant, QuotaRepo: c.Repos.Quota, InvitationRepo: c.Repos.TenantInvitation, UserRepo: c.Repos.User, RBACSvc: rbacSvc, AuditSvc: auditSvc, Logger: c.Logger})
+	quotaSvc := services.NewQuotaService(services.QuotaServiceParams{Repo: c.Repos.Quota, RBACSvc: rbacSvc, AuditSvc: auditSvc, Logger: c.Logger})
 	authSvc := services.NewAuthService(c.Repos.User, identitySvc, auditSvc, tenantSvc, c.DB, c.Logger)
 	pwdResetSvc := services.NewPasswordResetService(c.Repos.PasswordReset, c.Repos.User, c.Logger)
 
@@ -252,9 +314,9 @@ func InitServices(c ServiceConfig) (*Services, *Workers, error) {
 	eventSvc := services.NewEventService(services.EventServiceParams{Repo: c.Repos.Event, RBACSvc: rbacSvc, Publisher: wsHub, Logger: c.Logger})
 
 	// 3. Cloud Infrastructure Services (VPC, Subnet, Instance, Volume, SG, LB)
-	vpcSvc := services.NewVpcService(services.VpcServiceParams{Repo: c.Repos.Vpc, LBRepo: c.Repos.LB, PeeringRepo: c.Repos.VPCPeering, AsRepo: c.Repos.AutoScaling, RBACSvc: rbacSvc, Network: c.Network, AuditSvc: auditSvc, Logger: c.Logger, DefaultCIDR: c.Config.DefaultVPCCIDR, ComputeBackend: c.Config.ComputeBackend})
-	subnetSvc := services.NewSubnetService(services.SubnetServiceParams{Repo: c.Repos.Subnet, RBACSvc: rbacSvc, VpcRepo: c.Repos.Vpc, AuditSvc: auditSvc, Logger: c.Logger})
-	volumeSvc := services.NewVolumeService(services.VolumeServiceParams{Repo: c.Repos.Volume, RBACSvc: rbacSvc, Storage: c.Storage, EventSvc: eventSvc, AuditSvc: auditSvc, Logger: c.Logger})
+	vpcSvc := services.NewVpcService(services.VpcServiceParams{Repo: c.Repos.Vpc, LBRepo: c.Repos.LB, PeeringRepo: c.Repos.VPCPeering, NetworkACLRepo: c.Repos.NetworkACL, AsRepo: c.Repos.AutoScaling, RBACSvc: rbacSvc, Network: c.Network, AuditSvc: auditSvc, TenantSvc: tenantSvc, Logger: c.Logger, DefaultCIDR: c.Config.DefaultVPCCIDR, ComputeBackend: c.Config.ComputeBackend})
+	subnetSvc := services.NewSubnetService(services.SubnetServiceParams{Repo: c.Repos.Subnet, RBACSvc: rbacSvc, VpcRepo: c.Repos.Vpc, AuditSvc: auditSvc, Logger: c.Logger, ACLRepo: c.Repos.NetworkACL, Network: c.Network})
+	volumeSvc := services.NewVolumeService(services.VolumeServiceParams{Repo: c.Repos.Volume, RBACSvc: rbacSvc, Storage: c.Storage, EventSvc: eventSvc, AuditSvc: auditSvc, Logger: c.Logger, TenantSvc: tenantSvc})
 
 	// DNS Service
 	pdnsBackend, err := dnsadapter.NewPowerDNSBackend(c.Config.PowerDNSAPIURL, c.Config.PowerDNSAPIKey, c.Config.PowerDNSServerID, c.Logger)
@@ -263,9 +325,13 @@ func InitServices(c ServiceConfig) (*Services, *Workers, error) {
 	}
 	// Wrap DNS backend with resilience (circuit breaker + timeout).
 	resilientDNS := platform.NewResilientDNS(pdnsBackend, c.Logger, platform.ResilientDNSOpts{})
+	resolverSvc := services.NewVPCResolverService(services.VPCResolverServiceParams{
+		Repo: c.Repos.VPCResolver, VpcRepo: c.Repos.Vpc, DNSRepo: c.Repos.DNS, PeeringRepo: c.Repos.VPCPeering,
+		Compute: c.Compute, RBACSvc: rbacSvc, AuditSvc: auditSvc, AuthoritativeAddr: c.Config.PowerDNSResolverAddr, Logger: c.Logger,
+	})
 	dnsSvc := services.NewDNSService(services.DNSServiceParams{
 		Repo: c.Repos.DNS, RBAC: rbacSvc, Backend: resilientDNS, VpcRepo: c.Repos.Vpc,
-		AuditSvc: auditSvc, EventSvc: eventSvc, Logger: c.Logger,
+		AuditSvc: auditSvc, EventSvc: eventSvc, ResolverSvc: resolverSvc, Logger: c.Logger,
 	})
 
 	sshKeySvc, err := services.NewSSHKeyService(services.SSHKeyServiceParams{Repo: c.Repos.SSHKey, Logger: c.Logger, RBACSvc: rbacSvc})
@@ -275,10 +341,10 @@ func InitServices(c ServiceConfig) (*Services, *Workers, error) {
 
 	logSvc := services.NewCloudLogsService(c.Repos.Log, rbacSvc, c.Logger)
 
-	instSvcConcrete := services.NewInstanceService(services.InstanceServiceParams{Repo: c.Repos.Instance, VpcRepo: c.Repos.Vpc, SubnetRepo: c.Repos.Subnet, VolumeRepo: c.Repos.Volume, InstanceTypeRepo: c.Repos.InstanceType, RBAC: rbacSvc, Compute: c.Compute, Network: c.Network, EventSvc: eventSvc, AuditSvc: auditSvc, DNSSvc: dnsSvc, TaskQueue: c.Repos.DurableQueue, DockerNetwork: c.Config.DockerDefaultNetwork, Logger: c.Logger, TenantSvc: tenantSvc, SSHKeySvc: sshKeySvc, LogSvc: logSvc})
+	instSvcConcrete := services.NewInstanceService(services.InstanceServiceParams{Repo: c.Repos.Instance, VpcRepo: c.Repos.Vpc, SubnetRepo: c.Repos.Subnet, VolumeRepo: c.Repos.Volume, InstanceTypeRepo: c.Repos.InstanceType, RBAC: rbacSvc, Compute: c.Compute, Network: c.Network, EventSvc: eventSvc, AuditSvc: auditSvc, DNSSvc: dnsSvc, ResolverRepo: c.Repos.VPCResolver, DHCPRepo: c.Repos.DHCPOptions, MetadataRepo: c.Repos.InstanceMetadata, SARepo: c.Repos.ServiceAccount, ENIRepo: c.Repos.NetworkInterface, TaskQueue: c.Repos.DurableQueue, DockerNetwork: c.Config.DockerDefaultNetwork, Logger: c.Logger, TenantSvc: tenantSvc, SSHKeySvc: sshKeySvc, LogSvc: logSvc})
 	sgSvc := services.NewSecurityGroupService(c.Repos.SecurityGroup, rbacSvc, c.Repos.Vpc, c.Network, auditSvc, c.Logger)
 
-	lbSvc := services.NewLBService(c.Repos.LB, rbacSvc, c.Repos.Vpc, c.Repos.Instance, auditSvc, c.Logger)
+	lbSvc := services.NewLBService(c.Repos.LB, rbacSvc, c.Repos.Vpc, c.Repos.Instance, auditSvc, tenantSvc, c.Logger)
 	lbWorker := services.NewLBWorker(c.Repos.LB, c.Repos.Instance, c.LBProxy)
 
 	// Global LB Service
@@ -292,7 +358,7 @@ func InitServices(c ServiceConfig) (*Services, *Workers, error) {
 	}
 
 	// 4. Advanced Services (Storage, DB, Secrets, FaaS, Cache, Queue)
-	storageSvc, fileStore, err := initStorageServices(c, rbacSvc, auditSvc, encryptionSvc)
+	storageSvc, fileStore, err := initStorageServices(c, rbacSvc, auditSvc, encryptionSvc, tenantSvc)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -300,6 +366,7 @@ func InitServices(c ServiceConfig) (*Services, *Workers, error) {
 	snapshotSvc := services.NewSnapshotService(c.Repos.Snapshot, rbacSvc, c.Repos.Volume, c.Storage, eventSvc, auditSvc, c.Logger)
 
 	var secretsSvc ports.SecretsManager
+	var kmsClient ports.KMSClient
 	if c.Config.VaultToken == "" {
 		if c.Config.Environment == "production" || c.Config.Environment == "staging" {
 			return nil, nil, fmt.Errorf("VAULT_TOKEN is required in %s environment", c.Config.Environment)
@@ -317,16 +384,20 @@ func InitServices(c ServiceConfig) (*Services, *Workers, error) {
 			return nil, nil, fmt.Errorf("vault health check failed on startup: %w", err)
 		}
 		secretsSvc = vaultSvc
+		kmsClient = vaultSvc.TransitKMS()
 	}
 
-	databaseSvc := services.NewDatabaseService(services.DatabaseServiceParams{Repo: c.Repos.Database, RBAC: rbacSvc, Compute: c.Compute, VpcRepo: c.Repos.Vpc, VolumeSvc: volumeSvc, SnapshotSvc: snapshotSvc, SnapshotRepo: c.Repos.Snapshot, EventSvc: eventSvc, AuditSvc: auditSvc, Secrets: secretsSvc, VolumeEncryption: nil, Logger: c.Logger, VaultMountPath: c.Config.VaultMountPath})
+	databaseSvc := services.NewDatabaseService(services.DatabaseServiceParams{Repo: c.Repos.Database, RBAC: rbacSvc, Compute: c.Compute, VpcRepo: c.Repos.Vpc, VolumeSvc: volumeSvc, SnapshotSvc: snapshotSvc, SnapshotRepo: c.Repos.Snapshot, EventSvc: eventSvc, AuditSvc: auditSvc, Secrets: secretsSvc, VolumeEncryption: nil, TenantSvc: tenantSvc, StorageSvc: storageSvc, BackupRepo: c.Repos.DatabaseBackup, PolicyRepo: c.Repos.BackupPolicy, AutomatedRepo: c.Repos.AutomatedBackup, UpgradeRepo: c.Repos.DatabaseUpgrade, UserRepo: c.Repos.DatabaseUser, InsightRepo: c.Repos.DatabaseInsight, KMS: kmsClient, Logger: c.Logger, VaultMountPath: c.Config.VaultMountPath})
 	secretSvc, err := services.NewSecretService(services.SecretServiceParams{Repo: c.Repos.Secret, RBACSvc: rbacSvc, EventSvc: eventSvc, AuditSvc: auditSvc, Logger: c.Logger, MasterKey: c.Config.SecretsEncryptionKey, Environment: c.Config.Environment})
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to init secret service: %w", err)
 	}
-	fnSvc := services.NewFunctionService(c.Repos.Function, rbacSvc, c.Compute, fileStore, auditSvc, secretSvc, c.Logger)
+	fnSvc := services.NewFunctionService(c.Repos.Function, rbacSvc, c.Compute, fileStore, auditSvc, secretSvc, tenantSvc, c.Logger)
+	flowReconcilerSvc := services.NewFlowReconcilerService(services.FlowReconcilerServiceParams{VpcRepo: c.Repos.Vpc, SecurityGroupRepo: c.Repos.SecurityGroup, RouteTableRepo: c.Repos.RouteTable, NetworkACLRepo: c.Repos.NetworkACL, Network: c.Network, RBACSvc: rbacSvc, AuditSvc: auditSvc, Logger: c.Logger})
+	flowLogSvc := services.NewFlowLogService(services.FlowLogServiceParams{Repo: c.Repos.FlowLog, VpcRepo: c.Repos.Vpc, SubnetRepo: c.Repos.Subnet, InstanceRepo: c.Repos.Instance, Network: c.Network, LogSvc: logSvc, StorageSvc: storageSvc, RBACSvc: rbacSvc, AuditSvc: auditSvc, Logger: c.Logger})
+	secretRotationSvc := services.NewSecretRotationService(services.SecretRotationServiceParams{Repo: c.Repos.Secret, SecretSvc: secretSvc, FunctionSvc: fnSvc, RBACSvc: rbacSvc, AuditSvc: auditSvc, Logger: c.Logger})
 	fnSchedSvc := services.NewFunctionScheduleService(c.Repos.FunctionSchedule, c.Repos.Function, rbacSvc, eventSvc, auditSvc, c.Logger)
-	cacheSvc := services.NewCacheService(c.Repos.Cache, rbacSvc, c.Compute, c.Repos.Vpc, eventSvc, auditSvc, c.Logger)
+	cacheSvc := services.NewCacheService(services.CacheServiceParams{Repo: c.Repos.Cache, RBAC: rbacSvc, Compute: c.Compute, VpcRepo: c.Repos.Vpc, VolumeSvc: volumeSvc, EventSvc: eventSvc, AuditSvc: auditSvc, TenantSvc: tenantSvc, Logger: c.Logger})
 	queueSvc := services.NewQueueService(c.Repos.Queue, rbacSvc, eventSvc, auditSvc, c.Logger)
 	pipelineSvc := services.NewPipelineService(c.Repos.Pipeline, c.Repos.DurableQueue, eventSvc, auditSvc, c.Logger)
 	notifySvc := services.NewNotifyService(services.NotifyServiceParams{Repo: c.Repos.Notify, RBACSvc: rbacSvc, QueueSvc: queueSvc, EventSvc: eventSvc, AuditSvc: auditSvc, Logger: c.Logger})
@@ -342,19 +413,23 @@ func InitServices(c ServiceConfig) (*Services, *Workers, error) {
 	// 6. Business & Scaling Services
 	asgSvc := services.NewAutoScalingService(c.Repos.AutoScaling, rbacSvc, c.Repos.Vpc, auditSvc, c.Logger)
 	asgWorker := services.NewAutoScalingWorker(c.Repos.AutoScaling, instSvcConcrete, lbSvc, eventSvc, ports.RealClock{})
-	accountingSvc := services.NewAccountingService(c.Repos.Accounting, rbacSvc, c.Repos.Instance, c.Logger)
+	accountingSvc := services.NewAccountingService(c.Repos.Accounting, rbacSvc, c.Repos.Instance, c.Repos.Organization, c.Logger)
 	accountingWorker := workers.NewAccountingWorker(accountingSvc, c.Logger)
 	imageSvc := services.NewImageService(services.ImageServiceParams{Repo: c.Repos.Image, RBACSvc: rbacSvc, FileStore: fileStore, Logger: c.Logger})
 	iamSvc := services.NewIAMService(c.Repos.IAM, auditSvc, eventSvc, c.Logger)
 	provisionWorker := workers.NewProvisionWorker(instSvcConcrete, c.Repos.DurableQueue, c.Repos.Ledger, c.Logger)
 	healingWorker := workers.NewHealingWorker(instSvcConcrete, c.Repos.Instance, c.Logger)
 
-	clusterSvc, clusterProvisioner, err := initClusterServices(c, rbacSvc, vpcSvc, instSvcConcrete, secretSvc, storageSvc, lbSvc, sgSvc)
+	clusterSvc, clusterProvisioner, err := initClusterServices(c, rbacSvc, vpcSvc, instSvcConcrete, secretSvc, storageSvc, lbSvc, sgSvc, tenantSvc)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	svcs := &Services{WsHub: wsHub, Audit: auditSvc, Identity: identitySvc, Tenant: tenantSvc, Auth: authSvc, PasswordReset: pwdResetSvc, RBAC: rbacSvc, Vpc: vpcSvc, Subnet: subnetSvc, Event: eventSvc, Volume: volumeSvc, Instance: instSvcConcrete, SecurityGroup: sgSvc, LB: lbSvc, Snapshot: snapshotSvc, Stack: stackSvc, Storage: storageSvc, Database: databaseSvc, Secret: secretSvc, Function: fnSvc, FunctionSchedule: fnSchedSvc, Cache: cacheSvc, Queue: queueSvc, Notify: notifySvc, Cron: cronSvc, Gateway: gwSvc, Container: containerSvc, Pipeline: pipelineSvc, Health: services.NewHealthServiceImpl(c.DB, c.Compute, clusterSvc), AutoScaling: asgSvc, Accounting: accountingSvc, Image: imageSvc, Cluster: clusterSvc, Dashboard: services.NewDashboardService(rbacSvc, c.Repos.Instance, c.Repos.Volume, c.Repos.Vpc, c.Repos.Event, c.Logger), Lifecycle: services.NewLifecycleService(c.Repos.Lifecycle, rbacSvc, c.Repos.Storage), InstanceType: services.NewInstanceTypeService(c.Repos.InstanceType, rbacSvc), GlobalLB: glbSvc, DNS: dnsSvc, SSHKey: sshKeySvc, ElasticIP: services.NewElasticIPService(services.ElasticIPServiceParams{Repo: c.Repos.ElasticIP, RBAC: rbacSvc, InstanceRepo: c.Repos.Instance, AuditSvc: auditSvc, Logger: c.Logger}), Log: logSvc, IAM: iamSvc, VPCPeering: services.NewVPCPeeringService(services.VPCPeeringServiceParams{Repo: c.Repos.VPCPeering, VpcRepo: c.Repos.Vpc, Network: c.Network, AuditSvc: auditSvc, Logger: c.Logger}), RouteTable: services.NewRouteTableService(services.RouteTableServiceParams{Repo: c.Repos.RouteTable, VpcRepo: c.Repos.Vpc, RBACSvc: rbacSvc, Network: c.Network, AuditSvc: auditSvc, Logger: c.Logger}), InternetGateway: services.NewInternetGatewayService(services.InternetGatewayServiceParams{Repo: c.Repos.IGW, RTRepo: c.Repos.RouteTable, VpcRepo: c.Repos.Vpc, RBACSvc: rbacSvc, AuditSvc: auditSvc, Logger: c.Logger}), NATGateway: services.NewNATGatewayService(services.NATGatewayServiceParams{Repo: c.Repos.NATGateway, EIPRepo: c.Repos.ElasticIP, SubnetRepo: c.Repos.Subnet, VpcRepo: c.Repos.Vpc, RBACSvc: rbacSvc, Network: c.Network, AuditSvc: auditSvc, Logger: c.Logger})}
+	svcs := &Services{WsHub: wsHub, Audit: auditSvc, Identity: identitySvc, Tenant: tenantSvc, Auth: authSvc, PasswordReset: pwdResetSvc, RBAC: rbacSvc, Vpc: vpcSvc, Subnet: subnetSvc, Event: eventSvc, Volume: volumeSvc, Instance: instSvcConcrete, SecurityGroup: sgSvc, LB: lbSvc, Snapshot: snapshotSvc, Stack: stackSvc, Storage: storageSvc, Database: databaseSvc, Secret: secretSvc, Function: fnSvc, FunctionSchedule: fnSchedSvc, Cache: cacheSvc, Queue: queueSvc, Notify: notifySvc, Cron: cronSvc, Gateway: gwSvc, Container: containerSvc, Pipeline: pipelineSvc, Health: services.NewHealthServiceImpl(c.DB, c.Compute, clusterSvc), AutoScaling: asgSvc, Accounting: accountingSvc, Image: imageSvc, Cluster: clusterSvc, Dashboard: services.NewDashboardService(rbacSvc, c.Repos.Instance, c.Repos.Volume, c.Repos.Vpc, c.Repos.Event, c.Logger), Lifecycle: services.NewLifecycleService(c.Repos.Lifecycle, rbacSvc, c.Repos.Storage), InstanceType: services.NewInstanceTypeService(c.Repos.InstanceType, rbacSvc), GlobalLB: glbSvc, DNS: dnsSvc, SSHKey: sshKeySvc, ElasticIP: services.NewElasticIPService(services.ElasticIPServiceParams{Repo: c.Repos.ElasticIP, RBAC: rbacSvc, InstanceRepo: c.Repos.Instance, ENIRepo: c.Repos.NetworkInterface, AuditSvc: auditSvc, TenantSvc: tenantSvc, Logger: c.Logger}), Log: logSvc, IAM: iamSvc, ResourcePolicy: services.NewResourcePolicyService(services.ResourcePolicyServiceParams{Repo: c.Repos.ResourcePolicy, AuditSvc: auditSvc, Logger: c.Logger}), Organization: services.NewOrganizationService(services.OrganizationServiceParams{Repo: c.Repos.Organization, TenantRepo: c.Repos.Tenant, AuditSvc: auditSvc, Logger: c.Logger}), VPCPeering: services.NewVPCPeeringService(services.VPCPeeringServiceParams{Repo: c.Repos.VPCPeering, VpcRepo: c.Repos.Vpc, Network: c.Network, AuditSvc: auditSvc, ResolverSvc: resolverSvc, Logger: c.Logger}), RouteTable: services.NewRouteTableService(services.RouteTableServiceParams{Repo: c.Repos.RouteTable, VpcRepo: c.Repos.Vpc, RBACSvc: rbacSvc, Network: c.Network, AuditSvc: auditSvc, Logger: c.Logger}), InternetGateway: services.NewInternetGatewayService(services.InternetGatewayServiceParams{Repo: c.Repos.IGW, RTRepo: c.Repos.RouteTable, VpcRepo: c.Repos.Vpc, RBACSvc: rbacSvc, AuditSvc: auditSvc, Logger: c.Logger}), NATGateway: services.NewNATGatewayService(services.NATGatewayServiceParams{Repo: c.Repos.NATGateway, EIPRepo: c.Repos.ElasticIP, SubnetRepo: c.Repos.Subnet, VpcRepo: c.Repos.Vpc, RBACSvc: rbacSvc, Network: c.Network, AuditSvc: auditSvc, Logger: c.Logger}), Quota: quotaSvc, SecretRotation: secretRotationSvc, FlowReconciler: flowReconcilerSvc, FlowLog: flowLogSvc, NetworkACL: services.NewNetworkACLService(services.NetworkACLServiceParams{Repo: c.Repos.NetworkACL, VpcRepo: c.Repos.Vpc, RBACSvc: rbacSvc, Network: c.Network, AuditSvc: auditSvc, Logger: c.Logger}), VPN: services.NewVPNService(services.VPNServiceParams{Repo: c.Repos.VPN, VpcRepo: c.Repos.Vpc, RTRepo: c.Repos.RouteTable, SecretSvc: secretSvc, Compute: c.Compute, Network: c.Network, RBACSvc: rbacSvc, AuditSvc: auditSvc, Logger: c.Logger}), TransitGateway: services.NewTransitGatewayService(services.TransitGatewayServiceParams{Repo: c.Repos.TransitGateway, VpcRepo: c.Repos.Vpc, RTRepo: c.Repos.RouteTable, Network: c.Network, RBACSvc: rbacSvc, AuditSvc: auditSvc, Logger: c.Logger}), VPCResolver: resolverSvc, DHCPOptions: services.NewDHCPOptionsService(services.DHCPOptionsServiceParams{Repo: c.Repos.DHCPOptions, VpcRepo: c.Repos.Vpc, RBACSvc: rbacSvc, AuditSvc: auditSvc, Logger: c.Logger}), InstanceMetadata: services.NewInstanceMetadataService(services.InstanceMetadataServiceParams{Repo: c.Repos.InstanceMetadata, InstanceRepo: c.Repos.Instance, SubnetRepo: c.Repos.Subnet, DHCPRepo: c.Repos.DHCPOptions, ResolverRepo: c.Repos.VPCResolver, IdentitySvc: identitySvc, Logger: c.Logger}), NetworkInterface: services.NewNetworkInterfaceService(services.NetworkInterfaceServiceParams{Repo: c.Repos.NetworkInterface, SubnetRepo: c.Repos.Subnet, VpcRepo: c.Repos.Vpc, InstanceRepo: c.Repos.Instance, EIPRepo: c.Repos.ElasticIP, SGRepo: c.Repos.SecurityGroup, SGSvc: sgSvc, Compute: c.Compute, Network: c.Network, RBACSvc: rbacSvc, AuditSvc: auditSvc, Logger: c.Logger})}
+
+	if c.Config.MetadataAddr != "" {
+		svcs.MetadataServer = NewMetadataServer(c.Config, c.Logger, svcs.InstanceMetadata, c.Repos.Vpc)
+	}
 
 	// 7. High Availability & Monitoring
 	replicaMonitor := initReplicaMonitor(c)
@@ -372,7 +447,21 @@ func InitServices(c ServiceConfig) (*Services, *Workers, error) {
 	lifecycleWorker := workers.NewLifecycleWorker(c.Repos.Lifecycle, storageSvc, c.Repos.Storage, c.Logger)
 	clusterReconciler := workers.NewClusterReconciler(c.Repos.Cluster, clusterProvisioner, c.Logger)
 	dbFailoverWorker := workers.NewDatabaseFailoverWorker(databaseSvc, c.Repos.Database, c.Compute, c.Logger)
+	dbBackupWorker := workers.NewDatabaseBackupWorker(databaseSvc, c.Logger)
+	scheduledBackupWorker := workers.NewDatabaseScheduledBackupWorker(databaseSvc, c.Logger)
+	dbMaintenanceWorker := workers.NewDatabaseMaintenanceWorker(databaseSvc, c.Logger)
+	dbInsightsWorker := workers.NewDatabaseInsightsWorker(databaseSvc, c.Logger)
+	cacheFailoverWorker := workers.NewCacheFailoverWorker(cacheSvc, c.Repos.Cache, c.Compute, c.Logger)
+	preemptionThreshold := c.Config.PreemptionMemoryThresholdPercent
+	if preemptionThreshold <= 0 {
+		preemptionThreshold = 90
+	}
+	preemptionWorker := workers.NewPreemptionWorker(instSvcConcrete, c.Repos.Instance, c.Compute, preemptionThreshold, c.Logger)
 	logWorker := workers.NewLogWorker(logSvc, c.Logger)
+	quotaReconciler := workers.NewQuotaReconcileWorker(quotaSvc, c.Logger)
+	secretRotationWorker := workers.NewSecretRotationWorker(secretRotationSvc, c.Logger)
+	flowReconcileWorker := workers.NewFlowReconcileWorker(flowReconcilerSvc, c.Logger)
+	flowLogWorker := workers.NewFlowLogWorker(flowLogSvc, c.Logger)
 
 	// For replicaMonitor, we must convert nil *ReplicaMonitor to nil Runner to avoid
 	// a non-nil interface wrapping a nil pointer.
@@ -393,7 +482,17 @@ func InitServices(c ServiceConfig) (*Services, *Workers, error) {
 		ClusterReconciler: guardSingleton("singleton:cluster-reconciler", clusterReconciler),
 		Healing:           guardSingleton("singleton:healing", healingWorker),
 		DatabaseFailover:  guardSingleton("singleton:db-failover", dbFailoverWorker),
+		DatabaseBackup:    guardSingleton("singleton:db-backup", dbBackupWorker),
+		ScheduledBackup:   guardSingleton("singleton:db-scheduled-backup", scheduledBackupWorker),
+		DBMaintenance:     guardSingleton("singleton:db-maintenance", dbMaintenanceWorker),
+		DBInsights:        guardSingleton("singleton:db-insights", dbInsightsWorker),
+		CacheFailover:     guardSingleton("singleton:cache-failover", cacheFailoverWorker),
+		Preemption:        guardSingleton("singleton:preemption", preemptionWorker),
 		Log:               guardSingleton("singleton:log", logWorker),
+		QuotaReconciler:   guardSingleton("singleton:quota-reconciler", quotaReconciler),
+		SecretRotation:    guardSingleton("singleton:secret-rotation", secretRotationWorker),
+		FlowReconciler:    guardSingleton("singleton:flow-reconciler", flowReconcileWorker),
+		FlowLog:           guardSingleton("singleton:flow-log", flowLogWorker),
 
 		// Parallel consumer workers — no leader election needed
 		Pipeline:         workers.NewPipelineWorker(c.Repos.Pipeline, c.Repos.DurableQueue, c.Repos.Ledger, c.Compute, c.Logger),
@@ -417,7 +516,7 @@ func initIdentityServices(c ServiceConfig, rbacSvc ports.RBACService, audit port
 func initRBACServices(c ServiceConfig) ports.RBACService {
 	iamRepo := c.Repos.IAM
 	evaluator := services.NewIAMEvaluator()
-	base := services.NewRBACService(services.RBACServiceParams{UserRepo: c.Repos.User, RoleRepo: c.Repos.RBAC, TenantRepo: c.Repos.Tenant, IAMRepo: iamRepo, Evaluator: evaluator, Logger: c.Logger, SARepo: c.Repos.ServiceAccount})
+	base := services.NewRBACService(services.RBACServiceParams{UserRepo: c.Repos.User, RoleRepo: c.Repos.RBAC, TenantRepo: c.Repos.Tenant, IAMRepo: iamRepo, Evaluator: evaluator, Logger: c.Logger, SARepo: c.Repos.ServiceAccount, ResourcePolicyRepo: c.Repos.ResourcePolicy, OrgRepo: c.Repos.Organization, Region: c.Config.Region})
 	return services.NewCachedRBACService(base, c.Repos.ServiceAccount, c.RDB, c.Logger)
 }
 
@@ -451,7 +550,7 @@ func buildStorageDialOpts(cfg *platform.Config) ([]grpc.DialOption, error) {
 	return []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, nil
 }
 
-func initStorageServices(c ServiceConfig, rbacSvc ports.RBACService, audit ports.AuditService, encryption ports.EncryptionService) (ports.StorageService, ports.FileStore, error) {
+func initStorageServices(c ServiceConfig, rbacSvc ports.RBACService, audit ports.AuditService, encryption ports.EncryptionService, tenantSvc ports.TenantService) (ports.StorageService, ports.FileStore, error) {
 	var fileStore ports.FileStore
 	var err error
 
@@ -497,15 +596,16 @@ func initStorageServices(c ServiceConfig, rbacSvc ports.RBACService, audit ports
 		AuditSvc:   audit,
 		EncryptSvc: encryption,
 		Config:     c.Config,
+		TenantSvc:  tenantSvc,
 		Logger:     c.Logger,
 	})
 	return storageSvc, fileStore, nil
 }
 
-func initClusterServices(c ServiceConfig, rbacSvc ports.RBACService, vpcSvc ports.VpcService, instSvc ports.InstanceService, secretSvc ports.SecretService, storageSvc ports.StorageService, lbSvc ports.LBService, sgSvc ports.SecurityGroupService) (ports.ClusterService, ports.ClusterProvisioner, error) {
+func initClusterServices(c ServiceConfig, rbacSvc ports.RBACService, vpcSvc ports.VpcService, instSvc ports.InstanceService, secretSvc ports.SecretService, storageSvc ports.StorageService, lbSvc ports.LBService, sgSvc ports.SecurityGroupService, tenantSvc ports.TenantService) (ports.ClusterService, ports.ClusterProvisioner, error) {
 	clusterProvisioner := k8s.NewKubeadmProvisioner(instSvc, c.Repos.Cluster, secretSvc, sgSvc, storageSvc, lbSvc, c.Logger)
 	clusterSvc, err := services.NewClusterService(services.ClusterServiceParams{
-		Repo: c.Repos.Cluster, RBAC: rbacSvc, Provisioner: clusterProvisioner, VpcSvc: vpcSvc, InstanceSvc: instSvc, SecretSvc: secretSvc, TaskQueue: c.Repos.DurableQueue, Logger: c.Logger,
+		Repo: c.Repos.Cluster, RBAC: rbacSvc, Provisioner: clusterProvisioner, VpcSvc: vpcSvc, InstanceSvc: instSvc, SecretSvc: secretSvc, TaskQueue: c.Repos.DurableQueue, TenantSvc: tenantSvc, Logger: c.Logger,
 	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to init cluster service: %w", err)
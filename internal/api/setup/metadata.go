@@ -0,0 +1,142 @@
+package setup
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/platform"
+)
+
+const (
+	defaultMetadataReconcileInterval = 30 * time.Second
+	metadataShutdownTimeout          = 5 * time.Second
+	metadataReadHeaderTimeout        = 10 * time.Second
+)
+
+// MetadataServer runs the listeners of the instance metadata service: one on
+// MetadataAddr for instances outside any VPC and one per VPC bound to the VPC's
+// bridge. Private addresses are only unique within a VPC, so the listener that
+// accepts a request is what tells which VPC the caller is in.
+type MetadataServer struct {
+	cfg    *platform.Config
+	logger *slog.Logger
+	svc    ports.InstanceMetadataService
+	vpcs   ports.VpcRepository
+
+	// listen opens a listener on addr bound to a network device ("" for none).
+	listen   func(ctx context.Context, device, addr string) (net.Listener, error)
+	interval time.Duration
+	servers  map[uuid.UUID]*http.Server
+}
+
+// NewMetadataServer constructs a MetadataServer serving svc for the VPCs in vpcs.
+func NewMetadataServer(cfg *platform.Config, logger *slog.Logger, svc ports.InstanceMetadataService, vpcs ports.VpcRepository) *MetadataServer {
+	return &MetadataServer{
+		cfg:      cfg,
+		logger:   logger.With("component", "metadata"),
+		svc:      svc,
+		vpcs:     vpcs,
+		listen:   listenOnDevice,
+		interval: defaultMetadataReconcileInterval,
+		servers:  make(map[uuid.UUID]*http.Server),
+	}
+}
+
+// Run serves the metadata listeners until ctx is cancelled. VPC listeners are
+// started and stopped as VPCs come and go.
+func (m *MetadataServer) Run(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	shared, err := m.serve(ctx, m.cfg.MetadataInterface, nil)
+	if err != nil {
+		m.logger.Error("failed to start instance metadata service", "addr", m.cfg.MetadataAddr, "error", err)
+	} else {
+		m.logger.Info("starting instance metadata service", "addr", m.cfg.MetadataAddr, "interface", m.cfg.MetadataInterface)
+	}
+
+	m.reconcile(ctx)
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), metadataShutdownTimeout)
+			defer cancel()
+			if shared != nil {
+				_ = shared.Shutdown(shutdownCtx)
+			}
+			for id, srv := range m.servers {
+				_ = srv.Shutdown(shutdownCtx)
+				delete(m.servers, id)
+			}
+			m.logger.Info("stopped instance metadata service")
+			return
+		case <-ticker.C:
+			m.reconcile(ctx)
+		}
+	}
+}
+
+// reconcile starts a listener on the bridge of every VPC that has none yet and
+// stops those of deleted VPCs. A bridge that cannot be bound is retried later.
+func (m *MetadataServer) reconcile(ctx context.Context) {
+	vpcs, err := m.vpcs.ListAll(ctx)
+	if err != nil {
+		m.logger.Error("failed to list VPCs for metadata listeners", "error", err)
+		return
+	}
+
+	live := make(map[uuid.UUID]bool, len(vpcs))
+	for _, vpc := range vpcs {
+		if vpc.NetworkID == "" {
+			continue
+		}
+		live[vpc.ID] = true
+		if _, ok := m.servers[vpc.ID]; ok {
+			continue
+		}
+		vpcID := vpc.ID
+		srv, err := m.serve(ctx, vpc.NetworkID, &vpcID)
+		if err != nil {
+			m.logger.Warn("failed to start VPC metadata listener", "vpc_id", vpc.ID, "bridge", vpc.NetworkID, "error", err)
+			continue
+		}
+		m.servers[vpc.ID] = srv
+		m.logger.Info("started VPC metadata listener", "vpc_id", vpc.ID, "bridge", vpc.NetworkID)
+	}
+
+	for id, srv := range m.servers {
+		if live[id] {
+			continue
+		}
+		_ = srv.Close()
+		delete(m.servers, id)
+		m.logger.Info("stopped VPC metadata listener", "vpc_id", id)
+	}
+}
+
+// serve starts an HTTP server for the metadata router of vpcID on device.
+func (m *MetadataServer) serve(ctx context.Context, device string, vpcID *uuid.UUID) (*http.Server, error) {
+	l, err := m.listen(ctx, device, m.cfg.MetadataAddr)
+	if err != nil {
+		return nil, err
+	}
+	srv := &http.Server{
+		Handler:           SetupMetadataRouter(m.cfg, m.logger, m.svc, vpcID),
+		ReadHeaderTimeout: metadataReadHeaderTimeout,
+	}
+	go func() {
+		if err := srv.Serve(l); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			m.logger.Error("metadata listener failed", "device", device, "error", err)
+		}
+	}()
+	return srv, nil
+}
@@ -0,0 +1,28 @@
+//go:build linux
+
+package setup
+
+import (
+	"context"
+	"net"
+	"syscall"
+)
+
+// listenOnDevice listens on addr, only accepting connections that arrive on
+// device when it is set. Replies leave through the same device, so VPCs with
+// overlapping address ranges can share the metadata address.
+func listenOnDevice(ctx context.Context, device, addr string) (net.Listener, error) {
+	var lc net.ListenConfig
+	if device != "" {
+		lc.Control = func(_, _ string, c syscall.RawConn) error {
+			var bindErr error
+			if err := c.Control(func(fd uintptr) {
+				bindErr = syscall.BindToDevice(int(fd), device)
+			}); err != nil {
+				return err
+			}
+			return bindErr
+		}
+	}
+	return lc.Listen(ctx, "tcp", addr)
+}
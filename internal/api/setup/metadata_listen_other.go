@@ -0,0 +1,18 @@
+//go:build !linux
+
+package setup
+
+import (
+	"context"
+	"fmt"
+	"net"
+)
+
+// listenOnDevice listens on addr. Binding to a device is only supported on Linux.
+func listenOnDevice(ctx context.Context, device, addr string) (net.Listener, error) {
+	if device != "" {
+		return nil, fmt.Errorf("binding the metadata listener to %s requires linux", device)
+	}
+	var lc net.ListenConfig
+	return lc.Listen(ctx, "tcp", addr)
+}
@@ -0,0 +1,88 @@
+package setup
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/platform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubVpcRepo struct {
+	ports.VpcRepository
+	vpcs []*domain.VPC
+}
+
+func (r *stubVpcRepo) ListAll(context.Context) ([]*domain.VPC, error) { return r.vpcs, nil }
+
+// recordingMetadataService answers with the ID of a fixed instance and records callers.
+type recordingMetadataService struct {
+	mu      sync.Mutex
+	callers []domain.MetadataCaller
+}
+
+func (s *recordingMetadataService) GetMetadata(_ context.Context, caller domain.MetadataCaller) (*domain.InstanceMetadata, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.callers = append(s.callers, caller)
+	return &domain.InstanceMetadata{InstanceID: uuid.Nil}, nil
+}
+
+func (s *recordingMetadataService) GetCredentials(context.Context, domain.MetadataCaller) (*domain.InstanceCredentials, error) {
+	return nil, nil
+}
+
+func TestMetadataServerServesEachVPCOnItsBridge(t *testing.T) {
+	vpcA := &domain.VPC{ID: uuid.New(), NetworkID: "br-vpc-a"}
+	vpcB := &domain.VPC{ID: uuid.New(), NetworkID: "br-vpc-b"}
+	vpcs := &stubVpcRepo{vpcs: []*domain.VPC{vpcA, vpcB, {ID: uuid.New()}}}
+	svc := &recordingMetadataService{}
+
+	m := NewMetadataServer(&platform.Config{MetadataAddr: "169.254.169.254:80"}, slog.New(slog.NewTextHandler(io.Discard, nil)), svc, vpcs)
+	bound := map[string]string{}
+	m.listen = func(ctx context.Context, device, addr string) (net.Listener, error) {
+		assert.Equal(t, "169.254.169.254:80", addr)
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		if err == nil {
+			bound[device] = l.Addr().String()
+		}
+		return l, err
+	}
+	ctx := context.Background()
+
+	m.reconcile(ctx)
+	require.Len(t, m.servers, 2)
+	require.Contains(t, bound, "br-vpc-a")
+	require.Contains(t, bound, "br-vpc-b")
+
+	req, err := http.NewRequest(http.MethodGet, "http://"+bound["br-vpc-b"]+"/latest/meta-data/instance-id", nil)
+	require.NoError(t, err)
+	req.Header.Set("Metadata-Flavor", "thecloud")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Len(t, svc.callers, 1)
+	assert.Equal(t, domain.MetadataCaller{VPCID: &vpcB.ID, IP: "127.0.0.1"}, svc.callers[0])
+
+	// Listeners of deleted VPCs are stopped; running ones are kept.
+	vpcs.vpcs = []*domain.VPC{vpcA}
+	m.reconcile(ctx)
+	assert.Len(t, m.servers, 1)
+	assert.Contains(t, m.servers, vpcA.ID)
+	_, err = http.DefaultClient.Do(req)
+	assert.Error(t, err)
+
+	for _, srv := range m.servers {
+		_ = srv.Close()
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/gin-contrib/pprof"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	_ "github.com/poyrazk/thecloud/docs/swagger"
 	"github.com/poyrazk/thecloud/internal/core/domain"
 	"github.com/poyrazk/thecloud/internal/core/ports"
@@ -28,6 +29,8 @@ const (
 	roleIDRoute    = "/roles/:id"
 	recordIDRoute  = "/records/:id"
 	policyIDRoute  = "/policies/:id"
+
+	resourcePolicyRoute = "/resource-policies/:type/:id"
 )
 
 // Handlers bundles HTTP handlers used by the router.
@@ -76,6 +79,17 @@ type Handlers struct {
 	RouteTable    *httphandlers.RouteTableHandler
 	InternetGateway *httphandlers.InternetGatewayHandler
 	NATGateway    *httphandlers.NATGatewayHandler
+	ResourcePolicy *httphandlers.ResourcePolicyHandler
+	Organization   *httphandlers.OrganizationHandler
+	Quota          *httphandlers.QuotaHandler
+	FlowReconcile  *httphandlers.FlowReconcileHandler
+	FlowLog        *httphandlers.FlowLogHandler
+	NetworkACL     *httphandlers.NetworkACLHandler
+	VPN            *httphandlers.VPNHandler
+	TransitGateway *httphandlers.TransitGatewayHandler
+	VPCResolver    *httphandlers.VPCResolverHandler
+	DHCPOptions    *httphandlers.DHCPOptionsHandler
+	NetworkInterface *httphandlers.NetworkInterfaceHandler
 	Ws            *ws.Handler
 	Admin         *httphandlers.AdminHandler
 }
@@ -100,7 +114,7 @@ func InitHandlers(svcs *Services, cfg *platform.Config, logger *slog.Logger) *Ha
 		Stack:         httphandlers.NewStackHandler(svcs.Stack),
 		Storage:       httphandlers.NewStorageHandler(svcs.Storage, cfg),
 		Database:      httphandlers.NewDatabaseHandler(svcs.Database),
-		Secret:        httphandlers.NewSecretHandler(svcs.Secret),
+		Secret:        httphandlers.NewSecretHandler(svcs.Secret, svcs.SecretRotation),
 		Function:      httphandlers.NewFunctionHandler(svcs.Function),
 		FunctionSchedule: httphandlers.NewFunctionScheduleHandler(svcs.FunctionSchedule),
 		Cache:         httphandlers.NewCacheHandler(svcs.Cache),
@@ -128,6 +142,17 @@ func InitHandlers(svcs *Services, cfg *platform.Config, logger *slog.Logger) *Ha
 		RouteTable:    httphandlers.NewRouteTableHandler(svcs.RouteTable),
 		InternetGateway: httphandlers.NewInternetGatewayHandler(svcs.InternetGateway),
 		NATGateway:    httphandlers.NewNATGatewayHandler(svcs.NATGateway),
+		ResourcePolicy: httphandlers.NewResourcePolicyHandler(svcs.ResourcePolicy),
+		Organization:   httphandlers.NewOrganizationHandler(svcs.Organization),
+		Quota:          httphandlers.NewQuotaHandler(svcs.Quota),
+		FlowReconcile:  httphandlers.NewFlowReconcileHandler(svcs.FlowReconciler),
+		FlowLog:        httphandlers.NewFlowLogHandler(svcs.FlowLog),
+		NetworkACL:     httphandlers.NewNetworkACLHandler(svcs.NetworkACL),
+		VPN:            httphandlers.NewVPNHandler(svcs.VPN),
+		TransitGateway: httphandlers.NewTransitGatewayHandler(svcs.TransitGateway),
+		VPCResolver:    httphandlers.NewVPCResolverHandler(svcs.VPCResolver),
+		DHCPOptions:    httphandlers.NewDHCPOptionsHandler(svcs.DHCPOptions),
+		NetworkInterface: httphandlers.NewNetworkInterfaceHandler(svcs.NetworkInterface),
 		Ws:            ws.NewHandler(svcs.WsHub, svcs.Identity, logger, cfg.WSAllowedOrigins),
 	}
 }
@@ -197,6 +222,9 @@ func SetupRouter(cfg *platform.Config, logger *slog.Logger, handlers *Handlers,
 	registerDataRoutes(r, handlers, services)
 	registerDevOpsRoutes(r, handlers, services)
 	registerTenantRoutes(r, handlers, services)
+	registerOrganizationRoutes(r, handlers, services)
+	registerQuotaRoutes(r, handlers, services)
+	registerNetworkAdminRoutes(r, handlers, services)
 	registerIAMRoutes(r, handlers, services)
 	registerAdminRoutes(r, handlers, services)
 	registerLogRoutes(r, handlers, services)
@@ -221,6 +249,42 @@ func SetupRouter(cfg *platform.Config, logger *slog.Logger, handlers *Handlers,
 		dns.DELETE(recordIDRoute, handlers.DNS.DeleteRecord)
 	}
 
+	resolvers := r.Group("/vpc-resolvers")
+	resolvers.Use(httputil.Auth(services.Identity, services.Tenant), httputil.RequireTenant())
+	{
+		resolvers.POST("", handlers.VPCResolver.Create)
+		resolvers.GET("", handlers.VPCResolver.List)
+		resolvers.GET("/:id", handlers.VPCResolver.Get)
+		resolvers.DELETE("/:id", handlers.VPCResolver.Delete)
+		resolvers.POST("/:id/rules", handlers.VPCResolver.CreateRule)
+		resolvers.GET("/:id/rules", handlers.VPCResolver.ListRules)
+		resolvers.DELETE("/:id/rules/:rule_id", handlers.VPCResolver.DeleteRule)
+	}
+
+	dhcpOptions := r.Group("/dhcp-options")
+	dhcpOptions.Use(httputil.Auth(services.Identity, services.Tenant), httputil.RequireTenant())
+	{
+		dhcpOptions.POST("", handlers.DHCPOptions.Create)
+		dhcpOptions.GET("", handlers.DHCPOptions.List)
+		dhcpOptions.GET("/:id", handlers.DHCPOptions.Get)
+		dhcpOptions.DELETE("/:id", handlers.DHCPOptions.Delete)
+	}
+
+	networkInterfaces := r.Group("/network-interfaces")
+	networkInterfaces.Use(httputil.Auth(services.Identity, services.Tenant), httputil.RequireTenant())
+	{
+		networkInterfaces.POST("", handlers.NetworkInterface.Create)
+		networkInterfaces.GET("", handlers.NetworkInterface.List)
+		networkInterfaces.GET("/:id", handlers.NetworkInterface.Get)
+		networkInterfaces.DELETE("/:id", handlers.NetworkInterface.Delete)
+		networkInterfaces.POST("/:id/assign-private-ips", handlers.NetworkInterface.AssignPrivateIPs)
+		networkInterfaces.POST("/:id/unassign-private-ips", handlers.NetworkInterface.UnassignPrivateIPs)
+		networkInterfaces.PUT("/:id/security-groups", handlers.NetworkInterface.SetSecurityGroups)
+		networkInterfaces.POST("/:id/attach", handlers.NetworkInterface.Attach)
+		networkInterfaces.POST("/:id/detach", handlers.NetworkInterface.Detach)
+		networkInterfaces.POST("/:id/move", handlers.NetworkInterface.Move)
+	}
+
 	return r
 }
 
@@ -251,6 +315,12 @@ func registerIAMRoutes(r *gin.Engine, handlers *Handlers, svcs *Services) {
 		iamGroup.POST("/service-accounts/:id/policies/:policyId", handlers.IAM.AttachPolicyToServiceAccount)
 		iamGroup.DELETE("/service-accounts/:id/policies/:policyId", handlers.IAM.DetachPolicyFromServiceAccount)
 		iamGroup.GET("/service-accounts/:id/policies", handlers.IAM.GetServiceAccountPolicies)
+
+		// Resource policies (buckets, queues, topics, secrets)
+		iamGroup.GET("/resource-policies", handlers.ResourcePolicy.List)
+		iamGroup.PUT(resourcePolicyRoute, handlers.ResourcePolicy.Put)
+		iamGroup.GET(resourcePolicyRoute, handlers.ResourcePolicy.Get)
+		iamGroup.DELETE(resourcePolicyRoute, handlers.ResourcePolicy.Delete)
 	}
 }
 
@@ -373,6 +443,9 @@ func registerNetworkRoutes(r *gin.Engine, handlers *Handlers, svcs *Services) {
 		vpcGroup.GET("/:id", httputil.Permission(svcs.RBAC, domain.PermissionVpcRead), handlers.Vpc.Get)
 		vpcGroup.PATCH("/:id", httputil.Permission(svcs.RBAC, domain.PermissionVpcUpdate), handlers.Vpc.Update)
 		vpcGroup.DELETE("/:id", httputil.Permission(svcs.RBAC, domain.PermissionVpcDelete), handlers.Vpc.Delete)
+		vpcGroup.POST("/:id/ipv6", httputil.Permission(svcs.RBAC, domain.PermissionVpcUpdate), handlers.Vpc.AssignIPv6)
+		vpcGroup.PUT("/:id/dhcp-options", httputil.Permission(svcs.RBAC, domain.PermissionVpcUpdate), handlers.DHCPOptions.Associate)
+		vpcGroup.DELETE("/:id/dhcp-options", httputil.Permission(svcs.RBAC, domain.PermissionVpcUpdate), handlers.DHCPOptions.Disassociate)
 
 		vpcGroup.POST("/:id/subnets", httputil.Permission(svcs.RBAC, domain.PermissionVpcUpdate), handlers.Subnet.Create)
 		vpcGroup.GET("/:id/subnets", httputil.Permission(svcs.RBAC, domain.PermissionVpcRead), handlers.Subnet.List)
@@ -383,6 +456,7 @@ func registerNetworkRoutes(r *gin.Engine, handlers *Handlers, svcs *Services) {
 	{
 		subnetGroup.GET("/:id", httputil.Permission(svcs.RBAC, domain.PermissionVpcRead), handlers.Subnet.Get)
 		subnetGroup.DELETE("/:id", httputil.Permission(svcs.RBAC, domain.PermissionVpcUpdate), handlers.Subnet.Delete)
+		subnetGroup.POST("/:id/ipv6", httputil.Permission(svcs.RBAC, domain.PermissionVpcUpdate), handlers.Subnet.AssignIPv6)
 	}
 
 	sgGroup := r.Group("/security-groups")
@@ -446,6 +520,20 @@ func registerNetworkRoutes(r *gin.Engine, handlers *Handlers, svcs *Services) {
 			rtGroup.POST("/:id/disassociate", httputil.Permission(svcs.RBAC, domain.PermissionVpcUpdate), handlers.RouteTable.DisassociateSubnet)
 		}
 
+		// Network ACLs
+		naclGroup := r.Group("/network-acls")
+		naclGroup.Use(httputil.Auth(svcs.Identity, svcs.Tenant), httputil.RequireTenant(), httputil.TenantMember(svcs.Tenant))
+		{
+			naclGroup.POST("", httputil.Permission(svcs.RBAC, domain.PermissionVpcUpdate), handlers.NetworkACL.Create)
+			naclGroup.GET("", httputil.Permission(svcs.RBAC, domain.PermissionVpcRead), handlers.NetworkACL.List)
+			naclGroup.GET("/:id", httputil.Permission(svcs.RBAC, domain.PermissionVpcRead), handlers.NetworkACL.Get)
+			naclGroup.DELETE("/:id", httputil.Permission(svcs.RBAC, domain.PermissionVpcDelete), handlers.NetworkACL.Delete)
+			naclGroup.POST("/:id/rules", httputil.Permission(svcs.RBAC, domain.PermissionVpcUpdate), handlers.NetworkACL.AddRule)
+			naclGroup.DELETE("/:id/rules/:rule_id", httputil.Permission(svcs.RBAC, domain.PermissionVpcUpdate), handlers.NetworkACL.RemoveRule)
+			naclGroup.POST("/:id/associate", httputil.Permission(svcs.RBAC, domain.PermissionVpcUpdate), handlers.NetworkACL.AssociateSubnet)
+			naclGroup.POST("/:id/disassociate", httputil.Permission(svcs.RBAC, domain.PermissionVpcUpdate), handlers.NetworkACL.DisassociateSubnet)
+		}
+
 		// Internet Gateways
 		igwGroup := r.Group("/internet-gateways")
 		igwGroup.Use(httputil.Auth(svcs.Identity, svcs.Tenant), httputil.RequireTenant(), httputil.TenantMember(svcs.Tenant))
@@ -467,6 +555,76 @@ func registerNetworkRoutes(r *gin.Engine, handlers *Handlers, svcs *Services) {
 			natGroup.GET("/:id", httputil.Permission(svcs.RBAC, domain.PermissionVpcRead), handlers.NATGateway.Get)
 			natGroup.DELETE("/:id", httputil.Permission(svcs.RBAC, domain.PermissionVpcDelete), handlers.NATGateway.Delete)
 		}
+
+		// Site-to-site VPN
+		vpnGroup := r.Group("/vpn-gateways")
+		vpnGroup.Use(httputil.Auth(svcs.Identity, svcs.Tenant), httputil.RequireTenant(), httputil.TenantMember(svcs.Tenant))
+		{
+			vpnGroup.POST("", httputil.Permission(svcs.RBAC, domain.PermissionVpcCreate), handlers.VPN.CreateGateway)
+			vpnGroup.GET("", httputil.Permission(svcs.RBAC, domain.PermissionVpcRead), handlers.VPN.ListGateways)
+			vpnGroup.GET("/:id", httputil.Permission(svcs.RBAC, domain.PermissionVpcRead), handlers.VPN.GetGateway)
+			vpnGroup.DELETE("/:id", httputil.Permission(svcs.RBAC, domain.PermissionVpcDelete), handlers.VPN.DeleteGateway)
+		}
+
+		cgwGroup := r.Group("/customer-gateways")
+		cgwGroup.Use(httputil.Auth(svcs.Identity, svcs.Tenant), httputil.RequireTenant(), httputil.TenantMember(svcs.Tenant))
+		{
+			cgwGroup.POST("", httputil.Permission(svcs.RBAC, domain.PermissionVpcCreate), handlers.VPN.CreateCustomerGateway)
+			cgwGroup.GET("", httputil.Permission(svcs.RBAC, domain.PermissionVpcRead), handlers.VPN.ListCustomerGateways)
+			cgwGroup.DELETE("/:id", httputil.Permission(svcs.RBAC, domain.PermissionVpcDelete), handlers.VPN.DeleteCustomerGateway)
+		}
+
+		vpnConnGroup := r.Group("/vpn-connections")
+		vpnConnGroup.Use(httputil.Auth(svcs.Identity, svcs.Tenant), httputil.RequireTenant(), httputil.TenantMember(svcs.Tenant))
+		{
+			vpnConnGroup.POST("", httputil.Permission(svcs.RBAC, domain.PermissionVpcUpdate), handlers.VPN.CreateConnection)
+			vpnConnGroup.GET("", httputil.Permission(svcs.RBAC, domain.PermissionVpcRead), handlers.VPN.ListConnections)
+			vpnConnGroup.GET("/:id", httputil.Permission(svcs.RBAC, domain.PermissionVpcRead), handlers.VPN.GetConnection)
+			vpnConnGroup.GET("/:id/config", httputil.Permission(svcs.RBAC, domain.PermissionVpcRead), handlers.VPN.GetConnectionConfig)
+			vpnConnGroup.DELETE("/:id", httputil.Permission(svcs.RBAC, domain.PermissionVpcUpdate), handlers.VPN.DeleteConnection)
+		}
+
+		// Transit Gateways
+		tgwGroup := r.Group("/transit-gateways")
+		tgwGroup.Use(httputil.Auth(svcs.Identity, svcs.Tenant), httputil.RequireTenant(), httputil.TenantMember(svcs.Tenant))
+		{
+			tgwGroup.POST("", httputil.Permission(svcs.RBAC, domain.PermissionVpcCreate), handlers.TransitGateway.Create)
+			tgwGroup.GET("", httputil.Permission(svcs.RBAC, domain.PermissionVpcRead), handlers.TransitGateway.List)
+			tgwGroup.GET("/:id", httputil.Permission(svcs.RBAC, domain.PermissionVpcRead), handlers.TransitGateway.Get)
+			tgwGroup.DELETE("/:id", httputil.Permission(svcs.RBAC, domain.PermissionVpcDelete), handlers.TransitGateway.Delete)
+			tgwGroup.POST("/:id/attachments", httputil.Permission(svcs.RBAC, domain.PermissionVpcUpdate), handlers.TransitGateway.AttachVPC)
+			tgwGroup.GET("/:id/attachments", httputil.Permission(svcs.RBAC, domain.PermissionVpcRead), handlers.TransitGateway.ListAttachments)
+			tgwGroup.POST("/:id/route-tables", httputil.Permission(svcs.RBAC, domain.PermissionVpcUpdate), handlers.TransitGateway.CreateRouteTable)
+			tgwGroup.GET("/:id/route-tables", httputil.Permission(svcs.RBAC, domain.PermissionVpcRead), handlers.TransitGateway.ListRouteTables)
+		}
+
+		tgwAttGroup := r.Group("/transit-gateway-attachments")
+		tgwAttGroup.Use(httputil.Auth(svcs.Identity, svcs.Tenant), httputil.RequireTenant(), httputil.TenantMember(svcs.Tenant))
+		{
+			tgwAttGroup.DELETE("/:id", httputil.Permission(svcs.RBAC, domain.PermissionVpcUpdate), handlers.TransitGateway.DetachVPC)
+		}
+
+		tgwRTGroup := r.Group("/transit-gateway-route-tables")
+		tgwRTGroup.Use(httputil.Auth(svcs.Identity, svcs.Tenant), httputil.RequireTenant(), httputil.TenantMember(svcs.Tenant))
+		{
+			tgwRTGroup.GET("/:id", httputil.Permission(svcs.RBAC, domain.PermissionVpcRead), handlers.TransitGateway.GetRouteTable)
+			tgwRTGroup.DELETE("/:id", httputil.Permission(svcs.RBAC, domain.PermissionVpcUpdate), handlers.TransitGateway.DeleteRouteTable)
+			tgwRTGroup.POST("/:id/routes", httputil.Permission(svcs.RBAC, domain.PermissionVpcUpdate), handlers.TransitGateway.AddRoute)
+			tgwRTGroup.DELETE("/:id/routes/:route_id", httputil.Permission(svcs.RBAC, domain.PermissionVpcUpdate), handlers.TransitGateway.RemoveRoute)
+			tgwRTGroup.POST("/:id/associations", httputil.Permission(svcs.RBAC, domain.PermissionVpcUpdate), handlers.TransitGateway.Associate)
+			tgwRTGroup.POST("/:id/propagations", httputil.Permission(svcs.RBAC, domain.PermissionVpcUpdate), handlers.TransitGateway.EnablePropagation)
+			tgwRTGroup.DELETE("/:id/propagations/:attachment_id", httputil.Permission(svcs.RBAC, domain.PermissionVpcUpdate), handlers.TransitGateway.DisablePropagation)
+		}
+
+		// Flow Logs
+		flowLogGroup := r.Group("/flow-logs")
+		flowLogGroup.Use(httputil.Auth(svcs.Identity, svcs.Tenant), httputil.RequireTenant(), httputil.TenantMember(svcs.Tenant))
+		{
+			flowLogGroup.POST("", httputil.Permission(svcs.RBAC, domain.PermissionFlowLogCreate), handlers.FlowLog.Create)
+			flowLogGroup.GET("", httputil.Permission(svcs.RBAC, domain.PermissionFlowLogRead), handlers.FlowLog.List)
+			flowLogGroup.GET("/:id", httputil.Permission(svcs.RBAC, domain.PermissionFlowLogRead), handlers.FlowLog.Get)
+			flowLogGroup.DELETE("/:id", httputil.Permission(svcs.RBAC, domain.PermissionFlowLogDelete), handlers.FlowLog.Delete)
+		}
 }
 
 func registerGlobalLBRoutes(r *gin.Engine, handlers *Handlers, svcs *Services) {
@@ -548,6 +706,22 @@ func registerDataRoutes(r *gin.Engine, handlers *Handlers, svcs *Services) {
 		dbGroup.POST("/:id/promote", httputil.Permission(svcs.RBAC, domain.PermissionDBUpdate), handlers.Database.Promote)
 		dbGroup.POST("/:id/snapshots", httputil.Permission(svcs.RBAC, domain.PermissionDBCreate), handlers.Database.CreateSnapshot)
 		dbGroup.GET("/:id/snapshots", httputil.Permission(svcs.RBAC, domain.PermissionDBRead), handlers.Database.ListSnapshots)
+		dbGroup.GET("/:id/backup-policy", httputil.Permission(svcs.RBAC, domain.PermissionDBRead), handlers.Database.GetBackupPolicy)
+		dbGroup.PUT("/:id/backup-policy", httputil.Permission(svcs.RBAC, domain.PermissionDBUpdate), handlers.Database.PutBackupPolicy)
+		dbGroup.DELETE("/:id/backup-policy", httputil.Permission(svcs.RBAC, domain.PermissionDBUpdate), handlers.Database.DeleteBackupPolicy)
+		dbGroup.GET("/:id/backups", httputil.Permission(svcs.RBAC, domain.PermissionDBRead), handlers.Database.ListBackups)
+		dbGroup.DELETE("/:id/backups/:backup_id", httputil.Permission(svcs.RBAC, domain.PermissionDBUpdate), handlers.Database.DeleteBackup)
+		dbGroup.POST("/:id/upgrade", httputil.Permission(svcs.RBAC, domain.PermissionDBUpdate), handlers.Database.Upgrade)
+		dbGroup.GET("/:id/upgrades", httputil.Permission(svcs.RBAC, domain.PermissionDBRead), handlers.Database.ListUpgrades)
+		dbGroup.GET("/:id/insights", httputil.Permission(svcs.RBAC, domain.PermissionDBRead), handlers.Database.GetInsights)
+		dbGroup.POST("/:id/logical-databases", httputil.Permission(svcs.RBAC, domain.PermissionDBUpdate), handlers.Database.CreateLogicalDatabase)
+		dbGroup.GET("/:id/logical-databases", httputil.Permission(svcs.RBAC, domain.PermissionDBRead), handlers.Database.ListLogicalDatabases)
+		dbGroup.DELETE("/:id/logical-databases/:name", httputil.Permission(svcs.RBAC, domain.PermissionDBUpdate), handlers.Database.DeleteLogicalDatabase)
+		dbGroup.POST("/:id/users", httputil.Permission(svcs.RBAC, domain.PermissionDBUpdate), handlers.Database.CreateUser)
+		dbGroup.GET("/:id/users", httputil.Permission(svcs.RBAC, domain.PermissionDBRead), handlers.Database.ListUsers)
+		dbGroup.PUT("/:id/users/:username/grants", httputil.Permission(svcs.RBAC, domain.PermissionDBUpdate), handlers.Database.SetUserGrants)
+		dbGroup.POST("/:id/users/:username/rotate-credentials", httputil.Permission(svcs.RBAC, domain.PermissionDBUpdate), handlers.Database.RotateUserCredentials)
+		dbGroup.DELETE("/:id/users/:username", httputil.Permission(svcs.RBAC, domain.PermissionDBUpdate), handlers.Database.DeleteUser)
 		dbGroup.POST("/:id/rotate-credentials", httputil.Permission(svcs.RBAC, domain.PermissionDBUpdate), handlers.Database.RotateCredentials)
 		dbGroup.POST("/:id/stop", httputil.Permission(svcs.RBAC, domain.PermissionDBUpdate), handlers.Database.Stop)
 		dbGroup.POST("/:id/start", httputil.Permission(svcs.RBAC, domain.PermissionDBUpdate), handlers.Database.Start)
@@ -559,10 +733,13 @@ func registerDataRoutes(r *gin.Engine, handlers *Handlers, svcs *Services) {
 		cacheGroup.POST("", httputil.Permission(svcs.RBAC, domain.PermissionCacheCreate), handlers.Cache.Create)
 		cacheGroup.GET("", httputil.Permission(svcs.RBAC, domain.PermissionCacheRead), handlers.Cache.List)
 		cacheGroup.GET("/:id", httputil.Permission(svcs.RBAC, domain.PermissionCacheRead), handlers.Cache.Get)
+		cacheGroup.PATCH("/:id", httputil.Permission(svcs.RBAC, domain.PermissionCacheUpdate), handlers.Cache.Modify)
 		cacheGroup.DELETE("/:id", httputil.Permission(svcs.RBAC, domain.PermissionCacheDelete), handlers.Cache.Delete)
 		cacheGroup.GET("/:id/connection", httputil.Permission(svcs.RBAC, domain.PermissionCacheRead), handlers.Cache.GetConnectionString)
 		cacheGroup.POST("/:id/flush", httputil.Permission(svcs.RBAC, domain.PermissionCacheUpdate), handlers.Cache.Flush)
 		cacheGroup.GET("/:id/stats", httputil.Permission(svcs.RBAC, domain.PermissionCacheRead), handlers.Cache.GetStats)
+		cacheGroup.POST("/:id/failover", httputil.Permission(svcs.RBAC, domain.PermissionCacheUpdate), handlers.Cache.Failover)
+		cacheGroup.POST("/:id/rotate-credentials", httputil.Permission(svcs.RBAC, domain.PermissionCacheUpdate), handlers.Cache.RotateCredentials)
 	}
 
 	secretGroup := r.Group("/secrets")
@@ -572,6 +749,13 @@ func registerDataRoutes(r *gin.Engine, handlers *Handlers, svcs *Services) {
 		secretGroup.GET("", httputil.Permission(svcs.RBAC, domain.PermissionSecretRead), handlers.Secret.List)
 		secretGroup.GET("/:id", httputil.Permission(svcs.RBAC, domain.PermissionSecretRead), handlers.Secret.Get)
 		secretGroup.DELETE("/:id", httputil.Permission(svcs.RBAC, domain.PermissionSecretDelete), handlers.Secret.Delete)
+		secretGroup.PUT("/:id/value", httputil.Permission(svcs.RBAC, domain.PermissionSecretWrite), handlers.Secret.PutValue)
+		secretGroup.GET("/:id/versions", httputil.Permission(svcs.RBAC, domain.PermissionSecretRead), handlers.Secret.ListVersions)
+		secretGroup.GET("/:id/versions/:version", httputil.Permission(svcs.RBAC, domain.PermissionSecretRead), handlers.Secret.GetVersion)
+		secretGroup.PUT("/:id/stages/:stage", httputil.Permission(svcs.RBAC, domain.PermissionSecretWrite), handlers.Secret.MoveStage)
+		secretGroup.PUT("/:id/rotation", httputil.Permission(svcs.RBAC, domain.PermissionSecretWrite), handlers.Secret.ConfigureRotation)
+		secretGroup.DELETE("/:id/rotation", httputil.Permission(svcs.RBAC, domain.PermissionSecretWrite), handlers.Secret.DisableRotation)
+		secretGroup.POST("/:id/rotate", httputil.Permission(svcs.RBAC, domain.PermissionSecretWrite), handlers.Secret.Rotate)
 	}
 }
 
@@ -737,6 +921,7 @@ func registerAdminRoutes(r *gin.Engine, handlers *Handlers, svcs *Services) {
 	{
 		billingGroup.GET("/summary", handlers.Accounting.GetSummary)
 		billingGroup.GET("/usage", handlers.Accounting.ListUsage)
+		billingGroup.GET("/organizations/:id/summary", handlers.Accounting.GetOrganizationSummary)
 	}
 
 	// Internal admin endpoints (E2E test support)
@@ -755,6 +940,56 @@ func registerLogRoutes(r *gin.Engine, handlers *Handlers, svcs *Services) {
 	}
 }
 
+// Organizations sit above tenants, so their routes are not scoped to the caller's tenant.
+func registerOrganizationRoutes(r *gin.Engine, handlers *Handlers, svcs *Services) {
+	orgGroup := r.Group("/organizations")
+	orgGroup.Use(httputil.Auth(svcs.Identity, svcs.Tenant))
+	{
+		orgGroup.GET("", handlers.Organization.List)
+		orgGroup.POST("", handlers.Organization.Create)
+		orgGroup.GET("/:id", handlers.Organization.Get)
+		orgGroup.DELETE("/:id", handlers.Organization.Delete)
+		orgGroup.GET("/:id/tenants", handlers.Organization.ListTenants)
+		orgGroup.POST("/:id/tenants", handlers.Organization.AddTenant)
+		orgGroup.DELETE("/:id/tenants/:tenantId", handlers.Organization.RemoveTenant)
+		orgGroup.GET("/:id/guardrails", handlers.Organization.ListGuardrails)
+		orgGroup.POST("/:id/guardrails", handlers.Organization.CreateGuardrail)
+		orgGroup.DELETE("/:id/guardrails/:guardrailId", handlers.Organization.DeleteGuardrail)
+	}
+}
+
+// Admin quota routes are checked against the platform-wide quota:manage permission
+// in the service, so they are not scoped to the caller's tenant.
+func registerQuotaRoutes(r *gin.Engine, handlers *Handlers, svcs *Services) {
+	quotaGroup := r.Group("/quotas")
+	quotaGroup.Use(httputil.Auth(svcs.Identity, svcs.Tenant), httputil.RequireTenant())
+	{
+		quotaGroup.GET("", handlers.Quota.List)
+		quotaGroup.POST("/requests", handlers.Quota.RequestIncrease)
+		quotaGroup.GET("/requests", handlers.Quota.ListRequests)
+	}
+
+	adminGroup := r.Group("/admin/quotas")
+	adminGroup.Use(httputil.Auth(svcs.Identity, svcs.Tenant))
+	{
+		adminGroup.GET("/tenants/:tenantId", handlers.Quota.ListTenantQuotas)
+		adminGroup.PUT("/tenants/:tenantId/:resource", handlers.Quota.SetLimit)
+		adminGroup.GET("/requests", handlers.Quota.ListAllRequests)
+		adminGroup.POST("/requests/:id/approve", handlers.Quota.Approve)
+		adminGroup.POST("/requests/:id/deny", handlers.Quota.Deny)
+	}
+}
+
+// Flow routes are checked against the platform-wide network:manage permission in the service.
+func registerNetworkAdminRoutes(r *gin.Engine, handlers *Handlers, svcs *Services) {
+	adminGroup := r.Group("/admin/network")
+	adminGroup.Use(httputil.Auth(svcs.Identity, svcs.Tenant))
+	{
+		adminGroup.GET("/vpcs/:id/flows", handlers.FlowReconcile.Diff)
+		adminGroup.POST("/vpcs/:id/flows/reconcile", handlers.FlowReconcile.Reconcile)
+	}
+}
+
 func registerTenantRoutes(r *gin.Engine, handlers *Handlers, svcs *Services) {
 	tenantGroup := r.Group("/tenants")
 	tenantGroup.Use(httputil.Auth(svcs.Identity, svcs.Tenant))
@@ -762,6 +997,54 @@ func registerTenantRoutes(r *gin.Engine, handlers *Handlers, svcs *Services) {
 		tenantGroup.GET("", handlers.Tenant.List)
 		tenantGroup.POST("", handlers.Tenant.Create)
 		tenantGroup.POST("/:id/members", httputil.RequireTenant(), httputil.TenantMember(svcs.Tenant), handlers.Tenant.InviteMember)
+		tenantGroup.PUT("/:id/members/:userId", httputil.RequireTenant(), httputil.TenantMember(svcs.Tenant), handlers.Tenant.UpdateMemberRole)
+		tenantGroup.DELETE("/:id/members/:userId", httputil.RequireTenant(), httputil.TenantMember(svcs.Tenant), handlers.Tenant.RemoveMember)
+		tenantGroup.POST("/:id/transfer-ownership", httputil.RequireTenant(), httputil.TenantMember(svcs.Tenant), handlers.Tenant.TransferOwnership)
+		tenantGroup.GET("/:id/invitations", httputil.RequireTenant(), httputil.TenantMember(svcs.Tenant), handlers.Tenant.ListInvitations)
+		tenantGroup.POST("/:id/invitations/:invitationId/resend", httputil.RequireTenant(), httputil.TenantMember(svcs.Tenant), handlers.Tenant.ResendInvitation)
+		tenantGroup.DELETE("/:id/invitations/:invitationId", httputil.RequireTenant(), httputil.TenantMember(svcs.Tenant), handlers.Tenant.RevokeInvitation)
 		tenantGroup.POST("/:id/switch", handlers.Tenant.SwitchTenant)
 	}
+
+	// Invitations are answered by the invitee, who is not a member of the tenant yet.
+	invitationGroup := r.Group("/tenant-invitations")
+	invitationGroup.Use(httputil.Auth(svcs.Identity, svcs.Tenant))
+	{
+		invitationGroup.GET("", handlers.Tenant.ListMyInvitations)
+		invitationGroup.POST("/accept", handlers.Tenant.AcceptInvitation)
+		invitationGroup.POST("/decline", handlers.Tenant.DeclineInvitation)
+	}
+}
+
+// SetupMetadataRouter builds the instance metadata endpoint of a VPC, or of the
+// instances outside any VPC when vpcID is nil. It is served on its own
+// link-local listener, never on the public API port, because callers are
+// identified by the listener and their source address.
+func SetupMetadataRouter(cfg *platform.Config, logger *slog.Logger, svc ports.InstanceMetadataService, vpcID *uuid.UUID) *gin.Engine {
+	handler := httphandlers.NewInstanceMetadataHandler(svc, vpcID)
+	r := gin.New()
+	// Forwarded headers would let a caller claim another instance's address.
+	_ = r.SetTrustedProxies(nil)
+	r.Use(httputil.RequestID())
+	r.Use(httputil.Logger(logger))
+	r.Use(gin.Recovery())
+
+	globalRPS, _ := strconv.Atoi(cfg.RateLimitGlobal)
+	if globalRPS <= 0 {
+		globalRPS = 5
+	}
+	r.Use(ratelimit.Middleware(ratelimit.NewIPRateLimiter(rate.Limit(globalRPS), globalRPS*2, logger)))
+
+	md := r.Group("/latest")
+	md.Use(handler.RequireMetadataFlavor())
+	{
+		md.GET("/meta-data", handler.GetMetadata)
+		md.GET("/meta-data/instance-id", handler.GetInstanceID)
+		md.GET("/meta-data/tags", handler.GetTags)
+		md.GET("/meta-data/network", handler.GetNetwork)
+		md.GET("/meta-data/iam/credentials", handler.GetCredentials)
+		md.GET("/meta-data/preemption", handler.GetPreemption)
+		md.GET("/user-data", handler.GetUserData)
+	}
+	return r
 }
@@ -38,6 +38,9 @@ func (s stubNetworkBackend) DeleteFlowRule(_ context.Context, _ string, _ string
 func (s stubNetworkBackend) ListFlowRules(_ context.Context, _ string) ([]ports.FlowRule, error) {
 	return []ports.FlowRule{}, nil
 }
+func (s stubNetworkBackend) ListConntrackEntries(_ context.Context, _ int) ([]ports.ConntrackEntry, error) {
+	return []ports.ConntrackEntry{}, nil
+}
 func (s stubNetworkBackend) CreateVethPair(_ context.Context, _ string, _ string) error { return nil }
 func (s stubNetworkBackend) AttachVethToBridge(_ context.Context, _ string, _ string) error {
 	return nil
@@ -4,16 +4,28 @@ package autoscaler
 import (
 	"context"
 	"fmt"
+	"math"
 	"strings"
+	"time"
 
 	"github.com/poyrazk/thecloud/internal/autoscaler/protos"
 	"github.com/poyrazk/thecloud/internal/core/domain"
 	"github.com/poyrazk/thecloud/pkg/sdk"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
 )
 
+const (
+	// defaultInstanceType is used for node groups created without one.
+	defaultInstanceType = "standard-1"
+	// maxPodsPerNode matches the kubelet default.
+	maxPodsPerNode = 110
+)
+
 type AutoscalerServer struct {
 	protos.UnimplementedCloudProviderServer
 	client    *sdk.Client
@@ -340,17 +352,240 @@ func (s *AutoscalerServer) NodeGroupDecreaseTargetSize(ctx context.Context, req
 }
 
 func (s *AutoscalerServer) NodeGroupTemplateNodeInfo(ctx context.Context, req *protos.NodeGroupTemplateNodeInfoRequest) (*protos.NodeGroupTemplateNodeInfoResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "NodeGroupTemplateNodeInfo method not implemented")
+	ng, err := s.getNodeGroup(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	it, err := s.getInstanceType(ctx, nodeGroupInstanceType(ng))
+	if err != nil {
+		return nil, err
+	}
+
+	return &protos.NodeGroupTemplateNodeInfoResponse{
+		NodeInfo: templateNode(s.clusterID, ng, it),
+	}, nil
 }
 
 func (s *AutoscalerServer) NodeGroupGetOptions(ctx context.Context, req *protos.NodeGroupAutoscalingOptionsRequest) (*protos.NodeGroupAutoscalingOptionsResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "NodeGroupGetOptions method not implemented")
+	ng, err := s.getNodeGroup(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start from the autoscaler defaults and apply the node group overrides.
+	opts := &protos.NodeGroupAutoscalingOptions{}
+	if d := req.Defaults; d != nil {
+		opts.ScaleDownUtilizationThreshold = d.ScaleDownUtilizationThreshold
+		opts.ScaleDownGpuUtilizationThreshold = d.ScaleDownGpuUtilizationThreshold
+		opts.ScaleDownUnneededTime = d.ScaleDownUnneededTime
+		opts.ScaleDownUnreadyTime = d.ScaleDownUnreadyTime
+		opts.MaxNodeProvisionTime = d.MaxNodeProvisionTime
+	}
+	if ng.ScaleDownUtilizationThreshold > 0 {
+		opts.ScaleDownUtilizationThreshold = ng.ScaleDownUtilizationThreshold
+	}
+	if ng.ScaleDownUnneededSeconds > 0 {
+		opts.ScaleDownUnneededTime = &metav1.Duration{Duration: time.Duration(ng.ScaleDownUnneededSeconds) * time.Second}
+	}
+
+	return &protos.NodeGroupAutoscalingOptionsResponse{NodeGroupAutoscalingOptions: opts}, nil
 }
 
 func (s *AutoscalerServer) PricingNodePrice(ctx context.Context, req *protos.PricingNodePriceRequest) (*protos.PricingNodePriceResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "PricingNodePrice method not implemented")
+	hours, err := pricingHours(req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	if req.Node == nil {
+		return nil, status.Error(codes.InvalidArgument, "node is required")
+	}
+
+	// Template nodes have no provider ID, so prefer the instance type label.
+	typeID := req.Node.Labels[corev1.LabelInstanceTypeStable]
+	if typeID == "" {
+		providerID := strings.TrimPrefix(req.Node.ProviderID, "thecloud://")
+		if providerID == req.Node.ProviderID {
+			return nil, status.Errorf(codes.InvalidArgument, "node %q has no instance type label or provider ID", req.Node.Name)
+		}
+		inst, err := s.client.GetInstanceWithContext(ctx, providerID)
+		if err != nil {
+			return nil, err
+		}
+		typeID = inst.InstanceType
+		if typeID == "" {
+			typeID = defaultInstanceType
+		}
+	}
+
+	it, err := s.getInstanceType(ctx, typeID)
+	if err != nil {
+		return nil, err
+	}
+	price := capacityPrice(it.PricePerHr, domain.CapacityType(req.Node.Labels[domain.MetadataCapacityType]))
+	return &protos.PricingNodePriceResponse{Price: price * hours}, nil
 }
 
 func (s *AutoscalerServer) PricingPodPrice(ctx context.Context, req *protos.PricingPodPriceRequest) (*protos.PricingPodPriceResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "PricingPodPrice method not implemented")
+	hours, err := pricingHours(req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	if req.Pod == nil {
+		return nil, status.Error(codes.InvalidArgument, "pod is required")
+	}
+
+	var milliCPU, memoryBytes int64
+	for _, c := range req.Pod.Spec.Containers {
+		milliCPU += c.Resources.Requests.Cpu().MilliValue()
+		memoryBytes += c.Resources.Requests.Memory().Value()
+	}
+	if milliCPU == 0 && memoryBytes == 0 {
+		return &protos.PricingPodPriceResponse{}, nil
+	}
+
+	cluster, err := s.client.GetClusterWithContext(ctx, s.clusterID)
+	if err != nil {
+		return nil, err
+	}
+	types, err := s.client.ListInstanceTypesWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	typesByID := make(map[string]sdk.InstanceType, len(types))
+	for _, it := range types {
+		typesByID[it.ID] = it
+	}
+
+	// The pod is charged the share of a node it would occupy, on the
+	// cheapest node group of the cluster that could run it.
+	price := -1.0
+	for i := range cluster.NodeGroups {
+		it, ok := typesByID[nodeGroupInstanceType(&cluster.NodeGroups[i])]
+		if !ok || it.VCPUs <= 0 || it.MemoryMB <= 0 {
+			continue
+		}
+		share := math.Max(float64(milliCPU)/float64(it.VCPUs*1000), float64(memoryBytes)/float64(int64(it.MemoryMB)*1024*1024))
+		if share > 1 {
+			continue
+		}
+		if p := capacityPrice(it.PricePerHr, nodeGroupCapacityType(&cluster.NodeGroups[i])) * share * hours; price < 0 || p < price {
+			price = p
+		}
+	}
+	if price < 0 {
+		return nil, status.Errorf(codes.NotFound, "no node group in cluster %s fits pod %s/%s", s.clusterID, req.Pod.Namespace, req.Pod.Name)
+	}
+
+	return &protos.PricingPodPriceResponse{Price: price}, nil
+}
+
+func (s *AutoscalerServer) getNodeGroup(ctx context.Context, name string) (*sdk.NodeGroup, error) {
+	cluster, err := s.client.GetClusterWithContext(ctx, s.clusterID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range cluster.NodeGroups {
+		if cluster.NodeGroups[i].Name == name {
+			return &cluster.NodeGroups[i], nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "node group %s not found", name)
+}
+
+func (s *AutoscalerServer) getInstanceType(ctx context.Context, id string) (*sdk.InstanceType, error) {
+	types, err := s.client.ListInstanceTypesWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range types {
+		if types[i].ID == id {
+			return &types[i], nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "instance type %s not found", id)
+}
+
+func nodeGroupInstanceType(ng *sdk.NodeGroup) string {
+	if ng.InstanceType == "" {
+		return defaultInstanceType
+	}
+	return ng.InstanceType
+}
+
+func nodeGroupCapacityType(ng *sdk.NodeGroup) domain.CapacityType {
+	if ng.CapacityType == "" {
+		return domain.CapacityOnDemand
+	}
+	return domain.CapacityType(ng.CapacityType)
+}
+
+// capacityPrice discounts an on-demand price for preemptible capacity.
+func capacityPrice(price float64, capacityType domain.CapacityType) float64 {
+	if capacityType == domain.CapacityPreemptible {
+		return price * domain.PreemptiblePriceFactor
+	}
+	return price
+}
+
+// templateNode describes a node the group would add, so the autoscaler can
+// simulate scheduling on a group that currently has no nodes.
+func templateNode(clusterID string, ng *sdk.NodeGroup, it *sdk.InstanceType) *corev1.Node {
+	name := fmt.Sprintf("template-%s-%s", ng.Name, it.ID)
+	labels := map[string]string{
+		corev1.LabelHostname:           name,
+		corev1.LabelOSStable:           "linux",
+		corev1.LabelArchStable:         "amd64",
+		corev1.LabelInstanceTypeStable: it.ID,
+		corev1.LabelTopologyZone:       "local",
+		corev1.LabelTopologyRegion:     "local",
+		"thecloud.io/cluster-id":       clusterID,
+		"thecloud.io/node-group":       ng.Name,
+	}
+	if it.Category == "gpu" {
+		labels["thecloud.io/gpu"] = it.ID
+	}
+	labels[domain.MetadataCapacityType] = string(nodeGroupCapacityType(ng))
+	for k, v := range ng.Labels {
+		labels[k] = v
+	}
+	taints := make([]corev1.Taint, 0, len(ng.Taints))
+	for _, t := range ng.Taints {
+		taints = append(taints, corev1.Taint{Key: t.Key, Value: t.Value, Effect: corev1.TaintEffect(t.Effect)})
+	}
+
+	resources := corev1.ResourceList{
+		corev1.ResourceCPU:              *resource.NewQuantity(int64(it.VCPUs), resource.DecimalSI),
+		corev1.ResourceMemory:           *resource.NewQuantity(int64(it.MemoryMB)*1024*1024, resource.BinarySI),
+		corev1.ResourceEphemeralStorage: *resource.NewQuantity(int64(it.DiskGB)*1024*1024*1024, resource.BinarySI),
+		corev1.ResourcePods:             *resource.NewQuantity(maxPodsPerNode, resource.DecimalSI),
+	}
+
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+		Spec: corev1.NodeSpec{
+			Taints: taints,
+		},
+		Status: corev1.NodeStatus{
+			Capacity:    resources,
+			Allocatable: resources.DeepCopy(),
+			Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+			},
+		},
+	}
+}
+
+// pricingHours returns the length of a pricing period in hours.
+func pricingHours(start, end *metav1.Time) (float64, error) {
+	if start == nil || end == nil {
+		return 0, status.Error(codes.InvalidArgument, "start and end time are required")
+	}
+	d := end.Sub(start.Time)
+	if d < 0 {
+		return 0, status.Error(codes.InvalidArgument, "end time is before start time")
+	}
+	return d.Hours(), nil
 }
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/poyrazk/thecloud/internal/autoscaler/protos"
@@ -14,6 +15,9 @@ import (
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestAutoscalerServer_Refresh(t *testing.T) {
@@ -236,45 +240,165 @@ func TestAutoscalerServer_NodeGroupDecreaseTargetSize(t *testing.T) {
 	})
 }
 
+// newPricingTestServer serves a cluster with a standard and a gpu node group
+// plus the instance type catalog.
+func newPricingTestServer(t *testing.T, clusterID, instanceID string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/clusters/" + clusterID:
+			_, _ = fmt.Fprintf(w, `{"data": {"id": "%s", "status": "RUNNING", "node_groups": [
+				{"name": "pool-1", "instance_type": "standard-2", "min_size": 0, "max_size": 5, "current_size": 0,
+				 "scale_down_utilization_threshold": 0.3, "scale_down_unneeded_seconds": 120},
+				{"name": "gpu-pool", "instance_type": "gpu-1", "min_size": 0, "max_size": 2,
+				 "labels": {"accelerator": "a100"}, "taints": [{"key": "nvidia.com/gpu", "value": "true", "effect": "NoSchedule"}]}
+			]}}`, clusterID)
+		case "/instance-types":
+			_, _ = fmt.Fprint(w, `{"data": [
+				{"id": "standard-2", "vcpus": 2, "memory_mb": 4096, "disk_gb": 40, "price_per_hour": 0.08, "category": "standard"},
+				{"id": "gpu-1", "vcpus": 8, "memory_mb": 32768, "disk_gb": 100, "price_per_hour": 2.0, "category": "gpu"}
+			]}`)
+		case "/instances/" + instanceID:
+			_, _ = fmt.Fprintf(w, `{"data": {"id": "%s", "instance_type": "gpu-1"}}`, instanceID)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
 func TestAutoscalerServer_NodeGroupTemplateNodeInfo(t *testing.T) {
-	server := NewAutoscalerServer(nil, "id")
-	resp, err := server.NodeGroupTemplateNodeInfo(context.Background(), &protos.NodeGroupTemplateNodeInfoRequest{Id: "pool-1"})
-	require.Error(t, err)
-	assert.Nil(t, resp)
-	st, ok := status.FromError(err)
-	require.True(t, ok)
-	assert.Equal(t, codes.Unimplemented, st.Code())
+	clusterID := uuid.New().String()
+	ts := newPricingTestServer(t, clusterID, "")
+	defer ts.Close()
+	server := NewAutoscalerServer(sdk.NewClient(ts.URL, "test-token"), clusterID)
+
+	t.Run("Success", func(t *testing.T) {
+		resp, err := server.NodeGroupTemplateNodeInfo(context.Background(), &protos.NodeGroupTemplateNodeInfoRequest{Id: "pool-1"})
+		require.NoError(t, err)
+		node := resp.NodeInfo
+		require.NotNil(t, node)
+		assert.Equal(t, "standard-2", node.Labels[corev1.LabelInstanceTypeStable])
+		assert.Equal(t, "pool-1", node.Labels["thecloud.io/node-group"])
+		assert.NotContains(t, node.Labels, "thecloud.io/gpu")
+		assert.Empty(t, node.Spec.Taints)
+		assert.Equal(t, "on-demand", node.Labels["thecloud.io/capacity-type"])
+		assert.Equal(t, int64(2), node.Status.Allocatable.Cpu().Value())
+		assert.Equal(t, int64(4096*1024*1024), node.Status.Allocatable.Memory().Value())
+		assert.Equal(t, int64(110), node.Status.Allocatable.Pods().Value())
+	})
+
+	t.Run("GPU", func(t *testing.T) {
+		resp, err := server.NodeGroupTemplateNodeInfo(context.Background(), &protos.NodeGroupTemplateNodeInfoRequest{Id: "gpu-pool"})
+		require.NoError(t, err)
+		assert.Equal(t, "gpu-1", resp.NodeInfo.Labels["thecloud.io/gpu"])
+		assert.Equal(t, "a100", resp.NodeInfo.Labels["accelerator"])
+		assert.Equal(t, []corev1.Taint{{Key: "nvidia.com/gpu", Value: "true", Effect: corev1.TaintEffectNoSchedule}}, resp.NodeInfo.Spec.Taints)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := server.NodeGroupTemplateNodeInfo(context.Background(), &protos.NodeGroupTemplateNodeInfoRequest{Id: "missing"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
 }
 
 func TestAutoscalerServer_NodeGroupGetOptions(t *testing.T) {
-	server := NewAutoscalerServer(nil, "id")
-	resp, err := server.NodeGroupGetOptions(context.Background(), &protos.NodeGroupAutoscalingOptionsRequest{})
-	require.Error(t, err)
-	assert.Nil(t, resp)
-	st, ok := status.FromError(err)
-	require.True(t, ok)
-	assert.Equal(t, codes.Unimplemented, st.Code())
+	clusterID := uuid.New().String()
+	ts := newPricingTestServer(t, clusterID, "")
+	defer ts.Close()
+	server := NewAutoscalerServer(sdk.NewClient(ts.URL, "test-token"), clusterID)
+
+	defaults := &protos.NodeGroupAutoscalingOptions{
+		ScaleDownUtilizationThreshold: 0.5,
+		ScaleDownUnneededTime:         &metav1.Duration{Duration: 10 * time.Minute},
+		MaxNodeProvisionTime:          &metav1.Duration{Duration: 15 * time.Minute},
+	}
+
+	t.Run("Overrides", func(t *testing.T) {
+		resp, err := server.NodeGroupGetOptions(context.Background(), &protos.NodeGroupAutoscalingOptionsRequest{Id: "pool-1", Defaults: defaults})
+		require.NoError(t, err)
+		opts := resp.NodeGroupAutoscalingOptions
+		assert.InDelta(t, 0.3, opts.ScaleDownUtilizationThreshold, 1e-9)
+		assert.Equal(t, 2*time.Minute, opts.ScaleDownUnneededTime.Duration)
+		assert.Equal(t, 15*time.Minute, opts.MaxNodeProvisionTime.Duration)
+	})
+
+	t.Run("Defaults", func(t *testing.T) {
+		resp, err := server.NodeGroupGetOptions(context.Background(), &protos.NodeGroupAutoscalingOptionsRequest{Id: "gpu-pool", Defaults: defaults})
+		require.NoError(t, err)
+		opts := resp.NodeGroupAutoscalingOptions
+		assert.InDelta(t, 0.5, opts.ScaleDownUtilizationThreshold, 1e-9)
+		assert.Equal(t, 10*time.Minute, opts.ScaleDownUnneededTime.Duration)
+	})
 }
 
 func TestAutoscalerServer_Pricing(t *testing.T) {
-	server := NewAutoscalerServer(nil, "id")
+	clusterID := uuid.New().String()
+	instanceID := uuid.New().String()
+	ts := newPricingTestServer(t, clusterID, instanceID)
+	defer ts.Close()
+	server := NewAutoscalerServer(sdk.NewClient(ts.URL, "test-token"), clusterID)
+
+	start := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	end := metav1.NewTime(start.Add(2 * time.Hour))
+
+	t.Run("NodePrice_TemplateLabel", func(t *testing.T) {
+		resp, err := server.PricingNodePrice(context.Background(), &protos.PricingNodePriceRequest{
+			Node:      &protos.ExternalGrpcNode{Labels: map[string]string{corev1.LabelInstanceTypeStable: "standard-2"}},
+			StartTime: &start, EndTime: &end,
+		})
+		require.NoError(t, err)
+		assert.InDelta(t, 0.16, resp.Price, 1e-9)
+	})
+
+	t.Run("NodePrice_Preemptible", func(t *testing.T) {
+		resp, err := server.PricingNodePrice(context.Background(), &protos.PricingNodePriceRequest{
+			Node: &protos.ExternalGrpcNode{Labels: map[string]string{
+				corev1.LabelInstanceTypeStable: "standard-2",
+				"thecloud.io/capacity-type":    "preemptible",
+			}},
+			StartTime: &start, EndTime: &end,
+		})
+		require.NoError(t, err)
+		assert.InDelta(t, 0.048, resp.Price, 1e-9)
+	})
+
+	t.Run("NodePrice_ProviderID", func(t *testing.T) {
+		resp, err := server.PricingNodePrice(context.Background(), &protos.PricingNodePriceRequest{
+			Node:      &protos.ExternalGrpcNode{ProviderID: "thecloud://" + instanceID},
+			StartTime: &start, EndTime: &end,
+		})
+		require.NoError(t, err)
+		assert.InDelta(t, 4.0, resp.Price, 1e-9)
+	})
 
-	t.Run("NodePrice", func(t *testing.T) {
-		resp, err := server.PricingNodePrice(context.Background(), &protos.PricingNodePriceRequest{})
-		require.Error(t, err)
-		assert.Nil(t, resp)
-		st, ok := status.FromError(err)
-		require.True(t, ok)
-		assert.Equal(t, codes.Unimplemented, st.Code())
+	t.Run("NodePrice_MissingPeriod", func(t *testing.T) {
+		_, err := server.PricingNodePrice(context.Background(), &protos.PricingNodePriceRequest{Node: &protos.ExternalGrpcNode{}})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
 	})
 
 	t.Run("PodPrice", func(t *testing.T) {
-		resp, err := server.PricingPodPrice(context.Background(), &protos.PricingPodPriceRequest{})
-		require.Error(t, err)
-		assert.Nil(t, resp)
-		st, ok := status.FromError(err)
-		require.True(t, ok)
-		assert.Equal(t, codes.Unimplemented, st.Code())
+		// 1 vCPU and 1 GiB is half a standard-2 node for two hours.
+		pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+			Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("1Gi"),
+			}},
+		}}}}
+		resp, err := server.PricingPodPrice(context.Background(), &protos.PricingPodPriceRequest{Pod: pod, StartTime: &start, EndTime: &end})
+		require.NoError(t, err)
+		assert.InDelta(t, 0.08, resp.Price, 1e-9)
+	})
+
+	t.Run("PodPrice_OnlyFitsGPUPool", func(t *testing.T) {
+		pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+			Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("4"),
+			}},
+		}}}}
+		resp, err := server.PricingPodPrice(context.Background(), &protos.PricingPodPriceRequest{Pod: pod, StartTime: &start, EndTime: &end})
+		require.NoError(t, err)
+		assert.InDelta(t, 2.0, resp.Price, 1e-9)
 	})
 }
 
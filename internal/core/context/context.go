@@ -15,6 +15,8 @@ const (
 	internalKey         contextKey = "is_internal"
 	sourceIPKey         contextKey = "source_ip"
 	serviceAccountIDKey  contextKey = "service_account_id"
+	requestAttrsKey     contextKey = "request_attributes"
+	reservedMetaKey     contextKey = "reserved_metadata"
 
 	systemUserIDStr = "00000000-0000-0000-0000-000000000001"
 )
@@ -35,6 +37,19 @@ func IsInternalCall(ctx context.Context) bool {
 	return ok && internal
 }
 
+// WithReservedMetadata returns a new context for a platform component that may
+// set metadata keys reserved for the platform, such as the capacity type of a
+// cluster node.
+func WithReservedMetadata(ctx context.Context) context.Context {
+	return context.WithValue(ctx, reservedMetaKey, true)
+}
+
+// AllowsReservedMetadata returns true if the context may set reserved metadata keys.
+func AllowsReservedMetadata(ctx context.Context) bool {
+	allowed, ok := ctx.Value(reservedMetaKey).(bool)
+	return ok && allowed
+}
+
 // WithUserID returns a new context with the given userID.
 func WithUserID(ctx context.Context, userID uuid.UUID) context.Context {
 	return context.WithValue(ctx, userIDKey, userID)
@@ -90,3 +105,26 @@ func ServiceAccountIDFromContext(ctx context.Context) uuid.UUID {
 	}
 	return saID
 }
+
+// WithRequestAttributes returns a new context carrying attributes of the request being
+// authorized (e.g. instance type or image), exposed to policy conditions by key.
+// Attributes already on the context are kept unless overwritten.
+func WithRequestAttributes(ctx context.Context, attrs map[string]interface{}) context.Context {
+	merged := make(map[string]interface{}, len(attrs))
+	for k, v := range RequestAttributesFromContext(ctx) {
+		merged[k] = v
+	}
+	for k, v := range attrs {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, requestAttrsKey, merged)
+}
+
+// RequestAttributesFromContext returns the request attributes from the context, or nil if none.
+func RequestAttributesFromContext(ctx context.Context) map[string]interface{} {
+	attrs, ok := ctx.Value(requestAttrsKey).(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	return attrs
+}
@@ -13,6 +13,9 @@ type ResourceType string
 const (
 	// ResourceInstance represents compute instances (VMs or containers).
 	ResourceInstance ResourceType = "INSTANCE"
+	// ResourcePreemptibleInstance represents instances on preemptible capacity,
+	// which are billed at a discount.
+	ResourcePreemptibleInstance ResourceType = "PREEMPTIBLE_INSTANCE"
 	// ResourceStorage represents block storage volumes or object storage.
 	ResourceStorage ResourceType = "STORAGE"
 	// ResourceNetwork represents networking resources like IPs or bandwidth.
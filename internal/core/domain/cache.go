@@ -13,8 +13,36 @@ type CacheEngine string
 const (
 	// EngineRedis represents a Redis-based cache instance.
 	EngineRedis CacheEngine = "redis"
+	// EngineValkey represents a Valkey cache instance, a wire-compatible
+	// fork of Redis.
+	EngineValkey CacheEngine = "valkey"
+	// EngineMemcached represents a Memcached cache instance.
+	EngineMemcached CacheEngine = "memcached"
 )
 
+// IsValid reports whether e is a supported cache engine.
+func (e CacheEngine) IsValid() bool {
+	switch e {
+	case EngineRedis, EngineValkey, EngineMemcached:
+		return true
+	}
+	return false
+}
+
+// RedisCompatible reports whether the engine speaks the Redis protocol, and
+// so supports passwords, persistence, replication and cluster mode.
+func (e CacheEngine) RedisCompatible() bool {
+	return e == EngineRedis || e == EngineValkey
+}
+
+// CLI returns the command-line client of a Redis-compatible engine.
+func (e CacheEngine) CLI() string {
+	if e == EngineValkey {
+		return "valkey-cli"
+	}
+	return "redis-cli"
+}
+
 // CacheStatus represents the lifecycle state of a cache instance.
 type CacheStatus string
 
@@ -25,26 +53,133 @@ const (
 	CacheStatusRunning CacheStatus = "RUNNING"
 	// CacheStatusStopped indicates the cache instance is halted.
 	CacheStatusStopped CacheStatus = "STOPPED"
+	// CacheStatusModifying indicates the cache is being resized or resharded.
+	CacheStatusModifying CacheStatus = "MODIFYING"
 	// CacheStatusDeleting indicates the cache is being removed.
 	CacheStatusDeleting CacheStatus = "DELETING"
 	// CacheStatusFailed indicates the cache encountered an error.
 	CacheStatusFailed CacheStatus = "FAILED"
 )
 
+// CachePersistence selects how a cache writes its data to disk.
+type CachePersistence string
+
+const (
+	// CachePersistenceNone keeps data in memory only.
+	CachePersistenceNone CachePersistence = "none"
+	// CachePersistenceRDB writes periodic point-in-time snapshots.
+	CachePersistenceRDB CachePersistence = "rdb"
+	// CachePersistenceAOF logs every write to an append-only file, fsynced every second.
+	CachePersistenceAOF CachePersistence = "aof"
+)
+
+// IsValid reports whether p is a supported persistence mode.
+func (p CachePersistence) IsValid() bool {
+	switch p {
+	case CachePersistenceNone, CachePersistenceRDB, CachePersistenceAOF:
+		return true
+	}
+	return false
+}
+
+// CacheNodeRole identifies whether a cache node accepts writes.
+type CacheNodeRole string
+
+const (
+	// CacheNodePrimary is the node accepting writes.
+	CacheNodePrimary CacheNodeRole = "PRIMARY"
+	// CacheNodeReplica is a read-only node replicating from the primary.
+	CacheNodeReplica CacheNodeRole = "REPLICA"
+)
+
+// MaxCacheReplicas bounds the number of read replicas of a cache, or of
+// each shard in cluster mode.
+const MaxCacheReplicas = 5
+
+const (
+	// MinCacheShards is the smallest Redis Cluster, as required for a
+	// majority of primaries to agree on failovers.
+	MinCacheShards = 3
+	// MaxCacheShards bounds the number of shards of a cluster-mode cache.
+	MaxCacheShards = 16
+)
+
 // Cache represents a managed caching service instance (e.g. Redis).
+// ContainerID always refers to the current primary node. Caches with replicas
+// are reached through an endpoint proxy, so Port stays the same across failovers.
+// Cluster-mode caches split their keys across Shards primaries, each with
+// Replicas replicas, and are reached through the addresses of their nodes.
+// With metrics enabled, an engine exporter sidecar serves Prometheus metrics
+// on MetricsPort.
 type Cache struct {
-	ID          uuid.UUID   `json:"id"`
-	UserID      uuid.UUID   `json:"user_id"`
-	TenantID    uuid.UUID   `json:"tenant_id"`
-	Name        string      `json:"name"`
-	Engine      CacheEngine `json:"engine"`
-	Version     string      `json:"version"` // Engine version (e.g. "7.0")
-	Status      CacheStatus `json:"status"`
-	VpcID       *uuid.UUID  `json:"vpc_id,omitempty"` // Optional private networking
-	ContainerID string      `json:"container_id,omitempty"`
-	Port        int         `json:"port"`
-	Password    string      `json:"-"` // Never serialize password to JSON
-	MemoryMB    int         `json:"memory_mb"`
-	CreatedAt   time.Time   `json:"created_at"`
-	UpdatedAt   time.Time   `json:"updated_at"`
+	ID                  uuid.UUID        `json:"id"`
+	UserID              uuid.UUID        `json:"user_id"`
+	TenantID            uuid.UUID        `json:"tenant_id"`
+	Name                string           `json:"name"`
+	Engine              CacheEngine      `json:"engine"`
+	Version             string           `json:"version"` // Engine version (e.g. "7.0")
+	Status              CacheStatus      `json:"status"`
+	VpcID               *uuid.UUID       `json:"vpc_id,omitempty"` // Optional private networking
+	ContainerID         string           `json:"container_id,omitempty"`
+	Port                int              `json:"port"`
+	ReaderPort          int              `json:"reader_port,omitempty"` // Load-balanced across replicas
+	Password            string           `json:"-"`                     // Never serialize password to JSON
+	MemoryMB            int              `json:"memory_mb"`
+	Persistence         CachePersistence `json:"persistence"`
+	Replicas            int              `json:"replicas"`
+	FailoverEnabled     bool             `json:"failover_enabled"`
+	ClusterMode         bool             `json:"cluster_mode"`
+	Shards              int              `json:"shards,omitempty"` // Cluster mode only
+	ProxyContainerID    string           `json:"-"`
+	MetricsEnabled      bool             `json:"metrics_enabled"`
+	MetricsPort         int              `json:"metrics_port,omitempty"`
+	ExporterContainerID string           `json:"-"`
+	Nodes               []*CacheNode     `json:"nodes,omitempty"`
+	CreatedAt           time.Time        `json:"created_at"`
+	UpdatedAt           time.Time        `json:"updated_at"`
+}
+
+// CacheNode is a single engine container of a cache.
+type CacheNode struct {
+	ID          uuid.UUID     `json:"id"`
+	CacheID     uuid.UUID     `json:"cache_id"`
+	Role        CacheNodeRole `json:"role"`
+	Shard       int           `json:"shard"` // Always 0 outside cluster mode
+	Status      CacheStatus   `json:"status"`
+	ContainerID string        `json:"container_id,omitempty"`
+	VolumeID    *uuid.UUID    `json:"volume_id,omitempty"` // Set when persistence is enabled
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+}
+
+// PrimaryNode returns the node currently accepting writes, or nil if the
+// nodes are not loaded.
+func (c *Cache) PrimaryNode() *CacheNode {
+	for _, n := range c.Nodes {
+		if n.Role == CacheNodePrimary {
+			return n
+		}
+	}
+	return nil
+}
+
+// ShardNodes returns the nodes of shard, primary first as loaded.
+func (c *Cache) ShardNodes(shard int) []*CacheNode {
+	var nodes []*CacheNode
+	for _, n := range c.Nodes {
+		if n.Shard == shard {
+			nodes = append(nodes, n)
+		}
+	}
+	return nodes
+}
+
+// CacheVolumeSizeGB sizes the persistence volume of a cache node: twice its
+// memory, leaving room for AOF rewrites and RDB snapshots, and at least 1 GB.
+func CacheVolumeSizeGB(memoryMB int) int {
+	size := (memoryMB*2 + 1023) / 1024
+	if size < 1 {
+		return 1
+	}
+	return size
 }
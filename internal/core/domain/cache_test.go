@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCachePersistenceIsValid(t *testing.T) {
+	for _, p := range []CachePersistence{CachePersistenceNone, CachePersistenceRDB, CachePersistenceAOF} {
+		assert.True(t, p.IsValid(), p)
+	}
+	assert.False(t, CachePersistence("").IsValid())
+	assert.False(t, CachePersistence("both").IsValid())
+}
+
+func TestCacheEngine(t *testing.T) {
+	for _, e := range []CacheEngine{EngineRedis, EngineValkey, EngineMemcached} {
+		assert.True(t, e.IsValid(), e)
+	}
+	assert.False(t, CacheEngine("").IsValid())
+	assert.False(t, CacheEngine("dragonfly").IsValid())
+
+	assert.True(t, EngineRedis.RedisCompatible())
+	assert.True(t, EngineValkey.RedisCompatible())
+	assert.False(t, EngineMemcached.RedisCompatible())
+
+	assert.Equal(t, "redis-cli", EngineRedis.CLI())
+	assert.Equal(t, "valkey-cli", EngineValkey.CLI())
+}
+
+func TestCachePrimaryNode(t *testing.T) {
+	c := &Cache{}
+	assert.Nil(t, c.PrimaryNode())
+
+	primary := &CacheNode{ID: uuid.New(), Role: CacheNodePrimary}
+	c.Nodes = []*CacheNode{{ID: uuid.New(), Role: CacheNodeReplica}, primary}
+	assert.Equal(t, primary, c.PrimaryNode())
+}
+
+func TestCacheVolumeSizeGB(t *testing.T) {
+	assert.Equal(t, 1, CacheVolumeSizeGB(0))
+	assert.Equal(t, 1, CacheVolumeSizeGB(128))
+	assert.Equal(t, 1, CacheVolumeSizeGB(512))
+	assert.Equal(t, 2, CacheVolumeSizeGB(1024))
+	assert.Equal(t, 3, CacheVolumeSizeGB(1500))
+}
+
+func TestCacheShardNodes(t *testing.T) {
+	a0 := &CacheNode{ID: uuid.New(), Shard: 0, Role: CacheNodePrimary}
+	b0 := &CacheNode{ID: uuid.New(), Shard: 0, Role: CacheNodeReplica}
+	a1 := &CacheNode{ID: uuid.New(), Shard: 1, Role: CacheNodePrimary}
+	c := &Cache{Nodes: []*CacheNode{a0, a1, b0}}
+
+	assert.Equal(t, []*CacheNode{a0, b0}, c.ShardNodes(0))
+	assert.Equal(t, []*CacheNode{a1}, c.ShardNodes(1))
+	assert.Empty(t, c.ShardNodes(2))
+}
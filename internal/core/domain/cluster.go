@@ -2,6 +2,9 @@
 package domain
 
 import (
+	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -31,6 +34,92 @@ const (
 	NodeRoleWorker       NodeRole = "worker"
 )
 
+// TaintEffect is the scheduling effect of a node taint.
+type TaintEffect string
+
+// Kubernetes taint effects.
+const (
+	TaintEffectNoSchedule       TaintEffect = "NoSchedule"
+	TaintEffectPreferNoSchedule TaintEffect = "PreferNoSchedule"
+	TaintEffectNoExecute        TaintEffect = "NoExecute"
+)
+
+// NodeTaint is a Kubernetes taint applied to every node of a node group.
+type NodeTaint struct {
+	Key    string      `json:"key"`
+	Value  string      `json:"value,omitempty"`
+	Effect TaintEffect `json:"effect"`
+}
+
+// String formats the taint as key=value:effect, as accepted by the kubelet.
+func (t NodeTaint) String() string {
+	if t.Value == "" {
+		return t.Key + ":" + string(t.Effect)
+	}
+	return t.Key + "=" + t.Value + ":" + string(t.Effect)
+}
+
+var (
+	// labelNamePattern and labelValuePattern follow the Kubernetes rules for
+	// label names (the part after the optional prefix) and values.
+	labelNamePattern   = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_.]{0,61}[A-Za-z0-9])?$`)
+	labelValuePattern  = regexp.MustCompile(`^([A-Za-z0-9]([-A-Za-z0-9_.]{0,61}[A-Za-z0-9])?)?$`)
+	labelPrefixPattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+)
+
+// ValidateNodeLabelKey checks a node label or taint key. Keys in the
+// kubernetes.io, k8s.io and thecloud.io namespaces are reserved, except
+// node.kubernetes.io which the kubelet lets nodes set on themselves.
+func ValidateNodeLabelKey(key string) error {
+	prefix, name := "", key
+	if i := strings.LastIndex(key, "/"); i >= 0 {
+		prefix, name = key[:i], key[i+1:]
+		if len(prefix) > 253 || !labelPrefixPattern.MatchString(prefix) {
+			return fmt.Errorf("invalid prefix in key %q", key)
+		}
+	}
+	if !labelNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid key %q: names are up to 63 alphanumerics, '-', '_' or '.'", key)
+	}
+	for _, reserved := range []string{"kubernetes.io", "k8s.io", "thecloud.io"} {
+		if prefix == reserved || strings.HasSuffix(prefix, "."+reserved) {
+			if prefix == "node.kubernetes.io" {
+				break
+			}
+			return fmt.Errorf("key %q uses the reserved %s namespace", key, reserved)
+		}
+	}
+	return nil
+}
+
+// ValidateNodeLabels checks the labels of a node group.
+func ValidateNodeLabels(labels map[string]string) error {
+	for k, v := range labels {
+		if err := ValidateNodeLabelKey(k); err != nil {
+			return err
+		}
+		if !labelValuePattern.MatchString(v) {
+			return fmt.Errorf("invalid value %q for label %q", v, k)
+		}
+	}
+	return nil
+}
+
+// Validate checks the key, value and effect of a taint.
+func (t NodeTaint) Validate() error {
+	if err := ValidateNodeLabelKey(t.Key); err != nil {
+		return err
+	}
+	if !labelValuePattern.MatchString(t.Value) {
+		return fmt.Errorf("invalid value %q for taint %q", t.Value, t.Key)
+	}
+	switch t.Effect {
+	case TaintEffectNoSchedule, TaintEffectPreferNoSchedule, TaintEffectNoExecute:
+		return nil
+	}
+	return fmt.Errorf("invalid effect %q for taint %q (expected NoSchedule, PreferNoSchedule or NoExecute)", t.Effect, t.Key)
+}
+
 // NodeGroup represents a pool of similar worker nodes in a cluster.
 type NodeGroup struct {
 	ID           uuid.UUID `json:"id"`
@@ -40,8 +129,18 @@ type NodeGroup struct {
 	MinSize      int       `json:"min_size" example:"1"`
 	MaxSize      int       `json:"max_size" example:"10"`
 	CurrentSize  int       `json:"current_size" example:"3"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	// Labels and Taints are registered on every node when it joins.
+	Labels map[string]string `json:"labels,omitempty"`
+	Taints []NodeTaint       `json:"taints,omitempty"`
+	// CapacityType selects discounted preemptible nodes, which the platform
+	// may reclaim after a termination notice.
+	CapacityType CapacityType `json:"capacity_type" example:"on-demand"`
+	// Scale-down overrides reported to the cluster autoscaler. Zero values
+	// keep the autoscaler's own defaults.
+	ScaleDownUtilizationThreshold float64   `json:"scale_down_utilization_threshold,omitempty" example:"0.5"`
+	ScaleDownUnneededSeconds      int       `json:"scale_down_unneeded_seconds,omitempty" example:"600"`
+	CreatedAt                     time.Time `json:"created_at"`
+	UpdatedAt                     time.Time `json:"updated_at"`
 }
 
 // Cluster represents a managed Kubernetes cluster.
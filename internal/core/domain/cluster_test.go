@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateNodeLabelKey(t *testing.T) {
+	for _, ok := range []string{"workload", "team.name", "example.com/tier", "node.kubernetes.io/exclude-from-external-load-balancers"} {
+		assert.NoError(t, ValidateNodeLabelKey(ok), ok)
+	}
+	for _, bad := range []string{"", "-lead", "a b", "/name", "Example.com/tier", "kubernetes.io/role", "node-role.kubernetes.io/worker",
+		"k8s.io/x", "thecloud.io/node-group", strings.Repeat("a", 64)} {
+		assert.Error(t, ValidateNodeLabelKey(bad), bad)
+	}
+}
+
+func TestNodeTaintValidate(t *testing.T) {
+	assert.NoError(t, NodeTaint{Key: "dedicated", Value: "gpu", Effect: TaintEffectNoExecute}.Validate())
+	assert.Error(t, NodeTaint{Key: "dedicated", Effect: "Evict"}.Validate())
+	assert.Error(t, NodeTaint{Key: "dedicated", Value: "a:b", Effect: TaintEffectNoSchedule}.Validate())
+	assert.Equal(t, "dedicated:NoSchedule", NodeTaint{Key: "dedicated", Effect: TaintEffectNoSchedule}.String())
+}
@@ -25,6 +25,10 @@ const (
 
 	// Null check operator
 	CondNull ConditionOperator = "Null"
+
+	// Numeric operators
+	CondNumericGreaterThan ConditionOperator = "NumericGreaterThan"
+	CondNumericLessThan    ConditionOperator = "NumericLessThan"
 )
 
 // ConditionKey represents well-known condition keys used in evaluation context.
@@ -41,4 +45,12 @@ const (
 	// thecloud-specific condition keys
 	KeyTenantID   ConditionKey = "thecloud:TenantId"
 	KeyUserAgent ConditionKey = "thecloud:UserAgent"
+	KeyRegion    ConditionKey = "thecloud:Region"
+
+	// Request attributes supplied by the service performing the action
+	KeyInstanceType     ConditionKey = "thecloud:InstanceType"
+	KeyImage            ConditionKey = "thecloud:Image"
+	KeyInstanceVCPUs    ConditionKey = "thecloud:InstanceVCPUs"
+	KeyInstanceMemoryMB ConditionKey = "thecloud:InstanceMemoryMB"
+	KeyBucketPublic     ConditionKey = "thecloud:BucketPublic"
 )
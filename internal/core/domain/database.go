@@ -15,8 +15,16 @@ const (
 	EnginePostgres DatabaseEngine = "postgres"
 	// EngineMySQL represents MySQL.
 	EngineMySQL DatabaseEngine = "mysql"
+	// EngineMariaDB represents MariaDB.
+	EngineMariaDB DatabaseEngine = "mariadb"
 )
 
+// MySQLCompatible reports whether the engine speaks the MySQL protocol and
+// SQL dialect, so MySQL tooling, replication and grants apply to it.
+func (e DatabaseEngine) MySQLCompatible() bool {
+	return e == EngineMySQL || e == EngineMariaDB
+}
+
 // DatabaseStatus represents the lifecycle state of a managed database instance.
 type DatabaseStatus string
 
@@ -31,6 +39,9 @@ const (
 	DatabaseStatusDeleting DatabaseStatus = "DELETING"
 	// DatabaseStatusFailed indicates the database encountered a critical error.
 	DatabaseStatusFailed DatabaseStatus = "FAILED"
+	// DatabaseStatusMaintenance indicates the database is being restarted for
+	// an upgrade or a parameter change.
+	DatabaseStatusMaintenance DatabaseStatus = "MAINTENANCE"
 )
 
 // DatabaseRole represents the replication role of a database instance.
@@ -74,4 +85,24 @@ type Database struct {
 	EncryptedVolume     bool              `json:"encrypted_volume"`
 	VolumeKeyRef        string            `json:"volume_key_ref,omitempty"`
 	CredentialVersion   int               `json:"-"`
+
+	// Point-in-time recovery. A positive BackupRetentionDays enables continuous
+	// WAL/binlog archiving into BackupBucket; the restorable window is derived
+	// from the retained base backups and archived logs when the database is read.
+	BackupRetentionDays    int        `json:"backup_retention_days"`
+	BackupBucket           string     `json:"backup_bucket,omitempty"`
+	EarliestRestorableTime *time.Time `json:"earliest_restorable_time,omitempty"`
+	LatestRestorableTime   *time.Time `json:"latest_restorable_time,omitempty"`
+
+	// Maintenance. Version upgrades and parameter changes that need a restart
+	// wait in PendingVersion and PendingParameters until the weekly
+	// MaintenanceWindow ("ddd:HH:MM" UTC) unless they are applied immediately.
+	MaintenanceWindow string            `json:"maintenance_window"`
+	PendingVersion    string            `json:"pending_version,omitempty"`
+	PendingParameters map[string]string `json:"pending_parameters,omitempty"`
+}
+
+// PITREnabled reports whether continuous log archiving is configured.
+func (d *Database) PITREnabled() bool {
+	return d.BackupRetentionDays > 0 && d.BackupBucket != ""
 }
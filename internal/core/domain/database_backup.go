@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxDatabaseBackupRetentionDays caps the point-in-time recovery window.
+	MaxDatabaseBackupRetentionDays = 35
+	// DatabaseBaseBackupInterval is how often an automated base backup
+	// (volume snapshot) is taken for databases with point-in-time recovery.
+	DatabaseBaseBackupInterval = 24 * time.Hour
+)
+
+// DatabaseBackupKind distinguishes base backups from archived log files.
+type DatabaseBackupKind string
+
+const (
+	// DatabaseBackupBase is an automated volume snapshot that logs are replayed onto.
+	DatabaseBackupBase DatabaseBackupKind = "BASE"
+	// DatabaseBackupLog is a WAL segment (PostgreSQL) or binary log (MySQL)
+	// shipped to the database's backup bucket.
+	DatabaseBackupLog DatabaseBackupKind = "LOG"
+)
+
+// DatabaseBackup is one piece of a database's continuous backup chain.
+// For a base backup SnapshotID names the volume snapshot and ArchivedAt is
+// when it was taken; for MySQL and MariaDB, FileName and LogPosition are the
+// binary log coordinates the snapshot ends at, where replay starts. For a log
+// file ArchivedAt is when the engine closed the file, i.e. the latest moment
+// it covers.
+type DatabaseBackup struct {
+	ID          uuid.UUID          `json:"id"`
+	DatabaseID  uuid.UUID          `json:"database_id"`
+	TenantID    uuid.UUID          `json:"tenant_id"`
+	Kind        DatabaseBackupKind `json:"kind"`
+	SnapshotID  *uuid.UUID         `json:"snapshot_id,omitempty"`
+	FileName    string             `json:"file_name,omitempty"`
+	LogPosition int64              `json:"log_position,omitempty"`
+	ObjectKey   string             `json:"object_key,omitempty"`
+	SizeBytes   int64              `json:"size_bytes"`
+	ArchivedAt  time.Time          `json:"archived_at"`
+	CreatedAt   time.Time          `json:"created_at"`
+}
@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DatabaseBackupMethod selects how a scheduled backup is taken.
+type DatabaseBackupMethod string
+
+const (
+	// DatabaseBackupLogical dumps the database with pg_dump or mysqldump in a
+	// single transaction.
+	DatabaseBackupLogical DatabaseBackupMethod = "LOGICAL"
+	// DatabaseBackupPhysical snapshots the data volume and exports an archive
+	// of the snapshot's data directory. The snapshot is kept as well and can be
+	// restored with /databases/restore.
+	DatabaseBackupPhysical DatabaseBackupMethod = "PHYSICAL"
+)
+
+// AutomatedBackupStatus is the state of a scheduled backup.
+type AutomatedBackupStatus string
+
+const (
+	AutomatedBackupRunning   AutomatedBackupStatus = "RUNNING"
+	AutomatedBackupCompleted AutomatedBackupStatus = "COMPLETED"
+	AutomatedBackupFailed    AutomatedBackupStatus = "FAILED"
+)
+
+const (
+	// DefaultBackupWindowStart is the default start of the daily backup window (UTC).
+	DefaultBackupWindowStart = "03:00"
+	// DefaultBackupWindowMinutes is the default length of the backup window.
+	DefaultBackupWindowMinutes = 60
+	// MinBackupWindowMinutes is the shortest backup window allowed.
+	MinBackupWindowMinutes = 30
+	// DefaultBackupFrequencyHours takes one backup a day.
+	DefaultBackupFrequencyHours = 24
+	// MaxBackupFrequencyHours is the longest interval between backups (one week).
+	MaxBackupFrequencyHours = 168
+	// MaxBackupRetentionCount caps how many backups a policy keeps.
+	MaxBackupRetentionCount = 1000
+	// MaxBackupRetentionDays caps how long a policy keeps backups.
+	MaxBackupRetentionDays = 3650
+)
+
+// DatabaseBackupPolicy schedules automated backups of a database and exports
+// them to a bucket. Backups only start inside the daily window
+// [WindowStart, WindowStart+WindowMinutes) in UTC, at most every
+// FrequencyHours. Completed backups beyond RetentionCount or older than
+// RetentionDays are pruned; zero disables either limit.
+type DatabaseBackupPolicy struct {
+	DatabaseID     uuid.UUID            `json:"database_id"`
+	TenantID       uuid.UUID            `json:"tenant_id"`
+	Method         DatabaseBackupMethod `json:"method"`
+	WindowStart    string               `json:"window_start"`
+	WindowMinutes  int                  `json:"window_minutes"`
+	FrequencyHours int                  `json:"frequency_hours"`
+	RetentionCount int                  `json:"retention_count"`
+	RetentionDays  int                  `json:"retention_days"`
+	ExportBucket   string               `json:"export_bucket"`
+	NextBackupAt   time.Time            `json:"next_backup_at"`
+	LastBackupAt   *time.Time           `json:"last_backup_at,omitempty"`
+	CreatedAt      time.Time            `json:"created_at"`
+	UpdatedAt      time.Time            `json:"updated_at"`
+}
+
+// NextRun returns the earliest time at or after t that falls inside a backup
+// window. WindowStart must be a valid "15:04" time.
+func (p *DatabaseBackupPolicy) NextRun(t time.Time) time.Time {
+	start, err := time.Parse("15:04", p.WindowStart)
+	if err != nil {
+		return t
+	}
+	t = t.UTC()
+	offset := time.Duration(start.Hour())*time.Hour + time.Duration(start.Minute())*time.Minute
+	length := time.Duration(p.WindowMinutes) * time.Minute
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	// The window that started yesterday may still be open.
+	for ws := day.Add(offset - 24*time.Hour); ; ws = ws.Add(24 * time.Hour) {
+		if t.Before(ws.Add(length)) {
+			if t.Before(ws) {
+				return ws
+			}
+			return t
+		}
+	}
+}
+
+// DatabaseAutomatedBackup is one backup taken by a DatabaseBackupPolicy.
+// Encrypted exports start with the KMS-wrapped data key, so they can be
+// decrypted with KmsKeyID alone after the database is gone.
+type DatabaseAutomatedBackup struct {
+	ID          uuid.UUID             `json:"id"`
+	DatabaseID  uuid.UUID             `json:"database_id"`
+	TenantID    uuid.UUID             `json:"tenant_id"`
+	Method      DatabaseBackupMethod  `json:"method"`
+	Status      AutomatedBackupStatus `json:"status"`
+	SnapshotID  *uuid.UUID            `json:"snapshot_id,omitempty"`
+	Bucket      string                `json:"bucket"`
+	ObjectKey   string                `json:"object_key,omitempty"`
+	SizeBytes   int64                 `json:"size_bytes"`
+	KmsKeyID    string                `json:"kms_key_id,omitempty"`
+	Error       string                `json:"error,omitempty"`
+	StartedAt   time.Time             `json:"started_at"`
+	CompletedAt *time.Time            `json:"completed_at,omitempty"`
+}
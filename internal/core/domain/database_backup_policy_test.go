@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDatabaseBackupPolicy_NextRun(t *testing.T) {
+	day := func(d, h, m int) time.Time { return time.Date(2026, 10, d, h, m, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name   string
+		start  string
+		length int
+		at     time.Time
+		want   time.Time
+	}{
+		{name: "before window", start: "03:00", length: 60, at: day(18, 1, 0), want: day(18, 3, 0)},
+		{name: "inside window", start: "03:00", length: 60, at: day(18, 3, 20), want: day(18, 3, 20)},
+		{name: "after window", start: "03:00", length: 60, at: day(18, 4, 0), want: day(19, 3, 0)},
+		{name: "window across midnight", start: "23:30", length: 90, at: day(19, 0, 15), want: day(19, 0, 15)},
+		{name: "full day window", start: "00:00", length: 1440, at: day(18, 17, 5), want: day(18, 17, 5)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &DatabaseBackupPolicy{WindowStart: tt.start, WindowMinutes: tt.length}
+			assert.Equal(t, tt.want, p.NextRun(tt.at))
+		})
+	}
+}
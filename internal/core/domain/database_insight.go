@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DatabaseInsightsRetention is how long insight samples are kept.
+	DatabaseInsightsRetention = 7 * 24 * time.Hour
+	// DefaultDatabaseInsightsPeriod is the history returned when no period is given.
+	DefaultDatabaseInsightsPeriod = time.Hour
+	// DatabaseTopQueriesLimit is the number of top queries collected per database.
+	DatabaseTopQueriesLimit = 10
+	// MaxDatabaseInsightPoints caps the history returned for a period; longer
+	// periods are averaged into this many points.
+	MaxDatabaseInsightPoints = 360
+)
+
+// DatabaseQueryStat is the cumulative execution statistics of one normalized
+// query, as reported by pg_stat_statements or the MySQL performance schema
+// since statistics were last reset.
+type DatabaseQueryStat struct {
+	QueryID     string  `json:"query_id"`
+	Query       string  `json:"query"`
+	Calls       int64   `json:"calls"`
+	TotalTimeMs float64 `json:"total_time_ms"`
+	MeanTimeMs  float64 `json:"mean_time_ms"`
+	Rows        int64   `json:"rows"`
+}
+
+// DatabaseReplicaLag is how far a read replica trails its primary.
+type DatabaseReplicaLag struct {
+	ReplicaID  uuid.UUID `json:"replica_id"`
+	Name       string    `json:"name"`
+	LagSeconds float64   `json:"lag_seconds"`
+}
+
+// DatabaseInsightSample is one periodic measurement of a primary database
+// and its replicas.
+type DatabaseInsightSample struct {
+	ID             uuid.UUID            `json:"id"`
+	DatabaseID     uuid.UUID            `json:"database_id"`
+	TenantID       uuid.UUID            `json:"tenant_id"`
+	CollectedAt    time.Time            `json:"collected_at"`
+	Connections    int                  `json:"connections"`
+	MaxConnections int                  `json:"max_connections"`
+	CacheHitRatio  float64              `json:"cache_hit_ratio"`
+	ReplicaLag     []DatabaseReplicaLag `json:"replica_lag"`
+}
+
+// MaxReplicaLagSeconds returns the lag of the furthest behind replica.
+func (s *DatabaseInsightSample) MaxReplicaLagSeconds() float64 {
+	var lag float64
+	for _, r := range s.ReplicaLag {
+		if r.LagSeconds > lag {
+			lag = r.LagSeconds
+		}
+	}
+	return lag
+}
+
+// DatabaseInsightPoint is one point of the insight history of a database.
+type DatabaseInsightPoint struct {
+	Time                 time.Time `json:"time"`
+	Connections          float64   `json:"connections"`
+	CacheHitRatio        float64   `json:"cache_hit_ratio"`
+	MaxReplicaLagSeconds float64   `json:"max_replica_lag_seconds"`
+}
+
+// DatabaseInsights is the performance overview of a database: the latest
+// sample, the top queries by total execution time and the history of the
+// requested period.
+type DatabaseInsights struct {
+	DatabaseID     uuid.UUID              `json:"database_id"`
+	CollectedAt    *time.Time             `json:"collected_at,omitempty"`
+	Connections    int                    `json:"connections"`
+	MaxConnections int                    `json:"max_connections"`
+	CacheHitRatio  float64                `json:"cache_hit_ratio"`
+	ReplicaLag     []DatabaseReplicaLag   `json:"replica_lag"`
+	TopQueries     []DatabaseQueryStat    `json:"top_queries"`
+	History        []DatabaseInsightPoint `json:"history"`
+}
+
+// NewDatabaseInsights builds the insights of a database from its samples,
+// which must be ordered oldest first, and its latest top queries.
+func NewDatabaseInsights(databaseID uuid.UUID, samples []*DatabaseInsightSample, topQueries []DatabaseQueryStat) *DatabaseInsights {
+	insights := &DatabaseInsights{
+		DatabaseID: databaseID,
+		ReplicaLag: []DatabaseReplicaLag{},
+		TopQueries: topQueries,
+		History:    DownsampleDatabaseInsights(samples, MaxDatabaseInsightPoints),
+	}
+	if insights.TopQueries == nil {
+		insights.TopQueries = []DatabaseQueryStat{}
+	}
+	if len(samples) == 0 {
+		return insights
+	}
+
+	latest := samples[len(samples)-1]
+	collectedAt := latest.CollectedAt
+	insights.CollectedAt = &collectedAt
+	insights.Connections = latest.Connections
+	insights.MaxConnections = latest.MaxConnections
+	insights.CacheHitRatio = latest.CacheHitRatio
+	if latest.ReplicaLag != nil {
+		insights.ReplicaLag = latest.ReplicaLag
+	}
+	return insights
+}
+
+// DownsampleDatabaseInsights turns samples into at most maxPoints history
+// points. Consecutive samples are averaged together, except for replica lag,
+// where the worst value of each group is kept so that spikes stay visible.
+func DownsampleDatabaseInsights(samples []*DatabaseInsightSample, maxPoints int) []DatabaseInsightPoint {
+	points := make([]DatabaseInsightPoint, 0, min(len(samples), maxPoints))
+	if len(samples) == 0 || maxPoints <= 0 {
+		return points
+	}
+	size := (len(samples) + maxPoints - 1) / maxPoints
+	for start := 0; start < len(samples); start += size {
+		group := samples[start:min(start+size, len(samples))]
+		var p DatabaseInsightPoint
+		for _, s := range group {
+			p.Connections += float64(s.Connections)
+			p.CacheHitRatio += s.CacheHitRatio
+			p.MaxReplicaLagSeconds = max(p.MaxReplicaLagSeconds, s.MaxReplicaLagSeconds())
+		}
+		p.Time = group[0].CollectedAt
+		p.Connections /= float64(len(group))
+		p.CacheHitRatio /= float64(len(group))
+		points = append(points, p)
+	}
+	return points
+}
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDatabaseInsights(t *testing.T) {
+	id := uuid.New()
+	empty := NewDatabaseInsights(id, nil, nil)
+	assert.Nil(t, empty.CollectedAt)
+	assert.NotNil(t, empty.TopQueries)
+	assert.NotNil(t, empty.ReplicaLag)
+	assert.Empty(t, empty.History)
+
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	samples := []*DatabaseInsightSample{
+		{CollectedAt: start, Connections: 4, MaxConnections: 100, CacheHitRatio: 0.9},
+		{CollectedAt: start.Add(time.Minute), Connections: 6, MaxConnections: 100, CacheHitRatio: 0.95,
+			ReplicaLag: []DatabaseReplicaLag{{Name: "r1", LagSeconds: 0.5}, {Name: "r2", LagSeconds: 2}}},
+	}
+	insights := NewDatabaseInsights(id, samples, []DatabaseQueryStat{{Query: "SELECT 1"}})
+	require.NotNil(t, insights.CollectedAt)
+	assert.Equal(t, start.Add(time.Minute), *insights.CollectedAt)
+	assert.Equal(t, 6, insights.Connections)
+	assert.Len(t, insights.ReplicaLag, 2)
+	assert.Len(t, insights.TopQueries, 1)
+	require.Len(t, insights.History, 2)
+	assert.Equal(t, 2.0, insights.History[1].MaxReplicaLagSeconds)
+}
+
+func TestDownsampleDatabaseInsights(t *testing.T) {
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	var samples []*DatabaseInsightSample
+	for i := 0; i < 5; i++ {
+		s := &DatabaseInsightSample{CollectedAt: start.Add(time.Duration(i) * time.Minute), Connections: i * 2, CacheHitRatio: 1}
+		if i == 1 {
+			s.ReplicaLag = []DatabaseReplicaLag{{LagSeconds: 30}}
+		}
+		samples = append(samples, s)
+	}
+
+	points := DownsampleDatabaseInsights(samples, 2)
+	require.Len(t, points, 2)
+	assert.Equal(t, start, points[0].Time)
+	assert.Equal(t, 2.0, points[0].Connections)
+	assert.Equal(t, 30.0, points[0].MaxReplicaLagSeconds)
+	assert.Equal(t, start.Add(3*time.Minute), points[1].Time)
+	assert.Equal(t, 7.0, points[1].Connections)
+	assert.Equal(t, 1.0, points[1].CacheHitRatio)
+
+	assert.Len(t, DownsampleDatabaseInsights(samples, 10), 5)
+}
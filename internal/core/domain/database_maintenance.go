@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultMaintenanceWindow is the weekly maintenance window given to new
+	// databases: Sunday 03:00 UTC.
+	DefaultMaintenanceWindow = "sun:03:00"
+	// MaintenanceWindowDuration is how long a maintenance window stays open.
+	MaintenanceWindowDuration = time.Hour
+)
+
+var maintenanceWeekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// ParseMaintenanceWindow parses a weekly window in "ddd:HH:MM" form (UTC),
+// e.g. "sun:03:00", and returns the day and the offset into that day.
+func ParseMaintenanceWindow(window string) (time.Weekday, time.Duration, error) {
+	day, clock, ok := strings.Cut(strings.ToLower(window), ":")
+	weekday, known := maintenanceWeekdays[day]
+	if !ok || !known {
+		return 0, 0, fmt.Errorf("invalid maintenance window %q: expected ddd:HH:MM", window)
+	}
+	t, err := time.Parse("15:04", clock)
+	if err != nil || len(clock) != 5 {
+		return 0, 0, fmt.Errorf("invalid maintenance window %q: expected ddd:HH:MM", window)
+	}
+	return weekday, time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// NextMaintenance returns the earliest time at or after t that falls inside
+// the database's maintenance window. An unparsable window is treated as the
+// default one.
+func (d *Database) NextMaintenance(t time.Time) time.Time {
+	weekday, offset, err := ParseMaintenanceWindow(d.MaintenanceWindow)
+	if err != nil {
+		weekday, offset, _ = ParseMaintenanceWindow(DefaultMaintenanceWindow)
+	}
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	back := (int(day.Weekday()) - int(weekday) + 7) % 7
+	// The window that started earlier this week may still be open.
+	for ws := day.AddDate(0, 0, -back).Add(offset); ; ws = ws.AddDate(0, 0, 7) {
+		if t.Before(ws.Add(MaintenanceWindowDuration)) {
+			if t.Before(ws) {
+				return ws
+			}
+			return t
+		}
+	}
+}
+
+// InMaintenanceWindow reports whether t falls inside the maintenance window.
+func (d *Database) InMaintenanceWindow(t time.Time) bool {
+	return d.NextMaintenance(t).Equal(t.UTC())
+}
+
+// HasPendingMaintenance reports whether a version change or restart-bound
+// parameter change is waiting for the maintenance window.
+func (d *Database) HasPendingMaintenance() bool {
+	return d.PendingVersion != "" || d.PendingParameters != nil
+}
+
+// MajorVersion returns the major version of an engine version string:
+// "16" for Postgres "16.2", "8.4" for MySQL "8.4.1" and "11.4" for MariaDB
+// "11.4.2". Postgres releases before 10 used two components for the major
+// version as well.
+func MajorVersion(engine DatabaseEngine, version string) string {
+	parts := strings.Split(version, ".")
+	n := 2
+	if engine == EnginePostgres {
+		if major, err := strconv.Atoi(parts[0]); err == nil && major >= 10 {
+			n = 1
+		}
+	}
+	if len(parts) < n {
+		n = len(parts)
+	}
+	return strings.Join(parts[:n], ".")
+}
+
+// CompareVersions compares two dotted version strings numerically and
+// returns -1, 0 or 1. Missing components count as zero, so "16" == "16.0".
+// Non-numeric components compare as zero.
+func CompareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	}
+	return 0
+}
+
+// DatabaseUpgradeStatus is the state of a version upgrade.
+type DatabaseUpgradeStatus string
+
+const (
+	DatabaseUpgradeInProgress DatabaseUpgradeStatus = "IN_PROGRESS"
+	DatabaseUpgradeCompleted  DatabaseUpgradeStatus = "COMPLETED"
+	DatabaseUpgradeFailed     DatabaseUpgradeStatus = "FAILED"
+)
+
+// DatabaseUpgrade records one engine version change of a database and its
+// replicas. SnapshotID is the pre-upgrade snapshot of the primary's volume,
+// kept so a failed upgrade can be rolled back with /databases/restore.
+type DatabaseUpgrade struct {
+	ID          uuid.UUID             `json:"id"`
+	DatabaseID  uuid.UUID             `json:"database_id"`
+	TenantID    uuid.UUID             `json:"tenant_id"`
+	FromVersion string                `json:"from_version"`
+	ToVersion   string                `json:"to_version"`
+	Major       bool                  `json:"major"`
+	Status      DatabaseUpgradeStatus `json:"status"`
+	SnapshotID  *uuid.UUID            `json:"snapshot_id,omitempty"`
+	Error       string                `json:"error,omitempty"`
+	StartedAt   time.Time             `json:"started_at"`
+	CompletedAt *time.Time            `json:"completed_at,omitempty"`
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMaintenanceWindow(t *testing.T) {
+	day, offset, err := ParseMaintenanceWindow("Wed:22:30")
+	require.NoError(t, err)
+	assert.Equal(t, time.Wednesday, day)
+	assert.Equal(t, 22*time.Hour+30*time.Minute, offset)
+
+	for _, bad := range []string{"", "sun", "sunday:03:00", "sun:3:00", "sun:25:00", "xyz:03:00"} {
+		_, _, err := ParseMaintenanceWindow(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestDatabase_NextMaintenance(t *testing.T) {
+	// 2026-10-18 is a Sunday.
+	day := func(d, h, m int) time.Time { return time.Date(2026, 10, d, h, m, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name   string
+		window string
+		at     time.Time
+		want   time.Time
+	}{
+		{name: "later this week", window: "sun:03:00", at: day(14, 12, 0), want: day(18, 3, 0)},
+		{name: "inside window", window: "sun:03:00", at: day(18, 3, 45), want: day(18, 3, 45)},
+		{name: "just missed", window: "sun:03:00", at: day(18, 4, 0), want: day(25, 3, 0)},
+		{name: "window across midnight", window: "sat:23:30", at: day(18, 0, 15), want: day(18, 0, 15)},
+		{name: "invalid falls back to default", window: "bogus", at: day(15, 0, 0), want: day(18, 3, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := &Database{MaintenanceWindow: tt.window}
+			assert.Equal(t, tt.want, db.NextMaintenance(tt.at))
+			assert.Equal(t, tt.want.Equal(tt.at), db.InMaintenanceWindow(tt.at))
+		})
+	}
+}
+
+func TestMajorVersion(t *testing.T) {
+	assert.Equal(t, "16", MajorVersion(EnginePostgres, "16.2"))
+	assert.Equal(t, "16", MajorVersion(EnginePostgres, "16"))
+	assert.Equal(t, "9.6", MajorVersion(EnginePostgres, "9.6.24"))
+	assert.Equal(t, "8.0", MajorVersion(EngineMySQL, "8.0.36"))
+	assert.Equal(t, "8", MajorVersion(EngineMySQL, "8"))
+	assert.Equal(t, "11.4", MajorVersion(EngineMariaDB, "11.4.2"))
+}
+
+func TestCompareVersions(t *testing.T) {
+	assert.Equal(t, 0, CompareVersions("16", "16.0"))
+	assert.Equal(t, -1, CompareVersions("15.4", "16"))
+	assert.Equal(t, 1, CompareVersions("8.0.10", "8.0.9"))
+	assert.Equal(t, -1, CompareVersions("8.0", "8.4"))
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DatabasePrivilege is the level of access a grant gives on a logical database.
+type DatabasePrivilege string
+
+const (
+	// DatabasePrivilegeReadOnly allows reading tables and views.
+	DatabasePrivilegeReadOnly DatabasePrivilege = "READ_ONLY"
+	// DatabasePrivilegeReadWrite adds inserting, updating and deleting rows.
+	DatabasePrivilegeReadWrite DatabasePrivilege = "READ_WRITE"
+	// DatabasePrivilegeAll allows schema changes as well, for migration users.
+	DatabasePrivilegeAll DatabasePrivilege = "ALL"
+)
+
+// MaxDatabaseUsers caps the number of additional users per database.
+const MaxDatabaseUsers = 50
+
+// databaseIdentifierPattern matches names that are valid unquoted identifiers
+// in both Postgres and MySQL, so names never need escaping in either engine.
+var databaseIdentifierPattern = regexp.MustCompile(`^[a-z_][a-z0-9_]{0,31}$`)
+
+// reservedDatabaseNames are system databases and roles that cannot be managed
+// through the API.
+var reservedDatabaseNames = map[string]bool{
+	"postgres":           true,
+	"template0":          true,
+	"template1":          true,
+	"root":               true,
+	"mysql":              true,
+	"sys":                true,
+	"information_schema": true,
+	"performance_schema": true,
+}
+
+// ValidateDatabaseIdentifier checks a user or logical database name: lowercase
+// letters, digits and underscores, at most 32 characters, not starting with a
+// digit and not a reserved system name. kind names the identifier in errors.
+func ValidateDatabaseIdentifier(kind, name string) error {
+	if !databaseIdentifierPattern.MatchString(name) {
+		return fmt.Errorf("invalid %s name %q: use up to 32 lowercase letters, digits and underscores", kind, name)
+	}
+	if reservedDatabaseNames[name] || strings.HasPrefix(name, "pg_") {
+		return fmt.Errorf("%s name %q is reserved", kind, name)
+	}
+	return nil
+}
+
+// IsValid reports whether p is a known privilege.
+func (p DatabasePrivilege) IsValid() bool {
+	switch p {
+	case DatabasePrivilegeReadOnly, DatabasePrivilegeReadWrite, DatabasePrivilegeAll:
+		return true
+	}
+	return false
+}
+
+// LogicalDatabase is a database created inside a managed database instance in
+// addition to the one created with it. It is owned by the master user; other
+// users reach it through grants.
+type LogicalDatabase struct {
+	ID         uuid.UUID `json:"id"`
+	DatabaseID uuid.UUID `json:"database_id"`
+	TenantID   uuid.UUID `json:"tenant_id"`
+	Name       string    `json:"name"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// DatabaseGrant gives a user a privilege on one logical database.
+type DatabaseGrant struct {
+	Database  string            `json:"database"`
+	Privilege DatabasePrivilege `json:"privilege"`
+}
+
+// DatabaseUser is an additional login role of a managed database. Its
+// password lives in the secrets manager at CredentialPath and is only
+// returned when the user is created or its credentials are rotated.
+type DatabaseUser struct {
+	ID                uuid.UUID       `json:"id"`
+	DatabaseID        uuid.UUID       `json:"database_id"`
+	TenantID          uuid.UUID       `json:"tenant_id"`
+	Username          string          `json:"username"`
+	Password          string          `json:"password,omitempty"`
+	Grants            []DatabaseGrant `json:"grants"`
+	CredentialPath    string          `json:"-"`
+	CredentialVersion int             `json:"credential_version"`
+	CreatedAt         time.Time       `json:"created_at"`
+	UpdatedAt         time.Time       `json:"updated_at"`
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateDatabaseIdentifier(t *testing.T) {
+	for _, ok := range []string{"app", "app_ro", "_migrations", "reporting2"} {
+		assert.NoError(t, ValidateDatabaseIdentifier("user", ok), ok)
+	}
+	for _, bad := range []string{"", "App", "2fast", "app-ro", "app ro", "a;drop", "postgres", "root", "pg_monitor", "information_schema",
+		"abcdefghijklmnopqrstuvwxyz0123456"} {
+		assert.Error(t, ValidateDatabaseIdentifier("user", bad), bad)
+	}
+}
+
+func TestDatabasePrivilegeIsValid(t *testing.T) {
+	assert.True(t, DatabasePrivilegeReadOnly.IsValid())
+	assert.True(t, DatabasePrivilegeReadWrite.IsValid())
+	assert.True(t, DatabasePrivilegeAll.IsValid())
+	assert.False(t, DatabasePrivilege("SUPERUSER").IsValid())
+}
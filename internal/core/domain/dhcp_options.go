@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxDHCPOptionServers bounds the number of DNS or NTP servers in a DHCP options set.
+const MaxDHCPOptionServers = 4
+
+// DHCPOptionsSet is the network configuration handed to instances of the VPCs it
+// is associated with: the search domain, the nameservers (which take precedence
+// over the VPC resolver) and the NTP servers. A VPC has at most one options set;
+// a set can be shared by several VPCs of the same tenant.
+type DHCPOptionsSet struct {
+	ID                uuid.UUID `json:"id"`
+	UserID            uuid.UUID `json:"user_id"`
+	TenantID          uuid.UUID `json:"tenant_id"`
+	Name              string    `json:"name"`
+	DomainName        string    `json:"domain_name,omitempty"`         // e.g., "corp.internal"
+	DomainNameServers []string  `json:"domain_name_servers,omitempty"` // IP addresses
+	NTPServers        []string  `json:"ntp_servers,omitempty"`         // IP addresses
+	ARN               string    `json:"arn"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+// Validate checks the name and options of a DHCP options set. At least one
+// option must be set.
+func (d *DHCPOptionsSet) Validate() error {
+	if strings.TrimSpace(d.Name) == "" {
+		return errors.New("DHCP options set name cannot be empty")
+	}
+	if d.DomainName == "" && len(d.DomainNameServers) == 0 && len(d.NTPServers) == 0 {
+		return errors.New("DHCP options set requires a domain name, DNS servers or NTP servers")
+	}
+	if d.DomainName != "" {
+		name := strings.TrimSuffix(strings.ToLower(d.DomainName), ".")
+		if len(name) > 253 {
+			return errors.New("DHCP options domain name is too long")
+		}
+		for _, label := range strings.Split(name, ".") {
+			if !isValidDNSLabel(label) {
+				return fmt.Errorf("invalid domain name %q", d.DomainName)
+			}
+		}
+	}
+	if err := validateDHCPServers("DNS", d.DomainNameServers); err != nil {
+		return err
+	}
+	return validateDHCPServers("NTP", d.NTPServers)
+}
+
+func validateDHCPServers(kind string, servers []string) error {
+	if len(servers) > MaxDHCPOptionServers {
+		return fmt.Errorf("DHCP options set supports at most %d %s servers", MaxDHCPOptionServers, kind)
+	}
+	for _, server := range servers {
+		if net.ParseIP(server) == nil {
+			return fmt.Errorf("invalid %s server %q: must be an IP address", kind, server)
+		}
+	}
+	return nil
+}
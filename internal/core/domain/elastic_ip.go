@@ -15,7 +15,8 @@ const (
 	// EIPStatusAllocated indicates the IP is reserved by a user but not attached to any resource.
 	EIPStatusAllocated ElasticIPStatus = "allocated"
 
-	// EIPStatusAssociated indicates the IP is currently mapped to a compute instance.
+	// EIPStatusAssociated indicates the IP is currently mapped to a compute instance
+	// or to a private IP of a network interface.
 	EIPStatusAssociated ElasticIPStatus = "associated"
 
 	// EIPStatusReleased indicates the IP has been returned to the pool (soft delete state).
@@ -23,17 +24,23 @@ const (
 )
 
 // ElasticIP represents a static public IP address that can be remapped between instances.
+//
+// An Elastic IP maps either to an instance's primary private IP (InstanceID) or
+// to one private IP of a network interface (NetworkInterfaceID and PrivateIP).
+// In the latter case it follows the interface when it moves between instances.
 type ElasticIP struct {
-	ID         uuid.UUID       `json:"id"`
-	UserID     uuid.UUID       `json:"user_id"`
-	TenantID   uuid.UUID       `json:"tenant_id"`
-	PublicIP   string          `json:"public_ip"`
-	InstanceID *uuid.UUID      `json:"instance_id,omitempty"`
-	VpcID      *uuid.UUID      `json:"vpc_id,omitempty"`
-	Status     ElasticIPStatus `json:"status"`
-	ARN        string          `json:"arn"` // arn:thecloud:vpc:{region}:{user}:eip/{id}
-	CreatedAt  time.Time       `json:"created_at"`
-	UpdatedAt  time.Time       `json:"updated_at"`
+	ID                 uuid.UUID       `json:"id"`
+	UserID             uuid.UUID       `json:"user_id"`
+	TenantID           uuid.UUID       `json:"tenant_id"`
+	PublicIP           string          `json:"public_ip"`
+	InstanceID         *uuid.UUID      `json:"instance_id,omitempty"`
+	VpcID              *uuid.UUID      `json:"vpc_id,omitempty"`
+	NetworkInterfaceID *uuid.UUID      `json:"network_interface_id,omitempty"`
+	PrivateIP          string          `json:"private_ip,omitempty"`
+	Status             ElasticIPStatus `json:"status"`
+	ARN                string          `json:"arn"` // arn:thecloud:vpc:{region}:{user}:eip/{id}
+	CreatedAt          time.Time       `json:"created_at"`
+	UpdatedAt          time.Time       `json:"updated_at"`
 }
 
 // Validate checks if the Elastic IP model is valid.
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FlowEntry is one OpenFlow rule on a VPC bridge, as programmed or as observed.
+type FlowEntry struct {
+	Cookie   string `json:"cookie,omitempty"` // hex cookie tying the flow to the rule or route that owns it
+	Priority int    `json:"priority"`
+	Match    string `json:"match"`
+	Actions  string `json:"actions"`
+}
+
+// FlowDiff compares the flows a VPC should have with the flows installed on its bridge.
+// Unexpected only lists flows carrying a cookie; unmanaged flows are left alone.
+type FlowDiff struct {
+	VPCID      uuid.UUID   `json:"vpc_id"`
+	Bridge     string      `json:"bridge"`
+	Missing    []FlowEntry `json:"missing"`
+	Unexpected []FlowEntry `json:"unexpected"`
+	InSync     bool        `json:"in_sync"`
+	Repaired   bool        `json:"repaired"`
+	CheckedAt  time.Time   `json:"checked_at"`
+}
@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FlowLogResourceType is the kind of resource a flow log captures traffic for.
+type FlowLogResourceType string
+
+const (
+	FlowLogResourceVPC      FlowLogResourceType = "vpc"
+	FlowLogResourceSubnet   FlowLogResourceType = "subnet"
+	FlowLogResourceInstance FlowLogResourceType = "instance"
+)
+
+// FlowLogTrafficType selects which verdicts a flow log records.
+type FlowLogTrafficType string
+
+const (
+	FlowLogTrafficAccept FlowLogTrafficType = "ACCEPT"
+	FlowLogTrafficReject FlowLogTrafficType = "REJECT"
+	FlowLogTrafficAll    FlowLogTrafficType = "ALL"
+)
+
+// FlowLogDestination is where captured records are delivered.
+type FlowLogDestination string
+
+const (
+	FlowLogDestinationCloudLogs FlowLogDestination = "cloudlogs"
+	FlowLogDestinationBucket    FlowLogDestination = "bucket"
+)
+
+// FlowLogStatus reports whether the last capture was delivered.
+type FlowLogStatus string
+
+const (
+	FlowLogStatusActive FlowLogStatus = "ACTIVE"
+	FlowLogStatusError  FlowLogStatus = "ERROR"
+)
+
+// FlowLogResourceTypeLog is the CloudLogs resource type flow log records are ingested under.
+const FlowLogResourceTypeLog = "flow_log"
+
+// Aggregation intervals a flow log may use, in seconds.
+const (
+	FlowLogIntervalShort = 60
+	FlowLogIntervalLong  = 600
+)
+
+// FlowLog captures accepted and rejected traffic of a VPC, subnet or instance.
+// Records are aggregated over the interval and shipped to CloudLogs or a bucket.
+type FlowLog struct {
+	ID              uuid.UUID           `json:"id"`
+	UserID          uuid.UUID           `json:"user_id"`
+	TenantID        uuid.UUID           `json:"tenant_id"`
+	ResourceType    FlowLogResourceType `json:"resource_type"`
+	ResourceID      uuid.UUID           `json:"resource_id"`
+	VPCID           uuid.UUID           `json:"vpc_id"`
+	TrafficType     FlowLogTrafficType  `json:"traffic_type"`
+	Destination     FlowLogDestination  `json:"destination"`
+	Bucket          string              `json:"bucket,omitempty"`
+	IntervalSeconds int                 `json:"interval_seconds"`
+	Status          FlowLogStatus       `json:"status"`
+	LastError       string              `json:"last_error,omitempty"`
+	LastCapturedAt  *time.Time          `json:"last_captured_at,omitempty"`
+	CreatedAt       time.Time           `json:"created_at"`
+}
+
+// IsValid reports whether t is a known resource type.
+func (t FlowLogResourceType) IsValid() bool {
+	switch t {
+	case FlowLogResourceVPC, FlowLogResourceSubnet, FlowLogResourceInstance:
+		return true
+	}
+	return false
+}
+
+// IsValid reports whether t is a known traffic type.
+func (t FlowLogTrafficType) IsValid() bool {
+	switch t {
+	case FlowLogTrafficAccept, FlowLogTrafficReject, FlowLogTrafficAll:
+		return true
+	}
+	return false
+}
+
+// Includes reports whether records with the given verdict are captured.
+func (t FlowLogTrafficType) Includes(action FlowLogTrafficType) bool {
+	return t == FlowLogTrafficAll || t == action
+}
+
+// IsValid reports whether d is a known destination.
+func (d FlowLogDestination) IsValid() bool {
+	return d == FlowLogDestinationCloudLogs || d == FlowLogDestinationBucket
+}
+
+// CaptureDue reports whether the aggregation interval has elapsed since the last capture.
+func (f *FlowLog) CaptureDue(now time.Time) bool {
+	since := f.CreatedAt
+	if f.LastCapturedAt != nil {
+		since = *f.LastCapturedAt
+	}
+	return !now.Before(since.Add(time.Duration(f.IntervalSeconds) * time.Second))
+}
+
+// FlowLogRecord is the traffic of one connection or drop rule over one aggregation interval.
+type FlowLogRecord struct {
+	Protocol string             `json:"protocol"`
+	Src      string             `json:"src"`
+	Dst      string             `json:"dst"`
+	SrcPort  int                `json:"src_port"`
+	DstPort  int                `json:"dst_port"`
+	Packets  uint64             `json:"packets"`
+	Bytes    uint64             `json:"bytes"`
+	Start    time.Time          `json:"start"`
+	End      time.Time          `json:"end"`
+	Action   FlowLogTrafficType `json:"action"`
+}
+
+// String formats the record as one space-separated line:
+// src dst src_port dst_port protocol packets bytes start end action.
+// Unknown addresses are written as "-" and times as Unix seconds.
+func (r FlowLogRecord) String() string {
+	return fmt.Sprintf("%s %s %d %d %s %d %d %d %d %s",
+		orDash(r.Src), orDash(r.Dst), r.SrcPort, r.DstPort, r.Protocol,
+		r.Packets, r.Bytes, r.Start.Unix(), r.End.Unix(), r.Action)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -119,6 +119,20 @@ func TestFunctionUpdateValidate(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("env_var_secret_ref_pinned_stage", func(t *testing.T) {
+		u := &FunctionUpdate{
+			EnvVars: []*EnvVar{{Key: "API_KEY", SecretRef: "@my-secret:previous"}},
+		}
+		assert.NoError(t, u.Validate())
+	})
+
+	t.Run("env_var_secret_ref_colon_in_name", func(t *testing.T) {
+		u := &FunctionUpdate{
+			EnvVars: []*EnvVar{{Key: "DB_URL", SecretRef: "@db:url"}},
+		}
+		assert.NoError(t, u.Validate())
+	})
+
 	t.Run("env_var_mixed_one_invalid", func(t *testing.T) {
 		u := &FunctionUpdate{
 			EnvVars: []*EnvVar{
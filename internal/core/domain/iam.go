@@ -1,6 +1,9 @@
 package domain
 
 import (
+	"strings"
+	"time"
+
 	"github.com/google/uuid"
 )
 
@@ -17,9 +20,12 @@ const (
 type Condition map[string]map[string]interface{}
 
 // Statement is a single rule within a policy.
+// Principal is only meaningful in resource policies, where it names who the
+// statement applies to ("*", "tenant:<id>" or "user:<id>").
 type Statement struct {
 	Sid       string       `json:"sid,omitempty"`
 	Effect    PolicyEffect `json:"effect"`
+	Principal []string     `json:"principal,omitempty"`
 	Action    []string     `json:"action"`
 	Resource  []string     `json:"resource"`
 	Condition Condition    `json:"condition,omitempty"`
@@ -45,3 +51,59 @@ type RolePolicy struct {
 	RoleName string    `json:"role_name"`
 	PolicyID uuid.UUID `json:"policy_id"`
 }
+
+// Principal prefixes used in resource policy statements.
+const (
+	PrincipalAny          = "*"
+	PrincipalTenantPrefix = "tenant:"
+	PrincipalUserPrefix   = "user:"
+)
+
+// ResourcePolicyType identifies the kind of resource a resource policy is attached to.
+type ResourcePolicyType string
+
+const (
+	ResourcePolicyBucket ResourcePolicyType = "bucket"
+	ResourcePolicyQueue  ResourcePolicyType = "queue"
+	ResourcePolicyTopic  ResourcePolicyType = "topic"
+	ResourcePolicySecret ResourcePolicyType = "secret"
+)
+
+// IsValid reports whether t is a supported resource policy target.
+func (t ResourcePolicyType) IsValid() bool {
+	switch t {
+	case ResourcePolicyBucket, ResourcePolicyQueue, ResourcePolicyTopic, ResourcePolicySecret:
+		return true
+	}
+	return false
+}
+
+// ResourcePolicyTypeForPermission maps a permission to the resource type whose
+// resource policies must be consulted when authorizing it.
+func ResourcePolicyTypeForPermission(p Permission) (ResourcePolicyType, bool) {
+	service, _, _ := strings.Cut(string(p), ":")
+	switch service {
+	case "storage":
+		return ResourcePolicyBucket, true
+	case "queue":
+		return ResourcePolicyQueue, true
+	case "notify":
+		return ResourcePolicyTopic, true
+	case "secret":
+		return ResourcePolicySecret, true
+	}
+	return "", false
+}
+
+// ResourcePolicy is a policy attached directly to a resource. Unlike identity
+// policies it names its principals, which lets the owning tenant grant access
+// to users of other tenants.
+type ResourcePolicy struct {
+	ID           uuid.UUID          `json:"id"`
+	TenantID     uuid.UUID          `json:"tenant_id"`
+	ResourceType ResourcePolicyType `json:"resource_type"`
+	ResourceID   string             `json:"resource_id"`
+	Statements   []Statement        `json:"statements"`
+	CreatedAt    time.Time          `json:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at"`
+}
@@ -71,8 +71,9 @@ type Instance struct {
 	Ports        string            `json:"ports,omitempty"`  // "host:container" mappings
 	VpcID        *uuid.UUID        `json:"vpc_id,omitempty"` // Optional VPC attachment
 	SubnetID     *uuid.UUID        `json:"subnet_id,omitempty"`
-	PrivateIP    string            `json:"private_ip,omitempty"` // VPC private IP
-	OvsPort      string            `json:"ovs_port,omitempty"`   // OVS port name
+	PrivateIP    string            `json:"private_ip,omitempty"`   // VPC private IP
+	PrivateIPv6  string            `json:"private_ipv6,omitempty"` // Assigned when the subnet has an IPv6 block
+	OvsPort      string            `json:"ovs_port,omitempty"`     // OVS port name
 	InstanceType string            `json:"instance_type,omitempty"`
 	VolumeBinds  []string          `json:"volume_binds,omitempty"`
 	Env          []string          `json:"env,omitempty"`
@@ -91,15 +92,15 @@ type Instance struct {
 // InstanceStats contains real-time resource usage metrics.
 // Values are instantaneous snapshots from the compute backend.
 type InstanceStats struct {
-	CPUPercentage       float64 `json:"cpu_percentage"`
-	MemoryUsageBytes    float64 `json:"memory_usage_bytes"`
-	MemoryLimitBytes    float64 `json:"memory_limit_bytes"`
-	MemoryPercentage    float64 `json:"memory_percentage"`
-	NetworkRxBytes      *uint64 `json:"network_rx_bytes,omitempty"`
-	NetworkTxBytes      *uint64 `json:"network_tx_bytes,omitempty"`
-	DiskReadBytes       *uint64 `json:"disk_read_bytes,omitempty"`
-	DiskWriteBytes      *uint64 `json:"disk_write_bytes,omitempty"`
-	CPUTimeNanoseconds  *uint64 `json:"cpu_time_nanoseconds,omitempty"` // only populated by Libvirt backend; Docker uses delta-based percentage instead
+	CPUPercentage      float64 `json:"cpu_percentage"`
+	MemoryUsageBytes   float64 `json:"memory_usage_bytes"`
+	MemoryLimitBytes   float64 `json:"memory_limit_bytes"`
+	MemoryPercentage   float64 `json:"memory_percentage"`
+	NetworkRxBytes     *uint64 `json:"network_rx_bytes,omitempty"`
+	NetworkTxBytes     *uint64 `json:"network_tx_bytes,omitempty"`
+	DiskReadBytes      *uint64 `json:"disk_read_bytes,omitempty"`
+	DiskWriteBytes     *uint64 `json:"disk_write_bytes,omitempty"`
+	CPUTimeNanoseconds *uint64 `json:"cpu_time_nanoseconds,omitempty"` // only populated by Libvirt backend; Docker uses delta-based percentage instead
 }
 
 // RawDockerStats mirrors Docker's stats payload for CPU/memory calculations.
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InstanceMetadataAddr is the link-local address instances reach the metadata service on.
+const InstanceMetadataAddr = "169.254.169.254"
+
+// MetadataCaller identifies the instance calling the metadata service: the VPC
+// whose listener received the request and the source address of the request.
+// VPCID is nil for instances outside any VPC. Private addresses are only unique
+// within a VPC, so the address alone does not identify an instance.
+type MetadataCaller struct {
+	VPCID *uuid.UUID
+	IP    string
+}
+
+// InstanceMetadataConfig is the launch-time configuration served to an instance
+// by the metadata service that is not part of the instance record itself.
+type InstanceMetadataConfig struct {
+	InstanceID       uuid.UUID  `json:"instance_id"`
+	TenantID         uuid.UUID  `json:"tenant_id"`
+	UserData         string     `json:"-"`
+	ServiceAccountID *uuid.UUID `json:"service_account_id,omitempty"`
+}
+
+// InstanceNetworkMetadata describes the network an instance is attached to,
+// including the DHCP options of its VPC.
+type InstanceNetworkMetadata struct {
+	VPCID       *uuid.UUID `json:"vpc_id,omitempty"`
+	SubnetID    *uuid.UUID `json:"subnet_id,omitempty"`
+	SubnetCIDR  string     `json:"subnet_cidr,omitempty"`
+	Gateway     string     `json:"gateway,omitempty"`
+	PrivateIP   string     `json:"private_ip,omitempty"`
+	PrivateIPv6 string     `json:"private_ipv6,omitempty"`
+	DomainName  string     `json:"domain_name,omitempty"`
+	DNSServers  []string   `json:"dns_servers,omitempty"`
+	NTPServers  []string   `json:"ntp_servers,omitempty"`
+}
+
+// InstanceMetadata is the document an instance reads about itself from the
+// metadata service.
+type InstanceMetadata struct {
+	InstanceID       uuid.UUID               `json:"instance_id"`
+	Name             string                  `json:"name"`
+	Image            string                  `json:"image"`
+	InstanceType     string                  `json:"instance_type,omitempty"`
+	Tags             map[string]string       `json:"tags"`
+	Network          InstanceNetworkMetadata `json:"network"`
+	ServiceAccountID *uuid.UUID              `json:"service_account_id,omitempty"`
+	Preemption       *PreemptionNotice       `json:"preemption,omitempty"`
+	UserData         string                  `json:"-"`
+}
+
+// InstanceCredentials are short-lived credentials for the service account
+// attached to an instance.
+type InstanceCredentials struct {
+	ServiceAccountID uuid.UUID `json:"service_account_id"`
+	AccessToken      string    `json:"access_token"`
+	TokenType        string    `json:"token_type"`
+	Expiration       time.Time `json:"expiration"`
+}
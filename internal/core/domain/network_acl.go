@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NetworkACLAction is the verdict of a network ACL rule.
+type NetworkACLAction string
+
+const (
+	NetworkACLAllow NetworkACLAction = "allow"
+	NetworkACLDeny  NetworkACLAction = "deny"
+)
+
+const (
+	// NetworkACLMinRuleNumber and NetworkACLMaxRuleNumber bound rule numbers; lower numbers are evaluated first.
+	NetworkACLMinRuleNumber = 1
+	NetworkACLMaxRuleNumber = 32766
+	// NetworkACLDefaultRuleNumber is the number of the IPv4 allow-all rules in a VPC's
+	// default ACL; the IPv6 ones follow it.
+	NetworkACLDefaultRuleNumber = 100
+)
+
+// NetworkACL is a stateless, subnet-level firewall made of ordered allow/deny rules.
+// Every subnet uses exactly one ACL: the one it is associated with, or its VPC's default ACL.
+type NetworkACL struct {
+	ID           uuid.UUID               `json:"id"`
+	VPCID        uuid.UUID               `json:"vpc_id"`
+	Name         string                  `json:"name"`
+	IsDefault    bool                    `json:"is_default"`
+	Rules        []NetworkACLRule        `json:"rules,omitempty"`
+	Associations []NetworkACLAssociation `json:"associations,omitempty"`
+	CreatedAt    time.Time               `json:"created_at"`
+}
+
+// Validate checks if the network ACL fields are valid.
+func (a *NetworkACL) Validate() error {
+	if a.Name == "" {
+		return errors.New("network ACL name cannot be empty")
+	}
+	if a.VPCID == uuid.Nil {
+		return errors.New("network ACL must be associated with a VPC")
+	}
+	return nil
+}
+
+// NetworkACLRule matches traffic entering (ingress) or leaving (egress) a subnet.
+// The first rule that matches, by ascending rule number, decides; traffic no rule
+// matches is denied. Ports always refer to the destination port, so return traffic
+// needs its own rule. A rule only matches traffic of its CIDR's address family.
+type NetworkACLRule struct {
+	ID         uuid.UUID        `json:"id"`
+	ACLID      uuid.UUID        `json:"acl_id"`
+	RuleNumber int              `json:"rule_number"`
+	Direction  RuleDirection    `json:"direction"`
+	Protocol   string           `json:"protocol"` // "tcp", "udp", "icmp" or "all"
+	PortMin    int              `json:"port_min,omitempty"`
+	PortMax    int              `json:"port_max,omitempty"`
+	CIDR       string           `json:"cidr"` // Source for ingress, destination for egress; IPv4 or IPv6
+	Action     NetworkACLAction `json:"action"`
+	CreatedAt  time.Time        `json:"created_at"`
+}
+
+// Validate checks if the network ACL rule fields are valid.
+func (r *NetworkACLRule) Validate() error {
+	if r.RuleNumber < NetworkACLMinRuleNumber || r.RuleNumber > NetworkACLMaxRuleNumber {
+		return fmt.Errorf("rule_number must be between %d and %d", NetworkACLMinRuleNumber, NetworkACLMaxRuleNumber)
+	}
+	if r.Direction != RuleIngress && r.Direction != RuleEgress {
+		return fmt.Errorf("invalid rule direction: %s", r.Direction)
+	}
+	if r.Action != NetworkACLAllow && r.Action != NetworkACLDeny {
+		return fmt.Errorf("invalid rule action: %s", r.Action)
+	}
+	switch r.Protocol {
+	case "all", "icmp":
+	case "tcp", "udp":
+		if r.PortMin < 1 || r.PortMin > 65535 || r.PortMax < 1 || r.PortMax > 65535 {
+			return errors.New("port_min and port_max must be between 1 and 65535")
+		}
+		if r.PortMin > r.PortMax {
+			return fmt.Errorf("port_min (%d) cannot be greater than port_max (%d)", r.PortMin, r.PortMax)
+		}
+	default:
+		return fmt.Errorf("invalid protocol: %s", r.Protocol)
+	}
+	if _, _, err := net.ParseCIDR(r.CIDR); err != nil {
+		return fmt.Errorf("invalid CIDR: %w", err)
+	}
+	return nil
+}
+
+// NetworkACLAssociation links a subnet to a network ACL. A subnet has at most one.
+type NetworkACLAssociation struct {
+	ID        uuid.UUID `json:"id"`
+	ACLID     uuid.UUID `json:"acl_id"`
+	SubnetID  uuid.UUID `json:"subnet_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DefaultNetworkACLRules are the allow-all rules of a VPC's default ACL, which keep
+// subnets open until the tenant tightens them.
+func DefaultNetworkACLRules(aclID uuid.UUID) []NetworkACLRule {
+	rules := make([]NetworkACLRule, 0, 4)
+	for _, dir := range []RuleDirection{RuleIngress, RuleEgress} {
+		for i, cidr := range []string{"0.0.0.0/0", "::/0"} {
+			rules = append(rules, NetworkACLRule{
+				ID:         uuid.New(),
+				ACLID:      aclID,
+				RuleNumber: NetworkACLDefaultRuleNumber + i,
+				Direction:  dir,
+				Protocol:   "all",
+				CIDR:       cidr,
+				Action:     NetworkACLAllow,
+				CreatedAt:  time.Now(),
+			})
+		}
+	}
+	return rules
+}
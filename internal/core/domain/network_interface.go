@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NetworkInterfaceStatus represents the attachment state of a network interface.
+type NetworkInterfaceStatus string
+
+const (
+	// NetworkInterfaceStatusAvailable indicates the interface is not attached to an instance.
+	NetworkInterfaceStatusAvailable NetworkInterfaceStatus = "available"
+	// NetworkInterfaceStatusInUse indicates the interface is attached to an instance.
+	NetworkInterfaceStatusInUse NetworkInterfaceStatus = "in-use"
+)
+
+const (
+	// MaxNetworkInterfacePrivateIPs bounds the private IPv4 addresses of one interface.
+	MaxNetworkInterfacePrivateIPs = 16
+	// MaxNetworkInterfaceDeviceIndex is the highest device index of a secondary
+	// interface. Index 0 is the instance's primary interface.
+	MaxNetworkInterfaceDeviceIndex = 7
+)
+
+// NetworkInterface is an elastic network interface: a secondary NIC created in
+// a subnet that carries one or more private IPs and its own security groups.
+// It is hot-plugged into a running instance of the same VPC as eth<DeviceIndex>
+// and keeps its addresses, MAC and Elastic IPs when it moves to another
+// instance, which makes it the unit of IP failover.
+type NetworkInterface struct {
+	ID               uuid.UUID              `json:"id"`
+	UserID           uuid.UUID              `json:"user_id"`
+	TenantID         uuid.UUID              `json:"tenant_id"`
+	VpcID            uuid.UUID              `json:"vpc_id"`
+	SubnetID         uuid.UUID              `json:"subnet_id"`
+	Description      string                 `json:"description,omitempty"`
+	PrivateIPs       []string               `json:"private_ips"` // The first address is the primary
+	SecurityGroupIDs []uuid.UUID            `json:"security_group_ids,omitempty"`
+	MACAddress       string                 `json:"mac_address"`
+	OvsPort          string                 `json:"-"` // Host-side port on the VPC bridge
+	InstanceID       *uuid.UUID             `json:"instance_id,omitempty"`
+	DeviceIndex      int                    `json:"device_index,omitempty"`
+	Status           NetworkInterfaceStatus `json:"status"`
+	ARN              string                 `json:"arn"`
+	CreatedAt        time.Time              `json:"created_at"`
+	UpdatedAt        time.Time              `json:"updated_at"`
+}
+
+// PrimaryIP returns the primary private IP of the interface.
+func (n *NetworkInterface) PrimaryIP() string {
+	if len(n.PrivateIPs) == 0 {
+		return ""
+	}
+	return n.PrivateIPs[0]
+}
+
+// HasPrivateIP reports whether ip is assigned to the interface.
+func (n *NetworkInterface) HasPrivateIP(ip string) bool {
+	for _, assigned := range n.PrivateIPs {
+		if assigned == ip {
+			return true
+		}
+	}
+	return false
+}
+
+// DeviceName returns the name of the interface inside the instance.
+func (n *NetworkInterface) DeviceName() string {
+	return fmt.Sprintf("eth%d", n.DeviceIndex)
+}
+
+// GuestPort returns the peer of OvsPort that container backends move into the instance.
+func (n *NetworkInterface) GuestPort() string {
+	return n.OvsPort + "p"
+}
@@ -0,0 +1,48 @@
+// Package domain defines core business entities.
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Organization groups several tenants under one owner for guardrails and billing.
+type Organization struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	OwnerID   uuid.UUID `json:"owner_id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// GuardrailPolicy is an organization-wide policy that caps what member tenants can do.
+// Guardrails only deny; they are evaluated before any tenant IAM and cannot be
+// overridden by an Allow anywhere else.
+type GuardrailPolicy struct {
+	ID             uuid.UUID   `json:"id"`
+	OrganizationID uuid.UUID   `json:"organization_id"`
+	Name           string      `json:"name"`
+	Description    string      `json:"description,omitempty"`
+	Statements     []Statement `json:"statements"`
+	CreatedAt      time.Time   `json:"created_at"`
+	UpdatedAt      time.Time   `json:"updated_at"`
+}
+
+// TenantBill is one member tenant's share of a consolidated bill.
+type TenantBill struct {
+	TenantID    uuid.UUID                `json:"tenant_id"`
+	Amount      float64                  `json:"amount"`
+	UsageByType map[ResourceType]float64 `json:"usage_by_type"`
+}
+
+// OrganizationBillSummary consolidates usage costs across all tenants of an organization.
+type OrganizationBillSummary struct {
+	OrganizationID uuid.UUID    `json:"organization_id"`
+	TotalAmount    float64      `json:"total_amount"`
+	Currency       string       `json:"currency"`
+	Tenants        []TenantBill `json:"tenants"`
+	PeriodStart    time.Time    `json:"period_start"`
+	PeriodEnd      time.Time    `json:"period_end"`
+}
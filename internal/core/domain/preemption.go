@@ -0,0 +1,65 @@
+package domain
+
+import "time"
+
+// CapacityType is how the platform provisions an instance.
+type CapacityType string
+
+// Capacity types.
+const (
+	// CapacityOnDemand instances run until their owner terminates them.
+	CapacityOnDemand CapacityType = "on-demand"
+	// CapacityPreemptible instances are billed at a discount and may be
+	// reclaimed by the platform when hosts run short of resources.
+	CapacityPreemptible CapacityType = "preemptible"
+)
+
+// IsValid reports whether the capacity type is known.
+func (c CapacityType) IsValid() bool {
+	return c == CapacityOnDemand || c == CapacityPreemptible
+}
+
+// Instance metadata keys managed by the platform for preemptible capacity.
+// Owners cannot change them.
+const (
+	MetadataCapacityType   = "thecloud.io/capacity-type"
+	MetadataPreemptionTime = "thecloud.io/preemption-time"
+)
+
+// PreemptiblePriceFactor is the fraction of the on-demand price charged for
+// preemptible capacity.
+const PreemptiblePriceFactor = 0.3
+
+// PreemptionNoticePeriod is how long a preempted instance keeps running
+// after its termination notice, so that it can drain its workloads.
+const PreemptionNoticePeriod = 30 * time.Second
+
+// PreemptionNotice tells a preemptible instance that it is being reclaimed.
+type PreemptionNotice struct {
+	Action string    `json:"action"` // always "terminate"
+	Time   time.Time `json:"time"`   // when the instance will be terminated
+}
+
+// IsPreemptible reports whether the platform may reclaim the instance.
+func (i *Instance) IsPreemptible() bool {
+	return CapacityType(i.Metadata[MetadataCapacityType]) == CapacityPreemptible
+}
+
+// PreemptionNotice returns the pending termination notice of a preempted
+// instance, or nil when it has not been preempted.
+func (i *Instance) PreemptionNotice() *PreemptionNotice {
+	if !i.IsPreemptible() {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, i.Metadata[MetadataPreemptionTime])
+	if err != nil {
+		return nil
+	}
+	return &PreemptionNotice{Action: "terminate", Time: t}
+}
+
+// IsReservedMetadataKey reports whether a metadata key is managed by the
+// platform and must not be changed by instance owners.
+func IsReservedMetadataKey(key string) bool {
+	return key == MetadataCapacityType || key == MetadataPreemptionTime
+}
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstancePreemptionNotice(t *testing.T) {
+	inst := &Instance{Metadata: map[string]string{MetadataPreemptionTime: "2024-01-01T12:00:30Z"}}
+	assert.False(t, inst.IsPreemptible())
+	assert.Nil(t, inst.PreemptionNotice(), "on-demand instances are never preempted")
+
+	inst.Metadata[MetadataCapacityType] = string(CapacityPreemptible)
+	notice := inst.PreemptionNotice()
+	require.NotNil(t, notice)
+	assert.Equal(t, "terminate", notice.Action)
+	assert.Equal(t, time.Date(2024, 1, 1, 12, 0, 30, 0, time.UTC), notice.Time)
+
+	delete(inst.Metadata, MetadataPreemptionTime)
+	assert.Nil(t, inst.PreemptionNotice())
+	assert.True(t, IsReservedMetadataKey(MetadataCapacityType))
+	assert.False(t, IsReservedMetadataKey("thecloud.io/node-group"))
+}
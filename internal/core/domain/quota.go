@@ -0,0 +1,110 @@
+// Package domain defines core business entities.
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// QuotaResource identifies a resource type that is limited per tenant.
+type QuotaResource string
+
+const (
+	QuotaInstances     QuotaResource = "instances"
+	QuotaVCPUs         QuotaResource = "vcpus"
+	QuotaMemoryGB      QuotaResource = "memory"
+	QuotaVPCs          QuotaResource = "vpcs"
+	QuotaStorageGB     QuotaResource = "storage"
+	QuotaDatabases     QuotaResource = "databases"
+	QuotaCaches        QuotaResource = "caches"
+	QuotaClusters      QuotaResource = "clusters"
+	QuotaLoadBalancers QuotaResource = "load_balancers"
+	QuotaElasticIPs    QuotaResource = "elastic_ips"
+	QuotaFunctions     QuotaResource = "functions"
+	QuotaBuckets       QuotaResource = "buckets"
+)
+
+// QuotaDefinition describes a quota-limited resource type and the limit every tenant starts with.
+type QuotaDefinition struct {
+	Resource     QuotaResource `json:"resource"`
+	Description  string        `json:"description"`
+	Unit         string        `json:"unit"`
+	DefaultLimit int           `json:"default_limit"`
+}
+
+// quotaRegistry lists every resource type that is subject to a tenant quota.
+var quotaRegistry = []QuotaDefinition{
+	{Resource: QuotaInstances, Description: "Compute instances", Unit: "count", DefaultLimit: 10},
+	{Resource: QuotaVCPUs, Description: "vCPUs across all instances", Unit: "vcpus", DefaultLimit: 8},
+	{Resource: QuotaMemoryGB, Description: "Memory across all instances", Unit: "GB", DefaultLimit: 16},
+	{Resource: QuotaVPCs, Description: "Virtual private clouds", Unit: "count", DefaultLimit: 2},
+	{Resource: QuotaStorageGB, Description: "Block storage across all volumes", Unit: "GB", DefaultLimit: 50},
+	{Resource: QuotaDatabases, Description: "Managed databases", Unit: "count", DefaultLimit: 5},
+	{Resource: QuotaCaches, Description: "Managed caches", Unit: "count", DefaultLimit: 5},
+	{Resource: QuotaClusters, Description: "Kubernetes clusters", Unit: "count", DefaultLimit: 2},
+	{Resource: QuotaLoadBalancers, Description: "Load balancers", Unit: "count", DefaultLimit: 5},
+	{Resource: QuotaElasticIPs, Description: "Elastic IP addresses", Unit: "count", DefaultLimit: 5},
+	{Resource: QuotaFunctions, Description: "Serverless functions", Unit: "count", DefaultLimit: 20},
+	{Resource: QuotaBuckets, Description: "Object storage buckets", Unit: "count", DefaultLimit: 10},
+}
+
+// QuotaDefinitions returns the registry of quota-limited resource types.
+func QuotaDefinitions() []QuotaDefinition {
+	defs := make([]QuotaDefinition, len(quotaRegistry))
+	copy(defs, quotaRegistry)
+	return defs
+}
+
+// LookupQuota returns the registry entry for a resource type.
+func LookupQuota(resource QuotaResource) (QuotaDefinition, bool) {
+	for _, def := range quotaRegistry {
+		if def.Resource == resource {
+			return def, true
+		}
+	}
+	return QuotaDefinition{}, false
+}
+
+// ResourceQuota is a tenant's limit and current usage for one resource type.
+type ResourceQuota struct {
+	TenantID  uuid.UUID     `json:"tenant_id"`
+	Resource  QuotaResource `json:"resource"`
+	Limit     int           `json:"limit"`
+	Used      int           `json:"used"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+// Available returns how much of the quota is left, never less than zero.
+func (q *ResourceQuota) Available() int {
+	if q.Used >= q.Limit {
+		return 0
+	}
+	return q.Limit - q.Used
+}
+
+// QuotaRequestStatus tracks a quota increase request through review.
+type QuotaRequestStatus string
+
+const (
+	QuotaRequestPending  QuotaRequestStatus = "pending"
+	QuotaRequestApproved QuotaRequestStatus = "approved"
+	QuotaRequestDenied   QuotaRequestStatus = "denied"
+)
+
+// QuotaRequest asks a platform admin to raise a tenant's limit for one resource type.
+type QuotaRequest struct {
+	ID             uuid.UUID          `json:"id"`
+	TenantID       uuid.UUID          `json:"tenant_id"`
+	RequestedBy    uuid.UUID          `json:"requested_by"`
+	Resource       QuotaResource      `json:"resource"`
+	CurrentLimit   int                `json:"current_limit"`
+	RequestedLimit int                `json:"requested_limit"`
+	Reason         string             `json:"reason"`
+	Status         QuotaRequestStatus `json:"status" enums:"pending,approved,denied"`
+	ReviewedBy     *uuid.UUID         `json:"reviewed_by,omitempty"`
+	ReviewNote     string             `json:"review_note,omitempty"`
+	ReviewedAt     *time.Time         `json:"reviewed_at,omitempty"`
+	CreatedAt      time.Time          `json:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at"`
+}
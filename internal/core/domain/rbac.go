@@ -183,10 +183,25 @@ const (
 	PermissionServiceAccountRead   Permission = "service_account:read"
 	PermissionServiceAccountUpdate Permission = "service_account:update"
 	PermissionServiceAccountDelete Permission = "service_account:delete"
+	// PermissionServiceAccountUse allows attaching a service account to a workload, which can then act as it.
+	PermissionServiceAccountUse Permission = "service_account:use"
 
 	// Accounting Permissions
 	PermissionAccountingRead Permission = "accounting:read"
 
+	// Quota Permissions
+	PermissionQuotaRead    Permission = "quota:read"
+	PermissionQuotaRequest Permission = "quota:request"
+	PermissionQuotaManage  Permission = "quota:manage"
+
+	// Network Permissions
+	PermissionNetworkManage Permission = "network:manage"
+
+	// Flow Log Permissions
+	PermissionFlowLogCreate Permission = "flow_log:create"
+	PermissionFlowLogRead   Permission = "flow_log:read"
+	PermissionFlowLogDelete Permission = "flow_log:delete"
+
 	// Audit Permissions
 	PermissionAuditRead Permission = "audit:read"
 
@@ -12,10 +12,12 @@ import (
 type RouteTargetType string
 
 const (
-	RouteTargetLocal   RouteTargetType = "local"
-	RouteTargetIGW     RouteTargetType = "igw"
-	RouteTargetNAT     RouteTargetType = "nat"
-	RouteTargetPeering RouteTargetType = "peering"
+	RouteTargetLocal          RouteTargetType = "local"
+	RouteTargetIGW            RouteTargetType = "igw"
+	RouteTargetNAT            RouteTargetType = "nat"
+	RouteTargetPeering        RouteTargetType = "peering"
+	RouteTargetVPN            RouteTargetType = "vpn"
+	RouteTargetTransitGateway RouteTargetType = "tgw"
 )
 
 // RouteTable represents a collection of routes associated with a VPC.
@@ -60,19 +62,29 @@ func (r *Route) Validate() error {
 	if r.DestinationCIDR == "" {
 		return errors.New("destination CIDR is required")
 	}
-	_, _, err := net.ParseCIDR(r.DestinationCIDR)
+	ip, _, err := net.ParseCIDR(r.DestinationCIDR)
 	if err != nil {
 		return errors.New("invalid destination CIDR")
 	}
 	if !isValidRouteTargetType(r.TargetType) {
 		return errors.New("invalid target type")
 	}
+	// NAT gateways only translate IPv4; IPv6 addresses are routed without translation.
+	if r.TargetType == RouteTargetNAT && ip.To4() == nil {
+		return errors.New("NAT gateway routes require an IPv4 destination")
+	}
+	if r.TargetType == RouteTargetVPN && r.TargetID == nil {
+		return errors.New("VPN routes require a target VPN gateway")
+	}
+	if r.TargetType == RouteTargetTransitGateway && r.TargetID == nil {
+		return errors.New("transit gateway routes require a target transit gateway")
+	}
 	return nil
 }
 
 func isValidRouteTargetType(t RouteTargetType) bool {
 	switch t {
-	case RouteTargetLocal, RouteTargetIGW, RouteTargetNAT, RouteTargetPeering:
+	case RouteTargetLocal, RouteTargetIGW, RouteTargetNAT, RouteTargetPeering, RouteTargetVPN, RouteTargetTransitGateway:
 		return true
 	}
 	return false
@@ -2,6 +2,7 @@
 package domain
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,6 +10,7 @@ import (
 
 // Secret represents a sensitive configuration value (e.g., API key, certificate) stored securely.
 // Values are encrypted at rest and only decrypted during authorized retrieval.
+// EncryptedValue mirrors the version labelled SecretStageCurrent.
 type Secret struct {
 	ID             uuid.UUID  `json:"id"`
 	UserID         uuid.UUID  `json:"user_id"`
@@ -16,7 +18,76 @@ type Secret struct {
 	Name           string     `json:"name"`
 	EncryptedValue string     `json:"encrypted_value,omitempty"` // AES-encrypted representation of the secret content
 	Description    string     `json:"description"`
+	CurrentVersion int        `json:"current_version"`
 	CreatedAt      time.Time  `json:"created_at"`
 	UpdatedAt      time.Time  `json:"updated_at"`
 	LastAccessedAt *time.Time `json:"last_accessed_at,omitempty"`
+
+	// Rotation settings. RotationFunctionID is nil when rotation is disabled.
+	RotationFunctionID   *uuid.UUID `json:"rotation_function_id,omitempty"`
+	RotationIntervalDays int        `json:"rotation_interval_days,omitempty"`
+	LastRotatedAt        *time.Time `json:"last_rotated_at,omitempty"`
+	NextRotationAt       *time.Time `json:"next_rotation_at,omitempty"`
+}
+
+// RotationEnabled reports whether the secret is rotated on a schedule.
+func (s *Secret) RotationEnabled() bool {
+	return s.RotationFunctionID != nil && s.RotationIntervalDays > 0
+}
+
+// SecretStage labels a secret version. Each label is held by at most one version of a secret.
+type SecretStage string
+
+const (
+	// SecretStageCurrent marks the version returned by default.
+	SecretStageCurrent SecretStage = "current"
+	// SecretStagePrevious marks the version that was current before the last promotion.
+	SecretStagePrevious SecretStage = "previous"
+	// SecretStagePending marks a staged value that has not been promoted yet.
+	SecretStagePending SecretStage = "pending"
+)
+
+// IsValid reports whether the stage is a known label.
+func (s SecretStage) IsValid() bool {
+	switch s {
+	case SecretStageCurrent, SecretStagePrevious, SecretStagePending:
+		return true
+	}
+	return false
+}
+
+// SecretVersion is one immutable value of a secret.
+type SecretVersion struct {
+	ID             uuid.UUID     `json:"id"`
+	SecretID       uuid.UUID     `json:"secret_id"`
+	Version        int           `json:"version"`
+	EncryptedValue string        `json:"value,omitempty"` // plaintext once returned by the service
+	Stages         []SecretStage `json:"stages"`
+	CreatedBy      uuid.UUID     `json:"created_by"`
+	CreatedAt      time.Time     `json:"created_at"`
+}
+
+// HasStage reports whether the version carries the given label.
+func (v *SecretVersion) HasStage(stage SecretStage) bool {
+	for _, s := range v.Stages {
+		if s == stage {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseSecretRef splits a secret reference such as "@db-password" or
+// "@db-password:previous" into the secret name and the stage it pins.
+// References without a stage resolve to SecretStageCurrent. Secret names may
+// contain colons, so only a known stage after the last colon is treated as
+// a stage; "@db:url" refers to the secret named "db:url".
+func ParseSecretRef(ref string) (string, SecretStage) {
+	name := strings.TrimPrefix(ref, "@")
+	if i := strings.LastIndex(name, ":"); i > 0 {
+		if stage := SecretStage(name[i+1:]); stage.IsValid() {
+			return name[:i], stage
+		}
+	}
+	return name, SecretStageCurrent
 }
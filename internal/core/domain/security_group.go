@@ -45,9 +45,13 @@ type SecurityRule struct {
 	Protocol  string        `json:"protocol"` // Traffic protocol (e.g., "tcp", "udp", "icmp", "all")
 	PortMin   int           `json:"port_min,omitempty"`
 	PortMax   int           `json:"port_max,omitempty"`
-	CIDR      string        `json:"cidr"`     // Targeted IPv4 range (e.g., "0.0.0.0/0")
+	CIDR      string        `json:"cidr"`     // Targeted IPv4 or IPv6 range (e.g., "0.0.0.0/0", "::/0")
 	Priority  int           `json:"priority"` // Evaluation order (lower values evaluated first)
 	CreatedAt time.Time     `json:"created_at"`
+
+	// SourceGroupID targets the private IPs of instances in another security group
+	// instead of a fixed CIDR. The peer is the source for ingress and the destination for egress.
+	SourceGroupID *uuid.UUID `json:"source_group_id,omitempty"`
 }
 
 // Validate checks if the security rule fields are valid.
@@ -61,7 +65,7 @@ func (sr *SecurityRule) Validate() error {
 	if err := sr.validatePorts(); err != nil {
 		return err
 	}
-	if err := sr.validateCIDR(); err != nil {
+	if err := sr.validatePeer(); err != nil {
 		return err
 	}
 	return nil
@@ -98,7 +102,13 @@ func (sr *SecurityRule) validatePorts() error {
 	return nil
 }
 
-func (sr *SecurityRule) validateCIDR() error {
+func (sr *SecurityRule) validatePeer() error {
+	if sr.SourceGroupID != nil {
+		if sr.CIDR != "" {
+			return errors.New("rule cannot have both cidr and source_group_id")
+		}
+		return nil
+	}
 	if sr.CIDR == "" {
 		return errors.New("CIDR is required")
 	}
@@ -79,6 +79,7 @@ func TestSecurityGroupValidate(t *testing.T) {
 
 func TestSecurityRuleValidate(t *testing.T) {
 	t.Parallel()
+	peerGroupID := uuid.New()
 	tests := []struct {
 		name    string
 		rule    SecurityRule
@@ -205,6 +206,30 @@ func TestSecurityRuleValidate(t *testing.T) {
 			wantErr: true,
 			msg:     "CIDR is required",
 		},
+		{
+			name: "valid source group",
+			rule: SecurityRule{
+				Direction:     RuleIngress,
+				Protocol:      "tcp",
+				PortMin:       5432,
+				PortMax:       5432,
+				SourceGroupID: &peerGroupID,
+			},
+			wantErr: false,
+		},
+		{
+			name: "cidr and source group",
+			rule: SecurityRule{
+				Direction:     RuleIngress,
+				Protocol:      "tcp",
+				PortMin:       5432,
+				PortMax:       5432,
+				CIDR:          anyIPv4,
+				SourceGroupID: &peerGroupID,
+			},
+			wantErr: true,
+			msg:     "both cidr and source_group_id",
+		},
 	}
 
 	for _, tt := range tests {
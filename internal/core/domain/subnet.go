@@ -15,10 +15,14 @@ type Subnet struct {
 	TenantID         uuid.UUID `json:"tenant_id"`
 	VPCID            uuid.UUID `json:"vpc_id"`
 	Name             string    `json:"name"`
-	CIDRBlock        string    `json:"cidr_block"`        // IPv4 range (e.g. "10.0.1.0/24")
-	AvailabilityZone string    `json:"availability_zone"` // Physical zone (e.g. "us-east-1a")
-	GatewayIP        string    `json:"gateway_ip"`        // Router IP (usually first IP in block)
-	ARN              string    `json:"arn"`               // Amazon Resource Name compatible ID
-	Status           string    `json:"status"`            // e.g. "AVAILABLE"
+	CIDRBlock        string    `json:"cidr_block"`                // IPv4 range (e.g. "10.0.1.0/24")
+	IPv6CIDRBlock    string    `json:"ipv6_cidr_block,omitempty"` // Optional IPv6 /64 within the VPC's /56
+	AvailabilityZone string    `json:"availability_zone"`         // Physical zone (e.g. "us-east-1a")
+	GatewayIP        string    `json:"gateway_ip"`                // Router IP (usually first IP in block)
+	ARN              string    `json:"arn"`                       // Amazon Resource Name compatible ID
+	Status           string    `json:"status"`                    // e.g. "AVAILABLE"
 	CreatedAt        time.Time `json:"created_at"`
 }
+
+// SubnetIPv6PrefixLength is the size of a subnet's IPv6 block.
+const SubnetIPv6PrefixLength = 64
@@ -27,17 +27,42 @@ type TenantMember struct {
 	JoinedAt time.Time `json:"joined_at"`
 }
 
-// TenantQuota defines resource limits for a tenant.
-type TenantQuota struct {
-	TenantID      uuid.UUID `json:"tenant_id"`
-	MaxInstances  int       `json:"max_instances"`
-	UsedInstances int       `json:"used_instances"`
-	MaxVPCs       int       `json:"max_vpcs"`
-	UsedVPCs      int       `json:"used_vpcs"`
-	MaxStorageGB  int       `json:"max_storage_gb"`
-	UsedStorageGB int       `json:"used_storage_gb"`
-	MaxMemoryGB   int       `json:"max_memory_gb"`
-	UsedMemoryGB  int       `json:"used_memory_gb"`
-	MaxVCPUs      int       `json:"max_vcpus"`
-	UsedVCPUs     int       `json:"used_vcpus"`
+// Tenant member roles.
+const (
+	TenantRoleOwner  = "owner"
+	TenantRoleAdmin  = "admin"
+	TenantRoleMember = "member"
+)
+
+// InvitationStatus tracks where a tenant invitation is in its lifecycle.
+type InvitationStatus string
+
+const (
+	InvitationPending  InvitationStatus = "pending"
+	InvitationAccepted InvitationStatus = "accepted"
+	InvitationDeclined InvitationStatus = "declined"
+	InvitationRevoked  InvitationStatus = "revoked"
+	InvitationExpired  InvitationStatus = "expired"
+)
+
+// TenantInvitation is an offer for someone, registered or not, to join a tenant.
+type TenantInvitation struct {
+	ID          uuid.UUID        `json:"id"`
+	TenantID    uuid.UUID        `json:"tenant_id"`
+	Email       string           `json:"email"`
+	Role        string           `json:"role" enums:"admin,member"`
+	Status      InvitationStatus `json:"status" enums:"pending,accepted,declined,revoked,expired"`
+	InvitedBy   uuid.UUID        `json:"invited_by"`
+	TokenHash   string           `json:"-"`               // SHA-256 of the token handed to the invitee
+	Token       string           `json:"token,omitempty"` // Plaintext token, only set when issued or resent
+	AcceptedBy  *uuid.UUID       `json:"accepted_by,omitempty"`
+	ExpiresAt   time.Time        `json:"expires_at"`
+	RespondedAt *time.Time       `json:"responded_at,omitempty"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
+
+// IsExpired reports whether the invitation can no longer be accepted.
+func (i *TenantInvitation) IsExpired(now time.Time) bool {
+	return now.After(i.ExpiresAt)
 }
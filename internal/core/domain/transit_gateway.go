@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"errors"
+	"net"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TransitGatewayStatus represents the state of a transit gateway.
+type TransitGatewayStatus string
+
+const (
+	TransitGatewayStatusPending   TransitGatewayStatus = "pending"
+	TransitGatewayStatusAvailable TransitGatewayStatus = "available"
+	TransitGatewayStatusFailed    TransitGatewayStatus = "failed"
+)
+
+// TransitGatewayAttachmentStatus represents the state of a VPC attachment.
+type TransitGatewayAttachmentStatus string
+
+const (
+	TransitGatewayAttachmentStatusPending   TransitGatewayAttachmentStatus = "pending"
+	TransitGatewayAttachmentStatusAvailable TransitGatewayAttachmentStatus = "available"
+	TransitGatewayAttachmentStatusFailed    TransitGatewayAttachmentStatus = "failed"
+)
+
+// TransitGatewayRouteType tells static routes apart from those learned from attachments.
+type TransitGatewayRouteType string
+
+const (
+	TransitGatewayRouteStatic     TransitGatewayRouteType = "static"
+	TransitGatewayRoutePropagated TransitGatewayRouteType = "propagated"
+)
+
+// TransitGateway is a regional hub that any number of VPCs attach to. Traffic
+// between attachments is switched on a dedicated OVS bridge according to the
+// gateway's route tables, so connectivity is transitive without pairwise peering.
+type TransitGateway struct {
+	ID                  uuid.UUID            `json:"id"`
+	UserID              uuid.UUID            `json:"user_id"`
+	TenantID            uuid.UUID            `json:"tenant_id"`
+	Name                string               `json:"name"`
+	Status              TransitGatewayStatus `json:"status"`
+	BridgeName          string               `json:"bridge_name"`
+	VXLANID             int                  `json:"vxlan_id"`
+	DefaultRouteTableID *uuid.UUID           `json:"default_route_table_id,omitempty"`
+	ARN                 string               `json:"arn"`
+	CreatedAt           time.Time            `json:"created_at"`
+}
+
+// Validate checks if the transit gateway fields are valid.
+func (g *TransitGateway) Validate() error {
+	if g.Name == "" {
+		return errors.New("transit gateway name cannot be empty")
+	}
+	if g.UserID == uuid.Nil {
+		return errors.New("transit gateway must have a user owner")
+	}
+	if g.TenantID == uuid.Nil {
+		return errors.New("transit gateway must have a tenant")
+	}
+	return nil
+}
+
+// TransitGatewayAttachment connects a VPC to a transit gateway. Traffic entering
+// the hub from the VPC is forwarded using the associated route table.
+type TransitGatewayAttachment struct {
+	ID               uuid.UUID                      `json:"id"`
+	TransitGatewayID uuid.UUID                      `json:"transit_gateway_id"`
+	VPCID            uuid.UUID                      `json:"vpc_id"`
+	UserID           uuid.UUID                      `json:"user_id"`
+	TenantID         uuid.UUID                      `json:"tenant_id"`
+	RouteTableID     *uuid.UUID                     `json:"route_table_id,omitempty"` // Associated transit gateway route table
+	Status           TransitGatewayAttachmentStatus `json:"status"`
+	CreatedAt        time.Time                      `json:"created_at"`
+}
+
+// Validate checks if the attachment fields are valid.
+func (a *TransitGatewayAttachment) Validate() error {
+	if a.TransitGatewayID == uuid.Nil {
+		return errors.New("attachment must reference a transit gateway")
+	}
+	if a.VPCID == uuid.Nil {
+		return errors.New("attachment must reference a VPC")
+	}
+	return nil
+}
+
+// TransitGatewayRouteTable holds the routes used for traffic arriving from the
+// attachments associated with it.
+type TransitGatewayRouteTable struct {
+	ID               uuid.UUID             `json:"id"`
+	TransitGatewayID uuid.UUID             `json:"transit_gateway_id"`
+	Name             string                `json:"name"`
+	IsDefault        bool                  `json:"is_default"`
+	Routes           []TransitGatewayRoute `json:"routes,omitempty"`
+	Propagations     []uuid.UUID           `json:"propagations,omitempty"` // Attachments whose VPC CIDRs are learned
+	CreatedAt        time.Time             `json:"created_at"`
+}
+
+// Validate checks if the route table fields are valid.
+func (rt *TransitGatewayRouteTable) Validate() error {
+	if rt.Name == "" {
+		return errors.New("transit gateway route table name cannot be empty")
+	}
+	if rt.TransitGatewayID == uuid.Nil {
+		return errors.New("route table must belong to a transit gateway")
+	}
+	return nil
+}
+
+// TransitGatewayRoute forwards a destination to an attachment. A static route
+// without an attachment is a blackhole and drops matching traffic.
+type TransitGatewayRoute struct {
+	ID              uuid.UUID               `json:"id"`
+	RouteTableID    uuid.UUID               `json:"route_table_id"`
+	DestinationCIDR string                  `json:"destination_cidr"`
+	AttachmentID    *uuid.UUID              `json:"attachment_id,omitempty"`
+	Type            TransitGatewayRouteType `json:"type"`
+	CreatedAt       time.Time               `json:"created_at"`
+}
+
+// IsBlackhole reports whether the route drops matching traffic.
+func (r *TransitGatewayRoute) IsBlackhole() bool {
+	return r.AttachmentID == nil
+}
+
+// Validate checks if the route fields are valid.
+func (r *TransitGatewayRoute) Validate() error {
+	if r.RouteTableID == uuid.Nil {
+		return errors.New("route must be associated with a route table")
+	}
+	if _, _, err := net.ParseCIDR(r.DestinationCIDR); err != nil {
+		return errors.New("invalid destination CIDR")
+	}
+	switch r.Type {
+	case TransitGatewayRouteStatic:
+	case TransitGatewayRoutePropagated:
+		if r.AttachmentID == nil {
+			return errors.New("propagated routes require an attachment")
+		}
+	default:
+		return errors.New("invalid route type")
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// VPCResolverStatus represents the state of a VPC resolver.
+type VPCResolverStatus string
+
+const (
+	VPCResolverStatusPending VPCResolverStatus = "pending"
+	VPCResolverStatusActive  VPCResolverStatus = "active"
+	VPCResolverStatusFailed  VPCResolverStatus = "failed"
+)
+
+// VPCResolver is the recursive DNS resolver of a VPC. It runs as a container on
+// the VPC network and is handed to instances as their nameserver. Private zones
+// associated with the VPC, or with VPCs actively peered to it, are answered from
+// PowerDNS; forwarding rules send other domains to custom resolvers and all
+// remaining queries go to the public upstream (split-horizon).
+type VPCResolver struct {
+	ID          uuid.UUID         `json:"id"`
+	VPCID       uuid.UUID         `json:"vpc_id"`
+	UserID      uuid.UUID         `json:"user_id"`
+	TenantID    uuid.UUID         `json:"tenant_id"`
+	Status      VPCResolverStatus `json:"status"`
+	ContainerID string            `json:"-"`
+	PrivateIP   string            `json:"private_ip,omitempty"`
+	ARN         string            `json:"arn"`
+	CreatedAt   time.Time         `json:"created_at"`
+}
+
+// ResolverForwardingRule conditionally forwards queries for a domain, and all of
+// its subdomains, to a set of target resolvers.
+type ResolverForwardingRule struct {
+	ID         uuid.UUID `json:"id"`
+	ResolverID uuid.UUID `json:"resolver_id"`
+	TenantID   uuid.UUID `json:"tenant_id"`
+	DomainName string    `json:"domain_name"` // e.g., "corp.example.com"
+	TargetIPs  []string  `json:"target_ips"`  // IP or IP:port, e.g., "10.50.0.2:53"
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// MaxForwardingRuleTargets bounds the number of target resolvers per rule.
+const MaxForwardingRuleTargets = 6
+
+// Validate checks the domain name and target resolvers of a forwarding rule.
+func (r *ResolverForwardingRule) Validate() error {
+	name := strings.TrimSuffix(strings.ToLower(r.DomainName), ".")
+	if name == "" {
+		return errors.New("forwarding rule domain name cannot be empty")
+	}
+	if len(name) > 253 {
+		return errors.New("forwarding rule domain name is too long")
+	}
+	for _, label := range strings.Split(name, ".") {
+		if !isValidDNSLabel(label) {
+			return fmt.Errorf("invalid domain name %q", r.DomainName)
+		}
+	}
+	if len(r.TargetIPs) == 0 {
+		return errors.New("forwarding rule requires at least one target IP")
+	}
+	if len(r.TargetIPs) > MaxForwardingRuleTargets {
+		return fmt.Errorf("forwarding rule supports at most %d target IPs", MaxForwardingRuleTargets)
+	}
+	for _, target := range r.TargetIPs {
+		if _, _, err := ParseResolverTarget(target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParseResolverTarget splits a target of the form "ip" or "ip:port" ("[ipv6]:port"
+// for IPv6). The port defaults to 53.
+func ParseResolverTarget(target string) (net.IP, int, error) {
+	if ip := net.ParseIP(target); ip != nil {
+		return ip, 53, nil
+	}
+	host, portStr, err := net.SplitHostPort(target)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid target %q: must be an IP address with an optional port", target)
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return nil, 0, fmt.Errorf("invalid target %q: must be an IP address with an optional port", target)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil || port < 1 || port > 65535 {
+		return nil, 0, fmt.Errorf("invalid target %q: port must be between 1 and 65535", target)
+	}
+	return ip, port, nil
+}
+
+func isValidDNSLabel(label string) bool {
+	if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+		return false
+	}
+	for _, c := range label {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' {
+			return false
+		}
+	}
+	return true
+}
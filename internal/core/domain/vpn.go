@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// VPNGatewayStatus represents the state of a VPN gateway.
+type VPNGatewayStatus string
+
+const (
+	VPNGatewayStatusPending VPNGatewayStatus = "pending"
+	VPNGatewayStatusActive  VPNGatewayStatus = "active"
+	VPNGatewayStatusFailed  VPNGatewayStatus = "failed"
+)
+
+// VPNConnectionStatus represents the state of a site-to-site VPN connection.
+type VPNConnectionStatus string
+
+const (
+	VPNConnectionStatusPending   VPNConnectionStatus = "pending"
+	VPNConnectionStatusAvailable VPNConnectionStatus = "available"
+	VPNConnectionStatusFailed    VPNConnectionStatus = "failed"
+)
+
+// VPNListenPort is the UDP port WireGuard listens on inside the gateway container.
+const VPNListenPort = 51820
+
+// VPNGateway terminates site-to-site WireGuard tunnels for a VPC. It runs as a
+// container attached to the VPC network; its private key is kept in the secrets service.
+type VPNGateway struct {
+	ID                 uuid.UUID        `json:"id"`
+	VPCID              uuid.UUID        `json:"vpc_id"`
+	UserID             uuid.UUID        `json:"user_id"`
+	TenantID           uuid.UUID        `json:"tenant_id"`
+	Name               string           `json:"name"`
+	Status             VPNGatewayStatus `json:"status"`
+	PublicKey          string           `json:"public_key"`
+	PrivateKeySecretID uuid.UUID        `json:"private_key_secret_id"`
+	ContainerID        string           `json:"-"`
+	PrivateIP          string           `json:"private_ip,omitempty"`
+	Port               int              `json:"port,omitempty"` // Host UDP port mapped to VPNListenPort
+	ARN                string           `json:"arn"`
+	CreatedAt          time.Time        `json:"created_at"`
+}
+
+// Validate checks if the VPN gateway fields are valid.
+func (g *VPNGateway) Validate() error {
+	if g.Name == "" {
+		return errors.New("VPN gateway name cannot be empty")
+	}
+	if g.VPCID == uuid.Nil {
+		return errors.New("VPN gateway must be attached to a VPC")
+	}
+	if g.UserID == uuid.Nil {
+		return errors.New("VPN gateway must have a user owner")
+	}
+	if g.TenantID == uuid.Nil {
+		return errors.New("VPN gateway must have a tenant")
+	}
+	return nil
+}
+
+// CustomerGateway describes the on-premises end of a site-to-site VPN: the
+// public address of the customer's device and its WireGuard public key.
+type CustomerGateway struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"user_id"`
+	TenantID  uuid.UUID `json:"tenant_id"`
+	Name      string    `json:"name"`
+	IPAddress string    `json:"ip_address"`
+	PublicKey string    `json:"public_key"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Validate checks if the customer gateway fields are valid.
+func (g *CustomerGateway) Validate() error {
+	if g.Name == "" {
+		return errors.New("customer gateway name cannot be empty")
+	}
+	if net.ParseIP(g.IPAddress) == nil {
+		return fmt.Errorf("invalid customer gateway ip address: %q", g.IPAddress)
+	}
+	if !IsValidWireGuardKey(g.PublicKey) {
+		return errors.New("public key must be a base64-encoded 32 byte WireGuard key")
+	}
+	return nil
+}
+
+// VPNConnection is a tunnel between a VPN gateway and a customer gateway.
+// RemoteCIDRs are the on-premises networks reachable through the tunnel; each
+// one is routed to the gateway from the VPC's main route table.
+type VPNConnection struct {
+	ID                   uuid.UUID           `json:"id"`
+	VPNGatewayID         uuid.UUID           `json:"vpn_gateway_id"`
+	CustomerGatewayID    uuid.UUID           `json:"customer_gateway_id"`
+	UserID               uuid.UUID           `json:"user_id"`
+	TenantID             uuid.UUID           `json:"tenant_id"`
+	RemoteCIDRs          []string            `json:"remote_cidrs"`
+	PreSharedKeySecretID uuid.UUID           `json:"pre_shared_key_secret_id"`
+	Status               VPNConnectionStatus `json:"status"`
+	CreatedAt            time.Time           `json:"created_at"`
+}
+
+// Validate checks if the VPN connection fields are valid.
+func (c *VPNConnection) Validate() error {
+	if c.VPNGatewayID == uuid.Nil {
+		return errors.New("VPN connection requires a VPN gateway")
+	}
+	if c.CustomerGatewayID == uuid.Nil {
+		return errors.New("VPN connection requires a customer gateway")
+	}
+	if len(c.RemoteCIDRs) == 0 {
+		return errors.New("at least one remote CIDR is required")
+	}
+	for _, cidr := range c.RemoteCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("invalid remote CIDR: %q", cidr)
+		}
+	}
+	return nil
+}
+
+// VPNConnectionConfig is what the customer needs to configure their side of a tunnel.
+type VPNConnectionConfig struct {
+	GatewayPublicKey string   `json:"gateway_public_key"`
+	GatewayPort      int      `json:"gateway_port"`
+	PreSharedKey     string   `json:"pre_shared_key"`
+	AllowedIPs       []string `json:"allowed_ips"` // VPC address ranges to route into the tunnel
+}
+
+// IsValidWireGuardKey reports whether s is a base64-encoded Curve25519 key.
+func IsValidWireGuardKey(s string) bool {
+	b, err := base64.StdEncoding.DecodeString(s)
+	return err == nil && len(b) == 32
+}
@@ -19,6 +19,8 @@ type AccountingService interface {
 	ListUsage(ctx context.Context, userID uuid.UUID, start, end time.Time) ([]domain.UsageRecord, error)
 	// ProcessHourlyBilling triggers system-wide calculations for the current billing cycle.
 	ProcessHourlyBilling(ctx context.Context) error
+	// GetOrganizationSummary consolidates billing across all tenants of an organization.
+	GetOrganizationSummary(ctx context.Context, orgID uuid.UUID, start, end time.Time) (*domain.OrganizationBillSummary, error)
 }
 
 // AccountingRepository handles the persistence of usage records and billing data.
@@ -29,4 +31,6 @@ type AccountingRepository interface {
 	GetUsageSummary(ctx context.Context, userID uuid.UUID, start, end time.Time) (map[domain.ResourceType]float64, error)
 	// ListRecords fetches raw usage data from storage.
 	ListRecords(ctx context.Context, userID uuid.UUID, start, end time.Time) ([]domain.UsageRecord, error)
+	// GetUsageSummaryByTenant aggregates quantities per resource type for each of the given tenants.
+	GetUsageSummaryByTenant(ctx context.Context, tenantIDs []uuid.UUID, start, end time.Time) (map[uuid.UUID]map[domain.ResourceType]float64, error)
 }
@@ -16,22 +16,54 @@ type CacheStats struct {
 	TotalKeys        int64 // Approximate count of keys stored
 }
 
+// CreateCacheRequest defines the parameters for provisioning a managed cache.
+type CreateCacheRequest struct {
+	Name            string                  `json:"name"`
+	Engine          domain.CacheEngine      `json:"engine,omitempty"` // Defaults to redis
+	Version         string                  `json:"version"`
+	MemoryMB        int                     `json:"memory_mb"`
+	VpcID           *uuid.UUID              `json:"vpc_id,omitempty"`
+	Persistence     domain.CachePersistence `json:"persistence,omitempty"`      // Defaults to none
+	Replicas        int                     `json:"replicas,omitempty"`         // Read replicas besides the primary, per shard in cluster mode
+	FailoverEnabled bool                    `json:"failover_enabled,omitempty"` // Requires at least one replica
+	ClusterMode     bool                    `json:"cluster_mode,omitempty"`     // Shard keys across primaries
+	Shards          int                     `json:"shards,omitempty"`           // Cluster mode only, defaults to MinCacheShards
+	MetricsEnabled  bool                    `json:"metrics_enabled,omitempty"`  // Run a Prometheus exporter sidecar
+}
+
+// ModifyCacheRequest defines the online changes to an existing cache. Nil
+// fields are left unchanged.
+type ModifyCacheRequest struct {
+	MemoryMB *int `json:"memory_mb,omitempty"`
+	// Shards adds or removes shards of a cluster-mode cache, moving hash
+	// slots between them while the cache keeps serving.
+	Shards *int `json:"shards,omitempty"`
+}
+
 // CacheService orchestrates the lifecycle and management of managed cache instances (e.g., Redis).
 type CacheService interface {
 	// CreateCache provisions a new managed cache.
-	CreateCache(ctx context.Context, name, version string, memoryMB int, vpcID *uuid.UUID) (*domain.Cache, error)
+	CreateCache(ctx context.Context, req CreateCacheRequest) (*domain.Cache, error)
 	// GetCache retrieves a cache instance by its UUID or unique name.
 	GetCache(ctx context.Context, idOrName string) (*domain.Cache, error)
 	// ListCaches lists all cache instances for the current user.
 	ListCaches(ctx context.Context) ([]*domain.Cache, error)
 	// DeleteCache decommission an existing cache.
 	DeleteCache(ctx context.Context, idOrName string) error
+	// ModifyCache resizes the memory of a cache or reshards a cluster-mode cache.
+	ModifyCache(ctx context.Context, idOrName string, req ModifyCacheRequest) (*domain.Cache, error)
 	// GetConnectionString returns the access URI for the cache instance.
+	// Cluster-mode caches list the addresses of their primaries.
 	GetConnectionString(ctx context.Context, idOrName string) (string, error)
 	// FlushCache purges all data within the cache instance.
 	FlushCache(ctx context.Context, idOrName string) error
 	// GetCacheStats retrieves real-time performance metrics from the engine.
 	GetCacheStats(ctx context.Context, idOrName string) (*CacheStats, error)
+	// FailoverCache promotes the most up-to-date replica to primary.
+	FailoverCache(ctx context.Context, idOrName string) error
+	// RotateCacheCredentials replaces the password of a Redis-compatible
+	// cache without restarting its nodes.
+	RotateCacheCredentials(ctx context.Context, idOrName string) error
 }
 
 // CacheRepository handles the persistence of cache metadata.
@@ -48,4 +80,15 @@ type CacheRepository interface {
 	Update(ctx context.Context, cache *domain.Cache) error
 	// Delete removes a cache record from storage.
 	Delete(ctx context.Context, id, tenantID uuid.UUID) error
+	// ListAll returns the caches of all tenants, for background workers.
+	ListAll(ctx context.Context) ([]*domain.Cache, error)
+
+	// CreateNode saves a new engine node of a cache.
+	CreateNode(ctx context.Context, node *domain.CacheNode) error
+	// ListNodes returns the nodes of a cache by shard, primary first.
+	ListNodes(ctx context.Context, cacheID uuid.UUID) ([]*domain.CacheNode, error)
+	// UpdateNode modifies a node's role, shard, status or container.
+	UpdateNode(ctx context.Context, node *domain.CacheNode) error
+	// DeleteNode removes a node record.
+	DeleteNode(ctx context.Context, id uuid.UUID) error
 }
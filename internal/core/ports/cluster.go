@@ -59,13 +59,22 @@ type NodeGroupParams struct {
 	MinSize      int
 	MaxSize      int
 	DesiredSize  int
+	// Autoscaler scale-down overrides; zero keeps the autoscaler defaults.
+	ScaleDownUtilizationThreshold float64
+	ScaleDownUnneededSeconds      int
+	// Labels and taints are applied when nodes join and cannot be changed later.
+	Labels       map[string]string
+	Taints       []domain.NodeTaint
+	CapacityType domain.CapacityType // defaults to on-demand
 }
 
 // UpdateNodeGroupParams defines options for updating a node group.
 type UpdateNodeGroupParams struct {
-	MinSize     *int
-	MaxSize     *int
-	DesiredSize *int
+	MinSize                       *int
+	MaxSize                       *int
+	DesiredSize                   *int
+	ScaleDownUtilizationThreshold *float64
+	ScaleDownUnneededSeconds      *int
 }
 
 // BackupPolicyParams defines the options for cluster backup scheduling.
@@ -61,6 +61,13 @@ type ComputeBackend interface {
 	// Returns the new container ID (container ID may change after stop->recreate->start for Docker bind mounts).
 	DetachVolume(ctx context.Context, id string, volumePath string) (string, error)
 
+	// Network Interfaces
+
+	// AttachNetworkInterface hot-plugs a secondary network interface into a running instance.
+	AttachNetworkInterface(ctx context.Context, id string, nic NetworkInterfaceOptions) error
+	// DetachNetworkInterface removes a hot-plugged network interface from an instance.
+	DetachNetworkInterface(ctx context.Context, id string, nic NetworkInterfaceOptions) error
+
 	// Health
 
 	// Ping checks the connectivity and health of the backend provider.
@@ -80,6 +87,13 @@ type ComputeBackend interface {
 	// DeleteSnapshot deletes a previously created snapshot.
 	DeleteSnapshot(ctx context.Context, id, name string) error
 
+	// Capacity
+
+	// HostMemoryPressure reports the memory pressure on each host that runs the
+	// backend's instances, which the platform relieves by reclaiming
+	// preemptible instances on that host.
+	HostMemoryPressure(ctx context.Context) ([]HostPressure, error)
+
 	// Test support
 
 	// ResetCircuitBreaker resets the circuit breaker state. Used by E2E tests
@@ -87,3 +101,12 @@ type ComputeBackend interface {
 	// implement circuit breaker.
 	ResetCircuitBreaker()
 }
+
+// HostPressure is the memory pressure on one compute host.
+type HostPressure struct {
+	Host string
+	// Pressure is the fraction of host memory in use, from 0 to 1.
+	Pressure float64
+	// InstanceIDs are the backend IDs of the instances running on the host.
+	InstanceIDs []string
+}
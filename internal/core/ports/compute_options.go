@@ -3,17 +3,34 @@ package ports
 
 // CreateInstanceOptions encapsulates the requirements for provisioning a new compute resource.
 type CreateInstanceOptions struct {
-	Name        string            `json:"name"`               // Friendly name for the instance
-	ImageName   string            `json:"image_name"`         // Template or image to use (e.g., "ubuntu:latest")
-	Ports       []string          `json:"ports"`              // List of ports to expose (e.g., ["80/tcp", "443/tcp"])
-	NetworkID   string            `json:"network_id"`         // ID of the VPC/Network to join
-	VolumeBinds []string          `json:"volume_binds"`       // Storage mappings (e.g., ["/host/path:/container/path"])
-	Env         []string          `json:"env"`                // Environment variables (e.g., ["KEY=VALUE"])
-	Cmd         []string          `json:"cmd"`                // Optional override command for the instance entrypoint
-	Metadata    map[string]string `json:"metadata,omitempty"` // Key-value metadata for the instance
-	Labels      map[string]string `json:"labels,omitempty"`   // Scheduling or grouping labels
-	CPULimit    int64             `json:"cpu_limit"`          // CPU cores (or millicores)
-	MemoryLimit int64             `json:"memory_limit"`       // Memory in bytes
-	DiskLimit   int64             `json:"disk_limit"`         // Disk in bytes
-	UserData    string            `json:"user_data"`          // Cloud-init user data
+	Name         string            `json:"name"`                   // Friendly name for the instance
+	ImageName    string            `json:"image_name"`             // Template or image to use (e.g., "ubuntu:latest")
+	Ports        []string          `json:"ports"`                  // List of ports to expose (e.g., ["80/tcp", "443/tcp"])
+	NetworkID    string            `json:"network_id"`             // ID of the VPC/Network to join
+	VolumeBinds  []string          `json:"volume_binds"`           // Storage mappings (e.g., ["/host/path:/container/path"])
+	Env          []string          `json:"env"`                    // Environment variables (e.g., ["KEY=VALUE"])
+	Cmd          []string          `json:"cmd"`                    // Optional override command for the instance entrypoint
+	Metadata     map[string]string `json:"metadata,omitempty"`     // Key-value metadata for the instance
+	Labels       map[string]string `json:"labels,omitempty"`       // Scheduling or grouping labels
+	CPULimit     int64             `json:"cpu_limit"`              // CPU cores (or millicores)
+	MemoryLimit  int64             `json:"memory_limit"`           // Memory in bytes
+	DiskLimit    int64             `json:"disk_limit"`             // Disk in bytes
+	UserData     string            `json:"user_data"`              // Cloud-init user data
+	Capabilities []string          `json:"capabilities,omitempty"` // Extra Linux capabilities for container backends (e.g., ["NET_ADMIN"])
+	DNSServers   []string          `json:"dns_servers,omitempty"`  // Nameservers for container backends (e.g., the VPC resolver)
+	DNSSearch    []string          `json:"dns_search,omitempty"`   // DNS search domains for container backends
+
+	// InstanceType sizes the instance and is the size policy conditions see. Only the
+	// instance service reads it; when empty, the raw limits above apply.
+	InstanceType string `json:"instance_type,omitempty"`
+}
+
+// NetworkInterfaceOptions describes a secondary network interface to hot-plug into an instance.
+type NetworkInterfaceOptions struct {
+	Bridge     string   `json:"bridge"`               // OVS bridge of the interface's VPC
+	HostPort   string   `json:"host_port"`            // Host-side port on the bridge (tap device for VM backends)
+	GuestPort  string   `json:"guest_port,omitempty"` // Peer of HostPort that container backends move into the instance
+	DeviceName string   `json:"device_name"`          // Interface name inside the instance (e.g., "eth1")
+	MACAddress string   `json:"mac_address"`          // MAC address of the interface
+	Addresses  []string `json:"addresses,omitempty"`  // Private addresses in CIDR notation, primary first
 }
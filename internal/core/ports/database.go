@@ -3,6 +3,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/poyrazk/thecloud/internal/core/domain"
@@ -18,6 +19,13 @@ type DatabaseRepository interface {
 	List(ctx context.Context) ([]*domain.Database, error)
 	// ListReplicas returns all replicas associated with a specific primary database.
 	ListReplicas(ctx context.Context, primaryID uuid.UUID) ([]*domain.Database, error)
+	// ListWithBackupRetention returns databases of every tenant that have point-in-time recovery enabled.
+	ListWithBackupRetention(ctx context.Context) ([]*domain.Database, error)
+	// ListPendingMaintenance returns databases of every tenant with a version
+	// upgrade or parameter change waiting for their maintenance window, replicas first.
+	ListPendingMaintenance(ctx context.Context) ([]*domain.Database, error)
+	// ListWithMetrics returns the primary databases of every tenant that have metrics enabled.
+	ListWithMetrics(ctx context.Context) ([]*domain.Database, error)
 	// Update modifies an existing database's metadata or status.
 	Update(ctx context.Context, db *domain.Database) error
 	// Delete removes a database record from storage.
@@ -26,20 +34,27 @@ type DatabaseRepository interface {
 
 // CreateDatabaseRequest defines the parameters for provisioning a new database.
 type CreateDatabaseRequest struct {
-	Name             string            `json:"name"`
-	Engine           string            `json:"engine"`
-	Version          string            `json:"version"`
-	VpcID            *uuid.UUID        `json:"vpc_id,omitempty"`
-	AllocatedStorage int               `json:"allocated_storage"`
-	Parameters       map[string]string `json:"parameters,omitempty"`
-	MetricsEnabled   bool              `json:"metrics_enabled,omitempty"`
-	PoolingEnabled   bool              `json:"pooling_enabled,omitempty"`
-	KmsKeyID         string            `json:"kms_key_id,omitempty"`
+	Name                string            `json:"name"`
+	Engine              string            `json:"engine"`
+	Version             string            `json:"version"`
+	VpcID               *uuid.UUID        `json:"vpc_id,omitempty"`
+	AllocatedStorage    int               `json:"allocated_storage"`
+	Parameters          map[string]string `json:"parameters,omitempty"`
+	MetricsEnabled      bool              `json:"metrics_enabled,omitempty"`
+	PoolingEnabled      bool              `json:"pooling_enabled,omitempty"`
+	KmsKeyID            string            `json:"kms_key_id,omitempty"`
+	BackupRetentionDays int               `json:"backup_retention_days,omitempty"` // Enables point-in-time recovery when positive
+	BackupBucket        string            `json:"backup_bucket,omitempty"`         // Bucket receiving archived WAL/binlogs
 }
 
 // RestoreDatabaseRequest defines the parameters for restoring a database from a snapshot.
+// When RestoreTime is set the restore is point-in-time: SourceDatabaseID names the
+// database whose archived logs are replayed on top of its nearest base backup,
+// and SnapshotID is ignored.
 type RestoreDatabaseRequest struct {
 	SnapshotID       uuid.UUID         `json:"snapshot_id"`
+	SourceDatabaseID *uuid.UUID        `json:"source_database_id,omitempty"`
+	RestoreTime      *time.Time        `json:"restore_time,omitempty"`
 	NewName          string            `json:"new_name"`
 	Engine           string            `json:"engine"`
 	Version          string            `json:"version"`
@@ -57,6 +72,42 @@ type ModifyDatabaseRequest struct {
 	MetricsEnabled   *bool
 	PoolingEnabled   *bool
 	AllocatedStorage *int
+	// BackupRetentionDays changes the point-in-time recovery window; zero disables archiving.
+	BackupRetentionDays *int
+	// MaintenanceWindow moves the weekly maintenance window ("ddd:HH:MM", UTC).
+	MaintenanceWindow *string
+	// ApplyImmediately restarts the database with the new Parameters now
+	// instead of waiting for the maintenance window.
+	ApplyImmediately bool
+}
+
+// UpgradeDatabaseRequest changes the engine version of a database and its
+// replicas. Unless ApplyImmediately is set the upgrade is queued for the
+// next maintenance window.
+type UpgradeDatabaseRequest struct {
+	ID               uuid.UUID
+	Version          string
+	ApplyImmediately bool
+}
+
+// CreateDatabaseUserRequest adds a login user to a database with grants on
+// its logical databases. The password is generated.
+type CreateDatabaseUserRequest struct {
+	DatabaseID uuid.UUID
+	Username   string
+	Grants     []domain.DatabaseGrant
+}
+
+// BackupPolicyRequest defines an automated backup schedule. Zero values take
+// the defaults: a logical backup once a day in a one-hour window at 03:00 UTC.
+type BackupPolicyRequest struct {
+	Method         domain.DatabaseBackupMethod
+	WindowStart    string // "15:04", UTC
+	WindowMinutes  int
+	FrequencyHours int
+	RetentionCount int
+	RetentionDays  int
+	ExportBucket   string
 }
 
 // DatabaseService provides business logic for managing relational database instances (DBaaS).
@@ -81,8 +132,57 @@ type DatabaseService interface {
 	CreateDatabaseSnapshot(ctx context.Context, databaseID uuid.UUID, description string) (*domain.Snapshot, error)
 	// ListDatabaseSnapshots returns all snapshots belonging to a specific database.
 	ListDatabaseSnapshots(ctx context.Context, databaseID uuid.UUID) ([]*domain.Snapshot, error)
-	// RestoreDatabase creates a new database instance from an existing snapshot.
+	// RestoreDatabase creates a new database instance from an existing snapshot,
+	// or from a point in time within a source database's restorable window.
 	RestoreDatabase(ctx context.Context, req RestoreDatabaseRequest) (*domain.Database, error)
+	// ArchiveDatabaseLogs ships closed WAL/binlog files of every database with
+	// point-in-time recovery to its backup bucket, takes due base backups and
+	// prunes what fell out of the retention window. It returns the number of
+	// files archived.
+	ArchiveDatabaseLogs(ctx context.Context) (int, error)
+	// PutBackupPolicy creates or replaces the automated backup schedule of a database.
+	PutBackupPolicy(ctx context.Context, databaseID uuid.UUID, req BackupPolicyRequest) (*domain.DatabaseBackupPolicy, error)
+	// GetBackupPolicy returns the automated backup schedule of a database.
+	GetBackupPolicy(ctx context.Context, databaseID uuid.UUID) (*domain.DatabaseBackupPolicy, error)
+	// DeleteBackupPolicy stops automated backups; existing backups are kept.
+	DeleteBackupPolicy(ctx context.Context, databaseID uuid.UUID) error
+	// ListAutomatedBackups returns the scheduled backups of a database, newest first.
+	ListAutomatedBackups(ctx context.Context, databaseID uuid.UUID) ([]*domain.DatabaseAutomatedBackup, error)
+	// DeleteAutomatedBackup removes a scheduled backup with its export and snapshot.
+	DeleteAutomatedBackup(ctx context.Context, databaseID, backupID uuid.UUID) error
+	// RunScheduledBackups takes the backups whose window has come, exports
+	// them and prunes expired ones. It returns the number of backups completed.
+	RunScheduledBackups(ctx context.Context) (int, error)
+	// UpgradeDatabase changes the engine version of a database, replicas
+	// first, after snapshotting the primary's volume.
+	UpgradeDatabase(ctx context.Context, req UpgradeDatabaseRequest) (*domain.Database, error)
+	// ListDatabaseUpgrades returns the upgrade history of a database, newest first.
+	ListDatabaseUpgrades(ctx context.Context, databaseID uuid.UUID) ([]*domain.DatabaseUpgrade, error)
+	// RunMaintenance applies pending upgrades and parameter changes of the
+	// databases whose maintenance window is open. It returns the number of
+	// databases maintained.
+	RunMaintenance(ctx context.Context) (int, error)
+	// CreateLogicalDatabase creates an additional database inside a database
+	// instance, owned by the master user.
+	CreateLogicalDatabase(ctx context.Context, databaseID uuid.UUID, name string) (*domain.LogicalDatabase, error)
+	ListLogicalDatabases(ctx context.Context, databaseID uuid.UUID) ([]*domain.LogicalDatabase, error)
+	// DeleteLogicalDatabase drops a logical database and removes the grants on it.
+	DeleteLogicalDatabase(ctx context.Context, databaseID uuid.UUID, name string) error
+	// CreateDatabaseUser creates a login user and returns it with its password.
+	CreateDatabaseUser(ctx context.Context, req CreateDatabaseUserRequest) (*domain.DatabaseUser, error)
+	ListDatabaseUsers(ctx context.Context, databaseID uuid.UUID) ([]*domain.DatabaseUser, error)
+	// SetDatabaseUserGrants replaces the grants of a user.
+	SetDatabaseUserGrants(ctx context.Context, databaseID uuid.UUID, username string, grants []domain.DatabaseGrant) (*domain.DatabaseUser, error)
+	// RotateDatabaseUserCredentials regenerates a user's password and returns
+	// the user with the new password.
+	RotateDatabaseUserCredentials(ctx context.Context, databaseID uuid.UUID, username string) (*domain.DatabaseUser, error)
+	DeleteDatabaseUser(ctx context.Context, databaseID uuid.UUID, username string) error
+	// GetDatabaseInsights returns the latest performance sample, top queries
+	// and the history of the given period of a database with metrics enabled.
+	GetDatabaseInsights(ctx context.Context, databaseID uuid.UUID, period time.Duration) (*domain.DatabaseInsights, error)
+	// CollectDatabaseInsights samples every running database with metrics
+	// enabled and prunes expired samples. It returns the number of databases sampled.
+	CollectDatabaseInsights(ctx context.Context) (int, error)
 	// RotateCredentials regenerates the database password and updates it in the secrets manager.
 	RotateCredentials(ctx context.Context, id uuid.UUID, idempotencyKey string) error
 	// StopDatabase stops a running database instance, retaining its data volume.
@@ -90,3 +190,66 @@ type DatabaseService interface {
 	// StartDatabase starts a stopped database instance.
 	StartDatabase(ctx context.Context, id uuid.UUID) error
 }
+
+// DatabaseBackupRepository tracks the base backups and archived log files of
+// databases with point-in-time recovery.
+type DatabaseBackupRepository interface {
+	// Create records a backup. Re-archiving a log file with the same name updates it.
+	Create(ctx context.Context, backup *domain.DatabaseBackup) error
+	// ListByDatabase returns the backups of a database, oldest first.
+	ListByDatabase(ctx context.Context, databaseID uuid.UUID) ([]*domain.DatabaseBackup, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// DatabaseBackupPolicyRepository persists automated backup schedules.
+type DatabaseBackupPolicyRepository interface {
+	// Upsert creates or replaces the policy of a database.
+	Upsert(ctx context.Context, policy *domain.DatabaseBackupPolicy) error
+	// GetByDatabase returns the policy of a database the caller has resolved.
+	GetByDatabase(ctx context.Context, databaseID uuid.UUID) (*domain.DatabaseBackupPolicy, error)
+	// ListDue returns policies of every tenant whose next backup is at or before now.
+	ListDue(ctx context.Context, now time.Time) ([]*domain.DatabaseBackupPolicy, error)
+	Delete(ctx context.Context, databaseID uuid.UUID) error
+}
+
+// DatabaseAutomatedBackupRepository tracks backups taken by backup policies.
+type DatabaseAutomatedBackupRepository interface {
+	Create(ctx context.Context, backup *domain.DatabaseAutomatedBackup) error
+	Update(ctx context.Context, backup *domain.DatabaseAutomatedBackup) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.DatabaseAutomatedBackup, error)
+	// ListByDatabase returns the backups of a database, newest first.
+	ListByDatabase(ctx context.Context, databaseID uuid.UUID) ([]*domain.DatabaseAutomatedBackup, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// DatabaseUpgradeRepository tracks the version upgrades of databases.
+type DatabaseUpgradeRepository interface {
+	Create(ctx context.Context, upgrade *domain.DatabaseUpgrade) error
+	Update(ctx context.Context, upgrade *domain.DatabaseUpgrade) error
+	// ListByDatabase returns the upgrades of a database, newest first.
+	ListByDatabase(ctx context.Context, databaseID uuid.UUID) ([]*domain.DatabaseUpgrade, error)
+}
+
+// DatabaseUserRepository persists the additional users and logical databases
+// of databases.
+type DatabaseUserRepository interface {
+	CreateLogicalDatabase(ctx context.Context, db *domain.LogicalDatabase) error
+	ListLogicalDatabases(ctx context.Context, databaseID uuid.UUID) ([]*domain.LogicalDatabase, error)
+	DeleteLogicalDatabase(ctx context.Context, databaseID uuid.UUID, name string) error
+	CreateUser(ctx context.Context, user *domain.DatabaseUser) error
+	GetUser(ctx context.Context, databaseID uuid.UUID, username string) (*domain.DatabaseUser, error)
+	ListUsers(ctx context.Context, databaseID uuid.UUID) ([]*domain.DatabaseUser, error)
+	UpdateUser(ctx context.Context, user *domain.DatabaseUser) error
+	DeleteUser(ctx context.Context, databaseID uuid.UUID, username string) error
+}
+
+// DatabaseInsightRepository stores the performance samples and latest top
+// queries of databases.
+type DatabaseInsightRepository interface {
+	CreateSample(ctx context.Context, sample *domain.DatabaseInsightSample) error
+	// ListSamples returns the samples of a database collected since the given time, oldest first.
+	ListSamples(ctx context.Context, databaseID uuid.UUID, since time.Time) ([]*domain.DatabaseInsightSample, error)
+	DeleteSamplesBefore(ctx context.Context, before time.Time) (int64, error)
+	PutTopQueries(ctx context.Context, databaseID, tenantID uuid.UUID, queries []domain.DatabaseQueryStat, collectedAt time.Time) error
+	GetTopQueries(ctx context.Context, databaseID uuid.UUID) ([]domain.DatabaseQueryStat, error)
+}
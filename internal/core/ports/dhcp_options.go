@@ -0,0 +1,36 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+)
+
+// DHCPOptionsRepository manages persistence of DHCP options sets and their VPC associations.
+type DHCPOptionsRepository interface {
+	Create(ctx context.Context, opts *domain.DHCPOptionsSet) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.DHCPOptionsSet, error)
+	// GetByVPC returns the options set associated with a VPC, or a NotFound error if it has none.
+	GetByVPC(ctx context.Context, vpcID uuid.UUID) (*domain.DHCPOptionsSet, error)
+	List(ctx context.Context) ([]*domain.DHCPOptionsSet, error)
+	// Delete removes an options set. It fails with a Conflict error while VPCs still use it.
+	Delete(ctx context.Context, id uuid.UUID) error
+	// SetVPCAssociation associates the VPC with an options set, or clears the
+	// association when optionsID is nil.
+	SetVPCAssociation(ctx context.Context, vpcID uuid.UUID, optionsID *uuid.UUID) error
+}
+
+// DHCPOptionsService provides business logic for VPC DHCP options sets.
+type DHCPOptionsService interface {
+	CreateDHCPOptions(ctx context.Context, opts *domain.DHCPOptionsSet) (*domain.DHCPOptionsSet, error)
+	GetDHCPOptions(ctx context.Context, id uuid.UUID) (*domain.DHCPOptionsSet, error)
+	ListDHCPOptions(ctx context.Context) ([]*domain.DHCPOptionsSet, error)
+	DeleteDHCPOptions(ctx context.Context, id uuid.UUID) error
+
+	// AssociateVPC makes the options set apply to instances launched in the VPC,
+	// replacing any previous association.
+	AssociateVPC(ctx context.Context, optionsID, vpcID uuid.UUID) error
+	// DisassociateVPC reverts the VPC to the default options (the VPC resolver).
+	DisassociateVPC(ctx context.Context, vpcID uuid.UUID) error
+}
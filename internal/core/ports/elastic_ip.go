@@ -18,6 +18,8 @@ type ElasticIPRepository interface {
 	GetByPublicIP(ctx context.Context, publicIP string) (*domain.ElasticIP, error)
 	// GetByInstanceID retrieves the Elastic IP associated with a specific instance.
 	GetByInstanceID(ctx context.Context, instanceID uuid.UUID) (*domain.ElasticIP, error)
+	// ListByNetworkInterface returns the Elastic IPs associated with addresses of a network interface.
+	ListByNetworkInterface(ctx context.Context, interfaceID uuid.UUID) ([]*domain.ElasticIP, error)
 	// List returns Elastic IPs authorized for the current operational context.
 	List(ctx context.Context) ([]*domain.ElasticIP, error)
 	// Update modifies an existing Elastic IP's metadata or status.
@@ -34,7 +36,10 @@ type ElasticIPService interface {
 	ReleaseIP(ctx context.Context, id uuid.UUID) error
 	// AssociateIP maps an Elastic IP to a specific compute instance.
 	AssociateIP(ctx context.Context, id uuid.UUID, instanceID uuid.UUID) (*domain.ElasticIP, error)
-	// DisassociateIP removes the mapping between an Elastic IP and an instance.
+	// AssociateNetworkInterface maps an Elastic IP to a private IP of a network
+	// interface; an empty privateIP selects the interface's primary address.
+	AssociateNetworkInterface(ctx context.Context, id, interfaceID uuid.UUID, privateIP string) (*domain.ElasticIP, error)
+	// DisassociateIP removes the mapping between an Elastic IP and an instance or network interface.
 	DisassociateIP(ctx context.Context, id uuid.UUID) (*domain.ElasticIP, error)
 	// ListElasticIPs returns a slice of all Elastic IPs accessible to the caller.
 	ListElasticIPs(ctx context.Context) ([]*domain.ElasticIP, error)
@@ -0,0 +1,41 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+)
+
+// FlowLogRepository persists flow log configurations and their delivery state.
+type FlowLogRepository interface {
+	Create(ctx context.Context, fl *domain.FlowLog) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.FlowLog, error)
+	List(ctx context.Context) ([]*domain.FlowLog, error)
+	// ListAll returns every flow log across tenants, for the capture worker.
+	ListAll(ctx context.Context) ([]*domain.FlowLog, error)
+	// UpdateCapture records the outcome of a capture.
+	UpdateCapture(ctx context.Context, id uuid.UUID, capturedAt time.Time, status domain.FlowLogStatus, lastError string) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// CreateFlowLogParams holds parameters for creating a flow log.
+type CreateFlowLogParams struct {
+	ResourceType    domain.FlowLogResourceType
+	ResourceID      uuid.UUID
+	TrafficType     domain.FlowLogTrafficType
+	Destination     domain.FlowLogDestination
+	Bucket          string
+	IntervalSeconds int
+}
+
+// FlowLogService manages flow logs and captures their traffic records.
+type FlowLogService interface {
+	CreateFlowLog(ctx context.Context, params CreateFlowLogParams) (*domain.FlowLog, error)
+	GetFlowLog(ctx context.Context, id uuid.UUID) (*domain.FlowLog, error)
+	ListFlowLogs(ctx context.Context) ([]*domain.FlowLog, error)
+	DeleteFlowLog(ctx context.Context, id uuid.UUID) error
+	// CaptureDue captures and delivers every flow log whose interval has elapsed and returns how many were delivered.
+	CaptureDue(ctx context.Context) (int, error)
+}
@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+)
+
+// FlowReconcilerService detects and repairs drift between the flows derived from
+// security groups and route tables and the flows installed on each VPC bridge.
+type FlowReconcilerService interface {
+	// DiffVPC reports the drift on a VPC's bridge without changing it. Platform admin only.
+	DiffVPC(ctx context.Context, vpcID uuid.UUID) (*domain.FlowDiff, error)
+	// ReconcileVPC repairs any drift on a VPC's bridge and returns what was found. Platform admin only.
+	ReconcileVPC(ctx context.Context, vpcID uuid.UUID) (*domain.FlowDiff, error)
+	// ReconcileAll repairs every VPC and returns how many had drifted.
+	ReconcileAll(ctx context.Context) (int, error)
+}
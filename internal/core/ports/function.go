@@ -42,6 +42,8 @@ type FunctionService interface {
 	DeleteFunction(ctx context.Context, id uuid.UUID) error
 	// InvokeFunction executes a function with the provided payload.
 	InvokeFunction(ctx context.Context, id uuid.UUID, payload []byte, async bool) (*domain.Invocation, error)
+	// InvokeFunctionRedacted executes a function synchronously, returning its output but recording the invocation with redacted logs.
+	InvokeFunctionRedacted(ctx context.Context, id uuid.UUID, payload []byte) (*domain.Invocation, error)
 	// GetFunctionLogs retrieves execution history and results for a function.
 	GetFunctionLogs(ctx context.Context, id uuid.UUID, limit int) ([]*domain.Invocation, error)
 }
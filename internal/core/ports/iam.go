@@ -58,3 +58,28 @@ type PolicyEvaluator interface {
 	// Evaluate checks if the given action on a resource is allowed by the provided policies.
 	Evaluate(ctx context.Context, policies []*domain.Policy, action string, resource string, evalCtx map[string]interface{}) (domain.PolicyEffect, error)
 }
+
+// ResourcePolicyRepository manages policies attached directly to resources.
+type ResourcePolicyRepository interface {
+	// Upsert creates or replaces the policy attached to a resource.
+	Upsert(ctx context.Context, policy *domain.ResourcePolicy) error
+	// Get returns the policy attached to a resource owned by the tenant.
+	Get(ctx context.Context, tenantID uuid.UUID, resourceType domain.ResourcePolicyType, resourceID string) (*domain.ResourcePolicy, error)
+	// List returns every resource policy owned by the tenant.
+	List(ctx context.Context, tenantID uuid.UUID) ([]*domain.ResourcePolicy, error)
+	// Delete detaches the policy from a resource owned by the tenant.
+	Delete(ctx context.Context, tenantID uuid.UUID, resourceType domain.ResourcePolicyType, resourceID string) error
+	// GetForResource returns the policies the owning tenant attached to a resource, regardless of the
+	// caller's tenant. It is used during authorization, where the principal may belong to another tenant.
+	GetForResource(ctx context.Context, ownerTenantID uuid.UUID, resourceType domain.ResourcePolicyType, resourceID string) ([]*domain.ResourcePolicy, error)
+	// GetResourceOwner resolves the tenant that owns a resource.
+	GetResourceOwner(ctx context.Context, resourceType domain.ResourcePolicyType, resourceID string) (uuid.UUID, error)
+}
+
+// ResourcePolicyService manages resource policies for the caller's tenant.
+type ResourcePolicyService interface {
+	PutResourcePolicy(ctx context.Context, resourceType domain.ResourcePolicyType, resourceID string, statements []domain.Statement) (*domain.ResourcePolicy, error)
+	GetResourcePolicy(ctx context.Context, resourceType domain.ResourcePolicyType, resourceID string) (*domain.ResourcePolicy, error)
+	ListResourcePolicies(ctx context.Context) ([]*domain.ResourcePolicy, error)
+	DeleteResourcePolicy(ctx context.Context, resourceType domain.ResourcePolicyType, resourceID string) error
+}
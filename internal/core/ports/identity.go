@@ -68,6 +68,8 @@ type IdentityService interface {
 
 	// ValidateClientCredentials exchanges client credentials for a JWT access token.
 	ValidateClientCredentials(ctx context.Context, clientID, clientSecret string) (string, error)
+	// IssueServiceAccountToken mints an access token for a service account on behalf of a trusted caller.
+	IssueServiceAccountToken(ctx context.Context, saID uuid.UUID) (string, error)
 	// ValidateAccessToken validates a Bearer JWT and returns claims.
 	ValidateAccessToken(ctx context.Context, token string) (*domain.ServiceAccountClaims, error)
 
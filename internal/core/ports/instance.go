@@ -48,6 +48,9 @@ type LaunchParams struct {
 	SSHKeyID     *uuid.UUID
 	Metadata     map[string]string
 	Labels       map[string]string
+	// ServiceAccountID attaches a service account whose short-lived credentials
+	// the instance can fetch from the metadata service.
+	ServiceAccountID *uuid.UUID
 }
 
 // InstanceService defines the business logic for managing the lifecycle of compute instances.
@@ -0,0 +1,30 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+)
+
+// InstanceMetadataRepository persists the launch-time configuration served by the
+// instance metadata service.
+type InstanceMetadataRepository interface {
+	// Save stores the configuration of an instance, replacing any previous one.
+	Save(ctx context.Context, cfg *domain.InstanceMetadataConfig) error
+	// GetByAddress finds the live instance owning a private address in a VPC, or
+	// outside any VPC when vpcID is nil, across all tenants. Instances without a
+	// stored configuration are returned with empty user data and no service
+	// account. It fails with a Conflict error when the address is ambiguous.
+	GetByAddress(ctx context.Context, vpcID *uuid.UUID, ip string) (*domain.InstanceMetadataConfig, error)
+}
+
+// InstanceMetadataService serves instances information about themselves. The
+// caller is identified by the VPC listener that received the request and its
+// source address, so it must only be exposed on the link-local metadata address.
+type InstanceMetadataService interface {
+	GetMetadata(ctx context.Context, caller domain.MetadataCaller) (*domain.InstanceMetadata, error)
+	// GetCredentials issues a short-lived access token for the service account
+	// attached to the calling instance.
+	GetCredentials(ctx context.Context, caller domain.MetadataCaller) (*domain.InstanceCredentials, error)
+}
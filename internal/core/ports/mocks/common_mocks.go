@@ -42,6 +42,12 @@ func (m *VpcRepository) List(ctx context.Context) ([]*domain.VPC, error) {
 	return r0, args.Error(1)
 }
 
+func (m *VpcRepository) ListAll(ctx context.Context) ([]*domain.VPC, error) {
+	args := m.Called(ctx)
+	r0, _ := args.Get(0).([]*domain.VPC)
+	return r0, args.Error(1)
+}
+
 func (m *VpcRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
@@ -140,3 +146,36 @@ func (m *SecretService) Decrypt(ctx context.Context, userID uuid.UUID, cipherTex
 	args := m.Called(ctx, userID, cipherText)
 	return args.String(0), args.Error(1)
 }
+
+func (m *SecretService) PutSecretValue(ctx context.Context, id uuid.UUID, value string, stage domain.SecretStage) (*domain.SecretVersion, error) {
+	args := m.Called(ctx, id, value, stage)
+	r0, _ := args.Get(0).(*domain.SecretVersion)
+	return r0, args.Error(1)
+}
+
+func (m *SecretService) ListSecretVersions(ctx context.Context, id uuid.UUID) ([]*domain.SecretVersion, error) {
+	args := m.Called(ctx, id)
+	r0, _ := args.Get(0).([]*domain.SecretVersion)
+	return r0, args.Error(1)
+}
+
+func (m *SecretService) GetSecretVersion(ctx context.Context, id uuid.UUID, version int) (*domain.SecretVersion, error) {
+	args := m.Called(ctx, id, version)
+	r0, _ := args.Get(0).(*domain.SecretVersion)
+	return r0, args.Error(1)
+}
+
+func (m *SecretService) GetSecretVersionByStage(ctx context.Context, id uuid.UUID, stage domain.SecretStage) (*domain.SecretVersion, error) {
+	args := m.Called(ctx, id, stage)
+	r0, _ := args.Get(0).(*domain.SecretVersion)
+	return r0, args.Error(1)
+}
+
+func (m *SecretService) UpdateSecretVersionStage(ctx context.Context, id uuid.UUID, stage domain.SecretStage, version int) error {
+	return m.Called(ctx, id, stage, version).Error(0)
+}
+
+func (m *SecretService) ResolveSecretRef(ctx context.Context, ref string) (string, error) {
+	args := m.Called(ctx, ref)
+	return args.String(0), args.Error(1)
+}
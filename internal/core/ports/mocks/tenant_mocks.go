@@ -72,25 +72,12 @@ func (m *TenantRepository) ListUserTenants(ctx context.Context, userID uuid.UUID
 	return args.Get(0).([]domain.Tenant), args.Error(1)
 }
 
-func (m *TenantRepository) GetQuota(ctx context.Context, tenantID uuid.UUID) (*domain.TenantQuota, error) {
-	args := m.Called(ctx, tenantID)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*domain.TenantQuota), args.Error(1)
-}
-
-func (m *TenantRepository) UpdateQuota(ctx context.Context, quota *domain.TenantQuota) error {
-	args := m.Called(ctx, quota)
-	return args.Error(0)
-}
-
-func (m *TenantRepository) IncrementUsage(ctx context.Context, tenantID uuid.UUID, resource string, amount int) error {
-	args := m.Called(ctx, tenantID, resource, amount)
+func (m *TenantRepository) UpdateMemberRole(ctx context.Context, tenantID, userID uuid.UUID, role string) error {
+	args := m.Called(ctx, tenantID, userID, role)
 	return args.Error(0)
 }
 
-func (m *TenantRepository) DecrementUsage(ctx context.Context, tenantID uuid.UUID, resource string, amount int) error {
-	args := m.Called(ctx, tenantID, resource, amount)
+func (m *TenantRepository) TransferOwnership(ctx context.Context, tenantID, currentOwnerID, newOwnerID uuid.UUID) error {
+	args := m.Called(ctx, tenantID, currentOwnerID, newOwnerID)
 	return args.Error(0)
 }
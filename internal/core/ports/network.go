@@ -11,6 +11,21 @@ type FlowRule struct {
 	Priority int    // Rule priority (higher values are evaluated first)
 	Match    string // OVS-style match criteria (e.g., "in_port=1,dl_type=0x0800,nw_proto=6,tp_dst=80")
 	Actions  string // OVS-style actions (e.g., "allow", "drop", "output:2")
+	Cookie   uint64 // OpenFlow cookie shared by every flow generated for one rule (0 = none)
+	Packets  uint64 // Packets matched so far; only populated by ListFlowRules
+	Bytes    uint64 // Bytes matched so far; only populated by ListFlowRules
+}
+
+// ConntrackEntry is one connection tracked in a conntrack zone, with counters summed over both directions.
+type ConntrackEntry struct {
+	ID       uint64 // Kernel connection ID, stable for the life of the connection (0 if unavailable)
+	Protocol string // e.g. "tcp", "udp", "icmp"
+	Src      string
+	Dst      string
+	SrcPort  int
+	DstPort  int
+	Packets  uint64
+	Bytes    uint64
 }
 
 // NetworkBackend abstracts Open vSwitch operations to decouple virtual networking from compute management.
@@ -47,6 +62,8 @@ type NetworkBackend interface {
 	DeleteFlowRule(ctx context.Context, bridge string, match string) error
 	// ListFlowRules retrieves all active OpenFlow rules for a bridge.
 	ListFlowRules(ctx context.Context, bridge string) ([]FlowRule, error)
+	// ListConntrackEntries returns the connections committed to a conntrack zone.
+	ListConntrackEntries(ctx context.Context, zone int) ([]ConntrackEntry, error)
 
 	// Veth Pair Management (used to link instance namespaces to the bridge)
 
@@ -0,0 +1,58 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+)
+
+// SubnetACLBinding is the network ACL a subnet is currently governed by.
+type SubnetACLBinding struct {
+	SubnetID      uuid.UUID
+	CIDRBlock     string
+	IPv6CIDRBlock string // Empty unless the subnet is dual-stack
+	ACLID         uuid.UUID
+}
+
+// NetworkACLRepository manages the persistent state of network ACLs.
+type NetworkACLRepository interface {
+	// Create saves a new ACL together with its initial rules.
+	Create(ctx context.Context, acl *domain.NetworkACL) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.NetworkACL, error)
+	ListByVPC(ctx context.Context, vpcID uuid.UUID) ([]*domain.NetworkACL, error)
+	GetDefaultByVPC(ctx context.Context, vpcID uuid.UUID) (*domain.NetworkACL, error)
+	// Delete removes a non-default ACL; its subnets fall back to the VPC's default ACL.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// Rule operations
+	AddRule(ctx context.Context, rule *domain.NetworkACLRule) error
+	RemoveRule(ctx context.Context, aclID, ruleID uuid.UUID) error
+	ListRules(ctx context.Context, aclID uuid.UUID) ([]domain.NetworkACLRule, error)
+
+	// AssociateSubnet moves a subnet to an ACL, replacing any previous association.
+	AssociateSubnet(ctx context.Context, aclID, subnetID uuid.UUID) error
+	// DisassociateSubnet returns a subnet to its VPC's default ACL.
+	DisassociateSubnet(ctx context.Context, subnetID uuid.UUID) error
+	// ListSubnetBindings resolves the effective ACL of every subnet in a VPC.
+	ListSubnetBindings(ctx context.Context, vpcID uuid.UUID) ([]SubnetACLBinding, error)
+}
+
+// NetworkACLService provides business logic for stateless subnet firewalls.
+type NetworkACLService interface {
+	// CreateNetworkACL creates an empty ACL in a VPC; until rules are added it denies all traffic.
+	CreateNetworkACL(ctx context.Context, vpcID uuid.UUID, name string) (*domain.NetworkACL, error)
+	GetNetworkACL(ctx context.Context, id uuid.UUID) (*domain.NetworkACL, error)
+	ListNetworkACLs(ctx context.Context, vpcID uuid.UUID) ([]*domain.NetworkACL, error)
+	// DeleteNetworkACL removes a custom ACL. The default ACL cannot be deleted.
+	DeleteNetworkACL(ctx context.Context, id uuid.UUID) error
+
+	// AddRule adds a numbered rule and programs it on every subnet using the ACL.
+	AddRule(ctx context.Context, aclID uuid.UUID, rule domain.NetworkACLRule) (*domain.NetworkACLRule, error)
+	RemoveRule(ctx context.Context, aclID, ruleID uuid.UUID) error
+
+	// AssociateSubnet applies the ACL to a subnet in the same VPC.
+	AssociateSubnet(ctx context.Context, aclID, subnetID uuid.UUID) error
+	// DisassociateSubnet returns a subnet associated with the ACL to its VPC's default ACL.
+	DisassociateSubnet(ctx context.Context, aclID, subnetID uuid.UUID) error
+}
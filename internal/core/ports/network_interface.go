@@ -0,0 +1,55 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+)
+
+// NetworkInterfaceRepository manages persistence of elastic network interfaces
+// and their security group memberships.
+type NetworkInterfaceRepository interface {
+	// Create saves a new interface along with its security groups.
+	Create(ctx context.Context, ni *domain.NetworkInterface) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.NetworkInterface, error)
+	List(ctx context.Context) ([]*domain.NetworkInterface, error)
+	// ListByInstance returns the interfaces attached to an instance, ordered by device index.
+	ListByInstance(ctx context.Context, instanceID uuid.UUID) ([]*domain.NetworkInterface, error)
+	// ListBySubnet returns every interface in a subnet, for IP allocation.
+	ListBySubnet(ctx context.Context, subnetID uuid.UUID) ([]*domain.NetworkInterface, error)
+	// Update saves the description, private IPs, attachment and status of an interface.
+	Update(ctx context.Context, ni *domain.NetworkInterface) error
+	// Delete removes an interface. It fails with a Conflict error while Elastic IPs are associated with it.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// NetworkInterfaceService provides business logic for elastic network interfaces.
+type NetworkInterfaceService interface {
+	// CreateNetworkInterface creates an interface in a subnet. When privateIPs is
+	// empty a primary address is allocated from the subnet.
+	CreateNetworkInterface(ctx context.Context, subnetID uuid.UUID, description string, privateIPs []string, securityGroupIDs []uuid.UUID) (*domain.NetworkInterface, error)
+	GetNetworkInterface(ctx context.Context, id uuid.UUID) (*domain.NetworkInterface, error)
+	ListNetworkInterfaces(ctx context.Context) ([]*domain.NetworkInterface, error)
+	// DeleteNetworkInterface removes a detached interface.
+	DeleteNetworkInterface(ctx context.Context, id uuid.UUID) error
+
+	// AssignPrivateIPs adds the given secondary addresses, plus count addresses
+	// allocated from the subnet, to an interface.
+	AssignPrivateIPs(ctx context.Context, id uuid.UUID, privateIPs []string, count int) (*domain.NetworkInterface, error)
+	// UnassignPrivateIPs removes secondary addresses. The primary address cannot be removed.
+	UnassignPrivateIPs(ctx context.Context, id uuid.UUID, privateIPs []string) (*domain.NetworkInterface, error)
+	// SetSecurityGroups replaces the security groups of an interface.
+	SetSecurityGroups(ctx context.Context, id uuid.UUID, securityGroupIDs []uuid.UUID) (*domain.NetworkInterface, error)
+
+	// AttachNetworkInterface hot-plugs an available interface into a running
+	// instance of the same VPC. A zero deviceIndex picks the lowest free index.
+	AttachNetworkInterface(ctx context.Context, id, instanceID uuid.UUID, deviceIndex int) (*domain.NetworkInterface, error)
+	// DetachNetworkInterface unplugs an interface from its instance.
+	DetachNetworkInterface(ctx context.Context, id uuid.UUID) (*domain.NetworkInterface, error)
+	// MoveNetworkInterface detaches an interface from its current instance and
+	// attaches it to another one, keeping its device index where possible. A
+	// failure to unplug from the old instance (e.g., because it is down) does not
+	// prevent the move, so the interface's addresses can fail over.
+	MoveNetworkInterface(ctx context.Context, id, instanceID uuid.UUID) (*domain.NetworkInterface, error)
+}
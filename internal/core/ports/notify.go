@@ -14,6 +14,8 @@ type NotifyRepository interface {
 	CreateTopic(ctx context.Context, topic *domain.Topic) error
 	// GetTopicByID retrieves a topic by its unique UUID.
 	GetTopicByID(ctx context.Context, id, userID uuid.UUID) (*domain.Topic, error)
+	// GetTopicByTenant retrieves a topic by its UUID regardless of which user in the tenant owns it.
+	GetTopicByTenant(ctx context.Context, id, tenantID uuid.UUID) (*domain.Topic, error)
 	// GetTopicByName retrieves a topic by its friendly name for a specific user.
 	GetTopicByName(ctx context.Context, name string, userID uuid.UUID) (*domain.Topic, error)
 	// ListTopics returns all topics owned by a user.
@@ -0,0 +1,44 @@
+// Package ports defines interfaces for adapters and services.
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+)
+
+// OrganizationRepository persists organizations, their member tenants and guardrail policies.
+type OrganizationRepository interface {
+	Create(ctx context.Context, org *domain.Organization) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Organization, error)
+	ListByOwner(ctx context.Context, ownerID uuid.UUID) ([]*domain.Organization, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// AddTenant links a tenant to an organization. A tenant belongs to at most one organization.
+	AddTenant(ctx context.Context, orgID, tenantID uuid.UUID) error
+	RemoveTenant(ctx context.Context, orgID, tenantID uuid.UUID) error
+	ListTenantIDs(ctx context.Context, orgID uuid.UUID) ([]uuid.UUID, error)
+
+	CreateGuardrail(ctx context.Context, policy *domain.GuardrailPolicy) error
+	ListGuardrails(ctx context.Context, orgID uuid.UUID) ([]*domain.GuardrailPolicy, error)
+	DeleteGuardrail(ctx context.Context, orgID, policyID uuid.UUID) error
+	// GetGuardrailsForTenant returns the guardrails of the organization the tenant belongs to, if any.
+	GetGuardrailsForTenant(ctx context.Context, tenantID uuid.UUID) ([]*domain.GuardrailPolicy, error)
+}
+
+// OrganizationService manages organizations above tenants and their guardrails.
+type OrganizationService interface {
+	CreateOrganization(ctx context.Context, name, slug string) (*domain.Organization, error)
+	GetOrganization(ctx context.Context, id uuid.UUID) (*domain.Organization, error)
+	ListOrganizations(ctx context.Context) ([]*domain.Organization, error)
+	DeleteOrganization(ctx context.Context, id uuid.UUID) error
+
+	AddTenant(ctx context.Context, orgID, tenantID uuid.UUID) error
+	RemoveTenant(ctx context.Context, orgID, tenantID uuid.UUID) error
+	ListTenants(ctx context.Context, orgID uuid.UUID) ([]uuid.UUID, error)
+
+	CreateGuardrail(ctx context.Context, orgID uuid.UUID, name, description string, statements []domain.Statement) (*domain.GuardrailPolicy, error)
+	ListGuardrails(ctx context.Context, orgID uuid.UUID) ([]*domain.GuardrailPolicy, error)
+	DeleteGuardrail(ctx context.Context, orgID, policyID uuid.UUID) error
+}
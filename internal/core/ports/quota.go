@@ -0,0 +1,56 @@
+// Package ports defines interfaces for adapters and services.
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+)
+
+// QuotaRepository persists per-tenant resource limits, usage counters and quota increase requests.
+type QuotaRepository interface {
+	// GetQuota returns the tenant's quota for a resource, falling back to the registry default limit.
+	GetQuota(ctx context.Context, tenantID uuid.UUID, resource domain.QuotaResource) (*domain.ResourceQuota, error)
+	// ListQuotas returns the tenant's quota for every registered resource type.
+	ListQuotas(ctx context.Context, tenantID uuid.UUID) ([]*domain.ResourceQuota, error)
+	SetLimit(ctx context.Context, tenantID uuid.UUID, resource domain.QuotaResource, limit int) error
+	IncrementUsage(ctx context.Context, tenantID uuid.UUID, resource domain.QuotaResource, amount int) error
+	DecrementUsage(ctx context.Context, tenantID uuid.UUID, resource domain.QuotaResource, amount int) error
+
+	// ListUsage returns the stored usage counter of every tenant that has one for the resource.
+	ListUsage(ctx context.Context, resource domain.QuotaResource) (map[uuid.UUID]int, error)
+	// CountActualUsage measures usage of the resource from the resource tables themselves.
+	CountActualUsage(ctx context.Context, resource domain.QuotaResource) (map[uuid.UUID]int, error)
+	SetUsage(ctx context.Context, tenantID uuid.UUID, resource domain.QuotaResource, used int) error
+
+	CreateRequest(ctx context.Context, req *domain.QuotaRequest) error
+	GetRequest(ctx context.Context, id uuid.UUID) (*domain.QuotaRequest, error)
+	ListRequestsByTenant(ctx context.Context, tenantID uuid.UUID) ([]*domain.QuotaRequest, error)
+	// ListRequestsByStatus lists requests across all tenants; an empty status lists all of them.
+	ListRequestsByStatus(ctx context.Context, status domain.QuotaRequestStatus) ([]*domain.QuotaRequest, error)
+	// ReviewRequest records the decision on a pending request and, when approved, applies the new
+	// limit in the same transaction. It fails with Conflict if the request was already reviewed.
+	ReviewRequest(ctx context.Context, req *domain.QuotaRequest) error
+}
+
+// QuotaService exposes tenant quotas and the quota increase workflow.
+// Enforcement itself goes through TenantService.CheckQuota.
+type QuotaService interface {
+	// ListQuotas returns the limits and usage of the caller's tenant.
+	ListQuotas(ctx context.Context) ([]*domain.ResourceQuota, error)
+	RequestIncrease(ctx context.Context, resource domain.QuotaResource, requestedLimit int, reason string) (*domain.QuotaRequest, error)
+	// ListRequests returns the quota requests filed by the caller's tenant.
+	ListRequests(ctx context.Context) ([]*domain.QuotaRequest, error)
+
+	// Admin operations.
+	ListTenantQuotas(ctx context.Context, tenantID uuid.UUID) ([]*domain.ResourceQuota, error)
+	SetLimit(ctx context.Context, tenantID uuid.UUID, resource domain.QuotaResource, limit int) error
+	ListAllRequests(ctx context.Context, status domain.QuotaRequestStatus) ([]*domain.QuotaRequest, error)
+	ApproveRequest(ctx context.Context, id uuid.UUID, note string) (*domain.QuotaRequest, error)
+	DenyRequest(ctx context.Context, id uuid.UUID, note string) (*domain.QuotaRequest, error)
+
+	// ReconcileUsage recounts usage from the actual resources and corrects drifted counters.
+	// It returns the number of counters that were corrected.
+	ReconcileUsage(ctx context.Context) (int, error)
+}
@@ -39,6 +39,8 @@ type RBACService interface {
 	Authorize(ctx context.Context, userID uuid.UUID, tenantID uuid.UUID, permission domain.Permission, resource string) error
 	// HasPermission checks if a user has a specific permission on a resource, returning a boolean flag.
 	HasPermission(ctx context.Context, userID uuid.UUID, tenantID uuid.UUID, permission domain.Permission, resource string) (bool, error)
+	// ResourcePolicyOwner returns the tenant owning a resource when a policy attached to it allows the user the permission.
+	ResourcePolicyOwner(ctx context.Context, userID uuid.UUID, tenantID uuid.UUID, permission domain.Permission, resource string) (uuid.UUID, bool)
 
 	// Role management
 
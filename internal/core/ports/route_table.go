@@ -44,8 +44,8 @@ type RouteTableService interface {
 
 	// AddRoute adds a route to an existing route table.
 	// destinationCIDR: CIDR block (e.g., "0.0.0.0/0" or "10.0.1.0/24")
-	// targetType: local, igw, nat, or peering
-	// targetID: UUID of the target resource (IGW, NAT, or Peering) - nil for local
+	// targetType: local, igw, nat, peering, or vpn
+	// targetID: UUID of the target resource (IGW, NAT, Peering, or VPN gateway) - nil for local
 	AddRoute(ctx context.Context, rtID uuid.UUID, destinationCIDR string, targetType domain.RouteTargetType, targetID *uuid.UUID) (*domain.Route, error)
 
 	// RemoveRoute removes a route from a route table.
@@ -3,6 +3,7 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/poyrazk/thecloud/internal/core/domain"
@@ -22,6 +23,23 @@ type SecretRepository interface {
 	Update(ctx context.Context, secret *domain.Secret) error
 	// Delete removes a secret permanently from storage.
 	Delete(ctx context.Context, id uuid.UUID) error
+
+	// AddVersion stores a new value under the next version number and gives it the
+	// single stage in v.Stages, moving that label (and current to previous) atomically.
+	AddVersion(ctx context.Context, v *domain.SecretVersion) error
+	// ListVersions returns all versions of a secret, newest first.
+	ListVersions(ctx context.Context, secretID uuid.UUID) ([]*domain.SecretVersion, error)
+	// GetVersion retrieves a specific version of a secret.
+	GetVersion(ctx context.Context, secretID uuid.UUID, version int) (*domain.SecretVersion, error)
+	// GetVersionByStage retrieves the version holding a stage label.
+	GetVersionByStage(ctx context.Context, secretID uuid.UUID, stage domain.SecretStage) (*domain.SecretVersion, error)
+	// MoveStage attaches a stage label to an existing version. Moving current also
+	// moves previous to the version that held current.
+	MoveStage(ctx context.Context, secretID uuid.UUID, stage domain.SecretStage, version int) error
+	// UpdateRotation persists a secret's rotation settings and schedule.
+	UpdateRotation(ctx context.Context, secret *domain.Secret) error
+	// ListDueForRotation returns secrets across all tenants whose next rotation is at or before now.
+	ListDueForRotation(ctx context.Context, now time.Time) ([]*domain.Secret, error)
 }
 
 // SecretService provides business logic for secure credential storage and retrieval (e.g., KMS/Vault-like).
@@ -37,8 +55,35 @@ type SecretService interface {
 	// DeleteSecret decommissioning a sensitive configuration value.
 	DeleteSecret(ctx context.Context, id uuid.UUID) error
 
+	// PutSecretValue stores a new version, labelled current (promoting immediately) or pending.
+	PutSecretValue(ctx context.Context, id uuid.UUID, value string, stage domain.SecretStage) (*domain.SecretVersion, error)
+	// ListSecretVersions returns version metadata for a secret, without values.
+	ListSecretVersions(ctx context.Context, id uuid.UUID) ([]*domain.SecretVersion, error)
+	// GetSecretVersion retrieves a version by number, including its decrypted value.
+	GetSecretVersion(ctx context.Context, id uuid.UUID, version int) (*domain.SecretVersion, error)
+	// GetSecretVersionByStage retrieves the version holding a stage, including its decrypted value.
+	GetSecretVersionByStage(ctx context.Context, id uuid.UUID, stage domain.SecretStage) (*domain.SecretVersion, error)
+	// UpdateSecretVersionStage moves the current or pending label to a version, e.g. to promote or roll back.
+	UpdateSecretVersionStage(ctx context.Context, id uuid.UUID, stage domain.SecretStage, version int) error
+	// ResolveSecretRef returns the plaintext for a reference such as "@name" or "@name:previous".
+	ResolveSecretRef(ctx context.Context, ref string) (string, error)
+
 	// Encrypt encrypts plain text using the user's derived key.
 	Encrypt(ctx context.Context, userID uuid.UUID, plainText string) (string, error)
 	// Decrypt decrypts cipher text using the user's derived key.
 	Decrypt(ctx context.Context, userID uuid.UUID, cipherText string) (string, error)
 }
+
+// SecretRotationService schedules and performs secret rotation through a Cloud Function.
+// The function receives the secret's name and ID as payload and prints the new value
+// as the last line of its output.
+type SecretRotationService interface {
+	// ConfigureRotation enables scheduled rotation using the given function.
+	ConfigureRotation(ctx context.Context, id, functionID uuid.UUID, intervalDays int) (*domain.Secret, error)
+	// DisableRotation stops scheduled rotation for a secret.
+	DisableRotation(ctx context.Context, id uuid.UUID) error
+	// RotateSecret runs the rotation function now, stages its output as pending and promotes it.
+	RotateSecret(ctx context.Context, id uuid.UUID) (*domain.SecretVersion, error)
+	// RotateDue rotates every secret whose schedule has elapsed and returns how many succeeded.
+	RotateDue(ctx context.Context) (int, error)
+}
@@ -37,6 +37,15 @@ type SecurityGroupRepository interface {
 	RemoveInstanceFromGroup(ctx context.Context, instanceID, groupID uuid.UUID) error
 	// ListInstanceGroups retrieves all security groups currently protecting a specific instance.
 	ListInstanceGroups(ctx context.Context, instanceID uuid.UUID) ([]*domain.SecurityGroup, error)
+	// AddInterfaceToGroup links a network interface to a security group.
+	AddInterfaceToGroup(ctx context.Context, interfaceID, groupID uuid.UUID) error
+	// RemoveInterfaceFromGroup unlinks a network interface from a security group.
+	RemoveInterfaceFromGroup(ctx context.Context, interfaceID, groupID uuid.UUID) error
+	// ListGroupMemberIPs returns the private IPv4 and IPv6 addresses of instances
+	// and network interfaces attached to a group.
+	ListGroupMemberIPs(ctx context.Context, groupID uuid.UUID) ([]string, error)
+	// ListReferencingGroups returns groups, with rules, that have a rule whose source is the given group.
+	ListReferencingGroups(ctx context.Context, groupID uuid.UUID) ([]*domain.SecurityGroup, error)
 }
 
 // SecurityGroupService provides business logic for managing virtual networking firewalls.
@@ -59,4 +68,9 @@ type SecurityGroupService interface {
 	AttachToInstance(ctx context.Context, instanceID, groupID uuid.UUID) error
 	// DetachFromInstance removes a security group's rules from a compute instance.
 	DetachFromInstance(ctx context.Context, instanceID, groupID uuid.UUID) error
+
+	// AttachToNetworkInterface adds a network interface to a security group.
+	AttachToNetworkInterface(ctx context.Context, interfaceID, groupID uuid.UUID) error
+	// DetachFromNetworkInterface removes a network interface from a security group.
+	DetachFromNetworkInterface(ctx context.Context, interfaceID, groupID uuid.UUID) error
 }
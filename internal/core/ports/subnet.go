@@ -18,6 +18,8 @@ type SubnetRepository interface {
 	GetByName(ctx context.Context, vpcID uuid.UUID, name string) (*domain.Subnet, error)
 	// ListByVPC returns all subnets currently defined within a specific virtual network.
 	ListByVPC(ctx context.Context, vpcID uuid.UUID) ([]*domain.Subnet, error)
+	// Update modifies an existing subnet's name and IPv6 block.
+	Update(ctx context.Context, subnet *domain.Subnet) error
 	// Delete removes a subnet definition from persistent storage.
 	Delete(ctx context.Context, id uuid.UUID) error
 }
@@ -30,6 +32,9 @@ type SubnetService interface {
 	GetSubnet(ctx context.Context, idOrName string, vpcID uuid.UUID) (*domain.Subnet, error)
 	// ListSubnets returns all subdivisions within a specified authorized VPC.
 	ListSubnets(ctx context.Context, vpcID uuid.UUID) ([]*domain.Subnet, error)
+	// AssignIPv6CIDRBlock gives the subnet a /64 from its VPC's IPv6 block.
+	// An empty cidrBlock picks the next free /64.
+	AssignIPv6CIDRBlock(ctx context.Context, id uuid.UUID, cidrBlock string) (*domain.Subnet, error)
 	// DeleteSubnet decommissioning a virtual network subdivision.
 	DeleteSubnet(ctx context.Context, id uuid.UUID) error
 }
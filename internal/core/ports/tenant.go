@@ -21,11 +21,9 @@ type TenantRepository interface {
 	ListMembers(ctx context.Context, tenantID uuid.UUID) ([]domain.TenantMember, error)
 	GetMembership(ctx context.Context, tenantID, userID uuid.UUID) (*domain.TenantMember, error)
 	ListUserTenants(ctx context.Context, userID uuid.UUID) ([]domain.Tenant, error)
-
-	GetQuota(ctx context.Context, tenantID uuid.UUID) (*domain.TenantQuota, error)
-	UpdateQuota(ctx context.Context, quota *domain.TenantQuota) error
-	IncrementUsage(ctx context.Context, tenantID uuid.UUID, resource string, amount int) error
-	DecrementUsage(ctx context.Context, tenantID uuid.UUID, resource string, amount int) error
+	UpdateMemberRole(ctx context.Context, tenantID, userID uuid.UUID, role string) error
+	// TransferOwnership hands the tenant to newOwnerID and demotes the current owner to admin atomically.
+	TransferOwnership(ctx context.Context, tenantID, currentOwnerID, newOwnerID uuid.UUID) error
 }
 
 // TenantService defines the business logic for tenant management.
@@ -33,11 +31,30 @@ type TenantService interface {
 	CreateTenant(ctx context.Context, name, slug string, ownerID uuid.UUID) (*domain.Tenant, error)
 	GetTenant(ctx context.Context, id uuid.UUID) (*domain.Tenant, error)
 	ListUserTenants(ctx context.Context, userID uuid.UUID) ([]domain.Tenant, error)
-	InviteMember(ctx context.Context, tenantID uuid.UUID, email, role string) error
+	InviteMember(ctx context.Context, tenantID uuid.UUID, email, role string) (*domain.TenantInvitation, error)
+	ListInvitations(ctx context.Context, tenantID uuid.UUID) ([]domain.TenantInvitation, error)
+	ResendInvitation(ctx context.Context, tenantID, invitationID uuid.UUID) (*domain.TenantInvitation, error)
+	RevokeInvitation(ctx context.Context, tenantID, invitationID uuid.UUID) error
+	ListMyInvitations(ctx context.Context) ([]domain.TenantInvitation, error)
+	AcceptInvitation(ctx context.Context, token string) (*domain.TenantMember, error)
+	DeclineInvitation(ctx context.Context, token string) error
 	RemoveMember(ctx context.Context, tenantID, userID uuid.UUID) error
+	UpdateMemberRole(ctx context.Context, tenantID, userID uuid.UUID, role string) error
+	TransferOwnership(ctx context.Context, tenantID, newOwnerID uuid.UUID) error
 	SwitchTenant(ctx context.Context, userID, tenantID uuid.UUID) error
-	CheckQuota(ctx context.Context, tenantID uuid.UUID, resource string, requested int) error
+	CheckQuota(ctx context.Context, tenantID uuid.UUID, resource domain.QuotaResource, requested int) error
 	GetMembership(ctx context.Context, tenantID, userID uuid.UUID) (*domain.TenantMember, error)
-	IncrementUsage(ctx context.Context, tenantID uuid.UUID, resource string, amount int) error
-	DecrementUsage(ctx context.Context, tenantID uuid.UUID, resource string, amount int) error
+	IncrementUsage(ctx context.Context, tenantID uuid.UUID, resource domain.QuotaResource, amount int) error
+	DecrementUsage(ctx context.Context, tenantID uuid.UUID, resource domain.QuotaResource, amount int) error
+}
+
+// TenantInvitationRepository persists invitations to join a tenant.
+type TenantInvitationRepository interface {
+	Create(ctx context.Context, inv *domain.TenantInvitation) error
+	GetByID(ctx context.Context, tenantID, id uuid.UUID) (*domain.TenantInvitation, error)
+	GetByTokenHash(ctx context.Context, hash string) (*domain.TenantInvitation, error)
+	ListPendingByTenant(ctx context.Context, tenantID uuid.UUID) ([]domain.TenantInvitation, error)
+	ListPendingByEmail(ctx context.Context, email string) ([]domain.TenantInvitation, error)
+	// Update persists a change to a pending invitation and fails with Conflict if it was already answered.
+	Update(ctx context.Context, inv *domain.TenantInvitation) error
 }
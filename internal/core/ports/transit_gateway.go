@@ -0,0 +1,76 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+)
+
+// TransitGatewayRepository manages persistence of transit gateways, their VPC
+// attachments, route tables, routes and route propagations.
+type TransitGatewayRepository interface {
+	Create(ctx context.Context, tgw *domain.TransitGateway) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.TransitGateway, error)
+	List(ctx context.Context) ([]*domain.TransitGateway, error)
+	Update(ctx context.Context, tgw *domain.TransitGateway) error
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// Attachment operations
+	CreateAttachment(ctx context.Context, att *domain.TransitGatewayAttachment) error
+	GetAttachment(ctx context.Context, id uuid.UUID) (*domain.TransitGatewayAttachment, error)
+	ListAttachments(ctx context.Context, tgwID uuid.UUID) ([]*domain.TransitGatewayAttachment, error)
+	UpdateAttachment(ctx context.Context, att *domain.TransitGatewayAttachment) error
+	// DeleteAttachment also removes every route and propagation that references the attachment.
+	DeleteAttachment(ctx context.Context, id uuid.UUID) error
+
+	// Route table operations
+	CreateRouteTable(ctx context.Context, rt *domain.TransitGatewayRouteTable) error
+	GetRouteTable(ctx context.Context, id uuid.UUID) (*domain.TransitGatewayRouteTable, error)
+	ListRouteTables(ctx context.Context, tgwID uuid.UUID) ([]*domain.TransitGatewayRouteTable, error)
+	DeleteRouteTable(ctx context.Context, id uuid.UUID) error
+
+	// Route operations
+	AddRoute(ctx context.Context, route *domain.TransitGatewayRoute) error
+	ListRoutes(ctx context.Context, rtID uuid.UUID) ([]domain.TransitGatewayRoute, error)
+	RemoveRoute(ctx context.Context, rtID, routeID uuid.UUID) error
+
+	// Propagation operations
+	AddPropagation(ctx context.Context, rtID, attachmentID uuid.UUID) error
+	ListPropagations(ctx context.Context, rtID uuid.UUID) ([]uuid.UUID, error)
+	// RemovePropagation drops the propagation and the routes it learned.
+	RemovePropagation(ctx context.Context, rtID, attachmentID uuid.UUID) error
+}
+
+// TransitGatewayService provides business logic for transit gateways: hub
+// bridges that VPCs attach to for transitive, route-table driven connectivity.
+type TransitGatewayService interface {
+	// CreateTransitGateway creates the hub bridge and a default route table.
+	CreateTransitGateway(ctx context.Context, name string) (*domain.TransitGateway, error)
+	GetTransitGateway(ctx context.Context, id uuid.UUID) (*domain.TransitGateway, error)
+	ListTransitGateways(ctx context.Context) ([]*domain.TransitGateway, error)
+	// DeleteTransitGateway removes a gateway without attachments and its hub bridge.
+	DeleteTransitGateway(ctx context.Context, id uuid.UUID) error
+
+	// AttachVPC links a VPC bridge to the hub, associates the attachment with the
+	// default route table and propagates the VPC CIDRs into it.
+	AttachVPC(ctx context.Context, tgwID, vpcID uuid.UUID) (*domain.TransitGatewayAttachment, error)
+	ListAttachments(ctx context.Context, tgwID uuid.UUID) ([]*domain.TransitGatewayAttachment, error)
+	// DetachVPC unlinks the VPC and removes its routes from the gateway and from the VPC.
+	DetachVPC(ctx context.Context, attachmentID uuid.UUID) error
+
+	CreateRouteTable(ctx context.Context, tgwID uuid.UUID, name string) (*domain.TransitGatewayRouteTable, error)
+	GetRouteTable(ctx context.Context, id uuid.UUID) (*domain.TransitGatewayRouteTable, error)
+	ListRouteTables(ctx context.Context, tgwID uuid.UUID) ([]*domain.TransitGatewayRouteTable, error)
+	DeleteRouteTable(ctx context.Context, id uuid.UUID) error
+
+	// AssociateRouteTable selects the route table used for traffic arriving from an attachment.
+	AssociateRouteTable(ctx context.Context, rtID, attachmentID uuid.UUID) error
+	// EnablePropagation learns the attachment's VPC CIDRs into the route table.
+	EnablePropagation(ctx context.Context, rtID, attachmentID uuid.UUID) error
+	DisablePropagation(ctx context.Context, rtID, attachmentID uuid.UUID) error
+
+	// AddRoute adds a static route; a nil attachment makes it a blackhole.
+	AddRoute(ctx context.Context, rtID uuid.UUID, destinationCIDR string, attachmentID *uuid.UUID) (*domain.TransitGatewayRoute, error)
+	RemoveRoute(ctx context.Context, rtID, routeID uuid.UUID) error
+}
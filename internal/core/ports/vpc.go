@@ -20,6 +20,8 @@ type VpcRepository interface {
 	GetByIdempotencyKey(ctx context.Context, key string) (*domain.VPC, error)
 	// List returns all VPCs accessible to the current user context.
 	List(ctx context.Context) ([]*domain.VPC, error)
+	// ListAll returns every VPC across all tenants, for platform-level background jobs.
+	ListAll(ctx context.Context) ([]*domain.VPC, error)
 	// Update modifies an existing VPC record.
 	Update(ctx context.Context, vpc *domain.VPC) error
 	// Delete removes a VPC definition from storage.
@@ -36,6 +38,9 @@ type VpcService interface {
 	ListVPCs(ctx context.Context) ([]*domain.VPC, error)
 	// UpdateVPC modifies an existing VPC's name.
 	UpdateVPC(ctx context.Context, idOrName, name string) (*domain.VPC, error)
+	// AssignIPv6CIDRBlock makes a VPC dual-stack by giving it a /56 IPv6 block.
+	// An empty cidrBlock generates a random unique local (fd00::/8) block.
+	AssignIPv6CIDRBlock(ctx context.Context, idOrName, cidrBlock string) (*domain.VPC, error)
 	// DeleteVPC decommissioning an existing virtual network.
 	// If force is true, dependency checks are skipped (for async cleanup scenarios).
 	DeleteVPC(ctx context.Context, idOrName string, force bool) error
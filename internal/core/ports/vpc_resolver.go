@@ -0,0 +1,42 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+)
+
+// VPCResolverRepository manages persistence of VPC resolvers and their forwarding rules.
+type VPCResolverRepository interface {
+	Create(ctx context.Context, resolver *domain.VPCResolver) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.VPCResolver, error)
+	// GetByVPC returns the resolver of a VPC, or a NotFound error if the VPC has none.
+	GetByVPC(ctx context.Context, vpcID uuid.UUID) (*domain.VPCResolver, error)
+	List(ctx context.Context) ([]*domain.VPCResolver, error)
+	Update(ctx context.Context, resolver *domain.VPCResolver) error
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// Forwarding rule operations
+	CreateRule(ctx context.Context, rule *domain.ResolverForwardingRule) error
+	ListRules(ctx context.Context, resolverID uuid.UUID) ([]*domain.ResolverForwardingRule, error)
+	DeleteRule(ctx context.Context, resolverID, ruleID uuid.UUID) error
+}
+
+// VPCResolverService provides business logic for per-VPC DNS resolvers.
+type VPCResolverService interface {
+	// CreateResolver launches the resolver container of a VPC. A VPC has at most one resolver.
+	CreateResolver(ctx context.Context, vpcID uuid.UUID) (*domain.VPCResolver, error)
+	GetResolver(ctx context.Context, id uuid.UUID) (*domain.VPCResolver, error)
+	ListResolvers(ctx context.Context) ([]*domain.VPCResolver, error)
+	DeleteResolver(ctx context.Context, id uuid.UUID) error
+
+	CreateForwardingRule(ctx context.Context, resolverID uuid.UUID, domainName string, targetIPs []string) (*domain.ResolverForwardingRule, error)
+	ListForwardingRules(ctx context.Context, resolverID uuid.UUID) ([]*domain.ResolverForwardingRule, error)
+	DeleteForwardingRule(ctx context.Context, resolverID, ruleID uuid.UUID) error
+
+	// RefreshResolvers rewrites the configuration of the VPC's resolver and of the
+	// resolvers of every VPC actively peered with it. It is called when private
+	// zones or peerings change.
+	RefreshResolvers(ctx context.Context, vpcID uuid.UUID) error
+}
@@ -0,0 +1,60 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+)
+
+// VPNRepository manages persistence of VPN gateways, customer gateways and VPN connections.
+type VPNRepository interface {
+	CreateGateway(ctx context.Context, gw *domain.VPNGateway) error
+	GetGateway(ctx context.Context, id uuid.UUID) (*domain.VPNGateway, error)
+	ListGatewaysByVPC(ctx context.Context, vpcID uuid.UUID) ([]*domain.VPNGateway, error)
+	UpdateGateway(ctx context.Context, gw *domain.VPNGateway) error
+	DeleteGateway(ctx context.Context, id uuid.UUID) error
+
+	// Customer gateway operations
+	CreateCustomerGateway(ctx context.Context, cgw *domain.CustomerGateway) error
+	GetCustomerGateway(ctx context.Context, id uuid.UUID) (*domain.CustomerGateway, error)
+	ListCustomerGateways(ctx context.Context) ([]*domain.CustomerGateway, error)
+	DeleteCustomerGateway(ctx context.Context, id uuid.UUID) error
+
+	// Connection operations
+	CreateConnection(ctx context.Context, conn *domain.VPNConnection) error
+	GetConnection(ctx context.Context, id uuid.UUID) (*domain.VPNConnection, error)
+	ListConnectionsByGateway(ctx context.Context, gatewayID uuid.UUID) ([]*domain.VPNConnection, error)
+	ListConnectionsByCustomerGateway(ctx context.Context, customerGatewayID uuid.UUID) ([]*domain.VPNConnection, error)
+	UpdateConnection(ctx context.Context, conn *domain.VPNConnection) error
+	DeleteConnection(ctx context.Context, id uuid.UUID) error
+}
+
+// VPNService provides business logic for site-to-site VPNs between VPCs and on-premises networks.
+type VPNService interface {
+	// CreateVPNGateway launches a WireGuard gateway container in the VPC and stores
+	// its generated private key in the secrets service.
+	CreateVPNGateway(ctx context.Context, vpcID uuid.UUID, name string) (*domain.VPNGateway, error)
+	GetVPNGateway(ctx context.Context, id uuid.UUID) (*domain.VPNGateway, error)
+	ListVPNGateways(ctx context.Context, vpcID uuid.UUID) ([]*domain.VPNGateway, error)
+	// DeleteVPNGateway removes a gateway that has no connections, its container and its key.
+	DeleteVPNGateway(ctx context.Context, id uuid.UUID) error
+
+	// CreateCustomerGateway registers an on-premises WireGuard endpoint.
+	CreateCustomerGateway(ctx context.Context, name, ipAddress, publicKey string) (*domain.CustomerGateway, error)
+	ListCustomerGateways(ctx context.Context) ([]*domain.CustomerGateway, error)
+	// DeleteCustomerGateway removes a customer gateway that no connection uses.
+	DeleteCustomerGateway(ctx context.Context, id uuid.UUID) error
+
+	// CreateVPNConnection configures a tunnel peer on the gateway, stores a generated
+	// pre-shared key in the secrets service and routes remoteCIDRs to the gateway
+	// from the VPC's main route table.
+	CreateVPNConnection(ctx context.Context, gatewayID, customerGatewayID uuid.UUID, remoteCIDRs []string) (*domain.VPNConnection, error)
+	GetVPNConnection(ctx context.Context, id uuid.UUID) (*domain.VPNConnection, error)
+	ListVPNConnections(ctx context.Context, gatewayID uuid.UUID) ([]*domain.VPNConnection, error)
+	// GetVPNConnectionConfig returns the settings for the customer's side of the tunnel,
+	// including the decrypted pre-shared key.
+	GetVPNConnectionConfig(ctx context.Context, id uuid.UUID) (*domain.VPNConnectionConfig, error)
+	// DeleteVPNConnection removes the tunnel peer, its routes and its pre-shared key.
+	DeleteVPNConnection(ctx context.Context, id uuid.UUID) error
+}
@@ -11,22 +11,25 @@ import (
 	appcontext "github.com/poyrazk/thecloud/internal/core/context"
 	"github.com/poyrazk/thecloud/internal/core/domain"
 	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/errors"
 )
 
 type accountingService struct {
 	repo         ports.AccountingRepository
 	rbacSvc      ports.RBACService
 	instanceRepo ports.InstanceRepository
+	orgRepo      ports.OrganizationRepository
 	logger       *slog.Logger
 	// In a real system, we'd have price configuration here
 }
 
 // NewAccountingService constructs an AccountingService with its dependencies.
-func NewAccountingService(repo ports.AccountingRepository, rbacSvc ports.RBACService, instanceRepo ports.InstanceRepository, logger *slog.Logger) ports.AccountingService {
+func NewAccountingService(repo ports.AccountingRepository, rbacSvc ports.RBACService, instanceRepo ports.InstanceRepository, orgRepo ports.OrganizationRepository, logger *slog.Logger) ports.AccountingService {
 	return &accountingService{
 		repo:         repo,
 		rbacSvc:      rbacSvc,
 		instanceRepo: instanceRepo,
+		orgRepo:      orgRepo,
 		logger:       logger,
 	}
 }
@@ -51,20 +54,9 @@ func (s *accountingService) GetSummary(ctx context.Context, userID uuid.UUID, st
 		return nil, err
 	}
 
-	// Simple simulated billing: $0.01 per minute for instances, etc.
-	total := 0.0
-	for resType, quantity := range usage {
-		switch resType {
-		case domain.ResourceInstance:
-			total += quantity * 0.01
-		case domain.ResourceStorage:
-			total += quantity * 0.005
-		}
-	}
-
 	return &domain.BillSummary{
 		UserID:      userID,
-		TotalAmount: total,
+		TotalAmount: billableAmount(usage),
 		Currency:    "USD",
 		UsageByType: usage,
 		PeriodStart: start,
@@ -72,6 +64,53 @@ func (s *accountingService) GetSummary(ctx context.Context, userID uuid.UUID, st
 	}, nil
 }
 
+func (s *accountingService) GetOrganizationSummary(ctx context.Context, orgID uuid.UUID, start, end time.Time) (*domain.OrganizationBillSummary, error) {
+	if s.orgRepo == nil {
+		return nil, errors.New(errors.NotImplemented, "organizations are not enabled")
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	// Consolidated bills span tenants the caller may not belong to, so only the organization owner sees them.
+	if org.OwnerID != appcontext.UserIDFromContext(ctx) {
+		return nil, errors.New(errors.NotFound, "organization not found")
+	}
+
+	tenantIDs, err := s.orgRepo.ListTenantIDs(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &domain.OrganizationBillSummary{
+		OrganizationID: orgID,
+		Currency:       "USD",
+		Tenants:        make([]domain.TenantBill, 0, len(tenantIDs)),
+		PeriodStart:    start,
+		PeriodEnd:      end,
+	}
+	if len(tenantIDs) == 0 {
+		return summary, nil
+	}
+
+	usageByTenant, err := s.repo.GetUsageSummaryByTenant(ctx, tenantIDs, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tenantID := range tenantIDs {
+		usage := usageByTenant[tenantID]
+		if usage == nil {
+			usage = map[domain.ResourceType]float64{}
+		}
+		amount := billableAmount(usage)
+		summary.Tenants = append(summary.Tenants, domain.TenantBill{TenantID: tenantID, Amount: amount, UsageByType: usage})
+		summary.TotalAmount += amount
+	}
+	return summary, nil
+}
+
 func (s *accountingService) ListUsage(ctx context.Context, userID uuid.UUID, start, end time.Time) ([]domain.UsageRecord, error) {
 	uID := appcontext.UserIDFromContext(ctx)
 	tenantID := appcontext.TenantIDFromContext(ctx)
@@ -96,13 +135,17 @@ func (s *accountingService) ProcessHourlyBilling(ctx context.Context) error {
 
 	for _, inst := range instances {
 		if inst.Status == domain.StatusRunning {
+			resourceType := domain.ResourceInstance
+			if inst.IsPreemptible() {
+				resourceType = domain.ResourcePreemptibleInstance
+			}
 			// Record 60 minutes of usage
 			record := domain.UsageRecord{
 				ID:           uuid.New(),
 				UserID:       inst.UserID,
 				TenantID:     inst.TenantID,
 				ResourceID:   inst.ID,
-				ResourceType: domain.ResourceInstance,
+				ResourceType: resourceType,
 				Quantity:     60, // 60 minutes
 				Unit:         "minute",
 				StartTime:    startTime,
@@ -117,3 +160,20 @@ func (s *accountingService) ProcessHourlyBilling(ctx context.Context) error {
 
 	return nil
 }
+
+// billableAmount prices aggregated usage.
+// Simple simulated billing: $0.01 per minute for instances, etc.
+func billableAmount(usage map[domain.ResourceType]float64) float64 {
+	total := 0.0
+	for resType, quantity := range usage {
+		switch resType {
+		case domain.ResourceInstance:
+			total += quantity * 0.01
+		case domain.ResourcePreemptibleInstance:
+			total += quantity * 0.01 * domain.PreemptiblePriceFactor
+		case domain.ResourceStorage:
+			total += quantity * 0.005
+		}
+	}
+	return total
+}
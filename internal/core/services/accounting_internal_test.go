@@ -25,6 +25,10 @@ func (m *MockAccountingRepository) GetUsageSummary(ctx context.Context, userID u
 	return nil, nil
 }
 
+func (m *MockAccountingRepository) GetUsageSummaryByTenant(ctx context.Context, tenantIDs []uuid.UUID, start, end time.Time) (map[uuid.UUID]map[domain.ResourceType]float64, error) {
+	return nil, nil
+}
+
 func TestAccountingService_Internal(t *testing.T) {
 	repo := new(MockAccountingRepository)
 	s := &accountingService{repo: repo}
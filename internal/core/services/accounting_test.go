@@ -33,7 +33,7 @@ func setupAccountingServiceTest(t *testing.T) (ports.AccountingService, ports.Ac
 	rbacSvc := new(MockRBACService)
 	rbacSvc.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
-	svc := services.NewAccountingService(repo, rbacSvc, instRepo, logger)
+	svc := services.NewAccountingService(repo, rbacSvc, instRepo, postgres.NewOrganizationRepo(db), logger)
 
 	return svc, repo, instRepo, ctx
 }
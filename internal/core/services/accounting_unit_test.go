@@ -8,8 +8,10 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
 	"github.com/poyrazk/thecloud/internal/core/domain"
 	"github.com/poyrazk/thecloud/internal/core/services"
+	"github.com/poyrazk/thecloud/internal/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -21,7 +23,7 @@ func TestAccountingServiceUnit(t *testing.T) {
 	rbacSvc := new(MockRBACService)
 	rbacSvc.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
-	svc := services.NewAccountingService(mockRepo, rbacSvc, mockInstRepo, slog.Default())
+	svc := services.NewAccountingService(mockRepo, rbacSvc, mockInstRepo, nil, slog.Default())
 	ctx := context.Background()
 
 	t.Run("TrackUsage", func(t *testing.T) {
@@ -57,6 +59,16 @@ func TestAccountingServiceUnit(t *testing.T) {
 		assert.Equal(t, userID, summary.UserID)
 	})
 
+	t.Run("GetSummary_Preemptible", func(t *testing.T) {
+		userID := uuid.New()
+		usage := map[domain.ResourceType]float64{domain.ResourcePreemptibleInstance: 100}
+		mockRepo.On("GetUsageSummary", mock.Anything, userID, mock.Anything, mock.Anything).Return(usage, nil).Once()
+
+		summary, err := svc.GetSummary(ctx, userID, time.Now(), time.Now())
+		require.NoError(t, err)
+		assert.InDelta(t, 0.3, summary.TotalAmount, 0.0001)
+	})
+
 	t.Run("GetSummary_Empty", func(t *testing.T) {
 		userID := uuid.New()
 		mockRepo.On("GetUsageSummary", mock.Anything, userID, mock.Anything, mock.Anything).Return(make(map[domain.ResourceType]float64), nil).Once()
@@ -95,6 +107,18 @@ func TestAccountingServiceUnit(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("ProcessHourlyBilling_Preemptible", func(t *testing.T) {
+		inst := &domain.Instance{ID: uuid.New(), Status: domain.StatusRunning,
+			Metadata: map[string]string{domain.MetadataCapacityType: string(domain.CapacityPreemptible)}}
+		mockInstRepo.On("ListAll", mock.Anything).Return([]*domain.Instance{inst}, nil).Once()
+		mockRepo.On("CreateRecord", mock.Anything, mock.MatchedBy(func(r domain.UsageRecord) bool {
+			return r.ResourceID == inst.ID && r.ResourceType == domain.ResourcePreemptibleInstance
+		})).Return(nil).Once()
+
+		require.NoError(t, svc.ProcessHourlyBilling(ctx))
+		mockRepo.AssertExpectations(t)
+	})
+
 	t.Run("ProcessHourlyBilling_RepoFailure", func(t *testing.T) {
 		inst := &domain.Instance{ID: uuid.New(), Status: domain.StatusRunning}
 		mockInstRepo.On("ListAll", mock.Anything).Return([]*domain.Instance{inst}, nil).Once()
@@ -104,3 +128,41 @@ func TestAccountingServiceUnit(t *testing.T) {
 		require.NoError(t, err) // continues on error
 	})
 }
+
+func TestAccountingService_OrganizationSummary(t *testing.T) {
+	mockRepo := new(MockAccountingRepo)
+	orgRepo := new(MockOrganizationRepository)
+	rbacSvc := new(MockRBACService)
+	svc := services.NewAccountingService(mockRepo, rbacSvc, new(MockInstanceRepo), orgRepo, slog.Default())
+
+	ownerID := uuid.New()
+	orgID := uuid.New()
+	tenantA, tenantB := uuid.New(), uuid.New()
+	ctx := appcontext.WithUserID(context.Background(), ownerID)
+	start := time.Now().Add(-24 * time.Hour)
+	end := time.Now()
+
+	t.Run("ConsolidatesTenants", func(t *testing.T) {
+		orgRepo.On("GetByID", mock.Anything, orgID).Return(&domain.Organization{ID: orgID, OwnerID: ownerID}, nil).Once()
+		orgRepo.On("ListTenantIDs", mock.Anything, orgID).Return([]uuid.UUID{tenantA, tenantB}, nil).Once()
+		mockRepo.On("GetUsageSummaryByTenant", mock.Anything, []uuid.UUID{tenantA, tenantB}, start, end).Return(map[uuid.UUID]map[domain.ResourceType]float64{
+			tenantA: {domain.ResourceInstance: 100},
+			tenantB: {domain.ResourceStorage: 200},
+		}, nil).Once()
+
+		summary, err := svc.GetOrganizationSummary(ctx, orgID, start, end)
+		require.NoError(t, err)
+		require.Len(t, summary.Tenants, 2)
+		assert.InDelta(t, 1.0, summary.Tenants[0].Amount, 0.01)
+		assert.InDelta(t, 1.0, summary.Tenants[1].Amount, 0.01)
+		assert.InDelta(t, 2.0, summary.TotalAmount, 0.01)
+	})
+
+	t.Run("NotOwner", func(t *testing.T) {
+		orgRepo.On("GetByID", mock.Anything, orgID).Return(&domain.Organization{ID: orgID, OwnerID: uuid.New()}, nil).Once()
+
+		_, err := svc.GetOrganizationSummary(ctx, orgID, start, end)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.NotFound))
+	})
+}
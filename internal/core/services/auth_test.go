@@ -47,10 +47,11 @@ func setupAuthServiceTest(t *testing.T) (*pgxpool.Pool, *services.AuthService, *
 		AuditSvc: auditSvc,
 	})
 	tenantSvc := services.NewTenantService(services.TenantServiceParams{
-		Repo:     tenantRepo,
-		UserRepo: userRepo,
-		RBACSvc:  rbacSvc,
-		Logger:   slog.Default(),
+		Repo:      tenantRepo,
+		QuotaRepo: postgres.NewQuotaRepo(db),
+		UserRepo:  userRepo,
+		RBACSvc:   rbacSvc,
+		Logger:    slog.Default(),
 	})
 	svc := services.NewAuthService(userRepo, identitySvc, auditSvc, tenantSvc, db, slog.Default())
 
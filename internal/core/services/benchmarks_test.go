@@ -139,7 +139,7 @@ func BenchmarkFunctionServiceInvoke(b *testing.B) {
 	secretSvc := &noop.NoopSecretService{}
 	logger := slog.Default()
 
-	svc := services.NewFunctionService(repo, rbacSvc, compute, fileStore, auditSvc, secretSvc, logger)
+	svc := services.NewFunctionService(repo, rbacSvc, compute, fileStore, auditSvc, secretSvc, nil, logger)
 
 	ctx := context.Background()
 	id := uuid.New()
@@ -207,7 +207,31 @@ func (s *NoopTenantService) GetTenant(ctx context.Context, id uuid.UUID) (*domai
 func (s *NoopTenantService) ListUserTenants(ctx context.Context, userID uuid.UUID) ([]domain.Tenant, error) {
 	return nil, nil
 }
-func (s *NoopTenantService) InviteMember(ctx context.Context, tenantID uuid.UUID, email, role string) error {
+func (s *NoopTenantService) InviteMember(ctx context.Context, tenantID uuid.UUID, email, role string) (*domain.TenantInvitation, error) {
+	return nil, nil
+}
+func (s *NoopTenantService) ListInvitations(ctx context.Context, tenantID uuid.UUID) ([]domain.TenantInvitation, error) {
+	return nil, nil
+}
+func (s *NoopTenantService) ResendInvitation(ctx context.Context, tenantID, invitationID uuid.UUID) (*domain.TenantInvitation, error) {
+	return nil, nil
+}
+func (s *NoopTenantService) RevokeInvitation(ctx context.Context, tenantID, invitationID uuid.UUID) error {
+	return nil
+}
+func (s *NoopTenantService) ListMyInvitations(ctx context.Context) ([]domain.TenantInvitation, error) {
+	return nil, nil
+}
+func (s *NoopTenantService) AcceptInvitation(ctx context.Context, token string) (*domain.TenantMember, error) {
+	return nil, nil
+}
+func (s *NoopTenantService) DeclineInvitation(ctx context.Context, token string) error {
+	return nil
+}
+func (s *NoopTenantService) UpdateMemberRole(ctx context.Context, tenantID, userID uuid.UUID, role string) error {
+	return nil
+}
+func (s *NoopTenantService) TransferOwnership(ctx context.Context, tenantID, newOwnerID uuid.UUID) error {
 	return nil
 }
 func (s *NoopTenantService) RemoveMember(ctx context.Context, tenantID, userID uuid.UUID) error {
@@ -216,16 +240,16 @@ func (s *NoopTenantService) RemoveMember(ctx context.Context, tenantID, userID u
 func (s *NoopTenantService) SwitchTenant(ctx context.Context, userID, tenantID uuid.UUID) error {
 	return nil
 }
-func (s *NoopTenantService) CheckQuota(ctx context.Context, tenantID uuid.UUID, resource string, requested int) error {
+func (s *NoopTenantService) CheckQuota(ctx context.Context, tenantID uuid.UUID, resource domain.QuotaResource, requested int) error {
 	return nil
 }
 func (s *NoopTenantService) GetMembership(ctx context.Context, tenantID, userID uuid.UUID) (*domain.TenantMember, error) {
 	return &domain.TenantMember{}, nil
 }
-func (s *NoopTenantService) IncrementUsage(ctx context.Context, tenantID uuid.UUID, resource string, amount int) error {
+func (s *NoopTenantService) IncrementUsage(ctx context.Context, tenantID uuid.UUID, resource domain.QuotaResource, amount int) error {
 	return nil
 }
-func (s *NoopTenantService) DecrementUsage(ctx context.Context, tenantID uuid.UUID, resource string, amount int) error {
+func (s *NoopTenantService) DecrementUsage(ctx context.Context, tenantID uuid.UUID, resource domain.QuotaResource, amount int) error {
 	return nil
 }
 
@@ -414,7 +438,7 @@ func BenchmarkCacheServiceList(b *testing.B) {
 	rbacSvc := &noop.NoopRBACService{}
 	logger := slog.Default()
 
-	svc := services.NewCacheService(repo, rbacSvc, compute, vpcRepo, eventSvc, auditSvc, logger)
+	svc := services.NewCacheService(services.CacheServiceParams{Repo: repo, RBAC: rbacSvc, Compute: compute, VpcRepo: vpcRepo, EventSvc: eventSvc, AuditSvc: auditSvc, Logger: logger})
 
 	ctx := context.Background()
 
@@ -460,7 +484,7 @@ func BenchmarkFunctionServiceList(b *testing.B) {
 	secretSvc := &noop.NoopSecretService{}
 	logger := slog.Default()
 
-	svc := services.NewFunctionService(repo, rbacSvc, compute, fileStore, auditSvc, secretSvc, logger)
+	svc := services.NewFunctionService(repo, rbacSvc, compute, fileStore, auditSvc, secretSvc, nil, logger)
 
 	ctx := context.Background()
 
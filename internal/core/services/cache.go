@@ -8,6 +8,7 @@ import (
 	"io"
 	"log/slog"
 	"math"
+	"net"
 	"strconv"
 	"strings"
 	"time"
@@ -27,50 +28,68 @@ import (
 const tracerNameCache = "cache-service"
 
 const (
-	defaultRedisPort = "6379"
+	defaultRedisPort     = "6379"
+	defaultMemcachedPort = "11211"
+	// Exporter defaults
+	redisExporterImage     = "oliver006/redis_exporter"
+	redisExporterPort      = "9121"
+	memcachedExporterImage = "prom/memcached-exporter"
+	memcachedExporterPort  = "9150"
 	// maxCacheStatsSize bounds cache stats JSON decoding to prevent memory exhaustion.
 	maxCacheStatsSize = 1 * 1024 * 1024 // 1 MB
 )
 
 // CacheService manages cache clusters and their lifecycle.
 type CacheService struct {
-	repo     ports.CacheRepository
-	rbacSvc  ports.RBACService
-	compute  ports.ComputeBackend
-	vpcRepo  ports.VpcRepository
-	eventSvc ports.EventService
-	auditSvc ports.AuditService
-	logger   *slog.Logger
+	repo      ports.CacheRepository
+	rbacSvc   ports.RBACService
+	compute   ports.ComputeBackend
+	vpcRepo   ports.VpcRepository
+	volumeSvc ports.VolumeService
+	eventSvc  ports.EventService
+	auditSvc  ports.AuditService
+	tenantSvc ports.TenantService
+	logger    *slog.Logger
+}
+
+// CacheServiceParams holds dependencies for CacheService creation.
+type CacheServiceParams struct {
+	Repo      ports.CacheRepository
+	RBAC      ports.RBACService
+	Compute   ports.ComputeBackend
+	VpcRepo   ports.VpcRepository
+	VolumeSvc ports.VolumeService // Optional, required for persistence
+	EventSvc  ports.EventService
+	AuditSvc  ports.AuditService
+	TenantSvc ports.TenantService // Optional, enforces the caches quota
+	Logger    *slog.Logger
 }
 
 // NewCacheService constructs a CacheService with its dependencies.
-func NewCacheService(
-	repo ports.CacheRepository,
-	rbacSvc ports.RBACService,
-	compute ports.ComputeBackend,
-	vpcRepo ports.VpcRepository,
-	eventSvc ports.EventService,
-	auditSvc ports.AuditService,
-	logger *slog.Logger,
-) *CacheService {
+func NewCacheService(params CacheServiceParams) *CacheService {
 	return &CacheService{
-		repo:     repo,
-		rbacSvc:  rbacSvc,
-		compute:  compute,
-		vpcRepo:  vpcRepo,
-		eventSvc: eventSvc,
-		auditSvc: auditSvc,
-		logger:   logger,
+		repo:      params.Repo,
+		rbacSvc:   params.RBAC,
+		compute:   params.Compute,
+		vpcRepo:   params.VpcRepo,
+		volumeSvc: params.VolumeSvc,
+		eventSvc:  params.EventSvc,
+		auditSvc:  params.AuditSvc,
+		tenantSvc: params.TenantSvc,
+		logger:    params.Logger,
 	}
 }
 
-func (s *CacheService) CreateCache(ctx context.Context, name, version string, memoryMB int, vpcID *uuid.UUID) (*domain.Cache, error) {
+func (s *CacheService) CreateCache(ctx context.Context, req ports.CreateCacheRequest) (*domain.Cache, error) {
 	tracer := otel.Tracer(tracerNameCache)
 	_, span := tracer.Start(ctx, "CacheService.CreateCache",
 		trace.WithAttributes(
-			attribute.String("cache.name", name),
-			attribute.String("cache.version", version),
-			attribute.Int("cache.memory_mb", memoryMB),
+			attribute.String("cache.name", req.Name),
+			attribute.String("cache.engine", string(req.Engine)),
+			attribute.String("cache.version", req.Version),
+			attribute.Int("cache.memory_mb", req.MemoryMB),
+			attribute.Int("cache.replicas", req.Replicas),
+			attribute.Bool("cache.cluster_mode", req.ClusterMode),
 		))
 	defer span.End()
 
@@ -86,27 +105,82 @@ func (s *CacheService) CreateCache(ctx context.Context, name, version string, me
 		return nil, errors.New(errors.InvalidInput, "managed cache requires docker compute backend (COMPUTE_BACKEND=libvirt is not supported)")
 	}
 
-	password, err := util.GenerateRandomPassword(16)
-	if err != nil {
-		return nil, errors.Wrap(errors.Internal, "failed to generate password", err)
+	engine := req.Engine
+	if engine == "" {
+		engine = domain.EngineRedis
+	}
+	if !engine.IsValid() {
+		return nil, errors.New(errors.InvalidInput, fmt.Sprintf("unsupported cache engine %q (expected redis, valkey or memcached)", engine))
+	}
+	persistence := req.Persistence
+	if persistence == "" {
+		persistence = domain.CachePersistenceNone
+	}
+	if !persistence.IsValid() {
+		return nil, errors.New(errors.InvalidInput, fmt.Sprintf("unsupported cache persistence %q (expected none, rdb or aof)", persistence))
+	}
+	if !engine.RedisCompatible() && (persistence != domain.CachePersistenceNone || req.Replicas > 0 || req.FailoverEnabled || req.ClusterMode) {
+		return nil, errors.New(errors.InvalidInput, fmt.Sprintf("%s caches do not support persistence, replicas or cluster mode", engine))
+	}
+	if engine == domain.EngineMemcached && req.VpcID == nil {
+		return nil, errors.New(errors.InvalidInput, "memcached caches have no authentication and must be created in a VPC")
+	}
+	if req.Replicas < 0 || req.Replicas > domain.MaxCacheReplicas {
+		return nil, errors.New(errors.InvalidInput, fmt.Sprintf("replicas must be between 0 and %d", domain.MaxCacheReplicas))
+	}
+	if req.FailoverEnabled && req.Replicas == 0 {
+		return nil, errors.New(errors.InvalidInput, "automatic failover requires at least one replica")
+	}
+	shards := req.Shards
+	if req.ClusterMode {
+		if shards == 0 {
+			shards = domain.MinCacheShards
+		}
+		if shards < domain.MinCacheShards || shards > domain.MaxCacheShards {
+			return nil, errors.New(errors.InvalidInput, fmt.Sprintf("shards must be between %d and %d", domain.MinCacheShards, domain.MaxCacheShards))
+		}
+	} else if shards != 0 {
+		return nil, errors.New(errors.InvalidInput, "shards require cluster mode")
+	}
+	if persistence != domain.CachePersistenceNone && s.volumeSvc == nil {
+		return nil, errors.New(errors.Internal, "volume service not configured")
+	}
+
+	if err := checkQuota(ctx, s.tenantSvc, domain.QuotaCaches, 1); err != nil {
+		return nil, err
+	}
+
+	var password string
+	if engine.RedisCompatible() {
+		var err error
+		if password, err = util.GenerateRandomPassword(16); err != nil {
+			return nil, errors.Wrap(errors.Internal, "failed to generate password", err)
+		}
 	}
 
 	cache := &domain.Cache{
-		ID:        uuid.New(),
-		UserID:    userID,
-		TenantID:  tenantID,
-		Name:      name,
-		Engine:    domain.EngineRedis,
-		Version:   version,
-		Status:    domain.CacheStatusCreating,
-		VpcID:     vpcID,
-		Password:  password,
-		MemoryMB:  memoryMB,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}
-
-	networkID, err := s.resolveNetworkID(ctx, vpcID)
+		ID:          uuid.New(),
+		UserID:      userID,
+		TenantID:    tenantID,
+		Name:        req.Name,
+		Engine:      engine,
+		Version:     req.Version,
+		Status:      domain.CacheStatusCreating,
+		VpcID:       req.VpcID,
+		Password:    password,
+		MemoryMB:    req.MemoryMB,
+		Persistence: persistence,
+		Replicas:    req.Replicas,
+		// Redis Cluster promotes replicas by itself.
+		FailoverEnabled: req.FailoverEnabled || (req.ClusterMode && req.Replicas > 0),
+		ClusterMode:     req.ClusterMode,
+		Shards:          shards,
+		MetricsEnabled:  req.MetricsEnabled,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+
+	networkID, err := s.resolveNetworkID(ctx, req.VpcID)
 	if err != nil {
 		return nil, err
 	}
@@ -114,41 +188,28 @@ func (s *CacheService) CreateCache(ctx context.Context, name, version string, me
 	if err := s.repo.Create(ctx, cache); err != nil {
 		return nil, err
 	}
+	recordUsage(ctx, s.tenantSvc, s.logger, tenantID, domain.QuotaCaches, 1)
 
-	containerID, allocatedPorts, err := s.launchCacheContainer(ctx, cache, networkID)
+	err = s.provisionCache(ctx, cache, networkID)
+	if err == nil && cache.MetricsEnabled {
+		err = s.launchCacheExporter(ctx, cache, networkID)
+	}
 	if err != nil {
+		s.teardownCache(ctx, cache)
 		if delErr := s.repo.Delete(ctx, cache.ID, tenantID); delErr != nil {
 			s.logger.Error("failed to delete failed cache record", "id", cache.ID, "error", delErr)
+		} else {
+			recordUsage(ctx, s.tenantSvc, s.logger, tenantID, domain.QuotaCaches, -1)
 		}
 		return nil, errors.Wrap(errors.Internal, "failed to launch cache container", err)
 	}
 
-	port, err := s.parseAllocatedPort(allocatedPorts, defaultRedisPort)
-	if err != nil || port == 0 {
-		var portErr error
-		port, portErr = s.compute.GetInstancePort(ctx, containerID, defaultRedisPort)
-		if portErr != nil {
-			s.logger.Error("failed to resolve cache port", "container_id", containerID, "error", portErr)
-			if delErr := s.compute.DeleteInstance(ctx, containerID); delErr != nil {
-				s.logger.Error("failed to clean up cache container after port resolution failure", "container_id", containerID, "error", delErr)
-			}
-			cache.Status = domain.CacheStatusFailed
-			if upErr := s.repo.Update(ctx, cache); upErr != nil {
-				s.logger.Error("failed to update cache status to failed", "id", cache.ID, "error", upErr)
-			}
-			return nil, errors.Wrap(errors.Internal, "failed to resolve cache port", portErr)
-		}
-	}
-
 	cache.Status = domain.CacheStatusRunning
-	cache.ContainerID = containerID
-	cache.Port = port
-
 	if err := s.repo.Update(ctx, cache); err != nil {
 		s.logger.Warn("failed to update cache status after launch", "id", cache.ID, "error", err)
 	}
 
-	s.logCacheCreation(ctx, cache, name)
+	s.logCacheCreation(ctx, cache, req.Name)
 
 	return cache, nil
 }
@@ -181,39 +242,15 @@ func (s *CacheService) resolveNetworkID(ctx context.Context, vpcID *uuid.UUID) (
 	return vpc.NetworkID, nil
 }
 
-func (s *CacheService) launchCacheContainer(ctx context.Context, cache *domain.Cache, networkID string) (string, []string, error) {
-	dockerName := fmt.Sprintf("thecloud-cache-%s", cache.ID.String()[:8])
-	imageName := fmt.Sprintf("redis:%s-alpine", cache.Version)
-
-	cmd := []string{
-		"redis-server",
-		"--appendonly", "yes",
-		"--save", "",
-		"--requirepass", cache.Password,
-		"--maxmemory", fmt.Sprintf("%dmb", cache.MemoryMB),
-		"--maxmemory-policy", "allkeys-lru",
-		"--tcp-keepalive", "300",
-	}
-
-	containerID, allocatedPorts, err := s.compute.LaunchInstanceWithOptions(ctx, ports.CreateInstanceOptions{
-		Name:      dockerName,
-		ImageName: imageName,
-		Ports:     []string{"0:" + defaultRedisPort},
-		NetworkID: networkID,
-		Cmd:       cmd,
-	})
-	if err != nil {
-		s.logger.Error("failed to create cache container", "error", err)
-		return "", nil, err
-	}
-	return containerID, allocatedPorts, nil
-}
-
 func (s *CacheService) logCacheCreation(ctx context.Context, cache *domain.Cache, originalName string) {
 	if err := s.eventSvc.RecordEvent(ctx, "CACHE_CREATE", cache.ID.String(), "CACHE", map[string]interface{}{
-		"name":    cache.Name,
-		"version": cache.Version,
-		"memory":  cache.MemoryMB,
+		"name":        cache.Name,
+		"engine":      cache.Engine,
+		"version":     cache.Version,
+		"memory":      cache.MemoryMB,
+		"persistence": cache.Persistence,
+		"replicas":    cache.Replicas,
+		"shards":      cache.Shards,
 	}); err != nil {
 		s.logger.Warn("failed to record event", "action", "CACHE_CREATE", "cache_id", cache.ID, "error", err)
 	}
@@ -235,7 +272,14 @@ func (s *CacheService) GetCache(ctx context.Context, idOrName string) (*domain.C
 		return nil, err
 	}
 
-	return s.getCacheByIDOrName(ctx, idOrName)
+	cache, err := s.getCacheByIDOrName(ctx, idOrName)
+	if err != nil {
+		return nil, err
+	}
+	if cache.Nodes, err = s.repo.ListNodes(ctx, cache.ID); err != nil {
+		return nil, err
+	}
+	return cache, nil
 }
 
 func (s *CacheService) ListCaches(ctx context.Context) ([]*domain.Cache, error) {
@@ -262,18 +306,15 @@ func (s *CacheService) DeleteCache(ctx context.Context, idOrName string) error {
 		return err
 	}
 
-	if cache.ContainerID != "" {
-		if err := s.compute.StopInstance(ctx, cache.ContainerID); err != nil {
-			s.logger.Warn("failed to stop cache container", "container_id", cache.ContainerID, "error", err)
-		}
-		if err := s.compute.DeleteInstance(ctx, cache.ContainerID); err != nil {
-			s.logger.Warn("failed to remove cache container", "container_id", cache.ContainerID, "error", err)
-		}
+	if cache.Nodes, err = s.repo.ListNodes(ctx, cache.ID); err != nil {
+		return err
 	}
+	s.teardownCache(ctx, cache)
 
 	if err := s.repo.Delete(ctx, cache.ID, tenantID); err != nil {
 		return err
 	}
+	recordUsage(ctx, s.tenantSvc, s.logger, tenantID, domain.QuotaCaches, -1)
 
 	if err := s.eventSvc.RecordEvent(ctx, "CACHE_DELETE", cache.ID.String(), "CACHE", nil); err != nil {
 		s.logger.Warn("failed to record event", "action", "CACHE_DELETE", "cache_id", cache.ID, "error", err)
@@ -302,6 +343,17 @@ func (s *CacheService) GetConnectionString(ctx context.Context, idOrName string)
 	if err != nil {
 		return "", err
 	}
+	if cache.ClusterMode {
+		return s.clusterConnectionString(ctx, cache)
+	}
+	if cache.Engine == domain.EngineMemcached {
+		// Memcached is only reachable inside its VPC.
+		ip, err := s.compute.GetInstanceIP(ctx, cache.ContainerID)
+		if err != nil {
+			return "", errors.Wrap(errors.Internal, "failed to get cache IP", err)
+		}
+		return fmt.Sprintf("memcached://%s", net.JoinHostPort(ip, defaultMemcachedPort)), nil
+	}
 	// format: redis://:password@host:port
 	// We assume localhost for now as we don't have public IPs yet
 	return fmt.Sprintf("redis://:%s@localhost:%d", cache.Password, cache.Port), nil
@@ -337,17 +389,37 @@ func (s *CacheService) FlushCache(ctx context.Context, idOrName string) error {
 		return errors.New(errors.InvalidInput, "cache flush requires docker compute backend (COMPUTE_BACKEND=libvirt is not supported)")
 	}
 
-	// Exec FLUSHALL inside the container
-	// We need to pass the password if set.
-	cmd := []string{"redis-cli"}
-	if cache.Password != "" {
-		cmd = append(cmd, "-a", cache.Password)
+	if cache.ClusterMode {
+		if err := s.flushCacheCluster(ctx, cache); err != nil {
+			return err
+		}
+		if err := s.auditSvc.Log(ctx, cache.UserID, "cache.flush", "cache", cache.ID.String(), map[string]interface{}{}); err != nil {
+			s.logger.Warn("failed to log audit event", "action", "cache.flush", "cache_id", cache.ID, "error", err)
+		}
+		return nil
 	}
-	cmd = append(cmd, "FLUSHALL")
 
-	output, err := s.compute.Exec(ctx, cache.ContainerID, cmd)
-	if err != nil {
-		return errors.Wrap(errors.Internal, "failed to flush cache: "+output, err)
+	if cache.Engine == domain.EngineMemcached {
+		output, err := s.memcachedCommand(ctx, cache.ContainerID, "flush_all")
+		if err != nil {
+			return errors.Wrap(errors.Internal, "failed to flush cache: "+output, err)
+		}
+		if !strings.Contains(output, "OK") {
+			return errors.New(errors.Internal, "failed to flush cache: "+output)
+		}
+	} else {
+		// Exec FLUSHALL inside the container
+		// We need to pass the password if set.
+		cmd := []string{cache.Engine.CLI()}
+		if cache.Password != "" {
+			cmd = append(cmd, "-a", cache.Password)
+		}
+		cmd = append(cmd, "FLUSHALL")
+
+		output, err := s.compute.Exec(ctx, cache.ContainerID, cmd)
+		if err != nil {
+			return errors.Wrap(errors.Internal, "failed to flush cache: "+output, err)
+		}
 	}
 
 	if err := s.auditSvc.Log(ctx, cache.UserID, "cache.flush", "cache", cache.ID.String(), map[string]interface{}{}); err != nil {
@@ -427,8 +499,20 @@ func (s *CacheService) GetCacheStats(ctx context.Context, idOrName string) (*por
 		TotalKeys:        0,
 	}
 
+	if cache.Engine == domain.EngineMemcached {
+		output, err := s.memcachedCommand(ctx, cache.ContainerID, "stats")
+		if err == nil {
+			stats := parseMemcachedStats(output)
+			result.ConnectedClients, _ = strconv.Atoi(stats["curr_connections"])
+			result.TotalKeys, _ = strconv.ParseInt(stats["curr_items"], 10, 64)
+		} else {
+			s.logger.Warn("failed to get memcached internal stats", "error", err)
+		}
+		return result, nil
+	}
+
 	// Try to get Redis Internal Stats
-	cmd := []string{"redis-cli"}
+	cmd := []string{cache.Engine.CLI()}
 	if cache.Password != "" {
 		cmd = append(cmd, "-a", cache.Password)
 	}
@@ -445,6 +529,25 @@ func (s *CacheService) GetCacheStats(ctx context.Context, idOrName string) (*por
 	return result, nil
 }
 
+// memcachedCommand sends a text protocol command to the Memcached node in
+// containerID. The image ships no client, so it goes through busybox nc.
+func (s *CacheService) memcachedCommand(ctx context.Context, containerID, command string) (string, error) {
+	script := fmt.Sprintf(`printf '%s\r\nquit\r\n' | nc 127.0.0.1 %s`, command, defaultMemcachedPort)
+	return s.compute.Exec(ctx, containerID, []string{"sh", "-c", script})
+}
+
+// parseMemcachedStats maps the "STAT name value" lines of the stats command.
+func parseMemcachedStats(out string) map[string]string {
+	stats := make(map[string]string)
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 3 && fields[0] == "STAT" {
+			stats[fields[1]] = fields[2]
+		}
+	}
+	return stats
+}
+
 func parseRedisClients(info string) int {
 	// Look for connected_clients:N
 	lines := strings.Split(info, "\r\n")
@@ -0,0 +1,545 @@
+package services
+
+// Cluster-mode caches run Redis Cluster: the hash slots are split across
+// Shards primaries, each followed by Replicas replicas that the cluster
+// promotes by itself when their primary fails. Clients follow MOVED
+// redirects to the node owning a slot, so they connect to the nodes on the
+// cache network directly instead of through the endpoint proxy.
+//
+// Clusters are built and resharded with redis-cli --cluster, run inside one
+// of their nodes. Slots move between primaries with MIGRATE while the cluster
+// keeps serving, which makes scaling out and in an online operation.
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/errors"
+)
+
+const (
+	redisClusterConfigFile   = "nodes.conf"
+	redisClusterNodeTimeout  = "5000"
+	cacheClusterReadyRetries = 30
+)
+
+// redisClusterNode is a node as listed by CLUSTER NODES.
+type redisClusterNode struct {
+	ID      string
+	IP      string
+	Primary bool
+	Failed  bool
+}
+
+// provisionCacheCluster launches the primaries of every shard, assigns them
+// the hash slots and then attaches the replicas of each shard.
+func (s *CacheService) provisionCacheCluster(ctx context.Context, cache *domain.Cache, networkID string) error {
+	primaries := make([]*domain.CacheNode, 0, cache.Shards)
+	addrs := make([]string, 0, cache.Shards)
+	for shard := 0; shard < cache.Shards; shard++ {
+		node, addr, err := s.launchClusterNode(ctx, cache, domain.CacheNodePrimary, shard, networkID)
+		if err != nil {
+			return err
+		}
+		primaries = append(primaries, node)
+		addrs = append(addrs, addr)
+	}
+	cache.ContainerID = primaries[0].ContainerID
+
+	args := append([]string{"--cluster", "create"}, addrs...)
+	args = append(args, "--cluster-replicas", "0", "--cluster-yes")
+	if out, err := s.redisCLI(ctx, cache, cache.ContainerID, args...); err != nil {
+		return errors.Wrap(errors.Internal, "failed to create redis cluster: "+out, err)
+	}
+
+	for _, primary := range primaries {
+		if err := s.addShardReplicas(ctx, cache, primary, cache.ContainerID, addrs[0], networkID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// launchClusterNode starts a cluster node, waits until it answers and
+// returns its address on the cache network.
+func (s *CacheService) launchClusterNode(ctx context.Context, cache *domain.Cache, role domain.CacheNodeRole, shard int, networkID string) (*domain.CacheNode, string, error) {
+	node, _, err := s.launchCacheNode(ctx, cache, role, shard, "", networkID, false)
+	if err != nil {
+		return nil, "", err
+	}
+	cache.Nodes = append(cache.Nodes, node)
+
+	if err := s.waitForRedis(ctx, cache, node.ContainerID); err != nil {
+		return nil, "", err
+	}
+	ip, err := s.compute.GetInstanceIP(ctx, node.ContainerID)
+	if err != nil {
+		return nil, "", errors.Wrap(errors.Internal, "failed to get cache node IP", err)
+	}
+	return node, net.JoinHostPort(ip, defaultRedisPort), nil
+}
+
+// addShardReplicas launches the replicas of a shard and joins them to the
+// cluster through seed as replicas of primary.
+func (s *CacheService) addShardReplicas(ctx context.Context, cache *domain.Cache, primary *domain.CacheNode, seedContainerID, seed, networkID string) error {
+	if cache.Replicas == 0 {
+		return nil
+	}
+	primaryID, err := s.redisCLI(ctx, cache, primary.ContainerID, "CLUSTER", "MYID")
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to get cluster node ID", err)
+	}
+
+	for i := 0; i < cache.Replicas; i++ {
+		_, addr, err := s.launchClusterNode(ctx, cache, domain.CacheNodeReplica, primary.Shard, networkID)
+		if err != nil {
+			return err
+		}
+		if out, err := s.redisCLI(ctx, cache, seedContainerID, "--cluster", "add-node", addr, seed,
+			"--cluster-slave", "--cluster-master-id", strings.TrimSpace(primaryID)); err != nil {
+			return errors.Wrap(errors.Internal, "failed to add cluster replica: "+out, err)
+		}
+	}
+	return nil
+}
+
+// ModifyCache resizes the memory of a cache and, in cluster mode, adds or
+// removes shards. Memory is changed with CONFIG SET, or cache_memlimit on
+// Memcached, on every node, so neither change restarts the cache.
+func (s *CacheService) ModifyCache(ctx context.Context, idOrName string, req ports.ModifyCacheRequest) (*domain.Cache, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionCacheUpdate, idOrName); err != nil {
+		return nil, err
+	}
+	if s.compute.Type() == "libvirt" {
+		return nil, errors.New(errors.InvalidInput, "cache modification requires docker compute backend (COMPUTE_BACKEND=libvirt is not supported)")
+	}
+
+	cache, err := s.getCacheByIDOrName(ctx, idOrName)
+	if err != nil {
+		return nil, err
+	}
+	if cache.Status != domain.CacheStatusRunning {
+		return nil, errors.New(errors.InstanceNotRunning, "cache is not running")
+	}
+	if req.MemoryMB != nil && *req.MemoryMB <= 0 {
+		return nil, errors.New(errors.InvalidInput, "memory_mb must be positive")
+	}
+	if req.Shards != nil {
+		if !cache.ClusterMode {
+			return nil, errors.New(errors.InvalidInput, "shards require cluster mode")
+		}
+		if *req.Shards < domain.MinCacheShards || *req.Shards > domain.MaxCacheShards {
+			return nil, errors.New(errors.InvalidInput, fmt.Sprintf("shards must be between %d and %d", domain.MinCacheShards, domain.MaxCacheShards))
+		}
+	}
+	if cache.Nodes, err = s.repo.ListNodes(ctx, cache.ID); err != nil {
+		return nil, err
+	}
+
+	oldMemoryMB, oldShards := cache.MemoryMB, cache.Shards
+	s.setCacheStatus(ctx, cache, domain.CacheStatusModifying)
+	if err := s.applyCacheModification(ctx, cache, req); err != nil {
+		s.setCacheStatus(ctx, cache, domain.CacheStatusRunning)
+		return nil, err
+	}
+	s.setCacheStatus(ctx, cache, domain.CacheStatusRunning)
+
+	if err := s.eventSvc.RecordEvent(ctx, "CACHE_MODIFY", cache.ID.String(), "CACHE", map[string]interface{}{
+		"memory_mb": cache.MemoryMB,
+		"shards":    cache.Shards,
+	}); err != nil {
+		s.logger.Warn("failed to record event", "action", "CACHE_MODIFY", "cache_id", cache.ID, "error", err)
+	}
+	if err := s.auditSvc.Log(ctx, cache.UserID, "cache.modify", "cache", cache.ID.String(), map[string]interface{}{
+		"name":          cache.Name,
+		"old_memory_mb": oldMemoryMB,
+		"memory_mb":     cache.MemoryMB,
+		"old_shards":    oldShards,
+		"shards":        cache.Shards,
+	}); err != nil {
+		s.logger.Warn("failed to log audit event", "action", "cache.modify", "cache_id", cache.ID, "error", err)
+	}
+	return cache, nil
+}
+
+func (s *CacheService) applyCacheModification(ctx context.Context, cache *domain.Cache, req ports.ModifyCacheRequest) error {
+	// Memory goes first so shards added below start with the new size.
+	if req.MemoryMB != nil && *req.MemoryMB != cache.MemoryMB {
+		if err := s.resizeCacheMemory(ctx, cache, *req.MemoryMB); err != nil {
+			return err
+		}
+	}
+	if req.Shards == nil || *req.Shards == cache.Shards {
+		return nil
+	}
+
+	seedContainerID, seed, err := s.clusterSeed(ctx, cache)
+	if err != nil {
+		return err
+	}
+	if *req.Shards > cache.Shards {
+		err = s.scaleOutCluster(ctx, cache, *req.Shards, seedContainerID, seed)
+	} else {
+		err = s.scaleInCluster(ctx, cache, *req.Shards, seedContainerID, seed)
+	}
+	if err != nil {
+		return err
+	}
+	s.syncClusterRoles(ctx, cache, seedContainerID)
+	return s.repo.Update(ctx, cache)
+}
+
+// cacheNodeContainers returns the containers of every node of cache.
+func cacheNodeContainers(cache *domain.Cache) []string {
+	containers := make([]string, 0, len(cache.Nodes))
+	for _, n := range cache.Nodes {
+		containers = append(containers, n.ContainerID)
+	}
+	// Caches created before nodes were tracked only know their container.
+	if len(containers) == 0 {
+		containers = append(containers, cache.ContainerID)
+	}
+	return containers
+}
+
+// resizeCacheMemory applies a new memory limit to every node and grows the
+// persistence volumes to match.
+func (s *CacheService) resizeCacheMemory(ctx context.Context, cache *domain.Cache, memoryMB int) error {
+	for _, containerID := range cacheNodeContainers(cache) {
+		var out string
+		var err error
+		if cache.Engine == domain.EngineMemcached {
+			out, err = s.memcachedCommand(ctx, containerID, fmt.Sprintf("cache_memlimit %d", memoryMB))
+		} else {
+			out, err = s.redisCLI(ctx, cache, containerID, "CONFIG", "SET", "maxmemory", fmt.Sprintf("%dmb", memoryMB))
+		}
+		if err != nil {
+			return errors.Wrap(errors.Internal, "failed to resize cache memory: "+out, err)
+		}
+	}
+
+	if newSize := domain.CacheVolumeSizeGB(memoryMB); newSize > domain.CacheVolumeSizeGB(cache.MemoryMB) && s.volumeSvc != nil {
+		for _, n := range cache.Nodes {
+			if n.VolumeID == nil {
+				continue
+			}
+			if err := s.volumeSvc.ResizeVolume(ctx, n.VolumeID.String(), newSize); err != nil {
+				s.logger.Warn("failed to grow cache volume", "volume_id", n.VolumeID, "error", err)
+			}
+		}
+	}
+
+	cache.MemoryMB = memoryMB
+	cache.UpdatedAt = time.Now()
+	return s.repo.Update(ctx, cache)
+}
+
+// scaleOutCluster adds empty shards and rebalances the hash slots onto them.
+func (s *CacheService) scaleOutCluster(ctx context.Context, cache *domain.Cache, shards int, seedContainerID, seed string) error {
+	networkID, err := s.resolveNetworkID(ctx, cache.VpcID)
+	if err != nil {
+		return err
+	}
+
+	added := len(cache.Nodes)
+	for shard := cache.Shards; shard < shards; shard++ {
+		primary, addr, err := s.launchClusterNode(ctx, cache, domain.CacheNodePrimary, shard, networkID)
+		if err == nil {
+			if out, addErr := s.redisCLI(ctx, cache, seedContainerID, "--cluster", "add-node", addr, seed); addErr != nil {
+				err = errors.Wrap(errors.Internal, "failed to add cluster shard: "+out, addErr)
+			}
+		}
+		if err == nil {
+			err = s.addShardReplicas(ctx, cache, primary, seedContainerID, seed, networkID)
+		}
+		if err != nil {
+			s.removeClusterNodes(ctx, cache, cache.Nodes[added:], seedContainerID, seed)
+			cache.Nodes = cache.Nodes[:added]
+			return err
+		}
+	}
+
+	if err := s.waitForCluster(ctx, cache, seedContainerID, seed); err != nil {
+		s.removeClusterNodes(ctx, cache, cache.Nodes[added:], seedContainerID, seed)
+		cache.Nodes = cache.Nodes[:added]
+		return err
+	}
+	if out, err := s.redisCLI(ctx, cache, seedContainerID, "--cluster", "rebalance", seed, "--cluster-use-empty-masters", "--cluster-yes"); err != nil {
+		// The new shards joined the cluster and may already own slots, so
+		// they are kept and a later resize can finish the rebalance.
+		cache.Shards = shards
+		return errors.Wrap(errors.Internal, "failed to rebalance cluster slots: "+out, err)
+	}
+	cache.Shards = shards
+	return nil
+}
+
+// scaleInCluster moves the hash slots of the highest shards onto the
+// remaining ones, then removes their nodes.
+func (s *CacheService) scaleInCluster(ctx context.Context, cache *domain.Cache, shards int, seedContainerID, seed string) error {
+	live, err := s.clusterNodes(ctx, cache, seedContainerID)
+	if err != nil {
+		return err
+	}
+
+	// A weight of zero makes rebalance move every slot off a primary.
+	var primaries, replicas []*domain.CacheNode
+	known := make(map[*domain.CacheNode]*redisClusterNode)
+	args := []string{"--cluster", "rebalance", seed, "--cluster-weight"}
+	for _, n := range cache.Nodes {
+		if n.Shard < shards {
+			continue
+		}
+		ln := s.liveClusterNode(ctx, live, n)
+		known[n] = ln
+		if ln != nil && ln.Primary {
+			primaries = append(primaries, n)
+			args = append(args, ln.ID+"=0")
+		} else {
+			replicas = append(replicas, n)
+		}
+	}
+	if len(primaries) > 0 {
+		args = append(args, "--cluster-yes")
+		if out, err := s.redisCLI(ctx, cache, seedContainerID, args...); err != nil {
+			return errors.Wrap(errors.Internal, "failed to move slots off removed shards: "+out, err)
+		}
+	}
+
+	// Replicas go first, or del-node would move them under the primary of
+	// another shard.
+	for _, n := range append(replicas, primaries...) {
+		s.removeClusterNode(ctx, cache, n, known[n], seedContainerID, seed)
+	}
+
+	kept := cache.Nodes[:0]
+	for _, n := range cache.Nodes {
+		if n.Shard < shards {
+			kept = append(kept, n)
+		}
+	}
+	cache.Nodes = kept
+	cache.Shards = shards
+	return nil
+}
+
+// removeClusterNodes rolls back nodes that were added to the cluster.
+func (s *CacheService) removeClusterNodes(ctx context.Context, cache *domain.Cache, nodes []*domain.CacheNode, seedContainerID, seed string) {
+	for i := len(nodes) - 1; i >= 0; i-- {
+		var ln *redisClusterNode
+		if id, err := s.redisCLI(ctx, cache, nodes[i].ContainerID, "CLUSTER", "MYID"); err == nil {
+			ln = &redisClusterNode{ID: strings.TrimSpace(id)}
+		}
+		s.removeClusterNode(ctx, cache, nodes[i], ln, seedContainerID, seed)
+	}
+}
+
+// removeClusterNode makes the cluster forget node, when it knows it, and
+// deletes its container, volume and record.
+func (s *CacheService) removeClusterNode(ctx context.Context, cache *domain.Cache, node *domain.CacheNode, ln *redisClusterNode, seedContainerID, seed string) {
+	if ln != nil && ln.ID != "" {
+		if out, err := s.redisCLI(ctx, cache, seedContainerID, "--cluster", "del-node", seed, ln.ID); err != nil {
+			s.logger.Warn("failed to remove node from cache cluster", "cache_id", cache.ID, "node_id", node.ID, "output", out, "error", err)
+		}
+	}
+	if node.ContainerID != "" {
+		s.removeCacheContainer(ctx, node.ContainerID)
+	}
+	s.deleteNodeVolume(ctx, node)
+	if err := s.repo.DeleteNode(ctx, node.ID); err != nil {
+		s.logger.Warn("failed to delete cache node record", "node_id", node.ID, "error", err)
+	}
+}
+
+// syncClusterRoles records the roles the cluster reports, which change when
+// it fails a shard over by itself.
+func (s *CacheService) syncClusterRoles(ctx context.Context, cache *domain.Cache, seedContainerID string) {
+	live, err := s.clusterNodes(ctx, cache, seedContainerID)
+	if err != nil {
+		s.logger.Warn("failed to read cache cluster topology", "cache_id", cache.ID, "error", err)
+		return
+	}
+	for _, n := range cache.Nodes {
+		// A failed primary keeps its role until it rejoins as a replica.
+		ln := s.liveClusterNode(ctx, live, n)
+		if ln == nil || ln.Failed {
+			continue
+		}
+		role := domain.CacheNodeReplica
+		if ln.Primary {
+			role = domain.CacheNodePrimary
+		}
+		if n.Role != role {
+			s.setNodeRole(ctx, n, role)
+		}
+		if role == domain.CacheNodePrimary && n.Shard == 0 {
+			cache.ContainerID = n.ContainerID
+		}
+	}
+}
+
+// liveClusterNode finds node in the CLUSTER NODES listing by its address.
+func (s *CacheService) liveClusterNode(ctx context.Context, live map[string]*redisClusterNode, node *domain.CacheNode) *redisClusterNode {
+	ip, err := s.compute.GetInstanceIP(ctx, node.ContainerID)
+	if err != nil {
+		return nil
+	}
+	return live[ip]
+}
+
+// clusterSeed picks a reachable node to run cluster commands from and
+// returns its container and cluster address.
+func (s *CacheService) clusterSeed(ctx context.Context, cache *domain.Cache) (string, string, error) {
+	for _, n := range cache.Nodes {
+		if !s.redisHealthy(ctx, cache, n.ContainerID) {
+			continue
+		}
+		ip, err := s.compute.GetInstanceIP(ctx, n.ContainerID)
+		if err != nil {
+			continue
+		}
+		return n.ContainerID, net.JoinHostPort(ip, defaultRedisPort), nil
+	}
+	return "", "", errors.New(errors.Internal, "no reachable cache cluster node")
+}
+
+// clusterNodes returns the cluster topology as seen from containerID, keyed
+// by node IP.
+func (s *CacheService) clusterNodes(ctx context.Context, cache *domain.Cache, containerID string) (map[string]*redisClusterNode, error) {
+	out, err := s.redisCLI(ctx, cache, containerID, "CLUSTER", "NODES")
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list cluster nodes", err)
+	}
+	nodes := make(map[string]*redisClusterNode)
+	for _, n := range parseClusterNodes(out) {
+		nodes[n.IP] = n
+	}
+	return nodes, nil
+}
+
+// parseClusterNodes parses CLUSTER NODES output, one node per line:
+// "<id> <ip:port@cport[,hostname]> <flags> <primary> ...".
+func parseClusterNodes(out string) []*redisClusterNode {
+	var nodes []*redisClusterNode
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		addr, _, _ := strings.Cut(fields[1], "@")
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			continue
+		}
+		flags := strings.Split(fields[2], ",")
+		n := &redisClusterNode{ID: fields[0], IP: host}
+		for _, f := range flags {
+			switch f {
+			case "master":
+				n.Primary = true
+			case "fail":
+				n.Failed = true
+			}
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes
+}
+
+// waitForRedis polls a freshly started node until it answers PING.
+func (s *CacheService) waitForRedis(ctx context.Context, cache *domain.Cache, containerID string) error {
+	for i := 0; i < cacheClusterReadyRetries; i++ {
+		if s.redisHealthy(ctx, cache, containerID) {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+	return errors.New(errors.Internal, "cache node did not become ready")
+}
+
+// waitForCluster polls until every node agrees on the cluster configuration,
+// which redis-cli requires before moving slots.
+func (s *CacheService) waitForCluster(ctx context.Context, cache *domain.Cache, containerID, seed string) error {
+	var out string
+	var err error
+	for i := 0; i < cacheClusterReadyRetries; i++ {
+		out, err = s.redisCLI(ctx, cache, containerID, "--cluster", "check", seed)
+		if err == nil && !strings.Contains(out, "[ERR]") {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+	return errors.New(errors.Internal, "cache cluster did not converge: "+out)
+}
+
+// clusterConnectionString lists the primaries of a cluster-mode cache in the
+// cluster URL format of go-redis: the first address in the host and the
+// others as addr parameters.
+func (s *CacheService) clusterConnectionString(ctx context.Context, cache *domain.Cache) (string, error) {
+	nodes, err := s.repo.ListNodes(ctx, cache.ID)
+	if err != nil {
+		return "", err
+	}
+	var addrs []string
+	for _, n := range nodes {
+		if n.Role != domain.CacheNodePrimary {
+			continue
+		}
+		ip, err := s.compute.GetInstanceIP(ctx, n.ContainerID)
+		if err != nil {
+			return "", errors.Wrap(errors.Internal, "failed to get cache node IP", err)
+		}
+		addrs = append(addrs, net.JoinHostPort(ip, defaultRedisPort))
+	}
+	if len(addrs) == 0 {
+		return "", errors.New(errors.Internal, "cache cluster has no primaries")
+	}
+
+	conn := fmt.Sprintf("redis://:%s@%s", cache.Password, addrs[0])
+	if len(addrs) > 1 {
+		conn += "?addr=" + strings.Join(addrs[1:], "&addr=")
+	}
+	return conn, nil
+}
+
+// flushCacheCluster runs FLUSHALL on every primary of a cluster-mode cache.
+func (s *CacheService) flushCacheCluster(ctx context.Context, cache *domain.Cache) error {
+	nodes, err := s.repo.ListNodes(ctx, cache.ID)
+	if err != nil {
+		return err
+	}
+	cache.Nodes = nodes
+	containerID, seed, err := s.clusterSeed(ctx, cache)
+	if err != nil {
+		return err
+	}
+	if out, err := s.redisCLI(ctx, cache, containerID, "--cluster", "call", seed, "FLUSHALL", "--cluster-only-masters"); err != nil {
+		return errors.Wrap(errors.Internal, "failed to flush cache: "+out, err)
+	}
+	return nil
+}
+
+func (s *CacheService) setCacheStatus(ctx context.Context, cache *domain.Cache, status domain.CacheStatus) {
+	cache.Status = status
+	cache.UpdatedAt = time.Now()
+	if err := s.repo.Update(ctx, cache); err != nil {
+		s.logger.Warn("failed to update cache status", "cache_id", cache.ID, "status", status, "error", err)
+	}
+}
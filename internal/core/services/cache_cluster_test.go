@@ -0,0 +1,283 @@
+package services_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// redisCmdContaining matches a redis-cli invocation containing args.
+func redisCmdContaining(args string) interface{} {
+	return mock.MatchedBy(func(cmd []string) bool {
+		return cmd[0] == "redis-cli" && strings.Contains(strings.Join(cmd, " "), args)
+	})
+}
+
+func isClusterNode() interface{} {
+	return mock.MatchedBy(func(opts ports.CreateInstanceOptions) bool {
+		return cmdContains(opts, "--cluster-enabled yes")
+	})
+}
+
+func newClusterCache(shards int, nodes ...*domain.CacheNode) *domain.Cache {
+	return &domain.Cache{
+		ID: uuid.New(), UserID: uuid.New(), TenantID: uuid.New(), Name: "sessions",
+		Status: domain.CacheStatusRunning, Password: "pw", MemoryMB: 256,
+		Persistence: domain.CachePersistenceNone, ClusterMode: true, Shards: shards,
+		ContainerID: "cid-0", Nodes: nodes,
+	}
+}
+
+func clusterNode(shard int, role domain.CacheNodeRole, containerID string) *domain.CacheNode {
+	return &domain.CacheNode{ID: uuid.New(), Shard: shard, Role: role, Status: domain.CacheStatusRunning, ContainerID: containerID}
+}
+
+func TestCacheServiceCreateClusterCache(t *testing.T) {
+	m, svc, ctx := setupCacheReplicationTest()
+	m.repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	m.repo.On("CreateNode", mock.Anything, mock.Anything).Return(nil)
+	m.repo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+	for i, cid := range []string{"cid-0", "cid-1", "cid-2", "cid-r0", "cid-r1", "cid-r2"} {
+		m.compute.On("LaunchInstanceWithOptions", mock.Anything, isClusterNode()).Return(cid, nil, nil).Once()
+		m.compute.On("GetInstanceIP", mock.Anything, cid).Return(fmt.Sprintf("10.0.0.%d", 10+i), nil)
+	}
+	m.compute.On("Exec", mock.Anything, mock.Anything, redisCmdContaining("PING")).Return("PONG\n", nil)
+	m.compute.On("Exec", mock.Anything, "cid-0", redisCmdContaining("--cluster create 10.0.0.10:6379 10.0.0.11:6379 10.0.0.12:6379 --cluster-replicas 0")).Return("[OK] All 16384 slots covered.\n", nil).Once()
+	for shard := 0; shard < 3; shard++ {
+		m.compute.On("Exec", mock.Anything, fmt.Sprintf("cid-%d", shard), redisCmdContaining("CLUSTER MYID")).Return(fmt.Sprintf("id%d\n", shard), nil).Once()
+		m.compute.On("Exec", mock.Anything, "cid-0", redisCmdContaining(fmt.Sprintf("--cluster add-node 10.0.0.%d:6379 10.0.0.10:6379 --cluster-slave --cluster-master-id id%d", 13+shard, shard))).Return("[OK] New node added correctly.\n", nil).Once()
+	}
+
+	cache, err := svc.CreateCache(ctx, ports.CreateCacheRequest{
+		Name: "sessions", Version: "7.2", MemoryMB: 256, ClusterMode: true, Replicas: 1,
+	})
+	require.NoError(t, err)
+	assert.True(t, cache.ClusterMode)
+	assert.Equal(t, domain.MinCacheShards, cache.Shards)
+	assert.True(t, cache.FailoverEnabled)
+	assert.Equal(t, "cid-0", cache.ContainerID)
+	assert.Empty(t, cache.ProxyContainerID)
+	require.Len(t, cache.Nodes, 6)
+	for i, n := range cache.Nodes {
+		assert.Equal(t, i%3, n.Shard)
+		assert.Equal(t, i < 3, n.Role == domain.CacheNodePrimary)
+	}
+
+	for _, call := range m.compute.Calls {
+		if call.Method != "LaunchInstanceWithOptions" {
+			continue
+		}
+		opts := call.Arguments.Get(1).(ports.CreateInstanceOptions)
+		assert.Empty(t, opts.Ports)
+		assert.False(t, cmdContains(opts, "--replicaof"))
+	}
+	m.compute.AssertExpectations(t)
+}
+
+func TestCacheServiceCreateClusterCacheValidation(t *testing.T) {
+	_, svc, ctx := setupCacheReplicationTest()
+	cases := []ports.CreateCacheRequest{
+		{Name: "c", Version: "7.2", MemoryMB: 128, Shards: 3},
+		{Name: "c", Version: "7.2", MemoryMB: 128, ClusterMode: true, Shards: domain.MinCacheShards - 1},
+		{Name: "c", Version: "7.2", MemoryMB: 128, ClusterMode: true, Shards: domain.MaxCacheShards + 1},
+	}
+	for _, req := range cases {
+		_, err := svc.CreateCache(ctx, req)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.InvalidInput), err.Error())
+	}
+}
+
+func TestCacheServiceModifyCacheScaleOut(t *testing.T) {
+	m, svc, ctx := setupCacheReplicationTest()
+	cache := newClusterCache(3)
+	nodes := []*domain.CacheNode{
+		clusterNode(0, domain.CacheNodePrimary, "cid-0"),
+		clusterNode(1, domain.CacheNodePrimary, "cid-1"),
+		clusterNode(2, domain.CacheNodePrimary, "cid-2"),
+	}
+	m.repo.On("GetByID", mock.Anything, cache.ID, mock.Anything).Return(cache, nil)
+	m.repo.On("ListNodes", mock.Anything, cache.ID).Return(nodes, nil)
+	m.repo.On("Update", mock.Anything, cache).Return(nil)
+	m.repo.On("CreateNode", mock.Anything, mock.MatchedBy(func(n *domain.CacheNode) bool {
+		return n.Shard == 3 && n.Role == domain.CacheNodePrimary
+	})).Return(nil).Once()
+
+	for i := 0; i < 3; i++ {
+		m.compute.On("GetInstanceIP", mock.Anything, fmt.Sprintf("cid-%d", i)).Return(fmt.Sprintf("10.0.0.%d", 10+i), nil)
+	}
+	m.compute.On("LaunchInstanceWithOptions", mock.Anything, isClusterNode()).Return("cid-3", nil, nil).Once()
+	m.compute.On("GetInstanceIP", mock.Anything, "cid-3").Return("10.0.0.13", nil)
+	m.compute.On("Exec", mock.Anything, mock.Anything, redisCmdContaining("PING")).Return("PONG\n", nil)
+	m.compute.On("Exec", mock.Anything, "cid-0", redisCmdContaining("--cluster add-node 10.0.0.13:6379 10.0.0.10:6379")).Return("[OK] New node added correctly.\n", nil).Once()
+	m.compute.On("Exec", mock.Anything, "cid-0", redisCmdContaining("--cluster check 10.0.0.10:6379")).Return("[OK] All nodes agree about slots configuration.\n", nil).Once()
+	m.compute.On("Exec", mock.Anything, "cid-0", redisCmdContaining("--cluster rebalance 10.0.0.10:6379 --cluster-use-empty-masters")).Return("", nil).Once()
+	m.compute.On("Exec", mock.Anything, "cid-0", redisCmdContaining("CLUSTER NODES")).Return(
+		"id0 10.0.0.10:6379@16379 myself,master - 0 0 1 connected 0-4095\n"+
+			"id1 10.0.0.11:6379@16379 master - 0 0 2 connected 4096-8191\n"+
+			"id2 10.0.0.12:6379@16379 master - 0 0 3 connected 8192-12287\n"+
+			"id3 10.0.0.13:6379@16379 master - 0 0 4 connected 12288-16383\n", nil)
+
+	shards := 4
+	updated, err := svc.ModifyCache(ctx, cache.ID.String(), ports.ModifyCacheRequest{Shards: &shards})
+	require.NoError(t, err)
+	assert.Equal(t, 4, updated.Shards)
+	assert.Equal(t, domain.CacheStatusRunning, updated.Status)
+	require.Len(t, updated.Nodes, 4)
+	m.compute.AssertExpectations(t)
+	m.repo.AssertExpectations(t)
+}
+
+func TestCacheServiceModifyCacheScaleIn(t *testing.T) {
+	m, svc, ctx := setupCacheReplicationTest()
+	cache := newClusterCache(4)
+	removedPrimary := clusterNode(3, domain.CacheNodePrimary, "cid-3")
+	removedReplica := clusterNode(3, domain.CacheNodeReplica, "cid-3r")
+	nodes := []*domain.CacheNode{
+		clusterNode(0, domain.CacheNodePrimary, "cid-0"),
+		clusterNode(1, domain.CacheNodePrimary, "cid-1"),
+		clusterNode(2, domain.CacheNodePrimary, "cid-2"),
+		removedPrimary,
+		removedReplica,
+	}
+	m.repo.On("GetByID", mock.Anything, cache.ID, mock.Anything).Return(cache, nil)
+	m.repo.On("ListNodes", mock.Anything, cache.ID).Return(nodes, nil)
+	m.repo.On("Update", mock.Anything, cache).Return(nil)
+	m.repo.On("DeleteNode", mock.Anything, removedReplica.ID).Return(nil).Once()
+	m.repo.On("DeleteNode", mock.Anything, removedPrimary.ID).Return(nil).Once()
+
+	ips := map[string]string{"cid-0": "10.0.0.10", "cid-1": "10.0.0.11", "cid-2": "10.0.0.12", "cid-3": "10.0.0.13", "cid-3r": "10.0.0.23"}
+	for cid, ip := range ips {
+		m.compute.On("GetInstanceIP", mock.Anything, cid).Return(ip, nil)
+	}
+	m.compute.On("Exec", mock.Anything, "cid-0", redisCmdContaining("PING")).Return("PONG\n", nil)
+	m.compute.On("Exec", mock.Anything, "cid-0", redisCmdContaining("CLUSTER NODES")).Return(
+		"id0 10.0.0.10:6379@16379 myself,master - 0 0 1 connected 0-4095\n"+
+			"id1 10.0.0.11:6379@16379 master - 0 0 2 connected 4096-8191\n"+
+			"id2 10.0.0.12:6379@16379 master - 0 0 3 connected 8192-12287\n"+
+			"id3 10.0.0.13:6379@16379 master - 0 0 4 connected 12288-16383\n"+
+			"id3r 10.0.0.23:6379@16379 slave id3 0 0 4 connected\n", nil)
+	m.compute.On("Exec", mock.Anything, "cid-0", redisCmdContaining("--cluster rebalance 10.0.0.10:6379 --cluster-weight id3=0 --cluster-yes")).Return("", nil).Once()
+	m.compute.On("Exec", mock.Anything, "cid-0", redisCmdContaining("--cluster del-node 10.0.0.10:6379 id3r")).Return("", nil).Once()
+	m.compute.On("Exec", mock.Anything, "cid-0", redisCmdContaining("--cluster del-node 10.0.0.10:6379 id3")).Return("", nil).Once()
+	for _, cid := range []string{"cid-3", "cid-3r"} {
+		m.compute.On("StopInstance", mock.Anything, cid).Return(nil).Once()
+		m.compute.On("DeleteInstance", mock.Anything, cid).Return(nil).Once()
+	}
+
+	shards := 3
+	updated, err := svc.ModifyCache(ctx, cache.ID.String(), ports.ModifyCacheRequest{Shards: &shards})
+	require.NoError(t, err)
+	assert.Equal(t, 3, updated.Shards)
+	require.Len(t, updated.Nodes, 3)
+
+	// The replica leaves the cluster before its primary.
+	var deleted []string
+	for _, call := range m.compute.Calls {
+		if call.Method == "Exec" {
+			if cmd := strings.Join(call.Arguments.Get(2).([]string), " "); strings.Contains(cmd, "del-node") {
+				deleted = append(deleted, cmd[strings.LastIndex(cmd, " ")+1:])
+			}
+		}
+	}
+	assert.Equal(t, []string{"id3r", "id3"}, deleted)
+	m.compute.AssertExpectations(t)
+	m.repo.AssertExpectations(t)
+}
+
+func TestCacheServiceModifyCacheMemory(t *testing.T) {
+	m, svc, ctx := setupCacheReplicationTest()
+	volID := uuid.New()
+	cache := &domain.Cache{
+		ID: uuid.New(), Status: domain.CacheStatusRunning, Password: "pw", MemoryMB: 256,
+		Persistence: domain.CachePersistenceAOF, Replicas: 1,
+	}
+	nodes := []*domain.CacheNode{
+		{ID: uuid.New(), Role: domain.CacheNodePrimary, ContainerID: "cid-p", VolumeID: &volID},
+		{ID: uuid.New(), Role: domain.CacheNodeReplica, ContainerID: "cid-r"},
+	}
+	m.repo.On("GetByID", mock.Anything, cache.ID, mock.Anything).Return(cache, nil)
+	m.repo.On("ListNodes", mock.Anything, cache.ID).Return(nodes, nil)
+	m.repo.On("Update", mock.Anything, cache).Return(nil)
+	m.compute.On("Exec", mock.Anything, "cid-p", redisCmdContaining("CONFIG SET maxmemory 1024mb")).Return("OK\n", nil).Once()
+	m.compute.On("Exec", mock.Anything, "cid-r", redisCmdContaining("CONFIG SET maxmemory 1024mb")).Return("OK\n", nil).Once()
+	m.volumes.On("ResizeVolume", mock.Anything, volID.String(), 2).Return(nil).Once()
+
+	memory := 1024
+	updated, err := svc.ModifyCache(ctx, cache.ID.String(), ports.ModifyCacheRequest{MemoryMB: &memory})
+	require.NoError(t, err)
+	assert.Equal(t, 1024, updated.MemoryMB)
+	assert.Equal(t, domain.CacheStatusRunning, updated.Status)
+	m.compute.AssertExpectations(t)
+	m.volumes.AssertExpectations(t)
+}
+
+func TestCacheServiceModifyCacheValidation(t *testing.T) {
+	m, svc, ctx := setupCacheReplicationTest()
+	cache := &domain.Cache{ID: uuid.New(), Status: domain.CacheStatusRunning, MemoryMB: 256}
+	m.repo.On("GetByID", mock.Anything, cache.ID, mock.Anything).Return(cache, nil)
+
+	zero, shards, tooMany := 0, 4, domain.MaxCacheShards+1
+	for _, req := range []ports.ModifyCacheRequest{{MemoryMB: &zero}, {Shards: &shards}} {
+		_, err := svc.ModifyCache(ctx, cache.ID.String(), req)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.InvalidInput), err.Error())
+	}
+
+	cluster := newClusterCache(3)
+	m.repo.On("GetByID", mock.Anything, cluster.ID, mock.Anything).Return(cluster, nil)
+	_, err := svc.ModifyCache(ctx, cluster.ID.String(), ports.ModifyCacheRequest{Shards: &tooMany})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.InvalidInput))
+}
+
+func TestCacheServiceClusterConnectionString(t *testing.T) {
+	m, svc, ctx := setupCacheReplicationTest()
+	cache := newClusterCache(3)
+	m.repo.On("GetByID", mock.Anything, cache.ID, mock.Anything).Return(cache, nil)
+	m.repo.On("ListNodes", mock.Anything, cache.ID).Return([]*domain.CacheNode{
+		clusterNode(0, domain.CacheNodePrimary, "cid-0"),
+		clusterNode(0, domain.CacheNodeReplica, "cid-0r"),
+		clusterNode(1, domain.CacheNodePrimary, "cid-1"),
+		clusterNode(2, domain.CacheNodePrimary, "cid-2"),
+	}, nil)
+	for i := 0; i < 3; i++ {
+		m.compute.On("GetInstanceIP", mock.Anything, fmt.Sprintf("cid-%d", i)).Return(fmt.Sprintf("10.0.0.%d", 10+i), nil)
+	}
+
+	conn, err := svc.GetConnectionString(ctx, cache.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, "redis://:pw@10.0.0.10:6379?addr=10.0.0.11:6379&addr=10.0.0.12:6379", conn)
+}
+
+func TestCacheServiceFlushClusterCache(t *testing.T) {
+	m, svc, ctx := setupCacheReplicationTest()
+	cache := newClusterCache(3)
+	m.repo.On("GetByID", mock.Anything, cache.ID, mock.Anything).Return(cache, nil)
+	m.repo.On("ListNodes", mock.Anything, cache.ID).Return([]*domain.CacheNode{clusterNode(0, domain.CacheNodePrimary, "cid-0")}, nil)
+	m.compute.On("GetInstanceIP", mock.Anything, "cid-0").Return("10.0.0.10", nil)
+	m.compute.On("Exec", mock.Anything, "cid-0", redisCmdContaining("PING")).Return("PONG\n", nil)
+	m.compute.On("Exec", mock.Anything, "cid-0", redisCmdContaining("--cluster call 10.0.0.10:6379 FLUSHALL --cluster-only-masters")).Return("", nil).Once()
+
+	require.NoError(t, svc.FlushCache(ctx, cache.ID.String()))
+	m.compute.AssertExpectations(t)
+}
+
+func TestCacheServiceFailoverClusterCache(t *testing.T) {
+	m, svc, ctx := setupCacheReplicationTest()
+	cache := newClusterCache(3)
+	m.repo.On("GetByID", mock.Anything, cache.ID, mock.Anything).Return(cache, nil)
+
+	err := svc.FailoverCache(ctx, cache.ID.String())
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.InvalidInput))
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/poyrazk/thecloud/pkg/util"
+)
+
+// RotateCacheCredentials replaces the password of a Redis-compatible cache.
+// The new password is added to the default user of every node next to the
+// old one, replicas switch to it, and the endpoint proxy and exporter are
+// relaunched with it before the old password is removed and the change is
+// saved to each node's config file. Clients therefore keep working until they
+// reconnect, replication is never interrupted, and restarted nodes keep the
+// new password.
+func (s *CacheService) RotateCacheCredentials(ctx context.Context, idOrName string) error {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionCacheUpdate, idOrName); err != nil {
+		return err
+	}
+	if s.compute.Type() == "libvirt" {
+		return errors.New(errors.InvalidInput, "credential rotation requires docker compute backend (COMPUTE_BACKEND=libvirt is not supported)")
+	}
+
+	cache, err := s.getCacheByIDOrName(ctx, idOrName)
+	if err != nil {
+		return err
+	}
+	if !cache.Engine.RedisCompatible() {
+		return errors.New(errors.InvalidInput, string(cache.Engine)+" caches have no credentials to rotate")
+	}
+	if cache.Status != domain.CacheStatusRunning {
+		return errors.New(errors.InstanceNotRunning, "cache is not running")
+	}
+	if cache.Nodes, err = s.repo.ListNodes(ctx, cache.ID); err != nil {
+		return err
+	}
+
+	newPassword, err := util.GenerateRandomPassword(16)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to generate password", err)
+	}
+	oldPassword := cache.Password
+	containers := cacheNodeContainers(cache)
+
+	// Both passwords are accepted until the proxy and exporter use the new one.
+	if err := s.configureCacheNodes(ctx, cache, containers,
+		[]string{"ACL", "SETUSER", "default", ">" + newPassword},
+		[]string{"CONFIG", "SET", "masterauth", newPassword},
+	); err != nil {
+		if undoErr := s.configureCacheNodes(ctx, cache, containers,
+			[]string{"CONFIG", "SET", "masterauth", oldPassword},
+			[]string{"ACL", "SETUSER", "default", "<" + newPassword},
+		); undoErr != nil {
+			s.logger.Warn("failed to undo partial cache password change", "cache_id", cache.ID, "error", undoErr)
+		}
+		return errors.Wrap(errors.Internal, "failed to set new cache password", err)
+	}
+
+	cache.Password = newPassword
+	cache.UpdatedAt = time.Now()
+	sidecarErr := s.relaunchCacheSidecars(ctx, cache)
+	if err := s.repo.Update(ctx, cache); err != nil {
+		// The old password still works, so the cache stays reachable with
+		// the stored one until the rotation is retried.
+		s.logger.Error("cache password rotated on nodes but failed to persist", "cache_id", cache.ID, "error", err)
+		return err
+	}
+	// Keep the old password until a retry brings the sidecars back.
+	if sidecarErr != nil {
+		return sidecarErr
+	}
+
+	// Setting requirepass leaves only the new password on the default user,
+	// and CONFIG REWRITE saves both settings to the node's config file so a
+	// restarted node does not come back with the old password.
+	if err := s.configureCacheNodes(ctx, cache, containers,
+		[]string{"CONFIG", "SET", "requirepass", newPassword},
+	); err != nil {
+		return errors.Wrap(errors.Internal, "failed to remove old cache password", err)
+	}
+	if err := s.configureCacheNodes(ctx, cache, containers, []string{"CONFIG", "REWRITE"}); err != nil {
+		return errors.Wrap(errors.Internal, "failed to save cache password on nodes", err)
+	}
+
+	if err := s.eventSvc.RecordEvent(ctx, "CACHE_CREDENTIALS_ROTATE", cache.ID.String(), "CACHE", nil); err != nil {
+		s.logger.Warn("failed to record event", "action", "CACHE_CREDENTIALS_ROTATE", "cache_id", cache.ID, "error", err)
+	}
+	if err := s.auditSvc.Log(ctx, cache.UserID, "cache.rotate_credentials", "cache", cache.ID.String(), map[string]interface{}{
+		"name": cache.Name,
+	}); err != nil {
+		s.logger.Warn("failed to log audit event", "action", "cache.rotate_credentials", "cache_id", cache.ID, "error", err)
+	}
+	return nil
+}
+
+// configureCacheNodes runs each command on every node in turn. It runs all
+// of them and returns the first error, so it can also undo a partial change.
+func (s *CacheService) configureCacheNodes(ctx context.Context, cache *domain.Cache, containers []string, commands ...[]string) error {
+	var firstErr error
+	for _, command := range commands {
+		for _, containerID := range containers {
+			out, err := s.redisCLI(ctx, cache, containerID, command...)
+			if err != nil && firstErr == nil {
+				firstErr = errors.Wrap(errors.Internal, "failed to configure cache node: "+out, err)
+			}
+		}
+	}
+	return firstErr
+}
+
+// relaunchCacheSidecars replaces the endpoint proxy and exporter of cache,
+// which carry its password, on their current host ports.
+func (s *CacheService) relaunchCacheSidecars(ctx context.Context, cache *domain.Cache) error {
+	if cache.ProxyContainerID == "" && !cache.MetricsEnabled {
+		return nil
+	}
+	networkID, err := s.resolveNetworkID(ctx, cache.VpcID)
+	if err != nil {
+		return err
+	}
+	if cache.ProxyContainerID != "" {
+		s.removeCacheContainer(ctx, cache.ProxyContainerID)
+		if err := s.launchCacheProxy(ctx, cache, networkID); err != nil {
+			return err
+		}
+	}
+	return s.relaunchCacheExporter(ctx, cache, networkID)
+}
@@ -0,0 +1,126 @@
+package services_test
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheServiceRotateCacheCredentials(t *testing.T) {
+	m, svc, ctx := setupCacheReplicationTest()
+	cache, primary, r1, r2 := replicatedCache(ctx)
+	cache.Engine = domain.EngineRedis
+	cache.MetricsEnabled, cache.MetricsPort, cache.ExporterContainerID = true, 30121, "cid-exp"
+	m.repo.On("GetByID", mock.Anything, cache.ID, cache.TenantID).Return(cache, nil)
+	m.repo.On("ListNodes", mock.Anything, cache.ID).Return([]*domain.CacheNode{primary, r1, r2}, nil)
+
+	var execs [][]string
+	var containers []string
+	m.compute.On("Exec", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		containers = append(containers, args.String(1))
+		execs = append(execs, args.Get(2).([]string))
+	}).Return("OK", nil)
+	for _, id := range []string{"cid-p", "cid-r1", "cid-r2"} {
+		m.compute.On("GetInstanceIP", mock.Anything, id).Return("10.0.0."+id[len(id)-1:], nil)
+	}
+	m.compute.On("GetInstanceIP", mock.Anything, "cid-proxy-2").Return("10.0.0.9", nil)
+	for _, id := range []string{"cid-proxy", "cid-exp"} {
+		m.compute.On("StopInstance", mock.Anything, id).Return(nil).Once()
+		m.compute.On("DeleteInstance", mock.Anything, id).Return(nil).Once()
+	}
+	var proxy, exporter ports.CreateInstanceOptions
+	m.compute.On("LaunchInstanceWithOptions", mock.Anything, mock.MatchedBy(func(opts ports.CreateInstanceOptions) bool {
+		return strings.HasPrefix(opts.ImageName, "haproxy:")
+	})).Run(func(args mock.Arguments) {
+		proxy = args.Get(1).(ports.CreateInstanceOptions)
+	}).Return("cid-proxy-2", []string{"30001:6379", "30002:6380"}, nil).Once()
+	m.compute.On("LaunchInstanceWithOptions", mock.Anything, mock.MatchedBy(func(opts ports.CreateInstanceOptions) bool {
+		return opts.ImageName == "oliver006/redis_exporter"
+	})).Run(func(args mock.Arguments) {
+		exporter = args.Get(1).(ports.CreateInstanceOptions)
+	}).Return("cid-exp-2", []string{"30121:9121"}, nil).Once()
+	m.repo.On("Update", mock.Anything, mock.MatchedBy(func(c *domain.Cache) bool {
+		return c.Password != "pw" && c.ProxyContainerID == "cid-proxy-2" && c.ExporterContainerID == "cid-exp-2"
+	})).Return(nil).Once()
+
+	require.NoError(t, svc.RotateCacheCredentials(ctx, cache.ID.String()))
+	m.compute.AssertExpectations(t)
+	m.repo.AssertExpectations(t)
+
+	newPassword := cache.Password
+	require.NotEqual(t, "pw", newPassword)
+	// The new password is added and used for replication on every node with
+	// the old one, which is only removed once the proxy and exporter switched.
+	// The result is then saved to each node's config file for restarts.
+	require.Len(t, execs, 12)
+	for i, cmd := range execs[:6] {
+		assert.Equal(t, "pw", cmd[3])
+		want := []string{"ACL", "SETUSER", "default", ">" + newPassword}
+		if i >= 3 {
+			want = []string{"CONFIG", "SET", "masterauth", newPassword}
+		}
+		assert.Equal(t, want, cmd[4:])
+	}
+	for i, cmd := range execs[6:] {
+		assert.Equal(t, newPassword, cmd[3])
+		want := []string{"CONFIG", "SET", "requirepass", newPassword}
+		if i >= 3 {
+			want = []string{"CONFIG", "REWRITE"}
+		}
+		assert.Equal(t, want, cmd[4:])
+	}
+	assert.Equal(t, []string{"cid-p", "cid-r1", "cid-r2"}, containers[9:])
+	assert.Equal(t, []string{"cid-p", "cid-r1", "cid-r2"}, containers[:3])
+
+	assert.Equal(t, []string{"30001:6379", "30002:6380"}, proxy.Ports)
+	assert.Contains(t, strings.Join(proxy.Env, ""), hex.EncodeToString([]byte("AUTH "+newPassword+"\r\n")))
+	assert.Equal(t, []string{"30121:9121"}, exporter.Ports)
+	assert.Contains(t, exporter.Env, "REDIS_ADDR=redis://10.0.0.9:6379")
+	assert.Contains(t, exporter.Env, "REDIS_PASSWORD="+newPassword)
+	m.events.AssertCalled(t, "RecordEvent", mock.Anything, "CACHE_CREDENTIALS_ROTATE", cache.ID.String(), "CACHE", mock.Anything)
+}
+
+func TestCacheServiceRotateCacheCredentialsUndoesPartialChange(t *testing.T) {
+	m, svc, ctx := setupCacheReplicationTest()
+	cache, primary, r1, r2 := replicatedCache(ctx)
+	cache.Engine = domain.EngineRedis
+	m.repo.On("GetByID", mock.Anything, cache.ID, cache.TenantID).Return(cache, nil)
+	m.repo.On("ListNodes", mock.Anything, cache.ID).Return([]*domain.CacheNode{primary, r1, r2}, nil)
+
+	var undone []string
+	m.compute.On("Exec", mock.Anything, "cid-r2", mock.MatchedBy(func(cmd []string) bool {
+		return cmd[4] == "ACL" && strings.HasPrefix(cmd[7], ">")
+	})).Return("", errors.New(errors.Internal, "container not running"))
+	m.compute.On("Exec", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		cmd := args.Get(2).([]string)
+		if (cmd[4] == "CONFIG" && cmd[7] == "pw") || (cmd[4] == "ACL" && strings.HasPrefix(cmd[7], "<")) {
+			undone = append(undone, args.String(1))
+		}
+	}).Return("OK", nil)
+
+	err := svc.RotateCacheCredentials(ctx, cache.ID.String())
+	require.Error(t, err)
+	assert.Equal(t, "pw", cache.Password)
+	assert.ElementsMatch(t, []string{"cid-p", "cid-r1", "cid-r2", "cid-p", "cid-r1", "cid-r2"}, undone)
+	m.repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	m.compute.AssertNotCalled(t, "LaunchInstanceWithOptions", mock.Anything, mock.Anything)
+}
+
+func TestCacheServiceRotateCacheCredentialsMemcached(t *testing.T) {
+	m, svc, ctx := setupCacheReplicationTest()
+	cache, _, _, _ := replicatedCache(ctx)
+	cache.Engine, cache.Password = domain.EngineMemcached, ""
+	m.repo.On("GetByID", mock.Anything, cache.ID, cache.TenantID).Return(cache, nil)
+
+	err := svc.RotateCacheCredentials(ctx, cache.ID.String())
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.InvalidInput))
+	m.compute.AssertNotCalled(t, "Exec", mock.Anything, mock.Anything, mock.Anything)
+}
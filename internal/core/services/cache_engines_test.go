@@ -0,0 +1,228 @@
+package services_test
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func memcachedCmd(command string) interface{} {
+	return mock.MatchedBy(func(cmd []string) bool {
+		return len(cmd) == 3 && cmd[0] == "sh" && strings.Contains(cmd[2], "printf '"+command+"\\r\\nquit\\r\\n' | nc 127.0.0.1 11211")
+	})
+}
+
+func TestCacheServiceCreateValkeyCache(t *testing.T) {
+	m, svc, ctx := setupCacheReplicationTest()
+	m.repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	m.repo.On("CreateNode", mock.Anything, mock.Anything).Return(nil)
+	m.repo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+	m.compute.On("LaunchInstanceWithOptions", mock.Anything, mock.MatchedBy(func(opts ports.CreateInstanceOptions) bool {
+		return opts.ImageName == "valkey/valkey:8.0-alpine" && cmdContains(opts, " valkey-server ") && !cmdContains(opts, "--replicaof")
+	})).Return("cid-p", nil, nil).Once()
+	m.compute.On("LaunchInstanceWithOptions", mock.Anything, mock.MatchedBy(func(opts ports.CreateInstanceOptions) bool {
+		return opts.ImageName == "valkey/valkey:8.0-alpine" && cmdContains(opts, "--replicaof 10.0.0.2 6379")
+	})).Return("cid-r", nil, nil).Once()
+	m.compute.On("LaunchInstanceWithOptions", mock.Anything, mock.MatchedBy(func(opts ports.CreateInstanceOptions) bool {
+		return strings.HasPrefix(opts.ImageName, "haproxy:")
+	})).Return("cid-proxy", []string{"30001:6379", "30002:6380"}, nil).Once()
+	m.compute.On("GetInstanceIP", mock.Anything, "cid-p").Return("10.0.0.2", nil)
+	m.compute.On("GetInstanceIP", mock.Anything, "cid-r").Return("10.0.0.3", nil)
+
+	cache, err := svc.CreateCache(ctx, ports.CreateCacheRequest{Name: "sessions", Engine: domain.EngineValkey, Version: "8.0", MemoryMB: 256, Replicas: 1})
+	require.NoError(t, err)
+	assert.Equal(t, domain.EngineValkey, cache.Engine)
+	assert.NotEmpty(t, cache.Password)
+	assert.Equal(t, 30001, cache.Port)
+	m.compute.AssertExpectations(t)
+}
+
+func TestCacheServiceCreateMemcachedCache(t *testing.T) {
+	m, svc, ctx := setupCacheReplicationTest()
+	m.repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	m.repo.On("CreateNode", mock.Anything, mock.Anything).Return(nil)
+	m.repo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	vpcID := uuid.New()
+	m.vpcs.On("GetByID", mock.Anything, vpcID).Return(&domain.VPC{ID: vpcID, NetworkID: "br-vpc"}, nil)
+	// Memcached has no authentication, so it is not published on the host.
+	m.compute.On("LaunchInstanceWithOptions", mock.Anything, mock.MatchedBy(func(opts ports.CreateInstanceOptions) bool {
+		return opts.ImageName == "memcached:1.6-alpine" &&
+			strings.Join(opts.Cmd, " ") == "memcached -m 512 -p 11211 -U 0" &&
+			opts.NetworkID == "br-vpc" && len(opts.Ports) == 0 && len(opts.VolumeBinds) == 0
+	})).Return("cid-m", nil, nil).Once()
+
+	cache, err := svc.CreateCache(ctx, ports.CreateCacheRequest{Name: "legacy", Engine: domain.EngineMemcached, Version: "1.6", MemoryMB: 512, VpcID: &vpcID})
+	require.NoError(t, err)
+	assert.Equal(t, domain.EngineMemcached, cache.Engine)
+	assert.Empty(t, cache.Password)
+	assert.Equal(t, 11211, cache.Port)
+	m.compute.AssertExpectations(t)
+	m.volumes.AssertNotCalled(t, "CreateVolume", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCacheServiceCreateCacheWithMetrics(t *testing.T) {
+	t.Run("valkey scrapes the endpoint proxy", func(t *testing.T) {
+		m, svc, ctx := setupCacheReplicationTest()
+		m.repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		m.repo.On("CreateNode", mock.Anything, mock.Anything).Return(nil)
+		m.repo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		m.compute.On("LaunchInstanceWithOptions", mock.Anything, mock.MatchedBy(func(opts ports.CreateInstanceOptions) bool {
+			return strings.HasPrefix(opts.ImageName, "valkey/")
+		})).Return("cid-p", nil, nil).Once()
+		m.compute.On("LaunchInstanceWithOptions", mock.Anything, mock.MatchedBy(func(opts ports.CreateInstanceOptions) bool {
+			return strings.HasPrefix(opts.ImageName, "valkey/")
+		})).Return("cid-r", nil, nil).Once()
+		m.compute.On("LaunchInstanceWithOptions", mock.Anything, mock.MatchedBy(func(opts ports.CreateInstanceOptions) bool {
+			return strings.HasPrefix(opts.ImageName, "haproxy:")
+		})).Return("cid-proxy", []string{"30001:6379", "30002:6380"}, nil).Once()
+		var exporter ports.CreateInstanceOptions
+		m.compute.On("LaunchInstanceWithOptions", mock.Anything, mock.MatchedBy(func(opts ports.CreateInstanceOptions) bool {
+			return opts.ImageName == "oliver006/redis_exporter"
+		})).Run(func(args mock.Arguments) {
+			exporter = args.Get(1).(ports.CreateInstanceOptions)
+		}).Return("cid-exp", []string{"30121:9121"}, nil).Once()
+		m.compute.On("GetInstanceIP", mock.Anything, "cid-p").Return("10.0.0.2", nil)
+		m.compute.On("GetInstanceIP", mock.Anything, "cid-r").Return("10.0.0.3", nil)
+		m.compute.On("GetInstanceIP", mock.Anything, "cid-proxy").Return("10.0.0.9", nil)
+
+		cache, err := svc.CreateCache(ctx, ports.CreateCacheRequest{Name: "sessions", Engine: domain.EngineValkey, Version: "8.0", MemoryMB: 256, Replicas: 1, MetricsEnabled: true})
+		require.NoError(t, err)
+		assert.True(t, cache.MetricsEnabled)
+		assert.Equal(t, "cid-exp", cache.ExporterContainerID)
+		assert.Equal(t, 30121, cache.MetricsPort)
+		assert.Equal(t, []string{"0:9121"}, exporter.Ports)
+		assert.Contains(t, exporter.Env, "REDIS_ADDR=redis://10.0.0.9:6379")
+		assert.Contains(t, exporter.Env, "REDIS_PASSWORD="+cache.Password)
+		m.compute.AssertExpectations(t)
+	})
+
+	t.Run("memcached", func(t *testing.T) {
+		m, svc, ctx := setupCacheReplicationTest()
+		m.repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		m.repo.On("CreateNode", mock.Anything, mock.Anything).Return(nil)
+		m.repo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		vpcID := uuid.New()
+		m.vpcs.On("GetByID", mock.Anything, vpcID).Return(&domain.VPC{ID: vpcID, NetworkID: "br-vpc"}, nil)
+		m.compute.On("LaunchInstanceWithOptions", mock.Anything, mock.MatchedBy(func(opts ports.CreateInstanceOptions) bool {
+			return strings.HasPrefix(opts.ImageName, "memcached:")
+		})).Return("cid-m", nil, nil).Once()
+		m.compute.On("LaunchInstanceWithOptions", mock.Anything, mock.MatchedBy(func(opts ports.CreateInstanceOptions) bool {
+			return opts.ImageName == "prom/memcached-exporter" && opts.NetworkID == "br-vpc" &&
+				strings.Join(opts.Cmd, " ") == "--memcached.address=10.0.1.7:11211"
+		})).Return("cid-exp", []string{"30150:9150"}, nil).Once()
+		m.compute.On("GetInstanceIP", mock.Anything, "cid-m").Return("10.0.1.7", nil)
+
+		cache, err := svc.CreateCache(ctx, ports.CreateCacheRequest{Name: "legacy", Engine: domain.EngineMemcached, Version: "1.6", MemoryMB: 512, VpcID: &vpcID, MetricsEnabled: true})
+		require.NoError(t, err)
+		assert.Equal(t, 30150, cache.MetricsPort)
+		m.compute.AssertExpectations(t)
+	})
+
+	t.Run("exporter failure rolls back", func(t *testing.T) {
+		m, svc, ctx := setupCacheReplicationTest()
+		m.repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		m.repo.On("CreateNode", mock.Anything, mock.Anything).Return(nil)
+		m.repo.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+		m.compute.On("LaunchInstanceWithOptions", mock.Anything, mock.MatchedBy(func(opts ports.CreateInstanceOptions) bool {
+			return strings.HasPrefix(opts.ImageName, "redis:")
+		})).Return("cid-p", []string{"30001:6379"}, nil).Once()
+		m.compute.On("LaunchInstanceWithOptions", mock.Anything, mock.MatchedBy(func(opts ports.CreateInstanceOptions) bool {
+			return opts.ImageName == "oliver006/redis_exporter"
+		})).Return("", nil, errors.New(errors.Internal, "pull failed")).Once()
+		m.compute.On("GetInstanceIP", mock.Anything, "cid-p").Return("10.0.0.2", nil)
+		m.compute.On("StopInstance", mock.Anything, "cid-p").Return(nil).Once()
+		m.compute.On("DeleteInstance", mock.Anything, "cid-p").Return(nil).Once()
+
+		_, err := svc.CreateCache(ctx, ports.CreateCacheRequest{Name: "sessions", Version: "7.2", MemoryMB: 256, MetricsEnabled: true})
+		require.Error(t, err)
+		m.compute.AssertExpectations(t)
+		m.repo.AssertExpectations(t)
+	})
+}
+
+func TestCacheServiceCreateCacheEngineValidation(t *testing.T) {
+	_, svc, ctx := setupCacheReplicationTest()
+
+	for name, req := range map[string]ports.CreateCacheRequest{
+		"unknown engine":        {Engine: "dragonfly"},
+		"memcached persistence": {Engine: domain.EngineMemcached, Persistence: domain.CachePersistenceAOF},
+		"memcached replicas":    {Engine: domain.EngineMemcached, Replicas: 1},
+		"memcached cluster":     {Engine: domain.EngineMemcached, ClusterMode: true},
+		"memcached without vpc": {Engine: domain.EngineMemcached},
+	} {
+		t.Run(name, func(t *testing.T) {
+			req.Name, req.Version, req.MemoryMB = "c", "1.6", 128
+			_, err := svc.CreateCache(ctx, req)
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, errors.InvalidInput))
+		})
+	}
+}
+
+func TestCacheServiceMemcachedOperations(t *testing.T) {
+	newCache := func() *domain.Cache {
+		return &domain.Cache{ID: uuid.New(), Name: "legacy", Engine: domain.EngineMemcached, Status: domain.CacheStatusRunning,
+			ContainerID: "cid-m", Port: 11211, MemoryMB: 512}
+	}
+
+	t.Run("connection string", func(t *testing.T) {
+		m, svc, ctx := setupCacheReplicationTest()
+		cache := newCache()
+		m.repo.On("GetByID", mock.Anything, cache.ID, mock.Anything).Return(cache, nil)
+		m.compute.On("GetInstanceIP", mock.Anything, "cid-m").Return("10.0.1.7", nil)
+
+		conn, err := svc.GetConnectionString(ctx, cache.ID.String())
+		require.NoError(t, err)
+		assert.Equal(t, "memcached://10.0.1.7:11211", conn)
+	})
+
+	t.Run("flush", func(t *testing.T) {
+		m, svc, ctx := setupCacheReplicationTest()
+		cache := newCache()
+		m.repo.On("GetByID", mock.Anything, cache.ID, mock.Anything).Return(cache, nil)
+		m.compute.On("Exec", mock.Anything, "cid-m", memcachedCmd("flush_all")).Return("OK\r\n", nil).Once()
+
+		require.NoError(t, svc.FlushCache(ctx, cache.ID.String()))
+		m.compute.AssertExpectations(t)
+	})
+
+	t.Run("stats", func(t *testing.T) {
+		m, svc, ctx := setupCacheReplicationTest()
+		cache := newCache()
+		m.repo.On("GetByID", mock.Anything, cache.ID, mock.Anything).Return(cache, nil)
+		m.compute.On("GetInstanceStats", mock.Anything, "cid-m").
+			Return(io.NopCloser(strings.NewReader(`{"memory_stats": {"usage": 4096, "limit": 8192}}`)), nil)
+		m.compute.On("Exec", mock.Anything, "cid-m", memcachedCmd("stats")).
+			Return("STAT pid 1\r\nSTAT curr_connections 12\r\nSTAT curr_items 340\r\nEND\r\n", nil).Once()
+
+		stats, err := svc.GetCacheStats(ctx, cache.ID.String())
+		require.NoError(t, err)
+		assert.Equal(t, 12, stats.ConnectedClients)
+		assert.Equal(t, int64(340), stats.TotalKeys)
+		assert.Equal(t, int64(4096), stats.UsedMemoryBytes)
+	})
+
+	t.Run("resize memory", func(t *testing.T) {
+		m, svc, ctx := setupCacheReplicationTest()
+		cache := newCache()
+		m.repo.On("GetByID", mock.Anything, cache.ID, mock.Anything).Return(cache, nil)
+		m.repo.On("ListNodes", mock.Anything, cache.ID).Return([]*domain.CacheNode{{ID: uuid.New(), ContainerID: "cid-m", Role: domain.CacheNodePrimary}}, nil)
+		m.repo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		m.compute.On("Exec", mock.Anything, "cid-m", memcachedCmd("cache_memlimit 1024")).Return("OK\r\n", nil).Once()
+
+		memory := 1024
+		cache, err := svc.ModifyCache(ctx, cache.ID.String(), ports.ModifyCacheRequest{MemoryMB: &memory})
+		require.NoError(t, err)
+		assert.Equal(t, 1024, cache.MemoryMB)
+		m.compute.AssertExpectations(t)
+	})
+}
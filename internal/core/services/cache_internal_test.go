@@ -1,8 +1,14 @@
 package services
 
 import (
+	"encoding/hex"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/poyrazk/thecloud/internal/core/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -29,4 +35,115 @@ func TestCacheService_InternalParsers(t *testing.T) {
 		keys := parseRedisKeys(info)
 		assert.Equal(t, int64(150), keys)
 	})
+
+	t.Run("parseMemcachedStats", func(t *testing.T) {
+		stats := parseMemcachedStats("STAT pid 1\r\nSTAT curr_connections 10\r\nSTAT version 1.6.29\r\nEND\r\n")
+		assert.Equal(t, "10", stats["curr_connections"])
+		assert.Equal(t, "1.6.29", stats["version"])
+		assert.NotContains(t, stats, "END")
+	})
+
+	t.Run("parseRedisInfo", func(t *testing.T) {
+		info := parseRedisInfo("# Replication\r\nrole:slave\r\nmaster_host:10.0.0.2\r\nslave_repl_offset:42\r\n")
+		assert.Equal(t, "slave", info["role"])
+		assert.Equal(t, "42", info["slave_repl_offset"])
+		assert.NotContains(t, info, "# Replication")
+	})
+}
+
+func TestRedisServerCmd(t *testing.T) {
+	cache := &domain.Cache{Password: "pw", MemoryMB: 256, Persistence: domain.CachePersistenceNone}
+	cmd := strings.Join(redisServerCmd(cache, ""), " ")
+	assert.Contains(t, cmd, "--maxmemory 256mb")
+	assert.Contains(t, cmd, "--appendonly no")
+	assert.NotContains(t, cmd, "--dir")
+	assert.NotContains(t, cmd, "masterauth")
+
+	cache.Persistence = domain.CachePersistenceRDB
+	cache.Replicas = 1
+	args := redisServerCmd(cache, "10.0.0.2")
+	cmd = strings.Join(args, " ")
+	assert.Contains(t, args, redisRDBSchedule)
+	assert.Contains(t, cmd, "--dir /data")
+	assert.Contains(t, cmd, `printf 'masterauth "%s"\n' "$1"`)
+	assert.True(t, strings.HasSuffix(cmd, "--replicaof 10.0.0.2 6379"))
+	assert.NotContains(t, cmd, "--cluster-enabled")
+
+	cache.ClusterMode = true
+	cmd = strings.Join(redisServerCmd(cache, ""), " ")
+	assert.Contains(t, cmd, "--cluster-enabled yes --cluster-config-file nodes.conf")
+	assert.NotContains(t, cmd, "--replicaof")
+}
+
+func TestRedisServerCmdKeepsPasswordAcrossRestarts(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("sh not available")
+	}
+	dir := t.TempDir()
+	// Stand in for the image entrypoint, which starts the server.
+	entrypoint := filepath.Join(dir, "docker-entrypoint.sh")
+	require.NoError(t, os.WriteFile(entrypoint, []byte("#!/bin/sh\necho \"$@\"\n"), 0o755))
+	config := filepath.Join(dir, "cache.conf")
+
+	start := func(password string) string {
+		cache := &domain.Cache{Password: password, MemoryMB: 128, Replicas: 1}
+		args := redisServerCmd(cache, "")
+		require.Equal(t, []string{"sh", "-c"}, args[:2])
+		script := strings.ReplaceAll(args[2], redisNodeConfig, config)
+		c := exec.Command(sh, append([]string{"-c", script}, args[3:]...)...)
+		c.Env = append(os.Environ(), "PATH="+dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+		out, err := c.CombinedOutput()
+		require.NoError(t, err, string(out))
+		return string(out)
+	}
+
+	out := start("first")
+	assert.True(t, strings.HasPrefix(out, "redis-server "+redisNodeConfig+" --maxmemory"))
+	assert.NotContains(t, out, "first")
+	data, err := os.ReadFile(config)
+	require.NoError(t, err)
+	assert.Equal(t, "requirepass \"first\"\nmasterauth \"first\"\n", string(data))
+
+	// A restart reuses the original command, so it must not overwrite the
+	// password a rotation saved to the config file.
+	require.NoError(t, os.WriteFile(config, []byte("requirepass \"rotated\"\n"), 0o600))
+	start("first")
+	data, err = os.ReadFile(config)
+	require.NoError(t, err)
+	assert.Equal(t, "requirepass \"rotated\"\n", string(data))
+}
+
+func TestCacheServerCmd(t *testing.T) {
+	cache := &domain.Cache{Engine: domain.EngineValkey, Password: "pw", MemoryMB: 128}
+	assert.Contains(t, cacheServerCmd(cache, ""), "valkey-server")
+
+	cache.Engine = domain.EngineMemcached
+	assert.Equal(t, []string{"memcached", "-m", "128", "-p", "11211", "-U", "0"}, cacheServerCmd(cache, ""))
+}
+
+func TestParseClusterNodes(t *testing.T) {
+	out := "07c3 10.0.0.10:6379@16379 myself,master - 0 0 1 connected 0-5460\n" +
+		"67ed 10.0.0.11:6379@16379,cache-1 slave 07c3 0 1426238317239 1 connected\n" +
+		"292f 10.0.0.12:6379@16379 master,fail - 1426238316232 1426238315000 2 disconnected\n" +
+		"garbage\n"
+	nodes := parseClusterNodes(out)
+	require.Len(t, nodes, 3)
+	assert.Equal(t, redisClusterNode{ID: "07c3", IP: "10.0.0.10", Primary: true}, *nodes[0])
+	assert.Equal(t, redisClusterNode{ID: "67ed", IP: "10.0.0.11"}, *nodes[1])
+	assert.Equal(t, redisClusterNode{ID: "292f", IP: "10.0.0.12", Primary: true, Failed: true}, *nodes[2])
+}
+
+func TestRedisProxyConfig(t *testing.T) {
+	cfg := redisProxyConfig("p#ss w", map[string]string{"node-b": "10.0.0.3", "node-a": "10.0.0.2"})
+	assert.Contains(t, cfg, "bind :6379")
+	assert.Contains(t, cfg, "bind :6380")
+	assert.Contains(t, cfg, "tcp-check expect string role:master")
+	assert.Contains(t, cfg, "tcp-check expect string role:slave")
+	// The password only appears hex encoded.
+	assert.NotContains(t, cfg, "p#ss")
+	assert.Contains(t, cfg, hex.EncodeToString([]byte("AUTH p#ss w\r\n")))
+	// Both backends list every node, in a stable order.
+	assert.Equal(t, 2, strings.Count(cfg, "server node-a 10.0.0.2:6379"))
+	assert.Less(t, strings.Index(cfg, "node-a"), strings.Index(cfg, "node-b"))
 }
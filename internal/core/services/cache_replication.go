@@ -0,0 +1,608 @@
+package services
+
+// Caches run as one or more Redis nodes. The primary accepts writes; replicas
+// follow it with REPLICAOF and are read-only. With persistence enabled every
+// node keeps its RDB snapshots or append-only file on its own volume. Valkey
+// caches work the same way with the Valkey binaries; Memcached caches are a
+// single node that keeps nothing on disk.
+//
+// Caches with replicas are reached through an HAProxy endpoint that health
+// checks every node for "role:master" (port 6379) or "role:slave" (reader
+// port 6380). Promoting a replica is therefore enough to move the endpoint:
+// clients keep the same host port across failovers. The proxy is only
+// relaunched, on the same host ports, when a node is replaced.
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/errors"
+)
+
+const (
+	redisReaderPort   = "6380"
+	redisDataDir      = "/data"
+	redisNodeConfig   = redisDataDir + "/cache.conf"
+	cacheProxyImage   = "haproxy:2.9-alpine"
+	redisRDBSchedule  = "900 1 300 10 60 10000"
+	cacheCheckTimeout = 2 * time.Second
+)
+
+// provisionCache launches the nodes of cache and, when it has replicas, the
+// endpoint proxy in front of them. Launched resources are recorded on cache so
+// a failure can be rolled back with teardownCache.
+func (s *CacheService) provisionCache(ctx context.Context, cache *domain.Cache, networkID string) error {
+	if cache.ClusterMode {
+		return s.provisionCacheCluster(ctx, cache, networkID)
+	}
+
+	single := cache.Replicas == 0
+	// Memcached has no authentication, so it is never published on the host
+	// and only serves its VPC.
+	publish := single && cache.Engine.RedisCompatible()
+	primary, allocatedPorts, err := s.launchCacheNode(ctx, cache, domain.CacheNodePrimary, 0, "", networkID, publish)
+	if err != nil {
+		return err
+	}
+	cache.Nodes = append(cache.Nodes, primary)
+	cache.ContainerID = primary.ContainerID
+
+	if !cache.Engine.RedisCompatible() {
+		cache.Port, _ = strconv.Atoi(cacheEnginePort(cache.Engine))
+		return nil
+	}
+	if single {
+		port, err := s.resolveCachePort(ctx, primary.ContainerID, allocatedPorts, cacheEnginePort(cache.Engine))
+		if err != nil {
+			return err
+		}
+		cache.Port = port
+		return nil
+	}
+
+	primaryIP, err := s.compute.GetInstanceIP(ctx, primary.ContainerID)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to get cache primary IP", err)
+	}
+	for i := 0; i < cache.Replicas; i++ {
+		replica, _, err := s.launchCacheNode(ctx, cache, domain.CacheNodeReplica, 0, primaryIP, networkID, false)
+		if err != nil {
+			return err
+		}
+		cache.Nodes = append(cache.Nodes, replica)
+	}
+	return s.launchCacheProxy(ctx, cache, networkID)
+}
+
+// launchCacheNode starts an engine node of cache, on a fresh volume when
+// persistence is enabled, and records it. A non-empty primaryIP starts the
+// node as a replica of that address. Only published nodes expose a host port.
+func (s *CacheService) launchCacheNode(ctx context.Context, cache *domain.Cache, role domain.CacheNodeRole, shard int, primaryIP, networkID string, publish bool) (*domain.CacheNode, []string, error) {
+	now := time.Now()
+	node := &domain.CacheNode{
+		ID:        uuid.New(),
+		CacheID:   cache.ID,
+		Role:      role,
+		Shard:     shard,
+		Status:    domain.CacheStatusRunning,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	opts := ports.CreateInstanceOptions{
+		Name:      fmt.Sprintf("thecloud-cache-%s-%s", cache.ID.String()[:8], node.ID.String()[:8]),
+		ImageName: cacheImage(cache),
+		NetworkID: networkID,
+		Cmd:       cacheServerCmd(cache, primaryIP),
+	}
+	if publish {
+		opts.Ports = []string{"0:" + cacheEnginePort(cache.Engine)}
+	}
+
+	if cache.Persistence != domain.CachePersistenceNone {
+		vol, err := s.volumeSvc.CreateVolume(ctx, fmt.Sprintf("cache-vol-%s", node.ID.String()[:8]), domain.CacheVolumeSizeGB(cache.MemoryMB))
+		if err != nil {
+			return nil, nil, errors.Wrap(errors.Internal, "failed to create cache volume", err)
+		}
+		node.VolumeID = &vol.ID
+		volName := "thecloud-vol-" + vol.ID.String()[:8]
+		if vol.BackendPath != "" {
+			volName = vol.BackendPath
+		}
+		opts.VolumeBinds = []string{volName + ":" + redisDataDir}
+	}
+
+	containerID, allocatedPorts, err := s.compute.LaunchInstanceWithOptions(ctx, opts)
+	if err != nil {
+		s.logger.Error("failed to create cache container", "cache_id", cache.ID, "role", role, "error", err)
+		s.deleteNodeVolume(ctx, node)
+		return nil, nil, err
+	}
+	node.ContainerID = containerID
+
+	if err := s.repo.CreateNode(ctx, node); err != nil {
+		s.removeCacheContainer(ctx, containerID)
+		s.deleteNodeVolume(ctx, node)
+		return nil, nil, err
+	}
+	return node, allocatedPorts, nil
+}
+
+// cacheImage returns the container image of the engine and version of cache.
+func cacheImage(cache *domain.Cache) string {
+	switch cache.Engine {
+	case domain.EngineValkey:
+		return fmt.Sprintf("valkey/valkey:%s-alpine", cache.Version)
+	case domain.EngineMemcached:
+		return fmt.Sprintf("memcached:%s-alpine", cache.Version)
+	}
+	return fmt.Sprintf("redis:%s-alpine", cache.Version)
+}
+
+// cacheEnginePort returns the port the engine listens on inside its container.
+func cacheEnginePort(engine domain.CacheEngine) string {
+	if engine == domain.EngineMemcached {
+		return defaultMemcachedPort
+	}
+	return defaultRedisPort
+}
+
+// cacheServerCmd builds the engine command line of a cache node.
+func cacheServerCmd(cache *domain.Cache, primaryIP string) []string {
+	if cache.Engine == domain.EngineMemcached {
+		// Memcached runs without SASL, so it has no password; it is only
+		// reachable on its VPC network.
+		return []string{"memcached", "-m", strconv.Itoa(cache.MemoryMB), "-p", defaultMemcachedPort, "-U", "0"}
+	}
+	return redisServerCmd(cache, primaryIP)
+}
+
+// redisServerCmd builds the command line of a Redis or Valkey node. The
+// password is kept out of the server arguments, which are reapplied on every
+// restart: a shell writes it to redisNodeConfig on the first start only, and
+// the server loads that file, so a rotation saved with CONFIG REWRITE survives
+// restarts. The image entrypoint then drops root as it does for a plain
+// server command.
+func redisServerCmd(cache *domain.Cache, primaryIP string) []string {
+	server := "redis-server"
+	if cache.Engine == domain.EngineValkey {
+		server = "valkey-server"
+	}
+	directives := []string{"requirepass"}
+	if cache.Replicas > 0 {
+		// Every node may become a replica after a failover.
+		directives = append(directives, "masterauth")
+	}
+	writes := make([]string, len(directives))
+	for i, d := range directives {
+		writes[i] = fmt.Sprintf(`printf '%s "%%s"\n' "$1"`, d)
+	}
+	script := fmt.Sprintf(`[ -f %[1]s ] || { %[2]s; } > %[1]s; shift; exec docker-entrypoint.sh "$@"`,
+		redisNodeConfig, strings.Join(writes, "; "))
+	cmd := []string{
+		"sh", "-c", script, "sh", cache.Password,
+		server, redisNodeConfig,
+		"--maxmemory", fmt.Sprintf("%dmb", cache.MemoryMB),
+		"--maxmemory-policy", "allkeys-lru",
+		"--tcp-keepalive", "300",
+	}
+	switch cache.Persistence {
+	case domain.CachePersistenceRDB:
+		cmd = append(cmd, "--dir", redisDataDir, "--appendonly", "no", "--save", redisRDBSchedule)
+	case domain.CachePersistenceAOF:
+		cmd = append(cmd, "--dir", redisDataDir, "--appendonly", "yes", "--appendfsync", "everysec", "--save", "")
+	default:
+		cmd = append(cmd, "--appendonly", "no", "--save", "")
+	}
+	if cache.ClusterMode {
+		cmd = append(cmd,
+			"--cluster-enabled", "yes",
+			"--cluster-config-file", redisClusterConfigFile,
+			"--cluster-node-timeout", redisClusterNodeTimeout,
+		)
+	}
+	if primaryIP != "" {
+		cmd = append(cmd, "--replicaof", primaryIP, defaultRedisPort)
+	}
+	return cmd
+}
+
+// launchCacheProxy starts the endpoint proxy of cache in front of its nodes.
+// It reuses cache.Port and cache.ReaderPort when set, so relaunches keep the
+// endpoint stable.
+func (s *CacheService) launchCacheProxy(ctx context.Context, cache *domain.Cache, networkID string) error {
+	addrs := make(map[string]string, len(cache.Nodes))
+	for _, n := range cache.Nodes {
+		ip, err := s.compute.GetInstanceIP(ctx, n.ContainerID)
+		if err != nil {
+			return errors.Wrap(errors.Internal, "failed to get cache node IP", err)
+		}
+		addrs["node-"+n.ID.String()[:8]] = ip
+	}
+
+	containerID, allocatedPorts, err := s.compute.LaunchInstanceWithOptions(ctx, ports.CreateInstanceOptions{
+		Name:      fmt.Sprintf("thecloud-cache-proxy-%s", cache.ID.String()[:8]),
+		ImageName: cacheProxyImage,
+		Ports: []string{
+			fmt.Sprintf("%d:%s", cache.Port, defaultRedisPort),
+			fmt.Sprintf("%d:%s", cache.ReaderPort, redisReaderPort),
+		},
+		NetworkID: networkID,
+		Env:       []string{"HAPROXY_CFG=" + redisProxyConfig(cache.Password, addrs)},
+		Cmd:       []string{"sh", "-c", `printf '%s' "$HAPROXY_CFG" > /tmp/haproxy.cfg && exec haproxy -f /tmp/haproxy.cfg`},
+	})
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to launch cache endpoint proxy", err)
+	}
+	cache.ProxyContainerID = containerID
+
+	if cache.Port, err = s.resolveCachePort(ctx, containerID, allocatedPorts, defaultRedisPort); err != nil {
+		return err
+	}
+	if cache.ReaderPort, err = s.resolveCachePort(ctx, containerID, allocatedPorts, redisReaderPort); err != nil {
+		return err
+	}
+	return nil
+}
+
+// redisProxyConfig renders the HAProxy configuration routing the primary
+// port to the node reporting role:master and the reader port across nodes
+// reporting role:slave. Health check payloads are hex encoded so the password
+// needs no quoting.
+func redisProxyConfig(password string, addrs map[string]string) string {
+	names := make([]string, 0, len(addrs))
+	for name := range addrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString("global\n    maxconn 4096\n")
+	b.WriteString("defaults\n    mode tcp\n    timeout connect 3s\n    timeout client 1h\n    timeout server 1h\n    timeout check 2s\n")
+	b.WriteString("frontend primary\n    bind :" + defaultRedisPort + "\n    default_backend primary\n")
+	b.WriteString("frontend replicas\n    bind :" + redisReaderPort + "\n    default_backend replicas\n")
+	for _, backend := range []struct{ name, role string }{{"primary", "master"}, {"replicas", "slave"}} {
+		b.WriteString("backend " + backend.name + "\n")
+		if backend.name == "replicas" {
+			b.WriteString("    balance roundrobin\n")
+		}
+		b.WriteString("    option tcp-check\n    tcp-check connect\n")
+		b.WriteString("    tcp-check send-binary " + hex.EncodeToString([]byte("AUTH "+password+"\r\n")) + "\n")
+		b.WriteString("    tcp-check expect string +OK\n")
+		b.WriteString("    tcp-check send-binary " + hex.EncodeToString([]byte("INFO replication\r\n")) + "\n")
+		b.WriteString("    tcp-check expect string role:" + backend.role + "\n")
+		b.WriteString("    tcp-check send-binary " + hex.EncodeToString([]byte("QUIT\r\n")) + "\n")
+		b.WriteString("    tcp-check expect string +OK\n")
+		for _, name := range names {
+			fmt.Fprintf(&b, "    server %s %s:%s check inter 1s fall 2 rise 2 on-marked-down shutdown-sessions\n", name, addrs[name], defaultRedisPort)
+		}
+	}
+	return b.String()
+}
+
+// launchCacheExporter starts the Prometheus exporter sidecar of cache. It
+// scrapes the endpoint proxy when there is one, so it follows failovers, and
+// otherwise the primary; in cluster mode the exporter discovers the other
+// nodes from there. It reuses cache.MetricsPort when set.
+func (s *CacheService) launchCacheExporter(ctx context.Context, cache *domain.Cache, networkID string) error {
+	target := cache.ContainerID
+	if cache.ProxyContainerID != "" {
+		target = cache.ProxyContainerID
+	}
+	ip, err := s.compute.GetInstanceIP(ctx, target)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to get cache IP for metrics exporter", err)
+	}
+
+	image, env, cmd, internalPort := cacheExporterConfig(cache, ip)
+	containerID, allocatedPorts, err := s.compute.LaunchInstanceWithOptions(ctx, ports.CreateInstanceOptions{
+		Name:      fmt.Sprintf("thecloud-cache-exporter-%s", cache.ID.String()[:8]),
+		ImageName: image,
+		Ports:     []string{fmt.Sprintf("%d:%s", cache.MetricsPort, internalPort)},
+		NetworkID: networkID,
+		Env:       env,
+		Cmd:       cmd,
+	})
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to launch cache metrics exporter", err)
+	}
+	cache.ExporterContainerID = containerID
+
+	cache.MetricsPort, err = s.resolveCachePort(ctx, containerID, allocatedPorts, internalPort)
+	return err
+}
+
+// relaunchCacheExporter replaces the exporter of cache after its scrape
+// target or password changed. Caches without metrics are left alone.
+func (s *CacheService) relaunchCacheExporter(ctx context.Context, cache *domain.Cache, networkID string) error {
+	if !cache.MetricsEnabled {
+		return nil
+	}
+	if cache.ExporterContainerID != "" {
+		s.removeCacheContainer(ctx, cache.ExporterContainerID)
+		cache.ExporterContainerID = ""
+	}
+	return s.launchCacheExporter(ctx, cache, networkID)
+}
+
+// cacheExporterConfig returns the image, environment, arguments and metrics
+// port of the exporter for the engine of cache, scraping the engine at ip.
+// redis_exporter serves Valkey as well.
+func cacheExporterConfig(cache *domain.Cache, ip string) (string, []string, []string, string) {
+	addr := net.JoinHostPort(ip, cacheEnginePort(cache.Engine))
+	if cache.Engine == domain.EngineMemcached {
+		return memcachedExporterImage, nil, []string{"--memcached.address=" + addr}, memcachedExporterPort
+	}
+	env := []string{"REDIS_ADDR=redis://" + addr, "REDIS_PASSWORD=" + cache.Password}
+	if cache.ClusterMode {
+		env = append(env, "REDIS_EXPORTER_IS_CLUSTER=true")
+	}
+	return redisExporterImage, env, nil, redisExporterPort
+}
+
+func (s *CacheService) resolveCachePort(ctx context.Context, containerID string, allocatedPorts []string, targetPort string) (int, error) {
+	port, err := s.parseAllocatedPort(allocatedPorts, targetPort)
+	if err == nil && port != 0 {
+		return port, nil
+	}
+	port, err = s.compute.GetInstancePort(ctx, containerID, targetPort)
+	if err != nil {
+		s.logger.Error("failed to resolve cache port", "container_id", containerID, "error", err)
+		return 0, errors.Wrap(errors.Internal, "failed to resolve cache port", err)
+	}
+	return port, nil
+}
+
+// teardownCache removes the proxy, exporter, containers and volumes of
+// cache. Records are left to the caller; node rows go with the cache row.
+func (s *CacheService) teardownCache(ctx context.Context, cache *domain.Cache) {
+	if cache.ProxyContainerID != "" {
+		s.removeCacheContainer(ctx, cache.ProxyContainerID)
+	}
+	if cache.ExporterContainerID != "" {
+		s.removeCacheContainer(ctx, cache.ExporterContainerID)
+	}
+	for _, n := range cache.Nodes {
+		if n.ContainerID != "" {
+			s.removeCacheContainer(ctx, n.ContainerID)
+		}
+		s.deleteNodeVolume(ctx, n)
+	}
+	// Caches created before nodes were tracked only know their container.
+	if len(cache.Nodes) == 0 && cache.ContainerID != "" {
+		s.removeCacheContainer(ctx, cache.ContainerID)
+	}
+}
+
+func (s *CacheService) removeCacheContainer(ctx context.Context, containerID string) {
+	if err := s.compute.StopInstance(ctx, containerID); err != nil {
+		s.logger.Warn("failed to stop cache container", "container_id", containerID, "error", err)
+	}
+	if err := s.compute.DeleteInstance(ctx, containerID); err != nil {
+		s.logger.Warn("failed to remove cache container", "container_id", containerID, "error", err)
+	}
+}
+
+func (s *CacheService) deleteNodeVolume(ctx context.Context, node *domain.CacheNode) {
+	if node.VolumeID == nil || s.volumeSvc == nil {
+		return
+	}
+	if err := s.volumeSvc.DeleteVolume(ctx, node.VolumeID.String()); err != nil {
+		s.logger.Warn("failed to delete cache volume", "volume_id", node.VolumeID, "error", err)
+	}
+}
+
+func (s *CacheService) FailoverCache(ctx context.Context, idOrName string) error {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionCacheUpdate, idOrName); err != nil {
+		return err
+	}
+	if s.compute.Type() == "libvirt" {
+		return errors.New(errors.InvalidInput, "cache failover requires docker compute backend (COMPUTE_BACKEND=libvirt is not supported)")
+	}
+
+	cache, err := s.getCacheByIDOrName(ctx, idOrName)
+	if err != nil {
+		return err
+	}
+	if cache.Status != domain.CacheStatusRunning {
+		return errors.New(errors.InstanceNotRunning, "cache is not running")
+	}
+	if cache.ClusterMode {
+		return errors.New(errors.InvalidInput, "cluster-mode caches fail over each shard automatically")
+	}
+	nodes, err := s.repo.ListNodes(ctx, cache.ID)
+	if err != nil {
+		return err
+	}
+	cache.Nodes = nodes
+
+	primary := cache.PrimaryNode()
+	var replicas []*domain.CacheNode
+	for _, n := range nodes {
+		if n.Role == domain.CacheNodeReplica {
+			replicas = append(replicas, n)
+		}
+	}
+	if len(replicas) == 0 {
+		return errors.New(errors.InvalidInput, "cache has no replicas to fail over to")
+	}
+
+	target, err := s.selectFailoverReplica(ctx, cache, replicas)
+	if err != nil {
+		return err
+	}
+	if _, err := s.redisCLI(ctx, cache, target.ContainerID, "REPLICAOF", "NO", "ONE"); err != nil {
+		return errors.Wrap(errors.Internal, "failed to promote cache replica", err)
+	}
+	targetIP, err := s.compute.GetInstanceIP(ctx, target.ContainerID)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to get promoted replica IP", err)
+	}
+	s.setNodeRole(ctx, target, domain.CacheNodePrimary)
+
+	members := []*domain.CacheNode{target}
+	replaced := false
+	if primary != nil {
+		if s.redisHealthy(ctx, cache, primary.ContainerID) {
+			// A planned failover keeps the old primary as a replica.
+			if _, err := s.redisCLI(ctx, cache, primary.ContainerID, "REPLICAOF", targetIP, defaultRedisPort); err != nil {
+				s.logger.Warn("failed to demote old cache primary", "cache_id", cache.ID, "node_id", primary.ID, "error", err)
+			}
+			s.setNodeRole(ctx, primary, domain.CacheNodeReplica)
+			members = append(members, primary)
+		} else {
+			replacement, err := s.replaceFailedNode(ctx, cache, primary, targetIP)
+			if err != nil {
+				s.logger.Error("failed to replace failed cache node", "cache_id", cache.ID, "node_id", primary.ID, "error", err)
+			} else {
+				members = append(members, replacement)
+			}
+			replaced = true
+		}
+	}
+	for _, n := range replicas {
+		if n.ID == target.ID {
+			continue
+		}
+		if _, err := s.redisCLI(ctx, cache, n.ContainerID, "REPLICAOF", targetIP, defaultRedisPort); err != nil {
+			s.logger.Warn("failed to repoint cache replica", "cache_id", cache.ID, "node_id", n.ID, "error", err)
+		}
+		members = append(members, n)
+	}
+	cache.Nodes = members
+	cache.ContainerID = target.ContainerID
+
+	if replaced && cache.ProxyContainerID != "" {
+		networkID, err := s.resolveNetworkID(ctx, cache.VpcID)
+		if err != nil {
+			return err
+		}
+		s.removeCacheContainer(ctx, cache.ProxyContainerID)
+		if err := s.launchCacheProxy(ctx, cache, networkID); err != nil {
+			return err
+		}
+		// The exporter scrapes the proxy, which has a new address.
+		if err := s.relaunchCacheExporter(ctx, cache, networkID); err != nil {
+			return err
+		}
+	}
+
+	cache.UpdatedAt = time.Now()
+	if err := s.repo.Update(ctx, cache); err != nil {
+		return err
+	}
+
+	if err := s.eventSvc.RecordEvent(ctx, "CACHE_FAILOVER", cache.ID.String(), "CACHE", map[string]interface{}{
+		"primary_node_id": target.ID.String(),
+	}); err != nil {
+		s.logger.Warn("failed to record event", "action", "CACHE_FAILOVER", "cache_id", cache.ID, "error", err)
+	}
+	if err := s.auditSvc.Log(ctx, cache.UserID, "cache.failover", "cache", cache.ID.String(), map[string]interface{}{
+		"name":            cache.Name,
+		"primary_node_id": target.ID.String(),
+	}); err != nil {
+		s.logger.Warn("failed to log audit event", "action", "cache.failover", "cache_id", cache.ID, "error", err)
+	}
+	return nil
+}
+
+// selectFailoverReplica picks the reachable replica with the highest
+// replication offset, i.e. the one that lost the fewest writes.
+func (s *CacheService) selectFailoverReplica(ctx context.Context, cache *domain.Cache, replicas []*domain.CacheNode) (*domain.CacheNode, error) {
+	var best *domain.CacheNode
+	bestOffset := int64(-1)
+	for _, n := range replicas {
+		if n.Status != domain.CacheStatusRunning || n.ContainerID == "" {
+			continue
+		}
+		out, err := s.redisCLI(ctx, cache, n.ContainerID, "INFO", "replication")
+		if err != nil {
+			s.logger.Warn("cache replica unreachable", "cache_id", cache.ID, "node_id", n.ID, "error", err)
+			continue
+		}
+		offset, err := strconv.ParseInt(parseRedisInfo(out)["slave_repl_offset"], 10, 64)
+		if err != nil {
+			offset = 0
+		}
+		if offset > bestOffset {
+			best, bestOffset = n, offset
+		}
+	}
+	if best == nil {
+		return nil, errors.New(errors.Internal, "no healthy cache replica found")
+	}
+	return best, nil
+}
+
+// replaceFailedNode removes a dead node and launches a replica of primaryIP
+// in its place, so the cache keeps its replica count.
+func (s *CacheService) replaceFailedNode(ctx context.Context, cache *domain.Cache, failed *domain.CacheNode, primaryIP string) (*domain.CacheNode, error) {
+	if failed.ContainerID != "" {
+		s.removeCacheContainer(ctx, failed.ContainerID)
+	}
+	s.deleteNodeVolume(ctx, failed)
+	if err := s.repo.DeleteNode(ctx, failed.ID); err != nil {
+		return nil, err
+	}
+
+	networkID, err := s.resolveNetworkID(ctx, cache.VpcID)
+	if err != nil {
+		return nil, err
+	}
+	replacement, _, err := s.launchCacheNode(ctx, cache, domain.CacheNodeReplica, failed.Shard, primaryIP, networkID, false)
+	return replacement, err
+}
+
+func (s *CacheService) setNodeRole(ctx context.Context, node *domain.CacheNode, role domain.CacheNodeRole) {
+	node.Role = role
+	node.UpdatedAt = time.Now()
+	if err := s.repo.UpdateNode(ctx, node); err != nil {
+		s.logger.Warn("failed to update cache node role", "node_id", node.ID, "role", role, "error", err)
+	}
+}
+
+// redisHealthy reports whether the node in containerID answers PING.
+func (s *CacheService) redisHealthy(ctx context.Context, cache *domain.Cache, containerID string) bool {
+	if containerID == "" {
+		return false
+	}
+	checkCtx, cancel := context.WithTimeout(ctx, cacheCheckTimeout)
+	defer cancel()
+	out, err := s.redisCLI(checkCtx, cache, containerID, "PING")
+	return err == nil && strings.Contains(out, "PONG")
+}
+
+// redisCLI runs a redis-cli, or valkey-cli, command against the node in
+// containerID.
+func (s *CacheService) redisCLI(ctx context.Context, cache *domain.Cache, containerID string, args ...string) (string, error) {
+	cmd := []string{cache.Engine.CLI(), "--no-auth-warning"}
+	if cache.Password != "" {
+		cmd = append(cmd, "-a", cache.Password)
+	}
+	return s.compute.Exec(ctx, containerID, append(cmd, args...))
+}
+
+// parseRedisInfo splits INFO output into its fields.
+func parseRedisInfo(info string) map[string]string {
+	fields := make(map[string]string)
+	for _, line := range strings.Split(info, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if ok && !strings.HasPrefix(key, "#") {
+			fields[key] = value
+		}
+	}
+	return fields
+}
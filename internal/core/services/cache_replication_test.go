@@ -0,0 +1,260 @@
+package services_test
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/core/services"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type cacheReplicationMocks struct {
+	repo    *MockCacheRepository
+	compute *MockComputeBackend
+	volumes *MockVolumeService
+	events  *MockEventService
+	audit   *MockAuditService
+	vpcs    *MockVpcRepo
+}
+
+func setupCacheReplicationTest() (*cacheReplicationMocks, *services.CacheService, context.Context) {
+	m := &cacheReplicationMocks{
+		repo:    new(MockCacheRepository),
+		compute: new(MockComputeBackend),
+		volumes: new(MockVolumeService),
+		events:  new(MockEventService),
+		audit:   new(MockAuditService),
+		vpcs:    new(MockVpcRepo),
+	}
+	rbac := new(MockRBACService)
+	rbac.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	m.compute.On("Type").Return("docker").Maybe()
+	m.events.On("RecordEvent", mock.Anything, mock.Anything, mock.Anything, "CACHE", mock.Anything).Return(nil).Maybe()
+	m.audit.On("Log", mock.Anything, mock.Anything, mock.Anything, "cache", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	svc := services.NewCacheService(services.CacheServiceParams{
+		Repo:      m.repo,
+		RBAC:      rbac,
+		Compute:   m.compute,
+		VpcRepo:   m.vpcs,
+		VolumeSvc: m.volumes,
+		EventSvc:  m.events,
+		AuditSvc:  m.audit,
+		Logger:    slog.Default(),
+	})
+	ctx := appcontext.WithTenantID(appcontext.WithUserID(context.Background(), uuid.New()), uuid.New())
+	return m, svc, ctx
+}
+
+func cmdContains(opts ports.CreateInstanceOptions, arg string) bool {
+	return strings.Contains(strings.Join(opts.Cmd, " "), arg)
+}
+
+func TestCacheServiceCreateCacheWithReplicas(t *testing.T) {
+	m, svc, ctx := setupCacheReplicationTest()
+	m.repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	m.repo.On("CreateNode", mock.Anything, mock.Anything).Return(nil)
+	m.repo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	m.volumes.On("CreateVolume", mock.Anything, mock.MatchedBy(func(name string) bool {
+		return strings.HasPrefix(name, "cache-vol-")
+	}), 1).Return(&domain.Volume{ID: uuid.New()}, nil)
+
+	isNode := func(replica bool) interface{} {
+		return mock.MatchedBy(func(opts ports.CreateInstanceOptions) bool {
+			return strings.HasPrefix(opts.ImageName, "redis:") && cmdContains(opts, "--replicaof") == replica
+		})
+	}
+	m.compute.On("LaunchInstanceWithOptions", mock.Anything, isNode(false)).Return("cid-p", nil, nil).Once()
+	m.compute.On("LaunchInstanceWithOptions", mock.Anything, isNode(true)).Return("cid-r1", nil, nil).Once()
+	m.compute.On("LaunchInstanceWithOptions", mock.Anything, isNode(true)).Return("cid-r2", nil, nil).Once()
+	m.compute.On("LaunchInstanceWithOptions", mock.Anything, mock.MatchedBy(func(opts ports.CreateInstanceOptions) bool {
+		return strings.HasPrefix(opts.ImageName, "haproxy:")
+	})).Return("cid-proxy", []string{"30001:6379", "30002:6380"}, nil).Once()
+	m.compute.On("GetInstanceIP", mock.Anything, "cid-p").Return("10.0.0.2", nil)
+	m.compute.On("GetInstanceIP", mock.Anything, "cid-r1").Return("10.0.0.3", nil)
+	m.compute.On("GetInstanceIP", mock.Anything, "cid-r2").Return("10.0.0.4", nil)
+
+	cache, err := svc.CreateCache(ctx, ports.CreateCacheRequest{
+		Name: "sessions", Version: "7.2", MemoryMB: 256,
+		Persistence: domain.CachePersistenceAOF, Replicas: 2, FailoverEnabled: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.CacheStatusRunning, cache.Status)
+	assert.Equal(t, "cid-p", cache.ContainerID)
+	assert.Equal(t, "cid-proxy", cache.ProxyContainerID)
+	assert.Equal(t, 30001, cache.Port)
+	assert.Equal(t, 30002, cache.ReaderPort)
+	require.Len(t, cache.Nodes, 3)
+	for _, n := range cache.Nodes {
+		assert.NotNil(t, n.VolumeID)
+	}
+
+	for _, call := range m.compute.Calls {
+		if call.Method != "LaunchInstanceWithOptions" {
+			continue
+		}
+		opts := call.Arguments.Get(1).(ports.CreateInstanceOptions)
+		if strings.HasPrefix(opts.ImageName, "haproxy:") {
+			assert.Equal(t, []string{"0:6379", "0:6380"}, opts.Ports)
+			continue
+		}
+		// Nodes stay private behind the proxy and keep their data on a volume.
+		assert.Empty(t, opts.Ports)
+		require.Len(t, opts.VolumeBinds, 1)
+		assert.True(t, strings.HasSuffix(opts.VolumeBinds[0], ":/data"))
+		assert.True(t, cmdContains(opts, "--appendonly yes"))
+		assert.True(t, cmdContains(opts, "masterauth"))
+		if cmdContains(opts, "--replicaof") {
+			assert.True(t, cmdContains(opts, "--replicaof 10.0.0.2 6379"))
+		}
+	}
+}
+
+func TestCacheServiceCreateCacheValidation(t *testing.T) {
+	_, svc, ctx := setupCacheReplicationTest()
+	cases := []ports.CreateCacheRequest{
+		{Name: "c", Version: "7.2", MemoryMB: 128, Persistence: "both"},
+		{Name: "c", Version: "7.2", MemoryMB: 128, Replicas: -1},
+		{Name: "c", Version: "7.2", MemoryMB: 128, Replicas: domain.MaxCacheReplicas + 1},
+		{Name: "c", Version: "7.2", MemoryMB: 128, FailoverEnabled: true},
+	}
+	for _, req := range cases {
+		_, err := svc.CreateCache(ctx, req)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.InvalidInput), err.Error())
+	}
+}
+
+func TestCacheServiceCreateCacheRollsBackNodes(t *testing.T) {
+	m, svc, ctx := setupCacheReplicationTest()
+	volID := uuid.New()
+	m.repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+	m.repo.On("CreateNode", mock.Anything, mock.Anything).Return(nil)
+	m.repo.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	m.volumes.On("CreateVolume", mock.Anything, mock.Anything, 1).Return(&domain.Volume{ID: volID}, nil)
+	m.volumes.On("DeleteVolume", mock.Anything, volID.String()).Return(nil)
+	m.compute.On("LaunchInstanceWithOptions", mock.Anything, mock.MatchedBy(func(opts ports.CreateInstanceOptions) bool {
+		return !cmdContains(opts, "--replicaof")
+	})).Return("cid-p", nil, nil).Once()
+	m.compute.On("LaunchInstanceWithOptions", mock.Anything, mock.Anything).Return("", nil, errors.New(errors.Internal, "no capacity")).Once()
+	m.compute.On("GetInstanceIP", mock.Anything, "cid-p").Return("10.0.0.2", nil)
+	m.compute.On("StopInstance", mock.Anything, "cid-p").Return(nil).Once()
+	m.compute.On("DeleteInstance", mock.Anything, "cid-p").Return(nil).Once()
+
+	_, err := svc.CreateCache(ctx, ports.CreateCacheRequest{
+		Name: "c", Version: "7.2", MemoryMB: 128, Persistence: domain.CachePersistenceRDB, Replicas: 1,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "launch cache container")
+	m.compute.AssertExpectations(t)
+	// Both the primary's and the failed replica's volumes are released.
+	m.volumes.AssertNumberOfCalls(t, "DeleteVolume", 2)
+	m.repo.AssertExpectations(t)
+}
+
+func replicatedCache(ctx context.Context) (*domain.Cache, *domain.CacheNode, *domain.CacheNode, *domain.CacheNode) {
+	cache := &domain.Cache{
+		ID: uuid.New(), UserID: appcontext.UserIDFromContext(ctx), TenantID: appcontext.TenantIDFromContext(ctx),
+		Name: "sessions", Version: "7.2", Status: domain.CacheStatusRunning, Password: "pw", MemoryMB: 128,
+		Persistence: domain.CachePersistenceNone, Replicas: 2, FailoverEnabled: true,
+		ContainerID: "cid-p", Port: 30001, ReaderPort: 30002, ProxyContainerID: "cid-proxy",
+	}
+	primary := &domain.CacheNode{ID: uuid.New(), CacheID: cache.ID, Role: domain.CacheNodePrimary, Status: domain.CacheStatusRunning, ContainerID: "cid-p"}
+	r1 := &domain.CacheNode{ID: uuid.New(), CacheID: cache.ID, Role: domain.CacheNodeReplica, Status: domain.CacheStatusRunning, ContainerID: "cid-r1"}
+	r2 := &domain.CacheNode{ID: uuid.New(), CacheID: cache.ID, Role: domain.CacheNodeReplica, Status: domain.CacheStatusRunning, ContainerID: "cid-r2"}
+	return cache, primary, r1, r2
+}
+
+func redisCmd(args ...string) []string {
+	return append([]string{"redis-cli", "--no-auth-warning", "-a", "pw"}, args...)
+}
+
+func TestCacheServiceFailoverCacheReplacesDeadPrimary(t *testing.T) {
+	m, svc, ctx := setupCacheReplicationTest()
+	cache, primary, r1, r2 := replicatedCache(ctx)
+	m.repo.On("GetByID", mock.Anything, cache.ID, cache.TenantID).Return(cache, nil)
+	m.repo.On("ListNodes", mock.Anything, cache.ID).Return([]*domain.CacheNode{primary, r1, r2}, nil)
+	m.repo.On("UpdateNode", mock.Anything, mock.Anything).Return(nil)
+	m.repo.On("DeleteNode", mock.Anything, primary.ID).Return(nil).Once()
+	m.repo.On("CreateNode", mock.Anything, mock.Anything).Return(nil).Once()
+	m.repo.On("Update", mock.Anything, cache).Return(nil).Once()
+
+	m.compute.On("Exec", mock.Anything, "cid-r1", redisCmd("INFO", "replication")).Return("# Replication\r\nrole:slave\r\nslave_repl_offset:1200\r\n", nil)
+	m.compute.On("Exec", mock.Anything, "cid-r2", redisCmd("INFO", "replication")).Return("# Replication\r\nrole:slave\r\nslave_repl_offset:1500\r\n", nil)
+	m.compute.On("Exec", mock.Anything, "cid-r2", redisCmd("REPLICAOF", "NO", "ONE")).Return("OK", nil).Once()
+	m.compute.On("Exec", mock.Anything, "cid-p", redisCmd("PING")).Return("", errors.New(errors.Internal, "container not running"))
+	m.compute.On("Exec", mock.Anything, "cid-r1", redisCmd("REPLICAOF", "10.0.0.4", "6379")).Return("OK", nil).Once()
+	m.compute.On("GetInstanceIP", mock.Anything, "cid-r2").Return("10.0.0.4", nil)
+	m.compute.On("GetInstanceIP", mock.Anything, "cid-r1").Return("10.0.0.3", nil)
+	m.compute.On("GetInstanceIP", mock.Anything, "cid-new").Return("10.0.0.5", nil)
+	for _, id := range []string{"cid-p", "cid-proxy"} {
+		m.compute.On("StopInstance", mock.Anything, id).Return(nil).Once()
+		m.compute.On("DeleteInstance", mock.Anything, id).Return(nil).Once()
+	}
+	m.compute.On("LaunchInstanceWithOptions", mock.Anything, mock.MatchedBy(func(opts ports.CreateInstanceOptions) bool {
+		return cmdContains(opts, "--replicaof 10.0.0.4 6379")
+	})).Return("cid-new", nil, nil).Once()
+	m.compute.On("LaunchInstanceWithOptions", mock.Anything, mock.MatchedBy(func(opts ports.CreateInstanceOptions) bool {
+		// The proxy comes back on the same host ports, without the dead node.
+		cfg := strings.Join(opts.Env, "")
+		return strings.HasPrefix(opts.ImageName, "haproxy:") &&
+			assert.ObjectsAreEqual([]string{"30001:6379", "30002:6380"}, opts.Ports) &&
+			strings.Contains(cfg, "10.0.0.5:6379") && !strings.Contains(cfg, "10.0.0.2")
+	})).Return("cid-proxy-2", []string{"30001:6379", "30002:6380"}, nil).Once()
+
+	require.NoError(t, svc.FailoverCache(ctx, cache.ID.String()))
+	m.compute.AssertExpectations(t)
+	m.repo.AssertExpectations(t)
+	assert.Equal(t, domain.CacheNodePrimary, r2.Role)
+	assert.Equal(t, "cid-r2", cache.ContainerID)
+	assert.Equal(t, "cid-proxy-2", cache.ProxyContainerID)
+	assert.Equal(t, 30001, cache.Port)
+	require.Len(t, cache.Nodes, 3)
+	m.events.AssertCalled(t, "RecordEvent", mock.Anything, "CACHE_FAILOVER", cache.ID.String(), "CACHE", mock.Anything)
+}
+
+func TestCacheServiceFailoverCacheDemotesHealthyPrimary(t *testing.T) {
+	m, svc, ctx := setupCacheReplicationTest()
+	cache, primary, r1, r2 := replicatedCache(ctx)
+	m.repo.On("GetByID", mock.Anything, cache.ID, cache.TenantID).Return(cache, nil)
+	m.repo.On("ListNodes", mock.Anything, cache.ID).Return([]*domain.CacheNode{primary, r1, r2}, nil)
+	m.repo.On("UpdateNode", mock.Anything, mock.Anything).Return(nil)
+	m.repo.On("Update", mock.Anything, cache).Return(nil).Once()
+
+	m.compute.On("Exec", mock.Anything, "cid-r1", redisCmd("INFO", "replication")).Return("slave_repl_offset:900\n", nil)
+	m.compute.On("Exec", mock.Anything, "cid-r2", redisCmd("INFO", "replication")).Return("", errors.New(errors.Internal, "timeout"))
+	m.compute.On("Exec", mock.Anything, "cid-r1", redisCmd("REPLICAOF", "NO", "ONE")).Return("OK", nil).Once()
+	m.compute.On("GetInstanceIP", mock.Anything, "cid-r1").Return("10.0.0.3", nil)
+	m.compute.On("Exec", mock.Anything, "cid-p", redisCmd("PING")).Return("PONG", nil)
+	m.compute.On("Exec", mock.Anything, "cid-p", redisCmd("REPLICAOF", "10.0.0.3", "6379")).Return("OK", nil).Once()
+	m.compute.On("Exec", mock.Anything, "cid-r2", redisCmd("REPLICAOF", "10.0.0.3", "6379")).Return("OK", nil).Once()
+
+	require.NoError(t, svc.FailoverCache(ctx, cache.ID.String()))
+	m.compute.AssertExpectations(t)
+	assert.Equal(t, domain.CacheNodePrimary, r1.Role)
+	assert.Equal(t, domain.CacheNodeReplica, primary.Role)
+	assert.Equal(t, "cid-r1", cache.ContainerID)
+	// No node was replaced, so the proxy keeps running.
+	assert.Equal(t, "cid-proxy", cache.ProxyContainerID)
+	m.compute.AssertNotCalled(t, "LaunchInstanceWithOptions", mock.Anything, mock.Anything)
+}
+
+func TestCacheServiceFailoverCacheWithoutReplicas(t *testing.T) {
+	m, svc, ctx := setupCacheReplicationTest()
+	cache, primary, _, _ := replicatedCache(ctx)
+	m.repo.On("GetByID", mock.Anything, cache.ID, cache.TenantID).Return(cache, nil)
+	m.repo.On("ListNodes", mock.Anything, cache.ID).Return([]*domain.CacheNode{primary}, nil)
+
+	err := svc.FailoverCache(ctx, cache.ID.String())
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.InvalidInput))
+}
@@ -52,7 +52,7 @@ func setupCacheServiceTest(t *testing.T) (*services.CacheService, ports.CacheRep
 
 	logger := slog.Default()
 
-	svc := services.NewCacheService(repo, rbacSvc, compute, vpcRepo, eventSvc, auditSvc, logger)
+	svc := services.NewCacheService(services.CacheServiceParams{Repo: repo, RBAC: rbacSvc, Compute: compute, VpcRepo: vpcRepo, EventSvc: eventSvc, AuditSvc: auditSvc, Logger: logger})
 
 	return svc, repo, compute, vpcRepo, ctx
 }
@@ -61,7 +61,7 @@ func TestCreateCacheSuccess(t *testing.T) {
 	svc, repo, compute, _, ctx := setupCacheServiceTest(t)
 	name := "test-cache-success"
 
-	cache, err := svc.CreateCache(ctx, name, "7.2", 128, nil)
+	cache, err := svc.CreateCache(ctx, ports.CreateCacheRequest{Name: name, Version: "7.2", MemoryMB: 128})
 
 	require.NoError(t, err)
 	assert.NotNil(t, cache)
@@ -112,7 +112,7 @@ func TestCreateCacheWithVpc(t *testing.T) {
 	require.NoError(t, err)
 
 	name := "test-cache-vpc"
-	cache, err := svc.CreateCache(ctx, name, "7.2", 128, &vpcID)
+	cache, err := svc.CreateCache(ctx, ports.CreateCacheRequest{Name: name, Version: "7.2", MemoryMB: 128, VpcID: &vpcID})
 	require.NoError(t, err)
 	assert.Equal(t, &vpcID, cache.VpcID)
 
@@ -125,7 +125,7 @@ func TestDeleteCacheSuccess(t *testing.T) {
 
 	// Setup: Create a cache first
 	name := "test-cache-delete"
-	cache, err := svc.CreateCache(ctx, name, "7.2", 128, nil)
+	cache, err := svc.CreateCache(ctx, ports.CreateCacheRequest{Name: name, Version: "7.2", MemoryMB: 128})
 	require.NoError(t, err)
 
 	// Execute
@@ -11,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	appcontext "github.com/poyrazk/thecloud/internal/core/context"
 	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
 	"github.com/poyrazk/thecloud/internal/core/services"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -54,6 +55,31 @@ func (m *MockCacheRepository) Update(ctx context.Context, cache *domain.Cache) e
 func (m *MockCacheRepository) Delete(ctx context.Context, id, tenantID uuid.UUID) error {
 	return m.Called(ctx, id, tenantID).Error(0)
 }
+func (m *MockCacheRepository) ListAll(ctx context.Context) ([]*domain.Cache, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	r0, _ := args.Get(0).([]*domain.Cache)
+	return r0, args.Error(1)
+}
+func (m *MockCacheRepository) CreateNode(ctx context.Context, node *domain.CacheNode) error {
+	return m.Called(ctx, node).Error(0)
+}
+func (m *MockCacheRepository) ListNodes(ctx context.Context, cacheID uuid.UUID) ([]*domain.CacheNode, error) {
+	args := m.Called(ctx, cacheID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	r0, _ := args.Get(0).([]*domain.CacheNode)
+	return r0, args.Error(1)
+}
+func (m *MockCacheRepository) UpdateNode(ctx context.Context, node *domain.CacheNode) error {
+	return m.Called(ctx, node).Error(0)
+}
+func (m *MockCacheRepository) DeleteNode(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
 
 func TestCacheService_Unit(t *testing.T) {
 	t.Run("Extended", testCacheServiceUnitExtended)
@@ -67,7 +93,7 @@ func testCacheServiceUnitExtended(t *testing.T) {
 	rbacSvc := new(MockRBACService)
 	rbacSvc.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
-	svc := services.NewCacheService(repo, rbacSvc, compute, nil, eventSvc, auditSvc, slog.Default())
+	svc := services.NewCacheService(services.CacheServiceParams{Repo: repo, RBAC: rbacSvc, Compute: compute, EventSvc: eventSvc, AuditSvc: auditSvc, Logger: slog.Default()})
 	ctx := context.Background()
 	userID := uuid.New()
 	tenantID := uuid.New()
@@ -78,14 +104,19 @@ func testCacheServiceUnitExtended(t *testing.T) {
 		compute.On("Type").Return("docker").Maybe()
 		repo.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
 		compute.On("LaunchInstanceWithOptions", mock.Anything, mock.Anything).Return("cid", []string{"30001:6379"}, nil).Once()
+		repo.On("CreateNode", mock.Anything, mock.Anything).Return(nil).Once()
 		repo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
 		eventSvc.On("RecordEvent", mock.Anything, "CACHE_CREATE", mock.Anything, "CACHE", mock.Anything).Return(nil).Once()
 		auditSvc.On("Log", mock.Anything, userID, "cache.create", "cache", mock.Anything, mock.Anything).Return(nil).Once()
 
-		cache, err := svc.CreateCache(ctx, "my-cache", "7.0", 128, nil)
+		cache, err := svc.CreateCache(ctx, ports.CreateCacheRequest{Name: "my-cache", Version: "7.0", MemoryMB: 128})
 		require.NoError(t, err)
 		assert.NotNil(t, cache)
 		assert.Equal(t, 30001, cache.Port)
+		assert.Equal(t, "cid", cache.ContainerID)
+		assert.Equal(t, domain.CachePersistenceNone, cache.Persistence)
+		require.Len(t, cache.Nodes, 1)
+		assert.Equal(t, domain.CacheNodePrimary, cache.Nodes[0].Role)
 	})
 
 	t.Run("CreateCache_LaunchFailure", func(t *testing.T) {
@@ -94,7 +125,7 @@ func testCacheServiceUnitExtended(t *testing.T) {
 		compute.On("LaunchInstanceWithOptions", mock.Anything, mock.Anything).Return("", nil, fmt.Errorf("launch fail")).Once()
 		repo.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 
-		_, err := svc.CreateCache(ctx, "fail", "7.0", 128, nil)
+		_, err := svc.CreateCache(ctx, ports.CreateCacheRequest{Name: "fail", Version: "7.0", MemoryMB: 128})
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "launch cache container")
 	})
@@ -102,9 +133,11 @@ func testCacheServiceUnitExtended(t *testing.T) {
 	t.Run("GetCache", func(t *testing.T) {
 		cacheID := uuid.New()
 		repo.On("GetByID", mock.Anything, cacheID, mock.Anything).Return(&domain.Cache{ID: cacheID}, nil).Once()
+		repo.On("ListNodes", mock.Anything, cacheID).Return([]*domain.CacheNode{{CacheID: cacheID, Role: domain.CacheNodePrimary}}, nil).Once()
 		res, err := svc.GetCache(ctx, cacheID.String())
 		require.NoError(t, err)
 		assert.NotNil(t, res)
+		assert.NotNil(t, res.PrimaryNode())
 	})
 
 	t.Run("ListCaches", func(t *testing.T) {
@@ -118,6 +151,7 @@ func testCacheServiceUnitExtended(t *testing.T) {
 		cacheID := uuid.New()
 		cache := &domain.Cache{ID: cacheID, UserID: userID, TenantID: tenantID, Name: "my-cache", ContainerID: "cid"}
 		repo.On("GetByID", mock.Anything, cacheID, mock.Anything).Return(cache, nil).Once()
+		repo.On("ListNodes", mock.Anything, cacheID).Return([]*domain.CacheNode{}, nil).Once()
 		compute.On("StopInstance", mock.Anything, "cid").Return(nil).Once()
 		compute.On("DeleteInstance", mock.Anything, "cid").Return(nil).Once()
 		repo.On("Delete", mock.Anything, cacheID, mock.Anything).Return(nil).Once()
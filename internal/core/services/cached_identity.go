@@ -130,6 +130,10 @@ func (s *cachedIdentityService) ValidateClientCredentials(ctx context.Context, c
 	return s.base.ValidateClientCredentials(ctx, clientID, clientSecret)
 }
 
+func (s *cachedIdentityService) IssueServiceAccountToken(ctx context.Context, saID uuid.UUID) (string, error) {
+	return s.base.IssueServiceAccountToken(ctx, saID)
+}
+
 func (s *cachedIdentityService) ValidateAccessToken(ctx context.Context, token string) (*domain.ServiceAccountClaims, error) {
 	return s.base.ValidateAccessToken(ctx, token)
 }
@@ -24,6 +24,7 @@ type ClusterService struct {
 	instanceSvc ports.InstanceService
 	secretSvc   ports.SecretService
 	taskQueue   ports.TaskQueue
+	tenantSvc   ports.TenantService
 	logger      *slog.Logger
 }
 
@@ -36,6 +37,7 @@ type ClusterServiceParams struct {
 	InstanceSvc ports.InstanceService
 	SecretSvc   ports.SecretService
 	TaskQueue   ports.TaskQueue
+	TenantSvc   ports.TenantService // Optional, enforces the clusters quota
 	Logger      *slog.Logger
 }
 
@@ -52,6 +54,7 @@ func NewClusterService(params ClusterServiceParams) (*ClusterService, error) {
 		instanceSvc: params.InstanceSvc,
 		secretSvc:   params.SecretSvc,
 		taskQueue:   params.TaskQueue,
+		tenantSvc:   params.TenantSvc,
 		logger:      params.Logger,
 	}, nil
 }
@@ -71,6 +74,9 @@ func (s *ClusterService) CreateCluster(ctx context.Context, params ports.CreateC
 	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionClusterCreate, "*"); err != nil {
 		return nil, err
 	}
+	if err := checkQuota(ctx, s.tenantSvc, domain.QuotaClusters, 1); err != nil {
+		return nil, err
+	}
 
 	// 1. Verify VPC exists and belongs to user
 	vpc, err := s.vpcSvc.GetVPC(ctx, params.VpcID.String())
@@ -134,6 +140,7 @@ func (s *ClusterService) CreateCluster(ctx context.Context, params ports.CreateC
 		MinSize:      1,
 		MaxSize:      10,
 		CurrentSize:  params.Workers,
+		CapacityType: domain.CapacityOnDemand,
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
 	}
@@ -143,6 +150,7 @@ func (s *ClusterService) CreateCluster(ctx context.Context, params ports.CreateC
 	if err := s.repo.Create(ctx, cluster); err != nil {
 		return nil, errors.Wrap(errors.Internal, "failed to create cluster record", err)
 	}
+	recordUsage(ctx, s.tenantSvc, s.logger, tenantID, domain.QuotaClusters, 1)
 
 	// Persist Node Group
 	if err := s.repo.AddNodeGroup(ctx, &cluster.NodeGroups[0]); err != nil {
@@ -220,6 +228,7 @@ func (s *ClusterService) DeleteCluster(ctx context.Context, id uuid.UUID) error
 	if err := s.repo.Update(ctx, cluster); err != nil {
 		return errors.Wrap(errors.Internal, "failed to update cluster status", err)
 	}
+	recordUsage(ctx, s.tenantSvc, s.logger, tenantID, domain.QuotaClusters, -1)
 
 	// Enqueue deprovision job
 	job := domain.ClusterJob{
@@ -548,17 +557,29 @@ func (s *ClusterService) AddNodeGroup(ctx context.Context, clusterID uuid.UUID,
 	if err := s.validateNodeGroupSizing(params.MinSize, params.MaxSize, params.DesiredSize); err != nil {
 		return nil, err
 	}
+	if err := validateNodeGroupAutoscaling(params.ScaleDownUtilizationThreshold, params.ScaleDownUnneededSeconds); err != nil {
+		return nil, err
+	}
+	capacityType, err := validateNodeGroupScheduling(params)
+	if err != nil {
+		return nil, err
+	}
 
 	ng := &domain.NodeGroup{
-		ID:           uuid.New(),
-		ClusterID:    cluster.ID,
-		Name:         params.Name,
-		InstanceType: params.InstanceType,
-		MinSize:      params.MinSize,
-		MaxSize:      params.MaxSize,
-		CurrentSize:  params.DesiredSize,
-		CreatedAt:    time.Now(),
-		UpdatedAt:    time.Now(),
+		ID:                            uuid.New(),
+		ClusterID:                     cluster.ID,
+		Name:                          params.Name,
+		InstanceType:                  params.InstanceType,
+		MinSize:                       params.MinSize,
+		MaxSize:                       params.MaxSize,
+		CurrentSize:                   params.DesiredSize,
+		ScaleDownUtilizationThreshold: params.ScaleDownUtilizationThreshold,
+		ScaleDownUnneededSeconds:      params.ScaleDownUnneededSeconds,
+		Labels:                        params.Labels,
+		Taints:                        params.Taints,
+		CapacityType:                  capacityType,
+		CreatedAt:                     time.Now(),
+		UpdatedAt:                     time.Now(),
 	}
 
 	if err := s.repo.AddNodeGroup(ctx, ng); err != nil {
@@ -614,9 +635,24 @@ func (s *ClusterService) UpdateNodeGroup(ctx context.Context, clusterID uuid.UUI
 		return nil, err
 	}
 
+	newThreshold := targetGroup.ScaleDownUtilizationThreshold
+	newUnneeded := targetGroup.ScaleDownUnneededSeconds
+	if params.ScaleDownUtilizationThreshold != nil {
+		newThreshold = *params.ScaleDownUtilizationThreshold
+	}
+	if params.ScaleDownUnneededSeconds != nil {
+		newUnneeded = *params.ScaleDownUnneededSeconds
+	}
+	if err := validateNodeGroupAutoscaling(newThreshold, newUnneeded); err != nil {
+		return nil, err
+	}
+
 	oldMin, oldMax, oldDesired := targetGroup.MinSize, targetGroup.MaxSize, targetGroup.CurrentSize
+	oldThreshold, oldUnneeded := targetGroup.ScaleDownUtilizationThreshold, targetGroup.ScaleDownUnneededSeconds
 	targetGroup.MinSize = newMin
 	targetGroup.MaxSize = newMax
+	targetGroup.ScaleDownUtilizationThreshold = newThreshold
+	targetGroup.ScaleDownUnneededSeconds = newUnneeded
 
 	oldWorkerCount := cluster.WorkerCount
 	if params.DesiredSize != nil {
@@ -634,6 +670,8 @@ func (s *ClusterService) UpdateNodeGroup(ctx context.Context, clusterID uuid.UUI
 		targetGroup.MinSize = oldMin
 		targetGroup.MaxSize = oldMax
 		targetGroup.CurrentSize = oldDesired
+		targetGroup.ScaleDownUtilizationThreshold = oldThreshold
+		targetGroup.ScaleDownUnneededSeconds = oldUnneeded
 		cluster.WorkerCount = oldWorkerCount
 		return nil, errors.Wrap(errors.Internal, "failed to update cluster worker count after updating node group", err)
 	}
@@ -715,3 +753,33 @@ func (s *ClusterService) validateNodeGroupSizing(min, max, desired int) error {
 	}
 	return nil
 }
+
+func validateNodeGroupAutoscaling(threshold float64, unneededSeconds int) error {
+	if threshold < 0 || threshold > 1 {
+		return errors.New(errors.InvalidInput, "scale_down_utilization_threshold must be between 0 and 1")
+	}
+	if unneededSeconds < 0 {
+		return errors.New(errors.InvalidInput, "scale_down_unneeded_seconds must be non-negative")
+	}
+	return nil
+}
+
+// validateNodeGroupScheduling checks the labels, taints and capacity type of
+// a new node group and returns the capacity type to use.
+func validateNodeGroupScheduling(params ports.NodeGroupParams) (domain.CapacityType, error) {
+	if err := domain.ValidateNodeLabels(params.Labels); err != nil {
+		return "", errors.Wrap(errors.InvalidInput, "invalid node group labels", err)
+	}
+	for _, t := range params.Taints {
+		if err := t.Validate(); err != nil {
+			return "", errors.Wrap(errors.InvalidInput, "invalid node group taints", err)
+		}
+	}
+	if params.CapacityType == "" {
+		return domain.CapacityOnDemand, nil
+	}
+	if !params.CapacityType.IsValid() {
+		return "", errors.New(errors.InvalidInput, "capacity_type must be on-demand or preemptible")
+	}
+	return params.CapacityType, nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/poyrazk/thecloud/internal/core/domain"
 	"github.com/poyrazk/thecloud/internal/core/ports"
 	"github.com/poyrazk/thecloud/internal/core/services"
+	"github.com/poyrazk/thecloud/internal/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -442,6 +443,109 @@ func TestClusterService_Unit(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("UpdateNodeGroup_AutoscalingOptions", func(t *testing.T) {
+		svc, mockRepo, _, _, _, _, _, _ := newTestClusterSvc()
+
+		clusterID := uuid.New()
+		cluster := &domain.Cluster{
+			ID:       clusterID,
+			UserID:   userID,
+			TenantID: tenantID,
+			NodeGroups: []domain.NodeGroup{
+				{Name: "existing-pool", MinSize: 0, MaxSize: 3, CurrentSize: 0},
+			},
+		}
+		threshold, unneeded := 0.35, 300
+		mockRepo.On("GetByID", mock.Anything, clusterID).Return(cluster, nil).Once()
+		mockRepo.On("UpdateNodeGroup", mock.Anything, mock.MatchedBy(func(ng *domain.NodeGroup) bool {
+			return ng.ScaleDownUtilizationThreshold == 0.35 && ng.ScaleDownUnneededSeconds == 300
+		})).Return(nil).Once()
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+
+		ng, err := svc.UpdateNodeGroup(ctx, clusterID, "existing-pool", ports.UpdateNodeGroupParams{
+			ScaleDownUtilizationThreshold: &threshold,
+			ScaleDownUnneededSeconds:      &unneeded,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 300, ng.ScaleDownUnneededSeconds)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("AddNodeGroup_InvalidAutoscalingOptions", func(t *testing.T) {
+		svc, mockRepo, _, _, _, _, _, _ := newTestClusterSvc()
+
+		clusterID := uuid.New()
+		cluster := &domain.Cluster{ID: clusterID, UserID: userID, TenantID: tenantID}
+		mockRepo.On("GetByID", mock.Anything, clusterID).Return(cluster, nil).Once()
+
+		_, err := svc.AddNodeGroup(ctx, clusterID, ports.NodeGroupParams{
+			Name: "pool", MinSize: 0, MaxSize: 2, ScaleDownUtilizationThreshold: 1.5,
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "scale_down_utilization_threshold")
+		mockRepo.AssertNotCalled(t, "AddNodeGroup", mock.Anything, mock.Anything)
+	})
+
+	t.Run("AddNodeGroup_LabelsTaintsAndCapacity", func(t *testing.T) {
+		svc, mockRepo, _, _, _, _, _, _ := newTestClusterSvc()
+
+		clusterID := uuid.New()
+		cluster := &domain.Cluster{ID: clusterID, UserID: userID, TenantID: tenantID}
+		mockRepo.On("GetByID", mock.Anything, clusterID).Return(cluster, nil).Once()
+		mockRepo.On("AddNodeGroup", mock.Anything, mock.MatchedBy(func(ng *domain.NodeGroup) bool {
+			return ng.CapacityType == domain.CapacityPreemptible && ng.Labels["workload"] == "batch" && len(ng.Taints) == 1
+		})).Return(nil).Once()
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+
+		ng, err := svc.AddNodeGroup(ctx, clusterID, ports.NodeGroupParams{
+			Name: "spot", MinSize: 0, MaxSize: 4,
+			Labels:       map[string]string{"workload": "batch"},
+			Taints:       []domain.NodeTaint{{Key: "spot", Value: "true", Effect: domain.TaintEffectNoSchedule}},
+			CapacityType: domain.CapacityPreemptible,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "spot=true:NoSchedule", ng.Taints[0].String())
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("AddNodeGroup_DefaultsToOnDemand", func(t *testing.T) {
+		svc, mockRepo, _, _, _, _, _, _ := newTestClusterSvc()
+
+		clusterID := uuid.New()
+		cluster := &domain.Cluster{ID: clusterID, UserID: userID, TenantID: tenantID}
+		mockRepo.On("GetByID", mock.Anything, clusterID).Return(cluster, nil).Once()
+		mockRepo.On("AddNodeGroup", mock.Anything, mock.Anything).Return(nil).Once()
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+
+		ng, err := svc.AddNodeGroup(ctx, clusterID, ports.NodeGroupParams{Name: "pool", MinSize: 0, MaxSize: 2})
+		require.NoError(t, err)
+		assert.Equal(t, domain.CapacityOnDemand, ng.CapacityType)
+	})
+
+	t.Run("AddNodeGroup_InvalidScheduling", func(t *testing.T) {
+		for name, params := range map[string]ports.NodeGroupParams{
+			"reserved label":  {Labels: map[string]string{"kubernetes.io/role": "x"}},
+			"platform label":  {Labels: map[string]string{"thecloud.io/node-group": "x"}},
+			"bad label value": {Labels: map[string]string{"team": "a b"}},
+			"bad taint":       {Taints: []domain.NodeTaint{{Key: "spot", Effect: "Evict"}}},
+			"capacity type":   {CapacityType: "reserved"},
+		} {
+			t.Run(name, func(t *testing.T) {
+				svc, mockRepo, _, _, _, _, _, _ := newTestClusterSvc()
+
+				clusterID := uuid.New()
+				cluster := &domain.Cluster{ID: clusterID, UserID: userID, TenantID: tenantID}
+				mockRepo.On("GetByID", mock.Anything, clusterID).Return(cluster, nil).Once()
+
+				params.Name, params.MaxSize = "pool", 2
+				_, err := svc.AddNodeGroup(ctx, clusterID, params)
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, errors.InvalidInput))
+				mockRepo.AssertNotCalled(t, "AddNodeGroup", mock.Anything, mock.Anything)
+			})
+		}
+	})
+
 	t.Run("UpdateNodeGroup_NotFound", func(t *testing.T) {
 		svc, mockRepo, _, _, _, _, _, _ := newTestClusterSvc()
 
@@ -166,6 +166,15 @@ func (m *mockVpcRepo) List(ctx context.Context) ([]*domain.VPC, error) {
 	r0, _ := args.Get(0).([]*domain.VPC)
 	return r0, args.Error(1)
 }
+
+func (m *mockVpcRepo) ListAll(ctx context.Context) ([]*domain.VPC, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	r0, _ := args.Get(0).([]*domain.VPC)
+	return r0, args.Error(1)
+}
 func (m *mockVpcRepo) GetByIdempotencyKey(ctx context.Context, key string) (*domain.VPC, error) {
 	args := m.Called(ctx, key)
 	if args.Get(0) == nil {
@@ -214,6 +223,11 @@ func (m *mockRBACService) HasPermission(ctx context.Context, userID, tenantID uu
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *mockRBACService) ResourcePolicyOwner(ctx context.Context, userID, tenantID uuid.UUID, permission domain.Permission, resource string) (uuid.UUID, bool) {
+	args := m.Called(ctx, userID, tenantID, permission, resource)
+	return args.Get(0).(uuid.UUID), args.Bool(1)
+}
+
 func (m *mockRBACService) ListRoles(ctx context.Context) ([]*domain.Role, error) {
 	args := m.Called(ctx)
 	if args.Get(0) == nil {
@@ -56,6 +56,15 @@ type DatabaseService struct {
 	auditSvc         ports.AuditService
 	secrets          ports.SecretsManager
 	volumeEncryption ports.VolumeEncryptionService
+	tenantSvc        ports.TenantService
+	storageSvc       ports.StorageService
+	backupRepo       ports.DatabaseBackupRepository
+	policyRepo       ports.DatabaseBackupPolicyRepository
+	automatedRepo    ports.DatabaseAutomatedBackupRepository
+	upgradeRepo      ports.DatabaseUpgradeRepository
+	userRepo         ports.DatabaseUserRepository
+	insightRepo      ports.DatabaseInsightRepository
+	kms              ports.KMSClient
 	logger           *slog.Logger
 	vaultMountPath   string
 	// In-memory idempotency cache for rotation. Stores timestamp of last rotation attempt.
@@ -94,6 +103,15 @@ type DatabaseServiceParams struct {
 	AuditSvc         ports.AuditService
 	Secrets          ports.SecretsManager
 	VolumeEncryption ports.VolumeEncryptionService
+	TenantSvc        ports.TenantService                     // Optional, enforces the databases quota
+	StorageSvc       ports.StorageService                    // Optional, required for point-in-time recovery and scheduled backups
+	BackupRepo       ports.DatabaseBackupRepository          // Optional, required for point-in-time recovery
+	PolicyRepo       ports.DatabaseBackupPolicyRepository    // Optional, required for scheduled backups
+	AutomatedRepo    ports.DatabaseAutomatedBackupRepository // Optional, required for scheduled backups
+	UpgradeRepo      ports.DatabaseUpgradeRepository         // Optional, records version upgrade history
+	UserRepo         ports.DatabaseUserRepository            // Optional, required for additional users and logical databases
+	InsightRepo      ports.DatabaseInsightRepository         // Optional, required for performance insights
+	KMS              ports.KMSClient                         // Optional, encrypts exports of databases with a KmsKeyID
 	Logger           *slog.Logger
 	VaultMountPath   string
 }
@@ -112,6 +130,15 @@ func NewDatabaseService(params DatabaseServiceParams) *DatabaseService {
 		auditSvc:         params.AuditSvc,
 		secrets:          params.Secrets,
 		volumeEncryption: params.VolumeEncryption,
+		tenantSvc:        params.TenantSvc,
+		storageSvc:       params.StorageSvc,
+		backupRepo:       params.BackupRepo,
+		policyRepo:       params.PolicyRepo,
+		automatedRepo:    params.AutomatedRepo,
+		upgradeRepo:      params.UpgradeRepo,
+		userRepo:         params.UserRepo,
+		insightRepo:      params.InsightRepo,
+		kms:              params.KMS,
 		logger:           params.Logger,
 		vaultMountPath:   params.VaultMountPath,
 		rotationCache:    make(map[string]time.Time),
@@ -146,6 +173,12 @@ func (s *DatabaseService) CreateDatabase(ctx context.Context, req ports.CreateDa
 	if err := s.validateCreationRequest(req, dbEngine); err != nil {
 		return nil, err
 	}
+	if err := s.validateBackupSettings(ctx, req.BackupRetentionDays, req.BackupBucket); err != nil {
+		return nil, err
+	}
+	if err := checkQuota(ctx, s.tenantSvc, domain.QuotaDatabases, 1); err != nil {
+		return nil, err
+	}
 
 	password, err := util.GenerateRandomPassword(16)
 	if err != nil {
@@ -164,6 +197,8 @@ func (s *DatabaseService) CreateDatabase(ctx context.Context, req ports.CreateDa
 		db.KmsKeyID = req.KmsKeyID
 		db.EncryptedVolume = true
 	}
+	db.BackupRetentionDays = req.BackupRetentionDays
+	db.BackupBucket = req.BackupBucket
 
 	return s.provisionDatabase(ctx, db, password, req.Parameters, "", "DATABASE_CREATE")
 }
@@ -188,6 +223,9 @@ func (s *DatabaseService) CreateReplica(ctx context.Context, primaryID uuid.UUID
 	if primary.TenantID != tenantID {
 		return nil, errors.New(errors.NotFound, "database not found")
 	}
+	if err := checkQuota(ctx, s.tenantSvc, domain.QuotaDatabases, 1); err != nil {
+		return nil, err
+	}
 
 	primaryIP, err := s.compute.GetInstanceIP(ctx, primary.ContainerID)
 	if err != nil {
@@ -231,11 +269,25 @@ func (s *DatabaseService) RestoreDatabase(ctx context.Context, req ports.Restore
 		span.RecordError(err)
 		return nil, err
 	}
+	if err := checkQuota(ctx, s.tenantSvc, domain.QuotaDatabases, 1); err != nil {
+		return nil, err
+	}
 
 	if s.compute.Type() == "libvirt" {
 		return nil, errors.New(errors.InvalidInput, "database restore requires docker compute backend (COMPUTE_BACKEND=libvirt is not supported)")
 	}
 
+	var plan *pointInTimePlan
+	if req.RestoreTime != nil {
+		var err error
+		if plan, err = s.planPointInTimeRestore(ctx, &req); err != nil {
+			return nil, err
+		}
+		req.SnapshotID = plan.snapshotID
+	} else if req.SnapshotID == uuid.Nil {
+		return nil, errors.New(errors.InvalidInput, "either a snapshot or a restore time is required")
+	}
+
 	snap, err := s.snapshotSvc.GetSnapshot(ctx, req.SnapshotID)
 	if err != nil {
 		return nil, err
@@ -245,6 +297,10 @@ func (s *DatabaseService) RestoreDatabase(ctx context.Context, req ports.Restore
 	if err != nil {
 		return nil, errors.Wrap(errors.Internal, "failed to generate password for restore", err)
 	}
+	if plan != nil {
+		// The restored data directory carries the source's credentials.
+		password = plan.password
+	}
 	username := s.getDefaultUsername(dbEngine)
 	db := s.initialDatabaseRecord(userID, req.NewName, dbEngine, req.Version, username, password, req.VpcID)
 	db.TenantID = tenantID
@@ -255,6 +311,10 @@ func (s *DatabaseService) RestoreDatabase(ctx context.Context, req ports.Restore
 	}
 	db.MetricsEnabled = req.MetricsEnabled
 	db.PoolingEnabled = req.PoolingEnabled
+	if plan != nil {
+		db.BackupRetentionDays = plan.source.BackupRetentionDays
+		db.BackupBucket = plan.source.BackupBucket
+	}
 
 	// Note: We store credentials in Vault BEFORE restoring the snapshot to ensure
 	// secret availability during potential multi-step provisioning. If restore fails,
@@ -273,7 +333,24 @@ func (s *DatabaseService) RestoreDatabase(ctx context.Context, req ports.Restore
 		return nil, err
 	}
 
-	return s.finalizeProvisioning(ctx, db, vol, password, req.Parameters, "", "DATABASE_RESTORE")
+	if plan == nil {
+		return s.finalizeProvisioning(ctx, db, vol, password, req.Parameters, "", "DATABASE_RESTORE", nil)
+	}
+
+	parameters := req.Parameters
+	if db.Engine == domain.EnginePostgres {
+		// PostgreSQL replays WAL while starting, so the logs must be on the
+		// volume before the database container is launched.
+		if err := s.stagePostgresRecovery(ctx, db, vol, plan); err != nil {
+			return s.performProvisioningRollback(ctx, db, vol.ID.String(), err)
+		}
+		parameters = mergeParameters(parameters, postgresRecoveryParameters(*req.RestoreTime))
+		return s.finalizeProvisioning(ctx, db, vol, password, parameters, "", "DATABASE_RESTORE", nil)
+	}
+	// MySQL replays binary logs through a running server.
+	return s.finalizeProvisioning(ctx, db, vol, password, parameters, "", "DATABASE_RESTORE", func(ctx context.Context, db *domain.Database) error {
+		return s.replayMySQLBinlogs(ctx, db, plan, *req.RestoreTime)
+	})
 }
 
 func (s *DatabaseService) provisionDatabase(ctx context.Context, db *domain.Database, password string, parameters map[string]string, primaryIP string, action string) (*domain.Database, error) {
@@ -320,39 +397,30 @@ func (s *DatabaseService) provisionDatabase(ctx context.Context, db *domain.Data
 		db.VolumeKeyRef = fmt.Sprintf("vol-key-%s", vol.ID.String()[:8])
 	}
 
-	return s.finalizeProvisioning(ctx, db, vol, password, parameters, primaryIP, action)
+	return s.finalizeProvisioning(ctx, db, vol, password, parameters, primaryIP, action, nil)
 }
 
-func (s *DatabaseService) finalizeProvisioning(ctx context.Context, db *domain.Database, vol *domain.Volume, password string, parameters map[string]string, primaryIP string, action string) (*domain.Database, error) {
+// finalizeProvisioning launches the database container on vol and persists the
+// record. postLaunch, when set, runs once the container is up and fails the
+// provisioning if it returns an error.
+func (s *DatabaseService) finalizeProvisioning(ctx context.Context, db *domain.Database, vol *domain.Volume, password string, parameters map[string]string, primaryIP string, action string, postLaunch func(context.Context, *domain.Database) error) (*domain.Database, error) {
 	networkID, err := s.resolveVpcNetwork(ctx, db.VpcID)
 	if err != nil {
 		return s.performProvisioningRollback(ctx, db, vol.ID.String(), err)
 	}
 
-	imageName, env, defaultPort := s.getEngineConfig(db.Engine, db.Version, db.Username, password, db.Name, db.Role, primaryIP)
-
-	containerID, allocatedPorts, err := s.compute.LaunchInstanceWithOptions(ctx, ports.CreateInstanceOptions{
-		Name:        fmt.Sprintf("cloud-db-%s-%s", db.Name, db.ID.String()[:8]),
-		ImageName:   imageName,
-		Ports:       []string{"0:" + defaultPort},
-		NetworkID:   networkID,
-		VolumeBinds: []string{fmt.Sprintf("%s:%s", s.getBackendVolName(vol), s.getMountPath(db.Engine))},
-		Env:         env,
-		Cmd:         s.buildEngineCmd(db.Engine, parameters),
-	})
-
-	if err != nil {
-		return s.performProvisioningRollback(ctx, db, vol.ID.String(), errors.Wrap(errors.Internal, "failed to launch database container", err))
+	if err := s.launchDatabaseContainer(ctx, db, vol, password, parameters, primaryIP, networkID, 0); err != nil {
+		return s.performProvisioningRollback(ctx, db, vol.ID.String(), err)
 	}
-
-	db.ContainerID = containerID
-	if err := s.resolveDatabasePort(ctx, db, allocatedPorts, defaultPort); err != nil {
-		return s.performProvisioningRollback(ctx, db, vol.ID.String(), errors.Wrap(errors.Internal, "failed to resolve database port", err))
+	if postLaunch != nil {
+		if err := postLaunch(ctx, db); err != nil {
+			return s.performProvisioningRollback(ctx, db, vol.ID.String(), err)
+		}
 	}
 	db.Status = domain.DatabaseStatusRunning
 
 	if db.MetricsEnabled || db.PoolingEnabled {
-		dbIP, err := s.compute.GetInstanceIP(ctx, containerID)
+		dbIP, err := s.compute.GetInstanceIP(ctx, db.ContainerID)
 		if err != nil {
 			return s.performProvisioningRollback(ctx, db, vol.ID.String(), errors.Wrap(errors.Internal, "failed to get database IP", err))
 		}
@@ -365,11 +433,38 @@ func (s *DatabaseService) finalizeProvisioning(ctx context.Context, db *domain.D
 	if err := s.repo.Create(ctx, db); err != nil {
 		return s.performProvisioningRollback(ctx, db, vol.ID.String(), err)
 	}
+	recordUsage(ctx, s.tenantSvc, s.logger, db.TenantID, domain.QuotaDatabases, 1)
 
 	s.recordDatabaseCreation(ctx, db.UserID, db, action)
 	return db, nil
 }
 
+// launchDatabaseContainer starts the engine container of db on vol and
+// records its container ID and host port. A zero hostPort lets the backend
+// pick one; relaunches pass the current port so the endpoint stays stable.
+func (s *DatabaseService) launchDatabaseContainer(ctx context.Context, db *domain.Database, vol *domain.Volume, password string, parameters map[string]string, primaryIP, networkID string, hostPort int) error {
+	imageName, env, defaultPort := s.getEngineConfig(db.Engine, db.Version, db.Username, password, db.Name, db.Role, primaryIP)
+
+	containerID, allocatedPorts, err := s.compute.LaunchInstanceWithOptions(ctx, ports.CreateInstanceOptions{
+		Name:        fmt.Sprintf("cloud-db-%s-%s", db.Name, db.ID.String()[:8]),
+		ImageName:   imageName,
+		Ports:       []string{fmt.Sprintf("%d:%s", hostPort, defaultPort)},
+		NetworkID:   networkID,
+		VolumeBinds: []string{fmt.Sprintf("%s:%s", s.getBackendVolName(vol), s.getMountPath(db.Engine))},
+		Env:         env,
+		Cmd:         s.buildEngineCmd(db.Engine, withInsightParameters(db, s.withArchiveParameters(db, parameters))),
+	})
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to launch database container", err)
+	}
+
+	db.ContainerID = containerID
+	if err := s.resolveDatabasePort(ctx, db, allocatedPorts, defaultPort); err != nil {
+		return errors.Wrap(errors.Internal, "failed to resolve database port", err)
+	}
+	return nil
+}
+
 func (s *DatabaseService) ModifyDatabase(ctx context.Context, req ports.ModifyDatabaseRequest) (*domain.Database, error) {
 	userID := appcontext.UserIDFromContext(ctx)
 	tenantID := appcontext.TenantIDFromContext(ctx)
@@ -381,8 +476,25 @@ func (s *DatabaseService) ModifyDatabase(ctx context.Context, req ports.ModifyDa
 		return nil, err
 	}
 
-	if req.Parameters != nil {
-		db.Parameters = req.Parameters
+	// Parameters are passed on the engine's command line, so changing them
+	// takes a restart: now, or in the next maintenance window.
+	parameters := req.Parameters
+	if parameters == nil && req.ApplyImmediately {
+		parameters = db.PendingParameters
+	}
+	applyNow := parameters != nil && req.ApplyImmediately
+	if applyNow && db.Status != domain.DatabaseStatusRunning {
+		return nil, errors.New(errors.InvalidInput, "database must be running to apply changes immediately")
+	}
+	if parameters != nil && !applyNow {
+		db.PendingParameters = parameters
+	}
+
+	if req.MaintenanceWindow != nil {
+		if _, _, err := domain.ParseMaintenanceWindow(*req.MaintenanceWindow); err != nil {
+			return nil, errors.New(errors.InvalidInput, err.Error())
+		}
+		db.MaintenanceWindow = strings.ToLower(*req.MaintenanceWindow)
 	}
 
 	if s.compute.Type() == "libvirt" && (req.MetricsEnabled != nil && *req.MetricsEnabled) {
@@ -406,6 +518,12 @@ func (s *DatabaseService) ModifyDatabase(ctx context.Context, req ports.ModifyDa
 		db.AllocatedStorage = *req.AllocatedStorage
 	}
 
+	if req.BackupRetentionDays != nil && *req.BackupRetentionDays != db.BackupRetentionDays {
+		if err := s.setBackupRetention(ctx, db, *req.BackupRetentionDays); err != nil {
+			return nil, err
+		}
+	}
+
 	networkID, _ := s.resolveVpcNetwork(ctx, db.VpcID)
 	dbIP, _ := s.compute.GetInstanceIP(ctx, db.ContainerID)
 
@@ -431,6 +549,7 @@ func (s *DatabaseService) ModifyDatabase(ctx context.Context, req ports.ModifyDa
 			}
 			db.ExporterContainerID = ""
 			db.MetricsPort = 0
+			clearInsightMetrics(db.ID)
 		}
 		db.MetricsEnabled = *req.MetricsEnabled
 	}
@@ -460,6 +579,11 @@ func (s *DatabaseService) ModifyDatabase(ctx context.Context, req ports.ModifyDa
 	_ = s.eventSvc.RecordEvent(ctx, "DATABASE_MODIFY", db.ID.String(), "DATABASE", nil)
 	_ = s.auditSvc.Log(ctx, db.UserID, "database.modify", "database", db.ID.String(), map[string]interface{}{"name": db.Name})
 
+	if applyNow {
+		if err := s.performMaintenance(ctx, db, db.Version, parameters); err != nil {
+			return nil, err
+		}
+	}
 	return db, nil
 }
 
@@ -469,7 +593,12 @@ func (s *DatabaseService) GetDatabase(ctx context.Context, id uuid.UUID) (*domai
 	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionDBRead, id.String()); err != nil {
 		return nil, err
 	}
-	return s.repo.GetByID(ctx, id)
+	db, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	s.populateRestorableWindow(ctx, db, time.Now())
+	return db, nil
 }
 
 func (s *DatabaseService) ListDatabases(ctx context.Context) ([]*domain.Database, error) {
@@ -514,6 +643,7 @@ func (s *DatabaseService) DeleteDatabase(ctx context.Context, id uuid.UUID) erro
 			s.logger.Warn("failed to delete database credentials from vault", "path", db.CredentialPath, "error", err)
 		}
 	}
+	s.deleteUserCredentials(ctx, db)
 
 	var volID uuid.UUID
 	vols, err := s.volumeSvc.ListVolumes(ctx)
@@ -541,13 +671,22 @@ func (s *DatabaseService) DeleteDatabase(ctx context.Context, id uuid.UUID) erro
 		}
 	}
 
+	// Automated backups go with the database; user snapshots are kept.
+	if db.BackupBucket != "" {
+		s.purgeBackups(ctx, db)
+	}
+	// Scheduled backup exports outlive the database; their snapshots do not.
+	s.deleteBackupSnapshots(ctx, db)
+
 	if err := s.repo.Delete(ctx, id); err != nil {
 		return err
 	}
+	recordUsage(ctx, s.tenantSvc, s.logger, db.TenantID, domain.QuotaDatabases, -1)
 
 	_ = s.eventSvc.RecordEvent(ctx, "DATABASE_DELETE", id.String(), "DATABASE", nil)
 	_ = s.auditSvc.Log(ctx, db.UserID, "database.delete", "database", db.ID.String(), map[string]interface{}{"name": db.Name})
 	platform.RDSInstancesTotal.WithLabelValues(string(db.Engine), "running").Dec()
+	clearInsightMetrics(db.ID)
 
 	return nil
 }
@@ -578,7 +717,7 @@ func (s *DatabaseService) PromoteToPrimary(ctx context.Context, id uuid.UUID) er
 		// Create promotion trigger file - PostgreSQL monitors this file
 		// and exits recovery mode when it appears
 		cmd = []string{"touch", "/var/lib/postgresql/data/promote"}
-	case domain.EngineMySQL:
+	case domain.EngineMySQL, domain.EngineMariaDB:
 		// Fetch current password from Vault (db.Password may be stale after rotation)
 		password := db.Password
 		if db.CredentialPath != "" {
@@ -590,7 +729,7 @@ func (s *DatabaseService) PromoteToPrimary(ctx context.Context, id uuid.UUID) er
 			}
 		}
 		// Use MYSQL_PWD env var to avoid password appearing in process list
-		cmd = []string{"sh", "-c", fmt.Sprintf("MYSQL_PWD='%s' mysql -u root --execute='STOP REPLICA; RESET REPLICA ALL;'", sqlStringLiteral(password))}
+		cmd = []string{"sh", "-c", fmt.Sprintf("MYSQL_PWD='%s' %s -u root --execute='STOP REPLICA; RESET REPLICA ALL;'", sqlStringLiteral(password), mysqlTool(db.Engine, "mysql"))}
 	default:
 		return errors.New(errors.Internal, "unsupported engine for promotion")
 	}
@@ -638,7 +777,7 @@ func (s *DatabaseService) GetConnectionString(ctx context.Context, id uuid.UUID)
 	switch db.Engine {
 	case domain.EnginePostgres:
 		return fmt.Sprintf("postgres://%s:%s@127.0.0.1:%d/%s", db.Username, password, port, db.Name), nil
-	case domain.EngineMySQL:
+	case domain.EngineMySQL, domain.EngineMariaDB:
 		return fmt.Sprintf("%s:%s@tcp(127.0.0.1:%d)/%s", db.Username, password, port, db.Name), nil
 	default:
 		return "", errors.New(errors.Internal, "unknown engine")
@@ -1006,6 +1145,22 @@ func sqlStringLiteral(s string) string {
 	return strings.ReplaceAll(s, "'", "''")
 }
 
+// mysqlTool returns the name of a MySQL server or client binary in the image
+// of engine. Recent MariaDB images only ship the tools under their MariaDB
+// names.
+func mysqlTool(engine domain.DatabaseEngine, name string) string {
+	if engine != domain.EngineMariaDB {
+		return name
+	}
+	switch name {
+	case "mysqld":
+		return "mariadbd"
+	case "mysql":
+		return "mariadb"
+	}
+	return strings.Replace(name, "mysql", "mariadb-", 1)
+}
+
 // postgresIdentifier escapes a PostgreSQL identifier
 func postgresIdentifier(id string) string {
 	// PostgreSQL uses double quotes for identifiers
@@ -1022,11 +1177,11 @@ func (s *DatabaseService) buildPasswordChangeCmd(engine domain.DatabaseEngine, u
 		// authPassword (current password) authenticates the connection; targetPassword is the new password being set.
 		stmt := fmt.Sprintf("ALTER USER %s WITH PASSWORD '%s';", postgresIdentifier(username), sqlStringLiteral(targetPassword))
 		return []string{"sh", "-c", "PGPASSWORD='" + sqlStringLiteral(authPassword) + "' psql -h 127.0.0.1 -U " + username + " -d postgres -c '" + stmt + "'"}
-	case domain.EngineMySQL:
+	case domain.EngineMySQL, domain.EngineMariaDB:
 		// Use mysql with password via MYSQL_PWD env var to avoid cmdline exposure.
 		// Same tradeoff as above - password visible in /proc environment to root users.
 		stmt := fmt.Sprintf("ALTER USER '%s'@'%%' IDENTIFIED BY '%s';", sqlStringLiteral(username), sqlStringLiteral(targetPassword))
-		return []string{"sh", "-c", "MYSQL_PWD='" + sqlStringLiteral(authPassword) + "' mysql -u " + sqlStringLiteral(username) + " --execute='" + stmt + "'"}
+		return []string{"sh", "-c", "MYSQL_PWD='" + sqlStringLiteral(authPassword) + "' " + mysqlTool(engine, "mysql") + " -u " + sqlStringLiteral(username) + " --execute='" + stmt + "'"}
 	}
 	return nil
 }
@@ -1147,18 +1302,18 @@ func (s *DatabaseService) getBackendVolName(vol *domain.Volume) string {
 }
 
 func (s *DatabaseService) getMountPath(engine domain.DatabaseEngine) string {
-	if engine == domain.EngineMySQL {
+	if engine.MySQLCompatible() {
 		return "/var/lib/mysql"
 	}
 	return "/var/lib/postgresql/data"
 }
 
 func (s *DatabaseService) isValidEngine(engine domain.DatabaseEngine) bool {
-	return engine == domain.EnginePostgres || engine == domain.EngineMySQL
+	return engine == domain.EnginePostgres || engine.MySQLCompatible()
 }
 
 func (s *DatabaseService) getDefaultUsername(engine domain.DatabaseEngine) string {
-	if engine == domain.EngineMySQL {
+	if engine.MySQLCompatible() {
 		return "root"
 	}
 	return "cloud_user"
@@ -1169,7 +1324,8 @@ func (s *DatabaseService) getExporterConfig(engine domain.DatabaseEngine, dbIP,
 	case domain.EnginePostgres:
 		dsn := fmt.Sprintf("postgresql://%s:%s@%s:%s/%s?sslmode=disable", username, password, dbIP, DefaultPostgresPort, dbName)
 		return PostgresExporterImage, []string{"DATA_SOURCE_NAME=" + dsn}, PostgresExporterPort
-	case domain.EngineMySQL:
+	case domain.EngineMySQL, domain.EngineMariaDB:
+		// mysqld_exporter supports MariaDB as well.
 		dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s", username, password, dbIP, DefaultMySQLPort, dbName)
 		return MySQLExporterImage, []string{"DATA_SOURCE_NAME=" + dsn}, MySQLExporterPort
 	}
@@ -1204,8 +1360,8 @@ func (s *DatabaseService) buildEngineCmd(engine domain.DatabaseEngine, parameter
 		for k, v := range parameters {
 			cmd = append(cmd, "-c", fmt.Sprintf("%s=%s", k, v))
 		}
-	case domain.EngineMySQL:
-		cmd = append(cmd, "mysqld")
+	case domain.EngineMySQL, domain.EngineMariaDB:
+		cmd = append(cmd, mysqlTool(engine, "mysqld"))
 		for k, v := range parameters {
 			cmd = append(cmd, fmt.Sprintf("--%s=%s", k, v))
 		}
@@ -1227,6 +1383,14 @@ func (s *DatabaseService) getEngineConfig(engine domain.DatabaseEngine, version,
 			env = append(env, "PRIMARY_HOST="+primaryIP)
 		}
 		return fmt.Sprintf("mysql:%s", version), env, DefaultMySQLPort
+	case domain.EngineMariaDB:
+		// Major version upgrades relaunch the container on the old data
+		// directory; the image runs mariadb-upgrade on it before serving.
+		env := []string{"MARIADB_ROOT_PASSWORD=" + password, "MARIADB_DATABASE=" + name, "MARIADB_AUTO_UPGRADE=1"}
+		if role == domain.RoleReplica {
+			env = append(env, "PRIMARY_HOST="+primaryIP)
+		}
+		return fmt.Sprintf("mariadb:%s", version), env, DefaultMySQLPort
 	}
 	return "", nil, ""
 }
@@ -1255,6 +1419,8 @@ func (s *DatabaseService) initialDatabaseRecord(userID uuid.UUID, name string, e
 		Password:  password,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
+
+		MaintenanceWindow: domain.DefaultMaintenanceWindow,
 	}
 }
 
@@ -0,0 +1,509 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/errors"
+)
+
+// Scheduled backups.
+//
+// A backup policy starts a backup once its next run time has come, which is
+// always inside the policy's daily window. Logical backups stream pg_dump or
+// mysqldump output out of the database container; physical backups snapshot
+// the data volume and archive the snapshot's data directory from a staging
+// container. Either way the result is uploaded to the policy's export bucket.
+//
+// Exports of databases with a KmsKeyID are sealed with AES-256-GCM under a
+// fresh data key, which is wrapped by the KMS and stored in the object header:
+//
+//	backupExportMagic | uint16 wrapped key length | wrapped key | nonce | ciphertext
+const (
+	backupExportMagic = "TCDBK1"
+	backupDumpFile    = "/tmp/cloud-db-backup"
+)
+
+func (s *DatabaseService) PutBackupPolicy(ctx context.Context, databaseID uuid.UUID, req ports.BackupPolicyRequest) (*domain.DatabaseBackupPolicy, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionDBUpdate, databaseID.String()); err != nil {
+		return nil, err
+	}
+	db, err := s.repo.GetByID(ctx, databaseID)
+	if err != nil {
+		return nil, err
+	}
+	if s.policyRepo == nil || s.automatedRepo == nil || s.storageSvc == nil {
+		return nil, errors.New(errors.InvalidInput, "scheduled backups are not available on this deployment")
+	}
+	if db.Role == domain.RoleReplica {
+		return nil, errors.New(errors.InvalidInput, "scheduled backups can only be configured on a primary database")
+	}
+
+	policy := &domain.DatabaseBackupPolicy{
+		DatabaseID:     db.ID,
+		TenantID:       db.TenantID,
+		Method:         req.Method,
+		WindowStart:    req.WindowStart,
+		WindowMinutes:  req.WindowMinutes,
+		FrequencyHours: req.FrequencyHours,
+		RetentionCount: req.RetentionCount,
+		RetentionDays:  req.RetentionDays,
+		ExportBucket:   req.ExportBucket,
+	}
+	if err := s.validateBackupPolicy(ctx, db, policy); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	policy.CreatedAt = now
+	policy.UpdatedAt = now
+	if existing, err := s.policyRepo.GetByDatabase(ctx, db.ID); err == nil {
+		policy.CreatedAt = existing.CreatedAt
+		policy.LastBackupAt = existing.LastBackupAt
+	} else if !errors.Is(err, errors.NotFound) {
+		return nil, err
+	}
+	policy.NextBackupAt = policy.NextRun(now)
+
+	if err := s.policyRepo.Upsert(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	_ = s.auditSvc.Log(ctx, db.UserID, "database.backup_policy_update", "database", db.ID.String(), map[string]interface{}{
+		"method":          policy.Method,
+		"window_start":    policy.WindowStart,
+		"frequency_hours": policy.FrequencyHours,
+		"export_bucket":   policy.ExportBucket,
+	})
+	return policy, nil
+}
+
+// validateBackupPolicy fills in defaults and checks a policy against the database it backs up.
+func (s *DatabaseService) validateBackupPolicy(ctx context.Context, db *domain.Database, p *domain.DatabaseBackupPolicy) error {
+	if p.Method == "" {
+		p.Method = domain.DatabaseBackupLogical
+	}
+	if p.Method != domain.DatabaseBackupLogical && p.Method != domain.DatabaseBackupPhysical {
+		return errors.New(errors.InvalidInput, "backup method must be LOGICAL or PHYSICAL")
+	}
+	if p.WindowStart == "" {
+		p.WindowStart = domain.DefaultBackupWindowStart
+	}
+	if _, err := time.Parse("15:04", p.WindowStart); err != nil {
+		return errors.New(errors.InvalidInput, "backup window start must be a UTC time formatted as HH:MM")
+	}
+	if p.WindowMinutes == 0 {
+		p.WindowMinutes = domain.DefaultBackupWindowMinutes
+	}
+	if p.WindowMinutes < domain.MinBackupWindowMinutes || p.WindowMinutes > 24*60 {
+		return errors.New(errors.InvalidInput, fmt.Sprintf("backup window must be between %d and %d minutes", domain.MinBackupWindowMinutes, 24*60))
+	}
+	if p.FrequencyHours == 0 {
+		p.FrequencyHours = domain.DefaultBackupFrequencyHours
+	}
+	if p.FrequencyHours < 1 || p.FrequencyHours > domain.MaxBackupFrequencyHours {
+		return errors.New(errors.InvalidInput, fmt.Sprintf("backup frequency must be between 1 and %d hours", domain.MaxBackupFrequencyHours))
+	}
+	if p.RetentionCount < 0 || p.RetentionCount > domain.MaxBackupRetentionCount {
+		return errors.New(errors.InvalidInput, fmt.Sprintf("backup retention count must be between 0 and %d", domain.MaxBackupRetentionCount))
+	}
+	if p.RetentionDays < 0 || p.RetentionDays > domain.MaxBackupRetentionDays {
+		return errors.New(errors.InvalidInput, fmt.Sprintf("backup retention must be between 0 and %d days", domain.MaxBackupRetentionDays))
+	}
+	if p.RetentionCount == 0 && p.RetentionDays == 0 {
+		return errors.New(errors.InvalidInput, "a backup policy needs a retention count or a retention in days")
+	}
+	if p.ExportBucket == "" {
+		return errors.New(errors.InvalidInput, "a backup policy requires an export bucket")
+	}
+	if _, err := s.storageSvc.GetBucket(ctx, p.ExportBucket); err != nil {
+		return err
+	}
+	if db.KmsKeyID != "" && s.kms == nil {
+		return errors.New(errors.InvalidInput, "encrypted backup exports are not available on this deployment")
+	}
+	return nil
+}
+
+func (s *DatabaseService) GetBackupPolicy(ctx context.Context, databaseID uuid.UUID) (*domain.DatabaseBackupPolicy, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionDBRead, databaseID.String()); err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.GetByID(ctx, databaseID); err != nil {
+		return nil, err
+	}
+	if s.policyRepo == nil {
+		return nil, errors.New(errors.NotFound, "backup policy not found")
+	}
+	return s.policyRepo.GetByDatabase(ctx, databaseID)
+}
+
+func (s *DatabaseService) DeleteBackupPolicy(ctx context.Context, databaseID uuid.UUID) error {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionDBUpdate, databaseID.String()); err != nil {
+		return err
+	}
+	db, err := s.repo.GetByID(ctx, databaseID)
+	if err != nil {
+		return err
+	}
+	if s.policyRepo == nil {
+		return errors.New(errors.NotFound, "backup policy not found")
+	}
+	if err := s.policyRepo.Delete(ctx, databaseID); err != nil {
+		return err
+	}
+	_ = s.auditSvc.Log(ctx, db.UserID, "database.backup_policy_delete", "database", db.ID.String(), nil)
+	return nil
+}
+
+func (s *DatabaseService) ListAutomatedBackups(ctx context.Context, databaseID uuid.UUID) ([]*domain.DatabaseAutomatedBackup, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionDBRead, databaseID.String()); err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.GetByID(ctx, databaseID); err != nil {
+		return nil, err
+	}
+	if s.automatedRepo == nil {
+		return []*domain.DatabaseAutomatedBackup{}, nil
+	}
+	return s.automatedRepo.ListByDatabase(ctx, databaseID)
+}
+
+func (s *DatabaseService) DeleteAutomatedBackup(ctx context.Context, databaseID, backupID uuid.UUID) error {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionDBUpdate, databaseID.String()); err != nil {
+		return err
+	}
+	db, err := s.repo.GetByID(ctx, databaseID)
+	if err != nil {
+		return err
+	}
+	if s.automatedRepo == nil {
+		return errors.New(errors.NotFound, "backup not found")
+	}
+	b, err := s.automatedRepo.GetByID(ctx, backupID)
+	if err != nil {
+		return err
+	}
+	if b.DatabaseID != db.ID {
+		return errors.New(errors.NotFound, "backup not found")
+	}
+	if err := s.deleteAutomatedBackup(ctx, b); err != nil {
+		return err
+	}
+	_ = s.auditSvc.Log(ctx, db.UserID, "database.backup_delete", "database", db.ID.String(), map[string]interface{}{"backup_id": b.ID.String()})
+	return nil
+}
+
+// deleteAutomatedBackup removes a backup's snapshot, export and record.
+func (s *DatabaseService) deleteAutomatedBackup(ctx context.Context, b *domain.DatabaseAutomatedBackup) error {
+	if b.SnapshotID != nil {
+		if err := s.snapshotSvc.DeleteSnapshot(ctx, *b.SnapshotID); err != nil && !errors.Is(err, errors.NotFound) {
+			return errors.Wrap(errors.Internal, "failed to delete backup snapshot", err)
+		}
+	}
+	if b.ObjectKey != "" && s.storageSvc != nil {
+		if err := s.storageSvc.DeleteObject(ctx, b.Bucket, b.ObjectKey); err != nil && !errors.Is(err, errors.NotFound) {
+			return errors.Wrap(errors.Internal, "failed to delete backup export", err)
+		}
+	}
+	return s.automatedRepo.Delete(ctx, b.ID)
+}
+
+// deleteBackupSnapshots removes the snapshots kept by physical backups when
+// their database is deleted. Exports stay in their bucket.
+func (s *DatabaseService) deleteBackupSnapshots(ctx context.Context, db *domain.Database) {
+	if s.automatedRepo == nil {
+		return
+	}
+	backups, err := s.automatedRepo.ListByDatabase(ctx, db.ID)
+	if err != nil {
+		s.logger.Warn("failed to list scheduled backups for removal", "database_id", db.ID, "error", err)
+		return
+	}
+	for _, b := range backups {
+		if b.SnapshotID == nil {
+			continue
+		}
+		if err := s.snapshotSvc.DeleteSnapshot(ctx, *b.SnapshotID); err != nil && !errors.Is(err, errors.NotFound) {
+			s.logger.Warn("failed to delete backup snapshot", "database_id", db.ID, "snapshot_id", *b.SnapshotID, "error", err)
+		}
+	}
+}
+
+func (s *DatabaseService) RunScheduledBackups(ctx context.Context) (int, error) {
+	if s.policyRepo == nil || s.automatedRepo == nil || s.storageSvc == nil {
+		return 0, nil
+	}
+	now := time.Now()
+	policies, err := s.policyRepo.ListDue(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	completed := 0
+	for _, p := range policies {
+		tenantCtx := appcontext.WithTenantID(ctx, p.TenantID)
+		db, err := s.repo.GetByID(tenantCtx, p.DatabaseID)
+		if err != nil {
+			s.logger.Warn("failed to load database for scheduled backup", "database_id", p.DatabaseID, "error", err)
+			continue
+		}
+		// Back up as the owner, who must still be able to write to the bucket.
+		ownerCtx := appcontext.WithUserID(tenantCtx, db.UserID)
+		if db.Status == domain.DatabaseStatusRunning && db.ContainerID != "" {
+			if s.runScheduledBackup(ownerCtx, db, p, now) {
+				completed++
+			}
+			s.pruneAutomatedBackups(ownerCtx, db, p, now)
+		}
+		s.reschedule(ownerCtx, p, now)
+	}
+	return completed, nil
+}
+
+// runScheduledBackup takes and exports one backup, recording the outcome.
+func (s *DatabaseService) runScheduledBackup(ctx context.Context, db *domain.Database, p *domain.DatabaseBackupPolicy, now time.Time) bool {
+	b := &domain.DatabaseAutomatedBackup{
+		ID:         uuid.New(),
+		DatabaseID: db.ID,
+		TenantID:   db.TenantID,
+		Method:     p.Method,
+		Status:     domain.AutomatedBackupRunning,
+		Bucket:     p.ExportBucket,
+		StartedAt:  now,
+	}
+	if err := s.automatedRepo.Create(ctx, b); err != nil {
+		s.logger.Warn("failed to record scheduled backup", "database_id", db.ID, "error", err)
+		return false
+	}
+
+	err := s.takeScheduledBackup(ctx, db, b)
+	done := time.Now()
+	b.CompletedAt = &done
+	b.Status = domain.AutomatedBackupCompleted
+	if err != nil {
+		s.logger.Warn("scheduled database backup failed", "database_id", db.ID, "backup_id", b.ID, "error", err)
+		b.Status = domain.AutomatedBackupFailed
+		b.Error = err.Error()
+	}
+	if err := s.automatedRepo.Update(ctx, b); err != nil {
+		s.logger.Warn("failed to update scheduled backup", "database_id", db.ID, "backup_id", b.ID, "error", err)
+	}
+	if b.Status != domain.AutomatedBackupCompleted {
+		_ = s.eventSvc.RecordEvent(ctx, "DATABASE_BACKUP_FAILED", db.ID.String(), "DATABASE", map[string]interface{}{"backup_id": b.ID.String()})
+		return false
+	}
+	_ = s.eventSvc.RecordEvent(ctx, "DATABASE_BACKUP", db.ID.String(), "DATABASE", map[string]interface{}{"backup_id": b.ID.String()})
+	return true
+}
+
+func (s *DatabaseService) takeScheduledBackup(ctx context.Context, db *domain.Database, b *domain.DatabaseAutomatedBackup) error {
+	var (
+		data []byte
+		ext  string
+		err  error
+	)
+	switch b.Method {
+	case domain.DatabaseBackupPhysical:
+		data, ext, err = s.physicalBackup(ctx, db, b)
+	default:
+		data, ext, err = s.logicalBackup(ctx, db)
+	}
+	if err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf("databases/%s/backups/%s-%s.%s", db.ID, b.StartedAt.UTC().Format("20060102T150405Z"), strings.ToLower(string(b.Method)), ext)
+	if db.KmsKeyID != "" {
+		if s.kms == nil {
+			return errors.New(errors.Internal, "no KMS is configured to encrypt the backup export")
+		}
+		if data, err = s.sealBackupExport(ctx, db.KmsKeyID, data); err != nil {
+			return err
+		}
+		key += ".enc"
+		b.KmsKeyID = db.KmsKeyID
+	}
+	if _, err := s.storageSvc.Upload(ctx, b.Bucket, key, bytes.NewReader(data), ""); err != nil {
+		return errors.Wrap(errors.Internal, "failed to export backup", err)
+	}
+	b.ObjectKey = key
+	b.SizeBytes = int64(len(data))
+	return nil
+}
+
+// logicalBackup dumps the database inside a single transaction, so the dump is
+// consistent without stopping writes.
+func (s *DatabaseService) logicalBackup(ctx context.Context, db *domain.Database) ([]byte, string, error) {
+	password := s.databasePassword(ctx, db)
+	var dump, ext string
+	switch db.Engine {
+	case domain.EnginePostgres:
+		ext = "dump"
+		dump = fmt.Sprintf("PGPASSWORD='%s' pg_dump -h 127.0.0.1 -U %s -d %s -Fc -f %s.%s",
+			sqlStringLiteral(password), db.Username, db.Name, backupDumpFile, ext)
+	case domain.EngineMySQL, domain.EngineMariaDB:
+		ext = "sql.gz"
+		dump = fmt.Sprintf("MYSQL_PWD='%s' %s -u root --single-transaction --routines --triggers --events --databases %s --result-file=%s.sql && gzip -f %s.sql",
+			sqlStringLiteral(password), mysqlTool(db.Engine, "mysqldump"), db.Name, backupDumpFile, backupDumpFile)
+	default:
+		return nil, "", errors.New(errors.InvalidInput, "unsupported database engine")
+	}
+	data, err := s.readDumpFile(ctx, db.ContainerID, dump, backupDumpFile+"."+ext)
+	if err != nil {
+		return nil, "", errors.Wrap(errors.Internal, "failed to dump database", err)
+	}
+	return data, ext, nil
+}
+
+// physicalBackup snapshots the data volume and archives the data directory of
+// a volume restored from that snapshot. The snapshot is kept with the backup.
+func (s *DatabaseService) physicalBackup(ctx context.Context, db *domain.Database, b *domain.DatabaseAutomatedBackup) ([]byte, string, error) {
+	if db.Engine == domain.EnginePostgres {
+		// Flush dirty pages so the snapshot needs little WAL replay on restore.
+		checkpoint := fmt.Sprintf("PGPASSWORD='%s' psql -h 127.0.0.1 -U %s -d %s -c CHECKPOINT",
+			sqlStringLiteral(s.databasePassword(ctx, db)), db.Username, db.Name)
+		if _, err := s.compute.Exec(ctx, db.ContainerID, []string{"sh", "-c", checkpoint}); err != nil {
+			s.logger.Warn("checkpoint before backup snapshot failed", "database_id", db.ID, "error", err)
+		}
+	}
+	vol, err := s.getVolumeForDatabase(ctx, db)
+	if err != nil {
+		return nil, "", err
+	}
+	snap, err := s.snapshotSvc.CreateSnapshot(ctx, vol.ID, fmt.Sprintf("db-backup-%s-%s", db.Name, b.StartedAt.Format("20060102150405")))
+	if err != nil {
+		return nil, "", errors.Wrap(errors.Internal, "failed to snapshot database volume", err)
+	}
+	b.SnapshotID = &snap.ID
+
+	tmp, err := s.snapshotSvc.RestoreSnapshot(ctx, snap.ID, "db-backup-tmp-"+b.ID.String()[:8])
+	if err != nil {
+		return nil, "", errors.Wrap(errors.Internal, "failed to restore backup snapshot", err)
+	}
+	defer func() {
+		if err := s.volumeSvc.DeleteVolume(ctx, tmp.ID.String()); err != nil {
+			s.logger.Warn("failed to delete temporary backup volume", "volume_id", tmp.ID, "error", err)
+		}
+	}()
+
+	const ext = "tar.gz"
+	var data []byte
+	err = s.withStagingContainer(ctx, db, tmp, "backup", func(stagerID string) error {
+		archive := fmt.Sprintf("tar -C %s -czf %s.%s .", s.getMountPath(db.Engine), backupDumpFile, ext)
+		data, err = s.readDumpFile(ctx, stagerID, archive, backupDumpFile+"."+ext)
+		return err
+	})
+	if err != nil {
+		return nil, "", errors.Wrap(errors.Internal, "failed to archive backup snapshot", err)
+	}
+	return data, ext, nil
+}
+
+// readDumpFile runs script, which writes path, reads path back and removes it.
+// Writing to a file first keeps a failing dump from passing for an empty one.
+func (s *DatabaseService) readDumpFile(ctx context.Context, containerID, script, path string) ([]byte, error) {
+	cmd := fmt.Sprintf("%s && base64 %s; rc=$?; rm -f %s; exit $rc", script, path, path)
+	out, err := s.compute.Exec(ctx, containerID, []string{"sh", "-c", cmd})
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(strings.Join(strings.Fields(out), ""))
+}
+
+// sealBackupExport encrypts data under a new data key wrapped by keyID.
+func (s *DatabaseService) sealBackupExport(ctx context.Context, keyID string, data []byte) ([]byte, error) {
+	dek := make([]byte, 32)
+	if _, err := rand.Read(dek); err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to generate backup data key", err)
+	}
+	wrapped, err := s.kms.Encrypt(ctx, keyID, dek)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to wrap backup data key", err)
+	}
+	block, err := aes.NewCipher(dek)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to create backup cipher", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to create backup cipher", err)
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to generate backup nonce", err)
+	}
+
+	out := make([]byte, 0, len(backupExportMagic)+2+len(wrapped)+len(nonce)+len(data)+gcm.Overhead())
+	out = append(out, backupExportMagic...)
+	out = binary.BigEndian.AppendUint16(out, uint16(len(wrapped)))
+	out = append(out, wrapped...)
+	out = append(out, nonce...)
+	return gcm.Seal(out, nonce, data, []byte(backupExportMagic)), nil
+}
+
+// pruneAutomatedBackups keeps the newest RetentionCount completed backups and
+// drops anything older than RetentionDays, failed attempts included.
+func (s *DatabaseService) pruneAutomatedBackups(ctx context.Context, db *domain.Database, p *domain.DatabaseBackupPolicy, now time.Time) {
+	backups, err := s.automatedRepo.ListByDatabase(ctx, db.ID)
+	if err != nil {
+		s.logger.Warn("failed to list scheduled backups for pruning", "database_id", db.ID, "error", err)
+		return
+	}
+	cutoff := now.AddDate(0, 0, -p.RetentionDays)
+	kept := 0
+	for _, b := range backups {
+		if b.Status == domain.AutomatedBackupRunning {
+			continue
+		}
+		expired := p.RetentionDays > 0 && b.StartedAt.Before(cutoff)
+		if b.Status == domain.AutomatedBackupCompleted {
+			kept++
+			expired = expired || (p.RetentionCount > 0 && kept > p.RetentionCount)
+		}
+		if !expired {
+			continue
+		}
+		if err := s.deleteAutomatedBackup(ctx, b); err != nil {
+			s.logger.Warn("failed to prune scheduled backup", "database_id", db.ID, "backup_id", b.ID, "error", err)
+		}
+	}
+}
+
+// reschedule moves a policy to its next run. Runs missed while the worker was
+// down are skipped rather than caught up.
+func (s *DatabaseService) reschedule(ctx context.Context, p *domain.DatabaseBackupPolicy, now time.Time) {
+	frequency := time.Duration(p.FrequencyHours) * time.Hour
+	next := p.NextBackupAt.Add(frequency)
+	if !next.After(now) {
+		next = now.Add(frequency)
+	}
+	p.NextBackupAt = p.NextRun(next)
+	p.LastBackupAt = &now
+	p.UpdatedAt = now
+	if err := s.policyRepo.Upsert(ctx, p); err != nil {
+		s.logger.Warn("failed to reschedule database backup", "database_id", p.DatabaseID, "error", err)
+	}
+}
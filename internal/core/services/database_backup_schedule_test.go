@@ -0,0 +1,356 @@
+package services_test
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/binary"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/core/services"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockBackupPolicyRepo struct {
+	mock.Mock
+}
+
+func (m *mockBackupPolicyRepo) Upsert(ctx context.Context, p *domain.DatabaseBackupPolicy) error {
+	return m.Called(ctx, p).Error(0)
+}
+
+func (m *mockBackupPolicyRepo) GetByDatabase(ctx context.Context, databaseID uuid.UUID) (*domain.DatabaseBackupPolicy, error) {
+	args := m.Called(ctx, databaseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DatabaseBackupPolicy), args.Error(1)
+}
+
+func (m *mockBackupPolicyRepo) ListDue(ctx context.Context, now time.Time) ([]*domain.DatabaseBackupPolicy, error) {
+	args := m.Called(ctx, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.DatabaseBackupPolicy), args.Error(1)
+}
+
+func (m *mockBackupPolicyRepo) Delete(ctx context.Context, databaseID uuid.UUID) error {
+	return m.Called(ctx, databaseID).Error(0)
+}
+
+type mockAutomatedBackupRepo struct {
+	mock.Mock
+}
+
+func (m *mockAutomatedBackupRepo) Create(ctx context.Context, b *domain.DatabaseAutomatedBackup) error {
+	return m.Called(ctx, b).Error(0)
+}
+
+func (m *mockAutomatedBackupRepo) Update(ctx context.Context, b *domain.DatabaseAutomatedBackup) error {
+	return m.Called(ctx, b).Error(0)
+}
+
+func (m *mockAutomatedBackupRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.DatabaseAutomatedBackup, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DatabaseAutomatedBackup), args.Error(1)
+}
+
+func (m *mockAutomatedBackupRepo) ListByDatabase(ctx context.Context, databaseID uuid.UUID) ([]*domain.DatabaseAutomatedBackup, error) {
+	args := m.Called(ctx, databaseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.DatabaseAutomatedBackup), args.Error(1)
+}
+
+func (m *mockAutomatedBackupRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+type scheduleMocks struct {
+	*pitrMocks
+	policies  *mockBackupPolicyRepo
+	automated *mockAutomatedBackupRepo
+	kms       *mockKMSClient
+}
+
+func setupScheduledBackupTest(withKMS bool) (*scheduleMocks, *services.DatabaseService) {
+	m := &scheduleMocks{
+		pitrMocks: &pitrMocks{
+			repo:     new(DatabaseUnitMockRepo),
+			backups:  new(mockDatabaseBackupRepo),
+			storage:  new(pitrStorage),
+			compute:  new(MockComputeBackend),
+			volumes:  new(MockVolumeService),
+			snaps:    new(mockSnapshotService),
+			secrets:  new(MockSecretsManager),
+			events:   new(MockEventService),
+			auditSvc: new(MockAuditService),
+		},
+		policies:  new(mockBackupPolicyRepo),
+		automated: new(mockAutomatedBackupRepo),
+		kms:       new(mockKMSClient),
+	}
+	rbac := new(mockRBACService)
+	rbac.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	params := services.DatabaseServiceParams{
+		Repo:          m.repo,
+		RBAC:          rbac,
+		Compute:       m.compute,
+		VpcRepo:       new(MockVpcRepo),
+		VolumeSvc:     m.volumes,
+		SnapshotSvc:   m.snaps,
+		SnapshotRepo:  new(mockSnapshotRepository),
+		EventSvc:      m.events,
+		AuditSvc:      m.auditSvc,
+		Secrets:       m.secrets,
+		StorageSvc:    m.storage,
+		BackupRepo:    m.backups,
+		PolicyRepo:    m.policies,
+		AutomatedRepo: m.automated,
+		Logger:        slog.Default(),
+	}
+	if withKMS {
+		params.KMS = m.kms
+	}
+	return m, services.NewDatabaseService(params)
+}
+
+func TestDatabaseServicePutBackupPolicyValidation(t *testing.T) {
+	ctx := appcontext.WithUserID(context.Background(), uuid.New())
+
+	tests := []struct {
+		name   string
+		kmsKey string
+		req    ports.BackupPolicyRequest
+		setup  func(m *scheduleMocks)
+		errMsg string
+	}{
+		{name: "UnknownMethod", req: ports.BackupPolicyRequest{Method: "INCREMENTAL", RetentionCount: 7, ExportBucket: "exports"}, errMsg: "LOGICAL or PHYSICAL"},
+		{name: "BadWindowStart", req: ports.BackupPolicyRequest{WindowStart: "25:00", RetentionCount: 7, ExportBucket: "exports"}, errMsg: "HH:MM"},
+		{name: "ShortWindow", req: ports.BackupPolicyRequest{WindowMinutes: 10, RetentionCount: 7, ExportBucket: "exports"}, errMsg: "between 30 and 1440 minutes"},
+		{name: "RareFrequency", req: ports.BackupPolicyRequest{FrequencyHours: 200, RetentionCount: 7, ExportBucket: "exports"}, errMsg: "between 1 and 168 hours"},
+		{name: "NoRetention", req: ports.BackupPolicyRequest{ExportBucket: "exports"}, errMsg: "retention count or a retention in days"},
+		{name: "MissingBucket", req: ports.BackupPolicyRequest{RetentionDays: 30}, errMsg: "requires an export bucket"},
+		{
+			name: "UnknownBucket", req: ports.BackupPolicyRequest{RetentionDays: 30, ExportBucket: "missing"},
+			setup: func(m *scheduleMocks) {
+				m.storage.On("GetBucket", mock.Anything, "missing").Return(nil, errors.New(errors.NotFound, "bucket not found")).Once()
+			},
+			errMsg: "bucket not found",
+		},
+		{
+			name: "EncryptedWithoutKMS", kmsKey: "vault:transit/orders", req: ports.BackupPolicyRequest{RetentionDays: 30, ExportBucket: "exports"},
+			setup: func(m *scheduleMocks) {
+				m.storage.On("GetBucket", mock.Anything, "exports").Return(&domain.Bucket{Name: "exports"}, nil).Once()
+			},
+			errMsg: "encrypted backup exports are not available",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, svc := setupScheduledBackupTest(false)
+			db := &domain.Database{ID: uuid.New(), Role: domain.RolePrimary, KmsKeyID: tt.kmsKey}
+			m.repo.On("GetByID", mock.Anything, db.ID).Return(db, nil).Once()
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+
+			_, err := svc.PutBackupPolicy(ctx, db.ID, tt.req)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+			m.policies.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestDatabaseServicePutBackupPolicyDefaults(t *testing.T) {
+	m, svc := setupScheduledBackupTest(false)
+	ctx := appcontext.WithUserID(context.Background(), uuid.New())
+	db := &domain.Database{ID: uuid.New(), TenantID: uuid.New(), Role: domain.RolePrimary}
+
+	m.repo.On("GetByID", mock.Anything, db.ID).Return(db, nil).Once()
+	m.storage.On("GetBucket", mock.Anything, "exports").Return(&domain.Bucket{Name: "exports"}, nil).Once()
+	m.policies.On("GetByDatabase", mock.Anything, db.ID).Return(nil, errors.New(errors.NotFound, "backup policy not found")).Once()
+	m.policies.On("Upsert", mock.Anything, mock.Anything).Return(nil).Once()
+	m.auditSvc.On("Log", mock.Anything, mock.Anything, "database.backup_policy_update", "database", db.ID.String(), mock.Anything).Return(nil).Once()
+
+	policy, err := svc.PutBackupPolicy(ctx, db.ID, ports.BackupPolicyRequest{RetentionCount: 7, ExportBucket: "exports"})
+	require.NoError(t, err)
+	assert.Equal(t, db.TenantID, policy.TenantID)
+	assert.Equal(t, domain.DatabaseBackupLogical, policy.Method)
+	assert.Equal(t, domain.DefaultBackupWindowStart, policy.WindowStart)
+	assert.Equal(t, domain.DefaultBackupWindowMinutes, policy.WindowMinutes)
+	assert.Equal(t, domain.DefaultBackupFrequencyHours, policy.FrequencyHours)
+	assert.Equal(t, policy.NextRun(policy.CreatedAt), policy.NextBackupAt)
+	m.policies.AssertExpectations(t)
+}
+
+func TestDatabaseServiceRunScheduledBackupsEncryptsLogicalExport(t *testing.T) {
+	m, svc := setupScheduledBackupTest(true)
+	userID, tenantID := uuid.New(), uuid.New()
+	db := &domain.Database{
+		ID: uuid.New(), UserID: userID, TenantID: tenantID, Name: "orders", Username: "cloud", Password: "s3cret",
+		Engine: domain.EnginePostgres, Role: domain.RolePrimary, Status: domain.DatabaseStatusRunning,
+		ContainerID: "cid", KmsKeyID: "vault:transit/orders",
+	}
+	due := time.Now().Add(-time.Minute).UTC()
+	policy := &domain.DatabaseBackupPolicy{
+		DatabaseID: db.ID, TenantID: tenantID, Method: domain.DatabaseBackupLogical, WindowStart: "00:00",
+		WindowMinutes: 24 * 60, FrequencyHours: 6, RetentionCount: 7, ExportBucket: "exports", NextBackupAt: due,
+	}
+	dump := []byte("PGDMP custom format archive")
+
+	m.policies.On("ListDue", mock.Anything, mock.Anything).Return([]*domain.DatabaseBackupPolicy{policy}, nil).Once()
+	m.repo.On("GetByID", mock.MatchedBy(func(ctx context.Context) bool {
+		return appcontext.TenantIDFromContext(ctx) == tenantID
+	}), db.ID).Return(db, nil).Once()
+	m.automated.On("Create", mock.Anything, mock.MatchedBy(func(b *domain.DatabaseAutomatedBackup) bool {
+		return b.Status == domain.AutomatedBackupRunning && b.Bucket == "exports"
+	})).Return(nil).Once()
+	m.compute.On("Exec", mock.Anything, "cid", mock.MatchedBy(func(cmd []string) bool {
+		return len(cmd) == 3 && strings.Contains(cmd[2], "PGPASSWORD='s3cret' pg_dump -h 127.0.0.1 -U cloud -d orders -Fc")
+	})).Return(base64.StdEncoding.EncodeToString(dump)+"\n", nil).Once()
+
+	var dek []byte
+	m.kms.On("Encrypt", mock.Anything, "vault:transit/orders", mock.Anything).Run(func(args mock.Arguments) {
+		dek = args.Get(2).([]byte)
+	}).Return([]byte("vault:v1:wrapped"), nil).Once()
+
+	var export string
+	m.storage.On("Upload", mock.MatchedBy(func(ctx context.Context) bool {
+		return appcontext.UserIDFromContext(ctx) == userID
+	}), "exports", mock.MatchedBy(func(key string) bool {
+		return strings.HasPrefix(key, "databases/"+db.ID.String()+"/backups/") && strings.HasSuffix(key, "-logical.dump.enc")
+	}), mock.Anything).Run(func(args mock.Arguments) {
+		export = args.String(3)
+	}).Return(nil).Once()
+	m.automated.On("Update", mock.Anything, mock.MatchedBy(func(b *domain.DatabaseAutomatedBackup) bool {
+		return b.Status == domain.AutomatedBackupCompleted && b.KmsKeyID == db.KmsKeyID && b.SizeBytes == int64(len(export))
+	})).Return(nil).Once()
+	m.events.On("RecordEvent", mock.Anything, "DATABASE_BACKUP", db.ID.String(), "DATABASE", mock.Anything).Return(nil).Once()
+	m.automated.On("ListByDatabase", mock.Anything, db.ID).Return([]*domain.DatabaseAutomatedBackup{}, nil).Once()
+	m.policies.On("Upsert", mock.Anything, mock.MatchedBy(func(p *domain.DatabaseBackupPolicy) bool {
+		return p.NextBackupAt.Equal(due.Add(6*time.Hour)) && p.LastBackupAt != nil
+	})).Return(nil).Once()
+
+	n, err := svc.RunScheduledBackups(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	m.automated.AssertExpectations(t)
+	m.policies.AssertExpectations(t)
+
+	// The export opens with the wrapped key and decrypts with the data key.
+	data := []byte(export)
+	require.True(t, strings.HasPrefix(export, "TCDBK1"))
+	data = data[len("TCDBK1"):]
+	keyLen := int(binary.BigEndian.Uint16(data))
+	assert.Equal(t, "vault:v1:wrapped", string(data[2:2+keyLen]))
+	data = data[2+keyLen:]
+	block, err := aes.NewCipher(dek)
+	require.NoError(t, err)
+	gcm, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+	plain, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], []byte("TCDBK1"))
+	require.NoError(t, err)
+	assert.Equal(t, dump, plain)
+}
+
+func TestDatabaseServiceRunScheduledBackupsPrunesExpiredBackups(t *testing.T) {
+	m, svc := setupScheduledBackupTest(false)
+	now := time.Now()
+	db := &domain.Database{
+		ID: uuid.New(), Name: "shop", Password: "pw", Engine: domain.EngineMySQL,
+		Role: domain.RolePrimary, Status: domain.DatabaseStatusRunning, ContainerID: "cid",
+	}
+	policy := &domain.DatabaseBackupPolicy{
+		DatabaseID: db.ID, Method: domain.DatabaseBackupLogical, WindowStart: "00:00", WindowMinutes: 24 * 60,
+		FrequencyHours: 24, RetentionCount: 2, RetentionDays: 7, ExportBucket: "exports", NextBackupAt: now.Add(-time.Minute),
+	}
+	snapID := uuid.New()
+	latest := &domain.DatabaseAutomatedBackup{ID: uuid.New(), Status: domain.AutomatedBackupCompleted, Bucket: "exports", ObjectKey: "k/3", StartedAt: now}
+	previous := &domain.DatabaseAutomatedBackup{ID: uuid.New(), Status: domain.AutomatedBackupCompleted, Bucket: "exports", ObjectKey: "k/2", StartedAt: now.Add(-24 * time.Hour)}
+	failed := &domain.DatabaseAutomatedBackup{ID: uuid.New(), Status: domain.AutomatedBackupFailed, Bucket: "exports", StartedAt: now.Add(-36 * time.Hour)}
+	surplus := &domain.DatabaseAutomatedBackup{ID: uuid.New(), Status: domain.AutomatedBackupCompleted, Bucket: "exports", ObjectKey: "k/1", SnapshotID: &snapID, StartedAt: now.Add(-48 * time.Hour)}
+	expiredFailure := &domain.DatabaseAutomatedBackup{ID: uuid.New(), Status: domain.AutomatedBackupFailed, Bucket: "exports", StartedAt: now.Add(-8 * 24 * time.Hour)}
+
+	m.policies.On("ListDue", mock.Anything, mock.Anything).Return([]*domain.DatabaseBackupPolicy{policy}, nil).Once()
+	m.repo.On("GetByID", mock.Anything, db.ID).Return(db, nil).Once()
+	m.automated.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+	m.compute.On("Exec", mock.Anything, "cid", mock.MatchedBy(func(cmd []string) bool {
+		return len(cmd) == 3 && strings.Contains(cmd[2], "MYSQL_PWD='pw' mysqldump -u root --single-transaction") && strings.Contains(cmd[2], "--databases shop")
+	})).Return(base64.StdEncoding.EncodeToString([]byte("gzipped dump")), nil).Once()
+	m.storage.On("Upload", mock.Anything, "exports", mock.MatchedBy(func(key string) bool {
+		return strings.HasSuffix(key, "-logical.sql.gz")
+	}), "gzipped dump").Return(nil).Once()
+	m.automated.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+	m.events.On("RecordEvent", mock.Anything, "DATABASE_BACKUP", db.ID.String(), "DATABASE", mock.Anything).Return(nil).Once()
+	m.automated.On("ListByDatabase", mock.Anything, db.ID).Return([]*domain.DatabaseAutomatedBackup{latest, previous, failed, surplus, expiredFailure}, nil).Once()
+	m.snaps.On("DeleteSnapshot", mock.Anything, snapID).Return(nil).Once()
+	m.storage.On("DeleteObject", mock.Anything, "exports", "k/1").Return(nil).Once()
+	m.automated.On("Delete", mock.Anything, surplus.ID).Return(nil).Once()
+	m.automated.On("Delete", mock.Anything, expiredFailure.ID).Return(nil).Once()
+	m.policies.On("Upsert", mock.Anything, mock.Anything).Return(nil).Once()
+
+	n, err := svc.RunScheduledBackups(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	m.automated.AssertExpectations(t)
+	m.storage.AssertExpectations(t)
+	m.snaps.AssertExpectations(t)
+}
+
+func TestDatabaseServiceRunScheduledBackupsReschedulesStoppedDatabase(t *testing.T) {
+	m, svc := setupScheduledBackupTest(false)
+	now := time.Now()
+	db := &domain.Database{ID: uuid.New(), Engine: domain.EnginePostgres, Status: domain.DatabaseStatusStopped}
+	policy := &domain.DatabaseBackupPolicy{
+		DatabaseID: db.ID, WindowStart: "00:00", WindowMinutes: 24 * 60, FrequencyHours: 24,
+		RetentionCount: 3, ExportBucket: "exports", NextBackupAt: now.Add(-72 * time.Hour),
+	}
+
+	m.policies.On("ListDue", mock.Anything, mock.Anything).Return([]*domain.DatabaseBackupPolicy{policy}, nil).Once()
+	m.repo.On("GetByID", mock.Anything, db.ID).Return(db, nil).Once()
+	m.policies.On("Upsert", mock.Anything, mock.MatchedBy(func(p *domain.DatabaseBackupPolicy) bool {
+		// Missed runs are skipped, not caught up.
+		return p.NextBackupAt.After(now.Add(23 * time.Hour))
+	})).Return(nil).Once()
+
+	n, err := svc.RunScheduledBackups(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	m.policies.AssertExpectations(t)
+	m.automated.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestDatabaseServiceDeleteAutomatedBackupChecksDatabase(t *testing.T) {
+	m, svc := setupScheduledBackupTest(false)
+	ctx := appcontext.WithUserID(context.Background(), uuid.New())
+	db := &domain.Database{ID: uuid.New()}
+	other := &domain.DatabaseAutomatedBackup{ID: uuid.New(), DatabaseID: uuid.New()}
+
+	m.repo.On("GetByID", mock.Anything, db.ID).Return(db, nil).Once()
+	m.automated.On("GetByID", mock.Anything, other.ID).Return(other, nil).Once()
+
+	err := svc.DeleteAutomatedBackup(ctx, db.ID, other.ID)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.NotFound))
+	m.automated.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,393 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/poyrazk/thecloud/internal/platform"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Performance insights.
+//
+// Primaries with metrics enabled are sampled every minute: client connections
+// against the connection limit, the buffer cache hit ratio and the lag of each
+// running read replica. Samples are kept for DatabaseInsightsRetention and are
+// also exported as Prometheus gauges for the Grafana databases dashboard.
+//
+// Top queries come from pg_stat_statements, which Postgres preloads when
+// metrics are enabled, or from the MySQL performance schema digest summary.
+// Their counters are cumulative since statistics were last reset, so only the
+// latest list is kept. A primary that had metrics enabled without a restart
+// has no pg_stat_statements yet and reports no top queries until it restarts.
+
+const (
+	pgStatStatements = "pg_stat_statements"
+	// insightQueryMaxLength truncates the normalized query text of top queries.
+	insightQueryMaxLength = 1000
+)
+
+const pgInsightStatsQuery = `SELECT
+	(SELECT count(*) FROM pg_stat_activity WHERE backend_type = 'client backend'),
+	current_setting('max_connections'),
+	(SELECT COALESCE(sum(blks_hit)::float8 / NULLIF(sum(blks_hit) + sum(blks_read), 0), 1) FROM pg_stat_database)`
+
+const mysqlInsightStatsQuery = `SELECT
+	(SELECT VARIABLE_VALUE FROM performance_schema.global_status WHERE VARIABLE_NAME = 'Threads_connected'),
+	@@max_connections,
+	(SELECT IFNULL(1 - r.VARIABLE_VALUE / NULLIF(q.VARIABLE_VALUE, 0), 1)
+		FROM performance_schema.global_status r
+		JOIN performance_schema.global_status q ON q.VARIABLE_NAME = 'Innodb_buffer_pool_read_requests'
+		WHERE r.VARIABLE_NAME = 'Innodb_buffer_pool_reads');`
+
+// mariadbInsightStatsQuery reads the status counters from information_schema,
+// since MariaDB's performance_schema has no global_status table.
+const mariadbInsightStatsQuery = `SELECT
+	(SELECT VARIABLE_VALUE FROM information_schema.GLOBAL_STATUS WHERE VARIABLE_NAME = 'THREADS_CONNECTED'),
+	@@max_connections,
+	(SELECT IFNULL(1 - r.VARIABLE_VALUE / NULLIF(q.VARIABLE_VALUE, 0), 1)
+		FROM information_schema.GLOBAL_STATUS r
+		JOIN information_schema.GLOBAL_STATUS q ON q.VARIABLE_NAME = 'INNODB_BUFFER_POOL_READ_REQUESTS'
+		WHERE r.VARIABLE_NAME = 'INNODB_BUFFER_POOL_READS');`
+
+// pgReplicaLagQuery reports zero while a replica has replayed everything it
+// received, so an idle primary does not look like a lagging replica.
+const pgReplicaLagQuery = `SELECT CASE WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+	ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0) END`
+
+// GetDatabaseInsights returns the performance insights of a database.
+func (s *DatabaseService) GetDatabaseInsights(ctx context.Context, databaseID uuid.UUID, period time.Duration) (*domain.DatabaseInsights, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionDBRead, databaseID.String()); err != nil {
+		return nil, err
+	}
+	db, err := s.repo.GetByID(ctx, databaseID)
+	if err != nil {
+		return nil, err
+	}
+	if s.insightRepo == nil {
+		return nil, errors.New(errors.InvalidInput, "database insights are not available on this deployment")
+	}
+	if db.Role == domain.RoleReplica {
+		return nil, errors.New(errors.InvalidInput, "insights are collected on the primary, which reports the lag of its replicas")
+	}
+	if !db.MetricsEnabled {
+		return nil, errors.New(errors.InvalidInput, "insights require metrics to be enabled on the database")
+	}
+	if period == 0 {
+		period = domain.DefaultDatabaseInsightsPeriod
+	}
+	if period < 0 || period > domain.DatabaseInsightsRetention {
+		return nil, errors.New(errors.InvalidInput, fmt.Sprintf("period must be positive and at most %s", domain.DatabaseInsightsRetention))
+	}
+
+	samples, err := s.insightRepo.ListSamples(ctx, db.ID, time.Now().Add(-period))
+	if err != nil {
+		return nil, err
+	}
+	queries, err := s.insightRepo.GetTopQueries(ctx, db.ID)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewDatabaseInsights(db.ID, samples, queries), nil
+}
+
+func (s *DatabaseService) CollectDatabaseInsights(ctx context.Context) (int, error) {
+	if s.insightRepo == nil || s.compute.Type() == "libvirt" {
+		return 0, nil
+	}
+	dbs, err := s.repo.ListWithMetrics(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	sampled := 0
+	for _, db := range dbs {
+		if db.Status != domain.DatabaseStatusRunning || db.ContainerID == "" {
+			continue
+		}
+		ownerCtx := appcontext.WithUserID(appcontext.WithTenantID(ctx, db.TenantID), db.UserID)
+		if err := s.collectInsights(ownerCtx, db); err != nil {
+			s.logger.Warn("failed to collect database insights", "database_id", db.ID, "error", err)
+			continue
+		}
+		sampled++
+	}
+
+	if _, err := s.insightRepo.DeleteSamplesBefore(ctx, time.Now().Add(-domain.DatabaseInsightsRetention)); err != nil {
+		s.logger.Warn("failed to prune database insight samples", "error", err)
+	}
+	return sampled, nil
+}
+
+// collectInsights records one sample of db and refreshes its top queries.
+func (s *DatabaseService) collectInsights(ctx context.Context, db *domain.Database) error {
+	sample := &domain.DatabaseInsightSample{
+		ID:          uuid.New(),
+		DatabaseID:  db.ID,
+		TenantID:    db.TenantID,
+		CollectedAt: time.Now(),
+		ReplicaLag:  []domain.DatabaseReplicaLag{},
+	}
+	if err := s.sampleDatabaseStats(ctx, db, sample); err != nil {
+		return err
+	}
+
+	replicas, err := s.repo.ListReplicas(ctx, db.ID)
+	if err != nil {
+		s.logger.Warn("failed to list replicas for insights", "database_id", db.ID, "error", err)
+	}
+	for _, r := range replicas {
+		if r.Status != domain.DatabaseStatusRunning || r.ContainerID == "" {
+			continue
+		}
+		lag, err := s.replicaLag(ctx, r)
+		if err != nil {
+			s.logger.Warn("failed to measure replica lag", "database_id", db.ID, "replica_id", r.ID, "error", err)
+			continue
+		}
+		sample.ReplicaLag = append(sample.ReplicaLag, domain.DatabaseReplicaLag{ReplicaID: r.ID, Name: r.Name, LagSeconds: lag})
+	}
+
+	if err := s.insightRepo.CreateSample(ctx, sample); err != nil {
+		return err
+	}
+	exportInsightMetrics(db.ID, sample)
+
+	queries, err := s.topQueries(ctx, db)
+	if err != nil {
+		s.logger.Debug("top queries unavailable", "database_id", db.ID, "error", err)
+		return nil
+	}
+	if err := s.insightRepo.PutTopQueries(ctx, db.ID, db.TenantID, queries, sample.CollectedAt); err != nil {
+		s.logger.Warn("failed to save top queries", "database_id", db.ID, "error", err)
+	}
+	return nil
+}
+
+func (s *DatabaseService) sampleDatabaseStats(ctx context.Context, db *domain.Database, sample *domain.DatabaseInsightSample) error {
+	query := pgInsightStatsQuery
+	switch db.Engine {
+	case domain.EngineMySQL:
+		query = mysqlInsightStatsQuery
+	case domain.EngineMariaDB:
+		query = mariadbInsightStatsQuery
+	}
+	rows, err := s.querySQL(ctx, db, 3, query)
+	if err != nil {
+		return err
+	}
+	if len(rows) != 1 {
+		return fmt.Errorf("unexpected database stats output")
+	}
+	row := rows[0]
+	if sample.Connections, err = strconv.Atoi(row[0]); err != nil {
+		return fmt.Errorf("invalid connection count %q", row[0])
+	}
+	if sample.MaxConnections, err = strconv.Atoi(row[1]); err != nil {
+		return fmt.Errorf("invalid connection limit %q", row[1])
+	}
+	if sample.CacheHitRatio, err = strconv.ParseFloat(row[2], 64); err != nil {
+		return fmt.Errorf("invalid cache hit ratio %q", row[2])
+	}
+	return nil
+}
+
+// replicaLag measures on the replica itself how many seconds it trails its primary.
+func (s *DatabaseService) replicaLag(ctx context.Context, replica *domain.Database) (float64, error) {
+	if replica.Engine.MySQLCompatible() {
+		return s.mysqlReplicaLag(ctx, replica)
+	}
+	rows, err := s.querySQL(ctx, replica, 1, pgReplicaLagQuery)
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) != 1 {
+		return 0, fmt.Errorf("unexpected replica lag output")
+	}
+	return strconv.ParseFloat(rows[0][0], 64)
+}
+
+// mysqlReplicaLag reads Seconds_Behind_Source, or Seconds_Behind_Master
+// before MySQL 8.0.22, from the replica status.
+func (s *DatabaseService) mysqlReplicaLag(ctx context.Context, replica *domain.Database) (float64, error) {
+	status, err := s.mysqlStatus(ctx, replica, "SHOW REPLICA STATUS;")
+	if err != nil {
+		if status, err = s.mysqlStatus(ctx, replica, "SHOW SLAVE STATUS;"); err != nil {
+			return 0, err
+		}
+	}
+	value, ok := status["Seconds_Behind_Source"]
+	if !ok {
+		value = status["Seconds_Behind_Master"]
+	}
+	if value == "" || value == "NULL" {
+		return 0, fmt.Errorf("replication is not running")
+	}
+	return strconv.ParseFloat(value, 64)
+}
+
+func (s *DatabaseService) topQueries(ctx context.Context, db *domain.Database) ([]domain.DatabaseQueryStat, error) {
+	var rows [][]string
+	var err error
+	switch db.Engine {
+	case domain.EnginePostgres:
+		rows, err = s.querySQL(ctx, db, 6, "CREATE EXTENSION IF NOT EXISTS "+pgStatStatements, pgTopQueriesQuery(db.Version))
+	case domain.EngineMySQL, domain.EngineMariaDB:
+		rows, err = s.querySQL(ctx, db, 6, mysqlTopQueriesQuery())
+	default:
+		return nil, errors.New(errors.InvalidInput, "unsupported database engine")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	queries := make([]domain.DatabaseQueryStat, 0, len(rows))
+	for _, row := range rows {
+		q := domain.DatabaseQueryStat{QueryID: row[0], Query: row[5]}
+		q.Calls, _ = strconv.ParseInt(row[1], 10, 64)
+		q.TotalTimeMs, _ = strconv.ParseFloat(row[2], 64)
+		q.MeanTimeMs, _ = strconv.ParseFloat(row[3], 64)
+		q.Rows, _ = strconv.ParseInt(row[4], 10, 64)
+		queries = append(queries, q)
+	}
+	return queries, nil
+}
+
+// pgTopQueriesQuery selects the top queries by total execution time. The
+// timing columns were renamed in Postgres 13.
+func pgTopQueriesQuery(version string) string {
+	total, mean := "total_exec_time", "mean_exec_time"
+	if domain.CompareVersions(domain.MajorVersion(domain.EnginePostgres, version), "13") < 0 {
+		total, mean = "total_time", "mean_time"
+	}
+	return fmt.Sprintf(`SELECT queryid, calls, %[1]s, %[2]s, rows, regexp_replace(left(query, %[3]d), '\s+', ' ', 'g')
+	FROM %[5]s WHERE query NOT LIKE '%%pg_stat_%%'
+	ORDER BY %[1]s DESC LIMIT %[4]d`, total, mean, insightQueryMaxLength, domain.DatabaseTopQueriesLimit, pgStatStatements)
+}
+
+// mysqlTopQueriesQuery selects the top statement digests by total wait time,
+// converting the picosecond timers to milliseconds.
+func mysqlTopQueriesQuery() string {
+	return fmt.Sprintf(`SELECT DIGEST, COUNT_STAR, SUM_TIMER_WAIT / 1000000000, AVG_TIMER_WAIT / 1000000000,
+	SUM_ROWS_SENT + SUM_ROWS_AFFECTED, LEFT(DIGEST_TEXT, %d)
+	FROM performance_schema.events_statements_summary_by_digest
+	WHERE DIGEST_TEXT IS NOT NULL AND DIGEST_TEXT NOT LIKE '%%performance_schema%%'
+	ORDER BY SUM_TIMER_WAIT DESC LIMIT %d;`, insightQueryMaxLength, domain.DatabaseTopQueriesLimit)
+}
+
+// querySQL runs statements as the master user and returns the output rows
+// that have exactly columns tab-separated fields; anything else the client
+// prints is ignored. Postgres statements run against the postgres database,
+// since the statistics views are cluster-wide.
+func (s *DatabaseService) querySQL(ctx context.Context, db *domain.Database, columns int, stmts ...string) ([][]string, error) {
+	password := s.databasePassword(ctx, db)
+	var cmd []string
+	switch db.Engine {
+	case domain.EnginePostgres:
+		cmd = []string{"env", "PGPASSWORD=" + password, "PGOPTIONS=-c client_min_messages=warning",
+			"psql", "-h", "127.0.0.1", "-U", db.Username, "-d", "postgres", "-v", "ON_ERROR_STOP=1", "-q", "-A", "-t", "-F", "\t"}
+		for _, stmt := range stmts {
+			cmd = append(cmd, "-c", stmt)
+		}
+	case domain.EngineMySQL, domain.EngineMariaDB:
+		cmd = []string{"env", "MYSQL_PWD=" + password, mysqlTool(db.Engine, "mysql"), "-u", "root", "-N", "-B", "--execute", strings.Join(stmts, " ")}
+	default:
+		return nil, errors.New(errors.InvalidInput, "unsupported database engine")
+	}
+	out, err := s.compute.Exec(ctx, db.ContainerID, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows [][]string
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if line == "" {
+			continue
+		}
+		if fields := strings.Split(line, "\t"); len(fields) == columns {
+			rows = append(rows, fields)
+		}
+	}
+	return rows, nil
+}
+
+// mysqlStatus runs a SHOW statement that returns a single row and maps its
+// column names to values.
+func (s *DatabaseService) mysqlStatus(ctx context.Context, db *domain.Database, stmt string) (map[string]string, error) {
+	cmd := []string{"env", "MYSQL_PWD=" + s.databasePassword(ctx, db), mysqlTool(db.Engine, "mysql"), "-u", "root", "-B", "--execute", stmt}
+	out, err := s.compute.Exec(ctx, db.ContainerID, cmd)
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) < 2 {
+		return nil, fmt.Errorf("database is not replicating")
+	}
+	header, values := strings.Split(lines[0], "\t"), strings.Split(lines[1], "\t")
+	status := make(map[string]string, len(header))
+	for i, name := range header {
+		if i < len(values) {
+			status[name] = values[i]
+		}
+	}
+	return status, nil
+}
+
+// withInsightParameters preloads pg_stat_statements on Postgres databases
+// with metrics enabled, keeping any libraries the user preloads. MariaDB
+// disables the performance schema, and with it statement digests, by default.
+func withInsightParameters(db *domain.Database, parameters map[string]string) map[string]string {
+	if !db.MetricsEnabled {
+		return parameters
+	}
+	if db.Engine == domain.EngineMariaDB {
+		if _, ok := parameters["performance_schema"]; ok {
+			return parameters
+		}
+		return mergeParameters(parameters, map[string]string{"performance_schema": "ON"})
+	}
+	if db.Engine != domain.EnginePostgres {
+		return parameters
+	}
+	libraries := parameters["shared_preload_libraries"]
+	for _, lib := range strings.Split(libraries, ",") {
+		if strings.TrimSpace(lib) == pgStatStatements {
+			return parameters
+		}
+	}
+	if libraries != "" {
+		libraries += ","
+	}
+	return mergeParameters(parameters, map[string]string{"shared_preload_libraries": libraries + pgStatStatements})
+}
+
+func exportInsightMetrics(databaseID uuid.UUID, sample *domain.DatabaseInsightSample) {
+	id := databaseID.String()
+	platform.RDSConnections.WithLabelValues(id).Set(float64(sample.Connections))
+	platform.RDSMaxConnections.WithLabelValues(id).Set(float64(sample.MaxConnections))
+	platform.RDSCacheHitRatio.WithLabelValues(id).Set(sample.CacheHitRatio)
+	// Replicas that were removed or stopped must not keep reporting their last lag.
+	platform.RDSReplicationLagSeconds.DeletePartialMatch(prometheus.Labels{"database_id": id})
+	for _, r := range sample.ReplicaLag {
+		platform.RDSReplicationLagSeconds.WithLabelValues(id, r.ReplicaID.String()).Set(r.LagSeconds)
+	}
+}
+
+// clearInsightMetrics stops exporting the insight gauges of a database.
+func clearInsightMetrics(databaseID uuid.UUID) {
+	id := databaseID.String()
+	platform.RDSConnections.DeleteLabelValues(id)
+	platform.RDSMaxConnections.DeleteLabelValues(id)
+	platform.RDSCacheHitRatio.DeleteLabelValues(id)
+	platform.RDSReplicationLagSeconds.DeletePartialMatch(prometheus.Labels{"database_id": id})
+}
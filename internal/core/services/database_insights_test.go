@@ -0,0 +1,232 @@
+package services_test
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/services"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// memDatabaseInsightRepo is an in-memory ports.DatabaseInsightRepository.
+type memDatabaseInsightRepo struct {
+	samples []*domain.DatabaseInsightSample
+	queries map[uuid.UUID][]domain.DatabaseQueryStat
+	pruned  time.Time
+}
+
+func (r *memDatabaseInsightRepo) CreateSample(ctx context.Context, s *domain.DatabaseInsightSample) error {
+	r.samples = append(r.samples, s)
+	return nil
+}
+
+func (r *memDatabaseInsightRepo) ListSamples(ctx context.Context, databaseID uuid.UUID, since time.Time) ([]*domain.DatabaseInsightSample, error) {
+	var out []*domain.DatabaseInsightSample
+	for _, s := range r.samples {
+		if s.DatabaseID == databaseID && !s.CollectedAt.Before(since) {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+func (r *memDatabaseInsightRepo) DeleteSamplesBefore(ctx context.Context, before time.Time) (int64, error) {
+	r.pruned = before
+	return 0, nil
+}
+
+func (r *memDatabaseInsightRepo) PutTopQueries(ctx context.Context, databaseID, tenantID uuid.UUID, queries []domain.DatabaseQueryStat, collectedAt time.Time) error {
+	r.queries[databaseID] = queries
+	return nil
+}
+
+func (r *memDatabaseInsightRepo) GetTopQueries(ctx context.Context, databaseID uuid.UUID) ([]domain.DatabaseQueryStat, error) {
+	return r.queries[databaseID], nil
+}
+
+func setupDatabaseInsightsTest() (*pitrMocks, *memDatabaseInsightRepo, *services.DatabaseService) {
+	m := &pitrMocks{
+		repo:    new(DatabaseUnitMockRepo),
+		compute: new(MockComputeBackend),
+		secrets: new(MockSecretsManager),
+	}
+	insights := &memDatabaseInsightRepo{queries: map[uuid.UUID][]domain.DatabaseQueryStat{}}
+	rbac := new(mockRBACService)
+	rbac.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	m.compute.On("Type").Return("docker").Maybe()
+
+	svc := services.NewDatabaseService(services.DatabaseServiceParams{
+		Repo:        m.repo,
+		RBAC:        rbac,
+		Compute:     m.compute,
+		VpcRepo:     new(MockVpcRepo),
+		Secrets:     m.secrets,
+		InsightRepo: insights,
+		Logger:      slog.Default(),
+	})
+	return m, insights, svc
+}
+
+// execReturning answers container commands whose arguments contain match.
+func execReturning(m *pitrMocks, containerID, match, out string) {
+	m.compute.On("Exec", mock.Anything, containerID, mock.MatchedBy(func(cmd []string) bool {
+		return strings.Contains(strings.Join(cmd, " "), match)
+	})).Return(out, nil)
+}
+
+func TestDatabaseServiceCollectDatabaseInsightsPostgres(t *testing.T) {
+	m, insights, svc := setupDatabaseInsightsTest()
+	db := runningDatabase(domain.EnginePostgres)
+	db.MetricsEnabled = true
+	replica := &domain.Database{ID: uuid.New(), Name: "app-replica", Engine: domain.EnginePostgres, Role: domain.RoleReplica,
+		Status: domain.DatabaseStatusRunning, ContainerID: "cid-2", Username: "cloud_user"}
+	stopped := &domain.Database{ID: uuid.New(), Engine: domain.EnginePostgres, Role: domain.RoleReplica, Status: domain.DatabaseStatusStopped}
+	m.repo.On("ListWithMetrics", mock.Anything).Return([]*domain.Database{db}, nil)
+	m.repo.On("ListReplicas", mock.Anything, db.ID).Return([]*domain.Database{replica, stopped}, nil)
+
+	execReturning(m, "cid-1", "pg_stat_activity", "12\t100\t0.987\n")
+	execReturning(m, "cid-1", "total_exec_time", "-4211\t30\t1500.5\t50.0166\t30\tSELECT * FROM orders WHERE id = $1\n"+
+		"99\t2\t10\t5\t0\tUPDATE orders SET status = $1\n")
+	execReturning(m, "cid-2", "pg_last_wal_replay_lsn", "2.5\n")
+
+	sampled, err := svc.CollectDatabaseInsights(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, sampled)
+
+	require.Len(t, insights.samples, 1)
+	sample := insights.samples[0]
+	assert.Equal(t, 12, sample.Connections)
+	assert.Equal(t, 100, sample.MaxConnections)
+	assert.InDelta(t, 0.987, sample.CacheHitRatio, 1e-9)
+	require.Len(t, sample.ReplicaLag, 1)
+	assert.Equal(t, replica.ID, sample.ReplicaLag[0].ReplicaID)
+	assert.Equal(t, 2.5, sample.ReplicaLag[0].LagSeconds)
+
+	queries := insights.queries[db.ID]
+	require.Len(t, queries, 2)
+	assert.Equal(t, "-4211", queries[0].QueryID)
+	assert.Equal(t, int64(30), queries[0].Calls)
+	assert.Equal(t, 1500.5, queries[0].TotalTimeMs)
+	assert.Equal(t, "SELECT * FROM orders WHERE id = $1", queries[0].Query)
+	assert.WithinDuration(t, time.Now().Add(-domain.DatabaseInsightsRetention), insights.pruned, time.Minute)
+	m.compute.AssertNotCalled(t, "Exec", mock.Anything, "", mock.Anything)
+}
+
+func TestDatabaseServiceCollectDatabaseInsightsMySQL(t *testing.T) {
+	m, insights, svc := setupDatabaseInsightsTest()
+	db := runningDatabase(domain.EngineMySQL)
+	db.Version = "8.0.36"
+	db.MetricsEnabled = true
+	replica := &domain.Database{ID: uuid.New(), Name: "app-replica", Engine: domain.EngineMySQL, Role: domain.RoleReplica,
+		Status: domain.DatabaseStatusRunning, ContainerID: "cid-2", Username: "root"}
+	m.repo.On("ListWithMetrics", mock.Anything).Return([]*domain.Database{db}, nil)
+	m.repo.On("ListReplicas", mock.Anything, db.ID).Return([]*domain.Database{replica}, nil)
+
+	execReturning(m, "cid-1", "Threads_connected", "7\t151\t0.9990\n")
+	execReturning(m, "cid-1", "events_statements_summary_by_digest", "ab12\t400\t812.3\t2.03\t400\tSELECT * FROM `orders` WHERE `id` = ?\n")
+	execReturning(m, "cid-2", "SHOW REPLICA STATUS", "Replica_IO_State\tSource_Host\tSeconds_Behind_Source\nWaiting for source\t10.0.0.2\t4\n")
+
+	sampled, err := svc.CollectDatabaseInsights(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, sampled)
+
+	require.Len(t, insights.samples, 1)
+	assert.Equal(t, 7, insights.samples[0].Connections)
+	assert.Equal(t, 151, insights.samples[0].MaxConnections)
+	require.Len(t, insights.samples[0].ReplicaLag, 1)
+	assert.Equal(t, 4.0, insights.samples[0].ReplicaLag[0].LagSeconds)
+	require.Len(t, insights.queries[db.ID], 1)
+	assert.Equal(t, 812.3, insights.queries[db.ID][0].TotalTimeMs)
+}
+
+func TestDatabaseServiceCollectDatabaseInsightsMariaDB(t *testing.T) {
+	m, insights, svc := setupDatabaseInsightsTest()
+	db := runningDatabase(domain.EngineMariaDB)
+	db.Version = "11.4"
+	db.MetricsEnabled = true
+	m.repo.On("ListWithMetrics", mock.Anything).Return([]*domain.Database{db}, nil)
+	m.repo.On("ListReplicas", mock.Anything, db.ID).Return([]*domain.Database{}, nil)
+
+	execReturning(m, "cid-1", "information_schema.GLOBAL_STATUS WHERE VARIABLE_NAME = 'THREADS_CONNECTED'", "4\t151\t0.95\n")
+	execReturning(m, "cid-1", "events_statements_summary_by_digest", "cd34\t12\t3.5\t0.29\t12\tSELECT ?\n")
+
+	sampled, err := svc.CollectDatabaseInsights(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, sampled)
+	require.Len(t, insights.samples, 1)
+	assert.Equal(t, 4, insights.samples[0].Connections)
+	assert.Equal(t, 0.95, insights.samples[0].CacheHitRatio)
+	require.Len(t, insights.queries[db.ID], 1)
+	for _, call := range m.compute.Calls {
+		if call.Method == "Exec" {
+			assert.Equal(t, "mariadb", call.Arguments.Get(2).([]string)[2])
+		}
+	}
+}
+
+func TestDatabaseServiceCollectDatabaseInsightsSkipsFailures(t *testing.T) {
+	m, insights, svc := setupDatabaseInsightsTest()
+	db := runningDatabase(domain.EnginePostgres)
+	db.Version = "12"
+	db.MetricsEnabled = true
+	stopped := runningDatabase(domain.EnginePostgres)
+	stopped.Status = domain.DatabaseStatusStopped
+	m.repo.On("ListWithMetrics", mock.Anything).Return([]*domain.Database{stopped, db}, nil)
+	m.repo.On("ListReplicas", mock.Anything, db.ID).Return([]*domain.Database{}, nil)
+
+	execReturning(m, "cid-1", "pg_stat_activity", "3\t100\t1\n")
+	// pg_stat_statements is not preloaded yet: the sample is kept without top queries.
+	m.compute.On("Exec", mock.Anything, "cid-1", mock.MatchedBy(func(cmd []string) bool {
+		return strings.Contains(strings.Join(cmd, " "), "total_time")
+	})).Return("", errors.New(errors.Internal, "pg_stat_statements must be loaded via shared_preload_libraries"))
+
+	sampled, err := svc.CollectDatabaseInsights(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, sampled)
+	require.Len(t, insights.samples, 1)
+	assert.Empty(t, insights.queries)
+}
+
+func TestDatabaseServiceGetDatabaseInsights(t *testing.T) {
+	ctx := appcontext.WithUserID(context.Background(), uuid.New())
+	m, insights, svc := setupDatabaseInsightsTest()
+	db := runningDatabase(domain.EnginePostgres)
+	plain := runningDatabase(domain.EnginePostgres)
+	db.MetricsEnabled = true
+	m.repo.On("GetByID", mock.Anything, db.ID).Return(db, nil)
+	m.repo.On("GetByID", mock.Anything, plain.ID).Return(plain, nil)
+
+	now := time.Now()
+	insights.samples = []*domain.DatabaseInsightSample{
+		{DatabaseID: db.ID, CollectedAt: now.Add(-2 * time.Hour), Connections: 1},
+		{DatabaseID: db.ID, CollectedAt: now.Add(-10 * time.Minute), Connections: 5, MaxConnections: 100, CacheHitRatio: 0.99},
+	}
+	insights.queries[db.ID] = []domain.DatabaseQueryStat{{Query: "SELECT 1"}}
+
+	got, err := svc.GetDatabaseInsights(ctx, db.ID, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 5, got.Connections)
+	assert.Len(t, got.History, 1)
+	assert.Len(t, got.TopQueries, 1)
+
+	got, err = svc.GetDatabaseInsights(ctx, db.ID, 3*time.Hour)
+	require.NoError(t, err)
+	assert.Len(t, got.History, 2)
+
+	_, err = svc.GetDatabaseInsights(ctx, db.ID, 8*24*time.Hour)
+	assert.True(t, errors.Is(err, errors.InvalidInput))
+
+	_, err = svc.GetDatabaseInsights(ctx, plain.ID, 0)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.InvalidInput))
+	assert.Contains(t, err.Error(), "metrics")
+}
@@ -29,5 +29,47 @@ func TestDatabaseService_Internal(t *testing.T) {
 		image, _, port = s.getEngineConfig(domain.EngineMySQL, "8.0", "user", "pass", "mydb", domain.RolePrimary, "")
 		assert.Equal(t, "mysql:8.0", image)
 		assert.Equal(t, "3306", port)
+
+		image, env, port = s.getEngineConfig(domain.EngineMariaDB, "11.4", "root", "pass", "mydb", domain.RolePrimary, "")
+		assert.Equal(t, "mariadb:11.4", image)
+		assert.Contains(t, env, "MARIADB_ROOT_PASSWORD=pass")
+		assert.Contains(t, env, "MARIADB_AUTO_UPGRADE=1")
+		assert.Equal(t, "3306", port)
+	})
+
+	t.Run("buildEngineCmd", func(t *testing.T) {
+		assert.Nil(t, s.buildEngineCmd(domain.EngineMariaDB, nil))
+		assert.Equal(t, []string{"mariadbd", "--max_connections=200"}, s.buildEngineCmd(domain.EngineMariaDB, map[string]string{"max_connections": "200"}))
+		assert.Equal(t, []string{"mysqld", "--max_connections=200"}, s.buildEngineCmd(domain.EngineMySQL, map[string]string{"max_connections": "200"}))
+	})
+
+	t.Run("mysqlTool", func(t *testing.T) {
+		assert.Equal(t, "mysqldump", mysqlTool(domain.EngineMySQL, "mysqldump"))
+		assert.Equal(t, "mariadb", mysqlTool(domain.EngineMariaDB, "mysql"))
+		assert.Equal(t, "mariadbd", mysqlTool(domain.EngineMariaDB, "mysqld"))
+		assert.Equal(t, "mariadb-dump", mysqlTool(domain.EngineMariaDB, "mysqldump"))
+		assert.Equal(t, "mariadb-binlog", mysqlTool(domain.EngineMariaDB, "mysqlbinlog"))
+		assert.Equal(t, "mariadb-admin", mysqlTool(domain.EngineMariaDB, "mysqladmin"))
+	})
+
+	t.Run("MariaDB credential rotation and exporter", func(t *testing.T) {
+		cmd := s.buildPasswordChangeCmd(domain.EngineMariaDB, "root", "old", "new")
+		require.Len(t, cmd, 3)
+		assert.Contains(t, cmd[2], "MYSQL_PWD='old' mariadb -u root")
+		assert.Contains(t, cmd[2], "ALTER USER 'root'@'%' IDENTIFIED BY 'new';")
+
+		image, env, port := s.getExporterConfig(domain.EngineMariaDB, "10.0.0.5", "root", "pw", "mydb")
+		assert.Equal(t, MySQLExporterImage, image)
+		assert.Equal(t, []string{"DATA_SOURCE_NAME=root:pw@tcp(10.0.0.5:3306)/mydb"}, env)
+		assert.Equal(t, MySQLExporterPort, port)
+	})
+
+	t.Run("withInsightParameters", func(t *testing.T) {
+		db := &domain.Database{Engine: domain.EngineMariaDB, MetricsEnabled: true}
+		assert.Equal(t, map[string]string{"performance_schema": "ON"}, withInsightParameters(db, nil))
+		assert.Equal(t, "OFF", withInsightParameters(db, map[string]string{"performance_schema": "OFF"})["performance_schema"])
+
+		db.MetricsEnabled = false
+		assert.Nil(t, withInsightParameters(db, nil))
 	})
 }
@@ -0,0 +1,458 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/errors"
+)
+
+// Upgrades and maintenance.
+//
+// Version upgrades and parameter changes restart the database: the container
+// is replaced by one of the target image on the same volume and host port.
+// Replicas are restarted before their primary, so a change that breaks the
+// engine shows up on a replica while the primary still serves traffic.
+//
+// A minor version upgrade is a plain restart on the new image. A major
+// PostgreSQL upgrade runs pg_upgrade --link on the stopped data directory in a
+// staging container that ships both versions' binaries. A major MySQL upgrade
+// is logical: the database is dumped, the data directory is reinitialized by
+// the new server and the dump is loaded back. MariaDB upgrades its data
+// directory in place when the new server first starts. Either way the
+// primary's volume is snapshotted first, and point-in-time recovery restarts
+// from a fresh base backup because the archived logs cannot be replayed by
+// the new version.
+const (
+	// pgUpgradeImage ships the binaries of both PostgreSQL versions.
+	pgUpgradeImage = "tianon/postgres-upgrade:%s-to-%s"
+	// pgUpgradeDir holds the old and new clusters during pg_upgrade, inside
+	// the data volume so --link can hard-link instead of copying.
+	pgUpgradeDir = "/var/lib/postgresql/data/.upgrade"
+	// pgAlpineUID owns the data directory in the postgres:*-alpine images.
+	pgAlpineUID = "70"
+	// mysqlUpgradeDumpFile holds the logical dump while the server is replaced.
+	mysqlUpgradeDumpFile = "/tmp/cloud-db-upgrade.sql"
+)
+
+func (s *DatabaseService) UpgradeDatabase(ctx context.Context, req ports.UpgradeDatabaseRequest) (*domain.Database, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionDBUpdate, req.ID.String()); err != nil {
+		return nil, err
+	}
+	db, err := s.repo.GetByID(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	if db.Role == domain.RoleReplica {
+		return nil, errors.New(errors.InvalidInput, "replicas are upgraded with their primary")
+	}
+	if req.Version == "" {
+		return nil, errors.New(errors.InvalidInput, "version is required")
+	}
+	switch c := domain.CompareVersions(req.Version, db.Version); {
+	case c == 0:
+		return nil, errors.New(errors.InvalidInput, fmt.Sprintf("database is already running version %s", db.Version))
+	case c < 0:
+		return nil, errors.New(errors.InvalidInput, "downgrades are not supported; restore a pre-upgrade snapshot instead")
+	}
+
+	if !req.ApplyImmediately {
+		db.PendingVersion = req.Version
+		if err := s.repo.Update(ctx, db); err != nil {
+			return nil, err
+		}
+		_ = s.eventSvc.RecordEvent(ctx, "DATABASE_UPGRADE_SCHEDULED", db.ID.String(), "DATABASE", map[string]interface{}{"version": req.Version})
+		_ = s.auditSvc.Log(ctx, db.UserID, "database.upgrade_schedule", "database", db.ID.String(), map[string]interface{}{"name": db.Name, "version": req.Version})
+		return db, nil
+	}
+
+	if db.Status != domain.DatabaseStatusRunning {
+		return nil, errors.New(errors.InvalidInput, "database must be running to upgrade")
+	}
+	parameters := db.Parameters
+	if db.PendingParameters != nil {
+		parameters = db.PendingParameters
+	}
+	if err := s.performMaintenance(ctx, db, req.Version, parameters); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+func (s *DatabaseService) ListDatabaseUpgrades(ctx context.Context, databaseID uuid.UUID) ([]*domain.DatabaseUpgrade, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionDBRead, databaseID.String()); err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.GetByID(ctx, databaseID); err != nil {
+		return nil, err
+	}
+	if s.upgradeRepo == nil {
+		return []*domain.DatabaseUpgrade{}, nil
+	}
+	return s.upgradeRepo.ListByDatabase(ctx, databaseID)
+}
+
+func (s *DatabaseService) RunMaintenance(ctx context.Context) (int, error) {
+	dbs, err := s.repo.ListPendingMaintenance(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	maintained := 0
+	for _, db := range dbs {
+		// Stopped databases keep their changes pending until they run again.
+		if db.Status != domain.DatabaseStatusRunning || !db.InMaintenanceWindow(now) {
+			continue
+		}
+		version, parameters := db.Version, db.Parameters
+		if db.PendingVersion != "" {
+			version = db.PendingVersion
+		}
+		if db.PendingParameters != nil {
+			parameters = db.PendingParameters
+		}
+		ownerCtx := appcontext.WithUserID(appcontext.WithTenantID(ctx, db.TenantID), db.UserID)
+		if err := s.performMaintenance(ownerCtx, db, version, parameters); err != nil {
+			s.logger.Warn("database maintenance failed", "database_id", db.ID, "error", err)
+			continue
+		}
+		maintained++
+	}
+	return maintained, nil
+}
+
+// performMaintenance restarts db with the given version and parameters. A
+// version change snapshots the primary first and upgrades its replicas before
+// it. Pending changes are cleared whatever the outcome, so a failing change
+// is not retried every tick of its window.
+func (s *DatabaseService) performMaintenance(ctx context.Context, db *domain.Database, version string, parameters map[string]string) error {
+	var upgrade *domain.DatabaseUpgrade
+	var replicas []*domain.Database
+	if version != db.Version {
+		upgrade = &domain.DatabaseUpgrade{
+			ID:          uuid.New(),
+			DatabaseID:  db.ID,
+			TenantID:    db.TenantID,
+			FromVersion: db.Version,
+			ToVersion:   version,
+			Major:       domain.MajorVersion(db.Engine, db.Version) != domain.MajorVersion(db.Engine, version),
+			Status:      domain.DatabaseUpgradeInProgress,
+			StartedAt:   time.Now(),
+		}
+		if s.upgradeRepo != nil {
+			if err := s.upgradeRepo.Create(ctx, upgrade); err != nil {
+				return err
+			}
+		}
+		if err := s.snapshotBeforeUpgrade(ctx, db, upgrade); err != nil {
+			return s.finishMaintenance(ctx, db, upgrade, err)
+		}
+		if db.Role == domain.RolePrimary {
+			var err error
+			if replicas, err = s.repo.ListReplicas(ctx, db.ID); err != nil {
+				return s.finishMaintenance(ctx, db, upgrade, err)
+			}
+		}
+	}
+
+	if len(replicas) > 0 {
+		primaryIP, err := s.compute.GetInstanceIP(ctx, db.ContainerID)
+		if err != nil {
+			return s.finishMaintenance(ctx, db, upgrade, errors.Wrap(errors.Internal, "failed to get primary IP", err))
+		}
+		for _, r := range replicas {
+			if r.Status != domain.DatabaseStatusRunning {
+				s.logger.Warn("skipping replica that is not running during upgrade", "database_id", db.ID, "replica_id", r.ID, "status", r.Status)
+				continue
+			}
+			params := r.Parameters
+			if r.PendingParameters != nil {
+				params = r.PendingParameters
+			}
+			if err := s.restartDatabase(ctx, r, version, params, primaryIP); err != nil {
+				return s.finishMaintenance(ctx, db, upgrade, fmt.Errorf("replica %s: %w", r.Name, err))
+			}
+		}
+	}
+
+	primaryIP := ""
+	if db.Role == domain.RoleReplica && db.PrimaryID != nil {
+		if primary, err := s.repo.GetByID(ctx, *db.PrimaryID); err == nil {
+			primaryIP, _ = s.compute.GetInstanceIP(ctx, primary.ContainerID)
+		}
+	}
+	return s.finishMaintenance(ctx, db, upgrade, s.restartDatabase(ctx, db, version, parameters, primaryIP))
+}
+
+// snapshotBeforeUpgrade snapshots the volume of the database being upgraded.
+func (s *DatabaseService) snapshotBeforeUpgrade(ctx context.Context, db *domain.Database, upgrade *domain.DatabaseUpgrade) error {
+	vol, err := s.getVolumeForDatabase(ctx, db)
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("db-preupgrade-%s-%s", db.Name, upgrade.StartedAt.Format("20060102150405"))
+	snap, err := s.snapshotSvc.CreateSnapshot(ctx, vol.ID, name)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to snapshot database before upgrade", err)
+	}
+	upgrade.SnapshotID = &snap.ID
+	return nil
+}
+
+// finishMaintenance records the outcome of performMaintenance and returns err.
+func (s *DatabaseService) finishMaintenance(ctx context.Context, db *domain.Database, upgrade *domain.DatabaseUpgrade, err error) error {
+	if upgrade != nil {
+		done := time.Now()
+		upgrade.CompletedAt = &done
+		upgrade.Status = domain.DatabaseUpgradeCompleted
+		if err != nil {
+			upgrade.Status = domain.DatabaseUpgradeFailed
+			upgrade.Error = err.Error()
+		}
+		if s.upgradeRepo != nil {
+			if uerr := s.upgradeRepo.Update(ctx, upgrade); uerr != nil {
+				s.logger.Warn("failed to update database upgrade", "database_id", db.ID, "upgrade_id", upgrade.ID, "error", uerr)
+			}
+		}
+	}
+
+	if err != nil {
+		if db.HasPendingMaintenance() {
+			db.PendingVersion, db.PendingParameters = "", nil
+			if uerr := s.repo.Update(ctx, db); uerr != nil {
+				s.logger.Warn("failed to clear pending maintenance", "database_id", db.ID, "error", uerr)
+			}
+		}
+		_ = s.eventSvc.RecordEvent(ctx, "DATABASE_MAINTENANCE_FAILED", db.ID.String(), "DATABASE", map[string]interface{}{"error": err.Error()})
+		return err
+	}
+
+	if upgrade == nil {
+		_ = s.eventSvc.RecordEvent(ctx, "DATABASE_MAINTENANCE", db.ID.String(), "DATABASE", nil)
+		_ = s.auditSvc.Log(ctx, db.UserID, "database.maintenance", "database", db.ID.String(), map[string]interface{}{"name": db.Name})
+		return nil
+	}
+	if upgrade.Major && db.PITREnabled() {
+		s.purgeBackups(ctx, db)
+	}
+	meta := map[string]interface{}{"from_version": upgrade.FromVersion, "to_version": upgrade.ToVersion}
+	_ = s.eventSvc.RecordEvent(ctx, "DATABASE_UPGRADE", db.ID.String(), "DATABASE", meta)
+	_ = s.auditSvc.Log(ctx, db.UserID, "database.upgrade", "database", db.ID.String(), map[string]interface{}{"name": db.Name, "from_version": upgrade.FromVersion, "to_version": upgrade.ToVersion})
+	return nil
+}
+
+// restartDatabase replaces the container of db with one running version and
+// parameters on the same volume. Failures before the old container is gone
+// leave the database running; later ones mark it FAILED.
+func (s *DatabaseService) restartDatabase(ctx context.Context, db *domain.Database, version string, parameters map[string]string, primaryIP string) error {
+	vol, err := s.getVolumeForDatabase(ctx, db)
+	if err != nil {
+		return err
+	}
+	networkID, err := s.resolveVpcNetwork(ctx, db.VpcID)
+	if err != nil {
+		return err
+	}
+	password := s.databasePassword(ctx, db)
+	major := domain.MajorVersion(db.Engine, db.Version) != domain.MajorVersion(db.Engine, version)
+
+	// Failover must leave the database alone while it is down on purpose.
+	db.Status = domain.DatabaseStatusMaintenance
+	if err := s.repo.Update(ctx, db); err != nil {
+		return err
+	}
+
+	var dump []byte
+	var accounts []string
+	if major && db.Engine == domain.EngineMySQL {
+		dump, accounts, err = s.mysqlUpgradeDump(ctx, db, password)
+		if err != nil {
+			db.Status = domain.DatabaseStatusRunning
+			_ = s.repo.Update(ctx, db)
+			return errors.Wrap(errors.Internal, "failed to dump database for upgrade", err)
+		}
+	}
+
+	if err := s.compute.StopInstance(ctx, db.ContainerID); err != nil {
+		s.logger.Warn("failed to stop database container for maintenance", "database_id", db.ID, "container_id", db.ContainerID, "error", err)
+	}
+	if err := s.compute.DeleteInstance(ctx, db.ContainerID); err != nil {
+		return s.failMaintenance(ctx, db, errors.Wrap(errors.Internal, "failed to remove database container", err))
+	}
+	db.ContainerID = ""
+
+	if major {
+		switch db.Engine {
+		case domain.EnginePostgres:
+			err = s.pgUpgrade(ctx, db, vol, version)
+		case domain.EngineMySQL:
+			err = s.withStagingContainer(ctx, db, vol, "upgrade", func(stagerID string) error {
+				_, err := s.compute.Exec(ctx, stagerID, []string{"sh", "-c", fmt.Sprintf("find %s -mindepth 1 -delete", mysqlDataDir)})
+				return err
+			})
+		}
+		if err != nil {
+			return s.failMaintenance(ctx, db, errors.Wrap(errors.Internal, "failed to upgrade data directory", err))
+		}
+	}
+
+	db.Version = version
+	if err := s.launchDatabaseContainer(ctx, db, vol, password, parameters, primaryIP, networkID, db.Port); err != nil {
+		return s.failMaintenance(ctx, db, err)
+	}
+	if major && db.Engine == domain.EngineMySQL {
+		err = s.loadMySQLUpgradeDump(ctx, db, password, dump, accounts)
+	} else {
+		err = s.waitForDatabaseReady(ctx, db)
+	}
+	if err != nil {
+		return s.failMaintenance(ctx, db, err)
+	}
+	s.replaceSidecars(ctx, db, password, networkID)
+
+	db.Parameters = parameters
+	db.PendingVersion, db.PendingParameters = "", nil
+	db.Status = domain.DatabaseStatusRunning
+	return s.repo.Update(ctx, db)
+}
+
+func (s *DatabaseService) failMaintenance(ctx context.Context, db *domain.Database, err error) error {
+	db.Status = domain.DatabaseStatusFailed
+	if uerr := s.repo.Update(ctx, db); uerr != nil {
+		s.logger.Warn("failed to mark database failed after maintenance error", "database_id", db.ID, "error", uerr)
+	}
+	return err
+}
+
+// pgUpgrade runs pg_upgrade on the stopped data directory of db. The old
+// cluster is moved aside, a new one is initialized next to it and pg_upgrade
+// links the data files across; the archive directories stay where they are.
+func (s *DatabaseService) pgUpgrade(ctx context.Context, db *domain.Database, vol *domain.Volume, version string) error {
+	from := domain.MajorVersion(db.Engine, db.Version)
+	to := domain.MajorVersion(db.Engine, version)
+	data := s.getMountPath(db.Engine)
+	oldDir, newDir := pgUpgradeDir+"/old", pgUpgradeDir+"/new"
+	steps := []string{
+		"set -e",
+		"cd " + data,
+		fmt.Sprintf("rm -rf %s && mkdir -p %s %s", pgUpgradeDir, oldDir, newDir),
+		fmt.Sprintf("find . -mindepth 1 -maxdepth 1 ! -name .upgrade ! -name 'pitr_*' -exec mv {} %s/ \\;", oldDir),
+		fmt.Sprintf("chown -R postgres:postgres %s && chmod 700 %s %s", pgUpgradeDir, oldDir, newDir),
+		fmt.Sprintf("su postgres -c 'cd /tmp && /usr/lib/postgresql/%s/bin/initdb -D %s -U %s --encoding=UTF8 --locale=en_US.utf8 --auth=trust'", to, newDir, db.Username),
+		fmt.Sprintf("su postgres -c 'cd /tmp && /usr/lib/postgresql/%s/bin/pg_upgrade -b /usr/lib/postgresql/%s/bin -B /usr/lib/postgresql/%s/bin -d %s -D %s -U %s --link'",
+			to, from, to, oldDir, newDir, db.Username),
+		// Keep the password authentication of the old cluster and listen on
+		// all interfaces like the image's own initdb does.
+		fmt.Sprintf("cp %s/pg_hba.conf %s/pg_hba.conf", oldDir, newDir),
+		fmt.Sprintf("echo \"listen_addresses = '*'\" >> %s/postgresql.conf", newDir),
+		fmt.Sprintf("rm -rf %s", oldDir),
+		fmt.Sprintf("find %s -mindepth 1 -maxdepth 1 -exec mv {} . \\;", newDir),
+		fmt.Sprintf("rm -rf %s", pgUpgradeDir),
+		fmt.Sprintf("chown -R %s:%s .", pgAlpineUID, pgAlpineUID),
+	}
+	return s.withStagingImage(ctx, db, vol, "upgrade", fmt.Sprintf(pgUpgradeImage, from, to), func(stagerID string) error {
+		_, err := s.compute.Exec(ctx, stagerID, []string{"sh", "-c", strings.Join(steps, "\n")})
+		return err
+	})
+}
+
+// mysqlUpgradeDump dumps the master database and every logical database of db,
+// and returns the statements that recreate its users and their grants. The
+// users live in the mysql system schema, which the reinitialized server does
+// not keep, so they are rebuilt from the user records and their stored
+// passwords. Everything is read before the data directory is touched.
+func (s *DatabaseService) mysqlUpgradeDump(ctx context.Context, db *domain.Database, password string) ([]byte, []string, error) {
+	names := []string{db.Name}
+	var accounts []string
+	if s.userRepo != nil {
+		dbs, err := s.userRepo.ListLogicalDatabases(ctx, db.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, l := range dbs {
+			names = append(names, l.Name)
+		}
+		users, err := s.userRepo.ListUsers(ctx, db.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, u := range users {
+			secret, err := s.secrets.GetSecret(ctx, u.CredentialPath)
+			if err != nil {
+				return nil, nil, fmt.Errorf("credentials of user %s: %w", u.Username, err)
+			}
+			userPassword, _ := secret["password"].(string)
+			accounts = append(accounts, createUserSQL(db.Engine, u.Username, userPassword))
+			for _, g := range u.Grants {
+				accounts = append(accounts, mysqlGrantChange(g.Database, u.Username, "", g.Privilege)...)
+			}
+		}
+	}
+	dump, err := s.readDumpFile(ctx, db.ContainerID, s.mysqlUpgradeDumpCmd(password, names), mysqlUpgradeDumpFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	return dump, accounts, nil
+}
+
+func (s *DatabaseService) mysqlUpgradeDumpCmd(password string, names []string) string {
+	return fmt.Sprintf("MYSQL_PWD='%s' mysqldump -u root --single-transaction --routines --triggers --events --databases %s --result-file=%s",
+		sqlStringLiteral(password), strings.Join(names, " "), mysqlUpgradeDumpFile)
+}
+
+// loadMySQLUpgradeDump waits for the reinitialized server, loads the dump
+// taken before the upgrade and recreates the users.
+func (s *DatabaseService) loadMySQLUpgradeDump(ctx context.Context, db *domain.Database, password string, dump []byte, accounts []string) error {
+	if err := s.waitForMySQL(ctx, db, password); err != nil {
+		return errors.Wrap(errors.Internal, "upgraded database did not become ready", err)
+	}
+	if err := s.writeContainerFile(ctx, db.ContainerID, mysqlUpgradeDumpFile, dump); err != nil {
+		return errors.Wrap(errors.Internal, "failed to stage upgrade dump", err)
+	}
+	load := fmt.Sprintf("MYSQL_PWD='%s' mysql -u root < %s && rm -f %s", sqlStringLiteral(password), mysqlUpgradeDumpFile, mysqlUpgradeDumpFile)
+	if _, err := s.compute.Exec(ctx, db.ContainerID, []string{"sh", "-c", load}); err != nil {
+		return errors.Wrap(errors.Internal, "failed to load upgrade dump", err)
+	}
+	if len(accounts) > 0 {
+		if err := s.execAdminSQL(ctx, db, "", accounts...); err != nil {
+			return errors.Wrap(errors.Internal, "failed to recreate database users", err)
+		}
+	}
+	return nil
+}
+
+// replaceSidecars relaunches the exporter and pooler against the new database
+// container. The database is already serving, so failures are only logged.
+func (s *DatabaseService) replaceSidecars(ctx context.Context, db *domain.Database, password, networkID string) {
+	if !db.MetricsEnabled && !db.PoolingEnabled {
+		return
+	}
+	for _, cid := range []string{db.ExporterContainerID, db.PoolerContainerID} {
+		if cid == "" {
+			continue
+		}
+		if err := s.compute.DeleteInstance(ctx, cid); err != nil {
+			s.logger.Warn("failed to delete sidecar during maintenance", "database_id", db.ID, "container_id", cid, "error", err)
+		}
+	}
+	db.ExporterContainerID, db.PoolerContainerID = "", ""
+	dbIP, err := s.compute.GetInstanceIP(ctx, db.ContainerID)
+	if err != nil {
+		s.logger.Warn("failed to get database IP for sidecars", "database_id", db.ID, "error", err)
+		return
+	}
+	if err := s.provisionSidecars(ctx, db, db.Engine, dbIP, db.Username, password, networkID); err != nil {
+		s.logger.Warn("failed to relaunch sidecars after maintenance", "database_id", db.ID, "error", err)
+	}
+}
@@ -0,0 +1,448 @@
+package services_test
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/core/services"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockDatabaseUpgradeRepo struct {
+	mock.Mock
+}
+
+func (m *mockDatabaseUpgradeRepo) Create(ctx context.Context, u *domain.DatabaseUpgrade) error {
+	return m.Called(ctx, u).Error(0)
+}
+
+func (m *mockDatabaseUpgradeRepo) Update(ctx context.Context, u *domain.DatabaseUpgrade) error {
+	return m.Called(ctx, u).Error(0)
+}
+
+func (m *mockDatabaseUpgradeRepo) ListByDatabase(ctx context.Context, databaseID uuid.UUID) ([]*domain.DatabaseUpgrade, error) {
+	args := m.Called(ctx, databaseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.DatabaseUpgrade), args.Error(1)
+}
+
+type maintenanceMocks struct {
+	*pitrMocks
+	upgrades *mockDatabaseUpgradeRepo
+	users    *memDatabaseUserRepo
+}
+
+func setupDatabaseMaintenanceTest() (*maintenanceMocks, *services.DatabaseService) {
+	m := &maintenanceMocks{
+		pitrMocks: &pitrMocks{
+			repo:     new(DatabaseUnitMockRepo),
+			backups:  new(mockDatabaseBackupRepo),
+			storage:  new(pitrStorage),
+			compute:  new(MockComputeBackend),
+			volumes:  new(MockVolumeService),
+			snaps:    new(mockSnapshotService),
+			secrets:  new(MockSecretsManager),
+			events:   new(MockEventService),
+			auditSvc: new(MockAuditService),
+		},
+		upgrades: new(mockDatabaseUpgradeRepo),
+		users:    &memDatabaseUserRepo{},
+	}
+	rbac := new(mockRBACService)
+	rbac.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	svc := services.NewDatabaseService(services.DatabaseServiceParams{
+		Repo:         m.repo,
+		RBAC:         rbac,
+		Compute:      m.compute,
+		VpcRepo:      new(MockVpcRepo),
+		VolumeSvc:    m.volumes,
+		SnapshotSvc:  m.snaps,
+		SnapshotRepo: new(mockSnapshotRepository),
+		EventSvc:     m.events,
+		AuditSvc:     m.auditSvc,
+		Secrets:      m.secrets,
+		StorageSvc:   m.storage,
+		BackupRepo:   m.backups,
+		UpgradeRepo:  m.upgrades,
+		UserRepo:     m.users,
+		Logger:       slog.Default(),
+	})
+	return m, svc
+}
+
+// windowAround returns a maintenance window that opened offset before now.
+func windowAround(now time.Time, offset time.Duration) string {
+	start := now.UTC().Add(-offset)
+	return strings.ToLower(start.Weekday().String()[:3]) + ":" + start.Format("15:04")
+}
+
+func TestDatabaseServiceUpgradeDatabaseValidation(t *testing.T) {
+	ctx := appcontext.WithUserID(context.Background(), uuid.New())
+
+	tests := []struct {
+		name    string
+		db      *domain.Database
+		version string
+		errMsg  string
+	}{
+		{name: "Replica", db: &domain.Database{Role: domain.RoleReplica, Version: "15"}, version: "16", errMsg: "upgraded with their primary"},
+		{name: "MissingVersion", db: &domain.Database{Role: domain.RolePrimary, Version: "15"}, errMsg: "version is required"},
+		{name: "SameVersion", db: &domain.Database{Role: domain.RolePrimary, Version: "16"}, version: "16.0", errMsg: "already running version 16"},
+		{name: "Downgrade", db: &domain.Database{Role: domain.RolePrimary, Version: "8.4"}, version: "8.0", errMsg: "downgrades are not supported"},
+		{name: "NotRunning", db: &domain.Database{Role: domain.RolePrimary, Version: "15", Status: domain.DatabaseStatusStopped}, version: "16", errMsg: "must be running"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, svc := setupDatabaseMaintenanceTest()
+			tt.db.ID = uuid.New()
+			m.repo.On("GetByID", mock.Anything, tt.db.ID).Return(tt.db, nil).Once()
+
+			_, err := svc.UpgradeDatabase(ctx, ports.UpgradeDatabaseRequest{ID: tt.db.ID, Version: tt.version, ApplyImmediately: true})
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, errors.InvalidInput))
+			assert.Contains(t, err.Error(), tt.errMsg)
+			m.repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestDatabaseServiceUpgradeDatabaseWaitsForWindow(t *testing.T) {
+	m, svc := setupDatabaseMaintenanceTest()
+	ctx := appcontext.WithUserID(context.Background(), uuid.New())
+	db := &domain.Database{ID: uuid.New(), Role: domain.RolePrimary, Engine: domain.EnginePostgres, Version: "16.2", Status: domain.DatabaseStatusRunning}
+
+	m.repo.On("GetByID", mock.Anything, db.ID).Return(db, nil).Once()
+	m.repo.On("Update", mock.Anything, mock.MatchedBy(func(d *domain.Database) bool {
+		return d.PendingVersion == "16.3" && d.Version == "16.2"
+	})).Return(nil).Once()
+	m.events.On("RecordEvent", mock.Anything, "DATABASE_UPGRADE_SCHEDULED", db.ID.String(), "DATABASE", mock.Anything).Return(nil).Once()
+	m.auditSvc.On("Log", mock.Anything, mock.Anything, "database.upgrade_schedule", "database", db.ID.String(), mock.Anything).Return(nil).Once()
+
+	got, err := svc.UpgradeDatabase(ctx, ports.UpgradeDatabaseRequest{ID: db.ID, Version: "16.3"})
+	require.NoError(t, err)
+	assert.Equal(t, "16.3", got.PendingVersion)
+	m.compute.AssertNotCalled(t, "LaunchInstanceWithOptions", mock.Anything, mock.Anything)
+	m.upgrades.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestDatabaseServiceUpgradeDatabasePostgresMajorUpgradesReplicasFirst(t *testing.T) {
+	m, svc := setupDatabaseMaintenanceTest()
+	ctx := appcontext.WithUserID(context.Background(), uuid.New())
+	primaryID := uuid.New()
+	primary := &domain.Database{
+		ID: primaryID, Name: "orders", Username: "cloud", Password: "pw", Engine: domain.EnginePostgres, Version: "15",
+		Role: domain.RolePrimary, Status: domain.DatabaseStatusRunning, ContainerID: "primary-cid", Port: 30001,
+		Parameters: map[string]string{"max_connections": "200"},
+	}
+	replica := &domain.Database{
+		ID: uuid.New(), Name: "orders-ro", Username: "cloud", Password: "pw", Engine: domain.EnginePostgres, Version: "15",
+		Role: domain.RoleReplica, PrimaryID: &primaryID, Status: domain.DatabaseStatusRunning, ContainerID: "replica-cid", Port: 30002,
+	}
+	primaryVol := &domain.Volume{ID: uuid.New(), Name: "db-vol-" + primary.ID.String()[:8]}
+	replicaVol := &domain.Volume{ID: uuid.New(), Name: "db-replica-vol-" + replica.ID.String()[:8]}
+	snap := &domain.Snapshot{ID: uuid.New()}
+
+	m.repo.On("GetByID", mock.Anything, primary.ID).Return(primary, nil).Once()
+	m.upgrades.On("Create", mock.Anything, mock.MatchedBy(func(u *domain.DatabaseUpgrade) bool {
+		return u.Major && u.FromVersion == "15" && u.ToVersion == "16" && u.Status == domain.DatabaseUpgradeInProgress
+	})).Return(nil).Once()
+	m.volumes.On("ListVolumes", mock.Anything).Return([]*domain.Volume{primaryVol, replicaVol}, nil)
+	m.snaps.On("CreateSnapshot", mock.Anything, primaryVol.ID, mock.MatchedBy(func(name string) bool {
+		return strings.HasPrefix(name, "db-preupgrade-orders-")
+	})).Return(snap, nil).Once()
+	m.repo.On("ListReplicas", mock.Anything, primary.ID).Return([]*domain.Database{replica}, nil).Once()
+	m.compute.On("GetInstanceIP", mock.Anything, "primary-cid").Return("10.0.0.2", nil).Once()
+
+	var statuses []string
+	m.repo.On("Update", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		d := args.Get(1).(*domain.Database)
+		statuses = append(statuses, fmt.Sprintf("%s:%s:%s", d.Name, d.Status, d.Version))
+	}).Return(nil)
+
+	for _, member := range []*domain.Database{replica, primary} {
+		m.compute.On("StopInstance", mock.Anything, member.ContainerID).Return(nil).Once()
+		m.compute.On("DeleteInstance", mock.Anything, member.ContainerID).Return(nil).Once()
+	}
+	var launched []string
+	m.compute.On("LaunchInstanceWithOptions", mock.Anything, mock.MatchedBy(func(o ports.CreateInstanceOptions) bool {
+		return o.ImageName == "tianon/postgres-upgrade:15-to-16"
+	})).Run(func(args mock.Arguments) {
+		launched = append(launched, args.Get(1).(ports.CreateInstanceOptions).VolumeBinds[0])
+	}).Return("stager", nil, nil).Twice()
+	m.compute.On("Exec", mock.Anything, "stager", mock.MatchedBy(func(cmd []string) bool {
+		return len(cmd) == 3 && strings.Contains(cmd[2], "/usr/lib/postgresql/15/bin -B /usr/lib/postgresql/16/bin") &&
+			strings.Contains(cmd[2], "--link") && strings.Contains(cmd[2], "chown -R 70:70 .")
+	})).Return("", nil).Twice()
+	m.compute.On("DeleteInstance", mock.Anything, "stager").Return(nil).Twice()
+	m.compute.On("LaunchInstanceWithOptions", mock.Anything, mock.MatchedBy(func(o ports.CreateInstanceOptions) bool {
+		return o.ImageName == "postgres:16-alpine" && o.Ports[0] == "30002:5432" && strings.Contains(strings.Join(o.Env, ","), "PRIMARY_HOST=10.0.0.2")
+	})).Run(func(args mock.Arguments) {
+		launched = append(launched, "replica")
+	}).Return("replica-new", []string{"30002:5432"}, nil).Once()
+	m.compute.On("LaunchInstanceWithOptions", mock.Anything, mock.MatchedBy(func(o ports.CreateInstanceOptions) bool {
+		return o.ImageName == "postgres:16-alpine" && o.Ports[0] == "30001:5432" && strings.Contains(strings.Join(o.Cmd, " "), "max_connections=200")
+	})).Run(func(args mock.Arguments) {
+		launched = append(launched, "primary")
+	}).Return("primary-new", []string{"30001:5432"}, nil).Once()
+	m.compute.On("GetInstanceIP", mock.Anything, "replica-new").Return("10.0.0.3", nil).Once()
+	m.compute.On("GetInstanceIP", mock.Anything, "primary-new").Return("10.0.0.4", nil).Once()
+	m.upgrades.On("Update", mock.Anything, mock.MatchedBy(func(u *domain.DatabaseUpgrade) bool {
+		return u.Status == domain.DatabaseUpgradeCompleted && u.SnapshotID != nil && *u.SnapshotID == snap.ID && u.CompletedAt != nil
+	})).Return(nil).Once()
+	m.events.On("RecordEvent", mock.Anything, "DATABASE_UPGRADE", primary.ID.String(), "DATABASE", mock.Anything).Return(nil).Once()
+	m.auditSvc.On("Log", mock.Anything, mock.Anything, "database.upgrade", "database", primary.ID.String(), mock.Anything).Return(nil).Once()
+
+	got, err := svc.UpgradeDatabase(ctx, ports.UpgradeDatabaseRequest{ID: primary.ID, Version: "16", ApplyImmediately: true})
+	require.NoError(t, err)
+	assert.Equal(t, "16", got.Version)
+	assert.Equal(t, "primary-new", got.ContainerID)
+	assert.Equal(t, 30001, got.Port)
+	assert.Equal(t, "16", replica.Version)
+	assert.Equal(t, []string{
+		"thecloud-vol-" + replicaVol.ID.String()[:8] + ":/var/lib/postgresql/data", "replica",
+		"thecloud-vol-" + primaryVol.ID.String()[:8] + ":/var/lib/postgresql/data", "primary",
+	}, launched)
+	assert.Equal(t, []string{
+		"orders-ro:MAINTENANCE:15", "orders-ro:RUNNING:16",
+		"orders:MAINTENANCE:15", "orders:RUNNING:16",
+	}, statuses)
+	m.compute.AssertExpectations(t)
+	m.upgrades.AssertExpectations(t)
+}
+
+func TestDatabaseServiceUpgradeDatabaseMySQLMajorReloadsDump(t *testing.T) {
+	m, svc := setupDatabaseMaintenanceTest()
+	ctx := appcontext.WithUserID(context.Background(), uuid.New())
+	db := &domain.Database{
+		ID: uuid.New(), Name: "shop", Username: "root", Password: "pw", Engine: domain.EngineMySQL, Version: "8.0",
+		Role: domain.RolePrimary, Status: domain.DatabaseStatusRunning, ContainerID: "cid", Port: 30006,
+	}
+	vol := &domain.Volume{ID: uuid.New(), Name: "db-vol-" + db.ID.String()[:8]}
+	dump := []byte("CREATE DATABASE shop;")
+
+	m.repo.On("GetByID", mock.Anything, db.ID).Return(db, nil).Once()
+	m.upgrades.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+	m.volumes.On("ListVolumes", mock.Anything).Return([]*domain.Volume{vol}, nil)
+	m.snaps.On("CreateSnapshot", mock.Anything, vol.ID, mock.Anything).Return(&domain.Snapshot{ID: uuid.New()}, nil).Once()
+	m.repo.On("ListReplicas", mock.Anything, db.ID).Return([]*domain.Database{}, nil).Once()
+	m.repo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	m.compute.On("Exec", mock.Anything, "cid", mock.MatchedBy(func(cmd []string) bool {
+		return len(cmd) == 3 && strings.Contains(cmd[2], "MYSQL_PWD='pw' mysqldump -u root --single-transaction") && strings.Contains(cmd[2], "--databases shop")
+	})).Return(base64.StdEncoding.EncodeToString(dump), nil).Once()
+	m.compute.On("StopInstance", mock.Anything, "cid").Return(nil).Once()
+	m.compute.On("DeleteInstance", mock.Anything, "cid").Return(nil).Once()
+	m.compute.On("LaunchInstanceWithOptions", mock.Anything, mock.MatchedBy(func(o ports.CreateInstanceOptions) bool {
+		return o.ImageName == "mysql:8.0" && o.Name == "cloud-db-upgrade-"+db.ID.String()[:8]
+	})).Return("stager", nil, nil).Once()
+	m.compute.On("Exec", mock.Anything, "stager", []string{"sh", "-c", "find /var/lib/mysql -mindepth 1 -delete"}).Return("", nil).Once()
+	m.compute.On("DeleteInstance", mock.Anything, "stager").Return(nil).Once()
+	m.compute.On("LaunchInstanceWithOptions", mock.Anything, mock.MatchedBy(func(o ports.CreateInstanceOptions) bool {
+		return o.ImageName == "mysql:8.4" && o.Ports[0] == "30006:3306"
+	})).Return("new-cid", []string{"30006:3306"}, nil).Once()
+
+	var loaded []string
+	m.compute.On("Exec", mock.Anything, "new-cid", mock.Anything).Run(func(args mock.Arguments) {
+		loaded = append(loaded, args.Get(2).([]string)[2])
+	}).Return("", nil)
+	m.upgrades.On("Update", mock.Anything, mock.MatchedBy(func(u *domain.DatabaseUpgrade) bool {
+		return u.Status == domain.DatabaseUpgradeCompleted && u.Major
+	})).Return(nil).Once()
+	m.events.On("RecordEvent", mock.Anything, "DATABASE_UPGRADE", db.ID.String(), "DATABASE", mock.Anything).Return(nil).Once()
+	m.auditSvc.On("Log", mock.Anything, mock.Anything, "database.upgrade", "database", db.ID.String(), mock.Anything).Return(nil).Once()
+
+	got, err := svc.UpgradeDatabase(ctx, ports.UpgradeDatabaseRequest{ID: db.ID, Version: "8.4", ApplyImmediately: true})
+	require.NoError(t, err)
+	assert.Equal(t, "8.4", got.Version)
+	assert.Equal(t, domain.DatabaseStatusRunning, got.Status)
+	require.GreaterOrEqual(t, len(loaded), 3)
+	assert.Contains(t, loaded[0], "mysqladmin -u root ping")
+	assert.Contains(t, strings.Join(loaded, "\n"), base64.StdEncoding.EncodeToString(dump))
+	assert.Equal(t, "MYSQL_PWD='pw' mysql -u root < /tmp/cloud-db-upgrade.sql && rm -f /tmp/cloud-db-upgrade.sql", loaded[len(loaded)-1])
+	m.compute.AssertExpectations(t)
+}
+
+func TestDatabaseServiceUpgradeDatabaseMySQLMajorKeepsDatabasesAndUsers(t *testing.T) {
+	m, svc := setupDatabaseMaintenanceTest()
+	ctx := appcontext.WithUserID(context.Background(), uuid.New())
+	db := &domain.Database{
+		ID: uuid.New(), Name: "shop", Username: "root", Password: "pw", Engine: domain.EngineMySQL, Version: "8.0",
+		Role: domain.RolePrimary, Status: domain.DatabaseStatusRunning, ContainerID: "cid", Port: 30006,
+	}
+	vol := &domain.Volume{ID: uuid.New(), Name: "db-vol-" + db.ID.String()[:8]}
+	m.users.dbs = []*domain.LogicalDatabase{{DatabaseID: db.ID, Name: "orders"}, {DatabaseID: db.ID, Name: "reports"}}
+	m.users.users = []*domain.DatabaseUser{
+		{DatabaseID: db.ID, Username: "app", CredentialPath: "vault/app", Grants: []domain.DatabaseGrant{
+			{Database: "orders", Privilege: domain.DatabasePrivilegeReadWrite},
+			{Database: "shop", Privilege: domain.DatabasePrivilegeAll},
+		}},
+		{DatabaseID: db.ID, Username: "analyst", CredentialPath: "vault/analyst", Grants: []domain.DatabaseGrant{
+			{Database: "reports", Privilege: domain.DatabasePrivilegeReadOnly},
+		}},
+	}
+	m.secrets.On("GetSecret", mock.Anything, "vault/app").Return(map[string]interface{}{"password": "app-pw"}, nil).Once()
+	m.secrets.On("GetSecret", mock.Anything, "vault/analyst").Return(map[string]interface{}{"password": "analyst-pw"}, nil).Once()
+
+	m.repo.On("GetByID", mock.Anything, db.ID).Return(db, nil).Once()
+	m.upgrades.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+	m.volumes.On("ListVolumes", mock.Anything).Return([]*domain.Volume{vol}, nil)
+	m.snaps.On("CreateSnapshot", mock.Anything, vol.ID, mock.Anything).Return(&domain.Snapshot{ID: uuid.New()}, nil).Once()
+	m.repo.On("ListReplicas", mock.Anything, db.ID).Return([]*domain.Database{}, nil).Once()
+	m.repo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	m.compute.On("Exec", mock.Anything, "cid", mock.MatchedBy(func(cmd []string) bool {
+		return len(cmd) == 3 && strings.Contains(cmd[2], "--databases shop orders reports --result-file=")
+	})).Return(base64.StdEncoding.EncodeToString([]byte("CREATE DATABASE shop;")), nil).Once()
+	m.compute.On("StopInstance", mock.Anything, "cid").Return(nil).Once()
+	m.compute.On("DeleteInstance", mock.Anything, "cid").Return(nil).Once()
+	m.compute.On("LaunchInstanceWithOptions", mock.Anything, mock.MatchedBy(func(o ports.CreateInstanceOptions) bool {
+		return o.ImageName == "mysql:8.0"
+	})).Return("stager", nil, nil).Once()
+	m.compute.On("Exec", mock.Anything, "stager", mock.Anything).Return("", nil).Once()
+	m.compute.On("DeleteInstance", mock.Anything, "stager").Return(nil).Once()
+	m.compute.On("LaunchInstanceWithOptions", mock.Anything, mock.MatchedBy(func(o ports.CreateInstanceOptions) bool {
+		return o.ImageName == "mysql:8.4"
+	})).Return("new-cid", []string{"30006:3306"}, nil).Once()
+
+	var last []string
+	m.compute.On("Exec", mock.Anything, "new-cid", mock.Anything).Run(func(args mock.Arguments) {
+		last = args.Get(2).([]string)
+	}).Return("", nil)
+	m.upgrades.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+	m.events.On("RecordEvent", mock.Anything, "DATABASE_UPGRADE", db.ID.String(), "DATABASE", mock.Anything).Return(nil).Once()
+	m.auditSvc.On("Log", mock.Anything, mock.Anything, "database.upgrade", "database", db.ID.String(), mock.Anything).Return(nil).Once()
+
+	_, err := svc.UpgradeDatabase(ctx, ports.UpgradeDatabaseRequest{ID: db.ID, Version: "8.4", ApplyImmediately: true})
+	require.NoError(t, err)
+
+	// Users are recreated after the dump is loaded, in one admin call.
+	require.NotEmpty(t, last)
+	assert.Equal(t, "--execute", last[len(last)-2])
+	assert.Equal(t, strings.Join([]string{
+		"CREATE USER 'app'@'%' IDENTIFIED BY 'app-pw';",
+		"GRANT SELECT, INSERT, UPDATE, DELETE, SHOW VIEW, EXECUTE ON `orders`.* TO 'app'@'%';",
+		"GRANT ALL PRIVILEGES ON `shop`.* TO 'app'@'%';",
+		"CREATE USER 'analyst'@'%' IDENTIFIED BY 'analyst-pw';",
+		"GRANT SELECT, SHOW VIEW ON `reports`.* TO 'analyst'@'%';",
+	}, " "), last[len(last)-1])
+	m.compute.AssertExpectations(t)
+	m.secrets.AssertExpectations(t)
+}
+
+func TestDatabaseServiceUpgradeDatabaseFailureMarksDatabaseFailed(t *testing.T) {
+	m, svc := setupDatabaseMaintenanceTest()
+	ctx := appcontext.WithUserID(context.Background(), uuid.New())
+	db := &domain.Database{
+		ID: uuid.New(), Name: "orders", Engine: domain.EnginePostgres, Version: "16.2", Role: domain.RolePrimary,
+		Status: domain.DatabaseStatusRunning, ContainerID: "cid", Port: 30001, PendingVersion: "16.3",
+	}
+	vol := &domain.Volume{ID: uuid.New(), Name: "db-vol-" + db.ID.String()[:8]}
+
+	m.repo.On("GetByID", mock.Anything, db.ID).Return(db, nil).Once()
+	m.upgrades.On("Create", mock.Anything, mock.MatchedBy(func(u *domain.DatabaseUpgrade) bool { return !u.Major })).Return(nil).Once()
+	m.volumes.On("ListVolumes", mock.Anything).Return([]*domain.Volume{vol}, nil)
+	m.snaps.On("CreateSnapshot", mock.Anything, vol.ID, mock.Anything).Return(&domain.Snapshot{ID: uuid.New()}, nil).Once()
+	m.repo.On("ListReplicas", mock.Anything, db.ID).Return([]*domain.Database{}, nil).Once()
+	m.repo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	m.compute.On("StopInstance", mock.Anything, "cid").Return(nil).Once()
+	m.compute.On("DeleteInstance", mock.Anything, "cid").Return(nil).Once()
+	m.compute.On("LaunchInstanceWithOptions", mock.Anything, mock.Anything).Return("", nil, fmt.Errorf("image not found")).Once()
+	m.upgrades.On("Update", mock.Anything, mock.MatchedBy(func(u *domain.DatabaseUpgrade) bool {
+		return u.Status == domain.DatabaseUpgradeFailed && strings.Contains(u.Error, "failed to launch database container")
+	})).Return(nil).Once()
+	m.events.On("RecordEvent", mock.Anything, "DATABASE_MAINTENANCE_FAILED", db.ID.String(), "DATABASE", mock.Anything).Return(nil).Once()
+
+	_, err := svc.UpgradeDatabase(ctx, ports.UpgradeDatabaseRequest{ID: db.ID, Version: "16.3", ApplyImmediately: true})
+	require.Error(t, err)
+	assert.Equal(t, domain.DatabaseStatusFailed, db.Status)
+	assert.Empty(t, db.PendingVersion)
+	m.upgrades.AssertExpectations(t)
+	m.auditSvc.AssertNotCalled(t, "Log", mock.Anything, mock.Anything, "database.upgrade", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDatabaseServiceRunMaintenanceAppliesPendingParametersInWindow(t *testing.T) {
+	m, svc := setupDatabaseMaintenanceTest()
+	now := time.Now()
+	tenantID := uuid.New()
+	due := &domain.Database{
+		ID: uuid.New(), TenantID: tenantID, UserID: uuid.New(), Name: "due", Engine: domain.EnginePostgres, Version: "16",
+		Role: domain.RolePrimary, Status: domain.DatabaseStatusRunning, ContainerID: "cid", Port: 30001,
+		MaintenanceWindow: windowAround(now, 10*time.Minute), Parameters: map[string]string{"work_mem": "4MB"},
+		PendingParameters: map[string]string{"work_mem": "64MB"},
+	}
+	later := &domain.Database{
+		ID: uuid.New(), Name: "later", Engine: domain.EnginePostgres, Version: "16", Role: domain.RolePrimary,
+		Status: domain.DatabaseStatusRunning, ContainerID: "other", MaintenanceWindow: windowAround(now, -3*time.Hour),
+		PendingVersion: "16.4",
+	}
+	vol := &domain.Volume{ID: uuid.New(), Name: "db-vol-" + due.ID.String()[:8]}
+
+	m.repo.On("ListPendingMaintenance", mock.Anything).Return([]*domain.Database{later, due}, nil).Once()
+	m.volumes.On("ListVolumes", mock.MatchedBy(func(ctx context.Context) bool {
+		return appcontext.TenantIDFromContext(ctx) == tenantID && appcontext.UserIDFromContext(ctx) == due.UserID
+	})).Return([]*domain.Volume{vol}, nil)
+	m.repo.On("Update", mock.Anything, mock.Anything).Return(nil)
+	m.compute.On("StopInstance", mock.Anything, "cid").Return(nil).Once()
+	m.compute.On("DeleteInstance", mock.Anything, "cid").Return(nil).Once()
+	m.compute.On("LaunchInstanceWithOptions", mock.Anything, mock.MatchedBy(func(o ports.CreateInstanceOptions) bool {
+		return o.ImageName == "postgres:16-alpine" && strings.Contains(strings.Join(o.Cmd, " "), "work_mem=64MB")
+	})).Return("new-cid", []string{"30001:5432"}, nil).Once()
+	m.compute.On("GetInstanceIP", mock.Anything, "new-cid").Return("10.0.0.9", nil).Once()
+	m.events.On("RecordEvent", mock.Anything, "DATABASE_MAINTENANCE", due.ID.String(), "DATABASE", mock.Anything).Return(nil).Once()
+	m.auditSvc.On("Log", mock.Anything, due.UserID, "database.maintenance", "database", due.ID.String(), mock.Anything).Return(nil).Once()
+
+	n, err := svc.RunMaintenance(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, "64MB", due.Parameters["work_mem"])
+	assert.Nil(t, due.PendingParameters)
+	assert.Equal(t, "16.4", later.PendingVersion)
+	m.compute.AssertNotCalled(t, "StopInstance", mock.Anything, "other")
+	m.upgrades.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	m.compute.AssertExpectations(t)
+}
+
+func TestDatabaseServiceModifyDatabaseDefersParameters(t *testing.T) {
+	m, svc := setupDatabaseMaintenanceTest()
+	ctx := appcontext.WithUserID(context.Background(), uuid.New())
+	db := &domain.Database{
+		ID: uuid.New(), Engine: domain.EnginePostgres, Version: "16", Role: domain.RolePrimary, Status: domain.DatabaseStatusRunning,
+		ContainerID: "cid", Parameters: map[string]string{"work_mem": "4MB"}, MaintenanceWindow: domain.DefaultMaintenanceWindow,
+	}
+	window := "Wed:22:30"
+
+	m.repo.On("GetByID", mock.Anything, db.ID).Return(db, nil).Once()
+	m.compute.On("Type").Return("docker")
+	m.compute.On("GetInstanceIP", mock.Anything, "cid").Return("10.0.0.2", nil).Once()
+	m.repo.On("Update", mock.Anything, mock.MatchedBy(func(d *domain.Database) bool {
+		return d.PendingParameters["work_mem"] == "64MB" && d.Parameters["work_mem"] == "4MB" && d.MaintenanceWindow == "wed:22:30"
+	})).Return(nil).Once()
+	m.events.On("RecordEvent", mock.Anything, "DATABASE_MODIFY", db.ID.String(), "DATABASE", mock.Anything).Return(nil).Once()
+	m.auditSvc.On("Log", mock.Anything, mock.Anything, "database.modify", "database", db.ID.String(), mock.Anything).Return(nil).Once()
+
+	_, err := svc.ModifyDatabase(ctx, ports.ModifyDatabaseRequest{
+		ID: db.ID, Parameters: map[string]string{"work_mem": "64MB"}, MaintenanceWindow: &window,
+	})
+	require.NoError(t, err)
+	m.compute.AssertNotCalled(t, "LaunchInstanceWithOptions", mock.Anything, mock.Anything)
+
+	bad := "someday"
+	m.repo.On("GetByID", mock.Anything, db.ID).Return(db, nil).Once()
+	_, err = svc.ModifyDatabase(ctx, ports.ModifyDatabaseRequest{ID: db.ID, MaintenanceWindow: &bad})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.InvalidInput))
+}
@@ -0,0 +1,716 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/errors"
+)
+
+// Point-in-time recovery.
+//
+// PostgreSQL copies every completed WAL segment into pgArchiveDir on the data
+// volume (archive_command) and switches segments at least once a minute
+// (archive_timeout). MySQL and MariaDB write binary logs into their data
+// directory. The backup worker periodically ships closed files to the
+// database's bucket, records them, takes a daily base backup (volume snapshot)
+// and prunes what fell out of the retention window.
+//
+// A restore picks the newest base backup taken before the target time and
+// replays the logs archived after it: PostgreSQL through restore_command and
+// recovery_target_time, MySQL and MariaDB through mysqlbinlog from the binary
+// log position recorded with the base backup up to --stop-datetime.
+const (
+	pgArchiveDir     = "/var/lib/postgresql/data/pitr_archive"
+	pgArchivePaused  = "/var/lib/postgresql/data/pitr_paused"
+	pgRestoreDir     = "/var/lib/postgresql/data/pitr_restore"
+	pgRecoverySignal = "/var/lib/postgresql/data/recovery.signal"
+	mysqlDataDir     = "/var/lib/mysql"
+	mysqlBinlogName  = "binlog"
+	mysqlRestoreDir  = "/tmp/pitr_restore"
+	mysqlBaseLockLog = "/tmp/pitr_base_lock"
+
+	// pgArchiveTimeout bounds how stale the latest restorable time can get on an idle PostgreSQL database.
+	pgArchiveTimeout = "60"
+	// pitrStagingChunk is the number of base64 characters written per exec; exec
+	// arguments are limited to 128KiB each. It must be a multiple of 4.
+	pitrStagingChunk = 96 * 1024
+	// mysqlReadyAttempts bounds the wait for a restored MySQL server to accept connections.
+	mysqlReadyAttempts = 30
+	// mysqlBaseLockSeconds bounds how long a base backup can block commits on
+	// MySQL if the lock is never released.
+	mysqlBaseLockSeconds = 300
+)
+
+// pointInTimePlan is the base backup and logs a point-in-time restore replays.
+type pointInTimePlan struct {
+	source     *domain.Database
+	snapshotID uuid.UUID
+	baseTime   time.Time
+	// baseLogFile and baseLogPosition are where MySQL replay starts.
+	baseLogFile     string
+	baseLogPosition int64
+	logs            []*domain.DatabaseBackup
+	password        string
+}
+
+// archivedFile is a closed log file found in a database container.
+type archivedFile struct {
+	name     string
+	size     int64
+	closedAt time.Time
+}
+
+func (s *DatabaseService) validateBackupSettings(ctx context.Context, retentionDays int, bucket string) error {
+	if retentionDays < 0 || retentionDays > domain.MaxDatabaseBackupRetentionDays {
+		return errors.New(errors.InvalidInput, fmt.Sprintf("backup retention must be between 0 and %d days", domain.MaxDatabaseBackupRetentionDays))
+	}
+	if retentionDays == 0 {
+		if bucket != "" {
+			return errors.New(errors.InvalidInput, "a backup bucket requires a positive backup retention")
+		}
+		return nil
+	}
+	if bucket == "" {
+		return errors.New(errors.InvalidInput, "point-in-time recovery requires a backup bucket")
+	}
+	if s.storageSvc == nil || s.backupRepo == nil {
+		return errors.New(errors.InvalidInput, "point-in-time recovery is not available on this deployment")
+	}
+	if _, err := s.storageSvc.GetBucket(ctx, bucket); err != nil {
+		return err
+	}
+	return nil
+}
+
+// setBackupRetention changes the retention window of a database. Archiving can
+// only be configured at creation because it changes how the engine is started;
+// setting the retention to zero pauses it and removes the automated backups.
+func (s *DatabaseService) setBackupRetention(ctx context.Context, db *domain.Database, days int) error {
+	if days < 0 || days > domain.MaxDatabaseBackupRetentionDays {
+		return errors.New(errors.InvalidInput, fmt.Sprintf("backup retention must be between 0 and %d days", domain.MaxDatabaseBackupRetentionDays))
+	}
+	if db.BackupBucket == "" {
+		return errors.New(errors.InvalidInput, "point-in-time recovery can only be enabled when the database is created")
+	}
+	if db.Engine == domain.EnginePostgres && db.ContainerID != "" {
+		cmd := []string{"rm", "-f", pgArchivePaused}
+		if days == 0 {
+			cmd = []string{"touch", pgArchivePaused}
+		}
+		if _, err := s.compute.Exec(ctx, db.ContainerID, cmd); err != nil {
+			return errors.Wrap(errors.Internal, "failed to update WAL archiving", err)
+		}
+	}
+	if days == 0 {
+		s.purgeBackups(ctx, db)
+	}
+	db.BackupRetentionDays = days
+	return nil
+}
+
+// withArchiveParameters adds the engine settings that enable log archiving to
+// the user's parameters when point-in-time recovery is configured.
+func (s *DatabaseService) withArchiveParameters(db *domain.Database, parameters map[string]string) map[string]string {
+	if db.BackupBucket == "" || db.Role == domain.RoleReplica {
+		return parameters
+	}
+	switch db.Engine {
+	case domain.EnginePostgres:
+		return mergeParameters(parameters, map[string]string{
+			"archive_mode":    "on",
+			"archive_command": fmt.Sprintf("test -f %s || (mkdir -p %s && cp %%p %s/%%f)", pgArchivePaused, pgArchiveDir, pgArchiveDir),
+			"archive_timeout": pgArchiveTimeout,
+		})
+	case domain.EngineMySQL, domain.EngineMariaDB:
+		return mergeParameters(parameters, map[string]string{"log-bin": mysqlBinlogName})
+	}
+	return parameters
+}
+
+func postgresRecoveryParameters(target time.Time) map[string]string {
+	return map[string]string{
+		"restore_command":        fmt.Sprintf("cp %s/%%f %%p", pgRestoreDir),
+		"recovery_target_time":   target.UTC().Format("2006-01-02 15:04:05.999999") + "+00",
+		"recovery_target_action": "promote",
+	}
+}
+
+// mergeParameters returns base overlaid with extra, leaving both untouched.
+func mergeParameters(base, extra map[string]string) map[string]string {
+	merged := make(map[string]string, len(base)+len(extra))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range extra {
+		merged[k] = v
+	}
+	return merged
+}
+
+// restorableWindow derives the range a database can be restored to from its
+// backups, which must be ordered oldest first. The window opens at the oldest
+// base backup, or at the retention cutoff when an older base covers it, and
+// closes at the newest archived log or base backup.
+func restorableWindow(backups []*domain.DatabaseBackup, retentionDays int, now time.Time) (*time.Time, *time.Time) {
+	var earliest, latest time.Time
+	for _, b := range backups {
+		if b.Kind == domain.DatabaseBackupBase && earliest.IsZero() {
+			earliest = b.ArchivedAt
+		}
+		if !earliest.IsZero() && b.ArchivedAt.After(latest) {
+			latest = b.ArchivedAt
+		}
+	}
+	if earliest.IsZero() {
+		return nil, nil
+	}
+	if cutoff := now.AddDate(0, 0, -retentionDays); cutoff.After(earliest) {
+		earliest = cutoff
+	}
+	if latest.Before(earliest) {
+		return nil, nil
+	}
+	return &earliest, &latest
+}
+
+func (s *DatabaseService) populateRestorableWindow(ctx context.Context, db *domain.Database, now time.Time) {
+	if !db.PITREnabled() || s.backupRepo == nil {
+		return
+	}
+	backups, err := s.backupRepo.ListByDatabase(ctx, db.ID)
+	if err != nil {
+		s.logger.Warn("failed to load database backups", "database_id", db.ID, "error", err)
+		return
+	}
+	db.EarliestRestorableTime, db.LatestRestorableTime = restorableWindow(backups, db.BackupRetentionDays, now)
+}
+
+// planPointInTimeRestore validates a point-in-time restore request, fills in
+// the engine and version of the source and selects the backups to replay.
+func (s *DatabaseService) planPointInTimeRestore(ctx context.Context, req *ports.RestoreDatabaseRequest) (*pointInTimePlan, error) {
+	if req.SourceDatabaseID == nil {
+		return nil, errors.New(errors.InvalidInput, "a point-in-time restore requires a source database")
+	}
+	source, err := s.repo.GetByID(ctx, *req.SourceDatabaseID)
+	if err != nil {
+		return nil, err
+	}
+	if !source.PITREnabled() || s.backupRepo == nil {
+		return nil, errors.New(errors.InvalidInput, "point-in-time recovery is not enabled for the source database")
+	}
+	if req.Engine == "" {
+		req.Engine = string(source.Engine)
+	} else if domain.DatabaseEngine(req.Engine) != source.Engine {
+		return nil, errors.New(errors.InvalidInput, "a point-in-time restore must use the engine of the source database")
+	}
+	if req.Version == "" {
+		req.Version = source.Version
+	}
+
+	backups, err := s.backupRepo.ListByDatabase(ctx, source.ID)
+	if err != nil {
+		return nil, err
+	}
+	target := *req.RestoreTime
+	earliest, latest := restorableWindow(backups, source.BackupRetentionDays, time.Now())
+	if earliest == nil || target.Before(*earliest) || target.After(*latest) {
+		if earliest == nil {
+			return nil, errors.New(errors.InvalidInput, "the source database has no restorable backups yet")
+		}
+		return nil, errors.New(errors.InvalidInput, fmt.Sprintf("restore time must be between %s and %s",
+			earliest.UTC().Format(time.RFC3339), latest.UTC().Format(time.RFC3339)))
+	}
+
+	plan := &pointInTimePlan{source: source, password: s.databasePassword(ctx, source)}
+	for _, b := range backups {
+		if b.Kind == domain.DatabaseBackupBase && b.SnapshotID != nil && !b.ArchivedAt.After(target) {
+			plan.snapshotID, plan.baseTime, plan.logs = *b.SnapshotID, b.ArchivedAt, nil
+			plan.baseLogFile, plan.baseLogPosition = b.FileName, b.LogPosition
+		}
+	}
+	if plan.snapshotID == uuid.Nil {
+		return nil, errors.New(errors.InvalidInput, "no base backup precedes the restore time")
+	}
+	for _, b := range backups {
+		// The binary log the base ends in may have been closed within the
+		// same second, which file modification times cannot tell apart.
+		if b.Kind != domain.DatabaseBackupLog || (b.ArchivedAt.Before(plan.baseTime) && b.FileName != plan.baseLogFile) {
+			continue
+		}
+		plan.logs = append(plan.logs, b)
+		if !b.ArchivedAt.Before(target) {
+			break
+		}
+	}
+	return plan, nil
+}
+
+// stagePostgresRecovery puts the WAL to replay and recovery.signal on a
+// restored volume before PostgreSQL first starts on it.
+func (s *DatabaseService) stagePostgresRecovery(ctx context.Context, db *domain.Database, vol *domain.Volume, plan *pointInTimePlan) error {
+	return s.withStagingContainer(ctx, db, vol, "pitr", func(stagerID string) error {
+		// Segments the source had not shipped yet belong to its history, not ours.
+		prepare := fmt.Sprintf("rm -rf %s %s %s && mkdir -p %s", pgArchiveDir, pgArchivePaused, pgRestoreDir, pgRestoreDir)
+		if _, err := s.compute.Exec(ctx, stagerID, []string{"sh", "-c", prepare}); err != nil {
+			return errors.Wrap(errors.Internal, "failed to prepare WAL restore directory", err)
+		}
+		if err := s.stageLogs(ctx, stagerID, plan, pgRestoreDir); err != nil {
+			return err
+		}
+		finish := fmt.Sprintf("touch %s && chown -R postgres:postgres %s %s", pgRecoverySignal, pgRestoreDir, pgRecoverySignal)
+		if _, err := s.compute.Exec(ctx, stagerID, []string{"sh", "-c", finish}); err != nil {
+			return errors.Wrap(errors.Internal, "failed to enable recovery mode", err)
+		}
+		return nil
+	})
+}
+
+// withStagingContainer mounts vol at the engine's data directory in a
+// short-lived, idle container of the database's image and runs fn against it.
+func (s *DatabaseService) withStagingContainer(ctx context.Context, db *domain.Database, vol *domain.Volume, purpose string, fn func(containerID string) error) error {
+	imageName, _, _ := s.getEngineConfig(db.Engine, db.Version, db.Username, "", db.Name, db.Role, "")
+	return s.withStagingImage(ctx, db, vol, purpose, imageName, fn)
+}
+
+// withStagingImage is withStagingContainer with an explicit image.
+func (s *DatabaseService) withStagingImage(ctx context.Context, db *domain.Database, vol *domain.Volume, purpose, imageName string, fn func(containerID string) error) error {
+	stagerID, _, err := s.compute.LaunchInstanceWithOptions(ctx, ports.CreateInstanceOptions{
+		Name:        fmt.Sprintf("cloud-db-%s-%s", purpose, db.ID.String()[:8]),
+		ImageName:   imageName,
+		VolumeBinds: []string{fmt.Sprintf("%s:%s", s.getBackendVolName(vol), s.getMountPath(db.Engine))},
+		Cmd:         []string{"tail", "-f", "/dev/null"},
+	})
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to launch staging container", err)
+	}
+	defer func() {
+		if err := s.compute.DeleteInstance(ctx, stagerID); err != nil {
+			s.logger.Warn("failed to delete staging container", "container_id", stagerID, "error", err)
+		}
+	}()
+	return fn(stagerID)
+}
+
+// replayMySQLBinlogs applies the binary logs archived after the base backup,
+// up to the target time, to a freshly restored MySQL server. Replay starts at
+// the position recorded with the base backup, so no event the snapshot
+// already contains is applied twice.
+func (s *DatabaseService) replayMySQLBinlogs(ctx context.Context, db *domain.Database, plan *pointInTimePlan, target time.Time) error {
+	if err := s.waitForMySQL(ctx, db, plan.password); err != nil {
+		return errors.Wrap(errors.Internal, "restored database did not become ready", err)
+	}
+	auth := "MYSQL_PWD='" + sqlStringLiteral(plan.password) + "'"
+	if len(plan.logs) == 0 {
+		return nil
+	}
+	const layout = "2006-01-02 15:04:05"
+	// Base backups taken before positions were recorded only have a time.
+	start := fmt.Sprintf("--start-datetime='%s'", plan.baseTime.UTC().Format(layout))
+	if plan.baseLogFile != "" {
+		// --start-position applies to the first file named.
+		if plan.logs[0].FileName != plan.baseLogFile {
+			return errors.New(errors.Internal, "binary log "+plan.baseLogFile+" of the base backup was not archived")
+		}
+		start = fmt.Sprintf("--start-position=%d", plan.baseLogPosition)
+	}
+
+	if _, err := s.compute.Exec(ctx, db.ContainerID, []string{"mkdir", "-p", mysqlRestoreDir}); err != nil {
+		return errors.Wrap(errors.Internal, "failed to prepare binlog restore directory", err)
+	}
+	if err := s.stageLogs(ctx, db.ContainerID, plan, mysqlRestoreDir); err != nil {
+		return err
+	}
+	files := make([]string, 0, len(plan.logs))
+	for _, l := range plan.logs {
+		files = append(files, mysqlRestoreDir+"/"+l.FileName)
+	}
+	replay := fmt.Sprintf("%s %s --stop-datetime='%s' %s | %s %s -u root && rm -rf %s",
+		mysqlTool(db.Engine, "mysqlbinlog"), start, target.UTC().Format(layout), strings.Join(files, " "),
+		auth, mysqlTool(db.Engine, "mysql"), mysqlRestoreDir)
+	if _, err := s.compute.Exec(ctx, db.ContainerID, []string{"sh", "-c", replay}); err != nil {
+		return errors.Wrap(errors.Internal, "failed to replay binary logs", err)
+	}
+	return nil
+}
+
+// waitForMySQL waits until a freshly started MySQL server accepts connections.
+// The container is up well before mysqld finishes initializing its data directory.
+func (s *DatabaseService) waitForMySQL(ctx context.Context, db *domain.Database, password string) error {
+	ping := []string{"sh", "-c", "MYSQL_PWD='" + sqlStringLiteral(password) + "' " + mysqlTool(db.Engine, "mysqladmin") + " -u root ping"}
+	var err error
+	for i := 0; i < mysqlReadyAttempts; i++ {
+		if _, err = s.compute.Exec(ctx, db.ContainerID, ping); err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+	return err
+}
+
+// stageLogs downloads the plan's logs from the source's bucket into dir inside a container.
+func (s *DatabaseService) stageLogs(ctx context.Context, containerID string, plan *pointInTimePlan, dir string) error {
+	for _, l := range plan.logs {
+		rc, _, err := s.storageSvc.Download(ctx, plan.source.BackupBucket, l.ObjectKey)
+		if err != nil {
+			return errors.Wrap(errors.Internal, "failed to download archived log "+l.FileName, err)
+		}
+		var buf bytes.Buffer
+		_, err = buf.ReadFrom(rc)
+		_ = rc.Close()
+		if err != nil {
+			return errors.Wrap(errors.Internal, "failed to read archived log "+l.FileName, err)
+		}
+		if err := s.writeContainerFile(ctx, containerID, dir+"/"+l.FileName, buf.Bytes()); err != nil {
+			return errors.Wrap(errors.Internal, "failed to stage archived log "+l.FileName, err)
+		}
+	}
+	return nil
+}
+
+// writeContainerFile writes data to path inside a container in base64 chunks
+// small enough for a single exec argument.
+func (s *DatabaseService) writeContainerFile(ctx context.Context, containerID, path string, data []byte) error {
+	if _, err := s.compute.Exec(ctx, containerID, []string{"sh", "-c", fmt.Sprintf(": > '%s'", path)}); err != nil {
+		return err
+	}
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for start := 0; start < len(encoded); start += pitrStagingChunk {
+		chunk := encoded[start:min(start+pitrStagingChunk, len(encoded))]
+		cmd := fmt.Sprintf("printf '%%s' '%s' | base64 -d >> '%s'", chunk, path)
+		if _, err := s.compute.Exec(ctx, containerID, []string{"sh", "-c", cmd}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readContainerFile reads a binary file from a container.
+func (s *DatabaseService) readContainerFile(ctx context.Context, containerID, path string) ([]byte, error) {
+	out, err := s.compute.Exec(ctx, containerID, []string{"base64", path})
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(strings.Join(strings.Fields(out), ""))
+}
+
+func (s *DatabaseService) ArchiveDatabaseLogs(ctx context.Context) (int, error) {
+	if s.storageSvc == nil || s.backupRepo == nil {
+		return 0, nil
+	}
+	dbs, err := s.repo.ListWithBackupRetention(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	archived := 0
+	for _, db := range dbs {
+		if db.Role != domain.RolePrimary || db.Status != domain.DatabaseStatusRunning || db.ContainerID == "" {
+			continue
+		}
+		// Archive as the owner, who must still be able to write to the bucket.
+		ownerCtx := appcontext.WithTenantID(appcontext.WithUserID(ctx, db.UserID), db.TenantID)
+		n, err := s.archiveDatabase(ownerCtx, db, now)
+		archived += n
+		if err != nil {
+			s.logger.Warn("database log archiving failed", "database_id", db.ID, "error", err)
+		}
+	}
+	return archived, nil
+}
+
+func (s *DatabaseService) archiveDatabase(ctx context.Context, db *domain.Database, now time.Time) (int, error) {
+	backups, err := s.backupRepo.ListByDatabase(ctx, db.ID)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.ensureBaseBackup(ctx, db, backups, now); err != nil {
+		return 0, err
+	}
+
+	var n int
+	switch db.Engine {
+	case domain.EnginePostgres:
+		n, err = s.shipPostgresWAL(ctx, db)
+	case domain.EngineMySQL, domain.EngineMariaDB:
+		n, err = s.shipMySQLBinlogs(ctx, db, backups)
+	}
+	if err != nil {
+		return n, err
+	}
+	s.pruneBackups(ctx, db, backups, now)
+	return n, nil
+}
+
+func (s *DatabaseService) ensureBaseBackup(ctx context.Context, db *domain.Database, backups []*domain.DatabaseBackup, now time.Time) error {
+	for i := len(backups) - 1; i >= 0; i-- {
+		if backups[i].Kind == domain.DatabaseBackupBase {
+			if now.Sub(backups[i].ArchivedAt) < domain.DatabaseBaseBackupInterval {
+				return nil
+			}
+			break
+		}
+	}
+
+	vol, err := s.getVolumeForDatabase(ctx, db)
+	if err != nil {
+		return err
+	}
+	base := &domain.DatabaseBackup{
+		ID:         uuid.New(),
+		DatabaseID: db.ID,
+		TenantID:   db.TenantID,
+		Kind:       domain.DatabaseBackupBase,
+		CreatedAt:  now,
+	}
+	unlock := func() {}
+	if db.Engine == domain.EngineMySQL || db.Engine == domain.EngineMariaDB {
+		if unlock, err = s.lockMySQLForBaseBackup(ctx, db, base); err != nil {
+			return err
+		}
+	}
+	snap, err := s.snapshotSvc.CreateSnapshot(ctx, vol.ID, fmt.Sprintf("db-pitr-base-%s-%s", db.Name, now.Format("20060102150405")))
+	unlock()
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to take base backup", err)
+	}
+	base.SnapshotID, base.ArchivedAt = &snap.ID, snap.CreatedAt
+	return s.backupRepo.Create(ctx, base)
+}
+
+// lockMySQLForBaseBackup blocks commits on a MySQL or MariaDB server with a
+// global read lock, held by a background session, and records the binary log
+// position it stopped at on base. Nothing the snapshot misses is before that
+// position and nothing it contains is after it. The returned function
+// releases the lock; the session also gives it up after mysqlBaseLockSeconds.
+func (s *DatabaseService) lockMySQLForBaseBackup(ctx context.Context, db *domain.Database, base *domain.DatabaseBackup) (func(), error) {
+	auth := "MYSQL_PWD='" + sqlStringLiteral(s.databasePassword(ctx, db)) + "'"
+	mysql := mysqlTool(db.Engine, "mysql")
+	status := "SHOW MASTER STATUS"
+	if db.Engine == domain.EngineMySQL {
+		// MySQL 8.4 removed SHOW MASTER STATUS.
+		status = "SELECT LOCAL->>'$.binary_log_file', LOCAL->>'$.binary_log_position' FROM performance_schema.log_status"
+	}
+	query := fmt.Sprintf("SELECT CONNECTION_ID(); FLUSH TABLES WITH READ LOCK; %s; DO SLEEP(%d);", status, mysqlBaseLockSeconds)
+	// The session prints its ID, then the position once it holds the lock.
+	lock := fmt.Sprintf(`: > %[1]s; (%[2]s %[3]s -u root -N -B --unbuffered --execute="%[4]s" > %[1]s 2>&1 &); `+
+		`i=0; while [ "$(wc -l < %[1]s)" -lt 2 ] && [ $i -lt 100 ]; do sleep 0.1; i=$((i+1)); done; cat %[1]s`,
+		mysqlBaseLockLog, auth, mysql, query)
+	out, err := s.compute.Exec(ctx, db.ContainerID, []string{"sh", "-c", lock})
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to lock database for base backup", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	sessionID, err := strconv.ParseUint(strings.TrimSpace(lines[0]), 10, 64)
+	if err != nil {
+		return nil, errors.New(errors.Internal, "failed to lock database for base backup: "+out)
+	}
+	unlock := func() {
+		kill := fmt.Sprintf("%s %s -u root --execute='KILL %d;'", auth, mysql, sessionID)
+		if _, err := s.compute.Exec(ctx, db.ContainerID, []string{"sh", "-c", kill}); err != nil {
+			s.logger.Warn("failed to release base backup lock", "database_id", db.ID, "error", err)
+		}
+	}
+	var fields []string
+	if len(lines) > 1 {
+		fields = strings.Fields(lines[1])
+	}
+	if len(fields) < 2 {
+		unlock()
+		return nil, errors.New(errors.Internal, "failed to read binary log position for base backup: "+out)
+	}
+	position, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		unlock()
+		return nil, errors.New(errors.Internal, "failed to read binary log position for base backup: "+out)
+	}
+	base.FileName, base.LogPosition = fields[0], position
+	return unlock, nil
+}
+
+func (s *DatabaseService) shipPostgresWAL(ctx context.Context, db *domain.Database) (int, error) {
+	files, err := s.listContainerFiles(ctx, db.ContainerID, fmt.Sprintf("cd %s 2>/dev/null && stat -c '%%n %%s %%Y' * 2>/dev/null || true", pgArchiveDir))
+	if err != nil {
+		return 0, err
+	}
+	shipped := 0
+	for _, f := range files {
+		if err := s.shipLogFile(ctx, db, "wal", pgArchiveDir, f); err != nil {
+			return shipped, err
+		}
+		if _, err := s.compute.Exec(ctx, db.ContainerID, []string{"rm", "-f", pgArchiveDir + "/" + f.name}); err != nil {
+			return shipped, errors.Wrap(errors.Internal, "failed to remove archived WAL segment", err)
+		}
+		shipped++
+	}
+	return shipped, nil
+}
+
+// shipMySQLBinlogs rotates the binary log, ships every closed file not yet
+// archived and purges them from the server.
+func (s *DatabaseService) shipMySQLBinlogs(ctx context.Context, db *domain.Database, backups []*domain.DatabaseBackup) (int, error) {
+	auth := "MYSQL_PWD='" + sqlStringLiteral(s.databasePassword(ctx, db)) + "'"
+	if _, err := s.compute.Exec(ctx, db.ContainerID, []string{"sh", "-c", auth + " " + mysqlTool(db.Engine, "mysql") + " -u root --execute='FLUSH BINARY LOGS;'"}); err != nil {
+		return 0, errors.Wrap(errors.Internal, "failed to rotate binary log", err)
+	}
+	files, err := s.listContainerFiles(ctx, db.ContainerID, fmt.Sprintf("cd %s && stat -c '%%n %%s %%Y' %s.[0-9]* 2>/dev/null || true", mysqlDataDir, mysqlBinlogName))
+	if err != nil {
+		return 0, err
+	}
+	if len(files) < 2 {
+		return 0, nil
+	}
+	// The newest file is the one the server is writing to.
+	active := files[len(files)-1]
+	files = files[:len(files)-1]
+
+	archived := make(map[string]bool, len(backups))
+	for _, b := range backups {
+		if b.Kind == domain.DatabaseBackupLog {
+			archived[b.FileName] = true
+		}
+	}
+	shipped := 0
+	for _, f := range files {
+		if archived[f.name] {
+			continue
+		}
+		if err := s.shipLogFile(ctx, db, "binlog", mysqlDataDir, f); err != nil {
+			return shipped, err
+		}
+		shipped++
+	}
+	purge := fmt.Sprintf("%s %s -u root --execute=\"PURGE BINARY LOGS TO '%s';\"", auth, mysqlTool(db.Engine, "mysql"), active.name)
+	if _, err := s.compute.Exec(ctx, db.ContainerID, []string{"sh", "-c", purge}); err != nil {
+		s.logger.Warn("failed to purge archived binary logs", "database_id", db.ID, "error", err)
+	}
+	return shipped, nil
+}
+
+func (s *DatabaseService) shipLogFile(ctx context.Context, db *domain.Database, kind, dir string, f archivedFile) error {
+	data, err := s.readContainerFile(ctx, db.ContainerID, dir+"/"+f.name)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to read log file "+f.name, err)
+	}
+	key := fmt.Sprintf("databases/%s/%s/%s", db.ID, kind, f.name)
+	if _, err := s.storageSvc.Upload(ctx, db.BackupBucket, key, bytes.NewReader(data), ""); err != nil {
+		return err
+	}
+	return s.backupRepo.Create(ctx, &domain.DatabaseBackup{
+		ID:         uuid.New(),
+		DatabaseID: db.ID,
+		TenantID:   db.TenantID,
+		Kind:       domain.DatabaseBackupLog,
+		FileName:   f.name,
+		ObjectKey:  key,
+		SizeBytes:  int64(len(data)),
+		ArchivedAt: f.closedAt,
+		CreatedAt:  time.Now(),
+	})
+}
+
+// listContainerFiles runs a script printing "name size mtime" lines and
+// returns the files sorted by name, which is also their write order.
+func (s *DatabaseService) listContainerFiles(ctx context.Context, containerID, script string) ([]archivedFile, error) {
+	out, err := s.compute.Exec(ctx, containerID, []string{"sh", "-c", script})
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list log files", err)
+	}
+	var files []archivedFile
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			continue
+		}
+		size, err1 := strconv.ParseInt(fields[1], 10, 64)
+		mtime, err2 := strconv.ParseInt(fields[2], 10, 64)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		files = append(files, archivedFile{name: fields[0], size: size, closedAt: time.Unix(mtime, 0)})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].name < files[j].name })
+	return files, nil
+}
+
+// pruneBackups drops everything older than the newest base backup that still
+// precedes the retention cutoff; that base keeps the whole window restorable.
+func (s *DatabaseService) pruneBackups(ctx context.Context, db *domain.Database, backups []*domain.DatabaseBackup, now time.Time) {
+	cutoff := now.AddDate(0, 0, -db.BackupRetentionDays)
+	var anchor *domain.DatabaseBackup
+	for _, b := range backups {
+		if b.Kind == domain.DatabaseBackupBase && !b.ArchivedAt.After(cutoff) {
+			anchor = b
+		}
+	}
+	if anchor == nil {
+		return
+	}
+	for _, b := range backups {
+		if b.ArchivedAt.Before(anchor.ArchivedAt) {
+			s.deleteBackup(ctx, db, b)
+		}
+	}
+}
+
+// purgeBackups removes every automated backup of a database.
+func (s *DatabaseService) purgeBackups(ctx context.Context, db *domain.Database) {
+	if s.backupRepo == nil {
+		return
+	}
+	backups, err := s.backupRepo.ListByDatabase(ctx, db.ID)
+	if err != nil {
+		s.logger.Warn("failed to list database backups for removal", "database_id", db.ID, "error", err)
+		return
+	}
+	for _, b := range backups {
+		s.deleteBackup(ctx, db, b)
+	}
+}
+
+func (s *DatabaseService) deleteBackup(ctx context.Context, db *domain.Database, b *domain.DatabaseBackup) {
+	switch {
+	case b.Kind == domain.DatabaseBackupBase && b.SnapshotID != nil:
+		if err := s.snapshotSvc.DeleteSnapshot(ctx, *b.SnapshotID); err != nil && !errors.Is(err, errors.NotFound) {
+			s.logger.Warn("failed to delete base backup snapshot", "database_id", db.ID, "snapshot_id", *b.SnapshotID, "error", err)
+			return
+		}
+	case b.Kind == domain.DatabaseBackupLog && s.storageSvc != nil:
+		if err := s.storageSvc.DeleteObject(ctx, db.BackupBucket, b.ObjectKey); err != nil && !errors.Is(err, errors.NotFound) {
+			s.logger.Warn("failed to delete archived log", "database_id", db.ID, "key", b.ObjectKey, "error", err)
+			return
+		}
+	}
+	if err := s.backupRepo.Delete(ctx, b.ID); err != nil {
+		s.logger.Warn("failed to delete database backup record", "database_id", db.ID, "backup_id", b.ID, "error", err)
+	}
+}
+
+// databasePassword returns the current password of a database, preferring the secrets manager.
+func (s *DatabaseService) databasePassword(ctx context.Context, db *domain.Database) string {
+	if db.CredentialPath != "" {
+		secret, err := s.secrets.GetSecret(ctx, db.CredentialPath)
+		if err == nil && secret != nil {
+			if p, ok := secret["password"].(string); ok {
+				return p
+			}
+		}
+	}
+	return db.Password
+}
@@ -0,0 +1,511 @@
+package services_test
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/core/services"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockDatabaseBackupRepo struct {
+	mock.Mock
+}
+
+func (m *mockDatabaseBackupRepo) Create(ctx context.Context, b *domain.DatabaseBackup) error {
+	return m.Called(ctx, b).Error(0)
+}
+
+func (m *mockDatabaseBackupRepo) ListByDatabase(ctx context.Context, databaseID uuid.UUID) ([]*domain.DatabaseBackup, error) {
+	args := m.Called(ctx, databaseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.DatabaseBackup), args.Error(1)
+}
+
+func (m *mockDatabaseBackupRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+// pitrStorage stubs the storage calls point-in-time recovery makes.
+type pitrStorage struct {
+	ports.StorageService
+	mock.Mock
+}
+
+func (m *pitrStorage) GetBucket(ctx context.Context, name string) (*domain.Bucket, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Bucket), args.Error(1)
+}
+
+func (m *pitrStorage) Upload(ctx context.Context, bucket, key string, r io.Reader, checksum string) (*domain.Object, error) {
+	body, _ := io.ReadAll(r)
+	args := m.Called(ctx, bucket, key, string(body))
+	return &domain.Object{Bucket: bucket, Key: key}, args.Error(0)
+}
+
+func (m *pitrStorage) Download(ctx context.Context, bucket, key string) (io.ReadCloser, *domain.Object, error) {
+	args := m.Called(ctx, bucket, key)
+	return io.NopCloser(strings.NewReader(args.String(0))), &domain.Object{Bucket: bucket, Key: key}, args.Error(1)
+}
+
+func (m *pitrStorage) DeleteObject(ctx context.Context, bucket, key string) error {
+	return m.Called(ctx, bucket, key).Error(0)
+}
+
+type pitrMocks struct {
+	repo     *DatabaseUnitMockRepo
+	backups  *mockDatabaseBackupRepo
+	storage  *pitrStorage
+	compute  *MockComputeBackend
+	volumes  *MockVolumeService
+	snaps    *mockSnapshotService
+	secrets  *MockSecretsManager
+	events   *MockEventService
+	auditSvc *MockAuditService
+}
+
+func setupDatabasePITRTest() (*pitrMocks, *services.DatabaseService) {
+	m := &pitrMocks{
+		repo:     new(DatabaseUnitMockRepo),
+		backups:  new(mockDatabaseBackupRepo),
+		storage:  new(pitrStorage),
+		compute:  new(MockComputeBackend),
+		volumes:  new(MockVolumeService),
+		snaps:    new(mockSnapshotService),
+		secrets:  new(MockSecretsManager),
+		events:   new(MockEventService),
+		auditSvc: new(MockAuditService),
+	}
+	rbac := new(mockRBACService)
+	rbac.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	m.compute.On("Type").Return("docker").Maybe()
+
+	svc := services.NewDatabaseService(services.DatabaseServiceParams{
+		Repo:         m.repo,
+		RBAC:         rbac,
+		Compute:      m.compute,
+		VpcRepo:      new(MockVpcRepo),
+		VolumeSvc:    m.volumes,
+		SnapshotSvc:  m.snaps,
+		SnapshotRepo: new(mockSnapshotRepository),
+		EventSvc:     m.events,
+		AuditSvc:     m.auditSvc,
+		Secrets:      m.secrets,
+		StorageSvc:   m.storage,
+		BackupRepo:   m.backups,
+		Logger:       slog.Default(),
+	})
+	return m, svc
+}
+
+func TestDatabaseServiceCreateValidatesBackupSettings(t *testing.T) {
+	ctx := appcontext.WithUserID(context.Background(), uuid.New())
+
+	tests := []struct {
+		name   string
+		days   int
+		bucket string
+		setup  func(m *pitrMocks)
+		errMsg string
+	}{
+		{name: "RetentionTooLong", days: 36, bucket: "backups", errMsg: "between 0 and 35"},
+		{name: "MissingBucket", days: 7, errMsg: "requires a backup bucket"},
+		{name: "BucketWithoutRetention", bucket: "backups", errMsg: "positive backup retention"},
+		{
+			name: "UnknownBucket", days: 7, bucket: "missing",
+			setup: func(m *pitrMocks) {
+				m.storage.On("GetBucket", mock.Anything, "missing").Return(nil, errors.New(errors.NotFound, "bucket not found")).Once()
+			},
+			errMsg: "bucket not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, svc := setupDatabasePITRTest()
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+
+			_, err := svc.CreateDatabase(ctx, ports.CreateDatabaseRequest{
+				Name:                "orders",
+				Engine:              "postgres",
+				Version:             "16",
+				AllocatedStorage:    10,
+				BackupRetentionDays: tt.days,
+				BackupBucket:        tt.bucket,
+			})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+			m.volumes.AssertNotCalled(t, "CreateVolume", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestDatabaseServiceCreateEnablesWALArchiving(t *testing.T) {
+	m, svc := setupDatabasePITRTest()
+	ctx := appcontext.WithUserID(context.Background(), uuid.New())
+
+	m.storage.On("GetBucket", mock.Anything, "backups").Return(&domain.Bucket{Name: "backups"}, nil).Once()
+	m.volumes.On("CreateVolume", mock.Anything, mock.Anything, 10).Return(&domain.Volume{ID: uuid.New(), Name: "db-vol"}, nil).Once()
+	m.secrets.On("StoreSecret", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	m.compute.On("LaunchInstanceWithOptions", mock.Anything, mock.MatchedBy(func(opts ports.CreateInstanceOptions) bool {
+		cmd := strings.Join(opts.Cmd, " ")
+		return strings.Contains(cmd, "archive_mode=on") && strings.Contains(cmd, "archive_timeout=60")
+	})).Return("cid", []string{"30001:5432"}, nil).Once()
+	m.repo.On("Create", mock.Anything, mock.MatchedBy(func(db *domain.Database) bool {
+		return db.BackupRetentionDays == 7 && db.BackupBucket == "backups"
+	})).Return(nil).Once()
+	m.events.On("RecordEvent", mock.Anything, "DATABASE_CREATE", mock.Anything, "DATABASE", mock.Anything).Return(nil).Once()
+	m.auditSvc.On("Log", mock.Anything, mock.Anything, "database.create", "database", mock.Anything, mock.Anything).Return(nil).Once()
+
+	db, err := svc.CreateDatabase(ctx, ports.CreateDatabaseRequest{
+		Name:                "orders",
+		Engine:              "postgres",
+		Version:             "16",
+		AllocatedStorage:    10,
+		BackupRetentionDays: 7,
+		BackupBucket:        "backups",
+	})
+	require.NoError(t, err)
+	assert.True(t, db.PITREnabled())
+	m.compute.AssertExpectations(t)
+	m.repo.AssertExpectations(t)
+}
+
+func TestDatabaseServiceGetDatabaseRestorableWindow(t *testing.T) {
+	m, svc := setupDatabasePITRTest()
+	ctx := context.Background()
+	now := time.Now().UTC()
+	db := &domain.Database{ID: uuid.New(), Engine: domain.EnginePostgres, BackupRetentionDays: 7, BackupBucket: "backups"}
+	snapID := uuid.New()
+
+	m.repo.On("GetByID", mock.Anything, db.ID).Return(db, nil).Once()
+	m.backups.On("ListByDatabase", mock.Anything, db.ID).Return([]*domain.DatabaseBackup{
+		{Kind: domain.DatabaseBackupLog, FileName: "000000010000000000000001", ArchivedAt: now.Add(-9 * 24 * time.Hour)},
+		{Kind: domain.DatabaseBackupBase, SnapshotID: &snapID, ArchivedAt: now.Add(-8 * 24 * time.Hour)},
+		{Kind: domain.DatabaseBackupLog, FileName: "000000010000000000000002", ArchivedAt: now.Add(-2 * time.Minute)},
+	}, nil).Once()
+
+	got, err := svc.GetDatabase(ctx, db.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.EarliestRestorableTime)
+	require.NotNil(t, got.LatestRestorableTime)
+	assert.WithinDuration(t, now.AddDate(0, 0, -7), *got.EarliestRestorableTime, time.Minute)
+	assert.WithinDuration(t, now.Add(-2*time.Minute), *got.LatestRestorableTime, time.Second)
+}
+
+func TestDatabaseServiceArchiveDatabaseLogs(t *testing.T) {
+	m, svc := setupDatabasePITRTest()
+	userID, tenantID := uuid.New(), uuid.New()
+	db := &domain.Database{
+		ID: uuid.New(), UserID: userID, TenantID: tenantID, Name: "orders", Engine: domain.EnginePostgres,
+		Role: domain.RolePrimary, Status: domain.DatabaseStatusRunning, ContainerID: "cid",
+		BackupRetentionDays: 7, BackupBucket: "backups",
+	}
+	vol := &domain.Volume{ID: uuid.New(), Name: "db-vol-" + db.ID.String()[:8]}
+	snap := &domain.Snapshot{ID: uuid.New(), CreatedAt: time.Now()}
+	segment := "000000010000000000000003"
+	mtime := time.Now().Add(-time.Minute).Truncate(time.Second)
+
+	m.repo.On("ListWithBackupRetention", mock.Anything).Return([]*domain.Database{
+		db,
+		{ID: uuid.New(), Role: domain.RoleReplica, Status: domain.DatabaseStatusRunning, ContainerID: "rep", BackupBucket: "backups", BackupRetentionDays: 7},
+	}, nil).Once()
+	m.backups.On("ListByDatabase", mock.Anything, db.ID).Return([]*domain.DatabaseBackup{}, nil).Once()
+	m.volumes.On("ListVolumes", mock.MatchedBy(func(ctx context.Context) bool {
+		return appcontext.UserIDFromContext(ctx) == userID && appcontext.TenantIDFromContext(ctx) == tenantID
+	})).Return([]*domain.Volume{vol}, nil).Once()
+	m.snaps.On("CreateSnapshot", mock.Anything, vol.ID, mock.MatchedBy(func(desc string) bool {
+		return strings.HasPrefix(desc, "db-pitr-base-orders-")
+	})).Return(snap, nil).Once()
+	m.backups.On("Create", mock.Anything, mock.MatchedBy(func(b *domain.DatabaseBackup) bool {
+		return b.Kind == domain.DatabaseBackupBase && *b.SnapshotID == snap.ID
+	})).Return(nil).Once()
+	m.compute.On("Exec", mock.Anything, "cid", mock.MatchedBy(func(cmd []string) bool {
+		return len(cmd) == 3 && strings.Contains(cmd[2], "stat -c")
+	})).Return(segment+" 16 "+strconv.FormatInt(mtime.Unix(), 10)+"\n", nil).Once()
+	m.compute.On("Exec", mock.Anything, "cid", []string{"base64", "/var/lib/postgresql/data/pitr_archive/" + segment}).
+		Return(base64.StdEncoding.EncodeToString([]byte("wal-segment-data"))+"\n", nil).Once()
+	m.storage.On("Upload", mock.Anything, "backups", "databases/"+db.ID.String()+"/wal/"+segment, "wal-segment-data").Return(nil).Once()
+	m.backups.On("Create", mock.Anything, mock.MatchedBy(func(b *domain.DatabaseBackup) bool {
+		return b.Kind == domain.DatabaseBackupLog && b.FileName == segment && b.ArchivedAt.Equal(mtime) && b.SizeBytes == 16
+	})).Return(nil).Once()
+	m.compute.On("Exec", mock.Anything, "cid", []string{"rm", "-f", "/var/lib/postgresql/data/pitr_archive/" + segment}).Return("", nil).Once()
+
+	n, err := svc.ArchiveDatabaseLogs(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	m.backups.AssertExpectations(t)
+	m.storage.AssertExpectations(t)
+	m.compute.AssertExpectations(t)
+}
+
+func TestDatabaseServiceArchiveDatabaseLogsPrunesExpiredBackups(t *testing.T) {
+	m, svc := setupDatabasePITRTest()
+	now := time.Now()
+	db := &domain.Database{
+		ID: uuid.New(), Engine: domain.EnginePostgres, Role: domain.RolePrimary, Status: domain.DatabaseStatusRunning,
+		ContainerID: "cid", BackupRetentionDays: 1, BackupBucket: "backups",
+	}
+	oldSnap, anchorSnap, freshSnap := uuid.New(), uuid.New(), uuid.New()
+	expiredBase := &domain.DatabaseBackup{ID: uuid.New(), Kind: domain.DatabaseBackupBase, SnapshotID: &oldSnap, ArchivedAt: now.Add(-72 * time.Hour)}
+	expiredLog := &domain.DatabaseBackup{ID: uuid.New(), Kind: domain.DatabaseBackupLog, ObjectKey: "databases/x/wal/1", ArchivedAt: now.Add(-60 * time.Hour)}
+	anchor := &domain.DatabaseBackup{ID: uuid.New(), Kind: domain.DatabaseBackupBase, SnapshotID: &anchorSnap, ArchivedAt: now.Add(-48 * time.Hour)}
+	keptLog := &domain.DatabaseBackup{ID: uuid.New(), Kind: domain.DatabaseBackupLog, ObjectKey: "databases/x/wal/2", ArchivedAt: now.Add(-30 * time.Hour)}
+	fresh := &domain.DatabaseBackup{ID: uuid.New(), Kind: domain.DatabaseBackupBase, SnapshotID: &freshSnap, ArchivedAt: now.Add(-time.Hour)}
+
+	m.repo.On("ListWithBackupRetention", mock.Anything).Return([]*domain.Database{db}, nil).Once()
+	m.backups.On("ListByDatabase", mock.Anything, db.ID).Return([]*domain.DatabaseBackup{expiredBase, expiredLog, anchor, keptLog, fresh}, nil).Once()
+	m.compute.On("Exec", mock.Anything, "cid", mock.Anything).Return("", nil).Once()
+	m.snaps.On("DeleteSnapshot", mock.Anything, oldSnap).Return(nil).Once()
+	m.storage.On("DeleteObject", mock.Anything, "backups", "databases/x/wal/1").Return(nil).Once()
+	m.backups.On("Delete", mock.Anything, expiredBase.ID).Return(nil).Once()
+	m.backups.On("Delete", mock.Anything, expiredLog.ID).Return(nil).Once()
+
+	n, err := svc.ArchiveDatabaseLogs(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	m.backups.AssertExpectations(t)
+	m.snaps.AssertNotCalled(t, "DeleteSnapshot", mock.Anything, anchorSnap)
+	m.snaps.AssertNotCalled(t, "CreateSnapshot", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDatabaseServiceRestoreToPointInTimeValidation(t *testing.T) {
+	ctx := appcontext.WithUserID(context.Background(), uuid.New())
+	now := time.Now()
+	snapID := uuid.New()
+	source := &domain.Database{ID: uuid.New(), Engine: domain.EnginePostgres, Version: "16", BackupRetentionDays: 7, BackupBucket: "backups"}
+	backups := []*domain.DatabaseBackup{
+		{Kind: domain.DatabaseBackupBase, SnapshotID: &snapID, ArchivedAt: now.Add(-24 * time.Hour)},
+		{Kind: domain.DatabaseBackupLog, FileName: "000000010000000000000001", ArchivedAt: now.Add(-time.Hour)},
+	}
+
+	tests := []struct {
+		name   string
+		req    func() ports.RestoreDatabaseRequest
+		setup  func(m *pitrMocks)
+		errMsg string
+	}{
+		{
+			name: "MissingSource",
+			req: func() ports.RestoreDatabaseRequest {
+				at := now
+				return ports.RestoreDatabaseRequest{NewName: "restored", RestoreTime: &at}
+			},
+			errMsg: "requires a source database",
+		},
+		{
+			name: "SourceWithoutPITR",
+			req: func() ports.RestoreDatabaseRequest {
+				at, id := now, uuid.New()
+				return ports.RestoreDatabaseRequest{NewName: "restored", SourceDatabaseID: &id, RestoreTime: &at}
+			},
+			setup: func(m *pitrMocks) {
+				m.repo.On("GetByID", mock.Anything, mock.Anything).Return(&domain.Database{Engine: domain.EnginePostgres}, nil).Once()
+			},
+			errMsg: "not enabled",
+		},
+		{
+			name: "EngineMismatch",
+			req: func() ports.RestoreDatabaseRequest {
+				at := now.Add(-2 * time.Hour)
+				return ports.RestoreDatabaseRequest{NewName: "restored", Engine: "mysql", SourceDatabaseID: &source.ID, RestoreTime: &at}
+			},
+			setup: func(m *pitrMocks) {
+				m.repo.On("GetByID", mock.Anything, source.ID).Return(source, nil).Once()
+			},
+			errMsg: "engine of the source",
+		},
+		{
+			name: "OutsideWindow",
+			req: func() ports.RestoreDatabaseRequest {
+				at := now.Add(-48 * time.Hour)
+				return ports.RestoreDatabaseRequest{NewName: "restored", SourceDatabaseID: &source.ID, RestoreTime: &at}
+			},
+			setup: func(m *pitrMocks) {
+				m.repo.On("GetByID", mock.Anything, source.ID).Return(source, nil).Once()
+				m.backups.On("ListByDatabase", mock.Anything, source.ID).Return(backups, nil).Once()
+			},
+			errMsg: "restore time must be between",
+		},
+		{
+			name: "NoSnapshotOrTime",
+			req: func() ports.RestoreDatabaseRequest {
+				return ports.RestoreDatabaseRequest{NewName: "restored", Engine: "postgres", Version: "16"}
+			},
+			errMsg: "either a snapshot or a restore time",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, svc := setupDatabasePITRTest()
+			if tt.setup != nil {
+				tt.setup(m)
+			}
+
+			_, err := svc.RestoreDatabase(ctx, tt.req())
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, errors.InvalidInput))
+			assert.Contains(t, err.Error(), tt.errMsg)
+			m.snaps.AssertNotCalled(t, "RestoreSnapshot", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestDatabaseServiceRestoreToPointInTimePostgres(t *testing.T) {
+	m, svc := setupDatabasePITRTest()
+	ctx := appcontext.WithUserID(context.Background(), uuid.New())
+	now := time.Now()
+	baseSnap, newerSnap := uuid.New(), uuid.New()
+	source := &domain.Database{
+		ID: uuid.New(), Engine: domain.EnginePostgres, Version: "16", CredentialPath: "secret/rds/src",
+		BackupRetentionDays: 7, BackupBucket: "backups",
+	}
+	target := now.Add(-90 * time.Minute)
+	backups := []*domain.DatabaseBackup{
+		{Kind: domain.DatabaseBackupBase, SnapshotID: &baseSnap, ArchivedAt: now.Add(-3 * time.Hour)},
+		{Kind: domain.DatabaseBackupLog, FileName: "seg1", ObjectKey: "k/seg1", ArchivedAt: now.Add(-2 * time.Hour)},
+		{Kind: domain.DatabaseBackupLog, FileName: "seg2", ObjectKey: "k/seg2", ArchivedAt: now.Add(-80 * time.Minute)},
+		{Kind: domain.DatabaseBackupLog, FileName: "seg3", ObjectKey: "k/seg3", ArchivedAt: now.Add(-70 * time.Minute)},
+		{Kind: domain.DatabaseBackupBase, SnapshotID: &newerSnap, ArchivedAt: now.Add(-time.Hour)},
+	}
+	vol := &domain.Volume{ID: uuid.New(), Name: "db-vol-restored"}
+
+	m.repo.On("GetByID", mock.Anything, source.ID).Return(source, nil).Once()
+	m.backups.On("ListByDatabase", mock.Anything, source.ID).Return(backups, nil).Once()
+	m.secrets.On("GetSecret", mock.Anything, "secret/rds/src").Return(map[string]interface{}{"password": "source-pass"}, nil).Once()
+	m.snaps.On("GetSnapshot", mock.Anything, baseSnap).Return(&domain.Snapshot{ID: baseSnap, SizeGB: 10}, nil).Once()
+	m.secrets.On("StoreSecret", mock.Anything, mock.Anything, map[string]interface{}{"password": "source-pass"}).Return(nil).Once()
+	m.snaps.On("RestoreSnapshot", mock.Anything, baseSnap, mock.Anything).Return(vol, nil).Once()
+	m.compute.On("LaunchInstanceWithOptions", mock.Anything, mock.MatchedBy(func(opts ports.CreateInstanceOptions) bool {
+		return strings.HasPrefix(opts.Name, "cloud-db-pitr-")
+	})).Return("stager", nil, nil).Once()
+	m.compute.On("Exec", mock.Anything, "stager", mock.Anything).Return("", nil)
+	m.storage.On("Download", mock.Anything, "backups", "k/seg1").Return("one", nil).Once()
+	m.storage.On("Download", mock.Anything, "backups", "k/seg2").Return("two", nil).Once()
+	m.compute.On("DeleteInstance", mock.Anything, "stager").Return(nil).Once()
+	m.compute.On("LaunchInstanceWithOptions", mock.Anything, mock.MatchedBy(func(opts ports.CreateInstanceOptions) bool {
+		cmd := strings.Join(opts.Cmd, " ")
+		return strings.HasPrefix(opts.Name, "cloud-db-restored-") &&
+			strings.Contains(cmd, "recovery_target_time="+target.UTC().Format("2006-01-02 15:04:05")) &&
+			strings.Contains(cmd, "recovery_target_action=promote")
+	})).Return("cid", []string{"30005:5432"}, nil).Once()
+	m.repo.On("Create", mock.Anything, mock.MatchedBy(func(db *domain.Database) bool {
+		return db.BackupBucket == "backups" && db.BackupRetentionDays == 7 && db.Password == "source-pass"
+	})).Return(nil).Once()
+	m.events.On("RecordEvent", mock.Anything, "DATABASE_RESTORE", mock.Anything, "DATABASE", mock.Anything).Return(nil).Once()
+	m.auditSvc.On("Log", mock.Anything, mock.Anything, mock.Anything, "database", mock.Anything, mock.Anything).Return(nil).Once()
+
+	db, err := svc.RestoreDatabase(ctx, ports.RestoreDatabaseRequest{NewName: "restored", SourceDatabaseID: &source.ID, RestoreTime: &target})
+	require.NoError(t, err)
+	assert.Equal(t, domain.DatabaseStatusRunning, db.Status)
+	assert.Equal(t, "16", db.Version)
+	m.storage.AssertExpectations(t)
+	m.compute.AssertExpectations(t)
+	m.storage.AssertNotCalled(t, "Download", mock.Anything, "backups", "k/seg3")
+}
+
+func TestDatabaseServiceArchiveDatabaseLogsRecordsBinlogPosition(t *testing.T) {
+	m, svc := setupDatabasePITRTest()
+	db := &domain.Database{
+		ID: uuid.New(), Name: "orders", Engine: domain.EngineMySQL, Password: "pw",
+		Role: domain.RolePrimary, Status: domain.DatabaseStatusRunning, ContainerID: "cid",
+		BackupRetentionDays: 7, BackupBucket: "backups",
+	}
+	vol := &domain.Volume{ID: uuid.New(), Name: "db-vol-" + db.ID.String()[:8]}
+	snap := &domain.Snapshot{ID: uuid.New(), CreatedAt: time.Now()}
+
+	m.repo.On("ListWithBackupRetention", mock.Anything).Return([]*domain.Database{db}, nil).Once()
+	m.backups.On("ListByDatabase", mock.Anything, db.ID).Return([]*domain.DatabaseBackup{}, nil).Once()
+	m.volumes.On("ListVolumes", mock.Anything).Return([]*domain.Volume{vol}, nil).Once()
+
+	// Commits stay blocked by the session's read lock while the snapshot is taken.
+	locked := false
+	m.compute.On("Exec", mock.Anything, "cid", mock.MatchedBy(func(cmd []string) bool {
+		return strings.Contains(cmd[2], "FLUSH TABLES WITH READ LOCK; SELECT LOCAL->>'$.binary_log_file'")
+	})).Run(func(mock.Arguments) { locked = true }).Return("42\nbinlog.000003\t157\n", nil).Once()
+	m.snaps.On("CreateSnapshot", mock.Anything, vol.ID, mock.Anything).Run(func(mock.Arguments) {
+		assert.True(t, locked, "snapshot taken without the read lock")
+	}).Return(snap, nil).Once()
+	m.compute.On("Exec", mock.Anything, "cid", []string{"sh", "-c", "MYSQL_PWD='pw' mysql -u root --execute='KILL 42;'"}).
+		Run(func(mock.Arguments) { locked = false }).Return("", nil).Once()
+	m.backups.On("Create", mock.Anything, mock.MatchedBy(func(b *domain.DatabaseBackup) bool {
+		return b.Kind == domain.DatabaseBackupBase && *b.SnapshotID == snap.ID && b.FileName == "binlog.000003" && b.LogPosition == 157
+	})).Return(nil).Once()
+	m.compute.On("Exec", mock.Anything, "cid", mock.Anything).Return("", nil)
+
+	_, err := svc.ArchiveDatabaseLogs(context.Background())
+	require.NoError(t, err)
+	assert.False(t, locked)
+	m.backups.AssertExpectations(t)
+	m.compute.AssertExpectations(t)
+}
+
+func TestDatabaseServiceRestoreToPointInTimeMySQL(t *testing.T) {
+	m, svc := setupDatabasePITRTest()
+	ctx := appcontext.WithUserID(context.Background(), uuid.New())
+	now := time.Now()
+	baseSnap := uuid.New()
+	source := &domain.Database{
+		ID: uuid.New(), Engine: domain.EngineMySQL, Version: "8.0", Password: "source-pass",
+		BackupRetentionDays: 7, BackupBucket: "backups",
+	}
+	target := now.Add(-90 * time.Minute)
+	baseTime := now.Add(-3 * time.Hour)
+	backups := []*domain.DatabaseBackup{
+		{Kind: domain.DatabaseBackupLog, FileName: "binlog.000002", ObjectKey: "k/2", ArchivedAt: baseTime.Add(-time.Hour)},
+		{Kind: domain.DatabaseBackupBase, SnapshotID: &baseSnap, FileName: "binlog.000003", LogPosition: 157, ArchivedAt: baseTime},
+		// Closed within the second the base was taken in.
+		{Kind: domain.DatabaseBackupLog, FileName: "binlog.000003", ObjectKey: "k/3", ArchivedAt: baseTime.Truncate(time.Second)},
+		{Kind: domain.DatabaseBackupLog, FileName: "binlog.000004", ObjectKey: "k/4", ArchivedAt: now.Add(-80 * time.Minute)},
+	}
+	vol := &domain.Volume{ID: uuid.New(), Name: "db-vol-restored"}
+
+	m.repo.On("GetByID", mock.Anything, source.ID).Return(source, nil).Once()
+	m.backups.On("ListByDatabase", mock.Anything, source.ID).Return(backups, nil).Once()
+	m.snaps.On("GetSnapshot", mock.Anything, baseSnap).Return(&domain.Snapshot{ID: baseSnap, SizeGB: 10}, nil).Once()
+	m.secrets.On("StoreSecret", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	m.snaps.On("RestoreSnapshot", mock.Anything, baseSnap, mock.Anything).Return(vol, nil).Once()
+	m.compute.On("LaunchInstanceWithOptions", mock.Anything, mock.Anything).Return("cid", []string{"30006:3306"}, nil).Once()
+	m.storage.On("Download", mock.Anything, "backups", "k/3").Return("three", nil).Once()
+	m.storage.On("Download", mock.Anything, "backups", "k/4").Return("four", nil).Once()
+	var replay string
+	m.compute.On("Exec", mock.Anything, "cid", mock.Anything).Run(func(args mock.Arguments) {
+		if cmd := args.Get(2).([]string); strings.Contains(strings.Join(cmd, " "), "mysqlbinlog") {
+			replay = cmd[2]
+		}
+	}).Return("", nil)
+	m.repo.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+	m.repo.On("Update", mock.Anything, mock.Anything).Return(nil).Maybe()
+	m.events.On("RecordEvent", mock.Anything, "DATABASE_RESTORE", mock.Anything, "DATABASE", mock.Anything).Return(nil).Once()
+	m.auditSvc.On("Log", mock.Anything, mock.Anything, mock.Anything, "database", mock.Anything, mock.Anything).Return(nil).Once()
+
+	_, err := svc.RestoreDatabase(ctx, ports.RestoreDatabaseRequest{NewName: "restored", SourceDatabaseID: &source.ID, RestoreTime: &target})
+	require.NoError(t, err)
+	// Replay resumes exactly where the snapshot ends instead of at its second.
+	assert.Contains(t, replay, "mysqlbinlog --start-position=157 --stop-datetime='"+target.UTC().Format("2006-01-02 15:04:05")+
+		"' /tmp/pitr_restore/binlog.000003 /tmp/pitr_restore/binlog.000004 |")
+	assert.NotContains(t, replay, "--start-datetime")
+	m.storage.AssertExpectations(t)
+	m.storage.AssertNotCalled(t, "Download", mock.Anything, "backups", "k/2")
+}
@@ -49,6 +49,30 @@ func (m *DatabaseUnitMockRepo) ListReplicas(ctx context.Context, primaryID uuid.
 	r0, _ := args.Get(0).([]*domain.Database)
 	return r0, args.Error(1)
 }
+func (m *DatabaseUnitMockRepo) ListWithBackupRetention(ctx context.Context) ([]*domain.Database, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	r0, _ := args.Get(0).([]*domain.Database)
+	return r0, args.Error(1)
+}
+func (m *DatabaseUnitMockRepo) ListPendingMaintenance(ctx context.Context) ([]*domain.Database, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	r0, _ := args.Get(0).([]*domain.Database)
+	return r0, args.Error(1)
+}
+func (m *DatabaseUnitMockRepo) ListWithMetrics(ctx context.Context) ([]*domain.Database, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	r0, _ := args.Get(0).([]*domain.Database)
+	return r0, args.Error(1)
+}
 func (m *DatabaseUnitMockRepo) Update(ctx context.Context, db *domain.Database) error {
 	return m.Called(ctx, db).Error(0)
 }
@@ -123,6 +147,31 @@ func testDatabaseServiceUnitExtended(t *testing.T) {
 		assert.Equal(t, "100", db.Parameters["max_connections"])
 	})
 
+	t.Run("CreateDatabase_MariaDB", func(t *testing.T) {
+		mockVolumeSvc.On("CreateVolume", mock.Anything, mock.Anything, 20).
+			Return(&domain.Volume{ID: uuid.New(), Name: "db-vol", BackendPath: "maria-vol"}, nil).Once()
+		mockSecrets.On("StoreSecret", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+		mockCompute.On("LaunchInstanceWithOptions", mock.Anything, mock.MatchedBy(func(opts ports.CreateInstanceOptions) bool {
+			return opts.ImageName == "mariadb:11.4" && opts.VolumeBinds[0] == "maria-vol:/var/lib/mysql"
+		})).Return("maria-cid", []string{"30003:3306"}, nil).Once()
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+		mockEventSvc.On("RecordEvent", mock.Anything, "DATABASE_CREATE", mock.Anything, "DATABASE", mock.Anything).
+			Return(nil).Once()
+		mockAuditSvc.On("Log", mock.Anything, mock.Anything, "database.create", "database", mock.Anything, mock.Anything).
+			Return(nil).Once()
+
+		db, err := svc.CreateDatabase(ctx, ports.CreateDatabaseRequest{
+			Name:             "legacy",
+			Engine:           "mariadb",
+			Version:          "11.4",
+			AllocatedStorage: 20,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, domain.EngineMariaDB, db.Engine)
+		assert.Equal(t, "root", db.Username)
+		assert.Equal(t, 30003, db.Port)
+	})
+
 	t.Run("CreateReplica", func(t *testing.T) {
 		primaryID := uuid.New()
 		primary := &domain.Database{ID: primaryID, Engine: "postgres", Version: "16", Port: 5432, ContainerID: "primary-cid", AllocatedStorage: 20, Username: "cloud_user", Password: "pass"}
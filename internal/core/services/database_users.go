@@ -0,0 +1,674 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/poyrazk/thecloud/internal/platform"
+	"github.com/poyrazk/thecloud/pkg/util"
+)
+
+// Users, logical databases and grants.
+//
+// Additional users and logical databases are created on the primary with the
+// master credentials; replicas receive them through replication. Each user's
+// password is kept in the secrets manager under the database's vault path and
+// rotated like the master password, into a new versioned path.
+//
+// A grant gives a user READ_ONLY, READ_WRITE or ALL on one logical database.
+// MySQL grants on `db`.* cover future tables on their own. Postgres grants
+// only cover existing tables, so default privileges are set as well: every
+// user with a grant on a database gets default privileges on the tables that
+// the master user and the users with ALL on that database create later. That
+// way a read-only application user can read the tables a migration user
+// creates.
+
+// CreateLogicalDatabase creates an additional database inside a database instance.
+func (s *DatabaseService) CreateLogicalDatabase(ctx context.Context, databaseID uuid.UUID, name string) (*domain.LogicalDatabase, error) {
+	db, err := s.accountTarget(ctx, databaseID, domain.PermissionDBUpdate)
+	if err != nil {
+		return nil, err
+	}
+	if err := domain.ValidateDatabaseIdentifier("database", name); err != nil {
+		return nil, errors.New(errors.InvalidInput, err.Error())
+	}
+	if name == db.Name {
+		return nil, errors.New(errors.Conflict, "logical database already exists")
+	}
+
+	ldb := &domain.LogicalDatabase{
+		ID:         uuid.New(),
+		DatabaseID: db.ID,
+		TenantID:   db.TenantID,
+		Name:       name,
+		CreatedAt:  time.Now(),
+	}
+	if err := s.userRepo.CreateLogicalDatabase(ctx, ldb); err != nil {
+		return nil, err
+	}
+
+	var stmts []string
+	switch db.Engine {
+	case domain.EnginePostgres:
+		// PUBLIC may connect to new databases by default; access goes through grants instead.
+		stmts = []string{"CREATE DATABASE " + postgresIdentifier(name), "REVOKE ALL ON DATABASE " + postgresIdentifier(name) + " FROM PUBLIC"}
+	case domain.EngineMySQL, domain.EngineMariaDB:
+		stmts = []string{"CREATE DATABASE " + mysqlIdentifier(name) + ";"}
+	}
+	if err := s.execAdminSQL(ctx, db, "postgres", stmts...); err != nil {
+		if delErr := s.userRepo.DeleteLogicalDatabase(ctx, db.ID, name); delErr != nil {
+			s.logger.Warn("failed to remove logical database record after create failure", "database_id", db.ID, "name", name, "error", delErr)
+		}
+		return nil, errors.Wrap(errors.Internal, "failed to create logical database", err)
+	}
+
+	_ = s.eventSvc.RecordEvent(ctx, "DATABASE_LOGICAL_DB_CREATE", db.ID.String(), "DATABASE", map[string]interface{}{"name": name})
+	_ = s.auditSvc.Log(ctx, db.UserID, "database.logical_db_create", "database", db.ID.String(), map[string]interface{}{"name": name})
+	return ldb, nil
+}
+
+func (s *DatabaseService) ListLogicalDatabases(ctx context.Context, databaseID uuid.UUID) ([]*domain.LogicalDatabase, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionDBRead, databaseID.String()); err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.GetByID(ctx, databaseID); err != nil {
+		return nil, err
+	}
+	if s.userRepo == nil {
+		return []*domain.LogicalDatabase{}, nil
+	}
+	return s.userRepo.ListLogicalDatabases(ctx, databaseID)
+}
+
+// DeleteLogicalDatabase drops a logical database and removes the grants on it.
+func (s *DatabaseService) DeleteLogicalDatabase(ctx context.Context, databaseID uuid.UUID, name string) error {
+	db, err := s.accountTarget(ctx, databaseID, domain.PermissionDBUpdate)
+	if err != nil {
+		return err
+	}
+	dbs, err := s.userRepo.ListLogicalDatabases(ctx, db.ID)
+	if err != nil {
+		return err
+	}
+	if !containsLogicalDatabase(dbs, name) {
+		return errors.New(errors.NotFound, "logical database not found")
+	}
+	users, err := s.userRepo.ListUsers(ctx, db.ID)
+	if err != nil {
+		return err
+	}
+
+	switch db.Engine {
+	case domain.EnginePostgres:
+		drop := "DROP DATABASE " + postgresIdentifier(name)
+		if domain.CompareVersions(domain.MajorVersion(db.Engine, db.Version), "13") >= 0 {
+			drop += " WITH (FORCE)"
+		}
+		err = s.execAdminSQL(ctx, db, "postgres", drop)
+	case domain.EngineMySQL, domain.EngineMariaDB:
+		// MySQL keeps grants on a database after it is dropped.
+		var stmts []string
+		for _, u := range users {
+			if p := grantOn(u.Grants, name); p != "" {
+				stmts = append(stmts, mysqlGrantChange(name, u.Username, p, "")...)
+			}
+		}
+		err = s.execAdminSQL(ctx, db, "", append(stmts, "DROP DATABASE "+mysqlIdentifier(name)+";")...)
+	}
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to drop logical database", err)
+	}
+
+	for _, u := range users {
+		if grantOn(u.Grants, name) == "" {
+			continue
+		}
+		u.Grants = withoutGrant(u.Grants, name)
+		u.UpdatedAt = time.Now()
+		if err := s.userRepo.UpdateUser(ctx, u); err != nil {
+			s.logger.Warn("failed to remove grant on dropped logical database", "database_id", db.ID, "username", u.Username, "error", err)
+		}
+	}
+	if err := s.userRepo.DeleteLogicalDatabase(ctx, db.ID, name); err != nil {
+		return err
+	}
+
+	_ = s.eventSvc.RecordEvent(ctx, "DATABASE_LOGICAL_DB_DELETE", db.ID.String(), "DATABASE", map[string]interface{}{"name": name})
+	_ = s.auditSvc.Log(ctx, db.UserID, "database.logical_db_delete", "database", db.ID.String(), map[string]interface{}{"name": name})
+	return nil
+}
+
+// CreateDatabaseUser creates a login user with a generated password and the
+// requested grants. The password is only returned here and on rotation.
+func (s *DatabaseService) CreateDatabaseUser(ctx context.Context, req ports.CreateDatabaseUserRequest) (*domain.DatabaseUser, error) {
+	db, err := s.accountTarget(ctx, req.DatabaseID, domain.PermissionDBUpdate)
+	if err != nil {
+		return nil, err
+	}
+	if err := domain.ValidateDatabaseIdentifier("user", req.Username); err != nil {
+		return nil, errors.New(errors.InvalidInput, err.Error())
+	}
+	if req.Username == db.Username {
+		return nil, errors.New(errors.InvalidInput, "username is taken by the master user")
+	}
+	users, err := s.userRepo.ListUsers(ctx, db.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range users {
+		if u.Username == req.Username {
+			return nil, errors.New(errors.Conflict, "database user already exists")
+		}
+	}
+	if len(users) >= domain.MaxDatabaseUsers {
+		return nil, errors.New(errors.InvalidInput, fmt.Sprintf("a database can have at most %d users", domain.MaxDatabaseUsers))
+	}
+	grants, err := s.validateGrants(ctx, db, req.Grants)
+	if err != nil {
+		return nil, err
+	}
+
+	password, err := util.GenerateRandomPassword(16)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to generate password", err)
+	}
+	now := time.Now()
+	user := &domain.DatabaseUser{
+		ID:             uuid.New(),
+		DatabaseID:     db.ID,
+		TenantID:       db.TenantID,
+		Username:       req.Username,
+		Grants:         []domain.DatabaseGrant{},
+		CredentialPath: s.getUserVaultPath(db.ID, req.Username),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if err := s.secrets.StoreSecret(ctx, user.CredentialPath, map[string]interface{}{"password": password}); err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to store database user credentials in vault", err)
+	}
+
+	if err := s.execAdminSQL(ctx, db, "postgres", createUserSQL(db.Engine, user.Username, password)); err != nil {
+		s.deleteUserSecret(ctx, db, user.CredentialPath)
+		return nil, errors.Wrap(errors.Internal, "failed to create database user", err)
+	}
+	if err := s.applyGrants(ctx, db, users, user, grants); err != nil {
+		s.dropEngineUser(ctx, db, user)
+		s.deleteUserSecret(ctx, db, user.CredentialPath)
+		return nil, errors.Wrap(errors.Internal, "failed to grant privileges to database user", err)
+	}
+	user.Grants = grants
+	if err := s.userRepo.CreateUser(ctx, user); err != nil {
+		s.dropEngineUser(ctx, db, user)
+		s.deleteUserSecret(ctx, db, user.CredentialPath)
+		return nil, err
+	}
+
+	_ = s.eventSvc.RecordEvent(ctx, "DATABASE_USER_CREATE", db.ID.String(), "DATABASE", map[string]interface{}{"username": user.Username})
+	_ = s.auditSvc.Log(ctx, db.UserID, "database.user_create", "database", db.ID.String(), map[string]interface{}{"username": user.Username, "grants": grants})
+
+	user.Password = password
+	return user, nil
+}
+
+func (s *DatabaseService) ListDatabaseUsers(ctx context.Context, databaseID uuid.UUID) ([]*domain.DatabaseUser, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionDBRead, databaseID.String()); err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.GetByID(ctx, databaseID); err != nil {
+		return nil, err
+	}
+	if s.userRepo == nil {
+		return []*domain.DatabaseUser{}, nil
+	}
+	return s.userRepo.ListUsers(ctx, databaseID)
+}
+
+// SetDatabaseUserGrants replaces the grants of a user. Only the databases
+// whose privilege changes are touched.
+func (s *DatabaseService) SetDatabaseUserGrants(ctx context.Context, databaseID uuid.UUID, username string, grants []domain.DatabaseGrant) (*domain.DatabaseUser, error) {
+	db, err := s.accountTarget(ctx, databaseID, domain.PermissionDBUpdate)
+	if err != nil {
+		return nil, err
+	}
+	users, err := s.userRepo.ListUsers(ctx, db.ID)
+	if err != nil {
+		return nil, err
+	}
+	user, others := splitUser(users, username)
+	if user == nil {
+		return nil, errors.New(errors.NotFound, "database user not found")
+	}
+	grants, err = s.validateGrants(ctx, db, grants)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyGrants(ctx, db, others, user, grants); err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to update database user grants", err)
+	}
+	user.Grants = grants
+	user.UpdatedAt = time.Now()
+	if err := s.userRepo.UpdateUser(ctx, user); err != nil {
+		return nil, err
+	}
+
+	_ = s.eventSvc.RecordEvent(ctx, "DATABASE_USER_GRANTS_UPDATE", db.ID.String(), "DATABASE", map[string]interface{}{"username": username})
+	_ = s.auditSvc.Log(ctx, db.UserID, "database.user_grants_update", "database", db.ID.String(), map[string]interface{}{"username": username, "grants": grants})
+	return user, nil
+}
+
+// RotateDatabaseUserCredentials sets a new password for a user. The new
+// password is stored at the next versioned vault path before the engine is
+// changed, and the previous path is removed once the record points at the new
+// one.
+func (s *DatabaseService) RotateDatabaseUserCredentials(ctx context.Context, databaseID uuid.UUID, username string) (*domain.DatabaseUser, error) {
+	db, err := s.accountTarget(ctx, databaseID, domain.PermissionDBUpdate)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.userRepo.GetUser(ctx, db.ID, username)
+	if err != nil {
+		return nil, err
+	}
+
+	password, err := util.GenerateRandomPassword(16)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to generate password", err)
+	}
+	oldPath := user.CredentialPath
+	newVersion := user.CredentialVersion + 1
+	newPath := s.getUserVaultPath(db.ID, username) + "/v" + strconv.Itoa(newVersion)
+	if err := s.secrets.StoreSecret(ctx, newPath, map[string]interface{}{"password": password}); err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to store new credential in vault", err)
+	}
+	if err := s.execAdminSQL(ctx, db, "postgres", alterUserPasswordSQL(db.Engine, username, password)); err != nil {
+		s.deleteUserSecret(ctx, db, newPath)
+		return nil, errors.Wrap(errors.Internal, "failed to change database user password", err)
+	}
+
+	user.CredentialPath = newPath
+	user.CredentialVersion = newVersion
+	user.UpdatedAt = time.Now()
+	if err := s.userRepo.UpdateUser(ctx, user); err != nil {
+		s.logger.Error("user password rotated on engine but failed to persist credential path; recovery requires manual update",
+			"db_id", db.ID, "username", username, "vault_path", newPath, "error", err)
+		return nil, err
+	}
+	s.deleteUserSecret(ctx, db, oldPath)
+
+	_ = s.eventSvc.RecordEvent(ctx, "DATABASE_USER_CREDENTIALS_ROTATE", db.ID.String(), "DATABASE", map[string]interface{}{"username": username})
+	_ = s.auditSvc.Log(ctx, db.UserID, "database.user_rotate_credentials", "database", db.ID.String(), map[string]interface{}{"username": username})
+
+	user.Password = password
+	return user, nil
+}
+
+// DeleteDatabaseUser drops a user. On Postgres the objects it owns are handed
+// to the master user first, in every database.
+func (s *DatabaseService) DeleteDatabaseUser(ctx context.Context, databaseID uuid.UUID, username string) error {
+	db, err := s.accountTarget(ctx, databaseID, domain.PermissionDBUpdate)
+	if err != nil {
+		return err
+	}
+	user, err := s.userRepo.GetUser(ctx, db.ID, username)
+	if err != nil {
+		return err
+	}
+	if err := s.dropUserFromEngine(ctx, db, user); err != nil {
+		return errors.Wrap(errors.Internal, "failed to drop database user", err)
+	}
+	if err := s.userRepo.DeleteUser(ctx, db.ID, username); err != nil {
+		return err
+	}
+	s.deleteUserSecret(ctx, db, user.CredentialPath)
+
+	_ = s.eventSvc.RecordEvent(ctx, "DATABASE_USER_DELETE", db.ID.String(), "DATABASE", map[string]interface{}{"username": username})
+	_ = s.auditSvc.Log(ctx, db.UserID, "database.user_delete", "database", db.ID.String(), map[string]interface{}{"username": username})
+	return nil
+}
+
+// accountTarget authorizes a change to the users or logical databases of a
+// database and checks that the database can take it.
+func (s *DatabaseService) accountTarget(ctx context.Context, databaseID uuid.UUID, permission domain.Permission) (*domain.Database, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, permission, databaseID.String()); err != nil {
+		return nil, err
+	}
+	db, err := s.repo.GetByID(ctx, databaseID)
+	if err != nil {
+		return nil, err
+	}
+	if s.userRepo == nil {
+		return nil, errors.New(errors.InvalidInput, "database users are not available on this deployment")
+	}
+	if db.Role == domain.RoleReplica {
+		return nil, errors.New(errors.InvalidInput, "users and databases are managed on the primary; replicas receive them through replication")
+	}
+	if db.Status != domain.DatabaseStatusRunning {
+		return nil, errors.New(errors.InvalidInput, "database must be running")
+	}
+	if s.compute.Type() == "libvirt" {
+		return nil, errors.New(errors.InvalidInput, "database users require docker compute backend (COMPUTE_BACKEND=libvirt is not supported)")
+	}
+	return db, nil
+}
+
+// validateGrants checks that every grant names a known database and privilege,
+// at most once per database, and returns them sorted by database.
+func (s *DatabaseService) validateGrants(ctx context.Context, db *domain.Database, grants []domain.DatabaseGrant) ([]domain.DatabaseGrant, error) {
+	dbs, err := s.userRepo.ListLogicalDatabases(ctx, db.ID)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(grants))
+	out := make([]domain.DatabaseGrant, 0, len(grants))
+	for _, g := range grants {
+		if !g.Privilege.IsValid() {
+			return nil, errors.New(errors.InvalidInput, fmt.Sprintf("invalid privilege %q: use READ_ONLY, READ_WRITE or ALL", g.Privilege))
+		}
+		if g.Database != db.Name && !containsLogicalDatabase(dbs, g.Database) {
+			return nil, errors.New(errors.InvalidInput, fmt.Sprintf("database %q does not exist", g.Database))
+		}
+		if seen[g.Database] {
+			return nil, errors.New(errors.InvalidInput, fmt.Sprintf("duplicate grant on database %q", g.Database))
+		}
+		seen[g.Database] = true
+		out = append(out, g)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Database < out[j].Database })
+	return out, nil
+}
+
+// applyGrants moves user from its current grants to grants on the engine.
+// others are the database's other users, whose grants shape the Postgres
+// default privileges.
+func (s *DatabaseService) applyGrants(ctx context.Context, db *domain.Database, others []*domain.DatabaseUser, user *domain.DatabaseUser, grants []domain.DatabaseGrant) error {
+	for _, name := range grantDatabases(user.Grants, grants) {
+		from, to := grantOn(user.Grants, name), grantOn(grants, name)
+		if from == to {
+			continue
+		}
+		var err error
+		switch db.Engine {
+		case domain.EnginePostgres:
+			err = s.execAdminSQL(ctx, db, name, postgresGrantChange(db.Username, name, user.Username, from, to, others)...)
+		case domain.EngineMySQL, domain.EngineMariaDB:
+			err = s.execAdminSQL(ctx, db, "", mysqlGrantChange(name, user.Username, from, to)...)
+		}
+		if err != nil {
+			return fmt.Errorf("database %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (s *DatabaseService) dropUserFromEngine(ctx context.Context, db *domain.Database, user *domain.DatabaseUser) error {
+	if db.Engine.MySQLCompatible() {
+		return s.execAdminSQL(ctx, db, "", "DROP USER IF EXISTS "+mysqlAccount(user.Username)+";")
+	}
+	// Ownership and privileges are per database; the user may own objects
+	// even where it no longer has a grant.
+	dbs, err := s.userRepo.ListLogicalDatabases(ctx, db.ID)
+	if err != nil {
+		return err
+	}
+	names := []string{db.Name}
+	for _, l := range dbs {
+		names = append(names, l.Name)
+	}
+	role := postgresIdentifier(user.Username)
+	for _, name := range names {
+		if err := s.execAdminSQL(ctx, db, name,
+			"REASSIGN OWNED BY "+role+" TO "+postgresIdentifier(db.Username),
+			"DROP OWNED BY "+role,
+		); err != nil {
+			return fmt.Errorf("database %s: %w", name, err)
+		}
+	}
+	return s.execAdminSQL(ctx, db, "postgres", "DROP ROLE IF EXISTS "+role)
+}
+
+// dropEngineUser rolls back a user that was created on the engine but not recorded.
+func (s *DatabaseService) dropEngineUser(ctx context.Context, db *domain.Database, user *domain.DatabaseUser) {
+	if err := s.dropUserFromEngine(ctx, db, user); err != nil {
+		s.logger.Error("orphaned database user left after failed create",
+			"db_id", db.ID, "username", user.Username, "error", err)
+	}
+}
+
+func (s *DatabaseService) deleteUserSecret(ctx context.Context, db *domain.Database, path string) {
+	if err := s.secrets.DeleteSecret(ctx, path); err != nil {
+		s.logger.Warn("failed to delete database user credentials from vault", "db_id", db.ID, "path", path, "error", err)
+		platform.CredentialCleanupFailures.Inc()
+	}
+}
+
+// deleteUserCredentials removes the secrets of all users of a database that is
+// being deleted. The user rows go with the database.
+func (s *DatabaseService) deleteUserCredentials(ctx context.Context, db *domain.Database) {
+	if s.userRepo == nil {
+		return
+	}
+	users, err := s.userRepo.ListUsers(ctx, db.ID)
+	if err != nil {
+		s.logger.Warn("failed to list database users for credential cleanup", "db_id", db.ID, "error", err)
+		return
+	}
+	for _, u := range users {
+		s.deleteUserSecret(ctx, db, u.CredentialPath)
+	}
+}
+
+func (s *DatabaseService) getUserVaultPath(dbID uuid.UUID, username string) string {
+	return fmt.Sprintf("%s/%s/users/%s/credentials", s.vaultMountPath, dbID.String(), username)
+}
+
+// execAdminSQL runs statements as the master user. Postgres statements run one
+// at a time against the given database, since CREATE DATABASE cannot run in
+// a transaction; MySQL statements carry their own database names. Arguments
+// are passed without a shell, so statements need no shell quoting.
+func (s *DatabaseService) execAdminSQL(ctx context.Context, db *domain.Database, database string, stmts ...string) error {
+	password := s.databasePassword(ctx, db)
+	var cmd []string
+	switch db.Engine {
+	case domain.EnginePostgres:
+		cmd = []string{"env", "PGPASSWORD=" + password, "psql", "-h", "127.0.0.1", "-U", db.Username, "-d", database, "-v", "ON_ERROR_STOP=1"}
+		for _, stmt := range stmts {
+			cmd = append(cmd, "-c", stmt)
+		}
+	case domain.EngineMySQL, domain.EngineMariaDB:
+		cmd = []string{"env", "MYSQL_PWD=" + password, mysqlTool(db.Engine, "mysql"), "-u", "root", "--execute", strings.Join(stmts, " ")}
+	default:
+		return errors.New(errors.InvalidInput, "unsupported database engine")
+	}
+	_, err := s.compute.Exec(ctx, db.ContainerID, cmd)
+	return err
+}
+
+func createUserSQL(engine domain.DatabaseEngine, username, password string) string {
+	if engine.MySQLCompatible() {
+		return fmt.Sprintf("CREATE USER %s IDENTIFIED BY '%s';", mysqlAccount(username), sqlStringLiteral(password))
+	}
+	return fmt.Sprintf("CREATE ROLE %s WITH LOGIN PASSWORD '%s'", postgresIdentifier(username), sqlStringLiteral(password))
+}
+
+func alterUserPasswordSQL(engine domain.DatabaseEngine, username, password string) string {
+	if engine.MySQLCompatible() {
+		return fmt.Sprintf("ALTER USER %s IDENTIFIED BY '%s';", mysqlAccount(username), sqlStringLiteral(password))
+	}
+	return fmt.Sprintf("ALTER ROLE %s WITH PASSWORD '%s'", postgresIdentifier(username), sqlStringLiteral(password))
+}
+
+// postgresTablePrivileges returns the table and sequence privileges of p.
+func postgresTablePrivileges(p domain.DatabasePrivilege) (string, string) {
+	switch p {
+	case domain.DatabasePrivilegeReadWrite:
+		return "SELECT, INSERT, UPDATE, DELETE", "USAGE, SELECT"
+	case domain.DatabasePrivilegeAll:
+		return "ALL", "ALL"
+	}
+	return "SELECT", "SELECT"
+}
+
+// postgresGrantChange returns the statements, run in database name, that move
+// user from privilege from to privilege to. An empty privilege means no
+// grant. Tables created later by the master user or by users with ALL are
+// covered through default privileges, in both directions when user has ALL.
+func postgresGrantChange(master, name, user string, from, to domain.DatabasePrivilege, others []*domain.DatabaseUser) []string {
+	u, d := postgresIdentifier(user), postgresIdentifier(name)
+	creators := []string{master}
+	for _, o := range others {
+		if grantOn(o.Grants, name) == domain.DatabasePrivilegeAll {
+			creators = append(creators, o.Username)
+		}
+	}
+
+	var stmts []string
+	// defaults changes the default privileges on objects creator makes later.
+	defaults := func(creator string, tables, sequences string) {
+		prefix := "ALTER DEFAULT PRIVILEGES FOR ROLE " + postgresIdentifier(creator) + " IN SCHEMA public "
+		stmts = append(stmts, prefix+fmt.Sprintf(tables, "TABLES"), prefix+fmt.Sprintf(sequences, "SEQUENCES"))
+	}
+	if from != "" {
+		stmts = append(stmts,
+			"REVOKE ALL ON ALL TABLES IN SCHEMA public FROM "+u,
+			"REVOKE ALL ON ALL SEQUENCES IN SCHEMA public FROM "+u,
+			"REVOKE ALL ON SCHEMA public FROM "+u,
+			"REVOKE ALL ON DATABASE "+d+" FROM "+u,
+		)
+		for _, c := range creators {
+			defaults(c, "REVOKE ALL ON %s FROM "+u, "REVOKE ALL ON %s FROM "+u)
+		}
+		if from == domain.DatabasePrivilegeAll {
+			for _, o := range others {
+				if grantOn(o.Grants, name) != "" {
+					other := postgresIdentifier(o.Username)
+					defaults(user, "REVOKE ALL ON %s FROM "+other, "REVOKE ALL ON %s FROM "+other)
+				}
+			}
+		}
+	}
+	if to != "" {
+		dbPriv, schemaPriv := "CONNECT, TEMPORARY", "USAGE"
+		if to == domain.DatabasePrivilegeAll {
+			dbPriv, schemaPriv = "ALL", "ALL"
+		}
+		tables, sequences := postgresTablePrivileges(to)
+		stmts = append(stmts,
+			"GRANT "+dbPriv+" ON DATABASE "+d+" TO "+u,
+			"GRANT "+schemaPriv+" ON SCHEMA public TO "+u,
+			"GRANT "+tables+" ON ALL TABLES IN SCHEMA public TO "+u,
+			"GRANT "+sequences+" ON ALL SEQUENCES IN SCHEMA public TO "+u,
+		)
+		for _, c := range creators {
+			defaults(c, "GRANT "+tables+" ON %s TO "+u, "GRANT "+sequences+" ON %s TO "+u)
+		}
+		if to == domain.DatabasePrivilegeAll {
+			for _, o := range others {
+				if p := grantOn(o.Grants, name); p != "" {
+					other := postgresIdentifier(o.Username)
+					t, sq := postgresTablePrivileges(p)
+					defaults(user, "GRANT "+t+" ON %s TO "+other, "GRANT "+sq+" ON %s TO "+other)
+				}
+			}
+		}
+	}
+	return stmts
+}
+
+// mysqlGrantChange returns the statements that move user from privilege from
+// to privilege to on database name.
+func mysqlGrantChange(name, user string, from, to domain.DatabasePrivilege) []string {
+	var stmts []string
+	on := mysqlIdentifier(name) + ".*"
+	if from != "" {
+		stmts = append(stmts, "REVOKE ALL PRIVILEGES ON "+on+" FROM "+mysqlAccount(user)+";")
+	}
+	switch to {
+	case domain.DatabasePrivilegeReadOnly:
+		stmts = append(stmts, "GRANT SELECT, SHOW VIEW ON "+on+" TO "+mysqlAccount(user)+";")
+	case domain.DatabasePrivilegeReadWrite:
+		stmts = append(stmts, "GRANT SELECT, INSERT, UPDATE, DELETE, SHOW VIEW, EXECUTE ON "+on+" TO "+mysqlAccount(user)+";")
+	case domain.DatabasePrivilegeAll:
+		stmts = append(stmts, "GRANT ALL PRIVILEGES ON "+on+" TO "+mysqlAccount(user)+";")
+	}
+	return stmts
+}
+
+// mysqlIdentifier quotes a MySQL identifier.
+func mysqlIdentifier(id string) string {
+	return "`" + strings.ReplaceAll(id, "`", "``") + "`"
+}
+
+// mysqlAccount names a user that may connect from any host.
+func mysqlAccount(user string) string {
+	return "'" + sqlStringLiteral(user) + "'@'%'"
+}
+
+func grantOn(grants []domain.DatabaseGrant, name string) domain.DatabasePrivilege {
+	for _, g := range grants {
+		if g.Database == name {
+			return g.Privilege
+		}
+	}
+	return ""
+}
+
+func withoutGrant(grants []domain.DatabaseGrant, name string) []domain.DatabaseGrant {
+	out := make([]domain.DatabaseGrant, 0, len(grants))
+	for _, g := range grants {
+		if g.Database != name {
+			out = append(out, g)
+		}
+	}
+	return out
+}
+
+// grantDatabases returns the databases named in either list, in order.
+func grantDatabases(a, b []domain.DatabaseGrant) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, g := range append(append([]domain.DatabaseGrant{}, a...), b...) {
+		if !seen[g.Database] {
+			seen[g.Database] = true
+			names = append(names, g.Database)
+		}
+	}
+	return names
+}
+
+func splitUser(users []*domain.DatabaseUser, username string) (*domain.DatabaseUser, []*domain.DatabaseUser) {
+	var user *domain.DatabaseUser
+	others := make([]*domain.DatabaseUser, 0, len(users))
+	for _, u := range users {
+		if u.Username == username {
+			user = u
+		} else {
+			others = append(others, u)
+		}
+	}
+	return user, others
+}
+
+func containsLogicalDatabase(dbs []*domain.LogicalDatabase, name string) bool {
+	for _, l := range dbs {
+		if l.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,402 @@
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/core/services"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const usersVaultMount = "secret/data/thecloud/rds"
+
+// memDatabaseUserRepo is an in-memory ports.DatabaseUserRepository.
+type memDatabaseUserRepo struct {
+	dbs   []*domain.LogicalDatabase
+	users []*domain.DatabaseUser
+}
+
+func (r *memDatabaseUserRepo) CreateLogicalDatabase(ctx context.Context, l *domain.LogicalDatabase) error {
+	r.dbs = append(r.dbs, l)
+	return nil
+}
+
+func (r *memDatabaseUserRepo) ListLogicalDatabases(ctx context.Context, databaseID uuid.UUID) ([]*domain.LogicalDatabase, error) {
+	return r.dbs, nil
+}
+
+func (r *memDatabaseUserRepo) DeleteLogicalDatabase(ctx context.Context, databaseID uuid.UUID, name string) error {
+	for i, l := range r.dbs {
+		if l.Name == name {
+			r.dbs = append(r.dbs[:i], r.dbs[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New(errors.NotFound, "logical database not found")
+}
+
+func (r *memDatabaseUserRepo) CreateUser(ctx context.Context, u *domain.DatabaseUser) error {
+	r.users = append(r.users, u)
+	return nil
+}
+
+func (r *memDatabaseUserRepo) GetUser(ctx context.Context, databaseID uuid.UUID, username string) (*domain.DatabaseUser, error) {
+	for _, u := range r.users {
+		if u.Username == username {
+			return u, nil
+		}
+	}
+	return nil, errors.New(errors.NotFound, "database user not found")
+}
+
+func (r *memDatabaseUserRepo) ListUsers(ctx context.Context, databaseID uuid.UUID) ([]*domain.DatabaseUser, error) {
+	return append([]*domain.DatabaseUser{}, r.users...), nil
+}
+
+func (r *memDatabaseUserRepo) UpdateUser(ctx context.Context, u *domain.DatabaseUser) error {
+	return nil
+}
+
+func (r *memDatabaseUserRepo) DeleteUser(ctx context.Context, databaseID uuid.UUID, username string) error {
+	for i, u := range r.users {
+		if u.Username == username {
+			r.users = append(r.users[:i], r.users[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New(errors.NotFound, "database user not found")
+}
+
+type userMocks struct {
+	*pitrMocks
+	users *memDatabaseUserRepo
+	// execs records the SQL arguments of every command run in the container.
+	execs []string
+}
+
+func setupDatabaseUsersTest(db *domain.Database) (*userMocks, *services.DatabaseService) {
+	m := &userMocks{
+		pitrMocks: &pitrMocks{
+			repo:     new(DatabaseUnitMockRepo),
+			compute:  new(MockComputeBackend),
+			secrets:  new(MockSecretsManager),
+			events:   new(MockEventService),
+			auditSvc: new(MockAuditService),
+		},
+		users: &memDatabaseUserRepo{},
+	}
+	rbac := new(mockRBACService)
+	rbac.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	m.compute.On("Type").Return("docker").Maybe()
+	m.repo.On("GetByID", mock.Anything, db.ID).Return(db, nil).Maybe()
+	m.events.On("RecordEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	m.auditSvc.On("Log", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	svc := services.NewDatabaseService(services.DatabaseServiceParams{
+		Repo:           m.repo,
+		RBAC:           rbac,
+		Compute:        m.compute,
+		VpcRepo:        new(MockVpcRepo),
+		EventSvc:       m.events,
+		AuditSvc:       m.auditSvc,
+		Secrets:        m.secrets,
+		UserRepo:       m.users,
+		Logger:         slog.Default(),
+		VaultMountPath: usersVaultMount,
+	})
+	return m, svc
+}
+
+// recordExecs makes every container command succeed unless it contains
+// failOn, and records the SQL it runs.
+func (m *userMocks) recordExecs(failOn string) {
+	record := func(args mock.Arguments) {
+		// Drop "env" and the password variable.
+		m.execs = append(m.execs, strings.Join(args.Get(2).([]string)[2:], " "))
+	}
+	if failOn != "" {
+		m.compute.On("Exec", mock.Anything, "cid-1", mock.MatchedBy(func(cmd []string) bool {
+			return strings.Contains(strings.Join(cmd, " "), failOn)
+		})).Return("", fmt.Errorf("ERROR: permission denied")).Run(record).Once()
+	}
+	m.compute.On("Exec", mock.Anything, "cid-1", mock.Anything).Return("", nil).Run(record).Maybe()
+}
+
+func (m *userMocks) execContaining(s string) []string {
+	var out []string
+	for _, e := range m.execs {
+		if strings.Contains(e, s) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func runningDatabase(engine domain.DatabaseEngine) *domain.Database {
+	username := "cloud_user"
+	if engine == domain.EngineMySQL {
+		username = "root"
+	}
+	return &domain.Database{
+		ID: uuid.New(), Name: "app", Engine: engine, Version: "16", Status: domain.DatabaseStatusRunning,
+		Role: domain.RolePrimary, ContainerID: "cid-1", Username: username, Password: "master-pw",
+	}
+}
+
+func TestDatabaseServiceCreateDatabaseUserPostgres(t *testing.T) {
+	ctx := appcontext.WithUserID(context.Background(), uuid.New())
+	db := runningDatabase(domain.EnginePostgres)
+	m, svc := setupDatabaseUsersTest(db)
+	m.users.dbs = []*domain.LogicalDatabase{{DatabaseID: db.ID, Name: "reporting"}}
+	m.users.users = []*domain.DatabaseUser{{
+		Username: "migrator", Grants: []domain.DatabaseGrant{{Database: "reporting", Privilege: domain.DatabasePrivilegeAll}},
+	}}
+	m.recordExecs("")
+	path := fmt.Sprintf("%s/%s/users/app_ro/credentials", usersVaultMount, db.ID)
+	m.secrets.On("StoreSecret", mock.Anything, path, mock.Anything).Return(nil).Once()
+
+	user, err := svc.CreateDatabaseUser(ctx, ports.CreateDatabaseUserRequest{
+		DatabaseID: db.ID,
+		Username:   "app_ro",
+		Grants: []domain.DatabaseGrant{
+			{Database: "reporting", Privilege: domain.DatabasePrivilegeReadOnly},
+			{Database: "app", Privilege: domain.DatabasePrivilegeReadWrite},
+		},
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, user.Password)
+	assert.Equal(t, path, user.CredentialPath)
+	// Grants are kept sorted by database.
+	assert.Equal(t, "app", user.Grants[0].Database)
+	require.Len(t, m.users.users, 2)
+
+	require.Len(t, m.execContaining(`CREATE ROLE "app_ro" WITH LOGIN PASSWORD`), 1)
+	reporting := m.execContaining("-d reporting")
+	require.Len(t, reporting, 1)
+	assert.Contains(t, reporting[0], `GRANT SELECT ON ALL TABLES IN SCHEMA public TO "app_ro"`)
+	// Tables the migration user creates later are readable too.
+	assert.Contains(t, reporting[0], `ALTER DEFAULT PRIVILEGES FOR ROLE "migrator" IN SCHEMA public GRANT SELECT ON TABLES TO "app_ro"`)
+	assert.Contains(t, reporting[0], `ALTER DEFAULT PRIVILEGES FOR ROLE "cloud_user" IN SCHEMA public GRANT SELECT ON TABLES TO "app_ro"`)
+	app := m.execContaining("-d app ")
+	require.Len(t, app, 1)
+	assert.Contains(t, app[0], `GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public TO "app_ro"`)
+	assert.NotContains(t, app[0], `FOR ROLE "migrator"`)
+	m.secrets.AssertExpectations(t)
+}
+
+func TestDatabaseServiceCreateDatabaseUserValidation(t *testing.T) {
+	ctx := appcontext.WithUserID(context.Background(), uuid.New())
+
+	tests := []struct {
+		name   string
+		mutate func(db *domain.Database)
+		req    ports.CreateDatabaseUserRequest
+		errMsg string
+	}{
+		{name: "Replica", mutate: func(db *domain.Database) { db.Role = domain.RoleReplica }, req: ports.CreateDatabaseUserRequest{Username: "app"}, errMsg: "managed on the primary"},
+		{name: "Stopped", mutate: func(db *domain.Database) { db.Status = domain.DatabaseStatusStopped }, req: ports.CreateDatabaseUserRequest{Username: "app"}, errMsg: "must be running"},
+		{name: "InvalidName", req: ports.CreateDatabaseUserRequest{Username: "App;--"}, errMsg: "invalid user name"},
+		{name: "Reserved", req: ports.CreateDatabaseUserRequest{Username: "pg_monitor"}, errMsg: "reserved"},
+		{name: "MasterUser", req: ports.CreateDatabaseUserRequest{Username: "cloud_user"}, errMsg: "master user"},
+		{name: "Exists", req: ports.CreateDatabaseUserRequest{Username: "migrator"}, errMsg: "already exists"},
+		{name: "UnknownDatabase", req: ports.CreateDatabaseUserRequest{Username: "app_ro", Grants: []domain.DatabaseGrant{
+			{Database: "missing", Privilege: domain.DatabasePrivilegeReadOnly},
+		}}, errMsg: `database "missing" does not exist`},
+		{name: "DuplicateGrant", req: ports.CreateDatabaseUserRequest{Username: "app_ro", Grants: []domain.DatabaseGrant{
+			{Database: "app", Privilege: domain.DatabasePrivilegeReadOnly}, {Database: "app", Privilege: domain.DatabasePrivilegeAll},
+		}}, errMsg: "duplicate grant"},
+		{name: "InvalidPrivilege", req: ports.CreateDatabaseUserRequest{Username: "app_ro", Grants: []domain.DatabaseGrant{
+			{Database: "app", Privilege: "SUPERUSER"},
+		}}, errMsg: "invalid privilege"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := runningDatabase(domain.EnginePostgres)
+			if tt.mutate != nil {
+				tt.mutate(db)
+			}
+			m, svc := setupDatabaseUsersTest(db)
+			m.users.users = []*domain.DatabaseUser{{Username: "migrator"}}
+			tt.req.DatabaseID = db.ID
+
+			_, err := svc.CreateDatabaseUser(ctx, tt.req)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+			m.compute.AssertNotCalled(t, "Exec", mock.Anything, mock.Anything, mock.Anything)
+			m.secrets.AssertNotCalled(t, "StoreSecret", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestDatabaseServiceCreateDatabaseUserRollsBackOnGrantFailure(t *testing.T) {
+	ctx := appcontext.WithUserID(context.Background(), uuid.New())
+	db := runningDatabase(domain.EngineMySQL)
+	m, svc := setupDatabaseUsersTest(db)
+	m.recordExecs("GRANT ALL PRIVILEGES")
+	m.secrets.On("StoreSecret", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	m.secrets.On("DeleteSecret", mock.Anything, fmt.Sprintf("%s/%s/users/migrator/credentials", usersVaultMount, db.ID)).Return(nil).Once()
+
+	_, err := svc.CreateDatabaseUser(ctx, ports.CreateDatabaseUserRequest{
+		DatabaseID: db.ID,
+		Username:   "migrator",
+		Grants:     []domain.DatabaseGrant{{Database: "app", Privilege: domain.DatabasePrivilegeAll}},
+	})
+	require.Error(t, err)
+	assert.Empty(t, m.users.users)
+	assert.Len(t, m.execContaining("DROP USER IF EXISTS 'migrator'@'%';"), 1)
+	m.secrets.AssertExpectations(t)
+}
+
+func TestDatabaseServiceSetDatabaseUserGrantsMySQL(t *testing.T) {
+	ctx := appcontext.WithUserID(context.Background(), uuid.New())
+	db := runningDatabase(domain.EngineMySQL)
+	m, svc := setupDatabaseUsersTest(db)
+	m.users.dbs = []*domain.LogicalDatabase{{DatabaseID: db.ID, Name: "reporting"}}
+	m.users.users = []*domain.DatabaseUser{{
+		Username: "app_ro", Grants: []domain.DatabaseGrant{
+			{Database: "app", Privilege: domain.DatabasePrivilegeReadOnly},
+			{Database: "reporting", Privilege: domain.DatabasePrivilegeReadOnly},
+		},
+	}}
+	m.recordExecs("")
+
+	user, err := svc.SetDatabaseUserGrants(ctx, db.ID, "app_ro", []domain.DatabaseGrant{
+		{Database: "app", Privilege: domain.DatabasePrivilegeReadWrite},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []domain.DatabaseGrant{{Database: "app", Privilege: domain.DatabasePrivilegeReadWrite}}, user.Grants)
+	assert.Equal(t, []string{
+		"mysql -u root --execute REVOKE ALL PRIVILEGES ON `app`.* FROM 'app_ro'@'%'; GRANT SELECT, INSERT, UPDATE, DELETE, SHOW VIEW, EXECUTE ON `app`.* TO 'app_ro'@'%';",
+		"mysql -u root --execute REVOKE ALL PRIVILEGES ON `reporting`.* FROM 'app_ro'@'%';",
+	}, m.execs)
+
+	_, err = svc.SetDatabaseUserGrants(ctx, db.ID, "nobody", nil)
+	assert.True(t, errors.Is(err, errors.NotFound))
+}
+
+func TestDatabaseServiceRotateDatabaseUserCredentials(t *testing.T) {
+	ctx := appcontext.WithUserID(context.Background(), uuid.New())
+	db := runningDatabase(domain.EnginePostgres)
+	m, svc := setupDatabaseUsersTest(db)
+	base := fmt.Sprintf("%s/%s/users/app_ro/credentials", usersVaultMount, db.ID)
+	m.users.users = []*domain.DatabaseUser{{Username: "app_ro", CredentialPath: base}}
+	m.recordExecs("")
+	m.secrets.On("StoreSecret", mock.Anything, base+"/v1", mock.Anything).Return(nil).Once()
+	m.secrets.On("DeleteSecret", mock.Anything, base).Return(nil).Once()
+
+	user, err := svc.RotateDatabaseUserCredentials(ctx, db.ID, "app_ro")
+	require.NoError(t, err)
+	assert.Equal(t, 1, user.CredentialVersion)
+	assert.Equal(t, base+"/v1", user.CredentialPath)
+	assert.NotEmpty(t, user.Password)
+	require.Len(t, m.execContaining(`ALTER ROLE "app_ro" WITH PASSWORD '`+user.Password+`'`), 1)
+	m.secrets.AssertExpectations(t)
+}
+
+func TestDatabaseServiceRotateDatabaseUserCredentialsKeepsOldSecretOnFailure(t *testing.T) {
+	ctx := appcontext.WithUserID(context.Background(), uuid.New())
+	db := runningDatabase(domain.EnginePostgres)
+	m, svc := setupDatabaseUsersTest(db)
+	base := fmt.Sprintf("%s/%s/users/app_ro/credentials", usersVaultMount, db.ID)
+	m.users.users = []*domain.DatabaseUser{{Username: "app_ro", CredentialPath: base + "/v2", CredentialVersion: 2}}
+	m.recordExecs("ALTER ROLE")
+	m.secrets.On("StoreSecret", mock.Anything, base+"/v3", mock.Anything).Return(nil).Once()
+	m.secrets.On("DeleteSecret", mock.Anything, base+"/v3").Return(nil).Once()
+
+	_, err := svc.RotateDatabaseUserCredentials(ctx, db.ID, "app_ro")
+	require.Error(t, err)
+	assert.Equal(t, base+"/v2", m.users.users[0].CredentialPath)
+	m.secrets.AssertExpectations(t)
+}
+
+func TestDatabaseServiceDeleteDatabaseUserPostgres(t *testing.T) {
+	ctx := appcontext.WithUserID(context.Background(), uuid.New())
+	db := runningDatabase(domain.EnginePostgres)
+	m, svc := setupDatabaseUsersTest(db)
+	m.users.dbs = []*domain.LogicalDatabase{{DatabaseID: db.ID, Name: "reporting"}}
+	m.users.users = []*domain.DatabaseUser{{Username: "migrator", CredentialPath: "vault/migrator"}}
+	m.recordExecs("")
+	m.secrets.On("DeleteSecret", mock.Anything, "vault/migrator").Return(nil).Once()
+
+	require.NoError(t, svc.DeleteDatabaseUser(ctx, db.ID, "migrator"))
+	assert.Empty(t, m.users.users)
+	// Objects are handed to the master user in every database before the role goes.
+	reassign := m.execContaining(`REASSIGN OWNED BY "migrator" TO "cloud_user"`)
+	require.Len(t, reassign, 2)
+	assert.Contains(t, reassign[0], "-d app ")
+	assert.Contains(t, reassign[1], "-d reporting ")
+	assert.Len(t, m.execContaining(`-d postgres -v ON_ERROR_STOP=1 -c DROP ROLE IF EXISTS "migrator"`), 1)
+	m.secrets.AssertExpectations(t)
+}
+
+func TestDatabaseServiceLogicalDatabases(t *testing.T) {
+	ctx := appcontext.WithUserID(context.Background(), uuid.New())
+	db := runningDatabase(domain.EngineMySQL)
+	m, svc := setupDatabaseUsersTest(db)
+	m.users.users = []*domain.DatabaseUser{
+		{Username: "app_ro", Grants: []domain.DatabaseGrant{{Database: "app", Privilege: domain.DatabasePrivilegeReadOnly}}},
+	}
+	m.recordExecs("")
+
+	ldb, err := svc.CreateLogicalDatabase(ctx, db.ID, "reporting")
+	require.NoError(t, err)
+	assert.Equal(t, "reporting", ldb.Name)
+	assert.Equal(t, []string{"mysql -u root --execute CREATE DATABASE `reporting`;"}, m.execs)
+
+	_, err = svc.CreateLogicalDatabase(ctx, db.ID, "app")
+	assert.True(t, errors.Is(err, errors.Conflict))
+
+	_, err = svc.SetDatabaseUserGrants(ctx, db.ID, "app_ro", []domain.DatabaseGrant{
+		{Database: "app", Privilege: domain.DatabasePrivilegeReadOnly},
+		{Database: "reporting", Privilege: domain.DatabasePrivilegeReadOnly},
+	})
+	require.NoError(t, err)
+
+	m.execs = nil
+	require.NoError(t, svc.DeleteLogicalDatabase(ctx, db.ID, "reporting"))
+	// MySQL keeps grants on dropped databases, so they are revoked first.
+	assert.Equal(t, []string{
+		"mysql -u root --execute REVOKE ALL PRIVILEGES ON `reporting`.* FROM 'app_ro'@'%'; DROP DATABASE `reporting`;",
+	}, m.execs)
+	assert.Equal(t, []domain.DatabaseGrant{{Database: "app", Privilege: domain.DatabasePrivilegeReadOnly}}, m.users.users[0].Grants)
+	assert.Empty(t, m.users.dbs)
+
+	err = svc.DeleteLogicalDatabase(ctx, db.ID, "app")
+	assert.True(t, errors.Is(err, errors.NotFound))
+}
+
+func TestDatabaseServiceDeleteDatabaseRemovesUserCredentials(t *testing.T) {
+	ctx := appcontext.WithUserID(context.Background(), uuid.New())
+	db := runningDatabase(domain.EnginePostgres)
+	db.ContainerID = ""
+	m, svc := setupDatabaseUsersTest(db)
+	volumes := new(MockVolumeService)
+	svc = services.NewDatabaseService(services.DatabaseServiceParams{
+		Repo: m.repo, RBAC: allowAllRBAC(), Compute: m.compute, VpcRepo: new(MockVpcRepo), VolumeSvc: volumes,
+		EventSvc: m.events, AuditSvc: m.auditSvc, Secrets: m.secrets, UserRepo: m.users, Logger: slog.Default(),
+	})
+	m.users.users = []*domain.DatabaseUser{{Username: "app_ro", CredentialPath: "vault/app_ro/v3"}}
+	m.secrets.On("DeleteSecret", mock.Anything, "vault/app_ro/v3").Return(nil).Once()
+	volumes.On("ListVolumes", mock.Anything).Return([]*domain.Volume{}, nil)
+	m.repo.On("Delete", mock.Anything, db.ID).Return(nil)
+
+	require.NoError(t, svc.DeleteDatabase(ctx, db.ID))
+	m.secrets.AssertExpectations(t)
+}
+
+func allowAllRBAC() *mockRBACService {
+	rbac := new(mockRBACService)
+	rbac.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	return rbac
+}
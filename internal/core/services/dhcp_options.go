@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/errors"
+)
+
+// DHCPOptionsService manages DHCP options sets and their association with VPCs.
+// Options are applied to instances when they are provisioned, so changes only
+// reach instances launched afterwards.
+type DHCPOptionsService struct {
+	repo     ports.DHCPOptionsRepository
+	vpcRepo  ports.VpcRepository
+	rbacSvc  ports.RBACService
+	auditSvc ports.AuditService
+	logger   *slog.Logger
+}
+
+// DHCPOptionsServiceParams holds dependencies for DHCPOptionsService.
+type DHCPOptionsServiceParams struct {
+	Repo     ports.DHCPOptionsRepository
+	VpcRepo  ports.VpcRepository
+	RBACSvc  ports.RBACService
+	AuditSvc ports.AuditService
+	Logger   *slog.Logger
+}
+
+// NewDHCPOptionsService constructs a DHCPOptionsService with its dependencies.
+func NewDHCPOptionsService(params DHCPOptionsServiceParams) *DHCPOptionsService {
+	logger := params.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &DHCPOptionsService{
+		repo:     params.Repo,
+		vpcRepo:  params.VpcRepo,
+		rbacSvc:  params.RBACSvc,
+		auditSvc: params.AuditSvc,
+		logger:   logger,
+	}
+}
+
+// CreateDHCPOptions validates and stores a new DHCP options set.
+func (s *DHCPOptionsService) CreateDHCPOptions(ctx context.Context, opts *domain.DHCPOptionsSet) (*domain.DHCPOptionsSet, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcCreate, "*"); err != nil {
+		return nil, err
+	}
+
+	opts.DomainName = strings.TrimSuffix(strings.ToLower(opts.DomainName), ".")
+	if err := opts.Validate(); err != nil {
+		return nil, errors.New(errors.InvalidInput, err.Error())
+	}
+
+	opts.ID = uuid.New()
+	opts.UserID = userID
+	opts.TenantID = tenantID
+	opts.ARN = fmt.Sprintf("arn:thecloud:vpc:local:%s:dhcp-options/%s", userID.String(), opts.ID.String())
+	opts.CreatedAt = time.Now()
+	if err := s.repo.Create(ctx, opts); err != nil {
+		return nil, err
+	}
+
+	if err := s.auditSvc.Log(ctx, userID, "dhcp_options.create", "dhcp_options", opts.ID.String(), map[string]interface{}{
+		"name": opts.Name,
+	}); err != nil {
+		s.logger.Warn("failed to log audit event", "error", err)
+	}
+
+	return opts, nil
+}
+
+// GetDHCPOptions retrieves a DHCP options set by ID.
+func (s *DHCPOptionsService) GetDHCPOptions(ctx context.Context, id uuid.UUID) (*domain.DHCPOptionsSet, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcRead, id.String()); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetByID(ctx, id)
+}
+
+// ListDHCPOptions returns the DHCP options sets of the current tenant.
+func (s *DHCPOptionsService) ListDHCPOptions(ctx context.Context) ([]*domain.DHCPOptionsSet, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcRead, "*"); err != nil {
+		return nil, err
+	}
+
+	return s.repo.List(ctx)
+}
+
+// DeleteDHCPOptions removes a DHCP options set. Sets still associated with a VPC cannot be deleted.
+func (s *DHCPOptionsService) DeleteDHCPOptions(ctx context.Context, id uuid.UUID) error {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcDelete, id.String()); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.auditSvc.Log(ctx, userID, "dhcp_options.delete", "dhcp_options", id.String(), nil); err != nil {
+		s.logger.Warn("failed to log audit event", "error", err)
+	}
+	return nil
+}
+
+// AssociateVPC applies a DHCP options set to a VPC.
+func (s *DHCPOptionsService) AssociateVPC(ctx context.Context, optionsID, vpcID uuid.UUID) error {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcUpdate, vpcID.String()); err != nil {
+		return err
+	}
+
+	if _, err := s.repo.GetByID(ctx, optionsID); err != nil {
+		return err
+	}
+	if _, err := s.vpcRepo.GetByID(ctx, vpcID); err != nil {
+		return errors.Wrap(errors.NotFound, "VPC not found", err)
+	}
+
+	if err := s.repo.SetVPCAssociation(ctx, vpcID, &optionsID); err != nil {
+		return err
+	}
+
+	if err := s.auditSvc.Log(ctx, userID, "dhcp_options.associate", "dhcp_options", optionsID.String(), map[string]interface{}{
+		"vpc_id": vpcID.String(),
+	}); err != nil {
+		s.logger.Warn("failed to log audit event", "error", err)
+	}
+
+	s.logger.Info("DHCP options associated", "dhcp_options_id", optionsID, "vpc_id", vpcID)
+	return nil
+}
+
+// DisassociateVPC removes the DHCP options set of a VPC.
+func (s *DHCPOptionsService) DisassociateVPC(ctx context.Context, vpcID uuid.UUID) error {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcUpdate, vpcID.String()); err != nil {
+		return err
+	}
+
+	if err := s.repo.SetVPCAssociation(ctx, vpcID, nil); err != nil {
+		return err
+	}
+
+	if err := s.auditSvc.Log(ctx, userID, "dhcp_options.disassociate", "vpc", vpcID.String(), nil); err != nil {
+		s.logger.Warn("failed to log audit event", "error", err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package services_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/services"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDHCPOptionsService(t *testing.T) {
+	ctx := appcontext.WithTenantID(appcontext.WithUserID(context.Background(), uuid.New()), uuid.New())
+	vpc := &domain.VPC{ID: uuid.New()}
+
+	setup := func() (*services.DHCPOptionsService, *MockDHCPOptionsRepo, *MockVpcRepo) {
+		repo := new(MockDHCPOptionsRepo)
+		vpcRepo := new(MockVpcRepo)
+		rbacSvc := new(MockRBACService)
+		rbacSvc.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+		audit := new(MockAuditService)
+		audit.On("Log", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+		svc := services.NewDHCPOptionsService(services.DHCPOptionsServiceParams{
+			Repo:     repo,
+			VpcRepo:  vpcRepo,
+			RBACSvc:  rbacSvc,
+			AuditSvc: audit,
+			Logger:   slog.Default(),
+		})
+		return svc, repo, vpcRepo
+	}
+
+	t.Run("CreateNormalizesDomainName", func(t *testing.T) {
+		svc, repo, _ := setup()
+		repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+		opts, err := svc.CreateDHCPOptions(ctx, &domain.DHCPOptionsSet{Name: "corp", DomainName: "Corp.Internal.", DomainNameServers: []string{"10.0.0.53"}})
+		require.NoError(t, err)
+		assert.Equal(t, "corp.internal", opts.DomainName)
+		assert.NotEqual(t, uuid.Nil, opts.ID)
+	})
+
+	t.Run("CreateRejectsInvalidServers", func(t *testing.T) {
+		svc, repo, _ := setup()
+
+		_, err := svc.CreateDHCPOptions(ctx, &domain.DHCPOptionsSet{Name: "corp", NTPServers: []string{"pool.ntp.org"}})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.InvalidInput))
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("CreateRequiresAnOption", func(t *testing.T) {
+		svc, _, _ := setup()
+
+		_, err := svc.CreateDHCPOptions(ctx, &domain.DHCPOptionsSet{Name: "empty"})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.InvalidInput))
+	})
+
+	t.Run("AssociateVPC", func(t *testing.T) {
+		svc, repo, vpcRepo := setup()
+		optsID := uuid.New()
+		repo.On("GetByID", mock.Anything, optsID).Return(&domain.DHCPOptionsSet{ID: optsID}, nil)
+		vpcRepo.On("GetByID", mock.Anything, vpc.ID).Return(vpc, nil)
+		repo.On("SetVPCAssociation", mock.Anything, vpc.ID, &optsID).Return(nil)
+
+		require.NoError(t, svc.AssociateVPC(ctx, optsID, vpc.ID))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("AssociateUnknownOptions", func(t *testing.T) {
+		svc, repo, _ := setup()
+		optsID := uuid.New()
+		repo.On("GetByID", mock.Anything, optsID).Return(nil, errors.New(errors.NotFound, "DHCP options set not found"))
+
+		err := svc.AssociateVPC(ctx, optsID, vpc.ID)
+		assert.True(t, errors.Is(err, errors.NotFound))
+		repo.AssertNotCalled(t, "SetVPCAssociation", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("DisassociateVPC", func(t *testing.T) {
+		svc, repo, _ := setup()
+		repo.On("SetVPCAssociation", mock.Anything, vpc.ID, (*uuid.UUID)(nil)).Return(nil)
+
+		require.NoError(t, svc.DisassociateVPC(ctx, vpc.ID))
+		repo.AssertExpectations(t)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net"
 	"regexp"
 	"strings"
 	"time"
@@ -32,6 +33,7 @@ type DNSService struct {
 	vpcRepo  ports.VpcRepository
 	auditSvc ports.AuditService
 	eventSvc ports.EventService
+	resolver ports.VPCResolverService
 	logger   *slog.Logger
 }
 
@@ -43,7 +45,9 @@ type DNSServiceParams struct {
 	VpcRepo  ports.VpcRepository
 	AuditSvc ports.AuditService
 	EventSvc ports.EventService
-	Logger   *slog.Logger
+	// ResolverSvc is optional; when set, VPC resolvers are refreshed as zones change.
+	ResolverSvc ports.VPCResolverService
+	Logger      *slog.Logger
 }
 
 // NewDNSService constructs a DNSService with its dependencies.
@@ -59,6 +63,7 @@ func NewDNSService(params DNSServiceParams) *DNSService {
 		vpcRepo:  params.VpcRepo,
 		auditSvc: params.AuditSvc,
 		eventSvc: params.EventSvc,
+		resolver: params.ResolverSvc,
 		logger:   logger,
 	}
 }
@@ -126,6 +131,8 @@ func (s *DNSService) CreateZone(ctx context.Context, vpcID uuid.UUID, name, desc
 		s.logger.Warn("failed to log audit event", "action", "dns.zone.create", "zone_id", zone.ID, "error", err)
 	}
 
+	s.refreshResolvers(ctx, vpcID)
+
 	s.logger.Info("created DNS zone", "zone", name, "vpc", vpc.Name)
 	return zone, nil
 }
@@ -198,9 +205,20 @@ func (s *DNSService) DeleteZone(ctx context.Context, idOrName string) error {
 		s.logger.Warn("failed to log audit event", "action", "dns.zone.delete", "zone_id", zone.ID, "error", err)
 	}
 
+	s.refreshResolvers(ctx, zone.VpcID)
 	return nil
 }
 
+// refreshResolvers pushes zone changes to the resolvers that serve the VPC's zone.
+func (s *DNSService) refreshResolvers(ctx context.Context, vpcID uuid.UUID) {
+	if s.resolver == nil {
+		return
+	}
+	if err := s.resolver.RefreshResolvers(ctx, vpcID); err != nil {
+		s.logger.Warn("failed to refresh VPC resolvers", "vpc_id", vpcID, "error", err)
+	}
+}
+
 // --- Record Operations ---
 
 func (s *DNSService) CreateRecord(ctx context.Context, zoneID uuid.UUID, name string, recordType domain.RecordType, content string, ttl int, priority *int) (*domain.DNSRecord, error) {
@@ -422,10 +440,16 @@ func (s *DNSService) RegisterInstance(ctx context.Context, instance *domain.Inst
 
 	fqdn := fmt.Sprintf("%s.%s.", instance.Name, zone.Name)
 
+	// IPv6 addresses of dual-stack instances get an AAAA record
+	recordType := domain.RecordTypeA
+	if ip := net.ParseIP(ipAddress); ip != nil && ip.To4() == nil {
+		recordType = domain.RecordTypeAAAA
+	}
+
 	// Add record to PowerDNS
 	recordSet := ports.RecordSet{
 		Name:    fqdn,
-		Type:    "A",
+		Type:    string(recordType),
 		TTL:     zone.DefaultTTL,
 		Records: []string{ipAddress},
 	}
@@ -440,7 +464,7 @@ func (s *DNSService) RegisterInstance(ctx context.Context, instance *domain.Inst
 		ZoneID:      zone.ID,
 		TenantID:    zone.TenantID,
 		Name:        instance.Name,
-		Type:        domain.RecordTypeA,
+		Type:        recordType,
 		Content:     ipAddress,
 		TTL:         zone.DefaultTTL,
 		AutoManaged: true,
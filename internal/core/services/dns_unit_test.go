@@ -274,6 +274,24 @@ func testDNSServiceUnitExtended(t *testing.T) {
 		err := svc.RegisterInstance(ctx, inst, "10.0.0.10")
 		require.NoError(t, err)
 	})
+
+	t.Run("RegisterInstanceIPv6", func(t *testing.T) {
+		vpcID := uuid.New()
+		instID := uuid.New()
+		inst := &domain.Instance{ID: instID, Name: "web-1", VpcID: &vpcID}
+		zone := &domain.DNSZone{ID: uuid.New(), Name: "example.com", PowerDNSID: "example.com.", DefaultTTL: 300}
+
+		repo.On("GetZoneByVPC", mock.Anything, vpcID).Return(zone, nil).Once()
+		backend.On("AddRecords", mock.Anything, "example.com.", mock.MatchedBy(func(sets []ports.RecordSet) bool {
+			return len(sets) == 1 && sets[0].Type == "AAAA" && sets[0].Records[0] == "fd00:1:2:3::2"
+		})).Return(nil).Once()
+		repo.On("CreateRecord", mock.Anything, mock.MatchedBy(func(r *domain.DNSRecord) bool {
+			return r.Type == domain.RecordTypeAAAA && r.Content == "fd00:1:2:3::2"
+		})).Return(nil).Once()
+
+		err := svc.RegisterInstance(ctx, inst, "fd00:1:2:3::2")
+		require.NoError(t, err)
+	})
 }
 
 // testGetZoneByVPC tests the GetZoneByVPC method with table-driven cases
@@ -19,7 +19,9 @@ type elasticIPService struct {
 	repo         ports.ElasticIPRepository
 	rbacSvc      ports.RBACService
 	instanceRepo ports.InstanceRepository
+	eniRepo      ports.NetworkInterfaceRepository
 	auditSvc     ports.AuditService
+	tenantSvc    ports.TenantService
 	logger       *slog.Logger
 }
 
@@ -28,7 +30,9 @@ type ElasticIPServiceParams struct {
 	Repo         ports.ElasticIPRepository
 	RBAC         ports.RBACService
 	InstanceRepo ports.InstanceRepository
+	ENIRepo      ports.NetworkInterfaceRepository // Optional, enables network interface associations
 	AuditSvc     ports.AuditService
+	TenantSvc    ports.TenantService // Optional, enforces the elastic_ips quota
 	Logger       *slog.Logger
 }
 
@@ -42,7 +46,9 @@ func NewElasticIPService(params ElasticIPServiceParams) ports.ElasticIPService {
 		repo:         params.Repo,
 		rbacSvc:      params.RBAC,
 		instanceRepo: params.InstanceRepo,
+		eniRepo:      params.ENIRepo,
 		auditSvc:     params.AuditSvc,
+		tenantSvc:    params.TenantSvc,
 		logger:       logger,
 	}
 }
@@ -54,6 +60,9 @@ func (s *elasticIPService) AllocateIP(ctx context.Context) (*domain.ElasticIP, e
 	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcUpdate, "*"); err != nil {
 		return nil, err
 	}
+	if err := checkQuota(ctx, s.tenantSvc, domain.QuotaElasticIPs, 1); err != nil {
+		return nil, err
+	}
 
 	// Retry on potential IP collision (theoretical race with concurrent allocation).
 	// The IP is generated from UUID bytes 12-15 which has limited entropy,
@@ -86,6 +95,7 @@ func (s *elasticIPService) AllocateIP(ctx context.Context) (*domain.ElasticIP, e
 			}
 			return nil, err
 		}
+		recordUsage(ctx, s.tenantSvc, s.logger, tenantID, domain.QuotaElasticIPs, 1)
 
 		if err := s.auditSvc.Log(ctx, userID, "eip.allocate", "eip", id.String(), map[string]interface{}{
 			"public_ip": publicIP,
@@ -119,6 +129,7 @@ func (s *elasticIPService) ReleaseIP(ctx context.Context, id uuid.UUID) error {
 	if err := s.repo.Delete(ctx, id); err != nil {
 		return err
 	}
+	recordUsage(ctx, s.tenantSvc, s.logger, tenantID, domain.QuotaElasticIPs, -1)
 
 	if err := s.auditSvc.Log(ctx, eip.UserID, "eip.release", "eip", id.String(), map[string]interface{}{
 		"public_ip": eip.PublicIP,
@@ -189,6 +200,64 @@ func (s *elasticIPService) AssociateIP(ctx context.Context, id uuid.UUID, instan
 	return eip, nil
 }
 
+func (s *elasticIPService) AssociateNetworkInterface(ctx context.Context, id, interfaceID uuid.UUID, privateIP string) (*domain.ElasticIP, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcUpdate, id.String()); err != nil {
+		return nil, err
+	}
+	if s.eniRepo == nil {
+		return nil, errors.New(errors.NotImplemented, "network interfaces are not enabled")
+	}
+
+	eip, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ni, err := s.eniRepo.GetByID(ctx, interfaceID)
+	if err != nil {
+		return nil, err
+	}
+	if privateIP == "" {
+		privateIP = ni.PrimaryIP()
+	}
+	if !ni.HasPrivateIP(privateIP) {
+		return nil, errors.New(errors.InvalidInput, "private ip is not assigned to the network interface")
+	}
+
+	if eip.Status != domain.EIPStatusAllocated {
+		if eip.NetworkInterfaceID != nil && *eip.NetworkInterfaceID == interfaceID && eip.PrivateIP == privateIP {
+			return eip, nil
+		}
+		return nil, errors.New(errors.Conflict, "elastic ip is already associated")
+	}
+
+	vpcID := ni.VpcID
+	eip.InstanceID = nil
+	eip.NetworkInterfaceID = &interfaceID
+	eip.PrivateIP = privateIP
+	eip.VpcID = &vpcID
+	eip.Status = domain.EIPStatusAssociated
+	eip.UpdatedAt = time.Now()
+
+	// The unique index on (network_interface_id, private_ip) rejects a second EIP on the same address.
+	if err := s.repo.Update(ctx, eip); err != nil {
+		return nil, err
+	}
+
+	if err := s.auditSvc.Log(ctx, eip.UserID, "eip.associate", "eip", id.String(), map[string]interface{}{
+		"network_interface_id": interfaceID,
+		"private_ip":           privateIP,
+		"public_ip":            eip.PublicIP,
+	}); err != nil {
+		s.logger.Warn("audit log failed for eip.associate", "error", err)
+	}
+
+	return eip, nil
+}
+
 func (s *elasticIPService) DisassociateIP(ctx context.Context, id uuid.UUID) (*domain.ElasticIP, error) {
 	userID := appcontext.UserIDFromContext(ctx)
 	tenantID := appcontext.TenantIDFromContext(ctx)
@@ -207,8 +276,11 @@ func (s *elasticIPService) DisassociateIP(ctx context.Context, id uuid.UUID) (*d
 	}
 
 	oldInstanceID := eip.InstanceID
+	oldInterfaceID := eip.NetworkInterfaceID
 
 	eip.InstanceID = nil
+	eip.NetworkInterfaceID = nil
+	eip.PrivateIP = ""
 	eip.VpcID = nil
 	eip.Status = domain.EIPStatusAllocated
 	eip.UpdatedAt = time.Now()
@@ -218,8 +290,9 @@ func (s *elasticIPService) DisassociateIP(ctx context.Context, id uuid.UUID) (*d
 	}
 
 	if err := s.auditSvc.Log(ctx, eip.UserID, "eip.disassociate", "eip", id.String(), map[string]interface{}{
-		"instance_id": oldInstanceID,
-		"public_ip":   eip.PublicIP,
+		"instance_id":          oldInstanceID,
+		"network_interface_id": oldInterfaceID,
+		"public_ip":            eip.PublicIP,
 	}); err != nil {
 		s.logger.Warn("audit log failed for eip.disassociate", "error", err)
 	}
@@ -18,6 +18,7 @@ func TestElasticIPService_Unit(t *testing.T) {
 	t.Run("AllocateIP", testElasticIPServiceAllocateIP)
 	t.Run("ReleaseIP", testElasticIPServiceReleaseIP)
 	t.Run("AssociateIP", testElasticIPServiceAssociateIP)
+	t.Run("AssociateNetworkInterface", testElasticIPServiceAssociateNetworkInterface)
 	t.Run("DisassociateIP", testElasticIPServiceDisassociateIP)
 	t.Run("ListAndGet", testElasticIPServiceListAndGet)
 }
@@ -119,6 +120,53 @@ func testElasticIPServiceAssociateIP(t *testing.T) {
 	})
 }
 
+func testElasticIPServiceAssociateNetworkInterface(t *testing.T) {
+	repo := new(MockElasticIPRepo)
+	auditSvc := new(MockAuditService)
+	rbacSvc := new(MockRBACService)
+	eniRepo := new(MockNetworkInterfaceRepo)
+	rbacSvc.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	auditSvc.On("Log", mock.Anything, mock.Anything, "eip.associate", "eip", mock.Anything, mock.Anything).Return(nil)
+
+	svc := services.NewElasticIPService(services.ElasticIPServiceParams{
+		Repo: repo, AuditSvc: auditSvc, RBAC: rbacSvc, ENIRepo: eniRepo,
+	})
+
+	id := uuid.New()
+	ni := &domain.NetworkInterface{ID: uuid.New(), VpcID: uuid.New(), PrivateIPs: []string{"10.0.1.10", "10.0.1.11"}}
+	eniRepo.On("GetByID", mock.Anything, ni.ID).Return(ni, nil)
+
+	t.Run("defaults to primary ip", func(t *testing.T) {
+		repo.On("GetByID", mock.Anything, id).Return(&domain.ElasticIP{ID: id, Status: domain.EIPStatusAllocated}, nil).Once()
+		repo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+
+		result, err := svc.AssociateNetworkInterface(context.Background(), id, ni.ID, "")
+		require.NoError(t, err)
+		assert.Equal(t, domain.EIPStatusAssociated, result.Status)
+		assert.Equal(t, ni.ID, *result.NetworkInterfaceID)
+		assert.Equal(t, "10.0.1.10", result.PrivateIP)
+		assert.Equal(t, ni.VpcID, *result.VpcID)
+		assert.Nil(t, result.InstanceID)
+	})
+
+	t.Run("secondary ip", func(t *testing.T) {
+		repo.On("GetByID", mock.Anything, id).Return(&domain.ElasticIP{ID: id, Status: domain.EIPStatusAllocated}, nil).Once()
+		repo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+
+		result, err := svc.AssociateNetworkInterface(context.Background(), id, ni.ID, "10.0.1.11")
+		require.NoError(t, err)
+		assert.Equal(t, "10.0.1.11", result.PrivateIP)
+	})
+
+	t.Run("foreign ip", func(t *testing.T) {
+		repo.On("GetByID", mock.Anything, id).Return(&domain.ElasticIP{ID: id, Status: domain.EIPStatusAllocated}, nil).Once()
+
+		_, err := svc.AssociateNetworkInterface(context.Background(), id, ni.ID, "10.0.1.99")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not assigned")
+	})
+}
+
 func testElasticIPServiceDisassociateIP(t *testing.T) {
 	repo := new(MockElasticIPRepo)
 	auditSvc := new(MockAuditService)
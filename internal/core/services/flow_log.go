@@ -0,0 +1,461 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/errors"
+)
+
+// FlowLogServiceParams defines dependencies for flowLogService.
+type FlowLogServiceParams struct {
+	Repo         ports.FlowLogRepository
+	VpcRepo      ports.VpcRepository
+	SubnetRepo   ports.SubnetRepository
+	InstanceRepo ports.InstanceRepository
+	Network      ports.NetworkBackend
+	LogSvc       ports.LogService
+	StorageSvc   ports.StorageService
+	RBACSvc      ports.RBACService
+	AuditSvc     ports.AuditService
+	Logger       *slog.Logger
+}
+
+type flowLogService struct {
+	repo         ports.FlowLogRepository
+	vpcRepo      ports.VpcRepository
+	subnetRepo   ports.SubnetRepository
+	instanceRepo ports.InstanceRepository
+	network      ports.NetworkBackend
+	logSvc       ports.LogService
+	storageSvc   ports.StorageService
+	rbacSvc      ports.RBACService
+	auditSvc     ports.AuditService
+	logger       *slog.Logger
+
+	// counters holds the last counter snapshot per flow log so each capture reports
+	// only the traffic of its own interval. It is rebuilt after a restart.
+	mu       sync.Mutex
+	counters map[uuid.UUID]map[string]flowCounter
+}
+
+type flowCounter struct {
+	packets uint64
+	bytes   uint64
+}
+
+// NewFlowLogService creates a service that captures VPC traffic records.
+func NewFlowLogService(params FlowLogServiceParams) *flowLogService {
+	logger := params.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &flowLogService{
+		repo:         params.Repo,
+		vpcRepo:      params.VpcRepo,
+		subnetRepo:   params.SubnetRepo,
+		instanceRepo: params.InstanceRepo,
+		network:      params.Network,
+		logSvc:       params.LogSvc,
+		storageSvc:   params.StorageSvc,
+		rbacSvc:      params.RBACSvc,
+		auditSvc:     params.AuditSvc,
+		logger:       logger,
+		counters:     map[uuid.UUID]map[string]flowCounter{},
+	}
+}
+
+func (s *flowLogService) CreateFlowLog(ctx context.Context, params ports.CreateFlowLogParams) (*domain.FlowLog, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionFlowLogCreate, "*"); err != nil {
+		return nil, err
+	}
+
+	if params.TrafficType == "" {
+		params.TrafficType = domain.FlowLogTrafficAll
+	}
+	if params.Destination == "" {
+		params.Destination = domain.FlowLogDestinationCloudLogs
+	}
+	if params.IntervalSeconds == 0 {
+		params.IntervalSeconds = domain.FlowLogIntervalLong
+	}
+	if err := validateFlowLogParams(params); err != nil {
+		return nil, err
+	}
+
+	vpcID, err := s.resourceVPC(ctx, params.ResourceType, params.ResourceID)
+	if err != nil {
+		return nil, err
+	}
+	if params.Destination == domain.FlowLogDestinationBucket {
+		// Resolving the bucket through its service checks the caller may use it.
+		if _, err := s.storageSvc.GetBucket(ctx, params.Bucket); err != nil {
+			return nil, err
+		}
+	} else {
+		params.Bucket = ""
+	}
+
+	fl := &domain.FlowLog{
+		ID:              uuid.New(),
+		UserID:          userID,
+		TenantID:        tenantID,
+		ResourceType:    params.ResourceType,
+		ResourceID:      params.ResourceID,
+		VPCID:           vpcID,
+		TrafficType:     params.TrafficType,
+		Destination:     params.Destination,
+		Bucket:          params.Bucket,
+		IntervalSeconds: params.IntervalSeconds,
+		Status:          domain.FlowLogStatusActive,
+		CreatedAt:       time.Now(),
+	}
+	if err := s.repo.Create(ctx, fl); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, "flow_log.create", fl)
+	return fl, nil
+}
+
+func (s *flowLogService) GetFlowLog(ctx context.Context, id uuid.UUID) (*domain.FlowLog, error) {
+	if err := s.rbacSvc.Authorize(ctx, appcontext.UserIDFromContext(ctx), appcontext.TenantIDFromContext(ctx), domain.PermissionFlowLogRead, id.String()); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *flowLogService) ListFlowLogs(ctx context.Context) ([]*domain.FlowLog, error) {
+	if err := s.rbacSvc.Authorize(ctx, appcontext.UserIDFromContext(ctx), appcontext.TenantIDFromContext(ctx), domain.PermissionFlowLogRead, "*"); err != nil {
+		return nil, err
+	}
+	return s.repo.List(ctx)
+}
+
+func (s *flowLogService) DeleteFlowLog(ctx context.Context, id uuid.UUID) error {
+	if err := s.rbacSvc.Authorize(ctx, appcontext.UserIDFromContext(ctx), appcontext.TenantIDFromContext(ctx), domain.PermissionFlowLogDelete, id.String()); err != nil {
+		return err
+	}
+	fl, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	delete(s.counters, id)
+	s.mu.Unlock()
+
+	s.audit(ctx, "flow_log.delete", fl)
+	return nil
+}
+
+func (s *flowLogService) CaptureDue(ctx context.Context) (int, error) {
+	flowLogs, err := s.repo.ListAll(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	delivered := 0
+	for _, fl := range flowLogs {
+		if !fl.CaptureDue(now) {
+			continue
+		}
+
+		// Capture as the owner, who must still be able to write to the destination.
+		ownerCtx := appcontext.WithTenantID(appcontext.WithUserID(ctx, fl.UserID), fl.TenantID)
+		status, lastError := domain.FlowLogStatusActive, ""
+		if err := s.capture(ownerCtx, fl, now); err != nil {
+			s.logger.Warn("flow log capture failed", "flow_log_id", fl.ID, "error", err)
+			status, lastError = domain.FlowLogStatusError, err.Error()
+		} else {
+			delivered++
+		}
+		if err := s.repo.UpdateCapture(ctx, fl.ID, now, status, lastError); err != nil {
+			s.logger.Error("failed to record flow log capture", "flow_log_id", fl.ID, "error", err)
+		}
+	}
+	return delivered, nil
+}
+
+func (s *flowLogService) capture(ctx context.Context, fl *domain.FlowLog, now time.Time) error {
+	vpc, err := s.vpcRepo.GetByID(ctx, fl.VPCID)
+	if err != nil {
+		return err
+	}
+	scope, err := s.resourceScope(ctx, fl)
+	if err != nil {
+		return err
+	}
+
+	start := fl.CreatedAt
+	if fl.LastCapturedAt != nil {
+		start = *fl.LastCapturedAt
+	}
+
+	current := map[string]flowCounter{}
+	var records []capturedFlow
+
+	if fl.TrafficType.Includes(domain.FlowLogTrafficAccept) {
+		entries, err := s.network.ListConntrackEntries(ctx, vpc.VXLANID)
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			if !scope.contains(e.Src) && !scope.contains(e.Dst) {
+				continue
+			}
+			key := conntrackKey(e)
+			current[key] = flowCounter{packets: e.Packets, bytes: e.Bytes}
+			records = append(records, capturedFlow{key: key, record: domain.FlowLogRecord{
+				Protocol: e.Protocol, Src: e.Src, Dst: e.Dst, SrcPort: e.SrcPort, DstPort: e.DstPort,
+				Action: domain.FlowLogTrafficAccept,
+			}})
+		}
+	}
+
+	if fl.TrafficType.Includes(domain.FlowLogTrafficReject) {
+		flows, err := s.network.ListFlowRules(ctx, vpc.NetworkID)
+		if err != nil {
+			return err
+		}
+		for _, f := range flows {
+			if !strings.EqualFold(strings.TrimSpace(f.Actions), "drop") {
+				continue
+			}
+			record := dropFlowRecord(f)
+			// Drops that name no address can only be attributed to the whole VPC.
+			if !scope.all() && !scope.contains(record.Src) && !scope.contains(record.Dst) {
+				continue
+			}
+			key := "drop|" + flowKey(f)
+			current[key] = flowCounter{packets: f.Packets, bytes: f.Bytes}
+			records = append(records, capturedFlow{key: key, record: record})
+		}
+	}
+
+	out := s.applyDeltas(fl, current, records)
+	if len(out) == 0 {
+		return nil
+	}
+	for i := range out {
+		out[i].Start = start
+		out[i].End = now
+	}
+	return s.deliver(ctx, fl, out, now)
+}
+
+// capturedFlow pairs a record with the key its cumulative counters are tracked under.
+type capturedFlow struct {
+	key    string
+	record domain.FlowLogRecord
+}
+
+// applyDeltas turns cumulative counters into per-interval traffic and drops records
+// that saw none. Without a previous snapshot, counters are only taken as a baseline
+// unless this is the flow log's first capture, so a restart does not replay traffic.
+func (s *flowLogService) applyDeltas(fl *domain.FlowLog, current map[string]flowCounter, captured []capturedFlow) []domain.FlowLogRecord {
+	s.mu.Lock()
+	previous, seen := s.counters[fl.ID]
+	s.counters[fl.ID] = current
+	s.mu.Unlock()
+
+	if !seen && fl.LastCapturedAt != nil {
+		return nil
+	}
+
+	var out []domain.FlowLogRecord
+	for _, c := range captured {
+		now, prev := current[c.key], previous[c.key]
+		r := c.record
+		r.Packets, r.Bytes = now.packets, now.bytes
+		// A counter that went backwards belongs to a new connection or a re-added flow.
+		if now.packets >= prev.packets && now.bytes >= prev.bytes {
+			r.Packets, r.Bytes = now.packets-prev.packets, now.bytes-prev.bytes
+		}
+		if r.Packets > 0 {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+func (s *flowLogService) deliver(ctx context.Context, fl *domain.FlowLog, records []domain.FlowLogRecord, now time.Time) error {
+	switch fl.Destination {
+	case domain.FlowLogDestinationBucket:
+		var buf bytes.Buffer
+		for _, r := range records {
+			buf.WriteString(r.String())
+			buf.WriteByte('\n')
+		}
+		key := fmt.Sprintf("flow-logs/%s/%s.log", fl.ID, now.UTC().Format("2006/01/02/150405"))
+		_, err := s.storageSvc.Upload(ctx, fl.Bucket, key, &buf, "")
+		return err
+	default:
+		entries := make([]*domain.LogEntry, 0, len(records))
+		for _, r := range records {
+			entries = append(entries, &domain.LogEntry{
+				ID:           uuid.New(),
+				TenantID:     fl.TenantID,
+				ResourceID:   fl.ID.String(),
+				ResourceType: domain.FlowLogResourceTypeLog,
+				Level:        "INFO",
+				Message:      r.String(),
+				Timestamp:    now,
+			})
+		}
+		return s.logSvc.IngestLogs(ctx, entries)
+	}
+}
+
+// resourceVPC checks the resource exists in the caller's tenant and returns its VPC.
+func (s *flowLogService) resourceVPC(ctx context.Context, resourceType domain.FlowLogResourceType, id uuid.UUID) (uuid.UUID, error) {
+	switch resourceType {
+	case domain.FlowLogResourceVPC:
+		vpc, err := s.vpcRepo.GetByID(ctx, id)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return vpc.ID, nil
+	case domain.FlowLogResourceSubnet:
+		subnet, err := s.subnetRepo.GetByID(ctx, id)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return subnet.VPCID, nil
+	default:
+		inst, err := s.instanceRepo.GetByID(ctx, id)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		if inst.VpcID == nil {
+			return uuid.Nil, errors.New(errors.InvalidInput, "instance is not attached to a VPC")
+		}
+		return *inst.VpcID, nil
+	}
+}
+
+// resourceScope resolves the addresses a flow log covers at capture time, since an
+// instance may only get its private IP after the flow log was created.
+func (s *flowLogService) resourceScope(ctx context.Context, fl *domain.FlowLog) (flowLogScope, error) {
+	switch fl.ResourceType {
+	case domain.FlowLogResourceSubnet:
+		subnet, err := s.subnetRepo.GetByID(ctx, fl.ResourceID)
+		if err != nil {
+			return flowLogScope{}, err
+		}
+		_, ipNet, err := net.ParseCIDR(subnet.CIDRBlock)
+		if err != nil {
+			return flowLogScope{}, errors.Wrap(errors.Internal, "invalid subnet CIDR", err)
+		}
+		return flowLogScope{ipNet: ipNet}, nil
+	case domain.FlowLogResourceInstance:
+		inst, err := s.instanceRepo.GetByID(ctx, fl.ResourceID)
+		if err != nil {
+			return flowLogScope{}, err
+		}
+		ip := net.ParseIP(inst.PrivateIP)
+		if ip == nil {
+			// Nothing to match until the instance has an address.
+			return flowLogScope{none: true}, nil
+		}
+		return flowLogScope{ipNet: &net.IPNet{IP: ip, Mask: net.CIDRMask(len(ip)*8, len(ip)*8)}}, nil
+	default:
+		return flowLogScope{}, nil
+	}
+}
+
+// flowLogScope matches addresses inside a subnet or instance; the zero value covers the whole VPC.
+type flowLogScope struct {
+	ipNet *net.IPNet
+	none  bool
+}
+
+func (s flowLogScope) all() bool { return s.ipNet == nil && !s.none }
+
+func (s flowLogScope) contains(addr string) bool {
+	if s.none {
+		return false
+	}
+	if s.ipNet == nil {
+		return true
+	}
+	ip := net.ParseIP(strings.SplitN(addr, "/", 2)[0])
+	return ip != nil && s.ipNet.Contains(ip)
+}
+
+// dropFlowRecord describes a drop rule's traffic using whatever 5-tuple fields its match names.
+func dropFlowRecord(f ports.FlowRule) domain.FlowLogRecord {
+	r := domain.FlowLogRecord{Protocol: "ip", Action: domain.FlowLogTrafficReject}
+	for _, field := range strings.Split(f.Match, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(field), "=")
+		switch key {
+		case "tcp", "udp", "icmp", "arp", "tcp6", "udp6", "icmp6", "ipv6":
+			r.Protocol = key
+		case "nw_src", "ipv6_src":
+			r.Src = value
+		case "nw_dst", "ipv6_dst":
+			r.Dst = value
+		case "tp_src":
+			r.SrcPort, _ = strconv.Atoi(value)
+		case "tp_dst":
+			r.DstPort, _ = strconv.Atoi(value)
+		}
+	}
+	return r
+}
+
+func conntrackKey(e ports.ConntrackEntry) string {
+	if e.ID != 0 {
+		return fmt.Sprintf("ct|%d", e.ID)
+	}
+	return fmt.Sprintf("ct|%s|%s|%d|%s|%d", e.Protocol, e.Src, e.SrcPort, e.Dst, e.DstPort)
+}
+
+func validateFlowLogParams(params ports.CreateFlowLogParams) error {
+	if !params.ResourceType.IsValid() {
+		return errors.New(errors.InvalidInput, "resource_type must be vpc, subnet or instance")
+	}
+	if params.ResourceID == uuid.Nil {
+		return errors.New(errors.InvalidInput, "resource_id is required")
+	}
+	if !params.TrafficType.IsValid() {
+		return errors.New(errors.InvalidInput, "traffic_type must be ACCEPT, REJECT or ALL")
+	}
+	if !params.Destination.IsValid() {
+		return errors.New(errors.InvalidInput, "destination must be cloudlogs or bucket")
+	}
+	if params.Destination == domain.FlowLogDestinationBucket && params.Bucket == "" {
+		return errors.New(errors.InvalidInput, "bucket is required for bucket destination")
+	}
+	if params.IntervalSeconds != domain.FlowLogIntervalShort && params.IntervalSeconds != domain.FlowLogIntervalLong {
+		return errors.New(errors.InvalidInput, fmt.Sprintf("interval_seconds must be %d or %d", domain.FlowLogIntervalShort, domain.FlowLogIntervalLong))
+	}
+	return nil
+}
+
+func (s *flowLogService) audit(ctx context.Context, action string, fl *domain.FlowLog) {
+	if err := s.auditSvc.Log(ctx, appcontext.UserIDFromContext(ctx), action, "flow_log", fl.ID.String(), map[string]interface{}{
+		"resource_type": fl.ResourceType,
+		"resource_id":   fl.ResourceID.String(),
+		"destination":   fl.Destination,
+	}); err != nil {
+		s.logger.Warn("failed to log audit event", "action", action, "flow_log_id", fl.ID, "error", err)
+	}
+}
@@ -0,0 +1,275 @@
+package services_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/core/services"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// flowLogStorage stubs the storage calls flow logs make.
+type flowLogStorage struct {
+	ports.StorageService
+	mock.Mock
+}
+
+func (m *flowLogStorage) GetBucket(ctx context.Context, name string) (*domain.Bucket, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Bucket), args.Error(1)
+}
+
+func (m *flowLogStorage) Upload(ctx context.Context, bucket, key string, r io.Reader, checksum string) (*domain.Object, error) {
+	body, _ := io.ReadAll(r)
+	args := m.Called(ctx, bucket, key, string(body))
+	return &domain.Object{Bucket: bucket, Key: key}, args.Error(0)
+}
+
+func TestFlowLogService(t *testing.T) {
+	userID := uuid.New()
+	tenantID := uuid.New()
+	ctx := appcontext.WithTenantID(appcontext.WithUserID(context.Background(), userID), tenantID)
+	vpc := &domain.VPC{ID: uuid.New(), TenantID: tenantID, NetworkID: "br-vpc", VXLANID: 101}
+	subnet := &domain.Subnet{ID: uuid.New(), VPCID: vpc.ID, CIDRBlock: "10.0.1.0/24"}
+
+	type mocks struct {
+		repo     *MockFlowLogRepo
+		vpcRepo  *MockVpcRepo
+		subnets  *MockSubnetRepo
+		insts    *MockInstanceRepo
+		network  *MockNetworkBackend
+		logSvc   *MockLogService
+		storage  *flowLogStorage
+		rbacSvc  *MockRBACService
+		auditSvc *MockAuditService
+	}
+
+	setup := func() (*mocks, ports.FlowLogService) {
+		m := &mocks{
+			repo:     new(MockFlowLogRepo),
+			vpcRepo:  new(MockVpcRepo),
+			subnets:  new(MockSubnetRepo),
+			insts:    new(MockInstanceRepo),
+			network:  new(MockNetworkBackend),
+			logSvc:   new(MockLogService),
+			storage:  new(flowLogStorage),
+			rbacSvc:  new(MockRBACService),
+			auditSvc: new(MockAuditService),
+		}
+		m.rbacSvc.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+		m.auditSvc.On("Log", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+		m.vpcRepo.On("GetByID", mock.Anything, vpc.ID).Return(vpc, nil).Maybe()
+		m.subnets.On("GetByID", mock.Anything, subnet.ID).Return(subnet, nil).Maybe()
+		svc := services.NewFlowLogService(services.FlowLogServiceParams{
+			Repo:         m.repo,
+			VpcRepo:      m.vpcRepo,
+			SubnetRepo:   m.subnets,
+			InstanceRepo: m.insts,
+			Network:      m.network,
+			LogSvc:       m.logSvc,
+			StorageSvc:   m.storage,
+			RBACSvc:      m.rbacSvc,
+			AuditSvc:     m.auditSvc,
+			Logger:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+		})
+		return m, svc
+	}
+
+	t.Run("CreateDefaultsAndResolvesVPC", func(t *testing.T) {
+		m, svc := setup()
+		m.repo.On("Create", mock.Anything, mock.MatchedBy(func(fl *domain.FlowLog) bool {
+			return fl.VPCID == vpc.ID && fl.TenantID == tenantID && fl.UserID == userID
+		})).Return(nil).Once()
+
+		fl, err := svc.CreateFlowLog(ctx, ports.CreateFlowLogParams{ResourceType: domain.FlowLogResourceSubnet, ResourceID: subnet.ID})
+		require.NoError(t, err)
+		assert.Equal(t, domain.FlowLogTrafficAll, fl.TrafficType)
+		assert.Equal(t, domain.FlowLogDestinationCloudLogs, fl.Destination)
+		assert.Equal(t, domain.FlowLogIntervalLong, fl.IntervalSeconds)
+		assert.Equal(t, domain.FlowLogStatusActive, fl.Status)
+		m.repo.AssertExpectations(t)
+	})
+
+	t.Run("CreateRejectsInvalidParams", func(t *testing.T) {
+		_, svc := setup()
+		cases := []ports.CreateFlowLogParams{
+			{ResourceType: "volume", ResourceID: vpc.ID},
+			{ResourceType: domain.FlowLogResourceVPC, ResourceID: vpc.ID, IntervalSeconds: 30},
+			{ResourceType: domain.FlowLogResourceVPC, ResourceID: vpc.ID, TrafficType: "SOME"},
+			{ResourceType: domain.FlowLogResourceVPC, ResourceID: vpc.ID, Destination: domain.FlowLogDestinationBucket},
+		}
+		for _, params := range cases {
+			_, err := svc.CreateFlowLog(ctx, params)
+			assert.True(t, errors.Is(err, errors.InvalidInput), "params %+v", params)
+		}
+	})
+
+	t.Run("CreateRequiresInstanceInVPC", func(t *testing.T) {
+		m, svc := setup()
+		inst := &domain.Instance{ID: uuid.New()}
+		m.insts.On("GetByID", mock.Anything, inst.ID).Return(inst, nil).Once()
+
+		_, err := svc.CreateFlowLog(ctx, ports.CreateFlowLogParams{ResourceType: domain.FlowLogResourceInstance, ResourceID: inst.ID})
+		assert.True(t, errors.Is(err, errors.InvalidInput))
+	})
+
+	t.Run("CreateChecksBucket", func(t *testing.T) {
+		m, svc := setup()
+		m.storage.On("GetBucket", mock.Anything, "missing").Return(nil, errors.New(errors.NotFound, "bucket not found")).Once()
+
+		_, err := svc.CreateFlowLog(ctx, ports.CreateFlowLogParams{
+			ResourceType: domain.FlowLogResourceVPC, ResourceID: vpc.ID, Destination: domain.FlowLogDestinationBucket, Bucket: "missing",
+		})
+		assert.True(t, errors.Is(err, errors.NotFound))
+		m.repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("CaptureReportsDeltasForSubnet", func(t *testing.T) {
+		m, svc := setup()
+		fl := &domain.FlowLog{
+			ID: uuid.New(), UserID: userID, TenantID: tenantID, ResourceType: domain.FlowLogResourceSubnet, ResourceID: subnet.ID,
+			VPCID: vpc.ID, TrafficType: domain.FlowLogTrafficAll, Destination: domain.FlowLogDestinationCloudLogs,
+			IntervalSeconds: domain.FlowLogIntervalShort, CreatedAt: time.Now().Add(-2 * time.Minute),
+		}
+		m.repo.On("ListAll", mock.Anything).Return([]*domain.FlowLog{fl}, nil)
+		m.repo.On("UpdateCapture", mock.Anything, fl.ID, mock.Anything, domain.FlowLogStatusActive, "").Return(nil)
+
+		conntrack := func(packets uint64) []ports.ConntrackEntry {
+			return []ports.ConntrackEntry{
+				{ID: 7, Protocol: "tcp", Src: "10.0.1.5", Dst: "10.0.2.9", SrcPort: 40000, DstPort: 443, Packets: packets, Bytes: packets * 100},
+				{ID: 8, Protocol: "udp", Src: "10.0.3.1", Dst: "10.0.3.2", SrcPort: 53, DstPort: 53, Packets: 50, Bytes: 5000},
+			}
+		}
+		m.network.On("ListConntrackEntries", mock.Anything, 101).Return(conntrack(10), nil).Once()
+		m.network.On("ListConntrackEntries", mock.Anything, 101).Return(conntrack(14), nil).Once()
+		m.network.On("ListFlowRules", mock.Anything, "br-vpc").Return([]ports.FlowRule{
+			{Priority: 100, Match: "tcp,nw_src=10.0.1.6,tp_dst=22", Actions: "drop", Packets: 3, Bytes: 180},
+			{Priority: 100, Match: "tcp,nw_src=192.168.0.1,tp_dst=22", Actions: "drop", Packets: 9, Bytes: 540},
+			{Priority: 0, Match: "", Actions: "NORMAL", Packets: 99, Bytes: 9900},
+		}, nil)
+
+		var batches [][]*domain.LogEntry
+		m.logSvc.On("IngestLogs", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			batches = append(batches, args.Get(1).([]*domain.LogEntry))
+		}).Return(nil)
+
+		n, err := svc.CaptureDue(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		require.Len(t, batches, 1)
+		require.Len(t, batches[0], 2)
+		assert.Equal(t, domain.FlowLogResourceTypeLog, batches[0][0].ResourceType)
+		assert.Equal(t, fl.ID.String(), batches[0][0].ResourceID)
+		assert.True(t, strings.HasPrefix(batches[0][0].Message, "10.0.1.5 10.0.2.9 40000 443 tcp 10 1000 "))
+		assert.True(t, strings.HasSuffix(batches[0][0].Message, " ACCEPT"))
+		assert.True(t, strings.HasPrefix(batches[0][1].Message, "10.0.1.6 - 0 22 tcp 3 180 "))
+		assert.True(t, strings.HasSuffix(batches[0][1].Message, " REJECT"))
+
+		// The next capture reports only what changed since the last one.
+		captured := time.Now().Add(-2 * time.Minute)
+		fl.LastCapturedAt = &captured
+		_, err = svc.CaptureDue(ctx)
+		require.NoError(t, err)
+		require.Len(t, batches, 2)
+		require.Len(t, batches[1], 1)
+		assert.True(t, strings.HasPrefix(batches[1][0].Message, "10.0.1.5 10.0.2.9 40000 443 tcp 4 400 "))
+	})
+
+	t.Run("CaptureAfterRestartTakesBaseline", func(t *testing.T) {
+		m, svc := setup()
+		captured := time.Now().Add(-20 * time.Minute)
+		fl := &domain.FlowLog{
+			ID: uuid.New(), UserID: userID, TenantID: tenantID, ResourceType: domain.FlowLogResourceVPC, ResourceID: vpc.ID,
+			VPCID: vpc.ID, TrafficType: domain.FlowLogTrafficAccept, Destination: domain.FlowLogDestinationCloudLogs,
+			IntervalSeconds: domain.FlowLogIntervalLong, LastCapturedAt: &captured,
+		}
+		m.repo.On("ListAll", mock.Anything).Return([]*domain.FlowLog{fl}, nil)
+		m.repo.On("UpdateCapture", mock.Anything, fl.ID, mock.Anything, domain.FlowLogStatusActive, "").Return(nil).Once()
+		m.network.On("ListConntrackEntries", mock.Anything, 101).Return([]ports.ConntrackEntry{
+			{ID: 1, Protocol: "tcp", Src: "10.0.0.2", Dst: "10.0.0.3", SrcPort: 1000, DstPort: 80, Packets: 40, Bytes: 4000},
+		}, nil).Once()
+
+		n, err := svc.CaptureDue(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+		m.logSvc.AssertNotCalled(t, "IngestLogs", mock.Anything, mock.Anything)
+		m.network.AssertNotCalled(t, "ListFlowRules", mock.Anything, mock.Anything)
+	})
+
+	t.Run("CaptureUploadsToBucket", func(t *testing.T) {
+		m, svc := setup()
+		fl := &domain.FlowLog{
+			ID: uuid.New(), UserID: userID, TenantID: tenantID, ResourceType: domain.FlowLogResourceVPC, ResourceID: vpc.ID,
+			VPCID: vpc.ID, TrafficType: domain.FlowLogTrafficReject, Destination: domain.FlowLogDestinationBucket, Bucket: "logs",
+			IntervalSeconds: domain.FlowLogIntervalShort, CreatedAt: time.Now().Add(-time.Hour),
+		}
+		m.repo.On("ListAll", mock.Anything).Return([]*domain.FlowLog{fl}, nil)
+		m.repo.On("UpdateCapture", mock.Anything, fl.ID, mock.Anything, domain.FlowLogStatusActive, "").Return(nil).Once()
+		m.network.On("ListFlowRules", mock.Anything, "br-vpc").Return([]ports.FlowRule{
+			{Priority: 1, Match: "ip", Actions: "drop", Packets: 2, Bytes: 120},
+		}, nil).Once()
+		m.storage.On("Upload", mock.Anything, "logs", mock.MatchedBy(func(key string) bool {
+			return strings.HasPrefix(key, "flow-logs/"+fl.ID.String()+"/") && strings.HasSuffix(key, ".log")
+		}), mock.MatchedBy(func(body string) bool {
+			return strings.HasPrefix(body, "- - 0 0 ip 2 120 ") && strings.HasSuffix(body, " REJECT\n")
+		})).Return(nil).Once()
+
+		_, err := svc.CaptureDue(ctx)
+		require.NoError(t, err)
+		m.storage.AssertExpectations(t)
+	})
+
+	t.Run("CaptureFailureMarksError", func(t *testing.T) {
+		m, svc := setup()
+		fl := &domain.FlowLog{
+			ID: uuid.New(), UserID: userID, TenantID: tenantID, ResourceType: domain.FlowLogResourceVPC, ResourceID: vpc.ID,
+			VPCID: vpc.ID, TrafficType: domain.FlowLogTrafficAccept, Destination: domain.FlowLogDestinationCloudLogs,
+			IntervalSeconds: domain.FlowLogIntervalShort, CreatedAt: time.Now().Add(-time.Hour),
+		}
+		m.repo.On("ListAll", mock.Anything).Return([]*domain.FlowLog{fl}, nil)
+		m.network.On("ListConntrackEntries", mock.Anything, 101).Return(nil, assert.AnError).Once()
+		m.repo.On("UpdateCapture", mock.Anything, fl.ID, mock.Anything, domain.FlowLogStatusError, assert.AnError.Error()).Return(nil).Once()
+
+		n, err := svc.CaptureDue(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, n)
+		m.repo.AssertExpectations(t)
+	})
+
+	t.Run("CaptureSkipsFlowLogsNotDue", func(t *testing.T) {
+		m, svc := setup()
+		captured := time.Now()
+		m.repo.On("ListAll", mock.Anything).Return([]*domain.FlowLog{{
+			ID: uuid.New(), VPCID: vpc.ID, IntervalSeconds: domain.FlowLogIntervalLong, LastCapturedAt: &captured,
+		}}, nil)
+
+		n, err := svc.CaptureDue(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, n)
+		m.repo.AssertNotCalled(t, "UpdateCapture", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("DeleteRemovesFlowLog", func(t *testing.T) {
+		m, svc := setup()
+		fl := &domain.FlowLog{ID: uuid.New(), ResourceID: vpc.ID}
+		m.repo.On("GetByID", mock.Anything, fl.ID).Return(fl, nil).Once()
+		m.repo.On("Delete", mock.Anything, fl.ID).Return(nil).Once()
+
+		require.NoError(t, svc.DeleteFlowLog(ctx, fl.ID))
+		m.auditSvc.AssertCalled(t, "Log", mock.Anything, userID, "flow_log.delete", "flow_log", fl.ID.String(), mock.Anything)
+	})
+}
@@ -0,0 +1,374 @@
+package services
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/errors"
+)
+
+// FlowReconcilerServiceParams defines dependencies for flowReconcilerService.
+type FlowReconcilerServiceParams struct {
+	VpcRepo           ports.VpcRepository
+	SecurityGroupRepo ports.SecurityGroupRepository
+	RouteTableRepo    ports.RouteTableRepository
+	NetworkACLRepo    ports.NetworkACLRepository // Optional
+	Network           ports.NetworkBackend
+	RBACSvc           ports.RBACService
+	AuditSvc          ports.AuditService
+	Logger            *slog.Logger
+}
+
+type flowReconcilerService struct {
+	vpcRepo  ports.VpcRepository
+	sgRepo   ports.SecurityGroupRepository
+	rtRepo   ports.RouteTableRepository
+	aclRepo  ports.NetworkACLRepository
+	network  ports.NetworkBackend
+	rbacSvc  ports.RBACService
+	auditSvc ports.AuditService
+	logger   *slog.Logger
+}
+
+// NewFlowReconcilerService creates a service that keeps VPC bridges in line with the database.
+func NewFlowReconcilerService(params FlowReconcilerServiceParams) *flowReconcilerService {
+	logger := params.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &flowReconcilerService{
+		vpcRepo:  params.VpcRepo,
+		sgRepo:   params.SecurityGroupRepo,
+		rtRepo:   params.RouteTableRepo,
+		aclRepo:  params.NetworkACLRepo,
+		network:  params.Network,
+		rbacSvc:  params.RBACSvc,
+		auditSvc: params.AuditSvc,
+		logger:   logger,
+	}
+}
+
+// flowPlan is the outcome of comparing a bridge with the flows derived for its VPC.
+type flowPlan struct {
+	desired    []ports.FlowRule
+	missing    []ports.FlowRule
+	unexpected []ports.FlowRule
+}
+
+func (p *flowPlan) inSync() bool {
+	return len(p.missing) == 0 && len(p.unexpected) == 0
+}
+
+func (s *flowReconcilerService) DiffVPC(ctx context.Context, vpcID uuid.UUID) (*domain.FlowDiff, error) {
+	if err := s.authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+	vpc, err := s.findVPC(ctx, vpcID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := s.plan(ctx, vpc)
+	if err != nil {
+		return nil, err
+	}
+	return newFlowDiff(vpc, plan), nil
+}
+
+func (s *flowReconcilerService) ReconcileVPC(ctx context.Context, vpcID uuid.UUID) (*domain.FlowDiff, error) {
+	if err := s.authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+	vpc, err := s.findVPC(ctx, vpcID)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := s.reconcile(ctx, vpc)
+	if err != nil {
+		return nil, err
+	}
+
+	if diff.Repaired {
+		if err := s.auditSvc.Log(ctx, appcontext.UserIDFromContext(ctx), "network.flows_reconcile", "vpc", vpc.ID.String(), map[string]interface{}{
+			"missing":    len(diff.Missing),
+			"unexpected": len(diff.Unexpected),
+		}); err != nil {
+			s.logger.Warn("failed to log audit event", "action", "network.flows_reconcile", "vpc_id", vpc.ID, "error", err)
+		}
+	}
+	return diff, nil
+}
+
+func (s *flowReconcilerService) ReconcileAll(ctx context.Context) (int, error) {
+	vpcs, err := s.vpcRepo.ListAll(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	drifted := 0
+	for _, vpc := range vpcs {
+		diff, err := s.reconcile(ctx, vpc)
+		if err != nil {
+			s.logger.Warn("failed to reconcile vpc flows", "vpc_id", vpc.ID, "error", err)
+			continue
+		}
+		if !diff.InSync {
+			drifted++
+			s.logger.Info("repaired vpc flow drift", "vpc_id", vpc.ID, "missing", len(diff.Missing), "unexpected", len(diff.Unexpected))
+		}
+	}
+	return drifted, nil
+}
+
+func (s *flowReconcilerService) reconcile(ctx context.Context, vpc *domain.VPC) (*domain.FlowDiff, error) {
+	plan, err := s.plan(ctx, vpc)
+	if err != nil {
+		return nil, err
+	}
+	diff := newFlowDiff(vpc, plan)
+	if diff.InSync {
+		return diff, nil
+	}
+
+	if err := s.repair(ctx, vpc.NetworkID, plan); err != nil {
+		return nil, err
+	}
+	diff.Repaired = true
+	return diff, nil
+}
+
+// plan derives the VPC's desired flows and compares them with the bridge.
+func (s *flowReconcilerService) plan(ctx context.Context, vpc *domain.VPC) (*flowPlan, error) {
+	// Groups and routes are read as the owning tenant, whoever is asking.
+	ctx = appcontext.WithTenantID(ctx, vpc.TenantID)
+
+	desired, err := s.desiredFlows(ctx, vpc)
+	if err != nil {
+		return nil, err
+	}
+	actual, err := s.network.ListFlowRules(ctx, vpc.NetworkID)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list bridge flows", err)
+	}
+
+	plan := &flowPlan{desired: desired}
+	installed := make(map[string]bool, len(actual))
+	for _, flow := range actual {
+		installed[flowKey(flow)] = true
+	}
+	wanted := make(map[string]bool, len(desired))
+	for _, flow := range desired {
+		key := flowKey(flow)
+		if wanted[key] {
+			continue
+		}
+		wanted[key] = true
+		if !installed[key] {
+			plan.missing = append(plan.missing, flow)
+		}
+	}
+	for _, flow := range actual {
+		if flow.Cookie != 0 && !wanted[flowKey(flow)] {
+			plan.unexpected = append(plan.unexpected, flow)
+		}
+	}
+	return plan, nil
+}
+
+// desiredFlows lists every flow the VPC's security groups, route tables and network ACLs call for.
+func (s *flowReconcilerService) desiredFlows(ctx context.Context, vpc *domain.VPC) ([]ports.FlowRule, error) {
+	var flows []ports.FlowRule
+
+	groups, err := s.sgRepo.ListByVPC(ctx, vpc.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) > 0 {
+		flows = append(flows, conntrackFlows(vpc.VXLANID)...)
+	}
+	for _, g := range groups {
+		sg, err := s.sgRepo.GetByID(ctx, g.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range sg.Rules {
+			peers, err := securityRulePeers(ctx, s.sgRepo, rule)
+			if err != nil {
+				return nil, err
+			}
+			flows = append(flows, securityRuleFlows(rule, peers, vpc.VXLANID)...)
+		}
+	}
+
+	tables, err := s.rtRepo.GetByVPC(ctx, vpc.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, rt := range tables {
+		routes, err := s.rtRepo.ListRoutes(ctx, rt.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, route := range routes {
+			if route.TargetType != domain.RouteTargetLocal {
+				flows = append(flows, routeFlow(route))
+			}
+		}
+	}
+
+	if s.aclRepo != nil {
+		aclFlows, err := networkACLFlows(ctx, s.aclRepo, vpc)
+		if err != nil {
+			return nil, err
+		}
+		flows = append(flows, aclFlows...)
+	}
+	return flows, nil
+}
+
+// repair replaces every cookie that has drifted with its desired flows, so stale
+// flows go away along with the fix. Missing unmanaged flows are simply re-added.
+func (s *flowReconcilerService) repair(ctx context.Context, bridge string, plan *flowPlan) error {
+	drifted := map[uint64]bool{}
+	for _, flow := range append(append([]ports.FlowRule{}, plan.missing...), plan.unexpected...) {
+		if flow.Cookie != 0 {
+			drifted[flow.Cookie] = true
+		}
+	}
+	cookies := make([]uint64, 0, len(drifted))
+	for cookie := range drifted {
+		cookies = append(cookies, cookie)
+	}
+	sort.Slice(cookies, func(i, j int) bool { return cookies[i] < cookies[j] })
+
+	for _, cookie := range cookies {
+		if err := s.network.DeleteFlowRule(ctx, bridge, fmt.Sprintf("cookie=0x%x/-1", cookie)); err != nil {
+			return errors.Wrap(errors.Internal, "failed to delete drifted flows", err)
+		}
+		for _, flow := range plan.desired {
+			if flow.Cookie == cookie {
+				if err := s.network.AddFlowRule(ctx, bridge, flow); err != nil {
+					return errors.Wrap(errors.Internal, "failed to restore flow", err)
+				}
+			}
+		}
+	}
+	for _, flow := range plan.missing {
+		if flow.Cookie == 0 {
+			if err := s.network.AddFlowRule(ctx, bridge, flow); err != nil {
+				return errors.Wrap(errors.Internal, "failed to restore flow", err)
+			}
+		}
+	}
+	return nil
+}
+
+// findVPC looks a VPC up across tenants; the admin caller rarely owns it.
+func (s *flowReconcilerService) findVPC(ctx context.Context, id uuid.UUID) (*domain.VPC, error) {
+	vpcs, err := s.vpcRepo.ListAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, vpc := range vpcs {
+		if vpc.ID == id {
+			return vpc, nil
+		}
+	}
+	return nil, errors.New(errors.NotFound, "vpc not found")
+}
+
+func (s *flowReconcilerService) authorizeAdmin(ctx context.Context) error {
+	return s.rbacSvc.Authorize(ctx, appcontext.UserIDFromContext(ctx), uuid.Nil, domain.PermissionNetworkManage, "*")
+}
+
+func newFlowDiff(vpc *domain.VPC, plan *flowPlan) *domain.FlowDiff {
+	return &domain.FlowDiff{
+		VPCID:      vpc.ID,
+		Bridge:     vpc.NetworkID,
+		Missing:    flowEntries(plan.missing),
+		Unexpected: flowEntries(plan.unexpected),
+		InSync:     plan.inSync(),
+		CheckedAt:  time.Now(),
+	}
+}
+
+func flowEntries(flows []ports.FlowRule) []domain.FlowEntry {
+	entries := make([]domain.FlowEntry, 0, len(flows))
+	for _, flow := range flows {
+		entry := domain.FlowEntry{Priority: flow.Priority, Match: flow.Match, Actions: flow.Actions}
+		if flow.Cookie != 0 {
+			entry.Cookie = fmt.Sprintf("0x%x", flow.Cookie)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// flowCookie derives the OpenFlow cookie tagging every flow generated for a rule or route.
+func flowCookie(id uuid.UUID) uint64 {
+	return binary.BigEndian.Uint64(id[:8])
+}
+
+// cookieMatch selects all flows carrying the cookie for id.
+func cookieMatch(id uuid.UUID) string {
+	return fmt.Sprintf("cookie=0x%x/-1", flowCookie(id))
+}
+
+// flowKey identifies a flow independently of how ovs-ofctl chooses to print it:
+// match fields are order-insensitive, host prefixes and port masks are normalised.
+func flowKey(flow ports.FlowRule) string {
+	fields := strings.Split(flow.Match, ",")
+	for i, field := range fields {
+		fields[i] = normalizeMatchField(strings.TrimSpace(field))
+	}
+	sort.Strings(fields)
+	return fmt.Sprintf("%x|%d|%s|%s", flow.Cookie, flow.Priority, strings.Join(fields, ","), strings.ToLower(flow.Actions))
+}
+
+func normalizeMatchField(field string) string {
+	key, value, ok := strings.Cut(field, "=")
+	if !ok {
+		return field
+	}
+	switch key {
+	case "nw_src", "nw_dst":
+		value = strings.TrimSuffix(value, "/32")
+	case "ipv6_src", "ipv6_dst":
+		value = strings.TrimSuffix(value, "/128")
+	case "tp_src", "tp_dst":
+		parts := strings.Split(value, "/")
+		for i, part := range parts {
+			if n, err := strconv.ParseUint(part, 0, 16); err == nil {
+				parts[i] = strconv.FormatUint(n, 10)
+			}
+		}
+		if len(parts) == 2 && parts[1] == "65535" {
+			parts = parts[:1]
+		}
+		value = strings.Join(parts, "/")
+	case "ct_state":
+		var flags []string
+		for i := 0; i < len(value); {
+			j := i + 1
+			for j < len(value) && value[j] != '+' && value[j] != '-' {
+				j++
+			}
+			flags = append(flags, value[i:j])
+			i = j
+		}
+		sort.Strings(flags)
+		value = strings.Join(flags, "")
+	}
+	return key + "=" + value
+}
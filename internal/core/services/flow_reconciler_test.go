@@ -0,0 +1,277 @@
+package services_test
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/core/services"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func testFlowCookie(id uuid.UUID) uint64 {
+	return binary.BigEndian.Uint64(id[:8])
+}
+
+func TestFlowReconcilerService(t *testing.T) {
+	ctx := appcontext.WithUserID(context.Background(), uuid.New())
+	tenantID := uuid.New()
+	vpc := &domain.VPC{ID: uuid.New(), TenantID: tenantID, NetworkID: "br-vpc"}
+	sgID := uuid.New()
+	ruleID := uuid.New()
+	rtID := uuid.New()
+	routeID := uuid.New()
+	targetID := uuid.New()
+
+	type mocks struct {
+		vpcRepo *MockVpcRepo
+		sgRepo  *MockSecurityGroupRepo
+		rtRepo  *MockRTRepo
+		network *MockNetworkBackend
+		rbacSvc *MockRBACService
+		audit   *MockAuditService
+	}
+
+	setup := func() (ports.FlowReconcilerService, *mocks) {
+		m := &mocks{
+			vpcRepo: new(MockVpcRepo),
+			sgRepo:  new(MockSecurityGroupRepo),
+			rtRepo:  new(MockRTRepo),
+			network: new(MockNetworkBackend),
+			rbacSvc: new(MockRBACService),
+			audit:   new(MockAuditService),
+		}
+		m.vpcRepo.On("ListAll", mock.Anything).Return([]*domain.VPC{vpc}, nil).Maybe()
+		m.sgRepo.On("ListByVPC", mock.Anything, vpc.ID).Return([]*domain.SecurityGroup{{ID: sgID, VPCID: vpc.ID}}, nil).Maybe()
+		m.sgRepo.On("GetByID", mock.Anything, sgID).Return(&domain.SecurityGroup{ID: sgID, VPCID: vpc.ID, Rules: []domain.SecurityRule{{
+			ID: ruleID, Direction: domain.RuleIngress, Protocol: "tcp", PortMin: 64, PortMax: 127, CIDR: "10.0.0.5/32", Priority: 100,
+		}}}, nil).Maybe()
+		m.rtRepo.On("GetByVPC", mock.Anything, vpc.ID).Return([]*domain.RouteTable{{ID: rtID, VPCID: vpc.ID}}, nil).Maybe()
+		m.rtRepo.On("ListRoutes", mock.Anything, rtID).Return([]domain.Route{
+			{ID: uuid.New(), RouteTableID: rtID, DestinationCIDR: "10.0.0.0/16", TargetType: domain.RouteTargetLocal},
+			{ID: routeID, RouteTableID: rtID, DestinationCIDR: "10.1.0.0/16", TargetType: domain.RouteTargetPeering, TargetID: &targetID},
+		}, nil).Maybe()
+
+		svc := services.NewFlowReconcilerService(services.FlowReconcilerServiceParams{
+			VpcRepo:           m.vpcRepo,
+			SecurityGroupRepo: m.sgRepo,
+			RouteTableRepo:    m.rtRepo,
+			Network:           m.network,
+			RBACSvc:           m.rbacSvc,
+			AuditSvc:          m.audit,
+			Logger:            slog.Default(),
+		})
+		return svc, m
+	}
+
+	// installed is the bridge as ovs-ofctl prints it when nothing has drifted.
+	installed := func() []ports.FlowRule {
+		return []ports.FlowRule{
+			{Priority: 65000, Match: "ct_state=-trk,ip", Actions: "ct(table=0)"},
+			{Priority: 65000, Match: "ct_state=+est+trk,ip", Actions: "NORMAL"},
+			{Priority: 65000, Match: "ct_state=+rel+trk,ip", Actions: "NORMAL"},
+			{Priority: 65000, Match: "ct_state=+inv+trk,ip", Actions: "drop"},
+			{Priority: 65001, Match: "icmp6,icmp_type=135", Actions: "NORMAL"},
+			{Priority: 65001, Match: "icmp6,icmp_type=136", Actions: "NORMAL"},
+			{Priority: 65000, Match: "ct_state=-trk,ipv6", Actions: "ct(table=0)"},
+			{Priority: 65000, Match: "ct_state=+est+trk,ipv6", Actions: "NORMAL"},
+			{Priority: 65000, Match: "ct_state=+rel+trk,ipv6", Actions: "NORMAL"},
+			{Priority: 65000, Match: "ct_state=+inv+trk,ipv6", Actions: "drop"},
+			{Cookie: testFlowCookie(ruleID), Priority: 100, Match: "ct_state=+new+trk,tcp,nw_src=10.0.0.5,tp_dst=0x40/0xffc0", Actions: "ct(commit),NORMAL"},
+			{Cookie: testFlowCookie(routeID), Priority: 300, Match: "ip,nw_dst=10.1.0.0/16", Actions: "NORMAL"},
+			{Priority: 0, Match: "", Actions: "NORMAL"},
+		}
+	}
+
+	t.Run("DiffInSync", func(t *testing.T) {
+		svc, m := setup()
+		m.rbacSvc.On("Authorize", mock.Anything, mock.Anything, uuid.Nil, domain.PermissionNetworkManage, "*").Return(nil)
+		m.network.On("ListFlowRules", mock.Anything, "br-vpc").Return(installed(), nil)
+
+		diff, err := svc.DiffVPC(ctx, vpc.ID)
+		require.NoError(t, err)
+		assert.True(t, diff.InSync)
+		assert.False(t, diff.Repaired)
+		assert.Empty(t, diff.Missing)
+		assert.Empty(t, diff.Unexpected)
+		assert.Equal(t, "br-vpc", diff.Bridge)
+	})
+
+	t.Run("DiffReadsAsOwningTenant", func(t *testing.T) {
+		svc, m := setup()
+		m.rbacSvc.On("Authorize", mock.Anything, mock.Anything, uuid.Nil, domain.PermissionNetworkManage, "*").Return(nil)
+		m.network.On("ListFlowRules", mock.Anything, "br-vpc").Return(installed(), nil)
+
+		_, err := svc.DiffVPC(ctx, vpc.ID)
+		require.NoError(t, err)
+		m.sgRepo.AssertCalled(t, "ListByVPC", mock.MatchedBy(func(c context.Context) bool {
+			return appcontext.TenantIDFromContext(c) == tenantID
+		}), vpc.ID)
+	})
+
+	t.Run("DiffReportsDrift", func(t *testing.T) {
+		svc, m := setup()
+		m.rbacSvc.On("Authorize", mock.Anything, mock.Anything, uuid.Nil, domain.PermissionNetworkManage, "*").Return(nil)
+		stale := uuid.New()
+		flows := installed()[1:11] // conntrack entry point and route flow gone
+		flows = append(flows, ports.FlowRule{Cookie: testFlowCookie(stale), Priority: 100, Match: "udp,tp_dst=53", Actions: "NORMAL"})
+		m.network.On("ListFlowRules", mock.Anything, "br-vpc").Return(flows, nil)
+
+		diff, err := svc.DiffVPC(ctx, vpc.ID)
+		require.NoError(t, err)
+		assert.False(t, diff.InSync)
+		require.Len(t, diff.Missing, 2)
+		assert.Equal(t, "ip,ct_state=-trk", diff.Missing[0].Match)
+		assert.Equal(t, "", diff.Missing[0].Cookie)
+		assert.Equal(t, fmt.Sprintf("0x%x", testFlowCookie(routeID)), diff.Missing[1].Cookie)
+		require.Len(t, diff.Unexpected, 1)
+		assert.Equal(t, "udp,tp_dst=53", diff.Unexpected[0].Match)
+		m.network.AssertNotCalled(t, "DeleteFlowRule", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("ReconcileRepairsDrift", func(t *testing.T) {
+		svc, m := setup()
+		m.rbacSvc.On("Authorize", mock.Anything, mock.Anything, uuid.Nil, domain.PermissionNetworkManage, "*").Return(nil)
+		stale := uuid.New()
+		flows := installed()[:10] // rule flow gone
+		flows = append(flows, installed()[11], ports.FlowRule{Cookie: testFlowCookie(stale), Priority: 100, Match: "udp,tp_dst=53", Actions: "NORMAL"})
+		m.network.On("ListFlowRules", mock.Anything, "br-vpc").Return(flows, nil)
+		m.network.On("DeleteFlowRule", mock.Anything, "br-vpc", fmt.Sprintf("cookie=0x%x/-1", testFlowCookie(ruleID))).Return(nil).Once()
+		m.network.On("DeleteFlowRule", mock.Anything, "br-vpc", fmt.Sprintf("cookie=0x%x/-1", testFlowCookie(stale))).Return(nil).Once()
+		m.network.On("AddFlowRule", mock.Anything, "br-vpc", mock.MatchedBy(func(f ports.FlowRule) bool {
+			return f.Cookie == testFlowCookie(ruleID) && f.Match == "tcp,ct_state=+trk+new,nw_src=10.0.0.5/32,tp_dst=0x0040/0xffc0"
+		})).Return(nil).Once()
+		m.audit.On("Log", mock.Anything, mock.Anything, "network.flows_reconcile", "vpc", vpc.ID.String(), mock.Anything).Return(nil).Once()
+
+		diff, err := svc.ReconcileVPC(ctx, vpc.ID)
+		require.NoError(t, err)
+		assert.True(t, diff.Repaired)
+		assert.Len(t, diff.Missing, 1)
+		assert.Len(t, diff.Unexpected, 1)
+		m.network.AssertExpectations(t)
+		m.audit.AssertExpectations(t)
+	})
+
+	t.Run("ReconcileInSyncIsNoop", func(t *testing.T) {
+		svc, m := setup()
+		m.rbacSvc.On("Authorize", mock.Anything, mock.Anything, uuid.Nil, domain.PermissionNetworkManage, "*").Return(nil)
+		m.network.On("ListFlowRules", mock.Anything, "br-vpc").Return(installed(), nil)
+
+		diff, err := svc.ReconcileVPC(ctx, vpc.ID)
+		require.NoError(t, err)
+		assert.False(t, diff.Repaired)
+		m.network.AssertNotCalled(t, "AddFlowRule", mock.Anything, mock.Anything, mock.Anything)
+		m.audit.AssertNotCalled(t, "Log", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("DesiredFlowsUseVPCConntrackZone", func(t *testing.T) {
+		svc, m := setup()
+		zoned := &domain.VPC{ID: vpc.ID, TenantID: tenantID, NetworkID: "br-vpc", VXLANID: 101}
+		m.vpcRepo.ExpectedCalls = nil
+		m.vpcRepo.On("ListAll", mock.Anything).Return([]*domain.VPC{zoned}, nil)
+		m.rbacSvc.On("Authorize", mock.Anything, mock.Anything, uuid.Nil, domain.PermissionNetworkManage, "*").Return(nil)
+		m.network.On("ListFlowRules", mock.Anything, "br-vpc").Return([]ports.FlowRule{}, nil)
+
+		diff, err := svc.DiffVPC(ctx, vpc.ID)
+		require.NoError(t, err)
+		actions := map[string]bool{}
+		for _, f := range diff.Missing {
+			actions[f.Actions] = true
+		}
+		assert.True(t, actions["ct(table=0,zone=101)"])
+		assert.True(t, actions["ct(commit,zone=101),NORMAL"])
+	})
+
+	t.Run("Forbidden", func(t *testing.T) {
+		svc, m := setup()
+		m.rbacSvc.On("Authorize", mock.Anything, mock.Anything, uuid.Nil, domain.PermissionNetworkManage, "*").Return(errors.New(errors.Forbidden, "permission denied"))
+
+		_, err := svc.DiffVPC(ctx, vpc.ID)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.Forbidden))
+		m.network.AssertNotCalled(t, "ListFlowRules", mock.Anything, mock.Anything)
+	})
+
+	t.Run("UnknownVPC", func(t *testing.T) {
+		svc, m := setup()
+		m.rbacSvc.On("Authorize", mock.Anything, mock.Anything, uuid.Nil, domain.PermissionNetworkManage, "*").Return(nil)
+
+		_, err := svc.ReconcileVPC(ctx, uuid.New())
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.NotFound))
+	})
+
+	t.Run("ReconcileAllCountsDriftedVPCs", func(t *testing.T) {
+		svc, m := setup()
+		broken := &domain.VPC{ID: uuid.New(), TenantID: uuid.New(), NetworkID: "br-broken"}
+		m.vpcRepo.ExpectedCalls = nil
+		m.vpcRepo.On("ListAll", mock.Anything).Return([]*domain.VPC{vpc, broken}, nil)
+		m.sgRepo.On("ListByVPC", mock.Anything, broken.ID).Return(nil, errors.New(errors.Internal, "db down"))
+		m.network.On("ListFlowRules", mock.Anything, "br-vpc").Return(installed()[1:], nil)
+		m.network.On("AddFlowRule", mock.Anything, "br-vpc", mock.Anything).Return(nil).Once()
+
+		drifted, err := svc.ReconcileAll(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, drifted)
+		m.rbacSvc.AssertNotCalled(t, "Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		m.network.AssertExpectations(t)
+	})
+}
+
+func TestFlowReconcilerServiceNetworkACLFlows(t *testing.T) {
+	ctx := appcontext.WithUserID(context.Background(), uuid.New())
+	vpc := &domain.VPC{ID: uuid.New(), NetworkID: "br-vpc", VXLANID: 101}
+	aclID := uuid.New()
+
+	vpcRepo := new(MockVpcRepo)
+	sgRepo := new(MockSecurityGroupRepo)
+	rtRepo := new(MockRTRepo)
+	aclRepo := new(MockNetworkACLRepo)
+	network := new(MockNetworkBackend)
+	rbacSvc := new(MockRBACService)
+	vpcRepo.On("ListAll", mock.Anything).Return([]*domain.VPC{vpc}, nil)
+	sgRepo.On("ListByVPC", mock.Anything, vpc.ID).Return([]*domain.SecurityGroup{}, nil)
+	rtRepo.On("GetByVPC", mock.Anything, vpc.ID).Return([]*domain.RouteTable{}, nil)
+	aclRepo.On("ListSubnetBindings", mock.Anything, vpc.ID).Return([]ports.SubnetACLBinding{
+		{SubnetID: uuid.New(), CIDRBlock: "10.0.1.0/24", IPv6CIDRBlock: "fd12:3456:7800::/64", ACLID: aclID},
+	}, nil)
+	aclRepo.On("ListRules", mock.Anything, aclID).Return(domain.DefaultNetworkACLRules(aclID), nil)
+	rbacSvc.On("Authorize", mock.Anything, mock.Anything, uuid.Nil, domain.PermissionNetworkManage, "*").Return(nil)
+	network.On("ListFlowRules", mock.Anything, "br-vpc").Return([]ports.FlowRule{}, nil)
+
+	svc := services.NewFlowReconcilerService(services.FlowReconcilerServiceParams{
+		VpcRepo:           vpcRepo,
+		SecurityGroupRepo: sgRepo,
+		RouteTableRepo:    rtRepo,
+		NetworkACLRepo:    aclRepo,
+		Network:           network,
+		RBACSvc:           rbacSvc,
+		AuditSvc:          new(MockAuditService),
+		Logger:            slog.Default(),
+	})
+
+	diff, err := svc.DiffVPC(ctx, vpc.ID)
+	require.NoError(t, err)
+	var matches []string
+	for _, f := range diff.Missing {
+		matches = append(matches, f.Match)
+	}
+	// Neighbor discovery bypasses, then per address family the pipeline entry and table
+	// defaults, one allow-all rule and one implicit deny per direction.
+	assert.Len(t, matches, 16)
+	assert.Contains(t, matches, "ip,ct_state=-trk")
+	assert.Contains(t, matches, "table=1,ip,nw_src=10.0.1.0/24")
+	assert.Contains(t, matches, "table=2,ip,nw_dst=10.0.1.0/24")
+	assert.Contains(t, matches, "ipv6,ct_state=-trk")
+	assert.Contains(t, matches, "table=1,ipv6,ipv6_src=fd12:3456:7800::/64")
+	assert.Contains(t, matches, "table=2,ipv6,ipv6_dst=fd12:3456:7800::/64")
+}
@@ -75,13 +75,14 @@ type FunctionService struct {
 	fileStore        ports.FileStore
 	auditSvc         ports.AuditService
 	secretSvc        ports.SecretService
+	tenantSvc        ports.TenantService
 	logger           *slog.Logger
 	bulkheadRegistry map[uuid.UUID]*platform.Bulkhead
 	bulkheadMu       sync.RWMutex
 }
 
 // NewFunctionService constructs a FunctionService with its dependencies.
-func NewFunctionService(repo ports.FunctionRepository, rbacSvc ports.RBACService, compute ports.ComputeBackend, fileStore ports.FileStore, auditSvc ports.AuditService, secretSvc ports.SecretService, logger *slog.Logger) *FunctionService {
+func NewFunctionService(repo ports.FunctionRepository, rbacSvc ports.RBACService, compute ports.ComputeBackend, fileStore ports.FileStore, auditSvc ports.AuditService, secretSvc ports.SecretService, tenantSvc ports.TenantService, logger *slog.Logger) *FunctionService {
 	return &FunctionService{
 		repo:             repo,
 		rbacSvc:          rbacSvc,
@@ -89,6 +90,7 @@ func NewFunctionService(repo ports.FunctionRepository, rbacSvc ports.RBACService
 		fileStore:        fileStore,
 		auditSvc:         auditSvc,
 		secretSvc:        secretSvc,
+		tenantSvc:        tenantSvc,
 		logger:           logger,
 		bulkheadRegistry: make(map[uuid.UUID]*platform.Bulkhead),
 	}
@@ -115,6 +117,9 @@ func (s *FunctionService) CreateFunction(ctx context.Context, name, runtime, han
 	if _, ok := runtimes[runtime]; !ok {
 		return nil, errors.New(errors.InvalidInput, fmt.Sprintf("unsupported runtime: %s", runtime))
 	}
+	if err := checkQuota(ctx, s.tenantSvc, domain.QuotaFunctions, 1); err != nil {
+		return nil, err
+	}
 
 	id := uuid.New()
 	codeKey := fmt.Sprintf("%s/%s/code.zip", userID, id)
@@ -144,6 +149,7 @@ func (s *FunctionService) CreateFunction(ctx context.Context, name, runtime, han
 	if err := s.repo.Create(ctx, f); err != nil {
 		return nil, err
 	}
+	recordUsage(ctx, s.tenantSvc, s.logger, tenantID, domain.QuotaFunctions, 1)
 
 	if err := s.auditSvc.Log(ctx, f.UserID, "function.create", "function", f.ID.String(), map[string]interface{}{
 		"name":    f.Name,
@@ -226,6 +232,7 @@ func (s *FunctionService) DeleteFunction(ctx context.Context, id uuid.UUID) erro
 	if err := s.repo.Delete(ctx, id); err != nil {
 		return err
 	}
+	recordUsage(ctx, s.tenantSvc, s.logger, tenantID, domain.QuotaFunctions, -1)
 
 	// Remove bulkhead from registry to release resources
 	s.bulkheadMu.Lock()
@@ -319,7 +326,7 @@ func (s *FunctionService) InvokeFunction(ctx context.Context, id uuid.UUID, payl
 		if b == nil {
 			// No throttling — launch directly
 			go func() {
-				if _, err := s.runInvocation(bgCtx, f, &asyncInv, payload); err != nil {
+				if _, err := s.runInvocation(bgCtx, f, &asyncInv, payload, false); err != nil {
 					s.logger.Error("async invocation failed",
 						"function_id", f.ID,
 						"invocation_id", asyncInv.ID,
@@ -330,7 +337,7 @@ func (s *FunctionService) InvokeFunction(ctx context.Context, id uuid.UUID, payl
 			// Throttled path using bulkhead
 			go func() {
 				err := b.Execute(bgCtx, func() error {
-					_, err := s.runInvocation(bgCtx, f, &asyncInv, payload)
+					_, err := s.runInvocation(bgCtx, f, &asyncInv, payload, false)
 					return err
 				})
 				if err != nil {
@@ -359,10 +366,39 @@ func (s *FunctionService) InvokeFunction(ctx context.Context, id uuid.UUID, payl
 	if err := s.auditSvc.Log(ctx, f.UserID, "function.invoke", "function", f.ID.String(), map[string]interface{}{}); err != nil {
 		s.logger.Warn("failed to log audit event", "action", "function.invoke", "function_id", f.ID, "error", err)
 	}
-	return s.runInvocation(ctx, f, invocation, payload)
+	return s.runInvocation(ctx, f, invocation, payload, false)
+}
+
+// InvokeFunctionRedacted runs a function synchronously and returns its output to the
+// caller, but records the invocation with its logs redacted. It is used when the
+// output is itself sensitive, such as a freshly generated secret value.
+func (s *FunctionService) InvokeFunctionRedacted(ctx context.Context, id uuid.UUID, payload []byte) (*domain.Invocation, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionFunctionInvoke, id.String()); err != nil {
+		return nil, err
+	}
+
+	f, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	invocation := &domain.Invocation{
+		ID:         uuid.New(),
+		FunctionID: f.ID,
+		Status:     "PENDING",
+		StartedAt:  time.Now(),
+	}
+
+	if err := s.auditSvc.Log(ctx, f.UserID, "function.invoke", "function", f.ID.String(), map[string]interface{}{"redacted": true}); err != nil {
+		s.logger.Warn("failed to log audit event", "action", "function.invoke", "function_id", f.ID, "error", err)
+	}
+	return s.runInvocation(ctx, f, invocation, payload, true)
 }
 
-func (s *FunctionService) runInvocation(ctx context.Context, f *domain.Function, i *domain.Invocation, payload []byte) (*domain.Invocation, error) {
+func (s *FunctionService) runInvocation(ctx context.Context, f *domain.Function, i *domain.Invocation, payload []byte, redactLogs bool) (*domain.Invocation, error) {
 	i.Status = "RUNNING"
 
 	tmpDir, err := s.prepareCode(ctx, f)
@@ -392,7 +428,13 @@ func (s *FunctionService) runInvocation(ctx context.Context, f *domain.Function,
 		}
 	}(containerID)
 
-	if err := s.repo.CreateInvocation(ctx, i); err != nil {
+	record := i
+	if redactLogs {
+		redacted := *i
+		redacted.Logs = "[REDACTED]"
+		record = &redacted
+	}
+	if err := s.repo.CreateInvocation(ctx, record); err != nil {
 		s.logger.Error("failed to record invocation", "error", err)
 	}
 
@@ -447,15 +489,14 @@ func (s *FunctionService) buildTaskOptions(ctx context.Context, f *domain.Functi
 	env := []string{fmt.Sprintf("PAYLOAD=%s", string(payload))}
 	for _, e := range f.EnvVars {
 		if e.SecretRef != "" {
-			// Resolve secret reference at invocation time (dynamic)
-			name := strings.TrimPrefix(e.SecretRef, "@")
-			secret, err := s.secretSvc.GetSecretByName(ctx, name)
+			// Resolve secret reference at invocation time (dynamic); "@name:stage" pins a version stage
+			value, err := s.secretSvc.ResolveSecretRef(ctx, e.SecretRef)
 			if err != nil {
 				s.logger.Warn("failed to resolve secret", "ref", e.SecretRef, "key", e.Key, "error", err)
 				continue // skip rather than failing the invocation
 			}
 			// Inject as plain environment variable
-			env = append(env, e.Key+"="+secret.EncryptedValue)
+			env = append(env, e.Key+"="+value)
 		} else {
 			env = append(env, e.Key+"="+e.Value)
 		}
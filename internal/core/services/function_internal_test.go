@@ -58,6 +58,12 @@ func (t *testComputeBackend) AttachVolume(ctx context.Context, id string, volume
 func (t *testComputeBackend) DetachVolume(ctx context.Context, id string, volumePath string) (string, error) {
 	return "", nil
 }
+func (t *testComputeBackend) AttachNetworkInterface(ctx context.Context, id string, nic ports.NetworkInterfaceOptions) error {
+	return nil
+}
+func (t *testComputeBackend) DetachNetworkInterface(ctx context.Context, id string, nic ports.NetworkInterfaceOptions) error {
+	return nil
+}
 func (t *testComputeBackend) Ping(ctx context.Context) error                                        { return nil }
 func (t *testComputeBackend) Type() string                                                          { return "test" }
 func (t *testComputeBackend) ResizeInstance(ctx context.Context, id string, cpu, memory int64) error { return nil }
@@ -67,6 +73,9 @@ func (t *testComputeBackend) DeleteSnapshot(ctx context.Context, id, name string
 func (t *testComputeBackend) PauseInstance(ctx context.Context, id string) error                      { return nil }
 func (t *testComputeBackend) ResumeInstance(ctx context.Context, id string) error                     { return nil }
 func (t *testComputeBackend) ResetCircuitBreaker()                                                    {}
+func (t *testComputeBackend) HostMemoryPressure(ctx context.Context) ([]ports.HostPressure, error) {
+	return nil, nil
+}
 
 // compile-time check that testComputeBackend satisfies ports.ComputeBackend
 var _ ports.ComputeBackend = (*testComputeBackend)(nil)
@@ -91,6 +100,27 @@ func (t *testSecretSvc) Encrypt(ctx context.Context, userID uuid.UUID, plain str
 func (t *testSecretSvc) Decrypt(ctx context.Context, userID uuid.UUID, cipher string) (string, error) {
 	return cipher, nil
 }
+func (t *testSecretSvc) PutSecretValue(ctx context.Context, id uuid.UUID, value string, stage domain.SecretStage) (*domain.SecretVersion, error) {
+	return nil, nil
+}
+func (t *testSecretSvc) ListSecretVersions(ctx context.Context, id uuid.UUID) ([]*domain.SecretVersion, error) {
+	return nil, nil
+}
+func (t *testSecretSvc) GetSecretVersion(ctx context.Context, id uuid.UUID, version int) (*domain.SecretVersion, error) {
+	return nil, nil
+}
+func (t *testSecretSvc) GetSecretVersionByStage(ctx context.Context, id uuid.UUID, stage domain.SecretStage) (*domain.SecretVersion, error) {
+	return nil, nil
+}
+func (t *testSecretSvc) UpdateSecretVersionStage(ctx context.Context, id uuid.UUID, stage domain.SecretStage, version int) error {
+	return nil
+}
+func (t *testSecretSvc) ResolveSecretRef(ctx context.Context, ref string) (string, error) {
+	if t.err != nil {
+		return "", t.err
+	}
+	return t.val, nil
+}
 
 // compile-time check that testSecretSvc satisfies ports.SecretService
 var _ ports.SecretService = (*testSecretSvc)(nil)
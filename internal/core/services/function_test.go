@@ -71,7 +71,7 @@ func setupFunctionServiceTest(t *testing.T) (*services.FunctionService, ports.Fu
 
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 
-	svc := services.NewFunctionService(repo, rbacSvc, compute, fileStore, auditSvc, secretSvc, logger)
+	svc := services.NewFunctionService(repo, rbacSvc, compute, fileStore, auditSvc, secretSvc, nil, logger)
 
 	return svc, repo, secretSvc, ctx
 }
@@ -23,6 +23,7 @@ func TestFunctionService_Unit(t *testing.T) {
 	t.Run("BasicOps", testFunctionServiceBasicOps)
 	t.Run("CreateFunction", testFunctionServiceCreateFunction)
 	t.Run("InvokeFunction", testFunctionServiceInvokeFunction)
+	t.Run("InvokeFunctionRedacted", testFunctionServiceInvokeFunctionRedacted)
 	t.Run("UpdateFunction", testFunctionServiceUpdateFunction)
 	t.Run("CreateFunction_UnsupportedRuntime", testFunctionServiceCreateFunctionUnsupportedRuntime)
 }
@@ -36,7 +37,7 @@ func testFunctionServiceBasicOps(t *testing.T) {
 	secretSvc := new(MockSecretService)
 	rbacSvc.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
-	svc := services.NewFunctionService(repo, rbacSvc, compute, fileStore, auditSvc, secretSvc, slog.Default())
+	svc := services.NewFunctionService(repo, rbacSvc, compute, fileStore, auditSvc, secretSvc, nil, slog.Default())
 
 	ctx := context.Background()
 	userID := uuid.New()
@@ -92,7 +93,7 @@ func testFunctionServiceCreateFunction(t *testing.T) {
 	secretSvc := new(MockSecretService)
 	rbacSvc.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
-	svc := services.NewFunctionService(repo, rbacSvc, compute, fileStore, auditSvc, secretSvc, slog.Default())
+	svc := services.NewFunctionService(repo, rbacSvc, compute, fileStore, auditSvc, secretSvc, nil, slog.Default())
 
 	ctx := appcontext.WithUserID(context.Background(), uuid.New())
 	userID := appcontext.UserIDFromContext(ctx)
@@ -124,7 +125,7 @@ func testFunctionServiceInvokeFunction(t *testing.T) {
 	secretSvc := new(MockSecretService)
 	rbacSvc.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
-	svc := services.NewFunctionService(repo, rbacSvc, compute, fileStore, auditSvc, secretSvc, slog.Default())
+	svc := services.NewFunctionService(repo, rbacSvc, compute, fileStore, auditSvc, secretSvc, nil, slog.Default())
 
 	ctx := context.Background()
 	id := uuid.New()
@@ -201,6 +202,46 @@ func testFunctionServiceInvokeFunction(t *testing.T) {
 		compute.On("DeleteInstance", mock.Anything, mock.Anything).Return(nil).Maybe()
 		time.Sleep(200 * time.Millisecond)
 	})
+
+}
+
+func testFunctionServiceInvokeFunctionRedacted(t *testing.T) {
+	repo := new(MockFunctionRepo)
+	compute := new(MockComputeBackend)
+	fileStore := new(MockFileStore)
+	auditSvc := new(MockAuditService)
+	rbacSvc := new(MockRBACService)
+	rbacSvc.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	svc := services.NewFunctionService(repo, rbacSvc, compute, fileStore, auditSvc, new(MockSecretService), nil, slog.Default())
+
+	ctx := context.Background()
+	id := uuid.New()
+	userID := uuid.New()
+	f := &domain.Function{ID: id, UserID: userID, Name: "rotator", Runtime: "nodejs20", CodePath: "path/v1.zip", Timeout: 30}
+
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+	fw, _ := zw.Create("index.js")
+	_, _ = fw.Write([]byte("console.log('s3cr3t')"))
+	_ = zw.Close()
+
+	repo.On("GetByID", mock.Anything, id).Return(f, nil).Once()
+	auditSvc.On("Log", mock.Anything, userID, "function.invoke", "function", id.String(), mock.Anything).Return(nil).Once()
+	fileStore.On("Read", mock.Anything, "functions", f.CodePath).Return(io.NopCloser(bytes.NewReader(buf.Bytes())), nil).Once()
+	compute.On("RunTask", mock.Anything, mock.Anything).Return("task-redacted", []string{}, nil).Once()
+	compute.On("WaitTask", mock.Anything, "task-redacted").Return(int64(0), nil).Once()
+	compute.On("GetInstanceLogs", mock.Anything, "task-redacted").Return(io.NopCloser(strings.NewReader("s3cr3t")), nil).Once()
+	compute.On("DeleteInstance", mock.Anything, "task-redacted").Return(nil).Once()
+	repo.On("CreateInvocation", mock.Anything, mock.MatchedBy(func(i *domain.Invocation) bool {
+		return i.Logs == "[REDACTED]"
+	})).Return(nil).Once()
+
+	inv, err := svc.InvokeFunctionRedacted(ctx, id, []byte("{}"))
+	require.NoError(t, err)
+	assert.Equal(t, "SUCCESS", inv.Status)
+	assert.Equal(t, "s3cr3t", inv.Logs)
+	repo.AssertExpectations(t)
 }
 
 func testFunctionServiceCreateFunctionUnsupportedRuntime(t *testing.T) {
@@ -208,7 +249,7 @@ func testFunctionServiceCreateFunctionUnsupportedRuntime(t *testing.T) {
 	rbacSvc := new(MockRBACService)
 	secretSvc := new(MockSecretService)
 	rbacSvc.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
-	svc := services.NewFunctionService(repo, rbacSvc, nil, nil, nil, secretSvc, slog.Default())
+	svc := services.NewFunctionService(repo, rbacSvc, nil, nil, nil, secretSvc, nil, slog.Default())
 	ctx := appcontext.WithUserID(context.Background(), uuid.New())
 
 	_, err := svc.CreateFunction(ctx, "fail", "cobol99", "handler", []byte("code"))
@@ -225,7 +266,7 @@ func testFunctionServiceUpdateFunction(t *testing.T) {
 	secretSvc := new(MockSecretService)
 	rbacSvc.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
-	svc := services.NewFunctionService(repo, rbacSvc, compute, fileStore, auditSvc, secretSvc, slog.Default())
+	svc := services.NewFunctionService(repo, rbacSvc, compute, fileStore, auditSvc, secretSvc, nil, slog.Default())
 
 	ctx := appcontext.WithUserID(context.Background(), uuid.New())
 	id := uuid.New()
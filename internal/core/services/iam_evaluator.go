@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"net"
+	"strconv"
 	"strings"
 	"time"
 
@@ -21,6 +22,11 @@ func (e *iamEvaluator) Evaluate(ctx context.Context, policies []*domain.Policy,
 
 	for _, policy := range policies {
 		for _, statement := range policy.Statements {
+			// Resource policy statements only apply to their named principals
+			if len(statement.Principal) > 0 && !e.matchesPrincipal(statement.Principal, evalCtx) {
+				continue
+			}
+
 			// Evaluate conditions if present
 			if len(statement.Condition) > 0 {
 				if !e.evaluateCondition(statement.Condition, evalCtx) {
@@ -45,6 +51,28 @@ func (e *iamEvaluator) Evaluate(ctx context.Context, policies []*domain.Policy,
 	return "", nil // No match
 }
 
+// matchesPrincipal checks the caller identified in evalCtx against a statement's principals.
+func (e *iamEvaluator) matchesPrincipal(principals []string, evalCtx map[string]interface{}) bool {
+	userID, _ := evalCtx[string(domain.KeyUserID)].(string)
+	tenantID, _ := evalCtx[string(domain.KeyTenantID)].(string)
+
+	for _, p := range principals {
+		switch {
+		case p == domain.PrincipalAny:
+			return true
+		case strings.HasPrefix(p, domain.PrincipalTenantPrefix):
+			if tenantID != "" && strings.TrimPrefix(p, domain.PrincipalTenantPrefix) == tenantID {
+				return true
+			}
+		case strings.HasPrefix(p, domain.PrincipalUserPrefix):
+			if userID != "" && strings.TrimPrefix(p, domain.PrincipalUserPrefix) == userID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (e *iamEvaluator) evaluateCondition(cond domain.Condition, evalCtx map[string]interface{}) bool {
 	if evalCtx == nil {
 		return false
@@ -82,6 +110,8 @@ func (e *iamEvaluator) evaluateOperator(op domain.ConditionOperator, expected, a
 		return !e.evalIP(op, expected, actual, exists)
 	case domain.CondDateGreaterThan, domain.CondDateLessThan, domain.CondDateEquals:
 		return e.evalDate(op, expected, actual, exists)
+	case domain.CondNumericGreaterThan, domain.CondNumericLessThan:
+		return e.evalNumeric(op, expected, actual, exists)
 	}
 	return false
 }
@@ -111,34 +141,57 @@ func (e *iamEvaluator) evalBool(expected, actual interface{}) bool {
 	return expectedBool == actualBool
 }
 
+// evalStringEquals accepts a single expected string or a list, matching if any entry equals actual.
 func (e *iamEvaluator) evalStringEquals(expected, actual interface{}, exists bool) bool {
 	if !exists {
 		return false
 	}
-	expectedStr, ok := expected.(string)
-	if !ok {
-		return false
-	}
 	actualStr, ok := actual.(string)
 	if !ok {
 		return false
 	}
-	return expectedStr == actualStr
+	for _, want := range expectedStrings(expected) {
+		if want == actualStr {
+			return true
+		}
+	}
+	return false
 }
 
+// evalStringLike accepts a single expected pattern or a list, matching if any pattern matches actual.
 func (e *iamEvaluator) evalStringLike(expected, actual interface{}, exists bool) bool {
 	if !exists {
 		return false
 	}
-	expectedStr, ok := expected.(string)
-	if !ok {
-		return false
-	}
 	actualStr, ok := actual.(string)
 	if !ok {
 		return false
 	}
-	return e.matchPattern(expectedStr, actualStr)
+	for _, pattern := range expectedStrings(expected) {
+		if e.matchPattern(pattern, actualStr) {
+			return true
+		}
+	}
+	return false
+}
+
+// expectedStrings normalizes a condition value decoded from JSON into a list of strings.
+func expectedStrings(expected interface{}) []string {
+	switch v := expected.(type) {
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	case []interface{}:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			if str, ok := item.(string); ok {
+				out = append(out, str)
+			}
+		}
+		return out
+	}
+	return nil
 }
 
 func (e *iamEvaluator) evalIP(_ domain.ConditionOperator, expected, actual interface{}, exists bool) bool {
@@ -217,6 +270,43 @@ func (e *iamEvaluator) evalDate(op domain.ConditionOperator, expected, actual in
 	return false
 }
 
+func (e *iamEvaluator) evalNumeric(op domain.ConditionOperator, expected, actual interface{}, exists bool) bool {
+	if !exists {
+		return false
+	}
+	want, ok := toFloat(expected)
+	if !ok {
+		return false
+	}
+	got, ok := toFloat(actual)
+	if !ok {
+		return false
+	}
+
+	switch op {
+	case domain.CondNumericGreaterThan:
+		return got > want
+	case domain.CondNumericLessThan:
+		return got < want
+	}
+	return false
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case string:
+		f, err := strconv.ParseFloat(n, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
 func (e *iamEvaluator) matches(statement domain.Statement, action string, resource string) bool {
 	actionMatched := false
 	for _, a := range statement.Action {
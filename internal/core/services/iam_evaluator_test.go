@@ -941,3 +941,72 @@ func TestIAMEvaluator_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestIAMEvaluator_NumericAndListConditions(t *testing.T) {
+	evaluator := NewIAMEvaluator()
+	ctx := context.Background()
+
+	deny := func(cond domain.Condition) []*domain.Policy {
+		return []*domain.Policy{{Statements: []domain.Statement{{
+			Effect: domain.EffectDeny, Action: []string{"*"}, Resource: []string{"*"}, Condition: cond,
+		}}}}
+	}
+
+	tests := []struct {
+		name    string
+		cond    domain.Condition
+		evalCtx map[string]interface{}
+		want    domain.PolicyEffect
+	}{
+		{
+			name:    "NumericGreaterThan matches int attribute",
+			cond:    domain.Condition{"NumericGreaterThan": {"thecloud:InstanceVCPUs": float64(8)}},
+			evalCtx: map[string]interface{}{"thecloud:InstanceVCPUs": 16},
+			want:    domain.EffectDeny,
+		},
+		{
+			name:    "NumericGreaterThan below threshold",
+			cond:    domain.Condition{"NumericGreaterThan": {"thecloud:InstanceVCPUs": float64(8)}},
+			evalCtx: map[string]interface{}{"thecloud:InstanceVCPUs": 8},
+			want:    "",
+		},
+		{
+			name:    "NumericLessThan with string threshold",
+			cond:    domain.Condition{"NumericLessThan": {"thecloud:InstanceMemoryMB": "1024"}},
+			evalCtx: map[string]interface{}{"thecloud:InstanceMemoryMB": 512},
+			want:    domain.EffectDeny,
+		},
+		{
+			name:    "Numeric missing key",
+			cond:    domain.Condition{"NumericGreaterThan": {"thecloud:InstanceVCPUs": float64(8)}},
+			evalCtx: map[string]interface{}{},
+			want:    "",
+		},
+		{
+			name:    "StringEquals list",
+			cond:    domain.Condition{"StringEquals": {"thecloud:Image": []interface{}{"ubuntu", "alpine"}}},
+			evalCtx: map[string]interface{}{"thecloud:Image": "alpine"},
+			want:    domain.EffectDeny,
+		},
+		{
+			name:    "StringNotEquals list allows listed region",
+			cond:    domain.Condition{"StringNotEquals": {"thecloud:Region": []interface{}{"us-east-1", "eu-west-1"}}},
+			evalCtx: map[string]interface{}{"thecloud:Region": "eu-west-1"},
+			want:    "",
+		},
+		{
+			name:    "StringNotLike list denies other images",
+			cond:    domain.Condition{"StringNotLike": {"thecloud:Image": []interface{}{"ubuntu*", "debian*"}}},
+			evalCtx: map[string]interface{}{"thecloud:Image": "windows-2022"},
+			want:    domain.EffectDeny,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := evaluator.Evaluate(ctx, deny(tt.cond), "instance:launch", "*", tt.evalCtx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
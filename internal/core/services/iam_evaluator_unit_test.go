@@ -17,6 +17,7 @@ func TestIAMEvaluator_Unit(t *testing.T) {
 	t.Run("Evaluate_ExplicitDenyWins", testIAMEvaluatorEvaluateExplicitDenyWins)
 	t.Run("Evaluate_WildcardAction", testIAMEvaluatorEvaluateWildcardAction)
 	t.Run("Evaluate_WildcardResource", testIAMEvaluatorEvaluateWildcardResource)
+	t.Run("Evaluate_Principal", testIAMEvaluatorEvaluatePrincipal)
 }
 
 func testIAMEvaluatorEvaluateAllow(t *testing.T) {
@@ -160,3 +161,45 @@ func testIAMEvaluatorEvaluateWildcardResource(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, domain.EffectAllow, effect)
 }
+
+func testIAMEvaluatorEvaluatePrincipal(t *testing.T) {
+	evaluator := services.NewIAMEvaluator()
+	ctx := context.Background()
+	evalCtx := map[string]interface{}{
+		string(domain.KeyUserID):   "user-1",
+		string(domain.KeyTenantID): "tenant-1",
+	}
+
+	tests := []struct {
+		name      string
+		principal []string
+		want      domain.PolicyEffect
+	}{
+		{"Any", []string{"*"}, domain.EffectAllow},
+		{"MatchingTenant", []string{"tenant:tenant-1"}, domain.EffectAllow},
+		{"MatchingUser", []string{"tenant:other", "user:user-1"}, domain.EffectAllow},
+		{"OtherTenant", []string{"tenant:other"}, ""},
+		{"OtherUser", []string{"user:other"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policies := []*domain.Policy{
+				{
+					Statements: []domain.Statement{
+						{
+							Effect:    domain.EffectAllow,
+							Principal: tt.principal,
+							Action:    []string{"storage:read"},
+							Resource:  []string{"*"},
+						},
+					},
+				},
+			}
+
+			effect, err := evaluator.Evaluate(ctx, policies, "storage:read", "bucket-1", evalCtx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, effect)
+		})
+	}
+}
@@ -437,6 +437,25 @@ func (s *IdentityService) ValidateClientCredentials(ctx context.Context, clientI
 	return token, nil
 }
 
+// IssueServiceAccountToken mints an access token for a service account without
+// a client secret. It is used by trusted callers that have already established
+// the caller's identity, such as the instance metadata service.
+func (s *IdentityService) IssueServiceAccountToken(ctx context.Context, saID uuid.UUID) (string, error) {
+	sa, err := s.saRepo.GetByID(ctx, saID)
+	if err != nil {
+		return "", err
+	}
+	if !sa.Enabled {
+		return "", errors.New(errors.Forbidden, "service account is disabled")
+	}
+
+	token, err := s.generateSAToken(sa.ID, sa.TenantID, sa.Role)
+	if err != nil {
+		return "", errors.Wrap(errors.Internal, "failed to generate token", err)
+	}
+	return token, nil
+}
+
 // ValidateAccessToken validates a Bearer JWT and returns claims.
 //
 // SECURITY NOTE: This validates that the SA has at least one active (non-expired) secret
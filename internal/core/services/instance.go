@@ -51,6 +51,11 @@ type InstanceService struct {
 	eventSvc         ports.EventService
 	auditSvc         ports.AuditService
 	dnsSvc           ports.DNSService
+	resolverRepo     ports.VPCResolverRepository
+	dhcpRepo         ports.DHCPOptionsRepository
+	metadataRepo     ports.InstanceMetadataRepository
+	eniRepo          ports.NetworkInterfaceRepository
+	saRepo           ports.ServiceAccountRepository
 	logSvc           ports.LogService
 	taskQueue        ports.TaskQueue
 	tenantSvc        ports.TenantService
@@ -73,6 +78,11 @@ type InstanceServiceParams struct {
 	EventSvc         ports.EventService
 	AuditSvc         ports.AuditService
 	DNSSvc           ports.DNSService
+	ResolverRepo     ports.VPCResolverRepository // Optional
+	DHCPRepo         ports.DHCPOptionsRepository // Optional
+	MetadataRepo     ports.InstanceMetadataRepository // Optional
+	SARepo           ports.ServiceAccountRepository   // Optional
+	ENIRepo          ports.NetworkInterfaceRepository // Optional
 	LogSvc           ports.LogService
 	TaskQueue        ports.TaskQueue // Optional
 	TenantSvc        ports.TenantService
@@ -99,6 +109,11 @@ func NewInstanceService(params InstanceServiceParams) *InstanceService {
 		eventSvc:         params.EventSvc,
 		auditSvc:         params.AuditSvc,
 		dnsSvc:           params.DNSSvc,
+		resolverRepo:     params.ResolverRepo,
+		dhcpRepo:         params.DHCPRepo,
+		metadataRepo:     params.MetadataRepo,
+		saRepo:           params.SARepo,
+		eniRepo:          params.ENIRepo,
 		logSvc:           params.LogSvc,
 		taskQueue:        params.TaskQueue,
 		tenantSvc:        params.TenantSvc,
@@ -117,9 +132,20 @@ func (s *InstanceService) LaunchInstance(ctx context.Context, params ports.Launc
 	userID := appcontext.UserIDFromContext(ctx)
 	tenantID := appcontext.TenantIDFromContext(ctx)
 
+	// The instance type is resolved up front so its size is visible to policy conditions
+	instanceType := params.InstanceType
+	if instanceType == "" {
+		instanceType = "basic-2"
+	}
+	it, itErr := s.instanceTypeRepo.GetByID(ctx, instanceType)
+	ctx = appcontext.WithRequestAttributes(ctx, launchAttributes(instanceType, params.Image, it))
+
 	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionInstanceLaunch, "*"); err != nil {
 		return nil, err
 	}
+	if err := checkLaunchMetadata(ctx, params.Metadata); err != nil {
+		return nil, err
+	}
 
 	span.SetAttributes(
 		attribute.String("instance.name", params.Name),
@@ -132,16 +158,15 @@ func (s *InstanceService) LaunchInstance(ctx context.Context, params ports.Launc
 		return nil, err
 	}
 
-	// 2. Resolve Instance Type
-	instanceType := params.InstanceType
-	if instanceType == "" {
-		instanceType = "basic-2"
-	}
-	it, err := s.instanceTypeRepo.GetByID(ctx, instanceType)
-	if err != nil {
+	// 2. Reject unknown instance types
+	if itErr != nil {
 		return nil, errors.New(errors.InvalidInput, fmt.Sprintf("invalid instance type: %s", instanceType))
 	}
 
+	if err := s.validateServiceAccount(ctx, userID, tenantID, params.ServiceAccountID); err != nil {
+		return nil, err
+	}
+
 	// 3. Quota Check & Reservation
 
 	// Resolve SSH Key if provided
@@ -167,26 +192,31 @@ func (s *InstanceService) LaunchInstance(ctx context.Context, params ports.Launc
 	}
 
 	// Check instances quota
-	if err := s.tenantSvc.CheckQuota(ctx, tenantID, "instances", 1); err != nil {
+	if err := s.tenantSvc.CheckQuota(ctx, tenantID, domain.QuotaInstances, 1); err != nil {
 		return nil, err
 	}
 
 	// Check & Reserve vCPU/Memory quota
 	// Note: We use atomic increment/decrement to manage usage state
-	if err := s.tenantSvc.CheckQuota(ctx, tenantID, "vcpus", it.VCPUs); err != nil {
+	if err := s.tenantSvc.CheckQuota(ctx, tenantID, domain.QuotaVCPUs, it.VCPUs); err != nil {
 		return nil, err
 	}
-	if err := s.tenantSvc.CheckQuota(ctx, tenantID, "memory", it.MemoryMB/1024); err != nil {
+	if err := s.tenantSvc.CheckQuota(ctx, tenantID, domain.QuotaMemoryGB, it.MemoryMB/1024); err != nil {
 		return nil, err
 	}
 
 	// Reserve resources
-	if err := s.tenantSvc.IncrementUsage(ctx, tenantID, "vcpus", it.VCPUs); err != nil {
+	if err := s.tenantSvc.IncrementUsage(ctx, tenantID, domain.QuotaVCPUs, it.VCPUs); err != nil {
 		return nil, err
 	}
-	if err := s.tenantSvc.IncrementUsage(ctx, tenantID, "memory", it.MemoryMB/1024); err != nil {
+	if err := s.tenantSvc.IncrementUsage(ctx, tenantID, domain.QuotaMemoryGB, it.MemoryMB/1024); err != nil {
 		// Rollback vCPUs if memory fails
-		_ = s.tenantSvc.DecrementUsage(ctx, tenantID, "vcpus", it.VCPUs)
+		_ = s.tenantSvc.DecrementUsage(ctx, tenantID, domain.QuotaVCPUs, it.VCPUs)
+		return nil, err
+	}
+	if err := s.tenantSvc.IncrementUsage(ctx, tenantID, domain.QuotaInstances, 1); err != nil {
+		_ = s.tenantSvc.DecrementUsage(ctx, tenantID, domain.QuotaVCPUs, it.VCPUs)
+		_ = s.tenantSvc.DecrementUsage(ctx, tenantID, domain.QuotaMemoryGB, it.MemoryMB/1024)
 		return nil, err
 	}
 
@@ -218,8 +248,13 @@ func (s *InstanceService) LaunchInstance(ctx context.Context, params ports.Launc
 
 	if err := s.repo.Create(ctx, inst); err != nil {
 		// Rollback quota reservation
-		_ = s.tenantSvc.DecrementUsage(ctx, tenantID, "vcpus", it.VCPUs)
-		_ = s.tenantSvc.DecrementUsage(ctx, tenantID, "memory", it.MemoryMB/1024)
+		s.releaseLaunchQuota(ctx, tenantID, it)
+		return nil, err
+	}
+
+	if err := s.saveMetadataConfig(ctx, inst, userData, params.ServiceAccountID); err != nil {
+		_ = s.repo.Delete(ctx, inst.ID)
+		s.releaseLaunchQuota(ctx, tenantID, it)
 		return nil, err
 	}
 
@@ -244,14 +279,72 @@ func (s *InstanceService) LaunchInstance(ctx context.Context, params ports.Launc
 	if err := s.taskQueue.Enqueue(ctx, "provision_queue", job); err != nil {
 		s.logger.Error("failed to enqueue provision job", "instance_id", inst.ID, "error", err)
 		// Rollback quota reservation on enqueue failure
-		_ = s.tenantSvc.DecrementUsage(ctx, tenantID, "vcpus", it.VCPUs)
-		_ = s.tenantSvc.DecrementUsage(ctx, tenantID, "memory", it.MemoryMB/1024)
+		s.releaseLaunchQuota(ctx, tenantID, it)
 		return nil, errors.Wrap(errors.Internal, "failed to enqueue provisioning task", err)
 	}
 
 	return inst, nil
 }
 
+// releaseLaunchQuota gives back the usage reserved by LaunchInstance. Failures are
+// ignored here; the quota reconciler corrects any counter left behind.
+func (s *InstanceService) releaseLaunchQuota(ctx context.Context, tenantID uuid.UUID, it *domain.InstanceType) {
+	_ = s.tenantSvc.DecrementUsage(ctx, tenantID, domain.QuotaInstances, 1)
+	_ = s.tenantSvc.DecrementUsage(ctx, tenantID, domain.QuotaVCPUs, it.VCPUs)
+	_ = s.tenantSvc.DecrementUsage(ctx, tenantID, domain.QuotaMemoryGB, it.MemoryMB/1024)
+}
+
+// validateServiceAccount checks that a service account requested for an instance
+// exists in the caller's tenant and is enabled. The instance can fetch the account's
+// tokens, so the caller must also be allowed to use that account.
+func (s *InstanceService) validateServiceAccount(ctx context.Context, userID, tenantID uuid.UUID, saID *uuid.UUID) error {
+	if saID == nil {
+		return nil
+	}
+	if s.saRepo == nil || s.metadataRepo == nil {
+		return errors.New(errors.InvalidInput, "attaching service accounts to instances is not supported")
+	}
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionServiceAccountUse, saID.String()); err != nil {
+		return err
+	}
+	sa, err := s.saRepo.GetByID(ctx, *saID)
+	if err != nil || sa.TenantID != tenantID {
+		return errors.New(errors.InvalidInput, "service account not found")
+	}
+	if !sa.Enabled {
+		return errors.New(errors.InvalidInput, "service account is disabled")
+	}
+	return nil
+}
+
+// checkLaunchMetadata rejects reserved metadata keys, such as the capacity type
+// that sets the price of an instance, unless a platform component launches it.
+func checkLaunchMetadata(ctx context.Context, metadata map[string]string) error {
+	if appcontext.AllowsReservedMetadata(ctx) {
+		return nil
+	}
+	for k := range metadata {
+		if domain.IsReservedMetadataKey(k) {
+			return errors.New(errors.InvalidInput, "metadata key "+k+" is managed by the platform")
+		}
+	}
+	return nil
+}
+
+// saveMetadataConfig stores what the metadata service serves besides the instance
+// record itself. Nothing is stored when there is nothing to serve.
+func (s *InstanceService) saveMetadataConfig(ctx context.Context, inst *domain.Instance, userData string, saID *uuid.UUID) error {
+	if s.metadataRepo == nil || (userData == "" && saID == nil) {
+		return nil
+	}
+	return s.metadataRepo.Save(ctx, &domain.InstanceMetadataConfig{
+		InstanceID:       inst.ID,
+		TenantID:         inst.TenantID,
+		UserData:         userData,
+		ServiceAccountID: saID,
+	})
+}
+
 // LaunchInstanceWithOptions provisions an instance using structured options.
 func (s *InstanceService) LaunchInstanceWithOptions(ctx context.Context, opts ports.CreateInstanceOptions) (*domain.Instance, error) {
 	ctx, span := otel.Tracer("instance-service").Start(ctx, "LaunchInstanceWithOptions")
@@ -260,9 +353,26 @@ func (s *InstanceService) LaunchInstanceWithOptions(ctx context.Context, opts po
 	userID := appcontext.UserIDFromContext(ctx)
 	tenantID := appcontext.TenantIDFromContext(ctx)
 
+	// As in LaunchInstance, the size is resolved up front so policy conditions see it
+	var it *domain.InstanceType
+	var itErr error
+	if opts.InstanceType != "" {
+		it, itErr = s.instanceTypeRepo.GetByID(ctx, opts.InstanceType)
+	}
+	ctx = appcontext.WithRequestAttributes(ctx, optionsLaunchAttributes(opts, it))
 	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionInstanceLaunch, "*"); err != nil {
 		return nil, err
 	}
+	if err := checkLaunchMetadata(ctx, opts.Metadata); err != nil {
+		return nil, err
+	}
+	if itErr != nil {
+		return nil, errors.New(errors.InvalidInput, fmt.Sprintf("invalid instance type: %s", opts.InstanceType))
+	}
+	instanceType := opts.InstanceType
+	if instanceType == "" {
+		instanceType = "custom" // Marking as custom since we are passing raw constraints or defaults
+	}
 
 	inst := &domain.Instance{
 		ID:           uuid.New(),
@@ -278,7 +388,9 @@ func (s *InstanceService) LaunchInstanceWithOptions(ctx context.Context, opts po
 		CPULimit:     opts.CPULimit,
 		MemoryLimit:  opts.MemoryLimit,
 		DiskLimit:    opts.DiskLimit,
-		InstanceType: "custom", // Marking as custom since we are passing raw constraints or defaults
+		Metadata:     opts.Metadata,
+		Labels:       opts.Labels,
+		InstanceType: instanceType,
 		Version:      1,
 		CreatedAt:    time.Now(),
 		UpdatedAt:    time.Now(),
@@ -296,6 +408,11 @@ func (s *InstanceService) LaunchInstanceWithOptions(ctx context.Context, opts po
 		return nil, err
 	}
 
+	if err := s.saveMetadataConfig(ctx, inst, opts.UserData, nil); err != nil {
+		_ = s.repo.Delete(ctx, inst.ID)
+		return nil, err
+	}
+
 	// 4. Enqueue provision task with full options
 	job := domain.ProvisionJob{
 		InstanceID:  inst.ID,
@@ -367,6 +484,7 @@ func (s *InstanceService) Provision(ctx context.Context, job domain.ProvisionJob
 
 	dockerName := s.formatContainerName(inst.ID)
 	portList, _ := s.parseAndValidatePorts(inst.Ports)
+	dnsServers, dnsSearch := s.vpcDNSConfig(ctx, inst)
 	containerID, allocatedPorts, err := s.compute.LaunchInstanceWithOptions(ctx, ports.CreateInstanceOptions{
 		Name:        dockerName,
 		ImageName:   inst.Image,
@@ -379,6 +497,8 @@ func (s *InstanceService) Provision(ctx context.Context, job domain.ProvisionJob
 		MemoryLimit: memLimit,
 		DiskLimit:   diskLimit,
 		UserData:    userData,
+		DNSServers:  dnsServers,
+		DNSSearch:   dnsSearch,
 	})
 	if err != nil {
 		platform.InstanceOperationsTotal.WithLabelValues("launch", "failure").Inc()
@@ -394,18 +514,63 @@ func (s *InstanceService) Provision(ctx context.Context, job domain.ProvisionJob
 	// 4. Finalize
 	return s.finalizeProvision(ctx, inst, containerID, attachedVolumes)
 }
+
+// vpcDNSConfig returns the nameservers and search domains of an instance's VPC.
+// Nameservers from the VPC's DHCP options set take precedence over the VPC resolver.
+func (s *InstanceService) vpcDNSConfig(ctx context.Context, inst *domain.Instance) ([]string, []string) {
+	if inst.VpcID == nil {
+		return nil, nil
+	}
+
+	var servers, search []string
+	if s.dhcpRepo != nil {
+		opts, err := s.dhcpRepo.GetByVPC(ctx, *inst.VpcID)
+		if err == nil {
+			servers = opts.DomainNameServers
+			if opts.DomainName != "" {
+				search = []string{opts.DomainName}
+			}
+		} else if !errors.Is(err, errors.NotFound) {
+			s.logger.Warn("failed to look up VPC DHCP options", "instance_id", inst.ID, "error", err)
+		}
+	}
+	if len(servers) == 0 {
+		servers = s.vpcNameservers(ctx, inst)
+	}
+	return servers, search
+}
+
+// vpcNameservers returns the address of the VPC resolver, if the instance's VPC has an active one.
+func (s *InstanceService) vpcNameservers(ctx context.Context, inst *domain.Instance) []string {
+	if s.resolverRepo == nil || inst.VpcID == nil {
+		return nil
+	}
+	resolver, err := s.resolverRepo.GetByVPC(ctx, *inst.VpcID)
+	if err != nil {
+		if !errors.Is(err, errors.NotFound) {
+			s.logger.Warn("failed to look up VPC resolver", "instance_id", inst.ID, "error", err)
+		}
+		return nil
+	}
+	if resolver.Status != domain.VPCResolverStatusActive || resolver.PrivateIP == "" {
+		return nil
+	}
+	return []string{resolver.PrivateIP}
+}
+
 func (s *InstanceService) provisionNetwork(ctx context.Context, inst *domain.Instance) (string, error) {
 	if s.compute.Type() == "noop" && inst.VpcID == nil && inst.SubnetID == nil {
 		inst.PrivateIP = "127.0.0.1"
 		return "", nil
 	}
 
-	networkID, allocatedIP, ovsPort, err := s.resolveNetworkConfig(ctx, inst.VpcID, inst.SubnetID)
+	networkID, allocatedIP, allocatedIPv6, ovsPort, err := s.resolveNetworkConfig(ctx, inst.VpcID, inst.SubnetID)
 	if err != nil {
 		return "", err
 	}
 
 	inst.PrivateIP = allocatedIP
+	inst.PrivateIPv6 = allocatedIPv6
 	inst.OvsPort = ovsPort
 	return networkID, nil
 }
@@ -435,6 +600,11 @@ func (s *InstanceService) finalizeProvision(ctx context.Context, inst *domain.In
 			// Don't fail provisioning for DNS failure
 		}
 	}
+	if s.dnsSvc != nil && inst.PrivateIPv6 != "" {
+		if err := s.dnsSvc.RegisterInstance(ctx, inst, inst.PrivateIPv6); err != nil {
+			s.logger.Warn("failed to register instance AAAA record", "error", err, "instance", inst.Name)
+		}
+	}
 
 	if err := s.repo.Update(ctx, inst); err != nil {
 		return err
@@ -865,6 +1035,7 @@ func (s *InstanceService) ResizeInstance(ctx context.Context, idOrName, newInsta
 	userID := appcontext.UserIDFromContext(ctx)
 	tenantID := appcontext.TenantIDFromContext(ctx)
 
+	ctx = appcontext.WithRequestAttributes(ctx, map[string]interface{}{string(domain.KeyInstanceType): newInstanceType})
 	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionInstanceResize, idOrName); err != nil {
 		return nil, err
 	}
@@ -901,6 +1072,37 @@ func (s *InstanceService) ResizeInstance(ctx context.Context, idOrName, newInsta
 	return inst, nil
 }
 
+// launchAttributes describes a launch request to policy conditions such as organization guardrails.
+// Size attributes are only set when the instance type could be resolved.
+func launchAttributes(instanceType, image string, it *domain.InstanceType) map[string]interface{} {
+	attrs := map[string]interface{}{
+		string(domain.KeyImage): image,
+	}
+	if instanceType != "" {
+		attrs[string(domain.KeyInstanceType)] = instanceType
+	}
+	if it != nil {
+		attrs[string(domain.KeyInstanceVCPUs)] = it.VCPUs
+		attrs[string(domain.KeyInstanceMemoryMB)] = it.MemoryMB
+	}
+	return attrs
+}
+
+// optionsLaunchAttributes describes a structured launch to policy conditions. Without an
+// instance type, its size is taken from whichever raw CPU and memory limits are set.
+func optionsLaunchAttributes(opts ports.CreateInstanceOptions, it *domain.InstanceType) map[string]interface{} {
+	attrs := launchAttributes(opts.InstanceType, opts.ImageName, it)
+	if it == nil {
+		if opts.CPULimit > 0 {
+			attrs[string(domain.KeyInstanceVCPUs)] = int(opts.CPULimit)
+		}
+		if opts.MemoryLimit > 0 {
+			attrs[string(domain.KeyInstanceMemoryMB)] = int(opts.MemoryLimit / 1024 / 1024)
+		}
+	}
+	return attrs
+}
+
 func (s *InstanceService) resolveInstance(ctx context.Context, idOrName string) (*domain.Instance, error) {
 	inst, err := s.repo.GetByName(ctx, idOrName)
 	if err != nil {
@@ -943,20 +1145,20 @@ func (s *InstanceService) validateResize(inst *domain.Instance) error {
 // It logs failures but does not return errors, since undo is not guaranteed to be possible.
 func (s *InstanceService) rollbackQuotaChanges(ctx context.Context, tenantID uuid.UUID, deltaCPU, deltaMemMB, memoryGB int) {
 	if deltaCPU > 0 {
-		if err := s.tenantSvc.DecrementUsage(ctx, tenantID, "vcpus", deltaCPU); err != nil {
+		if err := s.tenantSvc.DecrementUsage(ctx, tenantID, domain.QuotaVCPUs, deltaCPU); err != nil {
 			s.logger.Error("rollback vcpu decrement failed", "error", err, "tenant_id", tenantID, "delta", deltaCPU)
 		}
 	} else if deltaCPU < 0 {
-		if err := s.tenantSvc.IncrementUsage(ctx, tenantID, "vcpus", -deltaCPU); err != nil {
+		if err := s.tenantSvc.IncrementUsage(ctx, tenantID, domain.QuotaVCPUs, -deltaCPU); err != nil {
 			s.logger.Error("rollback vcpu increment failed", "error", err, "tenant_id", tenantID, "delta", -deltaCPU)
 		}
 	}
 	if deltaMemMB > 0 {
-		if err := s.tenantSvc.DecrementUsage(ctx, tenantID, "memory", memoryGB); err != nil {
+		if err := s.tenantSvc.DecrementUsage(ctx, tenantID, domain.QuotaMemoryGB, memoryGB); err != nil {
 			s.logger.Error("rollback memory decrement failed", "error", err, "tenant_id", tenantID)
 		}
 	} else if deltaMemMB < 0 {
-		if err := s.tenantSvc.IncrementUsage(ctx, tenantID, "memory", -memoryGB); err != nil {
+		if err := s.tenantSvc.IncrementUsage(ctx, tenantID, domain.QuotaMemoryGB, -memoryGB); err != nil {
 			s.logger.Error("rollback memory increment failed", "error", err, "tenant_id", tenantID)
 		}
 	}
@@ -969,35 +1171,35 @@ func (s *InstanceService) completeResize(ctx context.Context, tenantID uuid.UUID
 
 	// 1. Quota changes first — fail fast before any VM state change
 	if deltaCPU > 0 {
-		if err := s.tenantSvc.CheckQuota(ctx, tenantID, "vcpus", deltaCPU); err != nil {
+		if err := s.tenantSvc.CheckQuota(ctx, tenantID, domain.QuotaVCPUs, deltaCPU); err != nil {
 			return err
 		}
-		if err := s.tenantSvc.IncrementUsage(ctx, tenantID, "vcpus", deltaCPU); err != nil {
+		if err := s.tenantSvc.IncrementUsage(ctx, tenantID, domain.QuotaVCPUs, deltaCPU); err != nil {
 			platform.InstanceOperationsTotal.WithLabelValues("resize", "quota_failure").Inc()
 			return errors.Wrap(errors.Internal, "failed to increment vCPU quota for resize", err)
 		}
 	} else if deltaCPU < 0 {
-		if err := s.tenantSvc.DecrementUsage(ctx, tenantID, "vcpus", -deltaCPU); err != nil {
+		if err := s.tenantSvc.DecrementUsage(ctx, tenantID, domain.QuotaVCPUs, -deltaCPU); err != nil {
 			platform.InstanceOperationsTotal.WithLabelValues("resize", "quota_decrement_failure").Inc()
 			return errors.Wrap(errors.Internal, "failed to decrement vCPU quota for resize", err)
 		}
 	}
 	if deltaMemMB > 0 {
-		if err := s.tenantSvc.CheckQuota(ctx, tenantID, "memory", memoryGB); err != nil {
+		if err := s.tenantSvc.CheckQuota(ctx, tenantID, domain.QuotaMemoryGB, memoryGB); err != nil {
 			// Rollback vCPU increment since memory quota check failed
 			if deltaCPU > 0 {
-				if decErr := s.tenantSvc.DecrementUsage(ctx, tenantID, "vcpus", deltaCPU); decErr != nil {
+				if decErr := s.tenantSvc.DecrementUsage(ctx, tenantID, domain.QuotaVCPUs, deltaCPU); decErr != nil {
 					return errors.Wrap(errors.Internal,
 						fmt.Sprintf("memory quota check failed (%v), vCPU rollback also failed (%v)", err, decErr), err)
 				}
 			}
 			return err
 		}
-		if err := s.tenantSvc.IncrementUsage(ctx, tenantID, "memory", memoryGB); err != nil {
+		if err := s.tenantSvc.IncrementUsage(ctx, tenantID, domain.QuotaMemoryGB, memoryGB); err != nil {
 			platform.InstanceOperationsTotal.WithLabelValues("resize", "quota_failure").Inc()
 			// Rollback vCPU increment since memory increment failed
 			if deltaCPU > 0 {
-				if decErr := s.tenantSvc.DecrementUsage(ctx, tenantID, "vcpus", deltaCPU); decErr != nil {
+				if decErr := s.tenantSvc.DecrementUsage(ctx, tenantID, domain.QuotaVCPUs, deltaCPU); decErr != nil {
 					return errors.Wrap(errors.Internal,
 						fmt.Sprintf("memory increment failed (%v), vCPU rollback also failed (%v)", err, decErr), err)
 				}
@@ -1005,7 +1207,7 @@ func (s *InstanceService) completeResize(ctx context.Context, tenantID uuid.UUID
 			return errors.Wrap(errors.Internal, "failed to increment memory quota for resize", err)
 		}
 	} else if deltaMemMB < 0 {
-		if err := s.tenantSvc.DecrementUsage(ctx, tenantID, "memory", -memoryGB); err != nil {
+		if err := s.tenantSvc.DecrementUsage(ctx, tenantID, domain.QuotaMemoryGB, -memoryGB); err != nil {
 			platform.InstanceOperationsTotal.WithLabelValues("resize", "quota_decrement_failure").Inc()
 			return errors.Wrap(errors.Internal, "failed to decrement memory quota for resize", err)
 		}
@@ -1046,20 +1248,20 @@ func (s *InstanceService) completeResize(ctx context.Context, tenantID uuid.UUID
 		}
 		// Quota rollback for DB update failure (quota was successfully updated before compute resize)
 		if deltaCPU > 0 {
-			if decErr := s.tenantSvc.DecrementUsage(ctx, tenantID, "vcpus", deltaCPU); decErr != nil {
+			if decErr := s.tenantSvc.DecrementUsage(ctx, tenantID, domain.QuotaVCPUs, deltaCPU); decErr != nil {
 				rollbackErrs = append(rollbackErrs, fmt.Errorf("vcpu decrement rollback (tenant_id=%s, delta_cpu=%d): %w", tenantID, deltaCPU, decErr))
 			}
 		} else if deltaCPU < 0 {
-			if incErr := s.tenantSvc.IncrementUsage(ctx, tenantID, "vcpus", -deltaCPU); incErr != nil {
+			if incErr := s.tenantSvc.IncrementUsage(ctx, tenantID, domain.QuotaVCPUs, -deltaCPU); incErr != nil {
 				rollbackErrs = append(rollbackErrs, fmt.Errorf("vcpu increment rollback (tenant_id=%s, delta_cpu=%d): %w", tenantID, -deltaCPU, incErr))
 			}
 		}
 		if deltaMemMB > 0 {
-			if decErr := s.tenantSvc.DecrementUsage(ctx, tenantID, "memory", memoryGB); decErr != nil {
+			if decErr := s.tenantSvc.DecrementUsage(ctx, tenantID, domain.QuotaMemoryGB, memoryGB); decErr != nil {
 				rollbackErrs = append(rollbackErrs, fmt.Errorf("memory decrement rollback (tenant_id=%s, delta_mem_gb=%d): %w", tenantID, memoryGB, decErr))
 			}
 		} else if deltaMemMB < 0 {
-			if incErr := s.tenantSvc.IncrementUsage(ctx, tenantID, "memory", -memoryGB); incErr != nil {
+			if incErr := s.tenantSvc.IncrementUsage(ctx, tenantID, domain.QuotaMemoryGB, -memoryGB); incErr != nil {
 				rollbackErrs = append(rollbackErrs, fmt.Errorf("memory increment rollback (tenant_id=%s, delta_mem_gb=%d): %w", tenantID, -memoryGB, incErr))
 			}
 		}
@@ -1152,6 +1354,7 @@ func (s *InstanceService) TerminateInstance(ctx context.Context, idOrName string
 		}
 	}
 
+	s.detachFromVpcBridge(ctx, inst)
 	if err := s.removeInstanceContainer(ctx, inst); err != nil {
 		platform.InstanceOperationsTotal.WithLabelValues("terminate", "failure").Inc()
 		return err
@@ -1162,6 +1365,9 @@ func (s *InstanceService) TerminateInstance(ctx context.Context, idOrName string
 	if err := s.releaseAttachedVolumes(ctx, inst.ID); err != nil {
 		s.logger.Warn("failed to release volumes during termination", "instance_id", inst.ID, "error", err)
 	}
+	if err := s.releaseAttachedInterfaces(ctx, inst); err != nil {
+		s.logger.Warn("failed to release network interfaces during termination", "instance_id", inst.ID, "error", err)
+	}
 
 	return s.finalizeTermination(ctx, inst)
 }
@@ -1187,13 +1393,13 @@ func (s *InstanceService) finalizeTermination(ctx context.Context, inst *domain.
 		s.logger.Error("failed to resolve instance type for quota release", "instance_id", inst.ID, "type", inst.InstanceType, "error", err)
 	}
 	if it != nil {
-		if err := s.tenantSvc.DecrementUsage(ctx, inst.TenantID, "instances", 1); err != nil {
+		if err := s.tenantSvc.DecrementUsage(ctx, inst.TenantID, domain.QuotaInstances, 1); err != nil {
 			s.logger.Error("failed to decrement instance quota", "instance_id", inst.ID, "error", err)
 		}
-		if err := s.tenantSvc.DecrementUsage(ctx, inst.TenantID, "vcpus", it.VCPUs); err != nil {
+		if err := s.tenantSvc.DecrementUsage(ctx, inst.TenantID, domain.QuotaVCPUs, it.VCPUs); err != nil {
 			s.logger.Error("failed to decrement vcpu quota", "instance_id", inst.ID, "error", err)
 		}
-		if err := s.tenantSvc.DecrementUsage(ctx, inst.TenantID, "memory", it.MemoryMB/1024); err != nil {
+		if err := s.tenantSvc.DecrementUsage(ctx, inst.TenantID, domain.QuotaMemoryGB, it.MemoryMB/1024); err != nil {
 			s.logger.Error("failed to decrement memory quota", "instance_id", inst.ID, "error", err)
 		}
 	}
@@ -1254,6 +1460,36 @@ func (s *InstanceService) releaseAttachedVolumes(ctx context.Context, instanceID
 	return nil
 }
 
+// releaseAttachedInterfaces detaches the network interfaces of a terminated
+// instance and removes their host-side ports so they can be attached elsewhere.
+func (s *InstanceService) releaseAttachedInterfaces(ctx context.Context, inst *domain.Instance) error {
+	if s.eniRepo == nil {
+		return nil
+	}
+	interfaces, err := s.eniRepo.ListByInstance(ctx, inst.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, ni := range interfaces {
+		if s.network != nil && s.compute.Type() != "libvirt" {
+			if vpc, err := s.vpcRepo.GetByID(ctx, ni.VpcID); err == nil {
+				removeInterfacePort(ctx, s.network, s.logger, vpc.NetworkID, ni)
+			}
+		}
+		ni.InstanceID = nil
+		ni.DeviceIndex = 0
+		ni.Status = domain.NetworkInterfaceStatusAvailable
+		ni.UpdatedAt = time.Now()
+		if err := s.eniRepo.Update(ctx, ni); err != nil {
+			s.logger.Warn("failed to release network interface", "interface_id", ni.ID, "error", err)
+			continue
+		}
+		s.logger.Info("network interface released during instance termination", "interface_id", ni.ID, "instance_id", inst.ID)
+	}
+	return nil
+}
+
 // GetInstanceStats retrieves real-time CPU and Memory usage for an instance.
 func (s *InstanceService) GetInstanceStats(ctx context.Context, idOrName string) (*domain.InstanceStats, error) {
 	userID := appcontext.UserIDFromContext(ctx)
@@ -1372,17 +1608,61 @@ func (s *InstanceService) updateVolumesAfterLaunch(ctx context.Context, volumes
 		}
 	}
 }
+// allocateIPv6 picks the lowest free address in a dual-stack subnet's /64.
+// The first address (::1) is reserved for the gateway.
+func (s *InstanceService) allocateIPv6(ctx context.Context, subnet *domain.Subnet) (string, error) {
+	if subnet.IPv6CIDRBlock == "" {
+		return "", nil
+	}
+	_, ipNet, err := net.ParseCIDR(subnet.IPv6CIDRBlock)
+	if err != nil {
+		return "", err
+	}
+
+	instances, err := s.repo.ListBySubnet(ctx, subnet.ID)
+	if err != nil {
+		return "", err
+	}
+
+	gw := make(net.IP, len(ipNet.IP))
+	copy(gw, ipNet.IP)
+	gw[len(gw)-1] |= 1
+	usedIPs := map[string]bool{gw.String(): true}
+	for _, inst := range instances {
+		if inst.PrivateIPv6 != "" {
+			usedIPs[strings.SplitN(inst.PrivateIPv6, "/", 2)[0]] = true
+		}
+	}
+	return s.findAvailableIP(ipNet, usedIPs)
+}
+
 func (s *InstanceService) allocateIP(ctx context.Context, subnet *domain.Subnet) (string, error) {
 	_, ipNet, err := net.ParseCIDR(subnet.CIDRBlock)
 	if err != nil {
 		return "", err
 	}
 
-	instances, err := s.repo.ListBySubnet(ctx, subnet.ID)
+	usedIPs, err := subnetIPsInUse(ctx, s.repo, s.eniRepo, subnet)
 	if err != nil {
 		return "", err
 	}
 
+	// Find first available IP
+	ip, err := s.findAvailableIP(ipNet, usedIPs)
+	if err != nil {
+		return "", err
+	}
+	return ip, nil
+}
+
+// subnetIPsInUse collects the IPv4 addresses of a subnet that are taken by
+// instances, network interfaces (when eniRepo is set) and the gateway.
+func subnetIPsInUse(ctx context.Context, repo ports.InstanceRepository, eniRepo ports.NetworkInterfaceRepository, subnet *domain.Subnet) (map[string]bool, error) {
+	instances, err := repo.ListBySubnet(ctx, subnet.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	usedIPs := make(map[string]bool)
 	for _, inst := range instances {
 		if inst.PrivateIP != "" {
@@ -1393,21 +1673,32 @@ func (s *InstanceService) allocateIP(ctx context.Context, subnet *domain.Subnet)
 			usedIPs[ip] = true
 		}
 	}
+	if eniRepo != nil {
+		interfaces, err := eniRepo.ListBySubnet(ctx, subnet.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, ni := range interfaces {
+			for _, ip := range ni.PrivateIPs {
+				usedIPs[ip] = true
+			}
+		}
+	}
 	gw := subnet.GatewayIP
 	if idx := strings.Index(gw, "/"); idx != -1 {
 		gw = gw[:idx]
 	}
 	usedIPs[gw] = true
-
-	// Find first available IP
-	ip, err := s.findAvailableIP(ipNet, usedIPs)
-	if err != nil {
-		return "", err
-	}
-	return ip, nil
+	return usedIPs, nil
 }
 
 func (s *InstanceService) isValidHostIP(ip net.IP, n *net.IPNet) bool {
+	return isValidHostIP(ip, n)
+}
+
+// isValidHostIP reports whether ip is a usable host address of n, i.e. neither
+// the network nor the broadcast address.
+func isValidHostIP(ip net.IP, n *net.IPNet) bool {
 	if !n.Contains(ip) {
 		return false
 	}
@@ -1433,13 +1724,13 @@ func (s *InstanceService) isValidHostIP(ip net.IP, n *net.IPNet) bool {
 	return true
 }
 
-func (s *InstanceService) resolveNetworkConfig(ctx context.Context, vpcID, subnetID *uuid.UUID) (string, string, string, error) {
+func (s *InstanceService) resolveNetworkConfig(ctx context.Context, vpcID, subnetID *uuid.UUID) (string, string, string, string, error) {
 	var networkID string
 	if vpcID != nil {
 		vpc, err := s.vpcRepo.GetByID(ctx, *vpcID)
 		if err != nil {
 			s.logger.Error("failed to get VPC", "vpc_id", vpcID, "error", err)
-			return "", "", "", err
+			return "", "", "", "", err
 		}
 		networkID = vpc.NetworkID
 	}
@@ -1455,7 +1746,7 @@ func (s *InstanceService) resolveNetworkConfig(ctx context.Context, vpcID, subne
 		// If no subnet is configured, we let the backend assign an IP (dynamic).
 		// We return empty string here, and LaunchInstance should fetch the real IP later.
 		if subnetID == nil {
-			return networkID, "", "", nil
+			return networkID, "", "", "", nil
 		}
 	}
 
@@ -1463,26 +1754,31 @@ func (s *InstanceService) resolveNetworkConfig(ctx context.Context, vpcID, subne
 	// return "default" (libvirt's built-in NAT network) instead of OVS bridge.
 	// This avoids libvirt failing to find non-existent OVS bridges.
 	if s.compute.Type() == "libvirt" && s.network == nil && vpcID != nil {
-		return "default", "", "", nil
+		return "default", "", "", "", nil
 	}
 
 	if subnetID == nil || s.network == nil {
-		return networkID, "", "", nil
+		return networkID, "", "", "", nil
 	}
 
 	subnet, err := s.subnetRepo.GetByID(ctx, *subnetID)
 	if err != nil {
-		return "", "", "", errors.Wrap(errors.NotFound, "subnet not found", err)
+		return "", "", "", "", errors.Wrap(errors.NotFound, "subnet not found", err)
 	}
 
 	// Dynamic IP allocation
 	allocatedIP, err := s.allocateIP(ctx, subnet)
 	if err != nil {
-		return "", "", "", errors.Wrap(errors.ResourceLimitExceeded, "failed to allocate IP in subnet", err)
+		return "", "", "", "", errors.Wrap(errors.ResourceLimitExceeded, "failed to allocate IP in subnet", err)
+	}
+
+	allocatedIPv6, err := s.allocateIPv6(ctx, subnet)
+	if err != nil {
+		return "", "", "", "", errors.Wrap(errors.ResourceLimitExceeded, "failed to allocate IPv6 address in subnet", err)
 	}
 
 	ovsPort := "veth-" + uuid.New().String()[:8]
-	return networkID, allocatedIP, ovsPort, nil
+	return networkID, allocatedIP, allocatedIPv6, ovsPort, nil
 }
 
 func (s *InstanceService) resolveVolumes(ctx context.Context, volumes []domain.VolumeAttachment) ([]string, []*domain.Volume, error) {
@@ -1521,33 +1817,59 @@ func (s *InstanceService) plumbNetwork(ctx context.Context, inst *domain.Instanc
 	}
 
 	if inst.VpcID != nil {
-		if err := s.attachToVpcBridge(ctx, *inst.VpcID, inst.OvsPort); err != nil {
+		if err := s.attachToVpcBridge(ctx, inst); err != nil {
 			return err
 		}
 	}
 
 	if inst.SubnetID != nil {
-		return s.configureVethIP(ctx, *inst.SubnetID, vethContainer, inst.PrivateIP)
+		return s.configureVethIP(ctx, *inst.SubnetID, vethContainer, inst.PrivateIP, inst.PrivateIPv6)
 	}
 	return nil
 }
 
-func (s *InstanceService) attachToVpcBridge(ctx context.Context, vpcID uuid.UUID, ovsPort string) error {
-	vpc, err := s.vpcRepo.GetByID(ctx, vpcID)
+// attachToVpcBridge connects the instance's port to its VPC bridge and only lets
+// traffic from the instance's own addresses through it.
+func (s *InstanceService) attachToVpcBridge(ctx context.Context, inst *domain.Instance) error {
+	vpc, err := s.vpcRepo.GetByID(ctx, *inst.VpcID)
 	if err != nil || vpc == nil {
 		return err
 	}
-	return s.network.AttachVethToBridge(ctx, vpc.NetworkID, ovsPort)
+	if err := s.network.AttachVethToBridge(ctx, vpc.NetworkID, inst.OvsPort); err != nil {
+		return err
+	}
+	return installAntiSpoofFlows(ctx, s.network, vpc.NetworkID, inst.OvsPort, []string{inst.PrivateIP, inst.PrivateIPv6})
+}
+
+// detachFromVpcBridge removes the source check of the instance's port. It runs
+// before the port goes away, while OVS can still resolve it by name.
+func (s *InstanceService) detachFromVpcBridge(ctx context.Context, inst *domain.Instance) {
+	if inst.OvsPort == "" || inst.VpcID == nil || s.network == nil {
+		return
+	}
+	vpc, err := s.vpcRepo.GetByID(ctx, *inst.VpcID)
+	if err != nil || vpc == nil {
+		return
+	}
+	if err := s.network.DeleteFlowRule(ctx, vpc.NetworkID, "in_port="+inst.OvsPort); err != nil {
+		s.logger.Warn("failed to remove anti-spoofing flows", "instance_id", inst.ID, "port", inst.OvsPort, "error", err)
+	}
 }
 
-func (s *InstanceService) configureVethIP(ctx context.Context, subnetID uuid.UUID, vethContainer, privateIP string) error {
+func (s *InstanceService) configureVethIP(ctx context.Context, subnetID uuid.UUID, vethContainer, privateIP, privateIPv6 string) error {
 	subnet, err := s.subnetRepo.GetByID(ctx, subnetID)
 	if err != nil || subnet == nil {
 		return err
 	}
 	_, ipNet, _ := net.ParseCIDR(subnet.CIDRBlock)
 	ones, _ := ipNet.Mask.Size()
-	return s.network.SetVethIP(ctx, vethContainer, privateIP, strconv.Itoa(ones))
+	if err := s.network.SetVethIP(ctx, vethContainer, privateIP, strconv.Itoa(ones)); err != nil {
+		return err
+	}
+	if privateIPv6 == "" {
+		return nil
+	}
+	return s.network.SetVethIP(ctx, vethContainer, privateIPv6, strconv.Itoa(domain.SubnetIPv6PrefixLength))
 }
 
 func (s *InstanceService) formatContainerName(id uuid.UUID) string {
@@ -1555,13 +1877,18 @@ func (s *InstanceService) formatContainerName(id uuid.UUID) string {
 }
 
 func (s *InstanceService) findAvailableIP(ipNet *net.IPNet, usedIPs map[string]bool) (string, error) {
+	return findAvailableIP(ipNet, usedIPs)
+}
+
+// findAvailableIP returns the lowest host address of ipNet that is not in usedIPs.
+func findAvailableIP(ipNet *net.IPNet, usedIPs map[string]bool) (string, error) {
 	ip := make(net.IP, len(ipNet.IP))
 	copy(ip, ipNet.IP)
 
-	ones, _ := ipNet.Mask.Size()
-	maxIPs := uint64(1) << (32 - ones)
-	if maxIPs > 1<<16 {
-		maxIPs = 1 << 16 // cap at 65536 to prevent unbounded iteration
+	ones, bits := ipNet.Mask.Size()
+	maxIPs := uint64(1) << 16 // cap at 65536 to prevent unbounded iteration
+	if bits-ones < 16 {
+		maxIPs = uint64(1) << (bits - ones)
 	}
 
 	for iterations := uint64(0); iterations < maxIPs; iterations++ {
@@ -1582,7 +1909,7 @@ func (s *InstanceService) findAvailableIP(ipNet *net.IPNet, usedIPs map[string]b
 			displayIP = ip4.String()
 		}
 
-		if !usedIPs[displayIP] && s.isValidHostIP(ip, ipNet) {
+		if !usedIPs[displayIP] && isValidHostIP(ip, ipNet) {
 			return displayIP, nil
 		}
 	}
@@ -1632,6 +1959,12 @@ func (s *InstanceService) UpdateInstanceMetadata(ctx context.Context, id uuid.UU
 		return err
 	}
 
+	for k := range metadata {
+		if domain.IsReservedMetadataKey(k) {
+			return errors.New(errors.InvalidInput, "metadata key "+k+" is managed by the platform")
+		}
+	}
+
 	inst, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return err
@@ -0,0 +1,157 @@
+package services
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/errors"
+)
+
+// InstanceMetadataService answers the link-local metadata endpoint. The calling
+// instance is identified by its VPC and the source address of its request;
+// every lookup after that runs in the instance's tenant.
+type InstanceMetadataService struct {
+	repo         ports.InstanceMetadataRepository
+	instanceRepo ports.InstanceRepository
+	subnetRepo   ports.SubnetRepository
+	dhcpRepo     ports.DHCPOptionsRepository
+	resolverRepo ports.VPCResolverRepository
+	identitySvc  ports.IdentityService
+	logger       *slog.Logger
+}
+
+// InstanceMetadataServiceParams holds dependencies for InstanceMetadataService.
+type InstanceMetadataServiceParams struct {
+	Repo         ports.InstanceMetadataRepository
+	InstanceRepo ports.InstanceRepository
+	SubnetRepo   ports.SubnetRepository
+	DHCPRepo     ports.DHCPOptionsRepository // optional
+	ResolverRepo ports.VPCResolverRepository // optional
+	IdentitySvc  ports.IdentityService
+	Logger       *slog.Logger
+}
+
+// NewInstanceMetadataService constructs an InstanceMetadataService with its dependencies.
+func NewInstanceMetadataService(params InstanceMetadataServiceParams) *InstanceMetadataService {
+	logger := params.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &InstanceMetadataService{
+		repo:         params.Repo,
+		instanceRepo: params.InstanceRepo,
+		subnetRepo:   params.SubnetRepo,
+		dhcpRepo:     params.DHCPRepo,
+		resolverRepo: params.ResolverRepo,
+		identitySvc:  params.IdentitySvc,
+		logger:       logger,
+	}
+}
+
+// GetMetadata returns the metadata document of the calling instance.
+func (s *InstanceMetadataService) GetMetadata(ctx context.Context, caller domain.MetadataCaller) (*domain.InstanceMetadata, error) {
+	cfg, ctx, err := s.identify(ctx, caller)
+	if err != nil {
+		return nil, err
+	}
+
+	inst, err := s.instanceRepo.GetByID(ctx, cfg.InstanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := inst.Labels
+	if tags == nil {
+		tags = map[string]string{}
+	}
+	md := &domain.InstanceMetadata{
+		InstanceID:       inst.ID,
+		Name:             inst.Name,
+		Image:            inst.Image,
+		InstanceType:     inst.InstanceType,
+		Tags:             tags,
+		ServiceAccountID: cfg.ServiceAccountID,
+		Preemption:       inst.PreemptionNotice(),
+		UserData:         cfg.UserData,
+		Network: domain.InstanceNetworkMetadata{
+			VPCID:       inst.VpcID,
+			SubnetID:    inst.SubnetID,
+			PrivateIP:   inst.PrivateIP,
+			PrivateIPv6: inst.PrivateIPv6,
+		},
+	}
+
+	if inst.SubnetID != nil {
+		if subnet, err := s.subnetRepo.GetByID(ctx, *inst.SubnetID); err == nil {
+			md.Network.SubnetCIDR = subnet.CIDRBlock
+			md.Network.Gateway = subnet.GatewayIP
+		} else {
+			s.logger.Warn("failed to load instance subnet for metadata", "instance_id", inst.ID, "error", err)
+		}
+	}
+	if inst.VpcID != nil {
+		s.applyDHCPOptions(ctx, md, inst)
+	}
+	return md, nil
+}
+
+// applyDHCPOptions fills the VPC's DHCP options into the network metadata. The
+// VPC resolver is reported as nameserver when the options set has none.
+func (s *InstanceMetadataService) applyDHCPOptions(ctx context.Context, md *domain.InstanceMetadata, inst *domain.Instance) {
+	if s.dhcpRepo != nil {
+		if opts, err := s.dhcpRepo.GetByVPC(ctx, *inst.VpcID); err == nil {
+			md.Network.DomainName = opts.DomainName
+			md.Network.DNSServers = opts.DomainNameServers
+			md.Network.NTPServers = opts.NTPServers
+		} else if !errors.Is(err, errors.NotFound) {
+			s.logger.Warn("failed to load VPC DHCP options for metadata", "vpc_id", inst.VpcID, "error", err)
+		}
+	}
+	if len(md.Network.DNSServers) == 0 && s.resolverRepo != nil {
+		if resolver, err := s.resolverRepo.GetByVPC(ctx, *inst.VpcID); err == nil &&
+			resolver.Status == domain.VPCResolverStatusActive && resolver.PrivateIP != "" {
+			md.Network.DNSServers = []string{resolver.PrivateIP}
+		}
+	}
+}
+
+// GetCredentials issues a short-lived access token for the service account
+// attached to the calling instance.
+func (s *InstanceMetadataService) GetCredentials(ctx context.Context, caller domain.MetadataCaller) (*domain.InstanceCredentials, error) {
+	cfg, ctx, err := s.identify(ctx, caller)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.ServiceAccountID == nil {
+		return nil, errors.New(errors.NotFound, "no service account is attached to this instance")
+	}
+
+	token, err := s.identitySvc.IssueServiceAccountToken(ctx, *cfg.ServiceAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Debug("issued instance credentials", "instance_id", cfg.InstanceID, "service_account_id", cfg.ServiceAccountID)
+	return &domain.InstanceCredentials{
+		ServiceAccountID: *cfg.ServiceAccountID,
+		AccessToken:      token,
+		TokenType:        "Bearer",
+		Expiration:       time.Now().Add(s.identitySvc.TokenTTL()),
+	}, nil
+}
+
+// identify resolves the calling instance and returns a context scoped to its tenant.
+func (s *InstanceMetadataService) identify(ctx context.Context, caller domain.MetadataCaller) (*domain.InstanceMetadataConfig, context.Context, error) {
+	cfg, err := s.repo.GetByAddress(ctx, caller.VPCID, caller.IP)
+	if err != nil {
+		if errors.Is(err, errors.NotFound) {
+			return nil, ctx, errors.New(errors.Forbidden, "metadata is only available to instances")
+		}
+		return nil, ctx, err
+	}
+	return cfg, appcontext.WithTenantID(ctx, cfg.TenantID), nil
+}
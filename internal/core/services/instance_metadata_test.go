@@ -0,0 +1,156 @@
+package services_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/services"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstanceMetadataService(t *testing.T) {
+	const sourceIP = "10.0.1.5"
+	tenantID := uuid.New()
+	vpcID, subnetID, saID := uuid.New(), uuid.New(), uuid.New()
+	inst := &domain.Instance{
+		ID: uuid.New(), TenantID: tenantID, Name: "web-1", Image: "nginx", VpcID: &vpcID, SubnetID: &subnetID,
+		PrivateIP: sourceIP, Labels: map[string]string{"env": "prod"},
+	}
+	caller := domain.MetadataCaller{VPCID: &vpcID, IP: sourceIP}
+
+	type mocks struct {
+		repo      *MockInstanceMetadataRepo
+		instances *MockInstanceRepo
+		subnets   *MockSubnetRepo
+		dhcp      *MockDHCPOptionsRepo
+		resolvers *MockVPCResolverRepo
+		identity  *MockIdentityService
+	}
+
+	setup := func() (*services.InstanceMetadataService, *mocks) {
+		m := &mocks{
+			repo:      new(MockInstanceMetadataRepo),
+			instances: new(MockInstanceRepo),
+			subnets:   new(MockSubnetRepo),
+			dhcp:      new(MockDHCPOptionsRepo),
+			resolvers: new(MockVPCResolverRepo),
+			identity:  new(MockIdentityService),
+		}
+		svc := services.NewInstanceMetadataService(services.InstanceMetadataServiceParams{
+			Repo:         m.repo,
+			InstanceRepo: m.instances,
+			SubnetRepo:   m.subnets,
+			DHCPRepo:     m.dhcp,
+			ResolverRepo: m.resolvers,
+			IdentitySvc:  m.identity,
+			Logger:       slog.Default(),
+		})
+		return svc, m
+	}
+
+	// inTenant matches contexts scoped to the instance's tenant.
+	inTenant := mock.MatchedBy(func(ctx context.Context) bool {
+		return appcontext.TenantIDFromContext(ctx) == tenantID
+	})
+
+	t.Run("MetadataIncludesTagsNetworkAndDHCPOptions", func(t *testing.T) {
+		svc, m := setup()
+		m.repo.On("GetByAddress", mock.Anything, &vpcID, sourceIP).Return(&domain.InstanceMetadataConfig{InstanceID: inst.ID, TenantID: tenantID, UserData: "#!/bin/sh"}, nil)
+		m.instances.On("GetByID", inTenant, inst.ID).Return(inst, nil)
+		m.subnets.On("GetByID", inTenant, subnetID).Return(&domain.Subnet{CIDRBlock: "10.0.1.0/24", GatewayIP: "10.0.1.1"}, nil)
+		m.dhcp.On("GetByVPC", inTenant, vpcID).Return(&domain.DHCPOptionsSet{DomainName: "corp.internal", DomainNameServers: []string{"10.0.0.53"}, NTPServers: []string{"10.0.0.123"}}, nil)
+
+		md, err := svc.GetMetadata(context.Background(), caller)
+		require.NoError(t, err)
+		assert.Equal(t, inst.ID, md.InstanceID)
+		assert.Equal(t, "prod", md.Tags["env"])
+		assert.Equal(t, "#!/bin/sh", md.UserData)
+		assert.Equal(t, "10.0.1.0/24", md.Network.SubnetCIDR)
+		assert.Equal(t, "corp.internal", md.Network.DomainName)
+		assert.Equal(t, []string{"10.0.0.53"}, md.Network.DNSServers)
+		assert.Equal(t, []string{"10.0.0.123"}, md.Network.NTPServers)
+		m.resolvers.AssertNotCalled(t, "GetByVPC", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ResolverIsNameserverWithoutDHCPOptions", func(t *testing.T) {
+		svc, m := setup()
+		m.repo.On("GetByAddress", mock.Anything, &vpcID, sourceIP).Return(&domain.InstanceMetadataConfig{InstanceID: inst.ID, TenantID: tenantID}, nil)
+		m.instances.On("GetByID", inTenant, inst.ID).Return(inst, nil)
+		m.subnets.On("GetByID", inTenant, subnetID).Return(&domain.Subnet{CIDRBlock: "10.0.1.0/24"}, nil)
+		m.dhcp.On("GetByVPC", inTenant, vpcID).Return(nil, errors.New(errors.NotFound, "DHCP options set not found"))
+		m.resolvers.On("GetByVPC", inTenant, vpcID).Return(&domain.VPCResolver{Status: domain.VPCResolverStatusActive, PrivateIP: "10.0.0.2"}, nil)
+
+		md, err := svc.GetMetadata(context.Background(), caller)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"10.0.0.2"}, md.Network.DNSServers)
+	})
+
+	t.Run("PreemptionNoticeForPreemptedInstance", func(t *testing.T) {
+		svc, m := setup()
+		preempted := *inst
+		preempted.VpcID, preempted.SubnetID = nil, nil
+		preempted.Metadata = map[string]string{
+			domain.MetadataCapacityType:   string(domain.CapacityPreemptible),
+			domain.MetadataPreemptionTime: "2024-01-01T12:00:30Z",
+		}
+		m.repo.On("GetByAddress", mock.Anything, (*uuid.UUID)(nil), sourceIP).Return(&domain.InstanceMetadataConfig{InstanceID: inst.ID, TenantID: tenantID}, nil)
+		m.instances.On("GetByID", inTenant, inst.ID).Return(&preempted, nil)
+
+		md, err := svc.GetMetadata(context.Background(), domain.MetadataCaller{IP: sourceIP})
+		require.NoError(t, err)
+		require.NotNil(t, md.Preemption)
+		assert.Equal(t, "terminate", md.Preemption.Action)
+		assert.Equal(t, time.Date(2024, 1, 1, 12, 0, 30, 0, time.UTC), md.Preemption.Time)
+	})
+
+	t.Run("UnknownSourceIsForbidden", func(t *testing.T) {
+		svc, m := setup()
+		m.repo.On("GetByAddress", mock.Anything, &vpcID, "192.0.2.1").Return(nil, errors.New(errors.NotFound, "no instance owns this address"))
+
+		_, err := svc.GetMetadata(context.Background(), domain.MetadataCaller{VPCID: &vpcID, IP: "192.0.2.1"})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.Forbidden))
+	})
+
+	t.Run("SameAddressInAnotherVPCIsNotTheCaller", func(t *testing.T) {
+		svc, m := setup()
+		otherVPC := uuid.New()
+		m.repo.On("GetByAddress", mock.Anything, &otherVPC, sourceIP).Return(nil, errors.New(errors.NotFound, "no instance owns this address"))
+
+		_, err := svc.GetCredentials(context.Background(), domain.MetadataCaller{VPCID: &otherVPC, IP: sourceIP})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.Forbidden))
+		m.identity.AssertNotCalled(t, "IssueServiceAccountToken", mock.Anything, mock.Anything)
+	})
+
+	t.Run("CredentialsForAttachedServiceAccount", func(t *testing.T) {
+		svc, m := setup()
+		m.repo.On("GetByAddress", mock.Anything, &vpcID, sourceIP).Return(&domain.InstanceMetadataConfig{InstanceID: inst.ID, TenantID: tenantID, ServiceAccountID: &saID}, nil)
+		m.identity.On("IssueServiceAccountToken", inTenant, saID).Return("sa-token", nil)
+
+		creds, err := svc.GetCredentials(context.Background(), caller)
+		require.NoError(t, err)
+		assert.Equal(t, "sa-token", creds.AccessToken)
+		assert.Equal(t, "Bearer", creds.TokenType)
+		assert.Equal(t, saID, creds.ServiceAccountID)
+		assert.False(t, creds.Expiration.IsZero())
+	})
+
+	t.Run("NoCredentialsWithoutServiceAccount", func(t *testing.T) {
+		svc, m := setup()
+		m.repo.On("GetByAddress", mock.Anything, &vpcID, sourceIP).Return(&domain.InstanceMetadataConfig{InstanceID: inst.ID, TenantID: tenantID}, nil)
+
+		_, err := svc.GetCredentials(context.Background(), caller)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.NotFound))
+		m.identity.AssertNotCalled(t, "IssueServiceAccountToken", mock.Anything, mock.Anything)
+	})
+}
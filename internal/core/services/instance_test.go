@@ -146,10 +146,11 @@ func setupInstanceServiceTest(t *testing.T) (*pgxpool.Pool, *services.InstanceSe
 	tenantRepo := postgres.NewTenantRepo(db)
 	userRepo := postgres.NewUserRepo(db)
 	tenantSvc := services.NewTenantService(services.TenantServiceParams{
-		Repo:     tenantRepo,
-		UserRepo: userRepo,
-		RBACSvc:  rbacSvc,
-		Logger:   slog.Default(),
+		Repo:      tenantRepo,
+		QuotaRepo: postgres.NewQuotaRepo(db),
+		UserRepo:  userRepo,
+		RBACSvc:   rbacSvc,
+		Logger:    slog.Default(),
 	})
 
 	taskQueue := &InMemoryTaskQueue{}
@@ -270,14 +271,12 @@ func TestInstanceServiceLaunchDBFailure(t *testing.T) {
 
 	defaultType := &domain.InstanceType{ID: testInstanceType, Name: "Basic 2", VCPUs: 1, MemoryMB: 128, DiskGB: 1}
 	_, _ = itRepo.Create(ctx, defaultType)
-	tenantRepo := postgres.NewTenantRepo(db)
-	_ = tenantRepo.UpdateQuota(ctx, &domain.TenantQuota{
-		TenantID:     appcontext.TenantIDFromContext(ctx),
-		MaxInstances: 10,
-		MaxVCPUs:     20,
-		MaxMemoryGB:  40,
-		MaxStorageGB: 1000,
-	})
+	quotaRepo := postgres.NewQuotaRepo(db)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	_ = quotaRepo.SetLimit(ctx, tenantID, domain.QuotaInstances, 10)
+	_ = quotaRepo.SetLimit(ctx, tenantID, domain.QuotaVCPUs, 20)
+	_ = quotaRepo.SetLimit(ctx, tenantID, domain.QuotaMemoryGB, 40)
+	_ = quotaRepo.SetLimit(ctx, tenantID, domain.QuotaStorageGB, 1000)
 
 	rbacSvc := new(MockRBACService)
 	rbacSvc.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
@@ -301,10 +300,11 @@ func TestInstanceServiceLaunchDBFailure(t *testing.T) {
 	require.NoError(t, err)
 
 	tenantSvc := services.NewTenantService(services.TenantServiceParams{
-		Repo:     postgres.NewTenantRepo(db),
-		UserRepo: postgres.NewUserRepo(db),
-		RBACSvc:  rbacSvc,
-		Logger:   slog.Default(),
+		Repo:      postgres.NewTenantRepo(db),
+		QuotaRepo: quotaRepo,
+		UserRepo:  postgres.NewUserRepo(db),
+		RBACSvc:   rbacSvc,
+		Logger:    slog.Default(),
 	})
 	svc := services.NewInstanceService(services.InstanceServiceParams{
 		Repo:             faultyRepo,
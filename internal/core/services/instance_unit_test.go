@@ -86,6 +86,7 @@ func TestInstanceService_Unit(t *testing.T) {
 	t.Run("RBACErrors", testInstanceServiceUnitRbacErrors)
 	t.Run("RepoErrors", testInstanceServiceUnitRepoErrors)
 	t.Run("ResizeInstance", testInstanceServiceResizeInstanceUnit)
+	t.Run("LaunchWithOptionsPolicyAttributes", testInstanceServiceLaunchWithOptionsPolicyAttributesUnit)
 }
 
 func testInstanceServiceLaunchInstanceUnit(t *testing.T) {
@@ -140,11 +141,12 @@ func testInstanceServiceLaunchInstanceUnit(t *testing.T) {
 		typeRepo.On("GetByID", mock.Anything, "t2.micro").Return(&domain.InstanceType{
 			ID: "t2.micro", VCPUs: 1, MemoryMB: 1024,
 		}, nil).Once()
-		tenantSvc.On("CheckQuota", mock.Anything, tenantID, "instances", 1).Return(nil).Once()
-		tenantSvc.On("CheckQuota", mock.Anything, tenantID, "vcpus", 1).Return(nil).Once()
-		tenantSvc.On("CheckQuota", mock.Anything, tenantID, "memory", 1).Return(nil).Once()
-		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, "vcpus", 1).Return(nil).Once()
-		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, "memory", 1).Return(nil).Once()
+		tenantSvc.On("CheckQuota", mock.Anything, tenantID, domain.QuotaInstances, 1).Return(nil).Once()
+		tenantSvc.On("CheckQuota", mock.Anything, tenantID, domain.QuotaVCPUs, 1).Return(nil).Once()
+		tenantSvc.On("CheckQuota", mock.Anything, tenantID, domain.QuotaMemoryGB, 1).Return(nil).Once()
+		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, domain.QuotaVCPUs, 1).Return(nil).Once()
+		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, domain.QuotaMemoryGB, 1).Return(nil).Once()
+		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, domain.QuotaInstances, 1).Return(nil).Once()
 
 		repo.On("Create", mock.Anything, mock.MatchedBy(func(i *domain.Instance) bool {
 			return i.Name == params.Name && i.UserID == userID
@@ -163,6 +165,96 @@ func testInstanceServiceLaunchInstanceUnit(t *testing.T) {
 		rbacSvc.AssertExpectations(t)
 	})
 
+	t.Run("ServiceAccountStoredForMetadata", func(t *testing.T) {
+		saRepo := new(mockServiceAccountRepository)
+		metadataRepo := new(MockInstanceMetadataRepo)
+		saSvc := services.NewInstanceService(services.InstanceServiceParams{
+			Repo:             repo,
+			InstanceTypeRepo: typeRepo,
+			Compute:          compute,
+			RBAC:             rbacSvc,
+			AuditSvc:         auditSvc,
+			TaskQueue:        taskQueue,
+			TenantSvc:        tenantSvc,
+			MetadataRepo:     metadataRepo,
+			SARepo:           saRepo,
+			Logger:           slog.Default(),
+		})
+		saID := uuid.New()
+		params := ports.LaunchParams{Name: "with-sa", Image: "alpine", InstanceType: "t2.micro", ServiceAccountID: &saID}
+
+		rbacSvc.On("Authorize", mock.Anything, userID, tenantID, domain.PermissionInstanceLaunch, "*").Return(nil).Once()
+		rbacSvc.On("Authorize", mock.Anything, userID, tenantID, domain.PermissionServiceAccountUse, saID.String()).Return(nil).Once()
+		typeRepo.On("GetByID", mock.Anything, "t2.micro").Return(&domain.InstanceType{ID: "t2.micro", VCPUs: 1, MemoryMB: 1024}, nil).Once()
+		saRepo.On("GetByID", mock.Anything, saID).Return(&domain.ServiceAccount{ID: saID, TenantID: tenantID, Enabled: true}, nil).Once()
+		tenantSvc.On("CheckQuota", mock.Anything, tenantID, mock.Anything, mock.Anything).Return(nil).Times(3)
+		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, mock.Anything, mock.Anything).Return(nil).Times(3)
+		repo.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+		metadataRepo.On("Save", mock.Anything, mock.MatchedBy(func(cfg *domain.InstanceMetadataConfig) bool {
+			return cfg.ServiceAccountID != nil && *cfg.ServiceAccountID == saID && cfg.TenantID == tenantID
+		})).Return(nil).Once()
+		taskQueue.On("Enqueue", mock.Anything, "provision_queue", mock.Anything).Return(nil).Once()
+		auditSvc.On("Log", mock.Anything, userID, "instance.launch", "instance", mock.Anything, mock.Anything).Return(nil).Once()
+
+		_, err := saSvc.LaunchInstance(ctx, params)
+		require.NoError(t, err)
+		metadataRepo.AssertExpectations(t)
+	})
+
+	t.Run("ServiceAccountFromOtherTenantRejected", func(t *testing.T) {
+		saRepo := new(mockServiceAccountRepository)
+		saSvc := services.NewInstanceService(services.InstanceServiceParams{
+			Repo:             repo,
+			InstanceTypeRepo: typeRepo,
+			Compute:          compute,
+			RBAC:             rbacSvc,
+			TenantSvc:        tenantSvc,
+			MetadataRepo:     new(MockInstanceMetadataRepo),
+			SARepo:           saRepo,
+			Logger:           slog.Default(),
+		})
+		saID := uuid.New()
+		params := ports.LaunchParams{Name: "foreign-sa", Image: "alpine", InstanceType: "t2.micro", ServiceAccountID: &saID}
+
+		rbacSvc.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Twice()
+		typeRepo.On("GetByID", mock.Anything, "t2.micro").Return(&domain.InstanceType{ID: "t2.micro", VCPUs: 1, MemoryMB: 1024}, nil).Once()
+		saRepo.On("GetByID", mock.Anything, saID).Return(&domain.ServiceAccount{ID: saID, TenantID: uuid.New(), Enabled: true}, nil).Once()
+
+		_, err := saSvc.LaunchInstance(ctx, params)
+		require.Error(t, err)
+		assert.True(t, svcerrors.Is(err, svcerrors.InvalidInput))
+		saRepo.AssertExpectations(t)
+	})
+
+	t.Run("ServiceAccountRequiresUsePermission", func(t *testing.T) {
+		saRepo := new(mockServiceAccountRepository)
+		metadataRepo := new(MockInstanceMetadataRepo)
+		saSvc := services.NewInstanceService(services.InstanceServiceParams{
+			Repo:             repo,
+			InstanceTypeRepo: typeRepo,
+			Compute:          compute,
+			RBAC:             rbacSvc,
+			TenantSvc:        tenantSvc,
+			MetadataRepo:     metadataRepo,
+			SARepo:           saRepo,
+			Logger:           slog.Default(),
+		})
+		saID := uuid.New()
+		params := ports.LaunchParams{Name: "borrowed-sa", Image: "alpine", InstanceType: "t2.micro", ServiceAccountID: &saID}
+
+		// The caller may launch instances but has no grant on the service account
+		rbacSvc.On("Authorize", mock.Anything, userID, tenantID, domain.PermissionInstanceLaunch, "*").Return(nil).Once()
+		rbacSvc.On("Authorize", mock.Anything, userID, tenantID, domain.PermissionServiceAccountUse, saID.String()).
+			Return(svcerrors.New(svcerrors.Forbidden, "permission denied")).Once()
+		typeRepo.On("GetByID", mock.Anything, "t2.micro").Return(&domain.InstanceType{ID: "t2.micro", VCPUs: 1, MemoryMB: 1024}, nil).Once()
+
+		_, err := saSvc.LaunchInstance(ctx, params)
+		require.Error(t, err)
+		assert.True(t, svcerrors.Is(err, svcerrors.Forbidden))
+		saRepo.AssertNotCalled(t, "GetByID", mock.Anything, saID)
+		metadataRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
 	t.Run("QuotaExceeded_Instances", func(t *testing.T) {
 		params := ports.LaunchParams{
 			Name:         "no-quota",
@@ -174,7 +266,7 @@ func testInstanceServiceLaunchInstanceUnit(t *testing.T) {
 		typeRepo.On("GetByID", mock.Anything, "t2.micro").Return(&domain.InstanceType{
 			ID: "t2.micro", VCPUs: 1, MemoryMB: 1024,
 		}, nil).Once()
-		tenantSvc.On("CheckQuota", mock.Anything, tenantID, "instances", 1).Return(fmt.Errorf("quota exceeded")).Once()
+		tenantSvc.On("CheckQuota", mock.Anything, tenantID, domain.QuotaInstances, 1).Return(fmt.Errorf("quota exceeded")).Once()
 
 		_, err := svc.LaunchInstance(ctx, params)
 		require.Error(t, err)
@@ -193,8 +285,8 @@ func testInstanceServiceLaunchInstanceUnit(t *testing.T) {
 		typeRepo.On("GetByID", mock.Anything, "t2.large").Return(&domain.InstanceType{
 			ID: "t2.large", VCPUs: 4, MemoryMB: 4096,
 		}, nil).Once()
-		tenantSvc.On("CheckQuota", mock.Anything, tenantID, "instances", 1).Return(nil).Once()
-		tenantSvc.On("CheckQuota", mock.Anything, tenantID, "vcpus", 4).Return(fmt.Errorf("quota exceeded")).Once()
+		tenantSvc.On("CheckQuota", mock.Anything, tenantID, domain.QuotaInstances, 1).Return(nil).Once()
+		tenantSvc.On("CheckQuota", mock.Anything, tenantID, domain.QuotaVCPUs, 4).Return(fmt.Errorf("quota exceeded")).Once()
 
 		_, err := svc.LaunchInstance(ctx, params)
 		require.Error(t, err)
@@ -212,9 +304,9 @@ func testInstanceServiceLaunchInstanceUnit(t *testing.T) {
 		typeRepo.On("GetByID", mock.Anything, "t2.large").Return(&domain.InstanceType{
 			ID: "t2.large", VCPUs: 4, MemoryMB: 4096,
 		}, nil).Once()
-		tenantSvc.On("CheckQuota", mock.Anything, tenantID, "instances", 1).Return(nil).Once()
-		tenantSvc.On("CheckQuota", mock.Anything, tenantID, "vcpus", 4).Return(nil).Once()
-		tenantSvc.On("CheckQuota", mock.Anything, tenantID, "memory", 4).Return(fmt.Errorf("quota exceeded")).Once()
+		tenantSvc.On("CheckQuota", mock.Anything, tenantID, domain.QuotaInstances, 1).Return(nil).Once()
+		tenantSvc.On("CheckQuota", mock.Anything, tenantID, domain.QuotaVCPUs, 4).Return(nil).Once()
+		tenantSvc.On("CheckQuota", mock.Anything, tenantID, domain.QuotaMemoryGB, 4).Return(fmt.Errorf("quota exceeded")).Once()
 
 		_, err := svc.LaunchInstance(ctx, params)
 		require.Error(t, err)
@@ -297,22 +389,28 @@ func testInstanceServiceLifecycleUnit(t *testing.T) {
 	})
 
 	t.Run("TerminateInstance", func(t *testing.T) {
+		vpcID := uuid.New()
 		inst := &domain.Instance{
 			ID: instanceID, UserID: userID, TenantID: tenantID, Status: domain.StatusRunning, ContainerID: "cid-1",
-			InstanceType: "t2.micro",
+			InstanceType: "t2.micro", VpcID: &vpcID, OvsPort: "veth-1",
 		}
 		typeRepo.On("GetByID", mock.Anything, "t2.micro").Return(&domain.InstanceType{VCPUs: 1, MemoryMB: 1024}, nil).Maybe()
 		repo.On("GetByName", mock.Anything, instanceID.String()).Return(nil, fmt.Errorf("not found")).Maybe()
 		repo.On("GetByID", mock.Anything, instanceID).Return(inst, nil).Once()
 		rbacSvc.On("Authorize", mock.Anything, userID, tenantID, domain.PermissionInstanceTerminate, instanceID.String()).Return(nil).Once()
-		compute.On("DeleteInstance", mock.Anything, "cid-1").Return(nil).Once()
+		vpcRepo.On("GetByID", mock.Anything, vpcID).Return(&domain.VPC{ID: vpcID, NetworkID: "br-vpc-1"}, nil)
+		// The source check goes while OVS can still resolve the port by name.
+		network.On("DeleteFlowRule", mock.Anything, "br-vpc-1", "in_port=veth-1").Return(nil).Once()
+		compute.On("DeleteInstance", mock.Anything, "cid-1").Run(func(mock.Arguments) {
+			network.AssertCalled(t, "DeleteFlowRule", mock.Anything, "br-vpc-1", "in_port=veth-1")
+		}).Return(nil).Once()
 		compute.On("Type").Return("docker").Maybe()
 		volRepo.On("ListByInstanceID", mock.Anything, instanceID).Return([]*domain.Volume{}, nil).Once()
 		repo.On("Delete", mock.Anything, instanceID).Return(nil).Once()
 
-		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, "instances", 1).Return(nil).Once()
-		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, "vcpus", 1).Return(nil).Once()
-		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, "memory", 1).Return(nil).Once()
+		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, domain.QuotaInstances, 1).Return(nil).Once()
+		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, domain.QuotaVCPUs, 1).Return(nil).Once()
+		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, domain.QuotaMemoryGB, 1).Return(nil).Once()
 
 		dnsSvc.On("UnregisterInstance", mock.Anything, instanceID).Return(nil).Maybe()
 		eventSvc.On("RecordEvent", mock.Anything, "INSTANCE_TERMINATE", instanceID.String(), "INSTANCE", mock.Anything).Return(nil).Once()
@@ -434,6 +532,8 @@ func testInstanceServiceProvisionFinalize(t *testing.T) {
 		repo.On("ListBySubnet", mock.Anything, subnetID).Return([]*domain.Instance{}, nil).Maybe()
 		network.On("CreateVethPair", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
 		network.On("AttachVethToBridge", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+		network.On("DeleteFlowRule", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+		network.On("AddFlowRule", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
 		network.On("SetVethIP", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
 
 		// Mock volume resolution (no volumes)
@@ -468,6 +568,198 @@ func testInstanceServiceProvisionFinalize(t *testing.T) {
 		repo.AssertExpectations(t)
 	})
 
+	t.Run("Finalize_DualStackSubnet", func(t *testing.T) {
+		repo := new(MockInstanceRepo)
+		vpcRepo := new(MockVpcRepo)
+		subnetRepo := new(MockSubnetRepo)
+		volRepo := new(MockVolumeRepo)
+		typeRepo := new(MockInstanceTypeRepo)
+		compute := new(MockComputeBackend)
+		network := new(MockNetworkBackend)
+		eventSvc := new(MockEventService)
+		auditSvc := new(MockAuditService)
+		dnsSvc := new(MockDNSService)
+
+		svc := services.NewInstanceService(services.InstanceServiceParams{
+			Repo:             repo,
+			VpcRepo:          vpcRepo,
+			SubnetRepo:       subnetRepo,
+			VolumeRepo:       volRepo,
+			InstanceTypeRepo: typeRepo,
+			Compute:          compute,
+			Network:          network,
+			EventSvc:         eventSvc,
+			AuditSvc:         auditSvc,
+			DNSSvc:           dnsSvc,
+			DockerNetwork:    "bridge",
+			Logger:           slog.Default(),
+		})
+
+		userID := uuid.New()
+		tenantID := uuid.New()
+		ctx := appcontext.WithTenantID(appcontext.WithUserID(context.Background(), userID), tenantID)
+
+		vpcID := uuid.New()
+		subnetID := uuid.New()
+		inst := &domain.Instance{
+			ID: uuid.New(), UserID: userID, TenantID: tenantID, Name: "web", Image: "alpine", InstanceType: "t2.micro",
+			VpcID: &vpcID, SubnetID: &subnetID, Status: domain.StatusStarting,
+		}
+
+		repo.On("GetByID", mock.Anything, inst.ID).Return(inst, nil).Once()
+		compute.On("Type").Return("docker").Maybe()
+		vpcRepo.On("GetByID", mock.Anything, vpcID).Return(&domain.VPC{ID: vpcID, NetworkID: "net1"}, nil).Maybe()
+		subnetRepo.On("GetByID", mock.Anything, subnetID).Return(&domain.Subnet{
+			ID: subnetID, CIDRBlock: "10.0.0.0/24", GatewayIP: "10.0.0.1", IPv6CIDRBlock: "fd00:1:2:3::/64",
+		}, nil).Maybe()
+		repo.On("ListBySubnet", mock.Anything, subnetID).Return([]*domain.Instance{
+			{PrivateIP: "10.0.0.2", PrivateIPv6: "fd00:1:2:3::2"},
+		}, nil).Maybe()
+		network.On("CreateVethPair", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+		network.On("AttachVethToBridge", mock.Anything, "net1", mock.Anything).Return(nil).Once()
+		network.On("DeleteFlowRule", mock.Anything, "net1", mock.MatchedBy(func(match string) bool {
+			return strings.HasPrefix(match, "in_port=veth-")
+		})).Return(nil).Once()
+		var spoofFlows []string
+		network.On("AddFlowRule", mock.Anything, "net1", mock.Anything).Run(func(args mock.Arguments) {
+			flow := args.Get(2).(ports.FlowRule)
+			spoofFlows = append(spoofFlows, fmt.Sprintf("%d %s %s", flow.Priority, flow.Match, flow.Actions))
+		}).Return(nil)
+		network.On("SetVethIP", mock.Anything, mock.Anything, "10.0.0.3", "24").Return(nil).Once()
+		network.On("SetVethIP", mock.Anything, mock.Anything, "fd00:1:2:3::3", "64").Return(nil).Once()
+		volRepo.On("ListByInstanceID", mock.Anything, inst.ID).Return([]*domain.Volume{}, nil).Once()
+		typeRepo.On("GetByID", mock.Anything, "t2.micro").Return(&domain.InstanceType{ID: "t2.micro", VCPUs: 1, MemoryMB: 1024, DiskGB: 10}, nil).Once()
+		compute.On("LaunchInstanceWithOptions", mock.Anything, mock.Anything).Return("container-123", []string{}, nil).Once()
+		dnsSvc.On("RegisterInstance", mock.Anything, inst, "10.0.0.3").Return(nil).Once()
+		dnsSvc.On("RegisterInstance", mock.Anything, inst, "fd00:1:2:3::3").Return(nil).Once()
+		repo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+		eventSvc.On("RecordEvent", mock.Anything, "INSTANCE_LAUNCH", mock.Anything, "INSTANCE", mock.Anything).Return(nil).Once()
+		auditSvc.On("Log", mock.Anything, userID, "instance.launch", "instance", mock.Anything, mock.Anything).Return(nil).Once()
+
+		require.NoError(t, svc.Provision(ctx, domain.ProvisionJob{InstanceID: inst.ID}))
+		assert.Equal(t, "10.0.0.3", inst.PrivateIP)
+		assert.Equal(t, "fd00:1:2:3::3", inst.PrivateIPv6)
+		network.AssertExpectations(t)
+		dnsSvc.AssertExpectations(t)
+
+		// Only the allocated addresses may leave the instance's port.
+		port := "in_port=" + inst.OvsPort
+		pass := "load:1->NXM_NX_REG0[0],resubmit(,0)"
+		assert.Contains(t, spoofFlows, "65301 "+port+",ip,nw_src=10.0.0.3,reg0=0/0x1 "+pass)
+		assert.Contains(t, spoofFlows, "65301 "+port+",arp,arp_spa=10.0.0.3,reg0=0/0x1 "+pass)
+		assert.Contains(t, spoofFlows, "65301 "+port+",ipv6,ipv6_src=fd00:1:2:3::3,reg0=0/0x1 "+pass)
+		assert.Contains(t, spoofFlows, "65300 "+port+",ip,reg0=0/0x1 drop")
+		assert.Contains(t, spoofFlows, "65300 "+port+",ipv6,reg0=0/0x1 drop")
+	})
+
+	t.Run("VPCResolverInjectedAsNameserver", func(t *testing.T) {
+		repo := new(MockInstanceRepo)
+		vpcRepo := new(MockVpcRepo)
+		subnetRepo := new(MockSubnetRepo)
+		volRepo := new(MockVolumeRepo)
+		typeRepo := new(MockInstanceTypeRepo)
+		compute := new(MockComputeBackend)
+		network := new(MockNetworkBackend)
+		eventSvc := new(MockEventService)
+		auditSvc := new(MockAuditService)
+		resolverRepo := new(MockVPCResolverRepo)
+
+		svc := services.NewInstanceService(services.InstanceServiceParams{
+			Repo:             repo,
+			VpcRepo:          vpcRepo,
+			SubnetRepo:       subnetRepo,
+			VolumeRepo:       volRepo,
+			InstanceTypeRepo: typeRepo,
+			Compute:          compute,
+			Network:          network,
+			EventSvc:         eventSvc,
+			AuditSvc:         auditSvc,
+			ResolverRepo:     resolverRepo,
+			Logger:           slog.Default(),
+		})
+
+		userID := uuid.New()
+		ctx := appcontext.WithTenantID(appcontext.WithUserID(context.Background(), userID), uuid.New())
+
+		vpcID := uuid.New()
+		inst := &domain.Instance{
+			ID: uuid.New(), UserID: userID, Name: "web", Image: "alpine", InstanceType: "t2.micro",
+			VpcID: &vpcID, Status: domain.StatusStarting,
+		}
+
+		repo.On("GetByID", mock.Anything, inst.ID).Return(inst, nil).Once()
+		compute.On("Type").Return("docker").Maybe()
+		vpcRepo.On("GetByID", mock.Anything, vpcID).Return(&domain.VPC{ID: vpcID, NetworkID: "net1"}, nil).Maybe()
+		volRepo.On("ListByInstanceID", mock.Anything, inst.ID).Return([]*domain.Volume{}, nil).Once()
+		typeRepo.On("GetByID", mock.Anything, "t2.micro").Return(&domain.InstanceType{ID: "t2.micro", VCPUs: 1, MemoryMB: 1024, DiskGB: 10}, nil).Once()
+		resolverRepo.On("GetByVPC", mock.Anything, vpcID).Return(&domain.VPCResolver{VPCID: vpcID, Status: domain.VPCResolverStatusActive, PrivateIP: "10.0.0.2"}, nil).Once()
+		compute.On("LaunchInstanceWithOptions", mock.Anything, mock.MatchedBy(func(opts ports.CreateInstanceOptions) bool {
+			return len(opts.DNSServers) == 1 && opts.DNSServers[0] == "10.0.0.2"
+		})).Return("container-123", []string{}, nil).Once()
+		compute.On("GetInstanceIP", mock.Anything, "container-123").Return("10.0.0.5", nil).Maybe()
+		repo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+		eventSvc.On("RecordEvent", mock.Anything, "INSTANCE_LAUNCH", mock.Anything, "INSTANCE", mock.Anything).Return(nil).Once()
+		auditSvc.On("Log", mock.Anything, userID, "instance.launch", "instance", mock.Anything, mock.Anything).Return(nil).Once()
+
+		require.NoError(t, svc.Provision(ctx, domain.ProvisionJob{InstanceID: inst.ID}))
+		compute.AssertExpectations(t)
+	})
+
+	t.Run("DHCPOptionsOverrideVPCResolver", func(t *testing.T) {
+		repo := new(MockInstanceRepo)
+		vpcRepo := new(MockVpcRepo)
+		volRepo := new(MockVolumeRepo)
+		typeRepo := new(MockInstanceTypeRepo)
+		compute := new(MockComputeBackend)
+		eventSvc := new(MockEventService)
+		auditSvc := new(MockAuditService)
+		resolverRepo := new(MockVPCResolverRepo)
+		dhcpRepo := new(MockDHCPOptionsRepo)
+
+		svc := services.NewInstanceService(services.InstanceServiceParams{
+			Repo:             repo,
+			VpcRepo:          vpcRepo,
+			SubnetRepo:       new(MockSubnetRepo),
+			VolumeRepo:       volRepo,
+			InstanceTypeRepo: typeRepo,
+			Compute:          compute,
+			Network:          new(MockNetworkBackend),
+			EventSvc:         eventSvc,
+			AuditSvc:         auditSvc,
+			ResolverRepo:     resolverRepo,
+			DHCPRepo:         dhcpRepo,
+			Logger:           slog.Default(),
+		})
+
+		userID := uuid.New()
+		ctx := appcontext.WithTenantID(appcontext.WithUserID(context.Background(), userID), uuid.New())
+
+		vpcID := uuid.New()
+		inst := &domain.Instance{
+			ID: uuid.New(), UserID: userID, Name: "web", Image: "alpine", InstanceType: "t2.micro",
+			VpcID: &vpcID, Status: domain.StatusStarting,
+		}
+
+		repo.On("GetByID", mock.Anything, inst.ID).Return(inst, nil).Once()
+		compute.On("Type").Return("docker").Maybe()
+		vpcRepo.On("GetByID", mock.Anything, vpcID).Return(&domain.VPC{ID: vpcID, NetworkID: "net1"}, nil).Maybe()
+		volRepo.On("ListByInstanceID", mock.Anything, inst.ID).Return([]*domain.Volume{}, nil).Once()
+		typeRepo.On("GetByID", mock.Anything, "t2.micro").Return(&domain.InstanceType{ID: "t2.micro", VCPUs: 1, MemoryMB: 1024, DiskGB: 10}, nil).Once()
+		dhcpRepo.On("GetByVPC", mock.Anything, vpcID).Return(&domain.DHCPOptionsSet{DomainName: "corp.internal", DomainNameServers: []string{"10.50.0.2", "10.50.0.3"}}, nil).Once()
+		compute.On("LaunchInstanceWithOptions", mock.Anything, mock.MatchedBy(func(opts ports.CreateInstanceOptions) bool {
+			return assert.ObjectsAreEqual([]string{"10.50.0.2", "10.50.0.3"}, opts.DNSServers) &&
+				assert.ObjectsAreEqual([]string{"corp.internal"}, opts.DNSSearch)
+		})).Return("container-123", []string{}, nil).Once()
+		compute.On("GetInstanceIP", mock.Anything, "container-123").Return("10.0.0.5", nil).Maybe()
+		repo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+		eventSvc.On("RecordEvent", mock.Anything, "INSTANCE_LAUNCH", mock.Anything, "INSTANCE", mock.Anything).Return(nil).Once()
+		auditSvc.On("Log", mock.Anything, userID, "instance.launch", "instance", mock.Anything, mock.Anything).Return(nil).Once()
+
+		require.NoError(t, svc.Provision(ctx, domain.ProvisionJob{InstanceID: inst.ID}))
+		compute.AssertExpectations(t)
+		resolverRepo.AssertNotCalled(t, "GetByVPC", mock.Anything, mock.Anything)
+	})
+
 	t.Run("Finalize_RepoUpdateFails", func(t *testing.T) {
 		repo := new(MockInstanceRepo)
 		vpcRepo := new(MockVpcRepo)
@@ -523,6 +815,8 @@ func testInstanceServiceProvisionFinalize(t *testing.T) {
 		repo.On("ListBySubnet", mock.Anything, mock.Anything).Return([]*domain.Instance{}, nil).Maybe()
 		network.On("CreateVethPair", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
 		network.On("AttachVethToBridge", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+		network.On("DeleteFlowRule", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+		network.On("AddFlowRule", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
 		network.On("SetVethIP", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
 		volRepo.On("ListByInstanceID", mock.Anything, mock.Anything).Return([]*domain.Volume{}, nil).Maybe()
 		typeRepo.On("GetByID", mock.Anything, mock.Anything).Return(&domain.InstanceType{ID: "t2.micro", VCPUs: 1, MemoryMB: 1024, DiskGB: 10}, nil).Maybe()
@@ -598,6 +892,8 @@ func testInstanceServiceProvisionFinalize(t *testing.T) {
 		repo.On("ListBySubnet", mock.Anything, mock.Anything).Return([]*domain.Instance{}, nil).Maybe()
 		network.On("CreateVethPair", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
 		network.On("AttachVethToBridge", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+		network.On("DeleteFlowRule", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+		network.On("AddFlowRule", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
 		network.On("SetVethIP", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
 		volRepo.On("ListByInstanceID", mock.Anything, mock.Anything).Return([]*domain.Volume{}, nil).Maybe()
 		typeRepo.On("GetByID", mock.Anything, mock.Anything).Return(&domain.InstanceType{ID: "t2.micro", VCPUs: 1, MemoryMB: 1024, DiskGB: 10}, nil).Maybe()
@@ -773,9 +1069,9 @@ func testInstanceServiceTerminateUnit(t *testing.T) {
 		})).Return(nil).Times(2)
 		repo.On("Delete", mock.Anything, instanceID).Return(nil).Once()
 		typeRepo.On("GetByID", mock.Anything, "t2.micro").Return(&domain.InstanceType{VCPUs: 1, MemoryMB: 1024}, nil).Once()
-		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, "instances", 1).Return(nil).Once()
-		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, "vcpus", 1).Return(nil).Once()
-		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, "memory", 1).Return(nil).Once()
+		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, domain.QuotaInstances, 1).Return(nil).Once()
+		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, domain.QuotaVCPUs, 1).Return(nil).Once()
+		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, domain.QuotaMemoryGB, 1).Return(nil).Once()
 		eventSvc.On("RecordEvent", mock.Anything, "INSTANCE_TERMINATE", instanceID.String(), "INSTANCE", mock.Anything).Return(nil).Once()
 		auditSvc.On("Log", mock.Anything, userID, "instance.terminate", "instance", instanceID.String(), mock.Anything).Return(nil).Once()
 		dnsSvc.On("UnregisterInstance", mock.Anything, instanceID).Return(nil).Maybe()
@@ -928,9 +1224,9 @@ func testInstanceServiceTerminateUnit(t *testing.T) {
 		volRepo.On("ListByInstanceID", mock.Anything, instanceID).Return([]*domain.Volume{}, nil).Once()
 		repo.On("Delete", mock.Anything, instanceID).Return(nil).Once()
 		typeRepo.On("GetByID", mock.Anything, "t2.micro").Return(&domain.InstanceType{VCPUs: 1, MemoryMB: 1024}, nil).Once()
-		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, "instances", 1).Return(nil).Once()
-		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, "vcpus", 1).Return(nil).Once()
-		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, "memory", 1).Return(nil).Once()
+		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, domain.QuotaInstances, 1).Return(nil).Once()
+		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, domain.QuotaVCPUs, 1).Return(nil).Once()
+		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, domain.QuotaMemoryGB, 1).Return(nil).Once()
 		eventSvc.On("RecordEvent", mock.Anything, "INSTANCE_TERMINATE", instanceID.String(), "INSTANCE", mock.Anything).Return(nil).Once()
 		auditSvc.On("Log", mock.Anything, userID, "instance.terminate", "instance", instanceID.String(), mock.Anything).Return(nil).Once()
 
@@ -988,9 +1284,9 @@ func testInstanceServiceVolumeReleaseUnit(t *testing.T) {
 		volRepo.On("ListByInstanceID", mock.Anything, instanceID).Return(nil, fmt.Errorf("db error")).Once()
 		repo.On("Delete", mock.Anything, instanceID).Return(nil).Once()
 		typeRepo.On("GetByID", mock.Anything, "t2.micro").Return(&domain.InstanceType{VCPUs: 1, MemoryMB: 1024}, nil).Once()
-		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, "instances", 1).Return(nil).Once()
-		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, "vcpus", 1).Return(nil).Once()
-		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, "memory", 1).Return(nil).Once()
+		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, domain.QuotaInstances, 1).Return(nil).Once()
+		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, domain.QuotaVCPUs, 1).Return(nil).Once()
+		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, domain.QuotaMemoryGB, 1).Return(nil).Once()
 		eventSvc.On("RecordEvent", mock.Anything, "INSTANCE_TERMINATE", instanceID.String(), "INSTANCE", mock.Anything).Return(nil).Once()
 		auditSvc.On("Log", mock.Anything, userID, "instance.terminate", "instance", instanceID.String(), mock.Anything).Return(nil).Once()
 
@@ -1054,9 +1350,9 @@ func testInstanceServiceVolumeReleaseUnit(t *testing.T) {
 		})).Return(fmt.Errorf("update error")).Once()
 		repo.On("Delete", mock.Anything, instanceID).Return(nil).Once()
 		typeRepo.On("GetByID", mock.Anything, "t2.micro").Return(&domain.InstanceType{VCPUs: 1, MemoryMB: 1024}, nil).Once()
-		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, "instances", 1).Return(nil).Once()
-		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, "vcpus", 1).Return(nil).Once()
-		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, "memory", 1).Return(nil).Once()
+		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, domain.QuotaInstances, 1).Return(nil).Once()
+		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, domain.QuotaVCPUs, 1).Return(nil).Once()
+		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, domain.QuotaMemoryGB, 1).Return(nil).Once()
 		eventSvc.On("RecordEvent", mock.Anything, "INSTANCE_TERMINATE", instanceID.String(), "INSTANCE", mock.Anything).Return(nil).Once()
 		auditSvc.On("Log", mock.Anything, userID, "instance.terminate", "instance", instanceID.String(), mock.Anything).Return(nil).Once()
 
@@ -1367,6 +1663,7 @@ func testInstanceServiceUnitRbacErrors(t *testing.T) {
 			permission: domain.PermissionInstanceLaunch,
 			resourceID: "*",
 			invoke: func() error {
+				typeRepo.On("GetByID", mock.Anything, "t2.micro").Return(&domain.InstanceType{ID: "t2.micro", VCPUs: 1, MemoryMB: 1024}, nil).Once()
 				_, err := svc.LaunchInstance(ctx, ports.LaunchParams{Name: "test", Image: "alpine", InstanceType: "t2.micro"})
 				return err
 			},
@@ -1596,9 +1893,9 @@ func testInstanceServiceUnitRepoErrors(t *testing.T) {
 		compute.On("DeleteInstance", mock.Anything, mock.Anything).Return(nil).Once()
 		volRepo.On("ListByInstanceID", mock.Anything, instID).Return([]*domain.Volume{}, nil).Once()
 		typeRepo.On("GetByID", mock.Anything, inst.InstanceType).Return(&domain.InstanceType{ID: inst.InstanceType, VCPUs: 1, MemoryMB: 1024}, nil).Once()
-		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, "instances", 1).Return(nil).Once()
-		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, "vcpus", 1).Return(nil).Once()
-		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, "memory", 1).Return(nil).Once()
+		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, domain.QuotaInstances, 1).Return(nil).Once()
+		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, domain.QuotaVCPUs, 1).Return(nil).Once()
+		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, domain.QuotaMemoryGB, 1).Return(nil).Once()
 		repo.On("Delete", mock.Anything, instID).Return(fmt.Errorf("db error")).Once()
 		compute.On("Type").Return("docker").Maybe()
 		dnsSvc.On("UnregisterInstance", mock.Anything, instID).Return(nil).Maybe()
@@ -1612,13 +1909,14 @@ func testInstanceServiceUnitRepoErrors(t *testing.T) {
 		sshKeyID := uuid.New()
 		params := ports.LaunchParams{Name: "test", Image: "alpine", InstanceType: "t2.micro", SSHKeyID: &sshKeyID}
 		typeRepo.On("GetByID", mock.Anything, "t2.micro").Return(&domain.InstanceType{ID: "t2.micro", VCPUs: 1, MemoryMB: 1024}, nil).Once()
-		tenantSvc.On("CheckQuota", mock.Anything, tenantID, "instances", 1).Return(nil).Once()
-		tenantSvc.On("CheckQuota", mock.Anything, tenantID, "vcpus", 1).Return(nil).Once()
-		tenantSvc.On("CheckQuota", mock.Anything, tenantID, "memory", 1).Return(nil).Once()
-		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, "vcpus", 1).Return(nil).Maybe()
-		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, "memory", 1).Return(nil).Maybe()
-		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, "vcpus", 1).Return(nil).Maybe()
-		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, "memory", 1).Return(nil).Maybe()
+		tenantSvc.On("CheckQuota", mock.Anything, tenantID, domain.QuotaInstances, 1).Return(nil).Once()
+		tenantSvc.On("CheckQuota", mock.Anything, tenantID, domain.QuotaVCPUs, 1).Return(nil).Once()
+		tenantSvc.On("CheckQuota", mock.Anything, tenantID, domain.QuotaMemoryGB, 1).Return(nil).Once()
+		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, domain.QuotaVCPUs, 1).Return(nil).Maybe()
+		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, domain.QuotaMemoryGB, 1).Return(nil).Maybe()
+		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, domain.QuotaInstances, 1).Return(nil).Maybe()
+		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, domain.QuotaVCPUs, 1).Return(nil).Maybe()
+		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, domain.QuotaMemoryGB, 1).Return(nil).Maybe()
 		sshKeySvc.On("GetKey", mock.Anything, sshKeyID).Return(nil, svcerrors.New(svcerrors.NotFound, "ssh key not found")).Once()
 
 		_, err := svc.LaunchInstance(ctx, params)
@@ -1629,19 +1927,54 @@ func testInstanceServiceUnitRepoErrors(t *testing.T) {
 	t.Run("LaunchInstance_PortValidationError", func(t *testing.T) {
 		params := ports.LaunchParams{Name: "test", Image: "alpine", InstanceType: "t2.micro", Ports: "invalid-port"}
 		typeRepo.On("GetByID", mock.Anything, "t2.micro").Return(&domain.InstanceType{ID: "t2.micro", VCPUs: 1, MemoryMB: 1024}, nil).Once()
-		tenantSvc.On("CheckQuota", mock.Anything, tenantID, "instances", 1).Return(nil).Once()
-		tenantSvc.On("CheckQuota", mock.Anything, tenantID, "vcpus", 1).Return(nil).Once()
-		tenantSvc.On("CheckQuota", mock.Anything, tenantID, "memory", 1).Return(nil).Once()
-		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, "vcpus", 1).Return(nil).Maybe()
-		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, "memory", 1).Return(nil).Maybe()
-		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, "vcpus", 1).Return(nil).Maybe()
-		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, "memory", 1).Return(nil).Maybe()
+		tenantSvc.On("CheckQuota", mock.Anything, tenantID, domain.QuotaInstances, 1).Return(nil).Once()
+		tenantSvc.On("CheckQuota", mock.Anything, tenantID, domain.QuotaVCPUs, 1).Return(nil).Once()
+		tenantSvc.On("CheckQuota", mock.Anything, tenantID, domain.QuotaMemoryGB, 1).Return(nil).Once()
+		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, domain.QuotaVCPUs, 1).Return(nil).Maybe()
+		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, domain.QuotaMemoryGB, 1).Return(nil).Maybe()
+		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, domain.QuotaInstances, 1).Return(nil).Maybe()
+		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, domain.QuotaVCPUs, 1).Return(nil).Maybe()
+		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, domain.QuotaMemoryGB, 1).Return(nil).Maybe()
 
 		_, err := svc.LaunchInstance(ctx, params)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "port format must be host:container")
 	})
 
+	t.Run("LaunchInstance_ReservedMetadataKey", func(t *testing.T) {
+		params := ports.LaunchParams{Name: "test", Image: "alpine", InstanceType: "t2.micro",
+			Metadata: map[string]string{domain.MetadataCapacityType: string(domain.CapacityPreemptible)}}
+		typeRepo.On("GetByID", mock.Anything, "t2.micro").Return(&domain.InstanceType{ID: "t2.micro", VCPUs: 1, MemoryMB: 1024}, nil).Once()
+
+		_, err := svc.LaunchInstance(ctx, params)
+		require.Error(t, err)
+		assert.True(t, svcerrors.Is(err, svcerrors.InvalidInput))
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("LaunchInstanceWithOptions_ReservedMetadataKey", func(t *testing.T) {
+		opts := ports.CreateInstanceOptions{Name: "node", ImageName: "ubuntu:22.04",
+			Metadata: map[string]string{domain.MetadataPreemptionTime: "2024-01-01T00:00:00Z"}}
+
+		_, err := svc.LaunchInstanceWithOptions(ctx, opts)
+		require.Error(t, err)
+		assert.True(t, svcerrors.Is(err, svcerrors.InvalidInput))
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("LaunchInstanceWithOptions_ReservedMetadataKeyFromPlatform", func(t *testing.T) {
+		opts := ports.CreateInstanceOptions{Name: "node", ImageName: "ubuntu:22.04",
+			Metadata: map[string]string{domain.MetadataCapacityType: string(domain.CapacityPreemptible)}}
+		repo.On("Create", mock.Anything, mock.MatchedBy(func(i *domain.Instance) bool {
+			return i.IsPreemptible()
+		})).Return(nil).Once()
+		taskQueue.On("Enqueue", mock.Anything, "provision_queue", mock.Anything).Return(nil).Once()
+
+		got, err := svc.LaunchInstanceWithOptions(appcontext.WithReservedMetadata(ctx), opts)
+		require.NoError(t, err)
+		assert.True(t, got.IsPreemptible())
+	})
+
 	t.Run("GetInstanceLogs_NotFound", func(t *testing.T) {
 		repo.On("GetByName", mock.Anything, mock.Anything).Return(nil, svcerrors.New(svcerrors.NotFound, "not found")).Once()
 		repo.On("GetByID", mock.Anything, mock.Anything).Return(nil, svcerrors.New(svcerrors.NotFound, "not found")).Once()
@@ -1710,6 +2043,12 @@ func testInstanceServiceUnitRepoErrors(t *testing.T) {
 		err := svc.UpdateInstanceMetadata(ctx, instID, nil, nil)
 		require.Error(t, err)
 	})
+
+	t.Run("UpdateInstanceMetadata_ReservedKey", func(t *testing.T) {
+		err := svc.UpdateInstanceMetadata(ctx, instID, map[string]string{domain.MetadataPreemptionTime: ""}, nil)
+		require.Error(t, err)
+		assert.True(t, svcerrors.Is(err, svcerrors.InvalidInput))
+	})
 }
 
 func testInstanceServiceResizeInstanceUnit(t *testing.T) {
@@ -1758,11 +2097,11 @@ func testInstanceServiceResizeInstanceUnit(t *testing.T) {
 		repo.On("GetByName", mock.Anything, "test-inst").Return(inst, nil).Once()
 		typeRepo.On("GetByID", mock.Anything, "basic-2").Return(oldType, nil).Once()
 		typeRepo.On("GetByID", mock.Anything, "basic-4").Return(newType, nil).Once()
-		tenantSvc.On("CheckQuota", mock.Anything, tenantID, "vcpus", 2).Return(nil).Once()
-		tenantSvc.On("CheckQuota", mock.Anything, tenantID, "memory", 2).Return(nil).Once()
+		tenantSvc.On("CheckQuota", mock.Anything, tenantID, domain.QuotaVCPUs, 2).Return(nil).Once()
+		tenantSvc.On("CheckQuota", mock.Anything, tenantID, domain.QuotaMemoryGB, 2).Return(nil).Once()
 		compute.On("ResizeInstance", mock.Anything, "cid-1", int64(4*1e9), int64(4096*1024*1024)).Return(nil).Once()
-		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, "vcpus", 2).Return(nil).Once()
-		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, "memory", 2).Return(nil).Once()
+		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, domain.QuotaVCPUs, 2).Return(nil).Once()
+		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, domain.QuotaMemoryGB, 2).Return(nil).Once()
 		repo.On("Update", mock.Anything, mock.MatchedBy(func(i *domain.Instance) bool {
 			return i.InstanceType == "basic-4"
 		})).Return(nil).Once()
@@ -1820,8 +2159,8 @@ func testInstanceServiceResizeInstanceUnit(t *testing.T) {
 		typeRepo.On("GetByID", mock.Anything, "basic-4").Return(oldType, nil).Once()
 		typeRepo.On("GetByID", mock.Anything, "basic-2").Return(newType, nil).Once()
 		compute.On("ResizeInstance", mock.Anything, "cid-1", int64(2*1e9), int64(2048*1024*1024)).Return(nil).Once()
-		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, "vcpus", 2).Return(nil).Once()
-		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, "memory", 2).Return(nil).Once()
+		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, domain.QuotaVCPUs, 2).Return(nil).Once()
+		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, domain.QuotaMemoryGB, 2).Return(nil).Once()
 		repo.On("Update", mock.Anything, mock.MatchedBy(func(i *domain.Instance) bool {
 			return i.InstanceType == "basic-2"
 		})).Return(nil).Once()
@@ -1928,11 +2267,11 @@ func testInstanceServiceResizeInstanceUnit(t *testing.T) {
 		repo.On("GetByID", mock.Anything, instanceID).Return(inst, nil).Once()
 		typeRepo.On("GetByID", mock.Anything, "basic-2").Return(oldType, nil).Once()
 		typeRepo.On("GetByID", mock.Anything, "basic-4").Return(newType, nil).Once()
-		tenantSvc.On("CheckQuota", mock.Anything, tenantID, "vcpus", 2).Return(nil).Once()
-		tenantSvc.On("CheckQuota", mock.Anything, tenantID, "memory", 2).Return(nil).Once()
+		tenantSvc.On("CheckQuota", mock.Anything, tenantID, domain.QuotaVCPUs, 2).Return(nil).Once()
+		tenantSvc.On("CheckQuota", mock.Anything, tenantID, domain.QuotaMemoryGB, 2).Return(nil).Once()
 		compute.On("ResizeInstance", mock.Anything, "cid-1", int64(4*1e9), int64(4096*1024*1024)).Return(nil).Once()
-		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, "vcpus", 2).Return(nil).Once()
-		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, "memory", 2).Return(nil).Once()
+		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, domain.QuotaVCPUs, 2).Return(nil).Once()
+		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, domain.QuotaMemoryGB, 2).Return(nil).Once()
 		repo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
 		eventSvc.On("RecordEvent", mock.Anything, "INSTANCE_RESIZE", instanceID.String(), "INSTANCE", mock.Anything).Return(nil).Once()
 		auditSvc.On("Log", mock.Anything, userID, "instance.resize", "instance", instanceID.String(), mock.Anything).Return(nil).Once()
@@ -2087,7 +2426,7 @@ func testInstanceServiceResizeInstanceUnit(t *testing.T) {
 		repo.On("GetByName", mock.Anything, "test-inst").Return(inst, nil).Once()
 		typeRepo.On("GetByID", mock.Anything, "basic-2").Return(oldType, nil).Once()
 		typeRepo.On("GetByID", mock.Anything, "basic-4").Return(newType, nil).Once()
-		tenantSvc.On("CheckQuota", mock.Anything, tenantID, "vcpus", 2).Return(fmt.Errorf("insufficient vCPU quota")).Once()
+		tenantSvc.On("CheckQuota", mock.Anything, tenantID, domain.QuotaVCPUs, 2).Return(fmt.Errorf("insufficient vCPU quota")).Once()
 
 		_, err := svc.ResizeInstance(ctx, "test-inst", "basic-4")
 
@@ -2135,10 +2474,10 @@ func testInstanceServiceResizeInstanceUnit(t *testing.T) {
 		repo.On("GetByName", mock.Anything, "test-inst").Return(inst, nil).Once()
 		typeRepo.On("GetByID", mock.Anything, "basic-2").Return(oldType, nil).Once()
 		typeRepo.On("GetByID", mock.Anything, "basic-4").Return(newType, nil).Once()
-		tenantSvc.On("CheckQuota", mock.Anything, tenantID, "vcpus", 2).Return(nil).Once()
-		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, "vcpus", 2).Return(nil).Once()
-		tenantSvc.On("CheckQuota", mock.Anything, tenantID, "memory", 2).Return(fmt.Errorf("insufficient memory quota")).Once()
-		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, "vcpus", 2).Return(nil).Once()
+		tenantSvc.On("CheckQuota", mock.Anything, tenantID, domain.QuotaVCPUs, 2).Return(nil).Once()
+		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, domain.QuotaVCPUs, 2).Return(nil).Once()
+		tenantSvc.On("CheckQuota", mock.Anything, tenantID, domain.QuotaMemoryGB, 2).Return(fmt.Errorf("insufficient memory quota")).Once()
+		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, domain.QuotaVCPUs, 2).Return(nil).Once()
 
 		_, err := svc.ResizeInstance(ctx, "test-inst", "basic-4")
 
@@ -2186,7 +2525,7 @@ func testInstanceServiceResizeInstanceUnit(t *testing.T) {
 		typeRepo.On("GetByID", mock.Anything, "basic-2").Return(newType, nil).Once()
 		// Downsize: deltaCPU = -2, deltaMemMB = -2048
 		// DecrementUsage fails for vCPUs — quota change fails before any compute touch
-		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, "vcpus", 2).Return(fmt.Errorf("quota record locked")).Once()
+		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, domain.QuotaVCPUs, 2).Return(fmt.Errorf("quota record locked")).Once()
 
 		_, err := svc.ResizeInstance(ctx, "test-inst", "basic-2")
 
@@ -2237,11 +2576,11 @@ func testInstanceServiceResizeInstanceUnit(t *testing.T) {
 		repo.On("GetByName", mock.Anything, "test-inst").Return(inst, nil).Once()
 		typeRepo.On("GetByID", mock.Anything, "basic-4").Return(oldType, nil).Once()
 		typeRepo.On("GetByID", mock.Anything, "basic-2").Return(newType, nil).Once()
-		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, "vcpus", 2).Return(nil).Once()
-		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, "memory", 2).Return(nil).Once()
+		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, domain.QuotaVCPUs, 2).Return(nil).Once()
+		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, domain.QuotaMemoryGB, 2).Return(nil).Once()
 		compute.On("ResizeInstance", mock.Anything, "cid-1", int64(2*1e9), int64(2048*1024*1024)).Return(fmt.Errorf("libvirt error")).Once()
-		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, "vcpus", 2).Return(nil).Maybe()
-		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, "memory", 2).Return(nil).Maybe()
+		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, domain.QuotaVCPUs, 2).Return(nil).Maybe()
+		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, domain.QuotaMemoryGB, 2).Return(nil).Maybe()
 
 		_, err := svc.ResizeInstance(ctx, "test-inst", "basic-2")
 
@@ -2295,10 +2634,10 @@ func testInstanceServiceResizeInstanceUnit(t *testing.T) {
 		repo.On("GetByName", mock.Anything, "test-inst").Return(inst, nil).Once()
 		typeRepo.On("GetByID", mock.Anything, "basic-2").Return(oldType, nil).Once()
 		typeRepo.On("GetByID", mock.Anything, "basic-4").Return(newType, nil).Once()
-		tenantSvc.On("CheckQuota", mock.Anything, tenantID, "vcpus", 2).Return(nil).Once()
-		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, "vcpus", 2).Return(nil).Once()
-		tenantSvc.On("CheckQuota", mock.Anything, tenantID, "memory", 2).Return(nil).Once()
-		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, "memory", 2).Return(nil).Once()
+		tenantSvc.On("CheckQuota", mock.Anything, tenantID, domain.QuotaVCPUs, 2).Return(nil).Once()
+		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, domain.QuotaVCPUs, 2).Return(nil).Once()
+		tenantSvc.On("CheckQuota", mock.Anything, tenantID, domain.QuotaMemoryGB, 2).Return(nil).Once()
+		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, domain.QuotaMemoryGB, 2).Return(nil).Once()
 		compute.On("ResizeInstance", mock.Anything, "cid-1", int64(4*1e9), int64(4096*1024*1024)).Return(nil).Once()
 		repo.On("Update", mock.Anything, mock.MatchedBy(func(i *domain.Instance) bool {
 			return i.InstanceType == "basic-4" && i.Version == 2
@@ -2353,10 +2692,10 @@ func testInstanceServiceResizeInstanceUnit(t *testing.T) {
 		typeRepo.On("GetByID", mock.Anything, "basic-2").Return(oldType, nil).Once()
 		typeRepo.On("GetByID", mock.Anything, "basic-4").Return(newType, nil).Once()
 		// Quota calls for upsize
-		tenantSvc.On("CheckQuota", mock.Anything, tenantID, "vcpus", 2).Return(nil).Once()
-		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, "vcpus", 2).Return(nil).Once()
-		tenantSvc.On("CheckQuota", mock.Anything, tenantID, "memory", 2).Return(nil).Once()
-		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, "memory", 2).Return(nil).Once()
+		tenantSvc.On("CheckQuota", mock.Anything, tenantID, domain.QuotaVCPUs, 2).Return(nil).Once()
+		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, domain.QuotaVCPUs, 2).Return(nil).Once()
+		tenantSvc.On("CheckQuota", mock.Anything, tenantID, domain.QuotaMemoryGB, 2).Return(nil).Once()
+		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, domain.QuotaMemoryGB, 2).Return(nil).Once()
 		// Compute resize succeeds
 		compute.On("ResizeInstance", mock.Anything, "cid-1", int64(4*1e9), int64(4096*1024*1024)).Return(nil).Once()
 		// repo.Update returns Conflict (simulating another resize modified the instance)
@@ -2411,10 +2750,10 @@ func testInstanceServiceResizeInstanceUnit(t *testing.T) {
 		typeRepo.On("GetByID", mock.Anything, "basic-2").Return(oldType, nil).Once()
 		typeRepo.On("GetByID", mock.Anything, "basic-4").Return(newType, nil).Once()
 		// Quota calls for upsize
-		tenantSvc.On("CheckQuota", mock.Anything, tenantID, "vcpus", 2).Return(nil).Once()
-		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, "vcpus", 2).Return(nil).Once()
-		tenantSvc.On("CheckQuota", mock.Anything, tenantID, "memory", 2).Return(nil).Once()
-		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, "memory", 2).Return(nil).Once()
+		tenantSvc.On("CheckQuota", mock.Anything, tenantID, domain.QuotaVCPUs, 2).Return(nil).Once()
+		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, domain.QuotaVCPUs, 2).Return(nil).Once()
+		tenantSvc.On("CheckQuota", mock.Anything, tenantID, domain.QuotaMemoryGB, 2).Return(nil).Once()
+		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, domain.QuotaMemoryGB, 2).Return(nil).Once()
 		// Compute resize succeeds
 		compute.On("ResizeInstance", mock.Anything, "cid-1", int64(4*1e9), int64(4096*1024*1024)).Return(nil).Once()
 		// repo.Update returns Internal error (not Conflict - this tests DB failure scenario)
@@ -2422,8 +2761,8 @@ func testInstanceServiceResizeInstanceUnit(t *testing.T) {
 		// Compute rollback FAILS
 		compute.On("ResizeInstance", mock.Anything, "cid-1", int64(2*1e9), int64(2048*1024*1024)).Return(fmt.Errorf("libvirt error")).Once()
 		// Quota rollback succeeds
-		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, "vcpus", 2).Return(nil).Once()
-		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, "memory", 2).Return(nil).Once()
+		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, domain.QuotaVCPUs, 2).Return(nil).Once()
+		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, domain.QuotaMemoryGB, 2).Return(nil).Once()
 
 		_, err := svc.ResizeInstance(ctx, "test-inst", "basic-4")
 
@@ -2474,14 +2813,14 @@ func testInstanceServiceResizeInstanceUnit(t *testing.T) {
 		repo.On("GetByName", mock.Anything, "test-inst").Return(inst, nil).Once()
 		typeRepo.On("GetByID", mock.Anything, "basic-2").Return(oldType, nil).Once()
 		typeRepo.On("GetByID", mock.Anything, "basic-4").Return(newType, nil).Once()
-		tenantSvc.On("CheckQuota", mock.Anything, tenantID, "vcpus", 2).Return(nil).Once()
-		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, "vcpus", 2).Return(nil).Once()
-		tenantSvc.On("CheckQuota", mock.Anything, tenantID, "memory", 2).Return(nil).Once()
-		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, "memory", 2).Return(nil).Once()
+		tenantSvc.On("CheckQuota", mock.Anything, tenantID, domain.QuotaVCPUs, 2).Return(nil).Once()
+		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, domain.QuotaVCPUs, 2).Return(nil).Once()
+		tenantSvc.On("CheckQuota", mock.Anything, tenantID, domain.QuotaMemoryGB, 2).Return(nil).Once()
+		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, domain.QuotaMemoryGB, 2).Return(nil).Once()
 		compute.On("ResizeInstance", mock.Anything, "cid-1", int64(4*1e9), int64(4096*1024*1024)).Return(fmt.Errorf("docker error")).Once()
 		// Quota rollback when compute resize fails
-		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, "vcpus", 2).Return(nil).Maybe()
-		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, "memory", 2).Return(nil).Maybe()
+		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, domain.QuotaVCPUs, 2).Return(nil).Maybe()
+		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, domain.QuotaMemoryGB, 2).Return(nil).Maybe()
 
 		_, err := svc.ResizeInstance(ctx, "test-inst", "basic-4")
 
@@ -2532,14 +2871,14 @@ func testInstanceServiceResizeInstanceUnit(t *testing.T) {
 		repo.On("GetByName", mock.Anything, "test-inst").Return(inst, nil).Once()
 		typeRepo.On("GetByID", mock.Anything, "basic-2").Return(oldType, nil).Once()
 		typeRepo.On("GetByID", mock.Anything, "basic-4").Return(newType, nil).Once()
-		tenantSvc.On("CheckQuota", mock.Anything, tenantID, "vcpus", 2).Return(nil).Once()
-		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, "vcpus", 2).Return(nil).Once()
-		tenantSvc.On("CheckQuota", mock.Anything, tenantID, "memory", 2).Return(nil).Once()
-		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, "memory", 2).Return(nil).Once()
+		tenantSvc.On("CheckQuota", mock.Anything, tenantID, domain.QuotaVCPUs, 2).Return(nil).Once()
+		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, domain.QuotaVCPUs, 2).Return(nil).Once()
+		tenantSvc.On("CheckQuota", mock.Anything, tenantID, domain.QuotaMemoryGB, 2).Return(nil).Once()
+		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, domain.QuotaMemoryGB, 2).Return(nil).Once()
 		compute.On("ResizeInstance", mock.Anything, "cid-1", int64(4*1e9), int64(4096*1024*1024)).Return(nil).Once()
 		compute.On("ResizeInstance", mock.Anything, "cid-1", int64(2*1e9), int64(2048*1024*1024)).Return(nil).Maybe()
-		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, "vcpus", 2).Return(nil).Maybe()
-		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, "memory", 2).Return(nil).Maybe()
+		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, domain.QuotaVCPUs, 2).Return(nil).Maybe()
+		tenantSvc.On("DecrementUsage", mock.Anything, tenantID, domain.QuotaMemoryGB, 2).Return(nil).Maybe()
 		repo.On("Update", mock.Anything, mock.Anything).Return(fmt.Errorf("db error")).Once()
 
 		_, err := svc.ResizeInstance(ctx, "test-inst", "basic-4")
@@ -2572,3 +2911,59 @@ func testInstanceServiceResizeInstanceUnit(t *testing.T) {
 		rbacSvc.AssertExpectations(t)
 	})
 }
+
+func testInstanceServiceLaunchWithOptionsPolicyAttributesUnit(t *testing.T) {
+	ctx := appcontext.WithTenantID(appcontext.WithUserID(context.Background(), uuid.New()), uuid.New())
+
+	// launch returns the request attributes the launch was authorized with.
+	launch := func(t *testing.T, opts ports.CreateInstanceOptions, typeRepo *MockInstanceTypeRepo) (map[string]interface{}, error) {
+		repo := new(MockInstanceRepo)
+		rbacSvc := new(MockRBACService)
+		taskQueue := new(MockTaskQueue)
+		svc := services.NewInstanceService(services.InstanceServiceParams{
+			Repo:             repo,
+			InstanceTypeRepo: typeRepo,
+			RBAC:             rbacSvc,
+			TaskQueue:        taskQueue,
+			Logger:           slog.Default(),
+		})
+
+		var attrs map[string]interface{}
+		rbacSvc.On("Authorize", mock.MatchedBy(func(c context.Context) bool {
+			attrs = appcontext.RequestAttributesFromContext(c)
+			return true
+		}), mock.Anything, mock.Anything, domain.PermissionInstanceLaunch, "*").Return(nil).Once()
+		repo.On("Create", mock.Anything, mock.Anything).Return(nil).Maybe()
+		taskQueue.On("Enqueue", mock.Anything, "provision_queue", mock.Anything).Return(nil).Maybe()
+
+		_, err := svc.LaunchInstanceWithOptions(ctx, opts)
+		return attrs, err
+	}
+
+	t.Run("InstanceType", func(t *testing.T) {
+		typeRepo := new(MockInstanceTypeRepo)
+		typeRepo.On("GetByID", mock.Anything, "standard-1").Return(&domain.InstanceType{ID: "standard-1", VCPUs: 2, MemoryMB: 2048}, nil).Once()
+
+		attrs, err := launch(t, ports.CreateInstanceOptions{Name: "node", ImageName: "ubuntu:22.04", InstanceType: "standard-1"}, typeRepo)
+		require.NoError(t, err)
+		assert.Equal(t, "standard-1", attrs[string(domain.KeyInstanceType)])
+		assert.Equal(t, 2, attrs[string(domain.KeyInstanceVCPUs)])
+		assert.Equal(t, 2048, attrs[string(domain.KeyInstanceMemoryMB)])
+	})
+
+	t.Run("RawLimits", func(t *testing.T) {
+		attrs, err := launch(t, ports.CreateInstanceOptions{Name: "node", ImageName: "ubuntu:22.04", CPULimit: 16, MemoryLimit: 8 << 30}, new(MockInstanceTypeRepo))
+		require.NoError(t, err)
+		assert.NotContains(t, attrs, string(domain.KeyInstanceType))
+		assert.Equal(t, 16, attrs[string(domain.KeyInstanceVCPUs)])
+		assert.Equal(t, 8192, attrs[string(domain.KeyInstanceMemoryMB)])
+	})
+
+	t.Run("UnknownInstanceType", func(t *testing.T) {
+		typeRepo := new(MockInstanceTypeRepo)
+		typeRepo.On("GetByID", mock.Anything, "huge-1").Return(nil, svcerrors.New(svcerrors.NotFound, "not found")).Once()
+
+		_, err := launch(t, ports.CreateInstanceOptions{Name: "node", ImageName: "ubuntu:22.04", InstanceType: "huge-1"}, typeRepo)
+		assert.True(t, svcerrors.Is(err, svcerrors.InvalidInput))
+	})
+}
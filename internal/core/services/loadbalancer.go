@@ -20,11 +20,12 @@ type LBService struct {
 	vpcRepo      ports.VpcRepository
 	instanceRepo ports.InstanceRepository
 	auditSvc     ports.AuditService
+	tenantSvc    ports.TenantService
 	logger       *slog.Logger
 }
 
 // NewLBService constructs an LBService with its dependencies.
-func NewLBService(lbRepo ports.LBRepository, rbacSvc ports.RBACService, vpcRepo ports.VpcRepository, instanceRepo ports.InstanceRepository, auditSvc ports.AuditService, logger *slog.Logger) *LBService {
+func NewLBService(lbRepo ports.LBRepository, rbacSvc ports.RBACService, vpcRepo ports.VpcRepository, instanceRepo ports.InstanceRepository, auditSvc ports.AuditService, tenantSvc ports.TenantService, logger *slog.Logger) *LBService {
 	if logger == nil {
 		logger = slog.Default()
 	}
@@ -34,6 +35,7 @@ func NewLBService(lbRepo ports.LBRepository, rbacSvc ports.RBACService, vpcRepo
 		vpcRepo:      vpcRepo,
 		instanceRepo: instanceRepo,
 		auditSvc:     auditSvc,
+		tenantSvc:    tenantSvc,
 		logger:       logger,
 	}
 }
@@ -60,6 +62,10 @@ func (s *LBService) Create(ctx context.Context, name string, vpcID uuid.UUID, po
 		return nil, errors.Wrap(errors.NotFound, "VPC not found", err)
 	}
 
+	if err := checkQuota(ctx, s.tenantSvc, domain.QuotaLoadBalancers, 1); err != nil {
+		return nil, err
+	}
+
 	// Set default algorithm
 	if algo == "" {
 		algo = "round-robin"
@@ -82,6 +88,7 @@ func (s *LBService) Create(ctx context.Context, name string, vpcID uuid.UUID, po
 	if err := s.lbRepo.Create(ctx, lb); err != nil {
 		return nil, err
 	}
+	recordUsage(ctx, s.tenantSvc, s.logger, tenantID, domain.QuotaLoadBalancers, 1)
 
 	if err := s.auditSvc.Log(ctx, lb.UserID, "lb.create", "loadbalancer", lb.ID.String(), map[string]interface{}{
 		"name": lb.Name,
@@ -137,6 +144,7 @@ func (s *LBService) Delete(ctx context.Context, idOrName string) error {
 	if err := s.lbRepo.Update(ctx, lb); err != nil {
 		return err
 	}
+	recordUsage(ctx, s.tenantSvc, s.logger, tenantID, domain.QuotaLoadBalancers, -1)
 
 	if err := s.auditSvc.Log(ctx, lb.UserID, "lb.delete", "loadbalancer", lb.ID.String(), map[string]interface{}{
 		"name": lb.Name,
@@ -24,7 +24,7 @@ func TestLBServiceGet(t *testing.T) {
 	rbacSvc := new(MockRBACService)
 	rbacSvc.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
-	svc := services.NewLBService(lbRepo, rbacSvc, vpcRepo, instanceRepo, auditSvc, slog.Default())
+	svc := services.NewLBService(lbRepo, rbacSvc, vpcRepo, instanceRepo, auditSvc, nil, slog.Default())
 
 	lbID := uuid.New()
 	lb := &domain.LoadBalancer{ID: lbID, Name: lbMainName}
@@ -45,7 +45,7 @@ func TestLBServiceList(t *testing.T) {
 	rbacSvc := new(MockRBACService)
 	rbacSvc.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
-	svc := services.NewLBService(lbRepo, rbacSvc, vpcRepo, instanceRepo, auditSvc, slog.Default())
+	svc := services.NewLBService(lbRepo, rbacSvc, vpcRepo, instanceRepo, auditSvc, nil, slog.Default())
 
 	lbRepo.On("List", mock.Anything).Return([]*domain.LoadBalancer{{ID: uuid.New()}}, nil).Once()
 
@@ -64,7 +64,7 @@ func TestLBServiceRemoveTarget(t *testing.T) {
 	rbacSvc := new(MockRBACService)
 	rbacSvc.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
-	svc := services.NewLBService(lbRepo, rbacSvc, vpcRepo, instanceRepo, auditSvc, slog.Default())
+	svc := services.NewLBService(lbRepo, rbacSvc, vpcRepo, instanceRepo, auditSvc, nil, slog.Default())
 
 	lbID := uuid.New()
 	instanceID := uuid.New()
@@ -89,7 +89,7 @@ func TestLBServiceListTargets(t *testing.T) {
 	rbacSvc := new(MockRBACService)
 	rbacSvc.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
-	svc := services.NewLBService(lbRepo, rbacSvc, vpcRepo, instanceRepo, auditSvc, slog.Default())
+	svc := services.NewLBService(lbRepo, rbacSvc, vpcRepo, instanceRepo, auditSvc, nil, slog.Default())
 
 	lbID := uuid.New()
 	targets := []*domain.LBTarget{{ID: uuid.New(), LBID: lbID}}
@@ -110,7 +110,7 @@ func TestLBServiceDelete(t *testing.T) {
 	rbacSvc := new(MockRBACService)
 	rbacSvc.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
-	svc := services.NewLBService(lbRepo, rbacSvc, vpcRepo, instanceRepo, auditSvc, slog.Default())
+	svc := services.NewLBService(lbRepo, rbacSvc, vpcRepo, instanceRepo, auditSvc, nil, slog.Default())
 
 	lbID := uuid.New()
 	lb := &domain.LoadBalancer{ID: lbID, Name: lbMainName, UserID: uuid.New()}
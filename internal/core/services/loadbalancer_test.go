@@ -38,7 +38,7 @@ func setupLBServiceIntegrationTest(t *testing.T) (ports.LBService, *postgres.LBR
 		RBACSvc: rbacSvc,
 	})
 
-	svc := services.NewLBService(lbRepo, rbacSvc, vpcRepo, instRepo, auditSvc, slog.Default())
+	svc := services.NewLBService(lbRepo, rbacSvc, vpcRepo, instRepo, auditSvc, nil, slog.Default())
 	return svc, lbRepo, vpcRepo, instRepo, ctx
 }
 
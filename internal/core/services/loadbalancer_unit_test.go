@@ -10,6 +10,7 @@ import (
 	appcontext "github.com/poyrazk/thecloud/internal/core/context"
 	"github.com/poyrazk/thecloud/internal/core/domain"
 	"github.com/poyrazk/thecloud/internal/core/services"
+	svcerrors "github.com/poyrazk/thecloud/internal/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -23,7 +24,7 @@ func TestLBService_Unit(t *testing.T) {
 	rbacSvc := new(MockRBACService)
 	rbacSvc.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
-	svc := services.NewLBService(mockRepo, rbacSvc, mockVpcRepo, mockInstRepo, mockAuditSvc, slog.Default())
+	svc := services.NewLBService(mockRepo, rbacSvc, mockVpcRepo, mockInstRepo, mockAuditSvc, nil, slog.Default())
 
 	ctx := context.Background()
 	userID := uuid.New()
@@ -120,3 +121,42 @@ func TestLBService_Unit(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestLBService_Quota(t *testing.T) {
+	mockRepo := new(MockLBRepo)
+	mockVpcRepo := new(MockVpcRepo)
+	mockAuditSvc := new(MockAuditService)
+	tenantSvc := new(MockTenantService)
+	rbacSvc := new(MockRBACService)
+	rbacSvc.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	svc := services.NewLBService(mockRepo, rbacSvc, mockVpcRepo, new(MockInstanceRepo), mockAuditSvc, tenantSvc, slog.Default())
+
+	userID := uuid.New()
+	tenantID := uuid.New()
+	ctx := appcontext.WithTenantID(appcontext.WithUserID(context.Background(), userID), tenantID)
+
+	t.Run("Create_RecordsUsage", func(t *testing.T) {
+		vpcID := uuid.New()
+		mockVpcRepo.On("GetByID", mock.Anything, vpcID).Return(&domain.VPC{ID: vpcID}, nil).Once()
+		tenantSvc.On("CheckQuota", mock.Anything, tenantID, domain.QuotaLoadBalancers, 1).Return(nil).Once()
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+		tenantSvc.On("IncrementUsage", mock.Anything, tenantID, domain.QuotaLoadBalancers, 1).Return(nil).Once()
+		mockAuditSvc.On("Log", mock.Anything, userID, "lb.create", "loadbalancer", mock.Anything, mock.Anything).Return(nil).Once()
+
+		_, err := svc.Create(ctx, "lb", vpcID, 80, "", "")
+		require.NoError(t, err)
+		tenantSvc.AssertExpectations(t)
+	})
+
+	t.Run("Create_QuotaExceeded", func(t *testing.T) {
+		vpcID := uuid.New()
+		mockVpcRepo.On("GetByID", mock.Anything, vpcID).Return(&domain.VPC{ID: vpcID}, nil).Once()
+		tenantSvc.On("CheckQuota", mock.Anything, tenantID, domain.QuotaLoadBalancers, 1).
+			Return(svcerrors.New(svcerrors.QuotaExceeded, "quota exceeded")).Once()
+
+		_, err := svc.Create(ctx, "lb", vpcID, 80, "", "")
+		require.Error(t, err)
+		assert.True(t, svcerrors.Is(err, svcerrors.QuotaExceeded))
+	})
+}
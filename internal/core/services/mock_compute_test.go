@@ -238,6 +238,12 @@ func (m *MockComputeBackend) DetachVolume(ctx context.Context, id string, path s
 	args := m.Called(ctx, id, path)
 	return args.String(0), args.Error(1)
 }
+func (m *MockComputeBackend) AttachNetworkInterface(ctx context.Context, id string, nic ports.NetworkInterfaceOptions) error {
+	return m.Called(ctx, id, nic).Error(0)
+}
+func (m *MockComputeBackend) DetachNetworkInterface(ctx context.Context, id string, nic ports.NetworkInterfaceOptions) error {
+	return m.Called(ctx, id, nic).Error(0)
+}
 func (m *MockComputeBackend) GetConsoleURL(ctx context.Context, id string) (string, error) {
 	args := m.Called(ctx, id)
 	return args.String(0), args.Error(1)
@@ -255,6 +261,11 @@ func (m *MockComputeBackend) DeleteSnapshot(ctx context.Context, id, name string
 	return m.Called(ctx, id, name).Error(0)
 }
 func (m *MockComputeBackend) ResetCircuitBreaker() {}
+func (m *MockComputeBackend) HostMemoryPressure(ctx context.Context) ([]ports.HostPressure, error) {
+	args := m.Called(ctx)
+	r0, _ := args.Get(0).([]ports.HostPressure)
+	return r0, args.Error(1)
+}
 
 // MockClusterRepo
 type MockClusterRepo struct{ mock.Mock }
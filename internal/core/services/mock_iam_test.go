@@ -21,6 +21,10 @@ func (m *MockRBACService) HasPermission(ctx context.Context, userID, tenantID uu
 	args := m.Called(ctx, userID, tenantID, permission, resource)
 	return args.Bool(0), args.Error(1)
 }
+func (m *MockRBACService) ResourcePolicyOwner(ctx context.Context, userID, tenantID uuid.UUID, permission domain.Permission, resource string) (uuid.UUID, bool) {
+	args := m.Called(ctx, userID, tenantID, permission, resource)
+	return args.Get(0).(uuid.UUID), args.Bool(1)
+}
 func (m *MockRBACService) CreateRole(ctx context.Context, role *domain.Role) error {
 	return m.Called(ctx, role).Error(0)
 }
@@ -211,6 +215,10 @@ func (m *MockIdentityService) ValidateClientCredentials(ctx context.Context, cli
 	args := m.Called(ctx, clientID, clientSecret)
 	return args.String(0), args.Error(1)
 }
+func (m *MockIdentityService) IssueServiceAccountToken(ctx context.Context, saID uuid.UUID) (string, error) {
+	args := m.Called(ctx, saID)
+	return args.String(0), args.Error(1)
+}
 func (m *MockIdentityService) ValidateAccessToken(ctx context.Context, token string) (*domain.ServiceAccountClaims, error) {
 	args := m.Called(ctx, token)
 	if args.Get(0) == nil {
@@ -356,21 +364,47 @@ func (m *MockTenantRepo) ListUserTenants(ctx context.Context, userID uuid.UUID)
 	args := m.Called(ctx, userID)
 	return args.Get(0).([]domain.Tenant), args.Error(1)
 }
-func (m *MockTenantRepo) GetQuota(ctx context.Context, tenantID uuid.UUID) (*domain.TenantQuota, error) {
-	args := m.Called(ctx, tenantID)
+func (m *MockTenantRepo) UpdateMemberRole(ctx context.Context, tenantID, userID uuid.UUID, role string) error {
+	return m.Called(ctx, tenantID, userID, role).Error(0)
+}
+func (m *MockTenantRepo) TransferOwnership(ctx context.Context, tenantID, currentOwnerID, newOwnerID uuid.UUID) error {
+	return m.Called(ctx, tenantID, currentOwnerID, newOwnerID).Error(0)
+}
+
+// MockTenantInvitationRepo
+type MockTenantInvitationRepo struct{ mock.Mock }
+
+func (m *MockTenantInvitationRepo) Create(ctx context.Context, inv *domain.TenantInvitation) error {
+	return m.Called(ctx, inv).Error(0)
+}
+func (m *MockTenantInvitationRepo) GetByID(ctx context.Context, tenantID, id uuid.UUID) (*domain.TenantInvitation, error) {
+	args := m.Called(ctx, tenantID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	r0, _ := args.Get(0).(*domain.TenantInvitation)
+	return r0, args.Error(1)
+}
+func (m *MockTenantInvitationRepo) GetByTokenHash(ctx context.Context, hash string) (*domain.TenantInvitation, error) {
+	args := m.Called(ctx, hash)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*domain.TenantQuota), args.Error(1)
+	r0, _ := args.Get(0).(*domain.TenantInvitation)
+	return r0, args.Error(1)
 }
-func (m *MockTenantRepo) UpdateQuota(ctx context.Context, quota *domain.TenantQuota) error {
-	return m.Called(ctx, quota).Error(0)
+func (m *MockTenantInvitationRepo) ListPendingByTenant(ctx context.Context, tenantID uuid.UUID) ([]domain.TenantInvitation, error) {
+	args := m.Called(ctx, tenantID)
+	r0, _ := args.Get(0).([]domain.TenantInvitation)
+	return r0, args.Error(1)
 }
-func (m *MockTenantRepo) IncrementUsage(ctx context.Context, tenantID uuid.UUID, resource string, amount int) error {
-	return m.Called(ctx, tenantID, resource, amount).Error(0)
+func (m *MockTenantInvitationRepo) ListPendingByEmail(ctx context.Context, email string) ([]domain.TenantInvitation, error) {
+	args := m.Called(ctx, email)
+	r0, _ := args.Get(0).([]domain.TenantInvitation)
+	return r0, args.Error(1)
 }
-func (m *MockTenantRepo) DecrementUsage(ctx context.Context, tenantID uuid.UUID, resource string, amount int) error {
-	return m.Called(ctx, tenantID, resource, amount).Error(0)
+func (m *MockTenantInvitationRepo) Update(ctx context.Context, inv *domain.TenantInvitation) error {
+	return m.Called(ctx, inv).Error(0)
 }
 
 type MockTenantRepository = MockTenantRepo
@@ -399,8 +433,51 @@ func (m *MockTenantService) ListUserTenants(ctx context.Context, userID uuid.UUI
 	}
 	return args.Get(0).([]domain.Tenant), args.Error(1)
 }
-func (m *MockTenantService) InviteMember(ctx context.Context, tenantID uuid.UUID, email, role string) error {
-	return m.Called(ctx, tenantID, email, role).Error(0)
+func (m *MockTenantService) InviteMember(ctx context.Context, tenantID uuid.UUID, email, role string) (*domain.TenantInvitation, error) {
+	args := m.Called(ctx, tenantID, email, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	r0, _ := args.Get(0).(*domain.TenantInvitation)
+	return r0, args.Error(1)
+}
+func (m *MockTenantService) ListInvitations(ctx context.Context, tenantID uuid.UUID) ([]domain.TenantInvitation, error) {
+	args := m.Called(ctx, tenantID)
+	r0, _ := args.Get(0).([]domain.TenantInvitation)
+	return r0, args.Error(1)
+}
+func (m *MockTenantService) ResendInvitation(ctx context.Context, tenantID, invitationID uuid.UUID) (*domain.TenantInvitation, error) {
+	args := m.Called(ctx, tenantID, invitationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	r0, _ := args.Get(0).(*domain.TenantInvitation)
+	return r0, args.Error(1)
+}
+func (m *MockTenantService) RevokeInvitation(ctx context.Context, tenantID, invitationID uuid.UUID) error {
+	return m.Called(ctx, tenantID, invitationID).Error(0)
+}
+func (m *MockTenantService) ListMyInvitations(ctx context.Context) ([]domain.TenantInvitation, error) {
+	args := m.Called(ctx)
+	r0, _ := args.Get(0).([]domain.TenantInvitation)
+	return r0, args.Error(1)
+}
+func (m *MockTenantService) AcceptInvitation(ctx context.Context, token string) (*domain.TenantMember, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	r0, _ := args.Get(0).(*domain.TenantMember)
+	return r0, args.Error(1)
+}
+func (m *MockTenantService) DeclineInvitation(ctx context.Context, token string) error {
+	return m.Called(ctx, token).Error(0)
+}
+func (m *MockTenantService) UpdateMemberRole(ctx context.Context, tenantID, userID uuid.UUID, role string) error {
+	return m.Called(ctx, tenantID, userID, role).Error(0)
+}
+func (m *MockTenantService) TransferOwnership(ctx context.Context, tenantID, newOwnerID uuid.UUID) error {
+	return m.Called(ctx, tenantID, newOwnerID).Error(0)
 }
 func (m *MockTenantService) RemoveMember(ctx context.Context, tenantID, userID uuid.UUID) error {
 	return m.Called(ctx, tenantID, userID).Error(0)
@@ -408,7 +485,7 @@ func (m *MockTenantService) RemoveMember(ctx context.Context, tenantID, userID u
 func (m *MockTenantService) SwitchTenant(ctx context.Context, userID, tenantID uuid.UUID) error {
 	return m.Called(ctx, userID, tenantID).Error(0)
 }
-func (m *MockTenantService) CheckQuota(ctx context.Context, tenantID uuid.UUID, resource string, requested int) error {
+func (m *MockTenantService) CheckQuota(ctx context.Context, tenantID uuid.UUID, resource domain.QuotaResource, requested int) error {
 	return m.Called(ctx, tenantID, resource, requested).Error(0)
 }
 func (m *MockTenantService) GetMembership(ctx context.Context, tenantID, userID uuid.UUID) (*domain.TenantMember, error) {
@@ -418,10 +495,10 @@ func (m *MockTenantService) GetMembership(ctx context.Context, tenantID, userID
 	}
 	return args.Get(0).(*domain.TenantMember), args.Error(1)
 }
-func (m *MockTenantService) IncrementUsage(ctx context.Context, tenantID uuid.UUID, resource string, amount int) error {
+func (m *MockTenantService) IncrementUsage(ctx context.Context, tenantID uuid.UUID, resource domain.QuotaResource, amount int) error {
 	return m.Called(ctx, tenantID, resource, amount).Error(0)
 }
-func (m *MockTenantService) DecrementUsage(ctx context.Context, tenantID uuid.UUID, resource string, amount int) error {
+func (m *MockTenantService) DecrementUsage(ctx context.Context, tenantID uuid.UUID, resource domain.QuotaResource, amount int) error {
 	return m.Called(ctx, tenantID, resource, amount).Error(0)
 }
 
@@ -504,3 +581,168 @@ func (m *MockImageRepo) GetByName(ctx context.Context, name string, userID uuid.
 	}
 	return args.Get(0).(*domain.Image), args.Error(1)
 }
+
+// MockResourcePolicyRepository
+type MockResourcePolicyRepository struct{ mock.Mock }
+
+func (m *MockResourcePolicyRepository) Upsert(ctx context.Context, policy *domain.ResourcePolicy) error {
+	return m.Called(ctx, policy).Error(0)
+}
+func (m *MockResourcePolicyRepository) Get(ctx context.Context, tenantID uuid.UUID, resourceType domain.ResourcePolicyType, resourceID string) (*domain.ResourcePolicy, error) {
+	args := m.Called(ctx, tenantID, resourceType, resourceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ResourcePolicy), args.Error(1)
+}
+func (m *MockResourcePolicyRepository) List(ctx context.Context, tenantID uuid.UUID) ([]*domain.ResourcePolicy, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ResourcePolicy), args.Error(1)
+}
+func (m *MockResourcePolicyRepository) Delete(ctx context.Context, tenantID uuid.UUID, resourceType domain.ResourcePolicyType, resourceID string) error {
+	return m.Called(ctx, tenantID, resourceType, resourceID).Error(0)
+}
+func (m *MockResourcePolicyRepository) GetForResource(ctx context.Context, ownerTenantID uuid.UUID, resourceType domain.ResourcePolicyType, resourceID string) ([]*domain.ResourcePolicy, error) {
+	args := m.Called(ctx, ownerTenantID, resourceType, resourceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ResourcePolicy), args.Error(1)
+}
+func (m *MockResourcePolicyRepository) GetResourceOwner(ctx context.Context, resourceType domain.ResourcePolicyType, resourceID string) (uuid.UUID, error) {
+	args := m.Called(ctx, resourceType, resourceID)
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+
+// MockOrganizationRepository
+type MockOrganizationRepository struct{ mock.Mock }
+
+func (m *MockOrganizationRepository) Create(ctx context.Context, org *domain.Organization) error {
+	return m.Called(ctx, org).Error(0)
+}
+func (m *MockOrganizationRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Organization, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Organization), args.Error(1)
+}
+func (m *MockOrganizationRepository) ListByOwner(ctx context.Context, ownerID uuid.UUID) ([]*domain.Organization, error) {
+	args := m.Called(ctx, ownerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Organization), args.Error(1)
+}
+func (m *MockOrganizationRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+func (m *MockOrganizationRepository) AddTenant(ctx context.Context, orgID, tenantID uuid.UUID) error {
+	return m.Called(ctx, orgID, tenantID).Error(0)
+}
+func (m *MockOrganizationRepository) RemoveTenant(ctx context.Context, orgID, tenantID uuid.UUID) error {
+	return m.Called(ctx, orgID, tenantID).Error(0)
+}
+func (m *MockOrganizationRepository) ListTenantIDs(ctx context.Context, orgID uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+func (m *MockOrganizationRepository) CreateGuardrail(ctx context.Context, policy *domain.GuardrailPolicy) error {
+	return m.Called(ctx, policy).Error(0)
+}
+func (m *MockOrganizationRepository) ListGuardrails(ctx context.Context, orgID uuid.UUID) ([]*domain.GuardrailPolicy, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.GuardrailPolicy), args.Error(1)
+}
+func (m *MockOrganizationRepository) DeleteGuardrail(ctx context.Context, orgID, policyID uuid.UUID) error {
+	return m.Called(ctx, orgID, policyID).Error(0)
+}
+func (m *MockOrganizationRepository) GetGuardrailsForTenant(ctx context.Context, tenantID uuid.UUID) ([]*domain.GuardrailPolicy, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.GuardrailPolicy), args.Error(1)
+}
+
+// MockQuotaRepo
+type MockQuotaRepo struct {
+	mock.Mock
+}
+
+func (m *MockQuotaRepo) GetQuota(ctx context.Context, tenantID uuid.UUID, resource domain.QuotaResource) (*domain.ResourceQuota, error) {
+	args := m.Called(ctx, tenantID, resource)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ResourceQuota), args.Error(1)
+}
+func (m *MockQuotaRepo) ListQuotas(ctx context.Context, tenantID uuid.UUID) ([]*domain.ResourceQuota, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ResourceQuota), args.Error(1)
+}
+func (m *MockQuotaRepo) SetLimit(ctx context.Context, tenantID uuid.UUID, resource domain.QuotaResource, limit int) error {
+	return m.Called(ctx, tenantID, resource, limit).Error(0)
+}
+func (m *MockQuotaRepo) IncrementUsage(ctx context.Context, tenantID uuid.UUID, resource domain.QuotaResource, amount int) error {
+	return m.Called(ctx, tenantID, resource, amount).Error(0)
+}
+func (m *MockQuotaRepo) DecrementUsage(ctx context.Context, tenantID uuid.UUID, resource domain.QuotaResource, amount int) error {
+	return m.Called(ctx, tenantID, resource, amount).Error(0)
+}
+func (m *MockQuotaRepo) ListUsage(ctx context.Context, resource domain.QuotaResource) (map[uuid.UUID]int, error) {
+	args := m.Called(ctx, resource)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]int), args.Error(1)
+}
+func (m *MockQuotaRepo) CountActualUsage(ctx context.Context, resource domain.QuotaResource) (map[uuid.UUID]int, error) {
+	args := m.Called(ctx, resource)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]int), args.Error(1)
+}
+func (m *MockQuotaRepo) SetUsage(ctx context.Context, tenantID uuid.UUID, resource domain.QuotaResource, used int) error {
+	return m.Called(ctx, tenantID, resource, used).Error(0)
+}
+func (m *MockQuotaRepo) CreateRequest(ctx context.Context, req *domain.QuotaRequest) error {
+	return m.Called(ctx, req).Error(0)
+}
+func (m *MockQuotaRepo) GetRequest(ctx context.Context, id uuid.UUID) (*domain.QuotaRequest, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.QuotaRequest), args.Error(1)
+}
+func (m *MockQuotaRepo) ListRequestsByTenant(ctx context.Context, tenantID uuid.UUID) ([]*domain.QuotaRequest, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.QuotaRequest), args.Error(1)
+}
+func (m *MockQuotaRepo) ListRequestsByStatus(ctx context.Context, status domain.QuotaRequestStatus) ([]*domain.QuotaRequest, error) {
+	args := m.Called(ctx, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.QuotaRequest), args.Error(1)
+}
+func (m *MockQuotaRepo) ReviewRequest(ctx context.Context, req *domain.QuotaRequest) error {
+	return m.Called(ctx, req).Error(0)
+}
@@ -2,6 +2,7 @@ package services_test
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/poyrazk/thecloud/internal/core/domain"
@@ -33,6 +34,13 @@ func (m *MockVpcRepo) List(ctx context.Context) ([]*domain.VPC, error) {
 	args := m.Called(ctx)
 	return args.Get(0).([]*domain.VPC), args.Error(1)
 }
+func (m *MockVpcRepo) ListAll(ctx context.Context) ([]*domain.VPC, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.VPC), args.Error(1)
+}
 func (m *MockVpcRepo) GetByIdempotencyKey(ctx context.Context, key string) (*domain.VPC, error) {
 	args := m.Called(ctx, key)
 	if args.Get(0) == nil {
@@ -70,6 +78,11 @@ func (m *MockVpcService) UpdateVPC(ctx context.Context, idOrName, name string) (
 	r0, _ := args.Get(0).(*domain.VPC)
 	return r0, args.Error(1)
 }
+func (m *MockVpcService) AssignIPv6CIDRBlock(ctx context.Context, idOrName, cidrBlock string) (*domain.VPC, error) {
+	args := m.Called(ctx, idOrName, cidrBlock)
+	r0, _ := args.Get(0).(*domain.VPC)
+	return r0, args.Error(1)
+}
 func (m *MockVpcService) DeleteVPC(ctx context.Context, idOrName string, force bool) error {
 	return m.Called(ctx, idOrName, force).Error(0)
 }
@@ -95,6 +108,9 @@ func (m *MockSubnetRepo) ListByVPC(ctx context.Context, vpcID uuid.UUID) ([]*dom
 	r0, _ := args.Get(0).([]*domain.Subnet)
 	return r0, args.Error(1)
 }
+func (m *MockSubnetRepo) Update(ctx context.Context, subnet *domain.Subnet) error {
+	return m.Called(ctx, subnet).Error(0)
+}
 func (m *MockSubnetRepo) Delete(ctx context.Context, id uuid.UUID) error {
 	return m.Called(ctx, id).Error(0)
 }
@@ -302,6 +318,12 @@ func (m *MockSecurityGroupRepo) AddInstanceToGroup(ctx context.Context, instance
 func (m *MockSecurityGroupRepo) RemoveInstanceFromGroup(ctx context.Context, instanceID, groupID uuid.UUID) error {
 	return m.Called(ctx, instanceID, groupID).Error(0)
 }
+func (m *MockSecurityGroupRepo) AddInterfaceToGroup(ctx context.Context, interfaceID, groupID uuid.UUID) error {
+	return m.Called(ctx, interfaceID, groupID).Error(0)
+}
+func (m *MockSecurityGroupRepo) RemoveInterfaceFromGroup(ctx context.Context, interfaceID, groupID uuid.UUID) error {
+	return m.Called(ctx, interfaceID, groupID).Error(0)
+}
 func (m *MockSecurityGroupRepo) ListInstanceGroups(ctx context.Context, instanceID uuid.UUID) ([]*domain.SecurityGroup, error) {
 	args := m.Called(ctx, instanceID)
 	if args.Get(0) == nil {
@@ -309,6 +331,20 @@ func (m *MockSecurityGroupRepo) ListInstanceGroups(ctx context.Context, instance
 	}
 	return args.Get(0).([]*domain.SecurityGroup), args.Error(1)
 }
+func (m *MockSecurityGroupRepo) ListGroupMemberIPs(ctx context.Context, groupID uuid.UUID) ([]string, error) {
+	args := m.Called(ctx, groupID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+func (m *MockSecurityGroupRepo) ListReferencingGroups(ctx context.Context, groupID uuid.UUID) ([]*domain.SecurityGroup, error) {
+	args := m.Called(ctx, groupID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.SecurityGroup), args.Error(1)
+}
 
 // MockNetworkBackend
 type MockNetworkBackend struct{ mock.Mock }
@@ -348,6 +384,13 @@ func (m *MockNetworkBackend) ListFlowRules(ctx context.Context, bridge string) (
 	}
 	return args.Get(0).([]ports.FlowRule), args.Error(1)
 }
+func (m *MockNetworkBackend) ListConntrackEntries(ctx context.Context, zone int) ([]ports.ConntrackEntry, error) {
+	args := m.Called(ctx, zone)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]ports.ConntrackEntry), args.Error(1)
+}
 func (m *MockNetworkBackend) CreateVethPair(ctx context.Context, hostEnd, containerEnd string) error {
 	return m.Called(ctx, hostEnd, containerEnd).Error(0)
 }
@@ -528,6 +571,11 @@ func (m *MockEIPRepo) GetByInstanceID(ctx context.Context, instanceID uuid.UUID)
 	}
 	return args.Get(0).(*domain.ElasticIP), args.Error(1)
 }
+func (m *MockEIPRepo) ListByNetworkInterface(ctx context.Context, interfaceID uuid.UUID) ([]*domain.ElasticIP, error) {
+	args := m.Called(ctx, interfaceID)
+	r0, _ := args.Get(0).([]*domain.ElasticIP)
+	return r0, args.Error(1)
+}
 func (m *MockEIPRepo) List(ctx context.Context) ([]*domain.ElasticIP, error) {
 	args := m.Called(ctx)
 	if args.Get(0) == nil {
@@ -541,3 +589,415 @@ func (m *MockEIPRepo) Update(ctx context.Context, eip *domain.ElasticIP) error {
 func (m *MockEIPRepo) Delete(ctx context.Context, id uuid.UUID) error {
 	return m.Called(ctx, id).Error(0)
 }
+
+// MockFlowLogRepo
+type MockFlowLogRepo struct{ mock.Mock }
+
+func (m *MockFlowLogRepo) Create(ctx context.Context, fl *domain.FlowLog) error {
+	return m.Called(ctx, fl).Error(0)
+}
+func (m *MockFlowLogRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.FlowLog, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.FlowLog), args.Error(1)
+}
+func (m *MockFlowLogRepo) List(ctx context.Context) ([]*domain.FlowLog, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.FlowLog), args.Error(1)
+}
+func (m *MockFlowLogRepo) ListAll(ctx context.Context) ([]*domain.FlowLog, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.FlowLog), args.Error(1)
+}
+func (m *MockFlowLogRepo) UpdateCapture(ctx context.Context, id uuid.UUID, capturedAt time.Time, status domain.FlowLogStatus, lastError string) error {
+	return m.Called(ctx, id, capturedAt, status, lastError).Error(0)
+}
+func (m *MockFlowLogRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+// MockNetworkACLRepo
+type MockNetworkACLRepo struct{ mock.Mock }
+
+func (m *MockNetworkACLRepo) Create(ctx context.Context, acl *domain.NetworkACL) error {
+	return m.Called(ctx, acl).Error(0)
+}
+func (m *MockNetworkACLRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.NetworkACL, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.NetworkACL), args.Error(1)
+}
+func (m *MockNetworkACLRepo) ListByVPC(ctx context.Context, vpcID uuid.UUID) ([]*domain.NetworkACL, error) {
+	args := m.Called(ctx, vpcID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.NetworkACL), args.Error(1)
+}
+func (m *MockNetworkACLRepo) GetDefaultByVPC(ctx context.Context, vpcID uuid.UUID) (*domain.NetworkACL, error) {
+	args := m.Called(ctx, vpcID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.NetworkACL), args.Error(1)
+}
+func (m *MockNetworkACLRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+func (m *MockNetworkACLRepo) AddRule(ctx context.Context, rule *domain.NetworkACLRule) error {
+	return m.Called(ctx, rule).Error(0)
+}
+func (m *MockNetworkACLRepo) RemoveRule(ctx context.Context, aclID, ruleID uuid.UUID) error {
+	return m.Called(ctx, aclID, ruleID).Error(0)
+}
+func (m *MockNetworkACLRepo) ListRules(ctx context.Context, aclID uuid.UUID) ([]domain.NetworkACLRule, error) {
+	args := m.Called(ctx, aclID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.NetworkACLRule), args.Error(1)
+}
+func (m *MockNetworkACLRepo) AssociateSubnet(ctx context.Context, aclID, subnetID uuid.UUID) error {
+	return m.Called(ctx, aclID, subnetID).Error(0)
+}
+func (m *MockNetworkACLRepo) DisassociateSubnet(ctx context.Context, subnetID uuid.UUID) error {
+	return m.Called(ctx, subnetID).Error(0)
+}
+func (m *MockNetworkACLRepo) ListSubnetBindings(ctx context.Context, vpcID uuid.UUID) ([]ports.SubnetACLBinding, error) {
+	args := m.Called(ctx, vpcID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]ports.SubnetACLBinding), args.Error(1)
+}
+
+// MockVPNRepo
+type MockVPNRepo struct{ mock.Mock }
+
+func (m *MockVPNRepo) CreateGateway(ctx context.Context, gw *domain.VPNGateway) error {
+	return m.Called(ctx, gw).Error(0)
+}
+func (m *MockVPNRepo) GetGateway(ctx context.Context, id uuid.UUID) (*domain.VPNGateway, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.VPNGateway), args.Error(1)
+}
+func (m *MockVPNRepo) ListGatewaysByVPC(ctx context.Context, vpcID uuid.UUID) ([]*domain.VPNGateway, error) {
+	args := m.Called(ctx, vpcID)
+	r0, _ := args.Get(0).([]*domain.VPNGateway)
+	return r0, args.Error(1)
+}
+func (m *MockVPNRepo) UpdateGateway(ctx context.Context, gw *domain.VPNGateway) error {
+	return m.Called(ctx, gw).Error(0)
+}
+func (m *MockVPNRepo) DeleteGateway(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+func (m *MockVPNRepo) CreateCustomerGateway(ctx context.Context, cgw *domain.CustomerGateway) error {
+	return m.Called(ctx, cgw).Error(0)
+}
+func (m *MockVPNRepo) GetCustomerGateway(ctx context.Context, id uuid.UUID) (*domain.CustomerGateway, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CustomerGateway), args.Error(1)
+}
+func (m *MockVPNRepo) ListCustomerGateways(ctx context.Context) ([]*domain.CustomerGateway, error) {
+	args := m.Called(ctx)
+	r0, _ := args.Get(0).([]*domain.CustomerGateway)
+	return r0, args.Error(1)
+}
+func (m *MockVPNRepo) DeleteCustomerGateway(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+func (m *MockVPNRepo) CreateConnection(ctx context.Context, conn *domain.VPNConnection) error {
+	return m.Called(ctx, conn).Error(0)
+}
+func (m *MockVPNRepo) GetConnection(ctx context.Context, id uuid.UUID) (*domain.VPNConnection, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.VPNConnection), args.Error(1)
+}
+func (m *MockVPNRepo) ListConnectionsByGateway(ctx context.Context, gatewayID uuid.UUID) ([]*domain.VPNConnection, error) {
+	args := m.Called(ctx, gatewayID)
+	r0, _ := args.Get(0).([]*domain.VPNConnection)
+	return r0, args.Error(1)
+}
+func (m *MockVPNRepo) ListConnectionsByCustomerGateway(ctx context.Context, customerGatewayID uuid.UUID) ([]*domain.VPNConnection, error) {
+	args := m.Called(ctx, customerGatewayID)
+	r0, _ := args.Get(0).([]*domain.VPNConnection)
+	return r0, args.Error(1)
+}
+func (m *MockVPNRepo) UpdateConnection(ctx context.Context, conn *domain.VPNConnection) error {
+	return m.Called(ctx, conn).Error(0)
+}
+func (m *MockVPNRepo) DeleteConnection(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+// MockTransitGatewayRepo
+type MockTransitGatewayRepo struct{ mock.Mock }
+
+func (m *MockTransitGatewayRepo) Create(ctx context.Context, tgw *domain.TransitGateway) error {
+	return m.Called(ctx, tgw).Error(0)
+}
+func (m *MockTransitGatewayRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.TransitGateway, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TransitGateway), args.Error(1)
+}
+func (m *MockTransitGatewayRepo) List(ctx context.Context) ([]*domain.TransitGateway, error) {
+	args := m.Called(ctx)
+	r0, _ := args.Get(0).([]*domain.TransitGateway)
+	return r0, args.Error(1)
+}
+func (m *MockTransitGatewayRepo) Update(ctx context.Context, tgw *domain.TransitGateway) error {
+	return m.Called(ctx, tgw).Error(0)
+}
+func (m *MockTransitGatewayRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+func (m *MockTransitGatewayRepo) CreateAttachment(ctx context.Context, att *domain.TransitGatewayAttachment) error {
+	return m.Called(ctx, att).Error(0)
+}
+func (m *MockTransitGatewayRepo) GetAttachment(ctx context.Context, id uuid.UUID) (*domain.TransitGatewayAttachment, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TransitGatewayAttachment), args.Error(1)
+}
+func (m *MockTransitGatewayRepo) ListAttachments(ctx context.Context, tgwID uuid.UUID) ([]*domain.TransitGatewayAttachment, error) {
+	args := m.Called(ctx, tgwID)
+	r0, _ := args.Get(0).([]*domain.TransitGatewayAttachment)
+	return r0, args.Error(1)
+}
+func (m *MockTransitGatewayRepo) UpdateAttachment(ctx context.Context, att *domain.TransitGatewayAttachment) error {
+	return m.Called(ctx, att).Error(0)
+}
+func (m *MockTransitGatewayRepo) DeleteAttachment(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+func (m *MockTransitGatewayRepo) CreateRouteTable(ctx context.Context, rt *domain.TransitGatewayRouteTable) error {
+	return m.Called(ctx, rt).Error(0)
+}
+func (m *MockTransitGatewayRepo) GetRouteTable(ctx context.Context, id uuid.UUID) (*domain.TransitGatewayRouteTable, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TransitGatewayRouteTable), args.Error(1)
+}
+func (m *MockTransitGatewayRepo) ListRouteTables(ctx context.Context, tgwID uuid.UUID) ([]*domain.TransitGatewayRouteTable, error) {
+	args := m.Called(ctx, tgwID)
+	r0, _ := args.Get(0).([]*domain.TransitGatewayRouteTable)
+	return r0, args.Error(1)
+}
+func (m *MockTransitGatewayRepo) DeleteRouteTable(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+func (m *MockTransitGatewayRepo) AddRoute(ctx context.Context, route *domain.TransitGatewayRoute) error {
+	return m.Called(ctx, route).Error(0)
+}
+func (m *MockTransitGatewayRepo) ListRoutes(ctx context.Context, rtID uuid.UUID) ([]domain.TransitGatewayRoute, error) {
+	args := m.Called(ctx, rtID)
+	r0, _ := args.Get(0).([]domain.TransitGatewayRoute)
+	return r0, args.Error(1)
+}
+func (m *MockTransitGatewayRepo) RemoveRoute(ctx context.Context, rtID, routeID uuid.UUID) error {
+	return m.Called(ctx, rtID, routeID).Error(0)
+}
+func (m *MockTransitGatewayRepo) AddPropagation(ctx context.Context, rtID, attachmentID uuid.UUID) error {
+	return m.Called(ctx, rtID, attachmentID).Error(0)
+}
+func (m *MockTransitGatewayRepo) ListPropagations(ctx context.Context, rtID uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, rtID)
+	r0, _ := args.Get(0).([]uuid.UUID)
+	return r0, args.Error(1)
+}
+func (m *MockTransitGatewayRepo) RemovePropagation(ctx context.Context, rtID, attachmentID uuid.UUID) error {
+	return m.Called(ctx, rtID, attachmentID).Error(0)
+}
+
+// MockVPCResolverRepo
+type MockVPCResolverRepo struct{ mock.Mock }
+
+func (m *MockVPCResolverRepo) Create(ctx context.Context, resolver *domain.VPCResolver) error {
+	return m.Called(ctx, resolver).Error(0)
+}
+func (m *MockVPCResolverRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.VPCResolver, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.VPCResolver), args.Error(1)
+}
+func (m *MockVPCResolverRepo) GetByVPC(ctx context.Context, vpcID uuid.UUID) (*domain.VPCResolver, error) {
+	args := m.Called(ctx, vpcID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.VPCResolver), args.Error(1)
+}
+func (m *MockVPCResolverRepo) List(ctx context.Context) ([]*domain.VPCResolver, error) {
+	args := m.Called(ctx)
+	r0, _ := args.Get(0).([]*domain.VPCResolver)
+	return r0, args.Error(1)
+}
+func (m *MockVPCResolverRepo) Update(ctx context.Context, resolver *domain.VPCResolver) error {
+	return m.Called(ctx, resolver).Error(0)
+}
+func (m *MockVPCResolverRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+func (m *MockVPCResolverRepo) CreateRule(ctx context.Context, rule *domain.ResolverForwardingRule) error {
+	return m.Called(ctx, rule).Error(0)
+}
+func (m *MockVPCResolverRepo) ListRules(ctx context.Context, resolverID uuid.UUID) ([]*domain.ResolverForwardingRule, error) {
+	args := m.Called(ctx, resolverID)
+	r0, _ := args.Get(0).([]*domain.ResolverForwardingRule)
+	return r0, args.Error(1)
+}
+func (m *MockVPCResolverRepo) DeleteRule(ctx context.Context, resolverID, ruleID uuid.UUID) error {
+	return m.Called(ctx, resolverID, ruleID).Error(0)
+}
+
+// MockDHCPOptionsRepo
+type MockDHCPOptionsRepo struct{ mock.Mock }
+
+func (m *MockDHCPOptionsRepo) Create(ctx context.Context, opts *domain.DHCPOptionsSet) error {
+	return m.Called(ctx, opts).Error(0)
+}
+func (m *MockDHCPOptionsRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.DHCPOptionsSet, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DHCPOptionsSet), args.Error(1)
+}
+func (m *MockDHCPOptionsRepo) GetByVPC(ctx context.Context, vpcID uuid.UUID) (*domain.DHCPOptionsSet, error) {
+	args := m.Called(ctx, vpcID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DHCPOptionsSet), args.Error(1)
+}
+func (m *MockDHCPOptionsRepo) List(ctx context.Context) ([]*domain.DHCPOptionsSet, error) {
+	args := m.Called(ctx)
+	r0, _ := args.Get(0).([]*domain.DHCPOptionsSet)
+	return r0, args.Error(1)
+}
+func (m *MockDHCPOptionsRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+func (m *MockDHCPOptionsRepo) SetVPCAssociation(ctx context.Context, vpcID uuid.UUID, optionsID *uuid.UUID) error {
+	return m.Called(ctx, vpcID, optionsID).Error(0)
+}
+
+// MockInstanceMetadataRepo
+type MockInstanceMetadataRepo struct{ mock.Mock }
+
+func (m *MockInstanceMetadataRepo) Save(ctx context.Context, cfg *domain.InstanceMetadataConfig) error {
+	return m.Called(ctx, cfg).Error(0)
+}
+func (m *MockInstanceMetadataRepo) GetByAddress(ctx context.Context, vpcID *uuid.UUID, ip string) (*domain.InstanceMetadataConfig, error) {
+	args := m.Called(ctx, vpcID, ip)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.InstanceMetadataConfig), args.Error(1)
+}
+
+// MockNetworkInterfaceRepo
+type MockNetworkInterfaceRepo struct{ mock.Mock }
+
+func (m *MockNetworkInterfaceRepo) Create(ctx context.Context, ni *domain.NetworkInterface) error {
+	return m.Called(ctx, ni).Error(0)
+}
+func (m *MockNetworkInterfaceRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.NetworkInterface, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.NetworkInterface), args.Error(1)
+}
+func (m *MockNetworkInterfaceRepo) List(ctx context.Context) ([]*domain.NetworkInterface, error) {
+	args := m.Called(ctx)
+	r0, _ := args.Get(0).([]*domain.NetworkInterface)
+	return r0, args.Error(1)
+}
+func (m *MockNetworkInterfaceRepo) ListByInstance(ctx context.Context, instanceID uuid.UUID) ([]*domain.NetworkInterface, error) {
+	args := m.Called(ctx, instanceID)
+	r0, _ := args.Get(0).([]*domain.NetworkInterface)
+	return r0, args.Error(1)
+}
+func (m *MockNetworkInterfaceRepo) ListBySubnet(ctx context.Context, subnetID uuid.UUID) ([]*domain.NetworkInterface, error) {
+	args := m.Called(ctx, subnetID)
+	r0, _ := args.Get(0).([]*domain.NetworkInterface)
+	return r0, args.Error(1)
+}
+func (m *MockNetworkInterfaceRepo) Update(ctx context.Context, ni *domain.NetworkInterface) error {
+	return m.Called(ctx, ni).Error(0)
+}
+func (m *MockNetworkInterfaceRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+// MockSecurityGroupService
+type MockSecurityGroupService struct{ mock.Mock }
+
+func (m *MockSecurityGroupService) CreateGroup(ctx context.Context, vpcID uuid.UUID, name, description string) (*domain.SecurityGroup, error) {
+	args := m.Called(ctx, vpcID, name, description)
+	r0, _ := args.Get(0).(*domain.SecurityGroup)
+	return r0, args.Error(1)
+}
+func (m *MockSecurityGroupService) GetGroup(ctx context.Context, idOrName string, vpcID uuid.UUID) (*domain.SecurityGroup, error) {
+	args := m.Called(ctx, idOrName, vpcID)
+	r0, _ := args.Get(0).(*domain.SecurityGroup)
+	return r0, args.Error(1)
+}
+func (m *MockSecurityGroupService) ListGroups(ctx context.Context, vpcID uuid.UUID) ([]*domain.SecurityGroup, error) {
+	args := m.Called(ctx, vpcID)
+	r0, _ := args.Get(0).([]*domain.SecurityGroup)
+	return r0, args.Error(1)
+}
+func (m *MockSecurityGroupService) DeleteGroup(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+func (m *MockSecurityGroupService) AddRule(ctx context.Context, idOrName string, rule domain.SecurityRule) (*domain.SecurityRule, error) {
+	args := m.Called(ctx, idOrName, rule)
+	r0, _ := args.Get(0).(*domain.SecurityRule)
+	return r0, args.Error(1)
+}
+func (m *MockSecurityGroupService) RemoveRule(ctx context.Context, ruleID uuid.UUID) error {
+	return m.Called(ctx, ruleID).Error(0)
+}
+func (m *MockSecurityGroupService) AttachToInstance(ctx context.Context, instanceID, groupID uuid.UUID) error {
+	return m.Called(ctx, instanceID, groupID).Error(0)
+}
+func (m *MockSecurityGroupService) DetachFromInstance(ctx context.Context, instanceID, groupID uuid.UUID) error {
+	return m.Called(ctx, instanceID, groupID).Error(0)
+}
+func (m *MockSecurityGroupService) AttachToNetworkInterface(ctx context.Context, interfaceID, groupID uuid.UUID) error {
+	return m.Called(ctx, interfaceID, groupID).Error(0)
+}
+func (m *MockSecurityGroupService) DetachFromNetworkInterface(ctx context.Context, interfaceID, groupID uuid.UUID) error {
+	return m.Called(ctx, interfaceID, groupID).Error(0)
+}
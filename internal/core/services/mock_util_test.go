@@ -20,6 +20,11 @@ func (m *MockAccountingRepo) GetUsageSummary(ctx context.Context, userID uuid.UU
 	args := m.Called(ctx, userID, start, end)
 	return args.Get(0).(map[domain.ResourceType]float64), args.Error(1)
 }
+func (m *MockAccountingRepo) GetUsageSummaryByTenant(ctx context.Context, tenantIDs []uuid.UUID, start, end time.Time) (map[uuid.UUID]map[domain.ResourceType]float64, error) {
+	args := m.Called(ctx, tenantIDs, start, end)
+	r0, _ := args.Get(0).(map[uuid.UUID]map[domain.ResourceType]float64)
+	return r0, args.Error(1)
+}
 func (m *MockAccountingRepo) ListRecords(ctx context.Context, userID uuid.UUID, start, end time.Time) ([]domain.UsageRecord, error) {
 	args := m.Called(ctx, userID, start, end)
 	return args.Get(0).([]domain.UsageRecord), args.Error(1)
@@ -171,6 +176,18 @@ func (m *MockDatabaseRepo) ListReplicas(ctx context.Context, primaryID uuid.UUID
 	args := m.Called(ctx, primaryID)
 	return args.Get(0).([]*domain.Database), args.Error(1)
 }
+func (m *MockDatabaseRepo) ListWithBackupRetention(ctx context.Context) ([]*domain.Database, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*domain.Database), args.Error(1)
+}
+func (m *MockDatabaseRepo) ListPendingMaintenance(ctx context.Context) ([]*domain.Database, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*domain.Database), args.Error(1)
+}
+func (m *MockDatabaseRepo) ListWithMetrics(ctx context.Context) ([]*domain.Database, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]*domain.Database), args.Error(1)
+}
 func (m *MockDatabaseRepo) Update(ctx context.Context, db *domain.Database) error {
 	return m.Called(ctx, db).Error(0)
 }
@@ -199,6 +216,11 @@ func (m *MockElasticIPRepo) GetByInstanceID(ctx context.Context, id uuid.UUID) (
 	r0, _ := args.Get(0).(*domain.ElasticIP)
 	return r0, args.Error(1)
 }
+func (m *MockElasticIPRepo) ListByNetworkInterface(ctx context.Context, interfaceID uuid.UUID) ([]*domain.ElasticIP, error) {
+	args := m.Called(ctx, interfaceID)
+	r0, _ := args.Get(0).([]*domain.ElasticIP)
+	return r0, args.Error(1)
+}
 func (m *MockElasticIPRepo) List(ctx context.Context) ([]*domain.ElasticIP, error) {
 	args := m.Called(ctx)
 	r0, _ := args.Get(0).([]*domain.ElasticIP)
@@ -301,6 +323,13 @@ func (m *MockNotifyRepo) GetTopicByID(ctx context.Context, id, userID uuid.UUID)
 	}
 	return args.Get(0).(*domain.Topic), args.Error(1)
 }
+func (m *MockNotifyRepo) GetTopicByTenant(ctx context.Context, id, tenantID uuid.UUID) (*domain.Topic, error) {
+	args := m.Called(ctx, id, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Topic), args.Error(1)
+}
 func (m *MockNotifyRepo) GetTopicByName(ctx context.Context, name string, userID uuid.UUID) (*domain.Topic, error) {
 	args := m.Called(ctx, name, userID)
 	if args.Get(0) == nil {
@@ -378,6 +407,33 @@ func (m *MockSecretService) Decrypt(ctx context.Context, userID uuid.UUID, ciphe
 	args := m.Called(ctx, userID, cipher)
 	return args.String(0), args.Error(1)
 }
+func (m *MockSecretService) PutSecretValue(ctx context.Context, id uuid.UUID, value string, stage domain.SecretStage) (*domain.SecretVersion, error) {
+	args := m.Called(ctx, id, value, stage)
+	r0, _ := args.Get(0).(*domain.SecretVersion)
+	return r0, args.Error(1)
+}
+func (m *MockSecretService) ListSecretVersions(ctx context.Context, id uuid.UUID) ([]*domain.SecretVersion, error) {
+	args := m.Called(ctx, id)
+	r0, _ := args.Get(0).([]*domain.SecretVersion)
+	return r0, args.Error(1)
+}
+func (m *MockSecretService) GetSecretVersion(ctx context.Context, id uuid.UUID, version int) (*domain.SecretVersion, error) {
+	args := m.Called(ctx, id, version)
+	r0, _ := args.Get(0).(*domain.SecretVersion)
+	return r0, args.Error(1)
+}
+func (m *MockSecretService) GetSecretVersionByStage(ctx context.Context, id uuid.UUID, stage domain.SecretStage) (*domain.SecretVersion, error) {
+	args := m.Called(ctx, id, stage)
+	r0, _ := args.Get(0).(*domain.SecretVersion)
+	return r0, args.Error(1)
+}
+func (m *MockSecretService) UpdateSecretVersionStage(ctx context.Context, id uuid.UUID, stage domain.SecretStage, version int) error {
+	return m.Called(ctx, id, stage, version).Error(0)
+}
+func (m *MockSecretService) ResolveSecretRef(ctx context.Context, ref string) (string, error) {
+	args := m.Called(ctx, ref)
+	return args.String(0), args.Error(1)
+}
 
 // MockStackRepo
 type MockStackRepo struct{ mock.Mock }
@@ -576,6 +632,35 @@ func (m *MockSecretRepo) Update(ctx context.Context, secret *domain.Secret) erro
 func (m *MockSecretRepo) Delete(ctx context.Context, id uuid.UUID) error {
 	return m.Called(ctx, id).Error(0)
 }
+func (m *MockSecretRepo) AddVersion(ctx context.Context, v *domain.SecretVersion) error {
+	return m.Called(ctx, v).Error(0)
+}
+func (m *MockSecretRepo) ListVersions(ctx context.Context, secretID uuid.UUID) ([]*domain.SecretVersion, error) {
+	args := m.Called(ctx, secretID)
+	r0, _ := args.Get(0).([]*domain.SecretVersion)
+	return r0, args.Error(1)
+}
+func (m *MockSecretRepo) GetVersion(ctx context.Context, secretID uuid.UUID, version int) (*domain.SecretVersion, error) {
+	args := m.Called(ctx, secretID, version)
+	r0, _ := args.Get(0).(*domain.SecretVersion)
+	return r0, args.Error(1)
+}
+func (m *MockSecretRepo) GetVersionByStage(ctx context.Context, secretID uuid.UUID, stage domain.SecretStage) (*domain.SecretVersion, error) {
+	args := m.Called(ctx, secretID, stage)
+	r0, _ := args.Get(0).(*domain.SecretVersion)
+	return r0, args.Error(1)
+}
+func (m *MockSecretRepo) MoveStage(ctx context.Context, secretID uuid.UUID, stage domain.SecretStage, version int) error {
+	return m.Called(ctx, secretID, stage, version).Error(0)
+}
+func (m *MockSecretRepo) UpdateRotation(ctx context.Context, secret *domain.Secret) error {
+	return m.Called(ctx, secret).Error(0)
+}
+func (m *MockSecretRepo) ListDueForRotation(ctx context.Context, now time.Time) ([]*domain.Secret, error) {
+	args := m.Called(ctx, now)
+	r0, _ := args.Get(0).([]*domain.Secret)
+	return r0, args.Error(1)
+}
 
 // MockRealtimePublisher
 type MockRealtimePublisher struct{ mock.Mock }
@@ -668,6 +753,13 @@ func (m *MockFunctionService) InvokeFunction(ctx context.Context, id uuid.UUID,
 	}
 	return args.Get(0).(*domain.Invocation), args.Error(1)
 }
+func (m *MockFunctionService) InvokeFunctionRedacted(ctx context.Context, id uuid.UUID, payload []byte) (*domain.Invocation, error) {
+	args := m.Called(ctx, id, payload)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Invocation), args.Error(1)
+}
 func (m *MockFunctionService) GetFunctionLogs(ctx context.Context, id uuid.UUID, limit int) ([]*domain.Invocation, error) {
 	args := m.Called(ctx, id, limit)
 	if args.Get(0) == nil {
@@ -0,0 +1,524 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/errors"
+)
+
+// NetworkACLService manages stateless subnet firewalls and their OVS flows.
+type NetworkACLService struct {
+	repo     ports.NetworkACLRepository
+	vpcRepo  ports.VpcRepository
+	rbacSvc  ports.RBACService
+	network  ports.NetworkBackend
+	auditSvc ports.AuditService
+	logger   *slog.Logger
+}
+
+// NetworkACLServiceParams holds dependencies for NetworkACLService.
+type NetworkACLServiceParams struct {
+	Repo     ports.NetworkACLRepository
+	VpcRepo  ports.VpcRepository
+	RBACSvc  ports.RBACService
+	Network  ports.NetworkBackend
+	AuditSvc ports.AuditService
+	Logger   *slog.Logger
+}
+
+// NewNetworkACLService constructs a NetworkACLService with its dependencies.
+func NewNetworkACLService(params NetworkACLServiceParams) *NetworkACLService {
+	logger := params.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &NetworkACLService{
+		repo:     params.Repo,
+		vpcRepo:  params.VpcRepo,
+		rbacSvc:  params.RBACSvc,
+		network:  params.Network,
+		auditSvc: params.AuditSvc,
+		logger:   logger,
+	}
+}
+
+// CreateNetworkACL creates an empty ACL; subnets associated with it deny all traffic until rules are added.
+func (s *NetworkACLService) CreateNetworkACL(ctx context.Context, vpcID uuid.UUID, name string) (*domain.NetworkACL, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcUpdate, vpcID.String()); err != nil {
+		return nil, err
+	}
+	if _, err := s.vpcRepo.GetByID(ctx, vpcID); err != nil {
+		return nil, err
+	}
+
+	acl := &domain.NetworkACL{
+		ID:        uuid.New(),
+		VPCID:     vpcID,
+		Name:      name,
+		CreatedAt: time.Now(),
+	}
+	if err := acl.Validate(); err != nil {
+		return nil, errors.New(errors.InvalidInput, err.Error())
+	}
+	if err := s.repo.Create(ctx, acl); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, "network_acl.create", acl.ID, map[string]interface{}{"vpc_id": vpcID.String(), "name": name})
+	return acl, nil
+}
+
+// GetNetworkACL retrieves an ACL with its rules and associations.
+func (s *NetworkACLService) GetNetworkACL(ctx context.Context, id uuid.UUID) (*domain.NetworkACL, error) {
+	if err := s.rbacSvc.Authorize(ctx, appcontext.UserIDFromContext(ctx), appcontext.TenantIDFromContext(ctx), domain.PermissionVpcRead, id.String()); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+// ListNetworkACLs returns the ACLs of a VPC.
+func (s *NetworkACLService) ListNetworkACLs(ctx context.Context, vpcID uuid.UUID) ([]*domain.NetworkACL, error) {
+	if err := s.rbacSvc.Authorize(ctx, appcontext.UserIDFromContext(ctx), appcontext.TenantIDFromContext(ctx), domain.PermissionVpcRead, vpcID.String()); err != nil {
+		return nil, err
+	}
+	return s.repo.ListByVPC(ctx, vpcID)
+}
+
+// DeleteNetworkACL removes a custom ACL and moves its subnets back to the default ACL.
+func (s *NetworkACLService) DeleteNetworkACL(ctx context.Context, id uuid.UUID) error {
+	if err := s.rbacSvc.Authorize(ctx, appcontext.UserIDFromContext(ctx), appcontext.TenantIDFromContext(ctx), domain.PermissionVpcDelete, id.String()); err != nil {
+		return err
+	}
+
+	acl, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if acl.IsDefault {
+		return errors.New(errors.InvalidInput, "the default network ACL cannot be deleted")
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	subnets := make(map[uuid.UUID]bool, len(acl.Associations))
+	for _, a := range acl.Associations {
+		subnets[a.SubnetID] = true
+	}
+	if len(subnets) > 0 {
+		s.resyncSubnets(ctx, acl.VPCID, func(b ports.SubnetACLBinding) bool { return subnets[b.SubnetID] })
+	}
+
+	s.audit(ctx, "network_acl.delete", id, map[string]interface{}{"vpc_id": acl.VPCID.String()})
+	return nil
+}
+
+// AddRule adds a numbered rule and programs it on every subnet using the ACL.
+func (s *NetworkACLService) AddRule(ctx context.Context, aclID uuid.UUID, rule domain.NetworkACLRule) (*domain.NetworkACLRule, error) {
+	if err := s.rbacSvc.Authorize(ctx, appcontext.UserIDFromContext(ctx), appcontext.TenantIDFromContext(ctx), domain.PermissionVpcUpdate, aclID.String()); err != nil {
+		return nil, err
+	}
+
+	acl, err := s.repo.GetByID(ctx, aclID)
+	if err != nil {
+		return nil, err
+	}
+
+	rule.ID = uuid.New()
+	rule.ACLID = aclID
+	rule.CreatedAt = time.Now()
+	if rule.Protocol == "" {
+		rule.Protocol = "all"
+	}
+	if rule.Protocol == "all" || rule.Protocol == "icmp" {
+		rule.PortMin, rule.PortMax = 0, 0
+	} else if rule.PortMax == 0 {
+		rule.PortMax = rule.PortMin
+	}
+	if err := rule.Validate(); err != nil {
+		return nil, errors.New(errors.InvalidInput, err.Error())
+	}
+	// Store the canonical network so flows match what ovs-ofctl prints back.
+	_, ipNet, _ := net.ParseCIDR(rule.CIDR)
+	rule.CIDR = ipNet.String()
+
+	if err := s.repo.AddRule(ctx, &rule); err != nil {
+		return nil, err
+	}
+
+	vpc, bindings, err := s.aclBindings(ctx, acl.VPCID)
+	if err != nil {
+		s.logger.Error("failed to program network ACL rule", "acl_id", aclID, "rule_id", rule.ID, "error", err)
+	} else {
+		for _, b := range bindings {
+			if b.ACLID != aclID {
+				continue
+			}
+			for _, cidr := range subnetACLCIDRs(b) {
+				for _, flow := range networkACLRuleFlows(rule, cidr, vpc.VXLANID) {
+					if err := s.network.AddFlowRule(ctx, vpc.NetworkID, flow); err != nil {
+						// The database is the source of truth; the flow reconciler restores missing flows.
+						s.logger.Error("failed to add OVS flow for network ACL rule", "rule_id", rule.ID, "subnet_id", b.SubnetID, "error", err)
+					}
+				}
+			}
+		}
+	}
+
+	s.audit(ctx, "network_acl.add_rule", aclID, map[string]interface{}{
+		"rule_id":     rule.ID.String(),
+		"rule_number": rule.RuleNumber,
+		"direction":   rule.Direction,
+		"action":      rule.Action,
+	})
+	return &rule, nil
+}
+
+// RemoveRule deletes a rule and its flows.
+func (s *NetworkACLService) RemoveRule(ctx context.Context, aclID, ruleID uuid.UUID) error {
+	if err := s.rbacSvc.Authorize(ctx, appcontext.UserIDFromContext(ctx), appcontext.TenantIDFromContext(ctx), domain.PermissionVpcUpdate, aclID.String()); err != nil {
+		return err
+	}
+
+	acl, err := s.repo.GetByID(ctx, aclID)
+	if err != nil {
+		return err
+	}
+	vpc, err := s.vpcRepo.GetByID(ctx, acl.VPCID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.RemoveRule(ctx, aclID, ruleID); err != nil {
+		return err
+	}
+
+	if err := s.network.DeleteFlowRule(ctx, vpc.NetworkID, cookieMatch(ruleID)); err != nil {
+		s.logger.Error("failed to remove OVS flows for network ACL rule", "rule_id", ruleID, "error", err)
+	}
+
+	s.audit(ctx, "network_acl.remove_rule", aclID, map[string]interface{}{"rule_id": ruleID.String()})
+	return nil
+}
+
+// AssociateSubnet applies the ACL to a subnet in the same VPC, replacing its current ACL.
+func (s *NetworkACLService) AssociateSubnet(ctx context.Context, aclID, subnetID uuid.UUID) error {
+	if err := s.rbacSvc.Authorize(ctx, appcontext.UserIDFromContext(ctx), appcontext.TenantIDFromContext(ctx), domain.PermissionVpcUpdate, aclID.String()); err != nil {
+		return err
+	}
+
+	acl, err := s.repo.GetByID(ctx, aclID)
+	if err != nil {
+		return err
+	}
+	vpc, binding, err := s.subnetBinding(ctx, acl.VPCID, subnetID)
+	if err != nil {
+		return err
+	}
+
+	// The default ACL applies to every subnet without an association.
+	if acl.IsDefault {
+		err = s.repo.DisassociateSubnet(ctx, subnetID)
+	} else {
+		err = s.repo.AssociateSubnet(ctx, aclID, subnetID)
+	}
+	if err != nil {
+		return err
+	}
+
+	binding.ACLID = aclID
+	if err := programSubnetACL(ctx, s.network, vpc, binding, acl.Rules); err != nil {
+		s.logger.Error("failed to program network ACL for subnet", "acl_id", aclID, "subnet_id", subnetID, "error", err)
+	}
+
+	s.audit(ctx, "network_acl.associate", aclID, map[string]interface{}{"subnet_id": subnetID.String()})
+	return nil
+}
+
+// DisassociateSubnet returns a subnet associated with the ACL to its VPC's default ACL.
+func (s *NetworkACLService) DisassociateSubnet(ctx context.Context, aclID, subnetID uuid.UUID) error {
+	if err := s.rbacSvc.Authorize(ctx, appcontext.UserIDFromContext(ctx), appcontext.TenantIDFromContext(ctx), domain.PermissionVpcUpdate, aclID.String()); err != nil {
+		return err
+	}
+
+	acl, err := s.repo.GetByID(ctx, aclID)
+	if err != nil {
+		return err
+	}
+	vpc, binding, err := s.subnetBinding(ctx, acl.VPCID, subnetID)
+	if err != nil {
+		return err
+	}
+	if binding.ACLID != aclID || acl.IsDefault {
+		return errors.New(errors.InvalidInput, "subnet is not associated with this network ACL")
+	}
+
+	def, err := s.repo.GetDefaultByVPC(ctx, acl.VPCID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DisassociateSubnet(ctx, subnetID); err != nil {
+		return err
+	}
+
+	binding.ACLID = def.ID
+	if err := programSubnetACL(ctx, s.network, vpc, binding, def.Rules); err != nil {
+		s.logger.Error("failed to program network ACL for subnet", "acl_id", def.ID, "subnet_id", subnetID, "error", err)
+	}
+
+	s.audit(ctx, "network_acl.disassociate", aclID, map[string]interface{}{"subnet_id": subnetID.String()})
+	return nil
+}
+
+func (s *NetworkACLService) aclBindings(ctx context.Context, vpcID uuid.UUID) (*domain.VPC, []ports.SubnetACLBinding, error) {
+	vpc, err := s.vpcRepo.GetByID(ctx, vpcID)
+	if err != nil {
+		return nil, nil, err
+	}
+	bindings, err := s.repo.ListSubnetBindings(ctx, vpcID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return vpc, bindings, nil
+}
+
+// subnetBinding finds a subnet in the ACL's VPC, which also rejects subnets of other VPCs.
+func (s *NetworkACLService) subnetBinding(ctx context.Context, vpcID, subnetID uuid.UUID) (*domain.VPC, ports.SubnetACLBinding, error) {
+	vpc, bindings, err := s.aclBindings(ctx, vpcID)
+	if err != nil {
+		return nil, ports.SubnetACLBinding{}, err
+	}
+	for _, b := range bindings {
+		if b.SubnetID == subnetID {
+			return vpc, b, nil
+		}
+	}
+	return nil, ports.SubnetACLBinding{}, errors.New(errors.NotFound, "subnet not found in the network ACL's VPC")
+}
+
+// resyncSubnets reprograms the selected subnets with whatever ACL now governs them.
+func (s *NetworkACLService) resyncSubnets(ctx context.Context, vpcID uuid.UUID, selected func(ports.SubnetACLBinding) bool) {
+	vpc, bindings, err := s.aclBindings(ctx, vpcID)
+	if err != nil {
+		s.logger.Error("failed to list subnet network ACLs", "vpc_id", vpcID, "error", err)
+		return
+	}
+	rules := map[uuid.UUID][]domain.NetworkACLRule{}
+	for _, b := range bindings {
+		if !selected(b) {
+			continue
+		}
+		if _, ok := rules[b.ACLID]; !ok {
+			if rules[b.ACLID], err = s.repo.ListRules(ctx, b.ACLID); err != nil {
+				s.logger.Error("failed to list network ACL rules", "acl_id", b.ACLID, "error", err)
+				continue
+			}
+		}
+		if err := programSubnetACL(ctx, s.network, vpc, b, rules[b.ACLID]); err != nil {
+			s.logger.Error("failed to program network ACL for subnet", "subnet_id", b.SubnetID, "error", err)
+		}
+	}
+}
+
+func (s *NetworkACLService) audit(ctx context.Context, action string, aclID uuid.UUID, details map[string]interface{}) {
+	if err := s.auditSvc.Log(ctx, appcontext.UserIDFromContext(ctx), action, "network_acl", aclID.String(), details); err != nil {
+		s.logger.Warn("failed to log audit event", "action", action, "acl_id", aclID, "error", err)
+	}
+}
+
+// Network ACLs are stateless, so they must see every packet, replies included, before
+// conntrack admits it. The entry flow sends untracked IP packets through the egress
+// table (rules of the source subnet) and then the ingress table (rules of the
+// destination subnet); only packets both allow enter conntrack and reach security
+// groups. Traffic to or from addresses outside any subnet passes the tables untouched.
+//
+// The entry flow only has to outrank conntrack's flow for untracked packets. Security
+// group rules match tracked packets alone, so no packet is ever a candidate for both,
+// and running the ACLs first delivers exactly what running them after the security
+// groups would: a packet passes only if both allow it. Unlike a security group, a deny
+// ACL still drops replies conntrack considers established.
+const (
+	aclEntryPriority        = conntrackPriority + 100
+	aclEgressTable          = 1
+	aclIngressTable         = 2
+	aclImplicitDenyPriority = 1
+)
+
+// aclRulePriority maps rule numbers onto flow priorities so lower numbers win.
+func aclRulePriority(ruleNumber int) int {
+	return domain.NetworkACLMaxRuleNumber + 2 - ruleNumber
+}
+
+// networkACLPipelineFlows are the VPC-wide flows that route packets through the ACL tables.
+// IPv6 neighbor discovery bypasses the ACLs, as ARP does, so a subnet never loses its neighbors.
+func networkACLPipelineFlows(zone int) []ports.FlowRule {
+	flows := []ports.FlowRule{
+		{Priority: aclEntryPriority + 1, Match: "icmp6,icmp_type=135", Actions: "NORMAL"},
+		{Priority: aclEntryPriority + 1, Match: "icmp6,icmp_type=136", Actions: "NORMAL"},
+	}
+	for _, proto := range []string{"ip", "ipv6"} {
+		flows = append(flows,
+			ports.FlowRule{Priority: aclEntryPriority, Match: proto + ",ct_state=-trk", Actions: fmt.Sprintf("resubmit(,%d)", aclEgressTable)},
+			ports.FlowRule{Priority: 0, Match: fmt.Sprintf("table=%d,%s", aclEgressTable, proto), Actions: fmt.Sprintf("resubmit(,%d)", aclIngressTable)},
+			ports.FlowRule{Priority: 0, Match: fmt.Sprintf("table=%d,%s", aclIngressTable, proto), Actions: ctAction("table=0", zone)},
+		)
+	}
+	return flows
+}
+
+// subnetACLCIDRs are the blocks a subnet's ACL flows are scoped to: its IPv4 block and,
+// on dual-stack subnets, its IPv6 block.
+func subnetACLCIDRs(b ports.SubnetACLBinding) []string {
+	if b.IPv6CIDRBlock == "" {
+		return []string{b.CIDRBlock}
+	}
+	return []string{b.CIDRBlock, b.IPv6CIDRBlock}
+}
+
+// aclMatchFields returns the protocol keyword and source and destination fields that
+// match proto within the address family of cidr.
+func aclMatchFields(proto, cidr string) (string, string, string) {
+	if strings.Contains(cidr, ":") {
+		return ipv6Protocols[proto], "ipv6_src", "ipv6_dst"
+	}
+	return proto, "nw_src", "nw_dst"
+}
+
+// subnetACLMatches are the egress and ingress matches covering every ACL flow of a subnet block.
+func subnetACLMatches(cidr string) (string, string) {
+	proto, src, dst := aclMatchFields("ip", cidr)
+	return fmt.Sprintf("table=%d,%s,%s=%s", aclEgressTable, proto, src, cidr),
+		fmt.Sprintf("table=%d,%s,%s=%s", aclIngressTable, proto, dst, cidr)
+}
+
+// networkACLSubnetFlows are a subnet's rule flows plus the implicit deny that ends each
+// direction, for each of its address families.
+func networkACLSubnetFlows(b ports.SubnetACLBinding, rules []domain.NetworkACLRule, zone int) []ports.FlowRule {
+	var flows []ports.FlowRule
+	cookie := flowCookie(b.SubnetID)
+	for _, cidr := range subnetACLCIDRs(b) {
+		for _, rule := range rules {
+			flows = append(flows, networkACLRuleFlows(rule, cidr, zone)...)
+		}
+		egress, ingress := subnetACLMatches(cidr)
+		flows = append(flows,
+			ports.FlowRule{Priority: aclImplicitDenyPriority, Match: egress, Actions: "drop", Cookie: cookie},
+			ports.FlowRule{Priority: aclImplicitDenyPriority, Match: ingress, Actions: "drop", Cookie: cookie},
+		)
+	}
+	return flows
+}
+
+// networkACLRuleFlows expands a rule for one subnet block: one flow per destination port
+// mask. A rule whose CIDR is of the other address family yields no flows.
+func networkACLRuleFlows(rule domain.NetworkACLRule, subnetCIDR string, zone int) []ports.FlowRule {
+	if strings.Contains(rule.CIDR, ":") != strings.Contains(subnetCIDR, ":") {
+		return nil
+	}
+
+	proto := rule.Protocol
+	if proto == "" || proto == "all" {
+		proto = "ip"
+	}
+	portMatches := []string{""}
+	if (proto == "tcp" || proto == "udp") && rule.PortMin > 0 {
+		portMatches = portRangeMasks(rule.PortMin, rule.PortMax)
+	}
+
+	proto, srcField, dstField := aclMatchFields(proto, subnetCIDR)
+	table, subnetField, peerField := aclIngressTable, dstField, srcField
+	allow := ctAction("table=0", zone)
+	if rule.Direction == domain.RuleEgress {
+		table, subnetField, peerField = aclEgressTable, srcField, dstField
+		allow = fmt.Sprintf("resubmit(,%d)", aclIngressTable)
+	}
+	action := allow
+	if rule.Action == domain.NetworkACLDeny {
+		action = "drop"
+	}
+
+	base := []string{fmt.Sprintf("table=%d", table), proto, subnetField + "=" + subnetCIDR}
+	if rule.CIDR != "" && rule.CIDR != "0.0.0.0/0" && rule.CIDR != anyIPv6 {
+		base = append(base, peerField+"="+rule.CIDR)
+	}
+
+	flows := make([]ports.FlowRule, 0, len(portMatches))
+	for _, port := range portMatches {
+		match := base
+		if port != "" {
+			match = append(append([]string{}, base...), "tp_dst="+port)
+		}
+		flows = append(flows, ports.FlowRule{
+			Priority: aclRulePriority(rule.RuleNumber),
+			Match:    strings.Join(match, ","),
+			Actions:  action,
+			Cookie:   flowCookie(rule.ID),
+		})
+	}
+	return flows
+}
+
+// programSubnetACL replaces a subnet's ACL flows with those of the ACL now governing it.
+// Replacement is not atomic: for a moment the subnet's traffic passes unfiltered.
+func programSubnetACL(ctx context.Context, network ports.NetworkBackend, vpc *domain.VPC, b ports.SubnetACLBinding, rules []domain.NetworkACLRule) error {
+	if err := removeSubnetACLFlows(ctx, network, vpc, subnetACLCIDRs(b)...); err != nil {
+		return err
+	}
+	flows := append(networkACLPipelineFlows(vpc.VXLANID), networkACLSubnetFlows(b, rules, vpc.VXLANID)...)
+	for _, flow := range flows {
+		if err := network.AddFlowRule(ctx, vpc.NetworkID, flow); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeSubnetACLFlows deletes every ACL flow scoped to a subnet's CIDRs; empty CIDRs are skipped.
+func removeSubnetACLFlows(ctx context.Context, network ports.NetworkBackend, vpc *domain.VPC, cidrs ...string) error {
+	for _, cidr := range cidrs {
+		if cidr == "" {
+			continue
+		}
+		egress, ingress := subnetACLMatches(cidr)
+		for _, match := range []string{egress, ingress} {
+			if err := network.DeleteFlowRule(ctx, vpc.NetworkID, match); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// networkACLFlows lists every ACL flow a VPC calls for; a VPC without subnets needs none.
+func networkACLFlows(ctx context.Context, repo ports.NetworkACLRepository, vpc *domain.VPC) ([]ports.FlowRule, error) {
+	bindings, err := repo.ListSubnetBindings(ctx, vpc.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(bindings) == 0 {
+		return nil, nil
+	}
+
+	flows := networkACLPipelineFlows(vpc.VXLANID)
+	rules := map[uuid.UUID][]domain.NetworkACLRule{}
+	for _, b := range bindings {
+		if _, ok := rules[b.ACLID]; !ok {
+			if rules[b.ACLID], err = repo.ListRules(ctx, b.ACLID); err != nil {
+				return nil, err
+			}
+		}
+		flows = append(flows, networkACLSubnetFlows(b, rules[b.ACLID], vpc.VXLANID)...)
+	}
+	return flows, nil
+}
@@ -0,0 +1,152 @@
+package services
+
+import (
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+)
+
+// testPacket is a packet walked through a bridge's flows by walkFlows.
+type testPacket struct {
+	proto    string // OVS protocol keyword, e.g. "tcp" or "tcp6"
+	src, dst string
+	dstPort  int
+	ctState  string // what conntrack reports once the packet is tracked: "new" or "est"
+}
+
+var ctFlagPattern = regexp.MustCompile(`[+-][a-z]+`)
+
+// walkFlows evaluates flows the way OVS does: in each table the highest-priority
+// matching flow acts. It understands the matches and actions of the conntrack,
+// security group and network ACL flows, and reports whether the packet is delivered.
+func walkFlows(t *testing.T, flows []ports.FlowRule, pkt testPacket) bool {
+	t.Helper()
+	table, tracked := 0, false
+	for hops := 0; hops < 8; hops++ {
+		var best *ports.FlowRule
+		for i := range flows {
+			if flowMatches(t, flows[i].Match, table, tracked, pkt) && (best == nil || flows[i].Priority > best.Priority) {
+				best = &flows[i]
+			}
+		}
+		switch {
+		case best == nil || best.Actions == "drop":
+			return false
+		case strings.HasSuffix(best.Actions, "NORMAL"):
+			return true
+		case strings.HasPrefix(best.Actions, "resubmit(,"):
+			table, _ = strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(best.Actions, "resubmit(,"), ")"))
+		case strings.HasPrefix(best.Actions, "ct(table=0"):
+			table, tracked = 0, true
+		default:
+			t.Fatalf("unsupported action %q", best.Actions)
+		}
+	}
+	t.Fatalf("packet %+v loops through the flow tables", pkt)
+	return false
+}
+
+func flowMatches(t *testing.T, match string, table int, tracked bool, pkt testPacket) bool {
+	t.Helper()
+	ipv6 := strings.Contains(pkt.src, ":")
+	flowTable := 0
+	for _, part := range strings.Split(match, ",") {
+		key, value, _ := strings.Cut(part, "=")
+		ok := true
+		switch key {
+		case "table":
+			flowTable, _ = strconv.Atoi(value)
+		case "ip":
+			ok = !ipv6
+		case "ipv6":
+			ok = ipv6
+		case "tcp", "udp", "icmp", "tcp6", "udp6", "icmp6":
+			ok = key == pkt.proto
+		case "icmp_type":
+			ok = false
+		case "ct_state":
+			for _, flag := range ctFlagPattern.FindAllString(value, -1) {
+				set := flag[1:] == "trk" && tracked || tracked && flag[1:] == pkt.ctState
+				ok = ok && set == (flag[0] == '+')
+			}
+		case "nw_src", "ipv6_src":
+			ok = cidrContains(t, value, pkt.src)
+		case "nw_dst", "ipv6_dst":
+			ok = cidrContains(t, value, pkt.dst)
+		case "tp_dst":
+			portValue, mask, masked := strings.Cut(value, "/")
+			want, _ := strconv.ParseInt(portValue, 0, 32)
+			m := int64(0xffff)
+			if masked {
+				m, _ = strconv.ParseInt(mask, 0, 32)
+			}
+			ok = int64(pkt.dstPort)&m == want
+		default:
+			t.Fatalf("unsupported match field %q in %q", key, match)
+		}
+		if !ok {
+			return false
+		}
+	}
+	return flowTable == table
+}
+
+func cidrContains(t *testing.T, cidr, addr string) bool {
+	t.Helper()
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		t.Fatalf("invalid CIDR %q in flow", cidr)
+	}
+	return ipNet.Contains(net.ParseIP(addr))
+}
+
+func TestNetworkACLPipelineWithSecurityGroups(t *testing.T) {
+	const zone = 101
+	binding := ports.SubnetACLBinding{SubnetID: uuid.New(), CIDRBlock: "10.0.1.0/24", IPv6CIDRBlock: "fd12:3456:7800::/64", ACLID: uuid.New()}
+	aclRule := func(number int, dir domain.RuleDirection, proto string, portMin, portMax int, cidr string, action domain.NetworkACLAction) domain.NetworkACLRule {
+		return domain.NetworkACLRule{ID: uuid.New(), RuleNumber: number, Direction: dir, Protocol: proto, PortMin: portMin, PortMax: portMax, CIDR: cidr, Action: action}
+	}
+
+	// The security group admits new HTTP connections; conntrack admits the rest of them.
+	flows := conntrackFlows(zone)
+	flows = append(flows, securityRuleFlows(domain.SecurityRule{ID: uuid.New(), Direction: domain.RuleIngress, Protocol: "tcp", PortMin: 80, PortMax: 80, Priority: 100}, []string{""}, zone)...)
+	flows = append(flows, networkACLPipelineFlows(zone)...)
+
+	request := testPacket{proto: "tcp", src: "198.51.100.9", dst: "10.0.1.5", dstPort: 80, ctState: "new"}
+	reply := testPacket{proto: "tcp", src: "10.0.1.5", dst: "198.51.100.9", dstPort: 40000, ctState: "est"}
+	ipv6Reply := testPacket{proto: "tcp6", src: "fd12:3456:7800::5", dst: "2001:db8::9", dstPort: 40000, ctState: "est"}
+	ssh := testPacket{proto: "tcp", src: "198.51.100.9", dst: "10.0.1.5", dstPort: 22, ctState: "new"}
+
+	t.Run("DenyACLDropsEstablishedReplies", func(t *testing.T) {
+		// Inbound HTTP is allowed, but nothing may leave the subnet.
+		acl := networkACLSubnetFlows(binding, []domain.NetworkACLRule{
+			aclRule(100, domain.RuleIngress, "tcp", 80, 80, "0.0.0.0/0", domain.NetworkACLAllow),
+			aclRule(100, domain.RuleEgress, "all", 0, 0, "0.0.0.0/0", domain.NetworkACLDeny),
+		}, zone)
+		all := append(append([]ports.FlowRule{}, flows...), acl...)
+
+		assert.True(t, walkFlows(t, all, request))
+		assert.False(t, walkFlows(t, all, reply), "conntrack must not let replies bypass a deny ACL")
+		assert.False(t, walkFlows(t, all, ipv6Reply), "IPv6 replies hit the implicit deny")
+	})
+
+	t.Run("BothLayersMustAllow", func(t *testing.T) {
+		acl := networkACLSubnetFlows(binding, []domain.NetworkACLRule{
+			aclRule(100, domain.RuleIngress, "all", 0, 0, "0.0.0.0/0", domain.NetworkACLAllow),
+			aclRule(100, domain.RuleEgress, "tcp", 1024, 65535, "0.0.0.0/0", domain.NetworkACLAllow),
+		}, zone)
+		all := append(append([]ports.FlowRule{}, flows...), acl...)
+
+		assert.True(t, walkFlows(t, all, request))
+		assert.True(t, walkFlows(t, all, reply))
+		assert.False(t, walkFlows(t, all, ssh), "the ACL allows SSH but the security group does not")
+		assert.False(t, walkFlows(t, all, ipv6Reply), "an IPv4 rule must not allow IPv6 traffic")
+	})
+}
@@ -0,0 +1,248 @@
+package services_test
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/core/services"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkACLService(t *testing.T) {
+	ctx := appcontext.WithTenantID(appcontext.WithUserID(context.Background(), uuid.New()), uuid.New())
+	vpc := &domain.VPC{ID: uuid.New(), NetworkID: "br-vpc", VXLANID: 101}
+	aclID := uuid.New()
+	defaultID := uuid.New()
+	subnetID := uuid.New()
+
+	type mocks struct {
+		repo    *MockNetworkACLRepo
+		vpcRepo *MockVpcRepo
+		network *MockNetworkBackend
+		rbacSvc *MockRBACService
+		audit   *MockAuditService
+	}
+
+	setup := func() (*services.NetworkACLService, *mocks) {
+		m := &mocks{
+			repo:    new(MockNetworkACLRepo),
+			vpcRepo: new(MockVpcRepo),
+			network: new(MockNetworkBackend),
+			rbacSvc: new(MockRBACService),
+			audit:   new(MockAuditService),
+		}
+		m.rbacSvc.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+		m.audit.On("Log", mock.Anything, mock.Anything, mock.Anything, "network_acl", mock.Anything, mock.Anything).Return(nil).Maybe()
+		m.vpcRepo.On("GetByID", mock.Anything, vpc.ID).Return(vpc, nil).Maybe()
+		svc := services.NewNetworkACLService(services.NetworkACLServiceParams{
+			Repo:     m.repo,
+			VpcRepo:  m.vpcRepo,
+			RBACSvc:  m.rbacSvc,
+			Network:  m.network,
+			AuditSvc: m.audit,
+			Logger:   slog.Default(),
+		})
+		return svc, m
+	}
+
+	flowsAdded := func(m *mocks) []ports.FlowRule {
+		var flows []ports.FlowRule
+		for _, call := range m.network.Calls {
+			if call.Method == "AddFlowRule" {
+				flows = append(flows, call.Arguments.Get(2).(ports.FlowRule))
+			}
+		}
+		return flows
+	}
+
+	t.Run("CreateStartsEmpty", func(t *testing.T) {
+		svc, m := setup()
+		m.repo.On("Create", mock.Anything, mock.MatchedBy(func(a *domain.NetworkACL) bool {
+			return a.VPCID == vpc.ID && !a.IsDefault && len(a.Rules) == 0
+		})).Return(nil)
+
+		acl, err := svc.CreateNetworkACL(ctx, vpc.ID, "web")
+		require.NoError(t, err)
+		assert.Equal(t, "web", acl.Name)
+	})
+
+	t.Run("AddRuleProgramsBoundSubnets", func(t *testing.T) {
+		svc, m := setup()
+		m.repo.On("GetByID", mock.Anything, aclID).Return(&domain.NetworkACL{ID: aclID, VPCID: vpc.ID}, nil)
+		m.repo.On("AddRule", mock.Anything, mock.Anything).Return(nil)
+		m.repo.On("ListSubnetBindings", mock.Anything, vpc.ID).Return([]ports.SubnetACLBinding{
+			{SubnetID: subnetID, CIDRBlock: "10.0.1.0/24", ACLID: aclID},
+			{SubnetID: uuid.New(), CIDRBlock: "10.0.2.0/24", ACLID: defaultID},
+		}, nil)
+		m.network.On("AddFlowRule", mock.Anything, "br-vpc", mock.Anything).Return(nil)
+
+		rule, err := svc.AddRule(ctx, aclID, domain.NetworkACLRule{
+			RuleNumber: 90, Direction: domain.RuleIngress, Protocol: "tcp", PortMin: 22, CIDR: "203.0.113.7/24", Action: domain.NetworkACLDeny,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 22, rule.PortMax)
+		assert.Equal(t, "203.0.113.0/24", rule.CIDR)
+
+		flows := flowsAdded(m)
+		require.Len(t, flows, 1)
+		assert.Equal(t, "table=2,tcp,nw_dst=10.0.1.0/24,nw_src=203.0.113.0/24,tp_dst=22", flows[0].Match)
+		assert.Equal(t, "drop", flows[0].Actions)
+		assert.Equal(t, domain.NetworkACLMaxRuleNumber+2-90, flows[0].Priority)
+	})
+
+	t.Run("AddRuleFiltersMatchingAddressFamily", func(t *testing.T) {
+		svc, m := setup()
+		m.repo.On("GetByID", mock.Anything, aclID).Return(&domain.NetworkACL{ID: aclID, VPCID: vpc.ID}, nil)
+		m.repo.On("AddRule", mock.Anything, mock.Anything).Return(nil)
+		m.repo.On("ListSubnetBindings", mock.Anything, vpc.ID).Return([]ports.SubnetACLBinding{
+			{SubnetID: subnetID, CIDRBlock: "10.0.1.0/24", IPv6CIDRBlock: "fd12:3456:7800::/64", ACLID: aclID},
+		}, nil)
+		m.network.On("AddFlowRule", mock.Anything, "br-vpc", mock.Anything).Return(nil)
+
+		_, err := svc.AddRule(ctx, aclID, domain.NetworkACLRule{
+			RuleNumber: 90, Direction: domain.RuleEgress, Protocol: "tcp", PortMin: 443, CIDR: "2001:db8::1/32", Action: domain.NetworkACLDeny,
+		})
+		require.NoError(t, err)
+
+		flows := flowsAdded(m)
+		require.Len(t, flows, 1)
+		assert.Equal(t, "table=1,tcp6,ipv6_src=fd12:3456:7800::/64,ipv6_dst=2001:db8::/32,tp_dst=443", flows[0].Match)
+		assert.Equal(t, "drop", flows[0].Actions)
+	})
+
+	t.Run("DualStackSubnetFiltersIPv6", func(t *testing.T) {
+		svc, m := setup()
+		m.repo.On("GetByID", mock.Anything, defaultID).Return(&domain.NetworkACL{
+			ID: defaultID, VPCID: vpc.ID, IsDefault: true, Rules: domain.DefaultNetworkACLRules(defaultID),
+		}, nil)
+		m.repo.On("ListSubnetBindings", mock.Anything, vpc.ID).Return([]ports.SubnetACLBinding{
+			{SubnetID: subnetID, CIDRBlock: "10.0.1.0/24", IPv6CIDRBlock: "fd12:3456:7800::/64", ACLID: aclID},
+		}, nil)
+		m.repo.On("DisassociateSubnet", mock.Anything, subnetID).Return(nil)
+		m.network.On("DeleteFlowRule", mock.Anything, "br-vpc", mock.Anything).Return(nil)
+		m.network.On("AddFlowRule", mock.Anything, "br-vpc", mock.Anything).Return(nil)
+
+		require.NoError(t, svc.AssociateSubnet(ctx, defaultID, subnetID))
+		m.network.AssertCalled(t, "DeleteFlowRule", mock.Anything, "br-vpc", "table=1,ipv6,ipv6_src=fd12:3456:7800::/64")
+		m.network.AssertCalled(t, "DeleteFlowRule", mock.Anything, "br-vpc", "table=2,ipv6,ipv6_dst=fd12:3456:7800::/64")
+
+		// Rule flows are added before the implicit deny that shares their match, so keep the first.
+		actions := map[string]string{}
+		for _, f := range flowsAdded(m) {
+			if _, ok := actions[f.Match]; !ok {
+				actions[f.Match] = f.Actions
+			}
+		}
+		assert.Equal(t, "resubmit(,1)", actions["ipv6,ct_state=-trk"])
+		assert.Equal(t, "NORMAL", actions["icmp6,icmp_type=135"])
+		assert.Equal(t, "resubmit(,2)", actions["table=1,ipv6,ipv6_src=fd12:3456:7800::/64"])
+		assert.Equal(t, "ct(table=0,zone=101)", actions["table=2,ipv6,ipv6_dst=fd12:3456:7800::/64"])
+		// The IPv4 allow-all rule must not leak into the IPv6 tables or vice versa.
+		assert.Equal(t, "resubmit(,2)", actions["table=1,ip,nw_src=10.0.1.0/24"])
+		for match := range actions {
+			assert.NotContains(t, match, "ip,nw_src=fd12")
+			assert.NotContains(t, match, "ipv6,ipv6_src=10.0")
+		}
+	})
+
+	t.Run("AddRuleRejectsInvalidRule", func(t *testing.T) {
+		svc, m := setup()
+		m.repo.On("GetByID", mock.Anything, aclID).Return(&domain.NetworkACL{ID: aclID, VPCID: vpc.ID}, nil)
+
+		_, err := svc.AddRule(ctx, aclID, domain.NetworkACLRule{RuleNumber: 0, Direction: domain.RuleEgress, CIDR: "0.0.0.0/0", Action: domain.NetworkACLAllow})
+		assert.True(t, errors.Is(err, errors.InvalidInput))
+		m.repo.AssertNotCalled(t, "AddRule", mock.Anything, mock.Anything)
+	})
+
+	t.Run("DeleteDefaultRejected", func(t *testing.T) {
+		svc, m := setup()
+		m.repo.On("GetByID", mock.Anything, defaultID).Return(&domain.NetworkACL{ID: defaultID, VPCID: vpc.ID, IsDefault: true}, nil)
+
+		err := svc.DeleteNetworkACL(ctx, defaultID)
+		assert.True(t, errors.Is(err, errors.InvalidInput))
+		m.repo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("DeleteRevertsSubnetsToDefault", func(t *testing.T) {
+		svc, m := setup()
+		m.repo.On("GetByID", mock.Anything, aclID).Return(&domain.NetworkACL{
+			ID: aclID, VPCID: vpc.ID, Associations: []domain.NetworkACLAssociation{{ACLID: aclID, SubnetID: subnetID}},
+		}, nil)
+		m.repo.On("Delete", mock.Anything, aclID).Return(nil)
+		m.repo.On("ListSubnetBindings", mock.Anything, vpc.ID).Return([]ports.SubnetACLBinding{
+			{SubnetID: subnetID, CIDRBlock: "10.0.1.0/24", ACLID: defaultID},
+		}, nil)
+		m.repo.On("ListRules", mock.Anything, defaultID).Return(domain.DefaultNetworkACLRules(defaultID), nil)
+		m.network.On("DeleteFlowRule", mock.Anything, "br-vpc", mock.Anything).Return(nil)
+		m.network.On("AddFlowRule", mock.Anything, "br-vpc", mock.Anything).Return(nil)
+
+		require.NoError(t, svc.DeleteNetworkACL(ctx, aclID))
+		m.network.AssertCalled(t, "DeleteFlowRule", mock.Anything, "br-vpc", "table=1,ip,nw_src=10.0.1.0/24")
+		m.network.AssertCalled(t, "DeleteFlowRule", mock.Anything, "br-vpc", "table=2,ip,nw_dst=10.0.1.0/24")
+
+		var matches []string
+		for _, f := range flowsAdded(m) {
+			matches = append(matches, f.Match)
+		}
+		assert.Contains(t, matches, "ip,ct_state=-trk")
+		assert.Contains(t, matches, "table=1,ip,nw_src=10.0.1.0/24")
+		assert.Contains(t, matches, "table=2,ip,nw_dst=10.0.1.0/24")
+	})
+
+	t.Run("AssociateSubnetOutsideVPC", func(t *testing.T) {
+		svc, m := setup()
+		m.repo.On("GetByID", mock.Anything, aclID).Return(&domain.NetworkACL{ID: aclID, VPCID: vpc.ID}, nil)
+		m.repo.On("ListSubnetBindings", mock.Anything, vpc.ID).Return([]ports.SubnetACLBinding{}, nil)
+
+		err := svc.AssociateSubnet(ctx, aclID, subnetID)
+		assert.True(t, errors.Is(err, errors.NotFound))
+		m.repo.AssertNotCalled(t, "AssociateSubnet", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("AssociateDefaultDropsAssociation", func(t *testing.T) {
+		svc, m := setup()
+		m.repo.On("GetByID", mock.Anything, defaultID).Return(&domain.NetworkACL{ID: defaultID, VPCID: vpc.ID, IsDefault: true}, nil)
+		m.repo.On("ListSubnetBindings", mock.Anything, vpc.ID).Return([]ports.SubnetACLBinding{
+			{SubnetID: subnetID, CIDRBlock: "10.0.1.0/24", ACLID: aclID},
+		}, nil)
+		m.repo.On("DisassociateSubnet", mock.Anything, subnetID).Return(nil)
+		m.network.On("DeleteFlowRule", mock.Anything, "br-vpc", mock.Anything).Return(nil)
+		m.network.On("AddFlowRule", mock.Anything, "br-vpc", mock.Anything).Return(nil)
+
+		require.NoError(t, svc.AssociateSubnet(ctx, defaultID, subnetID))
+		m.repo.AssertNotCalled(t, "AssociateSubnet", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("DisassociateRequiresAssociation", func(t *testing.T) {
+		svc, m := setup()
+		m.repo.On("GetByID", mock.Anything, aclID).Return(&domain.NetworkACL{ID: aclID, VPCID: vpc.ID}, nil)
+		m.repo.On("ListSubnetBindings", mock.Anything, vpc.ID).Return([]ports.SubnetACLBinding{
+			{SubnetID: subnetID, CIDRBlock: "10.0.1.0/24", ACLID: defaultID},
+		}, nil)
+
+		err := svc.DisassociateSubnet(ctx, aclID, subnetID)
+		assert.True(t, errors.Is(err, errors.InvalidInput))
+	})
+
+	t.Run("RemoveRuleDeletesItsFlows", func(t *testing.T) {
+		svc, m := setup()
+		ruleID := uuid.New()
+		m.repo.On("GetByID", mock.Anything, aclID).Return(&domain.NetworkACL{ID: aclID, VPCID: vpc.ID}, nil)
+		m.repo.On("RemoveRule", mock.Anything, aclID, ruleID).Return(nil)
+		m.network.On("DeleteFlowRule", mock.Anything, "br-vpc", mock.Anything).Return(nil)
+
+		require.NoError(t, svc.RemoveRule(ctx, aclID, ruleID))
+		m.network.AssertCalled(t, "DeleteFlowRule", mock.Anything, "br-vpc", mock.MatchedBy(func(match string) bool {
+			return strings.HasPrefix(match, "cookie=")
+		}))
+	})
+}
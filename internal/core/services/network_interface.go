@@ -0,0 +1,755 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"time"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const networkInterfaceTracer = "network-interface-service"
+
+// NetworkInterfaceService manages elastic network interfaces: secondary NICs
+// with their own private IPs and security groups that are hot-plugged into
+// instances and can be moved between them for failover.
+type NetworkInterfaceService struct {
+	repo         ports.NetworkInterfaceRepository
+	subnetRepo   ports.SubnetRepository
+	vpcRepo      ports.VpcRepository
+	instanceRepo ports.InstanceRepository
+	eipRepo      ports.ElasticIPRepository
+	sgRepo       ports.SecurityGroupRepository
+	sgSvc        ports.SecurityGroupService
+	compute      ports.ComputeBackend
+	network      ports.NetworkBackend
+	rbacSvc      ports.RBACService
+	auditSvc     ports.AuditService
+	logger       *slog.Logger
+}
+
+// NetworkInterfaceServiceParams holds dependencies for NetworkInterfaceService.
+type NetworkInterfaceServiceParams struct {
+	Repo         ports.NetworkInterfaceRepository
+	SubnetRepo   ports.SubnetRepository
+	VpcRepo      ports.VpcRepository
+	InstanceRepo ports.InstanceRepository
+	EIPRepo      ports.ElasticIPRepository
+	SGRepo       ports.SecurityGroupRepository
+	SGSvc        ports.SecurityGroupService
+	Compute      ports.ComputeBackend
+	Network      ports.NetworkBackend // Optional, creates host-side ports for container backends
+	RBACSvc      ports.RBACService
+	AuditSvc     ports.AuditService
+	Logger       *slog.Logger
+}
+
+// NewNetworkInterfaceService constructs a NetworkInterfaceService with its dependencies.
+func NewNetworkInterfaceService(params NetworkInterfaceServiceParams) *NetworkInterfaceService {
+	logger := params.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &NetworkInterfaceService{
+		repo:         params.Repo,
+		subnetRepo:   params.SubnetRepo,
+		vpcRepo:      params.VpcRepo,
+		instanceRepo: params.InstanceRepo,
+		eipRepo:      params.EIPRepo,
+		sgRepo:       params.SGRepo,
+		sgSvc:        params.SGSvc,
+		compute:      params.Compute,
+		network:      params.Network,
+		rbacSvc:      params.RBACSvc,
+		auditSvc:     params.AuditSvc,
+		logger:       logger,
+	}
+}
+
+// CreateNetworkInterface creates an available interface in a subnet.
+func (s *NetworkInterfaceService) CreateNetworkInterface(ctx context.Context, subnetID uuid.UUID, description string, privateIPs []string, securityGroupIDs []uuid.UUID) (*domain.NetworkInterface, error) {
+	ctx, span := otel.Tracer(networkInterfaceTracer).Start(ctx, "CreateNetworkInterface")
+	defer span.End()
+
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcCreate, "*"); err != nil {
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.String("subnet_id", subnetID.String()))
+
+	subnet, err := s.subnetRepo.GetByID(ctx, subnetID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateSecurityGroups(ctx, subnet.VPCID, securityGroupIDs); err != nil {
+		return nil, err
+	}
+
+	ips, err := s.reserveIPs(ctx, subnet, nil, privateIPs, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(ips) == 0 {
+		if ips, err = s.reserveIPs(ctx, subnet, nil, nil, 1); err != nil {
+			return nil, err
+		}
+	}
+
+	id := uuid.New()
+	now := time.Now()
+	ni := &domain.NetworkInterface{
+		ID:          id,
+		UserID:      userID,
+		TenantID:    tenantID,
+		VpcID:       subnet.VPCID,
+		SubnetID:    subnet.ID,
+		Description: description,
+		PrivateIPs:  ips,
+		MACAddress:  fmt.Sprintf("02:%02x:%02x:%02x:%02x:%02x", id[0], id[1], id[2], id[3], id[4]),
+		OvsPort:     "eni-" + id.String()[:8],
+		Status:      domain.NetworkInterfaceStatusAvailable,
+		ARN:         fmt.Sprintf("arn:thecloud:vpc:local:%s:network-interface/%s", userID.String(), id.String()),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.repo.Create(ctx, ni); err != nil {
+		return nil, err
+	}
+
+	for _, groupID := range securityGroupIDs {
+		if err := s.sgSvc.AttachToNetworkInterface(ctx, ni.ID, groupID); err != nil {
+			return nil, err
+		}
+		ni.SecurityGroupIDs = append(ni.SecurityGroupIDs, groupID)
+	}
+
+	if err := s.auditSvc.Log(ctx, userID, "network_interface.create", "network_interface", ni.ID.String(), map[string]interface{}{
+		"subnet_id":   subnet.ID.String(),
+		"private_ips": ni.PrivateIPs,
+	}); err != nil {
+		s.logger.Warn("failed to log audit event", "action", "network_interface.create", "error", err)
+	}
+
+	return ni, nil
+}
+
+// GetNetworkInterface retrieves a network interface by ID.
+func (s *NetworkInterfaceService) GetNetworkInterface(ctx context.Context, id uuid.UUID) (*domain.NetworkInterface, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcRead, id.String()); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetByID(ctx, id)
+}
+
+// ListNetworkInterfaces returns the network interfaces of the current tenant.
+func (s *NetworkInterfaceService) ListNetworkInterfaces(ctx context.Context) ([]*domain.NetworkInterface, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcRead, "*"); err != nil {
+		return nil, err
+	}
+
+	return s.repo.List(ctx)
+}
+
+// DeleteNetworkInterface removes a detached interface together with its
+// security group memberships.
+func (s *NetworkInterfaceService) DeleteNetworkInterface(ctx context.Context, id uuid.UUID) error {
+	ctx, span := otel.Tracer(networkInterfaceTracer).Start(ctx, "DeleteNetworkInterface")
+	defer span.End()
+
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcDelete, id.String()); err != nil {
+		return err
+	}
+
+	ni, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if ni.InstanceID != nil {
+		return errors.New(errors.Conflict, "network interface is attached to an instance; detach it first")
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	// Memberships cascade with the interface; detaching still resyncs the
+	// groups whose rules reference its addresses.
+	for _, groupID := range ni.SecurityGroupIDs {
+		if err := s.sgSvc.DetachFromNetworkInterface(ctx, ni.ID, groupID); err != nil {
+			s.logger.Warn("failed to resync security group after interface deletion", "interface_id", ni.ID, "group_id", groupID, "error", err)
+		}
+	}
+
+	if err := s.auditSvc.Log(ctx, userID, "network_interface.delete", "network_interface", id.String(), map[string]interface{}{}); err != nil {
+		s.logger.Warn("failed to log audit event", "action", "network_interface.delete", "error", err)
+	}
+
+	return nil
+}
+
+// AssignPrivateIPs adds secondary addresses to an interface. Addresses added
+// while the interface is attached are configured in the guest on its next attach.
+func (s *NetworkInterfaceService) AssignPrivateIPs(ctx context.Context, id uuid.UUID, privateIPs []string, count int) (*domain.NetworkInterface, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcUpdate, id.String()); err != nil {
+		return nil, err
+	}
+	if count < 0 {
+		return nil, errors.New(errors.InvalidInput, "count cannot be negative")
+	}
+	if len(privateIPs) == 0 && count == 0 {
+		return nil, errors.New(errors.InvalidInput, "private_ips or count is required")
+	}
+
+	ni, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	subnet, err := s.subnetRepo.GetByID(ctx, ni.SubnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	ips, err := s.reserveIPs(ctx, subnet, ni.PrivateIPs, privateIPs, count)
+	if err != nil {
+		return nil, err
+	}
+	ni.PrivateIPs = append(ni.PrivateIPs, ips...)
+	ni.UpdatedAt = time.Now()
+	if err := s.repo.Update(ctx, ni); err != nil {
+		return nil, err
+	}
+	s.refreshGroups(ctx, ni)
+	s.refreshSourceCheck(ctx, ni)
+
+	if err := s.auditSvc.Log(ctx, userID, "network_interface.assign_ips", "network_interface", id.String(), map[string]interface{}{
+		"private_ips": ips,
+	}); err != nil {
+		s.logger.Warn("failed to log audit event", "action", "network_interface.assign_ips", "error", err)
+	}
+
+	return ni, nil
+}
+
+// UnassignPrivateIPs removes secondary addresses that no Elastic IP is associated with.
+func (s *NetworkInterfaceService) UnassignPrivateIPs(ctx context.Context, id uuid.UUID, privateIPs []string) (*domain.NetworkInterface, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcUpdate, id.String()); err != nil {
+		return nil, err
+	}
+	if len(privateIPs) == 0 {
+		return nil, errors.New(errors.InvalidInput, "private_ips is required")
+	}
+
+	ni, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	remove := make(map[string]bool, len(privateIPs))
+	for _, ip := range privateIPs {
+		if ip == ni.PrimaryIP() {
+			return nil, errors.New(errors.InvalidInput, "the primary private ip cannot be unassigned")
+		}
+		if !ni.HasPrivateIP(ip) {
+			return nil, errors.New(errors.InvalidInput, fmt.Sprintf("private ip %s is not assigned to the network interface", ip))
+		}
+		remove[ip] = true
+	}
+
+	eips, err := s.eipRepo.ListByNetworkInterface(ctx, ni.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, eip := range eips {
+		if remove[eip.PrivateIP] {
+			return nil, errors.New(errors.Conflict, fmt.Sprintf("private ip %s has an associated elastic ip", eip.PrivateIP))
+		}
+	}
+
+	kept := make([]string, 0, len(ni.PrivateIPs))
+	for _, ip := range ni.PrivateIPs {
+		if !remove[ip] {
+			kept = append(kept, ip)
+		}
+	}
+	ni.PrivateIPs = kept
+	ni.UpdatedAt = time.Now()
+	if err := s.repo.Update(ctx, ni); err != nil {
+		return nil, err
+	}
+	s.refreshGroups(ctx, ni)
+	s.refreshSourceCheck(ctx, ni)
+
+	if err := s.auditSvc.Log(ctx, userID, "network_interface.unassign_ips", "network_interface", id.String(), map[string]interface{}{
+		"private_ips": privateIPs,
+	}); err != nil {
+		s.logger.Warn("failed to log audit event", "action", "network_interface.unassign_ips", "error", err)
+	}
+
+	return ni, nil
+}
+
+// SetSecurityGroups replaces the security groups of an interface.
+func (s *NetworkInterfaceService) SetSecurityGroups(ctx context.Context, id uuid.UUID, securityGroupIDs []uuid.UUID) (*domain.NetworkInterface, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcUpdate, id.String()); err != nil {
+		return nil, err
+	}
+
+	ni, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateSecurityGroups(ctx, ni.VpcID, securityGroupIDs); err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[uuid.UUID]bool, len(securityGroupIDs))
+	for _, groupID := range securityGroupIDs {
+		wanted[groupID] = true
+	}
+	current := make(map[uuid.UUID]bool, len(ni.SecurityGroupIDs))
+	for _, groupID := range ni.SecurityGroupIDs {
+		current[groupID] = true
+		if !wanted[groupID] {
+			if err := s.sgSvc.DetachFromNetworkInterface(ctx, ni.ID, groupID); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, groupID := range securityGroupIDs {
+		if !current[groupID] {
+			if err := s.sgSvc.AttachToNetworkInterface(ctx, ni.ID, groupID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return s.repo.GetByID(ctx, id)
+}
+
+// AttachNetworkInterface hot-plugs an available interface into a running instance.
+func (s *NetworkInterfaceService) AttachNetworkInterface(ctx context.Context, id, instanceID uuid.UUID, deviceIndex int) (*domain.NetworkInterface, error) {
+	ctx, span := otel.Tracer(networkInterfaceTracer).Start(ctx, "AttachNetworkInterface")
+	defer span.End()
+
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcUpdate, id.String()); err != nil {
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.String("interface_id", id.String()),
+		attribute.String("instance_id", instanceID.String()),
+	)
+
+	ni, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if ni.InstanceID != nil {
+		if *ni.InstanceID == instanceID {
+			return ni, nil
+		}
+		return nil, errors.New(errors.Conflict, "network interface is already attached to another instance")
+	}
+
+	inst, err := s.targetInstance(ctx, ni, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attach(ctx, ni, inst, deviceIndex); err != nil {
+		return nil, err
+	}
+
+	if err := s.auditSvc.Log(ctx, userID, "network_interface.attach", "network_interface", id.String(), map[string]interface{}{
+		"instance_id":  instanceID.String(),
+		"device_index": ni.DeviceIndex,
+	}); err != nil {
+		s.logger.Warn("failed to log audit event", "action", "network_interface.attach", "error", err)
+	}
+
+	return ni, nil
+}
+
+// DetachNetworkInterface unplugs an interface from its instance.
+func (s *NetworkInterfaceService) DetachNetworkInterface(ctx context.Context, id uuid.UUID) (*domain.NetworkInterface, error) {
+	ctx, span := otel.Tracer(networkInterfaceTracer).Start(ctx, "DetachNetworkInterface")
+	defer span.End()
+
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcUpdate, id.String()); err != nil {
+		return nil, err
+	}
+
+	ni, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if ni.InstanceID == nil {
+		return nil, errors.New(errors.InvalidInput, "network interface is not attached")
+	}
+	oldInstanceID := *ni.InstanceID
+
+	if err := s.detach(ctx, ni, false); err != nil {
+		return nil, err
+	}
+
+	if err := s.auditSvc.Log(ctx, userID, "network_interface.detach", "network_interface", id.String(), map[string]interface{}{
+		"instance_id": oldInstanceID.String(),
+	}); err != nil {
+		s.logger.Warn("failed to log audit event", "action", "network_interface.detach", "error", err)
+	}
+
+	return ni, nil
+}
+
+// MoveNetworkInterface moves an interface, with its addresses and Elastic IPs,
+// to another instance of the same VPC.
+func (s *NetworkInterfaceService) MoveNetworkInterface(ctx context.Context, id, instanceID uuid.UUID) (*domain.NetworkInterface, error) {
+	ctx, span := otel.Tracer(networkInterfaceTracer).Start(ctx, "MoveNetworkInterface")
+	defer span.End()
+
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcUpdate, id.String()); err != nil {
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.String("interface_id", id.String()),
+		attribute.String("instance_id", instanceID.String()),
+	)
+
+	ni, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if ni.InstanceID != nil && *ni.InstanceID == instanceID {
+		return ni, nil
+	}
+
+	// Validate the target before unplugging so a bad request leaves the interface in place.
+	inst, err := s.targetInstance(ctx, ni, instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var oldInstanceID *uuid.UUID
+	preferredIndex := ni.DeviceIndex
+	if ni.InstanceID != nil {
+		oldInstanceID = ni.InstanceID
+		if err := s.detach(ctx, ni, true); err != nil {
+			return nil, err
+		}
+	}
+
+	deviceIndex := 0
+	if preferredIndex > 0 {
+		if used, err := s.usedDeviceIndexes(ctx, inst.ID); err == nil && !used[preferredIndex] {
+			deviceIndex = preferredIndex
+		}
+	}
+	if err := s.attach(ctx, ni, inst, deviceIndex); err != nil {
+		return nil, err
+	}
+
+	if err := s.auditSvc.Log(ctx, userID, "network_interface.move", "network_interface", id.String(), map[string]interface{}{
+		"from_instance_id": oldInstanceID,
+		"instance_id":      instanceID.String(),
+	}); err != nil {
+		s.logger.Warn("failed to log audit event", "action", "network_interface.move", "error", err)
+	}
+
+	return ni, nil
+}
+
+// targetInstance loads an instance an interface is about to be attached to and
+// checks that it can take the interface.
+func (s *NetworkInterfaceService) targetInstance(ctx context.Context, ni *domain.NetworkInterface, instanceID uuid.UUID) (*domain.Instance, error) {
+	inst, err := s.instanceRepo.GetByID(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	if inst.VpcID == nil || *inst.VpcID != ni.VpcID {
+		return nil, errors.New(errors.InvalidInput, "instance must be in the same VPC as the network interface")
+	}
+	if inst.Status != domain.StatusRunning || inst.ContainerID == "" {
+		return nil, errors.New(errors.InstanceNotRunning, "network interfaces can only be attached to running instances")
+	}
+	return inst, nil
+}
+
+func (s *NetworkInterfaceService) attach(ctx context.Context, ni *domain.NetworkInterface, inst *domain.Instance, deviceIndex int) error {
+	used, err := s.usedDeviceIndexes(ctx, inst.ID)
+	if err != nil {
+		return err
+	}
+	if deviceIndex == 0 {
+		for i := 1; i <= domain.MaxNetworkInterfaceDeviceIndex; i++ {
+			if !used[i] {
+				deviceIndex = i
+				break
+			}
+		}
+		if deviceIndex == 0 {
+			return errors.New(errors.Conflict, fmt.Sprintf("instance already has %d network interfaces", domain.MaxNetworkInterfaceDeviceIndex))
+		}
+	} else if deviceIndex < 1 || deviceIndex > domain.MaxNetworkInterfaceDeviceIndex {
+		return errors.New(errors.InvalidInput, fmt.Sprintf("device index must be between 1 and %d", domain.MaxNetworkInterfaceDeviceIndex))
+	} else if used[deviceIndex] {
+		return errors.New(errors.Conflict, fmt.Sprintf("device index %d is already in use on the instance", deviceIndex))
+	}
+	ni.DeviceIndex = deviceIndex
+
+	opts, err := s.interfaceOptions(ctx, ni)
+	if err != nil {
+		return err
+	}
+
+	if s.usesHostPorts() {
+		if err := s.network.CreateVethPair(ctx, ni.OvsPort, ni.GuestPort()); err != nil {
+			return errors.Wrap(errors.Internal, "failed to create network interface port", err)
+		}
+		if err := s.network.AttachVethToBridge(ctx, opts.Bridge, ni.OvsPort); err != nil {
+			removeInterfacePort(ctx, s.network, s.logger, opts.Bridge, ni)
+			return errors.Wrap(errors.Internal, "failed to connect network interface to the VPC", err)
+		}
+		if err := installAntiSpoofFlows(ctx, s.network, opts.Bridge, ni.OvsPort, ni.PrivateIPs); err != nil {
+			removeInterfacePort(ctx, s.network, s.logger, opts.Bridge, ni)
+			return errors.Wrap(errors.Internal, "failed to restrict network interface source addresses", err)
+		}
+	}
+	if err := s.compute.AttachNetworkInterface(ctx, inst.ContainerID, opts); err != nil {
+		if s.usesHostPorts() {
+			removeInterfacePort(ctx, s.network, s.logger, opts.Bridge, ni)
+		}
+		return errors.Wrap(errors.Internal, "failed to attach network interface", err)
+	}
+
+	instanceID := inst.ID
+	ni.InstanceID = &instanceID
+	ni.Status = domain.NetworkInterfaceStatusInUse
+	ni.UpdatedAt = time.Now()
+	if err := s.repo.Update(ctx, ni); err != nil {
+		if detachErr := s.compute.DetachNetworkInterface(ctx, inst.ContainerID, opts); detachErr != nil {
+			s.logger.Warn("failed to roll back network interface attachment", "interface_id", ni.ID, "error", detachErr)
+		}
+		if s.usesHostPorts() {
+			removeInterfacePort(ctx, s.network, s.logger, opts.Bridge, ni)
+		}
+		ni.InstanceID = nil
+		ni.Status = domain.NetworkInterfaceStatusAvailable
+		return err
+	}
+	return nil
+}
+
+// detach unplugs an interface from its instance. With tolerant set, a failure
+// to reach the instance is logged instead of aborting, so that the interface
+// can fail over away from a broken instance.
+func (s *NetworkInterfaceService) detach(ctx context.Context, ni *domain.NetworkInterface, tolerant bool) error {
+	opts, err := s.interfaceOptions(ctx, ni)
+	if err != nil {
+		return err
+	}
+
+	inst, err := s.instanceRepo.GetByID(ctx, *ni.InstanceID)
+	switch {
+	case err == nil && inst.ContainerID != "":
+		if err := s.compute.DetachNetworkInterface(ctx, inst.ContainerID, opts); err != nil {
+			if !tolerant {
+				return errors.Wrap(errors.Internal, "failed to detach network interface", err)
+			}
+			s.logger.Warn("failed to unplug network interface from previous instance", "interface_id", ni.ID, "instance_id", inst.ID, "error", err)
+		}
+	case err != nil && !errors.Is(err, errors.NotFound):
+		if !tolerant {
+			return err
+		}
+		s.logger.Warn("failed to load previous instance of network interface", "interface_id", ni.ID, "error", err)
+	}
+	if s.usesHostPorts() {
+		removeInterfacePort(ctx, s.network, s.logger, opts.Bridge, ni)
+	}
+
+	ni.InstanceID = nil
+	ni.DeviceIndex = 0
+	ni.Status = domain.NetworkInterfaceStatusAvailable
+	ni.UpdatedAt = time.Now()
+	return s.repo.Update(ctx, ni)
+}
+
+// interfaceOptions describes an interface to the compute backend.
+func (s *NetworkInterfaceService) interfaceOptions(ctx context.Context, ni *domain.NetworkInterface) (ports.NetworkInterfaceOptions, error) {
+	vpc, err := s.vpcRepo.GetByID(ctx, ni.VpcID)
+	if err != nil {
+		return ports.NetworkInterfaceOptions{}, err
+	}
+	subnet, err := s.subnetRepo.GetByID(ctx, ni.SubnetID)
+	if err != nil {
+		return ports.NetworkInterfaceOptions{}, err
+	}
+	_, ipNet, err := net.ParseCIDR(subnet.CIDRBlock)
+	if err != nil {
+		return ports.NetworkInterfaceOptions{}, errors.Wrap(errors.Internal, "invalid subnet cidr", err)
+	}
+	ones, _ := ipNet.Mask.Size()
+
+	addresses := make([]string, 0, len(ni.PrivateIPs))
+	for _, ip := range ni.PrivateIPs {
+		addresses = append(addresses, fmt.Sprintf("%s/%d", ip, ones))
+	}
+	return ports.NetworkInterfaceOptions{
+		Bridge:     vpc.NetworkID,
+		HostPort:   ni.OvsPort,
+		GuestPort:  ni.GuestPort(),
+		DeviceName: ni.DeviceName(),
+		MACAddress: ni.MACAddress,
+		Addresses:  addresses,
+	}, nil
+}
+
+// usesHostPorts reports whether the interface's veth pair is created here.
+// Libvirt creates and removes the tap device of a hot-plugged NIC itself.
+func (s *NetworkInterfaceService) usesHostPorts() bool {
+	return s.network != nil && s.compute.Type() != "libvirt"
+}
+
+func (s *NetworkInterfaceService) usedDeviceIndexes(ctx context.Context, instanceID uuid.UUID) (map[int]bool, error) {
+	attached, err := s.repo.ListByInstance(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	used := make(map[int]bool, len(attached))
+	for _, other := range attached {
+		used[other.DeviceIndex] = true
+	}
+	return used, nil
+}
+
+// reserveIPs validates the requested addresses and allocates count more from
+// the subnet. existing holds the addresses already on the interface.
+func (s *NetworkInterfaceService) reserveIPs(ctx context.Context, subnet *domain.Subnet, existing, requested []string, count int) ([]string, error) {
+	if len(existing)+len(requested)+count > domain.MaxNetworkInterfacePrivateIPs {
+		return nil, errors.New(errors.InvalidInput, fmt.Sprintf("a network interface can have at most %d private ips", domain.MaxNetworkInterfacePrivateIPs))
+	}
+
+	_, ipNet, err := net.ParseCIDR(subnet.CIDRBlock)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "invalid subnet cidr", err)
+	}
+	usedIPs, err := subnetIPsInUse(ctx, s.instanceRepo, s.repo, subnet)
+	if err != nil {
+		return nil, err
+	}
+
+	ips := make([]string, 0, len(requested)+count)
+	for _, raw := range requested {
+		ip := net.ParseIP(raw).To4()
+		if ip == nil {
+			return nil, errors.New(errors.InvalidInput, fmt.Sprintf("invalid private ip: %s", raw))
+		}
+		if !isValidHostIP(ip, ipNet) {
+			return nil, errors.New(errors.InvalidInput, fmt.Sprintf("private ip %s is not a host address of subnet %s", raw, subnet.CIDRBlock))
+		}
+		if usedIPs[ip.String()] {
+			return nil, errors.New(errors.Conflict, fmt.Sprintf("private ip %s is already in use", raw))
+		}
+		usedIPs[ip.String()] = true
+		ips = append(ips, ip.String())
+	}
+	for i := 0; i < count; i++ {
+		ip, err := findAvailableIP(ipNet, usedIPs)
+		if err != nil {
+			return nil, errors.Wrap(errors.ResourceLimitExceeded, "subnet has no free addresses", err)
+		}
+		usedIPs[ip] = true
+		ips = append(ips, ip)
+	}
+	return ips, nil
+}
+
+func (s *NetworkInterfaceService) validateSecurityGroups(ctx context.Context, vpcID uuid.UUID, groupIDs []uuid.UUID) error {
+	for _, groupID := range groupIDs {
+		sg, err := s.sgRepo.GetByID(ctx, groupID)
+		if err != nil {
+			return err
+		}
+		if sg.VPCID != vpcID {
+			return errors.New(errors.InvalidInput, fmt.Sprintf("security group %s belongs to a different VPC", groupID))
+		}
+	}
+	return nil
+}
+
+// refreshGroups re-applies the interface's security groups so flows of rules
+// that reference them pick up its current addresses.
+func (s *NetworkInterfaceService) refreshGroups(ctx context.Context, ni *domain.NetworkInterface) {
+	for _, groupID := range ni.SecurityGroupIDs {
+		if err := s.sgSvc.AttachToNetworkInterface(ctx, ni.ID, groupID); err != nil {
+			s.logger.Warn("failed to refresh security group flows", "interface_id", ni.ID, "group_id", groupID, "error", err)
+		}
+	}
+}
+
+// refreshSourceCheck lets an attached interface send from its current addresses.
+func (s *NetworkInterfaceService) refreshSourceCheck(ctx context.Context, ni *domain.NetworkInterface) {
+	if ni.InstanceID == nil || !s.usesHostPorts() {
+		return
+	}
+	opts, err := s.interfaceOptions(ctx, ni)
+	if err == nil {
+		err = installAntiSpoofFlows(ctx, s.network, opts.Bridge, ni.OvsPort, ni.PrivateIPs)
+	}
+	if err != nil {
+		s.logger.Warn("failed to refresh anti-spoofing flows", "interface_id", ni.ID, "error", err)
+	}
+}
+
+// removeInterfacePort disconnects the host end of a network interface from the
+// VPC bridge and deletes its veth pair. Failures are logged because the port
+// may already be gone with the instance.
+func removeInterfacePort(ctx context.Context, network ports.NetworkBackend, logger *slog.Logger, bridge string, ni *domain.NetworkInterface) {
+	if err := network.DeleteFlowRule(ctx, bridge, "in_port="+ni.OvsPort); err != nil {
+		logger.Warn("failed to remove network interface anti-spoofing flows", "interface_id", ni.ID, "port", ni.OvsPort, "error", err)
+	}
+	if err := network.DeletePort(ctx, bridge, ni.OvsPort); err != nil {
+		logger.Warn("failed to remove network interface port", "interface_id", ni.ID, "port", ni.OvsPort, "error", err)
+	}
+	if err := network.DeleteVethPair(ctx, ni.OvsPort); err != nil {
+		logger.Warn("failed to delete network interface veth pair", "interface_id", ni.ID, "port", ni.OvsPort, "error", err)
+	}
+}
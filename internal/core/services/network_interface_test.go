@@ -0,0 +1,355 @@
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/core/services"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type networkInterfaceMocks struct {
+	repo     *MockNetworkInterfaceRepo
+	subnets  *MockSubnetRepo
+	vpcs     *MockVpcRepo
+	insts    *MockInstanceRepo
+	eips     *MockEIPRepo
+	sgRepo   *MockSecurityGroupRepo
+	sgSvc    *MockSecurityGroupService
+	compute  *MockComputeBackend
+	network  *MockNetworkBackend
+	vpc      *domain.VPC
+	subnet   *domain.Subnet
+	instance *domain.Instance
+}
+
+func setupNetworkInterfaceService(t *testing.T) (*services.NetworkInterfaceService, *networkInterfaceMocks) {
+	t.Helper()
+	vpcID := uuid.New()
+	m := &networkInterfaceMocks{
+		repo:    new(MockNetworkInterfaceRepo),
+		subnets: new(MockSubnetRepo),
+		vpcs:    new(MockVpcRepo),
+		insts:   new(MockInstanceRepo),
+		eips:    new(MockEIPRepo),
+		sgRepo:  new(MockSecurityGroupRepo),
+		sgSvc:   new(MockSecurityGroupService),
+		compute: new(MockComputeBackend),
+		network: new(MockNetworkBackend),
+		vpc:     &domain.VPC{ID: vpcID, NetworkID: "br-vpc-1"},
+		subnet:  &domain.Subnet{ID: uuid.New(), VPCID: vpcID, CIDRBlock: "10.0.1.0/24", GatewayIP: "10.0.1.1"},
+	}
+	m.instance = &domain.Instance{ID: uuid.New(), VpcID: &vpcID, Status: domain.StatusRunning, ContainerID: "c-1"}
+
+	m.subnets.On("GetByID", mock.Anything, m.subnet.ID).Return(m.subnet, nil).Maybe()
+	m.vpcs.On("GetByID", mock.Anything, vpcID).Return(m.vpc, nil).Maybe()
+	m.insts.On("GetByID", mock.Anything, m.instance.ID).Return(m.instance, nil).Maybe()
+	m.compute.On("Type").Return("docker").Maybe()
+
+	rbacSvc := new(MockRBACService)
+	rbacSvc.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	audit := new(MockAuditService)
+	audit.On("Log", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	svc := services.NewNetworkInterfaceService(services.NetworkInterfaceServiceParams{
+		Repo:         m.repo,
+		SubnetRepo:   m.subnets,
+		VpcRepo:      m.vpcs,
+		InstanceRepo: m.insts,
+		EIPRepo:      m.eips,
+		SGRepo:       m.sgRepo,
+		SGSvc:        m.sgSvc,
+		Compute:      m.compute,
+		Network:      m.network,
+		RBACSvc:      rbacSvc,
+		AuditSvc:     audit,
+		Logger:       slog.Default(),
+	})
+	return svc, m
+}
+
+func (m *networkInterfaceMocks) newInterface(ips ...string) *domain.NetworkInterface {
+	return &domain.NetworkInterface{
+		ID:         uuid.New(),
+		VpcID:      m.vpc.ID,
+		SubnetID:   m.subnet.ID,
+		PrivateIPs: ips,
+		MACAddress: "02:aa:bb:cc:dd:ee",
+		OvsPort:    "eni-aabbccdd",
+		Status:     domain.NetworkInterfaceStatusAvailable,
+	}
+}
+
+func TestNetworkInterfaceService(t *testing.T) {
+	ctx := appcontext.WithTenantID(appcontext.WithUserID(context.Background(), uuid.New()), uuid.New())
+
+	t.Run("CreateAllocatesFreePrimaryIP", func(t *testing.T) {
+		svc, m := setupNetworkInterfaceService(t)
+		sgID := uuid.New()
+		m.sgRepo.On("GetByID", mock.Anything, sgID).Return(&domain.SecurityGroup{ID: sgID, VPCID: m.vpc.ID}, nil)
+		m.insts.On("ListBySubnet", mock.Anything, m.subnet.ID).Return([]*domain.Instance{{PrivateIP: "10.0.1.2/24"}}, nil)
+		m.repo.On("ListBySubnet", mock.Anything, m.subnet.ID).Return([]*domain.NetworkInterface{{PrivateIPs: []string{"10.0.1.3"}}}, nil)
+		m.repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		m.sgSvc.On("AttachToNetworkInterface", mock.Anything, mock.Anything, sgID).Return(nil)
+
+		ni, err := svc.CreateNetworkInterface(ctx, m.subnet.ID, "db failover", nil, []uuid.UUID{sgID})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"10.0.1.4"}, ni.PrivateIPs)
+		assert.Equal(t, m.vpc.ID, ni.VpcID)
+		assert.Equal(t, domain.NetworkInterfaceStatusAvailable, ni.Status)
+		assert.Equal(t, []uuid.UUID{sgID}, ni.SecurityGroupIDs)
+		assert.Regexp(t, `^02(:[0-9a-f]{2}){5}$`, ni.MACAddress)
+		assert.LessOrEqual(t, len(ni.OvsPort), 15)
+	})
+
+	t.Run("CreateRejectsForeignSecurityGroup", func(t *testing.T) {
+		svc, m := setupNetworkInterfaceService(t)
+		sgID := uuid.New()
+		m.sgRepo.On("GetByID", mock.Anything, sgID).Return(&domain.SecurityGroup{ID: sgID, VPCID: uuid.New()}, nil)
+
+		_, err := svc.CreateNetworkInterface(ctx, m.subnet.ID, "", nil, []uuid.UUID{sgID})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.InvalidInput))
+		m.repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("CreateRejectsAddressInUse", func(t *testing.T) {
+		svc, m := setupNetworkInterfaceService(t)
+		m.insts.On("ListBySubnet", mock.Anything, m.subnet.ID).Return([]*domain.Instance{{PrivateIP: "10.0.1.2"}}, nil)
+		m.repo.On("ListBySubnet", mock.Anything, m.subnet.ID).Return(nil, nil)
+
+		_, err := svc.CreateNetworkInterface(ctx, m.subnet.ID, "", []string{"10.0.1.2"}, nil)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.Conflict))
+
+		_, err = svc.CreateNetworkInterface(ctx, m.subnet.ID, "", []string{"10.0.2.9"}, nil)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.InvalidInput))
+	})
+
+	t.Run("AssignAllocatesSecondaryIPs", func(t *testing.T) {
+		svc, m := setupNetworkInterfaceService(t)
+		ni := m.newInterface("10.0.1.10")
+		m.repo.On("GetByID", mock.Anything, ni.ID).Return(ni, nil)
+		m.insts.On("ListBySubnet", mock.Anything, m.subnet.ID).Return(nil, nil)
+		m.repo.On("ListBySubnet", mock.Anything, m.subnet.ID).Return([]*domain.NetworkInterface{ni}, nil)
+		m.repo.On("Update", mock.Anything, ni).Return(nil)
+
+		res, err := svc.AssignPrivateIPs(ctx, ni.ID, []string{"10.0.1.20"}, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"10.0.1.10", "10.0.1.20", "10.0.1.2", "10.0.1.3"}, res.PrivateIPs)
+	})
+
+	t.Run("AssignLetsAttachedInterfaceSendFromNewIPs", func(t *testing.T) {
+		svc, m := setupNetworkInterfaceService(t)
+		ni := m.newInterface("10.0.1.10")
+		ni.InstanceID = &m.instance.ID
+		m.repo.On("GetByID", mock.Anything, ni.ID).Return(ni, nil)
+		m.insts.On("ListBySubnet", mock.Anything, m.subnet.ID).Return(nil, nil)
+		m.repo.On("ListBySubnet", mock.Anything, m.subnet.ID).Return([]*domain.NetworkInterface{ni}, nil)
+		m.repo.On("Update", mock.Anything, ni).Return(nil)
+		m.network.On("DeleteFlowRule", mock.Anything, "br-vpc-1", "in_port=eni-aabbccdd").Return(nil).Once()
+		m.network.On("AddFlowRule", mock.Anything, "br-vpc-1", mock.Anything).Return(nil)
+
+		_, err := svc.AssignPrivateIPs(ctx, ni.ID, []string{"10.0.1.20"}, 0)
+		require.NoError(t, err)
+		m.network.AssertExpectations(t)
+		m.network.AssertCalled(t, "AddFlowRule", mock.Anything, "br-vpc-1", mock.MatchedBy(func(flow ports.FlowRule) bool {
+			return flow.Match == "in_port=eni-aabbccdd,ip,nw_src=10.0.1.20,reg0=0/0x1"
+		}))
+	})
+
+	t.Run("AssignEnforcesAddressLimit", func(t *testing.T) {
+		svc, m := setupNetworkInterfaceService(t)
+		ni := m.newInterface("10.0.1.10")
+		m.repo.On("GetByID", mock.Anything, ni.ID).Return(ni, nil)
+
+		_, err := svc.AssignPrivateIPs(ctx, ni.ID, nil, domain.MaxNetworkInterfacePrivateIPs)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.InvalidInput))
+	})
+
+	t.Run("UnassignKeepsPrimaryAndEIPTargets", func(t *testing.T) {
+		svc, m := setupNetworkInterfaceService(t)
+		ni := m.newInterface("10.0.1.10", "10.0.1.11", "10.0.1.12")
+		m.repo.On("GetByID", mock.Anything, ni.ID).Return(ni, nil)
+		m.eips.On("ListByNetworkInterface", mock.Anything, ni.ID).Return([]*domain.ElasticIP{{PrivateIP: "10.0.1.11"}}, nil)
+		m.repo.On("Update", mock.Anything, ni).Return(nil)
+
+		_, err := svc.UnassignPrivateIPs(ctx, ni.ID, []string{"10.0.1.10"})
+		assert.True(t, errors.Is(err, errors.InvalidInput))
+
+		_, err = svc.UnassignPrivateIPs(ctx, ni.ID, []string{"10.0.1.11"})
+		assert.True(t, errors.Is(err, errors.Conflict))
+
+		res, err := svc.UnassignPrivateIPs(ctx, ni.ID, []string{"10.0.1.12"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"10.0.1.10", "10.0.1.11"}, res.PrivateIPs)
+	})
+
+	t.Run("SetSecurityGroupsAppliesDifference", func(t *testing.T) {
+		svc, m := setupNetworkInterfaceService(t)
+		keep, drop, add := uuid.New(), uuid.New(), uuid.New()
+		ni := m.newInterface("10.0.1.10")
+		ni.SecurityGroupIDs = []uuid.UUID{keep, drop}
+		m.repo.On("GetByID", mock.Anything, ni.ID).Return(ni, nil)
+		for _, id := range []uuid.UUID{keep, add} {
+			m.sgRepo.On("GetByID", mock.Anything, id).Return(&domain.SecurityGroup{ID: id, VPCID: m.vpc.ID}, nil)
+		}
+		m.sgSvc.On("DetachFromNetworkInterface", mock.Anything, ni.ID, drop).Return(nil).Once()
+		m.sgSvc.On("AttachToNetworkInterface", mock.Anything, ni.ID, add).Return(nil).Once()
+
+		_, err := svc.SetSecurityGroups(ctx, ni.ID, []uuid.UUID{keep, add})
+		require.NoError(t, err)
+		m.sgSvc.AssertExpectations(t)
+		m.sgSvc.AssertNotCalled(t, "AttachToNetworkInterface", mock.Anything, ni.ID, keep)
+	})
+
+	t.Run("AttachPlugsVethIntoRunningInstance", func(t *testing.T) {
+		svc, m := setupNetworkInterfaceService(t)
+		ni := m.newInterface("10.0.1.10", "10.0.1.11")
+		m.repo.On("GetByID", mock.Anything, ni.ID).Return(ni, nil)
+		m.repo.On("ListByInstance", mock.Anything, m.instance.ID).Return([]*domain.NetworkInterface{{DeviceIndex: 1}}, nil)
+		m.network.On("CreateVethPair", mock.Anything, "eni-aabbccdd", "eni-aabbccddp").Return(nil)
+		m.network.On("AttachVethToBridge", mock.Anything, "br-vpc-1", "eni-aabbccdd").Return(nil)
+		m.network.On("DeleteFlowRule", mock.Anything, "br-vpc-1", "in_port=eni-aabbccdd").Return(nil).Once()
+		var sources []string
+		m.network.On("AddFlowRule", mock.Anything, "br-vpc-1", mock.Anything).Run(func(args mock.Arguments) {
+			if flow := args.Get(2).(ports.FlowRule); flow.Actions != "drop" {
+				sources = append(sources, flow.Match)
+			}
+		}).Return(nil)
+		m.compute.On("AttachNetworkInterface", mock.Anything, "c-1", ports.NetworkInterfaceOptions{
+			Bridge:     "br-vpc-1",
+			HostPort:   "eni-aabbccdd",
+			GuestPort:  "eni-aabbccddp",
+			DeviceName: "eth2",
+			MACAddress: "02:aa:bb:cc:dd:ee",
+			Addresses:  []string{"10.0.1.10/24", "10.0.1.11/24"},
+		}).Return(nil)
+		m.repo.On("Update", mock.Anything, ni).Return(nil)
+
+		res, err := svc.AttachNetworkInterface(ctx, ni.ID, m.instance.ID, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 2, res.DeviceIndex)
+		assert.Equal(t, domain.NetworkInterfaceStatusInUse, res.Status)
+		assert.Equal(t, m.instance.ID, *res.InstanceID)
+		m.compute.AssertExpectations(t)
+		m.network.AssertExpectations(t)
+		assert.Contains(t, sources, "in_port=eni-aabbccdd,ip,nw_src=10.0.1.10,reg0=0/0x1")
+		assert.Contains(t, sources, "in_port=eni-aabbccdd,ip,nw_src=10.0.1.11,reg0=0/0x1")
+		assert.Contains(t, sources, "in_port=eni-aabbccdd,arp,arp_spa=10.0.1.11,reg0=0/0x1")
+	})
+
+	t.Run("AttachRollsBackPortOnBackendFailure", func(t *testing.T) {
+		svc, m := setupNetworkInterfaceService(t)
+		ni := m.newInterface("10.0.1.10")
+		m.repo.On("GetByID", mock.Anything, ni.ID).Return(ni, nil)
+		m.repo.On("ListByInstance", mock.Anything, m.instance.ID).Return(nil, nil)
+		m.network.On("CreateVethPair", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		m.network.On("AttachVethToBridge", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		m.network.On("AddFlowRule", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		m.compute.On("AttachNetworkInterface", mock.Anything, "c-1", mock.Anything).Return(fmt.Errorf("nsenter failed"))
+		m.network.On("DeleteFlowRule", mock.Anything, "br-vpc-1", "in_port=eni-aabbccdd").Return(nil).Twice()
+		m.network.On("DeletePort", mock.Anything, "br-vpc-1", "eni-aabbccdd").Return(nil).Once()
+		m.network.On("DeleteVethPair", mock.Anything, "eni-aabbccdd").Return(nil).Once()
+
+		_, err := svc.AttachNetworkInterface(ctx, ni.ID, m.instance.ID, 0)
+		require.Error(t, err)
+		m.network.AssertExpectations(t)
+		m.repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("AttachRejectsOtherVPCAndStoppedInstances", func(t *testing.T) {
+		svc, m := setupNetworkInterfaceService(t)
+		ni := m.newInterface("10.0.1.10")
+		otherVpc := uuid.New()
+		foreign := &domain.Instance{ID: uuid.New(), VpcID: &otherVpc, Status: domain.StatusRunning, ContainerID: "c-2"}
+		stopped := &domain.Instance{ID: uuid.New(), VpcID: &m.vpc.ID, Status: domain.StatusStopped, ContainerID: "c-3"}
+		m.repo.On("GetByID", mock.Anything, ni.ID).Return(ni, nil)
+		m.insts.On("GetByID", mock.Anything, foreign.ID).Return(foreign, nil)
+		m.insts.On("GetByID", mock.Anything, stopped.ID).Return(stopped, nil)
+
+		_, err := svc.AttachNetworkInterface(ctx, ni.ID, foreign.ID, 0)
+		assert.True(t, errors.Is(err, errors.InvalidInput))
+
+		_, err = svc.AttachNetworkInterface(ctx, ni.ID, stopped.ID, 0)
+		assert.True(t, errors.Is(err, errors.InstanceNotRunning))
+	})
+
+	t.Run("AttachRejectsUsedDeviceIndex", func(t *testing.T) {
+		svc, m := setupNetworkInterfaceService(t)
+		ni := m.newInterface("10.0.1.10")
+		m.repo.On("GetByID", mock.Anything, ni.ID).Return(ni, nil)
+		m.repo.On("ListByInstance", mock.Anything, m.instance.ID).Return([]*domain.NetworkInterface{{DeviceIndex: 1}}, nil)
+
+		_, err := svc.AttachNetworkInterface(ctx, ni.ID, m.instance.ID, 1)
+		assert.True(t, errors.Is(err, errors.Conflict))
+
+		_, err = svc.AttachNetworkInterface(ctx, ni.ID, m.instance.ID, domain.MaxNetworkInterfaceDeviceIndex+1)
+		assert.True(t, errors.Is(err, errors.InvalidInput))
+	})
+
+	t.Run("MoveToleratesUnreachableOldInstance", func(t *testing.T) {
+		svc, m := setupNetworkInterfaceService(t)
+		oldVpc := m.vpc.ID
+		old := &domain.Instance{ID: uuid.New(), VpcID: &oldVpc, Status: domain.StatusError, ContainerID: "c-old"}
+		ni := m.newInterface("10.0.1.10")
+		ni.InstanceID = &old.ID
+		ni.DeviceIndex = 3
+		ni.Status = domain.NetworkInterfaceStatusInUse
+		m.repo.On("GetByID", mock.Anything, ni.ID).Return(ni, nil)
+		m.insts.On("GetByID", mock.Anything, old.ID).Return(old, nil)
+		m.compute.On("DetachNetworkInterface", mock.Anything, "c-old", mock.Anything).Return(fmt.Errorf("host unreachable"))
+		m.network.On("DeleteFlowRule", mock.Anything, "br-vpc-1", "in_port=eni-aabbccdd").Return(nil)
+		m.network.On("AddFlowRule", mock.Anything, "br-vpc-1", mock.Anything).Return(nil)
+		m.network.On("DeletePort", mock.Anything, "br-vpc-1", "eni-aabbccdd").Return(nil)
+		m.network.On("DeleteVethPair", mock.Anything, "eni-aabbccdd").Return(nil)
+		m.repo.On("ListByInstance", mock.Anything, m.instance.ID).Return(nil, nil)
+		m.network.On("CreateVethPair", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		m.network.On("AttachVethToBridge", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		m.compute.On("AttachNetworkInterface", mock.Anything, "c-1", mock.MatchedBy(func(nic ports.NetworkInterfaceOptions) bool {
+			return nic.DeviceName == "eth3"
+		})).Return(nil)
+		m.repo.On("Update", mock.Anything, ni).Return(nil)
+
+		res, err := svc.MoveNetworkInterface(ctx, ni.ID, m.instance.ID)
+		require.NoError(t, err)
+		assert.Equal(t, m.instance.ID, *res.InstanceID)
+		assert.Equal(t, 3, res.DeviceIndex)
+		assert.Equal(t, "10.0.1.10", res.PrimaryIP())
+	})
+
+	t.Run("DetachFailsWhenBackendFails", func(t *testing.T) {
+		svc, m := setupNetworkInterfaceService(t)
+		ni := m.newInterface("10.0.1.10")
+		ni.InstanceID = &m.instance.ID
+		ni.DeviceIndex = 1
+		m.repo.On("GetByID", mock.Anything, ni.ID).Return(ni, nil)
+		m.compute.On("DetachNetworkInterface", mock.Anything, "c-1", mock.Anything).Return(fmt.Errorf("busy"))
+
+		_, err := svc.DetachNetworkInterface(ctx, ni.ID)
+		require.Error(t, err)
+		m.repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("DeleteRequiresDetach", func(t *testing.T) {
+		svc, m := setupNetworkInterfaceService(t)
+		ni := m.newInterface("10.0.1.10")
+		ni.InstanceID = &m.instance.ID
+		m.repo.On("GetByID", mock.Anything, ni.ID).Return(ni, nil)
+
+		err := svc.DeleteNetworkInterface(ctx, ni.ID)
+		assert.True(t, errors.Is(err, errors.Conflict))
+		m.repo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
@@ -134,8 +134,8 @@ func (s *NotifyService) Subscribe(ctx context.Context, topicID uuid.UUID, protoc
 		return nil, err
 	}
 
-	// Verify topic exists and belongs to user
-	topic, err := s.repo.GetTopicByID(ctx, topicID, userID)
+	// Verify topic exists and belongs to user or is shared with them
+	topic, err := s.getTopic(ctx, topicID, userID, domain.PermissionNotifyWrite)
 	if err != nil {
 		return nil, err
 	}
@@ -174,7 +174,7 @@ func (s *NotifyService) ListSubscriptions(ctx context.Context, topicID uuid.UUID
 	}
 
 	// Verify topic ownership
-	_, err := s.repo.GetTopicByID(ctx, topicID, userID)
+	_, err := s.getTopic(ctx, topicID, userID, domain.PermissionNotifyRead)
 	if err != nil {
 		return nil, err
 	}
@@ -216,7 +216,7 @@ func (s *NotifyService) Publish(ctx context.Context, topicID uuid.UUID, body str
 		return err
 	}
 
-	topic, err := s.repo.GetTopicByID(ctx, topicID, userID)
+	topic, err := s.getTopic(ctx, topicID, userID, domain.PermissionNotifyWrite)
 	if err != nil {
 		return err
 	}
@@ -264,6 +264,21 @@ func (s *NotifyService) Publish(ctx context.Context, topicID uuid.UUID, body str
 	return nil
 }
 
+// getTopic loads a topic owned by the user, or one another tenant shared with the
+// user through a resource policy.
+func (s *NotifyService) getTopic(ctx context.Context, id, userID uuid.UUID, permission domain.Permission) (*domain.Topic, error) {
+	topic, err := s.repo.GetTopicByID(ctx, id, userID)
+	if err == nil {
+		return topic, nil
+	}
+	if sharedCtx, ok := sharedResourceContext(ctx, s.rbacSvc, permission, id.String()); ok {
+		if shared, sharedErr := s.repo.GetTopicByTenant(ctx, id, appcontext.TenantIDFromContext(sharedCtx)); sharedErr == nil {
+			return shared, nil
+		}
+	}
+	return nil, err
+}
+
 func (s *NotifyService) deliver(ctx context.Context, sub *domain.Subscription, body string) {
 	switch sub.Protocol {
 	case domain.ProtocolQueue:
@@ -247,6 +247,7 @@ func testNotifyServiceUnitRepoErrors(t *testing.T) {
 	mockAuditSvc := new(MockAuditService)
 	rbacSvc := new(MockRBACService)
 	rbacSvc.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	rbacSvc.On("ResourcePolicyOwner", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(uuid.Nil, false)
 
 	svc := services.NewNotifyService(services.NotifyServiceParams{
 		Repo:     mockRepo,
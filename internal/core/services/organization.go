@@ -0,0 +1,240 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/errors"
+)
+
+// OrganizationServiceParams defines dependencies for organizationService.
+type OrganizationServiceParams struct {
+	Repo       ports.OrganizationRepository
+	TenantRepo ports.TenantRepository
+	AuditSvc   ports.AuditService
+	Logger     *slog.Logger
+}
+
+type organizationService struct {
+	repo       ports.OrganizationRepository
+	tenantRepo ports.TenantRepository
+	auditSvc   ports.AuditService
+	logger     *slog.Logger
+}
+
+// NewOrganizationService creates a service managing organizations, their tenants and guardrails.
+func NewOrganizationService(params OrganizationServiceParams) *organizationService {
+	logger := params.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &organizationService{
+		repo:       params.Repo,
+		tenantRepo: params.TenantRepo,
+		auditSvc:   params.AuditSvc,
+		logger:     logger,
+	}
+}
+
+func (s *organizationService) CreateOrganization(ctx context.Context, name, slug string) (*domain.Organization, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	if userID == uuid.Nil {
+		return nil, errors.New(errors.Unauthorized, "authentication required")
+	}
+
+	name = strings.TrimSpace(name)
+	slug = strings.TrimSpace(slug)
+	if name == "" || slug == "" {
+		return nil, errors.New(errors.InvalidInput, "name and slug are required")
+	}
+
+	now := time.Now()
+	org := &domain.Organization{
+		ID:        uuid.New(),
+		Name:      name,
+		Slug:      slug,
+		OwnerID:   userID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.repo.Create(ctx, org); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, userID, "organization.create", org.ID.String(), map[string]interface{}{"name": name, "slug": slug})
+	return org, nil
+}
+
+func (s *organizationService) GetOrganization(ctx context.Context, id uuid.UUID) (*domain.Organization, error) {
+	return s.getOwned(ctx, id)
+}
+
+func (s *organizationService) ListOrganizations(ctx context.Context) ([]*domain.Organization, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	if userID == uuid.Nil {
+		return nil, errors.New(errors.Unauthorized, "authentication required")
+	}
+	return s.repo.ListByOwner(ctx, userID)
+}
+
+func (s *organizationService) DeleteOrganization(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.getOwned(ctx, id); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.audit(ctx, appcontext.UserIDFromContext(ctx), "organization.delete", id.String(), nil)
+	return nil
+}
+
+// AddTenant links a tenant to an organization. Joining puts the tenant under the
+// organization's guardrails, so the caller must own both the organization and the tenant.
+func (s *organizationService) AddTenant(ctx context.Context, orgID, tenantID uuid.UUID) error {
+	if _, err := s.getOwned(ctx, orgID); err != nil {
+		return err
+	}
+
+	tenant, err := s.tenantRepo.GetByID(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	if tenant == nil {
+		return errors.New(errors.NotFound, "tenant not found")
+	}
+	if tenant.OwnerID != appcontext.UserIDFromContext(ctx) {
+		return errors.New(errors.Forbidden, "only the tenant owner can add it to an organization")
+	}
+
+	if err := s.repo.AddTenant(ctx, orgID, tenantID); err != nil {
+		return err
+	}
+
+	s.audit(ctx, appcontext.UserIDFromContext(ctx), "organization.tenant_add", orgID.String(), map[string]interface{}{"tenant_id": tenantID.String()})
+	return nil
+}
+
+func (s *organizationService) RemoveTenant(ctx context.Context, orgID, tenantID uuid.UUID) error {
+	if _, err := s.getOwned(ctx, orgID); err != nil {
+		return err
+	}
+	if err := s.repo.RemoveTenant(ctx, orgID, tenantID); err != nil {
+		return err
+	}
+
+	s.audit(ctx, appcontext.UserIDFromContext(ctx), "organization.tenant_remove", orgID.String(), map[string]interface{}{"tenant_id": tenantID.String()})
+	return nil
+}
+
+func (s *organizationService) ListTenants(ctx context.Context, orgID uuid.UUID) ([]uuid.UUID, error) {
+	if _, err := s.getOwned(ctx, orgID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListTenantIDs(ctx, orgID)
+}
+
+func (s *organizationService) CreateGuardrail(ctx context.Context, orgID uuid.UUID, name, description string, statements []domain.Statement) (*domain.GuardrailPolicy, error) {
+	if _, err := s.getOwned(ctx, orgID); err != nil {
+		return nil, err
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New(errors.InvalidInput, "guardrail name is required")
+	}
+	if err := validateGuardrailStatements(statements); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	policy := &domain.GuardrailPolicy{
+		ID:             uuid.New(),
+		OrganizationID: orgID,
+		Name:           name,
+		Description:    description,
+		Statements:     statements,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if err := s.repo.CreateGuardrail(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, appcontext.UserIDFromContext(ctx), "organization.guardrail_create", orgID.String(), map[string]interface{}{
+		"guardrail_id": policy.ID.String(),
+		"name":         name,
+	})
+	return policy, nil
+}
+
+func (s *organizationService) ListGuardrails(ctx context.Context, orgID uuid.UUID) ([]*domain.GuardrailPolicy, error) {
+	if _, err := s.getOwned(ctx, orgID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListGuardrails(ctx, orgID)
+}
+
+func (s *organizationService) DeleteGuardrail(ctx context.Context, orgID, policyID uuid.UUID) error {
+	if _, err := s.getOwned(ctx, orgID); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteGuardrail(ctx, orgID, policyID); err != nil {
+		return err
+	}
+
+	s.audit(ctx, appcontext.UserIDFromContext(ctx), "organization.guardrail_delete", orgID.String(), map[string]interface{}{"guardrail_id": policyID.String()})
+	return nil
+}
+
+// getOwned loads an organization and makes sure the caller owns it.
+// Organizations owned by someone else are reported as not found.
+func (s *organizationService) getOwned(ctx context.Context, id uuid.UUID) (*domain.Organization, error) {
+	org, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if org.OwnerID != appcontext.UserIDFromContext(ctx) {
+		return nil, errors.New(errors.NotFound, "organization not found")
+	}
+	return org, nil
+}
+
+func (s *organizationService) audit(ctx context.Context, userID uuid.UUID, action, resourceID string, details map[string]interface{}) {
+	if s.auditSvc == nil {
+		return
+	}
+	if err := s.auditSvc.Log(ctx, userID, action, "organization", resourceID, details); err != nil {
+		s.logger.Warn("failed to log audit event", "action", action, "organization_id", resourceID, "error", err)
+	}
+}
+
+// validateGuardrailStatements enforces deny-only guardrails; an Allow at the
+// organization level would have no effect and is most likely a mistake.
+func validateGuardrailStatements(statements []domain.Statement) error {
+	if len(statements) == 0 {
+		return errors.New(errors.InvalidInput, "at least one guardrail statement is required")
+	}
+	for i, stmt := range statements {
+		if stmt.Effect != domain.EffectDeny {
+			return errors.New(errors.InvalidInput, fmt.Sprintf("statement %d has invalid effect: guardrails only support 'Deny'", i))
+		}
+		if len(stmt.Principal) > 0 {
+			return errors.New(errors.InvalidInput, fmt.Sprintf("statement %d must not specify principals", i))
+		}
+		if len(stmt.Action) == 0 {
+			return errors.New(errors.InvalidInput, fmt.Sprintf("statement %d must specify at least one action", i))
+		}
+		if len(stmt.Resource) == 0 {
+			return errors.New(errors.InvalidInput, fmt.Sprintf("statement %d must specify at least one resource", i))
+		}
+	}
+	return nil
+}
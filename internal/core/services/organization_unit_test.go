@@ -0,0 +1,122 @@
+package services_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/services"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganizationService_Unit(t *testing.T) {
+	repo := new(MockOrganizationRepository)
+	tenantRepo := new(MockTenantRepo)
+	auditSvc := new(MockAuditService)
+	auditSvc.On("Log", mock.Anything, mock.Anything, mock.Anything, "organization", mock.Anything, mock.Anything).Return(nil)
+	svc := services.NewOrganizationService(services.OrganizationServiceParams{Repo: repo, TenantRepo: tenantRepo, AuditSvc: auditSvc, Logger: slog.Default()})
+
+	ownerID := uuid.New()
+	ctx := appcontext.WithUserID(context.Background(), ownerID)
+	orgID := uuid.New()
+	org := &domain.Organization{ID: orgID, Name: "Acme", Slug: "acme", OwnerID: ownerID}
+
+	t.Run("CreateOrganization", func(t *testing.T) {
+		repo.On("Create", mock.Anything, mock.MatchedBy(func(o *domain.Organization) bool {
+			return o.OwnerID == ownerID && o.Slug == "acme"
+		})).Return(nil).Once()
+
+		created, err := svc.CreateOrganization(ctx, "Acme", "acme")
+		require.NoError(t, err)
+		assert.Equal(t, ownerID, created.OwnerID)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("CreateOrganization_Unauthenticated", func(t *testing.T) {
+		_, err := svc.CreateOrganization(context.Background(), "Acme", "acme")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.Unauthorized))
+	})
+
+	t.Run("GetOrganization_NotOwner", func(t *testing.T) {
+		repo.On("GetByID", mock.Anything, orgID).Return(org, nil).Once()
+
+		_, err := svc.GetOrganization(appcontext.WithUserID(context.Background(), uuid.New()), orgID)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.NotFound))
+	})
+
+	t.Run("AddTenant", func(t *testing.T) {
+		tenantID := uuid.New()
+		repo.On("GetByID", mock.Anything, orgID).Return(org, nil).Once()
+		tenantRepo.On("GetByID", mock.Anything, tenantID).Return(&domain.Tenant{ID: tenantID, OwnerID: ownerID}, nil).Once()
+		repo.On("AddTenant", mock.Anything, orgID, tenantID).Return(nil).Once()
+
+		require.NoError(t, svc.AddTenant(ctx, orgID, tenantID))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("AddTenant_NotTenantOwner", func(t *testing.T) {
+		tenantID := uuid.New()
+		repo.On("GetByID", mock.Anything, orgID).Return(org, nil).Once()
+		tenantRepo.On("GetByID", mock.Anything, tenantID).Return(&domain.Tenant{ID: tenantID, OwnerID: uuid.New()}, nil).Once()
+
+		err := svc.AddTenant(ctx, orgID, tenantID)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.Forbidden))
+		repo.AssertNotCalled(t, "AddTenant", mock.Anything, orgID, tenantID)
+	})
+
+	t.Run("CreateGuardrail", func(t *testing.T) {
+		statements := []domain.Statement{{
+			Effect:   domain.EffectDeny,
+			Action:   []string{"storage:write"},
+			Resource: []string{"*"},
+			Condition: domain.Condition{
+				string(domain.CondBool): {string(domain.KeyBucketPublic): true},
+			},
+		}}
+		repo.On("GetByID", mock.Anything, orgID).Return(org, nil).Once()
+		repo.On("CreateGuardrail", mock.Anything, mock.MatchedBy(func(p *domain.GuardrailPolicy) bool {
+			return p.OrganizationID == orgID && p.Name == "no-public-buckets"
+		})).Return(nil).Once()
+
+		policy, err := svc.CreateGuardrail(ctx, orgID, "no-public-buckets", "", statements)
+		require.NoError(t, err)
+		assert.Equal(t, orgID, policy.OrganizationID)
+	})
+
+	validationCases := []struct {
+		name       string
+		statements []domain.Statement
+	}{
+		{"NoStatements", nil},
+		{"AllowEffect", []domain.Statement{{Effect: domain.EffectAllow, Action: []string{"*"}, Resource: []string{"*"}}}},
+		{"WithPrincipal", []domain.Statement{{Effect: domain.EffectDeny, Principal: []string{"*"}, Action: []string{"*"}, Resource: []string{"*"}}}},
+		{"NoActions", []domain.Statement{{Effect: domain.EffectDeny, Resource: []string{"*"}}}},
+		{"NoResources", []domain.Statement{{Effect: domain.EffectDeny, Action: []string{"*"}}}},
+	}
+	for _, tc := range validationCases {
+		t.Run("CreateGuardrail_"+tc.name, func(t *testing.T) {
+			repo.On("GetByID", mock.Anything, orgID).Return(org, nil).Once()
+
+			_, err := svc.CreateGuardrail(ctx, orgID, "invalid", "", tc.statements)
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, errors.InvalidInput))
+		})
+	}
+
+	t.Run("DeleteOrganization", func(t *testing.T) {
+		repo.On("GetByID", mock.Anything, orgID).Return(org, nil).Once()
+		repo.On("Delete", mock.Anything, orgID).Return(nil).Once()
+
+		require.NoError(t, svc.DeleteOrganization(ctx, orgID))
+		repo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+)
+
+const (
+	// antiSpoofPriority sits above every other flow so the source of a packet is
+	// checked before the conntrack, ACL and security group pipeline sees it.
+	antiSpoofPriority = aclEntryPriority + 200
+	// antiSpoofPass marks a packet whose source passed the check in reg0 and
+	// sends it back through table 0, where the check no longer matches it.
+	antiSpoofPass    = "load:1->NXM_NX_REG0[0],resubmit(,0)"
+	antiSpoofPending = "reg0=0/0x1"
+
+	// metadataPriority admits metadata traffic ahead of the ACL and security
+	// group flows, but after the source check.
+	metadataPriority = aclEntryPriority + 100
+)
+
+// antiSpoofFlows bind the IPv4 and IPv6 sources a bridge port may send from to
+// addrs. Anything else entering through the port is dropped, so an instance
+// cannot borrow the address of another one, which the metadata service relies
+// on. IPv6 link-local sources stay allowed for neighbor discovery.
+func antiSpoofFlows(port string, addrs []string) []ports.FlowRule {
+	inPort := "in_port=" + port + ","
+	flows := []ports.FlowRule{
+		{Priority: antiSpoofPriority + 1, Match: inPort + "ipv6,ipv6_src=fe80::/10," + antiSpoofPending, Actions: antiSpoofPass},
+		{Priority: antiSpoofPriority, Match: inPort + "ip," + antiSpoofPending, Actions: "drop"},
+		{Priority: antiSpoofPriority, Match: inPort + "arp," + antiSpoofPending, Actions: "drop"},
+		{Priority: antiSpoofPriority, Match: inPort + "ipv6," + antiSpoofPending, Actions: "drop"},
+	}
+	for _, addr := range addrs {
+		if addr == "" {
+			continue
+		}
+		if strings.Contains(addr, ":") {
+			flows = append(flows, ports.FlowRule{Priority: antiSpoofPriority + 1, Match: inPort + "ipv6,ipv6_src=" + addr + "," + antiSpoofPending, Actions: antiSpoofPass})
+			continue
+		}
+		flows = append(flows,
+			ports.FlowRule{Priority: antiSpoofPriority + 1, Match: inPort + "ip,nw_src=" + addr + "," + antiSpoofPending, Actions: antiSpoofPass},
+			ports.FlowRule{Priority: antiSpoofPriority + 1, Match: inPort + "arp,arp_spa=" + addr + "," + antiSpoofPending, Actions: antiSpoofPass},
+		)
+	}
+	return flows
+}
+
+// installAntiSpoofFlows replaces the source check of a bridge port with one for addrs.
+func installAntiSpoofFlows(ctx context.Context, network ports.NetworkBackend, bridge, port string, addrs []string) error {
+	if err := network.DeleteFlowRule(ctx, bridge, "in_port="+port); err != nil {
+		return err
+	}
+	for _, flow := range antiSpoofFlows(port, addrs) {
+		if err := network.AddFlowRule(ctx, bridge, flow); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// metadataFlows deliver the metadata traffic of a VPC to the bridge's local
+// port, where the API listens for the VPC, and let the replies back out.
+func metadataFlows() []ports.FlowRule {
+	addr := domain.InstanceMetadataAddr
+	return []ports.FlowRule{
+		{Priority: metadataPriority, Match: "arp,arp_tpa=" + addr, Actions: "LOCAL"},
+		{Priority: metadataPriority, Match: "tcp,nw_dst=" + addr, Actions: "LOCAL"},
+		{Priority: metadataPriority, Match: "in_port=LOCAL,arp,arp_spa=" + addr, Actions: "NORMAL"},
+		{Priority: metadataPriority, Match: "in_port=LOCAL,tcp,nw_src=" + addr, Actions: "NORMAL"},
+	}
+}
+
+// setupMetadataPort gives a VPC bridge the metadata address and its flows.
+func setupMetadataPort(ctx context.Context, network ports.NetworkBackend, bridge string) error {
+	if err := network.SetVethIP(ctx, bridge, domain.InstanceMetadataAddr, "32"); err != nil {
+		return err
+	}
+	for _, flow := range metadataFlows() {
+		if err := network.AddFlowRule(ctx, bridge, flow); err != nil {
+			return err
+		}
+	}
+	return nil
+}
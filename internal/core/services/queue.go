@@ -104,13 +104,10 @@ func (s *QueueService) GetQueue(ctx context.Context, id uuid.UUID) (*domain.Queu
 		return nil, err
 	}
 
-	q, err := s.repo.GetByID(ctx, id, tenantID)
+	q, err := s.getQueue(ctx, id, tenantID, domain.PermissionQueueRead)
 	if err != nil {
 		return nil, err
 	}
-	if q == nil {
-		return nil, errors.New(errors.NotFound, "queue not found")
-	}
 
 	return q, nil
 }
@@ -167,13 +164,10 @@ func (s *QueueService) SendMessage(ctx context.Context, queueID uuid.UUID, body
 		return nil, err
 	}
 
-	q, err := s.repo.GetByID(ctx, queueID, tenantID)
+	q, err := s.getQueue(ctx, queueID, tenantID, domain.PermissionQueueWrite)
 	if err != nil {
 		return nil, err
 	}
-	if q == nil {
-		return nil, errors.New(errors.NotFound, "queue not found")
-	}
 
 	if len(body) > q.MaxMessageSize {
 		return nil, errors.New(errors.InvalidInput, fmt.Sprintf("message size exceeds limit of %d bytes", q.MaxMessageSize))
@@ -201,13 +195,10 @@ func (s *QueueService) ReceiveMessages(ctx context.Context, queueID uuid.UUID, m
 		return nil, err
 	}
 
-	q, err := s.repo.GetByID(ctx, queueID, tenantID)
+	q, err := s.getQueue(ctx, queueID, tenantID, domain.PermissionQueueRead)
 	if err != nil {
 		return nil, err
 	}
-	if q == nil {
-		return nil, errors.New(errors.NotFound, "queue not found")
-	}
 
 	if maxMessages <= 0 {
 		maxMessages = 1
@@ -239,13 +230,10 @@ func (s *QueueService) DeleteMessage(ctx context.Context, queueID uuid.UUID, rec
 		return err
 	}
 
-	q, err := s.repo.GetByID(ctx, queueID, tenantID)
+	q, err := s.getQueue(ctx, queueID, tenantID, domain.PermissionQueueWrite)
 	if err != nil {
 		return err
 	}
-	if q == nil {
-		return errors.New(errors.NotFound, "queue not found")
-	}
 
 	if err := s.repo.DeleteMessage(ctx, q.ID, receiptHandle); err != nil {
 		return err
@@ -268,13 +256,10 @@ func (s *QueueService) PurgeQueue(ctx context.Context, queueID uuid.UUID) error
 		return err
 	}
 
-	q, err := s.repo.GetByID(ctx, queueID, tenantID)
+	q, err := s.getQueue(ctx, queueID, tenantID, domain.PermissionQueueWrite)
 	if err != nil {
 		return err
 	}
-	if q == nil {
-		return errors.New(errors.NotFound, "queue not found")
-	}
 
 	_, err = s.repo.PurgeMessages(ctx, q.ID)
 	if err != nil {
@@ -291,3 +276,21 @@ func (s *QueueService) PurgeQueue(ctx context.Context, queueID uuid.UUID) error
 
 	return nil
 }
+
+// getQueue loads a queue owned by the caller's tenant, or one another tenant shared
+// with the caller through a resource policy.
+func (s *QueueService) getQueue(ctx context.Context, id, tenantID uuid.UUID, permission domain.Permission) (*domain.Queue, error) {
+	q, err := s.repo.GetByID(ctx, id, tenantID)
+	if err == nil && q == nil {
+		if sharedCtx, ok := sharedResourceContext(ctx, s.rbacSvc, permission, id.String()); ok {
+			q, err = s.repo.GetByID(ctx, id, appcontext.TenantIDFromContext(sharedCtx))
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if q == nil {
+		return nil, errors.New(errors.NotFound, "queue not found")
+	}
+	return q, nil
+}
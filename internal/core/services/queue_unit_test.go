@@ -21,6 +21,7 @@ func TestQueueServiceUnit(t *testing.T) {
 	mockAuditSvc := new(MockAuditService)
 	rbacSvc := new(MockRBACService)
 	rbacSvc.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	rbacSvc.On("ResourcePolicyOwner", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(uuid.Nil, false)
 
 	svc := services.NewQueueService(mockRepo, rbacSvc, mockEventSvc, mockAuditSvc, slog.Default())
 
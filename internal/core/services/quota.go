@@ -0,0 +1,286 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/errors"
+)
+
+// QuotaServiceParams defines dependencies for quotaService.
+type QuotaServiceParams struct {
+	Repo     ports.QuotaRepository
+	RBACSvc  ports.RBACService
+	AuditSvc ports.AuditService
+	Logger   *slog.Logger
+}
+
+type quotaService struct {
+	repo     ports.QuotaRepository
+	rbacSvc  ports.RBACService
+	auditSvc ports.AuditService
+	logger   *slog.Logger
+}
+
+// NewQuotaService creates a service exposing tenant quotas, quota increase requests and usage reconciliation.
+func NewQuotaService(params QuotaServiceParams) *quotaService {
+	logger := params.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &quotaService{
+		repo:     params.Repo,
+		rbacSvc:  params.RBACSvc,
+		auditSvc: params.AuditSvc,
+		logger:   logger,
+	}
+}
+
+func (s *quotaService) ListQuotas(ctx context.Context) ([]*domain.ResourceQuota, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionQuotaRead, "*"); err != nil {
+		return nil, err
+	}
+	return s.repo.ListQuotas(ctx, tenantID)
+}
+
+func (s *quotaService) RequestIncrease(ctx context.Context, resource domain.QuotaResource, requestedLimit int, reason string) (*domain.QuotaRequest, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionQuotaRequest, "*"); err != nil {
+		return nil, err
+	}
+
+	if _, ok := domain.LookupQuota(resource); !ok {
+		return nil, errors.New(errors.InvalidInput, "unknown quota resource: "+string(resource))
+	}
+	current, err := s.repo.GetQuota(ctx, tenantID, resource)
+	if err != nil {
+		return nil, err
+	}
+	if requestedLimit <= current.Limit {
+		return nil, errors.New(errors.InvalidInput, fmt.Sprintf("requested limit must be above the current limit of %d", current.Limit))
+	}
+
+	existing, err := s.repo.ListRequestsByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range existing {
+		if r.Resource == resource && r.Status == domain.QuotaRequestPending {
+			return nil, errors.New(errors.Conflict, "a quota request for "+string(resource)+" is already pending")
+		}
+	}
+
+	now := time.Now()
+	req := &domain.QuotaRequest{
+		ID:             uuid.New(),
+		TenantID:       tenantID,
+		RequestedBy:    userID,
+		Resource:       resource,
+		CurrentLimit:   current.Limit,
+		RequestedLimit: requestedLimit,
+		Reason:         strings.TrimSpace(reason),
+		Status:         domain.QuotaRequestPending,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if err := s.repo.CreateRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, userID, "quota.request", req.ID.String(), map[string]interface{}{
+		"resource":        string(resource),
+		"requested_limit": requestedLimit,
+	})
+	return req, nil
+}
+
+func (s *quotaService) ListRequests(ctx context.Context) ([]*domain.QuotaRequest, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionQuotaRead, "*"); err != nil {
+		return nil, err
+	}
+	return s.repo.ListRequestsByTenant(ctx, tenantID)
+}
+
+func (s *quotaService) ListTenantQuotas(ctx context.Context, tenantID uuid.UUID) ([]*domain.ResourceQuota, error) {
+	if err := s.authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+	return s.repo.ListQuotas(ctx, tenantID)
+}
+
+func (s *quotaService) SetLimit(ctx context.Context, tenantID uuid.UUID, resource domain.QuotaResource, limit int) error {
+	if err := s.authorizeAdmin(ctx); err != nil {
+		return err
+	}
+	if _, ok := domain.LookupQuota(resource); !ok {
+		return errors.New(errors.InvalidInput, "unknown quota resource: "+string(resource))
+	}
+	if limit < 0 {
+		return errors.New(errors.InvalidInput, "quota limit cannot be negative")
+	}
+	if err := s.repo.SetLimit(ctx, tenantID, resource, limit); err != nil {
+		return err
+	}
+
+	s.audit(ctx, appcontext.UserIDFromContext(ctx), "quota.set_limit", tenantID.String(), map[string]interface{}{
+		"resource": string(resource),
+		"limit":    limit,
+	})
+	return nil
+}
+
+func (s *quotaService) ListAllRequests(ctx context.Context, status domain.QuotaRequestStatus) ([]*domain.QuotaRequest, error) {
+	if err := s.authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+	return s.repo.ListRequestsByStatus(ctx, status)
+}
+
+func (s *quotaService) ApproveRequest(ctx context.Context, id uuid.UUID, note string) (*domain.QuotaRequest, error) {
+	return s.review(ctx, id, domain.QuotaRequestApproved, note)
+}
+
+func (s *quotaService) DenyRequest(ctx context.Context, id uuid.UUID, note string) (*domain.QuotaRequest, error) {
+	return s.review(ctx, id, domain.QuotaRequestDenied, note)
+}
+
+func (s *quotaService) review(ctx context.Context, id uuid.UUID, status domain.QuotaRequestStatus, note string) (*domain.QuotaRequest, error) {
+	if err := s.authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	req, err := s.repo.GetRequest(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Status != domain.QuotaRequestPending {
+		return nil, errors.New(errors.Conflict, "quota request was already "+string(req.Status))
+	}
+
+	reviewer := appcontext.UserIDFromContext(ctx)
+	now := time.Now()
+	req.Status = status
+	req.ReviewedBy = &reviewer
+	req.ReviewNote = strings.TrimSpace(note)
+	req.ReviewedAt = &now
+	req.UpdatedAt = now
+	if err := s.repo.ReviewRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, reviewer, "quota.request_"+string(status), req.ID.String(), map[string]interface{}{
+		"tenant_id":       req.TenantID.String(),
+		"resource":        string(req.Resource),
+		"requested_limit": req.RequestedLimit,
+	})
+	return req, nil
+}
+
+// ReconcileUsage compares each stored usage counter with the usage measured from
+// the resources themselves and overwrites counters that drifted, e.g. because a
+// delete path failed to decrement usage. Resources that cannot be measured are
+// skipped so one failing table does not block the rest.
+func (s *quotaService) ReconcileUsage(ctx context.Context) (int, error) {
+	corrected := 0
+	var failed []string
+	for _, def := range domain.QuotaDefinitions() {
+		n, err := s.reconcileResource(ctx, def.Resource)
+		corrected += n
+		if err != nil {
+			s.logger.Error("failed to reconcile quota usage", "resource", def.Resource, "error", err)
+			failed = append(failed, string(def.Resource))
+		}
+	}
+	if len(failed) > 0 {
+		return corrected, errors.New(errors.Internal, "failed to reconcile quota usage for "+strings.Join(failed, ", "))
+	}
+	return corrected, nil
+}
+
+func (s *quotaService) reconcileResource(ctx context.Context, resource domain.QuotaResource) (int, error) {
+	actual, err := s.repo.CountActualUsage(ctx, resource)
+	if err != nil {
+		return 0, err
+	}
+	stored, err := s.repo.ListUsage(ctx, resource)
+	if err != nil {
+		return 0, err
+	}
+
+	tenants := make(map[uuid.UUID]struct{}, len(actual)+len(stored))
+	for tenantID := range actual {
+		tenants[tenantID] = struct{}{}
+	}
+	for tenantID := range stored {
+		tenants[tenantID] = struct{}{}
+	}
+
+	corrected := 0
+	for tenantID := range tenants {
+		if actual[tenantID] == stored[tenantID] {
+			continue
+		}
+		if err := s.repo.SetUsage(ctx, tenantID, resource, actual[tenantID]); err != nil {
+			return corrected, err
+		}
+		s.logger.Info("corrected quota usage drift", "tenant_id", tenantID, "resource", resource,
+			"recorded", stored[tenantID], "actual", actual[tenantID])
+		corrected++
+	}
+	return corrected, nil
+}
+
+// authorizeAdmin checks quota:manage in the global context, so that only platform
+// admins, not tenant admins, can change limits or review requests.
+func (s *quotaService) authorizeAdmin(ctx context.Context) error {
+	return s.rbacSvc.Authorize(ctx, appcontext.UserIDFromContext(ctx), uuid.Nil, domain.PermissionQuotaManage, "*")
+}
+
+func (s *quotaService) audit(ctx context.Context, userID uuid.UUID, action, resourceID string, details map[string]interface{}) {
+	if s.auditSvc == nil {
+		return
+	}
+	if err := s.auditSvc.Log(ctx, userID, action, "quota", resourceID, details); err != nil {
+		s.logger.Warn("failed to log audit event", "action", action, "resource_id", resourceID, "error", err)
+	}
+}
+
+// checkQuota enforces the caller's tenant quota before a resource is created.
+// Services built without a tenant service, as in most tests, skip the check.
+func checkQuota(ctx context.Context, tenantSvc ports.TenantService, resource domain.QuotaResource, amount int) error {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	if tenantSvc == nil || tenantID == uuid.Nil {
+		return nil
+	}
+	return tenantSvc.CheckQuota(ctx, tenantID, resource, amount)
+}
+
+// recordUsage adjusts a tenant's usage counter after a resource was created (positive
+// delta) or deleted (negative delta). Errors are only logged: the resource change has
+// already happened and the quota reconciler corrects the counter on its next run.
+func recordUsage(ctx context.Context, tenantSvc ports.TenantService, logger *slog.Logger, tenantID uuid.UUID, resource domain.QuotaResource, delta int) {
+	if tenantSvc == nil || tenantID == uuid.Nil || delta == 0 {
+		return
+	}
+	var err error
+	if delta > 0 {
+		err = tenantSvc.IncrementUsage(ctx, tenantID, resource, delta)
+	} else {
+		err = tenantSvc.DecrementUsage(ctx, tenantID, resource, -delta)
+	}
+	if err != nil && logger != nil {
+		logger.Warn("failed to record quota usage", "tenant_id", tenantID, "resource", resource, "delta", delta, "error", err)
+	}
+}
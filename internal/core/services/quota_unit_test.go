@@ -0,0 +1,152 @@
+package services_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/services"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuotaService_Unit(t *testing.T) {
+	repo := new(MockQuotaRepo)
+	rbacSvc := new(MockRBACService)
+	auditSvc := new(MockAuditService)
+	auditSvc.On("Log", mock.Anything, mock.Anything, mock.Anything, "quota", mock.Anything, mock.Anything).Return(nil)
+	svc := services.NewQuotaService(services.QuotaServiceParams{Repo: repo, RBACSvc: rbacSvc, AuditSvc: auditSvc, Logger: slog.Default()})
+
+	userID := uuid.New()
+	tenantID := uuid.New()
+	ctx := appcontext.WithTenantID(appcontext.WithUserID(context.Background(), userID), tenantID)
+
+	t.Run("RequestIncrease", func(t *testing.T) {
+		rbacSvc.On("Authorize", mock.Anything, userID, tenantID, domain.PermissionQuotaRequest, "*").Return(nil).Once()
+		repo.On("GetQuota", mock.Anything, tenantID, domain.QuotaVCPUs).Return(&domain.ResourceQuota{Limit: 8, Used: 6}, nil).Once()
+		repo.On("ListRequestsByTenant", mock.Anything, tenantID).Return([]*domain.QuotaRequest{}, nil).Once()
+		repo.On("CreateRequest", mock.Anything, mock.MatchedBy(func(r *domain.QuotaRequest) bool {
+			return r.TenantID == tenantID && r.CurrentLimit == 8 && r.RequestedLimit == 32 && r.Status == domain.QuotaRequestPending
+		})).Return(nil).Once()
+
+		req, err := svc.RequestIncrease(ctx, domain.QuotaVCPUs, 32, " batch jobs ")
+		require.NoError(t, err)
+		assert.Equal(t, "batch jobs", req.Reason)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("RequestIncrease_NotAboveCurrentLimit", func(t *testing.T) {
+		rbacSvc.On("Authorize", mock.Anything, userID, tenantID, domain.PermissionQuotaRequest, "*").Return(nil).Once()
+		repo.On("GetQuota", mock.Anything, tenantID, domain.QuotaVPCs).Return(&domain.ResourceQuota{Limit: 5}, nil).Once()
+
+		_, err := svc.RequestIncrease(ctx, domain.QuotaVPCs, 5, "")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.InvalidInput))
+	})
+
+	t.Run("RequestIncrease_AlreadyPending", func(t *testing.T) {
+		rbacSvc.On("Authorize", mock.Anything, userID, tenantID, domain.PermissionQuotaRequest, "*").Return(nil).Once()
+		repo.On("GetQuota", mock.Anything, tenantID, domain.QuotaBuckets).Return(&domain.ResourceQuota{Limit: 10}, nil).Once()
+		repo.On("ListRequestsByTenant", mock.Anything, tenantID).Return([]*domain.QuotaRequest{
+			{Resource: domain.QuotaBuckets, Status: domain.QuotaRequestPending},
+		}, nil).Once()
+
+		_, err := svc.RequestIncrease(ctx, domain.QuotaBuckets, 20, "")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.Conflict))
+	})
+
+	t.Run("RequestIncrease_UnknownResource", func(t *testing.T) {
+		rbacSvc.On("Authorize", mock.Anything, userID, tenantID, domain.PermissionQuotaRequest, "*").Return(nil).Once()
+
+		_, err := svc.RequestIncrease(ctx, "gpus", 4, "")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.InvalidInput))
+	})
+
+	t.Run("SetLimit_RequiresPlatformAdmin", func(t *testing.T) {
+		rbacSvc.On("Authorize", mock.Anything, userID, uuid.Nil, domain.PermissionQuotaManage, "*").
+			Return(errors.New(errors.Forbidden, "permission denied")).Once()
+
+		err := svc.SetLimit(ctx, tenantID, domain.QuotaVCPUs, 64)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.Forbidden))
+	})
+
+	t.Run("SetLimit", func(t *testing.T) {
+		rbacSvc.On("Authorize", mock.Anything, userID, uuid.Nil, domain.PermissionQuotaManage, "*").Return(nil).Once()
+		repo.On("SetLimit", mock.Anything, tenantID, domain.QuotaVCPUs, 64).Return(nil).Once()
+
+		require.NoError(t, svc.SetLimit(ctx, tenantID, domain.QuotaVCPUs, 64))
+	})
+
+	t.Run("ApproveRequest", func(t *testing.T) {
+		id := uuid.New()
+		rbacSvc.On("Authorize", mock.Anything, userID, uuid.Nil, domain.PermissionQuotaManage, "*").Return(nil).Once()
+		repo.On("GetRequest", mock.Anything, id).Return(&domain.QuotaRequest{
+			ID: id, TenantID: tenantID, Resource: domain.QuotaVCPUs, RequestedLimit: 32, Status: domain.QuotaRequestPending,
+		}, nil).Once()
+		repo.On("ReviewRequest", mock.Anything, mock.MatchedBy(func(r *domain.QuotaRequest) bool {
+			return r.ID == id && r.Status == domain.QuotaRequestApproved && *r.ReviewedBy == userID && r.ReviewNote == "ok"
+		})).Return(nil).Once()
+
+		req, err := svc.ApproveRequest(ctx, id, "ok")
+		require.NoError(t, err)
+		assert.NotNil(t, req.ReviewedAt)
+	})
+
+	t.Run("DenyRequest_AlreadyReviewed", func(t *testing.T) {
+		id := uuid.New()
+		rbacSvc.On("Authorize", mock.Anything, userID, uuid.Nil, domain.PermissionQuotaManage, "*").Return(nil).Once()
+		repo.On("GetRequest", mock.Anything, id).Return(&domain.QuotaRequest{ID: id, Status: domain.QuotaRequestApproved}, nil).Once()
+
+		_, err := svc.DenyRequest(ctx, id, "")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.Conflict))
+	})
+}
+
+func TestQuotaService_ReconcileUsage(t *testing.T) {
+	repo := new(MockQuotaRepo)
+	svc := services.NewQuotaService(services.QuotaServiceParams{Repo: repo, RBACSvc: new(MockRBACService), Logger: slog.Default()})
+
+	drifted := uuid.New()
+	orphaned := uuid.New()
+	inSync := uuid.New()
+
+	for _, def := range domain.QuotaDefinitions() {
+		if def.Resource == domain.QuotaInstances {
+			continue
+		}
+		repo.On("CountActualUsage", mock.Anything, def.Resource).Return(map[uuid.UUID]int{}, nil).Once()
+		repo.On("ListUsage", mock.Anything, def.Resource).Return(map[uuid.UUID]int{}, nil).Once()
+	}
+	repo.On("CountActualUsage", mock.Anything, domain.QuotaInstances).Return(map[uuid.UUID]int{drifted: 3, inSync: 1}, nil).Once()
+	repo.On("ListUsage", mock.Anything, domain.QuotaInstances).Return(map[uuid.UUID]int{drifted: 5, orphaned: 2, inSync: 1}, nil).Once()
+	repo.On("SetUsage", mock.Anything, drifted, domain.QuotaInstances, 3).Return(nil).Once()
+	repo.On("SetUsage", mock.Anything, orphaned, domain.QuotaInstances, 0).Return(nil).Once()
+
+	corrected, err := svc.ReconcileUsage(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, corrected)
+	repo.AssertExpectations(t)
+}
+
+func TestQuotaService_ReconcileUsage_ContinuesAfterFailure(t *testing.T) {
+	repo := new(MockQuotaRepo)
+	svc := services.NewQuotaService(services.QuotaServiceParams{Repo: repo, RBACSvc: new(MockRBACService), Logger: slog.Default()})
+
+	repo.On("CountActualUsage", mock.Anything, domain.QuotaBuckets).Return(nil, assert.AnError).Once()
+	repo.On("CountActualUsage", mock.Anything, mock.Anything).Return(map[uuid.UUID]int{}, nil)
+	repo.On("ListUsage", mock.Anything, mock.Anything).Return(map[uuid.UUID]int{}, nil)
+
+	_, err := svc.ReconcileUsage(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "buckets")
+	repo.AssertNumberOfCalls(t, "ListUsage", len(domain.QuotaDefinitions())-1)
+}
@@ -24,6 +24,12 @@ type RBACServiceParams struct {
 	Logger     *slog.Logger
 	// SA repo for service account authorization - used when checking IAM policies for SAs
 	SARepo ports.ServiceAccountRepository
+	// ResourcePolicyRepo enables evaluation of policies attached to buckets, queues, topics and secrets
+	ResourcePolicyRepo ports.ResourcePolicyRepository
+	// OrgRepo enables organization guardrails, evaluated before any tenant policy
+	OrgRepo ports.OrganizationRepository
+	// Region is exposed to policy conditions as thecloud:Region
+	Region string
 }
 
 type rbacService struct {
@@ -34,6 +40,9 @@ type rbacService struct {
 	evaluator  ports.PolicyEvaluator
 	logger     *slog.Logger
 	saRepo     ports.ServiceAccountRepository
+	rpRepo     ports.ResourcePolicyRepository
+	orgRepo    ports.OrganizationRepository
+	region     string
 }
 
 // NewRBACService constructs an RBAC service for role-based authorization.
@@ -46,6 +55,9 @@ func NewRBACService(params RBACServiceParams) *rbacService {
 		evaluator:  params.Evaluator,
 		logger:     params.Logger,
 		saRepo:     params.SARepo,
+		rpRepo:     params.ResourcePolicyRepo,
+		orgRepo:    params.OrgRepo,
+		region:     params.Region,
 	}
 }
 
@@ -78,9 +90,21 @@ func (s *rbacService) HasPermission(ctx context.Context, userID uuid.UUID, tenan
 		s.logger.Warn("RBAC: system user ID used without internal signal", "user_id", userID)
 	}
 
+	// 1. Organization guardrails cap everything a member tenant can do
+	if denied, err := s.checkGuardrails(ctx, tenantID, permission, resource); err != nil || denied {
+		return false, err
+	}
+
+	// 2. Check policies attached to the resource itself. These may grant
+	// access to principals from other tenants, so they are evaluated before identity policies.
+	resourceEffect, _ := s.checkResourcePolicies(ctx, userID, tenantID, permission, resource)
+	if resourceEffect == domain.EffectDeny {
+		return false, nil
+	}
+
 	var roleName string
 
-	// 1. Resolve Role and check IAM Policies
+	// 3. Resolve Role and check IAM Policies
 	if tenantID == uuid.Nil {
 		// Global context fallback (for system admins or global roles)
 		user, err := s.userRepo.GetByID(ctx, userID)
@@ -99,6 +123,9 @@ func (s *rbacService) HasPermission(ctx context.Context, userID uuid.UUID, tenan
 		member, err := s.tenantRepo.GetMembership(ctx, tenantID, userID)
 		if err != nil {
 			if errors.Is(err, errors.NotFound) {
+				if resourceEffect == domain.EffectAllow {
+					return true, nil
+				}
 				s.logger.Warn("RBAC: user is not a member of tenant", "user_id", userID, "tenant_id", tenantID)
 				return false, nil
 			}
@@ -106,6 +133,9 @@ func (s *rbacService) HasPermission(ctx context.Context, userID uuid.UUID, tenan
 			return false, errors.Wrap(errors.Internal, "failed to get membership", err)
 		}
 		if member == nil {
+			if resourceEffect == domain.EffectAllow {
+				return true, nil
+			}
 			s.logger.Warn("RBAC: user is not a member of tenant", "user_id", userID, "tenant_id", tenantID)
 			return false, nil
 		}
@@ -113,7 +143,7 @@ func (s *rbacService) HasPermission(ctx context.Context, userID uuid.UUID, tenan
 		s.logger.Debug("RBAC: checking tenant permission", "user_id", userID, "tenant_id", tenantID, "tenant_role", roleName, "permission", permission, "resource", resource)
 	}
 
-	// 2. Check Attached IAM Policies (if IAMRepo and Evaluator are provided)
+	// 4. Check Attached IAM Policies (if IAMRepo and Evaluator are provided)
 	if s.iamRepo != nil && s.evaluator != nil {
 		evalCtx := s.buildEvalCtx(ctx, tenantID)
 
@@ -129,7 +159,13 @@ func (s *rbacService) HasPermission(ctx context.Context, userID uuid.UUID, tenan
 			}
 		}
 	}
-	// 3. Fallback to Role-based logic
+
+	// An Allow from a resource policy stands unless an identity policy denied above
+	if resourceEffect == domain.EffectAllow {
+		return true, nil
+	}
+
+	// 5. Fallback to Role-based logic
 	if roleName == domain.RoleAdmin {
 		return true, nil
 	}
@@ -146,7 +182,7 @@ func (s *rbacService) HasPermission(ctx context.Context, userID uuid.UUID, tenan
 
 	s.logger.Debug("RBAC: found role in DB", "role", role.Name, "permissions_count", len(role.Permissions))
 
-	// 4. Check permissions in role
+	// 6. Check permissions in role
 	for _, p := range role.Permissions {
 		if p == domain.PermissionFullAccess {
 			return true, nil
@@ -160,6 +196,98 @@ func (s *rbacService) HasPermission(ctx context.Context, userID uuid.UUID, tenan
 	return false, nil
 }
 
+// ResourcePolicyOwner returns the tenant owning resource when a policy attached to it
+// allows the user the permission. Callers use it to find resources shared from another
+// tenant after Authorize has passed; it does not replace the full permission check.
+func (s *rbacService) ResourcePolicyOwner(ctx context.Context, userID uuid.UUID, tenantID uuid.UUID, permission domain.Permission, resource string) (uuid.UUID, bool) {
+	effect, owner := s.checkResourcePolicies(ctx, userID, tenantID, permission, resource)
+	if effect != domain.EffectAllow || owner == uuid.Nil {
+		return uuid.Nil, false
+	}
+	return owner, true
+}
+
+// checkGuardrails evaluates the guardrails of the organization the tenant belongs to.
+// Guardrails only deny. Unlike other policy sources, a lookup failure denies access
+// instead of falling through, since skipping a guardrail would widen permissions.
+func (s *rbacService) checkGuardrails(ctx context.Context, tenantID uuid.UUID, permission domain.Permission, resource string) (bool, error) {
+	if s.orgRepo == nil || s.evaluator == nil || tenantID == uuid.Nil {
+		return false, nil
+	}
+
+	guardrails, err := s.orgRepo.GetGuardrailsForTenant(ctx, tenantID)
+	if err != nil {
+		s.logger.Error("RBAC: failed to get organization guardrails", "tenant_id", tenantID, "error", err)
+		return true, errors.Wrap(errors.Internal, "failed to get organization guardrails", err)
+	}
+	if len(guardrails) == 0 {
+		return false, nil
+	}
+
+	policies := make([]*domain.Policy, 0, len(guardrails))
+	for _, g := range guardrails {
+		policies = append(policies, &domain.Policy{ID: g.ID, Name: g.Name, Statements: g.Statements})
+	}
+
+	effect, err := s.evaluator.Evaluate(ctx, policies, string(permission), resource, s.buildEvalCtx(ctx, tenantID))
+	if err != nil {
+		s.logger.Error("RBAC: guardrail evaluation failed", "tenant_id", tenantID, "error", err)
+		return true, errors.Wrap(errors.Internal, "failed to evaluate organization guardrails", err)
+	}
+	if effect == domain.EffectDeny {
+		s.logger.Info("RBAC: denied by organization guardrail", "tenant_id", tenantID, "permission", permission, "resource", resource)
+		return true, nil
+	}
+	return false, nil
+}
+
+// checkResourcePolicies evaluates the policies attached to the target resource and returns
+// the effect along with the tenant that owns the resource.
+// Returns an empty effect when the permission has no resource policy type or no statement matches.
+func (s *rbacService) checkResourcePolicies(ctx context.Context, userID, tenantID uuid.UUID, permission domain.Permission, resource string) (domain.PolicyEffect, uuid.UUID) {
+	if s.rpRepo == nil || s.evaluator == nil || resource == "" || resource == "*" {
+		return "", uuid.Nil
+	}
+	resourceType, ok := domain.ResourcePolicyTypeForPermission(permission)
+	if !ok {
+		return "", uuid.Nil
+	}
+
+	// Only policies attached by the current owner count, so grants left behind by a
+	// previous owner of a reused bucket name never apply.
+	owner, err := s.rpRepo.GetResourceOwner(ctx, resourceType, resource)
+	if err != nil {
+		if !errors.Is(err, errors.NotFound) {
+			s.logger.Error("RBAC: failed to resolve resource owner, falling through to identity policies", "resource_type", resourceType, "resource", resource, "error", err)
+		}
+		return "", uuid.Nil
+	}
+
+	resourcePolicies, err := s.rpRepo.GetForResource(ctx, owner, resourceType, resource)
+	if err != nil {
+		s.logger.Error("RBAC: failed to get resource policies, falling through to identity policies", "resource_type", resourceType, "resource", resource, "error", err)
+		return "", uuid.Nil
+	}
+	if len(resourcePolicies) == 0 {
+		return "", uuid.Nil
+	}
+
+	policies := make([]*domain.Policy, 0, len(resourcePolicies))
+	for _, rp := range resourcePolicies {
+		policies = append(policies, &domain.Policy{ID: rp.ID, TenantID: rp.TenantID, Statements: rp.Statements})
+	}
+
+	evalCtx := s.buildEvalCtx(ctx, tenantID)
+	evalCtx[string(domain.KeyUserID)] = userID.String()
+
+	effect, err := s.evaluator.Evaluate(ctx, policies, string(permission), resource, evalCtx)
+	if err != nil {
+		s.logger.Error("RBAC: resource policy evaluation failed, falling through to identity policies", "error", err, "permission", permission, "resource", resource)
+		return "", uuid.Nil
+	}
+	return effect, owner
+}
+
 // checkIAMPolicies evaluates IAM policies attached directly to a user.
 // Returns (allowed, stop) where stop=true means decision is final.
 func (s *rbacService) checkIAMPolicies(ctx context.Context, tenantID, userID uuid.UUID, permission domain.Permission, resource string, evalCtx map[string]interface{}) (bool, bool) {
@@ -212,6 +340,15 @@ func (s *rbacService) buildEvalCtx(ctx context.Context, tenantID uuid.UUID) map[
 		string(domain.KeyCurrentTime): time.Now().UTC(),
 	}
 
+	// Request attributes are attached by the calling service (e.g. instance type on launch)
+	for k, v := range appcontext.RequestAttributesFromContext(ctx) {
+		evalCtx[k] = v
+	}
+
+	if s.region != "" {
+		evalCtx[string(domain.KeyRegion)] = s.region
+	}
+
 	if userID := appcontext.UserIDFromContext(ctx); userID != uuid.Nil {
 		evalCtx[string(domain.KeyUserID)] = userID.String()
 	}
@@ -356,15 +493,20 @@ func (s *rbacService) hasPermissionForSA(ctx context.Context, saID uuid.UUID, te
 	roleName := sa.Role
 	s.logger.Debug("RBAC: checking SA permission", "sa_id", saID, "tenant_id", tenantID, "role", roleName, "permission", permission, "resource", resource)
 
+	// 1. Organization guardrails apply to service accounts as well
+	if denied, err := s.checkGuardrails(ctx, tenantID, permission, resource); err != nil || denied {
+		return false, err
+	}
+
 	evalCtx := s.buildEvalCtx(ctx, tenantID)
 
-	// 1. Check IAM policies attached to SA
+	// 2. Check IAM policies attached to SA
 	if s.iamRepo != nil && s.evaluator != nil {
 		if allowed, stop := s.checkSAIAMPolicies(ctx, tenantID, saID, permission, resource, evalCtx); allowed || stop {
 			return allowed, nil
 		}
 
-		// 2. Check IAM policies attached to SA's role
+		// 3. Check IAM policies attached to SA's role
 		if roleName != "" {
 			if allowed, stop := s.checkRoleIAMPolicies(ctx, tenantID, roleName, permission, resource, evalCtx); allowed || stop {
 				return allowed, nil
@@ -372,7 +514,7 @@ func (s *rbacService) hasPermissionForSA(ctx context.Context, saID uuid.UUID, te
 		}
 	}
 
-	// 3. Fallback to Role-based logic
+	// 4. Fallback to Role-based logic
 	if roleName == domain.RoleAdmin {
 		return true, nil
 	}
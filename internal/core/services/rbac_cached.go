@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
 	"github.com/poyrazk/thecloud/internal/core/domain"
 	"github.com/poyrazk/thecloud/internal/core/ports"
 	"github.com/poyrazk/thecloud/internal/errors"
@@ -39,6 +40,11 @@ func (s *cachedRBACService) Authorize(ctx context.Context, userID uuid.UUID, ten
 }
 
 func (s *cachedRBACService) HasPermission(ctx context.Context, userID uuid.UUID, tenantID uuid.UUID, permission domain.Permission, resource string) (bool, error) {
+	// Decisions that depend on request attributes (instance type, image, ...) are not cacheable by key
+	if len(appcontext.RequestAttributesFromContext(ctx)) > 0 {
+		return s.rbac.HasPermission(ctx, userID, tenantID, permission, resource)
+	}
+
 	key := fmt.Sprintf("rbac:perm:%s:%s:%s:%s", tenantID, userID, permission, resource)
 
 	// Try cache
@@ -63,6 +69,10 @@ func (s *cachedRBACService) HasPermission(ctx context.Context, userID uuid.UUID,
 	return allowed, nil
 }
 
+func (s *cachedRBACService) ResourcePolicyOwner(ctx context.Context, userID uuid.UUID, tenantID uuid.UUID, permission domain.Permission, resource string) (uuid.UUID, bool) {
+	return s.rbac.ResourcePolicyOwner(ctx, userID, tenantID, permission, resource)
+}
+
 func (s *cachedRBACService) CreateRole(ctx context.Context, role *domain.Role) error {
 	return s.rbac.CreateRole(ctx, role)
 }
@@ -10,6 +10,7 @@ import (
 	appcontext "github.com/poyrazk/thecloud/internal/core/context"
 	"github.com/poyrazk/thecloud/internal/core/domain"
 	"github.com/poyrazk/thecloud/internal/core/services"
+	"github.com/poyrazk/thecloud/internal/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -144,3 +145,233 @@ func TestRBACService_IAMIntegration(t *testing.T) {
 		assert.False(t, allowed)
 	})
 }
+
+func TestRBACService_ResourcePolicies(t *testing.T) {
+	userRepo := new(MockUserRepo)
+	roleRepo := new(MockRoleRepository)
+	tenantRepo := new(MockTenantRepo)
+	iamRepo := new(MockIAMRepository)
+	rpRepo := new(MockResourcePolicyRepository)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	svc := services.NewRBACService(services.RBACServiceParams{
+		UserRepo:           userRepo,
+		RoleRepo:           roleRepo,
+		TenantRepo:         tenantRepo,
+		IAMRepo:            iamRepo,
+		Evaluator:          services.NewIAMEvaluator(),
+		Logger:             logger,
+		ResourcePolicyRepo: rpRepo,
+	})
+
+	userID := uuid.New()
+	partnerTenant := uuid.New()
+	ownerTenant := uuid.New()
+	ctx := appcontext.WithTenantID(appcontext.WithUserID(context.Background(), userID), partnerTenant)
+
+	bucketPolicy := func(effect domain.PolicyEffect, principal string) []*domain.ResourcePolicy {
+		return []*domain.ResourcePolicy{{
+			ID:           uuid.New(),
+			TenantID:     ownerTenant,
+			ResourceType: domain.ResourcePolicyBucket,
+			ResourceID:   "shared",
+			Statements: []domain.Statement{
+				{Effect: effect, Principal: []string{principal}, Action: []string{"storage:read"}, Resource: []string{"*"}},
+			},
+		}}
+	}
+
+	t.Run("AllowsMemberWithoutRolePermission", func(t *testing.T) {
+		rpRepo.On("GetResourceOwner", ctx, domain.ResourcePolicyBucket, "shared").Return(ownerTenant, nil).Once()
+		rpRepo.On("GetForResource", ctx, ownerTenant, domain.ResourcePolicyBucket, "shared").Return(bucketPolicy(domain.EffectAllow, "tenant:"+partnerTenant.String()), nil).Once()
+		tenantRepo.On("GetMembership", ctx, partnerTenant, userID).Return(&domain.TenantMember{UserID: userID, TenantID: partnerTenant, Role: "no-storage"}, nil).Once()
+		iamRepo.On("GetPoliciesForUser", ctx, partnerTenant, userID).Return([]*domain.Policy{}, nil).Once()
+		iamRepo.On("GetPoliciesForRole", ctx, partnerTenant, "no-storage").Return([]*domain.Policy{}, nil).Once()
+
+		allowed, err := svc.HasPermission(ctx, userID, partnerTenant, domain.PermissionStorageRead, "shared")
+		require.NoError(t, err)
+		assert.True(t, allowed)
+		roleRepo.AssertNotCalled(t, "GetRoleByName", mock.Anything, "no-storage")
+	})
+
+	t.Run("AllowsNonMemberPrincipal", func(t *testing.T) {
+		rpRepo.On("GetResourceOwner", ctx, domain.ResourcePolicyBucket, "shared").Return(ownerTenant, nil).Once()
+		rpRepo.On("GetForResource", ctx, ownerTenant, domain.ResourcePolicyBucket, "shared").Return(bucketPolicy(domain.EffectAllow, "user:"+userID.String()), nil).Once()
+		tenantRepo.On("GetMembership", ctx, partnerTenant, userID).Return(nil, nil).Once()
+
+		allowed, err := svc.HasPermission(ctx, userID, partnerTenant, domain.PermissionStorageRead, "shared")
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	})
+
+	t.Run("IgnoresOtherPrincipals", func(t *testing.T) {
+		rpRepo.On("GetResourceOwner", ctx, domain.ResourcePolicyBucket, "shared").Return(ownerTenant, nil).Once()
+		rpRepo.On("GetForResource", ctx, ownerTenant, domain.ResourcePolicyBucket, "shared").Return(bucketPolicy(domain.EffectAllow, "tenant:"+uuid.NewString()), nil).Once()
+		tenantRepo.On("GetMembership", ctx, partnerTenant, userID).Return(nil, nil).Once()
+
+		allowed, err := svc.HasPermission(ctx, userID, partnerTenant, domain.PermissionStorageRead, "shared")
+		require.NoError(t, err)
+		assert.False(t, allowed)
+	})
+
+	t.Run("DenyOverridesAdminRole", func(t *testing.T) {
+		rpRepo.On("GetResourceOwner", ctx, domain.ResourcePolicyBucket, "shared").Return(ownerTenant, nil).Once()
+		rpRepo.On("GetForResource", ctx, ownerTenant, domain.ResourcePolicyBucket, "shared").Return(bucketPolicy(domain.EffectDeny, "*"), nil).Once()
+
+		allowed, err := svc.HasPermission(ctx, userID, partnerTenant, domain.PermissionStorageRead, "shared")
+		require.NoError(t, err)
+		assert.False(t, allowed)
+	})
+
+	t.Run("IdentityDenyOverridesResourceAllow", func(t *testing.T) {
+		rpRepo.On("GetResourceOwner", ctx, domain.ResourcePolicyBucket, "shared").Return(ownerTenant, nil).Once()
+		rpRepo.On("GetForResource", ctx, ownerTenant, domain.ResourcePolicyBucket, "shared").Return(bucketPolicy(domain.EffectAllow, "*"), nil).Once()
+		tenantRepo.On("GetMembership", ctx, partnerTenant, userID).Return(&domain.TenantMember{UserID: userID, TenantID: partnerTenant, Role: "developer"}, nil).Once()
+		iamRepo.On("GetPoliciesForUser", ctx, partnerTenant, userID).Return([]*domain.Policy{{
+			Statements: []domain.Statement{{Effect: domain.EffectDeny, Action: []string{"storage:*"}, Resource: []string{"*"}}},
+		}}, nil).Once()
+
+		allowed, err := svc.HasPermission(ctx, userID, partnerTenant, domain.PermissionStorageRead, "shared")
+		require.NoError(t, err)
+		assert.False(t, allowed)
+	})
+
+	t.Run("SkipsWildcardResource", func(t *testing.T) {
+		tenantRepo.On("GetMembership", ctx, partnerTenant, userID).Return(&domain.TenantMember{UserID: userID, TenantID: partnerTenant, Role: domain.RoleAdmin}, nil).Once()
+		iamRepo.On("GetPoliciesForUser", ctx, partnerTenant, userID).Return([]*domain.Policy{}, nil).Once()
+		iamRepo.On("GetPoliciesForRole", ctx, partnerTenant, domain.RoleAdmin).Return([]*domain.Policy{}, nil).Once()
+
+		allowed, err := svc.HasPermission(ctx, userID, partnerTenant, domain.PermissionStorageWrite, "*")
+		require.NoError(t, err)
+		assert.True(t, allowed)
+		rpRepo.AssertNotCalled(t, "GetForResource", mock.Anything, mock.Anything, mock.Anything, "*")
+	})
+
+	t.Run("ResourcePolicyOwnerReturnsOwningTenant", func(t *testing.T) {
+		policies := bucketPolicy(domain.EffectAllow, "tenant:"+partnerTenant.String())
+		rpRepo.On("GetResourceOwner", ctx, domain.ResourcePolicyBucket, "shared").Return(ownerTenant, nil).Once()
+		rpRepo.On("GetForResource", ctx, ownerTenant, domain.ResourcePolicyBucket, "shared").Return(policies, nil).Once()
+
+		owner, ok := svc.ResourcePolicyOwner(ctx, userID, partnerTenant, domain.PermissionStorageRead, "shared")
+		assert.True(t, ok)
+		assert.Equal(t, ownerTenant, owner)
+	})
+
+	t.Run("IgnoresPoliciesOfDeletedResource", func(t *testing.T) {
+		rpRepo.On("GetResourceOwner", ctx, domain.ResourcePolicyBucket, "gone").Return(uuid.Nil, errors.New(errors.NotFound, "resource not found")).Once()
+		tenantRepo.On("GetMembership", ctx, partnerTenant, userID).Return(nil, nil).Once()
+
+		allowed, err := svc.HasPermission(ctx, userID, partnerTenant, domain.PermissionStorageRead, "gone")
+		require.NoError(t, err)
+		assert.False(t, allowed)
+		rpRepo.AssertNotCalled(t, "GetForResource", mock.Anything, mock.Anything, domain.ResourcePolicyBucket, "gone")
+	})
+
+	t.Run("ResourcePolicyOwnerWithoutGrant", func(t *testing.T) {
+		rpRepo.On("GetResourceOwner", ctx, domain.ResourcePolicyBucket, "shared").Return(ownerTenant, nil).Once()
+		rpRepo.On("GetForResource", ctx, ownerTenant, domain.ResourcePolicyBucket, "shared").Return(bucketPolicy(domain.EffectAllow, "tenant:"+uuid.NewString()), nil).Once()
+
+		owner, ok := svc.ResourcePolicyOwner(ctx, userID, partnerTenant, domain.PermissionStorageRead, "shared")
+		assert.False(t, ok)
+		assert.Equal(t, uuid.Nil, owner)
+	})
+}
+
+func TestRBACService_OrganizationGuardrails(t *testing.T) {
+	userRepo := new(MockUserRepo)
+	roleRepo := new(MockRoleRepository)
+	tenantRepo := new(MockTenantRepo)
+	iamRepo := new(MockIAMRepository)
+	orgRepo := new(MockOrganizationRepository)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	svc := services.NewRBACService(services.RBACServiceParams{
+		UserRepo:   userRepo,
+		RoleRepo:   roleRepo,
+		TenantRepo: tenantRepo,
+		IAMRepo:    iamRepo,
+		Evaluator:  services.NewIAMEvaluator(),
+		Logger:     logger,
+		OrgRepo:    orgRepo,
+		Region:     "eu-west-1",
+	})
+
+	userID := uuid.New()
+	tenantID := uuid.New()
+	ctx := appcontext.WithTenantID(appcontext.WithUserID(context.Background(), userID), tenantID)
+
+	guardrail := func(stmt domain.Statement) []*domain.GuardrailPolicy {
+		return []*domain.GuardrailPolicy{{ID: uuid.New(), Name: "guardrail", Statements: []domain.Statement{stmt}}}
+	}
+	allowAsAdmin := func(ctx context.Context) {
+		tenantRepo.On("GetMembership", ctx, tenantID, userID).Return(&domain.TenantMember{UserID: userID, TenantID: tenantID, Role: domain.RoleAdmin}, nil).Once()
+		iamRepo.On("GetPoliciesForUser", ctx, tenantID, userID).Return([]*domain.Policy{}, nil).Once()
+		iamRepo.On("GetPoliciesForRole", ctx, tenantID, domain.RoleAdmin).Return([]*domain.Policy{}, nil).Once()
+	}
+
+	t.Run("DenyOverridesAdminRole", func(t *testing.T) {
+		orgRepo.On("GetGuardrailsForTenant", ctx, tenantID).Return(guardrail(domain.Statement{
+			Effect: domain.EffectDeny, Action: []string{"instance:*"}, Resource: []string{"*"},
+		}), nil).Once()
+
+		allowed, err := svc.HasPermission(ctx, userID, tenantID, domain.PermissionInstanceLaunch, "*")
+		require.NoError(t, err)
+		assert.False(t, allowed)
+		tenantRepo.AssertNotCalled(t, "GetMembership", ctx, tenantID, userID)
+	})
+
+	t.Run("DeniesLargeInstanceSize", func(t *testing.T) {
+		launchCtx := appcontext.WithRequestAttributes(ctx, map[string]interface{}{string(domain.KeyInstanceVCPUs): 16})
+		orgRepo.On("GetGuardrailsForTenant", launchCtx, tenantID).Return(guardrail(domain.Statement{
+			Effect: domain.EffectDeny, Action: []string{"instance:launch"}, Resource: []string{"*"},
+			Condition: domain.Condition{string(domain.CondNumericGreaterThan): {string(domain.KeyInstanceVCPUs): float64(8)}},
+		}), nil).Once()
+
+		allowed, err := svc.HasPermission(launchCtx, userID, tenantID, domain.PermissionInstanceLaunch, "*")
+		require.NoError(t, err)
+		assert.False(t, allowed)
+	})
+
+	t.Run("SmallInstanceFallsThroughToTenantIAM", func(t *testing.T) {
+		launchCtx := appcontext.WithRequestAttributes(ctx, map[string]interface{}{string(domain.KeyInstanceVCPUs): 2})
+		orgRepo.On("GetGuardrailsForTenant", launchCtx, tenantID).Return(guardrail(domain.Statement{
+			Effect: domain.EffectDeny, Action: []string{"instance:launch"}, Resource: []string{"*"},
+			Condition: domain.Condition{string(domain.CondNumericGreaterThan): {string(domain.KeyInstanceVCPUs): float64(8)}},
+		}), nil).Once()
+		allowAsAdmin(launchCtx)
+
+		allowed, err := svc.HasPermission(launchCtx, userID, tenantID, domain.PermissionInstanceLaunch, "*")
+		require.NoError(t, err)
+		assert.True(t, allowed)
+	})
+
+	t.Run("RestrictsRegion", func(t *testing.T) {
+		orgRepo.On("GetGuardrailsForTenant", ctx, tenantID).Return(guardrail(domain.Statement{
+			Effect: domain.EffectDeny, Action: []string{"*"}, Resource: []string{"*"},
+			Condition: domain.Condition{string(domain.CondStringNotEquals): {string(domain.KeyRegion): []interface{}{"us-east-1", "us-west-2"}}},
+		}), nil).Once()
+
+		allowed, err := svc.HasPermission(ctx, userID, tenantID, domain.PermissionStorageRead, "*")
+		require.NoError(t, err)
+		assert.False(t, allowed)
+	})
+
+	t.Run("RepoErrorFailsClosed", func(t *testing.T) {
+		orgRepo.On("GetGuardrailsForTenant", ctx, tenantID).Return(nil, assert.AnError).Once()
+
+		allowed, err := svc.HasPermission(ctx, userID, tenantID, domain.PermissionInstanceRead, "*")
+		require.Error(t, err)
+		assert.False(t, allowed)
+	})
+
+	t.Run("SkippedWithoutTenant", func(t *testing.T) {
+		userRepo.On("GetByID", ctx, userID).Return(&domain.User{ID: userID, Role: domain.RoleAdmin}, nil).Once()
+		iamRepo.On("GetPoliciesForUser", ctx, uuid.Nil, userID).Return([]*domain.Policy{}, nil).Once()
+		iamRepo.On("GetPoliciesForRole", ctx, uuid.Nil, domain.RoleAdmin).Return([]*domain.Policy{}, nil).Once()
+
+		allowed, err := svc.HasPermission(ctx, userID, uuid.Nil, domain.PermissionInstanceRead, "*")
+		require.NoError(t, err)
+		assert.True(t, allowed)
+		orgRepo.AssertNotCalled(t, "GetGuardrailsForTenant", ctx, uuid.Nil)
+	})
+}
@@ -0,0 +1,208 @@
+package services_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/services"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourcePolicyService_Unit(t *testing.T) {
+	repo := new(MockResourcePolicyRepository)
+	auditSvc := new(MockAuditService)
+	svc := services.NewResourcePolicyService(services.ResourcePolicyServiceParams{Repo: repo, AuditSvc: auditSvc, Logger: slog.Default()})
+
+	userID := uuid.New()
+	tenantID := uuid.New()
+	partnerTenant := uuid.New()
+	ctx := appcontext.WithTenantID(appcontext.WithUserID(context.Background(), userID), tenantID)
+
+	shareStatements := []domain.Statement{{
+		Effect:    domain.EffectAllow,
+		Principal: []string{"tenant:" + partnerTenant.String()},
+		Action:    []string{"storage:read"},
+		Resource:  []string{"*"},
+	}}
+
+	t.Run("PutResourcePolicy", func(t *testing.T) {
+		repo.On("GetResourceOwner", mock.Anything, domain.ResourcePolicyBucket, "shared").Return(tenantID, nil).Once()
+		repo.On("Upsert", mock.Anything, mock.MatchedBy(func(p *domain.ResourcePolicy) bool {
+			return p.TenantID == tenantID && p.ResourceType == domain.ResourcePolicyBucket && p.ResourceID == "shared"
+		})).Return(nil).Once()
+		auditSvc.On("Log", mock.Anything, userID, "iam.resource_policy_put", "bucket", "shared", mock.Anything).Return(nil).Once()
+
+		policy, err := svc.PutResourcePolicy(ctx, domain.ResourcePolicyBucket, "shared", shareStatements)
+		require.NoError(t, err)
+		assert.Equal(t, "shared", policy.ResourceID)
+		repo.AssertExpectations(t)
+		auditSvc.AssertExpectations(t)
+	})
+
+	t.Run("PutResourcePolicy_NotOwner", func(t *testing.T) {
+		repo.On("GetResourceOwner", mock.Anything, domain.ResourcePolicyBucket, "theirs").Return(partnerTenant, nil).Once()
+
+		_, err := svc.PutResourcePolicy(ctx, domain.ResourcePolicyBucket, "theirs", shareStatements)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.NotFound))
+		repo.AssertNotCalled(t, "Upsert", mock.Anything, mock.MatchedBy(func(p *domain.ResourcePolicy) bool { return p.ResourceID == "theirs" }))
+	})
+
+	validationCases := []struct {
+		name         string
+		resourceType domain.ResourcePolicyType
+		resourceID   string
+		statements   []domain.Statement
+		wantErr      string
+	}{
+		{"InvalidType", "instance", uuid.NewString(), shareStatements, "unsupported resource type"},
+		{"NonUUIDQueue", domain.ResourcePolicyQueue, "my-queue", shareStatements, "valid UUID"},
+		{"NoStatements", domain.ResourcePolicyBucket, "shared", nil, "at least one policy statement"},
+		{"MissingPrincipal", domain.ResourcePolicyBucket, "shared", []domain.Statement{
+			{Effect: domain.EffectAllow, Action: []string{"storage:read"}, Resource: []string{"*"}},
+		}, "principal"},
+		{"BadPrincipal", domain.ResourcePolicyBucket, "shared", []domain.Statement{
+			{Effect: domain.EffectAllow, Principal: []string{"tenant:not-a-uuid"}, Action: []string{"storage:read"}, Resource: []string{"*"}},
+		}, "invalid principal"},
+		{"ForeignAction", domain.ResourcePolicyBucket, "shared", []domain.Statement{
+			{Effect: domain.EffectAllow, Principal: []string{"*"}, Action: []string{"instance:launch"}, Resource: []string{"*"}},
+		}, "does not apply"},
+	}
+	for _, tc := range validationCases {
+		t.Run("PutResourcePolicy_"+tc.name, func(t *testing.T) {
+			_, err := svc.PutResourcePolicy(ctx, tc.resourceType, tc.resourceID, tc.statements)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.wantErr)
+		})
+	}
+
+	t.Run("GetResourcePolicy", func(t *testing.T) {
+		queueID := uuid.NewString()
+		repo.On("Get", mock.Anything, tenantID, domain.ResourcePolicyQueue, queueID).Return(&domain.ResourcePolicy{ResourceID: queueID}, nil).Once()
+
+		policy, err := svc.GetResourcePolicy(ctx, domain.ResourcePolicyQueue, queueID)
+		require.NoError(t, err)
+		assert.Equal(t, queueID, policy.ResourceID)
+	})
+
+	t.Run("ListResourcePolicies", func(t *testing.T) {
+		repo.On("List", mock.Anything, tenantID).Return([]*domain.ResourcePolicy{{ResourceID: "shared"}}, nil).Once()
+
+		policies, err := svc.ListResourcePolicies(ctx)
+		require.NoError(t, err)
+		assert.Len(t, policies, 1)
+	})
+
+	t.Run("DeleteResourcePolicy", func(t *testing.T) {
+		repo.On("Delete", mock.Anything, tenantID, domain.ResourcePolicyBucket, "shared").Return(nil).Once()
+		auditSvc.On("Log", mock.Anything, userID, "iam.resource_policy_delete", "bucket", "shared", mock.Anything).Return(nil).Once()
+
+		err := svc.DeleteResourcePolicy(ctx, domain.ResourcePolicyBucket, "shared")
+		require.NoError(t, err)
+	})
+}
+
+func TestResourcePolicy_CrossTenantAccess(t *testing.T) {
+	ownerTenant := uuid.New()
+	ownerID := uuid.New()
+	partnerTenant := uuid.New()
+	partnerID := uuid.New()
+	ownerCtx := appcontext.WithTenantID(appcontext.WithUserID(context.Background(), ownerID), ownerTenant)
+	partnerCtx := appcontext.WithTenantID(appcontext.WithUserID(context.Background(), partnerID), partnerTenant)
+
+	inTenant := func(tenantID uuid.UUID) interface{} {
+		return mock.MatchedBy(func(ctx context.Context) bool { return appcontext.TenantIDFromContext(ctx) == tenantID })
+	}
+	notFound := errors.New(errors.NotFound, "not found")
+
+	rbacSvc := new(MockRBACService)
+	rbacSvc.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	eventSvc := new(MockEventService)
+	eventSvc.On("RecordEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	auditSvc := new(MockAuditService)
+	auditSvc.On("Log", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	t.Run("Secret", func(t *testing.T) {
+		repo := new(MockSecretRepo)
+		svc, err := services.NewSecretService(services.SecretServiceParams{
+			Repo: repo, RBACSvc: rbacSvc, EventSvc: eventSvc, AuditSvc: auditSvc, Logger: slog.Default(),
+			MasterKey: "test-master-key-32-chars-long-!!!", Environment: "test",
+		})
+		require.NoError(t, err)
+
+		repo.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+		created, err := svc.CreateSecret(ownerCtx, "db-password", "hunter2", "")
+		require.NoError(t, err)
+		stored := *created
+
+		rbacSvc.On("ResourcePolicyOwner", mock.Anything, partnerID, partnerTenant, domain.PermissionSecretRead, stored.ID.String()).Return(ownerTenant, true).Once()
+		repo.On("GetByID", inTenant(partnerTenant), stored.ID).Return(nil, notFound).Once()
+		repo.On("GetByID", inTenant(ownerTenant), stored.ID).Return(&stored, nil).Once()
+		repo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+
+		secret, err := svc.GetSecret(partnerCtx, stored.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "hunter2", secret.EncryptedValue)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Queue", func(t *testing.T) {
+		repo := new(MockQueueRepo)
+		svc := services.NewQueueService(repo, rbacSvc, eventSvc, auditSvc, slog.Default())
+		queue := &domain.Queue{ID: uuid.New(), UserID: ownerID, TenantID: ownerTenant, VisibilityTimeout: 30}
+		msgs := []*domain.Message{{ID: uuid.New(), QueueID: queue.ID, Body: "hello"}}
+
+		rbacSvc.On("ResourcePolicyOwner", mock.Anything, partnerID, partnerTenant, domain.PermissionQueueRead, queue.ID.String()).Return(ownerTenant, true).Once()
+		repo.On("GetByID", mock.Anything, queue.ID, partnerTenant).Return(nil, nil).Once()
+		repo.On("GetByID", mock.Anything, queue.ID, ownerTenant).Return(queue, nil).Once()
+		repo.On("ReceiveMessages", mock.Anything, queue.ID, 1, 30).Return(msgs, nil).Once()
+
+		received, err := svc.ReceiveMessages(partnerCtx, queue.ID, 1)
+		require.NoError(t, err)
+		assert.Equal(t, msgs, received)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Topic", func(t *testing.T) {
+		repo := new(MockNotifyRepo)
+		svc := services.NewNotifyService(services.NotifyServiceParams{
+			Repo: repo, RBACSvc: rbacSvc, QueueSvc: new(MockQueueService), EventSvc: eventSvc, AuditSvc: auditSvc, Logger: slog.Default(),
+		})
+		topic := &domain.Topic{ID: uuid.New(), UserID: ownerID, Name: "alerts"}
+
+		rbacSvc.On("ResourcePolicyOwner", mock.Anything, partnerID, partnerTenant, domain.PermissionNotifyWrite, topic.ID.String()).Return(ownerTenant, true).Once()
+		repo.On("GetTopicByID", mock.Anything, topic.ID, partnerID).Return(nil, notFound).Once()
+		repo.On("GetTopicByTenant", mock.Anything, topic.ID, ownerTenant).Return(topic, nil).Once()
+		repo.On("SaveMessage", mock.Anything, mock.Anything).Return(nil).Once()
+		repo.On("ListSubscriptions", mock.Anything, topic.ID).Return([]*domain.Subscription{}, nil).Once()
+
+		require.NoError(t, svc.Publish(partnerCtx, topic.ID, "hello"))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Bucket", func(t *testing.T) {
+		repo := new(MockStorageRepo)
+		svc := services.NewStorageService(services.StorageServiceParams{
+			Repo: repo, RBACSvc: rbacSvc, Store: new(MockFileStore), AuditSvc: auditSvc, Logger: slog.Default(),
+		})
+		objects := []*domain.Object{{Bucket: "shared", Key: "report.csv", UserID: ownerID}}
+
+		rbacSvc.On("ResourcePolicyOwner", mock.Anything, partnerID, partnerTenant, domain.PermissionStorageRead, "shared").Return(ownerTenant, true).Once()
+		repo.On("GetBucket", mock.Anything, "shared").Return(&domain.Bucket{Name: "shared", UserID: ownerID}, nil).Once()
+		repo.On("List", mock.MatchedBy(func(ctx context.Context) bool {
+			return appcontext.UserIDFromContext(ctx) == ownerID
+		}), "shared").Return(objects, nil).Once()
+
+		listed, err := svc.ListObjects(partnerCtx, "shared")
+		require.NoError(t, err)
+		assert.Equal(t, objects, listed)
+		repo.AssertExpectations(t)
+	})
+}
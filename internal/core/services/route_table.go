@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -203,12 +204,7 @@ func (s *RouteTableService) AddRoute(ctx context.Context, rtID uuid.UUID, destin
 	}
 
 	// Program OVS flow for the route
-	flow := ports.FlowRule{
-		Priority: 300,
-		Match:    fmt.Sprintf("ip,nw_dst=%s", destinationCIDR),
-		Actions:  "NORMAL",
-	}
-	if err := s.network.AddFlowRule(ctx, vpc.NetworkID, flow); err != nil {
+	if err := s.network.AddFlowRule(ctx, vpc.NetworkID, routeFlow(*route)); err != nil {
 		s.logger.Error("failed to add OVS flow for route", "route_id", route.ID, "error", err)
 		// Don't fail the operation - DB is source of truth
 	}
@@ -343,3 +339,20 @@ func (s *RouteTableService) ReplaceRoute(ctx context.Context, rtID, routeID uuid
 	// For now, just a placeholder - implementation would follow similar pattern
 	return errors.New(errors.NotImplemented, "ReplaceRoute not yet implemented")
 }
+
+// routePriority places route flows below conntrack and above default forwarding.
+const routePriority = 300
+
+// routeFlow is the OVS flow programmed for a route. Local routes need none.
+func routeFlow(route domain.Route) ports.FlowRule {
+	match := fmt.Sprintf("ip,nw_dst=%s", route.DestinationCIDR)
+	if strings.Contains(route.DestinationCIDR, ":") {
+		match = fmt.Sprintf("ipv6,ipv6_dst=%s", route.DestinationCIDR)
+	}
+	return ports.FlowRule{
+		Priority: routePriority,
+		Match:    match,
+		Actions:  "NORMAL",
+		Cookie:   flowCookie(route.ID),
+	}
+}
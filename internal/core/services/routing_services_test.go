@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	appcontext "github.com/poyrazk/thecloud/internal/core/context"
 	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
 	"github.com/poyrazk/thecloud/internal/core/services"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -346,6 +347,45 @@ func TestRouteTableService_AddRoute(t *testing.T) {
 	mockRT.AssertExpectations(t)
 }
 
+func TestRouteTableService_AddRouteIPv6(t *testing.T) {
+	mockRT := new(MockRTRepo)
+	mockVPC := new(MockVpcRepo)
+	mockRBAC := new(MockRBACService)
+	mockAudit := new(MockAuditService)
+	mockNetwork := new(MockNetworkBackend)
+
+	svc := services.NewRouteTableService(services.RouteTableServiceParams{
+		Repo:     mockRT,
+		VpcRepo:  mockVPC,
+		RBACSvc:  mockRBAC,
+		AuditSvc: mockAudit,
+		Network:  mockNetwork,
+		Logger:   slog.Default(),
+	})
+
+	ctx := appcontext.WithTenantID(appcontext.WithUserID(context.Background(), uuid.New()), uuid.New())
+	rtID := uuid.New()
+	vpcID := uuid.New()
+	igwID := uuid.New()
+
+	mockRBAC.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockRT.On("GetByID", mock.Anything, rtID).Return(&domain.RouteTable{ID: rtID, VPCID: vpcID}, nil)
+	mockVPC.On("GetByID", mock.Anything, vpcID).Return(&domain.VPC{ID: vpcID, NetworkID: "br-test"}, nil)
+	mockRT.On("AddRoute", mock.Anything, rtID, mock.AnythingOfType("*domain.Route")).Return(nil)
+	mockNetwork.On("AddFlowRule", mock.Anything, "br-test", mock.MatchedBy(func(f ports.FlowRule) bool {
+		return f.Match == "ipv6,ipv6_dst=::/0"
+	})).Return(nil).Once()
+	mockAudit.On("Log", mock.Anything, mock.Anything, "route_table.add_route", "route_table", mock.Anything, mock.Anything).Return(nil)
+
+	_, err := svc.AddRoute(ctx, rtID, "::/0", domain.RouteTargetIGW, &igwID)
+	require.NoError(t, err)
+	mockNetwork.AssertExpectations(t)
+
+	natID := uuid.New()
+	_, err = svc.AddRoute(ctx, rtID, "::/0", domain.RouteTargetNAT, &natID)
+	require.Error(t, err)
+}
+
 func TestRouteTableService_AssociateSubnet(t *testing.T) {
 	mockRT := new(MockRTRepo)
 	mockRBAC := new(MockRBACService)
@@ -148,15 +148,11 @@ func (s *SecretService) GetSecret(ctx context.Context, id uuid.UUID) (*domain.Se
 		return nil, err
 	}
 
-	secret, err := s.repo.GetByID(ctx, id)
+	secret, err := s.getTenantSecret(ctx, id, tenantID, domain.PermissionSecretRead)
 	if err != nil {
 		return nil, err
 	}
 
-	if secret.TenantID != tenantID && secret.TenantID != uuid.Nil {
-		return nil, errors.New(errors.NotFound, "secret not found")
-	}
-
 	key, err := s.getDerivedKey(secret.UserID)
 	if err != nil {
 		return nil, errors.Wrap(errors.Internal, errFailedDeriveKey, err)
@@ -325,3 +321,235 @@ func (s *SecretService) Decrypt(ctx context.Context, userID uuid.UUID, cipherTex
 
 	return string(decrypted), nil
 }
+
+// getTenantSecret loads a secret and hides secrets that belong to another tenant,
+// unless a policy attached to the secret grants the caller permission on it.
+func (s *SecretService) getTenantSecret(ctx context.Context, id, tenantID uuid.UUID, permission domain.Permission) (*domain.Secret, error) {
+	secret, err := s.repo.GetByID(ctx, id)
+	if errors.Is(err, errors.NotFound) {
+		if sharedCtx, ok := sharedResourceContext(ctx, s.rbacSvc, permission, id.String()); ok {
+			tenantID = appcontext.TenantIDFromContext(sharedCtx)
+			secret, err = s.repo.GetByID(sharedCtx, id)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if secret.TenantID != tenantID && secret.TenantID != uuid.Nil {
+		return nil, errors.New(errors.NotFound, "secret not found")
+	}
+	return secret, nil
+}
+
+// decryptVersion replaces the version's ciphertext with plaintext. Values are always
+// encrypted with the owner's key, whoever wrote them.
+func (s *SecretService) decryptVersion(secret *domain.Secret, v *domain.SecretVersion) error {
+	key, err := s.getDerivedKey(secret.UserID)
+	if err != nil {
+		return errors.Wrap(errors.Internal, errFailedDeriveKey, err)
+	}
+	decrypted, err := crypto.Decrypt(v.EncryptedValue, key)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to decrypt secret", err)
+	}
+	v.EncryptedValue = string(decrypted)
+	return nil
+}
+
+func (s *SecretService) PutSecretValue(ctx context.Context, id uuid.UUID, value string, stage domain.SecretStage) (*domain.SecretVersion, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if stage == "" {
+		stage = domain.SecretStageCurrent
+	}
+	if stage != domain.SecretStageCurrent && stage != domain.SecretStagePending {
+		return nil, errors.New(errors.InvalidInput, "new values can only be staged as current or pending")
+	}
+	if value == "" {
+		return nil, errors.New(errors.InvalidInput, "secret value is required")
+	}
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionSecretWrite, id.String()); err != nil {
+		return nil, err
+	}
+
+	secret, err := s.getTenantSecret(ctx, id, tenantID, domain.PermissionSecretWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := s.getDerivedKey(secret.UserID)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, errFailedDeriveKey, err)
+	}
+	encrypted, err := crypto.Encrypt([]byte(value), key)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to encrypt secret", err)
+	}
+
+	version := &domain.SecretVersion{
+		ID:             uuid.New(),
+		SecretID:       secret.ID,
+		EncryptedValue: encrypted,
+		Stages:         []domain.SecretStage{stage},
+		CreatedBy:      userID,
+		CreatedAt:      time.Now(),
+	}
+	if err := s.repo.AddVersion(ctx, version); err != nil {
+		return nil, err
+	}
+
+	if err := s.eventSvc.RecordEvent(ctx, "SECRET_PUT_VALUE", secret.ID.String(), "SECRET", map[string]interface{}{
+		"name":    secret.Name,
+		"version": version.Version,
+		"stage":   stage,
+	}); err != nil {
+		s.logger.Warn("failed to record event for secret value update", "secret_id", secret.ID.String(), "error", err)
+	}
+
+	if err := s.auditSvc.Log(ctx, userID, "secret.put_value", "secret", secret.ID.String(), map[string]interface{}{
+		"name":    secret.Name,
+		"version": version.Version,
+		"stage":   stage,
+	}); err != nil {
+		s.logger.Warn("failed to log audit event for secret value update", "secret_id", secret.ID.String(), "error", err)
+	}
+
+	version.EncryptedValue = ""
+	return version, nil
+}
+
+func (s *SecretService) ListSecretVersions(ctx context.Context, id uuid.UUID) ([]*domain.SecretVersion, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionSecretRead, id.String()); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.getTenantSecret(ctx, id, tenantID, domain.PermissionSecretRead); err != nil {
+		return nil, err
+	}
+
+	versions, err := s.repo.ListVersions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range versions {
+		v.EncryptedValue = ""
+	}
+	return versions, nil
+}
+
+func (s *SecretService) GetSecretVersion(ctx context.Context, id uuid.UUID, version int) (*domain.SecretVersion, error) {
+	return s.getVersion(ctx, id, func(secretID uuid.UUID) (*domain.SecretVersion, error) {
+		return s.repo.GetVersion(ctx, secretID, version)
+	})
+}
+
+func (s *SecretService) GetSecretVersionByStage(ctx context.Context, id uuid.UUID, stage domain.SecretStage) (*domain.SecretVersion, error) {
+	if !stage.IsValid() {
+		return nil, errors.New(errors.InvalidInput, "stage must be current, previous or pending")
+	}
+	return s.getVersion(ctx, id, func(secretID uuid.UUID) (*domain.SecretVersion, error) {
+		return s.repo.GetVersionByStage(ctx, secretID, stage)
+	})
+}
+
+func (s *SecretService) getVersion(ctx context.Context, id uuid.UUID, load func(uuid.UUID) (*domain.SecretVersion, error)) (*domain.SecretVersion, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionSecretRead, id.String()); err != nil {
+		return nil, err
+	}
+
+	secret, err := s.getTenantSecret(ctx, id, tenantID, domain.PermissionSecretRead)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := load(secret.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.decryptVersion(secret, v); err != nil {
+		return nil, err
+	}
+
+	if err := s.auditSvc.Log(ctx, userID, "secret.access", "secret", secret.ID.String(), map[string]interface{}{
+		"name":    secret.Name,
+		"version": v.Version,
+	}); err != nil {
+		s.logger.Warn("failed to log audit event for secret access", "secret_id", secret.ID.String(), "error", err)
+	}
+	return v, nil
+}
+
+func (s *SecretService) UpdateSecretVersionStage(ctx context.Context, id uuid.UUID, stage domain.SecretStage, version int) error {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if stage != domain.SecretStageCurrent && stage != domain.SecretStagePending {
+		return errors.New(errors.InvalidInput, "only the current and pending stages can be moved")
+	}
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionSecretWrite, id.String()); err != nil {
+		return err
+	}
+
+	secret, err := s.getTenantSecret(ctx, id, tenantID, domain.PermissionSecretWrite)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.MoveStage(ctx, secret.ID, stage, version); err != nil {
+		return err
+	}
+
+	if err := s.auditSvc.Log(ctx, userID, "secret.stage_update", "secret", secret.ID.String(), map[string]interface{}{
+		"name":    secret.Name,
+		"version": version,
+		"stage":   stage,
+	}); err != nil {
+		s.logger.Warn("failed to log audit event for secret stage update", "secret_id", secret.ID.String(), "error", err)
+	}
+	return nil
+}
+
+func (s *SecretService) ResolveSecretRef(ctx context.Context, ref string) (string, error) {
+	name, stage := domain.ParseSecretRef(ref)
+
+	if stage == domain.SecretStageCurrent {
+		secret, err := s.GetSecretByName(ctx, name)
+		if err != nil {
+			return "", err
+		}
+		return secret.EncryptedValue, nil
+	}
+
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionSecretRead, name); err != nil {
+		return "", err
+	}
+
+	secret, err := s.repo.GetByName(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	if secret.TenantID != tenantID && secret.TenantID != uuid.Nil {
+		return "", errors.New(errors.NotFound, "secret not found")
+	}
+
+	v, err := s.repo.GetVersionByStage(ctx, secret.ID, stage)
+	if err != nil {
+		return "", err
+	}
+	if err := s.decryptVersion(secret, v); err != nil {
+		return "", err
+	}
+	return v.EncryptedValue, nil
+}
@@ -0,0 +1,226 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/errors"
+)
+
+const (
+	maxRotationIntervalDays = 365
+	// rotationRetryDelay postpones a failed scheduled rotation so a broken function is not invoked every tick.
+	rotationRetryDelay = time.Hour
+)
+
+// SecretRotationServiceParams defines dependencies for secretRotationService.
+type SecretRotationServiceParams struct {
+	Repo        ports.SecretRepository
+	SecretSvc   ports.SecretService
+	FunctionSvc ports.FunctionService
+	RBACSvc     ports.RBACService
+	AuditSvc    ports.AuditService
+	Logger      *slog.Logger
+}
+
+type secretRotationService struct {
+	repo        ports.SecretRepository
+	secretSvc   ports.SecretService
+	functionSvc ports.FunctionService
+	rbacSvc     ports.RBACService
+	auditSvc    ports.AuditService
+	logger      *slog.Logger
+}
+
+// NewSecretRotationService creates a service that rotates secrets by invoking a Cloud Function.
+func NewSecretRotationService(params SecretRotationServiceParams) *secretRotationService {
+	logger := params.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &secretRotationService{
+		repo:        params.Repo,
+		secretSvc:   params.SecretSvc,
+		functionSvc: params.FunctionSvc,
+		rbacSvc:     params.RBACSvc,
+		auditSvc:    params.AuditSvc,
+		logger:      logger,
+	}
+}
+
+// rotationPayload is the event a rotation function receives in its PAYLOAD env var.
+type rotationPayload struct {
+	SecretID       uuid.UUID `json:"secret_id"`
+	SecretName     string    `json:"secret_name"`
+	CurrentVersion int       `json:"current_version"`
+}
+
+func (s *secretRotationService) ConfigureRotation(ctx context.Context, id, functionID uuid.UUID, intervalDays int) (*domain.Secret, error) {
+	if intervalDays < 1 || intervalDays > maxRotationIntervalDays {
+		return nil, errors.New(errors.InvalidInput, fmt.Sprintf("rotation interval must be between 1 and %d days", maxRotationIntervalDays))
+	}
+
+	secret, err := s.authorizedSecret(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resolving the function through its service checks the caller may use it.
+	if _, err := s.functionSvc.GetFunction(ctx, functionID); err != nil {
+		return nil, err
+	}
+
+	next := time.Now().AddDate(0, 0, intervalDays)
+	secret.RotationFunctionID = &functionID
+	secret.RotationIntervalDays = intervalDays
+	secret.NextRotationAt = &next
+	if err := s.repo.UpdateRotation(ctx, secret); err != nil {
+		return nil, err
+	}
+
+	s.audit(ctx, "secret.rotation_configure", secret, map[string]interface{}{
+		"function_id":   functionID.String(),
+		"interval_days": intervalDays,
+	})
+	secret.EncryptedValue = ""
+	return secret, nil
+}
+
+func (s *secretRotationService) DisableRotation(ctx context.Context, id uuid.UUID) error {
+	secret, err := s.authorizedSecret(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	secret.RotationFunctionID = nil
+	secret.RotationIntervalDays = 0
+	secret.NextRotationAt = nil
+	if err := s.repo.UpdateRotation(ctx, secret); err != nil {
+		return err
+	}
+
+	s.audit(ctx, "secret.rotation_disable", secret, nil)
+	return nil
+}
+
+func (s *secretRotationService) RotateSecret(ctx context.Context, id uuid.UUID) (*domain.SecretVersion, error) {
+	secret, err := s.authorizedSecret(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if secret.RotationFunctionID == nil {
+		return nil, errors.New(errors.InvalidInput, "rotation is not configured for this secret")
+	}
+
+	payload, err := json.Marshal(rotationPayload{SecretID: secret.ID, SecretName: secret.Name, CurrentVersion: secret.CurrentVersion})
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to build rotation payload", err)
+	}
+
+	inv, err := s.functionSvc.InvokeFunctionRedacted(ctx, *secret.RotationFunctionID, payload)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "rotation function failed", err)
+	}
+	if inv.Status != "SUCCESS" {
+		return nil, errors.New(errors.Internal, fmt.Sprintf("rotation function exited with status %d", inv.StatusCode))
+	}
+
+	value := lastOutputLine(inv.Logs)
+	if value == "" {
+		return nil, errors.New(errors.Internal, "rotation function did not print a new value")
+	}
+
+	// Stage first so a failed promotion leaves the new value inspectable as pending.
+	version, err := s.secretSvc.PutSecretValue(ctx, secret.ID, value, domain.SecretStagePending)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.secretSvc.UpdateSecretVersionStage(ctx, secret.ID, domain.SecretStageCurrent, version.Version); err != nil {
+		return nil, err
+	}
+	version.Stages = []domain.SecretStage{domain.SecretStageCurrent}
+
+	now := time.Now()
+	secret.LastRotatedAt = &now
+	if secret.RotationIntervalDays > 0 {
+		next := now.AddDate(0, 0, secret.RotationIntervalDays)
+		secret.NextRotationAt = &next
+	}
+	if err := s.repo.UpdateRotation(ctx, secret); err != nil {
+		s.logger.Warn("failed to record secret rotation time", "secret_id", secret.ID, "error", err)
+	}
+
+	s.audit(ctx, "secret.rotate", secret, map[string]interface{}{"version": version.Version})
+	return version, nil
+}
+
+func (s *secretRotationService) RotateDue(ctx context.Context) (int, error) {
+	due, err := s.repo.ListDueForRotation(ctx, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	rotated := 0
+	for _, secret := range due {
+		// Rotate as the owner, the same identity the secret's values are encrypted for.
+		ownerCtx := appcontext.WithTenantID(appcontext.WithUserID(ctx, secret.UserID), secret.TenantID)
+		if _, err := s.RotateSecret(ownerCtx, secret.ID); err != nil {
+			s.logger.Warn("scheduled secret rotation failed", "secret_id", secret.ID, "error", err)
+			retry := time.Now().Add(rotationRetryDelay)
+			secret.NextRotationAt = &retry
+			if err := s.repo.UpdateRotation(ownerCtx, secret); err != nil {
+				s.logger.Error("failed to postpone secret rotation", "secret_id", secret.ID, "error", err)
+			}
+			continue
+		}
+		rotated++
+	}
+	return rotated, nil
+}
+
+func (s *secretRotationService) authorizedSecret(ctx context.Context, id uuid.UUID) (*domain.Secret, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionSecretWrite, id.String()); err != nil {
+		return nil, err
+	}
+
+	secret, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if secret.TenantID != tenantID && secret.TenantID != uuid.Nil {
+		return nil, errors.New(errors.NotFound, "secret not found")
+	}
+	return secret, nil
+}
+
+func (s *secretRotationService) audit(ctx context.Context, action string, secret *domain.Secret, details map[string]interface{}) {
+	if details == nil {
+		details = map[string]interface{}{}
+	}
+	details["name"] = secret.Name
+	if err := s.auditSvc.Log(ctx, appcontext.UserIDFromContext(ctx), action, "secret", secret.ID.String(), details); err != nil {
+		s.logger.Warn("failed to log audit event", "action", action, "secret_id", secret.ID, "error", err)
+	}
+}
+
+// lastOutputLine returns the last non-empty line a function printed.
+func lastOutputLine(logs string) string {
+	lines := strings.Split(logs, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		if line := strings.TrimSpace(lines[i]); line != "" {
+			return line
+		}
+	}
+	return ""
+}
@@ -0,0 +1,132 @@
+package services_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/services"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupSecretRotationTest() (*MockSecretRepo, *MockSecretService, *MockFunctionService, *services.SecretRotationServiceParams) {
+	repo := new(MockSecretRepo)
+	secretSvc := new(MockSecretService)
+	fnSvc := new(MockFunctionService)
+	rbacSvc := new(MockRBACService)
+	rbacSvc.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	auditSvc := new(MockAuditService)
+	auditSvc.On("Log", mock.Anything, mock.Anything, mock.Anything, "secret", mock.Anything, mock.Anything).Return(nil)
+	return repo, secretSvc, fnSvc, &services.SecretRotationServiceParams{
+		Repo: repo, SecretSvc: secretSvc, FunctionSvc: fnSvc, RBACSvc: rbacSvc, AuditSvc: auditSvc, Logger: slog.Default(),
+	}
+}
+
+func TestSecretRotationService_Unit(t *testing.T) {
+	tenantID := uuid.New()
+	ctx := appcontext.WithTenantID(appcontext.WithUserID(context.Background(), uuid.New()), tenantID)
+
+	t.Run("ConfigureRotation", func(t *testing.T) {
+		repo, _, fnSvc, params := setupSecretRotationTest()
+		svc := services.NewSecretRotationService(*params)
+		secret := &domain.Secret{ID: uuid.New(), TenantID: tenantID, Name: "api-key"}
+		fnID := uuid.New()
+
+		repo.On("GetByID", mock.Anything, secret.ID).Return(secret, nil).Once()
+		fnSvc.On("GetFunction", mock.Anything, fnID).Return(&domain.Function{ID: fnID}, nil).Once()
+		repo.On("UpdateRotation", mock.Anything, mock.MatchedBy(func(s *domain.Secret) bool {
+			return *s.RotationFunctionID == fnID && s.RotationIntervalDays == 30 && s.NextRotationAt.After(time.Now().AddDate(0, 0, 29))
+		})).Return(nil).Once()
+
+		updated, err := svc.ConfigureRotation(ctx, secret.ID, fnID, 30)
+		require.NoError(t, err)
+		assert.True(t, updated.RotationEnabled())
+	})
+
+	t.Run("ConfigureRotation_InvalidInterval", func(t *testing.T) {
+		_, _, _, params := setupSecretRotationTest()
+		svc := services.NewSecretRotationService(*params)
+
+		_, err := svc.ConfigureRotation(ctx, uuid.New(), uuid.New(), 0)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.InvalidInput))
+	})
+
+	t.Run("RotateSecret_StagesThenPromotes", func(t *testing.T) {
+		repo, secretSvc, fnSvc, params := setupSecretRotationTest()
+		svc := services.NewSecretRotationService(*params)
+		fnID := uuid.New()
+		secret := &domain.Secret{ID: uuid.New(), TenantID: tenantID, Name: "api-key", CurrentVersion: 2, RotationFunctionID: &fnID, RotationIntervalDays: 7}
+
+		repo.On("GetByID", mock.Anything, secret.ID).Return(secret, nil).Once()
+		fnSvc.On("InvokeFunctionRedacted", mock.Anything, fnID, mock.Anything).
+			Return(&domain.Invocation{Status: "SUCCESS", Logs: "generating key\nnew-value-123\n"}, nil).Once()
+		secretSvc.On("PutSecretValue", mock.Anything, secret.ID, "new-value-123", domain.SecretStagePending).
+			Return(&domain.SecretVersion{SecretID: secret.ID, Version: 3, Stages: []domain.SecretStage{domain.SecretStagePending}}, nil).Once()
+		secretSvc.On("UpdateSecretVersionStage", mock.Anything, secret.ID, domain.SecretStageCurrent, 3).Return(nil).Once()
+		repo.On("UpdateRotation", mock.Anything, mock.MatchedBy(func(s *domain.Secret) bool {
+			return s.LastRotatedAt != nil && s.NextRotationAt != nil
+		})).Return(nil).Once()
+
+		v, err := svc.RotateSecret(ctx, secret.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 3, v.Version)
+		assert.True(t, v.HasStage(domain.SecretStageCurrent))
+		secretSvc.AssertExpectations(t)
+	})
+
+	t.Run("RotateSecret_FunctionFailed", func(t *testing.T) {
+		repo, secretSvc, fnSvc, params := setupSecretRotationTest()
+		svc := services.NewSecretRotationService(*params)
+		fnID := uuid.New()
+		secret := &domain.Secret{ID: uuid.New(), TenantID: tenantID, RotationFunctionID: &fnID, RotationIntervalDays: 7}
+
+		repo.On("GetByID", mock.Anything, secret.ID).Return(secret, nil).Once()
+		fnSvc.On("InvokeFunctionRedacted", mock.Anything, fnID, mock.Anything).
+			Return(&domain.Invocation{Status: "FAILED", StatusCode: 1}, nil).Once()
+
+		_, err := svc.RotateSecret(ctx, secret.ID)
+		require.Error(t, err)
+		secretSvc.AssertNotCalled(t, "PutSecretValue", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("RotateSecret_NotConfigured", func(t *testing.T) {
+		repo, _, _, params := setupSecretRotationTest()
+		svc := services.NewSecretRotationService(*params)
+		secret := &domain.Secret{ID: uuid.New(), TenantID: tenantID}
+
+		repo.On("GetByID", mock.Anything, secret.ID).Return(secret, nil).Once()
+
+		_, err := svc.RotateSecret(ctx, secret.ID)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.InvalidInput))
+	})
+
+	t.Run("RotateDue_PostponesFailures", func(t *testing.T) {
+		repo, _, fnSvc, params := setupSecretRotationTest()
+		svc := services.NewSecretRotationService(*params)
+		fnID := uuid.New()
+		secret := &domain.Secret{ID: uuid.New(), UserID: uuid.New(), TenantID: uuid.New(), RotationFunctionID: &fnID, RotationIntervalDays: 7}
+
+		repo.On("ListDueForRotation", mock.Anything, mock.Anything).Return([]*domain.Secret{secret}, nil).Once()
+		repo.On("GetByID", mock.Anything, secret.ID).Return(secret, nil).Once()
+		fnSvc.On("InvokeFunctionRedacted", mock.MatchedBy(func(c context.Context) bool {
+			return appcontext.UserIDFromContext(c) == secret.UserID && appcontext.TenantIDFromContext(c) == secret.TenantID
+		}), fnID, mock.Anything).Return(nil, assert.AnError).Once()
+		repo.On("UpdateRotation", mock.Anything, mock.MatchedBy(func(s *domain.Secret) bool {
+			return s.NextRotationAt != nil && s.NextRotationAt.After(time.Now().Add(30*time.Minute))
+		})).Return(nil).Once()
+
+		rotated, err := svc.RotateDue(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 0, rotated)
+		repo.AssertExpectations(t)
+	})
+}
@@ -20,6 +20,7 @@ func testSecretServiceUnitCRUD(t *testing.T) {
 	mockRepo := new(MockSecretRepo)
 	mockRBAC := new(MockRBACService)
 	mockRBAC.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockRBAC.On("ResourcePolicyOwner", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(uuid.Nil, false)
 	mockEvent := new(MockEventService)
 	mockAudit := new(MockAuditService)
 	mockAudit.On("Log", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
@@ -268,6 +269,7 @@ func testSecretServiceUnitCRUD(t *testing.T) {
 
 func TestSecretService_Unit(t *testing.T) {
 	t.Run("CRUD", testSecretServiceUnitCRUD)
+	t.Run("Versions", testSecretServiceUnitVersions)
 	t.Run("NewErrors", testNewSecretServiceUnitErrors)
 	t.Run("AuthorizeErrors", testSecretServiceUnitAuthorizeErrors)
 }
@@ -440,3 +442,108 @@ func testSecretServiceUnitAuthorizeErrors(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func testSecretServiceUnitVersions(t *testing.T) {
+	mockRepo := new(MockSecretRepo)
+	mockRBAC := new(MockRBACService)
+	mockRBAC.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockRBAC.On("ResourcePolicyOwner", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(uuid.Nil, false)
+	mockEvent := new(MockEventService)
+	mockEvent.On("RecordEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockAudit := new(MockAuditService)
+	mockAudit.On("Log", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	svc, err := services.NewSecretService(services.SecretServiceParams{
+		Repo:      mockRepo,
+		RBACSvc:   mockRBAC,
+		EventSvc:  mockEvent,
+		AuditSvc:  mockAudit,
+		Logger:    slog.Default(),
+		MasterKey: "test-master-key-32-chars-long-!!!",
+	})
+	require.NoError(t, err)
+
+	ownerID := uuid.New()
+	tenantID := uuid.New()
+	// A different tenant member writes the value; it must still be encrypted for the owner.
+	writerCtx := appcontext.WithTenantID(appcontext.WithUserID(context.Background(), uuid.New()), tenantID)
+	secret := &domain.Secret{ID: uuid.New(), UserID: ownerID, TenantID: tenantID, Name: "db-password"}
+	ownerCipher, err := svc.Encrypt(context.Background(), ownerID, "hunter2")
+	require.NoError(t, err)
+
+	t.Run("PutSecretValue_DefaultsToCurrent", func(t *testing.T) {
+		mockRepo.On("GetByID", mock.Anything, secret.ID).Return(secret, nil).Once()
+		var storedCipher string
+		mockRepo.On("AddVersion", mock.Anything, mock.MatchedBy(func(v *domain.SecretVersion) bool {
+			storedCipher = v.EncryptedValue
+			return v.SecretID == secret.ID && len(v.Stages) == 1 && v.Stages[0] == domain.SecretStageCurrent
+		})).Return(nil).Once()
+
+		v, err := svc.PutSecretValue(writerCtx, secret.ID, "rotated", "")
+		require.NoError(t, err)
+		assert.Empty(t, v.EncryptedValue)
+
+		plain, err := svc.Decrypt(context.Background(), ownerID, storedCipher)
+		require.NoError(t, err)
+		assert.Equal(t, "rotated", plain)
+	})
+
+	t.Run("PutSecretValue_RejectsPrevious", func(t *testing.T) {
+		_, err := svc.PutSecretValue(writerCtx, secret.ID, "x", domain.SecretStagePrevious)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.InvalidInput))
+	})
+
+	t.Run("GetSecretVersionByStage_Decrypts", func(t *testing.T) {
+		mockRepo.On("GetByID", mock.Anything, secret.ID).Return(secret, nil).Once()
+		mockRepo.On("GetVersionByStage", mock.Anything, secret.ID, domain.SecretStagePrevious).
+			Return(&domain.SecretVersion{SecretID: secret.ID, Version: 2, EncryptedValue: ownerCipher}, nil).Once()
+
+		v, err := svc.GetSecretVersionByStage(writerCtx, secret.ID, domain.SecretStagePrevious)
+		require.NoError(t, err)
+		assert.Equal(t, "hunter2", v.EncryptedValue)
+	})
+
+	t.Run("ListSecretVersions_HidesValues", func(t *testing.T) {
+		mockRepo.On("GetByID", mock.Anything, secret.ID).Return(secret, nil).Once()
+		mockRepo.On("ListVersions", mock.Anything, secret.ID).
+			Return([]*domain.SecretVersion{{Version: 2, EncryptedValue: ownerCipher}, {Version: 1, EncryptedValue: ownerCipher}}, nil).Once()
+
+		versions, err := svc.ListSecretVersions(writerCtx, secret.ID)
+		require.NoError(t, err)
+		require.Len(t, versions, 2)
+		for _, v := range versions {
+			assert.Empty(t, v.EncryptedValue)
+		}
+	})
+
+	t.Run("UpdateSecretVersionStage_Rollback", func(t *testing.T) {
+		mockRepo.On("GetByID", mock.Anything, secret.ID).Return(secret, nil).Once()
+		mockRepo.On("MoveStage", mock.Anything, secret.ID, domain.SecretStageCurrent, 1).Return(nil).Once()
+
+		require.NoError(t, svc.UpdateSecretVersionStage(writerCtx, secret.ID, domain.SecretStageCurrent, 1))
+	})
+
+	t.Run("ResolveSecretRef_PinnedStage", func(t *testing.T) {
+		mockRepo.On("GetByName", mock.Anything, "db-password").Return(secret, nil).Once()
+		mockRepo.On("GetVersionByStage", mock.Anything, secret.ID, domain.SecretStagePending).
+			Return(&domain.SecretVersion{SecretID: secret.ID, Version: 3, EncryptedValue: ownerCipher}, nil).Once()
+
+		value, err := svc.ResolveSecretRef(writerCtx, "@db-password:pending")
+		require.NoError(t, err)
+		assert.Equal(t, "hunter2", value)
+	})
+
+	t.Run("ResolveSecretRef_ColonInName", func(t *testing.T) {
+		// "url" is not a stage, so the whole ref names the secret "db:url".
+		colonSecret := *secret
+		colonSecret.Name = "db:url"
+		colonSecret.EncryptedValue = ownerCipher
+		mockRepo.On("GetByName", mock.Anything, "db:url").Return(&colonSecret, nil).Once()
+		mockRepo.On("Update", mock.Anything, &colonSecret).Return(nil).Once()
+
+		value, err := svc.ResolveSecretRef(writerCtx, "@db:url")
+		require.NoError(t, err)
+		assert.Equal(t, "hunter2", value)
+	})
+}
@@ -161,6 +161,16 @@ func (s *SecurityGroupService) DeleteGroup(ctx context.Context, id uuid.UUID) er
 
 	span.SetAttributes(attribute.String("group_id", id.String()))
 
+	referencing, err := s.repo.ListReferencingGroups(ctx, id)
+	if err != nil {
+		return err
+	}
+	for _, ref := range referencing {
+		if ref.ID != id {
+			return errors.New(errors.Conflict, fmt.Sprintf("security group is referenced by rules in group %s", ref.Name))
+		}
+	}
+
 	// In a real implementation, we should check if any instances are still attached
 	// For now, we just delete.
 	if err := s.repo.Delete(ctx, id); err != nil {
@@ -202,6 +212,10 @@ func (s *SecurityGroupService) AddRule(ctx context.Context, idOrName string, rul
 		return nil, err
 	}
 
+	if err := s.validateRule(ctx, sg, &rule); err != nil {
+		return nil, err
+	}
+
 	rule.ID = uuid.New()
 	rule.GroupID = groupID
 	rule.CreatedAt = time.Now()
@@ -209,6 +223,7 @@ func (s *SecurityGroupService) AddRule(ctx context.Context, idOrName string, rul
 	if err := s.repo.AddRule(ctx, &rule); err != nil {
 		return nil, errors.Wrap(errors.Internal, "failed to add security rule", err)
 	}
+	sg.Rules = append(sg.Rules, rule)
 
 	// Update OVS flows
 	if err := s.syncGroupFlows(ctx, sg); err != nil {
@@ -225,6 +240,37 @@ func (s *SecurityGroupService) AddRule(ctx context.Context, idOrName string, rul
 	return &rule, nil
 }
 
+// validateRule checks port bounds and the rule's peer. A source group must live in the same VPC.
+func (s *SecurityGroupService) validateRule(ctx context.Context, sg *domain.SecurityGroup, rule *domain.SecurityRule) error {
+	if rule.PortMin > 0 && rule.PortMax == 0 {
+		rule.PortMax = rule.PortMin
+	}
+	if rule.PortMin < 0 || rule.PortMax > 65535 || rule.PortMin > rule.PortMax {
+		return errors.New(errors.InvalidInput, fmt.Sprintf("invalid port range %d-%d", rule.PortMin, rule.PortMax))
+	}
+
+	if rule.SourceGroupID == nil {
+		return nil
+	}
+	if rule.CIDR != "" {
+		return errors.New(errors.InvalidInput, "rule cannot have both cidr and source_group_id")
+	}
+	if *rule.SourceGroupID == sg.ID {
+		return nil
+	}
+	peer, err := s.repo.GetByID(ctx, *rule.SourceGroupID)
+	if err != nil {
+		if errors.Is(err, errors.NotFound) {
+			return errors.New(errors.InvalidInput, "source security group not found")
+		}
+		return err
+	}
+	if peer.VPCID != sg.VPCID {
+		return errors.New(errors.InvalidInput, "source security group must be in the same VPC")
+	}
+	return nil
+}
+
 func (s *SecurityGroupService) resolveGroupID(ctx context.Context, idOrName string) (uuid.UUID, error) {
 	id, err := uuid.Parse(idOrName)
 	if err == nil {
@@ -270,8 +316,7 @@ func (s *SecurityGroupService) RemoveRule(ctx context.Context, ruleID uuid.UUID)
 	// In production, this should likely be a localized transaction or workflow.
 	vpc, err := s.vpcRepo.GetByID(ctx, sg.VPCID)
 	if err == nil {
-		flow := s.translateToFlow(*rule)
-		if err := s.network.DeleteFlowRule(ctx, vpc.NetworkID, flow.Match); err != nil {
+		if err := s.network.DeleteFlowRule(ctx, vpc.NetworkID, cookieMatch(rule.ID)); err != nil {
 			// Log but proceed to ensure DB consistency
 			s.logger.Error("failed to delete OVS flow rule", "rule_id", ruleID, "error", err)
 		}
@@ -323,6 +368,7 @@ func (s *SecurityGroupService) AttachToInstance(ctx context.Context, instanceID,
 	if err := s.syncGroupFlows(ctx, sg); err != nil {
 		return err
 	}
+	s.syncReferencingGroups(ctx, groupID)
 
 	if err := s.auditSvc.Log(ctx, sg.UserID, "security_group.attach", "instance", instanceID.String(), map[string]interface{}{
 		"group_id": groupID.String(),
@@ -360,6 +406,7 @@ func (s *SecurityGroupService) DetachFromInstance(ctx context.Context, instanceI
 			s.logger.Error("failed to remove OVS flows", "group_id", groupID, "error", err)
 		}
 	}
+	s.syncReferencingGroups(ctx, groupID)
 
 	if err := s.auditSvc.Log(ctx, userID, "security_group.detach", "instance", instanceID.String(), map[string]interface{}{
 		"group_id": groupID.String(),
@@ -370,23 +417,123 @@ func (s *SecurityGroupService) DetachFromInstance(ctx context.Context, instanceI
 	return nil
 }
 
+// AttachToNetworkInterface adds a network interface to a group, so that rules
+// sourced from the group also match the interface's private IPs.
+func (s *SecurityGroupService) AttachToNetworkInterface(ctx context.Context, interfaceID, groupID uuid.UUID) error {
+	ctx, span := otel.Tracer(securityGroupTracer).Start(ctx, "AttachToNetworkInterface")
+	defer span.End()
+
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionSgUpdate, groupID.String()); err != nil {
+		return err
+	}
+
+	span.SetAttributes(
+		attribute.String("interface_id", interfaceID.String()),
+		attribute.String("group_id", groupID.String()),
+	)
+
+	sg, err := s.repo.GetByID(ctx, groupID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.AddInterfaceToGroup(ctx, interfaceID, groupID); err != nil {
+		return err
+	}
+
+	if err := s.syncGroupFlows(ctx, sg); err != nil {
+		return err
+	}
+	s.syncReferencingGroups(ctx, groupID)
+
+	if err := s.auditSvc.Log(ctx, userID, "security_group.attach", "network_interface", interfaceID.String(), map[string]interface{}{
+		"group_id": groupID.String(),
+	}); err != nil {
+		s.logger.Warn("failed to log audit event", "action", "security_group.attach", "resource_id", interfaceID.String(), "error", err)
+	}
+
+	return nil
+}
+
+// DetachFromNetworkInterface removes a network interface from a group.
+func (s *SecurityGroupService) DetachFromNetworkInterface(ctx context.Context, interfaceID, groupID uuid.UUID) error {
+	ctx, span := otel.Tracer(securityGroupTracer).Start(ctx, "DetachFromNetworkInterface")
+	defer span.End()
+
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionSgUpdate, groupID.String()); err != nil {
+		return err
+	}
+
+	span.SetAttributes(
+		attribute.String("interface_id", interfaceID.String()),
+		attribute.String("group_id", groupID.String()),
+	)
+
+	if err := s.repo.RemoveInterfaceFromGroup(ctx, interfaceID, groupID); err != nil {
+		return err
+	}
+	s.syncReferencingGroups(ctx, groupID)
+
+	if err := s.auditSvc.Log(ctx, userID, "security_group.detach", "network_interface", interfaceID.String(), map[string]interface{}{
+		"group_id": groupID.String(),
+	}); err != nil {
+		s.logger.Warn("failed to log audit event", "action", "security_group.detach", "resource_id", interfaceID.String(), "error", err)
+	}
+
+	return nil
+}
+
 func (s *SecurityGroupService) syncGroupFlows(ctx context.Context, sg *domain.SecurityGroup) error {
 	vpc, err := s.vpcRepo.GetByID(ctx, sg.VPCID)
 	if err != nil {
 		return err
 	}
 
-	// For each rule, generate an OVS flow
+	if err := s.ensureConntrackFlows(ctx, vpc); err != nil {
+		return err
+	}
+
+	// Each rule's flows share a cookie, so replacing them also drops flows for
+	// port masks or member IPs that no longer apply.
 	for _, rule := range sg.Rules {
-		flow := s.translateToFlow(rule)
-		if err := s.network.AddFlowRule(ctx, vpc.NetworkID, flow); err != nil {
+		flows, err := s.translateToFlows(ctx, rule, vpc.VXLANID)
+		if err != nil {
 			return err
 		}
+		if rule.ID != uuid.Nil {
+			if err := s.network.DeleteFlowRule(ctx, vpc.NetworkID, cookieMatch(rule.ID)); err != nil {
+				return err
+			}
+		}
+		for _, flow := range flows {
+			if err := s.network.AddFlowRule(ctx, vpc.NetworkID, flow); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
 }
 
+// syncReferencingGroups refreshes the flows of groups whose rules expand to the members of groupID.
+func (s *SecurityGroupService) syncReferencingGroups(ctx context.Context, groupID uuid.UUID) {
+	groups, err := s.repo.ListReferencingGroups(ctx, groupID)
+	if err != nil {
+		s.logger.Error("failed to list referencing security groups", "group_id", groupID, "error", err)
+		return
+	}
+	for _, sg := range groups {
+		if err := s.syncGroupFlows(ctx, sg); err != nil {
+			s.logger.Error("failed to sync OVS flows", "group_id", sg.ID, "error", err)
+		}
+	}
+}
+
 func (s *SecurityGroupService) removeGroupFlows(ctx context.Context, sg *domain.SecurityGroup) error {
 	vpc, err := s.vpcRepo.GetByID(ctx, sg.VPCID)
 	if err != nil {
@@ -394,8 +541,10 @@ func (s *SecurityGroupService) removeGroupFlows(ctx context.Context, sg *domain.
 	}
 
 	for _, rule := range sg.Rules {
-		flow := s.translateToFlow(rule)
-		if err := s.network.DeleteFlowRule(ctx, vpc.NetworkID, flow.Match); err != nil {
+		if rule.ID == uuid.Nil {
+			continue
+		}
+		if err := s.network.DeleteFlowRule(ctx, vpc.NetworkID, cookieMatch(rule.ID)); err != nil {
 			s.logger.Error("failed to delete flow rule", "rule", rule.ID, "error", err)
 		}
 	}
@@ -403,44 +552,161 @@ func (s *SecurityGroupService) removeGroupFlows(ctx context.Context, sg *domain.
 	return nil
 }
 
-func (s *SecurityGroupService) translateToFlow(rule domain.SecurityRule) ports.FlowRule {
-	matchParts := []string{}
+// translateToFlows expands a rule into OVS flows, resolving source groups to their member IPs.
+func (s *SecurityGroupService) translateToFlows(ctx context.Context, rule domain.SecurityRule, zone int) ([]ports.FlowRule, error) {
+	peers, err := securityRulePeers(ctx, s.repo, rule)
+	if err != nil {
+		return nil, err
+	}
+	return securityRuleFlows(rule, peers, zone), nil
+}
 
-	switch rule.Protocol {
-	case "tcp":
-		matchParts = append(matchParts, "tcp")
-	case "udp":
-		matchParts = append(matchParts, "udp")
-	case "icmp":
-		matchParts = append(matchParts, "icmp")
-	case "arp":
-		matchParts = append(matchParts, "arp")
+func (s *SecurityGroupService) ensureConntrackFlows(ctx context.Context, vpc *domain.VPC) error {
+	for _, flow := range conntrackFlows(vpc.VXLANID) {
+		if err := s.network.AddFlowRule(ctx, vpc.NetworkID, flow); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	if rule.CIDR != "" && rule.CIDR != "0.0.0.0/0" {
-		if rule.Direction == domain.RuleIngress {
-			matchParts = append(matchParts, fmt.Sprintf("nw_src=%s", rule.CIDR))
-		} else {
-			matchParts = append(matchParts, fmt.Sprintf("nw_dst=%s", rule.CIDR))
+const (
+	// conntrackPriority sits above every rule so tracked return traffic is admitted first.
+	conntrackPriority = 65000
+	ctNew             = "ct_state=+trk+new"
+)
+
+// conntrackFlows are the bridge-wide flows that make rules stateful: untracked IP
+// packets are sent through conntrack, replies to admitted connections pass, and invalid
+// packets are dropped. Rules then only need to match the first packet of a connection.
+// Each VPC tracks connections in its own zone so flow logs can tell tenants apart.
+// IPv6 neighbor solicitations and advertisements bypass conntrack, as ARP does for IPv4.
+func conntrackFlows(zone int) []ports.FlowRule {
+	return []ports.FlowRule{
+		{Priority: conntrackPriority, Match: "ip,ct_state=-trk", Actions: ctAction("table=0", zone)},
+		{Priority: conntrackPriority, Match: "ip,ct_state=+trk+est", Actions: "NORMAL"},
+		{Priority: conntrackPriority, Match: "ip,ct_state=+trk+rel", Actions: "NORMAL"},
+		{Priority: conntrackPriority, Match: "ip,ct_state=+trk+inv", Actions: "drop"},
+		{Priority: conntrackPriority + 1, Match: "icmp6,icmp_type=135", Actions: "NORMAL"},
+		{Priority: conntrackPriority + 1, Match: "icmp6,icmp_type=136", Actions: "NORMAL"},
+		{Priority: conntrackPriority, Match: "ipv6,ct_state=-trk", Actions: ctAction("table=0", zone)},
+		{Priority: conntrackPriority, Match: "ipv6,ct_state=+trk+est", Actions: "NORMAL"},
+		{Priority: conntrackPriority, Match: "ipv6,ct_state=+trk+rel", Actions: "NORMAL"},
+		{Priority: conntrackPriority, Match: "ipv6,ct_state=+trk+inv", Actions: "drop"},
+	}
+}
+
+// anyIPv6 is the peer that matches every IPv6 address.
+const anyIPv6 = "::/0"
+
+// ipv6Protocols maps IPv4 OVS protocol keywords to their IPv6 equivalents.
+var ipv6Protocols = map[string]string{"ip": "ipv6", "tcp": "tcp6", "udp": "udp6", "icmp": "icmp6"}
+
+// securityRuleFlows expands a rule into OVS flows: one per peer address and port mask.
+// peers are the addresses the rule matches, where "" means any address.
+func securityRuleFlows(rule domain.SecurityRule, peers []string, zone int) []ports.FlowRule {
+	cookie := flowCookie(rule.ID)
+
+	if rule.Protocol == "arp" {
+		return []ports.FlowRule{{Priority: rule.Priority, Match: "arp", Actions: "NORMAL", Cookie: cookie}}
+	}
+
+	proto := rule.Protocol
+	if proto == "" || proto == "all" {
+		proto = "ip"
+	}
+
+	portMatches := []string{""}
+	if (proto == "tcp" || proto == "udp") && rule.PortMin > 0 {
+		portMax := rule.PortMax
+		if portMax < rule.PortMin {
+			portMax = rule.PortMin
 		}
+		portMatches = portRangeMasks(rule.PortMin, portMax)
+	}
+
+	peerField := "nw_src"
+	if rule.Direction == domain.RuleEgress {
+		peerField = "nw_dst"
 	}
 
-	if rule.PortMin > 0 {
-		if rule.PortMin == rule.PortMax {
-			switch rule.Protocol {
-			case "tcp", "udp":
-				matchParts = append(matchParts, fmt.Sprintf("tp_dst=%d", rule.PortMin))
+	flows := make([]ports.FlowRule, 0, len(peers)*len(portMatches))
+	for _, peer := range peers {
+		peerProto, field := proto, peerField
+		if strings.Contains(peer, ":") {
+			peerProto, field = ipv6Protocols[proto], "ipv6_"+strings.TrimPrefix(peerField, "nw_")
+			if peer == anyIPv6 {
+				peer = ""
+			}
+		}
+		for _, port := range portMatches {
+			matchParts := []string{peerProto, ctNew}
+			if peer != "" {
+				matchParts = append(matchParts, fmt.Sprintf("%s=%s", field, peer))
+			}
+			if port != "" {
+				matchParts = append(matchParts, "tp_dst="+port)
 			}
-		} else if rule.PortMax > rule.PortMin {
-			// OVS doesn't support easy ranges, would need multiple flows or mask
-			// Simplified for now
-			matchParts = append(matchParts, fmt.Sprintf("tp_dst=%d", rule.PortMin))
+			flows = append(flows, ports.FlowRule{
+				Priority: rule.Priority,
+				Match:    strings.Join(matchParts, ","),
+				Actions:  ctAction("commit", zone) + ",NORMAL",
+				Cookie:   cookie,
+			})
 		}
 	}
+	return flows
+}
 
-	return ports.FlowRule{
-		Priority: rule.Priority,
-		Match:    strings.Join(matchParts, ","),
-		Actions:  "NORMAL",
+// ctAction builds a ct() action in the VPC's conntrack zone; zone 0 is the default zone.
+func ctAction(args string, zone int) string {
+	if zone > 0 {
+		args += fmt.Sprintf(",zone=%d", zone)
+	}
+	return "ct(" + args + ")"
+}
+
+// securityRulePeers returns the addresses a rule matches; "" means any IPv4 address
+// and "::/0" any IPv6 address. A rule sourced from a group yields both the IPv4 and
+// IPv6 addresses of its members, and none when the group has no addressable members.
+func securityRulePeers(ctx context.Context, repo ports.SecurityGroupRepository, rule domain.SecurityRule) ([]string, error) {
+	if rule.SourceGroupID == nil {
+		if rule.CIDR == "" || rule.CIDR == "0.0.0.0/0" {
+			return []string{""}, nil
+		}
+		return []string{rule.CIDR}, nil
+	}
+
+	ips, err := repo.ListGroupMemberIPs(ctx, *rule.SourceGroupID)
+	if err != nil {
+		return nil, err
+	}
+	peers := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		if strings.Contains(ip, ":") {
+			peers = append(peers, ip+"/128")
+			continue
+		}
+		peers = append(peers, ip+"/32")
+	}
+	return peers, nil
+}
+
+// portRangeMasks covers [lo, hi] with the fewest value/mask pairs OVS can match,
+// e.g. 8000-8063 is the single match 0x1f40/0xffc0. A lone port is matched exactly.
+func portRangeMasks(lo, hi int) []string {
+	var matches []string
+	for lo <= hi {
+		size := 1
+		for size < 0x10000 && lo%(size*2) == 0 && lo+size*2-1 <= hi {
+			size *= 2
+		}
+		if size == 1 {
+			matches = append(matches, fmt.Sprintf("%d", lo))
+		} else {
+			matches = append(matches, fmt.Sprintf("0x%04x/0x%04x", lo, 0xffff&^(size-1)))
+		}
+		lo += size
 	}
+	return matches
 }
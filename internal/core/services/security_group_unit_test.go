@@ -3,12 +3,15 @@ package services_test
 import (
 	"context"
 	"log/slog"
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
 	appcontext "github.com/poyrazk/thecloud/internal/core/context"
 	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
 	"github.com/poyrazk/thecloud/internal/core/services"
+	"github.com/poyrazk/thecloud/internal/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -21,6 +24,9 @@ func TestSecurityGroupService_Unit(t *testing.T) {
 	mockAuditSvc := new(MockAuditService)
 	rbacSvc := new(MockRBACService)
 	rbacSvc.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	// Rule flows are replaced by cookie, and membership changes resync referencing groups.
+	mockNetwork.On("DeleteFlowRule", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	mockRepo.On("ListReferencingGroups", mock.Anything, mock.Anything).Return([]*domain.SecurityGroup{}, nil).Maybe()
 
 	svc := services.NewSecurityGroupService(mockRepo, rbacSvc, mockVpcRepo, mockNetwork, mockAuditSvc, slog.Default())
 
@@ -229,3 +235,200 @@ func TestSecurityGroupService_Unit(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 }
+
+func TestSecurityGroupService_Flows(t *testing.T) {
+	ctx := appcontext.WithUserID(context.Background(), uuid.New())
+	vpcID := uuid.New()
+
+	setup := func() (*services.SecurityGroupService, *MockSecurityGroupRepo, *MockNetworkBackend, *[]ports.FlowRule) {
+		repo := new(MockSecurityGroupRepo)
+		vpcRepo := new(MockVpcRepo)
+		network := new(MockNetworkBackend)
+		auditSvc := new(MockAuditService)
+		rbacSvc := new(MockRBACService)
+		rbacSvc.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		vpcRepo.On("GetByID", mock.Anything, vpcID).Return(&domain.VPC{ID: vpcID, NetworkID: "br-vpc"}, nil).Maybe()
+		network.On("DeleteFlowRule", mock.Anything, "br-vpc", mock.Anything).Return(nil).Maybe()
+		auditSvc.On("Log", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+		var flows []ports.FlowRule
+		network.On("AddFlowRule", mock.Anything, "br-vpc", mock.Anything).Run(func(args mock.Arguments) {
+			flows = append(flows, args.Get(2).(ports.FlowRule))
+		}).Return(nil).Maybe()
+
+		svc := services.NewSecurityGroupService(repo, rbacSvc, vpcRepo, network, auditSvc, slog.Default())
+		return svc, repo, network, &flows
+	}
+
+	ruleFlows := func(flows []ports.FlowRule) []ports.FlowRule {
+		var out []ports.FlowRule
+		for _, f := range flows {
+			if f.Cookie != 0 {
+				out = append(out, f)
+			}
+		}
+		return out
+	}
+
+	t.Run("PortRangeUsesMasks", func(t *testing.T) {
+		svc, repo, _, flows := setup()
+		sgID := uuid.New()
+		repo.On("GetByID", mock.Anything, sgID).Return(&domain.SecurityGroup{ID: sgID, VPCID: vpcID}, nil).Once()
+		repo.On("AddRule", mock.Anything, mock.Anything).Return(nil).Once()
+
+		_, err := svc.AddRule(ctx, sgID.String(), domain.SecurityRule{
+			Direction: domain.RuleIngress, Protocol: "tcp", PortMin: 8000, PortMax: 8080, CIDR: "10.0.0.0/16", Priority: 100,
+		})
+		require.NoError(t, err)
+
+		var matches []string
+		for _, f := range ruleFlows(*flows) {
+			matches = append(matches, f.Match)
+			assert.Equal(t, "ct(commit),NORMAL", f.Actions)
+		}
+		assert.Equal(t, []string{
+			"tcp,ct_state=+trk+new,nw_src=10.0.0.0/16,tp_dst=0x1f40/0xffc0",
+			"tcp,ct_state=+trk+new,nw_src=10.0.0.0/16,tp_dst=0x1f80/0xfff0",
+			"tcp,ct_state=+trk+new,nw_src=10.0.0.0/16,tp_dst=8080",
+		}, matches)
+	})
+
+	t.Run("InstallsConntrackFlows", func(t *testing.T) {
+		svc, repo, _, flows := setup()
+		sgID := uuid.New()
+		repo.On("GetByID", mock.Anything, sgID).Return(&domain.SecurityGroup{ID: sgID, VPCID: vpcID}, nil).Once()
+		repo.On("AddRule", mock.Anything, mock.Anything).Return(nil).Once()
+
+		_, err := svc.AddRule(ctx, sgID.String(), domain.SecurityRule{Direction: domain.RuleIngress, Protocol: "icmp"})
+		require.NoError(t, err)
+
+		var base []string
+		for _, f := range *flows {
+			if f.Cookie == 0 {
+				base = append(base, f.Match+" -> "+f.Actions)
+			}
+		}
+		assert.Contains(t, base, "ip,ct_state=-trk -> ct(table=0)")
+		assert.Contains(t, base, "ip,ct_state=+trk+est -> NORMAL")
+	})
+
+	t.Run("SourceGroupExpandsToMemberIPs", func(t *testing.T) {
+		svc, repo, _, flows := setup()
+		sgID := uuid.New()
+		appID := uuid.New()
+		repo.On("GetByID", mock.Anything, sgID).Return(&domain.SecurityGroup{ID: sgID, VPCID: vpcID}, nil).Once()
+		repo.On("GetByID", mock.Anything, appID).Return(&domain.SecurityGroup{ID: appID, VPCID: vpcID}, nil).Once()
+		repo.On("AddRule", mock.Anything, mock.Anything).Return(nil).Once()
+		repo.On("ListGroupMemberIPs", mock.Anything, appID).Return([]string{"10.0.1.5", "10.0.1.6"}, nil).Once()
+
+		_, err := svc.AddRule(ctx, sgID.String(), domain.SecurityRule{
+			Direction: domain.RuleIngress, Protocol: "tcp", PortMin: 5432, SourceGroupID: &appID,
+		})
+		require.NoError(t, err)
+
+		got := ruleFlows(*flows)
+		require.Len(t, got, 2)
+		assert.Equal(t, "tcp,ct_state=+trk+new,nw_src=10.0.1.5/32,tp_dst=5432", got[0].Match)
+		assert.Equal(t, "tcp,ct_state=+trk+new,nw_src=10.0.1.6/32,tp_dst=5432", got[1].Match)
+		assert.Equal(t, got[0].Cookie, got[1].Cookie)
+	})
+
+	t.Run("IPv6RuleUsesIPv6Matches", func(t *testing.T) {
+		svc, repo, _, flows := setup()
+		sgID := uuid.New()
+		repo.On("GetByID", mock.Anything, sgID).Return(&domain.SecurityGroup{ID: sgID, VPCID: vpcID}, nil).Once()
+		repo.On("AddRule", mock.Anything, mock.Anything).Return(nil).Twice()
+
+		_, err := svc.AddRule(ctx, sgID.String(), domain.SecurityRule{
+			Direction: domain.RuleIngress, Protocol: "tcp", PortMin: 443, CIDR: "2001:db8::/32", Priority: 100,
+		})
+		require.NoError(t, err)
+		repo.On("GetByID", mock.Anything, sgID).Return(&domain.SecurityGroup{ID: sgID, VPCID: vpcID}, nil).Once()
+		_, err = svc.AddRule(ctx, sgID.String(), domain.SecurityRule{
+			Direction: domain.RuleEgress, Protocol: "all", CIDR: "::/0", Priority: 100,
+		})
+		require.NoError(t, err)
+
+		got := ruleFlows(*flows)
+		require.Len(t, got, 2)
+		assert.Equal(t, "tcp6,ct_state=+trk+new,ipv6_src=2001:db8::/32,tp_dst=443", got[0].Match)
+		assert.Equal(t, "ipv6,ct_state=+trk+new", got[1].Match)
+
+		var base []string
+		for _, f := range *flows {
+			if f.Cookie == 0 {
+				base = append(base, f.Match)
+			}
+		}
+		assert.Contains(t, base, "ipv6,ct_state=-trk")
+		assert.Contains(t, base, "icmp6,icmp_type=135")
+	})
+
+	t.Run("SourceGroupIncludesIPv6Members", func(t *testing.T) {
+		svc, repo, _, flows := setup()
+		sgID := uuid.New()
+		appID := uuid.New()
+		repo.On("GetByID", mock.Anything, sgID).Return(&domain.SecurityGroup{ID: sgID, VPCID: vpcID}, nil).Once()
+		repo.On("GetByID", mock.Anything, appID).Return(&domain.SecurityGroup{ID: appID, VPCID: vpcID}, nil).Once()
+		repo.On("AddRule", mock.Anything, mock.Anything).Return(nil).Once()
+		repo.On("ListGroupMemberIPs", mock.Anything, appID).Return([]string{"10.0.1.5", "fd00:1:2:3::5"}, nil).Once()
+
+		_, err := svc.AddRule(ctx, sgID.String(), domain.SecurityRule{
+			Direction: domain.RuleIngress, Protocol: "tcp", PortMin: 5432, SourceGroupID: &appID,
+		})
+		require.NoError(t, err)
+
+		got := ruleFlows(*flows)
+		require.Len(t, got, 2)
+		assert.Equal(t, "tcp,ct_state=+trk+new,nw_src=10.0.1.5/32,tp_dst=5432", got[0].Match)
+		assert.Equal(t, "tcp6,ct_state=+trk+new,ipv6_src=fd00:1:2:3::5/128,tp_dst=5432", got[1].Match)
+	})
+
+	t.Run("SourceGroupInOtherVPC", func(t *testing.T) {
+		svc, repo, _, _ := setup()
+		sgID := uuid.New()
+		otherID := uuid.New()
+		repo.On("GetByID", mock.Anything, sgID).Return(&domain.SecurityGroup{ID: sgID, VPCID: vpcID}, nil).Once()
+		repo.On("GetByID", mock.Anything, otherID).Return(&domain.SecurityGroup{ID: otherID, VPCID: uuid.New()}, nil).Once()
+
+		_, err := svc.AddRule(ctx, sgID.String(), domain.SecurityRule{Direction: domain.RuleIngress, Protocol: "tcp", PortMin: 22, SourceGroupID: &otherID})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.InvalidInput))
+		repo.AssertNotCalled(t, "AddRule", mock.Anything, mock.Anything)
+	})
+
+	t.Run("AttachResyncsReferencingGroups", func(t *testing.T) {
+		svc, repo, network, flows := setup()
+		appID := uuid.New()
+		dbID := uuid.New()
+		instanceID := uuid.New()
+		dbRule := domain.SecurityRule{ID: uuid.New(), GroupID: dbID, Direction: domain.RuleIngress, Protocol: "tcp", PortMin: 5432, PortMax: 5432, SourceGroupID: &appID}
+
+		repo.On("AddInstanceToGroup", mock.Anything, instanceID, appID).Return(nil).Once()
+		repo.On("GetByID", mock.Anything, appID).Return(&domain.SecurityGroup{ID: appID, VPCID: vpcID}, nil).Once()
+		repo.On("ListReferencingGroups", mock.Anything, appID).Return([]*domain.SecurityGroup{
+			{ID: dbID, VPCID: vpcID, Rules: []domain.SecurityRule{dbRule}},
+		}, nil).Once()
+		repo.On("ListGroupMemberIPs", mock.Anything, appID).Return([]string{"10.0.1.9"}, nil).Once()
+
+		require.NoError(t, svc.AttachToInstance(ctx, instanceID, appID))
+
+		got := ruleFlows(*flows)
+		require.Len(t, got, 1)
+		assert.Equal(t, "tcp,ct_state=+trk+new,nw_src=10.0.1.9/32,tp_dst=5432", got[0].Match)
+		network.AssertCalled(t, "DeleteFlowRule", mock.Anything, "br-vpc", mock.MatchedBy(func(m string) bool {
+			return strings.HasPrefix(m, "cookie=0x") && strings.HasSuffix(m, "/-1")
+		}))
+	})
+
+	t.Run("DeleteReferencedGroup", func(t *testing.T) {
+		svc, repo, _, _ := setup()
+		appID := uuid.New()
+		repo.On("ListReferencingGroups", mock.Anything, appID).Return([]*domain.SecurityGroup{{ID: uuid.New(), Name: "db"}}, nil).Once()
+
+		err := svc.DeleteGroup(ctx, appID)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.Conflict))
+		repo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
@@ -124,6 +124,7 @@ type StorageServiceParams struct {
 	AuditSvc   ports.AuditService
 	EncryptSvc ports.EncryptionService
 	Config     *platform.Config
+	TenantSvc  ports.TenantService // Optional, enforces the buckets quota
 	Logger     *slog.Logger
 }
 
@@ -135,6 +136,7 @@ type StorageService struct {
 	auditSvc   ports.AuditService
 	encryptSvc ports.EncryptionService
 	cfg        *platform.Config
+	tenantSvc  ports.TenantService
 	logger     *slog.Logger
 }
 
@@ -151,6 +153,7 @@ func NewStorageService(params StorageServiceParams) *StorageService {
 		auditSvc:   params.AuditSvc,
 		encryptSvc: params.EncryptSvc,
 		cfg:        params.Config,
+		tenantSvc:  params.TenantSvc,
 		logger:     logger,
 	}
 }
@@ -318,7 +321,7 @@ func (s *StorageService) Download(ctx context.Context, bucket, key string) (io.R
 	}
 
 	// 1. Get metadata
-	obj, err := s.repo.GetMeta(ctx, bucket, key)
+	obj, err := s.repo.GetMeta(s.objectContext(ctx, bucket, domain.PermissionStorageRead), bucket, key)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -362,6 +365,21 @@ func (s *StorageService) Download(ctx context.Context, bucket, key string) (io.R
 	return reader, obj, nil
 }
 
+// objectContext scopes object lookups to the bucket owner when another tenant shared
+// the bucket with the caller through a resource policy. Object metadata is filtered by
+// the owning user, so without it a shared bucket would look empty.
+func (s *StorageService) objectContext(ctx context.Context, bucket string, permission domain.Permission) context.Context {
+	sharedCtx, ok := sharedResourceContext(ctx, s.rbacSvc, permission, bucket)
+	if !ok {
+		return ctx
+	}
+	b, err := s.repo.GetBucket(ctx, bucket)
+	if err != nil {
+		return ctx
+	}
+	return appcontext.WithUserID(sharedCtx, b.UserID)
+}
+
 type readCloserWrapper struct {
 	io.Reader
 	io.Closer
@@ -375,7 +393,7 @@ func (s *StorageService) ListObjects(ctx context.Context, bucket string) ([]*dom
 		return nil, err
 	}
 
-	return s.repo.List(ctx, bucket)
+	return s.repo.List(s.objectContext(ctx, bucket, domain.PermissionStorageRead), bucket)
 }
 
 func (s *StorageService) DownloadVersion(ctx context.Context, bucket, key, versionID string) (io.ReadCloser, *domain.Object, error) {
@@ -387,7 +405,7 @@ func (s *StorageService) DownloadVersion(ctx context.Context, bucket, key, versi
 	}
 
 	// 1. Get metadata
-	obj, err := s.repo.GetMetaByVersion(ctx, bucket, key, versionID)
+	obj, err := s.repo.GetMetaByVersion(s.objectContext(ctx, bucket, domain.PermissionStorageRead), bucket, key, versionID)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -414,7 +432,7 @@ func (s *StorageService) ListVersions(ctx context.Context, bucket, key string) (
 		return nil, err
 	}
 
-	return s.repo.ListVersions(ctx, bucket, key)
+	return s.repo.ListVersions(s.objectContext(ctx, bucket, domain.PermissionStorageRead), bucket, key)
 }
 
 func (s *StorageService) DeleteVersion(ctx context.Context, bucket, key, versionID string) error {
@@ -485,6 +503,7 @@ func (s *StorageService) CreateBucket(ctx context.Context, name string, isPublic
 	userID := appcontext.UserIDFromContext(ctx)
 	tenantID := appcontext.TenantIDFromContext(ctx)
 
+	ctx = appcontext.WithRequestAttributes(ctx, map[string]interface{}{string(domain.KeyBucketPublic): isPublic})
 	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionStorageWrite, "*"); err != nil {
 		span.RecordError(err)
 		return nil, err
@@ -506,6 +525,9 @@ func (s *StorageService) CreateBucket(ctx context.Context, name string, isPublic
 	if strings.Contains(name, "..") {
 		return nil, errors.New(errors.InvalidInput, "bucket name cannot contain consecutive dots")
 	}
+	if err := checkQuota(ctx, s.tenantSvc, domain.QuotaBuckets, 1); err != nil {
+		return nil, err
+	}
 
 	bucket := &domain.Bucket{
 		ID:        uuid.New(),
@@ -519,6 +541,7 @@ func (s *StorageService) CreateBucket(ctx context.Context, name string, isPublic
 	if err := s.repo.CreateBucket(ctx, bucket); err != nil {
 		return nil, errors.Wrap(errors.Internal, "failed to create bucket", err)
 	}
+	recordUsage(ctx, s.tenantSvc, s.logger, tenantID, domain.QuotaBuckets, 1)
 
 	if err := s.auditSvc.Log(ctx, bucket.UserID, "storage.bucket_create", "bucket", name, map[string]interface{}{
 		"is_public": isPublic,
@@ -579,7 +602,11 @@ func (s *StorageService) DeleteBucket(ctx context.Context, name string, force bo
 	}
 
 	// 3. Delete bucket record
-	return s.repo.DeleteBucket(ctx, name)
+	if err := s.repo.DeleteBucket(ctx, name); err != nil {
+		return err
+	}
+	recordUsage(ctx, s.tenantSvc, s.logger, tenantID, domain.QuotaBuckets, -1)
+	return nil
 }
 
 func (s *StorageService) ListBuckets(ctx context.Context) ([]*domain.Bucket, error) {
@@ -134,6 +134,7 @@ func setupStorageServiceIntegrationTest(t *testing.T) (ports.StorageService, por
 
 	rbacSvc := new(MockRBACService)
 	rbacSvc.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	rbacSvc.On("ResourcePolicyOwner", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(uuid.Nil, false)
 
 	auditSvc := services.NewAuditService(services.AuditServiceParams{
 		Repo:    auditRepo,
@@ -499,6 +500,7 @@ func TestStorageService_Integration(t *testing.T) {
 		// GeneratePresignedURL secret missing
 		rbacSvc := new(MockRBACService)
 		rbacSvc.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		rbacSvc.On("ResourcePolicyOwner", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(uuid.Nil, false)
 		badCfg := &platform.Config{StorageSecret: "", Port: "8080"}
 		badSvc := services.NewStorageService(services.StorageServiceParams{
 			Repo:       postgres.NewStorageRepository(db),
@@ -22,6 +22,9 @@ type SubnetServiceParams struct {
 	VpcRepo  ports.VpcRepository
 	AuditSvc ports.AuditService
 	Logger   *slog.Logger
+	// ACLRepo and Network are optional; when set, subnets get their network ACL flows.
+	ACLRepo ports.NetworkACLRepository
+	Network ports.NetworkBackend
 }
 
 // SubnetService manages subnet lifecycle within VPCs.
@@ -31,6 +34,8 @@ type SubnetService struct {
 	vpcRepo  ports.VpcRepository
 	auditSvc ports.AuditService
 	logger   *slog.Logger
+	aclRepo  ports.NetworkACLRepository
+	network  ports.NetworkBackend
 }
 
 // NewSubnetService constructs a SubnetService with its dependencies.
@@ -45,6 +50,8 @@ func NewSubnetService(params SubnetServiceParams) *SubnetService {
 		vpcRepo:  params.VpcRepo,
 		auditSvc: params.AuditSvc,
 		logger:   logger,
+		aclRepo:  params.ACLRepo,
+		network:  params.Network,
 	}
 }
 
@@ -102,6 +109,8 @@ func (s *SubnetService) CreateSubnet(ctx context.Context, vpcID uuid.UUID, name,
 		return nil, err
 	}
 
+	s.applyNetworkACL(ctx, vpc, subnet)
+
 	if err := s.auditSvc.Log(ctx, userID, "subnet.create", "subnet", subnetID.String(), map[string]interface{}{
 		"vpc_id":     vpcID.String(),
 		"name":       name,
@@ -156,12 +165,145 @@ func (s *SubnetService) DeleteSubnet(ctx context.Context, id uuid.UUID) error {
 		return err
 	}
 
+	s.removeNetworkACL(ctx, subnet)
+
 	if err := s.auditSvc.Log(ctx, subnet.UserID, "subnet.delete", "subnet", id.String(), nil); err != nil {
 		s.logger.Warn("failed to log audit event", "action", "subnet.delete", "subnet_id", id, "error", err)
 	}
 	return nil
 }
 
+// AssignIPv6CIDRBlock gives a subnet a /64 out of its VPC's IPv6 block.
+// When cidrBlock is empty the lowest /64 not used by another subnet is picked.
+func (s *SubnetService) AssignIPv6CIDRBlock(ctx context.Context, id uuid.UUID, cidrBlock string) (*domain.Subnet, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcUpdate, id.String()); err != nil {
+		return nil, err
+	}
+
+	subnet, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if subnet.IPv6CIDRBlock != "" {
+		return nil, errors.New(errors.Conflict, "subnet already has an ipv6 cidr block")
+	}
+
+	vpc, err := s.vpcRepo.GetByID(ctx, subnet.VPCID)
+	if err != nil {
+		return nil, err
+	}
+	if vpc.IPv6CIDRBlock == "" {
+		return nil, errors.New(errors.InvalidInput, "vpc has no ipv6 cidr block")
+	}
+	_, vpcNet, err := net.ParseCIDR(vpc.IPv6CIDRBlock)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "invalid VPC IPv6 CIDR", err)
+	}
+
+	siblings, err := s.repo.ListByVPC(ctx, vpc.ID)
+	if err != nil {
+		return nil, err
+	}
+	used := make(map[string]bool, len(siblings))
+	for _, sib := range siblings {
+		if sib.IPv6CIDRBlock != "" {
+			used[sib.IPv6CIDRBlock] = true
+		}
+	}
+
+	if cidrBlock == "" {
+		cidrBlock, err = nextFreeIPv6Subnet(vpcNet, used)
+		if err != nil {
+			return nil, err
+		}
+	}
+	ip, subnetNet, err := net.ParseCIDR(cidrBlock)
+	if err != nil || ip.To4() != nil {
+		return nil, errors.New(errors.InvalidInput, "invalid subnet IPv6 CIDR block")
+	}
+	if ones, _ := subnetNet.Mask.Size(); ones != domain.SubnetIPv6PrefixLength {
+		return nil, errors.New(errors.InvalidInput, fmt.Sprintf("subnet IPv6 CIDR block must be a /%d", domain.SubnetIPv6PrefixLength))
+	}
+	if !vpcNet.Contains(subnetNet.IP) {
+		return nil, errors.New(errors.InvalidInput, "subnet IPv6 CIDR must be within VPC IPv6 CIDR range")
+	}
+	if used[subnetNet.String()] {
+		return nil, errors.New(errors.Conflict, "ipv6 cidr block already in use in this vpc")
+	}
+
+	subnet.IPv6CIDRBlock = subnetNet.String()
+	if err := s.repo.Update(ctx, subnet); err != nil {
+		return nil, err
+	}
+	// The subnet's ACL now has to filter its IPv6 traffic too.
+	s.applyNetworkACL(ctx, vpc, subnet)
+
+	if err := s.auditSvc.Log(ctx, userID, "subnet.ipv6_assign", "subnet", subnet.ID.String(), map[string]interface{}{
+		"ipv6_cidr_block": subnet.IPv6CIDRBlock,
+	}); err != nil {
+		s.logger.Warn("failed to log audit event", "action", "subnet.ipv6_assign", "subnet_id", subnet.ID, "error", err)
+	}
+	return subnet, nil
+}
+
+// nextFreeIPv6Subnet returns the lowest /64 of a VPC's /56 that is not in used.
+func nextFreeIPv6Subnet(vpcNet *net.IPNet, used map[string]bool) (string, error) {
+	base := vpcNet.IP.To16()
+	for i := 0; i < 1<<(domain.SubnetIPv6PrefixLength-domain.VPCIPv6PrefixLength); i++ {
+		ip := make(net.IP, net.IPv6len)
+		copy(ip, base)
+		ip[7] = byte(i)
+		candidate := (&net.IPNet{IP: ip, Mask: net.CIDRMask(domain.SubnetIPv6PrefixLength, 128)}).String()
+		if !used[candidate] {
+			return candidate, nil
+		}
+	}
+	return "", errors.New(errors.ResourceLimitExceeded, "no free /64 left in the VPC's IPv6 block")
+}
+
+// applyNetworkACL programs the flows of the ACL governing a new or newly dual-stack subnet,
+// normally its VPC's default ACL.
+func (s *SubnetService) applyNetworkACL(ctx context.Context, vpc *domain.VPC, subnet *domain.Subnet) {
+	if s.aclRepo == nil || s.network == nil {
+		return
+	}
+	bindings, err := s.aclRepo.ListSubnetBindings(ctx, vpc.ID)
+	if err != nil {
+		s.logger.Error("failed to resolve network ACL for subnet", "subnet_id", subnet.ID, "error", err)
+		return
+	}
+	for _, b := range bindings {
+		if b.SubnetID != subnet.ID {
+			continue
+		}
+		rules, err := s.aclRepo.ListRules(ctx, b.ACLID)
+		if err == nil {
+			err = programSubnetACL(ctx, s.network, vpc, b, rules)
+		}
+		if err != nil {
+			s.logger.Error("failed to program network ACL for subnet", "subnet_id", subnet.ID, "error", err)
+		}
+		return
+	}
+}
+
+// removeNetworkACL deletes the ACL flows of a deleted subnet.
+func (s *SubnetService) removeNetworkACL(ctx context.Context, subnet *domain.Subnet) {
+	if s.aclRepo == nil || s.network == nil {
+		return
+	}
+	vpc, err := s.vpcRepo.GetByID(ctx, subnet.VPCID)
+	if err == nil {
+		err = removeSubnetACLFlows(ctx, s.network, vpc, subnet.CIDRBlock, subnet.IPv6CIDRBlock)
+	}
+	if err != nil {
+		s.logger.Error("failed to remove network ACL flows for subnet", "subnet_id", subnet.ID, "error", err)
+	}
+}
+
 func (s *SubnetService) calculateGatewayIP(ip net.IP, _ *net.IPNet) string {
 	// Simple implementation: IP + 1
 	gw := make(net.IP, len(ip))
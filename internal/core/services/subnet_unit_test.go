@@ -101,4 +101,45 @@ func TestSubnetService_Unit(t *testing.T) {
 		err := svc.DeleteSubnet(ctx, id)
 		require.NoError(t, err)
 	})
+	t.Run("AssignIPv6PicksNextFree64", func(t *testing.T) {
+		id := uuid.New()
+		vpcID := uuid.New()
+		repo.On("GetByID", mock.Anything, id).Return(&domain.Subnet{ID: id, VPCID: vpcID}, nil).Once()
+		vpcRepo.On("GetByID", mock.Anything, vpcID).Return(&domain.VPC{ID: vpcID, IPv6CIDRBlock: "fd12:3456:7800::/56"}, nil).Once()
+		repo.On("ListByVPC", mock.Anything, vpcID).Return([]*domain.Subnet{
+			{ID: uuid.New(), IPv6CIDRBlock: "fd12:3456:7800::/64"},
+			{ID: id},
+		}, nil).Once()
+		repo.On("Update", mock.Anything, mock.MatchedBy(func(s *domain.Subnet) bool {
+			return s.ID == id && s.IPv6CIDRBlock == "fd12:3456:7800:1::/64"
+		})).Return(nil).Once()
+		auditSvc.On("Log", mock.Anything, userID, "subnet.ipv6_assign", "subnet", id.String(), mock.Anything).Return(nil).Once()
+
+		subnet, err := svc.AssignIPv6CIDRBlock(ctx, id, "")
+		require.NoError(t, err)
+		assert.Equal(t, "fd12:3456:7800:1::/64", subnet.IPv6CIDRBlock)
+	})
+
+	t.Run("AssignIPv6OutsideVPCBlock", func(t *testing.T) {
+		id := uuid.New()
+		vpcID := uuid.New()
+		repo.On("GetByID", mock.Anything, id).Return(&domain.Subnet{ID: id, VPCID: vpcID}, nil).Once()
+		vpcRepo.On("GetByID", mock.Anything, vpcID).Return(&domain.VPC{ID: vpcID, IPv6CIDRBlock: "fd12:3456:7800::/56"}, nil).Once()
+		repo.On("ListByVPC", mock.Anything, vpcID).Return([]*domain.Subnet{{ID: id}}, nil).Once()
+
+		_, err := svc.AssignIPv6CIDRBlock(ctx, id, "fd99::/64")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "within VPC IPv6 CIDR range")
+	})
+
+	t.Run("AssignIPv6RequiresDualStackVPC", func(t *testing.T) {
+		id := uuid.New()
+		vpcID := uuid.New()
+		repo.On("GetByID", mock.Anything, id).Return(&domain.Subnet{ID: id, VPCID: vpcID}, nil).Once()
+		vpcRepo.On("GetByID", mock.Anything, vpcID).Return(&domain.VPC{ID: vpcID, CIDRBlock: "10.0.0.0/16"}, nil).Once()
+
+		_, err := svc.AssignIPv6CIDRBlock(ctx, id, "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no ipv6 cidr block")
+	})
 }
@@ -100,10 +100,11 @@ func TestSystem_ComputeLifecycle_Full(t *testing.T) {
 	tenantRepo := postgres.NewTenantRepo(db)
 	userRepo := postgres.NewUserRepo(db)
 	tenantSvc := services.NewTenantService(services.TenantServiceParams{
-		Repo:     tenantRepo,
-		UserRepo: userRepo,
-		RBACSvc:  rbacSvc,
-		Logger:   logger,
+		Repo:      tenantRepo,
+		QuotaRepo: postgres.NewQuotaRepo(db),
+		UserRepo:  userRepo,
+		RBACSvc:   rbacSvc,
+		Logger:    logger,
 	})
 	sshKeyRepo := postgres.NewSSHKeyRepo(db)
 	sshKeySvc, err := services.NewSSHKeyService(services.SSHKeyServiceParams{
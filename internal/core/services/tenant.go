@@ -3,7 +3,12 @@ package services
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,20 +18,29 @@ import (
 	"github.com/poyrazk/thecloud/internal/errors"
 )
 
+// invitationTTL is how long an invitation token stays valid after being issued or resent.
+const invitationTTL = 7 * 24 * time.Hour
+
 // TenantServiceParams defines dependencies for TenantService.
 type TenantServiceParams struct {
-	Repo     ports.TenantRepository
-	UserRepo ports.UserRepository
-	RBACSvc  ports.RBACService
-	Logger   *slog.Logger
+	Repo           ports.TenantRepository
+	InvitationRepo ports.TenantInvitationRepository
+	QuotaRepo      ports.QuotaRepository
+	UserRepo       ports.UserRepository
+	RBACSvc        ports.RBACService
+	AuditSvc       ports.AuditService
+	Logger         *slog.Logger
 }
 
-// TenantService manages tenants, membership, and quota checks.
+// TenantService manages tenants, membership, invitations, and quota checks.
 type TenantService struct {
-	repo     ports.TenantRepository
-	userRepo ports.UserRepository
-	rbacSvc  ports.RBACService
-	logger   *slog.Logger
+	repo           ports.TenantRepository
+	invitationRepo ports.TenantInvitationRepository
+	quotaRepo      ports.QuotaRepository
+	userRepo       ports.UserRepository
+	rbacSvc        ports.RBACService
+	auditSvc       ports.AuditService
+	logger         *slog.Logger
 }
 
 // NewTenantService constructs a TenantService.
@@ -36,10 +50,13 @@ func NewTenantService(params TenantServiceParams) *TenantService {
 		logger = slog.Default()
 	}
 	return &TenantService{
-		repo:     params.Repo,
-		userRepo: params.UserRepo,
-		rbacSvc:  params.RBACSvc,
-		logger:   logger,
+		repo:           params.Repo,
+		invitationRepo: params.InvitationRepo,
+		quotaRepo:      params.QuotaRepo,
+		userRepo:       params.UserRepo,
+		rbacSvc:        params.RBACSvc,
+		auditSvc:       params.AuditSvc,
+		logger:         logger,
 	}
 }
 
@@ -85,21 +102,9 @@ func (s *TenantService) CreateTenant(ctx context.Context, name, slug string, own
 		return nil, err
 	}
 
-	// 4. Set default quotas
-	quota := &domain.TenantQuota{
-		TenantID:     tenant.ID,
-		MaxInstances: 10,
-		MaxVPCs:      2,
-		MaxStorageGB: 50,
-		MaxMemoryGB:  16,
-		MaxVCPUs:     8,
-	}
-	if err := s.repo.UpdateQuota(ctx, quota); err != nil {
-		// Log but don't fail tenant creation
-		s.logger.Error("failed to set default quota", "tenant_id", tenant.ID, "err", err)
-	}
+	// Quotas start at the registry defaults; no rows are needed until a limit is changed.
 
-	// 5. Update user's default tenant if not set
+	// 4. Update user's default tenant if not set
 	user, err := s.userRepo.GetByID(ctx, ownerID)
 	if err == nil && user.DefaultTenantID == nil {
 		user.DefaultTenantID = &tenant.ID
@@ -129,28 +134,180 @@ func (s *TenantService) ListUserTenants(ctx context.Context, userID uuid.UUID) (
 	return s.repo.ListUserTenants(ctx, userID)
 }
 
-func (s *TenantService) InviteMember(ctx context.Context, tenantID uuid.UUID, email, role string) error {
+// InviteMember issues a pending invitation for email to join the tenant with the given role.
+// The invitee does not need an account yet; they accept with the token once signed in.
+func (s *TenantService) InviteMember(ctx context.Context, tenantID uuid.UUID, email, role string) (*domain.TenantInvitation, error) {
 	userID := appcontext.UserIDFromContext(ctx)
 
 	// Must have update permission in the target tenant
 	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionTenantUpdate, tenantID.String()); err != nil {
+		return nil, err
+	}
+
+	email = normalizeEmail(email)
+	if email == "" {
+		return nil, errors.New(errors.InvalidInput, "email is required")
+	}
+	if err := validateInviteRole(role); err != nil {
+		return nil, err
+	}
+
+	// Reject invitations for people who already belong to the tenant
+	if user, err := s.userRepo.GetByEmail(ctx, email); err == nil && user != nil {
+		membership, _ := s.repo.GetMembership(ctx, tenantID, user.ID)
+		if membership != nil {
+			return nil, errors.New(errors.Conflict, "user is already a member of this tenant")
+		}
+	}
+
+	token, hash, err := newInvitationToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	inv := &domain.TenantInvitation{
+		ID:        uuid.New(),
+		TenantID:  tenantID,
+		Email:     email,
+		Role:      role,
+		Status:    domain.InvitationPending,
+		InvitedBy: userID,
+		TokenHash: hash,
+		ExpiresAt: now.Add(invitationTTL),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.invitationRepo.Create(ctx, inv); err != nil {
+		return nil, err
+	}
+
+	// In a real system the token would be emailed; until then it is returned once to the inviter.
+	inv.Token = token
+	s.audit(ctx, userID, "tenant.invitation_create", inv.ID.String(), map[string]interface{}{
+		"tenant_id": tenantID.String(),
+		"email":     email,
+		"role":      role,
+	})
+	return inv, nil
+}
+
+func (s *TenantService) ListInvitations(ctx context.Context, tenantID uuid.UUID) ([]domain.TenantInvitation, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionTenantUpdate, tenantID.String()); err != nil {
+		return nil, err
+	}
+	return s.invitationRepo.ListPendingByTenant(ctx, tenantID)
+}
+
+// ResendInvitation rotates the invitation token and restarts its expiry window.
+func (s *TenantService) ResendInvitation(ctx context.Context, tenantID, invitationID uuid.UUID) (*domain.TenantInvitation, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionTenantUpdate, tenantID.String()); err != nil {
+		return nil, err
+	}
+
+	inv, err := s.getPendingInvitation(ctx, tenantID, invitationID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, hash, err := newInvitationToken()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	inv.TokenHash = hash
+	inv.ExpiresAt = now.Add(invitationTTL)
+	inv.UpdatedAt = now
+	if err := s.invitationRepo.Update(ctx, inv); err != nil {
+		return nil, err
+	}
+
+	inv.Token = token
+	s.audit(ctx, userID, "tenant.invitation_resend", inv.ID.String(), map[string]interface{}{
+		"tenant_id": tenantID.String(),
+		"email":     inv.Email,
+	})
+	return inv, nil
+}
+
+func (s *TenantService) RevokeInvitation(ctx context.Context, tenantID, invitationID uuid.UUID) error {
+	userID := appcontext.UserIDFromContext(ctx)
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionTenantUpdate, tenantID.String()); err != nil {
+		return err
+	}
+
+	inv, err := s.getPendingInvitation(ctx, tenantID, invitationID)
+	if err != nil {
+		return err
+	}
+	if err := s.respond(ctx, inv, domain.InvitationRevoked, nil); err != nil {
 		return err
 	}
 
-	// In a real system, this would send an invitation email.
-	// For now, we'll try to find the user by email and add them directly if they exist.
-	user, err := s.userRepo.GetByEmail(ctx, email)
+	s.audit(ctx, userID, "tenant.invitation_revoke", inv.ID.String(), map[string]interface{}{
+		"tenant_id": tenantID.String(),
+		"email":     inv.Email,
+	})
+	return nil
+}
+
+// ListMyInvitations returns pending invitations addressed to the caller's email.
+func (s *TenantService) ListMyInvitations(ctx context.Context) ([]domain.TenantInvitation, error) {
+	user, err := s.userRepo.GetByID(ctx, appcontext.UserIDFromContext(ctx))
 	if err != nil {
-		return errors.New(errors.NotFound, "user not found")
+		return nil, err
 	}
+	return s.invitationRepo.ListPendingByEmail(ctx, normalizeEmail(user.Email))
+}
 
-	// Check if already a member
-	membership, _ := s.repo.GetMembership(ctx, tenantID, user.ID)
+func (s *TenantService) AcceptInvitation(ctx context.Context, token string) (*domain.TenantMember, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	inv, err := s.invitationForCaller(ctx, userID, token)
+	if err != nil {
+		return nil, err
+	}
+
+	membership, _ := s.repo.GetMembership(ctx, inv.TenantID, userID)
 	if membership != nil {
-		return errors.New(errors.Conflict, "user is already a member of this tenant")
+		return nil, errors.New(errors.Conflict, "user is already a member of this tenant")
 	}
 
-	return s.repo.AddMember(ctx, tenantID, user.ID, role)
+	// Add the member before claiming the invitation, so an accepted invitation always has
+	// its member; the membership key already stops concurrent accepts from adding it twice.
+	if err := s.repo.AddMember(ctx, inv.TenantID, userID, inv.Role); err != nil {
+		return nil, err
+	}
+	if err := s.respond(ctx, inv, domain.InvitationAccepted, &userID); err != nil {
+		// The invitation was revoked or answered meanwhile, so the membership is undone.
+		if rmErr := s.repo.RemoveMember(ctx, inv.TenantID, userID); rmErr != nil {
+			s.logger.Error("failed to remove member of unclaimed invitation", "invitation_id", inv.ID, "user_id", userID, "error", rmErr)
+		}
+		return nil, err
+	}
+
+	s.audit(ctx, userID, "tenant.invitation_accept", inv.ID.String(), map[string]interface{}{
+		"tenant_id": inv.TenantID.String(),
+		"role":      inv.Role,
+	})
+	return &domain.TenantMember{TenantID: inv.TenantID, UserID: userID, Role: inv.Role, JoinedAt: *inv.RespondedAt}, nil
+}
+
+func (s *TenantService) DeclineInvitation(ctx context.Context, token string) error {
+	userID := appcontext.UserIDFromContext(ctx)
+	inv, err := s.invitationForCaller(ctx, userID, token)
+	if err != nil {
+		return err
+	}
+	if err := s.respond(ctx, inv, domain.InvitationDeclined, nil); err != nil {
+		return err
+	}
+
+	s.audit(ctx, userID, "tenant.invitation_decline", inv.ID.String(), map[string]interface{}{
+		"tenant_id": inv.TenantID.String(),
+	})
+	return nil
 }
 
 func (s *TenantService) RemoveMember(ctx context.Context, tenantID, userID uuid.UUID) error {
@@ -169,7 +326,83 @@ func (s *TenantService) RemoveMember(ctx context.Context, tenantID, userID uuid.
 		return errors.New(errors.Forbidden, "cannot remove tenant owner")
 	}
 
-	return s.repo.RemoveMember(ctx, tenantID, userID)
+	if err := s.repo.RemoveMember(ctx, tenantID, userID); err != nil {
+		return err
+	}
+
+	s.audit(ctx, uID, "tenant.member_remove", userID.String(), map[string]interface{}{
+		"tenant_id": tenantID.String(),
+	})
+	return nil
+}
+
+// UpdateMemberRole changes a member between admin and member. Ownership moves only via TransferOwnership.
+func (s *TenantService) UpdateMemberRole(ctx context.Context, tenantID, userID uuid.UUID, role string) error {
+	uID := appcontext.UserIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, uID, tenantID, domain.PermissionTenantUpdate, tenantID.String()); err != nil {
+		return err
+	}
+	if err := validateInviteRole(role); err != nil {
+		return err
+	}
+
+	membership, err := s.repo.GetMembership(ctx, tenantID, userID)
+	if err != nil {
+		return err
+	}
+	if membership == nil {
+		return errors.New(errors.NotFound, "member not found")
+	}
+	if membership.Role == domain.TenantRoleOwner {
+		return errors.New(errors.Forbidden, "cannot change the tenant owner's role; transfer ownership instead")
+	}
+
+	if err := s.repo.UpdateMemberRole(ctx, tenantID, userID, role); err != nil {
+		return err
+	}
+
+	s.audit(ctx, uID, "tenant.member_role_update", userID.String(), map[string]interface{}{
+		"tenant_id": tenantID.String(),
+		"old_role":  membership.Role,
+		"new_role":  role,
+	})
+	return nil
+}
+
+// TransferOwnership makes another member the owner. Only the current owner may do this,
+// and they stay in the tenant as an admin.
+func (s *TenantService) TransferOwnership(ctx context.Context, tenantID, newOwnerID uuid.UUID) error {
+	uID := appcontext.UserIDFromContext(ctx)
+
+	tenant, err := s.repo.GetByID(ctx, tenantID)
+	if err != nil {
+		return err
+	}
+	if tenant.OwnerID != uID {
+		return errors.New(errors.Forbidden, "only the tenant owner can transfer ownership")
+	}
+	if newOwnerID == uID {
+		return errors.New(errors.InvalidInput, "user already owns this tenant")
+	}
+
+	membership, err := s.repo.GetMembership(ctx, tenantID, newOwnerID)
+	if err != nil {
+		return err
+	}
+	if membership == nil {
+		return errors.New(errors.InvalidInput, "new owner must be a member of the tenant")
+	}
+
+	if err := s.repo.TransferOwnership(ctx, tenantID, uID, newOwnerID); err != nil {
+		return err
+	}
+
+	s.audit(ctx, uID, "tenant.ownership_transfer", tenantID.String(), map[string]interface{}{
+		"previous_owner_id": uID.String(),
+		"new_owner_id":      newOwnerID.String(),
+	})
+	return nil
 }
 
 func (s *TenantService) SwitchTenant(ctx context.Context, userID, tenantID uuid.UUID) error {
@@ -188,31 +421,19 @@ func (s *TenantService) SwitchTenant(ctx context.Context, userID, tenantID uuid.
 	return s.userRepo.Update(ctx, user)
 }
 
-func (s *TenantService) CheckQuota(ctx context.Context, tenantID uuid.UUID, resource string, requested int) error {
+func (s *TenantService) CheckQuota(ctx context.Context, tenantID uuid.UUID, resource domain.QuotaResource, requested int) error {
+	if _, ok := domain.LookupQuota(resource); !ok {
+		return errors.New(errors.InvalidInput, "unknown resource type for quota check: "+string(resource))
+	}
+
 	// Quota check is internal, but could be restricted
-	quota, err := s.repo.GetQuota(ctx, tenantID)
+	quota, err := s.quotaRepo.GetQuota(ctx, tenantID, resource)
 	if err != nil {
 		return errors.Wrap(errors.Internal, "failed to get tenant quota", err)
 	}
 
-	var current, limit int
-	switch resource {
-	case "instances":
-		current, limit = quota.UsedInstances, quota.MaxInstances
-	case "vpcs":
-		current, limit = quota.UsedVPCs, quota.MaxVPCs
-	case "storage":
-		current, limit = quota.UsedStorageGB, quota.MaxStorageGB
-	case "memory":
-		current, limit = quota.UsedMemoryGB, quota.MaxMemoryGB
-	case "vcpus":
-		current, limit = quota.UsedVCPUs, quota.MaxVCPUs
-	default:
-		return errors.New(errors.InvalidInput, "unknown resource type for quota check: "+resource)
-	}
-
-	if current+requested > limit {
-		return errors.New(errors.QuotaExceeded, "quota exceeded for "+resource)
+	if quota.Used+requested > quota.Limit {
+		return errors.New(errors.QuotaExceeded, fmt.Sprintf("quota exceeded for %s: %d of %d in use, %d requested", resource, quota.Used, quota.Limit, requested))
 	}
 	return nil
 }
@@ -221,10 +442,106 @@ func (s *TenantService) GetMembership(ctx context.Context, tenantID, userID uuid
 	return s.repo.GetMembership(ctx, tenantID, userID)
 }
 
-func (s *TenantService) IncrementUsage(ctx context.Context, tenantID uuid.UUID, resource string, amount int) error {
-	return s.repo.IncrementUsage(ctx, tenantID, resource, amount)
+func (s *TenantService) IncrementUsage(ctx context.Context, tenantID uuid.UUID, resource domain.QuotaResource, amount int) error {
+	if _, ok := domain.LookupQuota(resource); !ok {
+		return errors.New(errors.InvalidInput, "unknown quota resource: "+string(resource))
+	}
+	return s.quotaRepo.IncrementUsage(ctx, tenantID, resource, amount)
+}
+
+func (s *TenantService) DecrementUsage(ctx context.Context, tenantID uuid.UUID, resource domain.QuotaResource, amount int) error {
+	if _, ok := domain.LookupQuota(resource); !ok {
+		return errors.New(errors.InvalidInput, "unknown quota resource: "+string(resource))
+	}
+	return s.quotaRepo.DecrementUsage(ctx, tenantID, resource, amount)
+}
+
+func (s *TenantService) getPendingInvitation(ctx context.Context, tenantID, invitationID uuid.UUID) (*domain.TenantInvitation, error) {
+	inv, err := s.invitationRepo.GetByID(ctx, tenantID, invitationID)
+	if err != nil {
+		return nil, err
+	}
+	if inv.Status != domain.InvitationPending {
+		return nil, errors.New(errors.Conflict, fmt.Sprintf("invitation is already %s", inv.Status))
+	}
+	return inv, nil
+}
+
+// invitationForCaller resolves a token to a pending, unexpired invitation addressed to the caller.
+func (s *TenantService) invitationForCaller(ctx context.Context, userID uuid.UUID, token string) (*domain.TenantInvitation, error) {
+	if userID == uuid.Nil {
+		return nil, errors.New(errors.Unauthorized, "authentication required")
+	}
+	if token == "" {
+		return nil, errors.New(errors.InvalidInput, "invitation token is required")
+	}
+
+	inv, err := s.invitationRepo.GetByTokenHash(ctx, hashInvitationToken(token))
+	if err != nil {
+		if errors.Is(err, errors.NotFound) {
+			return nil, errors.New(errors.NotFound, "invalid invitation token")
+		}
+		return nil, err
+	}
+	if inv.Status != domain.InvitationPending {
+		return nil, errors.New(errors.Conflict, fmt.Sprintf("invitation is already %s", inv.Status))
+	}
+	if inv.IsExpired(time.Now()) {
+		if err := s.respond(ctx, inv, domain.InvitationExpired, nil); err != nil {
+			s.logger.Warn("failed to mark invitation expired", "invitation_id", inv.ID, "error", err)
+		}
+		return nil, errors.New(errors.InvalidInput, "invitation has expired")
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if normalizeEmail(user.Email) != inv.Email {
+		return nil, errors.New(errors.Forbidden, "invitation was sent to a different email address")
+	}
+	return inv, nil
+}
+
+func (s *TenantService) respond(ctx context.Context, inv *domain.TenantInvitation, status domain.InvitationStatus, acceptedBy *uuid.UUID) error {
+	now := time.Now()
+	inv.Status = status
+	inv.AcceptedBy = acceptedBy
+	inv.RespondedAt = &now
+	inv.UpdatedAt = now
+	return s.invitationRepo.Update(ctx, inv)
+}
+
+func (s *TenantService) audit(ctx context.Context, userID uuid.UUID, action, resourceID string, details map[string]interface{}) {
+	if s.auditSvc == nil {
+		return
+	}
+	if err := s.auditSvc.Log(ctx, userID, action, "tenant", resourceID, details); err != nil {
+		s.logger.Warn("failed to log audit event", "action", action, "resource_id", resourceID, "error", err)
+	}
+}
+
+func validateInviteRole(role string) error {
+	if role != domain.TenantRoleAdmin && role != domain.TenantRoleMember {
+		return errors.New(errors.InvalidInput, "role must be 'admin' or 'member'")
+	}
+	return nil
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func newInvitationToken() (token, hash string, err error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("failed to generate invitation token: %w", err)
+	}
+	token = hex.EncodeToString(b)
+	return token, hashInvitationToken(token), nil
 }
 
-func (s *TenantService) DecrementUsage(ctx context.Context, tenantID uuid.UUID, resource string, amount int) error {
-	return s.repo.DecrementUsage(ctx, tenantID, resource, amount)
+func hashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
 	"github.com/poyrazk/thecloud/internal/core/domain"
 	"github.com/poyrazk/thecloud/internal/core/ports"
 	"github.com/poyrazk/thecloud/internal/core/services"
@@ -33,10 +34,12 @@ func setupTenantServiceIntegrationTest(t *testing.T) (ports.TenantService, ports
 
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
 	svc := services.NewTenantService(services.TenantServiceParams{
-		Repo:     tenantRepo,
-		UserRepo: userRepo,
-		RBACSvc:  rbacSvc,
-		Logger:   logger,
+		Repo:           tenantRepo,
+		QuotaRepo:      postgres.NewQuotaRepo(db),
+		InvitationRepo: postgres.NewTenantInvitationRepo(db),
+		UserRepo:       userRepo,
+		RBACSvc:        rbacSvc,
+		Logger:         logger,
 	})
 
 	return svc, tenantRepo, userRepo, ctx
@@ -82,8 +85,14 @@ func TestTenantService_Integration(t *testing.T) {
 		_ = userRepo.Create(ctx, &domain.User{ID: inviteeID, Email: inviteeEmail})
 
 		// Invite
-		err := svc.InviteMember(ctx, tenant.ID, inviteeEmail, "member")
+		inv, err := svc.InviteMember(ctx, tenant.ID, inviteeEmail, "member")
 		require.NoError(t, err)
+		require.NotEmpty(t, inv.Token)
+
+		// Accept as the invitee
+		member, err := svc.AcceptInvitation(appcontext.WithUserID(ctx, inviteeID), inv.Token)
+		require.NoError(t, err)
+		assert.Equal(t, "member", member.Role)
 
 		// Switch
 		err = svc.SwitchTenant(ctx, inviteeID, tenant.ID)
@@ -99,20 +108,15 @@ func TestTenantService_Integration(t *testing.T) {
 		_ = userRepo.Create(ctx, &domain.User{ID: ownerID, Email: "quota@test.com"})
 		tenant, _ := svc.CreateTenant(ctx, "Quota Test", "quota-test", ownerID)
 
-		// Default quota should be there or we create it
-		quota := &domain.TenantQuota{
-			TenantID:     tenant.ID,
-			MaxInstances: 5,
-		}
-		err := tenantRepo.UpdateQuota(ctx, quota)
+		err := postgres.NewQuotaRepo(db).SetLimit(ctx, tenant.ID, domain.QuotaInstances, 5)
 		require.NoError(t, err)
 
 		// Check within limit
-		err = svc.CheckQuota(ctx, tenant.ID, "instances", 1)
+		err = svc.CheckQuota(ctx, tenant.ID, domain.QuotaInstances, 1)
 		require.NoError(t, err)
 
 		// Check over limit (used instances is 0 in DB)
-		err = svc.CheckQuota(ctx, tenant.ID, "instances", 10)
+		err = svc.CheckQuota(ctx, tenant.ID, domain.QuotaInstances, 10)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "quota exceeded")
 	})
@@ -6,18 +6,24 @@ import (
 	appcontext "github.com/poyrazk/thecloud/internal/core/context"
 	"github.com/poyrazk/thecloud/internal/core/domain"
 	"github.com/poyrazk/thecloud/internal/core/services"
+	"github.com/poyrazk/thecloud/internal/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"testing"
+	"time"
 )
 
 func TestTenantService_Unit(t *testing.T) {
 	mockRepo := new(MockTenantRepo)
+	mockInvRepo := new(MockTenantInvitationRepo)
 	mockUserRepo := new(MockUserRepo)
+	mockQuotaRepo := new(MockQuotaRepo)
 	rbacSvc := new(MockRBACService)
+	auditSvc := new(MockAuditService)
+	auditSvc.On("Log", mock.Anything, mock.Anything, mock.Anything, "tenant", mock.Anything, mock.Anything).Return(nil)
 	svc := services.NewTenantService(services.TenantServiceParams{
-		Repo: mockRepo, UserRepo: mockUserRepo, RBACSvc: rbacSvc,
+		Repo: mockRepo, QuotaRepo: mockQuotaRepo, InvitationRepo: mockInvRepo, UserRepo: mockUserRepo, RBACSvc: rbacSvc, AuditSvc: auditSvc,
 	})
 
 	ctx := context.Background()
@@ -31,7 +37,6 @@ func TestTenantService_Unit(t *testing.T) {
 		mockRepo.On("GetBySlug", mock.Anything, "new-tenant").Return(nil, nil).Once()
 		mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
 		mockRepo.On("AddMember", mock.Anything, mock.Anything, mock.Anything, "owner").Return(nil).Once()
-		mockRepo.On("UpdateQuota", mock.Anything, mock.Anything).Return(nil).Once()
 		mockUserRepo.On("GetByID", mock.Anything, userID).Return(&domain.User{ID: userID}, nil).Once()
 		mockUserRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
 
@@ -40,27 +45,206 @@ func TestTenantService_Unit(t *testing.T) {
 		assert.NotNil(t, tenant)
 	})
 
-	t.Run("InviteMember_UserNotFound", func(t *testing.T) {
+	t.Run("InviteMember_UnregisteredEmail", func(t *testing.T) {
 		rbacSvc.On("Authorize", mock.Anything, userID, tenantID, domain.PermissionTenantUpdate, tenantID.String()).Return(nil).Once()
-		mockUserRepo.On("GetByEmail", mock.Anything, "unknown@test.com").Return(nil, assert.AnError).Once()
+		mockUserRepo.On("GetByEmail", mock.Anything, "new@test.com").Return(nil, assert.AnError).Once()
+		mockInvRepo.On("Create", mock.Anything, mock.MatchedBy(func(inv *domain.TenantInvitation) bool {
+			return inv.Email == "new@test.com" && inv.Status == domain.InvitationPending && inv.TokenHash != "" && inv.Token == ""
+		})).Return(nil).Once()
+
+		inv, err := svc.InviteMember(ctx, tenantID, " New@Test.com ", "member")
+		require.NoError(t, err)
+		assert.Equal(t, "new@test.com", inv.Email)
+		assert.NotEmpty(t, inv.Token)
+		assert.NotEqual(t, inv.Token, inv.TokenHash)
+		assert.True(t, inv.ExpiresAt.After(time.Now().Add(6*24*time.Hour)))
+	})
+
+	t.Run("InviteMember_AlreadyMember", func(t *testing.T) {
+		memberID := uuid.New()
+		rbacSvc.On("Authorize", mock.Anything, userID, tenantID, domain.PermissionTenantUpdate, tenantID.String()).Return(nil).Once()
+		mockUserRepo.On("GetByEmail", mock.Anything, "member@test.com").Return(&domain.User{ID: memberID}, nil).Once()
+		mockRepo.On("GetMembership", mock.Anything, tenantID, memberID).Return(&domain.TenantMember{}, nil).Once()
+
+		_, err := svc.InviteMember(ctx, tenantID, "member@test.com", "member")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.Conflict))
+	})
+
+	t.Run("InviteMember_InvalidRole", func(t *testing.T) {
+		rbacSvc.On("Authorize", mock.Anything, userID, tenantID, domain.PermissionTenantUpdate, tenantID.String()).Return(nil).Once()
+
+		_, err := svc.InviteMember(ctx, tenantID, "x@test.com", "owner")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.InvalidInput))
+	})
+
+	t.Run("ResendInvitation", func(t *testing.T) {
+		invID := uuid.New()
+		rbacSvc.On("Authorize", mock.Anything, userID, tenantID, domain.PermissionTenantUpdate, tenantID.String()).Return(nil).Once()
+		mockInvRepo.On("GetByID", mock.Anything, tenantID, invID).Return(&domain.TenantInvitation{
+			ID: invID, TenantID: tenantID, Status: domain.InvitationPending, TokenHash: "old", ExpiresAt: time.Now().Add(-time.Hour),
+		}, nil).Once()
+		mockInvRepo.On("Update", mock.Anything, mock.MatchedBy(func(inv *domain.TenantInvitation) bool {
+			return inv.ID == invID && inv.TokenHash != "old" && inv.ExpiresAt.After(time.Now())
+		})).Return(nil).Once()
+
+		inv, err := svc.ResendInvitation(ctx, tenantID, invID)
+		require.NoError(t, err)
+		assert.NotEmpty(t, inv.Token)
+	})
+
+	t.Run("RevokeInvitation_AlreadyAccepted", func(t *testing.T) {
+		invID := uuid.New()
+		rbacSvc.On("Authorize", mock.Anything, userID, tenantID, domain.PermissionTenantUpdate, tenantID.String()).Return(nil).Once()
+		mockInvRepo.On("GetByID", mock.Anything, tenantID, invID).Return(&domain.TenantInvitation{ID: invID, Status: domain.InvitationAccepted}, nil).Once()
+
+		err := svc.RevokeInvitation(ctx, tenantID, invID)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.Conflict))
+	})
+
+	t.Run("AcceptInvitation", func(t *testing.T) {
+		invID := uuid.New()
+		mockInvRepo.On("GetByTokenHash", mock.Anything, mock.AnythingOfType("string")).Return(&domain.TenantInvitation{
+			ID: invID, TenantID: tenantID, Email: "me@test.com", Role: "admin", Status: domain.InvitationPending, ExpiresAt: time.Now().Add(time.Hour),
+		}, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, userID).Return(&domain.User{ID: userID, Email: "Me@test.com"}, nil).Once()
+		mockRepo.On("GetMembership", mock.Anything, tenantID, userID).Return(nil, nil).Once()
+		mockInvRepo.On("Update", mock.Anything, mock.MatchedBy(func(inv *domain.TenantInvitation) bool {
+			return inv.ID == invID && inv.Status == domain.InvitationAccepted && *inv.AcceptedBy == userID
+		})).Return(nil).Once()
+		mockRepo.On("AddMember", mock.Anything, tenantID, userID, "admin").Return(nil).Once()
+
+		member, err := svc.AcceptInvitation(ctx, "token")
+		require.NoError(t, err)
+		assert.Equal(t, "admin", member.Role)
+	})
+
+	t.Run("AcceptInvitation_AddMemberFails", func(t *testing.T) {
+		invID := uuid.New()
+		mockInvRepo.On("GetByTokenHash", mock.Anything, mock.AnythingOfType("string")).Return(&domain.TenantInvitation{
+			ID: invID, TenantID: tenantID, Email: "me@test.com", Role: "member", Status: domain.InvitationPending, ExpiresAt: time.Now().Add(time.Hour),
+		}, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, userID).Return(&domain.User{ID: userID, Email: "me@test.com"}, nil).Once()
+		mockRepo.On("GetMembership", mock.Anything, tenantID, userID).Return(nil, nil).Once()
+		mockRepo.On("AddMember", mock.Anything, tenantID, userID, "member").Return(errors.New(errors.Internal, "db down")).Once()
+
+		_, err := svc.AcceptInvitation(ctx, "token")
+		require.Error(t, err)
+		// The invitation stays pending so it can be accepted again.
+		mockInvRepo.AssertNotCalled(t, "Update", mock.Anything, mock.MatchedBy(func(inv *domain.TenantInvitation) bool {
+			return inv.ID == invID
+		}))
+	})
+
+	t.Run("AcceptInvitation_NoLongerPending", func(t *testing.T) {
+		invID := uuid.New()
+		mockInvRepo.On("GetByTokenHash", mock.Anything, mock.AnythingOfType("string")).Return(&domain.TenantInvitation{
+			ID: invID, TenantID: tenantID, Email: "me@test.com", Role: "member", Status: domain.InvitationPending, ExpiresAt: time.Now().Add(time.Hour),
+		}, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, userID).Return(&domain.User{ID: userID, Email: "me@test.com"}, nil).Once()
+		mockRepo.On("GetMembership", mock.Anything, tenantID, userID).Return(nil, nil).Once()
+		mockRepo.On("AddMember", mock.Anything, tenantID, userID, "member").Return(nil).Once()
+		mockInvRepo.On("Update", mock.Anything, mock.MatchedBy(func(inv *domain.TenantInvitation) bool {
+			return inv.ID == invID
+		})).Return(errors.New(errors.Conflict, "invitation is no longer pending")).Once()
+		mockRepo.On("RemoveMember", mock.Anything, tenantID, userID).Return(nil).Once()
 
-		err := svc.InviteMember(ctx, tenantID, "unknown@test.com", "member")
+		_, err := svc.AcceptInvitation(ctx, "token")
+		assert.True(t, errors.Is(err, errors.Conflict))
+		mockRepo.AssertCalled(t, "RemoveMember", mock.Anything, tenantID, userID)
+	})
+
+	t.Run("AcceptInvitation_WrongEmail", func(t *testing.T) {
+		mockInvRepo.On("GetByTokenHash", mock.Anything, mock.AnythingOfType("string")).Return(&domain.TenantInvitation{
+			TenantID: tenantID, Email: "someone@test.com", Status: domain.InvitationPending, ExpiresAt: time.Now().Add(time.Hour),
+		}, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, userID).Return(&domain.User{ID: userID, Email: "me@test.com"}, nil).Once()
+
+		_, err := svc.AcceptInvitation(ctx, "token")
 		require.Error(t, err)
-		assert.Contains(t, err.Error(), "user not found")
+		assert.True(t, errors.Is(err, errors.Forbidden))
+	})
+
+	t.Run("AcceptInvitation_Expired", func(t *testing.T) {
+		mockInvRepo.On("GetByTokenHash", mock.Anything, mock.AnythingOfType("string")).Return(&domain.TenantInvitation{
+			TenantID: tenantID, Email: "me@test.com", Status: domain.InvitationPending, ExpiresAt: time.Now().Add(-time.Minute),
+		}, nil).Once()
+		mockInvRepo.On("Update", mock.Anything, mock.MatchedBy(func(inv *domain.TenantInvitation) bool {
+			return inv.Status == domain.InvitationExpired
+		})).Return(nil).Once()
+
+		_, err := svc.AcceptInvitation(ctx, "token")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "expired")
+	})
+
+	t.Run("DeclineInvitation", func(t *testing.T) {
+		mockInvRepo.On("GetByTokenHash", mock.Anything, mock.AnythingOfType("string")).Return(&domain.TenantInvitation{
+			TenantID: tenantID, Email: "me@test.com", Status: domain.InvitationPending, ExpiresAt: time.Now().Add(time.Hour),
+		}, nil).Once()
+		mockUserRepo.On("GetByID", mock.Anything, userID).Return(&domain.User{ID: userID, Email: "me@test.com"}, nil).Once()
+		mockInvRepo.On("Update", mock.Anything, mock.MatchedBy(func(inv *domain.TenantInvitation) bool {
+			return inv.Status == domain.InvitationDeclined
+		})).Return(nil).Once()
+
+		require.NoError(t, svc.DeclineInvitation(ctx, "token"))
+	})
+
+	t.Run("UpdateMemberRole", func(t *testing.T) {
+		memberID := uuid.New()
+		rbacSvc.On("Authorize", mock.Anything, userID, tenantID, domain.PermissionTenantUpdate, tenantID.String()).Return(nil).Once()
+		mockRepo.On("GetMembership", mock.Anything, tenantID, memberID).Return(&domain.TenantMember{Role: "member"}, nil).Once()
+		mockRepo.On("UpdateMemberRole", mock.Anything, tenantID, memberID, "admin").Return(nil).Once()
+
+		require.NoError(t, svc.UpdateMemberRole(ctx, tenantID, memberID, "admin"))
+	})
+
+	t.Run("UpdateMemberRole_Owner", func(t *testing.T) {
+		rbacSvc.On("Authorize", mock.Anything, userID, tenantID, domain.PermissionTenantUpdate, tenantID.String()).Return(nil).Once()
+		mockRepo.On("GetMembership", mock.Anything, tenantID, userID).Return(&domain.TenantMember{Role: "owner"}, nil).Once()
+
+		err := svc.UpdateMemberRole(ctx, tenantID, userID, "member")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.Forbidden))
+	})
+
+	t.Run("TransferOwnership", func(t *testing.T) {
+		newOwner := uuid.New()
+		mockRepo.On("GetByID", mock.Anything, tenantID).Return(&domain.Tenant{ID: tenantID, OwnerID: userID}, nil).Once()
+		mockRepo.On("GetMembership", mock.Anything, tenantID, newOwner).Return(&domain.TenantMember{Role: "admin"}, nil).Once()
+		mockRepo.On("TransferOwnership", mock.Anything, tenantID, userID, newOwner).Return(nil).Once()
+
+		require.NoError(t, svc.TransferOwnership(ctx, tenantID, newOwner))
+	})
+
+	t.Run("TransferOwnership_NotOwner", func(t *testing.T) {
+		mockRepo.On("GetByID", mock.Anything, tenantID).Return(&domain.Tenant{ID: tenantID, OwnerID: uuid.New()}, nil).Once()
+
+		err := svc.TransferOwnership(ctx, tenantID, uuid.New())
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.Forbidden))
 	})
 
 	t.Run("CheckQuota_Exceeded", func(t *testing.T) {
-		quota := &domain.TenantQuota{UsedInstances: 10, MaxInstances: 10}
-		mockRepo.On("GetQuota", mock.Anything, tenantID).Return(quota, nil).Once()
+		quota := &domain.ResourceQuota{TenantID: tenantID, Resource: domain.QuotaInstances, Used: 10, Limit: 10}
+		mockQuotaRepo.On("GetQuota", mock.Anything, tenantID, domain.QuotaInstances).Return(quota, nil).Once()
 
-		err := svc.CheckQuota(ctx, tenantID, "instances", 1)
+		err := svc.CheckQuota(ctx, tenantID, domain.QuotaInstances, 1)
 		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.QuotaExceeded))
 		assert.Contains(t, err.Error(), "quota exceeded")
 	})
 
-	t.Run("CheckQuota_InvalidResource", func(t *testing.T) {
-		mockRepo.On("GetQuota", mock.Anything, tenantID).Return(&domain.TenantQuota{}, nil).Once()
+	t.Run("CheckQuota_WithinLimit", func(t *testing.T) {
+		quota := &domain.ResourceQuota{TenantID: tenantID, Resource: domain.QuotaBuckets, Used: 3, Limit: 10}
+		mockQuotaRepo.On("GetQuota", mock.Anything, tenantID, domain.QuotaBuckets).Return(quota, nil).Once()
+
+		require.NoError(t, svc.CheckQuota(ctx, tenantID, domain.QuotaBuckets, 7))
+	})
 
+	t.Run("CheckQuota_InvalidResource", func(t *testing.T) {
 		err := svc.CheckQuota(ctx, tenantID, "invalid", 1)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "unknown resource type")
@@ -102,13 +286,17 @@ func TestTenantService_Unit(t *testing.T) {
 	})
 
 	t.Run("Usage_Tracking", func(t *testing.T) {
-		mockRepo.On("IncrementUsage", mock.Anything, tenantID, "vpcs", 1).Return(nil).Once()
-		mockRepo.On("DecrementUsage", mock.Anything, tenantID, "vpcs", 1).Return(nil).Once()
+		mockQuotaRepo.On("IncrementUsage", mock.Anything, tenantID, domain.QuotaVPCs, 1).Return(nil).Once()
+		mockQuotaRepo.On("DecrementUsage", mock.Anything, tenantID, domain.QuotaVPCs, 1).Return(nil).Once()
 
-		err := svc.IncrementUsage(ctx, tenantID, "vpcs", 1)
+		err := svc.IncrementUsage(ctx, tenantID, domain.QuotaVPCs, 1)
 		require.NoError(t, err)
 
-		err = svc.DecrementUsage(ctx, tenantID, "vpcs", 1)
+		err = svc.DecrementUsage(ctx, tenantID, domain.QuotaVPCs, 1)
 		require.NoError(t, err)
+
+		err = svc.IncrementUsage(ctx, tenantID, "gpus", 1)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.InvalidInput))
 	})
 }
@@ -0,0 +1,867 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	transitGatewayTracer = "transit-gateway-service"
+	// transitGatewayVNIBase keeps hub VNIs clear of the range used by VPC bridges.
+	transitGatewayVNIBase = 1000
+	defaultTGWRouteTable  = "default"
+)
+
+// TransitGatewayService manages transit gateways. Each gateway is a hub OVS
+// bridge; an attachment links a VPC bridge to the hub with a veth pair, and the
+// hub forwards between attachments with flows derived from its route tables.
+type TransitGatewayService struct {
+	repo     ports.TransitGatewayRepository
+	vpcRepo  ports.VpcRepository
+	rtRepo   ports.RouteTableRepository
+	network  ports.NetworkBackend
+	rbacSvc  ports.RBACService
+	auditSvc ports.AuditService
+	logger   *slog.Logger
+}
+
+// TransitGatewayServiceParams holds dependencies for TransitGatewayService.
+type TransitGatewayServiceParams struct {
+	Repo     ports.TransitGatewayRepository
+	VpcRepo  ports.VpcRepository
+	RTRepo   ports.RouteTableRepository
+	Network  ports.NetworkBackend
+	RBACSvc  ports.RBACService
+	AuditSvc ports.AuditService
+	Logger   *slog.Logger
+}
+
+// NewTransitGatewayService constructs a TransitGatewayService with its dependencies.
+func NewTransitGatewayService(params TransitGatewayServiceParams) *TransitGatewayService {
+	logger := params.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &TransitGatewayService{
+		repo:     params.Repo,
+		vpcRepo:  params.VpcRepo,
+		rtRepo:   params.RTRepo,
+		network:  params.Network,
+		rbacSvc:  params.RBACSvc,
+		auditSvc: params.AuditSvc,
+		logger:   logger,
+	}
+}
+
+// CreateTransitGateway creates the hub bridge and the gateway's default route table.
+func (s *TransitGatewayService) CreateTransitGateway(ctx context.Context, name string) (*domain.TransitGateway, error) {
+	ctx, span := otel.Tracer(transitGatewayTracer).Start(ctx, "CreateTransitGateway")
+	defer span.End()
+
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcCreate, "*"); err != nil {
+		return nil, err
+	}
+
+	tgwID := uuid.New()
+	tgw := &domain.TransitGateway{
+		ID:         tgwID,
+		UserID:     userID,
+		TenantID:   tenantID,
+		Name:       name,
+		Status:     domain.TransitGatewayStatusPending,
+		BridgeName: fmt.Sprintf("br-tgw-%s", tgwID.String()[:8]),
+		VXLANID:    int(tgwID[0]) + transitGatewayVNIBase,
+		ARN:        fmt.Sprintf("arn:thecloud:vpc:local:%s:transit-gateway/%s", userID.String(), tgwID.String()),
+		CreatedAt:  time.Now(),
+	}
+	if err := tgw.Validate(); err != nil {
+		return nil, errors.New(errors.InvalidInput, err.Error())
+	}
+
+	if err := s.repo.Create(ctx, tgw); err != nil {
+		return nil, err
+	}
+
+	if err := s.network.CreateBridge(ctx, tgw.BridgeName, tgw.VXLANID); err != nil {
+		s.logger.Error("failed to create transit gateway bridge", "tgw_id", tgwID, "error", err)
+		tgw.Status = domain.TransitGatewayStatusFailed
+		_ = s.repo.Update(ctx, tgw)
+		return nil, errors.Wrap(errors.Internal, "failed to create transit gateway bridge", err)
+	}
+
+	rt := &domain.TransitGatewayRouteTable{
+		ID:               uuid.New(),
+		TransitGatewayID: tgwID,
+		Name:             defaultTGWRouteTable,
+		IsDefault:        true,
+		CreatedAt:        time.Now(),
+	}
+	if err := s.repo.CreateRouteTable(ctx, rt); err != nil {
+		return nil, err
+	}
+	tgw.DefaultRouteTableID = &rt.ID
+	tgw.Status = domain.TransitGatewayStatusAvailable
+	if err := s.repo.Update(ctx, tgw); err != nil {
+		return nil, err
+	}
+
+	if err := s.auditSvc.Log(ctx, userID, "transit_gateway.create", "transit_gateway", tgwID.String(), map[string]interface{}{
+		"name": name,
+	}); err != nil {
+		s.logger.Warn("failed to log audit event", "action", "transit_gateway.create", "tgw_id", tgwID, "error", err)
+	}
+
+	s.logger.Info("transit gateway created", "id", tgwID, "bridge", tgw.BridgeName)
+	return tgw, nil
+}
+
+// GetTransitGateway retrieves a transit gateway.
+func (s *TransitGatewayService) GetTransitGateway(ctx context.Context, id uuid.UUID) (*domain.TransitGateway, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcRead, id.String()); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+// ListTransitGateways returns the tenant's transit gateways.
+func (s *TransitGatewayService) ListTransitGateways(ctx context.Context) ([]*domain.TransitGateway, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcRead, "*"); err != nil {
+		return nil, err
+	}
+	return s.repo.List(ctx)
+}
+
+// DeleteTransitGateway removes a gateway without attachments and its hub bridge.
+func (s *TransitGatewayService) DeleteTransitGateway(ctx context.Context, id uuid.UUID) error {
+	ctx, span := otel.Tracer(transitGatewayTracer).Start(ctx, "DeleteTransitGateway")
+	defer span.End()
+
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcDelete, id.String()); err != nil {
+		return err
+	}
+
+	tgw, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	attachments, err := s.repo.ListAttachments(ctx, id)
+	if err != nil {
+		return err
+	}
+	if len(attachments) > 0 {
+		return errors.New(errors.Conflict, "transit gateway still has VPC attachments")
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	if err := s.network.DeleteBridge(ctx, tgw.BridgeName); err != nil {
+		s.logger.Warn("failed to delete transit gateway bridge", "bridge", tgw.BridgeName, "error", err)
+	}
+
+	if err := s.auditSvc.Log(ctx, userID, "transit_gateway.delete", "transit_gateway", id.String(), map[string]interface{}{}); err != nil {
+		s.logger.Warn("failed to log audit event", "action", "transit_gateway.delete", "tgw_id", id, "error", err)
+	}
+
+	s.logger.Info("transit gateway deleted", "id", id)
+	return nil
+}
+
+// AttachVPC links a VPC to the hub, associates it with the default route table
+// and propagates its CIDRs there.
+func (s *TransitGatewayService) AttachVPC(ctx context.Context, tgwID, vpcID uuid.UUID) (*domain.TransitGatewayAttachment, error) {
+	ctx, span := otel.Tracer(transitGatewayTracer).Start(ctx, "AttachVPC")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("tgw_id", tgwID.String()),
+		attribute.String("vpc_id", vpcID.String()),
+	)
+
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcUpdate, vpcID.String()); err != nil {
+		return nil, err
+	}
+
+	tgw, err := s.repo.GetByID(ctx, tgwID)
+	if err != nil {
+		return nil, err
+	}
+	vpc, err := s.vpcRepo.GetByID(ctx, vpcID)
+	if err != nil {
+		return nil, errors.Wrap(errors.NotFound, "VPC not found", err)
+	}
+	if vpc.NetworkID == "" {
+		return nil, errors.New(errors.InvalidInput, "VPC has no bridge to attach")
+	}
+
+	attachments, err := s.repo.ListAttachments(ctx, tgwID)
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range attachments {
+		if other.VPCID == vpcID {
+			return nil, errors.New(errors.Conflict, "the VPC is already attached to this transit gateway")
+		}
+		otherVPC, err := s.vpcRepo.GetByID(ctx, other.VPCID)
+		if err != nil {
+			return nil, errors.Wrap(errors.Internal, "failed to get attached VPC", err)
+		}
+		if cidrsOverlap(vpcCIDRs(vpc), vpcCIDRs(otherVPC)) {
+			return nil, errors.New(errors.Conflict, fmt.Sprintf("VPC CIDRs overlap attached VPC %s", otherVPC.ID))
+		}
+	}
+
+	att := &domain.TransitGatewayAttachment{
+		ID:               uuid.New(),
+		TransitGatewayID: tgwID,
+		VPCID:            vpcID,
+		UserID:           userID,
+		TenantID:         tenantID,
+		RouteTableID:     tgw.DefaultRouteTableID,
+		Status:           domain.TransitGatewayAttachmentStatusPending,
+		CreatedAt:        time.Now(),
+	}
+	if err := att.Validate(); err != nil {
+		return nil, errors.New(errors.InvalidInput, err.Error())
+	}
+	if err := s.repo.CreateAttachment(ctx, att); err != nil {
+		return nil, err
+	}
+
+	if err := s.linkAttachment(ctx, tgw, vpc, att); err != nil {
+		att.Status = domain.TransitGatewayAttachmentStatusFailed
+		_ = s.repo.UpdateAttachment(ctx, att)
+		return nil, err
+	}
+
+	if tgw.DefaultRouteTableID != nil {
+		if err := s.propagate(ctx, *tgw.DefaultRouteTableID, att, vpc); err != nil {
+			return nil, err
+		}
+		s.programRouteTable(ctx, tgw, *tgw.DefaultRouteTableID)
+	}
+
+	att.Status = domain.TransitGatewayAttachmentStatusAvailable
+	if err := s.repo.UpdateAttachment(ctx, att); err != nil {
+		return nil, err
+	}
+
+	if err := s.auditSvc.Log(ctx, userID, "transit_gateway.attach", "transit_gateway", tgwID.String(), map[string]interface{}{
+		"attachment_id": att.ID.String(),
+		"vpc_id":        vpcID.String(),
+	}); err != nil {
+		s.logger.Warn("failed to log audit event", "action", "transit_gateway.attach", "tgw_id", tgwID, "error", err)
+	}
+
+	s.logger.Info("VPC attached to transit gateway", "tgw_id", tgwID, "vpc_id", vpcID, "attachment_id", att.ID)
+	return att, nil
+}
+
+// ListAttachments returns the VPC attachments of a transit gateway.
+func (s *TransitGatewayService) ListAttachments(ctx context.Context, tgwID uuid.UUID) ([]*domain.TransitGatewayAttachment, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcRead, tgwID.String()); err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.GetByID(ctx, tgwID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListAttachments(ctx, tgwID)
+}
+
+// DetachVPC unlinks a VPC from the hub and removes the routes that pointed at it,
+// both on the gateway and in the VPC's own route tables.
+func (s *TransitGatewayService) DetachVPC(ctx context.Context, attachmentID uuid.UUID) error {
+	ctx, span := otel.Tracer(transitGatewayTracer).Start(ctx, "DetachVPC")
+	defer span.End()
+
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	att, err := s.repo.GetAttachment(ctx, attachmentID)
+	if err != nil {
+		return err
+	}
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcUpdate, att.VPCID.String()); err != nil {
+		return err
+	}
+	tgw, err := s.repo.GetByID(ctx, att.TransitGatewayID)
+	if err != nil {
+		return err
+	}
+
+	if vpc, err := s.vpcRepo.GetByID(ctx, att.VPCID); err == nil {
+		s.removeVPCRoutes(ctx, vpc, tgw)
+	} else {
+		s.logger.Warn("failed to get VPC for transit gateway route cleanup", "vpc_id", att.VPCID, "error", err)
+	}
+
+	// Routes and propagations referencing the attachment go with it.
+	if err := s.repo.DeleteAttachment(ctx, attachmentID); err != nil {
+		return err
+	}
+
+	vpcEnd, hubEnd := attachmentPorts(attachmentID)
+	if err := s.network.DeleteFlowRule(ctx, tgw.BridgeName, "in_port="+hubEnd); err != nil {
+		s.logger.Warn("failed to remove hub flows for attachment", "attachment_id", attachmentID, "error", err)
+	}
+	if err := s.network.DeleteVethPair(ctx, vpcEnd); err != nil {
+		s.logger.Warn("failed to delete attachment link", "attachment_id", attachmentID, "error", err)
+	}
+	s.programAllRouteTables(ctx, tgw)
+
+	if err := s.auditSvc.Log(ctx, userID, "transit_gateway.detach", "transit_gateway", tgw.ID.String(), map[string]interface{}{
+		"attachment_id": attachmentID.String(),
+		"vpc_id":        att.VPCID.String(),
+	}); err != nil {
+		s.logger.Warn("failed to log audit event", "action", "transit_gateway.detach", "tgw_id", tgw.ID, "error", err)
+	}
+
+	s.logger.Info("VPC detached from transit gateway", "tgw_id", tgw.ID, "vpc_id", att.VPCID, "attachment_id", attachmentID)
+	return nil
+}
+
+// CreateRouteTable adds a route table to a transit gateway.
+func (s *TransitGatewayService) CreateRouteTable(ctx context.Context, tgwID uuid.UUID, name string) (*domain.TransitGatewayRouteTable, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcUpdate, tgwID.String()); err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.GetByID(ctx, tgwID); err != nil {
+		return nil, err
+	}
+
+	rt := &domain.TransitGatewayRouteTable{
+		ID:               uuid.New(),
+		TransitGatewayID: tgwID,
+		Name:             name,
+		CreatedAt:        time.Now(),
+	}
+	if err := rt.Validate(); err != nil {
+		return nil, errors.New(errors.InvalidInput, err.Error())
+	}
+	if err := s.repo.CreateRouteTable(ctx, rt); err != nil {
+		return nil, err
+	}
+
+	if err := s.auditSvc.Log(ctx, userID, "transit_gateway.route_table_create", "transit_gateway", tgwID.String(), map[string]interface{}{
+		"route_table_id": rt.ID.String(),
+		"name":           name,
+	}); err != nil {
+		s.logger.Warn("failed to log audit event", "action", "transit_gateway.route_table_create", "tgw_id", tgwID, "error", err)
+	}
+	return rt, nil
+}
+
+// GetRouteTable retrieves a route table with its routes and propagations.
+func (s *TransitGatewayService) GetRouteTable(ctx context.Context, id uuid.UUID) (*domain.TransitGatewayRouteTable, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcRead, id.String()); err != nil {
+		return nil, err
+	}
+
+	rt, err := s.repo.GetRouteTable(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if rt.Routes, err = s.repo.ListRoutes(ctx, id); err != nil {
+		return nil, err
+	}
+	if rt.Propagations, err = s.repo.ListPropagations(ctx, id); err != nil {
+		return nil, err
+	}
+	return rt, nil
+}
+
+// ListRouteTables returns the route tables of a transit gateway.
+func (s *TransitGatewayService) ListRouteTables(ctx context.Context, tgwID uuid.UUID) ([]*domain.TransitGatewayRouteTable, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcRead, tgwID.String()); err != nil {
+		return nil, err
+	}
+	return s.repo.ListRouteTables(ctx, tgwID)
+}
+
+// DeleteRouteTable removes a non-default route table no attachment is associated with.
+func (s *TransitGatewayService) DeleteRouteTable(ctx context.Context, id uuid.UUID) error {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcUpdate, id.String()); err != nil {
+		return err
+	}
+
+	rt, err := s.repo.GetRouteTable(ctx, id)
+	if err != nil {
+		return err
+	}
+	if rt.IsDefault {
+		return errors.New(errors.InvalidInput, "cannot delete the default transit gateway route table")
+	}
+	attachments, err := s.repo.ListAttachments(ctx, rt.TransitGatewayID)
+	if err != nil {
+		return err
+	}
+	for _, att := range attachments {
+		if att.RouteTableID != nil && *att.RouteTableID == id {
+			return errors.New(errors.Conflict, "route table is still associated with attachments")
+		}
+	}
+
+	if err := s.repo.DeleteRouteTable(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.auditSvc.Log(ctx, userID, "transit_gateway.route_table_delete", "transit_gateway", rt.TransitGatewayID.String(), map[string]interface{}{
+		"route_table_id": id.String(),
+	}); err != nil {
+		s.logger.Warn("failed to log audit event", "action", "transit_gateway.route_table_delete", "route_table_id", id, "error", err)
+	}
+	return nil
+}
+
+// AssociateRouteTable selects the route table used for traffic arriving from an attachment.
+func (s *TransitGatewayService) AssociateRouteTable(ctx context.Context, rtID, attachmentID uuid.UUID) error {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	rt, att, tgw, err := s.loadRouteTableAndAttachment(ctx, rtID, attachmentID)
+	if err != nil {
+		return err
+	}
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcUpdate, tgw.ID.String()); err != nil {
+		return err
+	}
+
+	previous := att.RouteTableID
+	att.RouteTableID = &rt.ID
+	if err := s.repo.UpdateAttachment(ctx, att); err != nil {
+		return err
+	}
+
+	// Traffic from the attachment is now forwarded by the new table only.
+	_, hubEnd := attachmentPorts(att.ID)
+	if err := s.network.DeleteFlowRule(ctx, tgw.BridgeName, "in_port="+hubEnd); err != nil {
+		s.logger.Warn("failed to remove hub flows for attachment", "attachment_id", att.ID, "error", err)
+	}
+	if previous != nil && *previous != rt.ID {
+		s.programRouteTable(ctx, tgw, *previous)
+	}
+	s.programRouteTable(ctx, tgw, rt.ID)
+
+	if err := s.auditSvc.Log(ctx, userID, "transit_gateway.associate", "transit_gateway", tgw.ID.String(), map[string]interface{}{
+		"route_table_id": rtID.String(),
+		"attachment_id":  attachmentID.String(),
+	}); err != nil {
+		s.logger.Warn("failed to log audit event", "action", "transit_gateway.associate", "tgw_id", tgw.ID, "error", err)
+	}
+	return nil
+}
+
+// EnablePropagation learns the attachment's VPC CIDRs into the route table.
+func (s *TransitGatewayService) EnablePropagation(ctx context.Context, rtID, attachmentID uuid.UUID) error {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	rt, att, tgw, err := s.loadRouteTableAndAttachment(ctx, rtID, attachmentID)
+	if err != nil {
+		return err
+	}
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcUpdate, tgw.ID.String()); err != nil {
+		return err
+	}
+
+	propagations, err := s.repo.ListPropagations(ctx, rt.ID)
+	if err != nil {
+		return err
+	}
+	for _, id := range propagations {
+		if id == att.ID {
+			return nil
+		}
+	}
+
+	vpc, err := s.vpcRepo.GetByID(ctx, att.VPCID)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to get attached VPC", err)
+	}
+	if err := s.propagate(ctx, rt.ID, att, vpc); err != nil {
+		return err
+	}
+	s.programRouteTable(ctx, tgw, rt.ID)
+
+	if err := s.auditSvc.Log(ctx, userID, "transit_gateway.enable_propagation", "transit_gateway", tgw.ID.String(), map[string]interface{}{
+		"route_table_id": rtID.String(),
+		"attachment_id":  attachmentID.String(),
+	}); err != nil {
+		s.logger.Warn("failed to log audit event", "action", "transit_gateway.enable_propagation", "tgw_id", tgw.ID, "error", err)
+	}
+	return nil
+}
+
+// DisablePropagation stops learning the attachment's VPC CIDRs and withdraws them.
+func (s *TransitGatewayService) DisablePropagation(ctx context.Context, rtID, attachmentID uuid.UUID) error {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	rt, att, tgw, err := s.loadRouteTableAndAttachment(ctx, rtID, attachmentID)
+	if err != nil {
+		return err
+	}
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcUpdate, tgw.ID.String()); err != nil {
+		return err
+	}
+
+	if err := s.repo.RemovePropagation(ctx, rt.ID, att.ID); err != nil {
+		return err
+	}
+	s.programRouteTable(ctx, tgw, rt.ID)
+
+	if err := s.auditSvc.Log(ctx, userID, "transit_gateway.disable_propagation", "transit_gateway", tgw.ID.String(), map[string]interface{}{
+		"route_table_id": rtID.String(),
+		"attachment_id":  attachmentID.String(),
+	}); err != nil {
+		s.logger.Warn("failed to log audit event", "action", "transit_gateway.disable_propagation", "tgw_id", tgw.ID, "error", err)
+	}
+	return nil
+}
+
+// AddRoute adds a static route to a transit gateway route table. A nil
+// attachment creates a blackhole route that drops matching traffic.
+func (s *TransitGatewayService) AddRoute(ctx context.Context, rtID uuid.UUID, destinationCIDR string, attachmentID *uuid.UUID) (*domain.TransitGatewayRoute, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	rt, err := s.repo.GetRouteTable(ctx, rtID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcUpdate, rt.TransitGatewayID.String()); err != nil {
+		return nil, err
+	}
+	tgw, err := s.repo.GetByID(ctx, rt.TransitGatewayID)
+	if err != nil {
+		return nil, err
+	}
+	if attachmentID != nil {
+		att, err := s.repo.GetAttachment(ctx, *attachmentID)
+		if err != nil {
+			return nil, err
+		}
+		if att.TransitGatewayID != tgw.ID {
+			return nil, errors.New(errors.InvalidInput, "attachment belongs to a different transit gateway")
+		}
+	}
+
+	route := &domain.TransitGatewayRoute{
+		ID:              uuid.New(),
+		RouteTableID:    rtID,
+		DestinationCIDR: destinationCIDR,
+		AttachmentID:    attachmentID,
+		Type:            domain.TransitGatewayRouteStatic,
+		CreatedAt:       time.Now(),
+	}
+	if err := route.Validate(); err != nil {
+		return nil, errors.New(errors.InvalidInput, err.Error())
+	}
+	if err := s.repo.AddRoute(ctx, route); err != nil {
+		return nil, err
+	}
+	s.programRouteTable(ctx, tgw, rtID)
+
+	if err := s.auditSvc.Log(ctx, userID, "transit_gateway.add_route", "transit_gateway", tgw.ID.String(), map[string]interface{}{
+		"route_table_id":   rtID.String(),
+		"route_id":         route.ID.String(),
+		"destination_cidr": destinationCIDR,
+		"blackhole":        route.IsBlackhole(),
+	}); err != nil {
+		s.logger.Warn("failed to log audit event", "action", "transit_gateway.add_route", "tgw_id", tgw.ID, "error", err)
+	}
+	return route, nil
+}
+
+// RemoveRoute removes a static route from a transit gateway route table.
+func (s *TransitGatewayService) RemoveRoute(ctx context.Context, rtID, routeID uuid.UUID) error {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	rt, err := s.repo.GetRouteTable(ctx, rtID)
+	if err != nil {
+		return err
+	}
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcUpdate, rt.TransitGatewayID.String()); err != nil {
+		return err
+	}
+	tgw, err := s.repo.GetByID(ctx, rt.TransitGatewayID)
+	if err != nil {
+		return err
+	}
+
+	routes, err := s.repo.ListRoutes(ctx, rtID)
+	if err != nil {
+		return err
+	}
+	for _, route := range routes {
+		if route.ID == routeID && route.Type == domain.TransitGatewayRoutePropagated {
+			return errors.New(errors.InvalidInput, "propagated routes are removed by disabling propagation")
+		}
+	}
+
+	if err := s.repo.RemoveRoute(ctx, rtID, routeID); err != nil {
+		return err
+	}
+	s.programRouteTable(ctx, tgw, rtID)
+
+	if err := s.auditSvc.Log(ctx, userID, "transit_gateway.remove_route", "transit_gateway", tgw.ID.String(), map[string]interface{}{
+		"route_table_id": rtID.String(),
+		"route_id":       routeID.String(),
+	}); err != nil {
+		s.logger.Warn("failed to log audit event", "action", "transit_gateway.remove_route", "tgw_id", tgw.ID, "error", err)
+	}
+	return nil
+}
+
+// linkAttachment connects the VPC bridge to the hub with a veth pair.
+func (s *TransitGatewayService) linkAttachment(ctx context.Context, tgw *domain.TransitGateway, vpc *domain.VPC, att *domain.TransitGatewayAttachment) error {
+	vpcEnd, hubEnd := attachmentPorts(att.ID)
+	if err := s.network.CreateVethPair(ctx, vpcEnd, hubEnd); err != nil {
+		return errors.Wrap(errors.Internal, "failed to create attachment link", err)
+	}
+	if err := s.network.AttachVethToBridge(ctx, vpc.NetworkID, vpcEnd); err != nil {
+		_ = s.network.DeleteVethPair(ctx, vpcEnd)
+		return errors.Wrap(errors.Internal, "failed to attach link to VPC bridge", err)
+	}
+	if err := s.network.AttachVethToBridge(ctx, tgw.BridgeName, hubEnd); err != nil {
+		_ = s.network.DeleteVethPair(ctx, vpcEnd)
+		return errors.Wrap(errors.Internal, "failed to attach link to transit gateway bridge", err)
+	}
+	return nil
+}
+
+// propagate records the propagation and learns the VPC CIDRs as routes.
+func (s *TransitGatewayService) propagate(ctx context.Context, rtID uuid.UUID, att *domain.TransitGatewayAttachment, vpc *domain.VPC) error {
+	if err := s.repo.AddPropagation(ctx, rtID, att.ID); err != nil {
+		return err
+	}
+	for _, cidr := range vpcCIDRs(vpc) {
+		route := &domain.TransitGatewayRoute{
+			ID:              uuid.New(),
+			RouteTableID:    rtID,
+			DestinationCIDR: cidr,
+			AttachmentID:    &att.ID,
+			Type:            domain.TransitGatewayRoutePropagated,
+			CreatedAt:       time.Now(),
+		}
+		if err := s.repo.AddRoute(ctx, route); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// programAllRouteTables reprograms every route table of the gateway.
+func (s *TransitGatewayService) programAllRouteTables(ctx context.Context, tgw *domain.TransitGateway) {
+	tables, err := s.repo.ListRouteTables(ctx, tgw.ID)
+	if err != nil {
+		s.logger.Warn("failed to list transit gateway route tables", "tgw_id", tgw.ID, "error", err)
+		return
+	}
+	for _, rt := range tables {
+		s.programRouteTable(ctx, tgw, rt.ID)
+	}
+}
+
+// programRouteTable replaces the hub flows of a route table, which all carry
+// the table's cookie, with flows derived from its current routes and associations.
+// The database stays the source of truth, so failures are logged rather than returned.
+func (s *TransitGatewayService) programRouteTable(ctx context.Context, tgw *domain.TransitGateway, rtID uuid.UUID) {
+	routes, err := s.repo.ListRoutes(ctx, rtID)
+	if err != nil {
+		s.logger.Warn("failed to list transit gateway routes", "route_table_id", rtID, "error", err)
+		return
+	}
+	attachments, err := s.repo.ListAttachments(ctx, tgw.ID)
+	if err != nil {
+		s.logger.Warn("failed to list transit gateway attachments", "tgw_id", tgw.ID, "error", err)
+		return
+	}
+
+	if err := s.network.DeleteFlowRule(ctx, tgw.BridgeName, cookieMatch(rtID)); err != nil {
+		s.logger.Warn("failed to clear transit gateway route table flows", "route_table_id", rtID, "error", err)
+	}
+	for _, flow := range transitGatewayFlows(rtID, routes, attachments) {
+		if err := s.network.AddFlowRule(ctx, tgw.BridgeName, flow); err != nil {
+			s.logger.Error("failed to add transit gateway flow", "route_table_id", rtID, "match", flow.Match, "error", err)
+		}
+	}
+}
+
+// removeVPCRoutes deletes the VPC routes that target the gateway along with their flows.
+func (s *TransitGatewayService) removeVPCRoutes(ctx context.Context, vpc *domain.VPC, tgw *domain.TransitGateway) {
+	tables, err := s.rtRepo.GetByVPC(ctx, vpc.ID)
+	if err != nil {
+		s.logger.Warn("failed to list route tables for transit gateway route cleanup", "vpc_id", vpc.ID, "error", err)
+		return
+	}
+	for _, rt := range tables {
+		routes, err := s.rtRepo.ListRoutes(ctx, rt.ID)
+		if err != nil {
+			s.logger.Warn("failed to list routes for transit gateway route cleanup", "route_table_id", rt.ID, "error", err)
+			continue
+		}
+		for _, route := range routes {
+			if route.TargetType != domain.RouteTargetTransitGateway || route.TargetID == nil || *route.TargetID != tgw.ID {
+				continue
+			}
+			if err := s.rtRepo.RemoveRoute(ctx, rt.ID, route.ID); err != nil {
+				s.logger.Warn("failed to remove transit gateway route", "route_id", route.ID, "error", err)
+				continue
+			}
+			if err := s.network.DeleteFlowRule(ctx, vpc.NetworkID, routeFlow(route).Match); err != nil {
+				s.logger.Warn("failed to remove OVS flow for transit gateway route", "route_id", route.ID, "error", err)
+			}
+		}
+	}
+}
+
+func (s *TransitGatewayService) loadRouteTableAndAttachment(ctx context.Context, rtID, attachmentID uuid.UUID) (*domain.TransitGatewayRouteTable, *domain.TransitGatewayAttachment, *domain.TransitGateway, error) {
+	rt, err := s.repo.GetRouteTable(ctx, rtID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	att, err := s.repo.GetAttachment(ctx, attachmentID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if att.TransitGatewayID != rt.TransitGatewayID {
+		return nil, nil, nil, errors.New(errors.InvalidInput, "attachment and route table belong to different transit gateways")
+	}
+	tgw, err := s.repo.GetByID(ctx, rt.TransitGatewayID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return rt, att, tgw, nil
+}
+
+// transitGatewayFlows derives the hub flows of a route table. Traffic entering
+// from an associated attachment is matched on destination and sent out of the
+// target attachment's port; priorities grow with prefix length so the longest
+// prefix wins. A static route replaces a propagated one for the same CIDR.
+func transitGatewayFlows(rtID uuid.UUID, routes []domain.TransitGatewayRoute, attachments []*domain.TransitGatewayAttachment) []ports.FlowRule {
+	effective := make(map[string]domain.TransitGatewayRoute, len(routes))
+	var order []string
+	for _, route := range routes {
+		existing, seen := effective[route.DestinationCIDR]
+		if !seen {
+			order = append(order, route.DestinationCIDR)
+		}
+		if !seen || (existing.Type == domain.TransitGatewayRoutePropagated && route.Type == domain.TransitGatewayRouteStatic) {
+			effective[route.DestinationCIDR] = route
+		}
+	}
+
+	var flows []ports.FlowRule
+	for _, att := range attachments {
+		if att.RouteTableID == nil || *att.RouteTableID != rtID {
+			continue
+		}
+		_, inPort := attachmentPorts(att.ID)
+		for _, cidr := range order {
+			route := effective[cidr]
+			actions := "drop"
+			if !route.IsBlackhole() {
+				if *route.AttachmentID == att.ID {
+					continue
+				}
+				_, outPort := attachmentPorts(*route.AttachmentID)
+				actions = "output:" + outPort
+			}
+			_, network, err := net.ParseCIDR(cidr)
+			if err != nil {
+				continue
+			}
+			prefixLen, _ := network.Mask.Size()
+			match := fmt.Sprintf("in_port=%s,ip,nw_dst=%s", inPort, cidr)
+			if strings.Contains(cidr, ":") {
+				match = fmt.Sprintf("in_port=%s,ipv6,ipv6_dst=%s", inPort, cidr)
+			}
+			flows = append(flows, ports.FlowRule{
+				Priority: routePriority + prefixLen,
+				Match:    match,
+				Actions:  actions,
+				Cookie:   flowCookie(rtID),
+			})
+		}
+	}
+	return flows
+}
+
+// attachmentPorts names the veth ends linking an attachment's VPC bridge to the hub.
+func attachmentPorts(attachmentID uuid.UUID) (vpcEnd, hubEnd string) {
+	short := attachmentID.String()[:8]
+	return "tgwv" + short, "tgwh" + short
+}
+
+// vpcCIDRs lists the VPC's IPv4 block and, when dual-stack, its IPv6 block.
+func vpcCIDRs(vpc *domain.VPC) []string {
+	cidrs := []string{vpc.CIDRBlock}
+	if vpc.IPv6CIDRBlock != "" {
+		cidrs = append(cidrs, vpc.IPv6CIDRBlock)
+	}
+	return cidrs
+}
+
+// cidrsOverlap reports whether any block in a overlaps any block in b.
+func cidrsOverlap(a, b []string) bool {
+	for _, x := range a {
+		_, netA, err := net.ParseCIDR(x)
+		if err != nil {
+			continue
+		}
+		for _, y := range b {
+			_, netB, err := net.ParseCIDR(y)
+			if err != nil {
+				continue
+			}
+			if netA.Contains(netB.IP) || netB.Contains(netA.IP) {
+				return true
+			}
+		}
+	}
+	return false
+}
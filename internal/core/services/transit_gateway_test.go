@@ -0,0 +1,191 @@
+package services_test
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/core/services"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransitGatewayService(t *testing.T) {
+	ctx := appcontext.WithTenantID(appcontext.WithUserID(context.Background(), uuid.New()), uuid.New())
+	defaultRT := uuid.New()
+	tgw := &domain.TransitGateway{ID: uuid.New(), Name: "hub", BridgeName: "br-tgw-hub", Status: domain.TransitGatewayStatusAvailable, DefaultRouteTableID: &defaultRT}
+	vpcA := &domain.VPC{ID: uuid.New(), NetworkID: "br-vpc-a", CIDRBlock: "10.0.0.0/16"}
+	vpcB := &domain.VPC{ID: uuid.New(), NetworkID: "br-vpc-b", CIDRBlock: "10.1.0.0/16"}
+	attA := &domain.TransitGatewayAttachment{ID: uuid.MustParse("aaaaaaaa-0000-0000-0000-000000000000"), TransitGatewayID: tgw.ID, VPCID: vpcA.ID, RouteTableID: &defaultRT}
+	attB := &domain.TransitGatewayAttachment{ID: uuid.MustParse("bbbbbbbb-0000-0000-0000-000000000000"), TransitGatewayID: tgw.ID, VPCID: vpcB.ID, RouteTableID: &defaultRT}
+
+	type mocks struct {
+		repo    *MockTransitGatewayRepo
+		vpcRepo *MockVpcRepo
+		rtRepo  *MockRTRepo
+		network *MockNetworkBackend
+	}
+
+	setup := func() (*services.TransitGatewayService, *mocks) {
+		m := &mocks{
+			repo:    new(MockTransitGatewayRepo),
+			vpcRepo: new(MockVpcRepo),
+			rtRepo:  new(MockRTRepo),
+			network: new(MockNetworkBackend),
+		}
+		rbacSvc := new(MockRBACService)
+		rbacSvc.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+		audit := new(MockAuditService)
+		audit.On("Log", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+		m.vpcRepo.On("GetByID", mock.Anything, vpcA.ID).Return(vpcA, nil).Maybe()
+		m.vpcRepo.On("GetByID", mock.Anything, vpcB.ID).Return(vpcB, nil).Maybe()
+		m.repo.On("GetByID", mock.Anything, tgw.ID).Return(tgw, nil).Maybe()
+		svc := services.NewTransitGatewayService(services.TransitGatewayServiceParams{
+			Repo:     m.repo,
+			VpcRepo:  m.vpcRepo,
+			RTRepo:   m.rtRepo,
+			Network:  m.network,
+			RBACSvc:  rbacSvc,
+			AuditSvc: audit,
+			Logger:   slog.Default(),
+		})
+		return svc, m
+	}
+
+	t.Run("CreateBuildsHubBridgeAndDefaultTable", func(t *testing.T) {
+		svc, m := setup()
+		m.repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		m.network.On("CreateBridge", mock.Anything, mock.MatchedBy(func(name string) bool { return len(name) == len("br-tgw-")+8 }), mock.Anything).Return(nil)
+		m.repo.On("CreateRouteTable", mock.Anything, mock.MatchedBy(func(rt *domain.TransitGatewayRouteTable) bool {
+			return rt.IsDefault && rt.Name == "default"
+		})).Return(nil)
+		m.repo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+		created, err := svc.CreateTransitGateway(ctx, "hub")
+		require.NoError(t, err)
+		assert.Equal(t, domain.TransitGatewayStatusAvailable, created.Status)
+		require.NotNil(t, created.DefaultRouteTableID)
+		assert.GreaterOrEqual(t, created.VXLANID, 1000)
+	})
+
+	t.Run("AttachLinksVPCAndPropagatesCIDR", func(t *testing.T) {
+		svc, m := setup()
+		m.repo.On("ListAttachments", mock.Anything, tgw.ID).Return([]*domain.TransitGatewayAttachment{attB}, nil)
+		m.repo.On("CreateAttachment", mock.Anything, mock.Anything).Return(nil)
+		m.network.On("CreateVethPair", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		m.network.On("AttachVethToBridge", mock.Anything, vpcA.NetworkID, mock.Anything).Return(nil).Once()
+		m.network.On("AttachVethToBridge", mock.Anything, tgw.BridgeName, mock.Anything).Return(nil).Once()
+		m.repo.On("AddPropagation", mock.Anything, defaultRT, mock.Anything).Return(nil)
+		m.repo.On("AddRoute", mock.Anything, mock.MatchedBy(func(r *domain.TransitGatewayRoute) bool {
+			return r.Type == domain.TransitGatewayRoutePropagated && r.DestinationCIDR == vpcA.CIDRBlock && r.RouteTableID == defaultRT
+		})).Return(nil).Once()
+		m.repo.On("ListRoutes", mock.Anything, defaultRT).Return([]domain.TransitGatewayRoute{}, nil)
+		m.network.On("DeleteFlowRule", mock.Anything, tgw.BridgeName, mock.Anything).Return(nil)
+		m.repo.On("UpdateAttachment", mock.Anything, mock.Anything).Return(nil)
+
+		att, err := svc.AttachVPC(ctx, tgw.ID, vpcA.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.TransitGatewayAttachmentStatusAvailable, att.Status)
+		assert.Equal(t, defaultRT, *att.RouteTableID)
+		m.network.AssertExpectations(t)
+		m.repo.AssertExpectations(t)
+	})
+
+	t.Run("AttachRejectsOverlappingVPC", func(t *testing.T) {
+		svc, m := setup()
+		overlapping := &domain.VPC{ID: uuid.New(), NetworkID: "br-vpc-c", CIDRBlock: "10.1.128.0/17"}
+		m.vpcRepo.On("GetByID", mock.Anything, overlapping.ID).Return(overlapping, nil)
+		m.repo.On("ListAttachments", mock.Anything, tgw.ID).Return([]*domain.TransitGatewayAttachment{attB}, nil)
+
+		_, err := svc.AttachVPC(ctx, tgw.ID, overlapping.ID)
+		assert.True(t, errors.Is(err, errors.Conflict))
+		m.network.AssertNotCalled(t, "CreateVethPair", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("RouteTableProgramsHubFlows", func(t *testing.T) {
+		svc, m := setup()
+		m.repo.On("GetRouteTable", mock.Anything, defaultRT).Return(&domain.TransitGatewayRouteTable{ID: defaultRT, TransitGatewayID: tgw.ID, IsDefault: true}, nil)
+		m.repo.On("AddRoute", mock.Anything, mock.Anything).Return(nil)
+		m.repo.On("ListRoutes", mock.Anything, defaultRT).Return([]domain.TransitGatewayRoute{
+			{DestinationCIDR: "10.0.0.0/16", AttachmentID: &attA.ID, Type: domain.TransitGatewayRoutePropagated},
+			{DestinationCIDR: "10.1.0.0/16", AttachmentID: &attB.ID, Type: domain.TransitGatewayRoutePropagated},
+			{DestinationCIDR: "10.1.5.0/24", Type: domain.TransitGatewayRouteStatic},
+		}, nil)
+		m.repo.On("ListAttachments", mock.Anything, tgw.ID).Return([]*domain.TransitGatewayAttachment{attA, attB}, nil)
+		m.network.On("DeleteFlowRule", mock.Anything, tgw.BridgeName, mock.Anything).Return(nil).Once()
+
+		var flows []ports.FlowRule
+		m.network.On("AddFlowRule", mock.Anything, tgw.BridgeName, mock.Anything).Run(func(args mock.Arguments) {
+			flows = append(flows, args.Get(2).(ports.FlowRule))
+		}).Return(nil)
+
+		_, err := svc.AddRoute(ctx, defaultRT, "10.1.5.0/24", nil)
+		require.NoError(t, err)
+
+		actions := map[string]string{}
+		priorities := map[string]int{}
+		for _, f := range flows {
+			actions[f.Match] = f.Actions
+			priorities[f.Match] = f.Priority
+		}
+		assert.Len(t, flows, 4)
+		assert.Equal(t, "output:tgwhbbbbbbbb", actions["in_port=tgwhaaaaaaaa,ip,nw_dst=10.1.0.0/16"])
+		assert.Equal(t, "drop", actions["in_port=tgwhaaaaaaaa,ip,nw_dst=10.1.5.0/24"])
+		assert.Equal(t, "output:tgwhaaaaaaaa", actions["in_port=tgwhbbbbbbbb,ip,nw_dst=10.0.0.0/16"])
+		assert.Greater(t, priorities["in_port=tgwhaaaaaaaa,ip,nw_dst=10.1.5.0/24"], priorities["in_port=tgwhaaaaaaaa,ip,nw_dst=10.1.0.0/16"])
+	})
+
+	t.Run("DetachRemovesLinkAndVPCRoutes", func(t *testing.T) {
+		svc, m := setup()
+		mainRT := &domain.RouteTable{ID: uuid.New(), VPCID: vpcA.ID, IsMain: true}
+		tgwRoute := domain.Route{ID: uuid.New(), DestinationCIDR: "10.1.0.0/16", TargetType: domain.RouteTargetTransitGateway, TargetID: &tgw.ID}
+		other := domain.Route{ID: uuid.New(), DestinationCIDR: "0.0.0.0/0", TargetType: domain.RouteTargetIGW}
+		m.repo.On("GetAttachment", mock.Anything, attA.ID).Return(attA, nil)
+		m.rtRepo.On("GetByVPC", mock.Anything, vpcA.ID).Return([]*domain.RouteTable{mainRT}, nil)
+		m.rtRepo.On("ListRoutes", mock.Anything, mainRT.ID).Return([]domain.Route{tgwRoute, other}, nil)
+		m.rtRepo.On("RemoveRoute", mock.Anything, mainRT.ID, tgwRoute.ID).Return(nil).Once()
+		m.network.On("DeleteFlowRule", mock.Anything, vpcA.NetworkID, "ip,nw_dst=10.1.0.0/16").Return(nil).Once()
+		m.repo.On("DeleteAttachment", mock.Anything, attA.ID).Return(nil)
+		m.network.On("DeleteFlowRule", mock.Anything, tgw.BridgeName, "in_port=tgwhaaaaaaaa").Return(nil).Once()
+		m.network.On("DeleteVethPair", mock.Anything, "tgwvaaaaaaaa").Return(nil).Once()
+		m.repo.On("ListRouteTables", mock.Anything, tgw.ID).Return([]*domain.TransitGatewayRouteTable{}, nil)
+
+		require.NoError(t, svc.DetachVPC(ctx, attA.ID))
+		m.rtRepo.AssertExpectations(t)
+		m.network.AssertExpectations(t)
+	})
+
+	t.Run("DeleteWithAttachments", func(t *testing.T) {
+		svc, m := setup()
+		m.repo.On("ListAttachments", mock.Anything, tgw.ID).Return([]*domain.TransitGatewayAttachment{attA}, nil)
+
+		err := svc.DeleteTransitGateway(ctx, tgw.ID)
+		assert.True(t, errors.Is(err, errors.Conflict))
+		m.network.AssertNotCalled(t, "DeleteBridge", mock.Anything, mock.Anything)
+	})
+
+	t.Run("PropagatedRoutesCannotBeRemovedDirectly", func(t *testing.T) {
+		svc, m := setup()
+		route := domain.TransitGatewayRoute{ID: uuid.New(), DestinationCIDR: "10.0.0.0/16", AttachmentID: &attA.ID, Type: domain.TransitGatewayRoutePropagated}
+		m.repo.On("GetRouteTable", mock.Anything, defaultRT).Return(&domain.TransitGatewayRouteTable{ID: defaultRT, TransitGatewayID: tgw.ID}, nil)
+		m.repo.On("ListRoutes", mock.Anything, defaultRT).Return([]domain.TransitGatewayRoute{route}, nil)
+
+		err := svc.RemoveRoute(ctx, defaultRT, route.ID)
+		assert.True(t, errors.Is(err, errors.InvalidInput))
+		m.repo.AssertNotCalled(t, "RemoveRoute", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("DefaultRouteTableCannotBeDeleted", func(t *testing.T) {
+		svc, m := setup()
+		m.repo.On("GetRouteTable", mock.Anything, defaultRT).Return(&domain.TransitGatewayRouteTable{ID: defaultRT, TransitGatewayID: tgw.ID, IsDefault: true}, nil)
+
+		err := svc.DeleteRouteTable(ctx, defaultRT)
+		assert.True(t, errors.Is(err, errors.InvalidInput))
+	})
+}
@@ -37,6 +37,7 @@ type VolumeServiceParams struct {
 	AuditSvc     ports.AuditService
 	Logger       *slog.Logger
 	InstanceRepo ports.InstanceRepository
+	TenantSvc    ports.TenantService // Optional, enforces the storage quota
 }
 
 // VolumeService manages block volume lifecycle and attachments.
@@ -49,6 +50,7 @@ type VolumeService struct {
 	auditSvc     ports.AuditService
 	logger       *slog.Logger
 	instanceRepo ports.InstanceRepository
+	tenantSvc    ports.TenantService
 }
 
 // NewVolumeService constructs a VolumeService with its dependencies.
@@ -62,6 +64,7 @@ func NewVolumeService(params VolumeServiceParams) *VolumeService {
 		auditSvc:     params.AuditSvc,
 		logger:       params.Logger,
 		instanceRepo: params.InstanceRepo,
+		tenantSvc:    params.TenantSvc,
 	}
 }
 
@@ -75,6 +78,9 @@ func (s *VolumeService) CreateVolume(ctx context.Context, name string, sizeGB in
 	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVolumeCreate, "*"); err != nil {
 		return nil, err
 	}
+	if err := checkQuota(ctx, s.tenantSvc, domain.QuotaStorageGB, sizeGB); err != nil {
+		return nil, err
+	}
 
 	span.SetAttributes(
 		attribute.String("volume.name", name),
@@ -109,6 +115,7 @@ func (s *VolumeService) CreateVolume(ctx context.Context, name string, sizeGB in
 		}
 		return nil, err
 	}
+	recordUsage(ctx, s.tenantSvc, s.logger, tenantID, domain.QuotaStorageGB, sizeGB)
 
 	if err := s.eventSvc.RecordEvent(ctx, "VOLUME_CREATE", vol.ID.String(), "VOLUME", map[string]interface{}{
 		"name":    vol.Name,
@@ -191,6 +198,7 @@ func (s *VolumeService) DeleteVolume(ctx context.Context, idOrName string) error
 	if err := s.repo.Delete(ctx, vol.ID); err != nil {
 		return err
 	}
+	recordUsage(ctx, s.tenantSvc, s.logger, vol.TenantID, domain.QuotaStorageGB, -vol.SizeGB)
 
 	platform.VolumesTotal.WithLabelValues(string(vol.Status)).Dec()
 	platform.VolumeSizeBytes.Sub(float64(vol.SizeGB * 1024 * 1024 * 1024))
@@ -3,6 +3,7 @@ package services
 
 import (
 	"context"
+	"crypto/rand"
 	"fmt"
 	"log/slog"
 	"net"
@@ -24,10 +25,12 @@ type VpcServiceParams struct {
 	LBRepo         ports.LBRepository
 	PeeringRepo    ports.VPCPeeringRepository
 	RouteTableRepo ports.RouteTableRepository
+	NetworkACLRepo ports.NetworkACLRepository // Optional, creates the default network ACL
 	AsRepo         ports.AutoScalingRepository
 	RBACSvc        ports.RBACService
 	Network        ports.NetworkBackend
 	AuditSvc       ports.AuditService
+	TenantSvc      ports.TenantService // Optional, enforces the vpcs quota
 	Logger         *slog.Logger
 	DefaultCIDR    string
 	// ComputeBackend is the compute backend type ("docker", "libvirt", "firecracker").
@@ -42,10 +45,12 @@ type VpcService struct {
 	lbRepo         ports.LBRepository
 	peeringRepo    ports.VPCPeeringRepository
 	routeTableRepo ports.RouteTableRepository
+	aclRepo        ports.NetworkACLRepository
 	asRepo         ports.AutoScalingRepository
 	rbacSvc        ports.RBACService
 	network        ports.NetworkBackend
 	auditSvc       ports.AuditService
+	tenantSvc      ports.TenantService
 	logger         *slog.Logger
 	defaultCIDR    string
 	computeBackend string
@@ -67,10 +72,12 @@ func NewVpcService(params VpcServiceParams) *VpcService {
 		lbRepo:         params.LBRepo,
 		peeringRepo:    params.PeeringRepo,
 		routeTableRepo: params.RouteTableRepo,
+		aclRepo:        params.NetworkACLRepo,
 		asRepo:         params.AsRepo,
 		rbacSvc:        params.RBACSvc,
 		network:        params.Network,
 		auditSvc:       params.AuditSvc,
+		tenantSvc:      params.TenantSvc,
 		logger:         logger,
 		defaultCIDR:    defaultCIDR,
 		computeBackend: params.ComputeBackend,
@@ -97,6 +104,9 @@ func (s *VpcService) CreateVPC(ctx context.Context, name, cidrBlock, idempotency
 			return existing, nil
 		}
 	}
+	if err := checkQuota(ctx, s.tenantSvc, domain.QuotaVPCs, 1); err != nil {
+		return nil, err
+	}
 
 	span.SetAttributes(
 		attribute.String("vpc.name", name),
@@ -129,6 +139,9 @@ func (s *VpcService) CreateVPC(ctx context.Context, name, cidrBlock, idempotency
 			return nil, errors.Wrap(errors.Internal, "failed to create OVS bridge", err)
 		}
 		bridgeCreated = true
+		if err := setupMetadataPort(ctx, s.network, bridgeName); err != nil {
+			s.logger.Warn("failed to set up metadata port on VPC bridge", "bridge", bridgeName, "error", err)
+		}
 	}
 
 	// 3. Construct ARN
@@ -159,6 +172,7 @@ func (s *VpcService) CreateVPC(ctx context.Context, name, cidrBlock, idempotency
 		}
 		return nil, errors.Wrap(errors.Internal, "failed to create VPC in database", err)
 	}
+	recordUsage(ctx, s.tenantSvc, s.logger, tenantID, domain.QuotaVPCs, 1)
 
 	// 5. Create main route table with local route
 	if s.routeTableRepo != nil {
@@ -178,7 +192,9 @@ func (s *VpcService) CreateVPC(ctx context.Context, name, cidrBlock, idempotency
 		if err := s.routeTableRepo.Create(ctx, mainRT); err != nil {
 			// Rollback: delete VPC
 			s.logger.Error("failed to create main route table, rolling back VPC", "error", err)
-			_ = s.repo.Delete(ctx, vpc.ID)
+			if delErr := s.repo.Delete(ctx, vpc.ID); delErr == nil {
+				recordUsage(ctx, s.tenantSvc, s.logger, tenantID, domain.QuotaVPCs, -1)
+			}
 			if bridgeCreated {
 				_ = s.network.DeleteBridge(ctx, bridgeName)
 			}
@@ -186,6 +202,29 @@ func (s *VpcService) CreateVPC(ctx context.Context, name, cidrBlock, idempotency
 		}
 	}
 
+	// 6. Create the default network ACL, which allows all traffic
+	if s.aclRepo != nil {
+		defaultACL := &domain.NetworkACL{
+			ID:        uuid.New(),
+			VPCID:     vpc.ID,
+			Name:      "default",
+			IsDefault: true,
+			CreatedAt: time.Now(),
+		}
+		defaultACL.Rules = domain.DefaultNetworkACLRules(defaultACL.ID)
+		if err := s.aclRepo.Create(ctx, defaultACL); err != nil {
+			// Rollback: delete VPC, which cascades to its route tables
+			s.logger.Error("failed to create default network ACL, rolling back VPC", "error", err)
+			if delErr := s.repo.Delete(ctx, vpc.ID); delErr == nil {
+				recordUsage(ctx, s.tenantSvc, s.logger, tenantID, domain.QuotaVPCs, -1)
+			}
+			if bridgeCreated {
+				_ = s.network.DeleteBridge(ctx, bridgeName)
+			}
+			return nil, errors.Wrap(errors.Internal, "failed to create default network ACL", err)
+		}
+	}
+
 	if err := s.auditSvc.Log(ctx, vpc.UserID, "vpc.create", "vpc", vpc.ID.String(), map[string]interface{}{
 		"name":       vpc.Name,
 		"cidr_block": vpc.CIDRBlock,
@@ -257,6 +296,78 @@ func (s *VpcService) UpdateVPC(ctx context.Context, idOrName, name string) (*dom
 	return vpc, nil
 }
 
+// AssignIPv6CIDRBlock makes a VPC dual-stack by giving it a /56 IPv6 block.
+// When cidrBlock is empty a random unique local (fd00::/8) block is generated.
+// The main route table gets a local route for the new block.
+func (s *VpcService) AssignIPv6CIDRBlock(ctx context.Context, idOrName, cidrBlock string) (*domain.VPC, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcUpdate, idOrName); err != nil {
+		return nil, err
+	}
+
+	vpc, err := s.GetVPC(ctx, idOrName)
+	if err != nil {
+		return nil, err
+	}
+	if vpc.IPv6CIDRBlock != "" {
+		return nil, errors.New(errors.Conflict, "vpc already has an ipv6 cidr block")
+	}
+
+	if cidrBlock == "" {
+		cidrBlock, err = generateULABlock()
+		if err != nil {
+			return nil, errors.Wrap(errors.Internal, "failed to generate ipv6 cidr block", err)
+		}
+	}
+	ip, ipNet, err := net.ParseCIDR(cidrBlock)
+	if err != nil || ip.To4() != nil {
+		return nil, errors.New(errors.InvalidInput, fmt.Sprintf("invalid IPv6 CIDR block: %s", cidrBlock))
+	}
+	if ones, _ := ipNet.Mask.Size(); ones != domain.VPCIPv6PrefixLength {
+		return nil, errors.New(errors.InvalidInput, fmt.Sprintf("VPC IPv6 CIDR block must be a /%d", domain.VPCIPv6PrefixLength))
+	}
+
+	vpc.IPv6CIDRBlock = ipNet.String()
+	if err := s.repo.Update(ctx, vpc); err != nil {
+		return nil, err
+	}
+
+	if s.routeTableRepo != nil {
+		mainRT, err := s.routeTableRepo.GetMainByVPC(ctx, vpc.ID)
+		if err != nil {
+			s.logger.Warn("failed to load main route table for ipv6 local route", "vpc_id", vpc.ID, "error", err)
+		} else if err := s.routeTableRepo.AddRoute(ctx, mainRT.ID, &domain.Route{
+			ID:              uuid.New(),
+			RouteTableID:    mainRT.ID,
+			DestinationCIDR: vpc.IPv6CIDRBlock,
+			TargetType:      domain.RouteTargetLocal,
+		}); err != nil {
+			s.logger.Warn("failed to add ipv6 local route", "vpc_id", vpc.ID, "error", err)
+		}
+	}
+
+	if err := s.auditSvc.Log(ctx, vpc.UserID, "vpc.ipv6_assign", "vpc", vpc.ID.String(), map[string]interface{}{
+		"ipv6_cidr_block": vpc.IPv6CIDRBlock,
+	}); err != nil {
+		s.logger.Warn("failed to log audit event", "action", "vpc.ipv6_assign", "vpc_id", vpc.ID, "error", err)
+	}
+
+	s.logger.Info("vpc ipv6 cidr block assigned", "id", vpc.ID, "ipv6_cidr_block", vpc.IPv6CIDRBlock)
+	return vpc, nil
+}
+
+// generateULABlock returns a random /56 from the fd00::/8 unique local range.
+func generateULABlock() (string, error) {
+	ip := make(net.IP, net.IPv6len)
+	ip[0] = 0xfd
+	if _, err := rand.Read(ip[1:6]); err != nil {
+		return "", err
+	}
+	return (&net.IPNet{IP: ip, Mask: net.CIDRMask(domain.VPCIPv6PrefixLength, 128)}).String(), nil
+}
+
 // DeleteVPC removes a VPC, its associated OVS bridge, and all related database records.
 // If force is true, dependency checks are skipped (for async cleanup scenarios).
 func (s *VpcService) DeleteVPC(ctx context.Context, idOrName string, force bool) error {
@@ -297,6 +408,7 @@ func (s *VpcService) DeleteVPC(ctx context.Context, idOrName string, force bool)
 	if err := s.repo.Delete(ctx, vpc.ID); err != nil {
 		return errors.Wrap(errors.Internal, "failed to delete VPC from database", err)
 	}
+	recordUsage(ctx, s.tenantSvc, s.logger, tenantID, domain.QuotaVPCs, -1)
 
 	if err := s.auditSvc.Log(ctx, vpc.UserID, "vpc.delete", "vpc", vpc.ID.String(), map[string]interface{}{
 		"name": vpc.Name,
@@ -27,6 +27,7 @@ type VPCPeeringService struct {
 	rtRepo   ports.RouteTableRepository
 	network  ports.NetworkBackend
 	auditSvc ports.AuditService
+	resolver ports.VPCResolverService
 	logger   *slog.Logger
 }
 
@@ -37,7 +38,9 @@ type VPCPeeringServiceParams struct {
 	RTRepo   ports.RouteTableRepository
 	Network  ports.NetworkBackend
 	AuditSvc ports.AuditService
-	Logger   *slog.Logger
+	// ResolverSvc is optional; when set, peered VPC resolvers learn each other's private zones.
+	ResolverSvc ports.VPCResolverService
+	Logger      *slog.Logger
 }
 
 // NewVPCPeeringService constructs a VPCPeeringService with its dependencies.
@@ -48,6 +51,7 @@ func NewVPCPeeringService(params VPCPeeringServiceParams) *VPCPeeringService {
 		rtRepo:   params.RTRepo,
 		network:  params.Network,
 		auditSvc: params.AuditSvc,
+		resolver: params.ResolverSvc,
 		logger:   params.Logger,
 	}
 }
@@ -168,6 +172,7 @@ func (s *VPCPeeringService) AcceptPeering(ctx context.Context, peeringID uuid.UU
 	}
 
 	peering.Status = domain.PeeringStatusActive
+	s.refreshResolvers(ctx, peering)
 
 	userID := appcontext.UserIDFromContext(ctx)
 	if err := s.auditSvc.Log(ctx, userID, "vpc_peering.accept", "vpc_peering", peeringID.String(), nil); err != nil {
@@ -241,6 +246,9 @@ func (s *VPCPeeringService) DeletePeering(ctx context.Context, peeringID uuid.UU
 	if err := s.repo.Delete(ctx, peeringID); err != nil {
 		return errors.Wrap(errors.Internal, "failed to delete peering", err)
 	}
+	if peering.Status == domain.PeeringStatusActive {
+		s.refreshResolvers(ctx, peering)
+	}
 
 	userID := appcontext.UserIDFromContext(ctx)
 	if err := s.auditSvc.Log(ctx, userID, "vpc_peering.delete", "vpc_peering", peeringID.String(), nil); err != nil {
@@ -251,6 +259,18 @@ func (s *VPCPeeringService) DeletePeering(ctx context.Context, peeringID uuid.UU
 	return nil
 }
 
+// refreshResolvers updates the private zones served by the resolvers of both peered VPCs.
+func (s *VPCPeeringService) refreshResolvers(ctx context.Context, peering *domain.VPCPeering) {
+	if s.resolver == nil {
+		return
+	}
+	for _, vpcID := range []uuid.UUID{peering.RequesterVPCID, peering.AccepterVPCID} {
+		if err := s.resolver.RefreshResolvers(ctx, vpcID); err != nil {
+			s.logger.Warn("failed to refresh VPC resolvers", "vpc_id", vpcID, "error", err)
+		}
+	}
+}
+
 // GetPeering retrieves details of a specific peering connection.
 func (s *VPCPeeringService) GetPeering(ctx context.Context, peeringID uuid.UUID) (*domain.VPCPeering, error) {
 	return s.repo.GetByID(ctx, peeringID)
@@ -0,0 +1,439 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	vpcResolverTracer = "vpc-resolver-service"
+	// VPCResolverImage runs dnsmasq as the VPC resolver.
+	VPCResolverImage = "alpine:3.20"
+	// resolverServersFile holds the per-domain upstreams of dnsmasq; it is re-read on SIGHUP.
+	resolverServersFile = "/etc/dnsmasq.servers"
+)
+
+// vpcResolverScript starts dnsmasq with the container's default upstreams for
+// public names; private zones and forwarding rules are pushed later through Exec.
+var vpcResolverScript = fmt.Sprintf(`set -e
+apk add --no-cache dnsmasq >/dev/null
+touch %[1]s
+exec dnsmasq --keep-in-foreground --no-hosts --servers-file=%[1]s --cache-size=1000`, resolverServersFile)
+
+// VPCResolverService manages the per-VPC DNS resolvers that instances use as nameserver.
+type VPCResolverService struct {
+	repo              ports.VPCResolverRepository
+	vpcRepo           ports.VpcRepository
+	dnsRepo           ports.DNSRepository
+	peeringRepo       ports.VPCPeeringRepository
+	compute           ports.ComputeBackend
+	rbacSvc           ports.RBACService
+	auditSvc          ports.AuditService
+	authoritativeAddr string
+	logger            *slog.Logger
+}
+
+// VPCResolverServiceParams holds dependencies for VPCResolverService.
+type VPCResolverServiceParams struct {
+	Repo        ports.VPCResolverRepository
+	VpcRepo     ports.VpcRepository
+	DNSRepo     ports.DNSRepository
+	PeeringRepo ports.VPCPeeringRepository
+	Compute     ports.ComputeBackend
+	RBACSvc     ports.RBACService
+	AuditSvc    ports.AuditService
+	// AuthoritativeAddr is the "ip[:port]" of the PowerDNS server answering private zones,
+	// as reachable from VPC networks.
+	AuthoritativeAddr string
+	Logger            *slog.Logger
+}
+
+// NewVPCResolverService constructs a VPCResolverService with its dependencies.
+func NewVPCResolverService(params VPCResolverServiceParams) *VPCResolverService {
+	logger := params.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &VPCResolverService{
+		repo:              params.Repo,
+		vpcRepo:           params.VpcRepo,
+		dnsRepo:           params.DNSRepo,
+		peeringRepo:       params.PeeringRepo,
+		compute:           params.Compute,
+		rbacSvc:           params.RBACSvc,
+		auditSvc:          params.AuditSvc,
+		authoritativeAddr: params.AuthoritativeAddr,
+		logger:            logger,
+	}
+}
+
+// CreateResolver launches the resolver container of a VPC.
+func (s *VPCResolverService) CreateResolver(ctx context.Context, vpcID uuid.UUID) (*domain.VPCResolver, error) {
+	ctx, span := otel.Tracer(vpcResolverTracer).Start(ctx, "CreateResolver")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("vpc_id", vpcID.String()))
+
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionDNSCreate, "*"); err != nil {
+		return nil, err
+	}
+
+	vpc, err := s.vpcRepo.GetByID(ctx, vpcID)
+	if err != nil {
+		return nil, errors.Wrap(errors.NotFound, "VPC not found", err)
+	}
+
+	id := uuid.New()
+	resolver := &domain.VPCResolver{
+		ID:        id,
+		VPCID:     vpc.ID,
+		UserID:    userID,
+		TenantID:  tenantID,
+		Status:    domain.VPCResolverStatusPending,
+		ARN:       fmt.Sprintf("arn:thecloud:dns:local:%s:resolver/%s", userID.String(), id.String()),
+		CreatedAt: time.Now(),
+	}
+	if err := s.repo.Create(ctx, resolver); err != nil {
+		return nil, err
+	}
+
+	containerID, _, err := s.compute.LaunchInstanceWithOptions(ctx, ports.CreateInstanceOptions{
+		Name:      fmt.Sprintf("thecloud-resolver-%s", id.String()[:8]),
+		ImageName: VPCResolverImage,
+		NetworkID: vpc.NetworkID,
+		Cmd:       []string{"sh", "-c", vpcResolverScript},
+	})
+	if err != nil {
+		s.logger.Error("failed to launch VPC resolver container", "resolver_id", id, "error", err)
+		resolver.Status = domain.VPCResolverStatusFailed
+		_ = s.repo.Update(ctx, resolver)
+		return nil, errors.Wrap(errors.Internal, "failed to launch VPC resolver", err)
+	}
+
+	resolver.ContainerID = containerID
+	if ip, err := s.compute.GetInstanceIP(ctx, containerID); err == nil {
+		resolver.PrivateIP = ip
+	} else {
+		s.logger.Warn("failed to get VPC resolver ip", "resolver_id", id, "error", err)
+	}
+	resolver.Status = domain.VPCResolverStatusActive
+	if err := s.repo.Update(ctx, resolver); err != nil {
+		s.logger.Warn("failed to update VPC resolver status to active", "error", err)
+	}
+
+	if err := s.applyConfig(ctx, resolver); err != nil {
+		s.logger.Warn("failed to configure VPC resolver", "resolver_id", id, "error", err)
+	}
+
+	if err := s.auditSvc.Log(ctx, userID, "vpc_resolver.create", "vpc_resolver", id.String(), map[string]interface{}{
+		"vpc_id": vpcID.String(),
+	}); err != nil {
+		s.logger.Warn("failed to log audit event", "error", err)
+	}
+
+	s.logger.Info("VPC resolver created", "id", id, "vpc_id", vpcID, "ip", resolver.PrivateIP)
+	return resolver, nil
+}
+
+// GetResolver retrieves a VPC resolver by ID.
+func (s *VPCResolverService) GetResolver(ctx context.Context, id uuid.UUID) (*domain.VPCResolver, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionDNSRead, id.String()); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetByID(ctx, id)
+}
+
+// ListResolvers returns the VPC resolvers of the current tenant.
+func (s *VPCResolverService) ListResolvers(ctx context.Context) ([]*domain.VPCResolver, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionDNSRead, "*"); err != nil {
+		return nil, err
+	}
+
+	return s.repo.List(ctx)
+}
+
+// DeleteResolver removes a resolver, its container and its forwarding rules.
+// Running instances keep the resolver address until they are relaunched.
+func (s *VPCResolverService) DeleteResolver(ctx context.Context, id uuid.UUID) error {
+	ctx, span := otel.Tracer(vpcResolverTracer).Start(ctx, "DeleteResolver")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("resolver_id", id.String()))
+
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionDNSDelete, id.String()); err != nil {
+		return err
+	}
+
+	resolver, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if resolver.ContainerID != "" {
+		if err := s.compute.DeleteInstance(ctx, resolver.ContainerID); err != nil {
+			return errors.Wrap(errors.Internal, "failed to remove VPC resolver container", err)
+		}
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.auditSvc.Log(ctx, userID, "vpc_resolver.delete", "vpc_resolver", id.String(), nil); err != nil {
+		s.logger.Warn("failed to log audit event", "error", err)
+	}
+
+	s.logger.Info("VPC resolver deleted", "id", id)
+	return nil
+}
+
+// CreateForwardingRule forwards queries for a domain to custom resolvers.
+func (s *VPCResolverService) CreateForwardingRule(ctx context.Context, resolverID uuid.UUID, domainName string, targetIPs []string) (*domain.ResolverForwardingRule, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionDNSUpdate, resolverID.String()); err != nil {
+		return nil, err
+	}
+
+	resolver, err := s.repo.GetByID(ctx, resolverID)
+	if err != nil {
+		return nil, err
+	}
+
+	rule := &domain.ResolverForwardingRule{
+		ID:         uuid.New(),
+		ResolverID: resolver.ID,
+		TenantID:   tenantID,
+		DomainName: strings.TrimSuffix(strings.ToLower(domainName), "."),
+		TargetIPs:  targetIPs,
+		CreatedAt:  time.Now(),
+	}
+	if err := rule.Validate(); err != nil {
+		return nil, errors.New(errors.InvalidInput, err.Error())
+	}
+
+	if err := s.repo.CreateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	if err := s.applyConfig(ctx, resolver); err != nil {
+		_ = s.repo.DeleteRule(ctx, resolver.ID, rule.ID)
+		return nil, errors.Wrap(errors.Internal, "failed to configure VPC resolver", err)
+	}
+
+	if err := s.auditSvc.Log(ctx, userID, "vpc_resolver.rule_create", "vpc_resolver", resolver.ID.String(), map[string]interface{}{
+		"rule_id":     rule.ID.String(),
+		"domain_name": rule.DomainName,
+	}); err != nil {
+		s.logger.Warn("failed to log audit event", "error", err)
+	}
+
+	return rule, nil
+}
+
+// ListForwardingRules returns the forwarding rules of a resolver.
+func (s *VPCResolverService) ListForwardingRules(ctx context.Context, resolverID uuid.UUID) ([]*domain.ResolverForwardingRule, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionDNSRead, resolverID.String()); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListRules(ctx, resolverID)
+}
+
+// DeleteForwardingRule removes a forwarding rule from a resolver.
+func (s *VPCResolverService) DeleteForwardingRule(ctx context.Context, resolverID, ruleID uuid.UUID) error {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionDNSUpdate, resolverID.String()); err != nil {
+		return err
+	}
+
+	resolver, err := s.repo.GetByID(ctx, resolverID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteRule(ctx, resolverID, ruleID); err != nil {
+		return err
+	}
+
+	if err := s.applyConfig(ctx, resolver); err != nil {
+		return errors.Wrap(errors.Internal, "failed to configure VPC resolver", err)
+	}
+
+	if err := s.auditSvc.Log(ctx, userID, "vpc_resolver.rule_delete", "vpc_resolver", resolverID.String(), map[string]interface{}{
+		"rule_id": ruleID.String(),
+	}); err != nil {
+		s.logger.Warn("failed to log audit event", "error", err)
+	}
+	return nil
+}
+
+// RefreshResolvers reconfigures the resolver of a VPC and those of its active peers.
+func (s *VPCResolverService) RefreshResolvers(ctx context.Context, vpcID uuid.UUID) error {
+	vpcIDs := []uuid.UUID{vpcID}
+	peers, err := s.peerVPCs(ctx, vpcID)
+	if err != nil {
+		return err
+	}
+	vpcIDs = append(vpcIDs, peers...)
+
+	for _, id := range vpcIDs {
+		resolver, err := s.repo.GetByVPC(ctx, id)
+		if err != nil {
+			if errors.Is(err, errors.NotFound) {
+				continue
+			}
+			return err
+		}
+		if err := s.applyConfig(ctx, resolver); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyConfig renders the resolver's dnsmasq servers file and signals dnsmasq to reload it.
+func (s *VPCResolverService) applyConfig(ctx context.Context, resolver *domain.VPCResolver) error {
+	if resolver.ContainerID == "" {
+		return nil
+	}
+
+	zones, err := s.privateZones(ctx, resolver.VPCID)
+	if err != nil {
+		return err
+	}
+	rules, err := s.repo.ListRules(ctx, resolver.ID)
+	if err != nil {
+		return err
+	}
+
+	servers, err := renderResolverServers(zones, rules, s.authoritativeAddr)
+	if err != nil {
+		return err
+	}
+
+	cmd := []string{"sh", "-c", `printf '%s' "$1" > ` + resolverServersFile + ` && (pkill -HUP dnsmasq || true)`, "sh", servers}
+	if _, err := s.compute.Exec(ctx, resolver.ContainerID, cmd); err != nil {
+		return errors.Wrap(errors.Internal, "failed to reload VPC resolver", err)
+	}
+	return nil
+}
+
+// privateZones returns the active private zones visible from a VPC: its own and
+// those of VPCs actively peered with it.
+func (s *VPCResolverService) privateZones(ctx context.Context, vpcID uuid.UUID) ([]*domain.DNSZone, error) {
+	peers, err := s.peerVPCs(ctx, vpcID)
+	if err != nil {
+		return nil, err
+	}
+
+	var zones []*domain.DNSZone
+	for _, id := range append([]uuid.UUID{vpcID}, peers...) {
+		zone, err := s.dnsRepo.GetZoneByVPC(ctx, id)
+		if err != nil {
+			if errors.Is(err, errors.NotFound) {
+				continue
+			}
+			return nil, err
+		}
+		if zone == nil || zone.Status != domain.ZoneStatusActive {
+			continue
+		}
+		zones = append(zones, zone)
+	}
+	return zones, nil
+}
+
+func (s *VPCResolverService) peerVPCs(ctx context.Context, vpcID uuid.UUID) ([]uuid.UUID, error) {
+	peerings, err := s.peeringRepo.ListByVPC(ctx, vpcID)
+	if err != nil {
+		return nil, err
+	}
+	var peers []uuid.UUID
+	for _, p := range peerings {
+		if p.Status != domain.PeeringStatusActive {
+			continue
+		}
+		if p.RequesterVPCID == vpcID {
+			peers = append(peers, p.AccepterVPCID)
+		} else {
+			peers = append(peers, p.RequesterVPCID)
+		}
+	}
+	return peers, nil
+}
+
+// renderResolverServers builds the dnsmasq servers file. Private zones are sent to
+// PowerDNS and take precedence over a forwarding rule for the same domain.
+func renderResolverServers(zones []*domain.DNSZone, rules []*domain.ResolverForwardingRule, authoritativeAddr string) (string, error) {
+	var b strings.Builder
+	private := make(map[string]bool, len(zones))
+
+	if len(zones) > 0 {
+		upstream, err := dnsmasqUpstream(authoritativeAddr)
+		if err != nil {
+			return "", errors.Wrap(errors.Internal, "invalid authoritative DNS address", err)
+		}
+		for _, zone := range zones {
+			name := strings.TrimSuffix(zone.Name, ".")
+			private[name] = true
+			fmt.Fprintf(&b, "server=/%s/%s\n", name, upstream)
+		}
+	}
+
+	for _, rule := range rules {
+		if private[rule.DomainName] {
+			continue
+		}
+		for _, target := range rule.TargetIPs {
+			upstream, err := dnsmasqUpstream(target)
+			if err != nil {
+				return "", errors.New(errors.InvalidInput, err.Error())
+			}
+			fmt.Fprintf(&b, "server=/%s/%s\n", rule.DomainName, upstream)
+		}
+	}
+	return b.String(), nil
+}
+
+// dnsmasqUpstream converts an "ip[:port]" target to dnsmasq's "ip#port" form.
+func dnsmasqUpstream(target string) (string, error) {
+	ip, port, err := domain.ParseResolverTarget(target)
+	if err != nil {
+		return "", err
+	}
+	if port == 53 {
+		return ip.String(), nil
+	}
+	return fmt.Sprintf("%s#%d", ip.String(), port), nil
+}
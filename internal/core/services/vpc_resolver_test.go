@@ -0,0 +1,169 @@
+package services_test
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/core/services"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVPCResolverService(t *testing.T) {
+	ctx := appcontext.WithTenantID(appcontext.WithUserID(context.Background(), uuid.New()), uuid.New())
+	vpc := &domain.VPC{ID: uuid.New(), NetworkID: "br-vpc", CIDRBlock: "10.0.0.0/16"}
+	peerVPCID := uuid.New()
+	resolver := &domain.VPCResolver{ID: uuid.New(), VPCID: vpc.ID, Status: domain.VPCResolverStatusActive, ContainerID: "resolver-cid", PrivateIP: "10.0.0.2"}
+	zone := &domain.DNSZone{ID: uuid.New(), VpcID: vpc.ID, Name: "app.internal", Status: domain.ZoneStatusActive}
+	peerZone := &domain.DNSZone{ID: uuid.New(), VpcID: peerVPCID, Name: "db.internal", Status: domain.ZoneStatusActive}
+	activePeering := &domain.VPCPeering{ID: uuid.New(), RequesterVPCID: peerVPCID, AccepterVPCID: vpc.ID, Status: domain.PeeringStatusActive}
+
+	type mocks struct {
+		repo     *MockVPCResolverRepo
+		vpcRepo  *MockVpcRepo
+		dnsRepo  *MockDNSRepository
+		peerings *MockVPCPeeringRepo
+		compute  *MockComputeBackend
+	}
+
+	setup := func() (*services.VPCResolverService, *mocks) {
+		m := &mocks{
+			repo:     new(MockVPCResolverRepo),
+			vpcRepo:  new(MockVpcRepo),
+			dnsRepo:  new(MockDNSRepository),
+			peerings: new(MockVPCPeeringRepo),
+			compute:  new(MockComputeBackend),
+		}
+		rbacSvc := new(MockRBACService)
+		rbacSvc.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+		audit := new(MockAuditService)
+		audit.On("Log", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+		m.vpcRepo.On("GetByID", mock.Anything, vpc.ID).Return(vpc, nil).Maybe()
+		svc := services.NewVPCResolverService(services.VPCResolverServiceParams{
+			Repo:              m.repo,
+			VpcRepo:           m.vpcRepo,
+			DNSRepo:           m.dnsRepo,
+			PeeringRepo:       m.peerings,
+			Compute:           m.compute,
+			RBACSvc:           rbacSvc,
+			AuditSvc:          audit,
+			AuthoritativeAddr: "172.17.0.1:5354",
+			Logger:            slog.Default(),
+		})
+		return svc, m
+	}
+
+	// serversFile captures the dnsmasq servers file pushed to the resolver container.
+	serversFile := func(m *mocks) *string {
+		var servers string
+		m.compute.On("Exec", mock.Anything, "resolver-cid", mock.Anything).Run(func(args mock.Arguments) {
+			cmd := args.Get(2).([]string)
+			servers = cmd[len(cmd)-1]
+		}).Return("", nil)
+		return &servers
+	}
+
+	t.Run("CreateResolverServesOwnAndPeeredZones", func(t *testing.T) {
+		svc, m := setup()
+		m.repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+		m.compute.On("LaunchInstanceWithOptions", mock.Anything, mock.MatchedBy(func(opts ports.CreateInstanceOptions) bool {
+			return opts.NetworkID == vpc.NetworkID && opts.ImageName == services.VPCResolverImage
+		})).Return("resolver-cid", []string(nil), nil)
+		m.compute.On("GetInstanceIP", mock.Anything, "resolver-cid").Return("10.0.0.2", nil)
+		m.repo.On("Update", mock.Anything, mock.Anything).Return(nil)
+		m.peerings.On("ListByVPC", mock.Anything, vpc.ID).Return([]*domain.VPCPeering{activePeering}, nil)
+		m.dnsRepo.On("GetZoneByVPC", mock.Anything, vpc.ID).Return(zone, nil)
+		m.dnsRepo.On("GetZoneByVPC", mock.Anything, peerVPCID).Return(peerZone, nil)
+		m.repo.On("ListRules", mock.Anything, mock.Anything).Return([]*domain.ResolverForwardingRule(nil), nil)
+		servers := serversFile(m)
+
+		created, err := svc.CreateResolver(ctx, vpc.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.VPCResolverStatusActive, created.Status)
+		assert.Equal(t, "10.0.0.2", created.PrivateIP)
+		assert.Contains(t, *servers, "server=/app.internal/172.17.0.1#5354\n")
+		assert.Contains(t, *servers, "server=/db.internal/172.17.0.1#5354\n")
+	})
+
+	t.Run("InactivePeeringZonesAreNotServed", func(t *testing.T) {
+		svc, m := setup()
+		pending := &domain.VPCPeering{ID: uuid.New(), RequesterVPCID: vpc.ID, AccepterVPCID: peerVPCID, Status: domain.PeeringStatusPendingAcceptance}
+		m.repo.On("GetByVPC", mock.Anything, vpc.ID).Return(resolver, nil)
+		m.peerings.On("ListByVPC", mock.Anything, vpc.ID).Return([]*domain.VPCPeering{pending}, nil)
+		m.dnsRepo.On("GetZoneByVPC", mock.Anything, vpc.ID).Return(zone, nil)
+		m.repo.On("ListRules", mock.Anything, resolver.ID).Return([]*domain.ResolverForwardingRule(nil), nil)
+		servers := serversFile(m)
+
+		require.NoError(t, svc.RefreshResolvers(ctx, vpc.ID))
+		assert.Equal(t, "server=/app.internal/172.17.0.1#5354\n", *servers)
+		m.dnsRepo.AssertNotCalled(t, "GetZoneByVPC", mock.Anything, peerVPCID)
+	})
+
+	t.Run("RefreshSkipsVPCsWithoutResolver", func(t *testing.T) {
+		svc, m := setup()
+		m.peerings.On("ListByVPC", mock.Anything, vpc.ID).Return([]*domain.VPCPeering(nil), nil)
+		m.repo.On("GetByVPC", mock.Anything, vpc.ID).Return(nil, errors.New(errors.NotFound, "VPC resolver not found"))
+
+		require.NoError(t, svc.RefreshResolvers(ctx, vpc.ID))
+		m.compute.AssertNotCalled(t, "Exec", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("ForwardingRuleRenderedWithPorts", func(t *testing.T) {
+		svc, m := setup()
+		m.repo.On("GetByID", mock.Anything, resolver.ID).Return(resolver, nil)
+		m.repo.On("CreateRule", mock.Anything, mock.Anything).Return(nil)
+		m.peerings.On("ListByVPC", mock.Anything, vpc.ID).Return([]*domain.VPCPeering(nil), nil)
+		m.dnsRepo.On("GetZoneByVPC", mock.Anything, vpc.ID).Return(nil, errors.New(errors.NotFound, "zone not found"))
+		m.repo.On("ListRules", mock.Anything, resolver.ID).Return([]*domain.ResolverForwardingRule{
+			{DomainName: "corp.example.com", TargetIPs: []string{"10.50.0.2", "10.50.0.3:5353"}},
+		}, nil)
+		servers := serversFile(m)
+
+		rule, err := svc.CreateForwardingRule(ctx, resolver.ID, "Corp.Example.com.", []string{"10.50.0.2", "10.50.0.3:5353"})
+		require.NoError(t, err)
+		assert.Equal(t, "corp.example.com", rule.DomainName)
+		assert.Equal(t, "server=/corp.example.com/10.50.0.2\nserver=/corp.example.com/10.50.0.3#5353\n", *servers)
+	})
+
+	t.Run("ForwardingRuleRejectsInvalidTarget", func(t *testing.T) {
+		svc, m := setup()
+		m.repo.On("GetByID", mock.Anything, resolver.ID).Return(resolver, nil)
+
+		_, err := svc.CreateForwardingRule(ctx, resolver.ID, "corp.example.com", []string{"resolver.corp"})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.InvalidInput))
+		m.repo.AssertNotCalled(t, "CreateRule", mock.Anything, mock.Anything)
+	})
+
+	t.Run("PrivateZoneWinsOverForwardingRule", func(t *testing.T) {
+		svc, m := setup()
+		m.repo.On("GetByVPC", mock.Anything, vpc.ID).Return(resolver, nil)
+		m.peerings.On("ListByVPC", mock.Anything, vpc.ID).Return([]*domain.VPCPeering(nil), nil)
+		m.dnsRepo.On("GetZoneByVPC", mock.Anything, vpc.ID).Return(zone, nil)
+		m.repo.On("ListRules", mock.Anything, resolver.ID).Return([]*domain.ResolverForwardingRule{
+			{DomainName: "app.internal", TargetIPs: []string{"10.50.0.2"}},
+		}, nil)
+		servers := serversFile(m)
+
+		require.NoError(t, svc.RefreshResolvers(ctx, vpc.ID))
+		assert.False(t, strings.Contains(*servers, "10.50.0.2"))
+	})
+
+	t.Run("DeleteResolverRemovesContainer", func(t *testing.T) {
+		svc, m := setup()
+		m.repo.On("GetByID", mock.Anything, resolver.ID).Return(resolver, nil)
+		m.compute.On("DeleteInstance", mock.Anything, "resolver-cid").Return(nil)
+		m.repo.On("Delete", mock.Anything, resolver.ID).Return(nil)
+
+		require.NoError(t, svc.DeleteResolver(ctx, resolver.ID))
+		m.compute.AssertExpectations(t)
+	})
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net"
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
 	appcontext "github.com/poyrazk/thecloud/internal/core/context"
 	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
 	"github.com/poyrazk/thecloud/internal/core/services"
 	"github.com/poyrazk/thecloud/internal/errors"
 	"github.com/stretchr/testify/assert"
@@ -25,6 +28,8 @@ func TestVpcServiceUnit(t *testing.T) {
 	routeTableRepo := new(MockRTRepo)
 	rbacSvc := new(MockRBACService)
 	rbacSvc.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	network.On("SetVethIP", mock.Anything, mock.Anything, domain.InstanceMetadataAddr, "32").Return(nil).Maybe()
+	network.On("AddFlowRule", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
 
 	svc := services.NewVpcService(services.VpcServiceParams{
 		Repo:           repo,
@@ -58,6 +63,12 @@ func TestVpcServiceUnit(t *testing.T) {
 		assert.Equal(t, "10.1.0.0/16", vpc.CIDRBlock)
 		repo.AssertExpectations(t)
 		routeTableRepo.AssertExpectations(t)
+
+		// The bridge carries the metadata address, where the API listens for the VPC.
+		network.AssertCalled(t, "SetVethIP", mock.Anything, vpc.NetworkID, domain.InstanceMetadataAddr, "32")
+		network.AssertCalled(t, "AddFlowRule", mock.Anything, vpc.NetworkID, mock.MatchedBy(func(flow ports.FlowRule) bool {
+			return flow.Match == "tcp,nw_dst="+domain.InstanceMetadataAddr && flow.Actions == "LOCAL"
+		}))
 	})
 
 	t.Run("CreateVPC_BridgeFailure", func(t *testing.T) {
@@ -214,4 +225,41 @@ func TestVpcServiceUnit(t *testing.T) {
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "whitespace")
 	})
+	t.Run("AssignIPv6_GeneratesULABlock", func(t *testing.T) {
+		vpcID := uuid.New()
+		rtID := uuid.New()
+		repo.On("GetByID", mock.Anything, vpcID).Return(&domain.VPC{ID: vpcID, UserID: userID, CIDRBlock: "10.0.0.0/16"}, nil).Once()
+		repo.On("Update", mock.Anything, mock.MatchedBy(func(v *domain.VPC) bool {
+			return v.ID == vpcID && strings.HasPrefix(v.IPv6CIDRBlock, "fd") && strings.HasSuffix(v.IPv6CIDRBlock, "::/56")
+		})).Return(nil).Once()
+		routeTableRepo.On("GetMainByVPC", mock.Anything, vpcID).Return(&domain.RouteTable{ID: rtID, VPCID: vpcID, IsMain: true}, nil).Once()
+		routeTableRepo.On("AddRoute", mock.Anything, rtID, mock.MatchedBy(func(r *domain.Route) bool {
+			return r.TargetType == domain.RouteTargetLocal && strings.HasSuffix(r.DestinationCIDR, "::/56")
+		})).Return(nil).Once()
+		auditSvc.On("Log", mock.Anything, userID, "vpc.ipv6_assign", "vpc", vpcID.String(), mock.Anything).Return(nil).Once()
+
+		vpc, err := svc.AssignIPv6CIDRBlock(ctx, vpcID.String(), "")
+		require.NoError(t, err)
+		_, ipNet, err := net.ParseCIDR(vpc.IPv6CIDRBlock)
+		require.NoError(t, err)
+		assert.Equal(t, vpc.IPv6CIDRBlock, ipNet.String())
+		routeTableRepo.AssertExpectations(t)
+	})
+
+	t.Run("AssignIPv6_RejectsWrongPrefix", func(t *testing.T) {
+		vpcID := uuid.New()
+		repo.On("GetByID", mock.Anything, vpcID).Return(&domain.VPC{ID: vpcID, UserID: userID}, nil).Once()
+
+		_, err := svc.AssignIPv6CIDRBlock(ctx, vpcID.String(), "fd00:1::/48")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.InvalidInput))
+	})
+
+	t.Run("AssignIPv6_AlreadyDualStack", func(t *testing.T) {
+		vpcID := uuid.New()
+		repo.On("GetByID", mock.Anything, vpcID).Return(&domain.VPC{ID: vpcID, IPv6CIDRBlock: "fd00:1:2::/56"}, nil).Once()
+
+		_, err := svc.AssignIPv6CIDRBlock(ctx, vpcID.String(), "")
+		assert.True(t, errors.Is(err, errors.Conflict))
+	})
 }
@@ -0,0 +1,680 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/crypto/curve25519"
+)
+
+const (
+	vpnTracer = "vpn-service"
+	// VPNGatewayImage provides the wg and ip tools used to configure tunnels.
+	VPNGatewayImage = "linuxserver/wireguard:latest"
+)
+
+// vpnGatewayScript brings up wg0 with the key passed in WG_PRIVATE_KEY and keeps
+// the container running; peers are added later through Exec.
+var vpnGatewayScript = fmt.Sprintf(`set -e
+umask 077
+printf '%%s' "$WG_PRIVATE_KEY" > /tmp/wg.key
+ip link add wg0 type wireguard
+wg set wg0 listen-port %d private-key /tmp/wg.key
+ip link set wg0 up
+sysctl -w net.ipv4.ip_forward=1
+exec tail -f /dev/null`, domain.VPNListenPort)
+
+// VPNService manages site-to-site WireGuard VPNs between VPCs and on-premises networks.
+type VPNService struct {
+	repo      ports.VPNRepository
+	vpcRepo   ports.VpcRepository
+	rtRepo    ports.RouteTableRepository
+	secretSvc ports.SecretService
+	compute   ports.ComputeBackend
+	network   ports.NetworkBackend
+	rbacSvc   ports.RBACService
+	auditSvc  ports.AuditService
+	logger    *slog.Logger
+}
+
+// VPNServiceParams holds dependencies for VPNService.
+type VPNServiceParams struct {
+	Repo      ports.VPNRepository
+	VpcRepo   ports.VpcRepository
+	RTRepo    ports.RouteTableRepository
+	SecretSvc ports.SecretService
+	Compute   ports.ComputeBackend
+	Network   ports.NetworkBackend
+	RBACSvc   ports.RBACService
+	AuditSvc  ports.AuditService
+	Logger    *slog.Logger
+}
+
+// NewVPNService constructs a VPNService with its dependencies.
+func NewVPNService(params VPNServiceParams) *VPNService {
+	logger := params.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &VPNService{
+		repo:      params.Repo,
+		vpcRepo:   params.VpcRepo,
+		rtRepo:    params.RTRepo,
+		secretSvc: params.SecretSvc,
+		compute:   params.Compute,
+		network:   params.Network,
+		rbacSvc:   params.RBACSvc,
+		auditSvc:  params.AuditSvc,
+		logger:    logger,
+	}
+}
+
+// CreateVPNGateway launches a WireGuard gateway container in the VPC.
+func (s *VPNService) CreateVPNGateway(ctx context.Context, vpcID uuid.UUID, name string) (*domain.VPNGateway, error) {
+	ctx, span := otel.Tracer(vpnTracer).Start(ctx, "CreateVPNGateway")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("vpc_id", vpcID.String()))
+
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcCreate, "*"); err != nil {
+		return nil, err
+	}
+
+	vpc, err := s.vpcRepo.GetByID(ctx, vpcID)
+	if err != nil {
+		return nil, errors.Wrap(errors.NotFound, "VPC not found", err)
+	}
+
+	gwID := uuid.New()
+	gw := &domain.VPNGateway{
+		ID:        gwID,
+		VPCID:     vpc.ID,
+		UserID:    userID,
+		TenantID:  tenantID,
+		Name:      name,
+		Status:    domain.VPNGatewayStatusPending,
+		ARN:       fmt.Sprintf("arn:thecloud:vpc:local:%s:vpn-gateway/%s", userID.String(), gwID.String()),
+		CreatedAt: time.Now(),
+	}
+	if err := gw.Validate(); err != nil {
+		return nil, errors.New(errors.InvalidInput, err.Error())
+	}
+
+	privateKey, publicKey, err := generateWireGuardKeyPair()
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to generate WireGuard key pair", err)
+	}
+	gw.PublicKey = publicKey
+
+	secret, err := s.secretSvc.CreateSecret(ctx, fmt.Sprintf("vpn-gateway-%s-private-key", gwID),
+		privateKey, fmt.Sprintf("WireGuard private key of VPN gateway %s", name))
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to store VPN gateway private key", err)
+	}
+	gw.PrivateKeySecretID = secret.ID
+
+	if err := s.repo.CreateGateway(ctx, gw); err != nil {
+		s.deleteSecret(ctx, secret.ID)
+		return nil, err
+	}
+
+	containerID, allocatedPorts, err := s.compute.LaunchInstanceWithOptions(ctx, ports.CreateInstanceOptions{
+		Name:         fmt.Sprintf("thecloud-vpn-%s", gwID.String()[:8]),
+		ImageName:    VPNGatewayImage,
+		Ports:        []string{fmt.Sprintf("0:%d/udp", domain.VPNListenPort)},
+		NetworkID:    vpc.NetworkID,
+		Env:          []string{"WG_PRIVATE_KEY=" + privateKey},
+		Cmd:          []string{"sh", "-c", vpnGatewayScript},
+		Capabilities: []string{"NET_ADMIN"},
+	})
+	if err != nil {
+		s.logger.Error("failed to launch VPN gateway container", "vpn_gateway_id", gwID, "error", err)
+		gw.Status = domain.VPNGatewayStatusFailed
+		_ = s.repo.UpdateGateway(ctx, gw)
+		return nil, errors.Wrap(errors.Internal, "failed to launch VPN gateway", err)
+	}
+
+	gw.ContainerID = containerID
+	listenPort := fmt.Sprintf("%d/udp", domain.VPNListenPort)
+	gw.Port = hostPortFor(allocatedPorts, listenPort)
+	if gw.Port == 0 {
+		if gw.Port, err = s.compute.GetInstancePort(ctx, containerID, listenPort); err != nil {
+			s.logger.Warn("failed to resolve VPN gateway port", "vpn_gateway_id", gwID, "error", err)
+		}
+	}
+	if ip, err := s.compute.GetInstanceIP(ctx, containerID); err == nil {
+		gw.PrivateIP = ip
+	} else {
+		s.logger.Warn("failed to get VPN gateway ip", "vpn_gateway_id", gwID, "error", err)
+	}
+	gw.Status = domain.VPNGatewayStatusActive
+	if err := s.repo.UpdateGateway(ctx, gw); err != nil {
+		s.logger.Warn("failed to update VPN gateway status to active", "error", err)
+	}
+
+	if err := s.auditSvc.Log(ctx, userID, "vpn_gateway.create", "vpn_gateway", gwID.String(), map[string]interface{}{
+		"vpc_id": vpcID.String(),
+		"name":   name,
+	}); err != nil {
+		s.logger.Warn("failed to log audit event", "error", err)
+	}
+
+	s.logger.Info("VPN gateway created", "id", gwID, "vpc_id", vpcID, "port", gw.Port)
+	return gw, nil
+}
+
+// GetVPNGateway retrieves a VPN gateway by ID.
+func (s *VPNService) GetVPNGateway(ctx context.Context, id uuid.UUID) (*domain.VPNGateway, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcRead, id.String()); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetGateway(ctx, id)
+}
+
+// ListVPNGateways returns all VPN gateways attached to a VPC.
+func (s *VPNService) ListVPNGateways(ctx context.Context, vpcID uuid.UUID) ([]*domain.VPNGateway, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcRead, vpcID.String()); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListGatewaysByVPC(ctx, vpcID)
+}
+
+// DeleteVPNGateway removes a gateway without connections, its container and its private key.
+func (s *VPNService) DeleteVPNGateway(ctx context.Context, id uuid.UUID) error {
+	ctx, span := otel.Tracer(vpnTracer).Start(ctx, "DeleteVPNGateway")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("vpn_gateway_id", id.String()))
+
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcDelete, id.String()); err != nil {
+		return err
+	}
+
+	gw, err := s.repo.GetGateway(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	conns, err := s.repo.ListConnectionsByGateway(ctx, id)
+	if err != nil {
+		return err
+	}
+	if len(conns) > 0 {
+		return errors.New(errors.Conflict, "VPN gateway still has connections")
+	}
+
+	if gw.ContainerID != "" {
+		if err := s.compute.DeleteInstance(ctx, gw.ContainerID); err != nil {
+			return errors.Wrap(errors.Internal, "failed to remove VPN gateway container", err)
+		}
+	}
+
+	if err := s.repo.DeleteGateway(ctx, id); err != nil {
+		return err
+	}
+	s.deleteSecret(ctx, gw.PrivateKeySecretID)
+
+	if err := s.auditSvc.Log(ctx, userID, "vpn_gateway.delete", "vpn_gateway", id.String(), nil); err != nil {
+		s.logger.Warn("failed to log audit event", "error", err)
+	}
+
+	s.logger.Info("VPN gateway deleted", "id", id)
+	return nil
+}
+
+// CreateCustomerGateway registers an on-premises WireGuard endpoint.
+func (s *VPNService) CreateCustomerGateway(ctx context.Context, name, ipAddress, publicKey string) (*domain.CustomerGateway, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcCreate, "*"); err != nil {
+		return nil, err
+	}
+
+	cgw := &domain.CustomerGateway{
+		ID:        uuid.New(),
+		UserID:    userID,
+		TenantID:  tenantID,
+		Name:      name,
+		IPAddress: ipAddress,
+		PublicKey: publicKey,
+		CreatedAt: time.Now(),
+	}
+	if err := cgw.Validate(); err != nil {
+		return nil, errors.New(errors.InvalidInput, err.Error())
+	}
+
+	if err := s.repo.CreateCustomerGateway(ctx, cgw); err != nil {
+		return nil, err
+	}
+
+	if err := s.auditSvc.Log(ctx, userID, "customer_gateway.create", "customer_gateway", cgw.ID.String(), map[string]interface{}{
+		"name":       name,
+		"ip_address": ipAddress,
+	}); err != nil {
+		s.logger.Warn("failed to log audit event", "error", err)
+	}
+
+	return cgw, nil
+}
+
+// ListCustomerGateways returns the tenant's customer gateways.
+func (s *VPNService) ListCustomerGateways(ctx context.Context) ([]*domain.CustomerGateway, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcRead, "*"); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListCustomerGateways(ctx)
+}
+
+// DeleteCustomerGateway removes a customer gateway that no connection uses.
+func (s *VPNService) DeleteCustomerGateway(ctx context.Context, id uuid.UUID) error {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcDelete, id.String()); err != nil {
+		return err
+	}
+
+	conns, err := s.repo.ListConnectionsByCustomerGateway(ctx, id)
+	if err != nil {
+		return err
+	}
+	if len(conns) > 0 {
+		return errors.New(errors.Conflict, "customer gateway is used by VPN connections")
+	}
+
+	if err := s.repo.DeleteCustomerGateway(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.auditSvc.Log(ctx, userID, "customer_gateway.delete", "customer_gateway", id.String(), nil); err != nil {
+		s.logger.Warn("failed to log audit event", "error", err)
+	}
+	return nil
+}
+
+// CreateVPNConnection adds the customer gateway as a peer of the VPN gateway and
+// routes the remote CIDRs to the gateway from the VPC's main route table.
+func (s *VPNService) CreateVPNConnection(ctx context.Context, gatewayID, customerGatewayID uuid.UUID, remoteCIDRs []string) (*domain.VPNConnection, error) {
+	ctx, span := otel.Tracer(vpnTracer).Start(ctx, "CreateVPNConnection")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("vpn_gateway_id", gatewayID.String()),
+		attribute.String("customer_gateway_id", customerGatewayID.String()),
+	)
+
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcUpdate, gatewayID.String()); err != nil {
+		return nil, err
+	}
+
+	conn := &domain.VPNConnection{
+		ID:                uuid.New(),
+		VPNGatewayID:      gatewayID,
+		CustomerGatewayID: customerGatewayID,
+		UserID:            userID,
+		TenantID:          tenantID,
+		RemoteCIDRs:       remoteCIDRs,
+		Status:            domain.VPNConnectionStatusPending,
+		CreatedAt:         time.Now(),
+	}
+	if err := conn.Validate(); err != nil {
+		return nil, errors.New(errors.InvalidInput, err.Error())
+	}
+
+	gw, err := s.repo.GetGateway(ctx, gatewayID)
+	if err != nil {
+		return nil, err
+	}
+	if gw.Status != domain.VPNGatewayStatusActive {
+		return nil, errors.New(errors.InvalidInput, "VPN gateway is not active")
+	}
+	cgw, err := s.repo.GetCustomerGateway(ctx, customerGatewayID)
+	if err != nil {
+		return nil, err
+	}
+	vpc, err := s.vpcRepo.GetByID(ctx, gw.VPCID)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to get VPC", err)
+	}
+	if err := checkRemoteCIDRs(vpc, remoteCIDRs); err != nil {
+		return nil, err
+	}
+
+	psk, err := generateWireGuardKey()
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to generate pre-shared key", err)
+	}
+	secret, err := s.secretSvc.CreateSecret(ctx, fmt.Sprintf("vpn-connection-%s-psk", conn.ID),
+		psk, fmt.Sprintf("Pre-shared key of VPN connection to %s", cgw.Name))
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to store pre-shared key", err)
+	}
+	conn.PreSharedKeySecretID = secret.ID
+
+	if err := s.repo.CreateConnection(ctx, conn); err != nil {
+		s.deleteSecret(ctx, secret.ID)
+		return nil, err
+	}
+
+	if _, err := s.compute.Exec(ctx, gw.ContainerID, addPeerCmd(conn, cgw, psk)); err != nil {
+		s.logger.Error("failed to configure VPN peer", "vpn_connection_id", conn.ID, "error", err)
+		conn.Status = domain.VPNConnectionStatusFailed
+		_ = s.repo.UpdateConnection(ctx, conn)
+		return nil, errors.Wrap(errors.Internal, "failed to configure VPN tunnel", err)
+	}
+
+	if err := s.addVPNRoutes(ctx, vpc, gw, remoteCIDRs); err != nil {
+		conn.Status = domain.VPNConnectionStatusFailed
+		_ = s.repo.UpdateConnection(ctx, conn)
+		return nil, err
+	}
+
+	conn.Status = domain.VPNConnectionStatusAvailable
+	if err := s.repo.UpdateConnection(ctx, conn); err != nil {
+		s.logger.Warn("failed to update VPN connection status to available", "error", err)
+	}
+
+	if err := s.auditSvc.Log(ctx, userID, "vpn_connection.create", "vpn_connection", conn.ID.String(), map[string]interface{}{
+		"vpn_gateway_id":      gatewayID.String(),
+		"customer_gateway_id": customerGatewayID.String(),
+		"remote_cidrs":        remoteCIDRs,
+	}); err != nil {
+		s.logger.Warn("failed to log audit event", "error", err)
+	}
+
+	s.logger.Info("VPN connection created", "id", conn.ID, "vpn_gateway_id", gatewayID, "remote_cidrs", remoteCIDRs)
+	return conn, nil
+}
+
+// GetVPNConnection retrieves a VPN connection by ID.
+func (s *VPNService) GetVPNConnection(ctx context.Context, id uuid.UUID) (*domain.VPNConnection, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcRead, id.String()); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetConnection(ctx, id)
+}
+
+// ListVPNConnections returns the connections terminated by a VPN gateway.
+func (s *VPNService) ListVPNConnections(ctx context.Context, gatewayID uuid.UUID) ([]*domain.VPNConnection, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcRead, gatewayID.String()); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListConnectionsByGateway(ctx, gatewayID)
+}
+
+// GetVPNConnectionConfig returns the settings for the customer's side of the tunnel.
+func (s *VPNService) GetVPNConnectionConfig(ctx context.Context, id uuid.UUID) (*domain.VPNConnectionConfig, error) {
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcRead, id.String()); err != nil {
+		return nil, err
+	}
+
+	conn, err := s.repo.GetConnection(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	gw, err := s.repo.GetGateway(ctx, conn.VPNGatewayID)
+	if err != nil {
+		return nil, err
+	}
+	vpc, err := s.vpcRepo.GetByID(ctx, gw.VPCID)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to get VPC", err)
+	}
+	psk, err := s.secretSvc.GetSecret(ctx, conn.PreSharedKeySecretID)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := []string{vpc.CIDRBlock}
+	if vpc.IPv6CIDRBlock != "" {
+		allowed = append(allowed, vpc.IPv6CIDRBlock)
+	}
+	return &domain.VPNConnectionConfig{
+		GatewayPublicKey: gw.PublicKey,
+		GatewayPort:      gw.Port,
+		PreSharedKey:     psk.EncryptedValue, // GetSecret returns the plaintext in this field
+		AllowedIPs:       allowed,
+	}, nil
+}
+
+// DeleteVPNConnection removes the tunnel peer, its routes and its pre-shared key.
+func (s *VPNService) DeleteVPNConnection(ctx context.Context, id uuid.UUID) error {
+	ctx, span := otel.Tracer(vpnTracer).Start(ctx, "DeleteVPNConnection")
+	defer span.End()
+
+	span.SetAttributes(attribute.String("vpn_connection_id", id.String()))
+
+	userID := appcontext.UserIDFromContext(ctx)
+	tenantID := appcontext.TenantIDFromContext(ctx)
+
+	if err := s.rbacSvc.Authorize(ctx, userID, tenantID, domain.PermissionVpcUpdate, id.String()); err != nil {
+		return err
+	}
+
+	conn, err := s.repo.GetConnection(ctx, id)
+	if err != nil {
+		return err
+	}
+	gw, err := s.repo.GetGateway(ctx, conn.VPNGatewayID)
+	if err != nil {
+		return err
+	}
+	cgw, err := s.repo.GetCustomerGateway(ctx, conn.CustomerGatewayID)
+	if err != nil {
+		return err
+	}
+	vpc, err := s.vpcRepo.GetByID(ctx, gw.VPCID)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to get VPC", err)
+	}
+
+	s.removeVPNRoutes(ctx, vpc, gw, conn.RemoteCIDRs)
+
+	if gw.ContainerID != "" {
+		if _, err := s.compute.Exec(ctx, gw.ContainerID, removePeerCmd(conn, cgw)); err != nil {
+			s.logger.Warn("failed to remove VPN peer", "vpn_connection_id", id, "error", err)
+		}
+	}
+
+	if err := s.repo.DeleteConnection(ctx, id); err != nil {
+		return err
+	}
+	s.deleteSecret(ctx, conn.PreSharedKeySecretID)
+
+	if err := s.auditSvc.Log(ctx, userID, "vpn_connection.delete", "vpn_connection", id.String(), nil); err != nil {
+		s.logger.Warn("failed to log audit event", "error", err)
+	}
+
+	s.logger.Info("VPN connection deleted", "id", id)
+	return nil
+}
+
+// addVPNRoutes points each remote CIDR at the gateway in the VPC's main route table.
+func (s *VPNService) addVPNRoutes(ctx context.Context, vpc *domain.VPC, gw *domain.VPNGateway, cidrs []string) error {
+	mainRT, err := s.rtRepo.GetMainByVPC(ctx, vpc.ID)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to get main route table", err)
+	}
+	for _, cidr := range cidrs {
+		route := &domain.Route{
+			ID:              uuid.New(),
+			RouteTableID:    mainRT.ID,
+			DestinationCIDR: cidr,
+			TargetType:      domain.RouteTargetVPN,
+			TargetID:        &gw.ID,
+			TargetName:      gw.Name,
+			CreatedAt:       time.Now(),
+		}
+		if err := s.rtRepo.AddRoute(ctx, mainRT.ID, route); err != nil {
+			return errors.Wrap(errors.Internal, "failed to add VPN route", err)
+		}
+		if err := s.network.AddFlowRule(ctx, vpc.NetworkID, routeFlow(*route)); err != nil {
+			s.logger.Error("failed to add OVS flow for VPN route", "route_id", route.ID, "error", err)
+		}
+	}
+	return nil
+}
+
+// removeVPNRoutes deletes the gateway's routes for cidrs from the VPC's main route table.
+func (s *VPNService) removeVPNRoutes(ctx context.Context, vpc *domain.VPC, gw *domain.VPNGateway, cidrs []string) {
+	mainRT, err := s.rtRepo.GetMainByVPC(ctx, vpc.ID)
+	if err != nil {
+		s.logger.Warn("failed to get main route table for VPN route cleanup", "vpc_id", vpc.ID, "error", err)
+		return
+	}
+	routes, err := s.rtRepo.ListRoutes(ctx, mainRT.ID)
+	if err != nil {
+		s.logger.Warn("failed to list routes for VPN route cleanup", "route_table_id", mainRT.ID, "error", err)
+		return
+	}
+
+	wanted := make(map[string]bool, len(cidrs))
+	for _, cidr := range cidrs {
+		wanted[cidr] = true
+	}
+	for _, route := range routes {
+		if route.TargetType != domain.RouteTargetVPN || route.TargetID == nil || *route.TargetID != gw.ID || !wanted[route.DestinationCIDR] {
+			continue
+		}
+		if err := s.rtRepo.RemoveRoute(ctx, mainRT.ID, route.ID); err != nil {
+			s.logger.Warn("failed to remove VPN route", "route_id", route.ID, "error", err)
+			continue
+		}
+		if err := s.network.DeleteFlowRule(ctx, vpc.NetworkID, routeFlow(route).Match); err != nil {
+			s.logger.Warn("failed to remove OVS flow for VPN route", "route_id", route.ID, "error", err)
+		}
+	}
+}
+
+func (s *VPNService) deleteSecret(ctx context.Context, id uuid.UUID) {
+	if err := s.secretSvc.DeleteSecret(ctx, id); err != nil {
+		s.logger.Warn("failed to delete VPN secret", "secret_id", id, "error", err)
+	}
+}
+
+// checkRemoteCIDRs rejects on-premises ranges that overlap the VPC's own addresses.
+func checkRemoteCIDRs(vpc *domain.VPC, cidrs []string) error {
+	local := []string{vpc.CIDRBlock}
+	if vpc.IPv6CIDRBlock != "" {
+		local = append(local, vpc.IPv6CIDRBlock)
+	}
+	for _, cidr := range cidrs {
+		_, remote, _ := net.ParseCIDR(cidr)
+		for _, l := range local {
+			_, vpcNet, err := net.ParseCIDR(l)
+			if err != nil {
+				continue
+			}
+			if vpcNet.Contains(remote.IP) || remote.Contains(vpcNet.IP) {
+				return errors.New(errors.InvalidInput, fmt.Sprintf("remote CIDR %s overlaps the VPC", cidr))
+			}
+		}
+	}
+	return nil
+}
+
+// addPeerCmd configures the customer gateway as a WireGuard peer and routes the
+// remote CIDRs into the tunnel. Keys are base64 and CIDRs are validated, so
+// neither needs shell quoting beyond the single quotes used here.
+func addPeerCmd(conn *domain.VPNConnection, cgw *domain.CustomerGateway, psk string) []string {
+	pskFile := fmt.Sprintf("/tmp/psk-%s", conn.ID)
+	endpoint := net.JoinHostPort(cgw.IPAddress, strconv.Itoa(domain.VPNListenPort))
+	script := fmt.Sprintf("umask 077 && printf '%%s' '%s' > %s && wg set wg0 peer '%s' preshared-key %s endpoint %s allowed-ips %s && for c in %s; do ip route replace $c dev wg0; done",
+		psk, pskFile, cgw.PublicKey, pskFile, endpoint, strings.Join(conn.RemoteCIDRs, ","), strings.Join(conn.RemoteCIDRs, " "))
+	return []string{"sh", "-c", script}
+}
+
+// removePeerCmd undoes addPeerCmd.
+func removePeerCmd(conn *domain.VPNConnection, cgw *domain.CustomerGateway) []string {
+	script := fmt.Sprintf("wg set wg0 peer '%s' remove; for c in %s; do ip route del $c dev wg0; done; rm -f /tmp/psk-%s",
+		cgw.PublicKey, strings.Join(conn.RemoteCIDRs, " "), conn.ID)
+	return []string{"sh", "-c", script}
+}
+
+// hostPortFor returns the host port mapped to containerPort in "host:container"
+// port strings, or 0 when the backend assigned none.
+func hostPortFor(allocatedPorts []string, containerPort string) int {
+	for _, p := range allocatedPorts {
+		host, cPort, ok := strings.Cut(p, ":")
+		if !ok || cPort != containerPort {
+			continue
+		}
+		if port, err := strconv.Atoi(host); err == nil {
+			return port
+		}
+	}
+	return 0
+}
+
+// generateWireGuardKey returns 32 random bytes, base64-encoded, as used for WireGuard pre-shared keys.
+func generateWireGuardKey() (string, error) {
+	key := make([]byte, curve25519.ScalarSize)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// generateWireGuardKeyPair returns a base64-encoded Curve25519 private and public key.
+func generateWireGuardKeyPair() (string, string, error) {
+	priv := make([]byte, curve25519.ScalarSize)
+	if _, err := rand.Read(priv); err != nil {
+		return "", "", err
+	}
+	// Clamp as wg genkey does.
+	priv[0] &= 248
+	priv[31] = (priv[31] & 127) | 64
+
+	pub, err := curve25519.X25519(priv, curve25519.Basepoint)
+	if err != nil {
+		return "", "", err
+	}
+	return base64.StdEncoding.EncodeToString(priv), base64.StdEncoding.EncodeToString(pub), nil
+}
@@ -0,0 +1,179 @@
+package services_test
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/core/services"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testWireGuardKey = "xTIBA5rboUvnH4htodjb6e697QjLERt1NAB4mZqp8Dg="
+
+func TestVPNService(t *testing.T) {
+	ctx := appcontext.WithTenantID(appcontext.WithUserID(context.Background(), uuid.New()), uuid.New())
+	vpc := &domain.VPC{ID: uuid.New(), NetworkID: "br-vpc", CIDRBlock: "10.0.0.0/16"}
+	mainRT := &domain.RouteTable{ID: uuid.New(), VPCID: vpc.ID, IsMain: true}
+	gw := &domain.VPNGateway{ID: uuid.New(), VPCID: vpc.ID, Name: "onprem", Status: domain.VPNGatewayStatusActive, ContainerID: "vpn-cid", PublicKey: testWireGuardKey, Port: 40001}
+	cgw := &domain.CustomerGateway{ID: uuid.New(), Name: "office", IPAddress: "203.0.113.10", PublicKey: testWireGuardKey}
+
+	type mocks struct {
+		repo    *MockVPNRepo
+		vpcRepo *MockVpcRepo
+		rtRepo  *MockRTRepo
+		secrets *MockSecretService
+		compute *MockComputeBackend
+		network *MockNetworkBackend
+	}
+
+	setup := func() (*services.VPNService, *mocks) {
+		m := &mocks{
+			repo:    new(MockVPNRepo),
+			vpcRepo: new(MockVpcRepo),
+			rtRepo:  new(MockRTRepo),
+			secrets: new(MockSecretService),
+			compute: new(MockComputeBackend),
+			network: new(MockNetworkBackend),
+		}
+		rbacSvc := new(MockRBACService)
+		rbacSvc.On("Authorize", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+		audit := new(MockAuditService)
+		audit.On("Log", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+		m.vpcRepo.On("GetByID", mock.Anything, vpc.ID).Return(vpc, nil).Maybe()
+		svc := services.NewVPNService(services.VPNServiceParams{
+			Repo:      m.repo,
+			VpcRepo:   m.vpcRepo,
+			RTRepo:    m.rtRepo,
+			SecretSvc: m.secrets,
+			Compute:   m.compute,
+			Network:   m.network,
+			RBACSvc:   rbacSvc,
+			AuditSvc:  audit,
+			Logger:    slog.Default(),
+		})
+		return svc, m
+	}
+
+	t.Run("CreateGatewayStoresKeyAndLaunchesContainer", func(t *testing.T) {
+		svc, m := setup()
+		secretID := uuid.New()
+		var storedKey string
+		m.secrets.On("CreateSecret", mock.Anything, mock.MatchedBy(func(name string) bool {
+			return strings.HasPrefix(name, "vpn-gateway-") && strings.HasSuffix(name, "-private-key")
+		}), mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			storedKey = args.String(2)
+		}).Return(&domain.Secret{ID: secretID}, nil)
+		m.repo.On("CreateGateway", mock.Anything, mock.Anything).Return(nil)
+		m.compute.On("LaunchInstanceWithOptions", mock.Anything, mock.MatchedBy(func(opts ports.CreateInstanceOptions) bool {
+			return opts.NetworkID == vpc.NetworkID && opts.Ports[0] == "0:51820/udp" && opts.Capabilities[0] == "NET_ADMIN"
+		})).Return("vpn-cid", []string{"40001:51820/udp"}, nil)
+		m.compute.On("GetInstanceIP", mock.Anything, "vpn-cid").Return("10.0.1.5", nil)
+		m.repo.On("UpdateGateway", mock.Anything, mock.Anything).Return(nil)
+
+		created, err := svc.CreateVPNGateway(ctx, vpc.ID, "onprem")
+		require.NoError(t, err)
+		assert.Equal(t, domain.VPNGatewayStatusActive, created.Status)
+		assert.Equal(t, secretID, created.PrivateKeySecretID)
+		assert.Equal(t, 40001, created.Port)
+		assert.True(t, domain.IsValidWireGuardKey(created.PublicKey))
+		assert.True(t, domain.IsValidWireGuardKey(storedKey))
+		assert.NotEqual(t, storedKey, created.PublicKey)
+	})
+
+	t.Run("CreateConnectionConfiguresPeerAndRoutes", func(t *testing.T) {
+		svc, m := setup()
+		m.repo.On("GetGateway", mock.Anything, gw.ID).Return(gw, nil)
+		m.repo.On("GetCustomerGateway", mock.Anything, cgw.ID).Return(cgw, nil)
+		m.secrets.On("CreateSecret", mock.Anything, mock.MatchedBy(func(name string) bool {
+			return strings.HasSuffix(name, "-psk")
+		}), mock.Anything, mock.Anything).Return(&domain.Secret{ID: uuid.New()}, nil)
+		m.repo.On("CreateConnection", mock.Anything, mock.Anything).Return(nil)
+		m.compute.On("Exec", mock.Anything, "vpn-cid", mock.MatchedBy(func(cmd []string) bool {
+			script := cmd[len(cmd)-1]
+			return strings.Contains(script, "wg set wg0 peer '"+testWireGuardKey+"'") &&
+				strings.Contains(script, "endpoint 203.0.113.10:51820") &&
+				strings.Contains(script, "allowed-ips 192.168.0.0/16")
+		})).Return("", nil)
+		m.rtRepo.On("GetMainByVPC", mock.Anything, vpc.ID).Return(mainRT, nil)
+		m.rtRepo.On("AddRoute", mock.Anything, mainRT.ID, mock.MatchedBy(func(r *domain.Route) bool {
+			return r.TargetType == domain.RouteTargetVPN && *r.TargetID == gw.ID && r.DestinationCIDR == "192.168.0.0/16"
+		})).Return(nil)
+		m.network.On("AddFlowRule", mock.Anything, vpc.NetworkID, mock.Anything).Return(nil)
+		m.repo.On("UpdateConnection", mock.Anything, mock.Anything).Return(nil)
+
+		conn, err := svc.CreateVPNConnection(ctx, gw.ID, cgw.ID, []string{"192.168.0.0/16"})
+		require.NoError(t, err)
+		assert.Equal(t, domain.VPNConnectionStatusAvailable, conn.Status)
+		m.rtRepo.AssertExpectations(t)
+	})
+
+	t.Run("CreateConnectionRejectsOverlap", func(t *testing.T) {
+		svc, m := setup()
+		m.repo.On("GetGateway", mock.Anything, gw.ID).Return(gw, nil)
+		m.repo.On("GetCustomerGateway", mock.Anything, cgw.ID).Return(cgw, nil)
+
+		_, err := svc.CreateVPNConnection(ctx, gw.ID, cgw.ID, []string{"10.0.5.0/24"})
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.InvalidInput))
+	})
+
+	t.Run("DeleteConnectionRemovesRoutesAndKey", func(t *testing.T) {
+		svc, m := setup()
+		conn := &domain.VPNConnection{ID: uuid.New(), VPNGatewayID: gw.ID, CustomerGatewayID: cgw.ID, RemoteCIDRs: []string{"192.168.0.0/16"}, PreSharedKeySecretID: uuid.New()}
+		vpnRoute := domain.Route{ID: uuid.New(), DestinationCIDR: "192.168.0.0/16", TargetType: domain.RouteTargetVPN, TargetID: &gw.ID}
+		other := domain.Route{ID: uuid.New(), DestinationCIDR: "0.0.0.0/0", TargetType: domain.RouteTargetIGW}
+		m.repo.On("GetConnection", mock.Anything, conn.ID).Return(conn, nil)
+		m.repo.On("GetGateway", mock.Anything, gw.ID).Return(gw, nil)
+		m.repo.On("GetCustomerGateway", mock.Anything, cgw.ID).Return(cgw, nil)
+		m.rtRepo.On("GetMainByVPC", mock.Anything, vpc.ID).Return(mainRT, nil)
+		m.rtRepo.On("ListRoutes", mock.Anything, mainRT.ID).Return([]domain.Route{vpnRoute, other}, nil)
+		m.rtRepo.On("RemoveRoute", mock.Anything, mainRT.ID, vpnRoute.ID).Return(nil).Once()
+		m.network.On("DeleteFlowRule", mock.Anything, vpc.NetworkID, "ip,nw_dst=192.168.0.0/16").Return(nil)
+		m.compute.On("Exec", mock.Anything, "vpn-cid", mock.Anything).Return("", nil)
+		m.repo.On("DeleteConnection", mock.Anything, conn.ID).Return(nil)
+		m.secrets.On("DeleteSecret", mock.Anything, conn.PreSharedKeySecretID).Return(nil)
+
+		require.NoError(t, svc.DeleteVPNConnection(ctx, conn.ID))
+		m.rtRepo.AssertExpectations(t)
+		m.secrets.AssertExpectations(t)
+	})
+
+	t.Run("DeleteGatewayWithConnections", func(t *testing.T) {
+		svc, m := setup()
+		m.repo.On("GetGateway", mock.Anything, gw.ID).Return(gw, nil)
+		m.repo.On("ListConnectionsByGateway", mock.Anything, gw.ID).Return([]*domain.VPNConnection{{ID: uuid.New()}}, nil)
+
+		err := svc.DeleteVPNGateway(ctx, gw.ID)
+		assert.True(t, errors.Is(err, errors.Conflict))
+		m.compute.AssertNotCalled(t, "DeleteInstance", mock.Anything, mock.Anything)
+	})
+
+	t.Run("CustomerGatewayRequiresWireGuardKey", func(t *testing.T) {
+		svc, _ := setup()
+		_, err := svc.CreateCustomerGateway(ctx, "office", "203.0.113.10", "not-a-key")
+		assert.True(t, errors.Is(err, errors.InvalidInput))
+	})
+
+	t.Run("ConnectionConfig", func(t *testing.T) {
+		svc, m := setup()
+		conn := &domain.VPNConnection{ID: uuid.New(), VPNGatewayID: gw.ID, PreSharedKeySecretID: uuid.New()}
+		m.repo.On("GetConnection", mock.Anything, conn.ID).Return(conn, nil)
+		m.repo.On("GetGateway", mock.Anything, gw.ID).Return(gw, nil)
+		m.secrets.On("GetSecret", mock.Anything, conn.PreSharedKeySecretID).Return(&domain.Secret{EncryptedValue: "psk-plain"}, nil)
+
+		cfg, err := svc.GetVPNConnectionConfig(ctx, conn.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "psk-plain", cfg.PreSharedKey)
+		assert.Equal(t, 40001, cfg.GatewayPort)
+		assert.Equal(t, []string{"10.0.0.0/16"}, cfg.AllowedIPs)
+	})
+}
@@ -6,7 +6,9 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/errors"
 	"github.com/poyrazk/thecloud/pkg/httputil"
 )
 
@@ -31,23 +33,7 @@ func NewAccountingHandler(svc ports.AccountingService) *AccountingHandler {
 // @Router /billing/summary [get]
 func (h *AccountingHandler) GetSummary(c *gin.Context) {
 	userID := httputil.GetUserID(c)
-	startStr := c.Query("start")
-	endStr := c.Query("end")
-
-	now := time.Now()
-	start := now.AddDate(0, -1, 0)
-	end := now
-
-	if startStr != "" {
-		if t, err := time.Parse(time.RFC3339, startStr); err == nil {
-			start = t
-		}
-	}
-	if endStr != "" {
-		if t, err := time.Parse(time.RFC3339, endStr); err == nil {
-			end = t
-		}
-	}
+	start, end := billingPeriod(c)
 
 	summary, err := h.svc.GetSummary(c.Request.Context(), userID, start, end)
 	if err != nil {
@@ -69,29 +55,62 @@ func (h *AccountingHandler) GetSummary(c *gin.Context) {
 // @Router /billing/usage [get]
 func (h *AccountingHandler) ListUsage(c *gin.Context) {
 	userID := httputil.GetUserID(c)
-	startStr := c.Query("start")
-	endStr := c.Query("end")
+	start, end := billingPeriod(c)
+
+	records, err := h.svc.ListUsage(c.Request.Context(), userID, start, end)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	httputil.Success(c, http.StatusOK, records)
+}
 
+// GetOrganizationSummary returns a consolidated bill across all tenants of an organization
+// @Summary Get organization billing summary
+// @Tags billing
+// @Security APIKeyAuth
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Param start query string false "Start time (RFC3339)"
+// @Param end query string false "End time (RFC3339)"
+// @Success 200 {object} domain.OrganizationBillSummary
+// @Failure 400 {object} httputil.Response
+// @Failure 401 {object} httputil.Response
+// @Failure 404 {object} httputil.Response
+// @Router /billing/organizations/{id}/summary [get]
+func (h *AccountingHandler) GetOrganizationSummary(c *gin.Context) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid organization ID"))
+		return
+	}
+	start, end := billingPeriod(c)
+
+	summary, err := h.svc.GetOrganizationSummary(c.Request.Context(), orgID, start, end)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	httputil.Success(c, http.StatusOK, summary)
+}
+
+// billingPeriod reads the optional start/end query parameters, defaulting to the last month.
+func billingPeriod(c *gin.Context) (time.Time, time.Time) {
 	now := time.Now()
 	start := now.AddDate(0, -1, 0)
 	end := now
 
-	if startStr != "" {
+	if startStr := c.Query("start"); startStr != "" {
 		if t, err := time.Parse(time.RFC3339, startStr); err == nil {
 			start = t
 		}
 	}
-	if endStr != "" {
+	if endStr := c.Query("end"); endStr != "" {
 		if t, err := time.Parse(time.RFC3339, endStr); err == nil {
 			end = t
 		}
 	}
-
-	records, err := h.svc.ListUsage(c.Request.Context(), userID, start, end)
-	if err != nil {
-		httputil.Error(c, err)
-		return
-	}
-
-	httputil.Success(c, http.StatusOK, records)
+	return start, end
 }
@@ -40,6 +40,14 @@ func (m *mockAccountingService) ListUsage(ctx context.Context, userID uuid.UUID,
 	return r0, args.Error(1)
 }
 
+func (m *mockAccountingService) GetOrganizationSummary(ctx context.Context, orgID uuid.UUID, start, end time.Time) (*domain.OrganizationBillSummary, error) {
+	args := m.Called(ctx, orgID, start, end)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.OrganizationBillSummary), args.Error(1)
+}
+
 func (m *mockAccountingService) ProcessHourlyBilling(ctx context.Context) error {
 	args := m.Called(ctx)
 	return args.Error(0)
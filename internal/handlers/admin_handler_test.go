@@ -21,6 +21,10 @@ func (m *mockAdminComputeFull) ResetCircuitBreaker() {
 	m.Called()
 }
 
+func (m *mockAdminComputeFull) HostMemoryPressure(ctx context.Context) ([]ports.HostPressure, error) {
+	return nil, nil
+}
+
 func (m *mockAdminComputeFull) LaunchInstanceWithOptions(ctx context.Context, opts ports.CreateInstanceOptions) (string, []string, error) {
 	args := m.Called(ctx, opts)
 	if args.Get(1) == nil {
@@ -96,6 +100,12 @@ func (m *mockAdminComputeFull) DetachVolume(ctx context.Context, id string, volu
 	args := m.Called(ctx, id, volumePath)
 	return args.String(0), args.Error(1)
 }
+func (m *mockAdminComputeFull) AttachNetworkInterface(ctx context.Context, id string, nic ports.NetworkInterfaceOptions) error {
+	return m.Called(ctx, id, nic).Error(0)
+}
+func (m *mockAdminComputeFull) DetachNetworkInterface(ctx context.Context, id string, nic ports.NetworkInterfaceOptions) error {
+	return m.Called(ctx, id, nic).Error(0)
+}
 func (m *mockAdminComputeFull) Ping(ctx context.Context) error {
 	args := m.Called(ctx)
 	return args.Error(0)
@@ -189,6 +199,12 @@ func (c *computeNoOpReset) AttachVolume(ctx context.Context, id, volumePath stri
 func (c *computeNoOpReset) DetachVolume(ctx context.Context, id, volumePath string) (string, error) {
 	return "", nil
 }
+func (c *computeNoOpReset) AttachNetworkInterface(ctx context.Context, id string, nic ports.NetworkInterfaceOptions) error {
+	return nil
+}
+func (c *computeNoOpReset) DetachNetworkInterface(ctx context.Context, id string, nic ports.NetworkInterfaceOptions) error {
+	return nil
+}
 func (c *computeNoOpReset) Ping(ctx context.Context) error { return nil }
 func (c *computeNoOpReset) Type() string                   { return "" }
 func (c *computeNoOpReset) ResizeInstance(ctx context.Context, id string, cpu, memory int64) error {
@@ -198,6 +214,9 @@ func (c *computeNoOpReset) CreateSnapshot(ctx context.Context, id, name string)
 func (c *computeNoOpReset) RestoreSnapshot(ctx context.Context, id, name string) error { return nil }
 func (c *computeNoOpReset) DeleteSnapshot(ctx context.Context, id, name string) error  { return nil }
 func (c *computeNoOpReset) ResetCircuitBreaker()                                       {}
+func (c *computeNoOpReset) HostMemoryPressure(ctx context.Context) ([]ports.HostPressure, error) {
+	return nil, nil
+}
 
 // TestAdminHandlerResetCircuitBreakers_NopImplementation verifies that when
 // ResetCircuitBreaker is a no-op (backend doesn't support reset), the handler
@@ -169,6 +169,11 @@ func (m *mockIdentityService) ValidateClientCredentials(ctx context.Context, cli
 	return args.String(0), args.Error(1)
 }
 
+func (m *mockIdentityService) IssueServiceAccountToken(ctx context.Context, saID uuid.UUID) (string, error) {
+	args := m.Called(ctx, saID)
+	return args.String(0), args.Error(1)
+}
+
 func (m *mockIdentityService) ValidateAccessToken(ctx context.Context, token string) (*domain.ServiceAccountClaims, error) {
 	args := m.Called(ctx, token)
 	if args.Get(0) == nil {
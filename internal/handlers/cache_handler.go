@@ -6,6 +6,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
 	"github.com/poyrazk/thecloud/internal/core/ports"
 	"github.com/poyrazk/thecloud/internal/errors"
 	"github.com/poyrazk/thecloud/pkg/httputil"
@@ -23,10 +24,23 @@ func NewCacheHandler(svc ports.CacheService) *CacheHandler {
 
 // CreateCacheRequest is the payload for cache creation.
 type CreateCacheRequest struct {
-	Name     string     `json:"name" binding:"required"`
-	Version  string     `json:"version" binding:"required"`
-	MemoryMB int        `json:"memory_mb" binding:"required"`
-	VpcID    *uuid.UUID `json:"vpc_id"`
+	Name            string     `json:"name" binding:"required"`
+	Engine          string     `json:"engine"`
+	Version         string     `json:"version" binding:"required"`
+	MemoryMB        int        `json:"memory_mb" binding:"required"`
+	VpcID           *uuid.UUID `json:"vpc_id"`
+	Persistence     string     `json:"persistence"`
+	Replicas        int        `json:"replicas"`
+	FailoverEnabled bool       `json:"failover_enabled"`
+	ClusterMode     bool       `json:"cluster_mode"`
+	Shards          int        `json:"shards"`
+	MetricsEnabled  bool       `json:"metrics_enabled"`
+}
+
+// ModifyCacheRequest is the payload for resizing or resharding a cache.
+type ModifyCacheRequest struct {
+	MemoryMB *int `json:"memory_mb"`
+	Shards   *int `json:"shards"`
 }
 
 func (h *CacheHandler) Create(c *gin.Context) {
@@ -36,7 +50,19 @@ func (h *CacheHandler) Create(c *gin.Context) {
 		return
 	}
 
-	cache, err := h.svc.CreateCache(c.Request.Context(), req.Name, req.Version, req.MemoryMB, req.VpcID)
+	cache, err := h.svc.CreateCache(c.Request.Context(), ports.CreateCacheRequest{
+		Name:            req.Name,
+		Engine:          domain.CacheEngine(req.Engine),
+		Version:         req.Version,
+		MemoryMB:        req.MemoryMB,
+		VpcID:           req.VpcID,
+		Persistence:     domain.CachePersistence(req.Persistence),
+		Replicas:        req.Replicas,
+		FailoverEnabled: req.FailoverEnabled,
+		ClusterMode:     req.ClusterMode,
+		Shards:          req.Shards,
+		MetricsEnabled:  req.MetricsEnabled,
+	})
 	if err != nil {
 		httputil.Error(c, err)
 		return
@@ -65,6 +91,24 @@ func (h *CacheHandler) Get(c *gin.Context) {
 	httputil.Success(c, http.StatusOK, cache)
 }
 
+func (h *CacheHandler) Modify(c *gin.Context) {
+	var req ModifyCacheRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, err.Error()))
+		return
+	}
+
+	cache, err := h.svc.ModifyCache(c.Request.Context(), c.Param("id"), ports.ModifyCacheRequest{
+		MemoryMB: req.MemoryMB,
+		Shards:   req.Shards,
+	})
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, cache)
+}
+
 func (h *CacheHandler) Delete(c *gin.Context) {
 	idOrName := c.Param("id")
 	if err := h.svc.DeleteCache(c.Request.Context(), idOrName); err != nil {
@@ -102,3 +146,21 @@ func (h *CacheHandler) GetStats(c *gin.Context) {
 	}
 	httputil.Success(c, http.StatusOK, stats)
 }
+
+func (h *CacheHandler) Failover(c *gin.Context) {
+	idOrName := c.Param("id")
+	if err := h.svc.FailoverCache(c.Request.Context(), idOrName); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, gin.H{"message": "cache failover completed"})
+}
+
+func (h *CacheHandler) RotateCredentials(c *gin.Context) {
+	idOrName := c.Param("id")
+	if err := h.svc.RotateCacheCredentials(c.Request.Context(), idOrName); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, gin.H{"message": "cache credentials rotated"})
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/poyrazk/thecloud/internal/core/domain"
 	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -26,8 +27,8 @@ type mockCacheService struct {
 	mock.Mock
 }
 
-func (m *mockCacheService) CreateCache(ctx context.Context, name, version string, memoryMB int, vpcID *uuid.UUID) (*domain.Cache, error) {
-	args := m.Called(ctx, name, version, memoryMB, vpcID)
+func (m *mockCacheService) CreateCache(ctx context.Context, req ports.CreateCacheRequest) (*domain.Cache, error) {
+	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -76,6 +77,18 @@ func (m *mockCacheService) GetCacheStats(ctx context.Context, idOrName string) (
 	return r0, args.Error(1)
 }
 
+func (m *mockCacheService) FailoverCache(ctx context.Context, idOrName string) error {
+	return m.Called(ctx, idOrName).Error(0)
+}
+func (m *mockCacheService) RotateCacheCredentials(ctx context.Context, idOrName string) error {
+	return m.Called(ctx, idOrName).Error(0)
+}
+func (m *mockCacheService) ModifyCache(ctx context.Context, idOrName string, req ports.ModifyCacheRequest) (*domain.Cache, error) {
+	args := m.Called(ctx, idOrName, req)
+	r0, _ := args.Get(0).(*domain.Cache)
+	return r0, args.Error(1)
+}
+
 func setupCacheHandlerTest(_ *testing.T) (*mockCacheService, *CacheHandler, *gin.Engine) {
 	gin.SetMode(gin.TestMode)
 	svc := new(mockCacheService)
@@ -92,12 +105,26 @@ func TestCacheHandlerCreate(t *testing.T) {
 	r.POST(cachesPath, handler.Create)
 
 	cache := &domain.Cache{ID: uuid.New(), Name: testCacheName}
-	svc.On("CreateCache", mock.Anything, testCacheName, "redis6", 128, (*uuid.UUID)(nil)).Return(cache, nil)
+	svc.On("CreateCache", mock.Anything, ports.CreateCacheRequest{
+		Name:            testCacheName,
+		Engine:          domain.EngineValkey,
+		Version:         "redis6",
+		MemoryMB:        128,
+		Persistence:     domain.CachePersistenceAOF,
+		Replicas:        2,
+		FailoverEnabled: true,
+		MetricsEnabled:  true,
+	}).Return(cache, nil)
 
 	body, err := json.Marshal(map[string]interface{}{
-		"name":      testCacheName,
-		"version":   "redis6",
-		"memory_mb": 128,
+		"name":             testCacheName,
+		"engine":           "valkey",
+		"version":          "redis6",
+		"memory_mb":        128,
+		"persistence":      "aof",
+		"replicas":         2,
+		"failover_enabled": true,
+		"metrics_enabled":  true,
 	})
 	require.NoError(t, err)
 	w := httptest.NewRecorder()
@@ -219,6 +246,67 @@ func TestCacheHandlerGetStats(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 }
 
+func TestCacheHandlerFailover(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupCacheHandlerTest(t)
+	defer svc.AssertExpectations(t)
+
+	r.POST(cachesPath+"/:id/failover", handler.Failover)
+
+	id := uuid.New().String()
+	svc.On("FailoverCache", mock.Anything, id).Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, cachesPath+"/"+id+"/failover", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "failover completed")
+}
+
+func TestCacheHandlerRotateCredentials(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupCacheHandlerTest(t)
+	defer svc.AssertExpectations(t)
+
+	r.POST(cachesPath+"/:id/rotate-credentials", handler.RotateCredentials)
+
+	id := uuid.New().String()
+	svc.On("RotateCacheCredentials", mock.Anything, id).Return(nil)
+
+	req := httptest.NewRequest(http.MethodPost, cachesPath+"/"+id+"/rotate-credentials", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "credentials rotated")
+}
+
+func TestCacheHandlerModify(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupCacheHandlerTest(t)
+	defer svc.AssertExpectations(t)
+
+	r.PATCH(cachesPath+"/:id", handler.Modify)
+
+	id := uuid.New().String()
+	cache := &domain.Cache{ID: uuid.MustParse(id), Name: "sessions", ClusterMode: true, Shards: 4, MemoryMB: 512}
+	svc.On("ModifyCache", mock.Anything, id, mock.MatchedBy(func(req ports.ModifyCacheRequest) bool {
+		return req.MemoryMB != nil && *req.MemoryMB == 512 && req.Shards != nil && *req.Shards == 4
+	})).Return(cache, nil)
+
+	body, _ := json.Marshal(map[string]interface{}{"memory_mb": 512, "shards": 4})
+	req := httptest.NewRequest(http.MethodPatch, cachesPath+"/"+id, bytes.NewBuffer(body))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"shards":4`)
+}
+
 func TestCacheHandlerErrors(t *testing.T) {
 	t.Parallel()
 	svc, handler, r := setupCacheHandlerTest(t)
@@ -229,6 +317,8 @@ func TestCacheHandlerErrors(t *testing.T) {
 	r.GET(cachesPath+"/:id/connection", handler.GetConnectionString)
 	r.POST(cachesPath+"/:id/flush", handler.Flush)
 	r.GET(cachesPath+"/:id/stats", handler.GetStats)
+	r.POST(cachesPath+"/:id/failover", handler.Failover)
+	r.PATCH(cachesPath+"/:id", handler.Modify)
 
 	id := "test-id"
 
@@ -240,7 +330,7 @@ func TestCacheHandlerErrors(t *testing.T) {
 	})
 
 	t.Run("CreateService", func(t *testing.T) {
-		svc.On("CreateCache", mock.Anything, "err", mock.Anything, mock.Anything, mock.Anything).Return(nil, assert.AnError)
+		svc.On("CreateCache", mock.Anything, mock.MatchedBy(func(req ports.CreateCacheRequest) bool { return req.Name == "err" })).Return(nil, assert.AnError)
 		body, _ := json.Marshal(map[string]interface{}{"name": "err", "version": "v1", "memory_mb": 64})
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest("POST", cachesPath, bytes.NewBuffer(body))
@@ -295,4 +385,27 @@ func TestCacheHandlerErrors(t *testing.T) {
 		r.ServeHTTP(w, req)
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 	})
+
+	t.Run("Failover", func(t *testing.T) {
+		svc.On("FailoverCache", mock.Anything, id).Return(errors.New(errors.InvalidInput, "cache has no replicas to fail over to"))
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", cachesPath+"/"+id+"/failover", nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("ModifyJSON", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PATCH", cachesPath+"/"+id, bytes.NewBufferString("{invalid}"))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Modify", func(t *testing.T) {
+		svc.On("ModifyCache", mock.Anything, id, mock.Anything).Return(nil, errors.New(errors.InvalidInput, "shards require cluster mode"))
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PATCH", cachesPath+"/"+id, bytes.NewBufferString(`{"shards":4}`))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
 	"github.com/poyrazk/thecloud/internal/core/ports"
 	"github.com/poyrazk/thecloud/internal/errors"
 	"github.com/poyrazk/thecloud/pkg/httputil"
@@ -443,18 +444,25 @@ func isValidBackupSchedule(schedule string) bool {
 
 // NodeGroupRequest is the payload for adding/updating a node group.
 type NodeGroupRequest struct {
-	Name         string `json:"name" binding:"required"`
-	InstanceType string `json:"instance_type"`
-	MinSize      int    `json:"min_size" binding:"gte=0"`
-	MaxSize      int    `json:"max_size" binding:"gte=0"`
-	DesiredSize  int    `json:"desired_size" binding:"gte=0"`
+	Name                          string             `json:"name" binding:"required"`
+	InstanceType                  string             `json:"instance_type"`
+	MinSize                       int                `json:"min_size" binding:"gte=0"`
+	MaxSize                       int                `json:"max_size" binding:"gte=0"`
+	DesiredSize                   int                `json:"desired_size" binding:"gte=0"`
+	ScaleDownUtilizationThreshold float64            `json:"scale_down_utilization_threshold" binding:"gte=0,lte=1"`
+	ScaleDownUnneededSeconds      int                `json:"scale_down_unneeded_seconds" binding:"gte=0"`
+	Labels                        map[string]string  `json:"labels"`
+	Taints                        []domain.NodeTaint `json:"taints"`
+	CapacityType                  string             `json:"capacity_type" example:"preemptible"`
 }
 
 // UpdateNodeGroupRequest is the payload for updating a node group.
 type UpdateNodeGroupRequest struct {
-	MinSize     *int `json:"min_size" binding:"omitempty,gte=0"`
-	MaxSize     *int `json:"max_size" binding:"omitempty,gte=0"`
-	DesiredSize *int `json:"desired_size" binding:"omitempty,gte=0"`
+	MinSize                       *int     `json:"min_size" binding:"omitempty,gte=0"`
+	MaxSize                       *int     `json:"max_size" binding:"omitempty,gte=0"`
+	DesiredSize                   *int     `json:"desired_size" binding:"omitempty,gte=0"`
+	ScaleDownUtilizationThreshold *float64 `json:"scale_down_utilization_threshold" binding:"omitempty,gte=0,lte=1"`
+	ScaleDownUnneededSeconds      *int     `json:"scale_down_unneeded_seconds" binding:"omitempty,gte=0"`
 }
 
 // AddNodeGroup godoc
@@ -484,11 +492,16 @@ func (h *ClusterHandler) AddNodeGroup(c *gin.Context) {
 	}
 
 	ng, err := h.svc.AddNodeGroup(c.Request.Context(), id, ports.NodeGroupParams{
-		Name:         req.Name,
-		InstanceType: req.InstanceType,
-		MinSize:      req.MinSize,
-		MaxSize:      req.MaxSize,
-		DesiredSize:  req.DesiredSize,
+		Name:                          req.Name,
+		InstanceType:                  req.InstanceType,
+		MinSize:                       req.MinSize,
+		MaxSize:                       req.MaxSize,
+		DesiredSize:                   req.DesiredSize,
+		ScaleDownUtilizationThreshold: req.ScaleDownUtilizationThreshold,
+		ScaleDownUnneededSeconds:      req.ScaleDownUnneededSeconds,
+		Labels:                        req.Labels,
+		Taints:                        req.Taints,
+		CapacityType:                  domain.CapacityType(req.CapacityType),
 	})
 	if err != nil {
 		httputil.Error(c, err)
@@ -500,7 +513,7 @@ func (h *ClusterHandler) AddNodeGroup(c *gin.Context) {
 
 // UpdateNodeGroup godoc
 // @Summary Update a node group
-// @Description Modifies scaling boundaries or desired size of a node pool
+// @Description Modifies scaling boundaries, desired size or autoscaler scale-down settings of a node pool
 // @Tags K8s
 // @Security APIKeyAuth
 // @Param id path string true "Cluster ID"
@@ -526,9 +539,11 @@ func (h *ClusterHandler) UpdateNodeGroup(c *gin.Context) {
 	}
 
 	ng, err := h.svc.UpdateNodeGroup(c.Request.Context(), id, name, ports.UpdateNodeGroupParams{
-		MinSize:     req.MinSize,
-		MaxSize:     req.MaxSize,
-		DesiredSize: req.DesiredSize,
+		MinSize:                       req.MinSize,
+		MaxSize:                       req.MaxSize,
+		DesiredSize:                   req.DesiredSize,
+		ScaleDownUtilizationThreshold: req.ScaleDownUtilizationThreshold,
+		ScaleDownUnneededSeconds:      req.ScaleDownUnneededSeconds,
 	})
 	if err != nil {
 		httputil.Error(c, err)
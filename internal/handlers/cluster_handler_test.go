@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -651,6 +652,27 @@ func TestClusterHandlerNodeGroups(t *testing.T) {
 		assert.Equal(t, http.StatusCreated, w.Code)
 	})
 
+	t.Run("AddNodeGroup_Scheduling", func(t *testing.T) {
+		params := ports.NodeGroupParams{
+			Name:         "spot-pool",
+			MaxSize:      4,
+			Labels:       map[string]string{"workload": "batch"},
+			Taints:       []domain.NodeTaint{{Key: "spot", Value: "true", Effect: domain.TaintEffectNoSchedule}},
+			CapacityType: domain.CapacityPreemptible,
+		}
+		expected := &domain.NodeGroup{Name: "spot-pool", ClusterID: clusterID, CapacityType: domain.CapacityPreemptible}
+		svc.On("AddNodeGroup", mock.Anything, clusterID, params).Return(expected, nil).Once()
+
+		body := `{"name":"spot-pool","max_size":4,"labels":{"workload":"batch"},` +
+			`"taints":[{"key":"spot","value":"true","effect":"NoSchedule"}],"capacity_type":"preemptible"}`
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("POST", "/clusters/"+clusterID.String()+"/nodegroups", strings.NewReader(body))
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"capacity_type":"preemptible"`)
+	})
+
 	t.Run("UpdateNodeGroup_Success", func(t *testing.T) {
 		desired := 3
 		params := ports.UpdateNodeGroupParams{
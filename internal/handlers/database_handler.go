@@ -3,9 +3,11 @@ package httphandlers
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
 	"github.com/poyrazk/thecloud/internal/core/ports"
 	"github.com/poyrazk/thecloud/internal/errors"
 	"github.com/poyrazk/thecloud/pkg/httputil"
@@ -34,6 +36,9 @@ type CreateDatabaseRequest struct {
 	MetricsEnabled   bool              `json:"metrics_enabled"`
 	PoolingEnabled   bool              `json:"pooling_enabled"`
 	KmsKeyID         string            `json:"kms_key_id"`
+	// BackupRetentionDays and BackupBucket enable point-in-time recovery.
+	BackupRetentionDays int    `json:"backup_retention_days"`
+	BackupBucket        string `json:"backup_bucket"`
 }
 
 func (h *DatabaseHandler) Create(c *gin.Context) {
@@ -44,15 +49,17 @@ func (h *DatabaseHandler) Create(c *gin.Context) {
 	}
 
 	db, err := h.svc.CreateDatabase(c.Request.Context(), ports.CreateDatabaseRequest{
-		Name:             req.Name,
-		Engine:           req.Engine,
-		Version:          req.Version,
-		VpcID:            req.VpcID,
-		AllocatedStorage: req.AllocatedStorage,
-		Parameters:       req.Parameters,
-		MetricsEnabled:   req.MetricsEnabled,
-		PoolingEnabled:   req.PoolingEnabled,
-		KmsKeyID:         req.KmsKeyID,
+		Name:                req.Name,
+		Engine:              req.Engine,
+		Version:             req.Version,
+		VpcID:               req.VpcID,
+		AllocatedStorage:    req.AllocatedStorage,
+		Parameters:          req.Parameters,
+		MetricsEnabled:      req.MetricsEnabled,
+		PoolingEnabled:      req.PoolingEnabled,
+		KmsKeyID:            req.KmsKeyID,
+		BackupRetentionDays: req.BackupRetentionDays,
+		BackupBucket:        req.BackupBucket,
 	})
 	if err != nil {
 		httputil.Error(c, err)
@@ -181,6 +188,13 @@ type ModifyDatabaseRequest struct {
 	MetricsEnabled   *bool             `json:"metrics_enabled"`
 	PoolingEnabled   *bool             `json:"pooling_enabled"`
 	AllocatedStorage *int              `json:"allocated_storage"`
+	// BackupRetentionDays of 0 disables point-in-time recovery and removes its backups.
+	BackupRetentionDays *int `json:"backup_retention_days"`
+	// MaintenanceWindow is a weekly "ddd:HH:MM" window in UTC, e.g. "sun:03:00".
+	MaintenanceWindow *string `json:"maintenance_window"`
+	// ApplyImmediately restarts the database with new parameters now instead
+	// of in the next maintenance window.
+	ApplyImmediately bool `json:"apply_immediately"`
 }
 
 func (h *DatabaseHandler) Modify(c *gin.Context) {
@@ -197,11 +211,14 @@ func (h *DatabaseHandler) Modify(c *gin.Context) {
 	}
 
 	db, err := h.svc.ModifyDatabase(c.Request.Context(), ports.ModifyDatabaseRequest{
-		ID:               id,
-		Parameters:       req.Parameters,
-		MetricsEnabled:   req.MetricsEnabled,
-		PoolingEnabled:   req.PoolingEnabled,
-		AllocatedStorage: req.AllocatedStorage,
+		ID:                  id,
+		Parameters:          req.Parameters,
+		MetricsEnabled:      req.MetricsEnabled,
+		PoolingEnabled:      req.PoolingEnabled,
+		AllocatedStorage:    req.AllocatedStorage,
+		BackupRetentionDays: req.BackupRetentionDays,
+		MaintenanceWindow:   req.MaintenanceWindow,
+		ApplyImmediately:    req.ApplyImmediately,
 	})
 	if err != nil {
 		httputil.Error(c, err)
@@ -232,17 +249,21 @@ type CreateDatabaseSnapshotRequest struct {
 	Description string `json:"description"`
 }
 
-// RestoreDatabaseRequest is the payload for restoring a database from a snapshot.
+// RestoreDatabaseRequest is the payload for restoring a database from a
+// snapshot, or from the continuous backups of a source database to a point in
+// time when source_database_id and restore_time are set.
 type RestoreDatabaseRequest struct {
-	SnapshotID       uuid.UUID         `json:"snapshot_id" binding:"required"`
+	SnapshotID       uuid.UUID         `json:"snapshot_id"`
 	Name             string            `json:"name" binding:"required"`
-	Engine           string            `json:"engine" binding:"required"`
-	Version          string            `json:"version" binding:"required"`
+	Engine           string            `json:"engine"`
+	Version          string            `json:"version"`
 	VpcID            *uuid.UUID        `json:"vpc_id"`
-	AllocatedStorage int               `json:"allocated_storage" binding:"required"`
+	AllocatedStorage int               `json:"allocated_storage"`
 	Parameters       map[string]string `json:"parameters"`
 	MetricsEnabled   bool              `json:"metrics_enabled"`
 	PoolingEnabled   bool              `json:"pooling_enabled"`
+	SourceDatabaseID *uuid.UUID        `json:"source_database_id"`
+	RestoreTime      *time.Time        `json:"restore_time"`
 }
 
 // CreateSnapshot creates a point-in-time backup of the database.
@@ -303,9 +324,9 @@ func (h *DatabaseHandler) ListSnapshots(ctx *gin.Context) {
 	httputil.Success(ctx, http.StatusOK, snaps)
 }
 
-// Restore provisions a new database from a snapshot.
-// @Summary Restore database from snapshot
-// @Description Creates a new database instance from an existing volume snapshot
+// Restore provisions a new database from a snapshot or to a point in time.
+// @Summary Restore database from snapshot or to a point in time
+// @Description Creates a new database instance from an existing volume snapshot, or from the continuous backups of a source database as it was at restore_time
 // @Tags databases
 // @Accept json
 // @Produce json
@@ -319,6 +340,15 @@ func (h *DatabaseHandler) Restore(c *gin.Context) {
 		httputil.Error(c, errors.New(errors.InvalidInput, err.Error()))
 		return
 	}
+	if req.RestoreTime != nil {
+		if req.SourceDatabaseID == nil {
+			httputil.Error(c, errors.New(errors.InvalidInput, "source_database_id is required with restore_time"))
+			return
+		}
+	} else if req.SnapshotID == uuid.Nil || req.Engine == "" || req.Version == "" || req.AllocatedStorage <= 0 {
+		httputil.Error(c, errors.New(errors.InvalidInput, "snapshot_id, engine, version and allocated_storage are required"))
+		return
+	}
 
 	db, err := h.svc.RestoreDatabase(c.Request.Context(), ports.RestoreDatabaseRequest{
 		SnapshotID:       req.SnapshotID,
@@ -330,6 +360,8 @@ func (h *DatabaseHandler) Restore(c *gin.Context) {
 		Parameters:       req.Parameters,
 		MetricsEnabled:   req.MetricsEnabled,
 		PoolingEnabled:   req.PoolingEnabled,
+		SourceDatabaseID: req.SourceDatabaseID,
+		RestoreTime:      req.RestoreTime,
 	})
 	if err != nil {
 		httputil.Error(c, err)
@@ -369,3 +401,497 @@ func (h *DatabaseHandler) RotateCredentials(c *gin.Context) {
 
 	httputil.Success(c, http.StatusOK, gin.H{"message": "database credentials rotated successfully"})
 }
+
+// DatabaseBackupPolicyRequest is the payload for configuring scheduled backups.
+// Omitted fields take their defaults: a LOGICAL backup every 24 hours in a
+// 60 minute window starting at 03:00 UTC.
+type DatabaseBackupPolicyRequest struct {
+	Method         domain.DatabaseBackupMethod `json:"method" example:"LOGICAL"`
+	WindowStart    string                      `json:"window_start" example:"03:00"`
+	WindowMinutes  int                         `json:"window_minutes"`
+	FrequencyHours int                         `json:"frequency_hours"`
+	RetentionCount int                         `json:"retention_count"`
+	RetentionDays  int                         `json:"retention_days"`
+	ExportBucket   string                      `json:"export_bucket" binding:"required"`
+}
+
+// PutBackupPolicy creates or replaces the scheduled backup policy of a database.
+// @Summary Set database backup policy
+// @Description Schedules automated logical or physical backups exported to a bucket, with count and age based retention
+// @Tags databases
+// @Accept json
+// @Produce json
+// @Security APIKeyAuth
+// @Param id path string true "Database ID"
+// @Param request body DatabaseBackupPolicyRequest true "Backup policy"
+// @Success 200 {object} domain.DatabaseBackupPolicy
+// @Router /databases/{id}/backup-policy [put]
+func (h *DatabaseHandler) PutBackupPolicy(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidDatabaseIDMsg))
+		return
+	}
+
+	var req DatabaseBackupPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, err.Error()))
+		return
+	}
+
+	policy, err := h.svc.PutBackupPolicy(c.Request.Context(), id, ports.BackupPolicyRequest{
+		Method:         req.Method,
+		WindowStart:    req.WindowStart,
+		WindowMinutes:  req.WindowMinutes,
+		FrequencyHours: req.FrequencyHours,
+		RetentionCount: req.RetentionCount,
+		RetentionDays:  req.RetentionDays,
+		ExportBucket:   req.ExportBucket,
+	})
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	httputil.Success(c, http.StatusOK, policy)
+}
+
+// GetBackupPolicy returns the scheduled backup policy of a database.
+// @Summary Get database backup policy
+// @Tags databases
+// @Produce json
+// @Security APIKeyAuth
+// @Param id path string true "Database ID"
+// @Success 200 {object} domain.DatabaseBackupPolicy
+// @Router /databases/{id}/backup-policy [get]
+func (h *DatabaseHandler) GetBackupPolicy(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidDatabaseIDMsg))
+		return
+	}
+
+	policy, err := h.svc.GetBackupPolicy(c.Request.Context(), id)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	httputil.Success(c, http.StatusOK, policy)
+}
+
+// DeleteBackupPolicy stops scheduled backups of a database. Existing backups are kept.
+// @Summary Delete database backup policy
+// @Tags databases
+// @Produce json
+// @Security APIKeyAuth
+// @Param id path string true "Database ID"
+// @Success 200 {object} httputil.Response
+// @Router /databases/{id}/backup-policy [delete]
+func (h *DatabaseHandler) DeleteBackupPolicy(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidDatabaseIDMsg))
+		return
+	}
+
+	if err := h.svc.DeleteBackupPolicy(c.Request.Context(), id); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	httputil.Success(c, http.StatusOK, gin.H{"message": "backup policy deleted"})
+}
+
+// ListBackups returns the scheduled backups of a database, newest first.
+// @Summary List database scheduled backups
+// @Tags databases
+// @Produce json
+// @Security APIKeyAuth
+// @Param id path string true "Database ID"
+// @Success 200 {array} domain.DatabaseAutomatedBackup
+// @Router /databases/{id}/backups [get]
+func (h *DatabaseHandler) ListBackups(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidDatabaseIDMsg))
+		return
+	}
+
+	backups, err := h.svc.ListAutomatedBackups(c.Request.Context(), id)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	httputil.Success(c, http.StatusOK, backups)
+}
+
+// DeleteBackup removes a scheduled backup together with its export and snapshot.
+// @Summary Delete database scheduled backup
+// @Tags databases
+// @Produce json
+// @Security APIKeyAuth
+// @Param id path string true "Database ID"
+// @Param backup_id path string true "Backup ID"
+// @Success 200 {object} httputil.Response
+// @Router /databases/{id}/backups/{backup_id} [delete]
+func (h *DatabaseHandler) DeleteBackup(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidDatabaseIDMsg))
+		return
+	}
+	backupID, err := uuid.Parse(c.Param("backup_id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid backup id"))
+		return
+	}
+
+	if err := h.svc.DeleteAutomatedBackup(c.Request.Context(), id, backupID); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	httputil.Success(c, http.StatusOK, gin.H{"message": "backup deleted"})
+}
+
+// UpgradeDatabaseRequest is the payload for changing a database's engine version.
+type UpgradeDatabaseRequest struct {
+	Version string `json:"version" binding:"required"`
+	// ApplyImmediately upgrades now instead of in the next maintenance window.
+	ApplyImmediately bool `json:"apply_immediately"`
+}
+
+// Upgrade changes the engine version of a database and its replicas.
+// @Summary Upgrade database engine version
+// @Tags databases
+// @Accept json
+// @Produce json
+// @Security APIKeyAuth
+// @Param id path string true "Database ID"
+// @Param request body UpgradeDatabaseRequest true "Target version"
+// @Success 200 {object} domain.Database
+// @Router /databases/{id}/upgrade [post]
+func (h *DatabaseHandler) Upgrade(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidDatabaseIDMsg))
+		return
+	}
+
+	var req UpgradeDatabaseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, err.Error()))
+		return
+	}
+
+	db, err := h.svc.UpgradeDatabase(c.Request.Context(), ports.UpgradeDatabaseRequest{
+		ID:               id,
+		Version:          req.Version,
+		ApplyImmediately: req.ApplyImmediately,
+	})
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	httputil.Success(c, http.StatusOK, db)
+}
+
+// ListUpgrades returns the version upgrade history of a database, newest first.
+// @Summary List database upgrades
+// @Tags databases
+// @Produce json
+// @Security APIKeyAuth
+// @Param id path string true "Database ID"
+// @Success 200 {array} domain.DatabaseUpgrade
+// @Router /databases/{id}/upgrades [get]
+func (h *DatabaseHandler) ListUpgrades(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidDatabaseIDMsg))
+		return
+	}
+
+	upgrades, err := h.svc.ListDatabaseUpgrades(c.Request.Context(), id)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	httputil.Success(c, http.StatusOK, upgrades)
+}
+
+// GetInsights returns the performance insights of a database with metrics enabled.
+// @Summary Get database insights
+// @Description Top queries by total execution time, connections, cache hit ratio, replica lag and their history
+// @Tags databases
+// @Produce json
+// @Security APIKeyAuth
+// @Param id path string true "Database ID"
+// @Param period query string false "History period as a duration, e.g. 6h (default 1h, at most 168h)"
+// @Success 200 {object} domain.DatabaseInsights
+// @Failure 400 {object} httputil.Response
+// @Router /databases/{id}/insights [get]
+func (h *DatabaseHandler) GetInsights(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidDatabaseIDMsg))
+		return
+	}
+
+	var period time.Duration
+	if p := c.Query("period"); p != "" {
+		if period, err = time.ParseDuration(p); err != nil {
+			httputil.Error(c, errors.New(errors.InvalidInput, "invalid period, use a duration such as 1h or 30m"))
+			return
+		}
+	}
+
+	insights, err := h.svc.GetDatabaseInsights(c.Request.Context(), id, period)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	httputil.Success(c, http.StatusOK, insights)
+}
+
+// CreateLogicalDatabaseRequest is the payload for adding a database inside a database instance.
+type CreateLogicalDatabaseRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// CreateLogicalDatabase creates an additional database inside a database instance.
+// @Summary Create logical database
+// @Tags databases
+// @Accept json
+// @Produce json
+// @Security APIKeyAuth
+// @Param id path string true "Database ID"
+// @Param request body CreateLogicalDatabaseRequest true "Database name"
+// @Success 201 {object} domain.LogicalDatabase
+// @Router /databases/{id}/logical-databases [post]
+func (h *DatabaseHandler) CreateLogicalDatabase(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidDatabaseIDMsg))
+		return
+	}
+
+	var req CreateLogicalDatabaseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, err.Error()))
+		return
+	}
+
+	ldb, err := h.svc.CreateLogicalDatabase(c.Request.Context(), id, req.Name)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	httputil.Success(c, http.StatusCreated, ldb)
+}
+
+// ListLogicalDatabases returns the additional databases of a database instance.
+// @Summary List logical databases
+// @Tags databases
+// @Produce json
+// @Security APIKeyAuth
+// @Param id path string true "Database ID"
+// @Success 200 {array} domain.LogicalDatabase
+// @Router /databases/{id}/logical-databases [get]
+func (h *DatabaseHandler) ListLogicalDatabases(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidDatabaseIDMsg))
+		return
+	}
+
+	dbs, err := h.svc.ListLogicalDatabases(c.Request.Context(), id)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	httputil.Success(c, http.StatusOK, dbs)
+}
+
+// DeleteLogicalDatabase drops a logical database and the grants on it.
+// @Summary Delete logical database
+// @Tags databases
+// @Produce json
+// @Security APIKeyAuth
+// @Param id path string true "Database ID"
+// @Param name path string true "Logical database name"
+// @Success 200 {object} httputil.Response
+// @Router /databases/{id}/logical-databases/{name} [delete]
+func (h *DatabaseHandler) DeleteLogicalDatabase(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidDatabaseIDMsg))
+		return
+	}
+
+	if err := h.svc.DeleteLogicalDatabase(c.Request.Context(), id, c.Param("name")); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	httputil.Success(c, http.StatusOK, gin.H{"message": "logical database deleted"})
+}
+
+// CreateDatabaseUserRequest is the payload for adding a login user to a database.
+type CreateDatabaseUserRequest struct {
+	Username string                 `json:"username" binding:"required"`
+	Grants   []domain.DatabaseGrant `json:"grants"`
+}
+
+// SetDatabaseUserGrantsRequest replaces the grants of a database user.
+type SetDatabaseUserGrantsRequest struct {
+	Grants []domain.DatabaseGrant `json:"grants"`
+}
+
+// CreateUser creates a login user with a generated password, returned only in this response.
+// @Summary Create database user
+// @Tags databases
+// @Accept json
+// @Produce json
+// @Security APIKeyAuth
+// @Param id path string true "Database ID"
+// @Param request body CreateDatabaseUserRequest true "User and grants"
+// @Success 201 {object} domain.DatabaseUser
+// @Router /databases/{id}/users [post]
+func (h *DatabaseHandler) CreateUser(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidDatabaseIDMsg))
+		return
+	}
+
+	var req CreateDatabaseUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, err.Error()))
+		return
+	}
+
+	user, err := h.svc.CreateDatabaseUser(c.Request.Context(), ports.CreateDatabaseUserRequest{
+		DatabaseID: id,
+		Username:   req.Username,
+		Grants:     req.Grants,
+	})
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	httputil.Success(c, http.StatusCreated, user)
+}
+
+// ListUsers returns the additional users of a database with their grants.
+// @Summary List database users
+// @Tags databases
+// @Produce json
+// @Security APIKeyAuth
+// @Param id path string true "Database ID"
+// @Success 200 {array} domain.DatabaseUser
+// @Router /databases/{id}/users [get]
+func (h *DatabaseHandler) ListUsers(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidDatabaseIDMsg))
+		return
+	}
+
+	users, err := h.svc.ListDatabaseUsers(c.Request.Context(), id)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	httputil.Success(c, http.StatusOK, users)
+}
+
+// SetUserGrants replaces the grants of a database user.
+// @Summary Set database user grants
+// @Tags databases
+// @Accept json
+// @Produce json
+// @Security APIKeyAuth
+// @Param id path string true "Database ID"
+// @Param username path string true "Username"
+// @Param request body SetDatabaseUserGrantsRequest true "Grants"
+// @Success 200 {object} domain.DatabaseUser
+// @Router /databases/{id}/users/{username}/grants [put]
+func (h *DatabaseHandler) SetUserGrants(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidDatabaseIDMsg))
+		return
+	}
+
+	var req SetDatabaseUserGrantsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, err.Error()))
+		return
+	}
+
+	user, err := h.svc.SetDatabaseUserGrants(c.Request.Context(), id, c.Param("username"), req.Grants)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	httputil.Success(c, http.StatusOK, user)
+}
+
+// RotateUserCredentials sets a new password for a database user and returns it.
+// @Summary Rotate database user credentials
+// @Tags databases
+// @Produce json
+// @Security APIKeyAuth
+// @Param id path string true "Database ID"
+// @Param username path string true "Username"
+// @Success 200 {object} domain.DatabaseUser
+// @Router /databases/{id}/users/{username}/rotate-credentials [post]
+func (h *DatabaseHandler) RotateUserCredentials(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidDatabaseIDMsg))
+		return
+	}
+
+	user, err := h.svc.RotateDatabaseUserCredentials(c.Request.Context(), id, c.Param("username"))
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	httputil.Success(c, http.StatusOK, user)
+}
+
+// DeleteUser drops a database user and removes its credentials.
+// @Summary Delete database user
+// @Tags databases
+// @Produce json
+// @Security APIKeyAuth
+// @Param id path string true "Database ID"
+// @Param username path string true "Username"
+// @Success 200 {object} httputil.Response
+// @Router /databases/{id}/users/{username} [delete]
+func (h *DatabaseHandler) DeleteUser(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidDatabaseIDMsg))
+		return
+	}
+
+	if err := h.svc.DeleteDatabaseUser(c.Request.Context(), id, c.Param("username")); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	httputil.Success(c, http.StatusOK, gin.H{"message": "database user deleted"})
+}
@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -104,6 +105,120 @@ func (m *mockDatabaseService) RestoreDatabase(ctx context.Context, req ports.Res
 	}
 	return args.Get(0).(*domain.Database), args.Error(1)
 }
+func (m *mockDatabaseService) ArchiveDatabaseLogs(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+func (m *mockDatabaseService) PutBackupPolicy(ctx context.Context, databaseID uuid.UUID, req ports.BackupPolicyRequest) (*domain.DatabaseBackupPolicy, error) {
+	args := m.Called(ctx, databaseID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DatabaseBackupPolicy), args.Error(1)
+}
+func (m *mockDatabaseService) GetBackupPolicy(ctx context.Context, databaseID uuid.UUID) (*domain.DatabaseBackupPolicy, error) {
+	args := m.Called(ctx, databaseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DatabaseBackupPolicy), args.Error(1)
+}
+func (m *mockDatabaseService) DeleteBackupPolicy(ctx context.Context, databaseID uuid.UUID) error {
+	args := m.Called(ctx, databaseID)
+	return args.Error(0)
+}
+func (m *mockDatabaseService) ListAutomatedBackups(ctx context.Context, databaseID uuid.UUID) ([]*domain.DatabaseAutomatedBackup, error) {
+	args := m.Called(ctx, databaseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.DatabaseAutomatedBackup), args.Error(1)
+}
+func (m *mockDatabaseService) DeleteAutomatedBackup(ctx context.Context, databaseID, backupID uuid.UUID) error {
+	args := m.Called(ctx, databaseID, backupID)
+	return args.Error(0)
+}
+func (m *mockDatabaseService) RunScheduledBackups(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+func (m *mockDatabaseService) UpgradeDatabase(ctx context.Context, req ports.UpgradeDatabaseRequest) (*domain.Database, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Database), args.Error(1)
+}
+func (m *mockDatabaseService) ListDatabaseUpgrades(ctx context.Context, databaseID uuid.UUID) ([]*domain.DatabaseUpgrade, error) {
+	args := m.Called(ctx, databaseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.DatabaseUpgrade), args.Error(1)
+}
+func (m *mockDatabaseService) RunMaintenance(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+func (m *mockDatabaseService) CreateLogicalDatabase(ctx context.Context, databaseID uuid.UUID, name string) (*domain.LogicalDatabase, error) {
+	args := m.Called(ctx, databaseID, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.LogicalDatabase), args.Error(1)
+}
+func (m *mockDatabaseService) ListLogicalDatabases(ctx context.Context, databaseID uuid.UUID) ([]*domain.LogicalDatabase, error) {
+	args := m.Called(ctx, databaseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.LogicalDatabase), args.Error(1)
+}
+func (m *mockDatabaseService) DeleteLogicalDatabase(ctx context.Context, databaseID uuid.UUID, name string) error {
+	return m.Called(ctx, databaseID, name).Error(0)
+}
+func (m *mockDatabaseService) CreateDatabaseUser(ctx context.Context, req ports.CreateDatabaseUserRequest) (*domain.DatabaseUser, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DatabaseUser), args.Error(1)
+}
+func (m *mockDatabaseService) ListDatabaseUsers(ctx context.Context, databaseID uuid.UUID) ([]*domain.DatabaseUser, error) {
+	args := m.Called(ctx, databaseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.DatabaseUser), args.Error(1)
+}
+func (m *mockDatabaseService) SetDatabaseUserGrants(ctx context.Context, databaseID uuid.UUID, username string, grants []domain.DatabaseGrant) (*domain.DatabaseUser, error) {
+	args := m.Called(ctx, databaseID, username, grants)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DatabaseUser), args.Error(1)
+}
+func (m *mockDatabaseService) RotateDatabaseUserCredentials(ctx context.Context, databaseID uuid.UUID, username string) (*domain.DatabaseUser, error) {
+	args := m.Called(ctx, databaseID, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DatabaseUser), args.Error(1)
+}
+func (m *mockDatabaseService) DeleteDatabaseUser(ctx context.Context, databaseID uuid.UUID, username string) error {
+	return m.Called(ctx, databaseID, username).Error(0)
+}
+func (m *mockDatabaseService) GetDatabaseInsights(ctx context.Context, databaseID uuid.UUID, period time.Duration) (*domain.DatabaseInsights, error) {
+	args := m.Called(ctx, databaseID, period)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DatabaseInsights), args.Error(1)
+}
+func (m *mockDatabaseService) CollectDatabaseInsights(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
 func (m *mockDatabaseService) ModifyDatabase(ctx context.Context, req ports.ModifyDatabaseRequest) (*domain.Database, error) {
 	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
@@ -607,6 +722,320 @@ func TestDatabaseHandlerRestore(t *testing.T) {
 	assert.Equal(t, http.StatusCreated, w.Code)
 }
 
+func TestDatabaseHandlerRestoreToPointInTime(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupDatabaseHandlerTest(t)
+	defer svc.AssertExpectations(t)
+
+	r.POST("/databases/restore", handler.Restore)
+
+	sourceID := uuid.New()
+	restoreTime := time.Date(2026, 10, 18, 14, 30, 0, 0, time.UTC)
+	svc.On("RestoreDatabase", mock.Anything, mock.MatchedBy(func(req ports.RestoreDatabaseRequest) bool {
+		return req.SourceDatabaseID != nil && *req.SourceDatabaseID == sourceID &&
+			req.RestoreTime != nil && req.RestoreTime.Equal(restoreTime) && req.SnapshotID == uuid.Nil
+	})).Return(&domain.Database{ID: uuid.New(), Name: "restored-db"}, nil)
+
+	body, err := json.Marshal(map[string]interface{}{
+		"name":               "restored-db",
+		"source_database_id": sourceID,
+		"restore_time":       restoreTime,
+	})
+	require.NoError(t, err)
+	w := httptest.NewRecorder()
+	req, err := http.NewRequest("POST", "/databases/restore", bytes.NewBuffer(body))
+	require.NoError(t, err)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestDatabaseHandlerRestoreValidation(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupDatabaseHandlerTest(t)
+	r.POST("/databases/restore", handler.Restore)
+
+	for name, body := range map[string]string{
+		"MissingSnapshot":     `{"name":"restored-db","engine":"postgres","version":"15","allocated_storage":20}`,
+		"TimeWithoutSource":   `{"name":"restored-db","restore_time":"2026-10-18T14:30:00Z"}`,
+		"MissingSnapshotSize": `{"name":"restored-db","snapshot_id":"` + uuid.NewString() + `","engine":"postgres","version":"15"}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, err := http.NewRequest("POST", "/databases/restore", bytes.NewBufferString(body))
+			require.NoError(t, err)
+			r.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+	svc.AssertNotCalled(t, "RestoreDatabase", mock.Anything, mock.Anything)
+}
+
+func TestDatabaseHandlerPutBackupPolicy(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupDatabaseHandlerTest(t)
+	defer svc.AssertExpectations(t)
+
+	r.PUT(databasesPath+"/:id/backup-policy", handler.PutBackupPolicy)
+
+	id := uuid.New()
+	svc.On("PutBackupPolicy", mock.Anything, id, ports.BackupPolicyRequest{
+		Method:         domain.DatabaseBackupPhysical,
+		WindowStart:    "01:30",
+		RetentionCount: 7,
+		ExportBucket:   "db-exports",
+	}).Return(&domain.DatabaseBackupPolicy{DatabaseID: id, Method: domain.DatabaseBackupPhysical}, nil)
+
+	body := `{"method":"PHYSICAL","window_start":"01:30","retention_count":7,"export_bucket":"db-exports"}`
+	w := httptest.NewRecorder()
+	req, err := http.NewRequest("PUT", databasesPath+"/"+id.String()+"/backup-policy", bytes.NewBufferString(body))
+	require.NoError(t, err)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "PHYSICAL")
+}
+
+func TestDatabaseHandlerPutBackupPolicyRequiresBucket(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupDatabaseHandlerTest(t)
+	r.PUT(databasesPath+"/:id/backup-policy", handler.PutBackupPolicy)
+
+	w := httptest.NewRecorder()
+	req, err := http.NewRequest("PUT", databasesPath+"/"+uuid.NewString()+"/backup-policy", bytes.NewBufferString(`{"retention_count":7}`))
+	require.NoError(t, err)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	svc.AssertNotCalled(t, "PutBackupPolicy", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDatabaseHandlerBackupPolicyGetAndDelete(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupDatabaseHandlerTest(t)
+	defer svc.AssertExpectations(t)
+
+	r.GET(databasesPath+"/:id/backup-policy", handler.GetBackupPolicy)
+	r.DELETE(databasesPath+"/:id/backup-policy", handler.DeleteBackupPolicy)
+
+	id := uuid.New()
+	svc.On("GetBackupPolicy", mock.Anything, id).Return(&domain.DatabaseBackupPolicy{DatabaseID: id, ExportBucket: "db-exports"}, nil)
+	svc.On("DeleteBackupPolicy", mock.Anything, id).Return(nil)
+
+	w := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", databasesPath+"/"+id.String()+"/backup-policy", nil)
+	require.NoError(t, err)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "db-exports")
+
+	w = httptest.NewRecorder()
+	req, err = http.NewRequest("DELETE", databasesPath+"/"+id.String()+"/backup-policy", nil)
+	require.NoError(t, err)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestDatabaseHandlerBackups(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupDatabaseHandlerTest(t)
+	defer svc.AssertExpectations(t)
+
+	r.GET(databasesPath+"/:id/backups", handler.ListBackups)
+	r.DELETE(databasesPath+"/:id/backups/:backup_id", handler.DeleteBackup)
+
+	id := uuid.New()
+	backupID := uuid.New()
+	svc.On("ListAutomatedBackups", mock.Anything, id).Return([]*domain.DatabaseAutomatedBackup{
+		{ID: backupID, DatabaseID: id, Status: domain.AutomatedBackupCompleted},
+	}, nil)
+	svc.On("DeleteAutomatedBackup", mock.Anything, id, backupID).Return(nil)
+
+	w := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", databasesPath+"/"+id.String()+"/backups", nil)
+	require.NoError(t, err)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), backupID.String())
+
+	w = httptest.NewRecorder()
+	req, err = http.NewRequest("DELETE", databasesPath+"/"+id.String()+"/backups/"+backupID.String(), nil)
+	require.NoError(t, err)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	req, err = http.NewRequest("DELETE", databasesPath+"/"+id.String()+"/backups/not-a-uuid", nil)
+	require.NoError(t, err)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestDatabaseHandlerUpgrade(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupDatabaseHandlerTest(t)
+	defer svc.AssertExpectations(t)
+
+	r.POST(databasesPath+"/:id/upgrade", handler.Upgrade)
+	r.GET(databasesPath+"/:id/upgrades", handler.ListUpgrades)
+
+	id := uuid.New()
+	svc.On("UpgradeDatabase", mock.Anything, ports.UpgradeDatabaseRequest{
+		ID:               id,
+		Version:          "16",
+		ApplyImmediately: true,
+	}).Return(&domain.Database{ID: id, Version: "16"}, nil)
+	svc.On("ListDatabaseUpgrades", mock.Anything, id).Return([]*domain.DatabaseUpgrade{
+		{DatabaseID: id, FromVersion: "15", ToVersion: "16", Major: true, Status: domain.DatabaseUpgradeCompleted},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	req, err := http.NewRequest("POST", databasesPath+"/"+id.String()+"/upgrade", bytes.NewBufferString(`{"version":"16","apply_immediately":true}`))
+	require.NoError(t, err)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	req, err = http.NewRequest("GET", databasesPath+"/"+id.String()+"/upgrades", nil)
+	require.NoError(t, err)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "COMPLETED")
+}
+
+func TestDatabaseHandlerUpgradeRequiresVersion(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupDatabaseHandlerTest(t)
+	r.POST(databasesPath+"/:id/upgrade", handler.Upgrade)
+
+	w := httptest.NewRecorder()
+	req, err := http.NewRequest("POST", databasesPath+"/"+uuid.NewString()+"/upgrade", bytes.NewBufferString(`{"apply_immediately":true}`))
+	require.NoError(t, err)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	svc.AssertNotCalled(t, "UpgradeDatabase", mock.Anything, mock.Anything)
+}
+
+func TestDatabaseHandlerGetInsights(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupDatabaseHandlerTest(t)
+	defer svc.AssertExpectations(t)
+
+	r.GET(databasesPath+"/:id/insights", handler.GetInsights)
+
+	id := uuid.New()
+	svc.On("GetDatabaseInsights", mock.Anything, id, time.Duration(0)).
+		Return(&domain.DatabaseInsights{DatabaseID: id, Connections: 5, TopQueries: []domain.DatabaseQueryStat{{Query: "SELECT 1"}}}, nil).Once()
+	svc.On("GetDatabaseInsights", mock.Anything, id, 6*time.Hour).
+		Return(&domain.DatabaseInsights{DatabaseID: id}, nil).Once()
+
+	w := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", databasesPath+"/"+id.String()+"/insights", nil)
+	require.NoError(t, err)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "SELECT 1")
+
+	w = httptest.NewRecorder()
+	req, err = http.NewRequest("GET", databasesPath+"/"+id.String()+"/insights?period=6h", nil)
+	require.NoError(t, err)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = httptest.NewRecorder()
+	req, err = http.NewRequest("GET", databasesPath+"/"+id.String()+"/insights?period=yesterday", nil)
+	require.NoError(t, err)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestDatabaseHandlerUsers(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupDatabaseHandlerTest(t)
+	defer svc.AssertExpectations(t)
+
+	r.POST(databasesPath+"/:id/users", handler.CreateUser)
+	r.GET(databasesPath+"/:id/users", handler.ListUsers)
+	r.PUT(databasesPath+"/:id/users/:username/grants", handler.SetUserGrants)
+	r.POST(databasesPath+"/:id/users/:username/rotate-credentials", handler.RotateUserCredentials)
+	r.DELETE(databasesPath+"/:id/users/:username", handler.DeleteUser)
+
+	id := uuid.New()
+	readOnly := []domain.DatabaseGrant{{Database: "app", Privilege: domain.DatabasePrivilegeReadOnly}}
+	svc.On("CreateDatabaseUser", mock.Anything, ports.CreateDatabaseUserRequest{
+		DatabaseID: id, Username: "app_ro", Grants: readOnly,
+	}).Return(&domain.DatabaseUser{Username: "app_ro", Password: "s3cret", Grants: readOnly}, nil)
+	svc.On("ListDatabaseUsers", mock.Anything, id).Return([]*domain.DatabaseUser{{Username: "app_ro", Grants: readOnly}}, nil)
+	svc.On("SetDatabaseUserGrants", mock.Anything, id, "app_ro", []domain.DatabaseGrant{{Database: "app", Privilege: domain.DatabasePrivilegeReadWrite}}).
+		Return(&domain.DatabaseUser{Username: "app_ro"}, nil)
+	svc.On("RotateDatabaseUserCredentials", mock.Anything, id, "app_ro").Return(&domain.DatabaseUser{Username: "app_ro", Password: "n3w"}, nil)
+	svc.On("DeleteDatabaseUser", mock.Anything, id, "app_ro").Return(nil)
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, err := http.NewRequest(method, databasesPath+"/"+id.String()+path, bytes.NewBufferString(body))
+		require.NoError(t, err)
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := serve("POST", "/users", `{"username":"app_ro","grants":[{"database":"app","privilege":"READ_ONLY"}]}`)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), "s3cret")
+
+	w = serve("GET", "/users", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "READ_ONLY")
+
+	w = serve("PUT", "/users/app_ro/grants", `{"grants":[{"database":"app","privilege":"READ_WRITE"}]}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = serve("POST", "/users/app_ro/rotate-credentials", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "n3w")
+
+	w = serve("DELETE", "/users/app_ro", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = serve("POST", "/users", `{"grants":[]}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestDatabaseHandlerLogicalDatabases(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupDatabaseHandlerTest(t)
+	defer svc.AssertExpectations(t)
+
+	r.POST(databasesPath+"/:id/logical-databases", handler.CreateLogicalDatabase)
+	r.GET(databasesPath+"/:id/logical-databases", handler.ListLogicalDatabases)
+	r.DELETE(databasesPath+"/:id/logical-databases/:name", handler.DeleteLogicalDatabase)
+
+	id := uuid.New()
+	svc.On("CreateLogicalDatabase", mock.Anything, id, "reporting").Return(&domain.LogicalDatabase{DatabaseID: id, Name: "reporting"}, nil)
+	svc.On("ListLogicalDatabases", mock.Anything, id).Return([]*domain.LogicalDatabase{{DatabaseID: id, Name: "reporting"}}, nil)
+	svc.On("DeleteLogicalDatabase", mock.Anything, id, "reporting").Return(nil)
+
+	w := httptest.NewRecorder()
+	req, err := http.NewRequest("POST", databasesPath+"/"+id.String()+"/logical-databases", bytes.NewBufferString(`{"name":"reporting"}`))
+	require.NoError(t, err)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	w = httptest.NewRecorder()
+	req, err = http.NewRequest("GET", databasesPath+"/"+id.String()+"/logical-databases", nil)
+	require.NoError(t, err)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "reporting")
+
+	w = httptest.NewRecorder()
+	req, err = http.NewRequest("DELETE", databasesPath+"/"+id.String()+"/logical-databases/reporting", nil)
+	require.NoError(t, err)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
 func TestDatabaseHandlerRotateCredentials(t *testing.T) {
 	t.Parallel()
 	svc, _, r := setupDatabaseHandlerTest(t)
@@ -0,0 +1,187 @@
+package httphandlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/poyrazk/thecloud/pkg/httputil"
+)
+
+const (
+	invalidDHCPOptionsIDMsg = "invalid DHCP options id"
+	invalidVPCIDMsg         = "invalid vpc id"
+)
+
+// DHCPOptionsHandler handles HTTP requests for VPC DHCP options sets.
+type DHCPOptionsHandler struct {
+	svc ports.DHCPOptionsService
+}
+
+// NewDHCPOptionsHandler creates a new DHCPOptionsHandler.
+func NewDHCPOptionsHandler(svc ports.DHCPOptionsService) *DHCPOptionsHandler {
+	return &DHCPOptionsHandler{svc: svc}
+}
+
+// CreateDHCPOptionsRequest represents the body for creating a DHCP options set.
+type CreateDHCPOptionsRequest struct {
+	Name              string   `json:"name" binding:"required"`
+	DomainName        string   `json:"domain_name"`
+	DomainNameServers []string `json:"domain_name_servers"`
+	NTPServers        []string `json:"ntp_servers"`
+}
+
+// DHCPOptionsAssociationRequest represents the body for associating a VPC with a DHCP options set.
+type DHCPOptionsAssociationRequest struct {
+	DHCPOptionsID string `json:"dhcp_options_id" binding:"required,uuid"`
+}
+
+// Create creates a DHCP options set.
+// @Summary Create DHCP Options Set
+// @Tags vpcs
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param request body CreateDHCPOptionsRequest true "DHCP Options Request"
+// @Success 201 {object} domain.DHCPOptionsSet
+// @Failure 400 {object} httputil.Response
+// @Router /dhcp-options [post]
+func (h *DHCPOptionsHandler) Create(c *gin.Context) {
+	var req CreateDHCPOptionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid request body"))
+		return
+	}
+
+	opts, err := h.svc.CreateDHCPOptions(c.Request.Context(), &domain.DHCPOptionsSet{
+		Name:              req.Name,
+		DomainName:        req.DomainName,
+		DomainNameServers: req.DomainNameServers,
+		NTPServers:        req.NTPServers,
+	})
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusCreated, opts)
+}
+
+// List returns the DHCP options sets of the current tenant.
+// @Summary List DHCP Options Sets
+// @Tags vpcs
+// @Security APIKeyAuth
+// @Produce json
+// @Success 200 {array} domain.DHCPOptionsSet
+// @Router /dhcp-options [get]
+func (h *DHCPOptionsHandler) List(c *gin.Context) {
+	sets, err := h.svc.ListDHCPOptions(c.Request.Context())
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, sets)
+}
+
+// Get retrieves a DHCP options set.
+// @Summary Get DHCP Options Set
+// @Tags vpcs
+// @Security APIKeyAuth
+// @Produce json
+// @Param id path string true "DHCP Options ID"
+// @Success 200 {object} domain.DHCPOptionsSet
+// @Failure 404 {object} httputil.Response
+// @Router /dhcp-options/{id} [get]
+func (h *DHCPOptionsHandler) Get(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidDHCPOptionsIDMsg))
+		return
+	}
+
+	opts, err := h.svc.GetDHCPOptions(c.Request.Context(), id)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, opts)
+}
+
+// Delete removes a DHCP options set that is not associated with any VPC.
+// @Summary Delete DHCP Options Set
+// @Tags vpcs
+// @Security APIKeyAuth
+// @Param id path string true "DHCP Options ID"
+// @Success 204
+// @Failure 404 {object} httputil.Response
+// @Failure 409 {object} httputil.Response
+// @Router /dhcp-options/{id} [delete]
+func (h *DHCPOptionsHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidDHCPOptionsIDMsg))
+		return
+	}
+
+	if err := h.svc.DeleteDHCPOptions(c.Request.Context(), id); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Associate applies a DHCP options set to a VPC, replacing its previous one.
+// @Summary Associate DHCP Options With VPC
+// @Tags vpcs
+// @Security APIKeyAuth
+// @Accept json
+// @Param id path string true "VPC ID"
+// @Param request body DHCPOptionsAssociationRequest true "Association Request"
+// @Success 204
+// @Failure 400 {object} httputil.Response
+// @Failure 404 {object} httputil.Response
+// @Router /vpcs/{id}/dhcp-options [put]
+func (h *DHCPOptionsHandler) Associate(c *gin.Context) {
+	vpcID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidVPCIDMsg))
+		return
+	}
+
+	var req DHCPOptionsAssociationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid request body"))
+		return
+	}
+
+	optionsID, _ := uuid.Parse(req.DHCPOptionsID)
+	if err := h.svc.AssociateVPC(c.Request.Context(), optionsID, vpcID); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Disassociate reverts a VPC to the default DHCP options.
+// @Summary Disassociate DHCP Options From VPC
+// @Tags vpcs
+// @Security APIKeyAuth
+// @Param id path string true "VPC ID"
+// @Success 204
+// @Failure 404 {object} httputil.Response
+// @Router /vpcs/{id}/dhcp-options [delete]
+func (h *DHCPOptionsHandler) Disassociate(c *gin.Context) {
+	vpcID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidVPCIDMsg))
+		return
+	}
+
+	if err := h.svc.DisassociateVPC(c.Request.Context(), vpcID); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,127 @@
+package httphandlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockDHCPOptionsService struct {
+	mock.Mock
+}
+
+func (m *mockDHCPOptionsService) CreateDHCPOptions(ctx context.Context, opts *domain.DHCPOptionsSet) (*domain.DHCPOptionsSet, error) {
+	args := m.Called(ctx, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DHCPOptionsSet), args.Error(1)
+}
+
+func (m *mockDHCPOptionsService) GetDHCPOptions(ctx context.Context, id uuid.UUID) (*domain.DHCPOptionsSet, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DHCPOptionsSet), args.Error(1)
+}
+
+func (m *mockDHCPOptionsService) ListDHCPOptions(ctx context.Context) ([]*domain.DHCPOptionsSet, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.DHCPOptionsSet), args.Error(1)
+}
+
+func (m *mockDHCPOptionsService) DeleteDHCPOptions(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *mockDHCPOptionsService) AssociateVPC(ctx context.Context, optionsID, vpcID uuid.UUID) error {
+	return m.Called(ctx, optionsID, vpcID).Error(0)
+}
+
+func (m *mockDHCPOptionsService) DisassociateVPC(ctx context.Context, vpcID uuid.UUID) error {
+	return m.Called(ctx, vpcID).Error(0)
+}
+
+const dhcpOptionsPath = "/dhcp-options"
+
+func setupDHCPOptionsHandlerTest() (*mockDHCPOptionsService, *DHCPOptionsHandler, *gin.Engine) {
+	gin.SetMode(gin.TestMode)
+	svc := new(mockDHCPOptionsService)
+	handler := NewDHCPOptionsHandler(svc)
+	r := gin.New()
+	return svc, handler, r
+}
+
+func TestDHCPOptionsHandlerCreate(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupDHCPOptionsHandlerTest()
+	r.POST(dhcpOptionsPath, handler.Create)
+
+	svc.On("CreateDHCPOptions", mock.Anything, mock.MatchedBy(func(opts *domain.DHCPOptionsSet) bool {
+		return opts.Name == "corp" && opts.DomainName == "corp.internal" && len(opts.NTPServers) == 1
+	})).Return(&domain.DHCPOptionsSet{ID: uuid.New(), Name: "corp"}, nil).Once()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, dhcpOptionsPath, bytes.NewBufferString(`{"name":"corp","domain_name":"corp.internal","ntp_servers":["10.0.0.123"]}`))
+	req.Header.Set(contentType, applicationJSON)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	svc.AssertExpectations(t)
+}
+
+func TestDHCPOptionsHandlerDeleteInUse(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupDHCPOptionsHandlerTest()
+	r.DELETE(dhcpOptionsPath+"/:id", handler.Delete)
+
+	id := uuid.New()
+	svc.On("DeleteDHCPOptions", mock.Anything, id).Return(errors.New(errors.Conflict, "DHCP options set is still associated with VPCs")).Once()
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, dhcpOptionsPath+"/"+id.String(), nil))
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestDHCPOptionsHandlerAssociate(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupDHCPOptionsHandlerTest()
+	r.PUT("/vpcs/:id/dhcp-options", handler.Associate)
+
+	vpcID, optsID := uuid.New(), uuid.New()
+	svc.On("AssociateVPC", mock.Anything, optsID, vpcID).Return(nil).Once()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/vpcs/"+vpcID.String()+"/dhcp-options", bytes.NewBufferString(`{"dhcp_options_id":"`+optsID.String()+`"}`))
+	req.Header.Set(contentType, applicationJSON)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	svc.AssertExpectations(t)
+}
+
+func TestDHCPOptionsHandlerDisassociateInvalidVPC(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupDHCPOptionsHandlerTest()
+	r.DELETE("/vpcs/:id/dhcp-options", handler.Disassociate)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/vpcs/not-a-uuid/dhcp-options", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	svc.AssertNotCalled(t, "DisassociateVPC", mock.Anything, mock.Anything)
+}
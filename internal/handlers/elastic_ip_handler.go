@@ -18,9 +18,12 @@ type ElasticIPHandler struct {
 	svc ports.ElasticIPService
 }
 
-// AssociateIPRequest represents the body for associating an EIP.
+// AssociateIPRequest represents the body for associating an EIP with either an
+// instance or a private IP of a network interface.
 type AssociateIPRequest struct {
-	InstanceID string `json:"instance_id" validate:"required" binding:"required,uuid"`
+	InstanceID         string `json:"instance_id" binding:"omitempty,uuid"`
+	NetworkInterfaceID string `json:"network_interface_id" binding:"omitempty,uuid"`
+	PrivateIP          string `json:"private_ip" binding:"omitempty,ipv4"`
 }
 
 // NewElasticIPHandler creates a new ElasticIPHandler.
@@ -116,7 +119,7 @@ func (h *ElasticIPHandler) Release(c *gin.Context) {
 	httputil.Success(c, http.StatusOK, gin.H{"message": "elastic ip released"})
 }
 
-// Associate maps an Elastic IP to an instance.
+// Associate maps an Elastic IP to an instance or to a private IP of a network interface.
 // @Summary Associate Elastic IP
 // @Tags elastic-ips
 // @Security APIKeyAuth
@@ -141,6 +144,20 @@ func (h *ElasticIPHandler) Associate(c *gin.Context) {
 		httputil.Error(c, errors.New(errors.InvalidInput, "invalid instance_id"))
 		return
 	}
+	if (req.InstanceID == "") == (req.NetworkInterfaceID == "") {
+		httputil.Error(c, errors.New(errors.InvalidInput, "exactly one of instance_id or network_interface_id is required"))
+		return
+	}
+
+	if req.NetworkInterfaceID != "" {
+		eip, err := h.svc.AssociateNetworkInterface(c.Request.Context(), id, uuid.MustParse(req.NetworkInterfaceID), req.PrivateIP)
+		if err != nil {
+			httputil.Error(c, err)
+			return
+		}
+		httputil.Success(c, http.StatusOK, eip)
+		return
+	}
 
 	instID, err := uuid.Parse(req.InstanceID)
 	if err != nil {
@@ -45,6 +45,15 @@ func (m *mockElasticIPService) AssociateIP(ctx context.Context, id uuid.UUID, in
 	return r0, args.Error(1)
 }
 
+func (m *mockElasticIPService) AssociateNetworkInterface(ctx context.Context, id, interfaceID uuid.UUID, privateIP string) (*domain.ElasticIP, error) {
+	args := m.Called(ctx, id, interfaceID, privateIP)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	r0, _ := args.Get(0).(*domain.ElasticIP)
+	return r0, args.Error(1)
+}
+
 func (m *mockElasticIPService) DisassociateIP(ctx context.Context, id uuid.UUID) (*domain.ElasticIP, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -117,6 +126,22 @@ func TestElasticIPHandler(t *testing.T) {
 			body:           map[string]string{"instance_id": ""}, // Filled in loop
 			expectedStatus: http.StatusOK,
 		},
+		{
+			name:   "AssociateNetworkInterface",
+			method: "POST",
+			url:    "/elastic-ips/{id}/associate",
+			setupMock: func(svc *mockElasticIPService, eipID, niID uuid.UUID) {
+				svc.On("AssociateNetworkInterface", mock.Anything, eipID, niID, "10.0.1.11").Return(&domain.ElasticIP{}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "AssociateRequiresOneTarget",
+			method:         "POST",
+			url:            "/elastic-ips/{id}/associate",
+			setupMock:      func(svc *mockElasticIPService, eipID, instID uuid.UUID) {},
+			expectedStatus: http.StatusBadRequest,
+		},
 	}
 
 	for _, tt := range tests {
@@ -136,8 +161,13 @@ func TestElasticIPHandler(t *testing.T) {
 			}
 
 			var body []byte
-			if tt.name == "Associate" {
+			switch tt.name {
+			case "Associate":
 				body, _ = json.Marshal(map[string]string{"instance_id": instID.String()})
+			case "AssociateNetworkInterface":
+				body, _ = json.Marshal(map[string]string{"network_interface_id": instID.String(), "private_ip": "10.0.1.11"})
+			case "AssociateRequiresOneTarget":
+				body, _ = json.Marshal(map[string]string{"instance_id": instID.String(), "network_interface_id": uuid.NewString()})
 			}
 
 			w := httptest.NewRecorder()
@@ -0,0 +1,125 @@
+package httphandlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/poyrazk/thecloud/pkg/httputil"
+)
+
+// FlowLogHandler handles VPC flow log HTTP endpoints.
+type FlowLogHandler struct {
+	svc ports.FlowLogService
+}
+
+// NewFlowLogHandler creates a new FlowLogHandler.
+func NewFlowLogHandler(svc ports.FlowLogService) *FlowLogHandler {
+	return &FlowLogHandler{svc: svc}
+}
+
+// CreateFlowLogRequest is the payload for enabling a flow log.
+type CreateFlowLogRequest struct {
+	ResourceType    domain.FlowLogResourceType `json:"resource_type" binding:"required"` // vpc, subnet or instance
+	ResourceID      uuid.UUID                  `json:"resource_id" binding:"required"`
+	TrafficType     domain.FlowLogTrafficType  `json:"traffic_type"` // ACCEPT, REJECT or ALL (default)
+	Destination     domain.FlowLogDestination  `json:"destination"`  // cloudlogs (default) or bucket
+	Bucket          string                     `json:"bucket"`
+	IntervalSeconds int                        `json:"interval_seconds"` // 60 or 600 (default)
+}
+
+// Create enables a flow log on a VPC, subnet or instance.
+// @Summary Create Flow Log
+// @Tags network
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param request body CreateFlowLogRequest true "Flow log configuration"
+// @Success 201 {object} domain.FlowLog
+// @Failure 400 {object} httputil.Response
+// @Failure 404 {object} httputil.Response
+// @Router /flow-logs [post]
+func (h *FlowLogHandler) Create(c *gin.Context) {
+	var req CreateFlowLogRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, err.Error()))
+		return
+	}
+
+	fl, err := h.svc.CreateFlowLog(c.Request.Context(), ports.CreateFlowLogParams{
+		ResourceType:    req.ResourceType,
+		ResourceID:      req.ResourceID,
+		TrafficType:     req.TrafficType,
+		Destination:     req.Destination,
+		Bucket:          req.Bucket,
+		IntervalSeconds: req.IntervalSeconds,
+	})
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusCreated, fl)
+}
+
+// List returns the tenant's flow logs.
+// @Summary List Flow Logs
+// @Tags network
+// @Security APIKeyAuth
+// @Produce json
+// @Success 200 {array} domain.FlowLog
+// @Router /flow-logs [get]
+func (h *FlowLogHandler) List(c *gin.Context) {
+	flowLogs, err := h.svc.ListFlowLogs(c.Request.Context())
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, flowLogs)
+}
+
+// Get returns a flow log, including the outcome of its last capture.
+// @Summary Get Flow Log
+// @Tags network
+// @Security APIKeyAuth
+// @Produce json
+// @Param id path string true "Flow log ID"
+// @Success 200 {object} domain.FlowLog
+// @Failure 404 {object} httputil.Response
+// @Router /flow-logs/{id} [get]
+func (h *FlowLogHandler) Get(c *gin.Context) {
+	id, ok := parseUUID(c)
+	if !ok {
+		return
+	}
+
+	fl, err := h.svc.GetFlowLog(c.Request.Context(), *id)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, fl)
+}
+
+// Delete stops a flow log. Records already delivered are kept.
+// @Summary Delete Flow Log
+// @Tags network
+// @Security APIKeyAuth
+// @Param id path string true "Flow log ID"
+// @Success 204
+// @Failure 404 {object} httputil.Response
+// @Router /flow-logs/{id} [delete]
+func (h *FlowLogHandler) Delete(c *gin.Context) {
+	id, ok := parseUUID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.DeleteFlowLog(c.Request.Context(), *id); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,145 @@
+package httphandlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockFlowLogService struct {
+	mock.Mock
+}
+
+func (m *mockFlowLogService) CreateFlowLog(ctx context.Context, params ports.CreateFlowLogParams) (*domain.FlowLog, error) {
+	args := m.Called(ctx, params)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.FlowLog), args.Error(1)
+}
+
+func (m *mockFlowLogService) GetFlowLog(ctx context.Context, id uuid.UUID) (*domain.FlowLog, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.FlowLog), args.Error(1)
+}
+
+func (m *mockFlowLogService) ListFlowLogs(ctx context.Context) ([]*domain.FlowLog, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.FlowLog), args.Error(1)
+}
+
+func (m *mockFlowLogService) DeleteFlowLog(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *mockFlowLogService) CaptureDue(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func setupFlowLogHandlerTest() (*mockFlowLogService, *FlowLogHandler, *gin.Engine) {
+	gin.SetMode(gin.TestMode)
+	svc := new(mockFlowLogService)
+	handler := NewFlowLogHandler(svc)
+	r := gin.New()
+	return svc, handler, r
+}
+
+func TestFlowLogHandler(t *testing.T) {
+	vpcID := uuid.New()
+	flID := uuid.New()
+
+	t.Run("Create", func(t *testing.T) {
+		svc, handler, r := setupFlowLogHandlerTest()
+		r.POST("/flow-logs", handler.Create)
+
+		params := ports.CreateFlowLogParams{ResourceType: domain.FlowLogResourceVPC, ResourceID: vpcID, IntervalSeconds: 60}
+		svc.On("CreateFlowLog", mock.Anything, params).Return(&domain.FlowLog{ID: flID, ResourceID: vpcID, IntervalSeconds: 60}, nil)
+
+		body, _ := json.Marshal(map[string]interface{}{"resource_type": "vpc", "resource_id": vpcID, "interval_seconds": 60})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/flow-logs", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), flID.String())
+	})
+
+	t.Run("CreateMissingResource", func(t *testing.T) {
+		_, handler, r := setupFlowLogHandlerTest()
+		r.POST("/flow-logs", handler.Create)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/flow-logs", bytes.NewBufferString(`{"resource_type":"vpc"}`))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		svc, handler, r := setupFlowLogHandlerTest()
+		r.GET("/flow-logs", handler.List)
+		svc.On("ListFlowLogs", mock.Anything).Return([]*domain.FlowLog{{ID: flID}}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/flow-logs", nil)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), flID.String())
+	})
+
+	t.Run("GetNotFound", func(t *testing.T) {
+		svc, handler, r := setupFlowLogHandlerTest()
+		r.GET("/flow-logs/:id", handler.Get)
+		svc.On("GetFlowLog", mock.Anything, flID).Return(nil, errors.New(errors.NotFound, "flow log not found"))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/flow-logs/"+flID.String(), nil)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		svc, handler, r := setupFlowLogHandlerTest()
+		r.DELETE("/flow-logs/:id", handler.Delete)
+		svc.On("DeleteFlowLog", mock.Anything, flID).Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, "/flow-logs/"+flID.String(), nil)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("InvalidID", func(t *testing.T) {
+		_, handler, r := setupFlowLogHandlerTest()
+		r.GET("/flow-logs/:id", handler.Get)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/flow-logs/not-a-uuid", nil)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,67 @@
+package httphandlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/pkg/httputil"
+)
+
+// FlowReconcileHandler exposes OVS flow drift on VPC bridges to platform admins.
+type FlowReconcileHandler struct {
+	svc ports.FlowReconcilerService
+}
+
+// NewFlowReconcileHandler creates a new FlowReconcileHandler.
+func NewFlowReconcileHandler(svc ports.FlowReconcilerService) *FlowReconcileHandler {
+	return &FlowReconcileHandler{svc: svc}
+}
+
+// Diff compares a VPC's bridge with the flows its security groups and routes call for.
+// @Summary Diff VPC Flows (admin)
+// @Tags network
+// @Security APIKeyAuth
+// @Produce json
+// @Param id path string true "VPC ID"
+// @Success 200 {object} domain.FlowDiff
+// @Failure 403 {object} httputil.Response
+// @Failure 404 {object} httputil.Response
+// @Router /admin/network/vpcs/{id}/flows [get]
+func (h *FlowReconcileHandler) Diff(c *gin.Context) {
+	id, ok := parseUUID(c)
+	if !ok {
+		return
+	}
+
+	diff, err := h.svc.DiffVPC(c.Request.Context(), *id)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, diff)
+}
+
+// Reconcile repairs drift on a VPC's bridge and returns what was found.
+// @Summary Reconcile VPC Flows (admin)
+// @Tags network
+// @Security APIKeyAuth
+// @Produce json
+// @Param id path string true "VPC ID"
+// @Success 200 {object} domain.FlowDiff
+// @Failure 403 {object} httputil.Response
+// @Failure 404 {object} httputil.Response
+// @Router /admin/network/vpcs/{id}/flows/reconcile [post]
+func (h *FlowReconcileHandler) Reconcile(c *gin.Context) {
+	id, ok := parseUUID(c)
+	if !ok {
+		return
+	}
+
+	diff, err := h.svc.ReconcileVPC(c.Request.Context(), *id)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, diff)
+}
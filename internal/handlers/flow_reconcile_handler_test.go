@@ -0,0 +1,122 @@
+package httphandlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockFlowReconcilerService struct {
+	mock.Mock
+}
+
+func (m *mockFlowReconcilerService) DiffVPC(ctx context.Context, vpcID uuid.UUID) (*domain.FlowDiff, error) {
+	args := m.Called(ctx, vpcID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.FlowDiff), args.Error(1)
+}
+
+func (m *mockFlowReconcilerService) ReconcileVPC(ctx context.Context, vpcID uuid.UUID) (*domain.FlowDiff, error) {
+	args := m.Called(ctx, vpcID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.FlowDiff), args.Error(1)
+}
+
+func (m *mockFlowReconcilerService) ReconcileAll(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func setupFlowReconcileHandlerTest() (*mockFlowReconcilerService, *FlowReconcileHandler, *gin.Engine) {
+	gin.SetMode(gin.TestMode)
+	svc := new(mockFlowReconcilerService)
+	handler := NewFlowReconcileHandler(svc)
+	r := gin.New()
+	return svc, handler, r
+}
+
+func TestFlowReconcileHandler(t *testing.T) {
+	vpcID := uuid.New()
+
+	t.Run("Diff", func(t *testing.T) {
+		svc, handler, r := setupFlowReconcileHandlerTest()
+		r.GET("/admin/network/vpcs/:id/flows", handler.Diff)
+
+		svc.On("DiffVPC", mock.Anything, vpcID).Return(&domain.FlowDiff{
+			VPCID:   vpcID,
+			Bridge:  "br-vpc",
+			Missing: []domain.FlowEntry{{Cookie: "0x1", Priority: 100, Match: "tcp,tp_dst=22", Actions: "NORMAL"}},
+		}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/admin/network/vpcs/"+vpcID.String()+"/flows", nil)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"match":"tcp,tp_dst=22"`)
+	})
+
+	t.Run("Diff_InvalidID", func(t *testing.T) {
+		_, handler, r := setupFlowReconcileHandlerTest()
+		r.GET("/admin/network/vpcs/:id/flows", handler.Diff)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/admin/network/vpcs/not-a-uuid/flows", nil)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Diff_Forbidden", func(t *testing.T) {
+		svc, handler, r := setupFlowReconcileHandlerTest()
+		r.GET("/admin/network/vpcs/:id/flows", handler.Diff)
+
+		svc.On("DiffVPC", mock.Anything, vpcID).Return(nil, errors.New(errors.Forbidden, "permission denied"))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/admin/network/vpcs/"+vpcID.String()+"/flows", nil)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("Reconcile", func(t *testing.T) {
+		svc, handler, r := setupFlowReconcileHandlerTest()
+		r.POST("/admin/network/vpcs/:id/flows/reconcile", handler.Reconcile)
+
+		svc.On("ReconcileVPC", mock.Anything, vpcID).Return(&domain.FlowDiff{VPCID: vpcID, Repaired: true}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/admin/network/vpcs/"+vpcID.String()+"/flows/reconcile", nil)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"repaired":true`)
+		svc.AssertExpectations(t)
+	})
+
+	t.Run("Reconcile_NotFound", func(t *testing.T) {
+		svc, handler, r := setupFlowReconcileHandlerTest()
+		r.POST("/admin/network/vpcs/:id/flows/reconcile", handler.Reconcile)
+
+		svc.On("ReconcileVPC", mock.Anything, vpcID).Return(nil, errors.New(errors.NotFound, "vpc not found"))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/admin/network/vpcs/"+vpcID.String()+"/flows/reconcile", nil)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -72,6 +72,15 @@ func (m *mockFunctionService) InvokeFunction(ctx context.Context, id uuid.UUID,
 	return r0, args.Error(1)
 }
 
+func (m *mockFunctionService) InvokeFunctionRedacted(ctx context.Context, id uuid.UUID, payload []byte) (*domain.Invocation, error) {
+	args := m.Called(ctx, id, payload)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	r0, _ := args.Get(0).(*domain.Invocation)
+	return r0, args.Error(1)
+}
+
 func (m *mockFunctionService) GetFunctionLogs(ctx context.Context, id uuid.UUID, limit int) ([]*domain.Invocation, error) {
 	args := m.Called(ctx, id, limit)
 	if args.Get(0) == nil {
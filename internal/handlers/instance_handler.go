@@ -54,6 +54,9 @@ type LaunchRequest struct {
 	SSHKeyID     string                    `json:"ssh_key_id,omitempty"`
 	Metadata     map[string]string         `json:"metadata,omitempty"`
 	Labels       map[string]string         `json:"labels,omitempty"`
+	// ServiceAccountID attaches a service account; the instance fetches its
+	// credentials from the metadata service.
+	ServiceAccountID string `json:"service_account_id,omitempty"`
 }
 
 // validateLaunchRequest performs custom validation beyond struct tags
@@ -142,6 +145,15 @@ func (h *InstanceHandler) Launch(c *gin.Context) {
 		}
 		sshKeyID = &id
 	}
+	var serviceAccountID *uuid.UUID
+	if req.ServiceAccountID != "" {
+		id, err := uuid.Parse(req.ServiceAccountID)
+		if err != nil {
+			httputil.Error(c, errors.New(errors.InvalidInput, "invalid service_account_id format"))
+			return
+		}
+		serviceAccountID = &id
+	}
 
 	inst, err := h.svc.LaunchInstance(c.Request.Context(), ports.LaunchParams{
 		Name:         req.Name,
@@ -161,6 +173,8 @@ func (h *InstanceHandler) Launch(c *gin.Context) {
 		SSHKeyID:  sshKeyID,
 		Metadata:  req.Metadata,
 		Labels:    req.Labels,
+
+		ServiceAccountID: serviceAccountID,
 	})
 	if err != nil {
 		httputil.Error(c, err)
@@ -0,0 +1,136 @@
+package httphandlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/poyrazk/thecloud/pkg/httputil"
+)
+
+const (
+	// MetadataFlavorHeader must be sent with every metadata request. Requiring a
+	// custom header keeps server-side request forgery through the instance's own
+	// applications (which usually cannot set headers) away from its credentials.
+	MetadataFlavorHeader = "Metadata-Flavor"
+	// MetadataFlavor is the expected value of MetadataFlavorHeader.
+	MetadataFlavor = "thecloud"
+)
+
+// InstanceMetadataHandler serves the link-local instance metadata endpoint of
+// one VPC, or of the instances outside any VPC when vpcID is nil. Responses are
+// plain documents rather than API envelopes so that they can be consumed with
+// curl from inside an instance.
+type InstanceMetadataHandler struct {
+	svc   ports.InstanceMetadataService
+	vpcID *uuid.UUID
+}
+
+// NewInstanceMetadataHandler creates a new InstanceMetadataHandler for the
+// listener of a VPC.
+func NewInstanceMetadataHandler(svc ports.InstanceMetadataService, vpcID *uuid.UUID) *InstanceMetadataHandler {
+	return &InstanceMetadataHandler{svc: svc, vpcID: vpcID}
+}
+
+// caller identifies the requesting instance by the listener's VPC and the
+// source address of the connection.
+func (h *InstanceMetadataHandler) caller(c *gin.Context) domain.MetadataCaller {
+	return domain.MetadataCaller{VPCID: h.vpcID, IP: c.RemoteIP()}
+}
+
+// RequireMetadataFlavor rejects requests that do not carry the metadata header.
+func (h *InstanceMetadataHandler) RequireMetadataFlavor() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader(MetadataFlavorHeader) != MetadataFlavor {
+			httputil.Error(c, errors.New(errors.Forbidden, "missing "+MetadataFlavorHeader+": "+MetadataFlavor+" header"))
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// GetMetadata returns the full metadata document of the calling instance.
+func (h *InstanceMetadataHandler) GetMetadata(c *gin.Context) {
+	md, err := h.svc.GetMetadata(c.Request.Context(), h.caller(c))
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, md)
+}
+
+// GetInstanceID returns the ID of the calling instance as plain text.
+func (h *InstanceMetadataHandler) GetInstanceID(c *gin.Context) {
+	md, err := h.svc.GetMetadata(c.Request.Context(), h.caller(c))
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	c.String(http.StatusOK, md.InstanceID.String())
+}
+
+// GetTags returns the tags of the calling instance.
+func (h *InstanceMetadataHandler) GetTags(c *gin.Context) {
+	md, err := h.svc.GetMetadata(c.Request.Context(), h.caller(c))
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, md.Tags)
+}
+
+// GetNetwork returns the network configuration of the calling instance,
+// including the DHCP options of its VPC.
+func (h *InstanceMetadataHandler) GetNetwork(c *gin.Context) {
+	md, err := h.svc.GetMetadata(c.Request.Context(), h.caller(c))
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, md.Network)
+}
+
+// GetPreemption returns the termination notice of a preempted instance. It
+// answers 404 until the platform reclaims the instance, so that nodes can
+// poll it with curl -f.
+func (h *InstanceMetadataHandler) GetPreemption(c *gin.Context) {
+	md, err := h.svc.GetMetadata(c.Request.Context(), h.caller(c))
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	if md.Preemption == nil {
+		httputil.Error(c, errors.New(errors.NotFound, "instance has no preemption notice"))
+		return
+	}
+	c.JSON(http.StatusOK, md.Preemption)
+}
+
+// GetUserData returns the user data the instance was launched with.
+func (h *InstanceMetadataHandler) GetUserData(c *gin.Context) {
+	md, err := h.svc.GetMetadata(c.Request.Context(), h.caller(c))
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	if md.UserData == "" {
+		httputil.Error(c, errors.New(errors.NotFound, "instance has no user data"))
+		return
+	}
+	c.String(http.StatusOK, md.UserData)
+}
+
+// GetCredentials returns short-lived credentials for the service account
+// attached to the calling instance.
+func (h *InstanceMetadataHandler) GetCredentials(c *gin.Context) {
+	creds, err := h.svc.GetCredentials(c.Request.Context(), h.caller(c))
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, creds)
+}
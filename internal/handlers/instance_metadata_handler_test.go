@@ -0,0 +1,153 @@
+package httphandlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockInstanceMetadataService struct {
+	mock.Mock
+}
+
+func (m *mockInstanceMetadataService) GetMetadata(ctx context.Context, caller domain.MetadataCaller) (*domain.InstanceMetadata, error) {
+	args := m.Called(ctx, caller)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.InstanceMetadata), args.Error(1)
+}
+
+func (m *mockInstanceMetadataService) GetCredentials(ctx context.Context, caller domain.MetadataCaller) (*domain.InstanceCredentials, error) {
+	args := m.Called(ctx, caller)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.InstanceCredentials), args.Error(1)
+}
+
+const instanceSourceIP = "10.0.1.5"
+
+var (
+	metadataVPCID  = uuid.MustParse("6f1c2a4e-0b7d-4c1e-9a53-2d8e4f6a7b90")
+	metadataCaller = domain.MetadataCaller{VPCID: &metadataVPCID, IP: instanceSourceIP}
+)
+
+func setupInstanceMetadataHandlerTest() (*mockInstanceMetadataService, *gin.Engine) {
+	gin.SetMode(gin.TestMode)
+	svc := new(mockInstanceMetadataService)
+	handler := NewInstanceMetadataHandler(svc, &metadataVPCID)
+	r := gin.New()
+	md := r.Group("/latest", handler.RequireMetadataFlavor())
+	md.GET("/meta-data/instance-id", handler.GetInstanceID)
+	md.GET("/meta-data/iam/credentials", handler.GetCredentials)
+	md.GET("/meta-data/preemption", handler.GetPreemption)
+	md.GET("/user-data", handler.GetUserData)
+	return svc, r
+}
+
+func metadataRequest(path string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.RemoteAddr = instanceSourceIP + ":41000"
+	req.Header.Set(MetadataFlavorHeader, MetadataFlavor)
+	return req
+}
+
+func TestInstanceMetadataHandlerInstanceID(t *testing.T) {
+	t.Parallel()
+	svc, r := setupInstanceMetadataHandlerTest()
+
+	id := uuid.New()
+	svc.On("GetMetadata", mock.Anything, metadataCaller).Return(&domain.InstanceMetadata{InstanceID: id}, nil).Once()
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, metadataRequest("/latest/meta-data/instance-id"))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, id.String(), w.Body.String())
+}
+
+func TestInstanceMetadataHandlerRequiresFlavorHeader(t *testing.T) {
+	t.Parallel()
+	svc, r := setupInstanceMetadataHandlerTest()
+
+	req := metadataRequest("/latest/meta-data/iam/credentials")
+	req.Header.Del(MetadataFlavorHeader)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	svc.AssertNotCalled(t, "GetCredentials", mock.Anything, mock.Anything)
+}
+
+func TestInstanceMetadataHandlerCredentials(t *testing.T) {
+	t.Parallel()
+	svc, r := setupInstanceMetadataHandlerTest()
+
+	svc.On("GetCredentials", mock.Anything, metadataCaller).Return(&domain.InstanceCredentials{AccessToken: "sa-token", TokenType: "Bearer"}, nil).Once()
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, metadataRequest("/latest/meta-data/iam/credentials"))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"access_token":"sa-token"`)
+}
+
+func TestInstanceMetadataHandlerNoUserData(t *testing.T) {
+	t.Parallel()
+	svc, r := setupInstanceMetadataHandlerTest()
+
+	svc.On("GetMetadata", mock.Anything, metadataCaller).Return(&domain.InstanceMetadata{InstanceID: uuid.New()}, nil).Once()
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, metadataRequest("/latest/user-data"))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestInstanceMetadataHandlerPreemption(t *testing.T) {
+	t.Parallel()
+
+	t.Run("NoNotice", func(t *testing.T) {
+		svc, r := setupInstanceMetadataHandlerTest()
+		svc.On("GetMetadata", mock.Anything, metadataCaller).Return(&domain.InstanceMetadata{InstanceID: uuid.New()}, nil).Once()
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, metadataRequest("/latest/meta-data/preemption"))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Notice", func(t *testing.T) {
+		svc, r := setupInstanceMetadataHandlerTest()
+		notice := &domain.PreemptionNotice{Action: "terminate", Time: time.Date(2024, 1, 1, 12, 0, 30, 0, time.UTC)}
+		svc.On("GetMetadata", mock.Anything, metadataCaller).Return(&domain.InstanceMetadata{InstanceID: uuid.New(), Preemption: notice}, nil).Once()
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, metadataRequest("/latest/meta-data/preemption"))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"action":"terminate","time":"2024-01-01T12:00:30Z"}`, w.Body.String())
+	})
+}
+
+func TestInstanceMetadataHandlerUnknownCaller(t *testing.T) {
+	t.Parallel()
+	svc, r := setupInstanceMetadataHandlerTest()
+
+	svc.On("GetMetadata", mock.Anything, metadataCaller).Return(nil, errors.New(errors.Forbidden, "metadata is only available to instances")).Once()
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, metadataRequest("/latest/meta-data/instance-id"))
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
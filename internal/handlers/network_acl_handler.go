@@ -0,0 +1,277 @@
+package httphandlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/poyrazk/thecloud/pkg/httputil"
+)
+
+const invalidNetworkACLIDMsg = "invalid network ACL id"
+
+// NetworkACLHandler handles HTTP requests for network ACLs.
+type NetworkACLHandler struct {
+	svc ports.NetworkACLService
+}
+
+// NewNetworkACLHandler creates a new NetworkACLHandler.
+func NewNetworkACLHandler(svc ports.NetworkACLService) *NetworkACLHandler {
+	return &NetworkACLHandler{svc: svc}
+}
+
+// CreateNetworkACLRequest represents the body for creating a network ACL.
+type CreateNetworkACLRequest struct {
+	VPCID string `json:"vpc_id" binding:"required,uuid"`
+	Name  string `json:"name" binding:"required"`
+}
+
+// Create creates a new network ACL with no rules.
+// @Summary Create Network ACL
+// @Tags network-acls
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param request body CreateNetworkACLRequest true "Network ACL Request"
+// @Success 201 {object} domain.NetworkACL
+// @Failure 400 {object} httputil.Response
+// @Failure 500 {object} httputil.Response
+// @Router /network-acls [post]
+func (h *NetworkACLHandler) Create(c *gin.Context) {
+	var req CreateNetworkACLRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid request body"))
+		return
+	}
+
+	vpcID, _ := uuid.Parse(req.VPCID)
+	acl, err := h.svc.CreateNetworkACL(c.Request.Context(), vpcID, req.Name)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusCreated, acl)
+}
+
+// List returns all network ACLs for a VPC.
+// @Summary List Network ACLs
+// @Tags network-acls
+// @Security APIKeyAuth
+// @Produce json
+// @Param vpc_id query string true "VPC ID"
+// @Success 200 {array} domain.NetworkACL
+// @Failure 400 {object} httputil.Response
+// @Failure 500 {object} httputil.Response
+// @Router /network-acls [get]
+func (h *NetworkACLHandler) List(c *gin.Context) {
+	vpcID, err := uuid.Parse(c.Query("vpc_id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "vpc_id is required"))
+		return
+	}
+
+	acls, err := h.svc.ListNetworkACLs(c.Request.Context(), vpcID)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, acls)
+}
+
+// Get retrieves a network ACL with its rules and associations.
+// @Summary Get Network ACL
+// @Tags network-acls
+// @Security APIKeyAuth
+// @Produce json
+// @Param id path string true "Network ACL ID"
+// @Success 200 {object} domain.NetworkACL
+// @Failure 400 {object} httputil.Response
+// @Failure 404 {object} httputil.Response
+// @Router /network-acls/{id} [get]
+func (h *NetworkACLHandler) Get(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidNetworkACLIDMsg))
+		return
+	}
+
+	acl, err := h.svc.GetNetworkACL(c.Request.Context(), id)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, acl)
+}
+
+// Delete removes a custom network ACL; its subnets return to the default ACL.
+// @Summary Delete Network ACL
+// @Tags network-acls
+// @Security APIKeyAuth
+// @Param id path string true "Network ACL ID"
+// @Success 204
+// @Failure 400 {object} httputil.Response
+// @Failure 404 {object} httputil.Response
+// @Router /network-acls/{id} [delete]
+func (h *NetworkACLHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidNetworkACLIDMsg))
+		return
+	}
+
+	if err := h.svc.DeleteNetworkACL(c.Request.Context(), id); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// AddNetworkACLRuleRequest represents the body for adding a network ACL rule.
+type AddNetworkACLRuleRequest struct {
+	RuleNumber int    `json:"rule_number" binding:"required"`
+	Direction  string `json:"direction" binding:"required,oneof=ingress egress"`
+	Protocol   string `json:"protocol"`
+	PortMin    int    `json:"port_min"`
+	PortMax    int    `json:"port_max"`
+	CIDR       string `json:"cidr" binding:"required"`
+	Action     string `json:"action" binding:"required,oneof=allow deny"`
+}
+
+// AddRule adds a numbered rule to a network ACL.
+// @Summary Add Network ACL Rule
+// @Tags network-acls
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Network ACL ID"
+// @Param request body AddNetworkACLRuleRequest true "Rule Request"
+// @Success 201 {object} domain.NetworkACLRule
+// @Failure 400 {object} httputil.Response
+// @Failure 409 {object} httputil.Response
+// @Router /network-acls/{id}/rules [post]
+func (h *NetworkACLHandler) AddRule(c *gin.Context) {
+	aclID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidNetworkACLIDMsg))
+		return
+	}
+
+	var req AddNetworkACLRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid request body"))
+		return
+	}
+
+	rule, err := h.svc.AddRule(c.Request.Context(), aclID, domain.NetworkACLRule{
+		RuleNumber: req.RuleNumber,
+		Direction:  domain.RuleDirection(req.Direction),
+		Protocol:   req.Protocol,
+		PortMin:    req.PortMin,
+		PortMax:    req.PortMax,
+		CIDR:       req.CIDR,
+		Action:     domain.NetworkACLAction(req.Action),
+	})
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusCreated, rule)
+}
+
+// RemoveRule removes a rule from a network ACL.
+// @Summary Remove Network ACL Rule
+// @Tags network-acls
+// @Security APIKeyAuth
+// @Param id path string true "Network ACL ID"
+// @Param rule_id path string true "Rule ID"
+// @Success 204
+// @Failure 400 {object} httputil.Response
+// @Failure 404 {object} httputil.Response
+// @Router /network-acls/{id}/rules/{rule_id} [delete]
+func (h *NetworkACLHandler) RemoveRule(c *gin.Context) {
+	aclID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidNetworkACLIDMsg))
+		return
+	}
+	ruleID, err := uuid.Parse(c.Param("rule_id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid rule id"))
+		return
+	}
+
+	if err := h.svc.RemoveRule(c.Request.Context(), aclID, ruleID); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// AssociateSubnet applies a network ACL to a subnet.
+// @Summary Associate Subnet with Network ACL
+// @Tags network-acls
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Network ACL ID"
+// @Param request body AssociateSubnetRequest true "Subnet Association Request"
+// @Success 200
+// @Failure 400 {object} httputil.Response
+// @Failure 404 {object} httputil.Response
+// @Router /network-acls/{id}/associate [post]
+func (h *NetworkACLHandler) AssociateSubnet(c *gin.Context) {
+	aclID, subnetID, ok := h.parseAssociation(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.AssociateSubnet(c.Request.Context(), aclID, subnetID); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, nil)
+}
+
+// DisassociateSubnet returns a subnet to its VPC's default network ACL.
+// @Summary Disassociate Subnet from Network ACL
+// @Tags network-acls
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Network ACL ID"
+// @Param request body AssociateSubnetRequest true "Subnet Disassociation Request"
+// @Success 200
+// @Failure 400 {object} httputil.Response
+// @Failure 404 {object} httputil.Response
+// @Router /network-acls/{id}/disassociate [post]
+func (h *NetworkACLHandler) DisassociateSubnet(c *gin.Context) {
+	aclID, subnetID, ok := h.parseAssociation(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.DisassociateSubnet(c.Request.Context(), aclID, subnetID); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, nil)
+}
+
+func (h *NetworkACLHandler) parseAssociation(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	aclID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidNetworkACLIDMsg))
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	var req AssociateSubnetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid request body"))
+		return uuid.Nil, uuid.Nil, false
+	}
+	subnetID, _ := uuid.Parse(req.SubnetID)
+	return aclID, subnetID, true
+}
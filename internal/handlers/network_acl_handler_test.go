@@ -0,0 +1,174 @@
+package httphandlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockNetworkACLService struct {
+	mock.Mock
+}
+
+func (m *mockNetworkACLService) CreateNetworkACL(ctx context.Context, vpcID uuid.UUID, name string) (*domain.NetworkACL, error) {
+	args := m.Called(ctx, vpcID, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.NetworkACL), args.Error(1)
+}
+
+func (m *mockNetworkACLService) GetNetworkACL(ctx context.Context, id uuid.UUID) (*domain.NetworkACL, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.NetworkACL), args.Error(1)
+}
+
+func (m *mockNetworkACLService) ListNetworkACLs(ctx context.Context, vpcID uuid.UUID) ([]*domain.NetworkACL, error) {
+	args := m.Called(ctx, vpcID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.NetworkACL), args.Error(1)
+}
+
+func (m *mockNetworkACLService) DeleteNetworkACL(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *mockNetworkACLService) AddRule(ctx context.Context, aclID uuid.UUID, rule domain.NetworkACLRule) (*domain.NetworkACLRule, error) {
+	args := m.Called(ctx, aclID, rule)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.NetworkACLRule), args.Error(1)
+}
+
+func (m *mockNetworkACLService) RemoveRule(ctx context.Context, aclID, ruleID uuid.UUID) error {
+	return m.Called(ctx, aclID, ruleID).Error(0)
+}
+
+func (m *mockNetworkACLService) AssociateSubnet(ctx context.Context, aclID, subnetID uuid.UUID) error {
+	return m.Called(ctx, aclID, subnetID).Error(0)
+}
+
+func (m *mockNetworkACLService) DisassociateSubnet(ctx context.Context, aclID, subnetID uuid.UUID) error {
+	return m.Called(ctx, aclID, subnetID).Error(0)
+}
+
+const networkACLPath = "/network-acls"
+
+func setupNetworkACLHandlerTest() (*mockNetworkACLService, *NetworkACLHandler, *gin.Engine) {
+	gin.SetMode(gin.TestMode)
+	svc := new(mockNetworkACLService)
+	handler := NewNetworkACLHandler(svc)
+	r := gin.New()
+	return svc, handler, r
+}
+
+func TestNetworkACLHandlerCreate(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupNetworkACLHandlerTest()
+	r.POST(networkACLPath, handler.Create)
+
+	vpcID := uuid.New()
+	svc.On("CreateNetworkACL", mock.Anything, vpcID, "web").Return(&domain.NetworkACL{ID: uuid.New(), VPCID: vpcID, Name: "web"}, nil).Once()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, networkACLPath, bytes.NewBufferString(`{"vpc_id":"`+vpcID.String()+`","name":"web"}`))
+	req.Header.Set(contentType, applicationJSON)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	svc.AssertExpectations(t)
+}
+
+func TestNetworkACLHandlerListMissingVpcID(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupNetworkACLHandlerTest()
+	r.GET(networkACLPath, handler.List)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, networkACLPath, nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	svc.AssertNotCalled(t, "ListNetworkACLs", mock.Anything, mock.Anything)
+}
+
+func TestNetworkACLHandlerDeleteDefault(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupNetworkACLHandlerTest()
+	r.DELETE(networkACLPath+"/:id", handler.Delete)
+
+	id := uuid.New()
+	svc.On("DeleteNetworkACL", mock.Anything, id).Return(errors.New(errors.InvalidInput, "the default network ACL cannot be deleted")).Once()
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, networkACLPath+"/"+id.String(), nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestNetworkACLHandlerAddRule(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupNetworkACLHandlerTest()
+	r.POST(networkACLPath+"/:id/rules", handler.AddRule)
+
+	aclID := uuid.New()
+	expected := domain.NetworkACLRule{
+		RuleNumber: 100, Direction: domain.RuleIngress, Protocol: "tcp", PortMin: 443, PortMax: 443, CIDR: "0.0.0.0/0", Action: domain.NetworkACLAllow,
+	}
+	svc.On("AddRule", mock.Anything, aclID, expected).Return(&expected, nil).Once()
+
+	body := `{"rule_number":100,"direction":"ingress","protocol":"tcp","port_min":443,"port_max":443,"cidr":"0.0.0.0/0","action":"allow"}`
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, networkACLPath+"/"+aclID.String()+"/rules", bytes.NewBufferString(body))
+	req.Header.Set(contentType, applicationJSON)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	svc.AssertExpectations(t)
+}
+
+func TestNetworkACLHandlerAddRuleInvalidAction(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupNetworkACLHandlerTest()
+	r.POST(networkACLPath+"/:id/rules", handler.AddRule)
+
+	body := `{"rule_number":100,"direction":"ingress","cidr":"0.0.0.0/0","action":"reject"}`
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, networkACLPath+"/"+uuid.New().String()+"/rules", bytes.NewBufferString(body))
+	req.Header.Set(contentType, applicationJSON)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	svc.AssertNotCalled(t, "AddRule", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestNetworkACLHandlerDisassociateSubnet(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupNetworkACLHandlerTest()
+	r.POST(networkACLPath+"/:id/disassociate", handler.DisassociateSubnet)
+
+	aclID, subnetID := uuid.New(), uuid.New()
+	svc.On("DisassociateSubnet", mock.Anything, aclID, subnetID).Return(nil).Once()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, networkACLPath+"/"+aclID.String()+"/disassociate", bytes.NewBufferString(`{"subnet_id":"`+subnetID.String()+`"}`))
+	req.Header.Set(contentType, applicationJSON)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	svc.AssertExpectations(t)
+}
@@ -0,0 +1,332 @@
+package httphandlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/poyrazk/thecloud/pkg/httputil"
+)
+
+const invalidNetworkInterfaceIDMsg = "invalid network interface id"
+
+// NetworkInterfaceHandler handles HTTP requests for elastic network interfaces.
+type NetworkInterfaceHandler struct {
+	svc ports.NetworkInterfaceService
+}
+
+// NewNetworkInterfaceHandler creates a new NetworkInterfaceHandler.
+func NewNetworkInterfaceHandler(svc ports.NetworkInterfaceService) *NetworkInterfaceHandler {
+	return &NetworkInterfaceHandler{svc: svc}
+}
+
+// CreateNetworkInterfaceRequest represents the body for creating a network interface.
+type CreateNetworkInterfaceRequest struct {
+	SubnetID         string      `json:"subnet_id" binding:"required,uuid"`
+	Description      string      `json:"description"`
+	PrivateIPs       []string    `json:"private_ips" binding:"omitempty,dive,ipv4"`
+	SecurityGroupIDs []uuid.UUID `json:"security_group_ids"`
+}
+
+// AssignPrivateIPsRequest represents the body for assigning secondary private IPs.
+type AssignPrivateIPsRequest struct {
+	PrivateIPs []string `json:"private_ips" binding:"omitempty,dive,ipv4"`
+	Count      int      `json:"count" binding:"min=0"`
+}
+
+// UnassignPrivateIPsRequest represents the body for removing secondary private IPs.
+type UnassignPrivateIPsRequest struct {
+	PrivateIPs []string `json:"private_ips" binding:"required,min=1"`
+}
+
+// SetNetworkInterfaceSecurityGroupsRequest represents the body for replacing the security groups of an interface.
+type SetNetworkInterfaceSecurityGroupsRequest struct {
+	SecurityGroupIDs []uuid.UUID `json:"security_group_ids"`
+}
+
+// AttachNetworkInterfaceRequest represents the body for attaching or moving a network interface.
+type AttachNetworkInterfaceRequest struct {
+	InstanceID  string `json:"instance_id" binding:"required,uuid"`
+	DeviceIndex int    `json:"device_index" binding:"min=0"`
+}
+
+// Create creates a network interface in a subnet.
+// @Summary Create Network Interface
+// @Tags network-interfaces
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param request body CreateNetworkInterfaceRequest true "Network Interface Request"
+// @Success 201 {object} domain.NetworkInterface
+// @Failure 400 {object} httputil.Response
+// @Failure 404 {object} httputil.Response
+// @Router /network-interfaces [post]
+func (h *NetworkInterfaceHandler) Create(c *gin.Context) {
+	var req CreateNetworkInterfaceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid request body"))
+		return
+	}
+
+	subnetID, _ := uuid.Parse(req.SubnetID)
+	ni, err := h.svc.CreateNetworkInterface(c.Request.Context(), subnetID, req.Description, req.PrivateIPs, req.SecurityGroupIDs)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusCreated, ni)
+}
+
+// List returns the network interfaces of the current tenant.
+// @Summary List Network Interfaces
+// @Tags network-interfaces
+// @Security APIKeyAuth
+// @Produce json
+// @Success 200 {array} domain.NetworkInterface
+// @Router /network-interfaces [get]
+func (h *NetworkInterfaceHandler) List(c *gin.Context) {
+	interfaces, err := h.svc.ListNetworkInterfaces(c.Request.Context())
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, interfaces)
+}
+
+// Get retrieves a network interface.
+// @Summary Get Network Interface
+// @Tags network-interfaces
+// @Security APIKeyAuth
+// @Produce json
+// @Param id path string true "Network Interface ID"
+// @Success 200 {object} domain.NetworkInterface
+// @Failure 404 {object} httputil.Response
+// @Router /network-interfaces/{id} [get]
+func (h *NetworkInterfaceHandler) Get(c *gin.Context) {
+	id, ok := parseNetworkInterfaceID(c)
+	if !ok {
+		return
+	}
+
+	ni, err := h.svc.GetNetworkInterface(c.Request.Context(), id)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, ni)
+}
+
+// Delete removes a detached network interface.
+// @Summary Delete Network Interface
+// @Tags network-interfaces
+// @Security APIKeyAuth
+// @Param id path string true "Network Interface ID"
+// @Success 204
+// @Failure 404 {object} httputil.Response
+// @Failure 409 {object} httputil.Response
+// @Router /network-interfaces/{id} [delete]
+func (h *NetworkInterfaceHandler) Delete(c *gin.Context) {
+	id, ok := parseNetworkInterfaceID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.DeleteNetworkInterface(c.Request.Context(), id); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// AssignPrivateIPs adds secondary private IPs to a network interface.
+// @Summary Assign Private IPs
+// @Tags network-interfaces
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Network Interface ID"
+// @Param request body AssignPrivateIPsRequest true "Assignment Request"
+// @Success 200 {object} domain.NetworkInterface
+// @Failure 400 {object} httputil.Response
+// @Router /network-interfaces/{id}/assign-private-ips [post]
+func (h *NetworkInterfaceHandler) AssignPrivateIPs(c *gin.Context) {
+	id, ok := parseNetworkInterfaceID(c)
+	if !ok {
+		return
+	}
+
+	var req AssignPrivateIPsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid request body"))
+		return
+	}
+
+	ni, err := h.svc.AssignPrivateIPs(c.Request.Context(), id, req.PrivateIPs, req.Count)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, ni)
+}
+
+// UnassignPrivateIPs removes secondary private IPs from a network interface.
+// @Summary Unassign Private IPs
+// @Tags network-interfaces
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Network Interface ID"
+// @Param request body UnassignPrivateIPsRequest true "Unassignment Request"
+// @Success 200 {object} domain.NetworkInterface
+// @Failure 400 {object} httputil.Response
+// @Failure 409 {object} httputil.Response
+// @Router /network-interfaces/{id}/unassign-private-ips [post]
+func (h *NetworkInterfaceHandler) UnassignPrivateIPs(c *gin.Context) {
+	id, ok := parseNetworkInterfaceID(c)
+	if !ok {
+		return
+	}
+
+	var req UnassignPrivateIPsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid request body"))
+		return
+	}
+
+	ni, err := h.svc.UnassignPrivateIPs(c.Request.Context(), id, req.PrivateIPs)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, ni)
+}
+
+// SetSecurityGroups replaces the security groups of a network interface.
+// @Summary Set Network Interface Security Groups
+// @Tags network-interfaces
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Network Interface ID"
+// @Param request body SetNetworkInterfaceSecurityGroupsRequest true "Security Groups Request"
+// @Success 200 {object} domain.NetworkInterface
+// @Failure 400 {object} httputil.Response
+// @Router /network-interfaces/{id}/security-groups [put]
+func (h *NetworkInterfaceHandler) SetSecurityGroups(c *gin.Context) {
+	id, ok := parseNetworkInterfaceID(c)
+	if !ok {
+		return
+	}
+
+	var req SetNetworkInterfaceSecurityGroupsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid request body"))
+		return
+	}
+
+	ni, err := h.svc.SetSecurityGroups(c.Request.Context(), id, req.SecurityGroupIDs)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, ni)
+}
+
+// Attach hot-plugs a network interface into a running instance.
+// @Summary Attach Network Interface
+// @Tags network-interfaces
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Network Interface ID"
+// @Param request body AttachNetworkInterfaceRequest true "Attach Request"
+// @Success 200 {object} domain.NetworkInterface
+// @Failure 400 {object} httputil.Response
+// @Failure 409 {object} httputil.Response
+// @Router /network-interfaces/{id}/attach [post]
+func (h *NetworkInterfaceHandler) Attach(c *gin.Context) {
+	id, ok := parseNetworkInterfaceID(c)
+	if !ok {
+		return
+	}
+
+	var req AttachNetworkInterfaceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid request body"))
+		return
+	}
+
+	instanceID, _ := uuid.Parse(req.InstanceID)
+	ni, err := h.svc.AttachNetworkInterface(c.Request.Context(), id, instanceID, req.DeviceIndex)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, ni)
+}
+
+// Detach unplugs a network interface from its instance.
+// @Summary Detach Network Interface
+// @Tags network-interfaces
+// @Security APIKeyAuth
+// @Produce json
+// @Param id path string true "Network Interface ID"
+// @Success 200 {object} domain.NetworkInterface
+// @Failure 400 {object} httputil.Response
+// @Router /network-interfaces/{id}/detach [post]
+func (h *NetworkInterfaceHandler) Detach(c *gin.Context) {
+	id, ok := parseNetworkInterfaceID(c)
+	if !ok {
+		return
+	}
+
+	ni, err := h.svc.DetachNetworkInterface(c.Request.Context(), id)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, ni)
+}
+
+// Move moves a network interface, with its private IPs and Elastic IPs, to another instance.
+// @Summary Move Network Interface
+// @Tags network-interfaces
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Network Interface ID"
+// @Param request body AttachNetworkInterfaceRequest true "Move Request"
+// @Success 200 {object} domain.NetworkInterface
+// @Failure 400 {object} httputil.Response
+// @Router /network-interfaces/{id}/move [post]
+func (h *NetworkInterfaceHandler) Move(c *gin.Context) {
+	id, ok := parseNetworkInterfaceID(c)
+	if !ok {
+		return
+	}
+
+	var req AttachNetworkInterfaceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid request body"))
+		return
+	}
+
+	instanceID, _ := uuid.Parse(req.InstanceID)
+	ni, err := h.svc.MoveNetworkInterface(c.Request.Context(), id, instanceID)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, ni)
+}
+
+func parseNetworkInterfaceID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidNetworkInterfaceIDMsg))
+		return uuid.Nil, false
+	}
+	return id, true
+}
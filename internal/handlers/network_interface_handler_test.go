@@ -0,0 +1,193 @@
+package httphandlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockNetworkInterfaceService struct {
+	mock.Mock
+}
+
+func (m *mockNetworkInterfaceService) result(args mock.Arguments) (*domain.NetworkInterface, error) {
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.NetworkInterface), args.Error(1)
+}
+
+func (m *mockNetworkInterfaceService) CreateNetworkInterface(ctx context.Context, subnetID uuid.UUID, description string, privateIPs []string, securityGroupIDs []uuid.UUID) (*domain.NetworkInterface, error) {
+	return m.result(m.Called(ctx, subnetID, description, privateIPs, securityGroupIDs))
+}
+
+func (m *mockNetworkInterfaceService) GetNetworkInterface(ctx context.Context, id uuid.UUID) (*domain.NetworkInterface, error) {
+	return m.result(m.Called(ctx, id))
+}
+
+func (m *mockNetworkInterfaceService) ListNetworkInterfaces(ctx context.Context) ([]*domain.NetworkInterface, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.NetworkInterface), args.Error(1)
+}
+
+func (m *mockNetworkInterfaceService) DeleteNetworkInterface(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *mockNetworkInterfaceService) AssignPrivateIPs(ctx context.Context, id uuid.UUID, privateIPs []string, count int) (*domain.NetworkInterface, error) {
+	return m.result(m.Called(ctx, id, privateIPs, count))
+}
+
+func (m *mockNetworkInterfaceService) UnassignPrivateIPs(ctx context.Context, id uuid.UUID, privateIPs []string) (*domain.NetworkInterface, error) {
+	return m.result(m.Called(ctx, id, privateIPs))
+}
+
+func (m *mockNetworkInterfaceService) SetSecurityGroups(ctx context.Context, id uuid.UUID, securityGroupIDs []uuid.UUID) (*domain.NetworkInterface, error) {
+	return m.result(m.Called(ctx, id, securityGroupIDs))
+}
+
+func (m *mockNetworkInterfaceService) AttachNetworkInterface(ctx context.Context, id, instanceID uuid.UUID, deviceIndex int) (*domain.NetworkInterface, error) {
+	return m.result(m.Called(ctx, id, instanceID, deviceIndex))
+}
+
+func (m *mockNetworkInterfaceService) DetachNetworkInterface(ctx context.Context, id uuid.UUID) (*domain.NetworkInterface, error) {
+	return m.result(m.Called(ctx, id))
+}
+
+func (m *mockNetworkInterfaceService) MoveNetworkInterface(ctx context.Context, id, instanceID uuid.UUID) (*domain.NetworkInterface, error) {
+	return m.result(m.Called(ctx, id, instanceID))
+}
+
+const networkInterfacesPath = "/network-interfaces"
+
+func setupNetworkInterfaceHandlerTest() (*mockNetworkInterfaceService, *NetworkInterfaceHandler, *gin.Engine) {
+	gin.SetMode(gin.TestMode)
+	svc := new(mockNetworkInterfaceService)
+	handler := NewNetworkInterfaceHandler(svc)
+	r := gin.New()
+	return svc, handler, r
+}
+
+func TestNetworkInterfaceHandlerCreate(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupNetworkInterfaceHandlerTest()
+	r.POST(networkInterfacesPath, handler.Create)
+
+	subnetID, sgID := uuid.New(), uuid.New()
+	svc.On("CreateNetworkInterface", mock.Anything, subnetID, "vip", []string{"10.0.1.50"}, []uuid.UUID{sgID}).
+		Return(&domain.NetworkInterface{ID: uuid.New()}, nil).Once()
+
+	w := httptest.NewRecorder()
+	body := `{"subnet_id":"` + subnetID.String() + `","description":"vip","private_ips":["10.0.1.50"],"security_group_ids":["` + sgID.String() + `"]}`
+	req := httptest.NewRequest(http.MethodPost, networkInterfacesPath, bytes.NewBufferString(body))
+	req.Header.Set(contentType, applicationJSON)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	svc.AssertExpectations(t)
+}
+
+func TestNetworkInterfaceHandlerCreateRejectsInvalidIP(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupNetworkInterfaceHandlerTest()
+	r.POST(networkInterfacesPath, handler.Create)
+
+	w := httptest.NewRecorder()
+	body := `{"subnet_id":"` + uuid.NewString() + `","private_ips":["not-an-ip"]}`
+	req := httptest.NewRequest(http.MethodPost, networkInterfacesPath, bytes.NewBufferString(body))
+	req.Header.Set(contentType, applicationJSON)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	svc.AssertNotCalled(t, "CreateNetworkInterface", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestNetworkInterfaceHandlerAssignPrivateIPs(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupNetworkInterfaceHandlerTest()
+	r.POST(networkInterfacesPath+"/:id/assign-private-ips", handler.AssignPrivateIPs)
+
+	id := uuid.New()
+	svc.On("AssignPrivateIPs", mock.Anything, id, []string(nil), 2).Return(&domain.NetworkInterface{ID: id}, nil).Once()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, networkInterfacesPath+"/"+id.String()+"/assign-private-ips", bytes.NewBufferString(`{"count":2}`))
+	req.Header.Set(contentType, applicationJSON)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	svc.AssertExpectations(t)
+}
+
+func TestNetworkInterfaceHandlerAttach(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupNetworkInterfaceHandlerTest()
+	r.POST(networkInterfacesPath+"/:id/attach", handler.Attach)
+
+	id, instID := uuid.New(), uuid.New()
+	svc.On("AttachNetworkInterface", mock.Anything, id, instID, 2).Return(&domain.NetworkInterface{ID: id, DeviceIndex: 2}, nil).Once()
+
+	w := httptest.NewRecorder()
+	body := `{"instance_id":"` + instID.String() + `","device_index":2}`
+	req := httptest.NewRequest(http.MethodPost, networkInterfacesPath+"/"+id.String()+"/attach", bytes.NewBufferString(body))
+	req.Header.Set(contentType, applicationJSON)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	svc.AssertExpectations(t)
+}
+
+func TestNetworkInterfaceHandlerMove(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupNetworkInterfaceHandlerTest()
+	r.POST(networkInterfacesPath+"/:id/move", handler.Move)
+
+	id, instID := uuid.New(), uuid.New()
+	svc.On("MoveNetworkInterface", mock.Anything, id, instID).Return(&domain.NetworkInterface{ID: id}, nil).Once()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, networkInterfacesPath+"/"+id.String()+"/move", bytes.NewBufferString(`{"instance_id":"`+instID.String()+`"}`))
+	req.Header.Set(contentType, applicationJSON)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	svc.AssertExpectations(t)
+}
+
+func TestNetworkInterfaceHandlerDeleteAttached(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupNetworkInterfaceHandlerTest()
+	r.DELETE(networkInterfacesPath+"/:id", handler.Delete)
+
+	id := uuid.New()
+	svc.On("DeleteNetworkInterface", mock.Anything, id).Return(errors.New(errors.Conflict, "network interface is attached to an instance; detach it first")).Once()
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, networkInterfacesPath+"/"+id.String(), nil))
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestNetworkInterfaceHandlerInvalidID(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupNetworkInterfaceHandlerTest()
+	r.POST(networkInterfacesPath+"/:id/detach", handler.Detach)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, networkInterfacesPath+"/not-a-uuid/detach", nil))
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	svc.AssertNotCalled(t, "DetachNetworkInterface", mock.Anything, mock.Anything)
+}
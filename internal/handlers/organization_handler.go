@@ -0,0 +1,299 @@
+package httphandlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/poyrazk/thecloud/pkg/httputil"
+)
+
+// OrganizationHandler handles HTTP requests for organizations and their guardrails.
+type OrganizationHandler struct {
+	svc ports.OrganizationService
+}
+
+// CreateOrganizationRequest is the body for creating an organization.
+type CreateOrganizationRequest struct {
+	Name string `json:"name" binding:"required"`
+	Slug string `json:"slug" binding:"required"`
+}
+
+// AddOrganizationTenantRequest is the body for adding a tenant to an organization.
+type AddOrganizationTenantRequest struct {
+	TenantID uuid.UUID `json:"tenant_id" binding:"required"`
+}
+
+// CreateGuardrailRequest is the body for creating a guardrail policy.
+type CreateGuardrailRequest struct {
+	Name        string             `json:"name" binding:"required"`
+	Description string             `json:"description"`
+	Statements  []domain.Statement `json:"statements" binding:"required"`
+}
+
+// NewOrganizationHandler creates a new OrganizationHandler.
+func NewOrganizationHandler(svc ports.OrganizationService) *OrganizationHandler {
+	return &OrganizationHandler{svc: svc}
+}
+
+// Create creates a new organization owned by the caller.
+// @Summary Create Organization
+// @Tags organizations
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param request body CreateOrganizationRequest true "Organization details"
+// @Success 201 {object} domain.Organization
+// @Failure 400 {object} httputil.Response
+// @Failure 409 {object} httputil.Response
+// @Router /organizations [post]
+func (h *OrganizationHandler) Create(c *gin.Context) {
+	var req CreateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid request body"))
+		return
+	}
+
+	org, err := h.svc.CreateOrganization(c.Request.Context(), req.Name, req.Slug)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusCreated, org)
+}
+
+// List returns the organizations owned by the caller.
+// @Summary List Organizations
+// @Tags organizations
+// @Security APIKeyAuth
+// @Produce json
+// @Success 200 {array} domain.Organization
+// @Router /organizations [get]
+func (h *OrganizationHandler) List(c *gin.Context) {
+	orgs, err := h.svc.ListOrganizations(c.Request.Context())
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, orgs)
+}
+
+// Get returns an organization.
+// @Summary Get Organization
+// @Tags organizations
+// @Security APIKeyAuth
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Success 200 {object} domain.Organization
+// @Failure 404 {object} httputil.Response
+// @Router /organizations/{id} [get]
+func (h *OrganizationHandler) Get(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	org, err := h.svc.GetOrganization(c.Request.Context(), orgID)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, org)
+}
+
+// Delete removes an organization. Member tenants are released, not deleted.
+// @Summary Delete Organization
+// @Tags organizations
+// @Security APIKeyAuth
+// @Param id path string true "Organization ID"
+// @Success 204
+// @Failure 404 {object} httputil.Response
+// @Router /organizations/{id} [delete]
+func (h *OrganizationHandler) Delete(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.DeleteOrganization(c.Request.Context(), orgID); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusNoContent, nil)
+}
+
+// ListTenants returns the IDs of the tenants in an organization.
+// @Summary List Organization Tenants
+// @Tags organizations
+// @Security APIKeyAuth
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Success 200 {array} string
+// @Failure 404 {object} httputil.Response
+// @Router /organizations/{id}/tenants [get]
+func (h *OrganizationHandler) ListTenants(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	tenantIDs, err := h.svc.ListTenants(c.Request.Context(), orgID)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, tenantIDs)
+}
+
+// AddTenant places a tenant owned by the caller under the organization.
+// @Summary Add Tenant to Organization
+// @Tags organizations
+// @Security APIKeyAuth
+// @Accept json
+// @Param id path string true "Organization ID"
+// @Param request body AddOrganizationTenantRequest true "Tenant to add"
+// @Success 204
+// @Failure 403 {object} httputil.Response
+// @Failure 404 {object} httputil.Response
+// @Failure 409 {object} httputil.Response
+// @Router /organizations/{id}/tenants [post]
+func (h *OrganizationHandler) AddTenant(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	var req AddOrganizationTenantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid request body"))
+		return
+	}
+
+	if err := h.svc.AddTenant(c.Request.Context(), orgID, req.TenantID); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusNoContent, nil)
+}
+
+// RemoveTenant releases a tenant from the organization.
+// @Summary Remove Tenant from Organization
+// @Tags organizations
+// @Security APIKeyAuth
+// @Param id path string true "Organization ID"
+// @Param tenantId path string true "Tenant ID"
+// @Success 204
+// @Failure 404 {object} httputil.Response
+// @Router /organizations/{id}/tenants/{tenantId} [delete]
+func (h *OrganizationHandler) RemoveTenant(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+	tenantID, err := uuid.Parse(c.Param("tenantId"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid tenant ID"))
+		return
+	}
+
+	if err := h.svc.RemoveTenant(c.Request.Context(), orgID, tenantID); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusNoContent, nil)
+}
+
+// CreateGuardrail attaches a deny-only guardrail policy to the organization.
+// @Summary Create Guardrail
+// @Description Guardrails apply to every member tenant and are evaluated before tenant IAM. Only Deny statements are accepted.
+// @Tags organizations
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Param request body CreateGuardrailRequest true "Guardrail policy"
+// @Success 201 {object} domain.GuardrailPolicy
+// @Failure 400 {object} httputil.Response
+// @Failure 404 {object} httputil.Response
+// @Router /organizations/{id}/guardrails [post]
+func (h *OrganizationHandler) CreateGuardrail(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	var req CreateGuardrailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid request body"))
+		return
+	}
+
+	policy, err := h.svc.CreateGuardrail(c.Request.Context(), orgID, req.Name, req.Description, req.Statements)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusCreated, policy)
+}
+
+// ListGuardrails returns the guardrails of an organization.
+// @Summary List Guardrails
+// @Tags organizations
+// @Security APIKeyAuth
+// @Produce json
+// @Param id path string true "Organization ID"
+// @Success 200 {array} domain.GuardrailPolicy
+// @Failure 404 {object} httputil.Response
+// @Router /organizations/{id}/guardrails [get]
+func (h *OrganizationHandler) ListGuardrails(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+
+	policies, err := h.svc.ListGuardrails(c.Request.Context(), orgID)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, policies)
+}
+
+// DeleteGuardrail removes a guardrail from an organization.
+// @Summary Delete Guardrail
+// @Tags organizations
+// @Security APIKeyAuth
+// @Param id path string true "Organization ID"
+// @Param guardrailId path string true "Guardrail ID"
+// @Success 204
+// @Failure 404 {object} httputil.Response
+// @Router /organizations/{id}/guardrails/{guardrailId} [delete]
+func (h *OrganizationHandler) DeleteGuardrail(c *gin.Context) {
+	orgID, ok := parseOrganizationID(c)
+	if !ok {
+		return
+	}
+	policyID, err := uuid.Parse(c.Param("guardrailId"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid guardrail ID"))
+		return
+	}
+
+	if err := h.svc.DeleteGuardrail(c.Request.Context(), orgID, policyID); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusNoContent, nil)
+}
+
+func parseOrganizationID(c *gin.Context) (uuid.UUID, bool) {
+	orgID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid organization ID"))
+		return uuid.Nil, false
+	}
+	return orgID, true
+}
@@ -0,0 +1,169 @@
+package httphandlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockOrganizationService struct {
+	mock.Mock
+}
+
+func (m *mockOrganizationService) CreateOrganization(ctx context.Context, name, slug string) (*domain.Organization, error) {
+	args := m.Called(ctx, name, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Organization), args.Error(1)
+}
+
+func (m *mockOrganizationService) GetOrganization(ctx context.Context, id uuid.UUID) (*domain.Organization, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Organization), args.Error(1)
+}
+
+func (m *mockOrganizationService) ListOrganizations(ctx context.Context) ([]*domain.Organization, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Organization), args.Error(1)
+}
+
+func (m *mockOrganizationService) DeleteOrganization(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *mockOrganizationService) AddTenant(ctx context.Context, orgID, tenantID uuid.UUID) error {
+	return m.Called(ctx, orgID, tenantID).Error(0)
+}
+
+func (m *mockOrganizationService) RemoveTenant(ctx context.Context, orgID, tenantID uuid.UUID) error {
+	return m.Called(ctx, orgID, tenantID).Error(0)
+}
+
+func (m *mockOrganizationService) ListTenants(ctx context.Context, orgID uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *mockOrganizationService) CreateGuardrail(ctx context.Context, orgID uuid.UUID, name, description string, statements []domain.Statement) (*domain.GuardrailPolicy, error) {
+	args := m.Called(ctx, orgID, name, description, statements)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.GuardrailPolicy), args.Error(1)
+}
+
+func (m *mockOrganizationService) ListGuardrails(ctx context.Context, orgID uuid.UUID) ([]*domain.GuardrailPolicy, error) {
+	args := m.Called(ctx, orgID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.GuardrailPolicy), args.Error(1)
+}
+
+func (m *mockOrganizationService) DeleteGuardrail(ctx context.Context, orgID, policyID uuid.UUID) error {
+	return m.Called(ctx, orgID, policyID).Error(0)
+}
+
+func setupOrganizationHandlerTest() (*mockOrganizationService, *OrganizationHandler, *gin.Engine) {
+	gin.SetMode(gin.TestMode)
+	svc := new(mockOrganizationService)
+	handler := NewOrganizationHandler(svc)
+	r := gin.New()
+	return svc, handler, r
+}
+
+func TestOrganizationHandler(t *testing.T) {
+	orgID := uuid.New()
+
+	t.Run("Create", func(t *testing.T) {
+		svc, handler, r := setupOrganizationHandlerTest()
+		r.POST("/organizations", handler.Create)
+
+		svc.On("CreateOrganization", mock.Anything, "Acme", "acme").Return(&domain.Organization{ID: orgID, Name: "Acme", Slug: "acme"}, nil)
+
+		body, _ := json.Marshal(CreateOrganizationRequest{Name: "Acme", Slug: "acme"})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/organizations", bytes.NewBuffer(body))
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		svc.AssertExpectations(t)
+	})
+
+	t.Run("Get_InvalidID", func(t *testing.T) {
+		_, handler, r := setupOrganizationHandlerTest()
+		r.GET("/organizations/:id", handler.Get)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/organizations/not-a-uuid", nil)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("AddTenant_Forbidden", func(t *testing.T) {
+		svc, handler, r := setupOrganizationHandlerTest()
+		r.POST("/organizations/:id/tenants", handler.AddTenant)
+
+		tenantID := uuid.New()
+		svc.On("AddTenant", mock.Anything, orgID, tenantID).Return(errors.New(errors.Forbidden, "not tenant owner"))
+
+		body, _ := json.Marshal(AddOrganizationTenantRequest{TenantID: tenantID})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/organizations/"+orgID.String()+"/tenants", bytes.NewBuffer(body))
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("CreateGuardrail", func(t *testing.T) {
+		svc, handler, r := setupOrganizationHandlerTest()
+		r.POST("/organizations/:id/guardrails", handler.CreateGuardrail)
+
+		statements := []domain.Statement{{Effect: domain.EffectDeny, Action: []string{"instance:launch"}, Resource: []string{"*"}}}
+		svc.On("CreateGuardrail", mock.Anything, orgID, "no-launch", "", statements).
+			Return(&domain.GuardrailPolicy{ID: uuid.New(), OrganizationID: orgID, Name: "no-launch"}, nil)
+
+		body, _ := json.Marshal(CreateGuardrailRequest{Name: "no-launch", Statements: statements})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/organizations/"+orgID.String()+"/guardrails", bytes.NewBuffer(body))
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		svc.AssertExpectations(t)
+	})
+
+	t.Run("DeleteGuardrail", func(t *testing.T) {
+		svc, handler, r := setupOrganizationHandlerTest()
+		r.DELETE("/organizations/:id/guardrails/:guardrailId", handler.DeleteGuardrail)
+
+		policyID := uuid.New()
+		svc.On("DeleteGuardrail", mock.Anything, orgID, policyID).Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, "/organizations/"+orgID.String()+"/guardrails/"+policyID.String(), nil)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+}
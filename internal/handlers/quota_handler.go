@@ -0,0 +1,237 @@
+package httphandlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/poyrazk/thecloud/pkg/httputil"
+)
+
+// QuotaHandler handles HTTP requests for tenant quotas and quota increase requests.
+type QuotaHandler struct {
+	svc ports.QuotaService
+}
+
+// QuotaIncreaseRequest is the body for requesting a higher quota limit.
+type QuotaIncreaseRequest struct {
+	Resource       domain.QuotaResource `json:"resource" binding:"required"`
+	RequestedLimit int                  `json:"requested_limit" binding:"required"`
+	Reason         string               `json:"reason"`
+}
+
+// SetQuotaLimitRequest is the body for overriding a tenant's quota limit.
+type SetQuotaLimitRequest struct {
+	Limit *int `json:"limit" binding:"required"`
+}
+
+// ReviewQuotaRequest is the body for approving or denying a quota increase request.
+type ReviewQuotaRequest struct {
+	Note string `json:"note"`
+}
+
+// NewQuotaHandler creates a new QuotaHandler.
+func NewQuotaHandler(svc ports.QuotaService) *QuotaHandler {
+	return &QuotaHandler{svc: svc}
+}
+
+// List returns the limits and usage of the caller's tenant.
+// @Summary List Quotas
+// @Tags quotas
+// @Security APIKeyAuth
+// @Produce json
+// @Success 200 {array} domain.ResourceQuota
+// @Router /quotas [get]
+func (h *QuotaHandler) List(c *gin.Context) {
+	quotas, err := h.svc.ListQuotas(c.Request.Context())
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, quotas)
+}
+
+// RequestIncrease files a quota increase request for the caller's tenant.
+// @Summary Request Quota Increase
+// @Tags quotas
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param request body QuotaIncreaseRequest true "Requested limit"
+// @Success 201 {object} domain.QuotaRequest
+// @Failure 400 {object} httputil.Response
+// @Failure 409 {object} httputil.Response
+// @Router /quotas/requests [post]
+func (h *QuotaHandler) RequestIncrease(c *gin.Context) {
+	var req QuotaIncreaseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid request body"))
+		return
+	}
+
+	qr, err := h.svc.RequestIncrease(c.Request.Context(), req.Resource, req.RequestedLimit, req.Reason)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusCreated, qr)
+}
+
+// ListRequests returns the quota increase requests filed by the caller's tenant.
+// @Summary List Quota Requests
+// @Tags quotas
+// @Security APIKeyAuth
+// @Produce json
+// @Success 200 {array} domain.QuotaRequest
+// @Router /quotas/requests [get]
+func (h *QuotaHandler) ListRequests(c *gin.Context) {
+	reqs, err := h.svc.ListRequests(c.Request.Context())
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, reqs)
+}
+
+// ListTenantQuotas returns the limits and usage of any tenant.
+// @Summary List Tenant Quotas (admin)
+// @Tags quotas
+// @Security APIKeyAuth
+// @Produce json
+// @Param tenantId path string true "Tenant ID"
+// @Success 200 {array} domain.ResourceQuota
+// @Failure 403 {object} httputil.Response
+// @Router /admin/quotas/tenants/{tenantId} [get]
+func (h *QuotaHandler) ListTenantQuotas(c *gin.Context) {
+	tenantID, ok := parseQuotaTenantID(c)
+	if !ok {
+		return
+	}
+
+	quotas, err := h.svc.ListTenantQuotas(c.Request.Context(), tenantID)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, quotas)
+}
+
+// SetLimit overrides a tenant's limit for one resource type.
+// @Summary Set Tenant Quota Limit (admin)
+// @Tags quotas
+// @Security APIKeyAuth
+// @Accept json
+// @Param tenantId path string true "Tenant ID"
+// @Param resource path string true "Quota resource"
+// @Param request body SetQuotaLimitRequest true "New limit"
+// @Success 204
+// @Failure 400 {object} httputil.Response
+// @Failure 403 {object} httputil.Response
+// @Router /admin/quotas/tenants/{tenantId}/{resource} [put]
+func (h *QuotaHandler) SetLimit(c *gin.Context) {
+	tenantID, ok := parseQuotaTenantID(c)
+	if !ok {
+		return
+	}
+
+	var req SetQuotaLimitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid request body"))
+		return
+	}
+
+	resource := domain.QuotaResource(c.Param("resource"))
+	if err := h.svc.SetLimit(c.Request.Context(), tenantID, resource, *req.Limit); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusNoContent, nil)
+}
+
+// ListAllRequests returns quota increase requests across all tenants.
+// @Summary List All Quota Requests (admin)
+// @Tags quotas
+// @Security APIKeyAuth
+// @Produce json
+// @Param status query string false "Filter by status (pending, approved, denied)"
+// @Success 200 {array} domain.QuotaRequest
+// @Failure 403 {object} httputil.Response
+// @Router /admin/quotas/requests [get]
+func (h *QuotaHandler) ListAllRequests(c *gin.Context) {
+	reqs, err := h.svc.ListAllRequests(c.Request.Context(), domain.QuotaRequestStatus(c.Query("status")))
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, reqs)
+}
+
+// Approve approves a pending quota increase request and applies the new limit.
+// @Summary Approve Quota Request (admin)
+// @Tags quotas
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Request ID"
+// @Param request body ReviewQuotaRequest false "Review note"
+// @Success 200 {object} domain.QuotaRequest
+// @Failure 403 {object} httputil.Response
+// @Failure 409 {object} httputil.Response
+// @Router /admin/quotas/requests/{id}/approve [post]
+func (h *QuotaHandler) Approve(c *gin.Context) {
+	h.review(c, h.svc.ApproveRequest)
+}
+
+// Deny denies a pending quota increase request.
+// @Summary Deny Quota Request (admin)
+// @Tags quotas
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Request ID"
+// @Param request body ReviewQuotaRequest false "Review note"
+// @Success 200 {object} domain.QuotaRequest
+// @Failure 403 {object} httputil.Response
+// @Failure 409 {object} httputil.Response
+// @Router /admin/quotas/requests/{id}/deny [post]
+func (h *QuotaHandler) Deny(c *gin.Context) {
+	h.review(c, h.svc.DenyRequest)
+}
+
+type quotaReviewFunc func(ctx context.Context, id uuid.UUID, note string) (*domain.QuotaRequest, error)
+
+func (h *QuotaHandler) review(c *gin.Context, fn quotaReviewFunc) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid quota request ID"))
+		return
+	}
+
+	var req ReviewQuotaRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			httputil.Error(c, errors.New(errors.InvalidInput, "invalid request body"))
+			return
+		}
+	}
+
+	qr, err := fn(c.Request.Context(), id, req.Note)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, qr)
+}
+
+func parseQuotaTenantID(c *gin.Context) (uuid.UUID, bool) {
+	tenantID, err := uuid.Parse(c.Param("tenantId"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid tenant ID"))
+		return uuid.Nil, false
+	}
+	return tenantID, true
+}
@@ -0,0 +1,220 @@
+package httphandlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockQuotaService struct {
+	mock.Mock
+}
+
+func (m *mockQuotaService) ListQuotas(ctx context.Context) ([]*domain.ResourceQuota, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ResourceQuota), args.Error(1)
+}
+
+func (m *mockQuotaService) RequestIncrease(ctx context.Context, resource domain.QuotaResource, requestedLimit int, reason string) (*domain.QuotaRequest, error) {
+	args := m.Called(ctx, resource, requestedLimit, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.QuotaRequest), args.Error(1)
+}
+
+func (m *mockQuotaService) ListRequests(ctx context.Context) ([]*domain.QuotaRequest, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.QuotaRequest), args.Error(1)
+}
+
+func (m *mockQuotaService) ListTenantQuotas(ctx context.Context, tenantID uuid.UUID) ([]*domain.ResourceQuota, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ResourceQuota), args.Error(1)
+}
+
+func (m *mockQuotaService) SetLimit(ctx context.Context, tenantID uuid.UUID, resource domain.QuotaResource, limit int) error {
+	return m.Called(ctx, tenantID, resource, limit).Error(0)
+}
+
+func (m *mockQuotaService) ListAllRequests(ctx context.Context, status domain.QuotaRequestStatus) ([]*domain.QuotaRequest, error) {
+	args := m.Called(ctx, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.QuotaRequest), args.Error(1)
+}
+
+func (m *mockQuotaService) ApproveRequest(ctx context.Context, id uuid.UUID, note string) (*domain.QuotaRequest, error) {
+	args := m.Called(ctx, id, note)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.QuotaRequest), args.Error(1)
+}
+
+func (m *mockQuotaService) DenyRequest(ctx context.Context, id uuid.UUID, note string) (*domain.QuotaRequest, error) {
+	args := m.Called(ctx, id, note)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.QuotaRequest), args.Error(1)
+}
+
+func (m *mockQuotaService) ReconcileUsage(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func setupQuotaHandlerTest() (*mockQuotaService, *QuotaHandler, *gin.Engine) {
+	gin.SetMode(gin.TestMode)
+	svc := new(mockQuotaService)
+	handler := NewQuotaHandler(svc)
+	r := gin.New()
+	return svc, handler, r
+}
+
+func TestQuotaHandler(t *testing.T) {
+	tenantID := uuid.New()
+
+	t.Run("List", func(t *testing.T) {
+		svc, handler, r := setupQuotaHandlerTest()
+		r.GET("/quotas", handler.List)
+
+		svc.On("ListQuotas", mock.Anything).Return([]*domain.ResourceQuota{{Resource: domain.QuotaVCPUs, Limit: 8, Used: 2}}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/quotas", nil)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"resource":"vcpus"`)
+	})
+
+	t.Run("RequestIncrease", func(t *testing.T) {
+		svc, handler, r := setupQuotaHandlerTest()
+		r.POST("/quotas/requests", handler.RequestIncrease)
+
+		svc.On("RequestIncrease", mock.Anything, domain.QuotaVCPUs, 32, "training").
+			Return(&domain.QuotaRequest{ID: uuid.New(), Status: domain.QuotaRequestPending}, nil)
+
+		body, _ := json.Marshal(QuotaIncreaseRequest{Resource: domain.QuotaVCPUs, RequestedLimit: 32, Reason: "training"})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/quotas/requests", bytes.NewBuffer(body))
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		svc.AssertExpectations(t)
+	})
+
+	t.Run("RequestIncrease_InvalidBody", func(t *testing.T) {
+		_, handler, r := setupQuotaHandlerTest()
+		r.POST("/quotas/requests", handler.RequestIncrease)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/quotas/requests", bytes.NewBufferString(`{"resource":"vcpus"}`))
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("SetLimit", func(t *testing.T) {
+		svc, handler, r := setupQuotaHandlerTest()
+		r.PUT("/admin/quotas/tenants/:tenantId/:resource", handler.SetLimit)
+
+		svc.On("SetLimit", mock.Anything, tenantID, domain.QuotaBuckets, 0).Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, "/admin/quotas/tenants/"+tenantID.String()+"/buckets", bytes.NewBufferString(`{"limit":0}`))
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		svc.AssertExpectations(t)
+	})
+
+	t.Run("SetLimit_Forbidden", func(t *testing.T) {
+		svc, handler, r := setupQuotaHandlerTest()
+		r.PUT("/admin/quotas/tenants/:tenantId/:resource", handler.SetLimit)
+
+		svc.On("SetLimit", mock.Anything, tenantID, domain.QuotaVCPUs, 64).Return(errors.New(errors.Forbidden, "permission denied"))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, "/admin/quotas/tenants/"+tenantID.String()+"/vcpus", bytes.NewBufferString(`{"limit":64}`))
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("ListAllRequests_StatusFilter", func(t *testing.T) {
+		svc, handler, r := setupQuotaHandlerTest()
+		r.GET("/admin/quotas/requests", handler.ListAllRequests)
+
+		svc.On("ListAllRequests", mock.Anything, domain.QuotaRequestPending).Return([]*domain.QuotaRequest{}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/admin/quotas/requests?status=pending", nil)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		svc.AssertExpectations(t)
+	})
+
+	t.Run("Approve_WithoutBody", func(t *testing.T) {
+		svc, handler, r := setupQuotaHandlerTest()
+		r.POST("/admin/quotas/requests/:id/approve", handler.Approve)
+
+		id := uuid.New()
+		svc.On("ApproveRequest", mock.Anything, id, "").Return(&domain.QuotaRequest{ID: id, Status: domain.QuotaRequestApproved}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/admin/quotas/requests/"+id.String()+"/approve", nil)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		svc.AssertExpectations(t)
+	})
+
+	t.Run("Deny_Conflict", func(t *testing.T) {
+		svc, handler, r := setupQuotaHandlerTest()
+		r.POST("/admin/quotas/requests/:id/deny", handler.Deny)
+
+		id := uuid.New()
+		svc.On("DenyRequest", mock.Anything, id, "not now").Return(nil, errors.New(errors.Conflict, "quota request was already approved"))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/admin/quotas/requests/"+id.String()+"/deny", bytes.NewBufferString(`{"note":"not now"}`))
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Approve_InvalidID", func(t *testing.T) {
+		_, handler, r := setupQuotaHandlerTest()
+		r.POST("/admin/quotas/requests/:id/approve", handler.Approve)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/admin/quotas/requests/bad/approve", nil)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
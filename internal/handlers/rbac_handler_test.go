@@ -39,6 +39,10 @@ func (m *mockRBACService) HasPermission(ctx context.Context, userID uuid.UUID, t
 	args := m.Called(ctx, userID, tenantID, permission, resource)
 	return args.Bool(0), args.Error(1)
 }
+func (m *mockRBACService) ResourcePolicyOwner(ctx context.Context, userID uuid.UUID, tenantID uuid.UUID, permission domain.Permission, resource string) (uuid.UUID, bool) {
+	args := m.Called(ctx, userID, tenantID, permission, resource)
+	return args.Get(0).(uuid.UUID), args.Bool(1)
+}
 func (m *mockRBACService) CreateRole(ctx context.Context, role *domain.Role) error {
 	args := m.Called(ctx, role)
 	return args.Error(0)
@@ -0,0 +1,108 @@
+package httphandlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/poyrazk/thecloud/pkg/httputil"
+)
+
+// ResourcePolicyHandler handles HTTP requests for policies attached to resources.
+type ResourcePolicyHandler struct {
+	svc ports.ResourcePolicyService
+}
+
+// PutResourcePolicyRequest is the body for attaching a policy to a resource.
+type PutResourcePolicyRequest struct {
+	Statements []domain.Statement `json:"statements" binding:"required"`
+}
+
+// NewResourcePolicyHandler creates a new ResourcePolicyHandler.
+func NewResourcePolicyHandler(svc ports.ResourcePolicyService) *ResourcePolicyHandler {
+	return &ResourcePolicyHandler{svc: svc}
+}
+
+// Put attaches or replaces the policy on a resource.
+// @Summary Put Resource Policy
+// @Description Attach a resource policy to a bucket, queue, topic or secret, granting principals from any tenant access to it.
+// @Tags iam
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param type path string true "Resource type (bucket, queue, topic, secret)"
+// @Param id path string true "Resource ID (bucket name or UUID)"
+// @Param request body PutResourcePolicyRequest true "Policy statements"
+// @Success 200 {object} domain.ResourcePolicy
+// @Failure 400 {object} httputil.Response
+// @Failure 404 {object} httputil.Response
+// @Router /iam/resource-policies/{type}/{id} [put]
+func (h *ResourcePolicyHandler) Put(c *gin.Context) {
+	var req PutResourcePolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid request body"))
+		return
+	}
+
+	policy, err := h.svc.PutResourcePolicy(c.Request.Context(), domain.ResourcePolicyType(c.Param("type")), c.Param("id"), req.Statements)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, policy)
+}
+
+// Get returns the policy attached to a resource.
+// @Summary Get Resource Policy
+// @Tags iam
+// @Security APIKeyAuth
+// @Produce json
+// @Param type path string true "Resource type (bucket, queue, topic, secret)"
+// @Param id path string true "Resource ID (bucket name or UUID)"
+// @Success 200 {object} domain.ResourcePolicy
+// @Failure 404 {object} httputil.Response
+// @Router /iam/resource-policies/{type}/{id} [get]
+func (h *ResourcePolicyHandler) Get(c *gin.Context) {
+	policy, err := h.svc.GetResourcePolicy(c.Request.Context(), domain.ResourcePolicyType(c.Param("type")), c.Param("id"))
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, policy)
+}
+
+// List returns all resource policies owned by the tenant.
+// @Summary List Resource Policies
+// @Tags iam
+// @Security APIKeyAuth
+// @Produce json
+// @Success 200 {array} domain.ResourcePolicy
+// @Router /iam/resource-policies [get]
+func (h *ResourcePolicyHandler) List(c *gin.Context) {
+	policies, err := h.svc.ListResourcePolicies(c.Request.Context())
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, policies)
+}
+
+// Delete detaches the policy from a resource.
+// @Summary Delete Resource Policy
+// @Tags iam
+// @Security APIKeyAuth
+// @Produce json
+// @Param type path string true "Resource type (bucket, queue, topic, secret)"
+// @Param id path string true "Resource ID (bucket name or UUID)"
+// @Success 200 {object} map[string]string
+// @Failure 404 {object} httputil.Response
+// @Router /iam/resource-policies/{type}/{id} [delete]
+func (h *ResourcePolicyHandler) Delete(c *gin.Context) {
+	if err := h.svc.DeleteResourcePolicy(c.Request.Context(), domain.ResourcePolicyType(c.Param("type")), c.Param("id")); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, gin.H{"status": "deleted"})
+}
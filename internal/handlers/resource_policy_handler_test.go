@@ -0,0 +1,128 @@
+package httphandlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const resourcePolicyTestRoute = "/iam/resource-policies/:type/:id"
+
+type mockResourcePolicyService struct {
+	mock.Mock
+}
+
+func (m *mockResourcePolicyService) PutResourcePolicy(ctx context.Context, resourceType domain.ResourcePolicyType, resourceID string, statements []domain.Statement) (*domain.ResourcePolicy, error) {
+	args := m.Called(ctx, resourceType, resourceID, statements)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ResourcePolicy), args.Error(1)
+}
+
+func (m *mockResourcePolicyService) GetResourcePolicy(ctx context.Context, resourceType domain.ResourcePolicyType, resourceID string) (*domain.ResourcePolicy, error) {
+	args := m.Called(ctx, resourceType, resourceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ResourcePolicy), args.Error(1)
+}
+
+func (m *mockResourcePolicyService) ListResourcePolicies(ctx context.Context) ([]*domain.ResourcePolicy, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ResourcePolicy), args.Error(1)
+}
+
+func (m *mockResourcePolicyService) DeleteResourcePolicy(ctx context.Context, resourceType domain.ResourcePolicyType, resourceID string) error {
+	return m.Called(ctx, resourceType, resourceID).Error(0)
+}
+
+func setupResourcePolicyHandlerTest() (*mockResourcePolicyService, *ResourcePolicyHandler, *gin.Engine) {
+	gin.SetMode(gin.TestMode)
+	svc := new(mockResourcePolicyService)
+	handler := NewResourcePolicyHandler(svc)
+	r := gin.New()
+	return svc, handler, r
+}
+
+func TestResourcePolicyHandler(t *testing.T) {
+	t.Run("Put", func(t *testing.T) {
+		svc, handler, r := setupResourcePolicyHandlerTest()
+		r.PUT(resourcePolicyTestRoute, handler.Put)
+
+		statements := []domain.Statement{{Effect: domain.EffectAllow, Principal: []string{"*"}, Action: []string{"storage:read"}, Resource: []string{"*"}}}
+		svc.On("PutResourcePolicy", mock.Anything, domain.ResourcePolicyBucket, "shared", statements).
+			Return(&domain.ResourcePolicy{ResourceType: domain.ResourcePolicyBucket, ResourceID: "shared"}, nil)
+
+		body, _ := json.Marshal(PutResourcePolicyRequest{Statements: statements})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, "/iam/resource-policies/bucket/shared", bytes.NewBuffer(body))
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		svc.AssertExpectations(t)
+	})
+
+	t.Run("Put_InvalidBody", func(t *testing.T) {
+		_, handler, r := setupResourcePolicyHandlerTest()
+		r.PUT(resourcePolicyTestRoute, handler.Put)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPut, "/iam/resource-policies/bucket/shared", bytes.NewBufferString("{"))
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Get_NotFound", func(t *testing.T) {
+		svc, handler, r := setupResourcePolicyHandlerTest()
+		r.GET(resourcePolicyTestRoute, handler.Get)
+
+		svc.On("GetResourcePolicy", mock.Anything, domain.ResourcePolicyQueue, "q1").Return(nil, errors.New(errors.NotFound, "resource policy not found"))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/iam/resource-policies/queue/q1", nil)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		svc, handler, r := setupResourcePolicyHandlerTest()
+		r.GET("/iam/resource-policies", handler.List)
+
+		svc.On("ListResourcePolicies", mock.Anything).Return([]*domain.ResourcePolicy{{ResourceID: "shared"}}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/iam/resource-policies", nil)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		svc, handler, r := setupResourcePolicyHandlerTest()
+		r.DELETE(resourcePolicyTestRoute, handler.Delete)
+
+		svc.On("DeleteResourcePolicy", mock.Anything, domain.ResourcePolicyBucket, "shared").Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodDelete, "/iam/resource-policies/bucket/shared", nil)
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		svc.AssertExpectations(t)
+	})
+}
@@ -4,6 +4,7 @@ package httphandlers
 import (
 	"context"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -16,12 +17,13 @@ import (
 
 // SecretHandler handles secret HTTP endpoints.
 type SecretHandler struct {
-	svc ports.SecretService
+	svc         ports.SecretService
+	rotationSvc ports.SecretRotationService
 }
 
 // NewSecretHandler constructs a SecretHandler.
-func NewSecretHandler(svc ports.SecretService) *SecretHandler {
-	return &SecretHandler{svc: svc}
+func NewSecretHandler(svc ports.SecretService, rotationSvc ports.SecretRotationService) *SecretHandler {
+	return &SecretHandler{svc: svc, rotationSvc: rotationSvc}
 }
 
 // CreateSecretRequest is the payload for secret creation.
@@ -31,6 +33,23 @@ type CreateSecretRequest struct {
 	Description string `json:"description"`
 }
 
+// PutSecretValueRequest is the payload for storing a new secret version.
+type PutSecretValueRequest struct {
+	Value string             `json:"value" binding:"required"`
+	Stage domain.SecretStage `json:"stage"` // current (default) or pending
+}
+
+// MoveSecretStageRequest is the payload for pointing a stage at a version.
+type MoveSecretStageRequest struct {
+	Version int `json:"version" binding:"required"`
+}
+
+// ConfigureSecretRotationRequest is the payload for enabling scheduled rotation.
+type ConfigureSecretRotationRequest struct {
+	FunctionID   uuid.UUID `json:"function_id" binding:"required"`
+	IntervalDays int       `json:"interval_days" binding:"required"`
+}
+
 func (h *SecretHandler) Create(c *gin.Context) {
 	var req CreateSecretRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -127,3 +146,138 @@ func (h *SecretHandler) Delete(c *gin.Context) {
 
 	httputil.Success(c, http.StatusOK, gin.H{"message": "secret deleted"})
 }
+
+func (h *SecretHandler) PutValue(c *gin.Context) {
+	id, err := h.resolveShortID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	var req PutSecretValueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid request body"))
+		return
+	}
+
+	version, err := h.svc.PutSecretValue(c.Request.Context(), id, req.Value, req.Stage)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	httputil.Success(c, http.StatusCreated, version)
+}
+
+func (h *SecretHandler) ListVersions(c *gin.Context) {
+	id, err := h.resolveShortID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	versions, err := h.svc.ListSecretVersions(c.Request.Context(), id)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	httputil.Success(c, http.StatusOK, versions)
+}
+
+// GetVersion accepts either a version number or a stage name, e.g. /versions/3 or /versions/previous.
+func (h *SecretHandler) GetVersion(c *gin.Context) {
+	id, err := h.resolveShortID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	var version *domain.SecretVersion
+	ref := c.Param("version")
+	if n, convErr := strconv.Atoi(ref); convErr == nil {
+		version, err = h.svc.GetSecretVersion(c.Request.Context(), id, n)
+	} else {
+		version, err = h.svc.GetSecretVersionByStage(c.Request.Context(), id, domain.SecretStage(ref))
+	}
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	httputil.Success(c, http.StatusOK, version)
+}
+
+func (h *SecretHandler) MoveStage(c *gin.Context) {
+	id, err := h.resolveShortID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	var req MoveSecretStageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid request body"))
+		return
+	}
+
+	if err := h.svc.UpdateSecretVersionStage(c.Request.Context(), id, domain.SecretStage(c.Param("stage")), req.Version); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	httputil.Success(c, http.StatusNoContent, nil)
+}
+
+func (h *SecretHandler) ConfigureRotation(c *gin.Context) {
+	id, err := h.resolveShortID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	var req ConfigureSecretRotationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid request body"))
+		return
+	}
+
+	secret, err := h.rotationSvc.ConfigureRotation(c.Request.Context(), id, req.FunctionID, req.IntervalDays)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	httputil.Success(c, http.StatusOK, secret)
+}
+
+func (h *SecretHandler) DisableRotation(c *gin.Context) {
+	id, err := h.resolveShortID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	if err := h.rotationSvc.DisableRotation(c.Request.Context(), id); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	httputil.Success(c, http.StatusNoContent, nil)
+}
+
+func (h *SecretHandler) Rotate(c *gin.Context) {
+	id, err := h.resolveShortID(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	version, err := h.rotationSvc.RotateSecret(c.Request.Context(), id)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	httputil.Success(c, http.StatusOK, version)
+}
@@ -78,10 +78,43 @@ func (m *mockSecretService) Decrypt(ctx context.Context, userID uuid.UUID, ciphe
 	return args.String(0), args.Error(1)
 }
 
+func (m *mockSecretService) PutSecretValue(ctx context.Context, id uuid.UUID, value string, stage domain.SecretStage) (*domain.SecretVersion, error) {
+	args := m.Called(ctx, id, value, stage)
+	r0, _ := args.Get(0).(*domain.SecretVersion)
+	return r0, args.Error(1)
+}
+
+func (m *mockSecretService) ListSecretVersions(ctx context.Context, id uuid.UUID) ([]*domain.SecretVersion, error) {
+	args := m.Called(ctx, id)
+	r0, _ := args.Get(0).([]*domain.SecretVersion)
+	return r0, args.Error(1)
+}
+
+func (m *mockSecretService) GetSecretVersion(ctx context.Context, id uuid.UUID, version int) (*domain.SecretVersion, error) {
+	args := m.Called(ctx, id, version)
+	r0, _ := args.Get(0).(*domain.SecretVersion)
+	return r0, args.Error(1)
+}
+
+func (m *mockSecretService) GetSecretVersionByStage(ctx context.Context, id uuid.UUID, stage domain.SecretStage) (*domain.SecretVersion, error) {
+	args := m.Called(ctx, id, stage)
+	r0, _ := args.Get(0).(*domain.SecretVersion)
+	return r0, args.Error(1)
+}
+
+func (m *mockSecretService) UpdateSecretVersionStage(ctx context.Context, id uuid.UUID, stage domain.SecretStage, version int) error {
+	return m.Called(ctx, id, stage, version).Error(0)
+}
+
+func (m *mockSecretService) ResolveSecretRef(ctx context.Context, ref string) (string, error) {
+	args := m.Called(ctx, ref)
+	return args.String(0), args.Error(1)
+}
+
 func setupSecretHandlerTest(_ *testing.T) (*mockSecretService, *SecretHandler, *gin.Engine) {
 	gin.SetMode(gin.TestMode)
 	svc := new(mockSecretService)
-	handler := NewSecretHandler(svc)
+	handler := NewSecretHandler(svc, nil)
 	r := gin.New()
 	return svc, handler, r
 }
@@ -375,3 +408,130 @@ func TestSecretHandlerGetError(t *testing.T) {
 		svc.AssertExpectations(t)
 	})
 }
+
+type mockSecretRotationService struct {
+	mock.Mock
+}
+
+func (m *mockSecretRotationService) ConfigureRotation(ctx context.Context, id, functionID uuid.UUID, intervalDays int) (*domain.Secret, error) {
+	args := m.Called(ctx, id, functionID, intervalDays)
+	r0, _ := args.Get(0).(*domain.Secret)
+	return r0, args.Error(1)
+}
+
+func (m *mockSecretRotationService) DisableRotation(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *mockSecretRotationService) RotateSecret(ctx context.Context, id uuid.UUID) (*domain.SecretVersion, error) {
+	args := m.Called(ctx, id)
+	r0, _ := args.Get(0).(*domain.SecretVersion)
+	return r0, args.Error(1)
+}
+
+func (m *mockSecretRotationService) RotateDue(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func TestSecretHandlerPutValue(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupSecretHandlerTest(t)
+	r.PUT(secretsPath+"/:id/value", handler.PutValue)
+
+	id := uuid.New()
+	svc.On("PutSecretValue", mock.Anything, id, "v2", domain.SecretStagePending).
+		Return(&domain.SecretVersion{SecretID: id, Version: 2, Stages: []domain.SecretStage{domain.SecretStagePending}}, nil)
+
+	body := []byte(`{"value":"v2","stage":"pending"}`)
+	req, _ := http.NewRequest(http.MethodPut, secretsPath+"/"+id.String()+"/value", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	svc.AssertExpectations(t)
+}
+
+func TestSecretHandlerGetVersion(t *testing.T) {
+	t.Parallel()
+	t.Run("ByNumber", func(t *testing.T) {
+		svc, handler, r := setupSecretHandlerTest(t)
+		r.GET(secretsPath+"/:id/versions/:version", handler.GetVersion)
+		id := uuid.New()
+		svc.On("GetSecretVersion", mock.Anything, id, 3).Return(&domain.SecretVersion{Version: 3, EncryptedValue: "v3"}, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, secretsPath+"/"+id.String()+"/versions/3", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"value":"v3"`)
+		svc.AssertExpectations(t)
+	})
+
+	t.Run("ByStage", func(t *testing.T) {
+		svc, handler, r := setupSecretHandlerTest(t)
+		r.GET(secretsPath+"/:id/versions/:version", handler.GetVersion)
+		id := uuid.New()
+		svc.On("GetSecretVersionByStage", mock.Anything, id, domain.SecretStagePrevious).Return(&domain.SecretVersion{Version: 1}, nil)
+
+		req, _ := http.NewRequest(http.MethodGet, secretsPath+"/"+id.String()+"/versions/previous", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		svc.AssertExpectations(t)
+	})
+}
+
+func TestSecretHandlerMoveStage(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupSecretHandlerTest(t)
+	r.PUT(secretsPath+"/:id/stages/:stage", handler.MoveStage)
+
+	id := uuid.New()
+	svc.On("UpdateSecretVersionStage", mock.Anything, id, domain.SecretStageCurrent, 2).Return(nil)
+
+	req, _ := http.NewRequest(http.MethodPut, secretsPath+"/"+id.String()+"/stages/current", bytes.NewBufferString(`{"version":2}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	svc.AssertExpectations(t)
+}
+
+func TestSecretHandlerRotation(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	svc := new(mockSecretService)
+	rotationSvc := new(mockSecretRotationService)
+	handler := NewSecretHandler(svc, rotationSvc)
+	r := gin.New()
+	r.PUT(secretsPath+"/:id/rotation", handler.ConfigureRotation)
+	r.POST(secretsPath+"/:id/rotate", handler.Rotate)
+
+	id := uuid.New()
+	fnID := uuid.New()
+
+	t.Run("Configure", func(t *testing.T) {
+		rotationSvc.On("ConfigureRotation", mock.Anything, id, fnID, 30).Return(&domain.Secret{ID: id, RotationIntervalDays: 30}, nil).Once()
+		body, _ := json.Marshal(map[string]interface{}{"function_id": fnID, "interval_days": 30})
+		req, _ := http.NewRequest(http.MethodPut, secretsPath+"/"+id.String()+"/rotation", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("RotateNotConfigured", func(t *testing.T) {
+		rotationSvc.On("RotateSecret", mock.Anything, id).Return(nil, errors.New(errors.InvalidInput, "rotation is not configured for this secret")).Once()
+		req, _ := http.NewRequest(http.MethodPost, secretsPath+"/"+id.String()+"/rotate", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	rotationSvc.AssertExpectations(t)
+}
@@ -80,6 +80,16 @@ func (m *mockSecurityGroupService) DetachFromInstance(c context.Context, i uuid.
 	return args.Error(0)
 }
 
+func (m *mockSecurityGroupService) AttachToNetworkInterface(c context.Context, i uuid.UUID, g uuid.UUID) error {
+	args := m.Called(c, i, g)
+	return args.Error(0)
+}
+
+func (m *mockSecurityGroupService) DetachFromNetworkInterface(c context.Context, i uuid.UUID, g uuid.UUID) error {
+	args := m.Called(c, i, g)
+	return args.Error(0)
+}
+
 func (m *mockSecurityGroupService) RemoveRule(ctx context.Context, ruleID uuid.UUID) error {
 	args := m.Called(ctx, ruleID)
 	return args.Error(0)
@@ -99,3 +99,40 @@ func (h *SubnetHandler) Delete(c *gin.Context) {
 
 	httputil.Success(c, http.StatusOK, gin.H{"message": "subnet deleted"})
 }
+
+// AssignIPv6 gives a subnet a /64 out of its VPC's IPv6 block
+// @Summary Assign an IPv6 CIDR block to a subnet
+// @Description The next free /64 of the VPC's block is used when none is given
+// @Tags subnets
+// @Accept json
+// @Produce json
+// @Security APIKeyAuth
+// @Param id path string true "Subnet ID"
+// @Param request body AssignIPv6CIDRBlockRequest false "IPv6 block request"
+// @Success 200 {object} domain.Subnet
+// @Failure 400 {object} httputil.Response
+// @Failure 404 {object} httputil.Response
+// @Failure 409 {object} httputil.Response
+// @Router /subnets/{id}/ipv6 [post]
+func (h *SubnetHandler) AssignIPv6(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	var req AssignIPv6CIDRBlockRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			httputil.Error(c, err)
+			return
+		}
+	}
+
+	subnet, err := h.svc.AssignIPv6CIDRBlock(c.Request.Context(), id, req.IPv6CIDRBlock)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	httputil.Success(c, http.StatusOK, subnet)
+}
@@ -54,6 +54,15 @@ func (m *mockSubnetService) GetSubnet(ctx context.Context, idOrName string, vpcI
 	return r0, args.Error(1)
 }
 
+func (m *mockSubnetService) AssignIPv6CIDRBlock(ctx context.Context, id uuid.UUID, cidrBlock string) (*domain.Subnet, error) {
+	args := m.Called(ctx, id, cidrBlock)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	r0, _ := args.Get(0).(*domain.Subnet)
+	return r0, args.Error(1)
+}
+
 func (m *mockSubnetService) DeleteSubnet(ctx context.Context, id uuid.UUID) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
@@ -335,3 +344,24 @@ func TestSubnetHandlerDeleteServiceError(t *testing.T) {
 	assert.NotEqual(t, http.StatusOK, w.Code)
 	svc.AssertExpectations(t)
 }
+
+func TestSubnetHandlerAssignIPv6(t *testing.T) {
+	t.Parallel()
+	gin.SetMode(gin.TestMode)
+	svc := new(mockSubnetService)
+	handler := NewSubnetHandler(svc)
+
+	subnetID := uuid.New()
+	svc.On("AssignIPv6CIDRBlock", mock.Anything, subnetID, "fd12:3456:7801::/64").Return(&domain.Subnet{ID: subnetID, IPv6CIDRBlock: "fd12:3456:7801::/64"}, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/subnets/"+subnetID.String()+"/ipv6", bytes.NewBufferString(`{"ipv6_cidr_block":"fd12:3456:7801::/64"}`))
+	c.Request.Header.Set(contentType, applicationJSON)
+	c.Params = gin.Params{{Key: "id", Value: subnetID.String()}}
+
+	handler.AssignIPv6(c)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	svc.AssertExpectations(t)
+}
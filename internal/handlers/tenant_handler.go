@@ -33,6 +33,21 @@ type InviteMemberRequest struct {
 	Role  string `json:"role" binding:"required"`
 }
 
+// InvitationTokenRequest carries the token used to accept or decline an invitation.
+type InvitationTokenRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// UpdateMemberRoleRequest defines the payload for changing a member's role.
+type UpdateMemberRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=admin member"`
+}
+
+// TransferOwnershipRequest defines the payload for handing a tenant to another member.
+type TransferOwnershipRequest struct {
+	UserID uuid.UUID `json:"user_id" binding:"required"`
+}
+
 // Create godoc
 // @Summary Create a new tenant
 // @Tags Tenant
@@ -59,11 +74,14 @@ func (h *TenantHandler) Create(c *gin.Context) {
 
 // InviteMember godoc
 // @Summary Invite member to tenant
+// @Description Creates a pending invitation. The returned token is shown once and must be passed to the invitee.
 // @Tags Tenant
 // @Security APIKeyAuth
+// @Success 201 {object} domain.TenantInvitation
 // @Router /tenants/:id/members [post]
 // @Failure 401 {object} httputil.Response
 // @Failure 403 {object} httputil.Response
+// @Failure 409 {object} httputil.Response
 func (h *TenantHandler) InviteMember(c *gin.Context) {
 	tenantID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
@@ -77,12 +95,250 @@ func (h *TenantHandler) InviteMember(c *gin.Context) {
 		return
 	}
 
-	if err := h.svc.InviteMember(c.Request.Context(), tenantID, req.Email, req.Role); err != nil {
+	inv, err := h.svc.InviteMember(c.Request.Context(), tenantID, req.Email, req.Role)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	httputil.Success(c, http.StatusCreated, inv)
+}
+
+// ListInvitations godoc
+// @Summary List pending invitations for a tenant
+// @Tags Tenant
+// @Security APIKeyAuth
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Success 200 {array} domain.TenantInvitation
+// @Failure 401 {object} httputil.Response
+// @Failure 403 {object} httputil.Response
+// @Router /tenants/{id}/invitations [get]
+func (h *TenantHandler) ListInvitations(c *gin.Context) {
+	tenantID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid tenant ID"))
+		return
+	}
+
+	invitations, err := h.svc.ListInvitations(c.Request.Context(), tenantID)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	httputil.Success(c, http.StatusOK, invitations)
+}
+
+// ResendInvitation godoc
+// @Summary Resend a tenant invitation
+// @Description Issues a fresh token and restarts the expiry window. The previous token stops working.
+// @Tags Tenant
+// @Security APIKeyAuth
+// @Produce json
+// @Param id path string true "Tenant ID"
+// @Param invitationId path string true "Invitation ID"
+// @Success 200 {object} domain.TenantInvitation
+// @Failure 404 {object} httputil.Response
+// @Failure 409 {object} httputil.Response
+// @Router /tenants/{id}/invitations/{invitationId}/resend [post]
+func (h *TenantHandler) ResendInvitation(c *gin.Context) {
+	tenantID, invitationID, ok := parseTenantInvitationIDs(c)
+	if !ok {
+		return
+	}
+
+	inv, err := h.svc.ResendInvitation(c.Request.Context(), tenantID, invitationID)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	httputil.Success(c, http.StatusOK, inv)
+}
+
+// RevokeInvitation godoc
+// @Summary Revoke a pending tenant invitation
+// @Tags Tenant
+// @Security APIKeyAuth
+// @Param id path string true "Tenant ID"
+// @Param invitationId path string true "Invitation ID"
+// @Success 200 {object} httputil.Response
+// @Failure 404 {object} httputil.Response
+// @Failure 409 {object} httputil.Response
+// @Router /tenants/{id}/invitations/{invitationId} [delete]
+func (h *TenantHandler) RevokeInvitation(c *gin.Context) {
+	tenantID, invitationID, ok := parseTenantInvitationIDs(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.RevokeInvitation(c.Request.Context(), tenantID, invitationID); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	httputil.Success(c, http.StatusOK, gin.H{"message": "invitation revoked"})
+}
+
+// ListMyInvitations godoc
+// @Summary List invitations addressed to the current user
+// @Tags Tenant
+// @Security APIKeyAuth
+// @Produce json
+// @Success 200 {array} domain.TenantInvitation
+// @Failure 401 {object} httputil.Response
+// @Router /tenant-invitations [get]
+func (h *TenantHandler) ListMyInvitations(c *gin.Context) {
+	invitations, err := h.svc.ListMyInvitations(c.Request.Context())
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	httputil.Success(c, http.StatusOK, invitations)
+}
+
+// AcceptInvitation godoc
+// @Summary Accept a tenant invitation
+// @Tags Tenant
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param request body InvitationTokenRequest true "Invitation token"
+// @Success 200 {object} domain.TenantMember
+// @Failure 400 {object} httputil.Response
+// @Failure 403 {object} httputil.Response
+// @Failure 409 {object} httputil.Response
+// @Router /tenant-invitations/accept [post]
+func (h *TenantHandler) AcceptInvitation(c *gin.Context) {
+	var req InvitationTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid request"))
+		return
+	}
+
+	member, err := h.svc.AcceptInvitation(c.Request.Context(), req.Token)
+	if err != nil {
 		httputil.Error(c, err)
 		return
 	}
 
-	httputil.Success(c, http.StatusOK, gin.H{"message": "member invited"})
+	httputil.Success(c, http.StatusOK, member)
+}
+
+// DeclineInvitation godoc
+// @Summary Decline a tenant invitation
+// @Tags Tenant
+// @Security APIKeyAuth
+// @Accept json
+// @Param request body InvitationTokenRequest true "Invitation token"
+// @Success 200 {object} httputil.Response
+// @Failure 400 {object} httputil.Response
+// @Failure 403 {object} httputil.Response
+// @Router /tenant-invitations/decline [post]
+func (h *TenantHandler) DeclineInvitation(c *gin.Context) {
+	var req InvitationTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid request"))
+		return
+	}
+
+	if err := h.svc.DeclineInvitation(c.Request.Context(), req.Token); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	httputil.Success(c, http.StatusOK, gin.H{"message": "invitation declined"})
+}
+
+// RemoveMember godoc
+// @Summary Remove a member from a tenant
+// @Tags Tenant
+// @Security APIKeyAuth
+// @Param id path string true "Tenant ID"
+// @Param userId path string true "User ID"
+// @Success 200 {object} httputil.Response
+// @Failure 403 {object} httputil.Response
+// @Router /tenants/{id}/members/{userId} [delete]
+func (h *TenantHandler) RemoveMember(c *gin.Context) {
+	tenantID, userID, ok := parseTenantMemberIDs(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.RemoveMember(c.Request.Context(), tenantID, userID); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	httputil.Success(c, http.StatusOK, gin.H{"message": "member removed"})
+}
+
+// UpdateMemberRole godoc
+// @Summary Change a tenant member's role
+// @Tags Tenant
+// @Security APIKeyAuth
+// @Accept json
+// @Param id path string true "Tenant ID"
+// @Param userId path string true "User ID"
+// @Param request body UpdateMemberRoleRequest true "New role"
+// @Success 200 {object} httputil.Response
+// @Failure 400 {object} httputil.Response
+// @Failure 403 {object} httputil.Response
+// @Failure 404 {object} httputil.Response
+// @Router /tenants/{id}/members/{userId} [put]
+func (h *TenantHandler) UpdateMemberRole(c *gin.Context) {
+	tenantID, userID, ok := parseTenantMemberIDs(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateMemberRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid request"))
+		return
+	}
+
+	if err := h.svc.UpdateMemberRole(c.Request.Context(), tenantID, userID, req.Role); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	httputil.Success(c, http.StatusOK, gin.H{"message": "member role updated"})
+}
+
+// TransferOwnership godoc
+// @Summary Transfer tenant ownership to another member
+// @Description The current owner stays in the tenant as an admin.
+// @Tags Tenant
+// @Security APIKeyAuth
+// @Accept json
+// @Param id path string true "Tenant ID"
+// @Param request body TransferOwnershipRequest true "New owner"
+// @Success 200 {object} httputil.Response
+// @Failure 400 {object} httputil.Response
+// @Failure 403 {object} httputil.Response
+// @Router /tenants/{id}/transfer-ownership [post]
+func (h *TenantHandler) TransferOwnership(c *gin.Context) {
+	tenantID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid tenant ID"))
+		return
+	}
+
+	var req TransferOwnershipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid request"))
+		return
+	}
+
+	if err := h.svc.TransferOwnership(c.Request.Context(), tenantID, req.UserID); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	httputil.Success(c, http.StatusOK, gin.H{"message": "ownership transferred"})
 }
 
 // List godoc
@@ -127,3 +383,31 @@ func (h *TenantHandler) SwitchTenant(c *gin.Context) {
 
 	httputil.Success(c, http.StatusOK, gin.H{"message": "tenant switched"})
 }
+
+func parseTenantInvitationIDs(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	tenantID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid tenant ID"))
+		return uuid.Nil, uuid.Nil, false
+	}
+	invitationID, err := uuid.Parse(c.Param("invitationId"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid invitation ID"))
+		return uuid.Nil, uuid.Nil, false
+	}
+	return tenantID, invitationID, true
+}
+
+func parseTenantMemberIDs(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	tenantID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid tenant ID"))
+		return uuid.Nil, uuid.Nil, false
+	}
+	userID, err := uuid.Parse(c.Param("userId"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid user ID"))
+		return uuid.Nil, uuid.Nil, false
+	}
+	return tenantID, userID, true
+}
@@ -42,8 +42,51 @@ func (m *mockTenantService) GetTenant(ctx context.Context, id uuid.UUID) (*domai
 	r0, _ := args.Get(0).(*domain.Tenant)
 	return r0, args.Error(1)
 }
-func (m *mockTenantService) InviteMember(ctx context.Context, tenantID uuid.UUID, email, role string) error {
-	return m.Called(ctx, tenantID, email, role).Error(0)
+func (m *mockTenantService) InviteMember(ctx context.Context, tenantID uuid.UUID, email, role string) (*domain.TenantInvitation, error) {
+	args := m.Called(ctx, tenantID, email, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	r0, _ := args.Get(0).(*domain.TenantInvitation)
+	return r0, args.Error(1)
+}
+func (m *mockTenantService) ListInvitations(ctx context.Context, tenantID uuid.UUID) ([]domain.TenantInvitation, error) {
+	args := m.Called(ctx, tenantID)
+	r0, _ := args.Get(0).([]domain.TenantInvitation)
+	return r0, args.Error(1)
+}
+func (m *mockTenantService) ResendInvitation(ctx context.Context, tenantID, invitationID uuid.UUID) (*domain.TenantInvitation, error) {
+	args := m.Called(ctx, tenantID, invitationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	r0, _ := args.Get(0).(*domain.TenantInvitation)
+	return r0, args.Error(1)
+}
+func (m *mockTenantService) RevokeInvitation(ctx context.Context, tenantID, invitationID uuid.UUID) error {
+	return m.Called(ctx, tenantID, invitationID).Error(0)
+}
+func (m *mockTenantService) ListMyInvitations(ctx context.Context) ([]domain.TenantInvitation, error) {
+	args := m.Called(ctx)
+	r0, _ := args.Get(0).([]domain.TenantInvitation)
+	return r0, args.Error(1)
+}
+func (m *mockTenantService) AcceptInvitation(ctx context.Context, token string) (*domain.TenantMember, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	r0, _ := args.Get(0).(*domain.TenantMember)
+	return r0, args.Error(1)
+}
+func (m *mockTenantService) DeclineInvitation(ctx context.Context, token string) error {
+	return m.Called(ctx, token).Error(0)
+}
+func (m *mockTenantService) UpdateMemberRole(ctx context.Context, tenantID, userID uuid.UUID, role string) error {
+	return m.Called(ctx, tenantID, userID, role).Error(0)
+}
+func (m *mockTenantService) TransferOwnership(ctx context.Context, tenantID, newOwnerID uuid.UUID) error {
+	return m.Called(ctx, tenantID, newOwnerID).Error(0)
 }
 func (m *mockTenantService) RemoveMember(ctx context.Context, tenantID, userID uuid.UUID) error {
 	return m.Called(ctx, tenantID, userID).Error(0)
@@ -51,7 +94,7 @@ func (m *mockTenantService) RemoveMember(ctx context.Context, tenantID, userID u
 func (m *mockTenantService) SwitchTenant(ctx context.Context, userID, tenantID uuid.UUID) error {
 	return m.Called(ctx, userID, tenantID).Error(0)
 }
-func (m *mockTenantService) CheckQuota(ctx context.Context, tenantID uuid.UUID, resource string, requested int) error {
+func (m *mockTenantService) CheckQuota(ctx context.Context, tenantID uuid.UUID, resource domain.QuotaResource, requested int) error {
 	return m.Called(ctx, tenantID, resource, requested).Error(0)
 }
 func (m *mockTenantService) GetMembership(ctx context.Context, tenantID, userID uuid.UUID) (*domain.TenantMember, error) {
@@ -70,10 +113,10 @@ func (m *mockTenantService) ListUserTenants(ctx context.Context, userID uuid.UUI
 	r0, _ := args.Get(0).([]domain.Tenant)
 	return r0, args.Error(1)
 }
-func (m *mockTenantService) IncrementUsage(ctx context.Context, tenantID uuid.UUID, resource string, amount int) error {
+func (m *mockTenantService) IncrementUsage(ctx context.Context, tenantID uuid.UUID, resource domain.QuotaResource, amount int) error {
 	return m.Called(ctx, tenantID, resource, amount).Error(0)
 }
-func (m *mockTenantService) DecrementUsage(ctx context.Context, tenantID uuid.UUID, resource string, amount int) error {
+func (m *mockTenantService) DecrementUsage(ctx context.Context, tenantID uuid.UUID, resource domain.QuotaResource, amount int) error {
 	return m.Called(ctx, tenantID, resource, amount).Error(0)
 }
 
@@ -133,14 +176,15 @@ func TestTenantHandlerInviteMember(t *testing.T) {
 	}
 	body, _ := json.Marshal(reqBody)
 
-	svc.On("InviteMember", mock.Anything, tenantID, reqBody.Email, reqBody.Role).Return(nil)
+	inv := &domain.TenantInvitation{ID: uuid.New(), TenantID: tenantID, Email: reqBody.Email, Role: reqBody.Role, Status: domain.InvitationPending, Token: "tok"}
+	svc.On("InviteMember", mock.Anything, tenantID, reqBody.Email, reqBody.Role).Return(inv, nil)
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("POST", tenantsPrefix+tenantID.String()+"/members", bytes.NewBuffer(body))
 	r.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusOK, w.Code)
-	assert.Contains(t, w.Body.String(), "member invited")
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"pending"`)
 }
 
 func TestTenantHandlerSwitchTenant(t *testing.T) {
@@ -223,7 +267,7 @@ func TestTenantHandlerInviteMemberServiceError(t *testing.T) {
 	reqBody := InviteMemberRequest{Email: "e", Role: "r"}
 	body, _ := json.Marshal(reqBody)
 
-	svc.On("InviteMember", mock.Anything, tenantID, reqBody.Email, reqBody.Role).Return(assert.AnError)
+	svc.On("InviteMember", mock.Anything, tenantID, reqBody.Email, reqBody.Role).Return(nil, assert.AnError)
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("POST", tenantsPrefix+tenantID.String()+"/members", bytes.NewBuffer(body))
@@ -262,3 +306,97 @@ func TestTenantHandlerSwitchTenantServiceError(t *testing.T) {
 
 	assert.NotEqual(t, http.StatusOK, w.Code)
 }
+
+func TestTenantHandlerAcceptInvitation(t *testing.T) {
+	t.Parallel()
+	userID := uuid.New()
+	svc, handler, r := setupTenantHandlerTest(userID)
+
+	r.POST("/tenant-invitations/accept", handler.AcceptInvitation)
+
+	tenantID := uuid.New()
+	svc.On("AcceptInvitation", mock.Anything, "tok").Return(&domain.TenantMember{TenantID: tenantID, UserID: userID, Role: "member"}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/tenant-invitations/accept", bytes.NewBufferString(`{"token":"tok"}`))
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), tenantID.String())
+}
+
+func TestTenantHandlerAcceptInvitationMissingToken(t *testing.T) {
+	t.Parallel()
+	_, handler, r := setupTenantHandlerTest(uuid.New())
+
+	r.POST("/tenant-invitations/accept", handler.AcceptInvitation)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/tenant-invitations/accept", bytes.NewBufferString(`{}`))
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestTenantHandlerRevokeInvitation(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupTenantHandlerTest(uuid.New())
+
+	r.DELETE("/tenants/:id/invitations/:invitationId", handler.RevokeInvitation)
+
+	tenantID, invID := uuid.New(), uuid.New()
+	svc.On("RevokeInvitation", mock.Anything, tenantID, invID).Return(nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("DELETE", tenantsPrefix+tenantID.String()+"/invitations/"+invID.String(), nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "invitation revoked")
+}
+
+func TestTenantHandlerUpdateMemberRole(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupTenantHandlerTest(uuid.New())
+
+	r.PUT("/tenants/:id/members/:userId", handler.UpdateMemberRole)
+
+	tenantID, memberID := uuid.New(), uuid.New()
+	svc.On("UpdateMemberRole", mock.Anything, tenantID, memberID, "admin").Return(nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", tenantsPrefix+tenantID.String()+"/members/"+memberID.String(), bytes.NewBufferString(`{"role":"admin"}`))
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestTenantHandlerUpdateMemberRoleInvalidRole(t *testing.T) {
+	t.Parallel()
+	_, handler, r := setupTenantHandlerTest(uuid.New())
+
+	r.PUT("/tenants/:id/members/:userId", handler.UpdateMemberRole)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", tenantsPrefix+uuid.NewString()+"/members/"+uuid.NewString(), bytes.NewBufferString(`{"role":"owner"}`))
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestTenantHandlerTransferOwnership(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupTenantHandlerTest(uuid.New())
+
+	r.POST("/tenants/:id/transfer-ownership", handler.TransferOwnership)
+
+	tenantID, newOwner := uuid.New(), uuid.New()
+	svc.On("TransferOwnership", mock.Anything, tenantID, newOwner).Return(nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", tenantsPrefix+tenantID.String()+"/transfer-ownership", bytes.NewBufferString(`{"user_id":"`+newOwner.String()+`"}`))
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "ownership transferred")
+}
@@ -0,0 +1,482 @@
+package httphandlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/poyrazk/thecloud/pkg/httputil"
+)
+
+const (
+	invalidTransitGatewayIDMsg = "invalid transit gateway id"
+	invalidTGWAttachmentIDMsg  = "invalid transit gateway attachment id"
+	invalidTGWRouteTableIDMsg  = "invalid transit gateway route table id"
+)
+
+// TransitGatewayHandler handles HTTP requests for transit gateways.
+type TransitGatewayHandler struct {
+	svc ports.TransitGatewayService
+}
+
+// NewTransitGatewayHandler creates a new TransitGatewayHandler.
+func NewTransitGatewayHandler(svc ports.TransitGatewayService) *TransitGatewayHandler {
+	return &TransitGatewayHandler{svc: svc}
+}
+
+// CreateTransitGatewayRequest represents the body for creating a transit gateway.
+type CreateTransitGatewayRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// Create creates a transit gateway with a default route table.
+// @Summary Create Transit Gateway
+// @Tags transit-gateways
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param request body CreateTransitGatewayRequest true "Transit Gateway Request"
+// @Success 201 {object} domain.TransitGateway
+// @Failure 400 {object} httputil.Response
+// @Failure 500 {object} httputil.Response
+// @Router /transit-gateways [post]
+func (h *TransitGatewayHandler) Create(c *gin.Context) {
+	var req CreateTransitGatewayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid request body"))
+		return
+	}
+
+	tgw, err := h.svc.CreateTransitGateway(c.Request.Context(), req.Name)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusCreated, tgw)
+}
+
+// List returns the tenant's transit gateways.
+// @Summary List Transit Gateways
+// @Tags transit-gateways
+// @Security APIKeyAuth
+// @Produce json
+// @Success 200 {array} domain.TransitGateway
+// @Router /transit-gateways [get]
+func (h *TransitGatewayHandler) List(c *gin.Context) {
+	gateways, err := h.svc.ListTransitGateways(c.Request.Context())
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, gateways)
+}
+
+// Get retrieves a transit gateway.
+// @Summary Get Transit Gateway
+// @Tags transit-gateways
+// @Security APIKeyAuth
+// @Produce json
+// @Param id path string true "Transit Gateway ID"
+// @Success 200 {object} domain.TransitGateway
+// @Failure 404 {object} httputil.Response
+// @Router /transit-gateways/{id} [get]
+func (h *TransitGatewayHandler) Get(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidTransitGatewayIDMsg))
+		return
+	}
+
+	tgw, err := h.svc.GetTransitGateway(c.Request.Context(), id)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, tgw)
+}
+
+// Delete removes a transit gateway that has no attachments.
+// @Summary Delete Transit Gateway
+// @Tags transit-gateways
+// @Security APIKeyAuth
+// @Param id path string true "Transit Gateway ID"
+// @Success 204
+// @Failure 409 {object} httputil.Response
+// @Router /transit-gateways/{id} [delete]
+func (h *TransitGatewayHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidTransitGatewayIDMsg))
+		return
+	}
+
+	if err := h.svc.DeleteTransitGateway(c.Request.Context(), id); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// AttachVPCRequest represents the body for attaching a VPC to a transit gateway.
+type AttachVPCRequest struct {
+	VPCID string `json:"vpc_id" binding:"required,uuid"`
+}
+
+// AttachVPC attaches a VPC to a transit gateway.
+// @Summary Attach VPC to Transit Gateway
+// @Tags transit-gateways
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Transit Gateway ID"
+// @Param request body AttachVPCRequest true "Attachment Request"
+// @Success 201 {object} domain.TransitGatewayAttachment
+// @Failure 400 {object} httputil.Response
+// @Failure 409 {object} httputil.Response
+// @Router /transit-gateways/{id}/attachments [post]
+func (h *TransitGatewayHandler) AttachVPC(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidTransitGatewayIDMsg))
+		return
+	}
+
+	var req AttachVPCRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid request body"))
+		return
+	}
+
+	vpcID, _ := uuid.Parse(req.VPCID)
+	att, err := h.svc.AttachVPC(c.Request.Context(), id, vpcID)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusCreated, att)
+}
+
+// ListAttachments returns the VPC attachments of a transit gateway.
+// @Summary List Transit Gateway Attachments
+// @Tags transit-gateways
+// @Security APIKeyAuth
+// @Produce json
+// @Param id path string true "Transit Gateway ID"
+// @Success 200 {array} domain.TransitGatewayAttachment
+// @Router /transit-gateways/{id}/attachments [get]
+func (h *TransitGatewayHandler) ListAttachments(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidTransitGatewayIDMsg))
+		return
+	}
+
+	attachments, err := h.svc.ListAttachments(c.Request.Context(), id)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, attachments)
+}
+
+// DetachVPC removes a VPC attachment.
+// @Summary Detach VPC from Transit Gateway
+// @Tags transit-gateways
+// @Security APIKeyAuth
+// @Param id path string true "Attachment ID"
+// @Success 204
+// @Failure 404 {object} httputil.Response
+// @Router /transit-gateway-attachments/{id} [delete]
+func (h *TransitGatewayHandler) DetachVPC(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidTGWAttachmentIDMsg))
+		return
+	}
+
+	if err := h.svc.DetachVPC(c.Request.Context(), id); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// CreateTGWRouteTableRequest represents the body for creating a transit gateway route table.
+type CreateTGWRouteTableRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+// CreateRouteTable adds a route table to a transit gateway.
+// @Summary Create Transit Gateway Route Table
+// @Tags transit-gateways
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Transit Gateway ID"
+// @Param request body CreateTGWRouteTableRequest true "Route Table Request"
+// @Success 201 {object} domain.TransitGatewayRouteTable
+// @Failure 400 {object} httputil.Response
+// @Router /transit-gateways/{id}/route-tables [post]
+func (h *TransitGatewayHandler) CreateRouteTable(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidTransitGatewayIDMsg))
+		return
+	}
+
+	var req CreateTGWRouteTableRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid request body"))
+		return
+	}
+
+	rt, err := h.svc.CreateRouteTable(c.Request.Context(), id, req.Name)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusCreated, rt)
+}
+
+// ListRouteTables returns the route tables of a transit gateway.
+// @Summary List Transit Gateway Route Tables
+// @Tags transit-gateways
+// @Security APIKeyAuth
+// @Produce json
+// @Param id path string true "Transit Gateway ID"
+// @Success 200 {array} domain.TransitGatewayRouteTable
+// @Router /transit-gateways/{id}/route-tables [get]
+func (h *TransitGatewayHandler) ListRouteTables(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidTransitGatewayIDMsg))
+		return
+	}
+
+	tables, err := h.svc.ListRouteTables(c.Request.Context(), id)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, tables)
+}
+
+// GetRouteTable retrieves a route table with its routes and propagations.
+// @Summary Get Transit Gateway Route Table
+// @Tags transit-gateways
+// @Security APIKeyAuth
+// @Produce json
+// @Param id path string true "Route Table ID"
+// @Success 200 {object} domain.TransitGatewayRouteTable
+// @Failure 404 {object} httputil.Response
+// @Router /transit-gateway-route-tables/{id} [get]
+func (h *TransitGatewayHandler) GetRouteTable(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidTGWRouteTableIDMsg))
+		return
+	}
+
+	rt, err := h.svc.GetRouteTable(c.Request.Context(), id)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, rt)
+}
+
+// DeleteRouteTable removes a route table no attachment is associated with.
+// @Summary Delete Transit Gateway Route Table
+// @Tags transit-gateways
+// @Security APIKeyAuth
+// @Param id path string true "Route Table ID"
+// @Success 204
+// @Failure 409 {object} httputil.Response
+// @Router /transit-gateway-route-tables/{id} [delete]
+func (h *TransitGatewayHandler) DeleteRouteTable(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidTGWRouteTableIDMsg))
+		return
+	}
+
+	if err := h.svc.DeleteRouteTable(c.Request.Context(), id); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// AddTGWRouteRequest represents the body for adding a static transit gateway route.
+// Omitting the attachment creates a blackhole route.
+type AddTGWRouteRequest struct {
+	DestinationCIDR string  `json:"destination_cidr" binding:"required"`
+	AttachmentID    *string `json:"attachment_id,omitempty"`
+}
+
+// AddRoute adds a static route to a transit gateway route table.
+// @Summary Add Transit Gateway Route
+// @Tags transit-gateways
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Route Table ID"
+// @Param request body AddTGWRouteRequest true "Route Request"
+// @Success 201 {object} domain.TransitGatewayRoute
+// @Failure 400 {object} httputil.Response
+// @Router /transit-gateway-route-tables/{id}/routes [post]
+func (h *TransitGatewayHandler) AddRoute(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidTGWRouteTableIDMsg))
+		return
+	}
+
+	var req AddTGWRouteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid request body"))
+		return
+	}
+
+	var attachmentID *uuid.UUID
+	if req.AttachmentID != nil {
+		parsed, err := uuid.Parse(*req.AttachmentID)
+		if err != nil {
+			httputil.Error(c, errors.New(errors.InvalidInput, invalidTGWAttachmentIDMsg))
+			return
+		}
+		attachmentID = &parsed
+	}
+
+	route, err := h.svc.AddRoute(c.Request.Context(), id, req.DestinationCIDR, attachmentID)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusCreated, route)
+}
+
+// RemoveRoute removes a static route from a transit gateway route table.
+// @Summary Remove Transit Gateway Route
+// @Tags transit-gateways
+// @Security APIKeyAuth
+// @Param id path string true "Route Table ID"
+// @Param route_id path string true "Route ID"
+// @Success 204
+// @Failure 400 {object} httputil.Response
+// @Router /transit-gateway-route-tables/{id}/routes/{route_id} [delete]
+func (h *TransitGatewayHandler) RemoveRoute(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidTGWRouteTableIDMsg))
+		return
+	}
+	routeID, err := uuid.Parse(c.Param("route_id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid route id"))
+		return
+	}
+
+	if err := h.svc.RemoveRoute(c.Request.Context(), id, routeID); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// TGWAttachmentRequest names the attachment to associate or propagate.
+type TGWAttachmentRequest struct {
+	AttachmentID string `json:"attachment_id" binding:"required,uuid"`
+}
+
+// Associate makes the route table forward traffic arriving from an attachment.
+// @Summary Associate Transit Gateway Route Table
+// @Tags transit-gateways
+// @Security APIKeyAuth
+// @Accept json
+// @Param id path string true "Route Table ID"
+// @Param request body TGWAttachmentRequest true "Association Request"
+// @Success 204
+// @Failure 400 {object} httputil.Response
+// @Router /transit-gateway-route-tables/{id}/associations [post]
+func (h *TransitGatewayHandler) Associate(c *gin.Context) {
+	id, attachmentID, ok := h.bindRouteTableAttachment(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.AssociateRouteTable(c.Request.Context(), id, attachmentID); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// EnablePropagation learns an attachment's VPC CIDRs into the route table.
+// @Summary Enable Transit Gateway Route Propagation
+// @Tags transit-gateways
+// @Security APIKeyAuth
+// @Accept json
+// @Param id path string true "Route Table ID"
+// @Param request body TGWAttachmentRequest true "Propagation Request"
+// @Success 204
+// @Failure 400 {object} httputil.Response
+// @Router /transit-gateway-route-tables/{id}/propagations [post]
+func (h *TransitGatewayHandler) EnablePropagation(c *gin.Context) {
+	id, attachmentID, ok := h.bindRouteTableAttachment(c)
+	if !ok {
+		return
+	}
+
+	if err := h.svc.EnablePropagation(c.Request.Context(), id, attachmentID); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// DisablePropagation withdraws an attachment's propagated routes from the route table.
+// @Summary Disable Transit Gateway Route Propagation
+// @Tags transit-gateways
+// @Security APIKeyAuth
+// @Param id path string true "Route Table ID"
+// @Param attachment_id path string true "Attachment ID"
+// @Success 204
+// @Failure 404 {object} httputil.Response
+// @Router /transit-gateway-route-tables/{id}/propagations/{attachment_id} [delete]
+func (h *TransitGatewayHandler) DisablePropagation(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidTGWRouteTableIDMsg))
+		return
+	}
+	attachmentID, err := uuid.Parse(c.Param("attachment_id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidTGWAttachmentIDMsg))
+		return
+	}
+
+	if err := h.svc.DisablePropagation(c.Request.Context(), id, attachmentID); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *TransitGatewayHandler) bindRouteTableAttachment(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidTGWRouteTableIDMsg))
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	var req TGWAttachmentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid request body"))
+		return uuid.Nil, uuid.Nil, false
+	}
+	attachmentID, _ := uuid.Parse(req.AttachmentID)
+	return id, attachmentID, true
+}
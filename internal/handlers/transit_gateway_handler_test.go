@@ -0,0 +1,212 @@
+package httphandlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockTransitGatewayService struct {
+	mock.Mock
+}
+
+func (m *mockTransitGatewayService) CreateTransitGateway(ctx context.Context, name string) (*domain.TransitGateway, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TransitGateway), args.Error(1)
+}
+
+func (m *mockTransitGatewayService) GetTransitGateway(ctx context.Context, id uuid.UUID) (*domain.TransitGateway, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TransitGateway), args.Error(1)
+}
+
+func (m *mockTransitGatewayService) ListTransitGateways(ctx context.Context) ([]*domain.TransitGateway, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.TransitGateway), args.Error(1)
+}
+
+func (m *mockTransitGatewayService) DeleteTransitGateway(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *mockTransitGatewayService) AttachVPC(ctx context.Context, tgwID, vpcID uuid.UUID) (*domain.TransitGatewayAttachment, error) {
+	args := m.Called(ctx, tgwID, vpcID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TransitGatewayAttachment), args.Error(1)
+}
+
+func (m *mockTransitGatewayService) ListAttachments(ctx context.Context, tgwID uuid.UUID) ([]*domain.TransitGatewayAttachment, error) {
+	args := m.Called(ctx, tgwID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.TransitGatewayAttachment), args.Error(1)
+}
+
+func (m *mockTransitGatewayService) DetachVPC(ctx context.Context, attachmentID uuid.UUID) error {
+	return m.Called(ctx, attachmentID).Error(0)
+}
+
+func (m *mockTransitGatewayService) CreateRouteTable(ctx context.Context, tgwID uuid.UUID, name string) (*domain.TransitGatewayRouteTable, error) {
+	args := m.Called(ctx, tgwID, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TransitGatewayRouteTable), args.Error(1)
+}
+
+func (m *mockTransitGatewayService) GetRouteTable(ctx context.Context, id uuid.UUID) (*domain.TransitGatewayRouteTable, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TransitGatewayRouteTable), args.Error(1)
+}
+
+func (m *mockTransitGatewayService) ListRouteTables(ctx context.Context, tgwID uuid.UUID) ([]*domain.TransitGatewayRouteTable, error) {
+	args := m.Called(ctx, tgwID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.TransitGatewayRouteTable), args.Error(1)
+}
+
+func (m *mockTransitGatewayService) DeleteRouteTable(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *mockTransitGatewayService) AssociateRouteTable(ctx context.Context, rtID, attachmentID uuid.UUID) error {
+	return m.Called(ctx, rtID, attachmentID).Error(0)
+}
+
+func (m *mockTransitGatewayService) EnablePropagation(ctx context.Context, rtID, attachmentID uuid.UUID) error {
+	return m.Called(ctx, rtID, attachmentID).Error(0)
+}
+
+func (m *mockTransitGatewayService) DisablePropagation(ctx context.Context, rtID, attachmentID uuid.UUID) error {
+	return m.Called(ctx, rtID, attachmentID).Error(0)
+}
+
+func (m *mockTransitGatewayService) AddRoute(ctx context.Context, rtID uuid.UUID, destinationCIDR string, attachmentID *uuid.UUID) (*domain.TransitGatewayRoute, error) {
+	args := m.Called(ctx, rtID, destinationCIDR, attachmentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TransitGatewayRoute), args.Error(1)
+}
+
+func (m *mockTransitGatewayService) RemoveRoute(ctx context.Context, rtID, routeID uuid.UUID) error {
+	return m.Called(ctx, rtID, routeID).Error(0)
+}
+
+const (
+	transitGatewaysPath = "/transit-gateways"
+	tgwRouteTablesPath  = "/transit-gateway-route-tables"
+)
+
+func setupTransitGatewayHandlerTest() (*mockTransitGatewayService, *TransitGatewayHandler, *gin.Engine) {
+	gin.SetMode(gin.TestMode)
+	svc := new(mockTransitGatewayService)
+	handler := NewTransitGatewayHandler(svc)
+	r := gin.New()
+	return svc, handler, r
+}
+
+func TestTransitGatewayHandlerCreate(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupTransitGatewayHandlerTest()
+	r.POST(transitGatewaysPath, handler.Create)
+
+	svc.On("CreateTransitGateway", mock.Anything, "hub").Return(&domain.TransitGateway{ID: uuid.New(), Name: "hub"}, nil).Once()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, transitGatewaysPath, bytes.NewBufferString(`{"name":"hub"}`))
+	req.Header.Set(contentType, applicationJSON)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	svc.AssertExpectations(t)
+}
+
+func TestTransitGatewayHandlerAttachVPCConflict(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupTransitGatewayHandlerTest()
+	r.POST(transitGatewaysPath+"/:id/attachments", handler.AttachVPC)
+
+	tgwID, vpcID := uuid.New(), uuid.New()
+	svc.On("AttachVPC", mock.Anything, tgwID, vpcID).Return(nil, errors.New(errors.Conflict, "VPC CIDRs overlap")).Once()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, transitGatewaysPath+"/"+tgwID.String()+"/attachments", bytes.NewBufferString(`{"vpc_id":"`+vpcID.String()+`"}`))
+	req.Header.Set(contentType, applicationJSON)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestTransitGatewayHandlerAddBlackholeRoute(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupTransitGatewayHandlerTest()
+	r.POST(tgwRouteTablesPath+"/:id/routes", handler.AddRoute)
+
+	rtID := uuid.New()
+	svc.On("AddRoute", mock.Anything, rtID, "10.9.0.0/16", (*uuid.UUID)(nil)).
+		Return(&domain.TransitGatewayRoute{ID: uuid.New(), DestinationCIDR: "10.9.0.0/16", Type: domain.TransitGatewayRouteStatic}, nil).Once()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, tgwRouteTablesPath+"/"+rtID.String()+"/routes", bytes.NewBufferString(`{"destination_cidr":"10.9.0.0/16"}`))
+	req.Header.Set(contentType, applicationJSON)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	svc.AssertExpectations(t)
+}
+
+func TestTransitGatewayHandlerAddRouteInvalidAttachment(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupTransitGatewayHandlerTest()
+	r.POST(tgwRouteTablesPath+"/:id/routes", handler.AddRoute)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, tgwRouteTablesPath+"/"+uuid.New().String()+"/routes", bytes.NewBufferString(`{"destination_cidr":"10.9.0.0/16","attachment_id":"nope"}`))
+	req.Header.Set(contentType, applicationJSON)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	svc.AssertNotCalled(t, "AddRoute", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTransitGatewayHandlerDisablePropagation(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupTransitGatewayHandlerTest()
+	r.DELETE(tgwRouteTablesPath+"/:id/propagations/:attachment_id", handler.DisablePropagation)
+
+	rtID, attID := uuid.New(), uuid.New()
+	svc.On("DisablePropagation", mock.Anything, rtID, attID).Return(nil).Once()
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, tgwRouteTablesPath+"/"+rtID.String()+"/propagations/"+attID.String(), nil))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	svc.AssertExpectations(t)
+}
@@ -126,6 +126,45 @@ func (h *VpcHandler) Update(c *gin.Context) {
 	httputil.Success(c, http.StatusOK, vpc)
 }
 
+// AssignIPv6CIDRBlockRequest is the body for giving a VPC or subnet an IPv6 block.
+// An empty block lets the platform pick one.
+type AssignIPv6CIDRBlockRequest struct {
+	IPv6CIDRBlock string `json:"ipv6_cidr_block"`
+}
+
+// AssignIPv6 makes a VPC dual-stack
+// @Summary Assign an IPv6 CIDR block to a VPC
+// @Description Gives the VPC a /56 IPv6 block; a random unique local block is generated when none is given
+// @Tags vpcs
+// @Accept json
+// @Produce json
+// @Security APIKeyAuth
+// @Param id path string true "VPC ID or Name"
+// @Param request body AssignIPv6CIDRBlockRequest false "IPv6 block request"
+// @Success 200 {object} domain.VPC
+// @Failure 400 {object} httputil.Response
+// @Failure 404 {object} httputil.Response
+// @Failure 409 {object} httputil.Response
+// @Router /vpcs/{id}/ipv6 [post]
+func (h *VpcHandler) AssignIPv6(c *gin.Context) {
+	idOrName := c.Param("id")
+	var req AssignIPv6CIDRBlockRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			httputil.Error(c, err)
+			return
+		}
+	}
+
+	vpc, err := h.svc.AssignIPv6CIDRBlock(c.Request.Context(), idOrName, req.IPv6CIDRBlock)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+
+	httputil.Success(c, http.StatusOK, vpc)
+}
+
 // Delete deletes a VPC
 // @Summary Delete a VPC
 // @Description Removes a VPC network (must be empty of instances)
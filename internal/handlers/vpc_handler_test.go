@@ -58,6 +58,15 @@ func (m *mockVpcService) DeleteVPC(ctx context.Context, idOrName string, force b
 	return args.Error(0)
 }
 
+func (m *mockVpcService) AssignIPv6CIDRBlock(ctx context.Context, idOrName, cidrBlock string) (*domain.VPC, error) {
+	args := m.Called(ctx, idOrName, cidrBlock)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	r0, _ := args.Get(0).(*domain.VPC)
+	return r0, args.Error(1)
+}
+
 func (m *mockVpcService) UpdateVPC(ctx context.Context, idOrName, name string) (*domain.VPC, error) {
 	args := m.Called(ctx, idOrName, name)
 	if args.Get(0) == nil {
@@ -213,3 +222,22 @@ func TestVpcHandlerDeleteError(t *testing.T) {
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 }
+
+func TestVpcHandlerAssignIPv6(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupVpcHandlerTest(t)
+	defer svc.AssertExpectations(t)
+
+	r.POST(vpcsPath+"/:id/ipv6", handler.AssignIPv6)
+
+	vpcID := uuid.New().String()
+	svc.On("AssignIPv6CIDRBlock", mock.Anything, vpcID, "").Return(&domain.VPC{Name: testVpcName, IPv6CIDRBlock: "fd12:3456:7800::/56"}, nil)
+
+	w := httptest.NewRecorder()
+	req, err := http.NewRequest("POST", vpcsPath+"/"+vpcID+"/ipv6", nil)
+	require.NoError(t, err)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "fd12:3456:7800::/56")
+}
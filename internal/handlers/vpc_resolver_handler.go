@@ -0,0 +1,210 @@
+package httphandlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/poyrazk/thecloud/pkg/httputil"
+)
+
+const (
+	invalidResolverIDMsg = "invalid resolver id"
+	invalidRuleIDMsg     = "invalid forwarding rule id"
+)
+
+// VPCResolverHandler handles HTTP requests for VPC DNS resolvers.
+type VPCResolverHandler struct {
+	svc ports.VPCResolverService
+}
+
+// NewVPCResolverHandler creates a new VPCResolverHandler.
+func NewVPCResolverHandler(svc ports.VPCResolverService) *VPCResolverHandler {
+	return &VPCResolverHandler{svc: svc}
+}
+
+// CreateVPCResolverRequest represents the body for creating a VPC resolver.
+type CreateVPCResolverRequest struct {
+	VPCID string `json:"vpc_id" binding:"required,uuid"`
+}
+
+// Create launches the DNS resolver of a VPC.
+// @Summary Create VPC Resolver
+// @Tags dns
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param request body CreateVPCResolverRequest true "VPC Resolver Request"
+// @Success 201 {object} domain.VPCResolver
+// @Failure 400 {object} httputil.Response
+// @Failure 409 {object} httputil.Response
+// @Router /vpc-resolvers [post]
+func (h *VPCResolverHandler) Create(c *gin.Context) {
+	var req CreateVPCResolverRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid request body"))
+		return
+	}
+
+	vpcID, _ := uuid.Parse(req.VPCID)
+	resolver, err := h.svc.CreateResolver(c.Request.Context(), vpcID)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusCreated, resolver)
+}
+
+// List returns the VPC resolvers of the current tenant.
+// @Summary List VPC Resolvers
+// @Tags dns
+// @Security APIKeyAuth
+// @Produce json
+// @Success 200 {array} domain.VPCResolver
+// @Router /vpc-resolvers [get]
+func (h *VPCResolverHandler) List(c *gin.Context) {
+	resolvers, err := h.svc.ListResolvers(c.Request.Context())
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, resolvers)
+}
+
+// Get retrieves a VPC resolver.
+// @Summary Get VPC Resolver
+// @Tags dns
+// @Security APIKeyAuth
+// @Produce json
+// @Param id path string true "Resolver ID"
+// @Success 200 {object} domain.VPCResolver
+// @Failure 404 {object} httputil.Response
+// @Router /vpc-resolvers/{id} [get]
+func (h *VPCResolverHandler) Get(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidResolverIDMsg))
+		return
+	}
+
+	resolver, err := h.svc.GetResolver(c.Request.Context(), id)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, resolver)
+}
+
+// Delete removes a VPC resolver and its forwarding rules.
+// @Summary Delete VPC Resolver
+// @Tags dns
+// @Security APIKeyAuth
+// @Param id path string true "Resolver ID"
+// @Success 204
+// @Failure 404 {object} httputil.Response
+// @Router /vpc-resolvers/{id} [delete]
+func (h *VPCResolverHandler) Delete(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidResolverIDMsg))
+		return
+	}
+
+	if err := h.svc.DeleteResolver(c.Request.Context(), id); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// CreateForwardingRuleRequest represents the body for creating a forwarding rule.
+type CreateForwardingRuleRequest struct {
+	DomainName string   `json:"domain_name" binding:"required"`
+	TargetIPs  []string `json:"target_ips" binding:"required,min=1"`
+}
+
+// CreateRule adds a conditional forwarding rule to a resolver.
+// @Summary Create Resolver Forwarding Rule
+// @Tags dns
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param id path string true "Resolver ID"
+// @Param request body CreateForwardingRuleRequest true "Forwarding Rule Request"
+// @Success 201 {object} domain.ResolverForwardingRule
+// @Failure 400 {object} httputil.Response
+// @Failure 409 {object} httputil.Response
+// @Router /vpc-resolvers/{id}/rules [post]
+func (h *VPCResolverHandler) CreateRule(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidResolverIDMsg))
+		return
+	}
+
+	var req CreateForwardingRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid request body"))
+		return
+	}
+
+	rule, err := h.svc.CreateForwardingRule(c.Request.Context(), id, req.DomainName, req.TargetIPs)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusCreated, rule)
+}
+
+// ListRules returns the forwarding rules of a resolver.
+// @Summary List Resolver Forwarding Rules
+// @Tags dns
+// @Security APIKeyAuth
+// @Produce json
+// @Param id path string true "Resolver ID"
+// @Success 200 {array} domain.ResolverForwardingRule
+// @Router /vpc-resolvers/{id}/rules [get]
+func (h *VPCResolverHandler) ListRules(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidResolverIDMsg))
+		return
+	}
+
+	rules, err := h.svc.ListForwardingRules(c.Request.Context(), id)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, rules)
+}
+
+// DeleteRule removes a forwarding rule from a resolver.
+// @Summary Delete Resolver Forwarding Rule
+// @Tags dns
+// @Security APIKeyAuth
+// @Param id path string true "Resolver ID"
+// @Param rule_id path string true "Rule ID"
+// @Success 204
+// @Failure 404 {object} httputil.Response
+// @Router /vpc-resolvers/{id}/rules/{rule_id} [delete]
+func (h *VPCResolverHandler) DeleteRule(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidResolverIDMsg))
+		return
+	}
+	ruleID, err := uuid.Parse(c.Param("rule_id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidRuleIDMsg))
+		return
+	}
+
+	if err := h.svc.DeleteForwardingRule(c.Request.Context(), id, ruleID); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
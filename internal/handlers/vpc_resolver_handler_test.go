@@ -0,0 +1,163 @@
+package httphandlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockVPCResolverService struct {
+	mock.Mock
+}
+
+func (m *mockVPCResolverService) CreateResolver(ctx context.Context, vpcID uuid.UUID) (*domain.VPCResolver, error) {
+	args := m.Called(ctx, vpcID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.VPCResolver), args.Error(1)
+}
+
+func (m *mockVPCResolverService) GetResolver(ctx context.Context, id uuid.UUID) (*domain.VPCResolver, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.VPCResolver), args.Error(1)
+}
+
+func (m *mockVPCResolverService) ListResolvers(ctx context.Context) ([]*domain.VPCResolver, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.VPCResolver), args.Error(1)
+}
+
+func (m *mockVPCResolverService) DeleteResolver(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *mockVPCResolverService) CreateForwardingRule(ctx context.Context, resolverID uuid.UUID, domainName string, targetIPs []string) (*domain.ResolverForwardingRule, error) {
+	args := m.Called(ctx, resolverID, domainName, targetIPs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ResolverForwardingRule), args.Error(1)
+}
+
+func (m *mockVPCResolverService) ListForwardingRules(ctx context.Context, resolverID uuid.UUID) ([]*domain.ResolverForwardingRule, error) {
+	args := m.Called(ctx, resolverID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ResolverForwardingRule), args.Error(1)
+}
+
+func (m *mockVPCResolverService) DeleteForwardingRule(ctx context.Context, resolverID, ruleID uuid.UUID) error {
+	return m.Called(ctx, resolverID, ruleID).Error(0)
+}
+
+func (m *mockVPCResolverService) RefreshResolvers(ctx context.Context, vpcID uuid.UUID) error {
+	return m.Called(ctx, vpcID).Error(0)
+}
+
+const vpcResolversPath = "/vpc-resolvers"
+
+func setupVPCResolverHandlerTest() (*mockVPCResolverService, *VPCResolverHandler, *gin.Engine) {
+	gin.SetMode(gin.TestMode)
+	svc := new(mockVPCResolverService)
+	handler := NewVPCResolverHandler(svc)
+	r := gin.New()
+	return svc, handler, r
+}
+
+func TestVPCResolverHandlerCreate(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupVPCResolverHandlerTest()
+	r.POST(vpcResolversPath, handler.Create)
+
+	vpcID := uuid.New()
+	svc.On("CreateResolver", mock.Anything, vpcID).Return(&domain.VPCResolver{ID: uuid.New(), VPCID: vpcID, PrivateIP: "10.0.0.2"}, nil).Once()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, vpcResolversPath, bytes.NewBufferString(`{"vpc_id":"`+vpcID.String()+`"}`))
+	req.Header.Set(contentType, applicationJSON)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	svc.AssertExpectations(t)
+}
+
+func TestVPCResolverHandlerCreateConflict(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupVPCResolverHandlerTest()
+	r.POST(vpcResolversPath, handler.Create)
+
+	vpcID := uuid.New()
+	svc.On("CreateResolver", mock.Anything, vpcID).Return(nil, errors.New(errors.Conflict, "the VPC already has a resolver")).Once()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, vpcResolversPath, bytes.NewBufferString(`{"vpc_id":"`+vpcID.String()+`"}`))
+	req.Header.Set(contentType, applicationJSON)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestVPCResolverHandlerCreateRule(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupVPCResolverHandlerTest()
+	r.POST(vpcResolversPath+"/:id/rules", handler.CreateRule)
+
+	id := uuid.New()
+	targets := []string{"10.50.0.2", "10.50.0.3:5353"}
+	svc.On("CreateForwardingRule", mock.Anything, id, "corp.example.com", targets).
+		Return(&domain.ResolverForwardingRule{ID: uuid.New(), ResolverID: id, DomainName: "corp.example.com", TargetIPs: targets}, nil).Once()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, vpcResolversPath+"/"+id.String()+"/rules", bytes.NewBufferString(`{"domain_name":"corp.example.com","target_ips":["10.50.0.2","10.50.0.3:5353"]}`))
+	req.Header.Set(contentType, applicationJSON)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	svc.AssertExpectations(t)
+}
+
+func TestVPCResolverHandlerCreateRuleNoTargets(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupVPCResolverHandlerTest()
+	r.POST(vpcResolversPath+"/:id/rules", handler.CreateRule)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, vpcResolversPath+"/"+uuid.New().String()+"/rules", bytes.NewBufferString(`{"domain_name":"corp.example.com","target_ips":[]}`))
+	req.Header.Set(contentType, applicationJSON)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	svc.AssertNotCalled(t, "CreateForwardingRule", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestVPCResolverHandlerDeleteRule(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupVPCResolverHandlerTest()
+	r.DELETE(vpcResolversPath+"/:id/rules/:rule_id", handler.DeleteRule)
+
+	id, ruleID := uuid.New(), uuid.New()
+	svc.On("DeleteForwardingRule", mock.Anything, id, ruleID).Return(nil).Once()
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, vpcResolversPath+"/"+id.String()+"/rules/"+ruleID.String(), nil))
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	svc.AssertExpectations(t)
+}
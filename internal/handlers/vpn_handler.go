@@ -0,0 +1,329 @@
+package httphandlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/poyrazk/thecloud/pkg/httputil"
+)
+
+const (
+	invalidVPNGatewayIDMsg      = "invalid VPN gateway id"
+	invalidCustomerGatewayIDMsg = "invalid customer gateway id"
+	invalidVPNConnectionIDMsg   = "invalid VPN connection id"
+)
+
+// VPNHandler handles HTTP requests for site-to-site VPNs.
+type VPNHandler struct {
+	svc ports.VPNService
+}
+
+// NewVPNHandler creates a new VPNHandler.
+func NewVPNHandler(svc ports.VPNService) *VPNHandler {
+	return &VPNHandler{svc: svc}
+}
+
+// CreateVPNGatewayRequest represents the body for creating a VPN gateway.
+type CreateVPNGatewayRequest struct {
+	VPCID string `json:"vpc_id" binding:"required,uuid"`
+	Name  string `json:"name" binding:"required"`
+}
+
+// CreateGateway launches a WireGuard VPN gateway in a VPC.
+// @Summary Create VPN Gateway
+// @Tags vpn
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param request body CreateVPNGatewayRequest true "VPN Gateway Request"
+// @Success 201 {object} domain.VPNGateway
+// @Failure 400 {object} httputil.Response
+// @Failure 500 {object} httputil.Response
+// @Router /vpn-gateways [post]
+func (h *VPNHandler) CreateGateway(c *gin.Context) {
+	var req CreateVPNGatewayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid request body"))
+		return
+	}
+
+	vpcID, _ := uuid.Parse(req.VPCID)
+	gw, err := h.svc.CreateVPNGateway(c.Request.Context(), vpcID, req.Name)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusCreated, gw)
+}
+
+// ListGateways returns all VPN gateways for a VPC.
+// @Summary List VPN Gateways
+// @Tags vpn
+// @Security APIKeyAuth
+// @Produce json
+// @Param vpc_id query string true "VPC ID"
+// @Success 200 {array} domain.VPNGateway
+// @Failure 400 {object} httputil.Response
+// @Router /vpn-gateways [get]
+func (h *VPNHandler) ListGateways(c *gin.Context) {
+	vpcID, err := uuid.Parse(c.Query("vpc_id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "vpc_id is required"))
+		return
+	}
+
+	gateways, err := h.svc.ListVPNGateways(c.Request.Context(), vpcID)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, gateways)
+}
+
+// GetGateway retrieves a VPN gateway.
+// @Summary Get VPN Gateway
+// @Tags vpn
+// @Security APIKeyAuth
+// @Produce json
+// @Param id path string true "VPN Gateway ID"
+// @Success 200 {object} domain.VPNGateway
+// @Failure 404 {object} httputil.Response
+// @Router /vpn-gateways/{id} [get]
+func (h *VPNHandler) GetGateway(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidVPNGatewayIDMsg))
+		return
+	}
+
+	gw, err := h.svc.GetVPNGateway(c.Request.Context(), id)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, gw)
+}
+
+// DeleteGateway removes a VPN gateway that has no connections.
+// @Summary Delete VPN Gateway
+// @Tags vpn
+// @Security APIKeyAuth
+// @Param id path string true "VPN Gateway ID"
+// @Success 204
+// @Failure 409 {object} httputil.Response
+// @Router /vpn-gateways/{id} [delete]
+func (h *VPNHandler) DeleteGateway(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidVPNGatewayIDMsg))
+		return
+	}
+
+	if err := h.svc.DeleteVPNGateway(c.Request.Context(), id); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// CreateCustomerGatewayRequest represents the body for registering a customer gateway.
+type CreateCustomerGatewayRequest struct {
+	Name      string `json:"name" binding:"required"`
+	IPAddress string `json:"ip_address" binding:"required"`
+	PublicKey string `json:"public_key" binding:"required"`
+}
+
+// CreateCustomerGateway registers an on-premises WireGuard endpoint.
+// @Summary Create Customer Gateway
+// @Tags vpn
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param request body CreateCustomerGatewayRequest true "Customer Gateway Request"
+// @Success 201 {object} domain.CustomerGateway
+// @Failure 400 {object} httputil.Response
+// @Router /customer-gateways [post]
+func (h *VPNHandler) CreateCustomerGateway(c *gin.Context) {
+	var req CreateCustomerGatewayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid request body"))
+		return
+	}
+
+	cgw, err := h.svc.CreateCustomerGateway(c.Request.Context(), req.Name, req.IPAddress, req.PublicKey)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusCreated, cgw)
+}
+
+// ListCustomerGateways returns the tenant's customer gateways.
+// @Summary List Customer Gateways
+// @Tags vpn
+// @Security APIKeyAuth
+// @Produce json
+// @Success 200 {array} domain.CustomerGateway
+// @Router /customer-gateways [get]
+func (h *VPNHandler) ListCustomerGateways(c *gin.Context) {
+	gateways, err := h.svc.ListCustomerGateways(c.Request.Context())
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, gateways)
+}
+
+// DeleteCustomerGateway removes a customer gateway that no connection uses.
+// @Summary Delete Customer Gateway
+// @Tags vpn
+// @Security APIKeyAuth
+// @Param id path string true "Customer Gateway ID"
+// @Success 204
+// @Failure 409 {object} httputil.Response
+// @Router /customer-gateways/{id} [delete]
+func (h *VPNHandler) DeleteCustomerGateway(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidCustomerGatewayIDMsg))
+		return
+	}
+
+	if err := h.svc.DeleteCustomerGateway(c.Request.Context(), id); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// CreateVPNConnectionRequest represents the body for creating a VPN connection.
+type CreateVPNConnectionRequest struct {
+	VPNGatewayID      string   `json:"vpn_gateway_id" binding:"required,uuid"`
+	CustomerGatewayID string   `json:"customer_gateway_id" binding:"required,uuid"`
+	RemoteCIDRs       []string `json:"remote_cidrs" binding:"required,min=1"`
+}
+
+// CreateConnection connects a VPN gateway to a customer gateway.
+// @Summary Create VPN Connection
+// @Tags vpn
+// @Security APIKeyAuth
+// @Accept json
+// @Produce json
+// @Param request body CreateVPNConnectionRequest true "VPN Connection Request"
+// @Success 201 {object} domain.VPNConnection
+// @Failure 400 {object} httputil.Response
+// @Failure 500 {object} httputil.Response
+// @Router /vpn-connections [post]
+func (h *VPNHandler) CreateConnection(c *gin.Context) {
+	var req CreateVPNConnectionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "invalid request body"))
+		return
+	}
+
+	gatewayID, _ := uuid.Parse(req.VPNGatewayID)
+	customerGatewayID, _ := uuid.Parse(req.CustomerGatewayID)
+	conn, err := h.svc.CreateVPNConnection(c.Request.Context(), gatewayID, customerGatewayID, req.RemoteCIDRs)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusCreated, conn)
+}
+
+// ListConnections returns the connections of a VPN gateway.
+// @Summary List VPN Connections
+// @Tags vpn
+// @Security APIKeyAuth
+// @Produce json
+// @Param vpn_gateway_id query string true "VPN Gateway ID"
+// @Success 200 {array} domain.VPNConnection
+// @Failure 400 {object} httputil.Response
+// @Router /vpn-connections [get]
+func (h *VPNHandler) ListConnections(c *gin.Context) {
+	gatewayID, err := uuid.Parse(c.Query("vpn_gateway_id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, "vpn_gateway_id is required"))
+		return
+	}
+
+	conns, err := h.svc.ListVPNConnections(c.Request.Context(), gatewayID)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, conns)
+}
+
+// GetConnection retrieves a VPN connection.
+// @Summary Get VPN Connection
+// @Tags vpn
+// @Security APIKeyAuth
+// @Produce json
+// @Param id path string true "VPN Connection ID"
+// @Success 200 {object} domain.VPNConnection
+// @Failure 404 {object} httputil.Response
+// @Router /vpn-connections/{id} [get]
+func (h *VPNHandler) GetConnection(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidVPNConnectionIDMsg))
+		return
+	}
+
+	conn, err := h.svc.GetVPNConnection(c.Request.Context(), id)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, conn)
+}
+
+// GetConnectionConfig returns the settings for the customer's side of the tunnel.
+// @Summary Get VPN Connection Config
+// @Tags vpn
+// @Security APIKeyAuth
+// @Produce json
+// @Param id path string true "VPN Connection ID"
+// @Success 200 {object} domain.VPNConnectionConfig
+// @Failure 404 {object} httputil.Response
+// @Router /vpn-connections/{id}/config [get]
+func (h *VPNHandler) GetConnectionConfig(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidVPNConnectionIDMsg))
+		return
+	}
+
+	cfg, err := h.svc.GetVPNConnectionConfig(c.Request.Context(), id)
+	if err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	httputil.Success(c, http.StatusOK, cfg)
+}
+
+// DeleteConnection removes a VPN connection and its routes.
+// @Summary Delete VPN Connection
+// @Tags vpn
+// @Security APIKeyAuth
+// @Param id path string true "VPN Connection ID"
+// @Success 204
+// @Failure 404 {object} httputil.Response
+// @Router /vpn-connections/{id} [delete]
+func (h *VPNHandler) DeleteConnection(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		httputil.Error(c, errors.New(errors.InvalidInput, invalidVPNConnectionIDMsg))
+		return
+	}
+
+	if err := h.svc.DeleteVPNConnection(c.Request.Context(), id); err != nil {
+		httputil.Error(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
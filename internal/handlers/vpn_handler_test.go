@@ -0,0 +1,197 @@
+package httphandlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockVPNService struct {
+	mock.Mock
+}
+
+func (m *mockVPNService) CreateVPNGateway(ctx context.Context, vpcID uuid.UUID, name string) (*domain.VPNGateway, error) {
+	args := m.Called(ctx, vpcID, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.VPNGateway), args.Error(1)
+}
+
+func (m *mockVPNService) GetVPNGateway(ctx context.Context, id uuid.UUID) (*domain.VPNGateway, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.VPNGateway), args.Error(1)
+}
+
+func (m *mockVPNService) ListVPNGateways(ctx context.Context, vpcID uuid.UUID) ([]*domain.VPNGateway, error) {
+	args := m.Called(ctx, vpcID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.VPNGateway), args.Error(1)
+}
+
+func (m *mockVPNService) DeleteVPNGateway(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *mockVPNService) CreateCustomerGateway(ctx context.Context, name, ipAddress, publicKey string) (*domain.CustomerGateway, error) {
+	args := m.Called(ctx, name, ipAddress, publicKey)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CustomerGateway), args.Error(1)
+}
+
+func (m *mockVPNService) ListCustomerGateways(ctx context.Context) ([]*domain.CustomerGateway, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.CustomerGateway), args.Error(1)
+}
+
+func (m *mockVPNService) DeleteCustomerGateway(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+func (m *mockVPNService) CreateVPNConnection(ctx context.Context, gatewayID, customerGatewayID uuid.UUID, remoteCIDRs []string) (*domain.VPNConnection, error) {
+	args := m.Called(ctx, gatewayID, customerGatewayID, remoteCIDRs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.VPNConnection), args.Error(1)
+}
+
+func (m *mockVPNService) GetVPNConnection(ctx context.Context, id uuid.UUID) (*domain.VPNConnection, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.VPNConnection), args.Error(1)
+}
+
+func (m *mockVPNService) ListVPNConnections(ctx context.Context, gatewayID uuid.UUID) ([]*domain.VPNConnection, error) {
+	args := m.Called(ctx, gatewayID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.VPNConnection), args.Error(1)
+}
+
+func (m *mockVPNService) GetVPNConnectionConfig(ctx context.Context, id uuid.UUID) (*domain.VPNConnectionConfig, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.VPNConnectionConfig), args.Error(1)
+}
+
+func (m *mockVPNService) DeleteVPNConnection(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+const (
+	vpnGatewaysPath    = "/vpn-gateways"
+	vpnConnectionsPath = "/vpn-connections"
+)
+
+func setupVPNHandlerTest() (*mockVPNService, *VPNHandler, *gin.Engine) {
+	gin.SetMode(gin.TestMode)
+	svc := new(mockVPNService)
+	handler := NewVPNHandler(svc)
+	r := gin.New()
+	return svc, handler, r
+}
+
+func TestVPNHandlerCreateGateway(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupVPNHandlerTest()
+	r.POST(vpnGatewaysPath, handler.CreateGateway)
+
+	vpcID := uuid.New()
+	svc.On("CreateVPNGateway", mock.Anything, vpcID, "onprem").Return(&domain.VPNGateway{ID: uuid.New(), VPCID: vpcID, Name: "onprem"}, nil).Once()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, vpnGatewaysPath, bytes.NewBufferString(`{"vpc_id":"`+vpcID.String()+`","name":"onprem"}`))
+	req.Header.Set(contentType, applicationJSON)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	svc.AssertExpectations(t)
+}
+
+func TestVPNHandlerDeleteGatewayInUse(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupVPNHandlerTest()
+	r.DELETE(vpnGatewaysPath+"/:id", handler.DeleteGateway)
+
+	id := uuid.New()
+	svc.On("DeleteVPNGateway", mock.Anything, id).Return(errors.New(errors.Conflict, "VPN gateway still has connections")).Once()
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, vpnGatewaysPath+"/"+id.String(), nil))
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestVPNHandlerCreateConnection(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupVPNHandlerTest()
+	r.POST(vpnConnectionsPath, handler.CreateConnection)
+
+	gwID, cgwID := uuid.New(), uuid.New()
+	cidrs := []string{"192.168.0.0/16"}
+	svc.On("CreateVPNConnection", mock.Anything, gwID, cgwID, cidrs).Return(&domain.VPNConnection{ID: uuid.New(), RemoteCIDRs: cidrs}, nil).Once()
+
+	body := `{"vpn_gateway_id":"` + gwID.String() + `","customer_gateway_id":"` + cgwID.String() + `","remote_cidrs":["192.168.0.0/16"]}`
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, vpnConnectionsPath, bytes.NewBufferString(body))
+	req.Header.Set(contentType, applicationJSON)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	svc.AssertExpectations(t)
+}
+
+func TestVPNHandlerCreateConnectionWithoutCIDRs(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupVPNHandlerTest()
+	r.POST(vpnConnectionsPath, handler.CreateConnection)
+
+	body := `{"vpn_gateway_id":"` + uuid.New().String() + `","customer_gateway_id":"` + uuid.New().String() + `","remote_cidrs":[]}`
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, vpnConnectionsPath, bytes.NewBufferString(body))
+	req.Header.Set(contentType, applicationJSON)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	svc.AssertNotCalled(t, "CreateVPNConnection", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestVPNHandlerGetConnectionConfig(t *testing.T) {
+	t.Parallel()
+	svc, handler, r := setupVPNHandlerTest()
+	r.GET(vpnConnectionsPath+"/:id/config", handler.GetConnectionConfig)
+
+	id := uuid.New()
+	svc.On("GetVPNConnectionConfig", mock.Anything, id).Return(&domain.VPNConnectionConfig{GatewayPort: 40001, PreSharedKey: "psk"}, nil).Once()
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, vpnConnectionsPath+"/"+id.String()+"/config", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"gateway_port":40001`)
+}
@@ -0,0 +1,56 @@
+package platform
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// MeminfoPressureProbe reports host memory pressure from /proc/meminfo.
+type MeminfoPressureProbe struct {
+	path string
+}
+
+// NewMeminfoPressureProbe creates a probe reading the meminfo file at path,
+// usually /proc/meminfo.
+func NewMeminfoPressureProbe(path string) *MeminfoPressureProbe {
+	return &MeminfoPressureProbe{path: path}
+}
+
+// MemoryPressure returns the fraction of memory that is not available for new
+// allocations, 1 - MemAvailable/MemTotal.
+func (p *MeminfoPressureProbe) MemoryPressure(ctx context.Context) (float64, error) {
+	f, err := os.Open(p.path)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = f.Close() }()
+
+	var total, available int64 = -1, -1
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[0] {
+		case "MemTotal:":
+			total, err = strconv.ParseInt(fields[1], 10, 64)
+		case "MemAvailable:":
+			available, err = strconv.ParseInt(fields[1], 10, 64)
+		}
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s line in %s: %w", fields[0], p.path, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+	if total <= 0 || available < 0 {
+		return 0, fmt.Errorf("%s has no MemTotal or MemAvailable", p.path)
+	}
+	return 1 - float64(available)/float64(total), nil
+}
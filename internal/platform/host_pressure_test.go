@@ -0,0 +1,38 @@
+package platform
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeminfoPressureProbe(t *testing.T) {
+	write := func(t *testing.T, content string) string {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "meminfo")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	t.Run("Pressure", func(t *testing.T) {
+		path := write(t, "MemTotal:       16000000 kB\nMemFree:         1000000 kB\nMemAvailable:    4000000 kB\n")
+		pressure, err := NewMeminfoPressureProbe(path).MemoryPressure(context.Background())
+		require.NoError(t, err)
+		assert.InDelta(t, 0.75, pressure, 1e-9)
+	})
+
+	t.Run("MissingFields", func(t *testing.T) {
+		path := write(t, "MemTotal:       16000000 kB\n")
+		_, err := NewMeminfoPressureProbe(path).MemoryPressure(context.Background())
+		assert.Error(t, err)
+	})
+
+	t.Run("MissingFile", func(t *testing.T) {
+		_, err := NewMeminfoPressureProbe(filepath.Join(t.TempDir(), "missing")).MemoryPressure(context.Background())
+		assert.Error(t, err)
+	})
+}
@@ -77,6 +77,22 @@ var (
 		Name: "thecloud_rds_instances_total",
 		Help: "Total number of RDS instances",
 	}, []string{"engine", "status"})
+	RDSConnections = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "thecloud_rds_connections",
+		Help: "Client connections of RDS instances with metrics enabled",
+	}, []string{"database_id"})
+	RDSMaxConnections = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "thecloud_rds_max_connections",
+		Help: "Connection limit of RDS instances with metrics enabled",
+	}, []string{"database_id"})
+	RDSCacheHitRatio = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "thecloud_rds_cache_hit_ratio",
+		Help: "Buffer cache hit ratio of RDS instances with metrics enabled",
+	}, []string{"database_id"})
+	RDSReplicationLagSeconds = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "thecloud_rds_replication_lag_seconds",
+		Help: "Replication lag of RDS read replicas behind their primary",
+	}, []string{"database_id", "replica_id"})
 	CacheInstancesTotal = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Name: "thecloud_cache_instances_total",
 		Help: "Total number of cache instances",
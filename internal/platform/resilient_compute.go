@@ -296,6 +296,20 @@ func (r *ResilientCompute) DetachVolume(ctx context.Context, id string, volumePa
 	return containerID, nil
 }
 
+// ---------- Network Interfaces ----------
+
+func (r *ResilientCompute) AttachNetworkInterface(ctx context.Context, id string, nic ports.NetworkInterfaceOptions) error {
+	return r.callProtected(ctx, r.opts.CallTimeout, func(ctx context.Context) error {
+		return r.inner.AttachNetworkInterface(ctx, id, nic)
+	})
+}
+
+func (r *ResilientCompute) DetachNetworkInterface(ctx context.Context, id string, nic ports.NetworkInterfaceOptions) error {
+	return r.callProtected(ctx, r.opts.CallTimeout, func(ctx context.Context) error {
+		return r.inner.DetachNetworkInterface(ctx, id, nic)
+	})
+}
+
 // ---------- Health ----------
 
 // Ping bypasses the bulkhead (low cost, used for health checks) but still
@@ -330,6 +344,16 @@ func (r *ResilientCompute) Unwrap() ports.ComputeBackend {
 	return r.inner
 }
 
+func (r *ResilientCompute) HostMemoryPressure(ctx context.Context) ([]ports.HostPressure, error) {
+	var hosts []ports.HostPressure
+	err := r.callProtected(ctx, r.opts.CallTimeout, func(ctx context.Context) error {
+		var e error
+		hosts, e = r.inner.HostMemoryPressure(ctx)
+		return e
+	})
+	return hosts, err
+}
+
 // ResetCircuitBreaker resets the circuit breaker state.
 // Useful for E2E tests that need clean state between test suites.
 func (r *ResilientCompute) ResetCircuitBreaker() {
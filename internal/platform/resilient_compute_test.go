@@ -105,6 +105,14 @@ func (m *mockCompute) DetachVolume(_ context.Context, _ string, _ string) (strin
 	m.callCount.Add(1)
 	return "", m.err
 }
+func (m *mockCompute) AttachNetworkInterface(_ context.Context, _ string, _ ports.NetworkInterfaceOptions) error {
+	m.callCount.Add(1)
+	return m.err
+}
+func (m *mockCompute) DetachNetworkInterface(_ context.Context, _ string, _ ports.NetworkInterfaceOptions) error {
+	m.callCount.Add(1)
+	return m.err
+}
 func (m *mockCompute) Ping(_ context.Context) error {
 	m.callCount.Add(1)
 	return m.err
@@ -129,6 +137,9 @@ func (m *mockCompute) Type() string { return "mock" }
 func (m *mockCompute) PauseInstance(_ context.Context, _ string) error { return nil }
 func (m *mockCompute) ResumeInstance(_ context.Context, _ string) error { return nil }
 func (m *mockCompute) ResetCircuitBreaker() {}
+func (m *mockCompute) HostMemoryPressure(_ context.Context) ([]ports.HostPressure, error) {
+	return nil, nil
+}
 
 // ---------- tests ----------
 
@@ -165,6 +165,16 @@ func (r *ResilientNetwork) ListFlowRules(ctx context.Context, bridge string) ([]
 	return rules, err
 }
 
+func (r *ResilientNetwork) ListConntrackEntries(ctx context.Context, zone int) ([]ports.ConntrackEntry, error) {
+	var entries []ports.ConntrackEntry
+	err := r.callProtected(ctx, func(ctx context.Context) error {
+		var e error
+		entries, e = r.inner.ListConntrackEntries(ctx, zone)
+		return e
+	})
+	return entries, err
+}
+
 // ---------- Veth Pair Management ----------
 
 func (r *ResilientNetwork) CreateVethPair(ctx context.Context, hostEnd, containerEnd string) error {
@@ -4,10 +4,13 @@ package docker
 import (
 	"context"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"io"
 	"log/slog"
+	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -19,6 +22,7 @@ import (
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/system"
 	"github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/client"
 	"github.com/docker/docker/pkg/stdcopy"
@@ -69,6 +73,9 @@ func (a *DockerAdapter) getContainerLock(containerID string) *sync.Mutex {
 // into other packages.
 type dockerClient interface {
 	Ping(ctx context.Context) (types.Ping, error)
+	Info(ctx context.Context) (system.Info, error)
+	ContainerList(ctx context.Context, options container.ListOptions) ([]container.Summary, error)
+	ContainerStatsOneShot(ctx context.Context, containerID string) (container.StatsResponseReader, error)
 	ImagePull(ctx context.Context, ref string, options image.PullOptions) (io.ReadCloser, error)
 	ContainerCreate(ctx context.Context, config *container.Config, hostConfig *container.HostConfig, networkingConfig *network.NetworkingConfig, platform *v1.Platform, containerName string) (container.CreateResponse, error)
 	ContainerStart(ctx context.Context, containerID string, options container.StartOptions) error
@@ -226,6 +233,9 @@ func (a *DockerAdapter) LaunchInstanceWithOptions(ctx context.Context, opts port
 	hostConfig := &container.HostConfig{
 		PortBindings: make(nat.PortMap),
 		Binds:        opts.VolumeBinds,
+		CapAdd:       opts.Capabilities,
+		DNS:          opts.DNSServers,
+		DNSSearch:    opts.DNSSearch,
 	}
 
 	// For KIND images, we need privileged mode to support systemd and cgroups.
@@ -256,10 +266,7 @@ func (a *DockerAdapter) LaunchInstanceWithOptions(ctx context.Context, opts port
 		parts := strings.Split(p, ":")
 		if len(parts) == 2 {
 			hostPort := parts[0]
-			containerPort := parts[1]
-
-			// We assume TCP for now as per plan
-			cPort := nat.Port(containerPort + "/tcp")
+			cPort := containerNatPort(parts[1])
 			config.ExposedPorts[cPort] = struct{}{}
 			hostConfig.PortBindings[cPort] = []nat.PortBinding{
 				{
@@ -296,6 +303,15 @@ func (a *DockerAdapter) LaunchInstanceWithOptions(ctx context.Context, opts port
 	return resp.ID, opts.Ports, nil
 }
 
+// containerNatPort converts a container port such as "6379" or "51820/udp" to a
+// Docker port; ports without a protocol are TCP.
+func containerNatPort(spec string) nat.Port {
+	if strings.Contains(spec, "/") {
+		return nat.Port(spec)
+	}
+	return nat.Port(spec + "/tcp")
+}
+
 func (a *DockerAdapter) handleUserData(ctx context.Context, containerID string, userData string) error {
 	// For Docker, we simulate Cloud-Init by writing the script to the container and executing it.
 	if strings.HasPrefix(userData, "#cloud-config") {
@@ -512,6 +528,55 @@ func (a *DockerAdapter) GetInstanceStats(ctx context.Context, containerID string
 	return stats.Body, nil
 }
 
+// HostMemoryPressure reports the memory used by the running containers against
+// the memory of the Docker host. A daemon serves a single host.
+func (a *DockerAdapter) HostMemoryPressure(ctx context.Context) ([]ports.HostPressure, error) {
+	info, err := a.cli.Info(ctx)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to get docker host info", err)
+	}
+	if info.MemTotal <= 0 {
+		return nil, errors.New(errors.Internal, "docker host reports no memory")
+	}
+	containers, err := a.cli.ContainerList(ctx, container.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list containers", err)
+	}
+
+	var used uint64
+	ids := make([]string, 0, len(containers))
+	for _, c := range containers {
+		ids = append(ids, c.ID)
+		usage, err := a.containerMemoryUsage(ctx, c.ID)
+		if err != nil {
+			a.logger.Warn("failed to read container memory usage", "container_id", c.ID, "error", err)
+			continue
+		}
+		used += usage
+	}
+	return []ports.HostPressure{{Host: info.Name, Pressure: float64(used) / float64(info.MemTotal), InstanceIDs: ids}}, nil
+}
+
+// containerMemoryUsage returns the memory a container uses without the page
+// cache the kernel can reclaim, like docker stats does.
+func (a *DockerAdapter) containerMemoryUsage(ctx context.Context, containerID string) (uint64, error) {
+	stats, err := a.cli.ContainerStatsOneShot(ctx, containerID)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = stats.Body.Close() }()
+
+	var s container.StatsResponse
+	if err := json.NewDecoder(stats.Body).Decode(&s); err != nil {
+		return 0, err
+	}
+	usage := s.MemoryStats.Usage
+	if inactive := s.MemoryStats.Stats["inactive_file"]; inactive < usage {
+		usage -= inactive
+	}
+	return usage, nil
+}
+
 func (a *DockerAdapter) GetInstancePort(ctx context.Context, containerID string, containerPort string) (int, error) {
 	// Retry up to 30 times with 500ms backoff (15 seconds total)
 	for i := 0; i < 30; i++ {
@@ -520,7 +585,7 @@ func (a *DockerAdapter) GetInstancePort(ctx context.Context, containerID string,
 			return 0, fmt.Errorf("failed to inspect container: %w", err)
 		}
 
-		cPort := nat.Port(containerPort + "/tcp")
+		cPort := containerNatPort(containerPort)
 		bindings, ok := inspect.NetworkSettings.Ports[cPort]
 		if ok && len(bindings) > 0 {
 			var hostPort int
@@ -901,6 +966,77 @@ func (a *DockerAdapter) DetachVolume(ctx context.Context, id string, volumePath
 	return createResp.ID, nil
 }
 
+// hostCommand runs a command on the Docker host. It is a variable so tests can stub it.
+var hostCommand = func(ctx context.Context, name string, args ...string) error {
+	out, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s %s: %w: %s", name, strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// containerPID returns the PID of a running container's init process, or 0 if it is not running.
+func (a *DockerAdapter) containerPID(ctx context.Context, id string) (int, error) {
+	inspect, err := a.cli.ContainerInspect(ctx, id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to inspect container %s: %w", id, err)
+	}
+	if inspect.ContainerJSONBase == nil || inspect.State == nil || !inspect.State.Running {
+		return 0, nil
+	}
+	return inspect.State.Pid, nil
+}
+
+// AttachNetworkInterface moves the guest end of the interface's veth pair into
+// the container's network namespace and configures it there.
+func (a *DockerAdapter) AttachNetworkInterface(ctx context.Context, id string, nic ports.NetworkInterfaceOptions) error {
+	pid, err := a.containerPID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if pid == 0 {
+		return errors.New(errors.InstanceNotRunning, "container "+id+" is not running")
+	}
+	ns := []string{"-t", strconv.Itoa(pid), "-n", "ip"}
+
+	cmds := [][]string{
+		{"ip", "link", "set", nic.GuestPort, "netns", strconv.Itoa(pid)},
+		append(append([]string{"nsenter"}, ns...), "link", "set", nic.GuestPort, "name", nic.DeviceName),
+		append(append([]string{"nsenter"}, ns...), "link", "set", "dev", nic.DeviceName, "address", nic.MACAddress),
+	}
+	for _, addr := range nic.Addresses {
+		cmds = append(cmds, append(append([]string{"nsenter"}, ns...), "addr", "add", addr, "dev", nic.DeviceName))
+	}
+	cmds = append(cmds, append(append([]string{"nsenter"}, ns...), "link", "set", nic.DeviceName, "up"))
+
+	for _, c := range cmds {
+		if err := hostCommand(ctx, c[0], c[1:]...); err != nil {
+			return errors.Wrap(errors.Internal, "failed to attach network interface", err)
+		}
+	}
+	return nil
+}
+
+// DetachNetworkInterface removes the interface from the container's namespace.
+// A stopped or missing container has nothing to remove, which lets interfaces
+// move away from failed instances.
+func (a *DockerAdapter) DetachNetworkInterface(ctx context.Context, id string, nic ports.NetworkInterfaceOptions) error {
+	pid, err := a.containerPID(ctx, id)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if pid == 0 {
+		return nil
+	}
+	if err := hostCommand(ctx, "nsenter", "-t", strconv.Itoa(pid), "-n", "ip", "link", "del", nic.DeviceName); err != nil {
+		return errors.Wrap(errors.Internal, "failed to detach network interface", err)
+	}
+	return nil
+}
+
 func (a *DockerAdapter) GetConsoleURL(ctx context.Context, id string) (string, error) {
 	return "", errors.New(errors.NotImplemented, "console not supported for docker instances")
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/containerd/errdefs"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/system"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 
@@ -85,6 +86,25 @@ func TestDockerAdapterGetInstanceStatsError(t *testing.T) {
 	require.True(t, apierrors.Is(err, apierrors.Internal))
 }
 
+func TestDockerAdapterHostMemoryPressure(t *testing.T) {
+	cli := &fakeDockerClient{
+		info:       system.Info{Name: "host-1", MemTotal: 1000},
+		containers: []container.Summary{{ID: "a"}, {ID: "b"}, {ID: "gone"}},
+		memUsage: map[string]string{
+			"a": `{"memory_stats":{"usage":500,"stats":{"inactive_file":100}}}`,
+			"b": `{"memory_stats":{"usage":200}}`,
+		},
+	}
+	a := &DockerAdapter{cli: cli, logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+
+	hosts, err := a.HostMemoryPressure(context.Background())
+	require.NoError(t, err)
+	require.Len(t, hosts, 1)
+	require.Equal(t, "host-1", hosts[0].Host)
+	require.InDelta(t, 0.6, hosts[0].Pressure, 1e-9)
+	require.Equal(t, []string{"a", "b", "gone"}, hosts[0].InstanceIDs)
+}
+
 func TestDockerAdapterExecNonZeroExit(t *testing.T) {
 	cli := &fakeDockerClient{
 		execAttachRead: strings.NewReader("out"),
@@ -438,3 +458,50 @@ func TestDockerAdapterLaunchWithUserData(t *testing.T) {
 	require.Equal(t, 2, cli.CallCount("ContainerExecAttach"), "Expected associated ExecAttach calls for I/O")
 	require.Equal(t, 2, cli.CallCount("ContainerExecInspect"), "Expected ExecInspect calls to verify termination state")
 }
+
+func TestDockerAdapterAttachNetworkInterface(t *testing.T) {
+	var cmds []string
+	oldHostCommand := hostCommand
+	hostCommand = func(_ context.Context, name string, args ...string) error {
+		cmds = append(cmds, name+" "+strings.Join(args, " "))
+		return nil
+	}
+	defer func() { hostCommand = oldHostCommand }()
+
+	inspect := container.InspectResponse{ContainerJSONBase: &container.ContainerJSONBase{
+		State: &container.State{Running: true, Pid: 4242},
+	}}
+	a := &DockerAdapter{cli: &fakeDockerClient{inspect: inspect}}
+
+	err := a.AttachNetworkInterface(context.Background(), "cid", ports.NetworkInterfaceOptions{
+		HostPort:   "eni-1a2b3c4d",
+		GuestPort:  "eni-1a2b3c4dp",
+		DeviceName: "eth1",
+		MACAddress: "02:1a:2b:3c:4d:5e",
+		Addresses:  []string{"10.0.1.20/24", "10.0.1.21/24"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"ip link set eni-1a2b3c4dp netns 4242",
+		"nsenter -t 4242 -n ip link set eni-1a2b3c4dp name eth1",
+		"nsenter -t 4242 -n ip link set dev eth1 address 02:1a:2b:3c:4d:5e",
+		"nsenter -t 4242 -n ip addr add 10.0.1.20/24 dev eth1",
+		"nsenter -t 4242 -n ip addr add 10.0.1.21/24 dev eth1",
+		"nsenter -t 4242 -n ip link set eth1 up",
+	}, cmds)
+}
+
+func TestDockerAdapterAttachNetworkInterfaceNotRunning(t *testing.T) {
+	inspect := container.InspectResponse{ContainerJSONBase: &container.ContainerJSONBase{State: &container.State{}}}
+	a := &DockerAdapter{cli: &fakeDockerClient{inspect: inspect}}
+
+	err := a.AttachNetworkInterface(context.Background(), "cid", ports.NetworkInterfaceOptions{DeviceName: "eth1"})
+	require.True(t, apierrors.Is(err, apierrors.InstanceNotRunning))
+}
+
+func TestDockerAdapterDetachNetworkInterfaceStoppedContainer(t *testing.T) {
+	inspect := container.InspectResponse{ContainerJSONBase: &container.ContainerJSONBase{State: &container.State{}}}
+	a := &DockerAdapter{cli: &fakeDockerClient{inspect: inspect}}
+
+	require.NoError(t, a.DetachNetworkInterface(context.Background(), "cid", ports.NetworkInterfaceOptions{DeviceName: "eth1"}))
+}
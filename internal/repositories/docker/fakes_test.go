@@ -14,6 +14,7 @@ import (
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/system"
 	"github.com/docker/docker/api/types/volume"
 	v1 "github.com/opencontainers/image-spec/specs-go/v1"
 )
@@ -33,6 +34,11 @@ type fakeDockerClient struct {
 	statsErr error
 	statsRC  io.ReadCloser
 
+	// Host memory
+	info       system.Info
+	containers []container.Summary
+	memUsage   map[string]string // container ID -> stats JSON
+
 	// Exec
 	execCreateID   string
 	execCreateErr  error
@@ -124,6 +130,22 @@ func (f *fakeDockerClient) ContainerStats(ctx context.Context, containerID strin
 	return container.StatsResponseReader{Body: f.statsRC}, nil
 }
 
+func (f *fakeDockerClient) Info(ctx context.Context) (system.Info, error) {
+	return f.info, nil
+}
+
+func (f *fakeDockerClient) ContainerList(ctx context.Context, options container.ListOptions) ([]container.Summary, error) {
+	return f.containers, nil
+}
+
+func (f *fakeDockerClient) ContainerStatsOneShot(ctx context.Context, containerID string) (container.StatsResponseReader, error) {
+	body, ok := f.memUsage[containerID]
+	if !ok {
+		return container.StatsResponseReader{}, errors.New("no such container")
+	}
+	return container.StatsResponseReader{Body: io.NopCloser(strings.NewReader(body))}, nil
+}
+
 func (f *fakeDockerClient) ContainerInspect(ctx context.Context, containerID string) (container.InspectResponse, error) {
 	if f.inspectErr != nil {
 		return container.InspectResponse{}, f.inspectErr
@@ -109,6 +109,15 @@ func (m *mockVpcRepo) List(ctx context.Context) ([]*domain.VPC, error) {
 	return r0, args.Error(1)
 }
 
+func (m *mockVpcRepo) ListAll(ctx context.Context) ([]*domain.VPC, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	r0, _ := args.Get(0).([]*domain.VPC)
+	return r0, args.Error(1)
+}
+
 func (m *mockVpcRepo) GetByIdempotencyKey(ctx context.Context, key string) (*domain.VPC, error) {
 	args := m.Called(ctx, key)
 	if args.Get(0) == nil {
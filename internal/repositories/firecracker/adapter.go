@@ -16,6 +16,7 @@ import (
 	"github.com/firecracker-microvm/firecracker-go-sdk/client/models"
 	"github.com/google/uuid"
 	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/platform"
 )
 
 const (
@@ -273,6 +274,14 @@ func (a *FirecrackerAdapter) DetachVolume(ctx context.Context, id string, volume
 	return "", fmt.Errorf("detach volume not implemented for firecracker")
 }
 
+func (a *FirecrackerAdapter) AttachNetworkInterface(ctx context.Context, id string, nic ports.NetworkInterfaceOptions) error {
+	return fmt.Errorf("attach network interface not implemented for firecracker")
+}
+
+func (a *FirecrackerAdapter) DetachNetworkInterface(ctx context.Context, id string, nic ports.NetworkInterfaceOptions) error {
+	return fmt.Errorf("detach network interface not implemented for firecracker")
+}
+
 func (a *FirecrackerAdapter) Ping(ctx context.Context) error {
 	return nil
 }
@@ -303,3 +312,24 @@ func (a *FirecrackerAdapter) DeleteSnapshot(ctx context.Context, id, name string
 // ResetCircuitBreaker is a no-op for the raw Firecracker adapter.
 // The circuit breaker lives in ResilientCompute wrapping this backend.
 func (a *FirecrackerAdapter) ResetCircuitBreaker() {}
+
+// HostMemoryPressure reports the memory in use on this host, where the
+// adapter runs its microVMs.
+func (a *FirecrackerAdapter) HostMemoryPressure(ctx context.Context) ([]ports.HostPressure, error) {
+	pressure, err := platform.NewMeminfoPressureProbe("/proc/meminfo").MemoryPressure(ctx)
+	if err != nil {
+		return nil, err
+	}
+	host, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+
+	a.mu.RLock()
+	ids := make([]string, 0, len(a.machines))
+	for id := range a.machines {
+		ids = append(ids, id)
+	}
+	a.mu.RUnlock()
+	return []ports.HostPressure{{Host: host, Pressure: pressure, InstanceIDs: ids}}, nil
+}
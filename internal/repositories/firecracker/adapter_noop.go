@@ -102,6 +102,14 @@ func (a *FirecrackerAdapter) DetachVolume(ctx context.Context, id string, volume
 	return "", fmt.Errorf("firecracker not supported on this platform")
 }
 
+func (a *FirecrackerAdapter) AttachNetworkInterface(ctx context.Context, id string, nic ports.NetworkInterfaceOptions) error {
+	return fmt.Errorf("firecracker not supported on this platform")
+}
+
+func (a *FirecrackerAdapter) DetachNetworkInterface(ctx context.Context, id string, nic ports.NetworkInterfaceOptions) error {
+	return fmt.Errorf("firecracker not supported on this platform")
+}
+
 func (a *FirecrackerAdapter) Ping(ctx context.Context) error {
 	if a.logger != nil {
 		a.logger.Warn("Ping called on no-op firecracker adapter")
@@ -130,3 +138,7 @@ func (a *FirecrackerAdapter) DeleteSnapshot(ctx context.Context, id, name string
 }
 
 func (a *FirecrackerAdapter) ResetCircuitBreaker() {}
+
+func (a *FirecrackerAdapter) HostMemoryPressure(ctx context.Context) ([]ports.HostPressure, error) {
+	return nil, fmt.Errorf("firecracker not supported on this platform")
+}
@@ -194,6 +194,12 @@ func (m *MockSecurityGroupService) AttachToInstance(ctx context.Context, instanc
 func (m *MockSecurityGroupService) DetachFromInstance(ctx context.Context, instanceID, groupID uuid.UUID) error {
 	return m.Called(ctx, instanceID, groupID).Error(0)
 }
+func (m *MockSecurityGroupService) AttachToNetworkInterface(ctx context.Context, interfaceID, groupID uuid.UUID) error {
+	return m.Called(ctx, interfaceID, groupID).Error(0)
+}
+func (m *MockSecurityGroupService) DetachFromNetworkInterface(ctx context.Context, interfaceID, groupID uuid.UUID) error {
+	return m.Called(ctx, interfaceID, groupID).Error(0)
+}
 
 type MockStorageService struct{ mock.Mock }
 
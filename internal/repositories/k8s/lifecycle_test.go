@@ -207,6 +207,9 @@ func (n *noopNetworkBackend) DeleteFlowRule(ctx context.Context, bridge, match s
 func (n *noopNetworkBackend) ListFlowRules(ctx context.Context, bridge string) ([]ports.FlowRule, error) {
 	return nil, nil
 }
+func (n *noopNetworkBackend) ListConntrackEntries(ctx context.Context, zone int) ([]ports.ConntrackEntry, error) {
+	return nil, nil
+}
 func (n *noopNetworkBackend) CreateVethPair(ctx context.Context, h, c string) error { return nil }
 func (n *noopNetworkBackend) AttachVethToBridge(ctx context.Context, bridge, vethEnd string) error {
 	return nil
@@ -176,6 +176,22 @@ func (m *mockSecretService) Decrypt(ctx context.Context, userID uuid.UUID, ciphe
 	args := m.Called(ctx, userID, cipher)
 	return args.String(0), args.Error(1)
 }
+func (m *mockSecretService) PutSecretValue(ctx context.Context, id uuid.UUID, value string, stage domain.SecretStage) (*domain.SecretVersion, error) {
+	return nil, nil
+}
+func (m *mockSecretService) ListSecretVersions(ctx context.Context, id uuid.UUID) ([]*domain.SecretVersion, error) {
+	return nil, nil
+}
+func (m *mockSecretService) GetSecretVersion(ctx context.Context, id uuid.UUID, version int) (*domain.SecretVersion, error) {
+	return nil, nil
+}
+func (m *mockSecretService) GetSecretVersionByStage(ctx context.Context, id uuid.UUID, stage domain.SecretStage) (*domain.SecretVersion, error) {
+	return nil, nil
+}
+func (m *mockSecretService) UpdateSecretVersionStage(ctx context.Context, id uuid.UUID, stage domain.SecretStage, version int) error {
+	return nil
+}
+func (m *mockSecretService) ResolveSecretRef(ctx context.Context, ref string) (string, error) { return "", nil }
 
 type mockLBService struct{ mock.Mock }
 
@@ -10,11 +10,13 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"text/template"
 	"time"
 
 	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
 	"github.com/poyrazk/thecloud/internal/core/domain"
 	"github.com/poyrazk/thecloud/internal/core/ports"
 	"github.com/poyrazk/thecloud/internal/errors"
@@ -25,6 +27,8 @@ const (
 	defaultUser     = "ubuntu"
 	adminKubeconfig = "/etc/kubernetes/admin.conf"
 	etcdManifest    = "/etc/kubernetes/manifests/etcd.yaml"
+	// defaultNodeInstanceType sizes the control plane and the workers of clusters without node groups.
+	defaultNodeInstanceType = "standard-1"
 )
 
 var controlPlaneManifestPaths = []string{
@@ -155,10 +159,11 @@ func (p *KubeadmProvisioner) provisionControlPlane(ctx context.Context, cluster
 
 	nodeName := fmt.Sprintf("%s-cp-0", cluster.Name)
 	inst, err := p.instSvc.LaunchInstanceWithOptions(ctx, ports.CreateInstanceOptions{
-		Name:      nodeName,
-		ImageName: "ubuntu:22.04", // Use canonical docker hub image
-		NetworkID: cluster.VpcID.String(),
-		UserData:  userData,
+		Name:         nodeName,
+		ImageName:    "ubuntu:22.04", // Use canonical docker hub image
+		InstanceType: defaultNodeInstanceType,
+		NetworkID:    cluster.VpcID.String(),
+		UserData:     userData,
 		Metadata: map[string]string{
 			"thecloud.io/cluster-id":          cluster.ID.String(),
 			"thecloud.io/node-role":           string(domain.NodeRoleControlPlane),
@@ -225,7 +230,7 @@ func (p *KubeadmProvisioner) provisionWorkers(ctx context.Context, cluster *doma
 		cluster.NodeGroups = []domain.NodeGroup{
 			{
 				Name:         "default-pool",
-				InstanceType: "standard-1",
+				InstanceType: defaultNodeInstanceType,
 				CurrentSize:  cluster.WorkerCount,
 			},
 		}
@@ -248,6 +253,8 @@ func (p *KubeadmProvisioner) provisionWorkers(ctx context.Context, cluster *doma
 				"CACertHash":           cluster.CACertHash,
 				"SSHHostPrivateKeyB64": base64.StdEncoding.EncodeToString([]byte(hostPrivateKey)),
 				"SSHHostPublicKeyB64":  base64.StdEncoding.EncodeToString([]byte(hostPublicKey)),
+				"KubeletExtraArgs":     kubeletExtraArgs(ng),
+				"Preemptible":          ng.CapacityType == domain.CapacityPreemptible,
 			})
 			if err != nil {
 				errMsg := fmt.Sprintf("failed to render worker template %s-%d: %v", ng.Name, i, err)
@@ -257,16 +264,19 @@ func (p *KubeadmProvisioner) provisionWorkers(ctx context.Context, cluster *doma
 			}
 
 			workerName := fmt.Sprintf("%s-%s-%d", cluster.Name, ng.Name, i)
-			workerInst, err := p.instSvc.LaunchInstanceWithOptions(ctx, ports.CreateInstanceOptions{
-				Name:      workerName,
-				ImageName: "ubuntu:22.04", // Use canonical image name consistent with control-plane
-				NetworkID: cluster.VpcID.String(),
-				UserData:  workerUserData,
+			// The capacity type is a reserved key that only the provisioner sets.
+			workerInst, err := p.instSvc.LaunchInstanceWithOptions(appcontext.WithReservedMetadata(ctx), ports.CreateInstanceOptions{
+				Name:         workerName,
+				ImageName:    "ubuntu:22.04", // Use canonical image name consistent with control-plane
+				InstanceType: ng.InstanceType,
+				NetworkID:    cluster.VpcID.String(),
+				UserData:     workerUserData,
 				Metadata: map[string]string{
 					"thecloud.io/cluster-id":          cluster.ID.String(),
 					"thecloud.io/node-group":          ng.Name,
 					"thecloud.io/node-role":           string(domain.NodeRoleWorker),
 					"thecloud.io/ssh-host-public-key": strings.TrimSpace(hostPublicKey),
+					domain.MetadataCapacityType:       string(capacityType(ng)),
 				},
 			})
 			if err != nil {
@@ -299,6 +309,37 @@ func (p *KubeadmProvisioner) provisionWorkers(ctx context.Context, cluster *doma
 	return nil
 }
 
+func capacityType(ng domain.NodeGroup) domain.CapacityType {
+	if ng.CapacityType == "" {
+		return domain.CapacityOnDemand
+	}
+	return ng.CapacityType
+}
+
+// kubeletExtraArgs returns the kubelet flags that register a worker with the
+// labels and taints of its node group.
+func kubeletExtraArgs(ng domain.NodeGroup) string {
+	labels := []string{
+		"thecloud.io/node-group=" + ng.Name,
+		domain.MetadataCapacityType + "=" + string(capacityType(ng)),
+	}
+	userLabels := make([]string, 0, len(ng.Labels))
+	for k, v := range ng.Labels {
+		userLabels = append(userLabels, k+"="+v)
+	}
+	sort.Strings(userLabels)
+	args := []string{"--cloud-provider=external", "--node-labels=" + strings.Join(append(labels, userLabels...), ",")}
+
+	if len(ng.Taints) > 0 {
+		taints := make([]string, len(ng.Taints))
+		for i, t := range ng.Taints {
+			taints[i] = t.String()
+		}
+		args = append(args, "--register-with-taints="+strings.Join(taints, ","))
+	}
+	return strings.Join(args, " ")
+}
+
 func (p *KubeadmProvisioner) renderTemplate(name string, data interface{}) (string, error) {
 	path := filepath.Join(p.templateDir, name)
 	tpl, err := template.ParseFiles(path)
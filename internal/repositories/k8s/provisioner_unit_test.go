@@ -8,7 +8,9 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
 	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -33,6 +35,22 @@ func (m *mockSecretSvc) Encrypt(ctx context.Context, u uuid.UUID, p string) (str
 func (m *mockSecretSvc) Decrypt(ctx context.Context, u uuid.UUID, c string) (string, error) {
 	return "", nil
 }
+func (m *mockSecretSvc) PutSecretValue(ctx context.Context, id uuid.UUID, value string, stage domain.SecretStage) (*domain.SecretVersion, error) {
+	return nil, nil
+}
+func (m *mockSecretSvc) ListSecretVersions(ctx context.Context, id uuid.UUID) ([]*domain.SecretVersion, error) {
+	return nil, nil
+}
+func (m *mockSecretSvc) GetSecretVersion(ctx context.Context, id uuid.UUID, version int) (*domain.SecretVersion, error) {
+	return nil, nil
+}
+func (m *mockSecretSvc) GetSecretVersionByStage(ctx context.Context, id uuid.UUID, stage domain.SecretStage) (*domain.SecretVersion, error) {
+	return nil, nil
+}
+func (m *mockSecretSvc) UpdateSecretVersionStage(ctx context.Context, id uuid.UUID, stage domain.SecretStage, version int) error {
+	return nil
+}
+func (m *mockSecretSvc) ResolveSecretRef(ctx context.Context, ref string) (string, error) { return "", nil }
 
 type mockSGSvc struct{ mock.Mock }
 
@@ -52,6 +70,12 @@ func (m *mockSGSvc) AddRule(ctx context.Context, idOrName string, r domain.Secur
 func (m *mockSGSvc) RemoveRule(ctx context.Context, ruleID uuid.UUID) error               { return nil }
 func (m *mockSGSvc) AttachToInstance(ctx context.Context, instID, sgID uuid.UUID) error   { return nil }
 func (m *mockSGSvc) DetachFromInstance(ctx context.Context, instID, sgID uuid.UUID) error { return nil }
+func (m *mockSGSvc) AttachToNetworkInterface(ctx context.Context, niID, sgID uuid.UUID) error {
+	return nil
+}
+func (m *mockSGSvc) DetachFromNetworkInterface(ctx context.Context, niID, sgID uuid.UUID) error {
+	return nil
+}
 
 type mockStorageSvc struct{ mock.Mock }
 
@@ -166,13 +190,52 @@ func TestKubeadmProvisionerScale(t *testing.T) {
 
 	t.Run("Scale Up", func(t *testing.T) {
 		// Mock launching a new instance for the worker node
-		mockInst.On("LaunchInstanceWithOptions", ctx, mock.Anything).Return(&domain.Instance{ID: uuid.New()}, nil).Once()
+		mockInst.On("LaunchInstanceWithOptions", mock.Anything, mock.Anything).Return(&domain.Instance{ID: uuid.New()}, nil).Once()
 
 		err := p.Scale(ctx, cluster)
 		require.NoError(t, err)
 	})
 }
 
+func TestKubeadmProvisionerScaleNodeGroupScheduling(t *testing.T) {
+	p, mockInst, _ := setupProvisionerUnit(t)
+	ctx := context.Background()
+	cluster := &domain.Cluster{
+		ID:              uuid.New(),
+		Name:            "test-cluster",
+		ControlPlaneIPs: []string{"10.0.0.1"},
+		NodeGroups: []domain.NodeGroup{{
+			Name:         "spot",
+			InstanceType: "performance-4",
+			CurrentSize:  1,
+			Labels:       map[string]string{"workload": "batch", "team": "data"},
+			Taints:       []domain.NodeTaint{{Key: "spot", Value: "true", Effect: domain.TaintEffectNoSchedule}},
+			CapacityType: domain.CapacityPreemptible,
+		}},
+	}
+
+	var opts ports.CreateInstanceOptions
+	// Only the provisioner may set the reserved capacity type key.
+	mockInst.On("LaunchInstanceWithOptions", mock.MatchedBy(appcontext.AllowsReservedMetadata), mock.MatchedBy(func(o ports.CreateInstanceOptions) bool {
+		opts = o
+		return true
+	})).Return(&domain.Instance{ID: uuid.New()}, nil).Once()
+
+	require.NoError(t, p.Scale(ctx, cluster))
+	assert.Equal(t, "preemptible", opts.Metadata[domain.MetadataCapacityType])
+	// The node group's instance type sizes the node and is what guardrails see.
+	assert.Equal(t, "performance-4", opts.InstanceType)
+	assert.Contains(t, opts.UserData, "--node-labels=thecloud.io/node-group=spot,thecloud.io/capacity-type=preemptible,team=data,workload=batch")
+	assert.Contains(t, opts.UserData, "--register-with-taints=spot=true:NoSchedule")
+	assert.Contains(t, opts.UserData, "systemctl enable --now preemption-watcher.service")
+	assert.Contains(t, opts.UserData, "shutdownGracePeriod: 30s")
+}
+
+func TestKubeletExtraArgsOnDemand(t *testing.T) {
+	args := kubeletExtraArgs(domain.NodeGroup{Name: "default-pool"})
+	assert.Equal(t, "--cloud-provider=external --node-labels=thecloud.io/node-group=default-pool,thecloud.io/capacity-type=on-demand", args)
+}
+
 func TestKubeadmProvisionerUpgrade(t *testing.T) {
 	p, mockInst, mockRepo := setupProvisionerUnit(t)
 	ctx := context.Background()
@@ -694,6 +694,26 @@ func (a *LibvirtAdapter) DetachVolume(ctx context.Context, id string, volumePath
 	return "", a.client.DomainDetachDevice(ctx, dom, xml)
 }
 
+// AttachNetworkInterface hot-plugs a NIC into the domain. libvirt creates the
+// tap device named HostPort and adds it to the OVS bridge, so the guest
+// configures the interface's addresses itself (e.g., from the metadata service).
+func (a *LibvirtAdapter) AttachNetworkInterface(ctx context.Context, id string, nic ports.NetworkInterfaceOptions) error {
+	dom, err := a.client.DomainLookupByName(ctx, id)
+	if err != nil {
+		return fmt.Errorf(errDomainNotFound, err)
+	}
+	return a.client.DomainAttachDevice(ctx, dom, generateOVSInterfaceXML(nic.Bridge, nic.HostPort, nic.MACAddress))
+}
+
+// DetachNetworkInterface unplugs a NIC from the domain; libvirt removes its tap device.
+func (a *LibvirtAdapter) DetachNetworkInterface(ctx context.Context, id string, nic ports.NetworkInterfaceOptions) error {
+	dom, err := a.client.DomainLookupByName(ctx, id)
+	if err != nil {
+		return fmt.Errorf(errDomainNotFound, err)
+	}
+	return a.client.DomainDetachDevice(ctx, dom, generateOVSInterfaceXML(nic.Bridge, nic.HostPort, nic.MACAddress))
+}
+
 func (a *LibvirtAdapter) GetConsoleURL(ctx context.Context, id string) (string, error) {
 	if err := validateID(id); err != nil {
 		return "", err
@@ -1366,3 +1386,43 @@ func (a *LibvirtAdapter) isNotFound(err error) bool {
 // ResetCircuitBreaker is a no-op for the raw Libvirt adapter.
 // The circuit breaker lives in ResilientCompute wrapping this backend.
 func (a *LibvirtAdapter) ResetCircuitBreaker() {}
+
+// HostMemoryPressure reports the memory in use on the hypervisor host. Free,
+// buffered and cached memory count as available.
+func (a *LibvirtAdapter) HostMemoryPressure(ctx context.Context) ([]ports.HostPressure, error) {
+	host, err := a.client.ConnectGetHostname(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hypervisor hostname: %w", err)
+	}
+	// The first call returns the number of parameters the host reports.
+	_, n, err := a.client.NodeGetMemoryStats(ctx, 0, int32(libvirt.NodeMemoryStatsAllCells), 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get host memory stats: %w", err)
+	}
+	stats, _, err := a.client.NodeGetMemoryStats(ctx, n, int32(libvirt.NodeMemoryStatsAllCells), 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get host memory stats: %w", err)
+	}
+	var total, available uint64
+	for _, st := range stats {
+		switch st.Field {
+		case "total":
+			total = st.Value
+		case "free", "buffers", "cached":
+			available += st.Value
+		}
+	}
+	if total == 0 || available > total {
+		return nil, fmt.Errorf("host %s reports invalid memory stats", host)
+	}
+
+	doms, _, err := a.client.ConnectListAllDomains(ctx, 1, libvirt.ConnectListDomainsActive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list domains: %w", err)
+	}
+	ids := make([]string, 0, len(doms))
+	for _, d := range doms {
+		ids = append(ids, d.Name)
+	}
+	return []ports.HostPressure{{Host: host, Pressure: 1 - float64(available)/float64(total), InstanceIDs: ids}}, nil
+}
@@ -9,10 +9,12 @@ import (
 	"os"
 	"os/exec"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/digitalocean/go-libvirt"
+	"github.com/poyrazk/thecloud/internal/core/ports"
 	"github.com/poyrazk/thecloud/pkg/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -675,6 +677,33 @@ func TestLibvirtAdapter_VolumeOps(t *testing.T) {
 	m.AssertExpectations(t)
 }
 
+func TestLibvirtAdapter_NetworkInterfaceOps(t *testing.T) {
+	t.Parallel()
+	m := new(MockLibvirtClient)
+	a := &LibvirtAdapter{client: m, logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	ctx := context.Background()
+	dom := libvirt.Domain{Name: "test-vm"}
+	nic := ports.NetworkInterfaceOptions{Bridge: "br-vpc-1", HostPort: "eni-1a2b3c4d", MACAddress: "02:1a:2b:3c:4d:5e"}
+	isOVSInterface := mock.MatchedBy(func(xml string) bool {
+		return strings.Contains(xml, "<virtualport type='openvswitch'/>") &&
+			strings.Contains(xml, "<source bridge='br-vpc-1'/>") &&
+			strings.Contains(xml, "<target dev='eni-1a2b3c4d'/>") &&
+			strings.Contains(xml, "<mac address='02:1a:2b:3c:4d:5e'/>")
+	})
+
+	t.Run("AttachNetworkInterface", func(t *testing.T) {
+		m.On("DomainLookupByName", mock.Anything, "test-vm").Return(dom, nil).Once()
+		m.On("DomainAttachDevice", mock.Anything, dom, isOVSInterface).Return(nil).Once()
+		require.NoError(t, a.AttachNetworkInterface(ctx, "test-vm", nic))
+	})
+	t.Run("DetachNetworkInterface", func(t *testing.T) {
+		m.On("DomainLookupByName", mock.Anything, "test-vm").Return(dom, nil).Once()
+		m.On("DomainDetachDevice", mock.Anything, dom, isOVSInterface).Return(nil).Once()
+		require.NoError(t, a.DetachNetworkInterface(ctx, "test-vm", nic))
+	})
+	m.AssertExpectations(t)
+}
+
 func TestLibvirtAdapter_WaitInitialIP(t *testing.T) {
 	t.Parallel()
 	m := new(MockLibvirtClient)
@@ -1205,3 +1234,26 @@ func TestLibvirtAdapter_ResizeInstance_RollbackOnFailure(t *testing.T) {
 		m.AssertExpectations(t)
 	})
 }
+
+func TestHostMemoryPressure(t *testing.T) {
+	t.Parallel()
+	m := new(MockLibvirtClient)
+	a := &LibvirtAdapter{client: m, logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	ctx := context.Background()
+
+	allCells := int32(libvirt.NodeMemoryStatsAllCells)
+	m.On("ConnectGetHostname", mock.Anything).Return("hv-1", nil)
+	m.On("NodeGetMemoryStats", mock.Anything, int32(0), allCells, uint32(0)).Return(nil, int32(4), nil)
+	m.On("NodeGetMemoryStats", mock.Anything, int32(4), allCells, uint32(0)).Return([]libvirt.NodeGetMemoryStats{
+		{Field: "total", Value: 1000},
+		{Field: "free", Value: 100},
+		{Field: "buffers", Value: 50},
+		{Field: "cached", Value: 50},
+	}, int32(4), nil)
+	m.On("ConnectListAllDomains", mock.Anything, int32(1), libvirt.ConnectListDomainsActive).
+		Return([]libvirt.Domain{{Name: "vm-1"}}, uint32(1), nil)
+
+	hosts, err := a.HostMemoryPressure(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []ports.HostPressure{{Host: "hv-1", Pressure: 0.8, InstanceIDs: []string{"vm-1"}}}, hosts)
+}
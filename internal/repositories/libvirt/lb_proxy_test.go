@@ -58,6 +58,12 @@ func (m *mockCompute) AttachVolume(ctx context.Context, id string, volumePath st
 func (m *mockCompute) DetachVolume(ctx context.Context, id string, volumePath string) (string, error) {
 	return "", nil
 }
+func (m *mockCompute) AttachNetworkInterface(ctx context.Context, id string, nic ports.NetworkInterfaceOptions) error {
+	return nil
+}
+func (m *mockCompute) DetachNetworkInterface(ctx context.Context, id string, nic ports.NetworkInterfaceOptions) error {
+	return nil
+}
 func (m *mockCompute) Ping(ctx context.Context) error { return nil }
 func (m *mockCompute) Type() string                   { return "mock" }
 func (m *mockCompute) ResizeInstance(ctx context.Context, id string, cpu, memory int64) error {
@@ -67,6 +73,9 @@ func (m *mockCompute) CreateSnapshot(ctx context.Context, id, name string) error
 func (m *mockCompute) RestoreSnapshot(ctx context.Context, id, name string) error { return nil }
 func (m *mockCompute) DeleteSnapshot(ctx context.Context, id, name string) error  { return nil }
 func (m *mockCompute) ResetCircuitBreaker() {}
+func (m *mockCompute) HostMemoryPressure(ctx context.Context) ([]ports.HostPressure, error) {
+	return nil, nil
+}
 
 func TestLBProxyAdapter(t *testing.T) {
 	mc := new(mockCompute)
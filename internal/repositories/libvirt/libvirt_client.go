@@ -13,6 +13,9 @@ type LibvirtClient interface {
 	Connect(ctx context.Context) error
 	ConnectToURI(ctx context.Context, uri string) error
 	Close() error
+	ConnectGetHostname(ctx context.Context) (string, error)
+	ConnectListAllDomains(ctx context.Context, needResults int32, flags libvirt.ConnectListAllDomainsFlags) ([]libvirt.Domain, uint32, error)
+	NodeGetMemoryStats(ctx context.Context, nparams int32, cellNum int32, flags uint32) ([]libvirt.NodeGetMemoryStats, int32, error)
 
 	// Domain
 	DomainLookupByName(ctx context.Context, name string) (libvirt.Domain, error)
@@ -23,6 +23,25 @@ func (m *MockLibvirtClient) Close() error {
 	return m.Called().Error(0)
 }
 
+func (m *MockLibvirtClient) ConnectGetHostname(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockLibvirtClient) ConnectListAllDomains(ctx context.Context, needResults int32, flags libvirt.ConnectListAllDomainsFlags) ([]libvirt.Domain, uint32, error) {
+	args := m.Called(ctx, needResults, flags)
+	r0, _ := args.Get(0).([]libvirt.Domain)
+	r1, _ := args.Get(1).(uint32)
+	return r0, r1, args.Error(2)
+}
+
+func (m *MockLibvirtClient) NodeGetMemoryStats(ctx context.Context, nparams int32, cellNum int32, flags uint32) ([]libvirt.NodeGetMemoryStats, int32, error) {
+	args := m.Called(ctx, nparams, cellNum, flags)
+	r0, _ := args.Get(0).([]libvirt.NodeGetMemoryStats)
+	r1, _ := args.Get(1).(int32)
+	return r0, r1, args.Error(2)
+}
+
 // Domain
 
 func (m *MockLibvirtClient) DomainLookupByName(ctx context.Context, name string) (libvirt.Domain, error) {
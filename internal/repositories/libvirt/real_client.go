@@ -42,6 +42,33 @@ func (r *RealLibvirtClient) Close() error {
 	return r.conn.Disconnect()
 }
 
+func (r *RealLibvirtClient) ConnectGetHostname(ctx context.Context) (string, error) {
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	default:
+	}
+	return r.conn.ConnectGetHostname()
+}
+
+func (r *RealLibvirtClient) ConnectListAllDomains(ctx context.Context, needResults int32, flags libvirt.ConnectListAllDomainsFlags) ([]libvirt.Domain, uint32, error) {
+	select {
+	case <-ctx.Done():
+		return nil, 0, ctx.Err()
+	default:
+	}
+	return r.conn.ConnectListAllDomains(needResults, flags)
+}
+
+func (r *RealLibvirtClient) NodeGetMemoryStats(ctx context.Context, nparams int32, cellNum int32, flags uint32) ([]libvirt.NodeGetMemoryStats, int32, error) {
+	select {
+	case <-ctx.Done():
+		return nil, 0, ctx.Err()
+	default:
+	}
+	return r.conn.NodeGetMemoryStats(nparams, cellNum, flags)
+}
+
 // Domain
 
 func (r *RealLibvirtClient) DomainLookupByName(ctx context.Context, name string) (libvirt.Domain, error) {
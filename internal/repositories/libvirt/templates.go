@@ -177,3 +177,15 @@ func generateNetworkXML(name, bridgeName, gatewayIP, rangeStart, rangeEnd string
   </ip>
 </network>`, escapedName, escapedBridgeName, escapedGatewayIP, escapedRangeStart, escapedRangeEnd)
 }
+
+// generateOVSInterfaceXML describes a virtio NIC whose tap device libvirt plugs into an OVS bridge.
+func generateOVSInterfaceXML(bridge, tapName, macAddress string) string {
+	return fmt.Sprintf(`
+    <interface type='bridge'>
+      <mac address='%s'/>
+      <source bridge='%s'/>
+      <virtualport type='openvswitch'/>
+      <target dev='%s'/>
+      <model type='virtio'/>
+    </interface>`, xmlEscape(macAddress), xmlEscape(bridge), xmlEscape(tapName))
+}
@@ -59,6 +59,9 @@ func (r *NoopVpcRepository) GetByIdempotencyKey(ctx context.Context, key string)
 func (r *NoopVpcRepository) List(ctx context.Context) ([]*domain.VPC, error) {
 	return []*domain.VPC{}, nil
 }
+func (r *NoopVpcRepository) ListAll(ctx context.Context) ([]*domain.VPC, error) {
+	return []*domain.VPC{}, nil
+}
 func (r *NoopVpcRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.VPC, error) {
 	return []*domain.VPC{}, nil
 }
@@ -151,6 +154,12 @@ func (b *NoopComputeBackend) AttachVolume(ctx context.Context, id string, volume
 func (b *NoopComputeBackend) DetachVolume(ctx context.Context, id string, volumePath string) (string, error) {
 	return "", nil
 }
+func (b *NoopComputeBackend) AttachNetworkInterface(ctx context.Context, id string, nic ports.NetworkInterfaceOptions) error {
+	return nil
+}
+func (b *NoopComputeBackend) DetachNetworkInterface(ctx context.Context, id string, nic ports.NetworkInterfaceOptions) error {
+	return nil
+}
 func (b *NoopComputeBackend) Ping(ctx context.Context) error                      { return nil }
 func (b *NoopComputeBackend) Type() string                                   { return "noop" }
 func (b *NoopComputeBackend) PauseInstance(ctx context.Context, id string) error   { return nil }
@@ -160,6 +169,9 @@ func (b *NoopComputeBackend) CreateSnapshot(ctx context.Context, id, name string
 func (b *NoopComputeBackend) RestoreSnapshot(ctx context.Context, id, name string) error { return nil }
 func (b *NoopComputeBackend) DeleteSnapshot(ctx context.Context, id, name string) error { return nil }
 func (b *NoopComputeBackend) ResetCircuitBreaker() {}
+func (b *NoopComputeBackend) HostMemoryPressure(ctx context.Context) ([]ports.HostPressure, error) {
+	return nil, nil
+}
 
 // NoopDNSService is a no-op DNS service.
 type NoopDNSService struct{}
@@ -295,6 +307,24 @@ func (s *NoopSecretService) Encrypt(ctx context.Context, userID uuid.UUID, plain
 func (s *NoopSecretService) Decrypt(ctx context.Context, userID uuid.UUID, cipher string) (string, error) {
 	return cipher, nil
 }
+func (s *NoopSecretService) PutSecretValue(ctx context.Context, id uuid.UUID, value string, stage domain.SecretStage) (*domain.SecretVersion, error) {
+	return &domain.SecretVersion{ID: uuid.New(), SecretID: id, Version: 1, Stages: []domain.SecretStage{stage}}, nil
+}
+func (s *NoopSecretService) ListSecretVersions(ctx context.Context, id uuid.UUID) ([]*domain.SecretVersion, error) {
+	return []*domain.SecretVersion{}, nil
+}
+func (s *NoopSecretService) GetSecretVersion(ctx context.Context, id uuid.UUID, version int) (*domain.SecretVersion, error) {
+	return &domain.SecretVersion{SecretID: id, Version: version}, nil
+}
+func (s *NoopSecretService) GetSecretVersionByStage(ctx context.Context, id uuid.UUID, stage domain.SecretStage) (*domain.SecretVersion, error) {
+	return &domain.SecretVersion{SecretID: id, Version: 1, Stages: []domain.SecretStage{stage}}, nil
+}
+func (s *NoopSecretService) UpdateSecretVersionStage(ctx context.Context, id uuid.UUID, stage domain.SecretStage, version int) error {
+	return nil
+}
+func (s *NoopSecretService) ResolveSecretRef(ctx context.Context, ref string) (string, error) {
+	return "", nil
+}
 
 // NoopSecurityGroupService is a no-op security group service.
 type NoopSecurityGroupService struct{}
@@ -321,6 +351,12 @@ func (s *NoopSecurityGroupService) AttachToInstance(ctx context.Context, instID,
 func (s *NoopSecurityGroupService) DetachFromInstance(ctx context.Context, instID, groupID uuid.UUID) error {
 	return nil
 }
+func (s *NoopSecurityGroupService) AttachToNetworkInterface(ctx context.Context, interfaceID, groupID uuid.UUID) error {
+	return nil
+}
+func (s *NoopSecurityGroupService) DetachFromNetworkInterface(ctx context.Context, interfaceID, groupID uuid.UUID) error {
+	return nil
+}
 
 // NoopStorageService is a no-op storage service.
 type NoopStorageService struct{}
@@ -488,6 +524,15 @@ func (r *NoopDatabaseRepository) List(ctx context.Context) ([]*domain.Database,
 func (r *NoopDatabaseRepository) ListReplicas(ctx context.Context, primaryID uuid.UUID) ([]*domain.Database, error) {
 	return []*domain.Database{}, nil
 }
+func (r *NoopDatabaseRepository) ListWithBackupRetention(ctx context.Context) ([]*domain.Database, error) {
+	return []*domain.Database{}, nil
+}
+func (r *NoopDatabaseRepository) ListPendingMaintenance(ctx context.Context) ([]*domain.Database, error) {
+	return []*domain.Database{}, nil
+}
+func (r *NoopDatabaseRepository) ListWithMetrics(ctx context.Context) ([]*domain.Database, error) {
+	return []*domain.Database{}, nil
+}
 func (r *NoopDatabaseRepository) Update(ctx context.Context, db *domain.Database) error { return nil }
 func (r *NoopDatabaseRepository) Delete(ctx context.Context, id uuid.UUID) error        { return nil }
 
@@ -538,6 +583,9 @@ func (s *NoopIdentityService) DeleteServiceAccount(ctx context.Context, id uuid.
 func (s *NoopIdentityService) ValidateClientCredentials(ctx context.Context, clientID, clientSecret string) (string, error) {
 	return "noop-token", nil
 }
+func (s *NoopIdentityService) IssueServiceAccountToken(ctx context.Context, saID uuid.UUID) (string, error) {
+	return "noop-token", nil
+}
 func (s *NoopIdentityService) ValidateAccessToken(ctx context.Context, token string) (*domain.ServiceAccountClaims, error) {
 	return &domain.ServiceAccountClaims{ServiceAccountID: uuid.New(), TenantID: uuid.New(), Role: "service"}, nil
 }
@@ -582,6 +630,19 @@ func (r *NoopCacheRepository) List(ctx context.Context, tenantID uuid.UUID) ([]*
 }
 func (r *NoopCacheRepository) Update(ctx context.Context, c *domain.Cache) error { return nil }
 func (r *NoopCacheRepository) Delete(ctx context.Context, id, tenantID uuid.UUID) error { return nil }
+func (r *NoopCacheRepository) ListAll(ctx context.Context) ([]*domain.Cache, error) {
+	return []*domain.Cache{}, nil
+}
+func (r *NoopCacheRepository) CreateNode(ctx context.Context, node *domain.CacheNode) error {
+	return nil
+}
+func (r *NoopCacheRepository) ListNodes(ctx context.Context, cacheID uuid.UUID) ([]*domain.CacheNode, error) {
+	return []*domain.CacheNode{}, nil
+}
+func (r *NoopCacheRepository) UpdateNode(ctx context.Context, node *domain.CacheNode) error {
+	return nil
+}
+func (r *NoopCacheRepository) DeleteNode(ctx context.Context, id uuid.UUID) error { return nil }
 
 type NoopLBRepository struct {
 	mu             sync.Mutex
@@ -750,6 +811,9 @@ func (s *NoopRBACService) Authorize(ctx context.Context, userID, tenantID uuid.U
 func (s *NoopRBACService) HasPermission(ctx context.Context, userID, tenantID uuid.UUID, permission domain.Permission, resource string) (bool, error) {
 	return true, nil
 }
+func (s *NoopRBACService) ResourcePolicyOwner(ctx context.Context, userID, tenantID uuid.UUID, permission domain.Permission, resource string) (uuid.UUID, bool) {
+	return uuid.Nil, false
+}
 func (s *NoopRBACService) CreateRole(ctx context.Context, role *domain.Role) error { return nil }
 func (s *NoopRBACService) GetRoleByID(ctx context.Context, id uuid.UUID) (*domain.Role, error) {
 	return &domain.Role{ID: id}, nil
@@ -803,6 +867,60 @@ func (s *NoopDatabaseService) ListDatabaseSnapshots(ctx context.Context, databas
 func (s *NoopDatabaseService) RestoreDatabase(ctx context.Context, req ports.RestoreDatabaseRequest) (*domain.Database, error) {
 	return &domain.Database{ID: uuid.New(), Name: req.NewName, Role: domain.RolePrimary}, nil
 }
+func (s *NoopDatabaseService) ArchiveDatabaseLogs(ctx context.Context) (int, error) { return 0, nil }
+func (s *NoopDatabaseService) PutBackupPolicy(ctx context.Context, databaseID uuid.UUID, req ports.BackupPolicyRequest) (*domain.DatabaseBackupPolicy, error) {
+	return &domain.DatabaseBackupPolicy{DatabaseID: databaseID, Method: req.Method, ExportBucket: req.ExportBucket}, nil
+}
+func (s *NoopDatabaseService) GetBackupPolicy(ctx context.Context, databaseID uuid.UUID) (*domain.DatabaseBackupPolicy, error) {
+	return &domain.DatabaseBackupPolicy{DatabaseID: databaseID}, nil
+}
+func (s *NoopDatabaseService) DeleteBackupPolicy(ctx context.Context, databaseID uuid.UUID) error {
+	return nil
+}
+func (s *NoopDatabaseService) ListAutomatedBackups(ctx context.Context, databaseID uuid.UUID) ([]*domain.DatabaseAutomatedBackup, error) {
+	return []*domain.DatabaseAutomatedBackup{}, nil
+}
+func (s *NoopDatabaseService) DeleteAutomatedBackup(ctx context.Context, databaseID, backupID uuid.UUID) error {
+	return nil
+}
+func (s *NoopDatabaseService) RunScheduledBackups(ctx context.Context) (int, error) { return 0, nil }
+func (s *NoopDatabaseService) UpgradeDatabase(ctx context.Context, req ports.UpgradeDatabaseRequest) (*domain.Database, error) {
+	return &domain.Database{ID: req.ID, Version: req.Version}, nil
+}
+func (s *NoopDatabaseService) ListDatabaseUpgrades(ctx context.Context, databaseID uuid.UUID) ([]*domain.DatabaseUpgrade, error) {
+	return []*domain.DatabaseUpgrade{}, nil
+}
+func (s *NoopDatabaseService) RunMaintenance(ctx context.Context) (int, error) { return 0, nil }
+func (s *NoopDatabaseService) CreateLogicalDatabase(ctx context.Context, databaseID uuid.UUID, name string) (*domain.LogicalDatabase, error) {
+	return &domain.LogicalDatabase{ID: uuid.New(), DatabaseID: databaseID, Name: name}, nil
+}
+func (s *NoopDatabaseService) ListLogicalDatabases(ctx context.Context, databaseID uuid.UUID) ([]*domain.LogicalDatabase, error) {
+	return []*domain.LogicalDatabase{}, nil
+}
+func (s *NoopDatabaseService) DeleteLogicalDatabase(ctx context.Context, databaseID uuid.UUID, name string) error {
+	return nil
+}
+func (s *NoopDatabaseService) CreateDatabaseUser(ctx context.Context, req ports.CreateDatabaseUserRequest) (*domain.DatabaseUser, error) {
+	return &domain.DatabaseUser{ID: uuid.New(), DatabaseID: req.DatabaseID, Username: req.Username, Grants: req.Grants}, nil
+}
+func (s *NoopDatabaseService) ListDatabaseUsers(ctx context.Context, databaseID uuid.UUID) ([]*domain.DatabaseUser, error) {
+	return []*domain.DatabaseUser{}, nil
+}
+func (s *NoopDatabaseService) SetDatabaseUserGrants(ctx context.Context, databaseID uuid.UUID, username string, grants []domain.DatabaseGrant) (*domain.DatabaseUser, error) {
+	return &domain.DatabaseUser{DatabaseID: databaseID, Username: username, Grants: grants}, nil
+}
+func (s *NoopDatabaseService) RotateDatabaseUserCredentials(ctx context.Context, databaseID uuid.UUID, username string) (*domain.DatabaseUser, error) {
+	return &domain.DatabaseUser{DatabaseID: databaseID, Username: username}, nil
+}
+func (s *NoopDatabaseService) DeleteDatabaseUser(ctx context.Context, databaseID uuid.UUID, username string) error {
+	return nil
+}
+func (s *NoopDatabaseService) GetDatabaseInsights(ctx context.Context, databaseID uuid.UUID, period time.Duration) (*domain.DatabaseInsights, error) {
+	return domain.NewDatabaseInsights(databaseID, nil, nil), nil
+}
+func (s *NoopDatabaseService) CollectDatabaseInsights(ctx context.Context) (int, error) {
+	return 0, nil
+}
 func (s *NoopDatabaseService) CreateReplica(ctx context.Context, primaryID uuid.UUID, name string) (*domain.Database, error) {
 	return &domain.Database{ID: uuid.New(), Name: name, Role: domain.RoleReplica}, nil
 }
@@ -282,6 +282,9 @@ func TestNoopNetworkAdapter(t *testing.T) {
 		flows, err := adapter.ListFlowRules(ctx, "br0")
 		require.NoError(t, err)
 		require.Empty(t, flows)
+		entries, err := adapter.ListConntrackEntries(ctx, 100)
+		require.NoError(t, err)
+		require.Empty(t, entries)
 	})
 
 	t.Run("VethAndL3", func(t *testing.T) {
@@ -67,6 +67,10 @@ func (n *NoopNetworkAdapter) ListFlowRules(ctx context.Context, bridge string) (
 	return []ports.FlowRule{}, nil
 }
 
+func (n *NoopNetworkAdapter) ListConntrackEntries(ctx context.Context, zone int) ([]ports.ConntrackEntry, error) {
+	return []ports.ConntrackEntry{}, nil
+}
+
 func (n *NoopNetworkAdapter) CreateVethPair(ctx context.Context, hostEnd, containerEnd string) error {
 	n.logger.Warn("noop network adapter: CreateVethPair called but not implemented")
 	return nil
@@ -8,6 +8,7 @@ import (
 	"net"
 	"os/exec"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/poyrazk/thecloud/internal/core/ports"
@@ -179,13 +180,18 @@ func (a *OvsAdapter) AddFlowRule(ctx context.Context, bridge string, rule ports.
 		return errors.New(errors.InvalidInput, invalidBridgeNameMsg)
 	}
 
-	// Basic validation to prevent command/flow injection
-	if strings.ContainsAny(rule.Match, ";|&><`$") || strings.ContainsAny(rule.Actions, ";|&><`$") {
+	// Basic validation to prevent command/flow injection. The "->" of register
+	// loads such as load:1->NXM_NX_REG0[0] is the only arrow allowed.
+	actions := strings.ReplaceAll(rule.Actions, "->", "")
+	if strings.ContainsAny(rule.Match, ";|&><`$") || strings.ContainsAny(actions, ";|&><`$") {
 		return errors.New(errors.InvalidInput, "invalid characters in flow rule")
 	}
 
 	// ovs-ofctl add-flow <bridge> priority=<p>,<match>,actions=<actions>
 	flowSpec := fmt.Sprintf("priority=%d,%s,actions=%s", rule.Priority, rule.Match, rule.Actions)
+	if rule.Cookie != 0 {
+		flowSpec = fmt.Sprintf("cookie=0x%x,%s", rule.Cookie, flowSpec)
+	}
 	cmd := a.exec.CommandContext(ctx, a.ofctlPath, "add-flow", bridge, flowSpec)
 	if err := cmd.Run(); err != nil {
 		return errors.Wrap(errors.Internal, "failed to add flow rule", err)
@@ -207,9 +213,141 @@ func (a *OvsAdapter) DeleteFlowRule(ctx context.Context, bridge string, match st
 	return nil
 }
 
-func (a *OvsAdapter) ListFlowRules(_ context.Context, _ string) ([]ports.FlowRule, error) {
-	// Parsing ovs-ofctl dump-flows output is complex and will be implemented in a follow-up.
-	return []ports.FlowRule{}, nil
+func (a *OvsAdapter) ListFlowRules(ctx context.Context, bridge string) ([]ports.FlowRule, error) {
+	if !bridgeNameRegex.MatchString(bridge) {
+		return nil, errors.New(errors.InvalidInput, invalidBridgeNameMsg)
+	}
+
+	cmd := a.exec.CommandContext(ctx, a.ofctlPath, "dump-flows", bridge)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to dump flow rules", err)
+	}
+	return parseFlowDump(string(output)), nil
+}
+
+// flowStatFields are dump-flows fields that describe counters or timeouts rather than the match.
+var flowStatFields = map[string]bool{
+	"duration": true, "n_packets": true, "n_bytes": true,
+	"idle_age": true, "hard_age": true, "idle_timeout": true, "hard_timeout": true,
+	"importance": true, "send_flow_rem": true, "reset_counts": true,
+	"no_packet_counts": true, "no_byte_counts": true,
+}
+
+// defaultFlowPriority is the OpenFlow priority ovs-ofctl omits from dump-flows output.
+const defaultFlowPriority = 32768
+
+// parseFlowDump parses "ovs-ofctl dump-flows" output such as
+//
+//	cookie=0x1f, duration=3.2s, table=0, n_packets=0, n_bytes=0, priority=300,ip,nw_dst=10.1.0.0/16 actions=NORMAL
+//
+// into flow rules. Header lines and anything without an actions field are skipped.
+// Table 0 is implied, so only other tables are kept in the match, the way
+// AddFlowRule callers spell them.
+func parseFlowDump(output string) []ports.FlowRule {
+	rules := []ports.FlowRule{}
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		idx := strings.Index(line, " actions=")
+		if idx < 0 {
+			continue
+		}
+
+		rule := ports.FlowRule{Priority: defaultFlowPriority, Actions: strings.TrimSpace(line[idx+len(" actions="):])}
+		var match []string
+		for _, field := range strings.Split(line[:idx], ",") {
+			field = strings.TrimSpace(field)
+			if field == "" {
+				continue
+			}
+			key, value, _ := strings.Cut(field, "=")
+			switch {
+			case key == "cookie":
+				rule.Cookie, _ = strconv.ParseUint(value, 0, 64)
+			case key == "priority":
+				if p, err := strconv.Atoi(value); err == nil {
+					rule.Priority = p
+				}
+			case key == "n_packets":
+				rule.Packets, _ = strconv.ParseUint(value, 10, 64)
+			case key == "n_bytes":
+				rule.Bytes, _ = strconv.ParseUint(value, 10, 64)
+			case key == "table" && value == "0":
+			case flowStatFields[key]:
+			default:
+				match = append(match, field)
+			}
+		}
+		rule.Match = strings.Join(match, ",")
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+func (a *OvsAdapter) ListConntrackEntries(ctx context.Context, zone int) ([]ports.ConntrackEntry, error) {
+	if zone < 0 || zone > 0xffff {
+		return nil, errors.New(errors.InvalidInput, "invalid conntrack zone")
+	}
+
+	cmd := a.exec.CommandContext(ctx, "ovs-appctl", "dpctl/dump-conntrack", "-s", fmt.Sprintf("zone=%d", zone))
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to dump conntrack entries", err)
+	}
+	return parseConntrackDump(string(output)), nil
+}
+
+var conntrackTupleRegex = regexp.MustCompile(`(orig|reply)=\(([^)]*)\)`)
+
+// parseConntrackDump parses "ovs-appctl dpctl/dump-conntrack -s" output such as
+//
+//	tcp,orig=(src=10.0.0.2,dst=10.0.0.3,sport=45678,dport=80,packets=5,bytes=400),reply=(src=10.0.0.3,dst=10.0.0.2,sport=80,dport=45678,packets=4,bytes=300),id=42,zone=101,protoinfo=(state=ESTABLISHED)
+//
+// The 5-tuple comes from the original direction; counters are summed over both directions.
+func parseConntrackDump(output string) []ports.ConntrackEntry {
+	entries := []ports.ConntrackEntry{}
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		proto, rest, ok := strings.Cut(line, ",")
+		if !ok || !strings.Contains(rest, "orig=(") {
+			continue
+		}
+
+		entry := ports.ConntrackEntry{Protocol: proto}
+		for _, m := range conntrackTupleRegex.FindAllStringSubmatch(rest, -1) {
+			for _, field := range strings.Split(m[2], ",") {
+				key, value, _ := strings.Cut(field, "=")
+				switch key {
+				case "packets":
+					n, _ := strconv.ParseUint(value, 10, 64)
+					entry.Packets += n
+				case "bytes":
+					n, _ := strconv.ParseUint(value, 10, 64)
+					entry.Bytes += n
+				}
+				if m[1] != "orig" {
+					continue
+				}
+				switch key {
+				case "src":
+					entry.Src = value
+				case "dst":
+					entry.Dst = value
+				case "sport":
+					entry.SrcPort, _ = strconv.Atoi(value)
+				case "dport":
+					entry.DstPort, _ = strconv.Atoi(value)
+				}
+			}
+		}
+		for _, field := range strings.Split(conntrackTupleRegex.ReplaceAllString(rest, ""), ",") {
+			if key, value, _ := strings.Cut(field, "="); key == "id" {
+				entry.ID, _ = strconv.ParseUint(value, 10, 64)
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries
 }
 
 func (a *OvsAdapter) CreateVethPair(ctx context.Context, hostEnd, containerEnd string) error {
@@ -41,6 +41,7 @@ type fakeExecer struct {
 	lookPath map[string]string
 	lookErr  error
 	cmd      *fakeCmd
+	lastArgs []string
 }
 
 func (e *fakeExecer) LookPath(file string) (string, error) {
@@ -54,6 +55,7 @@ func (e *fakeExecer) LookPath(file string) (string, error) {
 }
 
 func (e *fakeExecer) CommandContext(ctx context.Context, name string, args ...string) cmd {
+	e.lastArgs = args
 	return e.cmd
 }
 
@@ -96,6 +98,30 @@ func TestOvsAdapterAddFlowRuleSuccess(t *testing.T) {
 
 	err := a.AddFlowRule(context.Background(), "br0", ports.FlowRule{Priority: 100, Match: "ip", Actions: "normal"})
 	require.NoError(t, err)
+	require.Equal(t, []string{"add-flow", "br0", "priority=100,ip,actions=normal"}, fx.lastArgs)
+}
+
+func TestOvsAdapterAddFlowRuleWithCookie(t *testing.T) {
+	fx := &fakeExecer{cmd: &fakeCmd{}}
+	a := &OvsAdapter{ofctlPath: ovsOfctlPath, logger: slog.Default(), exec: fx}
+
+	err := a.AddFlowRule(context.Background(), "br0", ports.FlowRule{Priority: 100, Match: "tcp,ct_state=+trk+new,tp_dst=0x1f40/0xffc0", Actions: "ct(commit),NORMAL", Cookie: 0xab12})
+	require.NoError(t, err)
+	require.Equal(t, "cookie=0xab12,priority=100,tcp,ct_state=+trk+new,tp_dst=0x1f40/0xffc0,actions=ct(commit),NORMAL", fx.lastArgs[2])
+}
+
+func TestOvsAdapterAddFlowRuleRegisterLoad(t *testing.T) {
+	fx := &fakeExecer{cmd: &fakeCmd{}}
+	a := &OvsAdapter{ofctlPath: ovsOfctlPath, logger: slog.Default(), exec: fx}
+
+	err := a.AddFlowRule(context.Background(), "br0", ports.FlowRule{Priority: 65301, Match: "in_port=veth-1,ip,nw_src=10.0.1.5,reg0=0/0x1", Actions: "load:1->NXM_NX_REG0[0],resubmit(,0)"})
+	require.NoError(t, err)
+	require.Equal(t, "priority=65301,in_port=veth-1,ip,nw_src=10.0.1.5,reg0=0/0x1,actions=load:1->NXM_NX_REG0[0],resubmit(,0)", fx.lastArgs[2])
+
+	for _, actions := range []string{"drop>x", "load:1->reg0;rm"} {
+		err = a.AddFlowRule(context.Background(), "br0", ports.FlowRule{Priority: 1, Match: "ip", Actions: actions})
+		require.True(t, apperrors.Is(err, apperrors.InvalidInput), actions)
+	}
 }
 
 func TestOvsAdapterAddPort(t *testing.T) {
@@ -214,12 +240,59 @@ func TestOvsAdapterSetVethIP(t *testing.T) {
 }
 
 func TestOvsAdapterListFlowRules(t *testing.T) {
-	fx := &fakeExecer{cmd: &fakeCmd{out: []byte("cookie=0x0, duration=1.0s, table=0, n_packets=0, n_bytes=0, priority=100,ip actions=NORMAL\n")}}
+	dump := "NXST_FLOW reply (xid=0x4):\n" +
+		" cookie=0x0, duration=1.0s, table=0, n_packets=0, n_bytes=0, priority=100,ip actions=NORMAL\n" +
+		" cookie=0x3e8a, duration=2.5s, table=0, n_packets=12, n_bytes=840, idle_age=3, priority=200,ct_state=+new+trk,tcp,nw_src=10.0.1.5,tp_dst=0x1f40/0xffc0 actions=ct(commit),NORMAL\n" +
+		" cookie=0x0, duration=9.1s, table=0, n_packets=4, n_bytes=168, actions=NORMAL\n" +
+		" cookie=0x7b, duration=4.0s, table=2, n_packets=1, n_bytes=60, priority=1,ip,nw_dst=10.0.1.0/24 actions=drop\n"
+	fx := &fakeExecer{cmd: &fakeCmd{out: []byte(dump)}}
 	a := &OvsAdapter{ofctlPath: ovsOfctlPath, logger: slog.Default(), exec: fx}
 
 	rules, err := a.ListFlowRules(context.Background(), "br0")
 	require.NoError(t, err)
-	require.NotNil(t, rules)
+	require.Equal(t, []string{"dump-flows", "br0"}, fx.lastArgs)
+	require.Equal(t, []ports.FlowRule{
+		{Priority: 100, Match: "ip", Actions: "NORMAL"},
+		{Priority: 200, Match: "ct_state=+new+trk,tcp,nw_src=10.0.1.5,tp_dst=0x1f40/0xffc0", Actions: "ct(commit),NORMAL", Cookie: 0x3e8a, Packets: 12, Bytes: 840},
+		{Priority: 32768, Match: "", Actions: "NORMAL", Packets: 4, Bytes: 168},
+		{Priority: 1, Match: "table=2,ip,nw_dst=10.0.1.0/24", Actions: "drop", Cookie: 0x7b, Packets: 1, Bytes: 60},
+	}, rules)
+}
+
+func TestOvsAdapterListFlowRulesErrors(t *testing.T) {
+	a := &OvsAdapter{ofctlPath: ovsOfctlPath, logger: slog.Default(), exec: &fakeExecer{cmd: &fakeCmd{}}}
+	_, err := a.ListFlowRules(context.Background(), badBridge)
+	require.True(t, apperrors.Is(err, apperrors.InvalidInput))
+
+	a.exec = &fakeExecer{cmd: &fakeCmd{outErr: errors.New("no bridge")}}
+	_, err = a.ListFlowRules(context.Background(), "br0")
+	require.True(t, apperrors.Is(err, apperrors.Internal))
+}
+
+func TestOvsAdapterListConntrackEntries(t *testing.T) {
+	dump := "tcp,orig=(src=10.0.0.2,dst=10.0.0.3,sport=45678,dport=80,packets=5,bytes=400),reply=(src=10.0.0.3,dst=10.0.0.2,sport=80,dport=45678,packets=4,bytes=300),id=42,zone=101,status=SEEN_REPLY|ASSURED|CONFIRMED,timeout=431999,protoinfo=(state=ESTABLISHED)\n" +
+		"icmp,orig=(src=10.0.0.2,dst=10.0.0.9,id=7,type=8,code=0,packets=1,bytes=84),reply=(src=10.0.0.9,dst=10.0.0.2,id=7,type=0,code=0,packets=1,bytes=84),id=43,zone=101\n" +
+		"garbage\n"
+	fx := &fakeExecer{cmd: &fakeCmd{out: []byte(dump)}}
+	a := &OvsAdapter{logger: slog.Default(), exec: fx}
+
+	entries, err := a.ListConntrackEntries(context.Background(), 101)
+	require.NoError(t, err)
+	require.Equal(t, []string{"dpctl/dump-conntrack", "-s", "zone=101"}, fx.lastArgs)
+	require.Equal(t, []ports.ConntrackEntry{
+		{ID: 42, Protocol: "tcp", Src: "10.0.0.2", Dst: "10.0.0.3", SrcPort: 45678, DstPort: 80, Packets: 9, Bytes: 700},
+		{ID: 43, Protocol: "icmp", Src: "10.0.0.2", Dst: "10.0.0.9", Packets: 2, Bytes: 168},
+	}, entries)
+}
+
+func TestOvsAdapterListConntrackEntriesErrors(t *testing.T) {
+	a := &OvsAdapter{logger: slog.Default(), exec: &fakeExecer{cmd: &fakeCmd{}}}
+	_, err := a.ListConntrackEntries(context.Background(), 70000)
+	require.True(t, apperrors.Is(err, apperrors.InvalidInput))
+
+	a.exec = &fakeExecer{cmd: &fakeCmd{outErr: errors.New("no datapath")}}
+	_, err = a.ListConntrackEntries(context.Background(), 101)
+	require.True(t, apperrors.Is(err, apperrors.Internal))
 }
 
 func TestOvsAdapterSetupNATForSubnet(t *testing.T) {
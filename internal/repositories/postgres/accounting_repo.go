@@ -70,6 +70,35 @@ func (r *accountingRepository) GetUsageSummary(ctx context.Context, userID uuid.
 	return summary, nil
 }
 
+func (r *accountingRepository) GetUsageSummaryByTenant(ctx context.Context, tenantIDs []uuid.UUID, start, end time.Time) (map[uuid.UUID]map[domain.ResourceType]float64, error) {
+	query := `
+		SELECT tenant_id, resource_type, SUM(quantity)
+		FROM usage_records
+		WHERE tenant_id = ANY($1) AND start_time >= $2 AND end_time <= $3
+		GROUP BY tenant_id, resource_type
+	`
+	rows, err := r.db.Query(ctx, query, tenantIDs, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get usage summary by tenant: %w", err)
+	}
+	defer rows.Close()
+
+	summary := make(map[uuid.UUID]map[domain.ResourceType]float64)
+	for rows.Next() {
+		var tenantID uuid.UUID
+		var resType string
+		var total float64
+		if err := rows.Scan(&tenantID, &resType, &total); err != nil {
+			return nil, err
+		}
+		if summary[tenantID] == nil {
+			summary[tenantID] = make(map[domain.ResourceType]float64)
+		}
+		summary[tenantID][domain.ResourceType(resType)] = total
+	}
+	return summary, rows.Err()
+}
+
 func (r *accountingRepository) ListRecords(ctx context.Context, userID uuid.UUID, start, end time.Time) ([]domain.UsageRecord, error) {
 	tenantID := appcontext.TenantIDFromContext(ctx)
 	query := `
@@ -67,6 +67,30 @@ func TestAccountingRepository_GetUsageSummary(t *testing.T) {
 	assert.InDelta(t, 5.0, summary[domain.ResourceStorage], 0.01)
 }
 
+func TestAccountingRepository_GetUsageSummaryByTenant(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewAccountingRepository(mock)
+	tenantA, tenantB := uuid.New(), uuid.New()
+	tenantIDs := []uuid.UUID{tenantA, tenantB}
+	start := time.Now()
+	end := time.Now().Add(time.Hour)
+
+	mock.ExpectQuery("SELECT tenant_id, resource_type, SUM\\(quantity\\)").
+		WithArgs(tenantIDs, start, end).
+		WillReturnRows(pgxmock.NewRows([]string{"tenant_id", "resource_type", "sum"}).
+			AddRow(tenantA, string(domain.ResourceInstance), 10.5).
+			AddRow(tenantB, string(domain.ResourceStorage), 5.0))
+
+	summary, err := repo.GetUsageSummaryByTenant(context.Background(), tenantIDs, start, end)
+	require.NoError(t, err)
+	assert.InDelta(t, 10.5, summary[tenantA][domain.ResourceInstance], 0.01)
+	assert.InDelta(t, 5.0, summary[tenantB][domain.ResourceStorage], 0.01)
+}
+
 func TestAccountingRepository_ListRecords(t *testing.T) {
 	t.Parallel()
 	mock, err := pgxmock.NewPool()
@@ -25,13 +25,17 @@ func (r *CacheRepository) Create(ctx context.Context, cache *domain.Cache) error
 	query := `
 		INSERT INTO caches (
 			id, user_id, tenant_id, name, engine, version, status, vpc_id,
-			container_id, port, password, memory_mb, created_at, updated_at
+			container_id, port, password, memory_mb, persistence, replicas,
+			failover_enabled, reader_port, proxy_container_id, cluster_mode, shards,
+			metrics_enabled, metrics_port, exporter_container_id, created_at, updated_at
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24)
 	`
 	_, err := r.db.Exec(ctx, query,
 		cache.ID, cache.UserID, cache.TenantID, cache.Name, cache.Engine, cache.Version, cache.Status, cache.VpcID,
-		cache.ContainerID, cache.Port, cache.Password, cache.MemoryMB, cache.CreatedAt, cache.UpdatedAt,
+		cache.ContainerID, cache.Port, cache.Password, cache.MemoryMB, cache.Persistence, cache.Replicas,
+		cache.FailoverEnabled, cache.ReaderPort, cache.ProxyContainerID, cache.ClusterMode, cache.Shards,
+		cache.MetricsEnabled, cache.MetricsPort, cache.ExporterContainerID, cache.CreatedAt, cache.UpdatedAt,
 	)
 	if err != nil {
 		return errors.Wrap(errors.Internal, "failed to create cache", err)
@@ -43,7 +47,9 @@ func (r *CacheRepository) GetByID(ctx context.Context, id, tenantID uuid.UUID) (
 	query := `
 		SELECT
 			id, user_id, tenant_id, name, engine, version, status, vpc_id,
-			container_id, port, password, memory_mb, created_at, updated_at
+			container_id, port, password, memory_mb, persistence, replicas,
+			failover_enabled, reader_port, proxy_container_id, cluster_mode, shards,
+			metrics_enabled, metrics_port, exporter_container_id, created_at, updated_at
 		FROM caches
 		WHERE id = $1 AND tenant_id = $2
 	`
@@ -54,7 +60,9 @@ func (r *CacheRepository) GetByName(ctx context.Context, tenantID uuid.UUID, nam
 	query := `
 		SELECT
 			id, user_id, tenant_id, name, engine, version, status, vpc_id,
-			container_id, port, password, memory_mb, created_at, updated_at
+			container_id, port, password, memory_mb, persistence, replicas,
+			failover_enabled, reader_port, proxy_container_id, cluster_mode, shards,
+			metrics_enabled, metrics_port, exporter_container_id, created_at, updated_at
 		FROM caches
 		WHERE tenant_id = $1 AND name = $2
 	`
@@ -65,7 +73,9 @@ func (r *CacheRepository) List(ctx context.Context, tenantID uuid.UUID) ([]*doma
 	query := `
 		SELECT
 			id, user_id, tenant_id, name, engine, version, status, vpc_id,
-			container_id, port, password, memory_mb, created_at, updated_at
+			container_id, port, password, memory_mb, persistence, replicas,
+			failover_enabled, reader_port, proxy_container_id, cluster_mode, shards,
+			metrics_enabled, metrics_port, exporter_container_id, created_at, updated_at
 		FROM caches
 		WHERE tenant_id = $1
 		ORDER BY created_at DESC
@@ -77,12 +87,33 @@ func (r *CacheRepository) List(ctx context.Context, tenantID uuid.UUID) ([]*doma
 	return r.scanCaches(rows)
 }
 
+// ListAll returns the caches of all tenants. It is meant for background
+// workers, which act on behalf of each cache's owner.
+func (r *CacheRepository) ListAll(ctx context.Context) ([]*domain.Cache, error) {
+	query := `
+		SELECT
+			id, user_id, tenant_id, name, engine, version, status, vpc_id,
+			container_id, port, password, memory_mb, persistence, replicas,
+			failover_enabled, reader_port, proxy_container_id, cluster_mode, shards,
+			metrics_enabled, metrics_port, exporter_container_id, created_at, updated_at
+		FROM caches
+		ORDER BY created_at
+	`
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list caches", err)
+	}
+	return r.scanCaches(rows)
+}
+
 func (r *CacheRepository) scanCache(row pgx.Row) (*domain.Cache, error) {
 	var cache domain.Cache
-	var engine, status string
+	var engine, status, persistence string
 	err := row.Scan(
 		&cache.ID, &cache.UserID, &cache.TenantID, &cache.Name, &engine, &cache.Version, &status, &cache.VpcID,
-		&cache.ContainerID, &cache.Port, &cache.Password, &cache.MemoryMB, &cache.CreatedAt, &cache.UpdatedAt,
+		&cache.ContainerID, &cache.Port, &cache.Password, &cache.MemoryMB, &persistence, &cache.Replicas,
+		&cache.FailoverEnabled, &cache.ReaderPort, &cache.ProxyContainerID, &cache.ClusterMode, &cache.Shards,
+		&cache.MetricsEnabled, &cache.MetricsPort, &cache.ExporterContainerID, &cache.CreatedAt, &cache.UpdatedAt,
 	)
 	if err != nil {
 		if stdlib_errors.Is(err, pgx.ErrNoRows) {
@@ -92,6 +123,7 @@ func (r *CacheRepository) scanCache(row pgx.Row) (*domain.Cache, error) {
 	}
 	cache.Engine = domain.CacheEngine(engine)
 	cache.Status = domain.CacheStatus(status)
+	cache.Persistence = domain.CachePersistence(persistence)
 	return &cache, nil
 }
 
@@ -114,11 +146,23 @@ func (r *CacheRepository) Update(ctx context.Context, cache *domain.Cache) error
 			status = $1,
 			container_id = $2,
 			port = $3,
-			updated_at = $4
-		WHERE id = $5
+			memory_mb = $4,
+			persistence = $5,
+			replicas = $6,
+			failover_enabled = $7,
+			reader_port = $8,
+			proxy_container_id = $9,
+			shards = $10,
+			metrics_port = $11,
+			exporter_container_id = $12,
+			password = $13,
+			updated_at = $14
+		WHERE id = $15
 	`
 	_, err := r.db.Exec(ctx, query,
-		cache.Status, cache.ContainerID, cache.Port, cache.UpdatedAt, cache.ID,
+		cache.Status, cache.ContainerID, cache.Port, cache.MemoryMB, cache.Persistence, cache.Replicas,
+		cache.FailoverEnabled, cache.ReaderPort, cache.ProxyContainerID, cache.Shards,
+		cache.MetricsPort, cache.ExporterContainerID, cache.Password, cache.UpdatedAt, cache.ID,
 	)
 	if err != nil {
 		return errors.Wrap(errors.Internal, "failed to update cache", err)
@@ -134,3 +178,59 @@ func (r *CacheRepository) Delete(ctx context.Context, id, tenantID uuid.UUID) er
 	}
 	return nil
 }
+
+const cacheNodeColumns = "id, cache_id, role, shard, status, container_id, volume_id, created_at, updated_at"
+
+func (r *CacheRepository) CreateNode(ctx context.Context, node *domain.CacheNode) error {
+	query := `INSERT INTO cache_nodes (` + cacheNodeColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	_, err := r.db.Exec(ctx, query,
+		node.ID, node.CacheID, node.Role, node.Shard, node.Status, node.ContainerID, node.VolumeID, node.CreatedAt, node.UpdatedAt,
+	)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to create cache node", err)
+	}
+	return nil
+}
+
+func (r *CacheRepository) ListNodes(ctx context.Context, cacheID uuid.UUID) ([]*domain.CacheNode, error) {
+	query := `SELECT ` + cacheNodeColumns + ` FROM cache_nodes
+		WHERE cache_id = $1
+		ORDER BY shard, role = 'PRIMARY' DESC, created_at`
+	rows, err := r.db.Query(ctx, query, cacheID)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list cache nodes", err)
+	}
+	defer rows.Close()
+
+	nodes := make([]*domain.CacheNode, 0)
+	for rows.Next() {
+		var node domain.CacheNode
+		var role, status string
+		if err := rows.Scan(&node.ID, &node.CacheID, &role, &node.Shard, &status, &node.ContainerID, &node.VolumeID, &node.CreatedAt, &node.UpdatedAt); err != nil {
+			return nil, errors.Wrap(errors.Internal, "failed to scan cache node", err)
+		}
+		node.Role = domain.CacheNodeRole(role)
+		node.Status = domain.CacheStatus(status)
+		nodes = append(nodes, &node)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to iterate cache nodes", err)
+	}
+	return nodes, nil
+}
+
+func (r *CacheRepository) UpdateNode(ctx context.Context, node *domain.CacheNode) error {
+	query := `UPDATE cache_nodes SET role = $1, shard = $2, status = $3, container_id = $4, volume_id = $5, updated_at = $6 WHERE id = $7`
+	_, err := r.db.Exec(ctx, query, node.Role, node.Shard, node.Status, node.ContainerID, node.VolumeID, node.UpdatedAt, node.ID)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to update cache node", err)
+	}
+	return nil
+}
+
+func (r *CacheRepository) DeleteNode(ctx context.Context, id uuid.UUID) error {
+	if _, err := r.db.Exec(ctx, `DELETE FROM cache_nodes WHERE id = $1`, id); err != nil {
+		return errors.Wrap(errors.Internal, "failed to delete cache node", err)
+	}
+	return nil
+}
@@ -36,13 +36,17 @@ func TestCacheRepository_Create(t *testing.T) {
 			Port:        6379,
 			Password:    "pass",
 			MemoryMB:    1024,
+			Persistence: domain.CachePersistenceAOF,
+			Replicas:    2,
 			CreatedAt:   now,
 			UpdatedAt:   now,
 		}
 
 		mock.ExpectExec("INSERT INTO caches").
 			WithArgs(cache.ID, cache.UserID, cache.TenantID, cache.Name, cache.Engine, cache.Version, cache.Status, cache.VpcID,
-				cache.ContainerID, cache.Port, cache.Password, cache.MemoryMB, cache.CreatedAt, cache.UpdatedAt).
+				cache.ContainerID, cache.Port, cache.Password, cache.MemoryMB, cache.Persistence, cache.Replicas,
+				cache.FailoverEnabled, cache.ReaderPort, cache.ProxyContainerID, cache.ClusterMode, cache.Shards,
+				cache.MetricsEnabled, cache.MetricsPort, cache.ExporterContainerID, cache.CreatedAt, cache.UpdatedAt).
 			WillReturnResult(pgxmock.NewResult("INSERT", 1))
 
 		err = repo.Create(context.Background(), cache)
@@ -80,9 +84,9 @@ func TestCacheRepository_GetByID(t *testing.T) {
 
 		mock.ExpectQuery("SELECT.*FROM caches WHERE id = \\$1 AND tenant_id = \\$2").
 			WithArgs(id, tenantID).
-			WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "tenant_id", "name", "engine", "version", "status", "vpc_id", "container_id", "port", "password", "memory_mb", "created_at", "updated_at"}).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "tenant_id", "name", "engine", "version", "status", "vpc_id", "container_id", "port", "password", "memory_mb", "persistence", "replicas", "failover_enabled", "reader_port", "proxy_container_id", "cluster_mode", "shards", "metrics_enabled", "metrics_port", "exporter_container_id", "created_at", "updated_at"}).
 				AddRow(id, uuid.New(), tenantID, "test-cache", string(domain.EngineRedis), "6.2", string(domain.CacheStatusRunning), vpcID,
-					"cid-1", 6379, "pass", 1024, now, now))
+					"cid-1", 6379, "pass", 1024, "none", 0, false, 0, "", false, 0, false, 0, "", now, now))
 
 		cache, err := repo.GetByID(context.Background(), id, tenantID)
 		require.NoError(t, err)
@@ -124,9 +128,9 @@ func TestCacheRepository_GetByName(t *testing.T) {
 
 		mock.ExpectQuery("SELECT.*FROM caches WHERE tenant_id = \\$1 AND name = \\$2").
 			WithArgs(tenantID, name).
-			WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "tenant_id", "name", "engine", "version", "status", "vpc_id", "container_id", "port", "password", "memory_mb", "created_at", "updated_at"}).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "tenant_id", "name", "engine", "version", "status", "vpc_id", "container_id", "port", "password", "memory_mb", "persistence", "replicas", "failover_enabled", "reader_port", "proxy_container_id", "cluster_mode", "shards", "metrics_enabled", "metrics_port", "exporter_container_id", "created_at", "updated_at"}).
 				AddRow(uuid.New(), uuid.New(), tenantID, name, string(domain.EngineRedis), "6.2", string(domain.CacheStatusRunning), nil,
-					"cid-1", 6379, "pass", 1024, now, now))
+					"cid-1", 6379, "pass", 1024, "none", 0, false, 0, "", false, 0, false, 0, "", now, now))
 
 		cache, err := repo.GetByName(context.Background(), tenantID, name)
 		require.NoError(t, err)
@@ -166,9 +170,9 @@ func TestCacheRepository_List(t *testing.T) {
 
 		mock.ExpectQuery("SELECT.*FROM caches WHERE tenant_id = \\$1").
 			WithArgs(tenantID).
-			WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "tenant_id", "name", "engine", "version", "status", "vpc_id", "container_id", "port", "password", "memory_mb", "created_at", "updated_at"}).
-				AddRow(uuid.New(), uuid.New(), tenantID, "cache-1", string(domain.EngineRedis), "6.2", string(domain.CacheStatusRunning), nil, "cid-1", 6379, "pass", 1024, now, now).
-				AddRow(uuid.New(), uuid.New(), tenantID, "cache-2", string(domain.EngineRedis), "6.2", string(domain.CacheStatusStopped), nil, "cid-2", 6380, "pass", 1024, now, now))
+			WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "tenant_id", "name", "engine", "version", "status", "vpc_id", "container_id", "port", "password", "memory_mb", "persistence", "replicas", "failover_enabled", "reader_port", "proxy_container_id", "cluster_mode", "shards", "metrics_enabled", "metrics_port", "exporter_container_id", "created_at", "updated_at"}).
+				AddRow(uuid.New(), uuid.New(), tenantID, "cache-1", string(domain.EngineRedis), "6.2", string(domain.CacheStatusRunning), nil, "cid-1", 6379, "pass", 1024, "none", 0, false, 0, "", false, 0, false, 0, "", now, now).
+				AddRow(uuid.New(), uuid.New(), tenantID, "cache-2", string(domain.EngineRedis), "6.2", string(domain.CacheStatusStopped), nil, "cid-2", 6380, "pass", 1024, "none", 0, false, 0, "", false, 0, false, 0, "", now, now))
 
 		caches, err := repo.List(context.Background(), tenantID)
 		require.NoError(t, err)
@@ -210,7 +214,9 @@ func TestCacheRepository_Update(t *testing.T) {
 		}
 
 		mock.ExpectExec("UPDATE caches").
-			WithArgs(cache.Status, cache.ContainerID, cache.Port, cache.UpdatedAt, cache.ID).
+			WithArgs(cache.Status, cache.ContainerID, cache.Port, cache.MemoryMB, cache.Persistence, cache.Replicas,
+				cache.FailoverEnabled, cache.ReaderPort, cache.ProxyContainerID, cache.Shards,
+				cache.MetricsPort, cache.ExporterContainerID, cache.Password, cache.UpdatedAt, cache.ID).
 			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
 
 		err = repo.Update(context.Background(), cache)
@@ -269,3 +275,76 @@ func TestCacheRepository_Delete(t *testing.T) {
 		assert.True(t, errors.Is(err, errors.Internal))
 	})
 }
+
+func TestCacheRepository_ListAll(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewCacheRepository(mock)
+	now := time.Now()
+
+	mock.ExpectQuery("SELECT.*FROM caches\\s+ORDER BY created_at").
+		WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "tenant_id", "name", "engine", "version", "status", "vpc_id", "container_id", "port", "password", "memory_mb", "persistence", "replicas", "failover_enabled", "reader_port", "proxy_container_id", "cluster_mode", "shards", "metrics_enabled", "metrics_port", "exporter_container_id", "created_at", "updated_at"}).
+			AddRow(uuid.New(), uuid.New(), uuid.New(), "cache-1", string(domain.EngineRedis), "7.2", string(domain.CacheStatusRunning), nil, "cid-1", 30001, "pass", 256, "aof", 2, true, 30002, "proxy-1", true, 3, true, 30003, "exp-1", now, now))
+
+	caches, err := repo.ListAll(context.Background())
+	require.NoError(t, err)
+	require.Len(t, caches, 1)
+	assert.Equal(t, domain.CachePersistenceAOF, caches[0].Persistence)
+	assert.True(t, caches[0].MetricsEnabled)
+	assert.Equal(t, "exp-1", caches[0].ExporterContainerID)
+	assert.Equal(t, 2, caches[0].Replicas)
+	assert.True(t, caches[0].FailoverEnabled)
+	assert.Equal(t, "proxy-1", caches[0].ProxyContainerID)
+	assert.True(t, caches[0].ClusterMode)
+	assert.Equal(t, 3, caches[0].Shards)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCacheRepository_Nodes(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewCacheRepository(mock)
+	now := time.Now()
+	volID := uuid.New()
+	node := &domain.CacheNode{
+		ID: uuid.New(), CacheID: uuid.New(), Role: domain.CacheNodePrimary, Status: domain.CacheStatusRunning,
+		ContainerID: "cid-1", VolumeID: &volID, CreatedAt: now, UpdatedAt: now,
+	}
+
+	mock.ExpectExec("INSERT INTO cache_nodes").
+		WithArgs(node.ID, node.CacheID, node.Role, node.Shard, node.Status, node.ContainerID, node.VolumeID, node.CreatedAt, node.UpdatedAt).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	require.NoError(t, repo.CreateNode(context.Background(), node))
+
+	mock.ExpectQuery("SELECT " + cacheNodeColumns + " FROM cache_nodes").
+		WithArgs(node.CacheID).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "cache_id", "role", "shard", "status", "container_id", "volume_id", "created_at", "updated_at"}).
+			AddRow(node.ID, node.CacheID, "PRIMARY", 0, "RUNNING", "cid-1", &volID, now, now).
+			AddRow(uuid.New(), node.CacheID, "REPLICA", 1, "RUNNING", "cid-2", nil, now, now))
+	nodes, err := repo.ListNodes(context.Background(), node.CacheID)
+	require.NoError(t, err)
+	require.Len(t, nodes, 2)
+	assert.Equal(t, domain.CacheNodePrimary, nodes[0].Role)
+	assert.Equal(t, volID, *nodes[0].VolumeID)
+	assert.Equal(t, domain.CacheNodeReplica, nodes[1].Role)
+	assert.Nil(t, nodes[1].VolumeID)
+	assert.Equal(t, 1, nodes[1].Shard)
+
+	node.Role = domain.CacheNodeReplica
+	mock.ExpectExec("UPDATE cache_nodes SET").
+		WithArgs(node.Role, node.Shard, node.Status, node.ContainerID, node.VolumeID, node.UpdatedAt, node.ID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	require.NoError(t, repo.UpdateNode(context.Background(), node))
+
+	mock.ExpectExec("DELETE FROM cache_nodes WHERE id = \\$1").
+		WithArgs(node.ID).
+		WillReturnError(assert.AnError)
+	err = repo.DeleteNode(context.Background(), node.ID)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.Internal))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -3,6 +3,7 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	stdlib_errors "errors"
@@ -236,11 +237,25 @@ func (r *ClusterRepository) DeleteNode(ctx context.Context, nodeID uuid.UUID) er
 }
 
 func (r *ClusterRepository) AddNodeGroup(ctx context.Context, ng *domain.NodeGroup) error {
+	labels, err := json.Marshal(ng.Labels)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to marshal node group labels", err)
+	}
+	taints, err := json.Marshal(ng.Taints)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to marshal node group taints", err)
+	}
+	capacityType := ng.CapacityType
+	if capacityType == "" {
+		capacityType = domain.CapacityOnDemand
+	}
 	query := `
-		INSERT INTO cluster_node_groups (id, cluster_id, name, instance_type, min_size, max_size, current_size, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		INSERT INTO cluster_node_groups (id, cluster_id, name, instance_type, min_size, max_size, current_size,
+			scale_down_utilization_threshold, scale_down_unneeded_seconds, labels, taints, capacity_type, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 	`
-	_, err := r.db.Exec(ctx, query, ng.ID, ng.ClusterID, ng.Name, ng.InstanceType, ng.MinSize, ng.MaxSize, ng.CurrentSize, ng.CreatedAt, ng.UpdatedAt)
+	_, err = r.db.Exec(ctx, query, ng.ID, ng.ClusterID, ng.Name, ng.InstanceType, ng.MinSize, ng.MaxSize, ng.CurrentSize,
+		ng.ScaleDownUtilizationThreshold, ng.ScaleDownUnneededSeconds, labels, taints, string(capacityType), ng.CreatedAt, ng.UpdatedAt)
 	if err != nil {
 		return errors.Wrap(errors.Internal, "failed to add node group", err)
 	}
@@ -249,7 +264,8 @@ func (r *ClusterRepository) AddNodeGroup(ctx context.Context, ng *domain.NodeGro
 
 func (r *ClusterRepository) GetNodeGroups(ctx context.Context, clusterID uuid.UUID) ([]domain.NodeGroup, error) {
 	query := `
-		SELECT id, cluster_id, name, instance_type, min_size, max_size, current_size, created_at, updated_at
+		SELECT id, cluster_id, name, instance_type, min_size, max_size, current_size,
+			scale_down_utilization_threshold, scale_down_unneeded_seconds, labels, taints, capacity_type, created_at, updated_at
 		FROM cluster_node_groups
 		WHERE cluster_id = $1
 	`
@@ -262,9 +278,23 @@ func (r *ClusterRepository) GetNodeGroups(ctx context.Context, clusterID uuid.UU
 	var groups []domain.NodeGroup
 	for rows.Next() {
 		var ng domain.NodeGroup
-		if err := rows.Scan(&ng.ID, &ng.ClusterID, &ng.Name, &ng.InstanceType, &ng.MinSize, &ng.MaxSize, &ng.CurrentSize, &ng.CreatedAt, &ng.UpdatedAt); err != nil {
+		var labels, taints []byte
+		var capacityType string
+		if err := rows.Scan(&ng.ID, &ng.ClusterID, &ng.Name, &ng.InstanceType, &ng.MinSize, &ng.MaxSize, &ng.CurrentSize,
+			&ng.ScaleDownUtilizationThreshold, &ng.ScaleDownUnneededSeconds, &labels, &taints, &capacityType, &ng.CreatedAt, &ng.UpdatedAt); err != nil {
 			return nil, errors.Wrap(errors.Internal, "failed to scan node group", err)
 		}
+		if len(labels) > 0 {
+			if err := json.Unmarshal(labels, &ng.Labels); err != nil {
+				return nil, errors.Wrap(errors.Internal, "failed to unmarshal node group labels", err)
+			}
+		}
+		if len(taints) > 0 {
+			if err := json.Unmarshal(taints, &ng.Taints); err != nil {
+				return nil, errors.Wrap(errors.Internal, "failed to unmarshal node group taints", err)
+			}
+		}
+		ng.CapacityType = domain.CapacityType(capacityType)
 		groups = append(groups, ng)
 	}
 	if err := rows.Err(); err != nil {
@@ -276,10 +306,12 @@ func (r *ClusterRepository) GetNodeGroups(ctx context.Context, clusterID uuid.UU
 func (r *ClusterRepository) UpdateNodeGroup(ctx context.Context, ng *domain.NodeGroup) error {
 	query := `
 		UPDATE cluster_node_groups
-		SET instance_type = $1, min_size = $2, max_size = $3, current_size = $4, updated_at = $5
-		WHERE cluster_id = $6 AND name = $7
+		SET instance_type = $1, min_size = $2, max_size = $3, current_size = $4,
+			scale_down_utilization_threshold = $5, scale_down_unneeded_seconds = $6, updated_at = $7
+		WHERE cluster_id = $8 AND name = $9
 	`
-	_, err := r.db.Exec(ctx, query, ng.InstanceType, ng.MinSize, ng.MaxSize, ng.CurrentSize, time.Now(), ng.ClusterID, ng.Name)
+	_, err := r.db.Exec(ctx, query, ng.InstanceType, ng.MinSize, ng.MaxSize, ng.CurrentSize,
+		ng.ScaleDownUtilizationThreshold, ng.ScaleDownUnneededSeconds, time.Now(), ng.ClusterID, ng.Name)
 	if err != nil {
 		return errors.Wrap(errors.Internal, "failed to update node group", err)
 	}
@@ -76,7 +76,7 @@ func TestClusterRepository(t *testing.T) {
 	t.Run("Read Operations", func(t *testing.T) {
 		clusterID := uuid.New()
 		cols := []string{"id", "user_id", "tenant_id", "vpc_id", "name", "version", "status", "control_plane_ips", "worker_count", "ha_enabled", "network_isolation", "pod_cidr", "service_cidr", "api_server_lb_address", "kubeconfig_encrypted", "ssh_private_key_encrypted", "join_token", "token_expires_at", "ca_cert_hash", "job_id", "backup_schedule", "backup_retention_days", "created_at", "updated_at"}
-		ngCols := []string{"id", "cluster_id", "name", "instance_type", "min_size", "max_size", "current_size", "created_at", "updated_at"}
+		ngCols := []string{"id", "cluster_id", "name", "instance_type", "min_size", "max_size", "current_size", "scale_down_utilization_threshold", "scale_down_unneeded_seconds", "labels", "taints", "capacity_type", "created_at", "updated_at"}
 
 		testCases := []struct {
 			name        string
@@ -94,7 +94,8 @@ func TestClusterRepository(t *testing.T) {
 							AddRow(clusterID, userID, tenantID, uuid.New(), testClusterName, testClusterVersion, string(domain.ClusterStatusRunning), []string{"10.0.0.1"}, 3, false, false, "10.244.0.0/16", "10.96.0.0/12", nil, "", "", "", nil, "", nil, "@daily", 7, time.Now(), time.Now()))
 					mock.ExpectQuery("SELECT .* FROM cluster_node_groups").WithArgs(clusterID).
 						WillReturnRows(pgxmock.NewRows(ngCols).
-							AddRow(uuid.New(), clusterID, "default-pool", "standard-1", 1, 10, 3, time.Now(), time.Now()))
+							AddRow(uuid.New(), clusterID, "default-pool", "standard-1", 1, 10, 3, 0.0, 0,
+								[]byte(`{"workload":"batch"}`), []byte(`[{"key":"spot","value":"true","effect":"NoSchedule"}]`), "preemptible", time.Now(), time.Now()))
 				},
 				callFn: func(repo *ClusterRepository) (any, error) {
 					return repo.GetByID(ctx, clusterID)
@@ -104,7 +105,11 @@ func TestClusterRepository(t *testing.T) {
 					cluster := res.(*domain.Cluster)
 					assert.NotNil(t, cluster)
 					assert.Equal(t, clusterID, cluster.ID)
-					assert.Len(t, cluster.NodeGroups, 1)
+					require.Len(t, cluster.NodeGroups, 1)
+					ng := cluster.NodeGroups[0]
+					assert.Equal(t, "batch", ng.Labels["workload"])
+					assert.Equal(t, []domain.NodeTaint{{Key: "spot", Value: "true", Effect: domain.TaintEffectNoSchedule}}, ng.Taints)
+					assert.Equal(t, domain.CapacityPreemptible, ng.CapacityType)
 				},
 			},
 			{
@@ -143,7 +148,7 @@ func TestClusterRepository(t *testing.T) {
 							AddRow(clusterID, userID, tenantID, uuid.New(), "c1", "v1", "RUNNING", []string{}, 3, false, false, "", "", nil, "", "", "", nil, "", nil, "", 7, time.Now(), time.Now()))
 					mock.ExpectQuery("SELECT .* FROM cluster_node_groups").WithArgs(clusterID).
 						WillReturnRows(pgxmock.NewRows(ngCols).
-							AddRow(uuid.New(), clusterID, "default-pool", "standard-1", 1, 10, 3, time.Now(), time.Now()))
+							AddRow(uuid.New(), clusterID, "default-pool", "standard-1", 1, 10, 3, 0.0, 0, nil, nil, "on-demand", time.Now(), time.Now()))
 				},
 				callFn: func(repo *ClusterRepository) (any, error) {
 					return repo.ListAll(ctx)
@@ -164,7 +169,7 @@ func TestClusterRepository(t *testing.T) {
 							AddRow(clusterID, userID, tenantID, uuid.New(), "c1", "v1", "RUNNING", []string{}, 3, false, false, "", "", nil, "", "", "", nil, "", nil, "", 7, time.Now(), time.Now()))
 					mock.ExpectQuery("SELECT .* FROM cluster_node_groups").WithArgs(clusterID).
 						WillReturnRows(pgxmock.NewRows(ngCols).
-							AddRow(uuid.New(), clusterID, "default-pool", "standard-1", 1, 10, 3, time.Now(), time.Now()))
+							AddRow(uuid.New(), clusterID, "default-pool", "standard-1", 1, 10, 3, 0.0, 0, nil, nil, "on-demand", time.Now(), time.Now()))
 				},
 				callFn: func(repo *ClusterRepository) (any, error) {
 					return repo.ListByUserID(ctx, userID)
@@ -358,9 +363,12 @@ func TestClusterRepository(t *testing.T) {
 			defer mock.Close()
 			repo := NewClusterRepository(mock)
 			mock.ExpectExec("INSERT INTO cluster_node_groups").
-				WithArgs(ngID, clusterID, "pool-1", "standard-1", 1, 5, 2, pgxmock.AnyArg(), pgxmock.AnyArg()).
+				WithArgs(ngID, clusterID, "pool-1", "standard-1", 1, 5, 2, 0.4, 300,
+					[]byte(`{"workload":"batch"}`), []byte(`[{"key":"spot","effect":"NoSchedule"}]`), "preemptible", pgxmock.AnyArg(), pgxmock.AnyArg()).
 				WillReturnResult(pgxmock.NewResult("INSERT", 1))
-			err := repo.AddNodeGroup(ctx, &domain.NodeGroup{ID: ngID, ClusterID: clusterID, Name: "pool-1", InstanceType: "standard-1", MinSize: 1, MaxSize: 5, CurrentSize: 2})
+			err := repo.AddNodeGroup(ctx, &domain.NodeGroup{ID: ngID, ClusterID: clusterID, Name: "pool-1", InstanceType: "standard-1", MinSize: 1, MaxSize: 5, CurrentSize: 2,
+				ScaleDownUtilizationThreshold: 0.4, ScaleDownUnneededSeconds: 300,
+				Labels: map[string]string{"workload": "batch"}, Taints: []domain.NodeTaint{{Key: "spot", Effect: domain.TaintEffectNoSchedule}}, CapacityType: domain.CapacityPreemptible})
 			require.NoError(t, err)
 		})
 
@@ -379,7 +387,7 @@ func TestClusterRepository(t *testing.T) {
 			defer mock.Close()
 			repo := NewClusterRepository(mock)
 			mock.ExpectExec("UPDATE cluster_node_groups").
-				WithArgs("standard-1", 1, 5, 3, pgxmock.AnyArg(), clusterID, "pool-1").
+				WithArgs("standard-1", 1, 5, 3, 0.0, 0, pgxmock.AnyArg(), clusterID, "pool-1").
 				WillReturnResult(pgxmock.NewResult("UPDATE", 1))
 			err := repo.UpdateNodeGroup(ctx, &domain.NodeGroup{ClusterID: clusterID, Name: "pool-1", InstanceType: "standard-1", MinSize: 1, MaxSize: 5, CurrentSize: 3})
 			require.NoError(t, err)
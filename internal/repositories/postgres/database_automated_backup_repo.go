@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"context"
+	stdlib_errors "errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/errors"
+)
+
+const (
+	databaseAutomatedBackupColumns = "id, database_id, tenant_id, method, status, snapshot_id, bucket, object_key, size_bytes, kms_key_id, error, started_at, completed_at"
+	errAutomatedBackupNotFound     = "automated backup not found"
+)
+
+// DatabaseAutomatedBackupRepository persists the backups taken by backup
+// policies. Backups are looked up by database ID, which callers resolve under
+// tenant scope.
+type DatabaseAutomatedBackupRepository struct {
+	db DB
+}
+
+// NewDatabaseAutomatedBackupRepository creates a new DatabaseAutomatedBackupRepository.
+func NewDatabaseAutomatedBackupRepository(db DB) *DatabaseAutomatedBackupRepository {
+	return &DatabaseAutomatedBackupRepository{db: db}
+}
+
+func (r *DatabaseAutomatedBackupRepository) Create(ctx context.Context, b *domain.DatabaseAutomatedBackup) error {
+	query := `INSERT INTO database_automated_backups (` + databaseAutomatedBackupColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+	_, err := r.db.Exec(ctx, query,
+		b.ID, b.DatabaseID, b.TenantID, b.Method, b.Status, b.SnapshotID, b.Bucket,
+		b.ObjectKey, b.SizeBytes, b.KmsKeyID, b.Error, b.StartedAt, b.CompletedAt,
+	)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to record automated backup", err)
+	}
+	return nil
+}
+
+func (r *DatabaseAutomatedBackupRepository) Update(ctx context.Context, b *domain.DatabaseAutomatedBackup) error {
+	query := `UPDATE database_automated_backups
+		SET status = $1, snapshot_id = $2, object_key = $3, size_bytes = $4, kms_key_id = $5, error = $6, completed_at = $7
+		WHERE id = $8`
+	cmd, err := r.db.Exec(ctx, query, b.Status, b.SnapshotID, b.ObjectKey, b.SizeBytes, b.KmsKeyID, b.Error, b.CompletedAt, b.ID)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to update automated backup", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, errAutomatedBackupNotFound)
+	}
+	return nil
+}
+
+func (r *DatabaseAutomatedBackupRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.DatabaseAutomatedBackup, error) {
+	query := `SELECT ` + databaseAutomatedBackupColumns + ` FROM database_automated_backups WHERE id = $1`
+	return scanDatabaseAutomatedBackup(r.db.QueryRow(ctx, query, id))
+}
+
+func (r *DatabaseAutomatedBackupRepository) ListByDatabase(ctx context.Context, databaseID uuid.UUID) ([]*domain.DatabaseAutomatedBackup, error) {
+	query := `SELECT ` + databaseAutomatedBackupColumns + ` FROM database_automated_backups WHERE database_id = $1 ORDER BY started_at DESC`
+	rows, err := r.db.Query(ctx, query, databaseID)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list automated backups", err)
+	}
+	defer rows.Close()
+
+	backups := make([]*domain.DatabaseAutomatedBackup, 0)
+	for rows.Next() {
+		b, err := scanDatabaseAutomatedBackup(rows)
+		if err != nil {
+			return nil, err
+		}
+		backups = append(backups, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to iterate automated backups", err)
+	}
+	return backups, nil
+}
+
+func (r *DatabaseAutomatedBackupRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	cmd, err := r.db.Exec(ctx, `DELETE FROM database_automated_backups WHERE id = $1`, id)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to delete automated backup", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, errAutomatedBackupNotFound)
+	}
+	return nil
+}
+
+func scanDatabaseAutomatedBackup(row pgx.Row) (*domain.DatabaseAutomatedBackup, error) {
+	var b domain.DatabaseAutomatedBackup
+	var method, status string
+	err := row.Scan(
+		&b.ID, &b.DatabaseID, &b.TenantID, &method, &status, &b.SnapshotID, &b.Bucket,
+		&b.ObjectKey, &b.SizeBytes, &b.KmsKeyID, &b.Error, &b.StartedAt, &b.CompletedAt,
+	)
+	if err != nil {
+		if stdlib_errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New(errors.NotFound, errAutomatedBackupNotFound)
+		}
+		return nil, errors.Wrap(errors.Internal, "failed to scan automated backup", err)
+	}
+	b.Method = domain.DatabaseBackupMethod(method)
+	b.Status = domain.AutomatedBackupStatus(status)
+	return &b, nil
+}
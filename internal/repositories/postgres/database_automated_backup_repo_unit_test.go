@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	theclouderrors "github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var databaseAutomatedBackupRowColumns = []string{"id", "database_id", "tenant_id", "method", "status", "snapshot_id", "bucket", "object_key", "size_bytes", "kms_key_id", "error", "started_at", "completed_at"}
+
+func TestDatabaseAutomatedBackupRepository_CreateAndUpdate(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewDatabaseAutomatedBackupRepository(mock)
+	b := &domain.DatabaseAutomatedBackup{
+		ID: uuid.New(), DatabaseID: uuid.New(), TenantID: uuid.New(), Method: domain.DatabaseBackupLogical,
+		Status: domain.AutomatedBackupRunning, Bucket: "exports", StartedAt: time.Now(),
+	}
+
+	mock.ExpectExec("INSERT INTO database_automated_backups").
+		WithArgs(b.ID, b.DatabaseID, b.TenantID, b.Method, b.Status, b.SnapshotID, b.Bucket,
+			b.ObjectKey, b.SizeBytes, b.KmsKeyID, b.Error, b.StartedAt, b.CompletedAt).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	require.NoError(t, repo.Create(context.Background(), b))
+
+	done := time.Now()
+	b.Status, b.ObjectKey, b.SizeBytes, b.CompletedAt = domain.AutomatedBackupCompleted, "databases/x/backups/1.dump", 42, &done
+	mock.ExpectExec("UPDATE database_automated_backups").
+		WithArgs(b.Status, b.SnapshotID, b.ObjectKey, b.SizeBytes, b.KmsKeyID, b.Error, b.CompletedAt, b.ID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	require.NoError(t, repo.Update(context.Background(), b))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatabaseAutomatedBackupRepository_ListByDatabase(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewDatabaseAutomatedBackupRepository(mock)
+	dbID, snapID := uuid.New(), uuid.New()
+	now := time.Now()
+
+	mock.ExpectQuery("SELECT " + databaseAutomatedBackupColumns + " FROM database_automated_backups WHERE database_id = \\$1 ORDER BY started_at DESC").
+		WithArgs(dbID).
+		WillReturnRows(pgxmock.NewRows(databaseAutomatedBackupRowColumns).
+			AddRow(uuid.New(), dbID, uuid.New(), "PHYSICAL", "COMPLETED", &snapID, "exports", "k1", int64(10), "vault:transit/db", "", now, &now).
+			AddRow(uuid.New(), dbID, uuid.New(), "LOGICAL", "FAILED", nil, "exports", "", int64(0), "", "pg_dump failed", now.Add(-time.Hour), nil))
+
+	backups, err := repo.ListByDatabase(context.Background(), dbID)
+	require.NoError(t, err)
+	require.Len(t, backups, 2)
+	assert.Equal(t, snapID, *backups[0].SnapshotID)
+	assert.Equal(t, domain.AutomatedBackupFailed, backups[1].Status)
+	assert.Nil(t, backups[1].CompletedAt)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatabaseAutomatedBackupRepository_DeleteNotFound(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewDatabaseAutomatedBackupRepository(mock)
+	id := uuid.New()
+	mock.ExpectExec("DELETE FROM database_automated_backups WHERE id = \\$1").WithArgs(id).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	err = repo.Delete(context.Background(), id)
+	assert.True(t, theclouderrors.Is(err, theclouderrors.NotFound))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	stdlib_errors "errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/errors"
+)
+
+const (
+	databaseBackupPolicyColumns     = "database_id, tenant_id, method, window_start, window_minutes, frequency_hours, retention_count, retention_days, export_bucket, next_backup_at, last_backup_at, created_at, updated_at"
+	errDatabaseBackupPolicyNotFound = "backup policy not found"
+)
+
+// DatabaseBackupPolicyRepository persists automated backup schedules. Policies
+// are keyed by database ID, which callers resolve under tenant scope.
+type DatabaseBackupPolicyRepository struct {
+	db DB
+}
+
+// NewDatabaseBackupPolicyRepository creates a new DatabaseBackupPolicyRepository.
+func NewDatabaseBackupPolicyRepository(db DB) *DatabaseBackupPolicyRepository {
+	return &DatabaseBackupPolicyRepository{db: db}
+}
+
+func (r *DatabaseBackupPolicyRepository) Upsert(ctx context.Context, p *domain.DatabaseBackupPolicy) error {
+	query := `
+		INSERT INTO database_backup_policies (` + databaseBackupPolicyColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (database_id) DO UPDATE
+		SET method = EXCLUDED.method, window_start = EXCLUDED.window_start, window_minutes = EXCLUDED.window_minutes,
+			frequency_hours = EXCLUDED.frequency_hours, retention_count = EXCLUDED.retention_count,
+			retention_days = EXCLUDED.retention_days, export_bucket = EXCLUDED.export_bucket,
+			next_backup_at = EXCLUDED.next_backup_at, last_backup_at = EXCLUDED.last_backup_at,
+			updated_at = EXCLUDED.updated_at
+	`
+	_, err := r.db.Exec(ctx, query,
+		p.DatabaseID, p.TenantID, p.Method, p.WindowStart, p.WindowMinutes, p.FrequencyHours,
+		p.RetentionCount, p.RetentionDays, p.ExportBucket, p.NextBackupAt, p.LastBackupAt, p.CreatedAt, p.UpdatedAt,
+	)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to save backup policy", err)
+	}
+	return nil
+}
+
+func (r *DatabaseBackupPolicyRepository) GetByDatabase(ctx context.Context, databaseID uuid.UUID) (*domain.DatabaseBackupPolicy, error) {
+	query := `SELECT ` + databaseBackupPolicyColumns + ` FROM database_backup_policies WHERE database_id = $1`
+	return scanDatabaseBackupPolicy(r.db.QueryRow(ctx, query, databaseID))
+}
+
+func (r *DatabaseBackupPolicyRepository) ListDue(ctx context.Context, now time.Time) ([]*domain.DatabaseBackupPolicy, error) {
+	query := `SELECT ` + databaseBackupPolicyColumns + ` FROM database_backup_policies WHERE next_backup_at <= $1 ORDER BY next_backup_at`
+	rows, err := r.db.Query(ctx, query, now)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list due backup policies", err)
+	}
+	defer rows.Close()
+
+	policies := make([]*domain.DatabaseBackupPolicy, 0)
+	for rows.Next() {
+		p, err := scanDatabaseBackupPolicy(rows)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to iterate backup policies", err)
+	}
+	return policies, nil
+}
+
+func (r *DatabaseBackupPolicyRepository) Delete(ctx context.Context, databaseID uuid.UUID) error {
+	cmd, err := r.db.Exec(ctx, `DELETE FROM database_backup_policies WHERE database_id = $1`, databaseID)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to delete backup policy", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, errDatabaseBackupPolicyNotFound)
+	}
+	return nil
+}
+
+func scanDatabaseBackupPolicy(row pgx.Row) (*domain.DatabaseBackupPolicy, error) {
+	var p domain.DatabaseBackupPolicy
+	var method string
+	err := row.Scan(
+		&p.DatabaseID, &p.TenantID, &method, &p.WindowStart, &p.WindowMinutes, &p.FrequencyHours,
+		&p.RetentionCount, &p.RetentionDays, &p.ExportBucket, &p.NextBackupAt, &p.LastBackupAt, &p.CreatedAt, &p.UpdatedAt,
+	)
+	if err != nil {
+		if stdlib_errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New(errors.NotFound, errDatabaseBackupPolicyNotFound)
+		}
+		return nil, errors.Wrap(errors.Internal, "failed to scan backup policy", err)
+	}
+	p.Method = domain.DatabaseBackupMethod(method)
+	return &p, nil
+}
@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	theclouderrors "github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var databaseBackupPolicyRowColumns = []string{"database_id", "tenant_id", "method", "window_start", "window_minutes", "frequency_hours", "retention_count", "retention_days", "export_bucket", "next_backup_at", "last_backup_at", "created_at", "updated_at"}
+
+func TestDatabaseBackupPolicyRepository_Upsert(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewDatabaseBackupPolicyRepository(mock)
+	now := time.Now()
+	p := &domain.DatabaseBackupPolicy{
+		DatabaseID: uuid.New(), TenantID: uuid.New(), Method: domain.DatabaseBackupLogical,
+		WindowStart: "03:00", WindowMinutes: 60, FrequencyHours: 24, RetentionCount: 7,
+		ExportBucket: "exports", NextBackupAt: now, CreatedAt: now, UpdatedAt: now,
+	}
+
+	mock.ExpectExec("INSERT INTO database_backup_policies .* ON CONFLICT \\(database_id\\) DO UPDATE").
+		WithArgs(p.DatabaseID, p.TenantID, p.Method, p.WindowStart, p.WindowMinutes, p.FrequencyHours,
+			p.RetentionCount, p.RetentionDays, p.ExportBucket, p.NextBackupAt, p.LastBackupAt, p.CreatedAt, p.UpdatedAt).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	require.NoError(t, repo.Upsert(context.Background(), p))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatabaseBackupPolicyRepository_GetByDatabase(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewDatabaseBackupPolicyRepository(mock)
+	dbID := uuid.New()
+	now := time.Now()
+
+	mock.ExpectQuery("SELECT " + databaseBackupPolicyColumns + " FROM database_backup_policies WHERE database_id = \\$1").
+		WithArgs(dbID).
+		WillReturnRows(pgxmock.NewRows(databaseBackupPolicyRowColumns).
+			AddRow(dbID, uuid.New(), "PHYSICAL", "01:30", 90, 12, 0, 30, "exports", now, &now, now, now))
+
+	p, err := repo.GetByDatabase(context.Background(), dbID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.DatabaseBackupPhysical, p.Method)
+	assert.Equal(t, 30, p.RetentionDays)
+	require.NotNil(t, p.LastBackupAt)
+
+	mock.ExpectQuery("SELECT .* FROM database_backup_policies").WithArgs(dbID).WillReturnError(pgx.ErrNoRows)
+	_, err = repo.GetByDatabase(context.Background(), dbID)
+	assert.True(t, theclouderrors.Is(err, theclouderrors.NotFound))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatabaseBackupPolicyRepository_ListDue(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewDatabaseBackupPolicyRepository(mock)
+	now := time.Now()
+
+	mock.ExpectQuery("SELECT .* FROM database_backup_policies WHERE next_backup_at <= \\$1 ORDER BY next_backup_at").
+		WithArgs(now).
+		WillReturnRows(pgxmock.NewRows(databaseBackupPolicyRowColumns).
+			AddRow(uuid.New(), uuid.New(), "LOGICAL", "03:00", 60, 24, 7, 0, "exports", now.Add(-time.Minute), nil, now, now))
+
+	policies, err := repo.ListDue(context.Background(), now)
+	require.NoError(t, err)
+	require.Len(t, policies, 1)
+	assert.Nil(t, policies[0].LastBackupAt)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatabaseBackupPolicyRepository_Delete(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewDatabaseBackupPolicyRepository(mock)
+	dbID := uuid.New()
+
+	mock.ExpectExec("DELETE FROM database_backup_policies WHERE database_id = \\$1").WithArgs(dbID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	err = repo.Delete(context.Background(), dbID)
+	assert.True(t, theclouderrors.Is(err, theclouderrors.NotFound))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
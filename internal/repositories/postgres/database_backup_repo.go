@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/errors"
+)
+
+const databaseBackupColumns = "id, database_id, tenant_id, kind, snapshot_id, file_name, log_position, object_key, size_bytes, archived_at, created_at"
+
+// DatabaseBackupRepository persists the base backups and archived WAL/binlog
+// files of managed databases. Backups are looked up by database ID, which
+// callers resolve under tenant scope.
+type DatabaseBackupRepository struct {
+	db DB
+}
+
+// NewDatabaseBackupRepository creates a new DatabaseBackupRepository.
+func NewDatabaseBackupRepository(db DB) *DatabaseBackupRepository {
+	return &DatabaseBackupRepository{db: db}
+}
+
+func (r *DatabaseBackupRepository) Create(ctx context.Context, b *domain.DatabaseBackup) error {
+	query := `
+		INSERT INTO database_backups (` + databaseBackupColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (database_id, file_name) WHERE kind = 'LOG' DO UPDATE
+		SET object_key = EXCLUDED.object_key, size_bytes = EXCLUDED.size_bytes, archived_at = EXCLUDED.archived_at
+	`
+	_, err := r.db.Exec(ctx, query, b.ID, b.DatabaseID, b.TenantID, b.Kind, b.SnapshotID, b.FileName, b.LogPosition, b.ObjectKey, b.SizeBytes, b.ArchivedAt, b.CreatedAt)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to record database backup", err)
+	}
+	return nil
+}
+
+func (r *DatabaseBackupRepository) ListByDatabase(ctx context.Context, databaseID uuid.UUID) ([]*domain.DatabaseBackup, error) {
+	query := `SELECT ` + databaseBackupColumns + ` FROM database_backups WHERE database_id = $1 ORDER BY archived_at, file_name`
+	rows, err := r.db.Query(ctx, query, databaseID)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list database backups", err)
+	}
+	defer rows.Close()
+
+	backups := make([]*domain.DatabaseBackup, 0)
+	for rows.Next() {
+		b, err := scanDatabaseBackup(rows)
+		if err != nil {
+			return nil, err
+		}
+		backups = append(backups, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to iterate database backups", err)
+	}
+	return backups, nil
+}
+
+func (r *DatabaseBackupRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	cmd, err := r.db.Exec(ctx, `DELETE FROM database_backups WHERE id = $1`, id)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to delete database backup", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, "database backup not found")
+	}
+	return nil
+}
+
+func scanDatabaseBackup(row pgx.Row) (*domain.DatabaseBackup, error) {
+	var b domain.DatabaseBackup
+	var kind string
+	if err := row.Scan(&b.ID, &b.DatabaseID, &b.TenantID, &kind, &b.SnapshotID, &b.FileName, &b.LogPosition, &b.ObjectKey, &b.SizeBytes, &b.ArchivedAt, &b.CreatedAt); err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to scan database backup", err)
+	}
+	b.Kind = domain.DatabaseBackupKind(kind)
+	return &b, nil
+}
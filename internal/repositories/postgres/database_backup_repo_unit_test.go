@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	theclouderrors "github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var databaseBackupRowColumns = []string{"id", "database_id", "tenant_id", "kind", "snapshot_id", "file_name", "log_position", "object_key", "size_bytes", "archived_at", "created_at"}
+
+func TestDatabaseBackupRepository_Create(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewDatabaseBackupRepository(mock)
+	b := &domain.DatabaseBackup{
+		ID: uuid.New(), DatabaseID: uuid.New(), TenantID: uuid.New(), Kind: domain.DatabaseBackupLog,
+		FileName: "000000010000000000000003", ObjectKey: "db/wal/000000010000000000000003",
+		SizeBytes: 16 << 20, ArchivedAt: time.Now(), CreatedAt: time.Now(),
+	}
+
+	mock.ExpectExec("INSERT INTO database_backups .* ON CONFLICT \\(database_id, file_name\\) WHERE kind = 'LOG' DO UPDATE").
+		WithArgs(b.ID, b.DatabaseID, b.TenantID, b.Kind, b.SnapshotID, b.FileName, b.LogPosition, b.ObjectKey, b.SizeBytes, b.ArchivedAt, b.CreatedAt).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	require.NoError(t, repo.Create(context.Background(), b))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatabaseBackupRepository_ListByDatabase(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewDatabaseBackupRepository(mock)
+	dbID, tenantID, snapID := uuid.New(), uuid.New(), uuid.New()
+	base := time.Now().Add(-time.Hour)
+
+	mock.ExpectQuery("SELECT " + databaseBackupColumns + " FROM database_backups WHERE database_id = \\$1 ORDER BY archived_at").
+		WithArgs(dbID).
+		WillReturnRows(pgxmock.NewRows(databaseBackupRowColumns).
+			AddRow(uuid.New(), dbID, tenantID, "BASE", &snapID, "binlog.000002", int64(157), "", int64(0), base, base).
+			AddRow(uuid.New(), dbID, tenantID, "LOG", nil, "binlog.000002", int64(0), "k2", int64(2048), base.Add(time.Minute), base))
+
+	backups, err := repo.ListByDatabase(context.Background(), dbID)
+	require.NoError(t, err)
+	require.Len(t, backups, 2)
+	assert.Equal(t, domain.DatabaseBackupBase, backups[0].Kind)
+	assert.Equal(t, snapID, *backups[0].SnapshotID)
+	assert.Equal(t, int64(157), backups[0].LogPosition)
+	assert.Equal(t, "binlog.000002", backups[1].FileName)
+	assert.Nil(t, backups[1].SnapshotID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatabaseBackupRepository_Delete(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewDatabaseBackupRepository(mock)
+	id := uuid.New()
+
+	mock.ExpectExec("DELETE FROM database_backups").WithArgs(id).WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	err = repo.Delete(context.Background(), id)
+	require.Error(t, err)
+	assert.True(t, theclouderrors.Is(err, theclouderrors.NotFound))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/errors"
+)
+
+const databaseInsightSampleColumns = "id, database_id, tenant_id, collected_at, connections, max_connections, cache_hit_ratio, replica_lag"
+
+// DatabaseInsightRepository persists the periodic performance samples and the
+// latest top queries of databases. Rows are looked up by database ID, which
+// callers resolve under tenant scope.
+type DatabaseInsightRepository struct {
+	db DB
+}
+
+// NewDatabaseInsightRepository creates a new DatabaseInsightRepository.
+func NewDatabaseInsightRepository(db DB) *DatabaseInsightRepository {
+	return &DatabaseInsightRepository{db: db}
+}
+
+func (r *DatabaseInsightRepository) CreateSample(ctx context.Context, s *domain.DatabaseInsightSample) error {
+	query := `INSERT INTO database_insight_samples (` + databaseInsightSampleColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err := r.db.Exec(ctx, query,
+		s.ID, s.DatabaseID, s.TenantID, s.CollectedAt, s.Connections, s.MaxConnections, s.CacheHitRatio, s.ReplicaLag,
+	)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to record database insight sample", err)
+	}
+	return nil
+}
+
+func (r *DatabaseInsightRepository) ListSamples(ctx context.Context, databaseID uuid.UUID, since time.Time) ([]*domain.DatabaseInsightSample, error) {
+	query := `SELECT ` + databaseInsightSampleColumns + ` FROM database_insight_samples
+		WHERE database_id = $1 AND collected_at >= $2
+		ORDER BY collected_at`
+	rows, err := r.db.Query(ctx, query, databaseID, since)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list database insight samples", err)
+	}
+	defer rows.Close()
+
+	samples := make([]*domain.DatabaseInsightSample, 0)
+	for rows.Next() {
+		var s domain.DatabaseInsightSample
+		if err := rows.Scan(&s.ID, &s.DatabaseID, &s.TenantID, &s.CollectedAt, &s.Connections, &s.MaxConnections, &s.CacheHitRatio, &s.ReplicaLag); err != nil {
+			return nil, errors.Wrap(errors.Internal, "failed to scan database insight sample", err)
+		}
+		samples = append(samples, &s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to iterate database insight samples", err)
+	}
+	return samples, nil
+}
+
+func (r *DatabaseInsightRepository) DeleteSamplesBefore(ctx context.Context, before time.Time) (int64, error) {
+	cmd, err := r.db.Exec(ctx, `DELETE FROM database_insight_samples WHERE collected_at < $1`, before)
+	if err != nil {
+		return 0, errors.Wrap(errors.Internal, "failed to delete old database insight samples", err)
+	}
+	return cmd.RowsAffected(), nil
+}
+
+func (r *DatabaseInsightRepository) PutTopQueries(ctx context.Context, databaseID, tenantID uuid.UUID, queries []domain.DatabaseQueryStat, collectedAt time.Time) error {
+	query := `INSERT INTO database_top_queries (database_id, tenant_id, queries, collected_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (database_id) DO UPDATE SET queries = EXCLUDED.queries, collected_at = EXCLUDED.collected_at`
+	if _, err := r.db.Exec(ctx, query, databaseID, tenantID, queries, collectedAt); err != nil {
+		return errors.Wrap(errors.Internal, "failed to save database top queries", err)
+	}
+	return nil
+}
+
+func (r *DatabaseInsightRepository) GetTopQueries(ctx context.Context, databaseID uuid.UUID) ([]domain.DatabaseQueryStat, error) {
+	rows, err := r.db.Query(ctx, `SELECT queries FROM database_top_queries WHERE database_id = $1`, databaseID)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to get database top queries", err)
+	}
+	defer rows.Close()
+
+	queries := []domain.DatabaseQueryStat{}
+	if rows.Next() {
+		if err := rows.Scan(&queries); err != nil {
+			return nil, errors.Wrap(errors.Internal, "failed to scan database top queries", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to read database top queries", err)
+	}
+	return queries, nil
+}
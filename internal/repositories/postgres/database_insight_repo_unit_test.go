@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabaseInsightRepository_Samples(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewDatabaseInsightRepository(mock)
+	now := time.Now()
+	s := &domain.DatabaseInsightSample{
+		ID: uuid.New(), DatabaseID: uuid.New(), TenantID: uuid.New(), CollectedAt: now,
+		Connections: 12, MaxConnections: 100, CacheHitRatio: 0.99,
+		ReplicaLag: []domain.DatabaseReplicaLag{{ReplicaID: uuid.New(), Name: "r1", LagSeconds: 1.5}},
+	}
+
+	mock.ExpectExec("INSERT INTO database_insight_samples").
+		WithArgs(s.ID, s.DatabaseID, s.TenantID, s.CollectedAt, s.Connections, s.MaxConnections, s.CacheHitRatio, s.ReplicaLag).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	require.NoError(t, repo.CreateSample(context.Background(), s))
+
+	since := now.Add(-time.Hour)
+	mock.ExpectQuery("SELECT "+databaseInsightSampleColumns+" FROM database_insight_samples").
+		WithArgs(s.DatabaseID, since).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "database_id", "tenant_id", "collected_at", "connections", "max_connections", "cache_hit_ratio", "replica_lag"}).
+			AddRow(s.ID, s.DatabaseID, s.TenantID, s.CollectedAt, s.Connections, s.MaxConnections, s.CacheHitRatio, s.ReplicaLag))
+	samples, err := repo.ListSamples(context.Background(), s.DatabaseID, since)
+	require.NoError(t, err)
+	require.Len(t, samples, 1)
+	assert.Equal(t, 12, samples[0].Connections)
+	assert.Equal(t, 1.5, samples[0].MaxReplicaLagSeconds())
+
+	mock.ExpectExec("DELETE FROM database_insight_samples WHERE collected_at < \\$1").
+		WithArgs(since).
+		WillReturnResult(pgxmock.NewResult("DELETE", 3))
+	deleted, err := repo.DeleteSamplesBefore(context.Background(), since)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatabaseInsightRepository_TopQueries(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewDatabaseInsightRepository(mock)
+	dbID, tenantID := uuid.New(), uuid.New()
+	now := time.Now()
+	queries := []domain.DatabaseQueryStat{{QueryID: "42", Query: "SELECT * FROM orders WHERE id = $1", Calls: 10, TotalTimeMs: 25}}
+
+	mock.ExpectExec("INSERT INTO database_top_queries .* ON CONFLICT \\(database_id\\) DO UPDATE").
+		WithArgs(dbID, tenantID, queries, now).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	require.NoError(t, repo.PutTopQueries(context.Background(), dbID, tenantID, queries, now))
+
+	mock.ExpectQuery("SELECT queries FROM database_top_queries WHERE database_id = \\$1").
+		WithArgs(dbID).
+		WillReturnRows(pgxmock.NewRows([]string{"queries"}).AddRow(queries))
+	got, err := repo.GetTopQueries(context.Background(), dbID)
+	require.NoError(t, err)
+	assert.Equal(t, queries, got)
+
+	mock.ExpectQuery("SELECT queries FROM database_top_queries WHERE database_id = \\$1").
+		WithArgs(dbID).
+		WillReturnRows(pgxmock.NewRows([]string{"queries"}))
+	got, err = repo.GetTopQueries(context.Background(), dbID)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
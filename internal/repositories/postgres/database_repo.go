@@ -26,11 +26,11 @@ func NewDatabaseRepository(db DB) *DatabaseRepository {
 
 func (r *DatabaseRepository) Create(ctx context.Context, db *domain.Database) error {
 	query := `
-		INSERT INTO databases (id, user_id, tenant_id, name, engine, version, status, role, primary_id, vpc_id, container_id, port, username, password, created_at, updated_at, allocated_storage, parameters, metrics_enabled, metrics_port, exporter_container_id, pooling_enabled, pooling_port, pooler_container_id, credential_path, credential_version)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26)
+		INSERT INTO databases (id, user_id, tenant_id, name, engine, version, status, role, primary_id, vpc_id, container_id, port, username, password, created_at, updated_at, allocated_storage, parameters, metrics_enabled, metrics_port, exporter_container_id, pooling_enabled, pooling_port, pooler_container_id, credential_path, credential_version, backup_retention_days, backup_bucket, maintenance_window, pending_version, pending_parameters)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, NULLIF($28, ''), $29, $30, $31)
 	`
 	_, err := r.db.Exec(ctx, query,
-		db.ID, db.UserID, db.TenantID, db.Name, db.Engine, db.Version, db.Status, db.Role, db.PrimaryID, db.VpcID, db.ContainerID, db.Port, db.Username, db.Password, db.CreatedAt, db.UpdatedAt, db.AllocatedStorage, db.Parameters, db.MetricsEnabled, db.MetricsPort, db.ExporterContainerID, db.PoolingEnabled, db.PoolingPort, db.PoolerContainerID, db.CredentialPath, db.CredentialVersion,
+		db.ID, db.UserID, db.TenantID, db.Name, db.Engine, db.Version, db.Status, db.Role, db.PrimaryID, db.VpcID, db.ContainerID, db.Port, db.Username, db.Password, db.CreatedAt, db.UpdatedAt, db.AllocatedStorage, db.Parameters, db.MetricsEnabled, db.MetricsPort, db.ExporterContainerID, db.PoolingEnabled, db.PoolingPort, db.PoolerContainerID, db.CredentialPath, db.CredentialVersion, db.BackupRetentionDays, db.BackupBucket, db.MaintenanceWindow, db.PendingVersion, db.PendingParameters,
 	)
 	if err != nil {
 		return errors.Wrap(errors.Internal, "failed to create database", err)
@@ -41,7 +41,7 @@ func (r *DatabaseRepository) Create(ctx context.Context, db *domain.Database) er
 func (r *DatabaseRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Database, error) {
 	tenantID := appcontext.TenantIDFromContext(ctx)
 	query := `
-		SELECT id, user_id, tenant_id, name, engine, version, status, role, primary_id, vpc_id, COALESCE(container_id, ''), port, username, password, created_at, updated_at, allocated_storage, parameters, metrics_enabled, COALESCE(metrics_port, 0), COALESCE(exporter_container_id, ''), pooling_enabled, COALESCE(pooling_port, 0), COALESCE(pooler_container_id, ''), COALESCE(credential_path, ''), COALESCE(credential_version, 1)
+		SELECT id, user_id, tenant_id, name, engine, version, status, role, primary_id, vpc_id, COALESCE(container_id, ''), port, username, password, created_at, updated_at, allocated_storage, parameters, metrics_enabled, COALESCE(metrics_port, 0), COALESCE(exporter_container_id, ''), pooling_enabled, COALESCE(pooling_port, 0), COALESCE(pooler_container_id, ''), COALESCE(credential_path, ''), COALESCE(credential_version, 1), backup_retention_days, COALESCE(backup_bucket, ''), maintenance_window, pending_version, pending_parameters
 		FROM databases
 		WHERE id = $1 AND tenant_id = $2
 	`
@@ -51,7 +51,7 @@ func (r *DatabaseRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain
 func (r *DatabaseRepository) List(ctx context.Context) ([]*domain.Database, error) {
 	tenantID := appcontext.TenantIDFromContext(ctx)
 	query := `
-		SELECT id, user_id, tenant_id, name, engine, version, status, role, primary_id, vpc_id, COALESCE(container_id, ''), port, username, password, created_at, updated_at, allocated_storage, parameters, metrics_enabled, COALESCE(metrics_port, 0), COALESCE(exporter_container_id, ''), pooling_enabled, COALESCE(pooling_port, 0), COALESCE(pooler_container_id, ''), COALESCE(credential_path, ''), COALESCE(credential_version, 1)
+		SELECT id, user_id, tenant_id, name, engine, version, status, role, primary_id, vpc_id, COALESCE(container_id, ''), port, username, password, created_at, updated_at, allocated_storage, parameters, metrics_enabled, COALESCE(metrics_port, 0), COALESCE(exporter_container_id, ''), pooling_enabled, COALESCE(pooling_port, 0), COALESCE(pooler_container_id, ''), COALESCE(credential_path, ''), COALESCE(credential_version, 1), backup_retention_days, COALESCE(backup_bucket, ''), maintenance_window, pending_version, pending_parameters
 		FROM databases
 		WHERE tenant_id = $1
 		ORDER BY created_at DESC
@@ -66,7 +66,7 @@ func (r *DatabaseRepository) List(ctx context.Context) ([]*domain.Database, erro
 func (r *DatabaseRepository) ListReplicas(ctx context.Context, primaryID uuid.UUID) ([]*domain.Database, error) {
 	tenantID := appcontext.TenantIDFromContext(ctx)
 	query := `
-		SELECT id, user_id, tenant_id, name, engine, version, status, role, primary_id, vpc_id, COALESCE(container_id, ''), port, username, password, created_at, updated_at, allocated_storage, parameters, metrics_enabled, COALESCE(metrics_port, 0), COALESCE(exporter_container_id, ''), pooling_enabled, COALESCE(pooling_port, 0), COALESCE(pooler_container_id, ''), COALESCE(credential_path, ''), COALESCE(credential_version, 1)
+		SELECT id, user_id, tenant_id, name, engine, version, status, role, primary_id, vpc_id, COALESCE(container_id, ''), port, username, password, created_at, updated_at, allocated_storage, parameters, metrics_enabled, COALESCE(metrics_port, 0), COALESCE(exporter_container_id, ''), pooling_enabled, COALESCE(pooling_port, 0), COALESCE(pooler_container_id, ''), COALESCE(credential_path, ''), COALESCE(credential_version, 1), backup_retention_days, COALESCE(backup_bucket, ''), maintenance_window, pending_version, pending_parameters
 		FROM databases
 		WHERE primary_id = $1 AND tenant_id = $2
 		ORDER BY created_at DESC
@@ -78,11 +78,55 @@ func (r *DatabaseRepository) ListReplicas(ctx context.Context, primaryID uuid.UU
 	return r.scanDatabases(rows)
 }
 
+func (r *DatabaseRepository) ListWithBackupRetention(ctx context.Context) ([]*domain.Database, error) {
+	query := `
+		SELECT id, user_id, tenant_id, name, engine, version, status, role, primary_id, vpc_id, COALESCE(container_id, ''), port, username, password, created_at, updated_at, allocated_storage, parameters, metrics_enabled, COALESCE(metrics_port, 0), COALESCE(exporter_container_id, ''), pooling_enabled, COALESCE(pooling_port, 0), COALESCE(pooler_container_id, ''), COALESCE(credential_path, ''), COALESCE(credential_version, 1), backup_retention_days, COALESCE(backup_bucket, ''), maintenance_window, pending_version, pending_parameters
+		FROM databases
+		WHERE backup_retention_days > 0 AND backup_bucket IS NOT NULL
+		ORDER BY created_at
+	`
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list databases with backup retention", err)
+	}
+	return r.scanDatabases(rows)
+}
+
+// ListPendingMaintenance sorts replicas before primaries ('REPLICA' > 'PRIMARY'),
+// so their own changes land before a primary upgrade restarts them again.
+func (r *DatabaseRepository) ListPendingMaintenance(ctx context.Context) ([]*domain.Database, error) {
+	query := `
+		SELECT id, user_id, tenant_id, name, engine, version, status, role, primary_id, vpc_id, COALESCE(container_id, ''), port, username, password, created_at, updated_at, allocated_storage, parameters, metrics_enabled, COALESCE(metrics_port, 0), COALESCE(exporter_container_id, ''), pooling_enabled, COALESCE(pooling_port, 0), COALESCE(pooler_container_id, ''), COALESCE(credential_path, ''), COALESCE(credential_version, 1), backup_retention_days, COALESCE(backup_bucket, ''), maintenance_window, pending_version, pending_parameters
+		FROM databases
+		WHERE pending_version <> '' OR pending_parameters IS NOT NULL
+		ORDER BY role DESC, created_at
+	`
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list databases with pending maintenance", err)
+	}
+	return r.scanDatabases(rows)
+}
+
+func (r *DatabaseRepository) ListWithMetrics(ctx context.Context) ([]*domain.Database, error) {
+	query := `
+		SELECT id, user_id, tenant_id, name, engine, version, status, role, primary_id, vpc_id, COALESCE(container_id, ''), port, username, password, created_at, updated_at, allocated_storage, parameters, metrics_enabled, COALESCE(metrics_port, 0), COALESCE(exporter_container_id, ''), pooling_enabled, COALESCE(pooling_port, 0), COALESCE(pooler_container_id, ''), COALESCE(credential_path, ''), COALESCE(credential_version, 1), backup_retention_days, COALESCE(backup_bucket, ''), maintenance_window, pending_version, pending_parameters
+		FROM databases
+		WHERE metrics_enabled AND role = 'PRIMARY'
+		ORDER BY created_at
+	`
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list databases with metrics", err)
+	}
+	return r.scanDatabases(rows)
+}
+
 func (r *DatabaseRepository) scanDatabase(row pgx.Row) (*domain.Database, error) {
 	var db domain.Database
 	var engine, status, role string
 	err := row.Scan(
-		&db.ID, &db.UserID, &db.TenantID, &db.Name, &engine, &db.Version, &status, &role, &db.PrimaryID, &db.VpcID, &db.ContainerID, &db.Port, &db.Username, &db.Password, &db.CreatedAt, &db.UpdatedAt, &db.AllocatedStorage, &db.Parameters, &db.MetricsEnabled, &db.MetricsPort, &db.ExporterContainerID, &db.PoolingEnabled, &db.PoolingPort, &db.PoolerContainerID, &db.CredentialPath, &db.CredentialVersion,
+		&db.ID, &db.UserID, &db.TenantID, &db.Name, &engine, &db.Version, &status, &role, &db.PrimaryID, &db.VpcID, &db.ContainerID, &db.Port, &db.Username, &db.Password, &db.CreatedAt, &db.UpdatedAt, &db.AllocatedStorage, &db.Parameters, &db.MetricsEnabled, &db.MetricsPort, &db.ExporterContainerID, &db.PoolingEnabled, &db.PoolingPort, &db.PoolerContainerID, &db.CredentialPath, &db.CredentialVersion, &db.BackupRetentionDays, &db.BackupBucket, &db.MaintenanceWindow, &db.PendingVersion, &db.PendingParameters,
 	)
 	if err != nil {
 		if stdlib_errors.Is(err, pgx.ErrNoRows) {
@@ -115,11 +159,11 @@ func (r *DatabaseRepository) scanDatabases(rows pgx.Rows) ([]*domain.Database, e
 func (r *DatabaseRepository) Update(ctx context.Context, db *domain.Database) error {
 	query := `
 		UPDATE databases
-		SET name = $1, status = $2, role = $3, primary_id = $4, container_id = $5, port = $6, updated_at = $7, parameters = $8, metrics_enabled = $9, metrics_port = $10, exporter_container_id = $11, pooling_enabled = $12, pooling_port = $13, pooler_container_id = $14, allocated_storage = $15, credential_path = $16, credential_version = $17
-		WHERE id = $18 AND tenant_id = $19
+		SET name = $1, status = $2, role = $3, primary_id = $4, container_id = $5, port = $6, updated_at = $7, parameters = $8, metrics_enabled = $9, metrics_port = $10, exporter_container_id = $11, pooling_enabled = $12, pooling_port = $13, pooler_container_id = $14, allocated_storage = $15, credential_path = $16, credential_version = $17, backup_retention_days = $18, version = $19, maintenance_window = $20, pending_version = $21, pending_parameters = $22
+		WHERE id = $23 AND tenant_id = $24
 	`
 	now := time.Now()
-	cmd, err := r.db.Exec(ctx, query, db.Name, db.Status, db.Role, db.PrimaryID, db.ContainerID, db.Port, now, db.Parameters, db.MetricsEnabled, db.MetricsPort, db.ExporterContainerID, db.PoolingEnabled, db.PoolingPort, db.PoolerContainerID, db.AllocatedStorage, db.CredentialPath, db.CredentialVersion, db.ID, db.TenantID)
+	cmd, err := r.db.Exec(ctx, query, db.Name, db.Status, db.Role, db.PrimaryID, db.ContainerID, db.Port, now, db.Parameters, db.MetricsEnabled, db.MetricsPort, db.ExporterContainerID, db.PoolingEnabled, db.PoolingPort, db.PoolerContainerID, db.AllocatedStorage, db.CredentialPath, db.CredentialVersion, db.BackupRetentionDays, db.Version, db.MaintenanceWindow, db.PendingVersion, db.PendingParameters, db.ID, db.TenantID)
 	if err != nil {
 		return errors.Wrap(errors.Internal, "failed to update database", err)
 	}
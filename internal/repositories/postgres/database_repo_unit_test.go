@@ -50,7 +50,7 @@ func TestDatabaseRepository_Create(t *testing.T) {
 	}
 
 	mock.ExpectExec("INSERT INTO databases").
-		WithArgs(db.ID, db.UserID, db.TenantID, db.Name, db.Engine, db.Version, db.Status, db.Role, db.PrimaryID, db.VpcID, db.ContainerID, db.Port, db.Username, db.Password, db.CreatedAt, db.UpdatedAt, db.AllocatedStorage, db.Parameters, db.MetricsEnabled, db.MetricsPort, db.ExporterContainerID, db.PoolingEnabled, db.PoolingPort, db.PoolerContainerID, db.CredentialPath, db.CredentialVersion).
+		WithArgs(db.ID, db.UserID, db.TenantID, db.Name, db.Engine, db.Version, db.Status, db.Role, db.PrimaryID, db.VpcID, db.ContainerID, db.Port, db.Username, db.Password, db.CreatedAt, db.UpdatedAt, db.AllocatedStorage, db.Parameters, db.MetricsEnabled, db.MetricsPort, db.ExporterContainerID, db.PoolingEnabled, db.PoolingPort, db.PoolerContainerID, db.CredentialPath, db.CredentialVersion, db.BackupRetentionDays, db.BackupBucket, db.MaintenanceWindow, db.PendingVersion, db.PendingParameters).
 		WillReturnResult(pgxmock.NewResult("INSERT", 1))
 
 	err = repo.Create(context.Background(), db)
@@ -70,10 +70,10 @@ func TestDatabaseRepository_GetByID(t *testing.T) {
 	ctx := appcontext.WithTenantID(context.Background(), tenantID)
 	now := time.Now()
 
-	mock.ExpectQuery("SELECT id, user_id, tenant_id, name, engine, version, status, role, primary_id, vpc_id, COALESCE\\(container_id, ''\\), port, username, password, created_at, updated_at, allocated_storage, parameters, metrics_enabled, COALESCE\\(metrics_port, 0\\), COALESCE\\(exporter_container_id, ''\\), pooling_enabled, COALESCE\\(pooling_port, 0\\), COALESCE\\(pooler_container_id, ''\\), COALESCE\\(credential_path, ''\\), COALESCE\\(credential_version, 1\\) FROM databases").
+	mock.ExpectQuery("SELECT id, user_id, tenant_id, name, engine, version, status, role, primary_id, vpc_id, COALESCE\\(container_id, ''\\), port, username, password, created_at, updated_at, allocated_storage, parameters, metrics_enabled, COALESCE\\(metrics_port, 0\\), COALESCE\\(exporter_container_id, ''\\), pooling_enabled, COALESCE\\(pooling_port, 0\\), COALESCE\\(pooler_container_id, ''\\), COALESCE\\(credential_path, ''\\), COALESCE\\(credential_version, 1\\), backup_retention_days, COALESCE\\(backup_bucket, ''\\), maintenance_window, pending_version, pending_parameters FROM databases").
 		WithArgs(id, tenantID).
-		WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "tenant_id", "name", "engine", "version", "status", "role", "primary_id", "vpc_id", "container_id", "port", "username", "password", "created_at", "updated_at", "allocated_storage", "parameters", "metrics_enabled", "metrics_port", "exporter_container_id", "pooling_enabled", "pooling_port", "pooler_container_id", "credential_path", "credential_version"}).
-			AddRow(id, userID, tenantID, "test-db", string(domain.EnginePostgres), "16", string(domain.DatabaseStatusCreating), string(domain.RolePrimary), nil, nil, "cid-1", 5432, "admin", "password", now, now, 10, map[string]string{"k": "v"}, true, 9187, "exp-cid", true, 6432, "pool-cid", "secret/rds/db1", 1))
+		WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "tenant_id", "name", "engine", "version", "status", "role", "primary_id", "vpc_id", "container_id", "port", "username", "password", "created_at", "updated_at", "allocated_storage", "parameters", "metrics_enabled", "metrics_port", "exporter_container_id", "pooling_enabled", "pooling_port", "pooler_container_id", "credential_path", "credential_version", "backup_retention_days", "backup_bucket", "maintenance_window", "pending_version", "pending_parameters"}).
+			AddRow(id, userID, tenantID, "test-db", string(domain.EnginePostgres), "16", string(domain.DatabaseStatusCreating), string(domain.RolePrimary), nil, nil, "cid-1", 5432, "admin", "password", now, now, 10, map[string]string{"k": "v"}, true, 9187, "exp-cid", true, 6432, "pool-cid", "secret/rds/db1", 1, 7, "db-backups", "sun:03:00", "16.3", map[string]string{"work_mem": "64MB"}))
 
 	db, err := repo.GetByID(ctx, id)
 	require.NoError(t, err)
@@ -87,6 +87,11 @@ func TestDatabaseRepository_GetByID(t *testing.T) {
 	assert.True(t, db.PoolingEnabled)
 	assert.Equal(t, 6432, db.PoolingPort)
 	assert.Equal(t, "secret/rds/db1", db.CredentialPath)
+	assert.Equal(t, 7, db.BackupRetentionDays)
+	assert.Equal(t, "db-backups", db.BackupBucket)
+	assert.Equal(t, "16.3", db.PendingVersion)
+	assert.Equal(t, "64MB", db.PendingParameters["work_mem"])
+	assert.True(t, db.HasPendingMaintenance())
 }
 
 func TestDatabaseRepository_List(t *testing.T) {
@@ -101,10 +106,10 @@ func TestDatabaseRepository_List(t *testing.T) {
 	ctx := appcontext.WithTenantID(context.Background(), tenantID)
 	now := time.Now()
 
-	mock.ExpectQuery("SELECT id, user_id, tenant_id, name, engine, version, status, role, primary_id, vpc_id, COALESCE\\(container_id, ''\\), port, username, password, created_at, updated_at, allocated_storage, parameters, metrics_enabled, COALESCE\\(metrics_port, 0\\), COALESCE\\(exporter_container_id, ''\\), pooling_enabled, COALESCE\\(pooling_port, 0\\), COALESCE\\(pooler_container_id, ''\\), COALESCE\\(credential_path, ''\\), COALESCE\\(credential_version, 1\\) FROM databases").
+	mock.ExpectQuery("SELECT id, user_id, tenant_id, name, engine, version, status, role, primary_id, vpc_id, COALESCE\\(container_id, ''\\), port, username, password, created_at, updated_at, allocated_storage, parameters, metrics_enabled, COALESCE\\(metrics_port, 0\\), COALESCE\\(exporter_container_id, ''\\), pooling_enabled, COALESCE\\(pooling_port, 0\\), COALESCE\\(pooler_container_id, ''\\), COALESCE\\(credential_path, ''\\), COALESCE\\(credential_version, 1\\), backup_retention_days, COALESCE\\(backup_bucket, ''\\), maintenance_window, pending_version, pending_parameters FROM databases").
 		WithArgs(tenantID).
-		WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "tenant_id", "name", "engine", "version", "status", "role", "primary_id", "vpc_id", "container_id", "port", "username", "password", "created_at", "updated_at", "allocated_storage", "parameters", "metrics_enabled", "metrics_port", "exporter_container_id", "pooling_enabled", "pooling_port", "pooler_container_id", "credential_path", "credential_version"}).
-			AddRow(uuid.New(), userID, tenantID, "test-db", string(domain.EnginePostgres), "16", string(domain.DatabaseStatusCreating), string(domain.RolePrimary), nil, nil, "cid-1", 5432, "admin", "password", now, now, 20, map[string]string{}, false, 0, "", false, 0, "", "", 1))
+		WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "tenant_id", "name", "engine", "version", "status", "role", "primary_id", "vpc_id", "container_id", "port", "username", "password", "created_at", "updated_at", "allocated_storage", "parameters", "metrics_enabled", "metrics_port", "exporter_container_id", "pooling_enabled", "pooling_port", "pooler_container_id", "credential_path", "credential_version", "backup_retention_days", "backup_bucket", "maintenance_window", "pending_version", "pending_parameters"}).
+			AddRow(uuid.New(), userID, tenantID, "test-db", string(domain.EnginePostgres), "16", string(domain.DatabaseStatusCreating), string(domain.RolePrimary), nil, nil, "cid-1", 5432, "admin", "password", now, now, 20, map[string]string{}, false, 0, "", false, 0, "", "", 1, 0, "", "sun:03:00", "", nil))
 
 	databases, err := repo.List(ctx)
 	require.NoError(t, err)
@@ -125,10 +130,10 @@ func TestDatabaseRepository_ListReplicas(t *testing.T) {
 	ctx := appcontext.WithTenantID(context.Background(), tenantID)
 	now := time.Now()
 
-	mock.ExpectQuery("SELECT id, user_id, tenant_id, name, engine, version, status, role, primary_id, vpc_id, COALESCE\\(container_id, ''\\), port, username, password, created_at, updated_at, allocated_storage, parameters, metrics_enabled, COALESCE\\(metrics_port, 0\\), COALESCE\\(exporter_container_id, ''\\), pooling_enabled, COALESCE\\(pooling_port, 0\\), COALESCE\\(pooler_container_id, ''\\), COALESCE\\(credential_path, ''\\), COALESCE\\(credential_version, 1\\) FROM databases WHERE primary_id = \\$1 AND tenant_id = \\$2").
+	mock.ExpectQuery("SELECT id, user_id, tenant_id, name, engine, version, status, role, primary_id, vpc_id, COALESCE\\(container_id, ''\\), port, username, password, created_at, updated_at, allocated_storage, parameters, metrics_enabled, COALESCE\\(metrics_port, 0\\), COALESCE\\(exporter_container_id, ''\\), pooling_enabled, COALESCE\\(pooling_port, 0\\), COALESCE\\(pooler_container_id, ''\\), COALESCE\\(credential_path, ''\\), COALESCE\\(credential_version, 1\\), backup_retention_days, COALESCE\\(backup_bucket, ''\\), maintenance_window, pending_version, pending_parameters FROM databases WHERE primary_id = \\$1 AND tenant_id = \\$2").
 		WithArgs(primaryID, tenantID).
-		WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "tenant_id", "name", "engine", "version", "status", "role", "primary_id", "vpc_id", "container_id", "port", "username", "password", "created_at", "updated_at", "allocated_storage", "parameters", "metrics_enabled", "metrics_port", "exporter_container_id", "pooling_enabled", "pooling_port", "pooler_container_id", "credential_path", "credential_version"}).
-			AddRow(uuid.New(), uuid.New(), tenantID, "replica-1", string(domain.EnginePostgres), "16", string(domain.DatabaseStatusRunning), string(domain.RoleReplica), &primaryID, nil, "cid-2", 5432, "admin", "password", now, now, 20, map[string]string{}, false, 0, "", false, 0, "", "secret/rds/replica1", 1))
+		WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "tenant_id", "name", "engine", "version", "status", "role", "primary_id", "vpc_id", "container_id", "port", "username", "password", "created_at", "updated_at", "allocated_storage", "parameters", "metrics_enabled", "metrics_port", "exporter_container_id", "pooling_enabled", "pooling_port", "pooler_container_id", "credential_path", "credential_version", "backup_retention_days", "backup_bucket", "maintenance_window", "pending_version", "pending_parameters"}).
+			AddRow(uuid.New(), uuid.New(), tenantID, "replica-1", string(domain.EnginePostgres), "16", string(domain.DatabaseStatusRunning), string(domain.RoleReplica), &primaryID, nil, "cid-2", 5432, "admin", "password", now, now, 20, map[string]string{}, false, 0, "", false, 0, "", "secret/rds/replica1", 1, 0, "", "sun:03:00", "", nil))
 
 	replicas, err := repo.ListReplicas(ctx, primaryID)
 	require.NoError(t, err)
@@ -138,6 +143,70 @@ func TestDatabaseRepository_ListReplicas(t *testing.T) {
 	assert.Equal(t, "secret/rds/replica1", replicas[0].CredentialPath)
 }
 
+func TestDatabaseRepository_ListWithBackupRetention(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewDatabaseRepository(mock)
+	now := time.Now()
+
+	// Runs from the backup worker without a tenant in context.
+	mock.ExpectQuery("SELECT .* FROM databases WHERE backup_retention_days > 0 AND backup_bucket IS NOT NULL").
+		WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "tenant_id", "name", "engine", "version", "status", "role", "primary_id", "vpc_id", "container_id", "port", "username", "password", "created_at", "updated_at", "allocated_storage", "parameters", "metrics_enabled", "metrics_port", "exporter_container_id", "pooling_enabled", "pooling_port", "pooler_container_id", "credential_path", "credential_version", "backup_retention_days", "backup_bucket", "maintenance_window", "pending_version", "pending_parameters"}).
+			AddRow(uuid.New(), uuid.New(), uuid.New(), "orders", string(domain.EngineMySQL), "8.0", string(domain.DatabaseStatusRunning), string(domain.RolePrimary), nil, nil, "cid-1", 3306, "root", "password", now, now, 20, map[string]string{}, false, 0, "", false, 0, "", "", 1, 14, "orders-backups", "wed:22:30", "", nil))
+
+	dbs, err := repo.ListWithBackupRetention(context.Background())
+	require.NoError(t, err)
+	require.Len(t, dbs, 1)
+	assert.True(t, dbs[0].PITREnabled())
+	assert.Equal(t, 14, dbs[0].BackupRetentionDays)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatabaseRepository_ListPendingMaintenance(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewDatabaseRepository(mock)
+	now := time.Now()
+
+	// Runs from the maintenance worker without a tenant in context.
+	mock.ExpectQuery("SELECT .* FROM databases WHERE pending_version <> '' OR pending_parameters IS NOT NULL ORDER BY role DESC, created_at").
+		WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "tenant_id", "name", "engine", "version", "status", "role", "primary_id", "vpc_id", "container_id", "port", "username", "password", "created_at", "updated_at", "allocated_storage", "parameters", "metrics_enabled", "metrics_port", "exporter_container_id", "pooling_enabled", "pooling_port", "pooler_container_id", "credential_path", "credential_version", "backup_retention_days", "backup_bucket", "maintenance_window", "pending_version", "pending_parameters"}).
+			AddRow(uuid.New(), uuid.New(), uuid.New(), "orders", string(domain.EngineMySQL), "8.0.35", string(domain.DatabaseStatusRunning), string(domain.RolePrimary), nil, nil, "cid-1", 3306, "root", "password", now, now, 20, map[string]string{}, false, 0, "", false, 0, "", "", 1, 0, "", "wed:22:30", "8.0.36", nil))
+
+	dbs, err := repo.ListPendingMaintenance(context.Background())
+	require.NoError(t, err)
+	require.Len(t, dbs, 1)
+	assert.Equal(t, "wed:22:30", dbs[0].MaintenanceWindow)
+	assert.Equal(t, "8.0.36", dbs[0].PendingVersion)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatabaseRepository_ListWithMetrics(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewDatabaseRepository(mock)
+	now := time.Now()
+
+	mock.ExpectQuery("SELECT .* FROM databases WHERE metrics_enabled AND role = 'PRIMARY' ORDER BY created_at").
+		WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "tenant_id", "name", "engine", "version", "status", "role", "primary_id", "vpc_id", "container_id", "port", "username", "password", "created_at", "updated_at", "allocated_storage", "parameters", "metrics_enabled", "metrics_port", "exporter_container_id", "pooling_enabled", "pooling_port", "pooler_container_id", "credential_path", "credential_version", "backup_retention_days", "backup_bucket", "maintenance_window", "pending_version", "pending_parameters"}).
+			AddRow(uuid.New(), uuid.New(), uuid.New(), "orders", string(domain.EnginePostgres), "16", string(domain.DatabaseStatusRunning), string(domain.RolePrimary), nil, nil, "cid-1", 5432, "app", "password", now, now, 20, map[string]string{}, true, 9187, "exp-1", false, 0, "", "", 1, 0, "", "sun:03:00", "", nil))
+
+	dbs, err := repo.ListWithMetrics(context.Background())
+	require.NoError(t, err)
+	require.Len(t, dbs, 1)
+	assert.True(t, dbs[0].MetricsEnabled)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestDatabaseRepository_Update(t *testing.T) {
 	t.Parallel()
 	mock, err := pgxmock.NewPool()
@@ -166,7 +235,7 @@ func TestDatabaseRepository_Update(t *testing.T) {
 	}
 
 	mock.ExpectExec("UPDATE databases").
-		WithArgs(db.Name, db.Status, db.Role, db.PrimaryID, db.ContainerID, db.Port, pgxmock.AnyArg(), db.Parameters, db.MetricsEnabled, db.MetricsPort, db.ExporterContainerID, db.PoolingEnabled, db.PoolingPort, db.PoolerContainerID, db.AllocatedStorage, db.CredentialPath, db.CredentialVersion, db.ID, db.TenantID).
+		WithArgs(db.Name, db.Status, db.Role, db.PrimaryID, db.ContainerID, db.Port, pgxmock.AnyArg(), db.Parameters, db.MetricsEnabled, db.MetricsPort, db.ExporterContainerID, db.PoolingEnabled, db.PoolingPort, db.PoolerContainerID, db.AllocatedStorage, db.CredentialPath, db.CredentialVersion, db.BackupRetentionDays, db.Version, db.MaintenanceWindow, db.PendingVersion, db.PendingParameters, db.ID, db.TenantID).
 		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
 
 	err = repo.Update(context.Background(), db)
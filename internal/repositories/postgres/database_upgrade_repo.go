@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/errors"
+)
+
+const databaseUpgradeColumns = "id, database_id, tenant_id, from_version, to_version, major, status, snapshot_id, error, started_at, completed_at"
+
+// DatabaseUpgradeRepository persists the version upgrade history of databases.
+// Upgrades are looked up by database ID, which callers resolve under tenant
+// scope.
+type DatabaseUpgradeRepository struct {
+	db DB
+}
+
+// NewDatabaseUpgradeRepository creates a new DatabaseUpgradeRepository.
+func NewDatabaseUpgradeRepository(db DB) *DatabaseUpgradeRepository {
+	return &DatabaseUpgradeRepository{db: db}
+}
+
+func (r *DatabaseUpgradeRepository) Create(ctx context.Context, u *domain.DatabaseUpgrade) error {
+	query := `INSERT INTO database_upgrades (` + databaseUpgradeColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+	_, err := r.db.Exec(ctx, query,
+		u.ID, u.DatabaseID, u.TenantID, u.FromVersion, u.ToVersion, u.Major, u.Status,
+		u.SnapshotID, u.Error, u.StartedAt, u.CompletedAt,
+	)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to record database upgrade", err)
+	}
+	return nil
+}
+
+func (r *DatabaseUpgradeRepository) Update(ctx context.Context, u *domain.DatabaseUpgrade) error {
+	query := `UPDATE database_upgrades
+		SET status = $1, snapshot_id = $2, error = $3, completed_at = $4
+		WHERE id = $5`
+	cmd, err := r.db.Exec(ctx, query, u.Status, u.SnapshotID, u.Error, u.CompletedAt, u.ID)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to update database upgrade", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, "database upgrade not found")
+	}
+	return nil
+}
+
+func (r *DatabaseUpgradeRepository) ListByDatabase(ctx context.Context, databaseID uuid.UUID) ([]*domain.DatabaseUpgrade, error) {
+	query := `SELECT ` + databaseUpgradeColumns + ` FROM database_upgrades WHERE database_id = $1 ORDER BY started_at DESC`
+	rows, err := r.db.Query(ctx, query, databaseID)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list database upgrades", err)
+	}
+	defer rows.Close()
+
+	upgrades := make([]*domain.DatabaseUpgrade, 0)
+	for rows.Next() {
+		u, err := scanDatabaseUpgrade(rows)
+		if err != nil {
+			return nil, err
+		}
+		upgrades = append(upgrades, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to iterate database upgrades", err)
+	}
+	return upgrades, nil
+}
+
+func scanDatabaseUpgrade(row pgx.Row) (*domain.DatabaseUpgrade, error) {
+	var u domain.DatabaseUpgrade
+	var status string
+	err := row.Scan(
+		&u.ID, &u.DatabaseID, &u.TenantID, &u.FromVersion, &u.ToVersion, &u.Major, &status,
+		&u.SnapshotID, &u.Error, &u.StartedAt, &u.CompletedAt,
+	)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to scan database upgrade", err)
+	}
+	u.Status = domain.DatabaseUpgradeStatus(status)
+	return &u, nil
+}
@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	theclouderrors "github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabaseUpgradeRepository_CreateAndUpdate(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewDatabaseUpgradeRepository(mock)
+	u := &domain.DatabaseUpgrade{
+		ID: uuid.New(), DatabaseID: uuid.New(), TenantID: uuid.New(), FromVersion: "15", ToVersion: "16",
+		Major: true, Status: domain.DatabaseUpgradeInProgress, StartedAt: time.Now(),
+	}
+
+	mock.ExpectExec("INSERT INTO database_upgrades").
+		WithArgs(u.ID, u.DatabaseID, u.TenantID, u.FromVersion, u.ToVersion, u.Major, u.Status,
+			u.SnapshotID, u.Error, u.StartedAt, u.CompletedAt).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	require.NoError(t, repo.Create(context.Background(), u))
+
+	snapID, done := uuid.New(), time.Now()
+	u.Status, u.SnapshotID, u.CompletedAt = domain.DatabaseUpgradeCompleted, &snapID, &done
+	mock.ExpectExec("UPDATE database_upgrades").
+		WithArgs(u.Status, u.SnapshotID, u.Error, u.CompletedAt, u.ID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	require.NoError(t, repo.Update(context.Background(), u))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatabaseUpgradeRepository_UpdateNotFound(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewDatabaseUpgradeRepository(mock)
+	u := &domain.DatabaseUpgrade{ID: uuid.New(), Status: domain.DatabaseUpgradeFailed, Error: "boom"}
+	mock.ExpectExec("UPDATE database_upgrades").
+		WithArgs(u.Status, u.SnapshotID, u.Error, u.CompletedAt, u.ID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+	err = repo.Update(context.Background(), u)
+	require.Error(t, err)
+	assert.True(t, theclouderrors.Is(err, theclouderrors.NotFound))
+}
+
+func TestDatabaseUpgradeRepository_ListByDatabase(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewDatabaseUpgradeRepository(mock)
+	dbID, snapID := uuid.New(), uuid.New()
+	now := time.Now()
+
+	mock.ExpectQuery("SELECT " + databaseUpgradeColumns + " FROM database_upgrades WHERE database_id = \\$1 ORDER BY started_at DESC").
+		WithArgs(dbID).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "database_id", "tenant_id", "from_version", "to_version", "major", "status", "snapshot_id", "error", "started_at", "completed_at"}).
+			AddRow(uuid.New(), dbID, uuid.New(), "15", "16", true, "COMPLETED", &snapID, "", now, &now).
+			AddRow(uuid.New(), dbID, uuid.New(), "15.3", "15.4", false, "FAILED", nil, "database did not become ready", now.Add(-time.Hour), nil))
+
+	upgrades, err := repo.ListByDatabase(context.Background(), dbID)
+	require.NoError(t, err)
+	require.Len(t, upgrades, 2)
+	assert.True(t, upgrades[0].Major)
+	assert.Equal(t, snapID, *upgrades[0].SnapshotID)
+	assert.Equal(t, domain.DatabaseUpgradeFailed, upgrades[1].Status)
+	assert.Nil(t, upgrades[1].CompletedAt)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
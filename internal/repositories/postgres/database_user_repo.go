@@ -0,0 +1,161 @@
+package postgres
+
+import (
+	"context"
+	stdlib_errors "errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/errors"
+)
+
+const (
+	logicalDatabaseColumns = "id, database_id, tenant_id, name, created_at"
+	databaseUserColumns    = "id, database_id, tenant_id, username, grants, credential_path, credential_version, created_at, updated_at"
+)
+
+// DatabaseUserRepository persists the additional users and logical databases
+// of managed databases. Rows are looked up by database ID, which callers
+// resolve under tenant scope.
+type DatabaseUserRepository struct {
+	db DB
+}
+
+// NewDatabaseUserRepository creates a new DatabaseUserRepository.
+func NewDatabaseUserRepository(db DB) *DatabaseUserRepository {
+	return &DatabaseUserRepository{db: db}
+}
+
+func (r *DatabaseUserRepository) CreateLogicalDatabase(ctx context.Context, l *domain.LogicalDatabase) error {
+	query := `INSERT INTO database_logical_databases (` + logicalDatabaseColumns + `) VALUES ($1, $2, $3, $4, $5)`
+	_, err := r.db.Exec(ctx, query, l.ID, l.DatabaseID, l.TenantID, l.Name, l.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if stdlib_errors.As(err, &pgErr) && pgErr.Code == uniqueViolationSQLState {
+			return errors.Wrap(errors.Conflict, "logical database already exists", err)
+		}
+		return errors.Wrap(errors.Internal, "failed to create logical database", err)
+	}
+	return nil
+}
+
+func (r *DatabaseUserRepository) ListLogicalDatabases(ctx context.Context, databaseID uuid.UUID) ([]*domain.LogicalDatabase, error) {
+	query := `SELECT ` + logicalDatabaseColumns + ` FROM database_logical_databases WHERE database_id = $1 ORDER BY name`
+	rows, err := r.db.Query(ctx, query, databaseID)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list logical databases", err)
+	}
+	defer rows.Close()
+
+	dbs := make([]*domain.LogicalDatabase, 0)
+	for rows.Next() {
+		var l domain.LogicalDatabase
+		if err := rows.Scan(&l.ID, &l.DatabaseID, &l.TenantID, &l.Name, &l.CreatedAt); err != nil {
+			return nil, errors.Wrap(errors.Internal, "failed to scan logical database", err)
+		}
+		dbs = append(dbs, &l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to iterate logical databases", err)
+	}
+	return dbs, nil
+}
+
+func (r *DatabaseUserRepository) DeleteLogicalDatabase(ctx context.Context, databaseID uuid.UUID, name string) error {
+	cmd, err := r.db.Exec(ctx, `DELETE FROM database_logical_databases WHERE database_id = $1 AND name = $2`, databaseID, name)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to delete logical database", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, "logical database not found")
+	}
+	return nil
+}
+
+func (r *DatabaseUserRepository) CreateUser(ctx context.Context, u *domain.DatabaseUser) error {
+	query := `INSERT INTO database_users (` + databaseUserColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+	_, err := r.db.Exec(ctx, query,
+		u.ID, u.DatabaseID, u.TenantID, u.Username, u.Grants, u.CredentialPath, u.CredentialVersion, u.CreatedAt, u.UpdatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if stdlib_errors.As(err, &pgErr) && pgErr.Code == uniqueViolationSQLState {
+			return errors.Wrap(errors.Conflict, "database user already exists", err)
+		}
+		return errors.Wrap(errors.Internal, "failed to create database user", err)
+	}
+	return nil
+}
+
+func (r *DatabaseUserRepository) GetUser(ctx context.Context, databaseID uuid.UUID, username string) (*domain.DatabaseUser, error) {
+	query := `SELECT ` + databaseUserColumns + ` FROM database_users WHERE database_id = $1 AND username = $2`
+	u, err := scanDatabaseUser(r.db.QueryRow(ctx, query, databaseID, username))
+	if err != nil {
+		if stdlib_errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New(errors.NotFound, "database user not found")
+		}
+		return nil, errors.Wrap(errors.Internal, "failed to get database user", err)
+	}
+	return u, nil
+}
+
+func (r *DatabaseUserRepository) ListUsers(ctx context.Context, databaseID uuid.UUID) ([]*domain.DatabaseUser, error) {
+	query := `SELECT ` + databaseUserColumns + ` FROM database_users WHERE database_id = $1 ORDER BY username`
+	rows, err := r.db.Query(ctx, query, databaseID)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list database users", err)
+	}
+	defer rows.Close()
+
+	users := make([]*domain.DatabaseUser, 0)
+	for rows.Next() {
+		u, err := scanDatabaseUser(rows)
+		if err != nil {
+			return nil, errors.Wrap(errors.Internal, "failed to scan database user", err)
+		}
+		users = append(users, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to iterate database users", err)
+	}
+	return users, nil
+}
+
+func (r *DatabaseUserRepository) UpdateUser(ctx context.Context, u *domain.DatabaseUser) error {
+	query := `UPDATE database_users
+		SET grants = $1, credential_path = $2, credential_version = $3, updated_at = $4
+		WHERE id = $5`
+	cmd, err := r.db.Exec(ctx, query, u.Grants, u.CredentialPath, u.CredentialVersion, u.UpdatedAt, u.ID)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to update database user", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, "database user not found")
+	}
+	return nil
+}
+
+func (r *DatabaseUserRepository) DeleteUser(ctx context.Context, databaseID uuid.UUID, username string) error {
+	cmd, err := r.db.Exec(ctx, `DELETE FROM database_users WHERE database_id = $1 AND username = $2`, databaseID, username)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to delete database user", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, "database user not found")
+	}
+	return nil
+}
+
+func scanDatabaseUser(row pgx.Row) (*domain.DatabaseUser, error) {
+	var u domain.DatabaseUser
+	err := row.Scan(&u.ID, &u.DatabaseID, &u.TenantID, &u.Username, &u.Grants, &u.CredentialPath, &u.CredentialVersion, &u.CreatedAt, &u.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if u.Grants == nil {
+		u.Grants = []domain.DatabaseGrant{}
+	}
+	return &u, nil
+}
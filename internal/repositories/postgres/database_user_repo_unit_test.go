@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	theclouderrors "github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var databaseUserRowColumns = []string{"id", "database_id", "tenant_id", "username", "grants", "credential_path", "credential_version", "created_at", "updated_at"}
+
+func TestDatabaseUserRepository_LogicalDatabases(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewDatabaseUserRepository(mock)
+	l := &domain.LogicalDatabase{ID: uuid.New(), DatabaseID: uuid.New(), TenantID: uuid.New(), Name: "reporting", CreatedAt: time.Now()}
+
+	mock.ExpectExec("INSERT INTO database_logical_databases").
+		WithArgs(l.ID, l.DatabaseID, l.TenantID, l.Name, l.CreatedAt).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	require.NoError(t, repo.CreateLogicalDatabase(context.Background(), l))
+
+	mock.ExpectExec("INSERT INTO database_logical_databases").
+		WithArgs(l.ID, l.DatabaseID, l.TenantID, l.Name, l.CreatedAt).
+		WillReturnError(&pgconn.PgError{Code: uniqueViolationSQLState})
+	err = repo.CreateLogicalDatabase(context.Background(), l)
+	assert.True(t, theclouderrors.Is(err, theclouderrors.Conflict))
+
+	mock.ExpectQuery("SELECT " + logicalDatabaseColumns + " FROM database_logical_databases WHERE database_id = \\$1 ORDER BY name").
+		WithArgs(l.DatabaseID).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "database_id", "tenant_id", "name", "created_at"}).
+			AddRow(l.ID, l.DatabaseID, l.TenantID, l.Name, l.CreatedAt))
+	dbs, err := repo.ListLogicalDatabases(context.Background(), l.DatabaseID)
+	require.NoError(t, err)
+	require.Len(t, dbs, 1)
+	assert.Equal(t, "reporting", dbs[0].Name)
+
+	mock.ExpectExec("DELETE FROM database_logical_databases").
+		WithArgs(l.DatabaseID, l.Name).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	err = repo.DeleteLogicalDatabase(context.Background(), l.DatabaseID, l.Name)
+	assert.True(t, theclouderrors.Is(err, theclouderrors.NotFound))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDatabaseUserRepository_Users(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewDatabaseUserRepository(mock)
+	now := time.Now()
+	u := &domain.DatabaseUser{
+		ID: uuid.New(), DatabaseID: uuid.New(), TenantID: uuid.New(), Username: "app_ro",
+		Grants:         []domain.DatabaseGrant{{Database: "app", Privilege: domain.DatabasePrivilegeReadOnly}},
+		CredentialPath: "secret/data/thecloud/rds/x/users/app_ro/credentials", CreatedAt: now, UpdatedAt: now,
+	}
+
+	mock.ExpectExec("INSERT INTO database_users").
+		WithArgs(u.ID, u.DatabaseID, u.TenantID, u.Username, u.Grants, u.CredentialPath, u.CredentialVersion, u.CreatedAt, u.UpdatedAt).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	require.NoError(t, repo.CreateUser(context.Background(), u))
+
+	mock.ExpectQuery("SELECT "+databaseUserColumns+" FROM database_users WHERE database_id = \\$1 AND username = \\$2").
+		WithArgs(u.DatabaseID, u.Username).
+		WillReturnRows(pgxmock.NewRows(databaseUserRowColumns).
+			AddRow(u.ID, u.DatabaseID, u.TenantID, u.Username, u.Grants, u.CredentialPath, 0, now, now))
+	got, err := repo.GetUser(context.Background(), u.DatabaseID, u.Username)
+	require.NoError(t, err)
+	assert.Equal(t, u.Grants, got.Grants)
+
+	mock.ExpectQuery("SELECT .* FROM database_users WHERE database_id = \\$1 AND username = \\$2").
+		WithArgs(u.DatabaseID, "missing").
+		WillReturnError(pgx.ErrNoRows)
+	_, err = repo.GetUser(context.Background(), u.DatabaseID, "missing")
+	assert.True(t, theclouderrors.Is(err, theclouderrors.NotFound))
+
+	mock.ExpectQuery("SELECT " + databaseUserColumns + " FROM database_users WHERE database_id = \\$1 ORDER BY username").
+		WithArgs(u.DatabaseID).
+		WillReturnRows(pgxmock.NewRows(databaseUserRowColumns).
+			AddRow(u.ID, u.DatabaseID, u.TenantID, u.Username, []domain.DatabaseGrant(nil), u.CredentialPath, 2, now, now))
+	users, err := repo.ListUsers(context.Background(), u.DatabaseID)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+	assert.Empty(t, users[0].Grants)
+	assert.NotNil(t, users[0].Grants)
+	assert.Equal(t, 2, users[0].CredentialVersion)
+
+	u.CredentialVersion = 1
+	mock.ExpectExec("UPDATE database_users").
+		WithArgs(u.Grants, u.CredentialPath, u.CredentialVersion, u.UpdatedAt, u.ID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	require.NoError(t, repo.UpdateUser(context.Background(), u))
+
+	mock.ExpectExec("DELETE FROM database_users").
+		WithArgs(u.DatabaseID, u.Username).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	require.NoError(t, repo.DeleteUser(context.Background(), u.DatabaseID, u.Username))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
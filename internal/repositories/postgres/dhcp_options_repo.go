@@ -0,0 +1,133 @@
+package postgres
+
+import (
+	"context"
+	stdlib_errors "errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/errors"
+)
+
+// foreignKeyViolationSQLState is the PostgreSQL error code for foreign key violations.
+const foreignKeyViolationSQLState = "23503"
+
+const dhcpOptionsColumns = `d.id, d.user_id, d.tenant_id, d.name, d.domain_name, d.domain_name_servers, d.ntp_servers, d.arn, d.created_at`
+
+// DHCPOptionsRepository provides PostgreSQL-backed persistence for DHCP options sets.
+type DHCPOptionsRepository struct {
+	db DB
+}
+
+// NewDHCPOptionsRepository creates a DHCPOptionsRepository using the provided DB.
+func NewDHCPOptionsRepository(db DB) *DHCPOptionsRepository {
+	return &DHCPOptionsRepository{db: db}
+}
+
+// Create inserts a new DHCP options set.
+func (r *DHCPOptionsRepository) Create(ctx context.Context, opts *domain.DHCPOptionsSet) error {
+	query := `
+		INSERT INTO dhcp_options_sets (id, user_id, tenant_id, name, domain_name, domain_name_servers, ntp_servers, arn, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	_, err := r.db.Exec(ctx, query, opts.ID, opts.UserID, opts.TenantID, opts.Name, opts.DomainName, nonNilStrings(opts.DomainNameServers), nonNilStrings(opts.NTPServers), opts.ARN, opts.CreatedAt)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to create DHCP options set", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a DHCP options set by ID.
+func (r *DHCPOptionsRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.DHCPOptionsSet, error) {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `SELECT ` + dhcpOptionsColumns + ` FROM dhcp_options_sets d WHERE d.id = $1 AND d.tenant_id = $2`
+	return r.scanOptions(r.db.QueryRow(ctx, query, id, tenantID))
+}
+
+// GetByVPC retrieves the DHCP options set associated with a VPC.
+func (r *DHCPOptionsRepository) GetByVPC(ctx context.Context, vpcID uuid.UUID) (*domain.DHCPOptionsSet, error) {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `
+		SELECT ` + dhcpOptionsColumns + `
+		FROM dhcp_options_sets d JOIN vpcs v ON v.dhcp_options_id = d.id
+		WHERE v.id = $1 AND d.tenant_id = $2
+	`
+	return r.scanOptions(r.db.QueryRow(ctx, query, vpcID, tenantID))
+}
+
+// List returns all DHCP options sets of the current tenant.
+func (r *DHCPOptionsRepository) List(ctx context.Context) ([]*domain.DHCPOptionsSet, error) {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `SELECT ` + dhcpOptionsColumns + ` FROM dhcp_options_sets d WHERE d.tenant_id = $1 ORDER BY d.created_at DESC`
+	rows, err := r.db.Query(ctx, query, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list DHCP options sets", err)
+	}
+	defer rows.Close()
+
+	var sets []*domain.DHCPOptionsSet
+	for rows.Next() {
+		opts, err := r.scanOptions(rows)
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, opts)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to iterate DHCP options sets", err)
+	}
+	return sets, nil
+}
+
+// Delete removes a DHCP options set that no VPC uses anymore.
+func (r *DHCPOptionsRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	cmd, err := r.db.Exec(ctx, `DELETE FROM dhcp_options_sets WHERE id = $1 AND tenant_id = $2`, id, tenantID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if stdlib_errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolationSQLState {
+			return errors.New(errors.Conflict, "DHCP options set is still associated with VPCs")
+		}
+		return errors.Wrap(errors.Internal, "failed to delete DHCP options set", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, "DHCP options set not found")
+	}
+	return nil
+}
+
+// SetVPCAssociation points a VPC at a DHCP options set, or clears it when optionsID is nil.
+func (r *DHCPOptionsRepository) SetVPCAssociation(ctx context.Context, vpcID uuid.UUID, optionsID *uuid.UUID) error {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	cmd, err := r.db.Exec(ctx, `UPDATE vpcs SET dhcp_options_id = $1 WHERE id = $2 AND tenant_id = $3`, optionsID, vpcID, tenantID)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to update VPC DHCP options", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, "VPC not found")
+	}
+	return nil
+}
+
+func (r *DHCPOptionsRepository) scanOptions(row pgx.Row) (*domain.DHCPOptionsSet, error) {
+	var opts domain.DHCPOptionsSet
+	err := row.Scan(&opts.ID, &opts.UserID, &opts.TenantID, &opts.Name, &opts.DomainName, &opts.DomainNameServers, &opts.NTPServers, &opts.ARN, &opts.CreatedAt)
+	if err != nil {
+		if stdlib_errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New(errors.NotFound, "DHCP options set not found")
+		}
+		return nil, errors.Wrap(errors.Internal, "failed to scan DHCP options set", err)
+	}
+	return &opts, nil
+}
+
+// nonNilStrings keeps NOT NULL text[] columns from receiving a SQL NULL.
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
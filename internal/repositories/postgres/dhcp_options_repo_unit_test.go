@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v3"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	theclouderrors "github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDHCPOptionsRepository_CreateStoresEmptyArrays(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	opts := &domain.DHCPOptionsSet{ID: uuid.New(), UserID: uuid.New(), TenantID: uuid.New(), Name: "corp", DomainName: "corp.internal", ARN: "arn", CreatedAt: time.Now()}
+	mock.ExpectExec("INSERT INTO dhcp_options_sets").
+		WithArgs(opts.ID, opts.UserID, opts.TenantID, opts.Name, opts.DomainName, []string{}, []string{}, opts.ARN, opts.CreatedAt).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	require.NoError(t, NewDHCPOptionsRepository(mock).Create(context.Background(), opts))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestDHCPOptionsRepository_GetByVPC(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	vpcID, tenantID := uuid.New(), uuid.New()
+	mock.ExpectQuery("FROM dhcp_options_sets d JOIN vpcs v ON v.dhcp_options_id = d.id").
+		WithArgs(vpcID, tenantID).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "tenant_id", "name", "domain_name", "domain_name_servers", "ntp_servers", "arn", "created_at"}).
+			AddRow(uuid.New(), uuid.New(), tenantID, "corp", "corp.internal", []string{"10.0.0.53"}, []string{}, "arn", time.Now()))
+
+	opts, err := NewDHCPOptionsRepository(mock).GetByVPC(appcontext.WithTenantID(context.Background(), tenantID), vpcID)
+	require.NoError(t, err)
+	assert.Equal(t, "corp.internal", opts.DomainName)
+	assert.Equal(t, []string{"10.0.0.53"}, opts.DomainNameServers)
+}
+
+func TestDHCPOptionsRepository_GetByVPCNotFound(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery("FROM dhcp_options_sets").
+		WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg()).
+		WillReturnError(pgx.ErrNoRows)
+
+	_, err = NewDHCPOptionsRepository(mock).GetByVPC(context.Background(), uuid.New())
+	assert.True(t, theclouderrors.Is(err, theclouderrors.NotFound))
+}
+
+func TestDHCPOptionsRepository_DeleteInUse(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectExec("DELETE FROM dhcp_options_sets").
+		WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg()).
+		WillReturnError(&pgconn.PgError{Code: "23503"})
+
+	err = NewDHCPOptionsRepository(mock).Delete(context.Background(), uuid.New())
+	assert.True(t, theclouderrors.Is(err, theclouderrors.Conflict))
+}
+
+func TestDHCPOptionsRepository_ClearVPCAssociation(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	vpcID, tenantID := uuid.New(), uuid.New()
+	mock.ExpectExec("UPDATE vpcs SET dhcp_options_id").
+		WithArgs((*uuid.UUID)(nil), vpcID, tenantID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	require.NoError(t, NewDHCPOptionsRepository(mock).SetVPCAssociation(appcontext.WithTenantID(context.Background(), tenantID), vpcID, nil))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -17,6 +17,8 @@ import (
 // See: https://www.postgresql.org/docs/current/errcodes-35.html
 const uniqueViolationSQLState = "23505"
 
+const elasticIPColumns = `id, user_id, tenant_id, public_ip, instance_id, vpc_id, network_interface_id, COALESCE(private_ip, ''), status, arn, created_at, updated_at`
+
 // ElasticIPRepository provides a PostgreSQL implementation for managing Elastic IP metadata.
 type ElasticIPRepository struct {
 	db DB
@@ -30,13 +32,13 @@ func NewElasticIPRepository(db DB) *ElasticIPRepository {
 // Create inserts a new Elastic IP record into the database.
 func (r *ElasticIPRepository) Create(ctx context.Context, eip *domain.ElasticIP) error {
 	query := `
-		INSERT INTO elastic_ips (id, user_id, tenant_id, public_ip, instance_id, vpc_id, status, arn, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		INSERT INTO elastic_ips (id, user_id, tenant_id, public_ip, instance_id, vpc_id, network_interface_id, private_ip, status, arn, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), $9, $10, $11, $12)
 	`
 	_, err := r.db.Exec(ctx, query,
 		eip.ID, eip.UserID, eip.TenantID, eip.PublicIP,
-		eip.InstanceID, eip.VpcID, eip.Status, eip.ARN,
-		eip.CreatedAt, eip.UpdatedAt,
+		eip.InstanceID, eip.VpcID, eip.NetworkInterfaceID, eip.PrivateIP,
+		eip.Status, eip.ARN, eip.CreatedAt, eip.UpdatedAt,
 	)
 	if err != nil {
 		var pgErr *pgconn.PgError
@@ -52,7 +54,7 @@ func (r *ElasticIPRepository) Create(ctx context.Context, eip *domain.ElasticIP)
 func (r *ElasticIPRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ElasticIP, error) {
 	tenantID := appcontext.TenantIDFromContext(ctx)
 	query := `
-		SELECT id, user_id, tenant_id, public_ip, instance_id, vpc_id, status, arn, created_at, updated_at 
+		SELECT ` + elasticIPColumns + `
 		FROM elastic_ips 
 		WHERE id = $1 AND tenant_id = $2
 	`
@@ -63,7 +65,7 @@ func (r *ElasticIPRepository) GetByID(ctx context.Context, id uuid.UUID) (*domai
 func (r *ElasticIPRepository) GetByPublicIP(ctx context.Context, publicIP string) (*domain.ElasticIP, error) {
 	tenantID := appcontext.TenantIDFromContext(ctx)
 	query := `
-		SELECT id, user_id, tenant_id, public_ip, instance_id, vpc_id, status, arn, created_at, updated_at 
+		SELECT ` + elasticIPColumns + `
 		FROM elastic_ips 
 		WHERE public_ip = $1 AND tenant_id = $2
 	`
@@ -74,18 +76,33 @@ func (r *ElasticIPRepository) GetByPublicIP(ctx context.Context, publicIP string
 func (r *ElasticIPRepository) GetByInstanceID(ctx context.Context, instanceID uuid.UUID) (*domain.ElasticIP, error) {
 	tenantID := appcontext.TenantIDFromContext(ctx)
 	query := `
-		SELECT id, user_id, tenant_id, public_ip, instance_id, vpc_id, status, arn, created_at, updated_at 
+		SELECT ` + elasticIPColumns + `
 		FROM elastic_ips 
 		WHERE instance_id = $1 AND tenant_id = $2
 	`
 	return r.scanElasticIP(r.db.QueryRow(ctx, query, instanceID, tenantID))
 }
 
+// ListByNetworkInterface returns the Elastic IPs associated with addresses of a network interface.
+func (r *ElasticIPRepository) ListByNetworkInterface(ctx context.Context, interfaceID uuid.UUID) ([]*domain.ElasticIP, error) {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `
+		SELECT ` + elasticIPColumns + `
+		FROM elastic_ips
+		WHERE network_interface_id = $1 AND tenant_id = $2
+	`
+	rows, err := r.db.Query(ctx, query, interfaceID, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list elastic ips", err)
+	}
+	return r.scanElasticIPs(rows)
+}
+
 // List returns all Elastic IPs belonging to the authenticated tenant.
 func (r *ElasticIPRepository) List(ctx context.Context) ([]*domain.ElasticIP, error) {
 	tenantID := appcontext.TenantIDFromContext(ctx)
 	query := `
-		SELECT id, user_id, tenant_id, public_ip, instance_id, vpc_id, status, arn, created_at, updated_at 
+		SELECT ` + elasticIPColumns + `
 		FROM elastic_ips 
 		WHERE tenant_id = $1 
 		ORDER BY created_at DESC
@@ -102,14 +119,18 @@ func (r *ElasticIPRepository) Update(ctx context.Context, eip *domain.ElasticIP)
 	tenantID := appcontext.TenantIDFromContext(ctx)
 	query := `
 		UPDATE elastic_ips 
-		SET instance_id = $1, vpc_id = $2, status = $3, updated_at = $4 
-		WHERE id = $5 AND tenant_id = $6
+		SET instance_id = $1, vpc_id = $2, network_interface_id = $3, private_ip = NULLIF($4, ''), status = $5, updated_at = $6
+		WHERE id = $7 AND tenant_id = $8
 	`
 	cmd, err := r.db.Exec(ctx, query,
-		eip.InstanceID, eip.VpcID, eip.Status, eip.UpdatedAt,
-		eip.ID, tenantID,
+		eip.InstanceID, eip.VpcID, eip.NetworkInterfaceID, eip.PrivateIP,
+		eip.Status, eip.UpdatedAt, eip.ID, tenantID,
 	)
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if stdlib_errors.As(err, &pgErr) && pgErr.Code == uniqueViolationSQLState {
+			return errors.Wrap(errors.Conflict, "address already has an associated elastic ip", err)
+		}
 		return errors.Wrap(errors.Internal, "failed to update elastic ip", err)
 	}
 	if cmd.RowsAffected() == 0 {
@@ -136,7 +157,8 @@ func (r *ElasticIPRepository) scanElasticIP(row pgx.Row) (*domain.ElasticIP, err
 	var eip domain.ElasticIP
 	err := row.Scan(
 		&eip.ID, &eip.UserID, &eip.TenantID, &eip.PublicIP,
-		&eip.InstanceID, &eip.VpcID, &eip.Status, &eip.ARN,
+		&eip.InstanceID, &eip.VpcID, &eip.NetworkInterfaceID, &eip.PrivateIP,
+		&eip.Status, &eip.ARN,
 		&eip.CreatedAt, &eip.UpdatedAt,
 	)
 	if err != nil {
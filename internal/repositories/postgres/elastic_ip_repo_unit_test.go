@@ -6,9 +6,11 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/pashagolub/pgxmock/v3"
 	appcontext "github.com/poyrazk/thecloud/internal/core/context"
 	"github.com/poyrazk/thecloud/internal/core/domain"
+	theclouderrors "github.com/poyrazk/thecloud/internal/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -34,7 +36,7 @@ func TestElasticIPRepositoryUnit(t *testing.T) {
 		}
 
 		mock.ExpectExec("INSERT INTO elastic_ips").
-			WithArgs(eip.ID, eip.UserID, eip.TenantID, eip.PublicIP, eip.InstanceID, eip.VpcID, eip.Status, eip.ARN, eip.CreatedAt, eip.UpdatedAt).
+			WithArgs(eip.ID, eip.UserID, eip.TenantID, eip.PublicIP, eip.InstanceID, eip.VpcID, eip.NetworkInterfaceID, eip.PrivateIP, eip.Status, eip.ARN, eip.CreatedAt, eip.UpdatedAt).
 			WillReturnResult(pgxmock.NewResult("INSERT", 1))
 
 		err = repo.Create(ctx, eip)
@@ -54,12 +56,29 @@ func TestElasticIPRepositoryUnit(t *testing.T) {
 
 		mock.ExpectQuery("SELECT .* FROM elastic_ips WHERE id = \\$1 AND tenant_id = \\$2").
 			WithArgs(id, tenantID).
-			WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "tenant_id", "public_ip", "instance_id", "vpc_id", "status", "arn", "created_at", "updated_at"}).
-				AddRow(id, uuid.New(), tenantID, "1.2.3.4", nil, nil, "allocated", "arn", now, now))
+			WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "tenant_id", "public_ip", "instance_id", "vpc_id", "network_interface_id", "private_ip", "status", "arn", "created_at", "updated_at"}).
+				AddRow(id, uuid.New(), tenantID, "1.2.3.4", nil, nil, nil, "", "allocated", "arn", now, now))
 
 		res, err := repo.GetByID(ctx, id)
 		require.NoError(t, err)
 		assert.NotNil(t, res)
 		assert.Equal(t, id, res.ID)
 	})
+
+	t.Run("UpdateAddressAlreadyMapped", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		repo := NewElasticIPRepository(mock)
+		niID := uuid.New()
+		eip := &domain.ElasticIP{ID: uuid.New(), NetworkInterfaceID: &niID, PrivateIP: "10.0.1.20", Status: domain.EIPStatusAssociated}
+
+		mock.ExpectExec("UPDATE elastic_ips").
+			WithArgs(eip.InstanceID, eip.VpcID, eip.NetworkInterfaceID, eip.PrivateIP, eip.Status, eip.UpdatedAt, eip.ID, uuid.Nil).
+			WillReturnError(&pgconn.PgError{Code: "23505"})
+
+		err = repo.Update(context.Background(), eip)
+		assert.True(t, theclouderrors.Is(err, theclouderrors.Conflict))
+	})
 }
@@ -0,0 +1,124 @@
+package postgres
+
+import (
+	"context"
+	stdlib_errors "errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/errors"
+)
+
+const (
+	flowLogColumns     = "id, user_id, tenant_id, resource_type, resource_id, vpc_id, traffic_type, destination, bucket, interval_seconds, status, last_error, last_captured_at, created_at"
+	errFlowLogNotFound = "flow log not found"
+)
+
+// FlowLogRepository persists VPC flow log configurations in Postgres.
+type FlowLogRepository struct {
+	db DB
+}
+
+// NewFlowLogRepository creates a new FlowLogRepository.
+func NewFlowLogRepository(db DB) *FlowLogRepository {
+	return &FlowLogRepository{db: db}
+}
+
+func (r *FlowLogRepository) Create(ctx context.Context, fl *domain.FlowLog) error {
+	query := `
+		INSERT INTO flow_logs (id, user_id, tenant_id, resource_type, resource_id, vpc_id, traffic_type, destination, bucket, interval_seconds, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+	_, err := r.db.Exec(ctx, query,
+		fl.ID, fl.UserID, fl.TenantID, fl.ResourceType, fl.ResourceID, fl.VPCID,
+		fl.TrafficType, fl.Destination, fl.Bucket, fl.IntervalSeconds, fl.Status, fl.CreatedAt,
+	)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to create flow log", err)
+	}
+	return nil
+}
+
+func (r *FlowLogRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.FlowLog, error) {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `SELECT ` + flowLogColumns + ` FROM flow_logs WHERE id = $1 AND tenant_id = $2`
+	return r.scanFlowLog(r.db.QueryRow(ctx, query, id, tenantID))
+}
+
+func (r *FlowLogRepository) List(ctx context.Context) ([]*domain.FlowLog, error) {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `SELECT ` + flowLogColumns + ` FROM flow_logs WHERE tenant_id = $1 ORDER BY created_at DESC`
+	rows, err := r.db.Query(ctx, query, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list flow logs", err)
+	}
+	return r.scanFlowLogs(rows)
+}
+
+func (r *FlowLogRepository) ListAll(ctx context.Context) ([]*domain.FlowLog, error) {
+	query := `SELECT ` + flowLogColumns + ` FROM flow_logs ORDER BY created_at`
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list all flow logs", err)
+	}
+	return r.scanFlowLogs(rows)
+}
+
+func (r *FlowLogRepository) UpdateCapture(ctx context.Context, id uuid.UUID, capturedAt time.Time, status domain.FlowLogStatus, lastError string) error {
+	query := `UPDATE flow_logs SET last_captured_at = $1, status = $2, last_error = $3 WHERE id = $4`
+	cmd, err := r.db.Exec(ctx, query, capturedAt, status, lastError, id)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to update flow log capture", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, errFlowLogNotFound)
+	}
+	return nil
+}
+
+func (r *FlowLogRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	cmd, err := r.db.Exec(ctx, `DELETE FROM flow_logs WHERE id = $1 AND tenant_id = $2`, id, tenantID)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to delete flow log", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, errFlowLogNotFound)
+	}
+	return nil
+}
+
+func (r *FlowLogRepository) scanFlowLog(row pgx.Row) (*domain.FlowLog, error) {
+	var fl domain.FlowLog
+	err := row.Scan(
+		&fl.ID, &fl.UserID, &fl.TenantID, &fl.ResourceType, &fl.ResourceID, &fl.VPCID,
+		&fl.TrafficType, &fl.Destination, &fl.Bucket, &fl.IntervalSeconds,
+		&fl.Status, &fl.LastError, &fl.LastCapturedAt, &fl.CreatedAt,
+	)
+	if err != nil {
+		if stdlib_errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New(errors.NotFound, errFlowLogNotFound)
+		}
+		return nil, errors.Wrap(errors.Internal, "failed to scan flow log", err)
+	}
+	return &fl, nil
+}
+
+func (r *FlowLogRepository) scanFlowLogs(rows pgx.Rows) ([]*domain.FlowLog, error) {
+	defer rows.Close()
+	var flowLogs []*domain.FlowLog
+	for rows.Next() {
+		fl, err := r.scanFlowLog(rows)
+		if err != nil {
+			return nil, err
+		}
+		flowLogs = append(flowLogs, fl)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to iterate flow logs", err)
+	}
+	return flowLogs, nil
+}
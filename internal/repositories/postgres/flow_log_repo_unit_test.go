@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v3"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	theclouderrors "github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var flowLogRowColumns = []string{"id", "user_id", "tenant_id", "resource_type", "resource_id", "vpc_id", "traffic_type", "destination", "bucket", "interval_seconds", "status", "last_error", "last_captured_at", "created_at"}
+
+func TestFlowLogRepository_Create(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewFlowLogRepository(mock)
+	fl := &domain.FlowLog{
+		ID: uuid.New(), UserID: uuid.New(), TenantID: uuid.New(),
+		ResourceType: domain.FlowLogResourceSubnet, ResourceID: uuid.New(), VPCID: uuid.New(),
+		TrafficType: domain.FlowLogTrafficAll, Destination: domain.FlowLogDestinationCloudLogs,
+		IntervalSeconds: 60, Status: domain.FlowLogStatusActive, CreatedAt: time.Now(),
+	}
+
+	mock.ExpectExec("INSERT INTO flow_logs").
+		WithArgs(fl.ID, fl.UserID, fl.TenantID, fl.ResourceType, fl.ResourceID, fl.VPCID, fl.TrafficType, fl.Destination, fl.Bucket, fl.IntervalSeconds, fl.Status, fl.CreatedAt).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	require.NoError(t, repo.Create(context.Background(), fl))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestFlowLogRepository_GetByID(t *testing.T) {
+	t.Parallel()
+	t.Run("found", func(t *testing.T) {
+		t.Parallel()
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		repo := NewFlowLogRepository(mock)
+		id := uuid.New()
+		tenantID := uuid.New()
+		captured := time.Now()
+
+		mock.ExpectQuery("SELECT " + flowLogColumns + " FROM flow_logs WHERE id").
+			WithArgs(id, tenantID).
+			WillReturnRows(pgxmock.NewRows(flowLogRowColumns).
+				AddRow(id, uuid.New(), tenantID, domain.FlowLogResourceVPC, uuid.New(), uuid.New(), domain.FlowLogTrafficReject, domain.FlowLogDestinationBucket, "logs", 600, domain.FlowLogStatusActive, "", &captured, time.Now()))
+
+		fl, err := repo.GetByID(appcontext.WithTenantID(context.Background(), tenantID), id)
+		require.NoError(t, err)
+		assert.Equal(t, "logs", fl.Bucket)
+		assert.Equal(t, domain.FlowLogTrafficReject, fl.TrafficType)
+		require.NotNil(t, fl.LastCapturedAt)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		repo := NewFlowLogRepository(mock)
+		mock.ExpectQuery("SELECT " + flowLogColumns + " FROM flow_logs WHERE id").
+			WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg()).
+			WillReturnError(pgx.ErrNoRows)
+
+		_, err = repo.GetByID(context.Background(), uuid.New())
+		require.Error(t, err)
+		assert.True(t, theclouderrors.Is(err, theclouderrors.NotFound))
+	})
+}
+
+func TestFlowLogRepository_ListAll(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewFlowLogRepository(mock)
+	mock.ExpectQuery("SELECT " + flowLogColumns + " FROM flow_logs ORDER BY created_at$").
+		WithArgs().
+		WillReturnRows(pgxmock.NewRows(flowLogRowColumns).
+			AddRow(uuid.New(), uuid.New(), uuid.New(), domain.FlowLogResourceVPC, uuid.New(), uuid.New(), domain.FlowLogTrafficAll, domain.FlowLogDestinationCloudLogs, "", 60, domain.FlowLogStatusActive, "", nil, time.Now()).
+			AddRow(uuid.New(), uuid.New(), uuid.New(), domain.FlowLogResourceInstance, uuid.New(), uuid.New(), domain.FlowLogTrafficAccept, domain.FlowLogDestinationCloudLogs, "", 600, domain.FlowLogStatusError, "bucket gone", nil, time.Now()))
+
+	flowLogs, err := repo.ListAll(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, flowLogs, 2)
+	assert.Nil(t, flowLogs[0].LastCapturedAt)
+}
+
+func TestFlowLogRepository_UpdateCapture(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewFlowLogRepository(mock)
+	id := uuid.New()
+	now := time.Now()
+
+	mock.ExpectExec("UPDATE flow_logs SET last_captured_at").
+		WithArgs(now, domain.FlowLogStatusError, "bucket not found", id).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	require.NoError(t, repo.UpdateCapture(context.Background(), id, now, domain.FlowLogStatusError, "bucket not found"))
+}
+
+func TestFlowLogRepository_Delete(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewFlowLogRepository(mock)
+	id := uuid.New()
+	tenantID := uuid.New()
+
+	mock.ExpectExec("DELETE FROM flow_logs").
+		WithArgs(id, tenantID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	err = repo.Delete(appcontext.WithTenantID(context.Background(), tenantID), id)
+	assert.True(t, theclouderrors.Is(err, theclouderrors.NotFound))
+}
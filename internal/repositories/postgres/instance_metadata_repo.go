@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/errors"
+)
+
+// InstanceMetadataRepository provides PostgreSQL-backed persistence for the
+// configuration served by the instance metadata service.
+type InstanceMetadataRepository struct {
+	db DB
+}
+
+// NewInstanceMetadataRepository creates an InstanceMetadataRepository using the provided DB.
+func NewInstanceMetadataRepository(db DB) *InstanceMetadataRepository {
+	return &InstanceMetadataRepository{db: db}
+}
+
+// Save upserts the metadata configuration of an instance.
+func (r *InstanceMetadataRepository) Save(ctx context.Context, cfg *domain.InstanceMetadataConfig) error {
+	query := `
+		INSERT INTO instance_metadata (instance_id, tenant_id, user_data, service_account_id)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (instance_id) DO UPDATE SET user_data = EXCLUDED.user_data, service_account_id = EXCLUDED.service_account_id
+	`
+	if _, err := r.db.Exec(ctx, query, cfg.InstanceID, cfg.TenantID, cfg.UserData, cfg.ServiceAccountID); err != nil {
+		return errors.Wrap(errors.Internal, "failed to save instance metadata", err)
+	}
+	return nil
+}
+
+// GetByAddress resolves the caller of the metadata service from its VPC and
+// source address. It is not tenant scoped: the tenant is what it establishes.
+func (r *InstanceMetadataRepository) GetByAddress(ctx context.Context, vpcID *uuid.UUID, ip string) (*domain.InstanceMetadataConfig, error) {
+	query := `
+		SELECT i.id, i.tenant_id, COALESCE(m.user_data, ''), m.service_account_id
+		FROM instances i LEFT JOIN instance_metadata m ON m.instance_id = i.id
+		WHERE (i.private_ip = $1::inet OR i.private_ipv6 = $1::inet) AND i.vpc_id IS NOT DISTINCT FROM $2::uuid AND i.status <> $3
+		LIMIT 2
+	`
+	rows, err := r.db.Query(ctx, query, ip, vpcID, string(domain.StatusDeleted))
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to look up instance by private ip", err)
+	}
+	defer rows.Close()
+
+	var found []*domain.InstanceMetadataConfig
+	for rows.Next() {
+		var cfg domain.InstanceMetadataConfig
+		if err := rows.Scan(&cfg.InstanceID, &cfg.TenantID, &cfg.UserData, &cfg.ServiceAccountID); err != nil {
+			return nil, errors.Wrap(errors.Internal, "failed to scan instance metadata", err)
+		}
+		found = append(found, &cfg)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to iterate instance metadata", err)
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, errors.New(errors.NotFound, "no instance owns this address")
+	case 1:
+		return found[0], nil
+	default:
+		return nil, errors.New(errors.Conflict, "address is used by more than one instance")
+	}
+}
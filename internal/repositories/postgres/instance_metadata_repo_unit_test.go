@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	theclouderrors "github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var instanceMetadataColumns = []string{"id", "tenant_id", "user_data", "service_account_id"}
+
+func TestInstanceMetadataRepository_GetByAddress(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	instID, tenantID, saID, vpcID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	mock.ExpectQuery(`FROM instances i LEFT JOIN instance_metadata m .* i.vpc_id IS NOT DISTINCT FROM \$2::uuid`).
+		WithArgs("10.0.1.5", &vpcID, string(domain.StatusDeleted)).
+		WillReturnRows(pgxmock.NewRows(instanceMetadataColumns).AddRow(instID, tenantID, "#!/bin/sh", &saID))
+
+	cfg, err := NewInstanceMetadataRepository(mock).GetByAddress(context.Background(), &vpcID, "10.0.1.5")
+	require.NoError(t, err)
+	assert.Equal(t, instID, cfg.InstanceID)
+	assert.Equal(t, tenantID, cfg.TenantID)
+	assert.Equal(t, saID, *cfg.ServiceAccountID)
+}
+
+func TestInstanceMetadataRepository_GetByAddressNotFound(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery("FROM instances i").
+		WithArgs("192.0.2.1", (*uuid.UUID)(nil), string(domain.StatusDeleted)).
+		WillReturnRows(pgxmock.NewRows(instanceMetadataColumns))
+
+	_, err = NewInstanceMetadataRepository(mock).GetByAddress(context.Background(), nil, "192.0.2.1")
+	assert.True(t, theclouderrors.Is(err, theclouderrors.NotFound))
+}
+
+func TestInstanceMetadataRepository_GetByAddressAmbiguous(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery("FROM instances i").
+		WithArgs("10.0.0.5", (*uuid.UUID)(nil), string(domain.StatusDeleted)).
+		WillReturnRows(pgxmock.NewRows(instanceMetadataColumns).
+			AddRow(uuid.New(), uuid.New(), "", (*uuid.UUID)(nil)).
+			AddRow(uuid.New(), uuid.New(), "", (*uuid.UUID)(nil)))
+
+	_, err = NewInstanceMetadataRepository(mock).GetByAddress(context.Background(), nil, "10.0.0.5")
+	assert.True(t, theclouderrors.Is(err, theclouderrors.Conflict))
+}
@@ -53,7 +53,7 @@ func (r *InstanceRepository) Create(ctx context.Context, inst *domain.Instance)
 func (r *InstanceRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Instance, error) {
 	tenantID := appcontext.TenantIDFromContext(ctx)
 	query := `
-		SELECT id, user_id, tenant_id, name, image, COALESCE(container_id, ''), status, COALESCE(ports, ''), vpc_id, subnet_id, COALESCE(private_ip::text, ''), COALESCE(ovs_port, ''), COALESCE(instance_type, ''), 
+		SELECT id, user_id, tenant_id, name, image, COALESCE(container_id, ''), status, COALESCE(ports, ''), vpc_id, subnet_id, COALESCE(private_ip::text, ''), COALESCE(private_ipv6::text, ''), COALESCE(ovs_port, ''), COALESCE(instance_type, ''), 
 		       volume_binds, env, cmd, COALESCE(cpu_limit, 0), COALESCE(memory_limit, 0), COALESCE(disk_limit, 0),
 		       COALESCE(metadata, '{}'::jsonb), COALESCE(labels, '{}'::jsonb), ssh_key_id,
 		       version, created_at, updated_at
@@ -67,7 +67,7 @@ func (r *InstanceRepository) scanInstance(row pgx.Row) (*domain.Instance, error)
 	var inst domain.Instance
 	var status string
 	err := row.Scan(
-		&inst.ID, &inst.UserID, &inst.TenantID, &inst.Name, &inst.Image, &inst.ContainerID, &status, &inst.Ports, &inst.VpcID, &inst.SubnetID, &inst.PrivateIP, &inst.OvsPort, &inst.InstanceType,
+		&inst.ID, &inst.UserID, &inst.TenantID, &inst.Name, &inst.Image, &inst.ContainerID, &status, &inst.Ports, &inst.VpcID, &inst.SubnetID, &inst.PrivateIP, &inst.PrivateIPv6, &inst.OvsPort, &inst.InstanceType,
 		&inst.VolumeBinds, &inst.Env, &inst.Cmd, &inst.CPULimit, &inst.MemoryLimit, &inst.DiskLimit,
 		&inst.Metadata, &inst.Labels, &inst.SSHKeyID,
 		&inst.Version, &inst.CreatedAt, &inst.UpdatedAt,
@@ -86,7 +86,7 @@ func (r *InstanceRepository) scanInstance(row pgx.Row) (*domain.Instance, error)
 func (r *InstanceRepository) GetByName(ctx context.Context, name string) (*domain.Instance, error) {
 	tenantID := appcontext.TenantIDFromContext(ctx)
 	query := `
-		SELECT id, user_id, tenant_id, name, image, COALESCE(container_id, ''), status, COALESCE(ports, ''), vpc_id, subnet_id, COALESCE(private_ip::text, ''), COALESCE(ovs_port, ''), COALESCE(instance_type, ''), 
+		SELECT id, user_id, tenant_id, name, image, COALESCE(container_id, ''), status, COALESCE(ports, ''), vpc_id, subnet_id, COALESCE(private_ip::text, ''), COALESCE(private_ipv6::text, ''), COALESCE(ovs_port, ''), COALESCE(instance_type, ''), 
 		       volume_binds, env, cmd, COALESCE(cpu_limit, 0), COALESCE(memory_limit, 0), COALESCE(disk_limit, 0),
 		       COALESCE(metadata, '{}'::jsonb), COALESCE(labels, '{}'::jsonb), ssh_key_id,
 		       version, created_at, updated_at
@@ -100,7 +100,7 @@ func (r *InstanceRepository) GetByName(ctx context.Context, name string) (*domai
 func (r *InstanceRepository) List(ctx context.Context, tagFilter []string) ([]*domain.Instance, error) {
 	tenantID := appcontext.TenantIDFromContext(ctx)
 	query := `
-		SELECT id, user_id, tenant_id, name, image, COALESCE(container_id, ''), status, COALESCE(ports, ''), vpc_id, subnet_id, COALESCE(private_ip::text, ''), COALESCE(ovs_port, ''), COALESCE(instance_type, ''),
+		SELECT id, user_id, tenant_id, name, image, COALESCE(container_id, ''), status, COALESCE(ports, ''), vpc_id, subnet_id, COALESCE(private_ip::text, ''), COALESCE(private_ipv6::text, ''), COALESCE(ovs_port, ''), COALESCE(instance_type, ''),
 		       volume_binds, env, cmd, COALESCE(cpu_limit, 0), COALESCE(memory_limit, 0), COALESCE(disk_limit, 0),
 		       COALESCE(metadata, '{}'::jsonb), COALESCE(labels, '{}'::jsonb), ssh_key_id,
 		       version, created_at, updated_at
@@ -130,7 +130,7 @@ func (r *InstanceRepository) List(ctx context.Context, tagFilter []string) ([]*d
 
 func (r *InstanceRepository) ListAll(ctx context.Context) ([]*domain.Instance, error) {
 	query := `
-		SELECT id, user_id, tenant_id, name, image, COALESCE(container_id, ''), status, COALESCE(ports, ''), vpc_id, subnet_id, COALESCE(private_ip::text, ''), COALESCE(ovs_port, ''), COALESCE(instance_type, ''), 
+		SELECT id, user_id, tenant_id, name, image, COALESCE(container_id, ''), status, COALESCE(ports, ''), vpc_id, subnet_id, COALESCE(private_ip::text, ''), COALESCE(private_ipv6::text, ''), COALESCE(ovs_port, ''), COALESCE(instance_type, ''), 
 		       volume_binds, env, cmd, COALESCE(cpu_limit, 0), COALESCE(memory_limit, 0), COALESCE(disk_limit, 0),
 		       COALESCE(metadata, '{}'::jsonb), COALESCE(labels, '{}'::jsonb), ssh_key_id,
 		       version, created_at, updated_at
@@ -151,13 +151,13 @@ func (r *InstanceRepository) Update(ctx context.Context, inst *domain.Instance)
 		UPDATE instances
 		SET name = $1, status = $2, version = version + 1, updated_at = $3, container_id = $4, ports = $5, vpc_id = $6, subnet_id = $7, private_ip = NULLIF($8, '')::inet, ovs_port = $9, instance_type = $10,
 		    volume_binds = $11, env = $12, cmd = $13, cpu_limit = $14, memory_limit = $15, disk_limit = $16,
-		    metadata = $17, labels = $18, ssh_key_id = $19
-		WHERE id = $20 AND version = $21 AND tenant_id = $22
+		    metadata = $17, labels = $18, ssh_key_id = $19, private_ipv6 = NULLIF($20, '')::inet
+		WHERE id = $21 AND version = $22 AND tenant_id = $23
 	`
 	now := time.Now()
 	cmd, err := r.db.Exec(ctx, query, inst.Name, string(inst.Status), now, inst.ContainerID, inst.Ports, inst.VpcID, inst.SubnetID, inst.PrivateIP, inst.OvsPort, inst.InstanceType,
 		inst.VolumeBinds, inst.Env, inst.Cmd, inst.CPULimit, inst.MemoryLimit, inst.DiskLimit,
-		inst.Metadata, inst.Labels, inst.SSHKeyID,
+		inst.Metadata, inst.Labels, inst.SSHKeyID, inst.PrivateIPv6,
 		inst.ID, inst.Version, inst.TenantID)
 	if err != nil {
 		return errors.Wrap(errors.Internal, "failed to update instance", err)
@@ -176,7 +176,7 @@ func (r *InstanceRepository) Update(ctx context.Context, inst *domain.Instance)
 func (r *InstanceRepository) ListBySubnet(ctx context.Context, subnetID uuid.UUID) ([]*domain.Instance, error) {
 	tenantID := appcontext.TenantIDFromContext(ctx)
 	query := `
-		SELECT id, user_id, tenant_id, name, image, COALESCE(container_id, ''), status, COALESCE(ports, ''), vpc_id, subnet_id, COALESCE(private_ip::text, ''), COALESCE(ovs_port, ''), COALESCE(instance_type, ''), 
+		SELECT id, user_id, tenant_id, name, image, COALESCE(container_id, ''), status, COALESCE(ports, ''), vpc_id, subnet_id, COALESCE(private_ip::text, ''), COALESCE(private_ipv6::text, ''), COALESCE(ovs_port, ''), COALESCE(instance_type, ''), 
 		       volume_binds, env, cmd, COALESCE(cpu_limit, 0), COALESCE(memory_limit, 0), COALESCE(disk_limit, 0),
 		       COALESCE(metadata, '{}'::jsonb), COALESCE(labels, '{}'::jsonb), ssh_key_id,
 		       version, created_at, updated_at
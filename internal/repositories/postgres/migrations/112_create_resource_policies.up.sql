@@ -0,0 +1,14 @@
+-- +goose Up
+-- Resource-based policies attached to buckets, queues, topics and secrets
+CREATE TABLE IF NOT EXISTS resource_policies (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    resource_type VARCHAR(50) NOT NULL,
+    resource_id VARCHAR(255) NOT NULL,
+    statements JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(resource_type, resource_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_resource_policies_tenant ON resource_policies(tenant_id);
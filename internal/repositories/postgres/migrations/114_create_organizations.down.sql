@@ -0,0 +1,4 @@
+-- +goose Down
+DROP TABLE IF EXISTS organization_guardrails;
+DROP TABLE IF EXISTS organization_tenants;
+DROP TABLE IF EXISTS organizations;
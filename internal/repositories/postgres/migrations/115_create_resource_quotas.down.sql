@@ -0,0 +1,31 @@
+-- +goose Down
+DELETE FROM role_permissions WHERE permission IN ('quota:read', 'quota:request') AND role_id = (SELECT id FROM roles WHERE name = 'developer');
+
+DROP TABLE IF EXISTS quota_requests;
+
+-- Defaults and fallbacks are the limits tenants got before the quota registry, which are
+-- also the registry defaults, so rolling back never changes a tenant's effective limits.
+CREATE TABLE IF NOT EXISTS tenant_quotas (
+    tenant_id UUID PRIMARY KEY REFERENCES tenants(id) ON DELETE CASCADE,
+    max_instances INT NOT NULL DEFAULT 10,
+    max_vpcs INT NOT NULL DEFAULT 2,
+    max_storage_gb INT NOT NULL DEFAULT 50,
+    max_memory_gb INT NOT NULL DEFAULT 16,
+    max_vcpus INT NOT NULL DEFAULT 8,
+    used_vcpus INTEGER NOT NULL DEFAULT 0,
+    used_memory_gb INTEGER NOT NULL DEFAULT 0
+);
+
+INSERT INTO tenant_quotas (tenant_id, max_instances, max_vpcs, max_storage_gb, max_memory_gb, max_vcpus, used_vcpus, used_memory_gb)
+SELECT t.id,
+    COALESCE((SELECT quota_limit FROM tenant_resource_quotas q WHERE q.tenant_id = t.id AND q.resource = 'instances'), 10),
+    COALESCE((SELECT quota_limit FROM tenant_resource_quotas q WHERE q.tenant_id = t.id AND q.resource = 'vpcs'), 2),
+    COALESCE((SELECT quota_limit FROM tenant_resource_quotas q WHERE q.tenant_id = t.id AND q.resource = 'storage'), 50),
+    COALESCE((SELECT quota_limit FROM tenant_resource_quotas q WHERE q.tenant_id = t.id AND q.resource = 'memory'), 16),
+    COALESCE((SELECT quota_limit FROM tenant_resource_quotas q WHERE q.tenant_id = t.id AND q.resource = 'vcpus'), 8),
+    COALESCE((SELECT used FROM tenant_resource_quotas q WHERE q.tenant_id = t.id AND q.resource = 'vcpus'), 0),
+    COALESCE((SELECT used FROM tenant_resource_quotas q WHERE q.tenant_id = t.id AND q.resource = 'memory'), 0)
+FROM tenants t
+ON CONFLICT (tenant_id) DO NOTHING;
+
+DROP TABLE IF EXISTS tenant_resource_quotas;
@@ -0,0 +1,51 @@
+-- +goose Up
+-- One row per tenant and resource type. A NULL quota_limit means the registry default applies.
+CREATE TABLE IF NOT EXISTS tenant_resource_quotas (
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    resource VARCHAR(50) NOT NULL,
+    quota_limit INT CHECK (quota_limit >= 0),
+    used INT NOT NULL DEFAULT 0 CHECK (used >= 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (tenant_id, resource)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tenant_resource_quotas_resource ON tenant_resource_quotas(resource);
+
+-- Carry over the limits and tracked usage of the fixed-column quota table.
+INSERT INTO tenant_resource_quotas (tenant_id, resource, quota_limit, used)
+SELECT tenant_id, 'instances', max_instances, 0 FROM tenant_quotas
+UNION ALL SELECT tenant_id, 'vpcs', max_vpcs, 0 FROM tenant_quotas
+UNION ALL SELECT tenant_id, 'storage', max_storage_gb, 0 FROM tenant_quotas
+UNION ALL SELECT tenant_id, 'memory', max_memory_gb, used_memory_gb FROM tenant_quotas
+UNION ALL SELECT tenant_id, 'vcpus', max_vcpus, used_vcpus FROM tenant_quotas
+ON CONFLICT (tenant_id, resource) DO NOTHING;
+
+DROP TABLE IF EXISTS tenant_quotas;
+
+-- Load balancers and buckets were created without a tenant; attribute them so usage can be counted.
+UPDATE load_balancers lb SET tenant_id = u.default_tenant_id FROM users u WHERE lb.user_id = u.id AND lb.tenant_id IS NULL;
+UPDATE buckets b SET tenant_id = u.default_tenant_id FROM users u WHERE b.user_id = u.id AND b.tenant_id IS NULL;
+
+CREATE TABLE IF NOT EXISTS quota_requests (
+    id UUID PRIMARY KEY,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    requested_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    resource VARCHAR(50) NOT NULL,
+    current_limit INT NOT NULL,
+    requested_limit INT NOT NULL CHECK (requested_limit > 0),
+    reason TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    review_note TEXT NOT NULL DEFAULT '',
+    reviewed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_quota_requests_tenant ON quota_requests(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_quota_requests_status ON quota_requests(status);
+
+-- Developers may view their tenant's quotas and ask for more.
+INSERT INTO role_permissions (role_id, permission)
+SELECT id, p FROM roles, (VALUES ('quota:read'), ('quota:request')) AS perms(p) WHERE name = 'developer'
+ON CONFLICT (role_id, permission) DO NOTHING;
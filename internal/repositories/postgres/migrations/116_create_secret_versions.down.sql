@@ -0,0 +1,11 @@
+-- +goose Down
+DROP INDEX IF EXISTS idx_secrets_next_rotation;
+
+ALTER TABLE secrets
+    DROP COLUMN IF EXISTS next_rotation_at,
+    DROP COLUMN IF EXISTS last_rotated_at,
+    DROP COLUMN IF EXISTS rotation_interval_days,
+    DROP COLUMN IF EXISTS rotation_function_id,
+    DROP COLUMN IF EXISTS current_version;
+
+DROP TABLE IF EXISTS secret_versions;
@@ -0,0 +1,28 @@
+-- +goose Up
+-- Every value a secret has held. Stage labels (current, previous, pending) are each held by at most one version.
+CREATE TABLE IF NOT EXISTS secret_versions (
+    id UUID PRIMARY KEY,
+    secret_id UUID NOT NULL REFERENCES secrets(id) ON DELETE CASCADE,
+    version INT NOT NULL CHECK (version > 0),
+    encrypted_value TEXT NOT NULL,
+    stages TEXT[] NOT NULL DEFAULT '{}',
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT secret_versions_secret_version_key UNIQUE (secret_id, version)
+);
+
+CREATE INDEX IF NOT EXISTS idx_secret_versions_stages ON secret_versions USING GIN (stages);
+
+ALTER TABLE secrets
+    ADD COLUMN IF NOT EXISTS current_version INT NOT NULL DEFAULT 1,
+    ADD COLUMN IF NOT EXISTS rotation_function_id UUID REFERENCES functions(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS rotation_interval_days INT CHECK (rotation_interval_days > 0),
+    ADD COLUMN IF NOT EXISTS last_rotated_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS next_rotation_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_secrets_next_rotation ON secrets(next_rotation_at) WHERE rotation_function_id IS NOT NULL;
+
+-- Existing values become version 1.
+INSERT INTO secret_versions (id, secret_id, version, encrypted_value, stages, created_by, created_at)
+SELECT gen_random_uuid(), id, 1, encrypted_value, ARRAY['current'], user_id, created_at FROM secrets
+ON CONFLICT (secret_id, version) DO NOTHING;
@@ -0,0 +1,6 @@
+-- +goose Down
+
+DROP INDEX IF EXISTS idx_security_rules_source_group;
+ALTER TABLE security_rules
+    ALTER COLUMN cidr DROP DEFAULT,
+    DROP COLUMN IF EXISTS source_group_id;
@@ -0,0 +1,9 @@
+-- +goose Up
+
+-- Rules reference either a CIDR or the members of another group. Deleting a
+-- referenced group is blocked until the referencing rules are removed.
+ALTER TABLE security_rules
+    ADD COLUMN IF NOT EXISTS source_group_id UUID REFERENCES security_groups(id),
+    ALTER COLUMN cidr SET DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_security_rules_source_group ON security_rules(source_group_id) WHERE source_group_id IS NOT NULL;
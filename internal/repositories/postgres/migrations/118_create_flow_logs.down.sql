@@ -0,0 +1,4 @@
+-- +goose Down
+DELETE FROM role_permissions WHERE permission IN ('flow_log:create', 'flow_log:read', 'flow_log:delete') AND role_id = (SELECT id FROM roles WHERE name = 'developer');
+
+DROP TABLE IF EXISTS flow_logs;
@@ -0,0 +1,24 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS flow_logs (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    resource_type VARCHAR(20) NOT NULL,
+    resource_id UUID NOT NULL,
+    vpc_id UUID NOT NULL REFERENCES vpcs(id) ON DELETE CASCADE,
+    traffic_type VARCHAR(10) NOT NULL DEFAULT 'ALL',
+    destination VARCHAR(20) NOT NULL,
+    bucket VARCHAR(255) NOT NULL DEFAULT '',
+    interval_seconds INT NOT NULL DEFAULT 600 CHECK (interval_seconds IN (60, 600)),
+    status VARCHAR(20) NOT NULL DEFAULT 'ACTIVE',
+    last_error TEXT NOT NULL DEFAULT '',
+    last_captured_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_flow_logs_tenant ON flow_logs(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_flow_logs_resource ON flow_logs(resource_id);
+
+INSERT INTO role_permissions (role_id, permission)
+SELECT id, p FROM roles, (VALUES ('flow_log:create'), ('flow_log:read'), ('flow_log:delete')) AS perms(p) WHERE name = 'developer'
+ON CONFLICT (role_id, permission) DO NOTHING;
@@ -0,0 +1,46 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS network_acls (
+    id UUID PRIMARY KEY,
+    vpc_id UUID NOT NULL REFERENCES vpcs(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(vpc_id, name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_network_acls_vpc ON network_acls(vpc_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_network_acls_default ON network_acls(vpc_id) WHERE is_default;
+
+CREATE TABLE IF NOT EXISTS network_acl_rules (
+    id UUID PRIMARY KEY,
+    acl_id UUID NOT NULL REFERENCES network_acls(id) ON DELETE CASCADE,
+    rule_number INT NOT NULL CHECK (rule_number BETWEEN 1 AND 32766),
+    direction VARCHAR(10) NOT NULL,
+    protocol VARCHAR(10) NOT NULL,
+    port_min INT NOT NULL DEFAULT 0,
+    port_max INT NOT NULL DEFAULT 0,
+    cidr CIDR NOT NULL,
+    action VARCHAR(10) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(acl_id, direction, rule_number)
+);
+
+CREATE TABLE IF NOT EXISTS network_acl_associations (
+    id UUID PRIMARY KEY,
+    acl_id UUID NOT NULL REFERENCES network_acls(id) ON DELETE CASCADE,
+    subnet_id UUID NOT NULL UNIQUE REFERENCES subnets(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_network_acl_assoc_acl ON network_acl_associations(acl_id);
+
+-- Existing VPCs get an allow-all default ACL so their subnets behave as before.
+INSERT INTO network_acls (id, vpc_id, name, is_default)
+SELECT uuid_generate_v4(), id, 'default', TRUE FROM vpcs
+ON CONFLICT DO NOTHING;
+
+INSERT INTO network_acl_rules (id, acl_id, rule_number, direction, protocol, cidr, action)
+SELECT uuid_generate_v4(), a.id, 100, d.direction, 'all', '0.0.0.0/0', 'allow'
+FROM network_acls a, (VALUES ('ingress'), ('egress')) AS d(direction)
+WHERE a.is_default
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,5 @@
+-- +goose Down
+DELETE FROM routes WHERE target_type = 'vpn';
+DROP TABLE IF EXISTS vpn_connections;
+DROP TABLE IF EXISTS customer_gateways;
+DROP TABLE IF EXISTS vpn_gateways;
@@ -0,0 +1,46 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS vpn_gateways (
+    id UUID PRIMARY KEY,
+    vpc_id UUID NOT NULL REFERENCES vpcs(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id),
+    tenant_id UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    public_key VARCHAR(64) NOT NULL,
+    private_key_secret_id UUID NOT NULL,
+    container_id VARCHAR(255) NOT NULL DEFAULT '',
+    private_ip INET,
+    port INT NOT NULL DEFAULT 0,
+    arn VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(vpc_id, name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_vpn_gateways_vpc ON vpn_gateways(vpc_id);
+
+CREATE TABLE IF NOT EXISTS customer_gateways (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id),
+    tenant_id UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    ip_address INET NOT NULL,
+    public_key VARCHAR(64) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(tenant_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS vpn_connections (
+    id UUID PRIMARY KEY,
+    vpn_gateway_id UUID NOT NULL REFERENCES vpn_gateways(id),
+    customer_gateway_id UUID NOT NULL REFERENCES customer_gateways(id),
+    user_id UUID NOT NULL REFERENCES users(id),
+    tenant_id UUID NOT NULL,
+    remote_cidrs TEXT[] NOT NULL,
+    pre_shared_key_secret_id UUID NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(vpn_gateway_id, customer_gateway_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_vpn_connections_gateway ON vpn_connections(vpn_gateway_id);
+CREATE INDEX IF NOT EXISTS idx_vpn_connections_customer_gateway ON vpn_connections(customer_gateway_id);
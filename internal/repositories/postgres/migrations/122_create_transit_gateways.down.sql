@@ -0,0 +1,7 @@
+-- +goose Down
+DELETE FROM routes WHERE target_type = 'tgw';
+DROP TABLE IF EXISTS transit_gateway_propagations;
+DROP TABLE IF EXISTS transit_gateway_routes;
+DROP TABLE IF EXISTS transit_gateway_attachments;
+DROP TABLE IF EXISTS transit_gateway_route_tables;
+DROP TABLE IF EXISTS transit_gateways;
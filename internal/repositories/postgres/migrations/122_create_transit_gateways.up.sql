@@ -0,0 +1,58 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS transit_gateways (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id),
+    tenant_id UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    bridge_name VARCHAR(64) NOT NULL,
+    vxlan_id INT NOT NULL,
+    default_route_table_id UUID,
+    arn VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(tenant_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS transit_gateway_route_tables (
+    id UUID PRIMARY KEY,
+    transit_gateway_id UUID NOT NULL REFERENCES transit_gateways(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(transit_gateway_id, name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tgw_route_tables_tgw ON transit_gateway_route_tables(transit_gateway_id);
+
+CREATE TABLE IF NOT EXISTS transit_gateway_attachments (
+    id UUID PRIMARY KEY,
+    transit_gateway_id UUID NOT NULL REFERENCES transit_gateways(id),
+    vpc_id UUID NOT NULL REFERENCES vpcs(id),
+    user_id UUID NOT NULL REFERENCES users(id),
+    tenant_id UUID NOT NULL,
+    route_table_id UUID REFERENCES transit_gateway_route_tables(id) ON DELETE SET NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(transit_gateway_id, vpc_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tgw_attachments_tgw ON transit_gateway_attachments(transit_gateway_id);
+CREATE INDEX IF NOT EXISTS idx_tgw_attachments_vpc ON transit_gateway_attachments(vpc_id);
+
+CREATE TABLE IF NOT EXISTS transit_gateway_routes (
+    id UUID PRIMARY KEY,
+    route_table_id UUID NOT NULL REFERENCES transit_gateway_route_tables(id) ON DELETE CASCADE,
+    destination_cidr CIDR NOT NULL,
+    attachment_id UUID REFERENCES transit_gateway_attachments(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL DEFAULT 'static',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_tgw_routes_table ON transit_gateway_routes(route_table_id);
+
+CREATE TABLE IF NOT EXISTS transit_gateway_propagations (
+    route_table_id UUID NOT NULL REFERENCES transit_gateway_route_tables(id) ON DELETE CASCADE,
+    attachment_id UUID NOT NULL REFERENCES transit_gateway_attachments(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (route_table_id, attachment_id)
+);
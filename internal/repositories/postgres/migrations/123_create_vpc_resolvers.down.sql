@@ -0,0 +1,3 @@
+-- +goose Down
+DROP TABLE IF EXISTS resolver_forwarding_rules;
+DROP TABLE IF EXISTS vpc_resolvers;
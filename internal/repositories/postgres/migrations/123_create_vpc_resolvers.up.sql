@@ -0,0 +1,26 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS vpc_resolvers (
+    id UUID PRIMARY KEY,
+    vpc_id UUID NOT NULL UNIQUE REFERENCES vpcs(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id),
+    tenant_id UUID NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    container_id VARCHAR(255) NOT NULL DEFAULT '',
+    private_ip INET,
+    arn VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_vpc_resolvers_tenant ON vpc_resolvers(tenant_id);
+
+CREATE TABLE IF NOT EXISTS resolver_forwarding_rules (
+    id UUID PRIMARY KEY,
+    resolver_id UUID NOT NULL REFERENCES vpc_resolvers(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL,
+    domain_name VARCHAR(253) NOT NULL,
+    target_ips TEXT[] NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE(resolver_id, domain_name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_resolver_forwarding_rules_resolver ON resolver_forwarding_rules(resolver_id);
@@ -0,0 +1,5 @@
+-- +goose Down
+DROP INDEX IF EXISTS idx_instances_private_ip;
+DROP TABLE IF EXISTS instance_metadata;
+ALTER TABLE vpcs DROP COLUMN IF EXISTS dhcp_options_id;
+DROP TABLE IF EXISTS dhcp_options_sets;
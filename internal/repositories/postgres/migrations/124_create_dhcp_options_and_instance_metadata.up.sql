@@ -0,0 +1,25 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS dhcp_options_sets (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id),
+    tenant_id UUID NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    domain_name VARCHAR(253) NOT NULL DEFAULT '',
+    domain_name_servers TEXT[] NOT NULL DEFAULT '{}',
+    ntp_servers TEXT[] NOT NULL DEFAULT '{}',
+    arn VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_dhcp_options_sets_tenant ON dhcp_options_sets(tenant_id);
+
+ALTER TABLE vpcs ADD COLUMN IF NOT EXISTS dhcp_options_id UUID REFERENCES dhcp_options_sets(id) ON DELETE RESTRICT;
+
+CREATE TABLE IF NOT EXISTS instance_metadata (
+    instance_id UUID PRIMARY KEY REFERENCES instances(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL,
+    user_data TEXT NOT NULL DEFAULT '',
+    service_account_id UUID REFERENCES service_accounts(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_instances_private_ip ON instances(private_ip);
@@ -0,0 +1,6 @@
+-- +goose Down
+DROP INDEX IF EXISTS idx_elastic_ips_interface_ip_unique;
+ALTER TABLE elastic_ips DROP COLUMN IF EXISTS private_ip;
+ALTER TABLE elastic_ips DROP COLUMN IF EXISTS network_interface_id;
+DROP TABLE IF EXISTS network_interface_security_groups;
+DROP TABLE IF EXISTS network_interfaces;
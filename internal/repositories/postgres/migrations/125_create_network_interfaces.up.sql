@@ -0,0 +1,39 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS network_interfaces (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id),
+    tenant_id UUID NOT NULL,
+    vpc_id UUID NOT NULL REFERENCES vpcs(id) ON DELETE RESTRICT,
+    subnet_id UUID NOT NULL REFERENCES subnets(id) ON DELETE RESTRICT,
+    description TEXT NOT NULL DEFAULT '',
+    private_ips TEXT[] NOT NULL,
+    mac_address VARCHAR(17) NOT NULL UNIQUE,
+    ovs_port VARCHAR(15) NOT NULL,
+    instance_id UUID REFERENCES instances(id) ON DELETE SET NULL,
+    device_index INT NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'available',
+    arn VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_network_interfaces_tenant ON network_interfaces(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_network_interfaces_subnet ON network_interfaces(subnet_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_network_interfaces_instance_device
+    ON network_interfaces(instance_id, device_index)
+    WHERE instance_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS network_interface_security_groups (
+    interface_id UUID NOT NULL REFERENCES network_interfaces(id) ON DELETE CASCADE,
+    group_id UUID NOT NULL REFERENCES security_groups(id) ON DELETE CASCADE,
+    PRIMARY KEY (interface_id, group_id)
+);
+
+-- Elastic IPs associated with an interface follow it between instances. An
+-- instance may then hold several Elastic IPs, one per interface address.
+ALTER TABLE elastic_ips ADD COLUMN IF NOT EXISTS network_interface_id UUID REFERENCES network_interfaces(id) ON DELETE RESTRICT;
+ALTER TABLE elastic_ips ADD COLUMN IF NOT EXISTS private_ip VARCHAR(45);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_elastic_ips_interface_ip_unique
+    ON elastic_ips(network_interface_id, private_ip)
+    WHERE network_interface_id IS NOT NULL;
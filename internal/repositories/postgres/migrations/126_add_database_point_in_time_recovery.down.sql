@@ -0,0 +1,4 @@
+-- +goose Down
+DROP TABLE IF EXISTS database_backups;
+ALTER TABLE databases DROP COLUMN IF EXISTS backup_bucket;
+ALTER TABLE databases DROP COLUMN IF EXISTS backup_retention_days;
@@ -0,0 +1,23 @@
+-- +goose Up
+ALTER TABLE databases ADD COLUMN IF NOT EXISTS backup_retention_days INT NOT NULL DEFAULT 0;
+ALTER TABLE databases ADD COLUMN IF NOT EXISTS backup_bucket VARCHAR(255);
+
+-- Base backups (volume snapshots) and the WAL/binlog files archived after
+-- them form the chain a point-in-time restore replays.
+CREATE TABLE IF NOT EXISTS database_backups (
+    id UUID PRIMARY KEY,
+    database_id UUID NOT NULL REFERENCES databases(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL,
+    kind VARCHAR(10) NOT NULL,
+    snapshot_id UUID REFERENCES snapshots(id) ON DELETE SET NULL,
+    file_name VARCHAR(255) NOT NULL DEFAULT '',
+    object_key TEXT NOT NULL DEFAULT '',
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    archived_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_database_backups_db_time ON database_backups(database_id, archived_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_database_backups_log_file
+    ON database_backups(database_id, file_name)
+    WHERE kind = 'LOG';
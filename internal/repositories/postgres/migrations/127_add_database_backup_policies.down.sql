@@ -0,0 +1,3 @@
+-- +goose Down
+DROP TABLE IF EXISTS database_automated_backups;
+DROP TABLE IF EXISTS database_backup_policies;
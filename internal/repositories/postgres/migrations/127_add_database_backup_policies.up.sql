@@ -0,0 +1,37 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS database_backup_policies (
+    database_id UUID PRIMARY KEY REFERENCES databases(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL,
+    method VARCHAR(10) NOT NULL,
+    window_start VARCHAR(5) NOT NULL,
+    window_minutes INT NOT NULL,
+    frequency_hours INT NOT NULL,
+    retention_count INT NOT NULL DEFAULT 0,
+    retention_days INT NOT NULL DEFAULT 0,
+    export_bucket VARCHAR(255) NOT NULL,
+    next_backup_at TIMESTAMPTZ NOT NULL,
+    last_backup_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_database_backup_policies_next ON database_backup_policies(next_backup_at);
+
+-- Exported objects outlive the database, so only the bookkeeping cascades.
+CREATE TABLE IF NOT EXISTS database_automated_backups (
+    id UUID PRIMARY KEY,
+    database_id UUID NOT NULL REFERENCES databases(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL,
+    method VARCHAR(10) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    snapshot_id UUID REFERENCES snapshots(id) ON DELETE SET NULL,
+    bucket VARCHAR(255) NOT NULL,
+    object_key TEXT NOT NULL DEFAULT '',
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    kms_key_id VARCHAR(255) NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT '',
+    started_at TIMESTAMPTZ NOT NULL,
+    completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_database_automated_backups_db ON database_automated_backups(database_id, started_at);
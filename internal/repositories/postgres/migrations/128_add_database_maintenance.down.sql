@@ -0,0 +1,5 @@
+-- +goose Down
+DROP TABLE IF EXISTS database_upgrades;
+ALTER TABLE databases DROP COLUMN IF EXISTS pending_parameters;
+ALTER TABLE databases DROP COLUMN IF EXISTS pending_version;
+ALTER TABLE databases DROP COLUMN IF EXISTS maintenance_window;
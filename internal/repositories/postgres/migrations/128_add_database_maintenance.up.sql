@@ -0,0 +1,20 @@
+-- +goose Up
+ALTER TABLE databases ADD COLUMN IF NOT EXISTS maintenance_window VARCHAR(9) NOT NULL DEFAULT 'sun:03:00';
+ALTER TABLE databases ADD COLUMN IF NOT EXISTS pending_version VARCHAR(20) NOT NULL DEFAULT '';
+ALTER TABLE databases ADD COLUMN IF NOT EXISTS pending_parameters JSONB;
+
+CREATE TABLE IF NOT EXISTS database_upgrades (
+    id UUID PRIMARY KEY,
+    database_id UUID NOT NULL REFERENCES databases(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL,
+    from_version VARCHAR(20) NOT NULL,
+    to_version VARCHAR(20) NOT NULL,
+    major BOOLEAN NOT NULL DEFAULT FALSE,
+    status VARCHAR(20) NOT NULL,
+    snapshot_id UUID REFERENCES snapshots(id) ON DELETE SET NULL,
+    error TEXT NOT NULL DEFAULT '',
+    started_at TIMESTAMPTZ NOT NULL,
+    completed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_database_upgrades_db ON database_upgrades(database_id, started_at);
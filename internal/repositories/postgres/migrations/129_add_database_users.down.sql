@@ -0,0 +1,3 @@
+-- +goose Down
+DROP TABLE IF EXISTS database_users;
+DROP TABLE IF EXISTS database_logical_databases;
@@ -0,0 +1,22 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS database_logical_databases (
+    id UUID PRIMARY KEY,
+    database_id UUID NOT NULL REFERENCES databases(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL,
+    name VARCHAR(32) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    UNIQUE (database_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS database_users (
+    id UUID PRIMARY KEY,
+    database_id UUID NOT NULL REFERENCES databases(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL,
+    username VARCHAR(32) NOT NULL,
+    grants JSONB NOT NULL DEFAULT '[]',
+    credential_path VARCHAR(255) NOT NULL,
+    credential_version INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    UNIQUE (database_id, username)
+);
@@ -0,0 +1,3 @@
+-- +goose Down
+DROP TABLE IF EXISTS database_top_queries;
+DROP TABLE IF EXISTS database_insight_samples;
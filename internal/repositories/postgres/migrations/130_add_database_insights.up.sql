@@ -0,0 +1,22 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS database_insight_samples (
+    id UUID PRIMARY KEY,
+    database_id UUID NOT NULL REFERENCES databases(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL,
+    collected_at TIMESTAMPTZ NOT NULL,
+    connections INT NOT NULL DEFAULT 0,
+    max_connections INT NOT NULL DEFAULT 0,
+    cache_hit_ratio DOUBLE PRECISION NOT NULL DEFAULT 0,
+    replica_lag JSONB NOT NULL DEFAULT '[]'
+);
+
+CREATE INDEX IF NOT EXISTS idx_database_insight_samples_db_time ON database_insight_samples(database_id, collected_at DESC);
+CREATE INDEX IF NOT EXISTS idx_database_insight_samples_collected_at ON database_insight_samples(collected_at);
+
+-- Only the latest top queries are kept; their counters are cumulative anyway.
+CREATE TABLE IF NOT EXISTS database_top_queries (
+    database_id UUID PRIMARY KEY REFERENCES databases(id) ON DELETE CASCADE,
+    tenant_id UUID NOT NULL,
+    queries JSONB NOT NULL DEFAULT '[]',
+    collected_at TIMESTAMPTZ NOT NULL
+);
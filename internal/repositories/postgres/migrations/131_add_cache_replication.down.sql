@@ -0,0 +1,9 @@
+-- +goose Down
+
+DROP TABLE IF EXISTS cache_nodes;
+
+ALTER TABLE caches DROP COLUMN IF EXISTS proxy_container_id;
+ALTER TABLE caches DROP COLUMN IF EXISTS reader_port;
+ALTER TABLE caches DROP COLUMN IF EXISTS failover_enabled;
+ALTER TABLE caches DROP COLUMN IF EXISTS replicas;
+ALTER TABLE caches DROP COLUMN IF EXISTS persistence;
@@ -0,0 +1,26 @@
+-- +goose Up
+
+ALTER TABLE caches ADD COLUMN IF NOT EXISTS persistence VARCHAR(10) NOT NULL DEFAULT 'none';
+ALTER TABLE caches ADD COLUMN IF NOT EXISTS replicas INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE caches ADD COLUMN IF NOT EXISTS failover_enabled BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE caches ADD COLUMN IF NOT EXISTS reader_port INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE caches ADD COLUMN IF NOT EXISTS proxy_container_id VARCHAR(255) NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS cache_nodes (
+    id UUID PRIMARY KEY,
+    cache_id UUID NOT NULL REFERENCES caches(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    container_id VARCHAR(255) NOT NULL DEFAULT '',
+    volume_id UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_cache_nodes_cache_id ON cache_nodes(cache_id);
+
+-- Existing caches run a single container, which becomes their primary node.
+INSERT INTO cache_nodes (id, cache_id, role, status, container_id, created_at, updated_at)
+SELECT gen_random_uuid(), id, 'PRIMARY', status, container_id, created_at, updated_at
+FROM caches
+WHERE container_id IS NOT NULL AND container_id <> '';
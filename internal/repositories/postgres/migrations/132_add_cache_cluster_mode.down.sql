@@ -0,0 +1,6 @@
+-- +goose Down
+
+ALTER TABLE cache_nodes DROP COLUMN IF EXISTS shard;
+
+ALTER TABLE caches DROP COLUMN IF EXISTS shards;
+ALTER TABLE caches DROP COLUMN IF EXISTS cluster_mode;
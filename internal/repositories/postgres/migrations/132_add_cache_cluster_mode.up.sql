@@ -0,0 +1,6 @@
+-- +goose Up
+
+ALTER TABLE caches ADD COLUMN IF NOT EXISTS cluster_mode BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE caches ADD COLUMN IF NOT EXISTS shards INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE cache_nodes ADD COLUMN IF NOT EXISTS shard INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,4 @@
+-- +goose Down
+
+ALTER TABLE cluster_node_groups DROP COLUMN IF EXISTS scale_down_unneeded_seconds;
+ALTER TABLE cluster_node_groups DROP COLUMN IF EXISTS scale_down_utilization_threshold;
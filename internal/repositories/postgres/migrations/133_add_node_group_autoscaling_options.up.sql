@@ -0,0 +1,4 @@
+-- +goose Up
+
+ALTER TABLE cluster_node_groups ADD COLUMN IF NOT EXISTS scale_down_utilization_threshold DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE cluster_node_groups ADD COLUMN IF NOT EXISTS scale_down_unneeded_seconds INTEGER NOT NULL DEFAULT 0;
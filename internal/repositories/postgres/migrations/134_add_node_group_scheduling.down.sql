@@ -0,0 +1,5 @@
+-- +goose Down
+
+ALTER TABLE cluster_node_groups DROP COLUMN IF EXISTS capacity_type;
+ALTER TABLE cluster_node_groups DROP COLUMN IF EXISTS taints;
+ALTER TABLE cluster_node_groups DROP COLUMN IF EXISTS labels;
@@ -0,0 +1,5 @@
+-- +goose Up
+
+ALTER TABLE cluster_node_groups ADD COLUMN IF NOT EXISTS labels JSONB;
+ALTER TABLE cluster_node_groups ADD COLUMN IF NOT EXISTS taints JSONB;
+ALTER TABLE cluster_node_groups ADD COLUMN IF NOT EXISTS capacity_type VARCHAR(20) NOT NULL DEFAULT 'on-demand';
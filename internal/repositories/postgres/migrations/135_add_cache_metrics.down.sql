@@ -0,0 +1,5 @@
+-- +goose Down
+
+ALTER TABLE caches DROP COLUMN IF EXISTS exporter_container_id;
+ALTER TABLE caches DROP COLUMN IF EXISTS metrics_port;
+ALTER TABLE caches DROP COLUMN IF EXISTS metrics_enabled;
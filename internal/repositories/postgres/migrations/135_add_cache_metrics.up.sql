@@ -0,0 +1,5 @@
+-- +goose Up
+
+ALTER TABLE caches ADD COLUMN IF NOT EXISTS metrics_enabled BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE caches ADD COLUMN IF NOT EXISTS metrics_port INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE caches ADD COLUMN IF NOT EXISTS exporter_container_id VARCHAR(255) NOT NULL DEFAULT '';
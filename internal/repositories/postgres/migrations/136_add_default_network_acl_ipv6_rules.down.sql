@@ -0,0 +1,4 @@
+-- +goose Down
+DELETE FROM network_acl_rules r
+USING network_acls a
+WHERE r.acl_id = a.id AND a.is_default AND r.rule_number = 101 AND r.cidr = '::/0'::cidr;
@@ -0,0 +1,9 @@
+-- +goose Up
+
+-- Network ACLs now filter IPv6 too; default ACLs get IPv6 allow-all rules so dual-stack
+-- subnets behave as before.
+INSERT INTO network_acl_rules (id, acl_id, rule_number, direction, protocol, cidr, action)
+SELECT uuid_generate_v4(), a.id, 101, d.direction, 'all', '::/0', 'allow'
+FROM network_acls a, (VALUES ('ingress'), ('egress')) AS d(direction)
+WHERE a.is_default
+ON CONFLICT DO NOTHING;
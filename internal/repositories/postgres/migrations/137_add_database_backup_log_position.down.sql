@@ -0,0 +1,2 @@
+-- +goose Down
+ALTER TABLE database_backups DROP COLUMN IF EXISTS log_position;
@@ -0,0 +1,4 @@
+-- +goose Up
+-- A MySQL or MariaDB base backup records the binary log position its snapshot
+-- ends at (in file_name and log_position) so a restore replays from there.
+ALTER TABLE database_backups ADD COLUMN IF NOT EXISTS log_position BIGINT NOT NULL DEFAULT 0;
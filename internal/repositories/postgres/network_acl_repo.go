@@ -0,0 +1,315 @@
+package postgres
+
+import (
+	"context"
+	stdlib_errors "errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/poyrazk/thecloud/internal/errors"
+)
+
+// NetworkACLRepository provides PostgreSQL-backed persistence for network ACLs.
+// ACLs carry no tenant of their own; access is scoped through the owning VPC.
+type NetworkACLRepository struct {
+	db DB
+}
+
+// NewNetworkACLRepository creates a NetworkACLRepository using the provided DB.
+func NewNetworkACLRepository(db DB) *NetworkACLRepository {
+	return &NetworkACLRepository{db: db}
+}
+
+const networkACLColumns = `a.id, a.vpc_id, a.name, a.is_default, a.created_at`
+
+// Create inserts an ACL and its initial rules in one transaction.
+func (r *NetworkACLRepository) Create(ctx context.Context, acl *domain.NetworkACL) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to begin transaction", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO network_acls (id, vpc_id, name, is_default, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`, acl.ID, acl.VPCID, acl.Name, acl.IsDefault, acl.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if stdlib_errors.As(err, &pgErr) && pgErr.Code == uniqueViolationSQLState {
+			return errors.Wrap(errors.Conflict, "network ACL already exists", err)
+		}
+		return errors.Wrap(errors.Internal, "failed to create network ACL", err)
+	}
+
+	for _, rule := range acl.Rules {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO network_acl_rules (id, acl_id, rule_number, direction, protocol, port_min, port_max, cidr, action, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		`, rule.ID, acl.ID, rule.RuleNumber, rule.Direction, rule.Protocol, rule.PortMin, rule.PortMax, rule.CIDR, rule.Action, rule.CreatedAt); err != nil {
+			return errors.Wrap(errors.Internal, "failed to create network ACL rule", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return errors.Wrap(errors.Internal, "failed to commit network ACL", err)
+	}
+	return nil
+}
+
+// GetByID retrieves an ACL with its rules and subnet associations.
+func (r *NetworkACLRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.NetworkACL, error) {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `
+		SELECT ` + networkACLColumns + `
+		FROM network_acls a
+		JOIN vpcs v ON a.vpc_id = v.id
+		WHERE a.id = $1 AND v.tenant_id = $2
+	`
+	acl, err := r.scanNetworkACL(r.db.QueryRow(ctx, query, id, tenantID))
+	if err != nil {
+		return nil, err
+	}
+
+	if acl.Rules, err = r.ListRules(ctx, id); err != nil {
+		return nil, err
+	}
+	if acl.Associations, err = r.listAssociations(ctx, id); err != nil {
+		return nil, err
+	}
+	return acl, nil
+}
+
+// ListByVPC returns a VPC's ACLs, default first.
+func (r *NetworkACLRepository) ListByVPC(ctx context.Context, vpcID uuid.UUID) ([]*domain.NetworkACL, error) {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `
+		SELECT ` + networkACLColumns + `
+		FROM network_acls a
+		JOIN vpcs v ON a.vpc_id = v.id
+		WHERE a.vpc_id = $1 AND v.tenant_id = $2
+		ORDER BY a.is_default DESC, a.created_at ASC
+	`
+	rows, err := r.db.Query(ctx, query, vpcID, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list network ACLs", err)
+	}
+	defer rows.Close()
+
+	var acls []*domain.NetworkACL
+	for rows.Next() {
+		acl, err := r.scanNetworkACL(rows)
+		if err != nil {
+			return nil, err
+		}
+		acls = append(acls, acl)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to iterate network ACLs", err)
+	}
+	return acls, nil
+}
+
+// GetDefaultByVPC returns the VPC's default ACL with its rules.
+func (r *NetworkACLRepository) GetDefaultByVPC(ctx context.Context, vpcID uuid.UUID) (*domain.NetworkACL, error) {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `
+		SELECT ` + networkACLColumns + `
+		FROM network_acls a
+		JOIN vpcs v ON a.vpc_id = v.id
+		WHERE a.vpc_id = $1 AND a.is_default = TRUE AND v.tenant_id = $2
+	`
+	acl, err := r.scanNetworkACL(r.db.QueryRow(ctx, query, vpcID, tenantID))
+	if err != nil {
+		return nil, err
+	}
+	if acl.Rules, err = r.ListRules(ctx, acl.ID); err != nil {
+		return nil, err
+	}
+	return acl, nil
+}
+
+// Delete removes a custom ACL. Associations cascade, returning subnets to the default ACL.
+func (r *NetworkACLRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `
+		DELETE FROM network_acls a
+		USING vpcs v
+		WHERE a.vpc_id = v.id AND a.id = $1 AND v.tenant_id = $2 AND a.is_default = FALSE
+	`
+	cmd, err := r.db.Exec(ctx, query, id, tenantID)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to delete network ACL", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, "network ACL not found or is a default ACL")
+	}
+	return nil
+}
+
+// AddRule inserts a rule into an ACL owned by the caller's tenant.
+func (r *NetworkACLRepository) AddRule(ctx context.Context, rule *domain.NetworkACLRule) error {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `
+		INSERT INTO network_acl_rules (id, acl_id, rule_number, direction, protocol, port_min, port_max, cidr, action, created_at)
+		SELECT $1, a.id, $3, $4, $5, $6, $7, $8, $9, $10
+		FROM network_acls a
+		JOIN vpcs v ON a.vpc_id = v.id
+		WHERE a.id = $2 AND v.tenant_id = $11
+	`
+	cmd, err := r.db.Exec(ctx, query, rule.ID, rule.ACLID, rule.RuleNumber, rule.Direction, rule.Protocol, rule.PortMin, rule.PortMax, rule.CIDR, rule.Action, rule.CreatedAt, tenantID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if stdlib_errors.As(err, &pgErr) && pgErr.Code == uniqueViolationSQLState {
+			return errors.Wrap(errors.Conflict, "rule number already used in this direction", err)
+		}
+		return errors.Wrap(errors.Internal, "failed to add network ACL rule", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, "network ACL not found")
+	}
+	return nil
+}
+
+// RemoveRule deletes a rule from an ACL owned by the caller's tenant.
+func (r *NetworkACLRepository) RemoveRule(ctx context.Context, aclID, ruleID uuid.UUID) error {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `
+		DELETE FROM network_acl_rules nr
+		USING network_acls a
+		JOIN vpcs v ON a.vpc_id = v.id
+		WHERE nr.acl_id = a.id AND nr.id = $1 AND a.id = $2 AND v.tenant_id = $3
+	`
+	cmd, err := r.db.Exec(ctx, query, ruleID, aclID, tenantID)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to remove network ACL rule", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, "network ACL rule not found")
+	}
+	return nil
+}
+
+// ListRules returns an ACL's rules in evaluation order.
+func (r *NetworkACLRepository) ListRules(ctx context.Context, aclID uuid.UUID) ([]domain.NetworkACLRule, error) {
+	query := `
+		SELECT id, acl_id, rule_number, direction, protocol, port_min, port_max, cidr::text, action, created_at
+		FROM network_acl_rules
+		WHERE acl_id = $1
+		ORDER BY direction, rule_number
+	`
+	rows, err := r.db.Query(ctx, query, aclID)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list network ACL rules", err)
+	}
+	defer rows.Close()
+
+	var rules []domain.NetworkACLRule
+	for rows.Next() {
+		var rule domain.NetworkACLRule
+		if err := rows.Scan(&rule.ID, &rule.ACLID, &rule.RuleNumber, &rule.Direction, &rule.Protocol, &rule.PortMin, &rule.PortMax, &rule.CIDR, &rule.Action, &rule.CreatedAt); err != nil {
+			return nil, errors.Wrap(errors.Internal, "failed to scan network ACL rule", err)
+		}
+		rules = append(rules, rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to iterate network ACL rules", err)
+	}
+	return rules, nil
+}
+
+// AssociateSubnet points a subnet at an ACL, replacing its previous association.
+func (r *NetworkACLRepository) AssociateSubnet(ctx context.Context, aclID, subnetID uuid.UUID) error {
+	query := `
+		INSERT INTO network_acl_associations (id, acl_id, subnet_id, created_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (subnet_id) DO UPDATE SET acl_id = EXCLUDED.acl_id, created_at = EXCLUDED.created_at
+	`
+	if _, err := r.db.Exec(ctx, query, uuid.New(), aclID, subnetID); err != nil {
+		return errors.Wrap(errors.Internal, "failed to associate subnet", err)
+	}
+	return nil
+}
+
+// DisassociateSubnet removes a subnet's explicit association.
+func (r *NetworkACLRepository) DisassociateSubnet(ctx context.Context, subnetID uuid.UUID) error {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `
+		DELETE FROM network_acl_associations aa
+		USING network_acls a
+		JOIN vpcs v ON a.vpc_id = v.id
+		WHERE aa.acl_id = a.id AND aa.subnet_id = $1 AND v.tenant_id = $2
+	`
+	if _, err := r.db.Exec(ctx, query, subnetID, tenantID); err != nil {
+		return errors.Wrap(errors.Internal, "failed to disassociate subnet", err)
+	}
+	return nil
+}
+
+// ListSubnetBindings pairs every subnet in a VPC with its associated ACL, or the default ACL.
+func (r *NetworkACLRepository) ListSubnetBindings(ctx context.Context, vpcID uuid.UUID) ([]ports.SubnetACLBinding, error) {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `
+		SELECT s.id, s.cidr_block::text, COALESCE(s.ipv6_cidr_block::text, ''), COALESCE(aa.acl_id, d.id)
+		FROM subnets s
+		JOIN vpcs v ON s.vpc_id = v.id
+		LEFT JOIN network_acl_associations aa ON aa.subnet_id = s.id
+		LEFT JOIN network_acls d ON d.vpc_id = s.vpc_id AND d.is_default = TRUE
+		WHERE s.vpc_id = $1 AND v.tenant_id = $2 AND COALESCE(aa.acl_id, d.id) IS NOT NULL
+		ORDER BY s.created_at
+	`
+	rows, err := r.db.Query(ctx, query, vpcID, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list subnet ACL bindings", err)
+	}
+	defer rows.Close()
+
+	var bindings []ports.SubnetACLBinding
+	for rows.Next() {
+		var b ports.SubnetACLBinding
+		if err := rows.Scan(&b.SubnetID, &b.CIDRBlock, &b.IPv6CIDRBlock, &b.ACLID); err != nil {
+			return nil, errors.Wrap(errors.Internal, "failed to scan subnet ACL binding", err)
+		}
+		bindings = append(bindings, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to iterate subnet ACL bindings", err)
+	}
+	return bindings, nil
+}
+
+func (r *NetworkACLRepository) listAssociations(ctx context.Context, aclID uuid.UUID) ([]domain.NetworkACLAssociation, error) {
+	rows, err := r.db.Query(ctx, `SELECT id, acl_id, subnet_id, created_at FROM network_acl_associations WHERE acl_id = $1 ORDER BY created_at`, aclID)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list network ACL associations", err)
+	}
+	defer rows.Close()
+
+	var assocs []domain.NetworkACLAssociation
+	for rows.Next() {
+		var a domain.NetworkACLAssociation
+		if err := rows.Scan(&a.ID, &a.ACLID, &a.SubnetID, &a.CreatedAt); err != nil {
+			return nil, errors.Wrap(errors.Internal, "failed to scan network ACL association", err)
+		}
+		assocs = append(assocs, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to iterate network ACL associations", err)
+	}
+	return assocs, nil
+}
+
+func (r *NetworkACLRepository) scanNetworkACL(row pgx.Row) (*domain.NetworkACL, error) {
+	var acl domain.NetworkACL
+	if err := row.Scan(&acl.ID, &acl.VPCID, &acl.Name, &acl.IsDefault, &acl.CreatedAt); err != nil {
+		if stdlib_errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New(errors.NotFound, "network ACL not found")
+		}
+		return nil, errors.Wrap(errors.Internal, "failed to scan network ACL", err)
+	}
+	return &acl, nil
+}
@@ -0,0 +1,177 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v3"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	theclouderrors "github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkACLRepository_Create(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewNetworkACLRepository(mock)
+	acl := &domain.NetworkACL{ID: uuid.New(), VPCID: uuid.New(), Name: "default", IsDefault: true, CreatedAt: time.Now()}
+	acl.Rules = domain.DefaultNetworkACLRules(acl.ID)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO network_acls").
+		WithArgs(acl.ID, acl.VPCID, acl.Name, acl.IsDefault, acl.CreatedAt).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	for _, rule := range acl.Rules {
+		mock.ExpectExec("INSERT INTO network_acl_rules").
+			WithArgs(rule.ID, acl.ID, rule.RuleNumber, rule.Direction, rule.Protocol, rule.PortMin, rule.PortMax, rule.CIDR, rule.Action, rule.CreatedAt).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	}
+	mock.ExpectCommit()
+
+	require.NoError(t, repo.Create(context.Background(), acl))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNetworkACLRepository_GetByID(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewNetworkACLRepository(mock)
+	id := uuid.New()
+	tenantID := uuid.New()
+	subnetID := uuid.New()
+
+	mock.ExpectQuery("SELECT a.id, a.vpc_id, a.name, a.is_default, a.created_at FROM network_acls a").
+		WithArgs(id, tenantID).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "vpc_id", "name", "is_default", "created_at"}).
+			AddRow(id, uuid.New(), "web", false, time.Now()))
+	mock.ExpectQuery("SELECT id, acl_id, rule_number, direction, protocol, port_min, port_max, cidr::text, action, created_at FROM network_acl_rules").
+		WithArgs(id).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "acl_id", "rule_number", "direction", "protocol", "port_min", "port_max", "cidr", "action", "created_at"}).
+			AddRow(uuid.New(), id, 100, domain.RuleIngress, "tcp", 443, 443, "0.0.0.0/0", domain.NetworkACLAllow, time.Now()))
+	mock.ExpectQuery("SELECT id, acl_id, subnet_id, created_at FROM network_acl_associations").
+		WithArgs(id).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "acl_id", "subnet_id", "created_at"}).
+			AddRow(uuid.New(), id, subnetID, time.Now()))
+
+	acl, err := repo.GetByID(appcontext.WithTenantID(context.Background(), tenantID), id)
+	require.NoError(t, err)
+	require.Len(t, acl.Rules, 1)
+	assert.Equal(t, 443, acl.Rules[0].PortMin)
+	require.Len(t, acl.Associations, 1)
+	assert.Equal(t, subnetID, acl.Associations[0].SubnetID)
+}
+
+func TestNetworkACLRepository_GetByIDNotFound(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewNetworkACLRepository(mock)
+	mock.ExpectQuery("FROM network_acls a").
+		WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg()).
+		WillReturnError(pgx.ErrNoRows)
+
+	_, err = repo.GetByID(context.Background(), uuid.New())
+	assert.True(t, theclouderrors.Is(err, theclouderrors.NotFound))
+}
+
+func TestNetworkACLRepository_AddRule(t *testing.T) {
+	t.Parallel()
+	rule := &domain.NetworkACLRule{
+		ID: uuid.New(), ACLID: uuid.New(), RuleNumber: 110, Direction: domain.RuleEgress,
+		Protocol: "udp", PortMin: 53, PortMax: 53, CIDR: "10.0.0.0/16", Action: domain.NetworkACLDeny, CreatedAt: time.Now(),
+	}
+	tenantID := uuid.New()
+	args := []interface{}{rule.ID, rule.ACLID, rule.RuleNumber, rule.Direction, rule.Protocol, rule.PortMin, rule.PortMax, rule.CIDR, rule.Action, rule.CreatedAt, tenantID}
+
+	t.Run("inserted", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectExec("INSERT INTO network_acl_rules").WithArgs(args...).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		require.NoError(t, NewNetworkACLRepository(mock).AddRule(appcontext.WithTenantID(context.Background(), tenantID), rule))
+	})
+
+	t.Run("acl not found", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectExec("INSERT INTO network_acl_rules").WithArgs(args...).WillReturnResult(pgxmock.NewResult("INSERT", 0))
+		err = NewNetworkACLRepository(mock).AddRule(appcontext.WithTenantID(context.Background(), tenantID), rule)
+		assert.True(t, theclouderrors.Is(err, theclouderrors.NotFound))
+	})
+
+	t.Run("duplicate rule number", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectExec("INSERT INTO network_acl_rules").WithArgs(args...).WillReturnError(&pgconn.PgError{Code: "23505"})
+		err = NewNetworkACLRepository(mock).AddRule(appcontext.WithTenantID(context.Background(), tenantID), rule)
+		assert.True(t, theclouderrors.Is(err, theclouderrors.Conflict))
+	})
+}
+
+func TestNetworkACLRepository_DeleteDefault(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectExec("DELETE FROM network_acls").
+		WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	err = NewNetworkACLRepository(mock).Delete(context.Background(), uuid.New())
+	assert.True(t, theclouderrors.Is(err, theclouderrors.NotFound))
+}
+
+func TestNetworkACLRepository_AssociateSubnet(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	aclID, subnetID := uuid.New(), uuid.New()
+	mock.ExpectExec("INSERT INTO network_acl_associations .* ON CONFLICT \\(subnet_id\\) DO UPDATE").
+		WithArgs(pgxmock.AnyArg(), aclID, subnetID).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	require.NoError(t, NewNetworkACLRepository(mock).AssociateSubnet(context.Background(), aclID, subnetID))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNetworkACLRepository_ListSubnetBindings(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	vpcID, tenantID := uuid.New(), uuid.New()
+	subnetID, aclID := uuid.New(), uuid.New()
+	mock.ExpectQuery("COALESCE\\(aa.acl_id, d.id\\)").
+		WithArgs(vpcID, tenantID).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "cidr_block", "ipv6_cidr_block", "acl_id"}).AddRow(subnetID, "10.0.1.0/24", "fd12:3456:7800::/64", aclID))
+
+	bindings, err := NewNetworkACLRepository(mock).ListSubnetBindings(appcontext.WithTenantID(context.Background(), tenantID), vpcID)
+	require.NoError(t, err)
+	require.Len(t, bindings, 1)
+	assert.Equal(t, "10.0.1.0/24", bindings[0].CIDRBlock)
+	assert.Equal(t, "fd12:3456:7800::/64", bindings[0].IPv6CIDRBlock)
+	assert.Equal(t, aclID, bindings[0].ACLID)
+}
@@ -0,0 +1,149 @@
+package postgres
+
+import (
+	"context"
+	stdlib_errors "errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/errors"
+)
+
+const networkInterfaceColumns = `n.id, n.user_id, n.tenant_id, n.vpc_id, n.subnet_id, n.description, n.private_ips,
+	ARRAY(SELECT g.group_id FROM network_interface_security_groups g WHERE g.interface_id = n.id ORDER BY g.group_id),
+	n.mac_address, n.ovs_port, n.instance_id, n.device_index, n.status, n.arn, n.created_at, n.updated_at`
+
+// NetworkInterfaceRepository provides PostgreSQL-backed persistence for elastic network interfaces.
+type NetworkInterfaceRepository struct {
+	db DB
+}
+
+// NewNetworkInterfaceRepository creates a NetworkInterfaceRepository using the provided DB.
+func NewNetworkInterfaceRepository(db DB) *NetworkInterfaceRepository {
+	return &NetworkInterfaceRepository{db: db}
+}
+
+// Create inserts a new network interface. Security group memberships are
+// managed by the security group repository.
+func (r *NetworkInterfaceRepository) Create(ctx context.Context, ni *domain.NetworkInterface) error {
+	query := `
+		INSERT INTO network_interfaces (id, user_id, tenant_id, vpc_id, subnet_id, description, private_ips, mac_address, ovs_port, instance_id, device_index, status, arn, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+	`
+	_, err := r.db.Exec(ctx, query, ni.ID, ni.UserID, ni.TenantID, ni.VpcID, ni.SubnetID, ni.Description, nonNilStrings(ni.PrivateIPs),
+		ni.MACAddress, ni.OvsPort, ni.InstanceID, ni.DeviceIndex, ni.Status, ni.ARN, ni.CreatedAt, ni.UpdatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if stdlib_errors.As(err, &pgErr) && pgErr.Code == uniqueViolationSQLState {
+			return errors.Wrap(errors.Conflict, "network interface MAC address already in use", err)
+		}
+		return errors.Wrap(errors.Internal, "failed to create network interface", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a network interface by ID.
+func (r *NetworkInterfaceRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.NetworkInterface, error) {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `SELECT ` + networkInterfaceColumns + ` FROM network_interfaces n WHERE n.id = $1 AND n.tenant_id = $2`
+	return r.scanInterface(r.db.QueryRow(ctx, query, id, tenantID))
+}
+
+// List returns all network interfaces of the current tenant.
+func (r *NetworkInterfaceRepository) List(ctx context.Context) ([]*domain.NetworkInterface, error) {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `SELECT ` + networkInterfaceColumns + ` FROM network_interfaces n WHERE n.tenant_id = $1 ORDER BY n.created_at DESC`
+	return r.queryInterfaces(ctx, query, tenantID)
+}
+
+// ListByInstance returns the interfaces attached to an instance, ordered by device index.
+func (r *NetworkInterfaceRepository) ListByInstance(ctx context.Context, instanceID uuid.UUID) ([]*domain.NetworkInterface, error) {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `SELECT ` + networkInterfaceColumns + ` FROM network_interfaces n WHERE n.instance_id = $1 AND n.tenant_id = $2 ORDER BY n.device_index`
+	return r.queryInterfaces(ctx, query, instanceID, tenantID)
+}
+
+// ListBySubnet returns every interface in a subnet.
+func (r *NetworkInterfaceRepository) ListBySubnet(ctx context.Context, subnetID uuid.UUID) ([]*domain.NetworkInterface, error) {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `SELECT ` + networkInterfaceColumns + ` FROM network_interfaces n WHERE n.subnet_id = $1 AND n.tenant_id = $2`
+	return r.queryInterfaces(ctx, query, subnetID, tenantID)
+}
+
+// Update saves the mutable fields of a network interface.
+func (r *NetworkInterfaceRepository) Update(ctx context.Context, ni *domain.NetworkInterface) error {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `
+		UPDATE network_interfaces
+		SET description = $1, private_ips = $2, instance_id = $3, device_index = $4, status = $5, updated_at = $6
+		WHERE id = $7 AND tenant_id = $8
+	`
+	cmd, err := r.db.Exec(ctx, query, ni.Description, nonNilStrings(ni.PrivateIPs), ni.InstanceID, ni.DeviceIndex, ni.Status, ni.UpdatedAt, ni.ID, tenantID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if stdlib_errors.As(err, &pgErr) && pgErr.Code == uniqueViolationSQLState {
+			return errors.Wrap(errors.Conflict, "device index is already in use on the instance", err)
+		}
+		return errors.Wrap(errors.Internal, "failed to update network interface", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, "network interface not found")
+	}
+	return nil
+}
+
+// Delete removes a network interface that no Elastic IP is associated with.
+func (r *NetworkInterfaceRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	cmd, err := r.db.Exec(ctx, `DELETE FROM network_interfaces WHERE id = $1 AND tenant_id = $2`, id, tenantID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if stdlib_errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolationSQLState {
+			return errors.New(errors.Conflict, "network interface still has associated Elastic IPs")
+		}
+		return errors.Wrap(errors.Internal, "failed to delete network interface", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, "network interface not found")
+	}
+	return nil
+}
+
+func (r *NetworkInterfaceRepository) queryInterfaces(ctx context.Context, query string, args ...any) ([]*domain.NetworkInterface, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list network interfaces", err)
+	}
+	defer rows.Close()
+
+	var interfaces []*domain.NetworkInterface
+	for rows.Next() {
+		ni, err := r.scanInterface(rows)
+		if err != nil {
+			return nil, err
+		}
+		interfaces = append(interfaces, ni)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to iterate network interfaces", err)
+	}
+	return interfaces, nil
+}
+
+func (r *NetworkInterfaceRepository) scanInterface(row pgx.Row) (*domain.NetworkInterface, error) {
+	var ni domain.NetworkInterface
+	var status string
+	err := row.Scan(&ni.ID, &ni.UserID, &ni.TenantID, &ni.VpcID, &ni.SubnetID, &ni.Description, &ni.PrivateIPs, &ni.SecurityGroupIDs,
+		&ni.MACAddress, &ni.OvsPort, &ni.InstanceID, &ni.DeviceIndex, &status, &ni.ARN, &ni.CreatedAt, &ni.UpdatedAt)
+	if err != nil {
+		if stdlib_errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New(errors.NotFound, "network interface not found")
+		}
+		return nil, errors.Wrap(errors.Internal, "failed to scan network interface", err)
+	}
+	ni.Status = domain.NetworkInterfaceStatus(status)
+	return &ni, nil
+}
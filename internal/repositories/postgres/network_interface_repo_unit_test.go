@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v3"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	theclouderrors "github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var networkInterfaceTestColumns = []string{"id", "user_id", "tenant_id", "vpc_id", "subnet_id", "description", "private_ips", "security_group_ids",
+	"mac_address", "ovs_port", "instance_id", "device_index", "status", "arn", "created_at", "updated_at"}
+
+func TestNetworkInterfaceRepository_ListByInstance(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	tenantID, instanceID, sgID := uuid.New(), uuid.New(), uuid.New()
+	mock.ExpectQuery("FROM network_interfaces n WHERE n.instance_id = \\$1").
+		WithArgs(instanceID, tenantID).
+		WillReturnRows(pgxmock.NewRows(networkInterfaceTestColumns).
+			AddRow(uuid.New(), uuid.New(), tenantID, uuid.New(), uuid.New(), "", []string{"10.0.1.20", "10.0.1.21"}, []uuid.UUID{sgID},
+				"02:1a:2b:3c:4d:5e", "eni-1a2b3c4d", &instanceID, 1, "in-use", "arn", time.Now(), time.Now()))
+
+	interfaces, err := NewNetworkInterfaceRepository(mock).ListByInstance(appcontext.WithTenantID(context.Background(), tenantID), instanceID)
+	require.NoError(t, err)
+	require.Len(t, interfaces, 1)
+	assert.Equal(t, "10.0.1.20", interfaces[0].PrimaryIP())
+	assert.Equal(t, []uuid.UUID{sgID}, interfaces[0].SecurityGroupIDs)
+	assert.Equal(t, domain.NetworkInterfaceStatusInUse, interfaces[0].Status)
+	assert.Equal(t, "eth1", interfaces[0].DeviceName())
+}
+
+func TestNetworkInterfaceRepository_UpdateDeviceIndexTaken(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	ni := &domain.NetworkInterface{ID: uuid.New(), PrivateIPs: []string{"10.0.1.20"}, DeviceIndex: 1, Status: domain.NetworkInterfaceStatusInUse}
+	mock.ExpectExec("UPDATE network_interfaces").
+		WithArgs(ni.Description, ni.PrivateIPs, ni.InstanceID, ni.DeviceIndex, ni.Status, ni.UpdatedAt, ni.ID, uuid.Nil).
+		WillReturnError(&pgconn.PgError{Code: "23505"})
+
+	err = NewNetworkInterfaceRepository(mock).Update(context.Background(), ni)
+	assert.True(t, theclouderrors.Is(err, theclouderrors.Conflict))
+}
+
+func TestNetworkInterfaceRepository_DeleteWithElasticIP(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectExec("DELETE FROM network_interfaces").
+		WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg()).
+		WillReturnError(&pgconn.PgError{Code: "23503"})
+
+	err = NewNetworkInterfaceRepository(mock).Delete(context.Background(), uuid.New())
+	assert.True(t, theclouderrors.Is(err, theclouderrors.Conflict))
+}
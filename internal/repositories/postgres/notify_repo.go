@@ -41,6 +41,13 @@ func (r *PostgresNotifyRepository) GetTopicByID(ctx context.Context, id, userID
 	return r.scanTopic(r.db.QueryRow(ctx, query, id, userID))
 }
 
+// GetTopicByTenant retrieves a topic owned by any user of the tenant. Topics created
+// before tenants existed fall back to their owner's tenant.
+func (r *PostgresNotifyRepository) GetTopicByTenant(ctx context.Context, id, tenantID uuid.UUID) (*domain.Topic, error) {
+	query := `SELECT t.id, t.user_id, t.name, t.arn, t.created_at, t.updated_at FROM topics t LEFT JOIN users u ON u.id = t.user_id WHERE t.id = $1 AND COALESCE(t.tenant_id, u.tenant_id) = $2`
+	return r.scanTopic(r.db.QueryRow(ctx, query, id, tenantID))
+}
+
 func (r *PostgresNotifyRepository) GetTopicByName(ctx context.Context, name string, userID uuid.UUID) (*domain.Topic, error) {
 	query := `SELECT id, user_id, name, arn, created_at, updated_at FROM topics WHERE name = $1 AND user_id = $2`
 	return r.scanTopic(r.db.QueryRow(ctx, query, name, userID))
@@ -56,7 +63,7 @@ func (r *PostgresNotifyRepository) ListTopics(ctx context.Context, userID uuid.U
 }
 
 func (r *PostgresNotifyRepository) DeleteTopic(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM topics WHERE id = $1`
+	query := deleteWithResourcePolicies(domain.ResourcePolicyTopic, `SELECT id::text FROM topics WHERE id = $1`, `DELETE FROM topics WHERE id = $1`)
 	_, err := r.db.Exec(ctx, query, id)
 	return err
 }
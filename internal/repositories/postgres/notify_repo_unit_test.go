@@ -79,3 +79,44 @@ func TestNotifyRepository_ListSubscriptions(t *testing.T) {
 	assert.Len(t, subs, 1)
 	assert.Equal(t, domain.ProtocolWebhook, subs[0].Protocol)
 }
+
+func TestNotifyRepository_GetTopicByTenant(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewPostgresNotifyRepository(mock)
+	id := uuid.New()
+	tenantID := uuid.New()
+	ownerID := uuid.New()
+	now := time.Now()
+
+	mock.ExpectQuery("SELECT t.id, t.user_id, t.name, t.arn, t.created_at, t.updated_at FROM topics t").
+		WithArgs(id, tenantID).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "name", "arn", "created_at", "updated_at"}).
+			AddRow(id, ownerID, "shared-topic", "arn", now, now))
+
+	topic, err := repo.GetTopicByTenant(context.Background(), id, tenantID)
+	require.NoError(t, err)
+	assert.Equal(t, id, topic.ID)
+	assert.Equal(t, ownerID, topic.UserID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotifyRepository_DeleteTopic(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewPostgresNotifyRepository(mock)
+	id := uuid.New()
+
+	mock.ExpectExec("DELETE FROM resource_policies WHERE resource_type = 'topic' AND resource_id IN \\(SELECT id::text FROM topics WHERE id = \\$1\\)\\) DELETE FROM topics WHERE id = \\$1").
+		WithArgs(id).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+
+	require.NoError(t, repo.DeleteTopic(context.Background(), id))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,198 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	stdlib_errors "errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/errors"
+)
+
+const guardrailColumns = `g.id, g.organization_id, g.name, COALESCE(g.description, ''), g.statements, g.created_at, g.updated_at`
+
+// OrganizationRepo persists organizations and their guardrails in Postgres.
+type OrganizationRepo struct {
+	db DB
+}
+
+// NewOrganizationRepo constructs an OrganizationRepo.
+func NewOrganizationRepo(db DB) *OrganizationRepo {
+	return &OrganizationRepo{db: db}
+}
+
+func (r *OrganizationRepo) Create(ctx context.Context, org *domain.Organization) error {
+	query := `
+		INSERT INTO organizations (id, name, slug, owner_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err := r.db.Exec(ctx, query, org.ID, org.Name, org.Slug, org.OwnerID, org.CreatedAt, org.UpdatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if stdlib_errors.As(err, &pgErr) && pgErr.Code == uniqueViolationSQLState {
+			return errors.Wrap(errors.Conflict, "organization slug already exists", err)
+		}
+		return errors.Wrap(errors.Internal, "failed to create organization", err)
+	}
+	return nil
+}
+
+func (r *OrganizationRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Organization, error) {
+	query := `SELECT id, name, slug, owner_id, created_at, updated_at FROM organizations WHERE id = $1`
+	var o domain.Organization
+	err := r.db.QueryRow(ctx, query, id).Scan(&o.ID, &o.Name, &o.Slug, &o.OwnerID, &o.CreatedAt, &o.UpdatedAt)
+	if err != nil {
+		if stdlib_errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New(errors.NotFound, "organization not found")
+		}
+		return nil, errors.Wrap(errors.Internal, "failed to get organization", err)
+	}
+	return &o, nil
+}
+
+func (r *OrganizationRepo) ListByOwner(ctx context.Context, ownerID uuid.UUID) ([]*domain.Organization, error) {
+	query := `SELECT id, name, slug, owner_id, created_at, updated_at FROM organizations WHERE owner_id = $1 ORDER BY created_at`
+	rows, err := r.db.Query(ctx, query, ownerID)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list organizations", err)
+	}
+	defer rows.Close()
+
+	var orgs []*domain.Organization
+	for rows.Next() {
+		var o domain.Organization
+		if err := rows.Scan(&o.ID, &o.Name, &o.Slug, &o.OwnerID, &o.CreatedAt, &o.UpdatedAt); err != nil {
+			return nil, errors.Wrap(errors.Internal, "failed to scan organization", err)
+		}
+		orgs = append(orgs, &o)
+	}
+	return orgs, rows.Err()
+}
+
+func (r *OrganizationRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.Exec(ctx, "DELETE FROM organizations WHERE id = $1", id)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to delete organization", err)
+	}
+	if result.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, "organization not found")
+	}
+	return nil
+}
+
+func (r *OrganizationRepo) AddTenant(ctx context.Context, orgID, tenantID uuid.UUID) error {
+	_, err := r.db.Exec(ctx, "INSERT INTO organization_tenants (organization_id, tenant_id, added_at) VALUES ($1, $2, NOW())", orgID, tenantID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if stdlib_errors.As(err, &pgErr) && pgErr.Code == uniqueViolationSQLState {
+			return errors.Wrap(errors.Conflict, "tenant already belongs to an organization", err)
+		}
+		return errors.Wrap(errors.Internal, "failed to add tenant to organization", err)
+	}
+	return nil
+}
+
+func (r *OrganizationRepo) RemoveTenant(ctx context.Context, orgID, tenantID uuid.UUID) error {
+	result, err := r.db.Exec(ctx, "DELETE FROM organization_tenants WHERE organization_id = $1 AND tenant_id = $2", orgID, tenantID)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to remove tenant from organization", err)
+	}
+	if result.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, "tenant is not part of this organization")
+	}
+	return nil
+}
+
+func (r *OrganizationRepo) ListTenantIDs(ctx context.Context, orgID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := r.db.Query(ctx, "SELECT tenant_id FROM organization_tenants WHERE organization_id = $1 ORDER BY added_at", orgID)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list organization tenants", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, errors.Wrap(errors.Internal, "failed to scan organization tenant", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func (r *OrganizationRepo) CreateGuardrail(ctx context.Context, policy *domain.GuardrailPolicy) error {
+	statementsJSON, err := json.Marshal(policy.Statements)
+	if err != nil {
+		return fmt.Errorf("failed to marshal statements: %w", err)
+	}
+
+	query := `
+		INSERT INTO organization_guardrails (id, organization_id, name, description, statements, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err = r.db.Exec(ctx, query, policy.ID, policy.OrganizationID, policy.Name, policy.Description, statementsJSON, policy.CreatedAt, policy.UpdatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if stdlib_errors.As(err, &pgErr) && pgErr.Code == uniqueViolationSQLState {
+			return errors.Wrap(errors.Conflict, "guardrail with this name already exists", err)
+		}
+		return errors.Wrap(errors.Internal, "failed to create guardrail", err)
+	}
+	return nil
+}
+
+func (r *OrganizationRepo) ListGuardrails(ctx context.Context, orgID uuid.UUID) ([]*domain.GuardrailPolicy, error) {
+	query := `SELECT ` + guardrailColumns + ` FROM organization_guardrails g WHERE g.organization_id = $1 ORDER BY g.name`
+	rows, err := r.db.Query(ctx, query, orgID)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list guardrails", err)
+	}
+	return r.scanGuardrails(rows)
+}
+
+func (r *OrganizationRepo) DeleteGuardrail(ctx context.Context, orgID, policyID uuid.UUID) error {
+	result, err := r.db.Exec(ctx, "DELETE FROM organization_guardrails WHERE id = $1 AND organization_id = $2", policyID, orgID)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to delete guardrail", err)
+	}
+	if result.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, "guardrail not found")
+	}
+	return nil
+}
+
+func (r *OrganizationRepo) GetGuardrailsForTenant(ctx context.Context, tenantID uuid.UUID) ([]*domain.GuardrailPolicy, error) {
+	query := `
+		SELECT ` + guardrailColumns + `
+		FROM organization_guardrails g
+		JOIN organization_tenants ot ON ot.organization_id = g.organization_id
+		WHERE ot.tenant_id = $1
+	`
+	rows, err := r.db.Query(ctx, query, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to get guardrails for tenant", err)
+	}
+	return r.scanGuardrails(rows)
+}
+
+func (r *OrganizationRepo) scanGuardrails(rows pgx.Rows) ([]*domain.GuardrailPolicy, error) {
+	defer rows.Close()
+	var policies []*domain.GuardrailPolicy
+	for rows.Next() {
+		var p domain.GuardrailPolicy
+		var statementsJSON []byte
+		if err := rows.Scan(&p.ID, &p.OrganizationID, &p.Name, &p.Description, &statementsJSON, &p.CreatedAt, &p.UpdatedAt); err != nil {
+			return nil, errors.Wrap(errors.Internal, "failed to scan guardrail", err)
+		}
+		if err := json.Unmarshal(statementsJSON, &p.Statements); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal statements: %w", err)
+		}
+		policies = append(policies, &p)
+	}
+	return policies, rows.Err()
+}
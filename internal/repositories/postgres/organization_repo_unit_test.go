@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganizationRepositoryUnit(t *testing.T) {
+	t.Parallel()
+
+	guardrailColumnNames := []string{"id", "organization_id", "name", "description", "statements", "created_at", "updated_at"}
+	statements := []domain.Statement{{Effect: domain.EffectDeny, Action: []string{"instance:launch"}, Resource: []string{"*"}}}
+	statementsJSON, _ := json.Marshal(statements)
+
+	t.Run("Create_DuplicateSlug", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		repo := NewOrganizationRepo(mock)
+		org := &domain.Organization{ID: uuid.New(), Name: "Acme", Slug: "acme", OwnerID: uuid.New()}
+
+		mock.ExpectExec("INSERT INTO organizations").
+			WithArgs(org.ID, org.Name, org.Slug, org.OwnerID, org.CreatedAt, org.UpdatedAt).
+			WillReturnError(&pgconn.PgError{Code: uniqueViolationSQLState})
+
+		err = repo.Create(context.Background(), org)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.Conflict))
+	})
+
+	t.Run("GetByID_NotFound", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		repo := NewOrganizationRepo(mock)
+		id := uuid.New()
+		mock.ExpectQuery("SELECT id, name, slug, owner_id").WithArgs(id).WillReturnError(pgx.ErrNoRows)
+
+		_, err = repo.GetByID(context.Background(), id)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.NotFound))
+	})
+
+	t.Run("AddTenant_AlreadyInOrganization", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		repo := NewOrganizationRepo(mock)
+		orgID, tenantID := uuid.New(), uuid.New()
+		mock.ExpectExec("INSERT INTO organization_tenants").WithArgs(orgID, tenantID).
+			WillReturnError(&pgconn.PgError{Code: uniqueViolationSQLState})
+
+		err = repo.AddTenant(context.Background(), orgID, tenantID)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.Conflict))
+	})
+
+	t.Run("GetGuardrailsForTenant", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		repo := NewOrganizationRepo(mock)
+		tenantID, orgID := uuid.New(), uuid.New()
+		now := time.Now()
+		mock.ExpectQuery("FROM organization_guardrails g\\s+JOIN organization_tenants").WithArgs(tenantID).
+			WillReturnRows(pgxmock.NewRows(guardrailColumnNames).AddRow(uuid.New(), orgID, "no-launch", "", statementsJSON, now, now))
+
+		policies, err := repo.GetGuardrailsForTenant(context.Background(), tenantID)
+		require.NoError(t, err)
+		require.Len(t, policies, 1)
+		assert.Equal(t, orgID, policies[0].OrganizationID)
+		assert.Equal(t, statements, policies[0].Statements)
+	})
+
+	t.Run("DeleteGuardrail_NotFound", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		repo := NewOrganizationRepo(mock)
+		orgID, policyID := uuid.New(), uuid.New()
+		mock.ExpectExec("DELETE FROM organization_guardrails").WithArgs(policyID, orgID).
+			WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+		err = repo.DeleteGuardrail(context.Background(), orgID, policyID)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.NotFound))
+	})
+}
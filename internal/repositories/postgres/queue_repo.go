@@ -86,7 +86,10 @@ func (r *PostgresQueueRepository) scanQueues(rows pgx.Rows) ([]*domain.Queue, er
 
 // Delete removes a queue definition; note that dependent messages should be handled by DB constraints.
 func (r *PostgresQueueRepository) Delete(ctx context.Context, id, tenantID uuid.UUID) error {
-	cmd, err := r.db.Exec(ctx, "DELETE FROM queues WHERE id = $1 AND tenant_id = $2", id, tenantID)
+	query := deleteWithResourcePolicies(domain.ResourcePolicyQueue,
+		`SELECT id::text FROM queues WHERE id = $1 AND tenant_id = $2`,
+		`DELETE FROM queues WHERE id = $1 AND tenant_id = $2`)
+	cmd, err := r.db.Exec(ctx, query, id, tenantID)
 	if err != nil {
 		return err
 	}
@@ -132,7 +132,7 @@ func TestQueueRepositoryDelete(t *testing.T) {
 		id := uuid.New()
 		tenantID := uuid.New()
 
-		mock.ExpectExec("DELETE FROM queues").
+		mock.ExpectExec("DELETE FROM resource_policies WHERE resource_type = 'queue' .* DELETE FROM queues").
 			WithArgs(id, tenantID).
 			WillReturnResult(pgxmock.NewResult("DELETE", 1))
 
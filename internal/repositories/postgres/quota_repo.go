@@ -0,0 +1,313 @@
+package postgres
+
+import (
+	"context"
+	stdlib_errors "errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/errors"
+)
+
+const quotaRequestColumns = `id, tenant_id, requested_by, resource, current_limit, requested_limit, reason, status, reviewed_by, review_note, reviewed_at, created_at, updated_at`
+
+// actualUsageQueries measure each quota resource from the tables that hold the resources.
+// They must stay in line with how services increment and decrement usage.
+// Instances launched with raw options (instance_type 'custom', such as cluster
+// nodes) are not charged to quotas, so the instance queries join instance_types.
+var actualUsageQueries = map[domain.QuotaResource]string{
+	domain.QuotaInstances: `
+		SELECT i.tenant_id, COUNT(*) FROM instances i
+		JOIN instance_types t ON t.id = i.instance_type
+		WHERE i.tenant_id IS NOT NULL AND i.status != 'DELETED' GROUP BY i.tenant_id`,
+	domain.QuotaVCPUs: `
+		SELECT i.tenant_id, COALESCE(SUM(t.vcpus), 0) FROM instances i
+		JOIN instance_types t ON t.id = i.instance_type
+		WHERE i.tenant_id IS NOT NULL AND i.status != 'DELETED' GROUP BY i.tenant_id`,
+	domain.QuotaMemoryGB: `
+		SELECT i.tenant_id, COALESCE(SUM(t.memory_mb / 1024), 0) FROM instances i
+		JOIN instance_types t ON t.id = i.instance_type
+		WHERE i.tenant_id IS NOT NULL AND i.status != 'DELETED' GROUP BY i.tenant_id`,
+	domain.QuotaVPCs:          `SELECT tenant_id, COUNT(*) FROM vpcs WHERE tenant_id IS NOT NULL GROUP BY tenant_id`,
+	domain.QuotaStorageGB:     `SELECT tenant_id, COALESCE(SUM(size_gb), 0) FROM volumes WHERE tenant_id IS NOT NULL GROUP BY tenant_id`,
+	domain.QuotaDatabases:     `SELECT tenant_id, COUNT(*) FROM databases WHERE tenant_id IS NOT NULL GROUP BY tenant_id`,
+	domain.QuotaCaches:        `SELECT tenant_id, COUNT(*) FROM caches WHERE tenant_id IS NOT NULL GROUP BY tenant_id`,
+	domain.QuotaClusters:      `SELECT tenant_id, COUNT(*) FROM clusters WHERE tenant_id IS NOT NULL AND status != 'deleting' GROUP BY tenant_id`,
+	domain.QuotaLoadBalancers: `SELECT tenant_id, COUNT(*) FROM load_balancers WHERE tenant_id IS NOT NULL AND status != 'DELETED' GROUP BY tenant_id`,
+	domain.QuotaElasticIPs:    `SELECT tenant_id, COUNT(*) FROM elastic_ips WHERE tenant_id IS NOT NULL GROUP BY tenant_id`,
+	domain.QuotaFunctions:     `SELECT tenant_id, COUNT(*) FROM functions WHERE tenant_id IS NOT NULL GROUP BY tenant_id`,
+	domain.QuotaBuckets:       `SELECT tenant_id, COUNT(*) FROM buckets WHERE tenant_id IS NOT NULL GROUP BY tenant_id`,
+}
+
+// QuotaRepo persists tenant resource quotas and quota increase requests in Postgres.
+type QuotaRepo struct {
+	db DB
+}
+
+// NewQuotaRepo constructs a QuotaRepo.
+func NewQuotaRepo(db DB) *QuotaRepo {
+	return &QuotaRepo{db: db}
+}
+
+func (r *QuotaRepo) GetQuota(ctx context.Context, tenantID uuid.UUID, resource domain.QuotaResource) (*domain.ResourceQuota, error) {
+	def, ok := domain.LookupQuota(resource)
+	if !ok {
+		return nil, errors.New(errors.InvalidInput, "unknown quota resource: "+string(resource))
+	}
+
+	query := `SELECT quota_limit, used, updated_at FROM tenant_resource_quotas WHERE tenant_id = $1 AND resource = $2`
+	var limit *int
+	q := &domain.ResourceQuota{TenantID: tenantID, Resource: resource, Limit: def.DefaultLimit}
+	err := r.db.QueryRow(ctx, query, tenantID, string(resource)).Scan(&limit, &q.Used, &q.UpdatedAt)
+	if err != nil {
+		if stdlib_errors.Is(err, pgx.ErrNoRows) {
+			return q, nil
+		}
+		return nil, errors.Wrap(errors.Internal, "failed to get quota", err)
+	}
+	if limit != nil {
+		q.Limit = *limit
+	}
+	return q, nil
+}
+
+func (r *QuotaRepo) ListQuotas(ctx context.Context, tenantID uuid.UUID) ([]*domain.ResourceQuota, error) {
+	query := `SELECT resource, quota_limit, used, updated_at FROM tenant_resource_quotas WHERE tenant_id = $1`
+	rows, err := r.db.Query(ctx, query, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list quotas", err)
+	}
+	defer rows.Close()
+
+	stored := make(map[domain.QuotaResource]*domain.ResourceQuota)
+	limits := make(map[domain.QuotaResource]*int)
+	for rows.Next() {
+		var (
+			resource string
+			limit    *int
+			q        domain.ResourceQuota
+		)
+		if err := rows.Scan(&resource, &limit, &q.Used, &q.UpdatedAt); err != nil {
+			return nil, errors.Wrap(errors.Internal, "failed to scan quota", err)
+		}
+		stored[domain.QuotaResource(resource)] = &q
+		limits[domain.QuotaResource(resource)] = limit
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list quotas", err)
+	}
+
+	defs := domain.QuotaDefinitions()
+	quotas := make([]*domain.ResourceQuota, 0, len(defs))
+	for _, def := range defs {
+		q, ok := stored[def.Resource]
+		if !ok {
+			q = &domain.ResourceQuota{}
+		}
+		q.TenantID = tenantID
+		q.Resource = def.Resource
+		q.Limit = def.DefaultLimit
+		if limit := limits[def.Resource]; limit != nil {
+			q.Limit = *limit
+		}
+		quotas = append(quotas, q)
+	}
+	return quotas, nil
+}
+
+func (r *QuotaRepo) SetLimit(ctx context.Context, tenantID uuid.UUID, resource domain.QuotaResource, limit int) error {
+	query := `
+		INSERT INTO tenant_resource_quotas (tenant_id, resource, quota_limit, updated_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (tenant_id, resource) DO UPDATE SET quota_limit = EXCLUDED.quota_limit, updated_at = NOW()
+	`
+	if _, err := r.db.Exec(ctx, query, tenantID, string(resource), limit); err != nil {
+		return errors.Wrap(errors.Internal, "failed to set quota limit", err)
+	}
+	return nil
+}
+
+// IncrementUsage adds amount to the usage counter. Negative amounts are allowed
+// (resizes shrink usage this way) but the counter never goes below zero.
+func (r *QuotaRepo) IncrementUsage(ctx context.Context, tenantID uuid.UUID, resource domain.QuotaResource, amount int) error {
+	query := `
+		INSERT INTO tenant_resource_quotas (tenant_id, resource, used, updated_at)
+		VALUES ($1, $2, GREATEST(0, $3::int), NOW())
+		ON CONFLICT (tenant_id, resource) DO UPDATE
+		SET used = GREATEST(0, tenant_resource_quotas.used + $3::int), updated_at = NOW()
+	`
+	if _, err := r.db.Exec(ctx, query, tenantID, string(resource), amount); err != nil {
+		return errors.Wrap(errors.Internal, "failed to increment quota usage", err)
+	}
+	return nil
+}
+
+func (r *QuotaRepo) DecrementUsage(ctx context.Context, tenantID uuid.UUID, resource domain.QuotaResource, amount int) error {
+	query := `
+		UPDATE tenant_resource_quotas SET used = GREATEST(0, used - $3), updated_at = NOW()
+		WHERE tenant_id = $1 AND resource = $2
+	`
+	if _, err := r.db.Exec(ctx, query, tenantID, string(resource), amount); err != nil {
+		return errors.Wrap(errors.Internal, "failed to decrement quota usage", err)
+	}
+	return nil
+}
+
+func (r *QuotaRepo) ListUsage(ctx context.Context, resource domain.QuotaResource) (map[uuid.UUID]int, error) {
+	query := `SELECT tenant_id, used FROM tenant_resource_quotas WHERE resource = $1`
+	return r.scanUsage(ctx, query, string(resource))
+}
+
+func (r *QuotaRepo) CountActualUsage(ctx context.Context, resource domain.QuotaResource) (map[uuid.UUID]int, error) {
+	query, ok := actualUsageQueries[resource]
+	if !ok {
+		return nil, errors.New(errors.InvalidInput, "no usage query for quota resource: "+string(resource))
+	}
+	return r.scanUsage(ctx, query)
+}
+
+func (r *QuotaRepo) scanUsage(ctx context.Context, query string, args ...interface{}) (map[uuid.UUID]int, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to query quota usage", err)
+	}
+	defer rows.Close()
+
+	usage := make(map[uuid.UUID]int)
+	for rows.Next() {
+		var (
+			tenantID uuid.UUID
+			used     int64
+		)
+		if err := rows.Scan(&tenantID, &used); err != nil {
+			return nil, errors.Wrap(errors.Internal, "failed to scan quota usage", err)
+		}
+		usage[tenantID] = int(used)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to query quota usage", err)
+	}
+	return usage, nil
+}
+
+func (r *QuotaRepo) SetUsage(ctx context.Context, tenantID uuid.UUID, resource domain.QuotaResource, used int) error {
+	query := `
+		INSERT INTO tenant_resource_quotas (tenant_id, resource, used, updated_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (tenant_id, resource) DO UPDATE SET used = EXCLUDED.used, updated_at = NOW()
+	`
+	if _, err := r.db.Exec(ctx, query, tenantID, string(resource), used); err != nil {
+		return errors.Wrap(errors.Internal, "failed to set quota usage", err)
+	}
+	return nil
+}
+
+func (r *QuotaRepo) CreateRequest(ctx context.Context, req *domain.QuotaRequest) error {
+	query := `
+		INSERT INTO quota_requests (id, tenant_id, requested_by, resource, current_limit, requested_limit, reason, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+	_, err := r.db.Exec(ctx, query, req.ID, req.TenantID, req.RequestedBy, string(req.Resource), req.CurrentLimit, req.RequestedLimit,
+		req.Reason, string(req.Status), req.CreatedAt, req.UpdatedAt)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to create quota request", err)
+	}
+	return nil
+}
+
+func (r *QuotaRepo) GetRequest(ctx context.Context, id uuid.UUID) (*domain.QuotaRequest, error) {
+	query := `SELECT ` + quotaRequestColumns + ` FROM quota_requests WHERE id = $1`
+	req, err := scanQuotaRequest(r.db.QueryRow(ctx, query, id))
+	if err != nil {
+		if stdlib_errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New(errors.NotFound, "quota request not found")
+		}
+		return nil, errors.Wrap(errors.Internal, "failed to get quota request", err)
+	}
+	return req, nil
+}
+
+func (r *QuotaRepo) ListRequestsByTenant(ctx context.Context, tenantID uuid.UUID) ([]*domain.QuotaRequest, error) {
+	query := `SELECT ` + quotaRequestColumns + ` FROM quota_requests WHERE tenant_id = $1 ORDER BY created_at DESC`
+	return r.listRequests(ctx, query, tenantID)
+}
+
+func (r *QuotaRepo) ListRequestsByStatus(ctx context.Context, status domain.QuotaRequestStatus) ([]*domain.QuotaRequest, error) {
+	query := `SELECT ` + quotaRequestColumns + ` FROM quota_requests WHERE ($1 = '' OR status = $1) ORDER BY created_at`
+	return r.listRequests(ctx, query, string(status))
+}
+
+func (r *QuotaRepo) listRequests(ctx context.Context, query string, args ...interface{}) ([]*domain.QuotaRequest, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list quota requests", err)
+	}
+	defer rows.Close()
+
+	var reqs []*domain.QuotaRequest
+	for rows.Next() {
+		req, err := scanQuotaRequest(rows)
+		if err != nil {
+			return nil, errors.Wrap(errors.Internal, "failed to scan quota request", err)
+		}
+		reqs = append(reqs, req)
+	}
+	return reqs, rows.Err()
+}
+
+func (r *QuotaRepo) ReviewRequest(ctx context.Context, req *domain.QuotaRequest) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to begin transaction", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	query := `
+		UPDATE quota_requests
+		SET status = $2, reviewed_by = $3, review_note = $4, reviewed_at = $5, updated_at = $6
+		WHERE id = $1 AND status = 'pending'
+	`
+	result, err := tx.Exec(ctx, query, req.ID, string(req.Status), req.ReviewedBy, req.ReviewNote, req.ReviewedAt, req.UpdatedAt)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to review quota request", err)
+	}
+	if result.RowsAffected() == 0 {
+		return errors.New(errors.Conflict, "quota request was already reviewed")
+	}
+
+	if req.Status == domain.QuotaRequestApproved {
+		limitQuery := `
+			INSERT INTO tenant_resource_quotas (tenant_id, resource, quota_limit, updated_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (tenant_id, resource) DO UPDATE SET quota_limit = EXCLUDED.quota_limit, updated_at = EXCLUDED.updated_at
+		`
+		if _, err := tx.Exec(ctx, limitQuery, req.TenantID, string(req.Resource), req.RequestedLimit, req.UpdatedAt); err != nil {
+			return errors.Wrap(errors.Internal, "failed to apply approved quota", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return errors.Wrap(errors.Internal, "failed to commit quota review", err)
+	}
+	return nil
+}
+
+func scanQuotaRequest(row pgx.Row) (*domain.QuotaRequest, error) {
+	var (
+		req      domain.QuotaRequest
+		resource string
+		status   string
+		reviewed *time.Time
+	)
+	err := row.Scan(&req.ID, &req.TenantID, &req.RequestedBy, &resource, &req.CurrentLimit, &req.RequestedLimit, &req.Reason,
+		&status, &req.ReviewedBy, &req.ReviewNote, &reviewed, &req.CreatedAt, &req.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	req.Resource = domain.QuotaResource(resource)
+	req.Status = domain.QuotaRequestStatus(status)
+	req.ReviewedAt = reviewed
+	return &req, nil
+}
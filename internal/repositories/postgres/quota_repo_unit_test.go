@@ -0,0 +1,169 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuotaRepositoryUnit(t *testing.T) {
+	t.Parallel()
+
+	t.Run("GetQuota_DefaultsWithoutRow", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		repo := NewQuotaRepo(mock)
+		tenantID := uuid.New()
+		mock.ExpectQuery("SELECT quota_limit, used, updated_at FROM tenant_resource_quotas").
+			WithArgs(tenantID, "vcpus").WillReturnError(pgx.ErrNoRows)
+
+		q, err := repo.GetQuota(context.Background(), tenantID, domain.QuotaVCPUs)
+		require.NoError(t, err)
+		def, _ := domain.LookupQuota(domain.QuotaVCPUs)
+		assert.Equal(t, def.DefaultLimit, q.Limit)
+		assert.Equal(t, 0, q.Used)
+	})
+
+	t.Run("GetQuota_NullLimitKeepsDefault", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		repo := NewQuotaRepo(mock)
+		tenantID := uuid.New()
+		mock.ExpectQuery("SELECT quota_limit, used, updated_at FROM tenant_resource_quotas").
+			WithArgs(tenantID, "buckets").
+			WillReturnRows(pgxmock.NewRows([]string{"quota_limit", "used", "updated_at"}).AddRow(nil, 4, time.Now()))
+
+		q, err := repo.GetQuota(context.Background(), tenantID, domain.QuotaBuckets)
+		require.NoError(t, err)
+		def, _ := domain.LookupQuota(domain.QuotaBuckets)
+		assert.Equal(t, def.DefaultLimit, q.Limit)
+		assert.Equal(t, 4, q.Used)
+	})
+
+	t.Run("ListQuotas_MergesRegistry", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		repo := NewQuotaRepo(mock)
+		tenantID := uuid.New()
+		limit := 64
+		mock.ExpectQuery("SELECT resource, quota_limit, used, updated_at FROM tenant_resource_quotas").WithArgs(tenantID).
+			WillReturnRows(pgxmock.NewRows([]string{"resource", "quota_limit", "used", "updated_at"}).AddRow("vcpus", &limit, 12, time.Now()))
+
+		quotas, err := repo.ListQuotas(context.Background(), tenantID)
+		require.NoError(t, err)
+		require.Len(t, quotas, len(domain.QuotaDefinitions()))
+		for _, q := range quotas {
+			if q.Resource == domain.QuotaVCPUs {
+				assert.Equal(t, 64, q.Limit)
+				assert.Equal(t, 12, q.Used)
+			}
+		}
+	})
+
+	t.Run("CountActualUsage", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		repo := NewQuotaRepo(mock)
+		tenantID := uuid.New()
+		mock.ExpectQuery("SELECT tenant_id, COALESCE\\(SUM\\(size_gb\\), 0\\) FROM volumes").
+			WillReturnRows(pgxmock.NewRows([]string{"tenant_id", "sum"}).AddRow(tenantID, int64(120)))
+
+		usage, err := repo.CountActualUsage(context.Background(), domain.QuotaStorageGB)
+		require.NoError(t, err)
+		assert.Equal(t, 120, usage[tenantID])
+	})
+
+	t.Run("CountActualUsage_InstancesSkipCustomTypes", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		// Cluster nodes use instance_type 'custom' and are never charged, so
+		// only instances with a catalog type are counted.
+		repo := NewQuotaRepo(mock)
+		tenantID := uuid.New()
+		mock.ExpectQuery("SELECT i.tenant_id, COUNT\\(\\*\\) FROM instances i\\s+JOIN instance_types t ON t.id = i.instance_type").
+			WillReturnRows(pgxmock.NewRows([]string{"tenant_id", "count"}).AddRow(tenantID, int64(2)))
+
+		usage, err := repo.CountActualUsage(context.Background(), domain.QuotaInstances)
+		require.NoError(t, err)
+		assert.Equal(t, 2, usage[tenantID])
+	})
+
+	t.Run("ReviewRequest_ApprovedAppliesLimit", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		repo := NewQuotaRepo(mock)
+		reviewer := uuid.New()
+		now := time.Now()
+		req := &domain.QuotaRequest{
+			ID: uuid.New(), TenantID: uuid.New(), Resource: domain.QuotaVCPUs, RequestedLimit: 32,
+			Status: domain.QuotaRequestApproved, ReviewedBy: &reviewer, ReviewedAt: &now, UpdatedAt: now,
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE quota_requests").
+			WithArgs(req.ID, "approved", req.ReviewedBy, "", req.ReviewedAt, req.UpdatedAt).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mock.ExpectExec("INSERT INTO tenant_resource_quotas").
+			WithArgs(req.TenantID, "vcpus", 32, req.UpdatedAt).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mock.ExpectCommit()
+		mock.ExpectRollback()
+
+		require.NoError(t, repo.ReviewRequest(context.Background(), req))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ReviewRequest_AlreadyReviewed", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		repo := NewQuotaRepo(mock)
+		now := time.Now()
+		req := &domain.QuotaRequest{ID: uuid.New(), Status: domain.QuotaRequestDenied, ReviewedAt: &now, UpdatedAt: now}
+
+		mock.ExpectBegin()
+		mock.ExpectExec("UPDATE quota_requests").
+			WithArgs(req.ID, "denied", req.ReviewedBy, "", req.ReviewedAt, req.UpdatedAt).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+		mock.ExpectRollback()
+
+		err = repo.ReviewRequest(context.Background(), req)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.Conflict))
+	})
+
+	t.Run("GetRequest_NotFound", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		repo := NewQuotaRepo(mock)
+		id := uuid.New()
+		mock.ExpectQuery("FROM quota_requests WHERE id").WithArgs(id).WillReturnError(pgx.ErrNoRows)
+
+		_, err = repo.GetRequest(context.Background(), id)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.NotFound))
+	})
+}
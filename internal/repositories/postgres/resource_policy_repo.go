@@ -0,0 +1,155 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	stdlib_errors "errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/errors"
+)
+
+const resourcePolicyColumns = `id, tenant_id, resource_type, resource_id, statements, created_at, updated_at`
+
+type resourcePolicyRepository struct {
+	db DB
+}
+
+// NewResourcePolicyRepository creates a new postgres-backed resource policy repository.
+func NewResourcePolicyRepository(db DB) *resourcePolicyRepository {
+	return &resourcePolicyRepository{db: db}
+}
+
+func (r *resourcePolicyRepository) Upsert(ctx context.Context, policy *domain.ResourcePolicy) error {
+	statementsJSON, err := json.Marshal(policy.Statements)
+	if err != nil {
+		return fmt.Errorf("failed to marshal statements: %w", err)
+	}
+
+	// The WHERE clause keeps a tenant from replacing a policy owned by another tenant.
+	query := `
+		INSERT INTO resource_policies (id, tenant_id, resource_type, resource_id, statements, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (resource_type, resource_id) DO UPDATE
+		SET statements = EXCLUDED.statements, updated_at = EXCLUDED.updated_at
+		WHERE resource_policies.tenant_id = EXCLUDED.tenant_id
+		RETURNING id, created_at
+	`
+	err = r.db.QueryRow(ctx, query, policy.ID, policy.TenantID, string(policy.ResourceType), policy.ResourceID,
+		statementsJSON, policy.CreatedAt, policy.UpdatedAt).Scan(&policy.ID, &policy.CreatedAt)
+	if err != nil {
+		if stdlib_errors.Is(err, pgx.ErrNoRows) {
+			return errors.New(errors.Conflict, "resource policy is owned by another tenant")
+		}
+		return errors.Wrap(errors.Internal, "failed to save resource policy", err)
+	}
+	return nil
+}
+
+func (r *resourcePolicyRepository) Get(ctx context.Context, tenantID uuid.UUID, resourceType domain.ResourcePolicyType, resourceID string) (*domain.ResourcePolicy, error) {
+	query := `SELECT ` + resourcePolicyColumns + ` FROM resource_policies WHERE tenant_id = $1 AND resource_type = $2 AND resource_id = $3`
+	return r.scanPolicy(r.db.QueryRow(ctx, query, tenantID, string(resourceType), resourceID))
+}
+
+func (r *resourcePolicyRepository) List(ctx context.Context, tenantID uuid.UUID) ([]*domain.ResourcePolicy, error) {
+	query := `SELECT ` + resourcePolicyColumns + ` FROM resource_policies WHERE tenant_id = $1 ORDER BY resource_type, resource_id`
+	rows, err := r.db.Query(ctx, query, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return r.scanPolicies(rows)
+}
+
+func (r *resourcePolicyRepository) Delete(ctx context.Context, tenantID uuid.UUID, resourceType domain.ResourcePolicyType, resourceID string) error {
+	query := `DELETE FROM resource_policies WHERE tenant_id = $1 AND resource_type = $2 AND resource_id = $3`
+	result, err := r.db.Exec(ctx, query, tenantID, string(resourceType), resourceID)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, "resource policy not found")
+	}
+	return nil
+}
+
+func (r *resourcePolicyRepository) GetForResource(ctx context.Context, ownerTenantID uuid.UUID, resourceType domain.ResourcePolicyType, resourceID string) ([]*domain.ResourcePolicy, error) {
+	query := `SELECT ` + resourcePolicyColumns + ` FROM resource_policies WHERE tenant_id = $1 AND resource_type = $2 AND resource_id = $3`
+	rows, err := r.db.Query(ctx, query, ownerTenantID, string(resourceType), resourceID)
+	if err != nil {
+		return nil, err
+	}
+	return r.scanPolicies(rows)
+}
+
+func (r *resourcePolicyRepository) GetResourceOwner(ctx context.Context, resourceType domain.ResourcePolicyType, resourceID string) (uuid.UUID, error) {
+	var query string
+	switch resourceType {
+	case domain.ResourcePolicyBucket:
+		// Older buckets predate tenant_id; fall back to the owning user's tenant.
+		query = `SELECT COALESCE(b.tenant_id, u.tenant_id) FROM buckets b LEFT JOIN users u ON u.id = b.user_id WHERE b.name = $1`
+	case domain.ResourcePolicyQueue:
+		query = `SELECT tenant_id FROM queues WHERE id::text = $1`
+	case domain.ResourcePolicyTopic:
+		query = `SELECT COALESCE(t.tenant_id, u.tenant_id) FROM topics t LEFT JOIN users u ON u.id = t.user_id WHERE t.id::text = $1`
+	case domain.ResourcePolicySecret:
+		query = `SELECT tenant_id FROM secrets WHERE id::text = $1`
+	default:
+		return uuid.Nil, errors.New(errors.InvalidInput, fmt.Sprintf("unsupported resource type: %s", resourceType))
+	}
+
+	var owner *uuid.UUID
+	if err := r.db.QueryRow(ctx, query, resourceID).Scan(&owner); err != nil {
+		if stdlib_errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, errors.New(errors.NotFound, "resource not found")
+		}
+		return uuid.Nil, err
+	}
+	if owner == nil {
+		return uuid.Nil, errors.New(errors.NotFound, "resource not found")
+	}
+	return *owner, nil
+}
+
+// deleteWithResourcePolicies prefixes a resource DELETE with a statement that drops the
+// policies attached to the rows it removes, so a policy never outlives its resource.
+// idQuery selects the resource IDs, as text, of the rows being deleted.
+func deleteWithResourcePolicies(resourceType domain.ResourcePolicyType, idQuery, deleteQuery string) string {
+	return `WITH dropped_policies AS (DELETE FROM resource_policies WHERE resource_type = '` + string(resourceType) +
+		`' AND resource_id IN (` + idQuery + `)) ` + deleteQuery
+}
+
+func (r *resourcePolicyRepository) scanPolicy(row pgx.Row) (*domain.ResourcePolicy, error) {
+	var p domain.ResourcePolicy
+	var resourceType string
+	var statementsJSON []byte
+	if err := row.Scan(&p.ID, &p.TenantID, &resourceType, &p.ResourceID, &statementsJSON, &p.CreatedAt, &p.UpdatedAt); err != nil {
+		if stdlib_errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New(errors.NotFound, "resource policy not found")
+		}
+		return nil, err
+	}
+	p.ResourceType = domain.ResourcePolicyType(resourceType)
+	if err := json.Unmarshal(statementsJSON, &p.Statements); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal statements: %w", err)
+	}
+	return &p, nil
+}
+
+func (r *resourcePolicyRepository) scanPolicies(rows pgx.Rows) ([]*domain.ResourcePolicy, error) {
+	defer rows.Close()
+	var policies []*domain.ResourcePolicy
+	for rows.Next() {
+		p, err := r.scanPolicy(rows)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return policies, nil
+}
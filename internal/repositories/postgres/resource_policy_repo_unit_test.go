@@ -0,0 +1,138 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourcePolicyRepositoryUnit(t *testing.T) {
+	t.Parallel()
+
+	columns := []string{"id", "tenant_id", "resource_type", "resource_id", "statements", "created_at", "updated_at"}
+	statements := []domain.Statement{{Effect: domain.EffectAllow, Principal: []string{"*"}, Action: []string{"storage:read"}, Resource: []string{"*"}}}
+	statementsJSON, _ := json.Marshal(statements)
+
+	t.Run("Upsert", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		repo := NewResourcePolicyRepository(mock)
+		now := time.Now()
+		existingID := uuid.New()
+		policy := &domain.ResourcePolicy{
+			ID: uuid.New(), TenantID: uuid.New(), ResourceType: domain.ResourcePolicyBucket, ResourceID: "shared",
+			Statements: statements, CreatedAt: now, UpdatedAt: now,
+		}
+
+		mock.ExpectQuery("INSERT INTO resource_policies").
+			WithArgs(policy.ID, policy.TenantID, "bucket", "shared", statementsJSON, now, now).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "created_at"}).AddRow(existingID, now))
+
+		require.NoError(t, repo.Upsert(context.Background(), policy))
+		assert.Equal(t, existingID, policy.ID)
+	})
+
+	t.Run("Upsert_OwnedByOtherTenant", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		repo := NewResourcePolicyRepository(mock)
+		policy := &domain.ResourcePolicy{ID: uuid.New(), TenantID: uuid.New(), ResourceType: domain.ResourcePolicyBucket, ResourceID: "shared", Statements: statements}
+
+		mock.ExpectQuery("INSERT INTO resource_policies").
+			WithArgs(policy.ID, policy.TenantID, "bucket", "shared", statementsJSON, policy.CreatedAt, policy.UpdatedAt).
+			WillReturnError(pgx.ErrNoRows)
+
+		err = repo.Upsert(context.Background(), policy)
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.Conflict))
+	})
+
+	t.Run("GetForResource", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		repo := NewResourcePolicyRepository(mock)
+		now := time.Now()
+		owner := uuid.New()
+		mock.ExpectQuery("SELECT .* FROM resource_policies WHERE tenant_id = \\$1 AND resource_type = \\$2 AND resource_id = \\$3").
+			WithArgs(owner, "bucket", "shared").
+			WillReturnRows(pgxmock.NewRows(columns).AddRow(uuid.New(), owner, "bucket", "shared", statementsJSON, now, now))
+
+		policies, err := repo.GetForResource(context.Background(), owner, domain.ResourcePolicyBucket, "shared")
+		require.NoError(t, err)
+		require.Len(t, policies, 1)
+		assert.Equal(t, domain.ResourcePolicyBucket, policies[0].ResourceType)
+		assert.Equal(t, []string{"*"}, policies[0].Statements[0].Principal)
+	})
+
+	t.Run("Get_NotFound", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		repo := NewResourcePolicyRepository(mock)
+		tenantID := uuid.New()
+		mock.ExpectQuery("SELECT .* FROM resource_policies WHERE tenant_id = \\$1").
+			WithArgs(tenantID, "queue", "q1").
+			WillReturnError(pgx.ErrNoRows)
+
+		_, err = repo.Get(context.Background(), tenantID, domain.ResourcePolicyQueue, "q1")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, errors.NotFound))
+	})
+
+	t.Run("Delete_NotFound", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		repo := NewResourcePolicyRepository(mock)
+		tenantID := uuid.New()
+		mock.ExpectExec("DELETE FROM resource_policies").
+			WithArgs(tenantID, "bucket", "shared").
+			WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+		err = repo.Delete(context.Background(), tenantID, domain.ResourcePolicyBucket, "shared")
+		assert.True(t, errors.Is(err, errors.NotFound))
+	})
+
+	t.Run("GetResourceOwner", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		repo := NewResourcePolicyRepository(mock)
+		owner := uuid.New()
+		mock.ExpectQuery("SELECT COALESCE\\(b.tenant_id, u.tenant_id\\) FROM buckets").
+			WithArgs("shared").
+			WillReturnRows(pgxmock.NewRows([]string{"tenant_id"}).AddRow(&owner))
+
+		got, err := repo.GetResourceOwner(context.Background(), domain.ResourcePolicyBucket, "shared")
+		require.NoError(t, err)
+		assert.Equal(t, owner, got)
+	})
+
+	t.Run("GetResourceOwner_UnsupportedType", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		repo := NewResourcePolicyRepository(mock)
+		_, err = repo.GetResourceOwner(context.Background(), "instance", "i-1")
+		assert.True(t, errors.Is(err, errors.InvalidInput))
+	})
+}
@@ -14,6 +14,9 @@ import (
 	"github.com/poyrazk/thecloud/internal/errors"
 )
 
+const secretColumns = `id, user_id, tenant_id, name, encrypted_value, description, created_at, updated_at, last_accessed_at,
+		current_version, rotation_function_id, rotation_interval_days, last_rotated_at, next_rotation_at`
+
 // SecretRepository provides PostgreSQL-backed secret persistence.
 type SecretRepository struct {
 	db DB
@@ -24,22 +27,29 @@ func NewSecretRepository(db DB) *SecretRepository {
 	return &SecretRepository{db: db}
 }
 
+// Create inserts the secret together with its first version, labelled current.
 func (r *SecretRepository) Create(ctx context.Context, s *domain.Secret) error {
 	query := `
-		INSERT INTO secrets (id, user_id, tenant_id, name, encrypted_value, description, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		WITH inserted AS (
+			INSERT INTO secrets (id, user_id, tenant_id, name, encrypted_value, description, created_at, updated_at, current_version)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, 1)
+			RETURNING id
+		)
+		INSERT INTO secret_versions (id, secret_id, version, encrypted_value, stages, created_by, created_at)
+		SELECT $9, id, 1, $5, ARRAY['current'], $2, $7 FROM inserted
 	`
-	_, err := r.db.Exec(ctx, query, s.ID, s.UserID, s.TenantID, s.Name, s.EncryptedValue, s.Description, s.CreatedAt, s.UpdatedAt)
+	_, err := r.db.Exec(ctx, query, s.ID, s.UserID, s.TenantID, s.Name, s.EncryptedValue, s.Description, s.CreatedAt, s.UpdatedAt, uuid.New())
 	if err != nil {
 		return fmt.Errorf("failed to create secret: %w", err)
 	}
+	s.CurrentVersion = 1
 	return nil
 }
 
 func (r *SecretRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Secret, error) {
 	tenantID := appcontext.TenantIDFromContext(ctx)
 	query := `
-		SELECT id, user_id, tenant_id, name, encrypted_value, description, created_at, updated_at, last_accessed_at
+		SELECT ` + secretColumns + `
 		FROM secrets
 		WHERE id = $1 AND (tenant_id = $2 OR tenant_id IS NULL)
 	`
@@ -49,7 +59,7 @@ func (r *SecretRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.S
 func (r *SecretRepository) GetByName(ctx context.Context, name string) (*domain.Secret, error) {
 	tenantID := appcontext.TenantIDFromContext(ctx)
 	query := `
-		SELECT id, user_id, tenant_id, name, encrypted_value, description, created_at, updated_at, last_accessed_at
+		SELECT ` + secretColumns + `
 		FROM secrets
 		WHERE name = $1 AND (tenant_id = $2 OR tenant_id IS NULL)
 	`
@@ -59,7 +69,7 @@ func (r *SecretRepository) GetByName(ctx context.Context, name string) (*domain.
 func (r *SecretRepository) List(ctx context.Context) ([]*domain.Secret, error) {
 	tenantID := appcontext.TenantIDFromContext(ctx)
 	query := `
-		SELECT id, user_id, tenant_id, name, encrypted_value, description, created_at, updated_at, last_accessed_at
+		SELECT ` + secretColumns + `
 		FROM secrets
 		WHERE tenant_id = $1 OR tenant_id IS NULL
 		ORDER BY name ASC
@@ -71,10 +81,27 @@ func (r *SecretRepository) List(ctx context.Context) ([]*domain.Secret, error) {
 	return r.scanSecrets(rows)
 }
 
+// ListDueForRotation is used by the rotation worker and is not tenant-scoped.
+func (r *SecretRepository) ListDueForRotation(ctx context.Context, now time.Time) ([]*domain.Secret, error) {
+	query := `
+		SELECT ` + secretColumns + `
+		FROM secrets
+		WHERE rotation_function_id IS NOT NULL AND next_rotation_at <= $1
+		ORDER BY next_rotation_at ASC
+	`
+	rows, err := r.db.Query(ctx, query, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets due for rotation: %w", err)
+	}
+	return r.scanSecrets(rows)
+}
+
 func (r *SecretRepository) scanSecret(row pgx.Row) (*domain.Secret, error) {
 	s := &domain.Secret{}
+	var intervalDays *int
 	err := row.Scan(
 		&s.ID, &s.UserID, &s.TenantID, &s.Name, &s.EncryptedValue, &s.Description, &s.CreatedAt, &s.UpdatedAt, &s.LastAccessedAt,
+		&s.CurrentVersion, &s.RotationFunctionID, &intervalDays, &s.LastRotatedAt, &s.NextRotationAt,
 	)
 	if err != nil {
 		if stdlib_errors.Is(err, pgx.ErrNoRows) {
@@ -82,6 +109,9 @@ func (r *SecretRepository) scanSecret(row pgx.Row) (*domain.Secret, error) {
 		}
 		return nil, fmt.Errorf("failed to scan secret: %w", err)
 	}
+	if intervalDays != nil {
+		s.RotationIntervalDays = *intervalDays
+	}
 	return s, nil
 }
 
@@ -98,27 +128,50 @@ func (r *SecretRepository) scanSecrets(rows pgx.Rows) ([]*domain.Secret, error)
 	return secrets, nil
 }
 
+// Update modifies a secret's metadata. Values change only through AddVersion and MoveStage.
 func (r *SecretRepository) Update(ctx context.Context, s *domain.Secret) error {
 	query := `
 		UPDATE secrets
-		SET encrypted_value = $1, description = $2, updated_at = $3, last_accessed_at = $4
-		WHERE id = $5 AND (tenant_id = $6 OR (tenant_id IS NULL AND $6 IS NULL))
+		SET description = $1, updated_at = $2, last_accessed_at = $3
+		WHERE id = $4 AND (tenant_id = $5 OR (tenant_id IS NULL AND $5 IS NULL))
 	`
 	var tenantParam interface{} = s.TenantID
 	if s.TenantID == uuid.Nil {
 		tenantParam = nil
 	}
 
-	_, err := r.db.Exec(ctx, query, s.EncryptedValue, s.Description, time.Now(), s.LastAccessedAt, s.ID, tenantParam)
+	_, err := r.db.Exec(ctx, query, s.Description, time.Now(), s.LastAccessedAt, s.ID, tenantParam)
 	if err != nil {
 		return fmt.Errorf("failed to update secret: %w", err)
 	}
 	return nil
 }
 
+func (r *SecretRepository) UpdateRotation(ctx context.Context, s *domain.Secret) error {
+	query := `
+		UPDATE secrets
+		SET rotation_function_id = $1, rotation_interval_days = $2, last_rotated_at = $3, next_rotation_at = $4, updated_at = $5
+		WHERE id = $6
+	`
+	var intervalDays interface{}
+	if s.RotationIntervalDays > 0 {
+		intervalDays = s.RotationIntervalDays
+	}
+
+	tag, err := r.db.Exec(ctx, query, s.RotationFunctionID, intervalDays, s.LastRotatedAt, s.NextRotationAt, time.Now(), s.ID)
+	if err != nil {
+		return fmt.Errorf("failed to update secret rotation: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, "secret not found")
+	}
+	return nil
+}
+
 func (r *SecretRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	tenantID := appcontext.TenantIDFromContext(ctx)
-	query := `DELETE FROM secrets WHERE id = $1 AND (tenant_id = $2 OR (tenant_id IS NULL AND $2 IS NULL))`
+	where := `WHERE id = $1 AND (tenant_id = $2 OR (tenant_id IS NULL AND $2 IS NULL))`
+	query := deleteWithResourcePolicies(domain.ResourcePolicySecret, `SELECT id::text FROM secrets `+where, `DELETE FROM secrets `+where)
 
 	var tenantParam interface{} = tenantID
 	if tenantID == uuid.Nil {
@@ -131,3 +184,169 @@ func (r *SecretRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	}
 	return nil
 }
+
+func (r *SecretRepository) AddVersion(ctx context.Context, v *domain.SecretVersion) error {
+	if len(v.Stages) != 1 {
+		return errors.New(errors.InvalidInput, "a new secret version must carry exactly one stage")
+	}
+	stage := v.Stages[0]
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	// Lock the secret so concurrent writers get distinct version numbers.
+	var latest int
+	err = tx.QueryRow(ctx, `
+		SELECT COALESCE((SELECT MAX(version) FROM secret_versions WHERE secret_id = s.id), 0)
+		FROM secrets s WHERE s.id = $1 FOR UPDATE
+	`, v.SecretID).Scan(&latest)
+	if err != nil {
+		if stdlib_errors.Is(err, pgx.ErrNoRows) {
+			return errors.New(errors.NotFound, "secret not found")
+		}
+		return fmt.Errorf("failed to lock secret: %w", err)
+	}
+	v.Version = latest + 1
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO secret_versions (id, secret_id, version, encrypted_value, stages, created_by, created_at)
+		VALUES ($1, $2, $3, $4, '{}', $5, $6)
+	`, v.ID, v.SecretID, v.Version, v.EncryptedValue, v.CreatedBy, v.CreatedAt); err != nil {
+		return fmt.Errorf("failed to create secret version: %w", err)
+	}
+
+	if err := r.moveStageTx(ctx, tx, v.SecretID, stage, v.Version, v.EncryptedValue); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit secret version: %w", err)
+	}
+	return nil
+}
+
+func (r *SecretRepository) MoveStage(ctx context.Context, secretID uuid.UUID, stage domain.SecretStage, version int) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var encrypted string
+	err = tx.QueryRow(ctx, `
+		SELECT encrypted_value FROM secret_versions WHERE secret_id = $1 AND version = $2 FOR UPDATE
+	`, secretID, version).Scan(&encrypted)
+	if err != nil {
+		if stdlib_errors.Is(err, pgx.ErrNoRows) {
+			return errors.New(errors.NotFound, "secret version not found")
+		}
+		return fmt.Errorf("failed to get secret version: %w", err)
+	}
+
+	if err := r.moveStageTx(ctx, tx, secretID, stage, version, encrypted); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit stage change: %w", err)
+	}
+	return nil
+}
+
+// moveStageTx points a stage label at version. Promoting to current demotes the old
+// current version to previous, clears pending from the promoted version and keeps
+// secrets.encrypted_value in step.
+func (r *SecretRepository) moveStageTx(ctx context.Context, tx pgx.Tx, secretID uuid.UUID, stage domain.SecretStage, version int, encrypted string) error {
+	if stage == domain.SecretStageCurrent {
+		var currentVersion int
+		err := tx.QueryRow(ctx, `SELECT current_version FROM secrets WHERE id = $1`, secretID).Scan(&currentVersion)
+		if err != nil {
+			return fmt.Errorf("failed to read current version: %w", err)
+		}
+		if currentVersion == version {
+			return nil
+		}
+
+		if _, err := tx.Exec(ctx, `
+			UPDATE secret_versions
+			SET stages = CASE
+				WHEN version = $2 THEN array_append(array_remove(array_remove(stages, 'previous'), 'current'), 'previous')
+				WHEN version = $3 THEN array_append(array_remove(array_remove(stages, 'previous'), 'pending'), 'current')
+				ELSE array_remove(stages, 'previous')
+			END
+			WHERE secret_id = $1
+		`, secretID, currentVersion, version); err != nil {
+			return fmt.Errorf("failed to move secret stages: %w", err)
+		}
+
+		if _, err := tx.Exec(ctx, `
+			UPDATE secrets SET encrypted_value = $1, current_version = $2, updated_at = $3 WHERE id = $4
+		`, encrypted, version, time.Now(), secretID); err != nil {
+			return fmt.Errorf("failed to update current secret value: %w", err)
+		}
+		return nil
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE secret_versions
+		SET stages = CASE WHEN version = $3 THEN array_append(array_remove(stages, $2), $2) ELSE array_remove(stages, $2) END
+		WHERE secret_id = $1
+	`, secretID, string(stage), version); err != nil {
+		return fmt.Errorf("failed to move secret stage: %w", err)
+	}
+	return nil
+}
+
+func (r *SecretRepository) ListVersions(ctx context.Context, secretID uuid.UUID) ([]*domain.SecretVersion, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, secret_id, version, encrypted_value, stages, created_by, created_at
+		FROM secret_versions WHERE secret_id = $1 ORDER BY version DESC
+	`, secretID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secret versions: %w", err)
+	}
+	defer rows.Close()
+
+	var versions []*domain.SecretVersion
+	for rows.Next() {
+		v, err := r.scanVersion(rows)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, v)
+	}
+	return versions, rows.Err()
+}
+
+func (r *SecretRepository) GetVersion(ctx context.Context, secretID uuid.UUID, version int) (*domain.SecretVersion, error) {
+	return r.scanVersion(r.db.QueryRow(ctx, `
+		SELECT id, secret_id, version, encrypted_value, stages, created_by, created_at
+		FROM secret_versions WHERE secret_id = $1 AND version = $2
+	`, secretID, version))
+}
+
+func (r *SecretRepository) GetVersionByStage(ctx context.Context, secretID uuid.UUID, stage domain.SecretStage) (*domain.SecretVersion, error) {
+	return r.scanVersion(r.db.QueryRow(ctx, `
+		SELECT id, secret_id, version, encrypted_value, stages, created_by, created_at
+		FROM secret_versions WHERE secret_id = $1 AND $2 = ANY(stages)
+	`, secretID, string(stage)))
+}
+
+func (r *SecretRepository) scanVersion(row pgx.Row) (*domain.SecretVersion, error) {
+	v := &domain.SecretVersion{}
+	var stages []string
+	if err := row.Scan(&v.ID, &v.SecretID, &v.Version, &v.EncryptedValue, &stages, &v.CreatedBy, &v.CreatedAt); err != nil {
+		if stdlib_errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New(errors.NotFound, "secret version not found")
+		}
+		return nil, fmt.Errorf("failed to scan secret version: %w", err)
+	}
+	v.Stages = make([]domain.SecretStage, 0, len(stages))
+	for _, s := range stages {
+		v.Stages = append(v.Stages, domain.SecretStage(s))
+	}
+	return v, nil
+}
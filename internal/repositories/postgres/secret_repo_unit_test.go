@@ -16,6 +16,11 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+var secretRowColumns = []string{
+	"id", "user_id", "tenant_id", "name", "encrypted_value", "description", "created_at", "updated_at", "last_accessed_at",
+	"current_version", "rotation_function_id", "rotation_interval_days", "last_rotated_at", "next_rotation_at",
+}
+
 func TestSecretRepositoryCreate(t *testing.T) {
 	t.Parallel()
 	t.Run("success", func(t *testing.T) {
@@ -37,11 +42,12 @@ func TestSecretRepositoryCreate(t *testing.T) {
 		}
 
 		mock.ExpectExec("INSERT INTO secrets").
-			WithArgs(secret.ID, secret.UserID, secret.TenantID, secret.Name, secret.EncryptedValue, secret.Description, secret.CreatedAt, secret.UpdatedAt).
+			WithArgs(secret.ID, secret.UserID, secret.TenantID, secret.Name, secret.EncryptedValue, secret.Description, secret.CreatedAt, secret.UpdatedAt, pgxmock.AnyArg()).
 			WillReturnResult(pgxmock.NewResult("INSERT", 1))
 
 		err = repo.Create(context.Background(), secret)
 		require.NoError(t, err)
+		assert.Equal(t, 1, secret.CurrentVersion)
 	})
 
 	t.Run("db error", func(t *testing.T) {
@@ -80,10 +86,10 @@ func TestSecretRepositoryGetByID(t *testing.T) {
 		now := time.Now()
 		var lastAccessedAt *time.Time = nil
 
-		mock.ExpectQuery("SELECT id, user_id, tenant_id, name, encrypted_value, description, created_at, updated_at, last_accessed_at FROM secrets").
+		mock.ExpectQuery("SELECT id, user_id, tenant_id, name, encrypted_value, description, created_at, updated_at, last_accessed_at,\\s+current_version, rotation_function_id, rotation_interval_days, last_rotated_at, next_rotation_at FROM secrets").
 			WithArgs(id, tenantID).
-			WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "tenant_id", "name", "encrypted_value", "description", "created_at", "updated_at", "last_accessed_at"}).
-				AddRow(id, userID, tenantID, "test-secret", "encrypted", "desc", now, now, lastAccessedAt))
+			WillReturnRows(pgxmock.NewRows(secretRowColumns).
+				AddRow(id, userID, tenantID, "test-secret", "encrypted", "desc", now, now, lastAccessedAt, 1, nil, nil, nil, nil))
 
 		secret, err := repo.GetByID(ctx, id)
 		require.NoError(t, err)
@@ -103,7 +109,7 @@ func TestSecretRepositoryGetByID(t *testing.T) {
 		tenantID := uuid.New()
 		ctx := appcontext.WithTenantID(context.Background(), tenantID)
 
-		mock.ExpectQuery("SELECT id, user_id, tenant_id, name, encrypted_value, description, created_at, updated_at, last_accessed_at FROM secrets").
+		mock.ExpectQuery("SELECT id, user_id, tenant_id, name, encrypted_value, description, created_at, updated_at, last_accessed_at,\\s+current_version, rotation_function_id, rotation_interval_days, last_rotated_at, next_rotation_at FROM secrets").
 			WithArgs(id, tenantID).
 			WillReturnError(pgx.ErrNoRows)
 
@@ -133,10 +139,10 @@ func TestSecretRepositoryList(t *testing.T) {
 		now := time.Now()
 		var lastAccessedAt *time.Time = nil
 
-		mock.ExpectQuery("SELECT id, user_id, tenant_id, name, encrypted_value, description, created_at, updated_at, last_accessed_at FROM secrets").
+		mock.ExpectQuery("SELECT id, user_id, tenant_id, name, encrypted_value, description, created_at, updated_at, last_accessed_at,\\s+current_version, rotation_function_id, rotation_interval_days, last_rotated_at, next_rotation_at FROM secrets").
 			WithArgs(tenantID).
-			WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "tenant_id", "name", "encrypted_value", "description", "created_at", "updated_at", "last_accessed_at"}).
-				AddRow(uuid.New(), userID, tenantID, "test-secret", "encrypted", "desc", now, now, lastAccessedAt))
+			WillReturnRows(pgxmock.NewRows(secretRowColumns).
+				AddRow(uuid.New(), userID, tenantID, "test-secret", "encrypted", "desc", now, now, lastAccessedAt, 1, nil, nil, nil, nil))
 
 		secrets, err := repo.List(ctx)
 		require.NoError(t, err)
@@ -163,7 +169,7 @@ func TestSecretRepositoryUpdate(t *testing.T) {
 		}
 
 		mock.ExpectExec("UPDATE secrets").
-			WithArgs(secret.EncryptedValue, secret.Description, pgxmock.AnyArg(), secret.LastAccessedAt, secret.ID, secret.TenantID).
+			WithArgs(secret.Description, pgxmock.AnyArg(), secret.LastAccessedAt, secret.ID, secret.TenantID).
 			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
 
 		err = repo.Update(context.Background(), secret)
@@ -184,7 +190,7 @@ func TestSecretRepositoryDelete(t *testing.T) {
 		tenantID := uuid.New()
 		ctx := appcontext.WithTenantID(context.Background(), tenantID)
 
-		mock.ExpectExec("DELETE FROM secrets").
+		mock.ExpectExec("DELETE FROM resource_policies WHERE resource_type = 'secret' .* DELETE FROM secrets").
 			WithArgs(id, tenantID).
 			WillReturnResult(pgxmock.NewResult("DELETE", 1))
 
@@ -192,3 +198,167 @@ func TestSecretRepositoryDelete(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestSecretRepositoryAddVersion(t *testing.T) {
+	t.Parallel()
+	t.Run("promotes to current", func(t *testing.T) {
+		t.Parallel()
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		repo := NewSecretRepository(mock)
+		v := &domain.SecretVersion{
+			ID: uuid.New(), SecretID: uuid.New(), EncryptedValue: "enc-v3",
+			Stages: []domain.SecretStage{domain.SecretStageCurrent}, CreatedBy: uuid.New(), CreatedAt: time.Now(),
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT COALESCE").WithArgs(v.SecretID).
+			WillReturnRows(pgxmock.NewRows([]string{"coalesce"}).AddRow(2))
+		mock.ExpectExec("INSERT INTO secret_versions").
+			WithArgs(v.ID, v.SecretID, 3, "enc-v3", v.CreatedBy, v.CreatedAt).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mock.ExpectQuery("SELECT current_version FROM secrets").WithArgs(v.SecretID).
+			WillReturnRows(pgxmock.NewRows([]string{"current_version"}).AddRow(2))
+		mock.ExpectExec("UPDATE secret_versions").WithArgs(v.SecretID, 2, 3).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 3))
+		mock.ExpectExec("UPDATE secrets SET encrypted_value").WithArgs("enc-v3", 3, pgxmock.AnyArg(), v.SecretID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mock.ExpectCommit()
+		mock.ExpectRollback()
+
+		require.NoError(t, repo.AddVersion(context.Background(), v))
+		assert.Equal(t, 3, v.Version)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("staged as pending leaves current alone", func(t *testing.T) {
+		t.Parallel()
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		repo := NewSecretRepository(mock)
+		v := &domain.SecretVersion{
+			ID: uuid.New(), SecretID: uuid.New(), EncryptedValue: "enc",
+			Stages: []domain.SecretStage{domain.SecretStagePending}, CreatedAt: time.Now(),
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT COALESCE").WithArgs(v.SecretID).
+			WillReturnRows(pgxmock.NewRows([]string{"coalesce"}).AddRow(1))
+		mock.ExpectExec("INSERT INTO secret_versions").
+			WithArgs(v.ID, v.SecretID, 2, "enc", v.CreatedBy, v.CreatedAt).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		mock.ExpectExec("UPDATE secret_versions").WithArgs(v.SecretID, "pending", 2).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+		mock.ExpectCommit()
+		mock.ExpectRollback()
+
+		require.NoError(t, repo.AddVersion(context.Background(), v))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("secret not found", func(t *testing.T) {
+		t.Parallel()
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		repo := NewSecretRepository(mock)
+		v := &domain.SecretVersion{SecretID: uuid.New(), Stages: []domain.SecretStage{domain.SecretStageCurrent}}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT COALESCE").WithArgs(v.SecretID).WillReturnError(pgx.ErrNoRows)
+		mock.ExpectRollback()
+
+		err = repo.AddVersion(context.Background(), v)
+		require.Error(t, err)
+		assert.True(t, theclouderrors.Is(err, theclouderrors.NotFound))
+	})
+}
+
+func TestSecretRepositoryMoveStage(t *testing.T) {
+	t.Parallel()
+	t.Run("already current is a no-op", func(t *testing.T) {
+		t.Parallel()
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		repo := NewSecretRepository(mock)
+		secretID := uuid.New()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT encrypted_value FROM secret_versions").WithArgs(secretID, 4).
+			WillReturnRows(pgxmock.NewRows([]string{"encrypted_value"}).AddRow("enc"))
+		mock.ExpectQuery("SELECT current_version FROM secrets").WithArgs(secretID).
+			WillReturnRows(pgxmock.NewRows([]string{"current_version"}).AddRow(4))
+		mock.ExpectCommit()
+		mock.ExpectRollback()
+
+		require.NoError(t, repo.MoveStage(context.Background(), secretID, domain.SecretStageCurrent, 4))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		t.Parallel()
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		repo := NewSecretRepository(mock)
+		secretID := uuid.New()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT encrypted_value FROM secret_versions").WithArgs(secretID, 9).WillReturnError(pgx.ErrNoRows)
+		mock.ExpectRollback()
+
+		err = repo.MoveStage(context.Background(), secretID, domain.SecretStagePending, 9)
+		require.Error(t, err)
+		assert.True(t, theclouderrors.Is(err, theclouderrors.NotFound))
+	})
+}
+
+func TestSecretRepositoryVersions(t *testing.T) {
+	t.Parallel()
+	t.Run("get by stage", func(t *testing.T) {
+		t.Parallel()
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		repo := NewSecretRepository(mock)
+		secretID := uuid.New()
+		mock.ExpectQuery("FROM secret_versions WHERE secret_id = \\$1 AND \\$2 = ANY\\(stages\\)").WithArgs(secretID, "previous").
+			WillReturnRows(pgxmock.NewRows([]string{"id", "secret_id", "version", "encrypted_value", "stages", "created_by", "created_at"}).
+				AddRow(uuid.New(), secretID, 2, "enc", []string{"previous"}, uuid.New(), time.Now()))
+
+		v, err := repo.GetVersionByStage(context.Background(), secretID, domain.SecretStagePrevious)
+		require.NoError(t, err)
+		assert.Equal(t, 2, v.Version)
+		assert.True(t, v.HasStage(domain.SecretStagePrevious))
+	})
+
+	t.Run("list due for rotation", func(t *testing.T) {
+		t.Parallel()
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		repo := NewSecretRepository(mock)
+		now := time.Now()
+		fnID := uuid.New()
+		interval := 30
+		mock.ExpectQuery("WHERE rotation_function_id IS NOT NULL AND next_rotation_at <= \\$1").WithArgs(now).
+			WillReturnRows(pgxmock.NewRows(secretRowColumns).
+				AddRow(uuid.New(), uuid.New(), uuid.New(), "db-pass", "enc", "", now, now, nil, 3, &fnID, &interval, nil, &now))
+
+		secrets, err := repo.ListDueForRotation(context.Background(), now)
+		require.NoError(t, err)
+		require.Len(t, secrets, 1)
+		assert.Equal(t, 30, secrets[0].RotationIntervalDays)
+		assert.True(t, secrets[0].RotationEnabled())
+	})
+}
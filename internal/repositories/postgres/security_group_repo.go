@@ -126,10 +126,10 @@ func (r *SecurityGroupRepository) scanSecurityGroups(rows pgx.Rows) ([]*domain.S
 
 func (r *SecurityGroupRepository) AddRule(ctx context.Context, rule *domain.SecurityRule) error {
 	query := `
-		INSERT INTO security_rules (id, group_id, direction, protocol, port_min, port_max, cidr, priority, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		INSERT INTO security_rules (id, group_id, direction, protocol, port_min, port_max, cidr, priority, created_at, source_group_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 	`
-	_, err := r.db.Exec(ctx, query, rule.ID, rule.GroupID, rule.Direction, rule.Protocol, rule.PortMin, rule.PortMax, rule.CIDR, rule.Priority, rule.CreatedAt)
+	_, err := r.db.Exec(ctx, query, rule.ID, rule.GroupID, rule.Direction, rule.Protocol, rule.PortMin, rule.PortMax, rule.CIDR, rule.Priority, rule.CreatedAt, rule.SourceGroupID)
 	if err != nil {
 		return errors.Wrap(errors.Internal, "failed to add security rule", err)
 	}
@@ -139,7 +139,7 @@ func (r *SecurityGroupRepository) AddRule(ctx context.Context, rule *domain.Secu
 func (r *SecurityGroupRepository) GetRuleByID(ctx context.Context, ruleID uuid.UUID) (*domain.SecurityRule, error) {
 	tenantID := appcontext.TenantIDFromContext(ctx)
 	query := `
-		SELECT sr.id, sr.group_id, sr.direction, sr.protocol, sr.port_min, sr.port_max, sr.cidr, sr.priority, sr.created_at 
+		SELECT sr.id, sr.group_id, sr.direction, sr.protocol, sr.port_min, sr.port_max, sr.cidr, sr.priority, sr.created_at, sr.source_group_id
 		FROM security_rules sr
 		JOIN security_groups sg ON sr.group_id = sg.id
 		WHERE sr.id = $1 AND sg.tenant_id = $2
@@ -222,6 +222,37 @@ func (r *SecurityGroupRepository) RemoveInstanceFromGroup(ctx context.Context, i
 	return tx.Commit(ctx)
 }
 
+// AddInterfaceToGroup links a network interface to a security group of the same tenant and VPC.
+func (r *SecurityGroupRepository) AddInterfaceToGroup(ctx context.Context, interfaceID, groupID uuid.UUID) error {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `
+		INSERT INTO network_interface_security_groups (interface_id, group_id)
+		SELECT n.id, sg.id
+		FROM network_interfaces n
+		JOIN security_groups sg ON sg.tenant_id = n.tenant_id AND sg.vpc_id = n.vpc_id
+		WHERE n.id = $1 AND sg.id = $2 AND n.tenant_id = $3
+		ON CONFLICT DO NOTHING
+	`
+	if _, err := r.db.Exec(ctx, query, interfaceID, groupID, tenantID); err != nil {
+		return errors.Wrap(errors.Internal, "failed to add network interface to group", err)
+	}
+	return nil
+}
+
+// RemoveInterfaceFromGroup unlinks a network interface from a security group.
+func (r *SecurityGroupRepository) RemoveInterfaceFromGroup(ctx context.Context, interfaceID, groupID uuid.UUID) error {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `
+		DELETE FROM network_interface_security_groups g
+		USING network_interfaces n
+		WHERE g.interface_id = n.id AND g.interface_id = $1 AND g.group_id = $2 AND n.tenant_id = $3
+	`
+	if _, err := r.db.Exec(ctx, query, interfaceID, groupID, tenantID); err != nil {
+		return errors.Wrap(errors.Internal, "failed to remove network interface from group", err)
+	}
+	return nil
+}
+
 func (r *SecurityGroupRepository) verifyTenantOwnership(ctx context.Context, tx pgx.Tx, tenantID, instanceID, groupID uuid.UUID) error {
 	var instanceTenant uuid.UUID
 	err := tx.QueryRow(ctx, "SELECT tenant_id FROM instances WHERE id = $1", instanceID).Scan(&instanceTenant)
@@ -262,8 +293,72 @@ func (r *SecurityGroupRepository) ListInstanceGroups(ctx context.Context, instan
 	return r.scanSecurityGroups(rows)
 }
 
+func (r *SecurityGroupRepository) ListGroupMemberIPs(ctx context.Context, groupID uuid.UUID) ([]string, error) {
+	query := `
+		SELECT host(ip) FROM (
+			SELECT i.private_ip AS ip
+			FROM instances i
+			JOIN instance_security_groups isg ON isg.instance_id = i.id
+			WHERE isg.group_id = $1 AND i.private_ip IS NOT NULL
+			UNION ALL
+			SELECT i.private_ipv6
+			FROM instances i
+			JOIN instance_security_groups isg ON isg.instance_id = i.id
+			WHERE isg.group_id = $1 AND i.private_ipv6 IS NOT NULL
+			UNION ALL
+			SELECT unnest(n.private_ips)::inet
+			FROM network_interfaces n
+			JOIN network_interface_security_groups nsg ON nsg.interface_id = n.id
+			WHERE nsg.group_id = $1
+		) members
+		ORDER BY ip
+	`
+	rows, err := r.db.Query(ctx, query, groupID)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list security group members", err)
+	}
+	defer rows.Close()
+
+	var ips []string
+	for rows.Next() {
+		var ip string
+		if err := rows.Scan(&ip); err != nil {
+			return nil, errors.Wrap(errors.Internal, "failed to scan member ip", err)
+		}
+		ips = append(ips, ip)
+	}
+	return ips, rows.Err()
+}
+
+func (r *SecurityGroupRepository) ListReferencingGroups(ctx context.Context, groupID uuid.UUID) ([]*domain.SecurityGroup, error) {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `
+		SELECT sg.id, sg.user_id, sg.tenant_id, sg.vpc_id, sg.name, sg.description, sg.arn, sg.created_at
+		FROM security_groups sg
+		WHERE sg.tenant_id = $2
+		  AND EXISTS (SELECT 1 FROM security_rules sr WHERE sr.group_id = sg.id AND sr.source_group_id = $1)
+	`
+	rows, err := r.db.Query(ctx, query, groupID, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list referencing security groups", err)
+	}
+	groups, err := r.scanSecurityGroups(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sg := range groups {
+		rules, err := r.getRulesForGroup(ctx, sg.ID)
+		if err != nil {
+			return nil, err
+		}
+		sg.Rules = rules
+	}
+	return groups, nil
+}
+
 func (r *SecurityGroupRepository) getRulesForGroup(ctx context.Context, groupID uuid.UUID) ([]domain.SecurityRule, error) {
-	query := `SELECT id, group_id, direction, protocol, port_min, port_max, cidr, priority, created_at FROM security_rules WHERE group_id = $1 ORDER BY priority DESC`
+	query := `SELECT id, group_id, direction, protocol, port_min, port_max, cidr, priority, created_at, source_group_id FROM security_rules WHERE group_id = $1 ORDER BY priority DESC`
 	rows, err := r.db.Query(ctx, query, groupID)
 	if err != nil {
 		return nil, err
@@ -274,7 +369,7 @@ func (r *SecurityGroupRepository) getRulesForGroup(ctx context.Context, groupID
 func (r *SecurityGroupRepository) scanSecurityRule(row pgx.Row) (domain.SecurityRule, error) {
 	var rule domain.SecurityRule
 	var direction string
-	if err := row.Scan(&rule.ID, &rule.GroupID, &direction, &rule.Protocol, &rule.PortMin, &rule.PortMax, &rule.CIDR, &rule.Priority, &rule.CreatedAt); err != nil {
+	if err := row.Scan(&rule.ID, &rule.GroupID, &direction, &rule.Protocol, &rule.PortMin, &rule.PortMax, &rule.CIDR, &rule.Priority, &rule.CreatedAt, &rule.SourceGroupID); err != nil {
 		return domain.SecurityRule{}, err
 	}
 	rule.Direction = domain.RuleDirection(direction)
@@ -20,11 +20,13 @@ import (
 const (
 	testSgName         = "test-sg"
 	selectSg           = "SELECT id, user_id, tenant_id, vpc_id, name, description, arn, created_at FROM security_groups"
-	selectRule         = "SELECT id, group_id, direction, protocol, port_min, port_max, cidr, priority, created_at FROM security_rules"
+	selectRule         = "SELECT id, group_id, direction, protocol, port_min, port_max, cidr, priority, created_at, source_group_id FROM security_rules"
 	selectInstanceUser = "SELECT tenant_id FROM instances WHERE id = \\$1"
 	selectSgUser       = "SELECT tenant_id FROM security_groups WHERE id = \\$1"
 )
 
+var ruleColumns = []string{"id", "group_id", "direction", "protocol", "port_min", "port_max", "cidr", "priority", "created_at", "source_group_id"}
+
 func TestSecurityGroupRepositoryCreate(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mock, err := pgxmock.NewPool()
@@ -89,8 +91,8 @@ func TestSecurityGroupRepositoryGetByID(t *testing.T) {
 
 		mock.ExpectQuery(selectRule).
 			WithArgs(id).
-			WillReturnRows(pgxmock.NewRows([]string{"id", "group_id", "direction", "protocol", "port_min", "port_max", "cidr", "priority", "created_at"}).
-				AddRow(uuid.New(), id, string(domain.RuleIngress), "tcp", 80, 80, testutil.TestAnyCIDR, 100, now))
+			WillReturnRows(pgxmock.NewRows(ruleColumns).
+				AddRow(uuid.New(), id, string(domain.RuleIngress), "tcp", 80, 80, testutil.TestAnyCIDR, 100, now, nil))
 
 		sg, err := repo.GetByID(ctx, id)
 		require.NoError(t, err)
@@ -146,8 +148,8 @@ func TestSecurityGroupRepositoryGetByName(t *testing.T) {
 
 		mock.ExpectQuery(selectRule).
 			WithArgs(id).
-			WillReturnRows(pgxmock.NewRows([]string{"id", "group_id", "direction", "protocol", "port_min", "port_max", "cidr", "priority", "created_at"}).
-				AddRow(uuid.New(), id, string(domain.RuleIngress), "tcp", 80, 80, testutil.TestAnyCIDR, 100, now))
+			WillReturnRows(pgxmock.NewRows(ruleColumns).
+				AddRow(uuid.New(), id, string(domain.RuleIngress), "tcp", 80, 80, testutil.TestAnyCIDR, 100, now, nil))
 
 		sg, err := repo.GetByName(ctx, vpcID, name)
 		require.NoError(t, err)
@@ -204,8 +206,8 @@ func TestSecurityGroupRepositoryGetByNameAcrossVPCs(t *testing.T) {
 
 		mock.ExpectQuery(selectRule).
 			WithArgs(id).
-			WillReturnRows(pgxmock.NewRows([]string{"id", "group_id", "direction", "protocol", "port_min", "port_max", "cidr", "priority", "created_at"}).
-				AddRow(uuid.New(), id, string(domain.RuleIngress), "tcp", 80, 80, testutil.TestAnyCIDR, 100, now))
+			WillReturnRows(pgxmock.NewRows(ruleColumns).
+				AddRow(uuid.New(), id, string(domain.RuleIngress), "tcp", 80, 80, testutil.TestAnyCIDR, 100, now, nil))
 
 		sg, err := repo.GetByNameAcrossVPCs(ctx, name)
 		require.NoError(t, err)
@@ -303,7 +305,7 @@ func TestSecurityGroupRepositoryAddRule(t *testing.T) {
 		}
 
 		mock.ExpectExec("INSERT INTO security_rules").
-			WithArgs(rule.ID, rule.GroupID, rule.Direction, rule.Protocol, rule.PortMin, rule.PortMax, rule.CIDR, rule.Priority, rule.CreatedAt).
+			WithArgs(rule.ID, rule.GroupID, rule.Direction, rule.Protocol, rule.PortMin, rule.PortMax, rule.CIDR, rule.Priority, rule.CreatedAt, rule.SourceGroupID).
 			WillReturnResult(pgxmock.NewResult("INSERT", 1))
 
 		err = repo.AddRule(context.Background(), rule)
@@ -380,8 +382,8 @@ func TestSecurityGroupRepositoryGetRuleByID(t *testing.T) {
 		ctx := appcontext.WithTenantID(appcontext.WithUserID(context.Background(), userID), tenantID)
 		now := time.Now()
 
-		rows := pgxmock.NewRows([]string{"id", "group_id", "direction", "protocol", "port_min", "port_max", "cidr", "priority", "created_at"}).
-			AddRow(ruleID, uuid.New(), "ingress", "tcp", 80, 80, "0.0.0.0/0", 100, now)
+		rows := pgxmock.NewRows(ruleColumns).
+			AddRow(ruleID, uuid.New(), "ingress", "tcp", 80, 80, "0.0.0.0/0", 100, now, nil)
 
 		mock.ExpectQuery("SELECT .* FROM security_rules").
 			WithArgs(ruleID, tenantID).
@@ -639,3 +641,50 @@ func TestSecurityGroupRepositoryListInstanceGroups(t *testing.T) {
 		assert.Nil(t, groups)
 	})
 }
+
+func TestSecurityGroupRepositoryListGroupMemberIPs(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewSecurityGroupRepository(mock)
+	groupID := uuid.New()
+
+	mock.ExpectQuery("(?s)SELECT host\\(ip\\).*private_ip.*UNION ALL.*private_ipv6.*UNION ALL.*network_interfaces").
+		WithArgs(groupID).
+		WillReturnRows(pgxmock.NewRows([]string{"host"}).AddRow("10.0.1.5").AddRow("10.0.1.6").AddRow("fd00:1:2:3::5"))
+
+	ips, err := repo.ListGroupMemberIPs(context.Background(), groupID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.1.5", "10.0.1.6", "fd00:1:2:3::5"}, ips)
+}
+
+func TestSecurityGroupRepositoryListReferencingGroups(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewSecurityGroupRepository(mock)
+	tenantID := uuid.New()
+	ctx := appcontext.WithTenantID(context.Background(), tenantID)
+	appID := uuid.New()
+	dbID := uuid.New()
+	now := time.Now()
+
+	mock.ExpectQuery("SELECT sg.id, .* FROM security_groups sg\\s+WHERE sg.tenant_id = \\$2").
+		WithArgs(appID, tenantID).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "tenant_id", "vpc_id", "name", "description", "arn", "created_at"}).
+			AddRow(dbID, uuid.New(), tenantID, uuid.New(), "db-sg", "desc", "arn", now))
+	mock.ExpectQuery(selectRule).
+		WithArgs(dbID).
+		WillReturnRows(pgxmock.NewRows(ruleColumns).
+			AddRow(uuid.New(), dbID, string(domain.RuleIngress), "tcp", 5432, 5432, "", 100, now, &appID))
+
+	groups, err := repo.ListReferencingGroups(ctx, appID)
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	require.Len(t, groups[0].Rules, 1)
+	require.NotNil(t, groups[0].Rules[0].SourceGroupID)
+	assert.Equal(t, appID, *groups[0].Rules[0].SourceGroupID)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -210,10 +210,10 @@ func (r *StorageRepository) SoftDelete(ctx context.Context, bucket, key string)
 
 func (r *StorageRepository) CreateBucket(ctx context.Context, b *domain.Bucket) error {
 	query := `
-		INSERT INTO buckets (id, name, user_id, is_public, versioning_enabled, encryption_enabled, encryption_key_id, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO buckets (id, name, user_id, tenant_id, is_public, versioning_enabled, encryption_enabled, encryption_key_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 	`
-	_, err := r.db.Exec(ctx, query, b.ID, b.Name, b.UserID, b.IsPublic, b.VersioningEnabled, b.EncryptionEnabled, b.EncryptionKeyID, b.CreatedAt)
+	_, err := r.db.Exec(ctx, query, b.ID, b.Name, b.UserID, b.TenantID, b.IsPublic, b.VersioningEnabled, b.EncryptionEnabled, b.EncryptionKeyID, b.CreatedAt)
 	if err != nil {
 		return errors.Wrap(errors.Internal, "failed to create bucket", err)
 	}
@@ -240,7 +240,7 @@ func (r *StorageRepository) GetBucket(ctx context.Context, name string) (*domain
 }
 
 func (r *StorageRepository) DeleteBucket(ctx context.Context, name string) error {
-	query := `DELETE FROM buckets WHERE name = $1`
+	query := deleteWithResourcePolicies(domain.ResourcePolicyBucket, `SELECT name FROM buckets WHERE name = $1`, `DELETE FROM buckets WHERE name = $1`)
 	cmd, err := r.db.Exec(ctx, query, name)
 	if err != nil {
 		return errors.Wrap(errors.Internal, "failed to delete bucket", err)
@@ -351,7 +351,7 @@ func TestStorageRepositoryBucketOps(t *testing.T) {
 		repo := NewStorageRepository(mock)
 		bucket := &domain.Bucket{ID: uuid.New(), Name: "b1", UserID: uuid.New(), CreatedAt: time.Now()}
 
-		mock.ExpectExec("INSERT INTO buckets").WithArgs(bucket.ID, bucket.Name, bucket.UserID, bucket.IsPublic, bucket.VersioningEnabled, bucket.EncryptionEnabled, bucket.EncryptionKeyID, bucket.CreatedAt).
+		mock.ExpectExec("INSERT INTO buckets").WithArgs(bucket.ID, bucket.Name, bucket.UserID, bucket.TenantID, bucket.IsPublic, bucket.VersioningEnabled, bucket.EncryptionEnabled, bucket.EncryptionKeyID, bucket.CreatedAt).
 			WillReturnResult(pgxmock.NewResult("INSERT", 1))
 
 		err = repo.CreateBucket(context.Background(), bucket)
@@ -399,7 +399,7 @@ func TestStorageRepositoryBucketOps(t *testing.T) {
 		repo := NewStorageRepository(mock)
 		name := "b1"
 
-		mock.ExpectExec("DELETE FROM buckets WHERE name = \\$1").
+		mock.ExpectExec("DELETE FROM resource_policies WHERE resource_type = 'bucket' AND resource_id IN \\(SELECT name FROM buckets WHERE name = \\$1\\)\\) DELETE FROM buckets WHERE name = \\$1").
 			WithArgs(name).
 			WillReturnResult(pgxmock.NewResult("DELETE", 1))
 
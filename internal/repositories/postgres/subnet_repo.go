@@ -7,6 +7,7 @@ import (
 	stdlib_errors "errors"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	appcontext "github.com/poyrazk/thecloud/internal/core/context"
 	"github.com/poyrazk/thecloud/internal/core/domain"
 	"github.com/poyrazk/thecloud/internal/errors"
@@ -36,19 +37,19 @@ func (r *SubnetRepository) Create(ctx context.Context, subnet *domain.Subnet) er
 
 func (r *SubnetRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Subnet, error) {
 	userID := appcontext.UserIDFromContext(ctx)
-	query := `SELECT id, user_id, vpc_id, name, cidr_block::text, availability_zone, COALESCE(gateway_ip::text, ''), arn, status, created_at FROM subnets WHERE id = $1 AND user_id = $2`
+	query := `SELECT id, user_id, vpc_id, name, cidr_block::text, COALESCE(ipv6_cidr_block::text, ''), availability_zone, COALESCE(gateway_ip::text, ''), arn, status, created_at FROM subnets WHERE id = $1 AND user_id = $2`
 	return r.scanSubnet(r.db.QueryRow(ctx, query, id, userID))
 }
 
 func (r *SubnetRepository) GetByName(ctx context.Context, vpcID uuid.UUID, name string) (*domain.Subnet, error) {
 	userID := appcontext.UserIDFromContext(ctx)
-	query := `SELECT id, user_id, vpc_id, name, cidr_block::text, availability_zone, COALESCE(gateway_ip::text, ''), arn, status, created_at FROM subnets WHERE vpc_id = $1 AND name = $2 AND user_id = $3`
+	query := `SELECT id, user_id, vpc_id, name, cidr_block::text, COALESCE(ipv6_cidr_block::text, ''), availability_zone, COALESCE(gateway_ip::text, ''), arn, status, created_at FROM subnets WHERE vpc_id = $1 AND name = $2 AND user_id = $3`
 	return r.scanSubnet(r.db.QueryRow(ctx, query, vpcID, name, userID))
 }
 
 func (r *SubnetRepository) ListByVPC(ctx context.Context, vpcID uuid.UUID) ([]*domain.Subnet, error) {
 	userID := appcontext.UserIDFromContext(ctx)
-	query := `SELECT id, user_id, vpc_id, name, cidr_block::text, availability_zone, COALESCE(gateway_ip::text, ''), arn, status, created_at FROM subnets WHERE vpc_id = $1 AND user_id = $2 ORDER BY created_at DESC`
+	query := `SELECT id, user_id, vpc_id, name, cidr_block::text, COALESCE(ipv6_cidr_block::text, ''), availability_zone, COALESCE(gateway_ip::text, ''), arn, status, created_at FROM subnets WHERE vpc_id = $1 AND user_id = $2 ORDER BY created_at DESC`
 	rows, err := r.db.Query(ctx, query, vpcID, userID)
 	if err != nil {
 		return nil, errors.Wrap(errors.Internal, "failed to list subnets", err)
@@ -58,7 +59,7 @@ func (r *SubnetRepository) ListByVPC(ctx context.Context, vpcID uuid.UUID) ([]*d
 
 func (r *SubnetRepository) scanSubnet(row pgx.Row) (*domain.Subnet, error) {
 	var s domain.Subnet
-	err := row.Scan(&s.ID, &s.UserID, &s.VPCID, &s.Name, &s.CIDRBlock, &s.AvailabilityZone, &s.GatewayIP, &s.ARN, &s.Status, &s.CreatedAt)
+	err := row.Scan(&s.ID, &s.UserID, &s.VPCID, &s.Name, &s.CIDRBlock, &s.IPv6CIDRBlock, &s.AvailabilityZone, &s.GatewayIP, &s.ARN, &s.Status, &s.CreatedAt)
 	if err != nil {
 		if stdlib_errors.Is(err, pgx.ErrNoRows) {
 			return nil, errors.New(errors.NotFound, "subnet not found")
@@ -81,6 +82,24 @@ func (r *SubnetRepository) scanSubnets(rows pgx.Rows) ([]*domain.Subnet, error)
 	return subnets, nil
 }
 
+// Update modifies a subnet's name and IPv6 block.
+func (r *SubnetRepository) Update(ctx context.Context, subnet *domain.Subnet) error {
+	userID := appcontext.UserIDFromContext(ctx)
+	query := `UPDATE subnets SET name = $1, ipv6_cidr_block = NULLIF($2, '')::cidr WHERE id = $3 AND user_id = $4`
+	cmd, err := r.db.Exec(ctx, query, subnet.Name, subnet.IPv6CIDRBlock, subnet.ID, userID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if stdlib_errors.As(err, &pgErr) && pgErr.Code == uniqueViolationSQLState {
+			return errors.New(errors.Conflict, "ipv6 cidr block already in use in this vpc")
+		}
+		return errors.Wrap(errors.Internal, "failed to update subnet", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, "subnet not found")
+	}
+	return nil
+}
+
 func (r *SubnetRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	userID := appcontext.UserIDFromContext(ctx)
 	query := `DELETE FROM subnets WHERE id = $1 AND user_id = $2`
@@ -8,6 +8,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/pashagolub/pgxmock/v3"
 	appcontext "github.com/poyrazk/thecloud/internal/core/context"
 	"github.com/poyrazk/thecloud/internal/core/domain"
@@ -20,7 +21,7 @@ import (
 const (
 	testSubnetName    = "subnet-1"
 	testAZ            = "us-east-1a"
-	selectSubnet      = "SELECT id, user_id, vpc_id, name, cidr_block::text, availability_zone, COALESCE\\(gateway_ip::text, ''\\), arn, status, created_at FROM subnets"
+	selectSubnet      = "SELECT id, user_id, vpc_id, name, cidr_block::text, COALESCE\\(ipv6_cidr_block::text, ''\\), availability_zone, COALESCE\\(gateway_ip::text, ''\\), arn, status, created_at FROM subnets"
 	deleteSubnetQuery = "DELETE FROM subnets"
 )
 
@@ -84,8 +85,8 @@ func TestSubnetRepositoryGetByID(t *testing.T) {
 
 		mock.ExpectQuery(selectSubnet).
 			WithArgs(id, userID).
-			WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "vpc_id", "name", "cidr_block", "availability_zone", "gateway_ip", "arn", "status", "created_at"}).
-				AddRow(id, userID, uuid.New(), testSubnetName, testutil.TestSubnetCIDR, testAZ, testutil.TestGatewayIP, "arn", "available", now))
+			WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "vpc_id", "name", "cidr_block", "ipv6_cidr_block", "availability_zone", "gateway_ip", "arn", "status", "created_at"}).
+				AddRow(id, userID, uuid.New(), testSubnetName, testutil.TestSubnetCIDR, "", testAZ, testutil.TestGatewayIP, "arn", "available", now))
 
 		s, err := repo.GetByID(ctx, id)
 		require.NoError(t, err)
@@ -104,7 +105,7 @@ func TestSubnetRepositoryGetByID(t *testing.T) {
 		userID := uuid.New()
 		ctx := appcontext.WithUserID(context.Background(), userID)
 
-		mock.ExpectQuery("SELECT id, user_id, vpc_id, name, cidr_block::text, availability_zone, COALESCE\\(gateway_ip::text, ''\\), arn, status, created_at FROM subnets").
+		mock.ExpectQuery("SELECT id, user_id, vpc_id, name, cidr_block::text, COALESCE\\(ipv6_cidr_block::text, ''\\), availability_zone, COALESCE\\(gateway_ip::text, ''\\), arn, status, created_at FROM subnets").
 			WithArgs(id, userID).
 			WillReturnError(pgx.ErrNoRows)
 
@@ -154,8 +155,8 @@ func TestSubnetRepositoryGetByName(t *testing.T) {
 
 		mock.ExpectQuery(selectSubnet).
 			WithArgs(vpcID, name, userID).
-			WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "vpc_id", "name", "cidr_block", "availability_zone", "gateway_ip", "arn", "status", "created_at"}).
-				AddRow(id, userID, vpcID, name, testutil.TestSubnetCIDR, testAZ, testutil.TestGatewayIP, "arn", "available", now))
+			WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "vpc_id", "name", "cidr_block", "ipv6_cidr_block", "availability_zone", "gateway_ip", "arn", "status", "created_at"}).
+				AddRow(id, userID, vpcID, name, testutil.TestSubnetCIDR, "", testAZ, testutil.TestGatewayIP, "arn", "available", now))
 
 		s, err := repo.GetByName(ctx, vpcID, name)
 		require.NoError(t, err)
@@ -204,8 +205,8 @@ func TestSubnetRepositoryListByVPC(t *testing.T) {
 
 		mock.ExpectQuery(selectSubnet).
 			WithArgs(vpcID, userID).
-			WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "vpc_id", "name", "cidr_block", "availability_zone", "gateway_ip", "arn", "status", "created_at"}).
-				AddRow(uuid.New(), userID, vpcID, testSubnetName, testutil.TestSubnetCIDR, testAZ, testutil.TestGatewayIP, "arn", "available", now))
+			WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "vpc_id", "name", "cidr_block", "ipv6_cidr_block", "availability_zone", "gateway_ip", "arn", "status", "created_at"}).
+				AddRow(uuid.New(), userID, vpcID, testSubnetName, testutil.TestSubnetCIDR, "", testAZ, testutil.TestGatewayIP, "arn", "available", now))
 
 		subnets, err := repo.ListByVPC(ctx, vpcID)
 		require.NoError(t, err)
@@ -245,8 +246,8 @@ func TestSubnetRepositoryListByVPC(t *testing.T) {
 
 		mock.ExpectQuery(selectSubnet).
 			WithArgs(vpcID, userID).
-			WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "vpc_id", "name", "cidr_block", "availability_zone", "gateway_ip", "arn", "status", "created_at"}).
-				AddRow("invalid-uuid", userID, vpcID, testSubnetName, testutil.TestSubnetCIDR, testAZ, testutil.TestGatewayIP, "arn", "available", now))
+			WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "vpc_id", "name", "cidr_block", "ipv6_cidr_block", "availability_zone", "gateway_ip", "arn", "status", "created_at"}).
+				AddRow("invalid-uuid", userID, vpcID, testSubnetName, testutil.TestSubnetCIDR, "", testAZ, testutil.TestGatewayIP, "arn", "available", now))
 
 		subnets, err := repo.ListByVPC(ctx, vpcID)
 		require.Error(t, err)
@@ -254,6 +255,44 @@ func TestSubnetRepositoryListByVPC(t *testing.T) {
 	})
 }
 
+func TestSubnetRepositoryUpdate(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		repo := NewSubnetRepository(mock)
+		userID := uuid.New()
+		ctx := appcontext.WithUserID(context.Background(), userID)
+		s := &domain.Subnet{ID: uuid.New(), Name: testSubnetName, IPv6CIDRBlock: "fd00:1:2:3::/64"}
+
+		mock.ExpectExec("UPDATE subnets SET name = \\$1, ipv6_cidr_block").
+			WithArgs(s.Name, s.IPv6CIDRBlock, s.ID, userID).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+		require.NoError(t, repo.Update(ctx, s))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("duplicate ipv6 block", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		repo := NewSubnetRepository(mock)
+		userID := uuid.New()
+		ctx := appcontext.WithUserID(context.Background(), userID)
+		s := &domain.Subnet{ID: uuid.New(), Name: testSubnetName, IPv6CIDRBlock: "fd00:1:2:3::/64"}
+
+		mock.ExpectExec("UPDATE subnets").
+			WithArgs(s.Name, s.IPv6CIDRBlock, s.ID, userID).
+			WillReturnError(&pgconn.PgError{Code: uniqueViolationSQLState})
+
+		err = repo.Update(ctx, s)
+		assert.True(t, theclouderrors.Is(err, theclouderrors.Conflict))
+	})
+}
+
 func TestSubnetRepositoryDelete(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mock, err := pgxmock.NewPool()
@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"context"
+	stdlib_errors "errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/errors"
+)
+
+const tenantInvitationColumns = `id, tenant_id, email, role, status, invited_by, token_hash, accepted_by, expires_at, responded_at, created_at, updated_at`
+
+// TenantInvitationRepo persists tenant invitations in Postgres.
+type TenantInvitationRepo struct {
+	db DB
+}
+
+// NewTenantInvitationRepo constructs a TenantInvitationRepo.
+func NewTenantInvitationRepo(db DB) *TenantInvitationRepo {
+	return &TenantInvitationRepo{db: db}
+}
+
+func (r *TenantInvitationRepo) Create(ctx context.Context, inv *domain.TenantInvitation) error {
+	query := `
+		INSERT INTO tenant_invitations (id, tenant_id, email, role, status, invited_by, token_hash, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+	_, err := r.db.Exec(ctx, query,
+		inv.ID, inv.TenantID, inv.Email, inv.Role, string(inv.Status), inv.InvitedBy,
+		inv.TokenHash, inv.ExpiresAt, inv.CreatedAt, inv.UpdatedAt,
+	)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if stdlib_errors.As(err, &pgErr) && pgErr.Code == uniqueViolationSQLState {
+			return errors.Wrap(errors.Conflict, "a pending invitation already exists for this email", err)
+		}
+		return errors.Wrap(errors.Internal, "failed to create tenant invitation", err)
+	}
+	return nil
+}
+
+func (r *TenantInvitationRepo) GetByID(ctx context.Context, tenantID, id uuid.UUID) (*domain.TenantInvitation, error) {
+	query := `SELECT ` + tenantInvitationColumns + ` FROM tenant_invitations WHERE id = $1 AND tenant_id = $2`
+	return r.scanInvitation(r.db.QueryRow(ctx, query, id, tenantID))
+}
+
+func (r *TenantInvitationRepo) GetByTokenHash(ctx context.Context, hash string) (*domain.TenantInvitation, error) {
+	query := `SELECT ` + tenantInvitationColumns + ` FROM tenant_invitations WHERE token_hash = $1`
+	return r.scanInvitation(r.db.QueryRow(ctx, query, hash))
+}
+
+func (r *TenantInvitationRepo) ListPendingByTenant(ctx context.Context, tenantID uuid.UUID) ([]domain.TenantInvitation, error) {
+	query := `SELECT ` + tenantInvitationColumns + ` FROM tenant_invitations WHERE tenant_id = $1 AND status = 'pending' ORDER BY created_at DESC`
+	rows, err := r.db.Query(ctx, query, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list tenant invitations", err)
+	}
+	return r.scanInvitations(rows)
+}
+
+func (r *TenantInvitationRepo) ListPendingByEmail(ctx context.Context, email string) ([]domain.TenantInvitation, error) {
+	query := `SELECT ` + tenantInvitationColumns + ` FROM tenant_invitations WHERE email = $1 AND status = 'pending' AND expires_at > NOW() ORDER BY created_at DESC`
+	rows, err := r.db.Query(ctx, query, email)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list invitations", err)
+	}
+	return r.scanInvitations(rows)
+}
+
+func (r *TenantInvitationRepo) Update(ctx context.Context, inv *domain.TenantInvitation) error {
+	query := `
+		UPDATE tenant_invitations
+		SET status = $2, token_hash = $3, accepted_by = $4, expires_at = $5, responded_at = $6, updated_at = $7
+		WHERE id = $1 AND status = 'pending'
+	`
+	result, err := r.db.Exec(ctx, query,
+		inv.ID, string(inv.Status), inv.TokenHash, inv.AcceptedBy, inv.ExpiresAt, inv.RespondedAt, inv.UpdatedAt,
+	)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to update tenant invitation", err)
+	}
+	if result.RowsAffected() == 0 {
+		return errors.New(errors.Conflict, "invitation is no longer pending")
+	}
+	return nil
+}
+
+func (r *TenantInvitationRepo) scanInvitation(row pgx.Row) (*domain.TenantInvitation, error) {
+	var inv domain.TenantInvitation
+	var status string
+	var invitedBy *uuid.UUID
+	err := row.Scan(
+		&inv.ID, &inv.TenantID, &inv.Email, &inv.Role, &status, &invitedBy, &inv.TokenHash,
+		&inv.AcceptedBy, &inv.ExpiresAt, &inv.RespondedAt, &inv.CreatedAt, &inv.UpdatedAt,
+	)
+	if err != nil {
+		if stdlib_errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New(errors.NotFound, "invitation not found")
+		}
+		return nil, errors.Wrap(errors.Internal, "failed to scan tenant invitation", err)
+	}
+	inv.Status = domain.InvitationStatus(status)
+	if invitedBy != nil {
+		inv.InvitedBy = *invitedBy
+	}
+	return &inv, nil
+}
+
+func (r *TenantInvitationRepo) scanInvitations(rows pgx.Rows) ([]domain.TenantInvitation, error) {
+	defer rows.Close()
+	var invitations []domain.TenantInvitation
+	for rows.Next() {
+		inv, err := r.scanInvitation(rows)
+		if err != nil {
+			return nil, err
+		}
+		invitations = append(invitations, *inv)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to iterate tenant invitations", err)
+	}
+	return invitations, nil
+}
@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestInvitation() *domain.TenantInvitation {
+	now := time.Now()
+	return &domain.TenantInvitation{
+		ID:        uuid.New(),
+		TenantID:  uuid.New(),
+		Email:     "invitee@test.com",
+		Role:      "member",
+		Status:    domain.InvitationPending,
+		InvitedBy: uuid.New(),
+		TokenHash: "hash",
+		ExpiresAt: now.Add(time.Hour),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func TestTenantInvitationRepo_Create(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewTenantInvitationRepo(mock)
+	inv := newTestInvitation()
+
+	mock.ExpectExec("INSERT INTO tenant_invitations").
+		WithArgs(inv.ID, inv.TenantID, inv.Email, inv.Role, "pending", inv.InvitedBy, inv.TokenHash, inv.ExpiresAt, inv.CreatedAt, inv.UpdatedAt).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	require.NoError(t, repo.Create(context.Background(), inv))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTenantInvitationRepo_CreateDuplicatePending(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewTenantInvitationRepo(mock)
+	inv := newTestInvitation()
+
+	mock.ExpectExec("INSERT INTO tenant_invitations").
+		WithArgs(inv.ID, inv.TenantID, inv.Email, inv.Role, "pending", inv.InvitedBy, inv.TokenHash, inv.ExpiresAt, inv.CreatedAt, inv.UpdatedAt).
+		WillReturnError(&pgconn.PgError{Code: uniqueViolationSQLState})
+
+	err = repo.Create(context.Background(), inv)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.Conflict))
+}
+
+func TestTenantInvitationRepo_GetByTokenHash(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewTenantInvitationRepo(mock)
+	inv := newTestInvitation()
+
+	mock.ExpectQuery("SELECT .* FROM tenant_invitations WHERE token_hash").
+		WithArgs("hash").
+		WillReturnRows(pgxmock.NewRows([]string{"id", "tenant_id", "email", "role", "status", "invited_by", "token_hash", "accepted_by", "expires_at", "responded_at", "created_at", "updated_at"}).
+			AddRow(inv.ID, inv.TenantID, inv.Email, inv.Role, "pending", &inv.InvitedBy, inv.TokenHash, (*uuid.UUID)(nil), inv.ExpiresAt, (*time.Time)(nil), inv.CreatedAt, inv.UpdatedAt))
+
+	got, err := repo.GetByTokenHash(context.Background(), "hash")
+	require.NoError(t, err)
+	assert.Equal(t, inv.ID, got.ID)
+	assert.Equal(t, domain.InvitationPending, got.Status)
+	assert.Equal(t, inv.InvitedBy, got.InvitedBy)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTenantInvitationRepo_UpdateNoLongerPending(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewTenantInvitationRepo(mock)
+	inv := newTestInvitation()
+	inv.Status = domain.InvitationAccepted
+
+	mock.ExpectExec("UPDATE tenant_invitations").
+		WithArgs(inv.ID, "accepted", inv.TokenHash, inv.AcceptedBy, inv.ExpiresAt, inv.RespondedAt, inv.UpdatedAt).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+	err = repo.Update(context.Background(), inv)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errors.Conflict))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -173,98 +173,36 @@ func (r *TenantRepo) ListUserTenants(ctx context.Context, userID uuid.UUID) ([]d
 	return tenants, nil
 }
 
-func (r *TenantRepo) GetQuota(ctx context.Context, tenantID uuid.UUID) (*domain.TenantQuota, error) {
-	query := `
-		SELECT 
-			tq.tenant_id, 
-			tq.max_instances, 
-			tq.max_vpcs, 
-			tq.max_storage_gb, 
-			tq.max_memory_gb, 
-			tq.max_vcpus,
-			(SELECT COUNT(*) FROM instances WHERE tenant_id = tq.tenant_id AND status != 'DELETED') as used_instances,
-			(SELECT COUNT(*) FROM vpcs WHERE tenant_id = tq.tenant_id) as used_vpcs,
-			(SELECT COALESCE(SUM(size_gb), 0) FROM volumes WHERE tenant_id = tq.tenant_id AND status != 'deleted') as used_storage_gb,
-			tq.used_memory_gb,
-			tq.used_vcpus
-		FROM tenant_quotas tq 
-		WHERE tq.tenant_id = $1
-	`
-	var q domain.TenantQuota
-	err := r.getDB(ctx).QueryRow(ctx, query, tenantID).Scan(
-		&q.TenantID,
-		&q.MaxInstances,
-		&q.MaxVPCs,
-		&q.MaxStorageGB,
-		&q.MaxMemoryGB,
-		&q.MaxVCPUs,
-		&q.UsedInstances,
-		&q.UsedVPCs,
-		&q.UsedStorageGB,
-		&q.UsedMemoryGB,
-		&q.UsedVCPUs,
-	)
+func (r *TenantRepo) UpdateMemberRole(ctx context.Context, tenantID, userID uuid.UUID, role string) error {
+	result, err := r.getDB(ctx).Exec(ctx, "UPDATE tenant_members SET role = $3 WHERE tenant_id = $1 AND user_id = $2", tenantID, userID, role)
 	if err != nil {
-		if stdlib_errors.Is(err, pgx.ErrNoRows) {
-			return nil, errors.New(errors.NotFound, "quota not found")
-		}
-		return nil, errors.Wrap(errors.Internal, "failed to get quota", err)
+		return errors.Wrap(errors.Internal, "failed to update member role", err)
 	}
-	return &q, nil
-}
-
-func (r *TenantRepo) UpdateQuota(ctx context.Context, quota *domain.TenantQuota) error {
-	query := `
-		INSERT INTO tenant_quotas (tenant_id, max_instances, max_vpcs, max_storage_gb, max_memory_gb, max_vcpus)
-		VALUES ($1, $2, $3, $4, $5, $6)
-		ON CONFLICT (tenant_id) DO UPDATE
-		SET max_instances = $2, max_vpcs = $3, max_storage_gb = $4, max_memory_gb = $5, max_vcpus = $6
-	`
-	_, err := r.getDB(ctx).Exec(ctx, query,
-		quota.TenantID, quota.MaxInstances, quota.MaxVPCs, quota.MaxStorageGB, quota.MaxMemoryGB, quota.MaxVCPUs,
-	)
-	if err != nil {
-		return errors.Wrap(errors.Internal, "failed to update quota", err)
+	if result.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, "member not found")
 	}
 	return nil
 }
 
-func (r *TenantRepo) IncrementUsage(ctx context.Context, tenantID uuid.UUID, resource string, amount int) error {
-	var query string
-	switch resource {
-	case "vcpus":
-		query = `UPDATE tenant_quotas SET used_vcpus = used_vcpus + $1 WHERE tenant_id = $2`
-	case "memory":
-		query = `UPDATE tenant_quotas SET used_memory_gb = used_memory_gb + $1 WHERE tenant_id = $2`
-	// For instances, vpcs, storage we rely on live counts for now, but if we wanted to switch:
-	// case "instances":
-	// 	query = `UPDATE tenant_quotas SET used_instances = used_instances + $1 WHERE tenant_id = $2`
-	default:
-		// No-op for resources tracked via live count
-		return nil
-	}
-
-	_, err := r.getDB(ctx).Exec(ctx, query, amount, tenantID)
+func (r *TenantRepo) TransferOwnership(ctx context.Context, tenantID, currentOwnerID, newOwnerID uuid.UUID) error {
+	// A single statement keeps tenants.owner_id and both member roles consistent.
+	query := `
+		WITH moved AS (
+			UPDATE tenants SET owner_id = $3, updated_at = NOW()
+			WHERE id = $1 AND owner_id = $2
+			RETURNING id
+		)
+		UPDATE tenant_members tm
+		SET role = CASE WHEN tm.user_id = $3 THEN 'owner' ELSE 'admin' END
+		FROM moved
+		WHERE tm.tenant_id = moved.id AND tm.user_id IN ($2, $3)
+	`
+	result, err := r.getDB(ctx).Exec(ctx, query, tenantID, currentOwnerID, newOwnerID)
 	if err != nil {
-		return errors.Wrap(errors.Internal, "failed to increment quota usage", err)
+		return errors.Wrap(errors.Internal, "failed to transfer tenant ownership", err)
 	}
-	return nil
-}
-
-func (r *TenantRepo) DecrementUsage(ctx context.Context, tenantID uuid.UUID, resource string, amount int) error {
-	var query string
-	switch resource {
-	case "vcpus":
-		query = `UPDATE tenant_quotas SET used_vcpus = GREATEST(0, used_vcpus - $1) WHERE tenant_id = $2`
-	case "memory":
-		query = `UPDATE tenant_quotas SET used_memory_gb = GREATEST(0, used_memory_gb - $1) WHERE tenant_id = $2`
-	default:
-		return nil
-	}
-
-	_, err := r.getDB(ctx).Exec(ctx, query, amount, tenantID)
-	if err != nil {
-		return errors.Wrap(errors.Internal, "failed to decrement quota usage", err)
+	if result.RowsAffected() == 0 {
+		return errors.New(errors.Conflict, "tenant ownership changed concurrently")
 	}
 	return nil
 }
@@ -245,62 +245,6 @@ func TestTenantRepoListUserTenants(t *testing.T) {
 	assert.Nil(t, tenants)
 }
 
-func TestTenantRepoGetQuotaUpdateQuota(t *testing.T) {
-	mock, err := pgxmock.NewPool()
-	require.NoError(t, err)
-	defer mock.Close()
-
-	repo := NewTenantRepo(mock)
-	tenantID := uuid.New()
-
-	mock.ExpectQuery("FROM tenant_quotas tq").
-		WithArgs(tenantID).
-		WillReturnRows(pgxmock.NewRows([]string{
-			"tenant_id",
-			"max_instances",
-			"max_vpcs",
-			"max_storage_gb",
-			"max_memory_gb",
-			"max_vcpus",
-			"used_instances",
-			"used_vpcs",
-			"used_storage_gb",
-			"used_memory_gb",
-			"used_vcpus",
-		}).AddRow(tenantID, 10, 5, 100, 32, 16, 2, 1, 50, 0, 0))
-
-	quota, err := repo.GetQuota(context.Background(), tenantID)
-	require.NoError(t, err)
-	assert.NotNil(t, quota)
-	assert.Equal(t, 10, quota.MaxInstances)
-	require.NoError(t, mock.ExpectationsWereMet())
-
-	mock.ExpectQuery("FROM tenant_quotas tq").
-		WithArgs(tenantID).
-		WillReturnError(pgx.ErrNoRows)
-
-	quota, err = repo.GetQuota(context.Background(), tenantID)
-	require.Error(t, err)
-	assert.Nil(t, quota)
-
-	q := &domain.TenantQuota{
-		TenantID:     tenantID,
-		MaxInstances: 10,
-		MaxVPCs:      5,
-		MaxStorageGB: 100,
-		MaxMemoryGB:  32,
-		MaxVCPUs:     16,
-	}
-
-	mock.ExpectExec("INSERT INTO tenant_quotas").
-		WithArgs(q.TenantID, q.MaxInstances, q.MaxVPCs, q.MaxStorageGB, q.MaxMemoryGB, q.MaxVCPUs).
-		WillReturnResult(pgxmock.NewResult("INSERT", 1))
-
-	err = repo.UpdateQuota(context.Background(), q)
-	require.NoError(t, err)
-	require.NoError(t, mock.ExpectationsWereMet())
-}
-
 func TestTenantRepoExecErrors(t *testing.T) {
 	mock, err := pgxmock.NewPool()
 	require.NoError(t, err)
@@ -343,3 +287,53 @@ func TestTenantRepoExecErrors(t *testing.T) {
 	require.Error(t, err)
 	assert.Nil(t, members2)
 }
+
+func TestTenantRepoUpdateMemberRole(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewTenantRepo(mock)
+	tenantID, userID := uuid.New(), uuid.New()
+
+	mock.ExpectExec("UPDATE tenant_members SET role").
+		WithArgs(tenantID, userID, "admin").
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	err = repo.UpdateMemberRole(context.Background(), tenantID, userID, "admin")
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTenantRepoTransferOwnership(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewTenantRepo(mock)
+	tenantID, oldOwner, newOwner := uuid.New(), uuid.New(), uuid.New()
+
+	mock.ExpectExec("WITH moved AS").
+		WithArgs(tenantID, oldOwner, newOwner).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+
+	err = repo.TransferOwnership(context.Background(), tenantID, oldOwner, newOwner)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTenantRepoTransferOwnershipStaleOwner(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewTenantRepo(mock)
+	tenantID, oldOwner, newOwner := uuid.New(), uuid.New(), uuid.New()
+
+	mock.ExpectExec("WITH moved AS").
+		WithArgs(tenantID, oldOwner, newOwner).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+	err = repo.TransferOwnership(context.Background(), tenantID, oldOwner, newOwner)
+	require.Error(t, err)
+}
@@ -136,10 +136,10 @@ func SetupTestUser(t *testing.T, db *pgxpool.Pool) context.Context {
 	`, tenantID, userID)
 	require.NoError(t, err)
 
-	// Create default quota for the test tenant
+	// Raise the compute quotas of the test tenant above the registry defaults
 	_, err = db.Exec(ctx, `
-		INSERT INTO tenant_quotas (tenant_id, max_instances, max_vpcs, max_storage_gb, max_memory_gb, max_vcpus)
-		VALUES ($1, 10, 10, 1000, 40960, 100)
+		INSERT INTO tenant_resource_quotas (tenant_id, resource, quota_limit)
+		VALUES ($1, 'instances', 10), ($1, 'vpcs', 10), ($1, 'storage', 1000), ($1, 'memory', 40960), ($1, 'vcpus', 100)
 	`, tenantID)
 	require.NoError(t, err)
 
@@ -0,0 +1,409 @@
+package postgres
+
+import (
+	"context"
+	stdlib_errors "errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/errors"
+)
+
+const (
+	transitGatewayColumns  = `id, user_id, tenant_id, name, status, bridge_name, vxlan_id, default_route_table_id, arn, created_at`
+	tgwAttachmentColumns   = `id, transit_gateway_id, vpc_id, user_id, tenant_id, route_table_id, status, created_at`
+	tgwRouteTableColumns   = `rt.id, rt.transit_gateway_id, rt.name, rt.is_default, rt.created_at`
+	tgwRouteTableNotFound  = "transit gateway route table not found"
+	tgwAttachmentNotFound  = "transit gateway attachment not found"
+	transitGatewayNotFound = "transit gateway not found"
+)
+
+// TransitGatewayRepository provides PostgreSQL-backed persistence for transit gateways.
+type TransitGatewayRepository struct {
+	db DB
+}
+
+// NewTransitGatewayRepository creates a TransitGatewayRepository using the provided DB.
+func NewTransitGatewayRepository(db DB) *TransitGatewayRepository {
+	return &TransitGatewayRepository{db: db}
+}
+
+// Create inserts a new transit gateway record.
+func (r *TransitGatewayRepository) Create(ctx context.Context, tgw *domain.TransitGateway) error {
+	query := `
+		INSERT INTO transit_gateways (id, user_id, tenant_id, name, status, bridge_name, vxlan_id, default_route_table_id, arn, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+	_, err := r.db.Exec(ctx, query, tgw.ID, tgw.UserID, tgw.TenantID, tgw.Name, tgw.Status, tgw.BridgeName, tgw.VXLANID, tgw.DefaultRouteTableID, tgw.ARN, tgw.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if stdlib_errors.As(err, &pgErr) && pgErr.Code == uniqueViolationSQLState {
+			return errors.New(errors.Conflict, "a transit gateway with this name already exists")
+		}
+		return errors.Wrap(errors.Internal, "failed to create transit gateway", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a transit gateway by ID.
+func (r *TransitGatewayRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.TransitGateway, error) {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `SELECT ` + transitGatewayColumns + ` FROM transit_gateways WHERE id = $1 AND tenant_id = $2`
+	return r.scanTransitGateway(r.db.QueryRow(ctx, query, id, tenantID))
+}
+
+// List returns all transit gateways of the current tenant.
+func (r *TransitGatewayRepository) List(ctx context.Context) ([]*domain.TransitGateway, error) {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `SELECT ` + transitGatewayColumns + ` FROM transit_gateways WHERE tenant_id = $1 ORDER BY created_at DESC`
+	rows, err := r.db.Query(ctx, query, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list transit gateways", err)
+	}
+	defer rows.Close()
+
+	var gateways []*domain.TransitGateway
+	for rows.Next() {
+		tgw, err := r.scanTransitGateway(rows)
+		if err != nil {
+			return nil, err
+		}
+		gateways = append(gateways, tgw)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to iterate transit gateways", err)
+	}
+	return gateways, nil
+}
+
+// Update persists the status and default route table of a transit gateway.
+func (r *TransitGatewayRepository) Update(ctx context.Context, tgw *domain.TransitGateway) error {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `UPDATE transit_gateways SET status = $1, default_route_table_id = $2 WHERE id = $3 AND tenant_id = $4`
+	cmd, err := r.db.Exec(ctx, query, tgw.Status, tgw.DefaultRouteTableID, tgw.ID, tenantID)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to update transit gateway", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, transitGatewayNotFound)
+	}
+	return nil
+}
+
+// Delete removes a transit gateway record together with its route tables.
+func (r *TransitGatewayRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	cmd, err := r.db.Exec(ctx, `DELETE FROM transit_gateways WHERE id = $1 AND tenant_id = $2`, id, tenantID)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to delete transit gateway", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, transitGatewayNotFound)
+	}
+	return nil
+}
+
+// CreateAttachment inserts a new VPC attachment record.
+func (r *TransitGatewayRepository) CreateAttachment(ctx context.Context, att *domain.TransitGatewayAttachment) error {
+	query := `
+		INSERT INTO transit_gateway_attachments (id, transit_gateway_id, vpc_id, user_id, tenant_id, route_table_id, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	_, err := r.db.Exec(ctx, query, att.ID, att.TransitGatewayID, att.VPCID, att.UserID, att.TenantID, att.RouteTableID, att.Status, att.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if stdlib_errors.As(err, &pgErr) && pgErr.Code == uniqueViolationSQLState {
+			return errors.New(errors.Conflict, "the VPC is already attached to this transit gateway")
+		}
+		return errors.Wrap(errors.Internal, "failed to create transit gateway attachment", err)
+	}
+	return nil
+}
+
+// GetAttachment retrieves a VPC attachment by ID.
+func (r *TransitGatewayRepository) GetAttachment(ctx context.Context, id uuid.UUID) (*domain.TransitGatewayAttachment, error) {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `SELECT ` + tgwAttachmentColumns + ` FROM transit_gateway_attachments WHERE id = $1 AND tenant_id = $2`
+	return r.scanAttachment(r.db.QueryRow(ctx, query, id, tenantID))
+}
+
+// ListAttachments returns all VPC attachments of a transit gateway.
+func (r *TransitGatewayRepository) ListAttachments(ctx context.Context, tgwID uuid.UUID) ([]*domain.TransitGatewayAttachment, error) {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `SELECT ` + tgwAttachmentColumns + ` FROM transit_gateway_attachments WHERE transit_gateway_id = $1 AND tenant_id = $2 ORDER BY created_at`
+	rows, err := r.db.Query(ctx, query, tgwID, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list transit gateway attachments", err)
+	}
+	defer rows.Close()
+
+	var attachments []*domain.TransitGatewayAttachment
+	for rows.Next() {
+		att, err := r.scanAttachment(rows)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, att)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to iterate transit gateway attachments", err)
+	}
+	return attachments, nil
+}
+
+// UpdateAttachment persists the status and route table association of an attachment.
+func (r *TransitGatewayRepository) UpdateAttachment(ctx context.Context, att *domain.TransitGatewayAttachment) error {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `UPDATE transit_gateway_attachments SET status = $1, route_table_id = $2 WHERE id = $3 AND tenant_id = $4`
+	cmd, err := r.db.Exec(ctx, query, att.Status, att.RouteTableID, att.ID, tenantID)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to update transit gateway attachment", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, tgwAttachmentNotFound)
+	}
+	return nil
+}
+
+// DeleteAttachment removes an attachment; its routes and propagations cascade.
+func (r *TransitGatewayRepository) DeleteAttachment(ctx context.Context, id uuid.UUID) error {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	cmd, err := r.db.Exec(ctx, `DELETE FROM transit_gateway_attachments WHERE id = $1 AND tenant_id = $2`, id, tenantID)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to delete transit gateway attachment", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, tgwAttachmentNotFound)
+	}
+	return nil
+}
+
+// CreateRouteTable inserts a new transit gateway route table.
+func (r *TransitGatewayRepository) CreateRouteTable(ctx context.Context, rt *domain.TransitGatewayRouteTable) error {
+	query := `
+		INSERT INTO transit_gateway_route_tables (id, transit_gateway_id, name, is_default, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`
+	_, err := r.db.Exec(ctx, query, rt.ID, rt.TransitGatewayID, rt.Name, rt.IsDefault, rt.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if stdlib_errors.As(err, &pgErr) && pgErr.Code == uniqueViolationSQLState {
+			return errors.New(errors.Conflict, "a route table with this name already exists on the transit gateway")
+		}
+		return errors.Wrap(errors.Internal, "failed to create transit gateway route table", err)
+	}
+	return nil
+}
+
+// GetRouteTable retrieves a transit gateway route table by ID.
+func (r *TransitGatewayRepository) GetRouteTable(ctx context.Context, id uuid.UUID) (*domain.TransitGatewayRouteTable, error) {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `
+		SELECT ` + tgwRouteTableColumns + `
+		FROM transit_gateway_route_tables rt
+		JOIN transit_gateways t ON rt.transit_gateway_id = t.id
+		WHERE rt.id = $1 AND t.tenant_id = $2
+	`
+	var rt domain.TransitGatewayRouteTable
+	err := r.db.QueryRow(ctx, query, id, tenantID).Scan(&rt.ID, &rt.TransitGatewayID, &rt.Name, &rt.IsDefault, &rt.CreatedAt)
+	if err != nil {
+		if stdlib_errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New(errors.NotFound, tgwRouteTableNotFound)
+		}
+		return nil, errors.Wrap(errors.Internal, "failed to get transit gateway route table", err)
+	}
+	return &rt, nil
+}
+
+// ListRouteTables returns all route tables of a transit gateway.
+func (r *TransitGatewayRepository) ListRouteTables(ctx context.Context, tgwID uuid.UUID) ([]*domain.TransitGatewayRouteTable, error) {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `
+		SELECT ` + tgwRouteTableColumns + `
+		FROM transit_gateway_route_tables rt
+		JOIN transit_gateways t ON rt.transit_gateway_id = t.id
+		WHERE rt.transit_gateway_id = $1 AND t.tenant_id = $2
+		ORDER BY rt.is_default DESC, rt.created_at
+	`
+	rows, err := r.db.Query(ctx, query, tgwID, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list transit gateway route tables", err)
+	}
+	defer rows.Close()
+
+	var tables []*domain.TransitGatewayRouteTable
+	for rows.Next() {
+		var rt domain.TransitGatewayRouteTable
+		if err := rows.Scan(&rt.ID, &rt.TransitGatewayID, &rt.Name, &rt.IsDefault, &rt.CreatedAt); err != nil {
+			return nil, errors.Wrap(errors.Internal, "failed to scan transit gateway route table", err)
+		}
+		tables = append(tables, &rt)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to iterate transit gateway route tables", err)
+	}
+	return tables, nil
+}
+
+// DeleteRouteTable removes a route table; its routes and propagations cascade.
+func (r *TransitGatewayRepository) DeleteRouteTable(ctx context.Context, id uuid.UUID) error {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `
+		DELETE FROM transit_gateway_route_tables rt
+		USING transit_gateways t
+		WHERE rt.transit_gateway_id = t.id AND rt.id = $1 AND t.tenant_id = $2
+	`
+	cmd, err := r.db.Exec(ctx, query, id, tenantID)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to delete transit gateway route table", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, tgwRouteTableNotFound)
+	}
+	return nil
+}
+
+// AddRoute inserts a route into a transit gateway route table.
+func (r *TransitGatewayRepository) AddRoute(ctx context.Context, route *domain.TransitGatewayRoute) error {
+	query := `
+		INSERT INTO transit_gateway_routes (id, route_table_id, destination_cidr, attachment_id, type, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err := r.db.Exec(ctx, query, route.ID, route.RouteTableID, route.DestinationCIDR, route.AttachmentID, route.Type, route.CreatedAt)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to add transit gateway route", err)
+	}
+	return nil
+}
+
+// ListRoutes returns all routes of a transit gateway route table.
+func (r *TransitGatewayRepository) ListRoutes(ctx context.Context, rtID uuid.UUID) ([]domain.TransitGatewayRoute, error) {
+	query := `
+		SELECT id, route_table_id, destination_cidr::text, attachment_id, type, created_at
+		FROM transit_gateway_routes WHERE route_table_id = $1 ORDER BY destination_cidr
+	`
+	rows, err := r.db.Query(ctx, query, rtID)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list transit gateway routes", err)
+	}
+	defer rows.Close()
+
+	var routes []domain.TransitGatewayRoute
+	for rows.Next() {
+		var route domain.TransitGatewayRoute
+		if err := rows.Scan(&route.ID, &route.RouteTableID, &route.DestinationCIDR, &route.AttachmentID, &route.Type, &route.CreatedAt); err != nil {
+			return nil, errors.Wrap(errors.Internal, "failed to scan transit gateway route", err)
+		}
+		routes = append(routes, route)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to iterate transit gateway routes", err)
+	}
+	return routes, nil
+}
+
+// RemoveRoute removes a route from a transit gateway route table.
+func (r *TransitGatewayRepository) RemoveRoute(ctx context.Context, rtID, routeID uuid.UUID) error {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `
+		DELETE FROM transit_gateway_routes r
+		USING transit_gateway_route_tables rt
+		JOIN transit_gateways t ON rt.transit_gateway_id = t.id
+		WHERE r.route_table_id = rt.id AND r.id = $1 AND rt.id = $2 AND t.tenant_id = $3
+	`
+	cmd, err := r.db.Exec(ctx, query, routeID, rtID, tenantID)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to remove transit gateway route", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, "route not found")
+	}
+	return nil
+}
+
+// AddPropagation records that an attachment's VPC CIDRs are learned into a route table.
+func (r *TransitGatewayRepository) AddPropagation(ctx context.Context, rtID, attachmentID uuid.UUID) error {
+	query := `
+		INSERT INTO transit_gateway_propagations (route_table_id, attachment_id, created_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (route_table_id, attachment_id) DO NOTHING
+	`
+	if _, err := r.db.Exec(ctx, query, rtID, attachmentID); err != nil {
+		return errors.Wrap(errors.Internal, "failed to enable route propagation", err)
+	}
+	return nil
+}
+
+// ListPropagations returns the attachments propagating into a route table.
+func (r *TransitGatewayRepository) ListPropagations(ctx context.Context, rtID uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := r.db.Query(ctx, `SELECT attachment_id FROM transit_gateway_propagations WHERE route_table_id = $1 ORDER BY created_at`, rtID)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list route propagations", err)
+	}
+	defer rows.Close()
+
+	var attachments []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, errors.Wrap(errors.Internal, "failed to scan route propagation", err)
+		}
+		attachments = append(attachments, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to iterate route propagations", err)
+	}
+	return attachments, nil
+}
+
+// RemovePropagation drops a propagation together with the routes it learned.
+func (r *TransitGatewayRepository) RemovePropagation(ctx context.Context, rtID, attachmentID uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to begin transaction", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	cmd, err := tx.Exec(ctx, `DELETE FROM transit_gateway_propagations WHERE route_table_id = $1 AND attachment_id = $2`, rtID, attachmentID)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to disable route propagation", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, "route propagation not found")
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM transit_gateway_routes WHERE route_table_id = $1 AND attachment_id = $2 AND type = $3`,
+		rtID, attachmentID, domain.TransitGatewayRoutePropagated); err != nil {
+		return errors.Wrap(errors.Internal, "failed to remove propagated routes", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return errors.Wrap(errors.Internal, "failed to commit transaction", err)
+	}
+	return nil
+}
+
+func (r *TransitGatewayRepository) scanTransitGateway(row pgx.Row) (*domain.TransitGateway, error) {
+	var tgw domain.TransitGateway
+	err := row.Scan(&tgw.ID, &tgw.UserID, &tgw.TenantID, &tgw.Name, &tgw.Status, &tgw.BridgeName, &tgw.VXLANID, &tgw.DefaultRouteTableID, &tgw.ARN, &tgw.CreatedAt)
+	if err != nil {
+		if stdlib_errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New(errors.NotFound, transitGatewayNotFound)
+		}
+		return nil, errors.Wrap(errors.Internal, "failed to scan transit gateway", err)
+	}
+	return &tgw, nil
+}
+
+func (r *TransitGatewayRepository) scanAttachment(row pgx.Row) (*domain.TransitGatewayAttachment, error) {
+	var att domain.TransitGatewayAttachment
+	err := row.Scan(&att.ID, &att.TransitGatewayID, &att.VPCID, &att.UserID, &att.TenantID, &att.RouteTableID, &att.Status, &att.CreatedAt)
+	if err != nil {
+		if stdlib_errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New(errors.NotFound, tgwAttachmentNotFound)
+		}
+		return nil, errors.Wrap(errors.Internal, "failed to scan transit gateway attachment", err)
+	}
+	return &att, nil
+}
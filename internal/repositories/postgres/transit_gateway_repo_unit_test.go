@@ -0,0 +1,147 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v3"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	theclouderrors "github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransitGatewayRepository_Create(t *testing.T) {
+	t.Parallel()
+	tgw := &domain.TransitGateway{
+		ID: uuid.New(), UserID: uuid.New(), TenantID: uuid.New(), Name: "hub",
+		Status: domain.TransitGatewayStatusPending, BridgeName: "br-tgw-12345678", VXLANID: 1042, ARN: "arn", CreatedAt: time.Now(),
+	}
+	args := []interface{}{tgw.ID, tgw.UserID, tgw.TenantID, tgw.Name, tgw.Status, tgw.BridgeName, tgw.VXLANID, tgw.DefaultRouteTableID, tgw.ARN, tgw.CreatedAt}
+
+	t.Run("inserted", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectExec("INSERT INTO transit_gateways").WithArgs(args...).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		require.NoError(t, NewTransitGatewayRepository(mock).Create(context.Background(), tgw))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectExec("INSERT INTO transit_gateways").WithArgs(args...).WillReturnError(&pgconn.PgError{Code: "23505"})
+		err = NewTransitGatewayRepository(mock).Create(context.Background(), tgw)
+		assert.True(t, theclouderrors.Is(err, theclouderrors.Conflict))
+	})
+}
+
+func TestTransitGatewayRepository_GetByIDNotFound(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery("FROM transit_gateways").
+		WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg()).
+		WillReturnError(pgx.ErrNoRows)
+
+	_, err = NewTransitGatewayRepository(mock).GetByID(context.Background(), uuid.New())
+	assert.True(t, theclouderrors.Is(err, theclouderrors.NotFound))
+}
+
+func TestTransitGatewayRepository_CreateAttachmentDuplicate(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	rtID := uuid.New()
+	att := &domain.TransitGatewayAttachment{ID: uuid.New(), TransitGatewayID: uuid.New(), VPCID: uuid.New(), UserID: uuid.New(), TenantID: uuid.New(), RouteTableID: &rtID, Status: domain.TransitGatewayAttachmentStatusPending, CreatedAt: time.Now()}
+	mock.ExpectExec("INSERT INTO transit_gateway_attachments").
+		WithArgs(att.ID, att.TransitGatewayID, att.VPCID, att.UserID, att.TenantID, att.RouteTableID, att.Status, att.CreatedAt).
+		WillReturnError(&pgconn.PgError{Code: "23505"})
+
+	err = NewTransitGatewayRepository(mock).CreateAttachment(context.Background(), att)
+	assert.True(t, theclouderrors.Is(err, theclouderrors.Conflict))
+}
+
+func TestTransitGatewayRepository_ListRoutes(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	rtID, attID := uuid.New(), uuid.New()
+	mock.ExpectQuery("SELECT id, route_table_id, destination_cidr::text, attachment_id, type, created_at").
+		WithArgs(rtID).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "route_table_id", "destination_cidr", "attachment_id", "type", "created_at"}).
+			AddRow(uuid.New(), rtID, "10.1.0.0/16", &attID, domain.TransitGatewayRoutePropagated, time.Now()).
+			AddRow(uuid.New(), rtID, "10.9.0.0/16", nil, domain.TransitGatewayRouteStatic, time.Now()))
+
+	routes, err := NewTransitGatewayRepository(mock).ListRoutes(context.Background(), rtID)
+	require.NoError(t, err)
+	require.Len(t, routes, 2)
+	assert.Equal(t, attID, *routes[0].AttachmentID)
+	assert.True(t, routes[1].IsBlackhole())
+}
+
+func TestTransitGatewayRepository_GetRouteTable(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	id, tgwID, tenantID := uuid.New(), uuid.New(), uuid.New()
+	mock.ExpectQuery("FROM transit_gateway_route_tables rt").
+		WithArgs(id, tenantID).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "transit_gateway_id", "name", "is_default", "created_at"}).
+			AddRow(id, tgwID, "default", true, time.Now()))
+
+	rt, err := NewTransitGatewayRepository(mock).GetRouteTable(appcontext.WithTenantID(context.Background(), tenantID), id)
+	require.NoError(t, err)
+	assert.Equal(t, tgwID, rt.TransitGatewayID)
+	assert.True(t, rt.IsDefault)
+}
+
+func TestTransitGatewayRepository_RemovePropagation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("removes learned routes", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		rtID, attID := uuid.New(), uuid.New()
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM transit_gateway_propagations").WithArgs(rtID, attID).WillReturnResult(pgxmock.NewResult("DELETE", 1))
+		mock.ExpectExec("DELETE FROM transit_gateway_routes").WithArgs(rtID, attID, domain.TransitGatewayRoutePropagated).WillReturnResult(pgxmock.NewResult("DELETE", 2))
+		mock.ExpectCommit()
+		mock.ExpectRollback()
+
+		require.NoError(t, NewTransitGatewayRepository(mock).RemovePropagation(context.Background(), rtID, attID))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not propagating", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec("DELETE FROM transit_gateway_propagations").WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg()).WillReturnResult(pgxmock.NewResult("DELETE", 0))
+		mock.ExpectRollback()
+
+		err = NewTransitGatewayRepository(mock).RemovePropagation(context.Background(), uuid.New(), uuid.New())
+		assert.True(t, theclouderrors.Is(err, theclouderrors.NotFound))
+	})
+}
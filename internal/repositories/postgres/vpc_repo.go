@@ -38,14 +38,14 @@ func (r *VpcRepository) Create(ctx context.Context, vpc *domain.VPC) error {
 // GetByID retrieves a single VPC by its UUID and ensures it belongs to the authenticated user.
 func (r *VpcRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.VPC, error) {
 	tenantID := appcontext.TenantIDFromContext(ctx)
-	query := `SELECT id, user_id, tenant_id, name, COALESCE(cidr_block::text, ''), network_id, vxlan_id, status, arn, created_at FROM vpcs WHERE id = $1 AND tenant_id = $2`
+	query := `SELECT id, user_id, tenant_id, name, COALESCE(cidr_block::text, ''), COALESCE(ipv6_cidr_block::text, ''), network_id, vxlan_id, status, arn, created_at FROM vpcs WHERE id = $1 AND tenant_id = $2`
 	return r.scanVPC(r.db.QueryRow(ctx, query, id, tenantID))
 }
 
 // GetByName retrieves a single VPC by its name and ensures it belongs to the authenticated user.
 func (r *VpcRepository) GetByName(ctx context.Context, name string) (*domain.VPC, error) {
 	tenantID := appcontext.TenantIDFromContext(ctx)
-	query := `SELECT id, user_id, tenant_id, name, COALESCE(cidr_block::text, ''), network_id, vxlan_id, status, arn, created_at FROM vpcs WHERE name = $1 AND tenant_id = $2`
+	query := `SELECT id, user_id, tenant_id, name, COALESCE(cidr_block::text, ''), COALESCE(ipv6_cidr_block::text, ''), network_id, vxlan_id, status, arn, created_at FROM vpcs WHERE name = $1 AND tenant_id = $2`
 	return r.scanVPC(r.db.QueryRow(ctx, query, name, tenantID))
 }
 
@@ -55,14 +55,14 @@ func (r *VpcRepository) GetByIdempotencyKey(ctx context.Context, key string) (*d
 		return nil, errors.New(errors.NotFound, "idempotency key empty")
 	}
 	userID := appcontext.UserIDFromContext(ctx)
-	query := `SELECT id, user_id, tenant_id, name, COALESCE(cidr_block::text, ''), network_id, vxlan_id, status, arn, created_at FROM vpcs WHERE idempotency_key = $1 AND user_id = $2`
+	query := `SELECT id, user_id, tenant_id, name, COALESCE(cidr_block::text, ''), COALESCE(ipv6_cidr_block::text, ''), network_id, vxlan_id, status, arn, created_at FROM vpcs WHERE idempotency_key = $1 AND user_id = $2`
 	return r.scanVPC(r.db.QueryRow(ctx, query, key, userID))
 }
 
 // List returns all VPCs belonging to the authenticated user.
 func (r *VpcRepository) List(ctx context.Context) ([]*domain.VPC, error) {
 	tenantID := appcontext.TenantIDFromContext(ctx)
-	query := `SELECT id, user_id, tenant_id, name, COALESCE(cidr_block::text, ''), network_id, vxlan_id, status, arn, created_at FROM vpcs WHERE tenant_id = $1 ORDER BY created_at DESC`
+	query := `SELECT id, user_id, tenant_id, name, COALESCE(cidr_block::text, ''), COALESCE(ipv6_cidr_block::text, ''), network_id, vxlan_id, status, arn, created_at FROM vpcs WHERE tenant_id = $1 ORDER BY created_at DESC`
 	rows, err := r.db.Query(ctx, query, tenantID)
 	if err != nil {
 		return nil, errors.Wrap(errors.Internal, "failed to list vpcs", err)
@@ -70,9 +70,18 @@ func (r *VpcRepository) List(ctx context.Context) ([]*domain.VPC, error) {
 	return r.scanVPCs(rows)
 }
 
+func (r *VpcRepository) ListAll(ctx context.Context) ([]*domain.VPC, error) {
+	query := `SELECT id, user_id, tenant_id, name, COALESCE(cidr_block::text, ''), COALESCE(ipv6_cidr_block::text, ''), network_id, vxlan_id, status, arn, created_at FROM vpcs ORDER BY created_at`
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list all vpcs", err)
+	}
+	return r.scanVPCs(rows)
+}
+
 func (r *VpcRepository) scanVPC(row pgx.Row) (*domain.VPC, error) {
 	var vpc domain.VPC
-	err := row.Scan(&vpc.ID, &vpc.UserID, &vpc.TenantID, &vpc.Name, &vpc.CIDRBlock, &vpc.NetworkID, &vpc.VXLANID, &vpc.Status, &vpc.ARN, &vpc.CreatedAt)
+	err := row.Scan(&vpc.ID, &vpc.UserID, &vpc.TenantID, &vpc.Name, &vpc.CIDRBlock, &vpc.IPv6CIDRBlock, &vpc.NetworkID, &vpc.VXLANID, &vpc.Status, &vpc.ARN, &vpc.CreatedAt)
 	if err != nil {
 		if stdlib_errors.Is(err, pgx.ErrNoRows) {
 			return nil, errors.New(errors.NotFound, "vpc not found")
@@ -112,8 +121,8 @@ func (r *VpcRepository) Delete(ctx context.Context, id uuid.UUID) error {
 // Update modifies an existing VPC record.
 func (r *VpcRepository) Update(ctx context.Context, vpc *domain.VPC) error {
 	tenantID := appcontext.TenantIDFromContext(ctx)
-	query := `UPDATE vpcs SET name = $1 WHERE id = $2 AND tenant_id = $3`
-	cmd, err := r.db.Exec(ctx, query, vpc.Name, vpc.ID, tenantID)
+	query := `UPDATE vpcs SET name = $1, ipv6_cidr_block = NULLIF($2, '')::cidr WHERE id = $3 AND tenant_id = $4`
+	cmd, err := r.db.Exec(ctx, query, vpc.Name, vpc.IPv6CIDRBlock, vpc.ID, tenantID)
 	if err != nil {
 		return errors.Wrap(errors.Internal, "failed to update vpc", err)
 	}
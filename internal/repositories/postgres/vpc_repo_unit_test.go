@@ -19,7 +19,7 @@ import (
 
 const (
 	testVpcName = "test-vpc"
-	selectVpc   = "SELECT id, user_id, tenant_id, name, COALESCE\\(cidr_block::text, ''\\), network_id, vxlan_id, status, arn, created_at FROM vpcs"
+	selectVpc   = "SELECT id, user_id, tenant_id, name, COALESCE\\(cidr_block::text, ''\\), COALESCE\\(ipv6_cidr_block::text, ''\\), network_id, vxlan_id, status, arn, created_at FROM vpcs"
 )
 
 func TestVpcRepositoryCreate(t *testing.T) {
@@ -88,8 +88,8 @@ func TestVpcRepositoryGetByID(t *testing.T) {
 
 		mock.ExpectQuery(selectVpc).
 			WithArgs(id, tenantID).
-			WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "tenant_id", "name", "cidr_block", "network_id", "vxlan_id", "status", "arn", "created_at"}).
-				AddRow(id, userID, tenantID, testVpcName, testutil.TestCIDR, "net-1", 100, "available", "arn", now))
+			WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "tenant_id", "name", "cidr_block", "ipv6_cidr_block", "network_id", "vxlan_id", "status", "arn", "created_at"}).
+				AddRow(id, userID, tenantID, testVpcName, testutil.TestCIDR, "", "net-1", 100, "available", "arn", now))
 
 		vpc, err := repo.GetByID(ctx, id)
 		require.NoError(t, err)
@@ -166,8 +166,8 @@ func TestVpcRepositoryGetByName(t *testing.T) {
 
 		mock.ExpectQuery(selectVpc).
 			WithArgs(name, tenantID).
-			WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "tenant_id", "name", "cidr_block", "network_id", "vxlan_id", "status", "arn", "created_at"}).
-				AddRow(id, userID, tenantID, name, testutil.TestCIDR, "net-1", 100, "available", "arn", now))
+			WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "tenant_id", "name", "cidr_block", "ipv6_cidr_block", "network_id", "vxlan_id", "status", "arn", "created_at"}).
+				AddRow(id, userID, tenantID, name, testutil.TestCIDR, "", "net-1", 100, "available", "arn", now))
 
 		vpc, err := repo.GetByName(ctx, name)
 		require.NoError(t, err)
@@ -213,8 +213,8 @@ func TestVpcRepositoryList(t *testing.T) {
 
 		mock.ExpectQuery(selectVpc).
 			WithArgs(tenantID).
-			WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "tenant_id", "name", "cidr_block", "network_id", "vxlan_id", "status", "arn", "created_at"}).
-				AddRow(uuid.New(), userID, tenantID, testVpcName, testutil.TestCIDR, "net-1", 100, "available", "arn", now))
+			WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "tenant_id", "name", "cidr_block", "ipv6_cidr_block", "network_id", "vxlan_id", "status", "arn", "created_at"}).
+				AddRow(uuid.New(), userID, tenantID, testVpcName, testutil.TestCIDR, "", "net-1", 100, "available", "arn", now))
 
 		vpcs, err := repo.List(ctx)
 		require.NoError(t, err)
@@ -256,8 +256,8 @@ func TestVpcRepositoryList(t *testing.T) {
 		// Return a row with incompatible types to force scan error
 		mock.ExpectQuery(selectVpc).
 			WithArgs(tenantID).
-			WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "tenant_id", "name", "cidr_block", "network_id", "vxlan_id", "status", "arn", "created_at"}).
-				AddRow("invalid-uuid", userID, tenantID, testVpcName, testutil.TestCIDR, "net-1", 100, "available", "arn", now))
+			WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "tenant_id", "name", "cidr_block", "ipv6_cidr_block", "network_id", "vxlan_id", "status", "arn", "created_at"}).
+				AddRow("invalid-uuid", userID, tenantID, testVpcName, testutil.TestCIDR, "", "net-1", 100, "available", "arn", now))
 
 		vpcs, err := repo.List(ctx)
 		require.Error(t, err)
@@ -265,6 +265,27 @@ func TestVpcRepositoryList(t *testing.T) {
 	})
 }
 
+func TestVpcRepositoryListAll(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	repo := NewVpcRepository(mock)
+	now := time.Now()
+
+	mock.ExpectQuery(selectVpc + " ORDER BY created_at$").
+		WithArgs().
+		WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "tenant_id", "name", "cidr_block", "ipv6_cidr_block", "network_id", "vxlan_id", "status", "arn", "created_at"}).
+			AddRow(uuid.New(), uuid.New(), uuid.New(), testVpcName, testutil.TestCIDR, "", "net-1", 100, "available", "arn", now).
+			AddRow(uuid.New(), uuid.New(), uuid.New(), "other", testutil.TestCIDR, "", "net-2", 101, "available", "arn", now))
+
+	vpcs, err := repo.ListAll(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, vpcs, 2)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestVpcRepositoryDelete(t *testing.T) {
 	t.Parallel()
 	t.Run("success", func(t *testing.T) {
@@ -326,8 +347,8 @@ func TestVpcRepositoryGetByIdempotencyKey(t *testing.T) {
 
 		mock.ExpectQuery("SELECT id, user_id, tenant_id, name, COALESCE").
 			WithArgs(key, userID).
-			WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "tenant_id", "name", "cidr_block", "network_id", "vxlan_id", "status", "arn", "created_at"}).
-				AddRow(id, userID, tenantID, testVpcName, testutil.TestCIDR, "net-1", 100, "available", "arn", now))
+			WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "tenant_id", "name", "cidr_block", "ipv6_cidr_block", "network_id", "vxlan_id", "status", "arn", "created_at"}).
+				AddRow(id, userID, tenantID, testVpcName, testutil.TestCIDR, "", "net-1", 100, "available", "arn", now))
 
 		vpc, err := repo.GetByIdempotencyKey(ctx, key)
 		require.NoError(t, err)
@@ -364,7 +385,7 @@ func TestVpcRepositoryGetByIdempotencyKey(t *testing.T) {
 		userID := uuid.New()
 		ctx := appcontext.WithUserID(context.Background(), userID)
 
-		mock.ExpectQuery("SELECT id, user_id, tenant_id, name, COALESCE\\(cidr_block::text, ''\\), network_id, vxlan_id, status, arn, created_at FROM vpcs WHERE idempotency_key = $1 AND user_id = $2").
+		mock.ExpectQuery("SELECT id, user_id, tenant_id, name, COALESCE\\(cidr_block::text, ''\\), COALESCE\\(ipv6_cidr_block::text, ''\\), network_id, vxlan_id, status, arn, created_at FROM vpcs WHERE idempotency_key = $1 AND user_id = $2").
 			WithArgs("nonexistent-key", userID).
 			WillReturnError(pgx.ErrNoRows)
 
@@ -383,7 +404,7 @@ func TestVpcRepositoryGetByIdempotencyKey(t *testing.T) {
 		userID := uuid.New()
 		ctx := appcontext.WithUserID(context.Background(), userID)
 
-		mock.ExpectQuery("SELECT id, user_id, tenant_id, name, COALESCE\\(cidr_block::text, ''\\), network_id, vxlan_id, status, arn, created_at FROM vpcs WHERE idempotency_key = $1 AND user_id = $2").
+		mock.ExpectQuery("SELECT id, user_id, tenant_id, name, COALESCE\\(cidr_block::text, ''\\), COALESCE\\(ipv6_cidr_block::text, ''\\), network_id, vxlan_id, status, arn, created_at FROM vpcs WHERE idempotency_key = $1 AND user_id = $2").
 			WithArgs("some-key", userID).
 			WillReturnError(errors.New(testDBError))
 
@@ -0,0 +1,179 @@
+package postgres
+
+import (
+	"context"
+	stdlib_errors "errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/errors"
+)
+
+const (
+	vpcResolverColumns    = `id, vpc_id, user_id, tenant_id, status, container_id, COALESCE(host(private_ip), ''), arn, created_at`
+	forwardingRuleColumns = `id, resolver_id, tenant_id, domain_name, target_ips, created_at`
+)
+
+// VPCResolverRepository provides PostgreSQL-backed persistence for VPC resolvers.
+type VPCResolverRepository struct {
+	db DB
+}
+
+// NewVPCResolverRepository creates a VPCResolverRepository using the provided DB.
+func NewVPCResolverRepository(db DB) *VPCResolverRepository {
+	return &VPCResolverRepository{db: db}
+}
+
+// Create inserts a new VPC resolver record.
+func (r *VPCResolverRepository) Create(ctx context.Context, resolver *domain.VPCResolver) error {
+	query := `
+		INSERT INTO vpc_resolvers (id, vpc_id, user_id, tenant_id, status, container_id, private_ip, arn, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, '')::inet, $8, $9)
+	`
+	_, err := r.db.Exec(ctx, query, resolver.ID, resolver.VPCID, resolver.UserID, resolver.TenantID, resolver.Status, resolver.ContainerID, resolver.PrivateIP, resolver.ARN, resolver.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if stdlib_errors.As(err, &pgErr) && pgErr.Code == uniqueViolationSQLState {
+			return errors.New(errors.Conflict, "the VPC already has a resolver")
+		}
+		return errors.Wrap(errors.Internal, "failed to create VPC resolver", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a VPC resolver by ID.
+func (r *VPCResolverRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.VPCResolver, error) {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `SELECT ` + vpcResolverColumns + ` FROM vpc_resolvers WHERE id = $1 AND tenant_id = $2`
+	return r.scanResolver(r.db.QueryRow(ctx, query, id, tenantID))
+}
+
+// GetByVPC retrieves the resolver of a VPC.
+func (r *VPCResolverRepository) GetByVPC(ctx context.Context, vpcID uuid.UUID) (*domain.VPCResolver, error) {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `SELECT ` + vpcResolverColumns + ` FROM vpc_resolvers WHERE vpc_id = $1 AND tenant_id = $2`
+	return r.scanResolver(r.db.QueryRow(ctx, query, vpcID, tenantID))
+}
+
+// List returns all VPC resolvers of the current tenant.
+func (r *VPCResolverRepository) List(ctx context.Context) ([]*domain.VPCResolver, error) {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `SELECT ` + vpcResolverColumns + ` FROM vpc_resolvers WHERE tenant_id = $1 ORDER BY created_at DESC`
+	rows, err := r.db.Query(ctx, query, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list VPC resolvers", err)
+	}
+	defer rows.Close()
+
+	var resolvers []*domain.VPCResolver
+	for rows.Next() {
+		resolver, err := r.scanResolver(rows)
+		if err != nil {
+			return nil, err
+		}
+		resolvers = append(resolvers, resolver)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to iterate VPC resolvers", err)
+	}
+	return resolvers, nil
+}
+
+// Update persists the runtime state of a VPC resolver.
+func (r *VPCResolverRepository) Update(ctx context.Context, resolver *domain.VPCResolver) error {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `
+		UPDATE vpc_resolvers SET status = $1, container_id = $2, private_ip = NULLIF($3, '')::inet
+		WHERE id = $4 AND tenant_id = $5
+	`
+	cmd, err := r.db.Exec(ctx, query, resolver.Status, resolver.ContainerID, resolver.PrivateIP, resolver.ID, tenantID)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to update VPC resolver", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, "VPC resolver not found")
+	}
+	return nil
+}
+
+// Delete removes a VPC resolver and, through the cascade, its forwarding rules.
+func (r *VPCResolverRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	cmd, err := r.db.Exec(ctx, `DELETE FROM vpc_resolvers WHERE id = $1 AND tenant_id = $2`, id, tenantID)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to delete VPC resolver", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, "VPC resolver not found")
+	}
+	return nil
+}
+
+// CreateRule inserts a new forwarding rule.
+func (r *VPCResolverRepository) CreateRule(ctx context.Context, rule *domain.ResolverForwardingRule) error {
+	query := `
+		INSERT INTO resolver_forwarding_rules (id, resolver_id, tenant_id, domain_name, target_ips, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err := r.db.Exec(ctx, query, rule.ID, rule.ResolverID, rule.TenantID, rule.DomainName, rule.TargetIPs, rule.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if stdlib_errors.As(err, &pgErr) && pgErr.Code == uniqueViolationSQLState {
+			return errors.New(errors.Conflict, "a forwarding rule for this domain already exists")
+		}
+		return errors.Wrap(errors.Internal, "failed to create forwarding rule", err)
+	}
+	return nil
+}
+
+// ListRules returns the forwarding rules of a resolver.
+func (r *VPCResolverRepository) ListRules(ctx context.Context, resolverID uuid.UUID) ([]*domain.ResolverForwardingRule, error) {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `SELECT ` + forwardingRuleColumns + ` FROM resolver_forwarding_rules WHERE resolver_id = $1 AND tenant_id = $2 ORDER BY domain_name`
+	rows, err := r.db.Query(ctx, query, resolverID, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list forwarding rules", err)
+	}
+	defer rows.Close()
+
+	var rules []*domain.ResolverForwardingRule
+	for rows.Next() {
+		var rule domain.ResolverForwardingRule
+		if err := rows.Scan(&rule.ID, &rule.ResolverID, &rule.TenantID, &rule.DomainName, &rule.TargetIPs, &rule.CreatedAt); err != nil {
+			return nil, errors.Wrap(errors.Internal, "failed to scan forwarding rule", err)
+		}
+		rules = append(rules, &rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to iterate forwarding rules", err)
+	}
+	return rules, nil
+}
+
+// DeleteRule removes a forwarding rule from a resolver.
+func (r *VPCResolverRepository) DeleteRule(ctx context.Context, resolverID, ruleID uuid.UUID) error {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	cmd, err := r.db.Exec(ctx, `DELETE FROM resolver_forwarding_rules WHERE id = $1 AND resolver_id = $2 AND tenant_id = $3`, ruleID, resolverID, tenantID)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to delete forwarding rule", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, "forwarding rule not found")
+	}
+	return nil
+}
+
+func (r *VPCResolverRepository) scanResolver(row pgx.Row) (*domain.VPCResolver, error) {
+	var resolver domain.VPCResolver
+	err := row.Scan(&resolver.ID, &resolver.VPCID, &resolver.UserID, &resolver.TenantID, &resolver.Status, &resolver.ContainerID, &resolver.PrivateIP, &resolver.ARN, &resolver.CreatedAt)
+	if err != nil {
+		if stdlib_errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New(errors.NotFound, "VPC resolver not found")
+		}
+		return nil, errors.Wrap(errors.Internal, "failed to scan VPC resolver", err)
+	}
+	return &resolver, nil
+}
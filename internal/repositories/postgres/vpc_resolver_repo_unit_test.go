@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v3"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	theclouderrors "github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVPCResolverRepository_CreateDuplicateVPC(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	resolver := &domain.VPCResolver{ID: uuid.New(), VPCID: uuid.New(), UserID: uuid.New(), TenantID: uuid.New(), Status: domain.VPCResolverStatusPending, ARN: "arn", CreatedAt: time.Now()}
+	mock.ExpectExec("INSERT INTO vpc_resolvers").
+		WithArgs(resolver.ID, resolver.VPCID, resolver.UserID, resolver.TenantID, resolver.Status, resolver.ContainerID, resolver.PrivateIP, resolver.ARN, resolver.CreatedAt).
+		WillReturnError(&pgconn.PgError{Code: "23505"})
+
+	err = NewVPCResolverRepository(mock).Create(context.Background(), resolver)
+	assert.True(t, theclouderrors.Is(err, theclouderrors.Conflict))
+}
+
+func TestVPCResolverRepository_GetByVPC(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	vpcID, tenantID := uuid.New(), uuid.New()
+	mock.ExpectQuery("SELECT id, vpc_id, user_id, tenant_id, status, container_id, COALESCE\\(host\\(private_ip\\), ''\\), arn, created_at FROM vpc_resolvers WHERE vpc_id").
+		WithArgs(vpcID, tenantID).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "vpc_id", "user_id", "tenant_id", "status", "container_id", "private_ip", "arn", "created_at"}).
+			AddRow(uuid.New(), vpcID, uuid.New(), tenantID, domain.VPCResolverStatusActive, "cid", "10.0.0.2", "arn", time.Now()))
+
+	resolver, err := NewVPCResolverRepository(mock).GetByVPC(appcontext.WithTenantID(context.Background(), tenantID), vpcID)
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.2", resolver.PrivateIP)
+}
+
+func TestVPCResolverRepository_GetByVPCNotFound(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery("FROM vpc_resolvers").
+		WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg()).
+		WillReturnError(pgx.ErrNoRows)
+
+	_, err = NewVPCResolverRepository(mock).GetByVPC(context.Background(), uuid.New())
+	assert.True(t, theclouderrors.Is(err, theclouderrors.NotFound))
+}
+
+func TestVPCResolverRepository_ListRules(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	resolverID, tenantID := uuid.New(), uuid.New()
+	mock.ExpectQuery("FROM resolver_forwarding_rules WHERE resolver_id").
+		WithArgs(resolverID, tenantID).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "resolver_id", "tenant_id", "domain_name", "target_ips", "created_at"}).
+			AddRow(uuid.New(), resolverID, tenantID, "corp.example.com", []string{"10.50.0.2", "10.50.0.3:5353"}, time.Now()))
+
+	rules, err := NewVPCResolverRepository(mock).ListRules(appcontext.WithTenantID(context.Background(), tenantID), resolverID)
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	assert.Equal(t, []string{"10.50.0.2", "10.50.0.3:5353"}, rules[0].TargetIPs)
+}
+
+func TestVPCResolverRepository_DeleteRuleNotFound(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	resolverID, ruleID, tenantID := uuid.New(), uuid.New(), uuid.New()
+	mock.ExpectExec("DELETE FROM resolver_forwarding_rules").
+		WithArgs(ruleID, resolverID, tenantID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	err = NewVPCResolverRepository(mock).DeleteRule(appcontext.WithTenantID(context.Background(), tenantID), resolverID, ruleID)
+	assert.True(t, theclouderrors.Is(err, theclouderrors.NotFound))
+}
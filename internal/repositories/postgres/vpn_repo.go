@@ -0,0 +1,289 @@
+package postgres
+
+import (
+	"context"
+	stdlib_errors "errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/errors"
+)
+
+const (
+	vpnGatewayColumns      = `id, vpc_id, user_id, tenant_id, name, status, public_key, private_key_secret_id, container_id, COALESCE(host(private_ip), ''), port, arn, created_at`
+	customerGatewayColumns = `id, user_id, tenant_id, name, host(ip_address), public_key, created_at`
+	vpnConnectionColumns   = `id, vpn_gateway_id, customer_gateway_id, user_id, tenant_id, remote_cidrs, pre_shared_key_secret_id, status, created_at`
+)
+
+// VPNRepository provides PostgreSQL-backed persistence for site-to-site VPN resources.
+type VPNRepository struct {
+	db DB
+}
+
+// NewVPNRepository creates a VPNRepository using the provided DB.
+func NewVPNRepository(db DB) *VPNRepository {
+	return &VPNRepository{db: db}
+}
+
+// CreateGateway inserts a new VPN gateway record.
+func (r *VPNRepository) CreateGateway(ctx context.Context, gw *domain.VPNGateway) error {
+	query := `
+		INSERT INTO vpn_gateways (id, vpc_id, user_id, tenant_id, name, status, public_key, private_key_secret_id, container_id, private_ip, port, arn, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, '')::inet, $11, $12, $13)
+	`
+	_, err := r.db.Exec(ctx, query, gw.ID, gw.VPCID, gw.UserID, gw.TenantID, gw.Name, gw.Status, gw.PublicKey, gw.PrivateKeySecretID, gw.ContainerID, gw.PrivateIP, gw.Port, gw.ARN, gw.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if stdlib_errors.As(err, &pgErr) && pgErr.Code == uniqueViolationSQLState {
+			return errors.New(errors.Conflict, "a VPN gateway with this name already exists in the VPC")
+		}
+		return errors.Wrap(errors.Internal, "failed to create VPN gateway", err)
+	}
+	return nil
+}
+
+// GetGateway retrieves a VPN gateway by ID.
+func (r *VPNRepository) GetGateway(ctx context.Context, id uuid.UUID) (*domain.VPNGateway, error) {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `SELECT ` + vpnGatewayColumns + ` FROM vpn_gateways WHERE id = $1 AND tenant_id = $2`
+	return r.scanGateway(r.db.QueryRow(ctx, query, id, tenantID))
+}
+
+// ListGatewaysByVPC returns all VPN gateways attached to a VPC.
+func (r *VPNRepository) ListGatewaysByVPC(ctx context.Context, vpcID uuid.UUID) ([]*domain.VPNGateway, error) {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `SELECT ` + vpnGatewayColumns + ` FROM vpn_gateways WHERE vpc_id = $1 AND tenant_id = $2 ORDER BY created_at DESC`
+	rows, err := r.db.Query(ctx, query, vpcID, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list VPN gateways", err)
+	}
+	defer rows.Close()
+
+	var gateways []*domain.VPNGateway
+	for rows.Next() {
+		gw, err := r.scanGateway(rows)
+		if err != nil {
+			return nil, err
+		}
+		gateways = append(gateways, gw)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to iterate VPN gateways", err)
+	}
+	return gateways, nil
+}
+
+// UpdateGateway persists the runtime state of a VPN gateway.
+func (r *VPNRepository) UpdateGateway(ctx context.Context, gw *domain.VPNGateway) error {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `
+		UPDATE vpn_gateways SET status = $1, container_id = $2, private_ip = NULLIF($3, '')::inet, port = $4
+		WHERE id = $5 AND tenant_id = $6
+	`
+	cmd, err := r.db.Exec(ctx, query, gw.Status, gw.ContainerID, gw.PrivateIP, gw.Port, gw.ID, tenantID)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to update VPN gateway", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, "VPN gateway not found")
+	}
+	return nil
+}
+
+// DeleteGateway removes a VPN gateway record.
+func (r *VPNRepository) DeleteGateway(ctx context.Context, id uuid.UUID) error {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	cmd, err := r.db.Exec(ctx, `DELETE FROM vpn_gateways WHERE id = $1 AND tenant_id = $2`, id, tenantID)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to delete VPN gateway", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, "VPN gateway not found")
+	}
+	return nil
+}
+
+// CreateCustomerGateway inserts a new customer gateway record.
+func (r *VPNRepository) CreateCustomerGateway(ctx context.Context, cgw *domain.CustomerGateway) error {
+	query := `
+		INSERT INTO customer_gateways (id, user_id, tenant_id, name, ip_address, public_key, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := r.db.Exec(ctx, query, cgw.ID, cgw.UserID, cgw.TenantID, cgw.Name, cgw.IPAddress, cgw.PublicKey, cgw.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if stdlib_errors.As(err, &pgErr) && pgErr.Code == uniqueViolationSQLState {
+			return errors.New(errors.Conflict, "a customer gateway with this name already exists")
+		}
+		return errors.Wrap(errors.Internal, "failed to create customer gateway", err)
+	}
+	return nil
+}
+
+// GetCustomerGateway retrieves a customer gateway by ID.
+func (r *VPNRepository) GetCustomerGateway(ctx context.Context, id uuid.UUID) (*domain.CustomerGateway, error) {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `SELECT ` + customerGatewayColumns + ` FROM customer_gateways WHERE id = $1 AND tenant_id = $2`
+	return r.scanCustomerGateway(r.db.QueryRow(ctx, query, id, tenantID))
+}
+
+// ListCustomerGateways returns all customer gateways of the current tenant.
+func (r *VPNRepository) ListCustomerGateways(ctx context.Context) ([]*domain.CustomerGateway, error) {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `SELECT ` + customerGatewayColumns + ` FROM customer_gateways WHERE tenant_id = $1 ORDER BY name`
+	rows, err := r.db.Query(ctx, query, tenantID)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list customer gateways", err)
+	}
+	defer rows.Close()
+
+	var gateways []*domain.CustomerGateway
+	for rows.Next() {
+		cgw, err := r.scanCustomerGateway(rows)
+		if err != nil {
+			return nil, err
+		}
+		gateways = append(gateways, cgw)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to iterate customer gateways", err)
+	}
+	return gateways, nil
+}
+
+// DeleteCustomerGateway removes a customer gateway record.
+func (r *VPNRepository) DeleteCustomerGateway(ctx context.Context, id uuid.UUID) error {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	cmd, err := r.db.Exec(ctx, `DELETE FROM customer_gateways WHERE id = $1 AND tenant_id = $2`, id, tenantID)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to delete customer gateway", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, "customer gateway not found")
+	}
+	return nil
+}
+
+// CreateConnection inserts a new VPN connection record.
+func (r *VPNRepository) CreateConnection(ctx context.Context, conn *domain.VPNConnection) error {
+	query := `
+		INSERT INTO vpn_connections (id, vpn_gateway_id, customer_gateway_id, user_id, tenant_id, remote_cidrs, pre_shared_key_secret_id, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	_, err := r.db.Exec(ctx, query, conn.ID, conn.VPNGatewayID, conn.CustomerGatewayID, conn.UserID, conn.TenantID, conn.RemoteCIDRs, conn.PreSharedKeySecretID, conn.Status, conn.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if stdlib_errors.As(err, &pgErr) && pgErr.Code == uniqueViolationSQLState {
+			return errors.New(errors.Conflict, "a VPN connection between these gateways already exists")
+		}
+		return errors.Wrap(errors.Internal, "failed to create VPN connection", err)
+	}
+	return nil
+}
+
+// GetConnection retrieves a VPN connection by ID.
+func (r *VPNRepository) GetConnection(ctx context.Context, id uuid.UUID) (*domain.VPNConnection, error) {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `SELECT ` + vpnConnectionColumns + ` FROM vpn_connections WHERE id = $1 AND tenant_id = $2`
+	return r.scanConnection(r.db.QueryRow(ctx, query, id, tenantID))
+}
+
+// ListConnectionsByGateway returns all connections terminated by a VPN gateway.
+func (r *VPNRepository) ListConnectionsByGateway(ctx context.Context, gatewayID uuid.UUID) ([]*domain.VPNConnection, error) {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `SELECT ` + vpnConnectionColumns + ` FROM vpn_connections WHERE vpn_gateway_id = $1 AND tenant_id = $2 ORDER BY created_at`
+	return r.listConnections(ctx, query, gatewayID, tenantID)
+}
+
+// ListConnectionsByCustomerGateway returns all connections that use a customer gateway.
+func (r *VPNRepository) ListConnectionsByCustomerGateway(ctx context.Context, customerGatewayID uuid.UUID) ([]*domain.VPNConnection, error) {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	query := `SELECT ` + vpnConnectionColumns + ` FROM vpn_connections WHERE customer_gateway_id = $1 AND tenant_id = $2 ORDER BY created_at`
+	return r.listConnections(ctx, query, customerGatewayID, tenantID)
+}
+
+// UpdateConnection persists the status of a VPN connection.
+func (r *VPNRepository) UpdateConnection(ctx context.Context, conn *domain.VPNConnection) error {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	cmd, err := r.db.Exec(ctx, `UPDATE vpn_connections SET status = $1 WHERE id = $2 AND tenant_id = $3`, conn.Status, conn.ID, tenantID)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to update VPN connection", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, "VPN connection not found")
+	}
+	return nil
+}
+
+// DeleteConnection removes a VPN connection record.
+func (r *VPNRepository) DeleteConnection(ctx context.Context, id uuid.UUID) error {
+	tenantID := appcontext.TenantIDFromContext(ctx)
+	cmd, err := r.db.Exec(ctx, `DELETE FROM vpn_connections WHERE id = $1 AND tenant_id = $2`, id, tenantID)
+	if err != nil {
+		return errors.Wrap(errors.Internal, "failed to delete VPN connection", err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return errors.New(errors.NotFound, "VPN connection not found")
+	}
+	return nil
+}
+
+func (r *VPNRepository) listConnections(ctx context.Context, query string, args ...any) ([]*domain.VPNConnection, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to list VPN connections", err)
+	}
+	defer rows.Close()
+
+	var conns []*domain.VPNConnection
+	for rows.Next() {
+		conn, err := r.scanConnection(rows)
+		if err != nil {
+			return nil, err
+		}
+		conns = append(conns, conn)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(errors.Internal, "failed to iterate VPN connections", err)
+	}
+	return conns, nil
+}
+
+func (r *VPNRepository) scanGateway(row pgx.Row) (*domain.VPNGateway, error) {
+	var gw domain.VPNGateway
+	err := row.Scan(&gw.ID, &gw.VPCID, &gw.UserID, &gw.TenantID, &gw.Name, &gw.Status, &gw.PublicKey, &gw.PrivateKeySecretID, &gw.ContainerID, &gw.PrivateIP, &gw.Port, &gw.ARN, &gw.CreatedAt)
+	if err != nil {
+		if stdlib_errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New(errors.NotFound, "VPN gateway not found")
+		}
+		return nil, errors.Wrap(errors.Internal, "failed to scan VPN gateway", err)
+	}
+	return &gw, nil
+}
+
+func (r *VPNRepository) scanCustomerGateway(row pgx.Row) (*domain.CustomerGateway, error) {
+	var cgw domain.CustomerGateway
+	err := row.Scan(&cgw.ID, &cgw.UserID, &cgw.TenantID, &cgw.Name, &cgw.IPAddress, &cgw.PublicKey, &cgw.CreatedAt)
+	if err != nil {
+		if stdlib_errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New(errors.NotFound, "customer gateway not found")
+		}
+		return nil, errors.Wrap(errors.Internal, "failed to scan customer gateway", err)
+	}
+	return &cgw, nil
+}
+
+func (r *VPNRepository) scanConnection(row pgx.Row) (*domain.VPNConnection, error) {
+	var conn domain.VPNConnection
+	err := row.Scan(&conn.ID, &conn.VPNGatewayID, &conn.CustomerGatewayID, &conn.UserID, &conn.TenantID, &conn.RemoteCIDRs, &conn.PreSharedKeySecretID, &conn.Status, &conn.CreatedAt)
+	if err != nil {
+		if stdlib_errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New(errors.NotFound, "VPN connection not found")
+		}
+		return nil, errors.Wrap(errors.Internal, "failed to scan VPN connection", err)
+	}
+	return &conn, nil
+}
@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v3"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	theclouderrors "github.com/poyrazk/thecloud/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVPNRepository_CreateGateway(t *testing.T) {
+	t.Parallel()
+	gw := &domain.VPNGateway{
+		ID: uuid.New(), VPCID: uuid.New(), UserID: uuid.New(), TenantID: uuid.New(), Name: "onprem",
+		Status: domain.VPNGatewayStatusPending, PublicKey: "pub", PrivateKeySecretID: uuid.New(), ARN: "arn", CreatedAt: time.Now(),
+	}
+	args := []interface{}{gw.ID, gw.VPCID, gw.UserID, gw.TenantID, gw.Name, gw.Status, gw.PublicKey, gw.PrivateKeySecretID, gw.ContainerID, gw.PrivateIP, gw.Port, gw.ARN, gw.CreatedAt}
+
+	t.Run("inserted", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectExec("INSERT INTO vpn_gateways").WithArgs(args...).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+		require.NoError(t, NewVPNRepository(mock).CreateGateway(context.Background(), gw))
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("duplicate name", func(t *testing.T) {
+		mock, err := pgxmock.NewPool()
+		require.NoError(t, err)
+		defer mock.Close()
+
+		mock.ExpectExec("INSERT INTO vpn_gateways").WithArgs(args...).WillReturnError(&pgconn.PgError{Code: "23505"})
+		err = NewVPNRepository(mock).CreateGateway(context.Background(), gw)
+		assert.True(t, theclouderrors.Is(err, theclouderrors.Conflict))
+	})
+}
+
+func TestVPNRepository_GetGateway(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	id, tenantID := uuid.New(), uuid.New()
+	mock.ExpectQuery("SELECT id, vpc_id, user_id, tenant_id, name, status, public_key, private_key_secret_id, container_id, COALESCE\\(host\\(private_ip\\), ''\\), port, arn, created_at FROM vpn_gateways").
+		WithArgs(id, tenantID).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "vpc_id", "user_id", "tenant_id", "name", "status", "public_key", "private_key_secret_id", "container_id", "private_ip", "port", "arn", "created_at"}).
+			AddRow(id, uuid.New(), uuid.New(), tenantID, "onprem", domain.VPNGatewayStatusActive, "pub", uuid.New(), "cid", "10.0.1.5", 40001, "arn", time.Now()))
+
+	gw, err := NewVPNRepository(mock).GetGateway(appcontext.WithTenantID(context.Background(), tenantID), id)
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.1.5", gw.PrivateIP)
+	assert.Equal(t, 40001, gw.Port)
+}
+
+func TestVPNRepository_GetGatewayNotFound(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectQuery("FROM vpn_gateways").
+		WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg()).
+		WillReturnError(pgx.ErrNoRows)
+
+	_, err = NewVPNRepository(mock).GetGateway(context.Background(), uuid.New())
+	assert.True(t, theclouderrors.Is(err, theclouderrors.NotFound))
+}
+
+func TestVPNRepository_ListConnectionsByGateway(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	gatewayID, tenantID := uuid.New(), uuid.New()
+	mock.ExpectQuery("SELECT id, vpn_gateway_id, customer_gateway_id, user_id, tenant_id, remote_cidrs, pre_shared_key_secret_id, status, created_at FROM vpn_connections WHERE vpn_gateway_id = \\$1").
+		WithArgs(gatewayID, tenantID).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "vpn_gateway_id", "customer_gateway_id", "user_id", "tenant_id", "remote_cidrs", "pre_shared_key_secret_id", "status", "created_at"}).
+			AddRow(uuid.New(), gatewayID, uuid.New(), uuid.New(), tenantID, []string{"192.168.0.0/16", "172.16.0.0/12"}, uuid.New(), domain.VPNConnectionStatusAvailable, time.Now()))
+
+	conns, err := NewVPNRepository(mock).ListConnectionsByGateway(appcontext.WithTenantID(context.Background(), tenantID), gatewayID)
+	require.NoError(t, err)
+	require.Len(t, conns, 1)
+	assert.Equal(t, []string{"192.168.0.0/16", "172.16.0.0/12"}, conns[0].RemoteCIDRs)
+}
+
+func TestVPNRepository_DeleteCustomerGatewayNotFound(t *testing.T) {
+	t.Parallel()
+	mock, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer mock.Close()
+
+	mock.ExpectExec("DELETE FROM customer_gateways").
+		WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	err = NewVPNRepository(mock).DeleteCustomerGateway(context.Background(), uuid.New())
+	assert.True(t, theclouderrors.Is(err, theclouderrors.NotFound))
+}
@@ -41,6 +41,10 @@ func (t *countingAccountingService) GetSummary(ctx context.Context, userID uuid.
 	return nil, nil
 }
 
+func (t *countingAccountingService) GetOrganizationSummary(ctx context.Context, orgID uuid.UUID, start, end time.Time) (*domain.OrganizationBillSummary, error) {
+	return nil, nil
+}
+
 func (t *countingAccountingService) ListUsage(ctx context.Context, userID uuid.UUID, start, end time.Time) ([]domain.UsageRecord, error) {
 	return nil, nil
 }
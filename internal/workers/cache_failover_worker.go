@@ -0,0 +1,118 @@
+// Package workers hosts background worker implementations.
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+)
+
+const (
+	defaultCacheFailoverInterval = 15 * time.Second
+	cacheCheckTimeout            = 2 * time.Second
+	// cacheFailureThreshold is the number of consecutive failed checks
+	// before a primary is failed over, so a single slow PING does not
+	// trigger a failover.
+	cacheFailureThreshold = 2
+)
+
+// CacheFailoverWorker monitors the primary node of caches with automatic
+// failover enabled and promotes a replica when it stops answering.
+type CacheFailoverWorker struct {
+	cacheSvc ports.CacheService
+	repo     ports.CacheRepository
+	compute  ports.ComputeBackend
+	logger   *slog.Logger
+
+	interval time.Duration
+	failures map[string]int // consecutive failed checks by primary container ID
+}
+
+// NewCacheFailoverWorker constructs a CacheFailoverWorker.
+func NewCacheFailoverWorker(cacheSvc ports.CacheService, repo ports.CacheRepository, compute ports.ComputeBackend, logger *slog.Logger) *CacheFailoverWorker {
+	return &CacheFailoverWorker{
+		cacheSvc: cacheSvc,
+		repo:     repo,
+		compute:  compute,
+		logger:   logger.With("worker", "cache_failover"),
+		interval: defaultCacheFailoverInterval,
+		failures: make(map[string]int),
+	}
+}
+
+// Run starts the failover monitoring loop.
+func (w *CacheFailoverWorker) Run(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+	w.logger.Info("starting cache failover worker", "interval", w.interval)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("stopping cache failover worker")
+			return
+		case <-ticker.C:
+			w.checkCaches(ctx)
+		}
+	}
+}
+
+func (w *CacheFailoverWorker) checkCaches(ctx context.Context) {
+	if w.compute.Type() == "libvirt" {
+		return
+	}
+	caches, err := w.repo.ListAll(ctx)
+	if err != nil {
+		w.logger.Error("failed to list caches for failover check", "error", err)
+		return
+	}
+
+	for _, cache := range caches {
+		// Redis Cluster fails its shards over by itself.
+		if !cache.FailoverEnabled || cache.ClusterMode || cache.Status != domain.CacheStatusRunning || cache.ContainerID == "" {
+			continue
+		}
+
+		if w.isHealthy(ctx, cache) {
+			delete(w.failures, cache.ContainerID)
+			continue
+		}
+		w.failures[cache.ContainerID]++
+		if w.failures[cache.ContainerID] < cacheFailureThreshold {
+			continue
+		}
+		delete(w.failures, cache.ContainerID)
+
+		w.logger.Warn("detected cache primary failure, initiating failover", "id", cache.ID, "name", cache.Name)
+		ownerCtx := appcontext.WithUserID(appcontext.WithTenantID(ctx, cache.TenantID), cache.UserID)
+		if err := w.cacheSvc.FailoverCache(ownerCtx, cache.ID.String()); err != nil {
+			w.logger.Error("cache failover failed", "id", cache.ID, "error", err)
+			continue
+		}
+		w.logger.Info("cache failover completed", "id", cache.ID)
+	}
+}
+
+// isHealthy reports whether the cache primary answers PING.
+func (w *CacheFailoverWorker) isHealthy(ctx context.Context, cache *domain.Cache) bool {
+	execCtx, cancel := context.WithTimeout(ctx, cacheCheckTimeout)
+	defer cancel()
+
+	cmd := []string{cache.Engine.CLI(), "--no-auth-warning"}
+	if cache.Password != "" {
+		cmd = append(cmd, "-a", cache.Password)
+	}
+	output, err := w.compute.Exec(execCtx, cache.ContainerID, append(cmd, "PING"))
+	if err != nil {
+		w.logger.Debug("cache health check failed", "id", cache.ID, "error", err)
+		return false
+	}
+	return strings.Contains(output, "PONG")
+}
@@ -0,0 +1,193 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockCacheRepo struct {
+	mock.Mock
+}
+
+func (m *mockCacheRepo) Create(ctx context.Context, cache *domain.Cache) error {
+	return m.Called(ctx, cache).Error(0)
+}
+func (m *mockCacheRepo) GetByID(ctx context.Context, id, tenantID uuid.UUID) (*domain.Cache, error) {
+	args := m.Called(ctx, id, tenantID)
+	r0, _ := args.Get(0).(*domain.Cache)
+	return r0, args.Error(1)
+}
+func (m *mockCacheRepo) GetByName(ctx context.Context, tenantID uuid.UUID, name string) (*domain.Cache, error) {
+	args := m.Called(ctx, tenantID, name)
+	r0, _ := args.Get(0).(*domain.Cache)
+	return r0, args.Error(1)
+}
+func (m *mockCacheRepo) List(ctx context.Context, tenantID uuid.UUID) ([]*domain.Cache, error) {
+	args := m.Called(ctx, tenantID)
+	r0, _ := args.Get(0).([]*domain.Cache)
+	return r0, args.Error(1)
+}
+func (m *mockCacheRepo) Update(ctx context.Context, cache *domain.Cache) error {
+	return m.Called(ctx, cache).Error(0)
+}
+func (m *mockCacheRepo) Delete(ctx context.Context, id, tenantID uuid.UUID) error {
+	return m.Called(ctx, id, tenantID).Error(0)
+}
+func (m *mockCacheRepo) ListAll(ctx context.Context) ([]*domain.Cache, error) {
+	args := m.Called(ctx)
+	r0, _ := args.Get(0).([]*domain.Cache)
+	return r0, args.Error(1)
+}
+func (m *mockCacheRepo) CreateNode(ctx context.Context, node *domain.CacheNode) error {
+	return m.Called(ctx, node).Error(0)
+}
+func (m *mockCacheRepo) ListNodes(ctx context.Context, cacheID uuid.UUID) ([]*domain.CacheNode, error) {
+	args := m.Called(ctx, cacheID)
+	r0, _ := args.Get(0).([]*domain.CacheNode)
+	return r0, args.Error(1)
+}
+func (m *mockCacheRepo) UpdateNode(ctx context.Context, node *domain.CacheNode) error {
+	return m.Called(ctx, node).Error(0)
+}
+func (m *mockCacheRepo) DeleteNode(ctx context.Context, id uuid.UUID) error {
+	return m.Called(ctx, id).Error(0)
+}
+
+type mockCacheService struct {
+	mock.Mock
+}
+
+func (m *mockCacheService) CreateCache(ctx context.Context, req ports.CreateCacheRequest) (*domain.Cache, error) {
+	args := m.Called(ctx, req)
+	r0, _ := args.Get(0).(*domain.Cache)
+	return r0, args.Error(1)
+}
+func (m *mockCacheService) GetCache(ctx context.Context, idOrName string) (*domain.Cache, error) {
+	args := m.Called(ctx, idOrName)
+	r0, _ := args.Get(0).(*domain.Cache)
+	return r0, args.Error(1)
+}
+func (m *mockCacheService) ListCaches(ctx context.Context) ([]*domain.Cache, error) {
+	args := m.Called(ctx)
+	r0, _ := args.Get(0).([]*domain.Cache)
+	return r0, args.Error(1)
+}
+func (m *mockCacheService) DeleteCache(ctx context.Context, idOrName string) error {
+	return m.Called(ctx, idOrName).Error(0)
+}
+func (m *mockCacheService) GetConnectionString(ctx context.Context, idOrName string) (string, error) {
+	args := m.Called(ctx, idOrName)
+	return args.String(0), args.Error(1)
+}
+func (m *mockCacheService) FlushCache(ctx context.Context, idOrName string) error {
+	return m.Called(ctx, idOrName).Error(0)
+}
+func (m *mockCacheService) GetCacheStats(ctx context.Context, idOrName string) (*ports.CacheStats, error) {
+	args := m.Called(ctx, idOrName)
+	r0, _ := args.Get(0).(*ports.CacheStats)
+	return r0, args.Error(1)
+}
+func (m *mockCacheService) FailoverCache(ctx context.Context, idOrName string) error {
+	return m.Called(ctx, idOrName).Error(0)
+}
+func (m *mockCacheService) RotateCacheCredentials(ctx context.Context, idOrName string) error {
+	return m.Called(ctx, idOrName).Error(0)
+}
+func (m *mockCacheService) ModifyCache(ctx context.Context, idOrName string, req ports.ModifyCacheRequest) (*domain.Cache, error) {
+	args := m.Called(ctx, idOrName, req)
+	r0, _ := args.Get(0).(*domain.Cache)
+	return r0, args.Error(1)
+}
+
+func TestCacheFailoverWorker(t *testing.T) {
+	t.Parallel()
+
+	newCache := func(failover bool) *domain.Cache {
+		return &domain.Cache{
+			ID: uuid.New(), UserID: uuid.New(), TenantID: uuid.New(), Name: "sessions",
+			Status: domain.CacheStatusRunning, ContainerID: "cid-" + uuid.NewString()[:8], Password: "pw",
+			Replicas: 1, FailoverEnabled: failover,
+		}
+	}
+	ping := []string{"redis-cli", "--no-auth-warning", "-a", "pw", "PING"}
+
+	t.Run("Failover after consecutive failed checks", func(t *testing.T) {
+		repo := new(mockCacheRepo)
+		svc := new(mockCacheService)
+		compute := new(mockComputeBackend)
+		worker := NewCacheFailoverWorker(svc, repo, compute, slog.Default())
+
+		cache := newCache(true)
+		repo.On("ListAll", mock.Anything).Return([]*domain.Cache{cache}, nil)
+		compute.On("Exec", mock.Anything, cache.ContainerID, ping).Return("", errors.New("container is not running"))
+		svc.On("FailoverCache", mock.MatchedBy(func(ctx context.Context) bool {
+			return appcontext.TenantIDFromContext(ctx) == cache.TenantID && appcontext.UserIDFromContext(ctx) == cache.UserID
+		}), cache.ID.String()).Return(nil).Once()
+
+		worker.checkCaches(context.Background())
+		svc.AssertNotCalled(t, "FailoverCache", mock.Anything, mock.Anything)
+
+		worker.checkCaches(context.Background())
+		svc.AssertExpectations(t)
+		assert.Empty(t, worker.failures)
+	})
+
+	t.Run("Healthy primary resets the failure count", func(t *testing.T) {
+		repo := new(mockCacheRepo)
+		svc := new(mockCacheService)
+		compute := new(mockComputeBackend)
+		worker := NewCacheFailoverWorker(svc, repo, compute, slog.Default())
+
+		cache := newCache(true)
+		repo.On("ListAll", mock.Anything).Return([]*domain.Cache{cache}, nil)
+		compute.On("Exec", mock.Anything, cache.ContainerID, ping).Return("", errors.New("timeout")).Once()
+		compute.On("Exec", mock.Anything, cache.ContainerID, ping).Return("PONG\n", nil).Once()
+
+		worker.checkCaches(context.Background())
+		assert.Equal(t, 1, worker.failures[cache.ContainerID])
+		worker.checkCaches(context.Background())
+		assert.Empty(t, worker.failures)
+		svc.AssertNotCalled(t, "FailoverCache", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Checks Valkey caches with valkey-cli", func(t *testing.T) {
+		repo := new(mockCacheRepo)
+		svc := new(mockCacheService)
+		compute := new(mockComputeBackend)
+		worker := NewCacheFailoverWorker(svc, repo, compute, slog.Default())
+
+		cache := newCache(true)
+		cache.Engine = domain.EngineValkey
+		repo.On("ListAll", mock.Anything).Return([]*domain.Cache{cache}, nil)
+		compute.On("Exec", mock.Anything, cache.ContainerID, []string{"valkey-cli", "--no-auth-warning", "-a", "pw", "PING"}).Return("PONG\n", nil).Once()
+
+		worker.checkCaches(context.Background())
+		compute.AssertExpectations(t)
+		assert.Empty(t, worker.failures)
+	})
+
+	t.Run("Skips caches without automatic failover", func(t *testing.T) {
+		repo := new(mockCacheRepo)
+		svc := new(mockCacheService)
+		compute := new(mockComputeBackend)
+		worker := NewCacheFailoverWorker(svc, repo, compute, slog.Default())
+
+		stopped := newCache(true)
+		stopped.Status = domain.CacheStatusStopped
+		cluster := newCache(true)
+		cluster.ClusterMode = true
+		repo.On("ListAll", mock.Anything).Return([]*domain.Cache{newCache(false), stopped, cluster}, nil)
+
+		worker.checkCaches(context.Background())
+		compute.AssertNotCalled(t, "Exec", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
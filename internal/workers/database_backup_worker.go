@@ -0,0 +1,59 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/poyrazk/thecloud/internal/core/ports"
+)
+
+// DatabaseBackupWorker ships archived WAL and binary logs of databases with
+// point-in-time recovery enabled, takes their base backups and prunes
+// backups that fell out of the retention window.
+type DatabaseBackupWorker struct {
+	databaseSvc ports.DatabaseService
+	logger      *slog.Logger
+	interval    time.Duration
+}
+
+// NewDatabaseBackupWorker constructs a DatabaseBackupWorker. The interval
+// matches the PostgreSQL archive_timeout so restores can reach within a couple
+// of minutes of the present.
+func NewDatabaseBackupWorker(databaseSvc ports.DatabaseService, logger *slog.Logger) *DatabaseBackupWorker {
+	return &DatabaseBackupWorker{
+		databaseSvc: databaseSvc,
+		logger:      logger,
+		interval:    time.Minute,
+	}
+}
+
+func (w *DatabaseBackupWorker) Run(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+	w.logger.Info("starting database backup worker", "interval", w.interval)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("stopping database backup worker")
+			return
+		case <-ticker.C:
+			w.archive(ctx)
+		}
+	}
+}
+
+func (w *DatabaseBackupWorker) archive(ctx context.Context) {
+	shipped, err := w.databaseSvc.ArchiveDatabaseLogs(ctx)
+	if err != nil {
+		w.logger.Error("failed to archive database logs", "error", err)
+		return
+	}
+	if shipped > 0 {
+		w.logger.Debug("archived database logs", "count", shipped)
+	}
+}
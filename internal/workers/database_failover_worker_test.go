@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/poyrazk/thecloud/internal/core/domain"
@@ -44,6 +45,27 @@ func (m *mockDatabaseRepo) ListReplicas(ctx context.Context, primaryID uuid.UUID
 	}
 	return args.Get(0).([]*domain.Database), args.Error(1)
 }
+func (m *mockDatabaseRepo) ListWithBackupRetention(ctx context.Context) ([]*domain.Database, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Database), args.Error(1)
+}
+func (m *mockDatabaseRepo) ListPendingMaintenance(ctx context.Context) ([]*domain.Database, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Database), args.Error(1)
+}
+func (m *mockDatabaseRepo) ListWithMetrics(ctx context.Context) ([]*domain.Database, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Database), args.Error(1)
+}
 func (m *mockDatabaseRepo) Update(ctx context.Context, db *domain.Database) error {
 	return m.Called(ctx, db).Error(0)
 }
@@ -121,6 +143,121 @@ func (m *mockDatabaseService) RestoreDatabase(ctx context.Context, req ports.Res
 	}
 	return args.Get(0).(*domain.Database), args.Error(1)
 }
+func (m *mockDatabaseService) ArchiveDatabaseLogs(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+func (m *mockDatabaseService) PutBackupPolicy(ctx context.Context, databaseID uuid.UUID, req ports.BackupPolicyRequest) (*domain.DatabaseBackupPolicy, error) {
+	args := m.Called(ctx, databaseID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DatabaseBackupPolicy), args.Error(1)
+}
+func (m *mockDatabaseService) GetBackupPolicy(ctx context.Context, databaseID uuid.UUID) (*domain.DatabaseBackupPolicy, error) {
+	args := m.Called(ctx, databaseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DatabaseBackupPolicy), args.Error(1)
+}
+func (m *mockDatabaseService) DeleteBackupPolicy(ctx context.Context, databaseID uuid.UUID) error {
+	args := m.Called(ctx, databaseID)
+	return args.Error(0)
+}
+func (m *mockDatabaseService) ListAutomatedBackups(ctx context.Context, databaseID uuid.UUID) ([]*domain.DatabaseAutomatedBackup, error) {
+	args := m.Called(ctx, databaseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.DatabaseAutomatedBackup), args.Error(1)
+}
+func (m *mockDatabaseService) DeleteAutomatedBackup(ctx context.Context, databaseID, backupID uuid.UUID) error {
+	args := m.Called(ctx, databaseID, backupID)
+	return args.Error(0)
+}
+func (m *mockDatabaseService) RunScheduledBackups(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+func (m *mockDatabaseService) UpgradeDatabase(ctx context.Context, req ports.UpgradeDatabaseRequest) (*domain.Database, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Database), args.Error(1)
+}
+func (m *mockDatabaseService) ListDatabaseUpgrades(ctx context.Context, databaseID uuid.UUID) ([]*domain.DatabaseUpgrade, error) {
+	args := m.Called(ctx, databaseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.DatabaseUpgrade), args.Error(1)
+}
+func (m *mockDatabaseService) RunMaintenance(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+func (m *mockDatabaseService) CreateLogicalDatabase(ctx context.Context, databaseID uuid.UUID, name string) (*domain.LogicalDatabase, error) {
+	args := m.Called(ctx, databaseID, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.LogicalDatabase), args.Error(1)
+}
+func (m *mockDatabaseService) ListLogicalDatabases(ctx context.Context, databaseID uuid.UUID) ([]*domain.LogicalDatabase, error) {
+	args := m.Called(ctx, databaseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.LogicalDatabase), args.Error(1)
+}
+func (m *mockDatabaseService) DeleteLogicalDatabase(ctx context.Context, databaseID uuid.UUID, name string) error {
+	return m.Called(ctx, databaseID, name).Error(0)
+}
+func (m *mockDatabaseService) CreateDatabaseUser(ctx context.Context, req ports.CreateDatabaseUserRequest) (*domain.DatabaseUser, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DatabaseUser), args.Error(1)
+}
+func (m *mockDatabaseService) ListDatabaseUsers(ctx context.Context, databaseID uuid.UUID) ([]*domain.DatabaseUser, error) {
+	args := m.Called(ctx, databaseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.DatabaseUser), args.Error(1)
+}
+func (m *mockDatabaseService) SetDatabaseUserGrants(ctx context.Context, databaseID uuid.UUID, username string, grants []domain.DatabaseGrant) (*domain.DatabaseUser, error) {
+	args := m.Called(ctx, databaseID, username, grants)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DatabaseUser), args.Error(1)
+}
+func (m *mockDatabaseService) RotateDatabaseUserCredentials(ctx context.Context, databaseID uuid.UUID, username string) (*domain.DatabaseUser, error) {
+	args := m.Called(ctx, databaseID, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DatabaseUser), args.Error(1)
+}
+func (m *mockDatabaseService) DeleteDatabaseUser(ctx context.Context, databaseID uuid.UUID, username string) error {
+	return m.Called(ctx, databaseID, username).Error(0)
+}
+func (m *mockDatabaseService) GetDatabaseInsights(ctx context.Context, databaseID uuid.UUID, period time.Duration) (*domain.DatabaseInsights, error) {
+	args := m.Called(ctx, databaseID, period)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.DatabaseInsights), args.Error(1)
+}
+func (m *mockDatabaseService) CollectDatabaseInsights(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
 func (m *mockDatabaseService) RotateCredentials(ctx context.Context, id uuid.UUID, idempotencyKey string) error {
 	args := m.Called(ctx, id, idempotencyKey)
 	return args.Error(0)
@@ -204,6 +341,12 @@ func (m *mockComputeBackend) DetachVolume(ctx context.Context, id string, volume
 	args := m.Called(ctx, id, volumePath)
 	return args.String(0), args.Error(1)
 }
+func (m *mockComputeBackend) AttachNetworkInterface(ctx context.Context, id string, nic ports.NetworkInterfaceOptions) error {
+	return m.Called(ctx, id, nic).Error(0)
+}
+func (m *mockComputeBackend) DetachNetworkInterface(ctx context.Context, id string, nic ports.NetworkInterfaceOptions) error {
+	return m.Called(ctx, id, nic).Error(0)
+}
 func (m *mockComputeBackend) Ping(ctx context.Context) error {
 	return m.Called(ctx).Error(0)
 }
@@ -229,6 +372,11 @@ func (m *mockComputeBackend) DeleteSnapshot(ctx context.Context, id, name string
 	return m.Called(ctx, id, name).Error(0)
 }
 func (m *mockComputeBackend) ResetCircuitBreaker() {}
+func (m *mockComputeBackend) HostMemoryPressure(ctx context.Context) ([]ports.HostPressure, error) {
+	args := m.Called(ctx)
+	r0, _ := args.Get(0).([]ports.HostPressure)
+	return r0, args.Error(1)
+}
 
 func TestDatabaseFailoverWorker(t *testing.T) {
 	t.Parallel()
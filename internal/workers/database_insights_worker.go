@@ -0,0 +1,55 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/poyrazk/thecloud/internal/core/ports"
+)
+
+// DatabaseInsightsWorker samples the performance of databases with metrics
+// enabled and prunes expired samples.
+type DatabaseInsightsWorker struct {
+	databaseSvc ports.DatabaseService
+	logger      *slog.Logger
+	interval    time.Duration
+}
+
+// NewDatabaseInsightsWorker constructs a DatabaseInsightsWorker that samples
+// once a minute.
+func NewDatabaseInsightsWorker(databaseSvc ports.DatabaseService, logger *slog.Logger) *DatabaseInsightsWorker {
+	return &DatabaseInsightsWorker{
+		databaseSvc: databaseSvc,
+		logger:      logger,
+		interval:    time.Minute,
+	}
+}
+
+func (w *DatabaseInsightsWorker) Run(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+	w.logger.Info("starting database insights worker", "interval", w.interval)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("stopping database insights worker")
+			return
+		case <-ticker.C:
+			w.collect(ctx)
+		}
+	}
+}
+
+func (w *DatabaseInsightsWorker) collect(ctx context.Context) {
+	sampled, err := w.databaseSvc.CollectDatabaseInsights(ctx)
+	if err != nil {
+		w.logger.Error("failed to collect database insights", "error", err)
+		return
+	}
+	w.logger.Debug("collected database insights", "count", sampled)
+}
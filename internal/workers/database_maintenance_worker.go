@@ -0,0 +1,57 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/poyrazk/thecloud/internal/core/ports"
+)
+
+// DatabaseMaintenanceWorker applies pending version upgrades and parameter
+// changes of databases whose maintenance window is open.
+type DatabaseMaintenanceWorker struct {
+	databaseSvc ports.DatabaseService
+	logger      *slog.Logger
+	interval    time.Duration
+}
+
+// NewDatabaseMaintenanceWorker constructs a DatabaseMaintenanceWorker.
+// Maintenance windows are an hour long, so a one-minute tick never misses one.
+func NewDatabaseMaintenanceWorker(databaseSvc ports.DatabaseService, logger *slog.Logger) *DatabaseMaintenanceWorker {
+	return &DatabaseMaintenanceWorker{
+		databaseSvc: databaseSvc,
+		logger:      logger,
+		interval:    time.Minute,
+	}
+}
+
+func (w *DatabaseMaintenanceWorker) Run(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+	w.logger.Info("starting database maintenance worker", "interval", w.interval)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("stopping database maintenance worker")
+			return
+		case <-ticker.C:
+			w.maintain(ctx)
+		}
+	}
+}
+
+func (w *DatabaseMaintenanceWorker) maintain(ctx context.Context) {
+	maintained, err := w.databaseSvc.RunMaintenance(ctx)
+	if err != nil {
+		w.logger.Error("failed to run database maintenance", "error", err)
+		return
+	}
+	if maintained > 0 {
+		w.logger.Info("completed database maintenance", "count", maintained)
+	}
+}
@@ -0,0 +1,45 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNewDatabaseMaintenanceWorker(t *testing.T) {
+	svc := new(mockDatabaseService)
+	worker := NewDatabaseMaintenanceWorker(svc, slog.Default())
+	assert.NotNil(t, worker)
+	assert.Equal(t, time.Minute, worker.interval)
+}
+
+func TestDatabaseMaintenanceWorker_Run(t *testing.T) {
+	svc := new(mockDatabaseService)
+	worker := &DatabaseMaintenanceWorker{
+		databaseSvc: svc,
+		logger:      slog.New(slog.NewTextHandler(io.Discard, nil)),
+		interval:    10 * time.Millisecond,
+	}
+
+	svc.On("RunMaintenance", mock.Anything).Return(1, nil).Once()
+	svc.On("RunMaintenance", mock.Anything).Return(0, errors.New("database lookup failed")).Once()
+	svc.On("RunMaintenance", mock.Anything).Return(0, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go worker.Run(ctx, &wg)
+
+	time.Sleep(45 * time.Millisecond)
+	cancel()
+	wg.Wait()
+
+	assert.GreaterOrEqual(t, len(svc.Calls), 3)
+}
@@ -0,0 +1,58 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/poyrazk/thecloud/internal/core/ports"
+)
+
+// DatabaseScheduledBackupWorker takes the backups of database backup policies
+// whose window has come, exports them and prunes expired ones.
+type DatabaseScheduledBackupWorker struct {
+	databaseSvc ports.DatabaseService
+	logger      *slog.Logger
+	interval    time.Duration
+}
+
+// NewDatabaseScheduledBackupWorker constructs a DatabaseScheduledBackupWorker.
+// Backup windows are at least 30 minutes long, so a one-minute tick never
+// misses one.
+func NewDatabaseScheduledBackupWorker(databaseSvc ports.DatabaseService, logger *slog.Logger) *DatabaseScheduledBackupWorker {
+	return &DatabaseScheduledBackupWorker{
+		databaseSvc: databaseSvc,
+		logger:      logger,
+		interval:    time.Minute,
+	}
+}
+
+func (w *DatabaseScheduledBackupWorker) Run(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+	w.logger.Info("starting database scheduled backup worker", "interval", w.interval)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("stopping database scheduled backup worker")
+			return
+		case <-ticker.C:
+			w.backup(ctx)
+		}
+	}
+}
+
+func (w *DatabaseScheduledBackupWorker) backup(ctx context.Context) {
+	completed, err := w.databaseSvc.RunScheduledBackups(ctx)
+	if err != nil {
+		w.logger.Error("failed to run scheduled database backups", "error", err)
+		return
+	}
+	if completed > 0 {
+		w.logger.Info("completed scheduled database backups", "count", completed)
+	}
+}
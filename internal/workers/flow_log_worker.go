@@ -0,0 +1,56 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/poyrazk/thecloud/internal/core/ports"
+)
+
+// FlowLogWorker periodically captures and delivers VPC flow log records.
+type FlowLogWorker struct {
+	flowLogSvc ports.FlowLogService
+	logger     *slog.Logger
+	interval   time.Duration
+}
+
+// NewFlowLogWorker constructs a FlowLogWorker. It ticks at the shortest capture
+// interval; each flow log decides whether it is due.
+func NewFlowLogWorker(flowLogSvc ports.FlowLogService, logger *slog.Logger) *FlowLogWorker {
+	return &FlowLogWorker{
+		flowLogSvc: flowLogSvc,
+		logger:     logger,
+		interval:   time.Minute,
+	}
+}
+
+func (w *FlowLogWorker) Run(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+	w.logger.Info("starting flow log worker", "interval", w.interval)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("stopping flow log worker")
+			return
+		case <-ticker.C:
+			w.capture(ctx)
+		}
+	}
+}
+
+func (w *FlowLogWorker) capture(ctx context.Context) {
+	delivered, err := w.flowLogSvc.CaptureDue(ctx)
+	if err != nil {
+		w.logger.Error("failed to capture flow logs", "error", err)
+		return
+	}
+	if delivered > 0 {
+		w.logger.Debug("captured flow logs", "count", delivered)
+	}
+}
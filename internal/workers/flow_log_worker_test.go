@@ -0,0 +1,57 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// mockFlowLogService implements only what the worker uses.
+type mockFlowLogService struct {
+	ports.FlowLogService
+	mock.Mock
+}
+
+func (m *mockFlowLogService) CaptureDue(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func TestNewFlowLogWorker(t *testing.T) {
+	svc := new(mockFlowLogService)
+	worker := NewFlowLogWorker(svc, slog.Default())
+	assert.NotNil(t, worker)
+	assert.Equal(t, time.Minute, worker.interval)
+}
+
+func TestFlowLogWorker_Run(t *testing.T) {
+	svc := new(mockFlowLogService)
+	worker := &FlowLogWorker{
+		flowLogSvc: svc,
+		logger:     slog.New(slog.NewTextHandler(io.Discard, nil)),
+		interval:   10 * time.Millisecond,
+	}
+
+	svc.On("CaptureDue", mock.Anything).Return(3, nil).Once()
+	svc.On("CaptureDue", mock.Anything).Return(0, errors.New("db down")).Once()
+	svc.On("CaptureDue", mock.Anything).Return(0, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go worker.Run(ctx, &wg)
+
+	time.Sleep(45 * time.Millisecond)
+	cancel()
+	wg.Wait()
+
+	assert.GreaterOrEqual(t, len(svc.Calls), 3)
+}
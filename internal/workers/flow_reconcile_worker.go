@@ -0,0 +1,55 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/poyrazk/thecloud/internal/core/ports"
+)
+
+// FlowReconcileWorker periodically repairs drift between the database and the flows on VPC bridges.
+type FlowReconcileWorker struct {
+	reconcilerSvc ports.FlowReconcilerService
+	logger        *slog.Logger
+	interval      time.Duration
+}
+
+// NewFlowReconcileWorker constructs a FlowReconcileWorker with the default interval.
+func NewFlowReconcileWorker(reconcilerSvc ports.FlowReconcilerService, logger *slog.Logger) *FlowReconcileWorker {
+	return &FlowReconcileWorker{
+		reconcilerSvc: reconcilerSvc,
+		logger:        logger,
+		interval:      5 * time.Minute,
+	}
+}
+
+func (w *FlowReconcileWorker) Run(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+	w.logger.Info("starting flow reconcile worker", "interval", w.interval)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("stopping flow reconcile worker")
+			return
+		case <-ticker.C:
+			w.reconcile(ctx)
+		}
+	}
+}
+
+func (w *FlowReconcileWorker) reconcile(ctx context.Context) {
+	drifted, err := w.reconcilerSvc.ReconcileAll(ctx)
+	if err != nil {
+		w.logger.Error("failed to reconcile flows", "error", err)
+		return
+	}
+	if drifted > 0 {
+		w.logger.Warn("repaired flow drift", "vpcs", drifted)
+	}
+}
@@ -0,0 +1,58 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// mockFlowReconcilerService implements only what the worker uses.
+type mockFlowReconcilerService struct {
+	ports.FlowReconcilerService
+	mock.Mock
+}
+
+func (m *mockFlowReconcilerService) ReconcileAll(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func TestNewFlowReconcileWorker(t *testing.T) {
+	svc := new(mockFlowReconcilerService)
+	worker := NewFlowReconcileWorker(svc, slog.Default())
+	assert.NotNil(t, worker)
+	assert.Equal(t, 5*time.Minute, worker.interval)
+}
+
+func TestFlowReconcileWorker_Run(t *testing.T) {
+	svc := new(mockFlowReconcilerService)
+	worker := &FlowReconcileWorker{
+		reconcilerSvc: svc,
+		logger:        slog.New(slog.NewTextHandler(io.Discard, nil)),
+		interval:      10 * time.Millisecond,
+	}
+
+	// First tick repairs drift, the second fails, later ticks find bridges in sync.
+	svc.On("ReconcileAll", mock.Anything).Return(2, nil).Once()
+	svc.On("ReconcileAll", mock.Anything).Return(0, errors.New("db down")).Once()
+	svc.On("ReconcileAll", mock.Anything).Return(0, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go worker.Run(ctx, &wg)
+
+	time.Sleep(45 * time.Millisecond)
+	cancel()
+	wg.Wait()
+
+	assert.GreaterOrEqual(t, len(svc.Calls), 3)
+}
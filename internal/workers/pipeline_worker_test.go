@@ -155,6 +155,12 @@ func (m *mockComputeBackendExtended) DetachVolume(ctx context.Context, id, volum
 	args := m.Called(ctx, id, volumePath)
 	return args.String(0), args.Error(1)
 }
+func (m *mockComputeBackendExtended) AttachNetworkInterface(ctx context.Context, id string, nic ports.NetworkInterfaceOptions) error {
+	return m.Called(ctx, id, nic).Error(0)
+}
+func (m *mockComputeBackendExtended) DetachNetworkInterface(ctx context.Context, id string, nic ports.NetworkInterfaceOptions) error {
+	return m.Called(ctx, id, nic).Error(0)
+}
 func (m *mockComputeBackendExtended) Ping(ctx context.Context) error {
 	return nil
 }
@@ -177,6 +183,9 @@ func (m *mockComputeBackendExtended) DeleteSnapshot(ctx context.Context, id, nam
 	return m.Called(ctx, id, name).Error(0)
 }
 func (m *mockComputeBackendExtended) ResetCircuitBreaker() {}
+func (m *mockComputeBackendExtended) HostMemoryPressure(ctx context.Context) ([]ports.HostPressure, error) {
+	return nil, nil
+}
 
 func TestPipelineWorker_processJob(t *testing.T) {
 	repo := new(mockPipelineRepo)
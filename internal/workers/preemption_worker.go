@@ -0,0 +1,142 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+)
+
+const defaultPreemptionInterval = 10 * time.Second
+
+// PreemptionWorker reclaims preemptible instances on compute hosts that run
+// short of memory. A reclaimed instance first receives a termination notice
+// through the metadata service and is terminated once the notice period has
+// passed. Only instances on the host under pressure are reclaimed.
+type PreemptionWorker struct {
+	instSvc   ports.InstanceService
+	repo      ports.InstanceRepository
+	compute   ports.ComputeBackend
+	threshold float64 // memory pressure, from 0 to 1, that triggers a preemption
+	logger    *slog.Logger
+
+	interval time.Duration
+	now      func() time.Time
+}
+
+// NewPreemptionWorker constructs a PreemptionWorker that preempts an instance
+// on a compute host when its memory usage reaches thresholdPercent.
+func NewPreemptionWorker(instSvc ports.InstanceService, repo ports.InstanceRepository, compute ports.ComputeBackend, thresholdPercent int, logger *slog.Logger) *PreemptionWorker {
+	return &PreemptionWorker{
+		instSvc:   instSvc,
+		repo:      repo,
+		compute:   compute,
+		threshold: float64(thresholdPercent) / 100,
+		logger:    logger.With("worker", "preemption"),
+		interval:  defaultPreemptionInterval,
+		now:       time.Now,
+	}
+}
+
+// Run starts the preemption loop.
+func (w *PreemptionWorker) Run(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+	w.logger.Info("starting preemption worker", "interval", w.interval, "threshold", w.threshold)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("stopping preemption worker")
+			return
+		case <-ticker.C:
+			w.reclaim(ctx)
+		}
+	}
+}
+
+func (w *PreemptionWorker) reclaim(ctx context.Context) {
+	instances, err := w.repo.ListAll(ctx)
+	if err != nil {
+		w.logger.Error("failed to list instances for preemption", "error", err)
+		return
+	}
+
+	now := w.now()
+	var running []*domain.Instance
+	for _, inst := range instances {
+		if !inst.IsPreemptible() {
+			continue
+		}
+		if notice := inst.PreemptionNotice(); notice != nil && !now.Before(notice.Time) {
+			w.terminate(ctx, inst)
+			continue
+		}
+		running = append(running, inst)
+	}
+	if len(running) == 0 {
+		return
+	}
+
+	hosts, err := w.compute.HostMemoryPressure(ctx)
+	if err != nil {
+		w.logger.Error("failed to read host memory pressure", "error", err)
+		return
+	}
+	for _, host := range hosts {
+		if host.Pressure >= w.threshold {
+			w.preemptOn(ctx, host, running, now)
+		}
+	}
+}
+
+// preemptOn posts a notice to the newest preemptible instance on host, unless
+// a notice there is still pending.
+func (w *PreemptionWorker) preemptOn(ctx context.Context, host ports.HostPressure, instances []*domain.Instance, now time.Time) {
+	onHost := make(map[string]bool, len(host.InstanceIDs))
+	for _, id := range host.InstanceIDs {
+		onHost[id] = true
+	}
+
+	var candidate *domain.Instance
+	for _, inst := range instances {
+		if inst.ContainerID == "" || !onHost[inst.ContainerID] {
+			continue
+		}
+		// Wait for pending notices to take effect before reclaiming more.
+		if inst.PreemptionNotice() != nil {
+			return
+		}
+		// The most recently launched instance has done the least work.
+		if inst.Status == domain.StatusRunning && (candidate == nil || inst.CreatedAt.After(candidate.CreatedAt)) {
+			candidate = inst
+		}
+	}
+	if candidate == nil {
+		return
+	}
+
+	candidate.Metadata[domain.MetadataPreemptionTime] = now.Add(domain.PreemptionNoticePeriod).UTC().Format(time.RFC3339)
+	if err := w.repo.Update(ownerContext(ctx, candidate), candidate); err != nil {
+		w.logger.Error("failed to post preemption notice", "instance_id", candidate.ID, "error", err)
+		return
+	}
+	w.logger.Warn("preempting instance under host memory pressure", "instance_id", candidate.ID, "host", host.Host, "pressure", host.Pressure)
+}
+
+func (w *PreemptionWorker) terminate(ctx context.Context, inst *domain.Instance) {
+	if err := w.instSvc.TerminateInstance(ownerContext(ctx, inst), inst.ID.String()); err != nil {
+		w.logger.Error("failed to terminate preempted instance", "instance_id", inst.ID, "error", err)
+		return
+	}
+	w.logger.Info("terminated preempted instance", "instance_id", inst.ID)
+}
+
+func ownerContext(ctx context.Context, inst *domain.Instance) context.Context {
+	return appcontext.WithUserID(appcontext.WithTenantID(ctx, inst.TenantID), inst.UserID)
+}
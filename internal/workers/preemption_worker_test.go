@@ -0,0 +1,90 @@
+package workers
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	appcontext "github.com/poyrazk/thecloud/internal/core/context"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPreemptionWorker(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	preemptible := func(containerID string, created time.Time, noticeAt string) *domain.Instance {
+		md := map[string]string{domain.MetadataCapacityType: string(domain.CapacityPreemptible)}
+		if noticeAt != "" {
+			md[domain.MetadataPreemptionTime] = noticeAt
+		}
+		return &domain.Instance{ID: uuid.New(), TenantID: uuid.New(), UserID: uuid.New(), ContainerID: containerID, Status: domain.StatusRunning, CreatedAt: created, Metadata: md}
+	}
+	setup := func(hosts ...ports.HostPressure) (*PreemptionWorker, *mockInstanceSvc, *mockInstanceRepo) {
+		svc, repo, compute := new(mockInstanceSvc), new(mockInstanceRepo), new(mockComputeBackend)
+		compute.On("HostMemoryPressure", mock.Anything).Return(hosts, nil).Maybe()
+		w := NewPreemptionWorker(svc, repo, compute, 90, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		w.now = func() time.Time { return now }
+		return w, svc, repo
+	}
+
+	t.Run("NoticesNewestInstanceOnPressuredHost", func(t *testing.T) {
+		w, _, repo := setup(
+			ports.HostPressure{Host: "busy", Pressure: 0.95, InstanceIDs: []string{"older", "newer", "on-demand"}},
+			ports.HostPressure{Host: "idle", Pressure: 0.2, InstanceIDs: []string{"elsewhere"}},
+		)
+		older, newer := preemptible("older", now.Add(-2*time.Hour), ""), preemptible("newer", now.Add(-time.Hour), "")
+		elsewhere := preemptible("elsewhere", now, "")
+		onDemand := &domain.Instance{ID: uuid.New(), ContainerID: "on-demand", Status: domain.StatusRunning, CreatedAt: now}
+		repo.On("ListAll", mock.Anything).Return([]*domain.Instance{older, newer, elsewhere, onDemand}, nil).Once()
+		repo.On("Update", mock.MatchedBy(func(ctx context.Context) bool {
+			return appcontext.TenantIDFromContext(ctx) == newer.TenantID
+		}), newer).Return(nil).Once()
+
+		w.reclaim(context.Background())
+
+		repo.AssertExpectations(t)
+		assert.Equal(t, "2024-01-01T12:00:30Z", newer.Metadata[domain.MetadataPreemptionTime])
+		assert.NotContains(t, older.Metadata, domain.MetadataPreemptionTime)
+		assert.NotContains(t, elsewhere.Metadata, domain.MetadataPreemptionTime)
+	})
+
+	t.Run("NothingBelowThreshold", func(t *testing.T) {
+		w, _, repo := setup(ports.HostPressure{Host: "h", Pressure: 0.5, InstanceIDs: []string{"c"}})
+		repo.On("ListAll", mock.Anything).Return([]*domain.Instance{preemptible("c", now, "")}, nil).Once()
+
+		w.reclaim(context.Background())
+
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("WaitsForPendingNoticeOnSameHost", func(t *testing.T) {
+		w, svc, repo := setup(ports.HostPressure{Host: "h", Pressure: 0.95, InstanceIDs: []string{"a", "b"}})
+		repo.On("ListAll", mock.Anything).Return([]*domain.Instance{
+			preemptible("a", now, "2024-01-01T12:00:20Z"),
+			preemptible("b", now, ""),
+		}, nil).Once()
+
+		w.reclaim(context.Background())
+
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		svc.AssertNotCalled(t, "TerminateInstance", mock.Anything, mock.Anything)
+	})
+
+	t.Run("TerminatesAfterNoticePeriod", func(t *testing.T) {
+		w, svc, repo := setup()
+		inst := preemptible("c", now.Add(-time.Hour), "2024-01-01T11:59:50Z")
+		repo.On("ListAll", mock.Anything).Return([]*domain.Instance{inst}, nil).Once()
+		svc.On("TerminateInstance", mock.MatchedBy(func(ctx context.Context) bool {
+			return appcontext.UserIDFromContext(ctx) == inst.UserID
+		}), inst.ID.String()).Return(nil).Once()
+
+		w.reclaim(context.Background())
+
+		svc.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,58 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/poyrazk/thecloud/internal/core/ports"
+)
+
+// QuotaReconcileWorker periodically recounts tenant resource usage and corrects
+// quota counters that drifted from the resources that actually exist.
+type QuotaReconcileWorker struct {
+	quotaSvc ports.QuotaService
+	logger   *slog.Logger
+	interval time.Duration
+}
+
+// NewQuotaReconcileWorker constructs a QuotaReconcileWorker with the default interval.
+func NewQuotaReconcileWorker(quotaSvc ports.QuotaService, logger *slog.Logger) *QuotaReconcileWorker {
+	return &QuotaReconcileWorker{
+		quotaSvc: quotaSvc,
+		logger:   logger,
+		interval: 10 * time.Minute,
+	}
+}
+
+func (w *QuotaReconcileWorker) Run(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+	w.logger.Info("starting quota reconcile worker", "interval", w.interval)
+
+	// Initial run so counters are corrected right after a deploy.
+	w.reconcile(ctx)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("stopping quota reconcile worker")
+			return
+		case <-ticker.C:
+			w.reconcile(ctx)
+		}
+	}
+}
+
+func (w *QuotaReconcileWorker) reconcile(ctx context.Context) {
+	corrected, err := w.quotaSvc.ReconcileUsage(ctx)
+	if err != nil {
+		w.logger.Error("failed to reconcile quota usage", "error", err)
+	}
+	if corrected > 0 {
+		w.logger.Info("reconciled quota usage", "corrected", corrected)
+	}
+}
@@ -0,0 +1,58 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// mockQuotaService implements only what the worker uses.
+type mockQuotaService struct {
+	ports.QuotaService
+	mock.Mock
+}
+
+func (m *mockQuotaService) ReconcileUsage(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func TestNewQuotaReconcileWorker(t *testing.T) {
+	svc := new(mockQuotaService)
+	worker := NewQuotaReconcileWorker(svc, slog.Default())
+	assert.NotNil(t, worker)
+	assert.Equal(t, 10*time.Minute, worker.interval)
+}
+
+func TestQuotaReconcileWorker_Run(t *testing.T) {
+	svc := new(mockQuotaService)
+	worker := &QuotaReconcileWorker{
+		quotaSvc: svc,
+		logger:   slog.New(slog.NewTextHandler(io.Discard, nil)),
+		interval: 10 * time.Millisecond,
+	}
+
+	// Initial run corrects drift, the first tick fails, later ticks find nothing to do.
+	svc.On("ReconcileUsage", mock.Anything).Return(2, nil).Once()
+	svc.On("ReconcileUsage", mock.Anything).Return(0, errors.New("db down")).Once()
+	svc.On("ReconcileUsage", mock.Anything).Return(0, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go worker.Run(ctx, &wg)
+
+	time.Sleep(35 * time.Millisecond)
+	cancel()
+	wg.Wait()
+
+	assert.GreaterOrEqual(t, len(svc.Calls), 3)
+}
@@ -0,0 +1,55 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/poyrazk/thecloud/internal/core/ports"
+)
+
+// SecretRotationWorker periodically rotates secrets whose rotation schedule has elapsed.
+type SecretRotationWorker struct {
+	rotationSvc ports.SecretRotationService
+	logger      *slog.Logger
+	interval    time.Duration
+}
+
+// NewSecretRotationWorker constructs a SecretRotationWorker with the default interval.
+func NewSecretRotationWorker(rotationSvc ports.SecretRotationService, logger *slog.Logger) *SecretRotationWorker {
+	return &SecretRotationWorker{
+		rotationSvc: rotationSvc,
+		logger:      logger,
+		interval:    5 * time.Minute,
+	}
+}
+
+func (w *SecretRotationWorker) Run(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+	w.logger.Info("starting secret rotation worker", "interval", w.interval)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("stopping secret rotation worker")
+			return
+		case <-ticker.C:
+			w.rotate(ctx)
+		}
+	}
+}
+
+func (w *SecretRotationWorker) rotate(ctx context.Context) {
+	rotated, err := w.rotationSvc.RotateDue(ctx)
+	if err != nil {
+		w.logger.Error("failed to rotate due secrets", "error", err)
+		return
+	}
+	if rotated > 0 {
+		w.logger.Info("rotated secrets", "count", rotated)
+	}
+}
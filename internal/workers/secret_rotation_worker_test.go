@@ -0,0 +1,58 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/poyrazk/thecloud/internal/core/ports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// mockSecretRotationService implements only what the worker uses.
+type mockSecretRotationService struct {
+	ports.SecretRotationService
+	mock.Mock
+}
+
+func (m *mockSecretRotationService) RotateDue(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func TestNewSecretRotationWorker(t *testing.T) {
+	svc := new(mockSecretRotationService)
+	worker := NewSecretRotationWorker(svc, slog.Default())
+	assert.NotNil(t, worker)
+	assert.Equal(t, 5*time.Minute, worker.interval)
+}
+
+func TestSecretRotationWorker_Run(t *testing.T) {
+	svc := new(mockSecretRotationService)
+	worker := &SecretRotationWorker{
+		rotationSvc: svc,
+		logger:      slog.New(slog.NewTextHandler(io.Discard, nil)),
+		interval:    10 * time.Millisecond,
+	}
+
+	// First tick rotates, the second fails, later ticks find nothing due.
+	svc.On("RotateDue", mock.Anything).Return(1, nil).Once()
+	svc.On("RotateDue", mock.Anything).Return(0, errors.New("db down")).Once()
+	svc.On("RotateDue", mock.Anything).Return(0, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go worker.Run(ctx, &wg)
+
+	time.Sleep(45 * time.Millisecond)
+	cancel()
+	wg.Wait()
+
+	assert.GreaterOrEqual(t, len(svc.Calls), 3)
+}
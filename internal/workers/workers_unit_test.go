@@ -24,6 +24,9 @@ func (m *mockAccountingSvc) TrackUsage(ctx context.Context, record domain.UsageR
 func (m *mockAccountingSvc) GetSummary(ctx context.Context, userID uuid.UUID, start, end time.Time) (*domain.BillSummary, error) {
 	return nil, nil
 }
+func (m *mockAccountingSvc) GetOrganizationSummary(ctx context.Context, orgID uuid.UUID, start, end time.Time) (*domain.OrganizationBillSummary, error) {
+	return nil, nil
+}
 func (m *mockAccountingSvc) ListUsage(ctx context.Context, userID uuid.UUID, start, end time.Time) ([]domain.UsageRecord, error) {
 	return nil, nil
 }
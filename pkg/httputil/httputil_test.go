@@ -112,6 +112,11 @@ func (m *mockIdentityService) ValidateClientCredentials(ctx context.Context, cli
 	return args.String(0), args.Error(1)
 }
 
+func (m *mockIdentityService) IssueServiceAccountToken(ctx context.Context, saID uuid.UUID) (string, error) {
+	args := m.Called(ctx, saID)
+	return args.String(0), args.Error(1)
+}
+
 func (m *mockIdentityService) ValidateAccessToken(ctx context.Context, token string) (*domain.ServiceAccountClaims, error) {
 	args := m.Called(ctx, token)
 	if args.Get(0) == nil {
@@ -158,7 +163,31 @@ func (m *mockTenantService) CreateTenant(ctx context.Context, name, slug string,
 func (m *mockTenantService) GetTenant(ctx context.Context, id uuid.UUID) (*domain.Tenant, error) {
 	return nil, nil
 }
-func (m *mockTenantService) InviteMember(ctx context.Context, tenantID uuid.UUID, email, role string) error {
+func (m *mockTenantService) InviteMember(ctx context.Context, tenantID uuid.UUID, email, role string) (*domain.TenantInvitation, error) {
+	return nil, nil
+}
+func (m *mockTenantService) ListInvitations(ctx context.Context, tenantID uuid.UUID) ([]domain.TenantInvitation, error) {
+	return nil, nil
+}
+func (m *mockTenantService) ResendInvitation(ctx context.Context, tenantID, invitationID uuid.UUID) (*domain.TenantInvitation, error) {
+	return nil, nil
+}
+func (m *mockTenantService) RevokeInvitation(ctx context.Context, tenantID, invitationID uuid.UUID) error {
+	return nil
+}
+func (m *mockTenantService) ListMyInvitations(ctx context.Context) ([]domain.TenantInvitation, error) {
+	return nil, nil
+}
+func (m *mockTenantService) AcceptInvitation(ctx context.Context, token string) (*domain.TenantMember, error) {
+	return nil, nil
+}
+func (m *mockTenantService) DeclineInvitation(ctx context.Context, token string) error {
+	return nil
+}
+func (m *mockTenantService) UpdateMemberRole(ctx context.Context, tenantID, userID uuid.UUID, role string) error {
+	return nil
+}
+func (m *mockTenantService) TransferOwnership(ctx context.Context, tenantID, newOwnerID uuid.UUID) error {
 	return nil
 }
 func (m *mockTenantService) RemoveMember(ctx context.Context, tenantID, userID uuid.UUID) error {
@@ -167,7 +196,7 @@ func (m *mockTenantService) RemoveMember(ctx context.Context, tenantID, userID u
 func (m *mockTenantService) SwitchTenant(ctx context.Context, userID, tenantID uuid.UUID) error {
 	return nil
 }
-func (m *mockTenantService) CheckQuota(ctx context.Context, tenantID uuid.UUID, resource string, requested int) error {
+func (m *mockTenantService) CheckQuota(ctx context.Context, tenantID uuid.UUID, resource domain.QuotaResource, requested int) error {
 	return nil
 }
 func (m *mockTenantService) GetMembership(ctx context.Context, tenantID, userID uuid.UUID) (*domain.TenantMember, error) {
@@ -186,10 +215,10 @@ func (m *mockTenantService) ListUserTenants(ctx context.Context, userID uuid.UUI
 	r0, _ := args.Get(0).([]domain.Tenant)
 	return r0, args.Error(1)
 }
-func (m *mockTenantService) IncrementUsage(ctx context.Context, tenantID uuid.UUID, resource string, amount int) error {
+func (m *mockTenantService) IncrementUsage(ctx context.Context, tenantID uuid.UUID, resource domain.QuotaResource, amount int) error {
 	return nil
 }
-func (m *mockTenantService) DecrementUsage(ctx context.Context, tenantID uuid.UUID, resource string, amount int) error {
+func (m *mockTenantService) DecrementUsage(ctx context.Context, tenantID uuid.UUID, resource domain.QuotaResource, amount int) error {
 	return nil
 }
 
@@ -203,6 +232,11 @@ func (m *mockRBACService) HasPermission(ctx context.Context, userID, tenantID uu
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *mockRBACService) ResourcePolicyOwner(ctx context.Context, userID, tenantID uuid.UUID, permission domain.Permission, resource string) (uuid.UUID, bool) {
+	args := m.Called(ctx, userID, tenantID, permission, resource)
+	return args.Get(0).(uuid.UUID), args.Bool(1)
+}
+
 func (m *mockRBACService) CreateRole(ctx context.Context, role *domain.Role) error { return nil }
 func (m *mockRBACService) GetRoleByID(ctx context.Context, id uuid.UUID) (*domain.Role, error) {
 	return nil, nil
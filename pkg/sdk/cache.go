@@ -5,27 +5,61 @@ import "time"
 
 // Cache describes a cache instance.
 type Cache struct {
+	ID              string       `json:"id"`
+	UserID          string       `json:"user_id"`
+	Name            string       `json:"name"`
+	Engine          string       `json:"engine"`
+	Version         string       `json:"version"`
+	Status          string       `json:"status"`
+	VpcID           *string      `json:"vpc_id,omitempty"`
+	ContainerID     string       `json:"container_id,omitempty"`
+	Port            int          `json:"port"`
+	ReaderPort      int          `json:"reader_port,omitempty"`
+	Password        string       `json:"password,omitempty"` // Only returned on Create/Get usually?
+	MemoryMB        int          `json:"memory_mb"`
+	Persistence     string       `json:"persistence"`
+	Replicas        int          `json:"replicas"`
+	FailoverEnabled bool         `json:"failover_enabled"`
+	ClusterMode     bool         `json:"cluster_mode"`
+	Shards          int          `json:"shards,omitempty"`
+	MetricsEnabled  bool         `json:"metrics_enabled"`
+	MetricsPort     int          `json:"metrics_port,omitempty"`
+	Nodes           []*CacheNode `json:"nodes,omitempty"`
+	CreatedAt       time.Time    `json:"created_at"`
+	UpdatedAt       time.Time    `json:"updated_at"`
+}
+
+// CacheNode describes one engine node of a cache.
+type CacheNode struct {
 	ID          string    `json:"id"`
-	UserID      string    `json:"user_id"`
-	Name        string    `json:"name"`
-	Engine      string    `json:"engine"`
-	Version     string    `json:"version"`
+	Role        string    `json:"role"`
+	Shard       int       `json:"shard"`
 	Status      string    `json:"status"`
-	VpcID       *string   `json:"vpc_id,omitempty"`
 	ContainerID string    `json:"container_id,omitempty"`
-	Port        int       `json:"port"`
-	Password    string    `json:"password,omitempty"` // Only returned on Create/Get usually?
-	MemoryMB    int       `json:"memory_mb"`
+	VolumeID    *string   `json:"volume_id,omitempty"`
 	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
 }
 
 // CreateCacheInput defines parameters for creating a cache.
 type CreateCacheInput struct {
-	Name     string  `json:"name"`
-	Version  string  `json:"version"`
-	MemoryMB int     `json:"memory_mb"`
-	VpcID    *string `json:"vpc_id,omitempty"`
+	Name            string  `json:"name"`
+	Engine          string  `json:"engine,omitempty"` // redis (default), valkey or memcached
+	Version         string  `json:"version"`
+	MemoryMB        int     `json:"memory_mb"`
+	VpcID           *string `json:"vpc_id,omitempty"`
+	Persistence     string  `json:"persistence,omitempty"` // none, rdb or aof
+	Replicas        int     `json:"replicas,omitempty"`
+	FailoverEnabled bool    `json:"failover_enabled,omitempty"`
+	ClusterMode     bool    `json:"cluster_mode,omitempty"`
+	Shards          int     `json:"shards,omitempty"` // Cluster mode only
+	MetricsEnabled  bool    `json:"metrics_enabled,omitempty"`
+}
+
+// ModifyCacheInput defines the online changes to a cache. Nil fields are
+// left unchanged.
+type ModifyCacheInput struct {
+	MemoryMB *int `json:"memory_mb,omitempty"`
+	Shards   *int `json:"shards,omitempty"` // Cluster mode only
 }
 
 // CacheStats summarizes cache runtime metrics.
@@ -45,6 +79,11 @@ func (c *Client) CreateCache(name, version string, memoryMB int, vpcID *string)
 		MemoryMB: memoryMB,
 		VpcID:    vpcID,
 	}
+	return c.CreateCacheWithInput(input)
+}
+
+// CreateCacheWithInput creates a cache with the full set of options.
+func (c *Client) CreateCacheWithInput(input CreateCacheInput) (*Cache, error) {
 	var resp Response[Cache]
 	if err := c.post("/caches", input, &resp); err != nil {
 		return nil, err
@@ -91,3 +130,23 @@ func (c *Client) GetCacheStats(id string) (*CacheStats, error) {
 	}
 	return &resp.Data, nil
 }
+
+// FailoverCache promotes the most up-to-date replica of a cache to primary.
+func (c *Client) FailoverCache(id string) error {
+	return c.post(cachesPath+id+"/failover", nil, nil)
+}
+
+// RotateCacheCredentials replaces the password of a Redis or Valkey cache.
+// The new password is part of the connection string.
+func (c *Client) RotateCacheCredentials(id string) error {
+	return c.post(cachesPath+id+"/rotate-credentials", nil, nil)
+}
+
+// ModifyCache resizes the memory of a cache or reshards a cluster-mode cache.
+func (c *Client) ModifyCache(id string, input ModifyCacheInput) (*Cache, error) {
+	var resp Response[Cache]
+	if err := c.patch(cachesPath+id, input, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
@@ -34,8 +34,11 @@ func newCacheTestServer(t *testing.T) *httptest.Server {
 				w.WriteHeader(http.StatusCreated)
 				_ = json.NewEncoder(w).Encode(map[string]interface{}{
 					"data": map[string]interface{}{
-						"id":   cacheTestID,
-						"name": cacheTestName,
+						"id":               cacheTestID,
+						"name":             cacheTestName,
+						"persistence":      body["persistence"],
+						"replicas":         body["replicas"],
+						"failover_enabled": body["failover_enabled"],
 					},
 				})
 			}
@@ -54,6 +57,18 @@ func newCacheTestServer(t *testing.T) *httptest.Server {
 					"name": cacheTestName,
 				},
 			})
+		case r.URL.Path == cacheTestBasePath+"/"+cacheTestID && r.Method == http.MethodPatch:
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]interface{}{
+					"id":           cacheTestID,
+					"cluster_mode": true,
+					"shards":       body["shards"],
+					"memory_mb":    512,
+				},
+			})
 		case r.URL.Path == cacheTestBasePath+"/"+cacheTestID && r.Method == http.MethodDelete:
 			w.WriteHeader(http.StatusNoContent)
 		case r.URL.Path == cacheTestBasePath+"/"+cacheTestID+"/connection" && r.Method == http.MethodGet:
@@ -61,6 +76,16 @@ func newCacheTestServer(t *testing.T) *httptest.Server {
 			_ = json.NewEncoder(w).Encode(map[string]interface{}{
 				"data": map[string]string{"connection_string": "redis://localhost:6379"},
 			})
+		case r.URL.Path == cacheTestBasePath+"/"+cacheTestID+"/failover" && r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]string{"message": "failover initiated"},
+			})
+		case r.URL.Path == cacheTestBasePath+"/"+cacheTestID+"/rotate-credentials" && r.Method == http.MethodPost:
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"data": map[string]string{"message": "cache credentials rotated"},
+			})
 		case r.URL.Path == cacheTestBasePath+"/"+cacheTestID+"/flush" && r.Method == http.MethodPost:
 			w.WriteHeader(http.StatusNoContent)
 		case r.URL.Path == cacheTestBasePath+"/"+cacheTestID+"/stats" && r.Method == http.MethodGet:
@@ -88,6 +113,21 @@ func TestCacheSDK(t *testing.T) {
 		}
 	})
 
+	t.Run("CreateCacheWithInput", func(t *testing.T) {
+		c, err := client.CreateCacheWithInput(sdk.CreateCacheInput{
+			Name:            cacheTestName,
+			Version:         "7.2",
+			MemoryMB:        256,
+			Persistence:     "aof",
+			Replicas:        2,
+			FailoverEnabled: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "aof", c.Persistence)
+		assert.Equal(t, 2, c.Replicas)
+		assert.True(t, c.FailoverEnabled)
+	})
+
 	t.Run("ListCaches", func(t *testing.T) {
 		cs, err := client.ListCaches()
 		require.NoError(t, err)
@@ -119,6 +159,24 @@ func TestCacheSDK(t *testing.T) {
 		assert.NotNil(t, stats)
 	})
 
+	t.Run("ModifyCache", func(t *testing.T) {
+		shards := 6
+		c, err := client.ModifyCache(cacheTestID, sdk.ModifyCacheInput{Shards: &shards})
+		require.NoError(t, err)
+		assert.True(t, c.ClusterMode)
+		assert.Equal(t, 6, c.Shards)
+	})
+
+	t.Run("FailoverCache", func(t *testing.T) {
+		err := client.FailoverCache(cacheTestID)
+		require.NoError(t, err)
+	})
+
+	t.Run("RotateCacheCredentials", func(t *testing.T) {
+		err := client.RotateCacheCredentials(cacheTestID)
+		require.NoError(t, err)
+	})
+
 	t.Run("DeleteCache", func(t *testing.T) {
 		err := client.DeleteCache(cacheTestID)
 		require.NoError(t, err)
@@ -151,6 +209,12 @@ func TestCacheSDKErrors(t *testing.T) {
 	_, err = client.GetCacheStats(cacheTestID)
 	require.Error(t, err)
 
+	err = client.FailoverCache(cacheTestID)
+	require.Error(t, err)
+
+	_, err = client.ModifyCache(cacheTestID, sdk.ModifyCacheInput{})
+	require.Error(t, err)
+
 	err = client.DeleteCache(cacheTestID)
 	require.Error(t, err)
 }
@@ -18,6 +18,7 @@ type Instance struct {
 	VpcID        string            `json:"vpc_id,omitempty"`
 	SubnetID     string            `json:"subnet_id,omitempty"`
 	PrivateIP    string            `json:"private_ip,omitempty"`
+	PrivateIPv6  string            `json:"private_ipv6,omitempty"`
 	ContainerID  string            `json:"container_id"`
 	Version      int               `json:"version"`
 	Metadata     map[string]string `json:"metadata,omitempty"`
@@ -111,20 +112,58 @@ type VolumeAttachmentInput struct {
 	MountPath string `json:"mount_path"`
 }
 
+// LaunchInstanceInput holds the parameters of LaunchInstanceWithInput.
+type LaunchInstanceInput struct {
+	Name         string
+	Image        string
+	Ports        string
+	InstanceType string
+	VpcID        string
+	SubnetID     string
+	Volumes      []VolumeAttachmentInput
+	Metadata     map[string]string
+	Labels       map[string]string
+	SSHKeyID     string
+	Cmd          []string
+	// ServiceAccountID attaches a service account whose credentials the
+	// instance can fetch from the metadata service.
+	ServiceAccountID string
+}
+
 // LaunchInstance provisions a new instance with optional metadata, labels, and volume attachments.
 func (c *Client) LaunchInstance(name, image, ports, instanceType string, vpcID, subnetID string, volumes []VolumeAttachmentInput, metadata, labels map[string]string, sshKeyID string, cmd []string) (*Instance, error) {
+	return c.LaunchInstanceWithInput(LaunchInstanceInput{
+		Name:         name,
+		Image:        image,
+		Ports:        ports,
+		InstanceType: instanceType,
+		VpcID:        vpcID,
+		SubnetID:     subnetID,
+		Volumes:      volumes,
+		Metadata:     metadata,
+		Labels:       labels,
+		SSHKeyID:     sshKeyID,
+		Cmd:          cmd,
+	})
+}
+
+// LaunchInstanceWithInput provisions a new instance from a LaunchInstanceInput.
+func (c *Client) LaunchInstanceWithInput(in LaunchInstanceInput) (*Instance, error) {
 	body := map[string]interface{}{
-		"name":          name,
-		"image":         image,
-		"ports":         ports,
-		"instance_type": instanceType,
-		"vpc_id":        vpcID,
-		"subnet_id":     subnetID,
-		"volumes":       volumes,
-		"metadata":      metadata,
-		"labels":        labels,
-		"ssh_key_id":    sshKeyID,
-		"cmd":           cmd,
+		"name":          in.Name,
+		"image":         in.Image,
+		"ports":         in.Ports,
+		"instance_type": in.InstanceType,
+		"vpc_id":        in.VpcID,
+		"subnet_id":     in.SubnetID,
+		"volumes":       in.Volumes,
+		"metadata":      in.Metadata,
+		"labels":        in.Labels,
+		"ssh_key_id":    in.SSHKeyID,
+		"cmd":           in.Cmd,
+	}
+	if in.ServiceAccountID != "" {
+		body["service_account_id"] = in.ServiceAccountID
 	}
 	var res Response[Instance]
 	if err := c.post("/instances", body, &res); err != nil {
@@ -266,6 +305,15 @@ func (c *Client) ListInstanceTypes() ([]InstanceType, error) {
 	return res.Data, nil
 }
 
+// ListInstanceTypesWithContext returns all available instance sizes with context support.
+func (c *Client) ListInstanceTypesWithContext(ctx context.Context) ([]InstanceType, error) {
+	var res Response[[]InstanceType]
+	if err := c.getWithContext(ctx, "/instance-types", &res); err != nil {
+		return nil, err
+	}
+	return res.Data, nil
+}
+
 // ListInstanceTypesWithPagination returns instance types with pagination metadata.
 func (c *Client) ListInstanceTypesWithPagination(limit, offset int) ([]InstanceType, *ListResponse[InstanceType], error) {
 	var res Response[ListResponse[InstanceType]]
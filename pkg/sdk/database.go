@@ -1,7 +1,10 @@
 // Package sdk provides the official Go SDK for the platform.
 package sdk
 
-import "time"
+import (
+	"net/url"
+	"time"
+)
 
 // Database describes a managed database instance.
 type Database struct {
@@ -18,6 +21,17 @@ type Database struct {
 	Password    string    `json:"password,omitempty"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
+
+	// Point-in-time recovery; the restorable window is only set on GetDatabase.
+	BackupRetentionDays    int        `json:"backup_retention_days"`
+	BackupBucket           string     `json:"backup_bucket,omitempty"`
+	EarliestRestorableTime *time.Time `json:"earliest_restorable_time,omitempty"`
+	LatestRestorableTime   *time.Time `json:"latest_restorable_time,omitempty"`
+
+	// Maintenance; pending changes are applied in the next window.
+	MaintenanceWindow string            `json:"maintenance_window,omitempty"`
+	PendingVersion    string            `json:"pending_version,omitempty"`
+	PendingParameters map[string]string `json:"pending_parameters,omitempty"`
 }
 
 // CreateDatabaseInput defines parameters for creating a database.
@@ -27,6 +41,126 @@ type CreateDatabaseInput struct {
 	Version          string  `json:"version"`
 	VpcID            *string `json:"vpc_id,omitempty"`
 	AllocatedStorage int     `json:"allocated_storage_gb,omitempty"`
+	// BackupRetentionDays and BackupBucket enable point-in-time recovery.
+	BackupRetentionDays int    `json:"backup_retention_days,omitempty"`
+	BackupBucket        string `json:"backup_bucket,omitempty"`
+}
+
+// RestoreDatabaseToTimeInput defines parameters for a point-in-time restore.
+type RestoreDatabaseToTimeInput struct {
+	Name             string    `json:"name"`
+	SourceDatabaseID string    `json:"source_database_id"`
+	RestoreTime      time.Time `json:"restore_time"`
+}
+
+// DatabaseBackupPolicy schedules automated backups exported to a bucket.
+// WindowStart is a UTC time formatted as "15:04".
+type DatabaseBackupPolicy struct {
+	DatabaseID     string     `json:"database_id,omitempty"`
+	Method         string     `json:"method,omitempty"`
+	WindowStart    string     `json:"window_start,omitempty"`
+	WindowMinutes  int        `json:"window_minutes,omitempty"`
+	FrequencyHours int        `json:"frequency_hours,omitempty"`
+	RetentionCount int        `json:"retention_count,omitempty"`
+	RetentionDays  int        `json:"retention_days,omitempty"`
+	ExportBucket   string     `json:"export_bucket"`
+	NextBackupAt   *time.Time `json:"next_backup_at,omitempty"`
+	LastBackupAt   *time.Time `json:"last_backup_at,omitempty"`
+}
+
+// DatabaseAutomatedBackup is one backup taken by a backup policy.
+type DatabaseAutomatedBackup struct {
+	ID          string     `json:"id"`
+	DatabaseID  string     `json:"database_id"`
+	Method      string     `json:"method"`
+	Status      string     `json:"status"`
+	SnapshotID  *string    `json:"snapshot_id,omitempty"`
+	Bucket      string     `json:"bucket"`
+	ObjectKey   string     `json:"object_key,omitempty"`
+	SizeBytes   int64      `json:"size_bytes"`
+	KmsKeyID    string     `json:"kms_key_id,omitempty"`
+	Error       string     `json:"error,omitempty"`
+	StartedAt   time.Time  `json:"started_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// DatabaseUpgrade records one engine version change of a database.
+type DatabaseUpgrade struct {
+	ID          string     `json:"id"`
+	DatabaseID  string     `json:"database_id"`
+	FromVersion string     `json:"from_version"`
+	ToVersion   string     `json:"to_version"`
+	Major       bool       `json:"major"`
+	Status      string     `json:"status"`
+	SnapshotID  *string    `json:"snapshot_id,omitempty"`
+	Error       string     `json:"error,omitempty"`
+	StartedAt   time.Time  `json:"started_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// LogicalDatabase is an additional database inside a managed database instance.
+type LogicalDatabase struct {
+	ID         string    `json:"id"`
+	DatabaseID string    `json:"database_id"`
+	Name       string    `json:"name"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// DatabaseGrant gives a database user a privilege on one logical database.
+// Privilege is READ_ONLY, READ_WRITE or ALL.
+type DatabaseGrant struct {
+	Database  string `json:"database"`
+	Privilege string `json:"privilege"`
+}
+
+// DatabaseUser is an additional login of a managed database. Password is only
+// set in responses to creation and credential rotation.
+type DatabaseUser struct {
+	ID                string          `json:"id"`
+	DatabaseID        string          `json:"database_id"`
+	Username          string          `json:"username"`
+	Password          string          `json:"password,omitempty"`
+	Grants            []DatabaseGrant `json:"grants"`
+	CredentialVersion int             `json:"credential_version"`
+	CreatedAt         time.Time       `json:"created_at"`
+	UpdatedAt         time.Time       `json:"updated_at"`
+}
+
+// DatabaseQueryStat is the cumulative execution statistics of one normalized query.
+type DatabaseQueryStat struct {
+	QueryID     string  `json:"query_id"`
+	Query       string  `json:"query"`
+	Calls       int64   `json:"calls"`
+	TotalTimeMs float64 `json:"total_time_ms"`
+	MeanTimeMs  float64 `json:"mean_time_ms"`
+	Rows        int64   `json:"rows"`
+}
+
+// DatabaseReplicaLag is how far a read replica trails its primary.
+type DatabaseReplicaLag struct {
+	ReplicaID  string  `json:"replica_id"`
+	Name       string  `json:"name"`
+	LagSeconds float64 `json:"lag_seconds"`
+}
+
+// DatabaseInsightPoint is one point of the insight history of a database.
+type DatabaseInsightPoint struct {
+	Time                 time.Time `json:"time"`
+	Connections          float64   `json:"connections"`
+	CacheHitRatio        float64   `json:"cache_hit_ratio"`
+	MaxReplicaLagSeconds float64   `json:"max_replica_lag_seconds"`
+}
+
+// DatabaseInsights is the performance overview of a database.
+type DatabaseInsights struct {
+	DatabaseID     string                 `json:"database_id"`
+	CollectedAt    *time.Time             `json:"collected_at,omitempty"`
+	Connections    int                    `json:"connections"`
+	MaxConnections int                    `json:"max_connections"`
+	CacheHitRatio  float64                `json:"cache_hit_ratio"`
+	ReplicaLag     []DatabaseReplicaLag   `json:"replica_lag"`
+	TopQueries     []DatabaseQueryStat    `json:"top_queries"`
+	History        []DatabaseInsightPoint `json:"history"`
 }
 
 const databasesPath = "/databases/"
@@ -39,6 +173,11 @@ func (c *Client) CreateDatabase(name, engine, version string, vpcID *string, all
 		VpcID:            vpcID,
 		AllocatedStorage: allocatedStorageGB,
 	}
+	return c.CreateDatabaseWithInput(input)
+}
+
+// CreateDatabaseWithInput creates a database with the full set of options.
+func (c *Client) CreateDatabaseWithInput(input CreateDatabaseInput) (*Database, error) {
 	var resp Response[Database]
 	if err := c.post("/databases", input, &resp); err != nil {
 		return nil, err
@@ -77,3 +216,171 @@ func (c *Client) GetDatabaseConnectionString(id string) (string, error) {
 func (c *Client) RotateDatabaseCredentials(id string) error {
 	return c.post(databasesPath+id+"/rotate-credentials", nil, nil)
 }
+
+// RestoreDatabaseToTime creates a new database from the continuous backups of
+// sourceID as it was at restoreTime.
+func (c *Client) RestoreDatabaseToTime(sourceID, name string, restoreTime time.Time) (*Database, error) {
+	input := RestoreDatabaseToTimeInput{
+		Name:             name,
+		SourceDatabaseID: sourceID,
+		RestoreTime:      restoreTime,
+	}
+	var resp Response[Database]
+	if err := c.post("/databases/restore", input, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// SetDatabaseBackupRetention changes the point-in-time recovery window of a
+// database. Zero disables it and removes the automated backups.
+func (c *Client) SetDatabaseBackupRetention(id string, days int) (*Database, error) {
+	body := map[string]int{"backup_retention_days": days}
+	var resp Response[Database]
+	if err := c.patch(databasesPath+id, body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// PutDatabaseBackupPolicy creates or replaces the scheduled backup policy of a
+// database. Zero fields take the server defaults.
+func (c *Client) PutDatabaseBackupPolicy(id string, policy DatabaseBackupPolicy) (*DatabaseBackupPolicy, error) {
+	var resp Response[DatabaseBackupPolicy]
+	if err := c.put(databasesPath+id+"/backup-policy", policy, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+func (c *Client) GetDatabaseBackupPolicy(id string) (*DatabaseBackupPolicy, error) {
+	var resp Response[DatabaseBackupPolicy]
+	if err := c.get(databasesPath+id+"/backup-policy", &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+func (c *Client) DeleteDatabaseBackupPolicy(id string) error {
+	return c.delete(databasesPath+id+"/backup-policy", nil)
+}
+
+func (c *Client) ListDatabaseBackups(id string) ([]*DatabaseAutomatedBackup, error) {
+	var resp Response[[]*DatabaseAutomatedBackup]
+	if err := c.get(databasesPath+id+"/backups", &resp); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+func (c *Client) DeleteDatabaseBackup(id, backupID string) error {
+	return c.delete(databasesPath+id+"/backups/"+backupID, nil)
+}
+
+// SetDatabaseMaintenanceWindow changes the weekly maintenance window of a
+// database. The window is a UTC start time formatted as "ddd:HH:MM".
+func (c *Client) SetDatabaseMaintenanceWindow(id, window string) (*Database, error) {
+	body := map[string]string{"maintenance_window": window}
+	var resp Response[Database]
+	if err := c.patch(databasesPath+id, body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// UpgradeDatabase changes the engine version of a database. Unless
+// applyImmediately is set, the upgrade waits for the maintenance window.
+func (c *Client) UpgradeDatabase(id, version string, applyImmediately bool) (*Database, error) {
+	body := map[string]interface{}{"version": version, "apply_immediately": applyImmediately}
+	var resp Response[Database]
+	if err := c.post(databasesPath+id+"/upgrade", body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+func (c *Client) ListDatabaseUpgrades(id string) ([]*DatabaseUpgrade, error) {
+	var resp Response[[]*DatabaseUpgrade]
+	if err := c.get(databasesPath+id+"/upgrades", &resp); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+func (c *Client) CreateLogicalDatabase(id, name string) (*LogicalDatabase, error) {
+	body := map[string]string{"name": name}
+	var resp Response[LogicalDatabase]
+	if err := c.post(databasesPath+id+"/logical-databases", body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+func (c *Client) ListLogicalDatabases(id string) ([]*LogicalDatabase, error) {
+	var resp Response[[]*LogicalDatabase]
+	if err := c.get(databasesPath+id+"/logical-databases", &resp); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+func (c *Client) DeleteLogicalDatabase(id, name string) error {
+	return c.delete(databasesPath+id+"/logical-databases/"+name, nil)
+}
+
+// CreateDatabaseUser adds a login to a database. The returned user carries
+// the generated password, which is not returned again.
+func (c *Client) CreateDatabaseUser(id, username string, grants []DatabaseGrant) (*DatabaseUser, error) {
+	body := map[string]interface{}{"username": username, "grants": grants}
+	var resp Response[DatabaseUser]
+	if err := c.post(databasesPath+id+"/users", body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+func (c *Client) ListDatabaseUsers(id string) ([]*DatabaseUser, error) {
+	var resp Response[[]*DatabaseUser]
+	if err := c.get(databasesPath+id+"/users", &resp); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// SetDatabaseUserGrants replaces all grants of a database user.
+func (c *Client) SetDatabaseUserGrants(id, username string, grants []DatabaseGrant) (*DatabaseUser, error) {
+	body := map[string]interface{}{"grants": grants}
+	var resp Response[DatabaseUser]
+	if err := c.put(databasesPath+id+"/users/"+username+"/grants", body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// RotateDatabaseUserCredentials sets a new password for a database user and
+// returns it.
+func (c *Client) RotateDatabaseUserCredentials(id, username string) (*DatabaseUser, error) {
+	var resp Response[DatabaseUser]
+	if err := c.post(databasesPath+id+"/users/"+username+"/rotate-credentials", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+func (c *Client) DeleteDatabaseUser(id, username string) error {
+	return c.delete(databasesPath+id+"/users/"+username, nil)
+}
+
+// GetDatabaseInsights returns the performance insights of a database with
+// metrics enabled. A zero period returns the default hour of history.
+func (c *Client) GetDatabaseInsights(id string, period time.Duration) (*DatabaseInsights, error) {
+	path := databasesPath + id + "/insights"
+	if period > 0 {
+		path += "?" + url.Values{"period": {period.String()}}.Encode()
+	}
+	var resp Response[DatabaseInsights]
+	if err := c.get(path, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
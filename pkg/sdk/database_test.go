@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -185,3 +186,232 @@ func TestClientDatabaseErrors(t *testing.T) {
 	err = client.RotateDatabaseCredentials("db-1")
 	require.Error(t, err)
 }
+
+func TestClientRestoreDatabaseToTime(t *testing.T) {
+	restoreTime := time.Date(2026, 10, 18, 14, 30, 0, 0, time.UTC)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/databases/restore", r.URL.Path)
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		var req RestoreDatabaseToTimeInput
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, dbID, req.SourceDatabaseID)
+		assert.True(t, restoreTime.Equal(req.RestoreTime))
+
+		w.Header().Set(dbContentType, dbApplicationJSON)
+		_ = json.NewEncoder(w).Encode(Response[Database]{Data: Database{ID: "db-2", Name: req.Name, BackupRetentionDays: 7}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, dbAPIKey)
+	db, err := client.RestoreDatabaseToTime(dbID, "restored", restoreTime)
+
+	require.NoError(t, err)
+	assert.Equal(t, "restored", db.Name)
+	assert.Equal(t, 7, db.BackupRetentionDays)
+}
+
+func TestClientSetDatabaseBackupRetention(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, dbPathPrefix+dbID, r.URL.Path)
+		assert.Equal(t, http.MethodPatch, r.Method)
+
+		var req map[string]int
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, 0, req["backup_retention_days"])
+
+		w.Header().Set(dbContentType, dbApplicationJSON)
+		_ = json.NewEncoder(w).Encode(Response[Database]{Data: Database{ID: dbID}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, dbAPIKey)
+	db, err := client.SetDatabaseBackupRetention(dbID, 0)
+
+	require.NoError(t, err)
+	assert.Zero(t, db.BackupRetentionDays)
+}
+
+func TestClientPutDatabaseBackupPolicy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, dbPathPrefix+dbID+"/backup-policy", r.URL.Path)
+		assert.Equal(t, http.MethodPut, r.Method)
+
+		var req map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "PHYSICAL", req["method"])
+		assert.Equal(t, "db-exports", req["export_bucket"])
+		assert.NotContains(t, req, "window_start")
+
+		w.Header().Set(dbContentType, dbApplicationJSON)
+		_ = json.NewEncoder(w).Encode(Response[DatabaseBackupPolicy]{Data: DatabaseBackupPolicy{
+			DatabaseID: dbID, Method: "PHYSICAL", WindowStart: "03:00", ExportBucket: "db-exports",
+		}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, dbAPIKey)
+	policy, err := client.PutDatabaseBackupPolicy(dbID, DatabaseBackupPolicy{Method: "PHYSICAL", RetentionCount: 7, ExportBucket: "db-exports"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "03:00", policy.WindowStart)
+}
+
+func TestClientDatabaseBackups(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == dbPathPrefix+dbID+"/backups":
+			w.Header().Set(dbContentType, dbApplicationJSON)
+			_ = json.NewEncoder(w).Encode(Response[[]*DatabaseAutomatedBackup]{Data: []*DatabaseAutomatedBackup{
+				{ID: "bk-1", DatabaseID: dbID, Status: "COMPLETED", ObjectKey: "databases/db-123/backups/x.dump"},
+			}})
+		case r.Method == http.MethodDelete && r.URL.Path == dbPathPrefix+dbID+"/backups/bk-1":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete && r.URL.Path == dbPathPrefix+dbID+"/backup-policy":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, dbAPIKey)
+	backups, err := client.ListDatabaseBackups(dbID)
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	assert.Equal(t, "COMPLETED", backups[0].Status)
+
+	require.NoError(t, client.DeleteDatabaseBackup(dbID, "bk-1"))
+	require.NoError(t, client.DeleteDatabaseBackupPolicy(dbID))
+}
+
+func TestClientDatabaseUpgrades(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(dbContentType, dbApplicationJSON)
+		switch {
+		case r.Method == http.MethodPatch && r.URL.Path == dbPathPrefix+dbID:
+			var req map[string]string
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			_ = json.NewEncoder(w).Encode(Response[Database]{Data: Database{ID: dbID, MaintenanceWindow: req["maintenance_window"]}})
+		case r.Method == http.MethodPost && r.URL.Path == dbPathPrefix+dbID+"/upgrade":
+			var req map[string]interface{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "16", req["version"])
+			assert.Equal(t, false, req["apply_immediately"])
+			_ = json.NewEncoder(w).Encode(Response[Database]{Data: Database{ID: dbID, Version: "15", PendingVersion: "16"}})
+		case r.Method == http.MethodGet && r.URL.Path == dbPathPrefix+dbID+"/upgrades":
+			_ = json.NewEncoder(w).Encode(Response[[]*DatabaseUpgrade]{Data: []*DatabaseUpgrade{
+				{ID: "up-1", DatabaseID: dbID, FromVersion: "15", ToVersion: "16", Major: true, Status: "COMPLETED"},
+			}})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, dbAPIKey)
+	db, err := client.SetDatabaseMaintenanceWindow(dbID, "wed:22:30")
+	require.NoError(t, err)
+	assert.Equal(t, "wed:22:30", db.MaintenanceWindow)
+
+	db, err = client.UpgradeDatabase(dbID, "16", false)
+	require.NoError(t, err)
+	assert.Equal(t, "16", db.PendingVersion)
+
+	upgrades, err := client.ListDatabaseUpgrades(dbID)
+	require.NoError(t, err)
+	require.Len(t, upgrades, 1)
+	assert.True(t, upgrades[0].Major)
+}
+
+func TestClientDatabaseUsers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(dbContentType, dbApplicationJSON)
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == dbPathPrefix+dbID+"/logical-databases":
+			_ = json.NewEncoder(w).Encode(Response[LogicalDatabase]{Data: LogicalDatabase{DatabaseID: dbID, Name: "reporting"}})
+		case r.Method == http.MethodGet && r.URL.Path == dbPathPrefix+dbID+"/logical-databases":
+			_ = json.NewEncoder(w).Encode(Response[[]*LogicalDatabase]{Data: []*LogicalDatabase{{Name: "reporting"}}})
+		case r.Method == http.MethodDelete && r.URL.Path == dbPathPrefix+dbID+"/logical-databases/reporting":
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodPost && r.URL.Path == dbPathPrefix+dbID+"/users":
+			var req struct {
+				Username string          `json:"username"`
+				Grants   []DatabaseGrant `json:"grants"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "app_ro", req.Username)
+			_ = json.NewEncoder(w).Encode(Response[DatabaseUser]{Data: DatabaseUser{Username: req.Username, Password: "s3cret", Grants: req.Grants}})
+		case r.Method == http.MethodGet && r.URL.Path == dbPathPrefix+dbID+"/users":
+			_ = json.NewEncoder(w).Encode(Response[[]*DatabaseUser]{Data: []*DatabaseUser{{Username: "app_ro"}}})
+		case r.Method == http.MethodPut && r.URL.Path == dbPathPrefix+dbID+"/users/app_ro/grants":
+			var req struct {
+				Grants []DatabaseGrant `json:"grants"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			_ = json.NewEncoder(w).Encode(Response[DatabaseUser]{Data: DatabaseUser{Username: "app_ro", Grants: req.Grants}})
+		case r.Method == http.MethodPost && r.URL.Path == dbPathPrefix+dbID+"/users/app_ro/rotate-credentials":
+			_ = json.NewEncoder(w).Encode(Response[DatabaseUser]{Data: DatabaseUser{Username: "app_ro", Password: "n3w", CredentialVersion: 2}})
+		case r.Method == http.MethodDelete && r.URL.Path == dbPathPrefix+dbID+"/users/app_ro":
+			w.WriteHeader(http.StatusOK)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, dbAPIKey)
+	ldb, err := client.CreateLogicalDatabase(dbID, "reporting")
+	require.NoError(t, err)
+	assert.Equal(t, "reporting", ldb.Name)
+
+	ldbs, err := client.ListLogicalDatabases(dbID)
+	require.NoError(t, err)
+	require.Len(t, ldbs, 1)
+	require.NoError(t, client.DeleteLogicalDatabase(dbID, "reporting"))
+
+	user, err := client.CreateDatabaseUser(dbID, "app_ro", []DatabaseGrant{{Database: "reporting", Privilege: "READ_ONLY"}})
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", user.Password)
+	require.Len(t, user.Grants, 1)
+
+	users, err := client.ListDatabaseUsers(dbID)
+	require.NoError(t, err)
+	require.Len(t, users, 1)
+
+	user, err = client.SetDatabaseUserGrants(dbID, "app_ro", []DatabaseGrant{{Database: "reporting", Privilege: "READ_WRITE"}})
+	require.NoError(t, err)
+	assert.Equal(t, "READ_WRITE", user.Grants[0].Privilege)
+
+	user, err = client.RotateDatabaseUserCredentials(dbID, "app_ro")
+	require.NoError(t, err)
+	assert.Equal(t, "n3w", user.Password)
+
+	require.NoError(t, client.DeleteDatabaseUser(dbID, "app_ro"))
+}
+
+func TestClientGetDatabaseInsights(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, dbPathPrefix+dbID+"/insights", r.URL.Path)
+		assert.Equal(t, "6h0m0s", r.URL.Query().Get("period"))
+		w.Header().Set(dbContentType, dbApplicationJSON)
+		_ = json.NewEncoder(w).Encode(Response[DatabaseInsights]{Data: DatabaseInsights{
+			DatabaseID:    dbID,
+			Connections:   12,
+			CacheHitRatio: 0.99,
+			ReplicaLag:    []DatabaseReplicaLag{{Name: "replica-1", LagSeconds: 1.5}},
+			TopQueries:    []DatabaseQueryStat{{Query: "SELECT 1", Calls: 3, TotalTimeMs: 4.5}},
+		}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, dbAPIKey)
+	insights, err := client.GetDatabaseInsights(dbID, 6*time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 12, insights.Connections)
+	require.Len(t, insights.TopQueries, 1)
+	assert.Equal(t, int64(3), insights.TopQueries[0].Calls)
+	require.Len(t, insights.ReplicaLag, 1)
+}
@@ -0,0 +1,57 @@
+package sdk
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+)
+
+// CreateDHCPOptions creates a DHCP options set that can be associated with VPCs.
+func (c *Client) CreateDHCPOptions(ctx context.Context, name, domainName string, dnsServers, ntpServers []string) (*domain.DHCPOptionsSet, error) {
+	body := map[string]interface{}{
+		"name":                name,
+		"domain_name":         domainName,
+		"domain_name_servers": dnsServers,
+		"ntp_servers":         ntpServers,
+	}
+	var res Response[domain.DHCPOptionsSet]
+	if err := c.postWithContext(ctx, "/dhcp-options", body, &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// ListDHCPOptions lists the tenant's DHCP options sets.
+func (c *Client) ListDHCPOptions(ctx context.Context) ([]domain.DHCPOptionsSet, error) {
+	var res Response[[]domain.DHCPOptionsSet]
+	if err := c.getWithContext(ctx, "/dhcp-options", &res); err != nil {
+		return nil, err
+	}
+	return res.Data, nil
+}
+
+// GetDHCPOptions returns a DHCP options set.
+func (c *Client) GetDHCPOptions(ctx context.Context, id uuid.UUID) (*domain.DHCPOptionsSet, error) {
+	var res Response[domain.DHCPOptionsSet]
+	if err := c.getWithContext(ctx, "/dhcp-options/"+id.String(), &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// DeleteDHCPOptions removes a DHCP options set that is no longer associated with any VPC.
+func (c *Client) DeleteDHCPOptions(ctx context.Context, id uuid.UUID) error {
+	return c.deleteWithContext(ctx, "/dhcp-options/"+id.String(), nil)
+}
+
+// AssociateVPCDHCPOptions makes a DHCP options set apply to instances launched in a VPC.
+func (c *Client) AssociateVPCDHCPOptions(ctx context.Context, vpcID, optionsID uuid.UUID) error {
+	body := map[string]string{"dhcp_options_id": optionsID.String()}
+	return c.putWithContext(ctx, "/vpcs/"+vpcID.String()+"/dhcp-options", body, nil)
+}
+
+// DisassociateVPCDHCPOptions reverts a VPC to the default DHCP options.
+func (c *Client) DisassociateVPCDHCPOptions(ctx context.Context, vpcID uuid.UUID) error {
+	return c.deleteWithContext(ctx, "/vpcs/"+vpcID.String()+"/dhcp-options", nil)
+}
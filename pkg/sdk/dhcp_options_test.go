@@ -0,0 +1,62 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientCreateDHCPOptions(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/dhcp-options", r.URL.Path)
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		var req struct {
+			Name              string   `json:"name"`
+			DomainName        string   `json:"domain_name"`
+			DomainNameServers []string `json:"domain_name_servers"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "corp", req.Name)
+		assert.Equal(t, []string{"10.0.0.53"}, req.DomainNameServers)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Response[domain.DHCPOptionsSet]{Data: domain.DHCPOptionsSet{ID: uuid.New(), Name: req.Name, DomainName: req.DomainName}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	opts, err := client.CreateDHCPOptions(context.Background(), "corp", "corp.internal", []string{"10.0.0.53"}, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, "corp.internal", opts.DomainName)
+}
+
+func TestClientAssociateVPCDHCPOptions(t *testing.T) {
+	t.Parallel()
+	vpcID, optsID := uuid.New(), uuid.New()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/vpcs/"+vpcID.String()+"/dhcp-options", r.URL.Path)
+		assert.Equal(t, http.MethodPut, r.Method)
+
+		var req map[string]string
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, optsID.String(), req["dhcp_options_id"])
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	require.NoError(t, client.AssociateVPCDHCPOptions(context.Background(), vpcID, optsID))
+}
@@ -0,0 +1,51 @@
+package sdk
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+)
+
+// CreateFlowLogInput configures a new flow log. Zero values take the server defaults:
+// ALL traffic delivered to CloudLogs every 600 seconds.
+type CreateFlowLogInput struct {
+	ResourceType    domain.FlowLogResourceType `json:"resource_type"`
+	ResourceID      uuid.UUID                  `json:"resource_id"`
+	TrafficType     domain.FlowLogTrafficType  `json:"traffic_type,omitempty"`
+	Destination     domain.FlowLogDestination  `json:"destination,omitempty"`
+	Bucket          string                     `json:"bucket,omitempty"`
+	IntervalSeconds int                        `json:"interval_seconds,omitempty"`
+}
+
+// CreateFlowLog starts capturing traffic for a VPC, subnet or instance.
+func (c *Client) CreateFlowLog(ctx context.Context, input CreateFlowLogInput) (*domain.FlowLog, error) {
+	var res Response[domain.FlowLog]
+	if err := c.postWithContext(ctx, "/flow-logs", input, &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// ListFlowLogs lists the current tenant's flow logs.
+func (c *Client) ListFlowLogs(ctx context.Context) ([]domain.FlowLog, error) {
+	var res Response[[]domain.FlowLog]
+	if err := c.getWithContext(ctx, "/flow-logs", &res); err != nil {
+		return nil, err
+	}
+	return res.Data, nil
+}
+
+// GetFlowLog returns a flow log and the outcome of its last capture.
+func (c *Client) GetFlowLog(ctx context.Context, id uuid.UUID) (*domain.FlowLog, error) {
+	var res Response[domain.FlowLog]
+	if err := c.getWithContext(ctx, "/flow-logs/"+id.String(), &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// DeleteFlowLog stops a flow log.
+func (c *Client) DeleteFlowLog(ctx context.Context, id uuid.UUID) error {
+	return c.deleteWithContext(ctx, "/flow-logs/"+id.String(), nil)
+}
@@ -0,0 +1,79 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientCreateFlowLog(t *testing.T) {
+	t.Parallel()
+	vpcID := uuid.New()
+	flID := uuid.New()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/flow-logs", r.URL.Path)
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		var req map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "vpc", req["resource_type"])
+		assert.Equal(t, vpcID.String(), req["resource_id"])
+		assert.Equal(t, "REJECT", req["traffic_type"])
+		assert.NotContains(t, req, "bucket")
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Response[domain.FlowLog]{Data: domain.FlowLog{ID: flID, ResourceID: vpcID, TrafficType: domain.FlowLogTrafficReject}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	fl, err := client.CreateFlowLog(context.Background(), CreateFlowLogInput{
+		ResourceType: domain.FlowLogResourceVPC,
+		ResourceID:   vpcID,
+		TrafficType:  domain.FlowLogTrafficReject,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, flID, fl.ID)
+}
+
+func TestClientFlowLogLifecycle(t *testing.T) {
+	t.Parallel()
+	flID := uuid.New()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/flow-logs":
+			_ = json.NewEncoder(w).Encode(Response[[]domain.FlowLog]{Data: []domain.FlowLog{{ID: flID}}})
+		case r.Method == http.MethodGet && r.URL.Path == "/flow-logs/"+flID.String():
+			_ = json.NewEncoder(w).Encode(Response[domain.FlowLog]{Data: domain.FlowLog{ID: flID, Status: domain.FlowLogStatusError, LastError: "bucket not found"}})
+		case r.Method == http.MethodDelete && r.URL.Path == "/flow-logs/"+flID.String():
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	ctx := context.Background()
+
+	list, err := client.ListFlowLogs(ctx)
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	fl, err := client.GetFlowLog(ctx, flID)
+	require.NoError(t, err)
+	assert.Equal(t, "bucket not found", fl.LastError)
+
+	require.NoError(t, client.DeleteFlowLog(ctx, flID))
+}
@@ -3,6 +3,7 @@ package sdk
 import (
 	"context"
 	"fmt"
+	"net/url"
 
 	"github.com/google/uuid"
 	"github.com/poyrazk/thecloud/internal/core/domain"
@@ -71,3 +72,36 @@ func (c *Client) GetUserPolicies(ctx context.Context, userID uuid.UUID) ([]domai
 	}
 	return res.Data, nil
 }
+
+// PutResourcePolicy attaches or replaces the policy on a bucket, queue, topic or secret.
+func (c *Client) PutResourcePolicy(ctx context.Context, resourceType domain.ResourcePolicyType, resourceID string, statements []domain.Statement) (*domain.ResourcePolicy, error) {
+	body := map[string]interface{}{"statements": statements}
+	var res Response[domain.ResourcePolicy]
+	if err := c.putWithContext(ctx, fmt.Sprintf("/iam/resource-policies/%s/%s", resourceType, url.PathEscape(resourceID)), body, &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// GetResourcePolicy retrieves the policy attached to a resource.
+func (c *Client) GetResourcePolicy(ctx context.Context, resourceType domain.ResourcePolicyType, resourceID string) (*domain.ResourcePolicy, error) {
+	var res Response[domain.ResourcePolicy]
+	if err := c.getWithContext(ctx, fmt.Sprintf("/iam/resource-policies/%s/%s", resourceType, url.PathEscape(resourceID)), &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// ListResourcePolicies lists all resource policies owned by the tenant.
+func (c *Client) ListResourcePolicies(ctx context.Context) ([]domain.ResourcePolicy, error) {
+	var res Response[[]domain.ResourcePolicy]
+	if err := c.getWithContext(ctx, "/iam/resource-policies", &res); err != nil {
+		return nil, err
+	}
+	return res.Data, nil
+}
+
+// DeleteResourcePolicy detaches the policy from a resource.
+func (c *Client) DeleteResourcePolicy(ctx context.Context, resourceType domain.ResourcePolicyType, resourceID string) error {
+	return c.deleteWithContext(ctx, fmt.Sprintf("/iam/resource-policies/%s/%s", resourceType, url.PathEscape(resourceID)), nil)
+}
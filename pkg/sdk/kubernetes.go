@@ -20,8 +20,21 @@ type NodeGroup struct {
 	MinSize      int       `json:"min_size"`
 	MaxSize      int       `json:"max_size"`
 	CurrentSize  int       `json:"current_size"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	// Scale-down overrides for the cluster autoscaler; zero means its default.
+	ScaleDownUtilizationThreshold float64           `json:"scale_down_utilization_threshold,omitempty"`
+	ScaleDownUnneededSeconds      int               `json:"scale_down_unneeded_seconds,omitempty"`
+	Labels                        map[string]string `json:"labels,omitempty"`
+	Taints                        []NodeTaint       `json:"taints,omitempty"`
+	CapacityType                  string            `json:"capacity_type"`
+	CreatedAt                     time.Time         `json:"created_at"`
+	UpdatedAt                     time.Time         `json:"updated_at"`
+}
+
+// NodeTaint is a Kubernetes taint applied to the nodes of a node group.
+type NodeTaint struct {
+	Key    string `json:"key"`
+	Value  string `json:"value,omitempty"`
+	Effect string `json:"effect"` // NoSchedule, PreferNoSchedule or NoExecute
 }
 
 // Cluster represents a managed Kubernetes cluster in the SDK.
@@ -58,13 +71,23 @@ type NodeGroupInput struct {
 	MinSize      int    `json:"min_size"`
 	MaxSize      int    `json:"max_size"`
 	DesiredSize  int    `json:"desired_size"`
+	// Optional scale-down overrides for the cluster autoscaler.
+	ScaleDownUtilizationThreshold float64 `json:"scale_down_utilization_threshold,omitempty"`
+	ScaleDownUnneededSeconds      int     `json:"scale_down_unneeded_seconds,omitempty"`
+	// Optional labels and taints applied to nodes when they join the cluster.
+	Labels map[string]string `json:"labels,omitempty"`
+	Taints []NodeTaint       `json:"taints,omitempty"`
+	// CapacityType is "on-demand" (default) or "preemptible".
+	CapacityType string `json:"capacity_type,omitempty"`
 }
 
 // UpdateNodeGroupInput defines the input for updating a node group.
 type UpdateNodeGroupInput struct {
-	DesiredSize *int `json:"desired_size"`
-	MinSize     *int `json:"min_size"`
-	MaxSize     *int `json:"max_size"`
+	DesiredSize                   *int     `json:"desired_size"`
+	MinSize                       *int     `json:"min_size"`
+	MaxSize                       *int     `json:"max_size"`
+	ScaleDownUtilizationThreshold *float64 `json:"scale_down_utilization_threshold,omitempty"`
+	ScaleDownUnneededSeconds      *int     `json:"scale_down_unneeded_seconds,omitempty"`
 }
 
 // ClusterHealth represents the operational status of a cluster.
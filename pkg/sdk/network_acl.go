@@ -0,0 +1,80 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+)
+
+// NetworkACLRuleInput describes a rule to add to a network ACL. Ports apply to tcp and
+// udp only; PortMax defaults to PortMin.
+type NetworkACLRuleInput struct {
+	RuleNumber int                     `json:"rule_number"`
+	Direction  domain.RuleDirection    `json:"direction"`
+	Protocol   string                  `json:"protocol,omitempty"`
+	PortMin    int                     `json:"port_min,omitempty"`
+	PortMax    int                     `json:"port_max,omitempty"`
+	CIDR       string                  `json:"cidr"`
+	Action     domain.NetworkACLAction `json:"action"`
+}
+
+// CreateNetworkACL creates an empty network ACL in a VPC.
+func (c *Client) CreateNetworkACL(ctx context.Context, vpcID uuid.UUID, name string) (*domain.NetworkACL, error) {
+	body := map[string]string{"vpc_id": vpcID.String(), "name": name}
+	var res Response[domain.NetworkACL]
+	if err := c.postWithContext(ctx, "/network-acls", body, &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// ListNetworkACLs lists the network ACLs of a VPC.
+func (c *Client) ListNetworkACLs(ctx context.Context, vpcID uuid.UUID) ([]domain.NetworkACL, error) {
+	var res Response[[]domain.NetworkACL]
+	if err := c.getWithContext(ctx, "/network-acls?vpc_id="+vpcID.String(), &res); err != nil {
+		return nil, err
+	}
+	return res.Data, nil
+}
+
+// GetNetworkACL returns a network ACL with its rules and subnet associations.
+func (c *Client) GetNetworkACL(ctx context.Context, id uuid.UUID) (*domain.NetworkACL, error) {
+	var res Response[domain.NetworkACL]
+	if err := c.getWithContext(ctx, "/network-acls/"+id.String(), &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// DeleteNetworkACL removes a custom network ACL.
+func (c *Client) DeleteNetworkACL(ctx context.Context, id uuid.UUID) error {
+	return c.deleteWithContext(ctx, "/network-acls/"+id.String(), nil)
+}
+
+// AddNetworkACLRule adds a numbered rule to a network ACL.
+func (c *Client) AddNetworkACLRule(ctx context.Context, aclID uuid.UUID, input NetworkACLRuleInput) (*domain.NetworkACLRule, error) {
+	var res Response[domain.NetworkACLRule]
+	if err := c.postWithContext(ctx, fmt.Sprintf("/network-acls/%s/rules", aclID), input, &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// RemoveNetworkACLRule removes a rule from a network ACL.
+func (c *Client) RemoveNetworkACLRule(ctx context.Context, aclID, ruleID uuid.UUID) error {
+	return c.deleteWithContext(ctx, fmt.Sprintf("/network-acls/%s/rules/%s", aclID, ruleID), nil)
+}
+
+// AssociateNetworkACL applies a network ACL to a subnet.
+func (c *Client) AssociateNetworkACL(ctx context.Context, aclID, subnetID uuid.UUID) error {
+	body := map[string]string{"subnet_id": subnetID.String()}
+	return c.postWithContext(ctx, fmt.Sprintf("/network-acls/%s/associate", aclID), body, nil)
+}
+
+// DisassociateNetworkACL returns a subnet to its VPC's default network ACL.
+func (c *Client) DisassociateNetworkACL(ctx context.Context, aclID, subnetID uuid.UUID) error {
+	body := map[string]string{"subnet_id": subnetID.String()}
+	return c.postWithContext(ctx, fmt.Sprintf("/network-acls/%s/disassociate", aclID), body, nil)
+}
@@ -0,0 +1,87 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientAddNetworkACLRule(t *testing.T) {
+	t.Parallel()
+	aclID := uuid.New()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/network-acls/"+aclID.String()+"/rules", r.URL.Path)
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		var req map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, float64(100), req["rule_number"])
+		assert.Equal(t, "ingress", req["direction"])
+		assert.Equal(t, "deny", req["action"])
+		assert.NotContains(t, req, "port_min")
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Response[domain.NetworkACLRule]{Data: domain.NetworkACLRule{ID: uuid.New(), ACLID: aclID, RuleNumber: 100}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	rule, err := client.AddNetworkACLRule(context.Background(), aclID, NetworkACLRuleInput{
+		RuleNumber: 100,
+		Direction:  domain.RuleIngress,
+		Protocol:   "all",
+		CIDR:       "198.51.100.0/24",
+		Action:     domain.NetworkACLDeny,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, aclID, rule.ACLID)
+}
+
+func TestClientNetworkACLLifecycle(t *testing.T) {
+	t.Parallel()
+	vpcID, aclID, subnetID := uuid.New(), uuid.New(), uuid.New()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/network-acls":
+			_ = json.NewEncoder(w).Encode(Response[domain.NetworkACL]{Data: domain.NetworkACL{ID: aclID, VPCID: vpcID, Name: "web"}})
+		case r.Method == http.MethodGet && r.URL.Path == "/network-acls":
+			assert.Equal(t, vpcID.String(), r.URL.Query().Get("vpc_id"))
+			_ = json.NewEncoder(w).Encode(Response[[]domain.NetworkACL]{Data: []domain.NetworkACL{{ID: aclID}}})
+		case r.Method == http.MethodPost && r.URL.Path == "/network-acls/"+aclID.String()+"/associate":
+			var req map[string]string
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, subnetID.String(), req["subnet_id"])
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodDelete && r.URL.Path == "/network-acls/"+aclID.String():
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	ctx := context.Background()
+
+	acl, err := client.CreateNetworkACL(ctx, vpcID, "web")
+	require.NoError(t, err)
+	assert.Equal(t, "web", acl.Name)
+
+	acls, err := client.ListNetworkACLs(ctx, vpcID)
+	require.NoError(t, err)
+	assert.Len(t, acls, 1)
+
+	require.NoError(t, client.AssociateNetworkACL(ctx, aclID, subnetID))
+	require.NoError(t, client.DeleteNetworkACL(ctx, aclID))
+}
@@ -0,0 +1,111 @@
+package sdk
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+)
+
+// CreateNetworkInterfaceInput holds the options for creating a network interface.
+// Leave PrivateIPs empty to have a primary address allocated from the subnet.
+type CreateNetworkInterfaceInput struct {
+	SubnetID         uuid.UUID   `json:"subnet_id"`
+	Description      string      `json:"description,omitempty"`
+	PrivateIPs       []string    `json:"private_ips,omitempty"`
+	SecurityGroupIDs []uuid.UUID `json:"security_group_ids,omitempty"`
+}
+
+// CreateNetworkInterface creates a network interface in a subnet.
+func (c *Client) CreateNetworkInterface(ctx context.Context, input CreateNetworkInterfaceInput) (*domain.NetworkInterface, error) {
+	var res Response[domain.NetworkInterface]
+	if err := c.postWithContext(ctx, "/network-interfaces", input, &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// ListNetworkInterfaces lists the tenant's network interfaces.
+func (c *Client) ListNetworkInterfaces(ctx context.Context) ([]domain.NetworkInterface, error) {
+	var res Response[[]domain.NetworkInterface]
+	if err := c.getWithContext(ctx, "/network-interfaces", &res); err != nil {
+		return nil, err
+	}
+	return res.Data, nil
+}
+
+// GetNetworkInterface returns a network interface.
+func (c *Client) GetNetworkInterface(ctx context.Context, id uuid.UUID) (*domain.NetworkInterface, error) {
+	var res Response[domain.NetworkInterface]
+	if err := c.getWithContext(ctx, "/network-interfaces/"+id.String(), &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// DeleteNetworkInterface removes a detached network interface.
+func (c *Client) DeleteNetworkInterface(ctx context.Context, id uuid.UUID) error {
+	return c.deleteWithContext(ctx, "/network-interfaces/"+id.String(), nil)
+}
+
+// AssignPrivateIPs adds the given secondary addresses, plus count addresses
+// allocated from the subnet, to a network interface.
+func (c *Client) AssignPrivateIPs(ctx context.Context, id uuid.UUID, privateIPs []string, count int) (*domain.NetworkInterface, error) {
+	body := map[string]interface{}{"private_ips": privateIPs, "count": count}
+	var res Response[domain.NetworkInterface]
+	if err := c.postWithContext(ctx, "/network-interfaces/"+id.String()+"/assign-private-ips", body, &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// UnassignPrivateIPs removes secondary addresses from a network interface.
+func (c *Client) UnassignPrivateIPs(ctx context.Context, id uuid.UUID, privateIPs []string) (*domain.NetworkInterface, error) {
+	body := map[string]interface{}{"private_ips": privateIPs}
+	var res Response[domain.NetworkInterface]
+	if err := c.postWithContext(ctx, "/network-interfaces/"+id.String()+"/unassign-private-ips", body, &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// SetNetworkInterfaceSecurityGroups replaces the security groups of a network interface.
+func (c *Client) SetNetworkInterfaceSecurityGroups(ctx context.Context, id uuid.UUID, securityGroupIDs []uuid.UUID) (*domain.NetworkInterface, error) {
+	body := map[string]interface{}{"security_group_ids": securityGroupIDs}
+	var res Response[domain.NetworkInterface]
+	if err := c.putWithContext(ctx, "/network-interfaces/"+id.String()+"/security-groups", body, &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// AttachNetworkInterface hot-plugs a network interface into a running instance.
+// A zero deviceIndex picks the lowest free index.
+func (c *Client) AttachNetworkInterface(ctx context.Context, id, instanceID uuid.UUID, deviceIndex int) (*domain.NetworkInterface, error) {
+	body := map[string]interface{}{"instance_id": instanceID.String(), "device_index": deviceIndex}
+	var res Response[domain.NetworkInterface]
+	if err := c.postWithContext(ctx, "/network-interfaces/"+id.String()+"/attach", body, &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// DetachNetworkInterface unplugs a network interface from its instance.
+func (c *Client) DetachNetworkInterface(ctx context.Context, id uuid.UUID) (*domain.NetworkInterface, error) {
+	var res Response[domain.NetworkInterface]
+	if err := c.postWithContext(ctx, "/network-interfaces/"+id.String()+"/detach", nil, &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// MoveNetworkInterface moves a network interface, with its private IPs and
+// Elastic IPs, to another instance of the same VPC.
+func (c *Client) MoveNetworkInterface(ctx context.Context, id, instanceID uuid.UUID) (*domain.NetworkInterface, error) {
+	body := map[string]string{"instance_id": instanceID.String()}
+	var res Response[domain.NetworkInterface]
+	if err := c.postWithContext(ctx, "/network-interfaces/"+id.String()+"/move", body, &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
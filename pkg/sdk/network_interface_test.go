@@ -0,0 +1,88 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientCreateNetworkInterface(t *testing.T) {
+	t.Parallel()
+	subnetID := uuid.New()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/network-interfaces", r.URL.Path)
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		var req CreateNetworkInterfaceInput
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, subnetID, req.SubnetID)
+		assert.Equal(t, []string{"10.0.1.50"}, req.PrivateIPs)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Response[domain.NetworkInterface]{Data: domain.NetworkInterface{ID: uuid.New(), SubnetID: subnetID, PrivateIPs: req.PrivateIPs}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	ni, err := client.CreateNetworkInterface(context.Background(), CreateNetworkInterfaceInput{SubnetID: subnetID, PrivateIPs: []string{"10.0.1.50"}})
+
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.1.50", ni.PrimaryIP())
+}
+
+func TestClientMoveNetworkInterface(t *testing.T) {
+	t.Parallel()
+	id, instanceID := uuid.New(), uuid.New()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/network-interfaces/"+id.String()+"/move", r.URL.Path)
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		var req map[string]string
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, instanceID.String(), req["instance_id"])
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Response[domain.NetworkInterface]{Data: domain.NetworkInterface{ID: id, InstanceID: &instanceID, DeviceIndex: 1}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	ni, err := client.MoveNetworkInterface(context.Background(), id, instanceID)
+
+	require.NoError(t, err)
+	assert.Equal(t, instanceID, *ni.InstanceID)
+}
+
+func TestClientAssignPrivateIPs(t *testing.T) {
+	t.Parallel()
+	id := uuid.New()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/network-interfaces/"+id.String()+"/assign-private-ips", r.URL.Path)
+
+		var req struct {
+			Count int `json:"count"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, 2, req.Count)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Response[domain.NetworkInterface]{Data: domain.NetworkInterface{ID: id, PrivateIPs: []string{"10.0.1.10", "10.0.1.11", "10.0.1.12"}}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	ni, err := client.AssignPrivateIPs(context.Background(), id, nil, 2)
+
+	require.NoError(t, err)
+	assert.Len(t, ni.PrivateIPs, 3)
+}
@@ -0,0 +1,109 @@
+package sdk
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+)
+
+// CreateOrganization creates an organization owned by the caller.
+func (c *Client) CreateOrganization(ctx context.Context, name, slug string) (*domain.Organization, error) {
+	body := map[string]string{"name": name, "slug": slug}
+	var res Response[domain.Organization]
+	if err := c.postWithContext(ctx, "/organizations", body, &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// ListOrganizations lists the organizations owned by the caller.
+func (c *Client) ListOrganizations(ctx context.Context) ([]domain.Organization, error) {
+	var res Response[[]domain.Organization]
+	if err := c.getWithContext(ctx, "/organizations", &res); err != nil {
+		return nil, err
+	}
+	return res.Data, nil
+}
+
+// GetOrganization retrieves an organization.
+func (c *Client) GetOrganization(ctx context.Context, id uuid.UUID) (*domain.Organization, error) {
+	var res Response[domain.Organization]
+	if err := c.getWithContext(ctx, "/organizations/"+id.String(), &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// DeleteOrganization deletes an organization. Member tenants are released, not deleted.
+func (c *Client) DeleteOrganization(ctx context.Context, id uuid.UUID) error {
+	return c.deleteWithContext(ctx, "/organizations/"+id.String(), nil)
+}
+
+// ListOrganizationTenants lists the IDs of the tenants in an organization.
+func (c *Client) ListOrganizationTenants(ctx context.Context, orgID uuid.UUID) ([]uuid.UUID, error) {
+	var res Response[[]uuid.UUID]
+	if err := c.getWithContext(ctx, "/organizations/"+orgID.String()+"/tenants", &res); err != nil {
+		return nil, err
+	}
+	return res.Data, nil
+}
+
+// AddOrganizationTenant places a tenant owned by the caller under an organization.
+func (c *Client) AddOrganizationTenant(ctx context.Context, orgID, tenantID uuid.UUID) error {
+	body := map[string]string{"tenant_id": tenantID.String()}
+	return c.postWithContext(ctx, "/organizations/"+orgID.String()+"/tenants", body, nil)
+}
+
+// RemoveOrganizationTenant releases a tenant from an organization.
+func (c *Client) RemoveOrganizationTenant(ctx context.Context, orgID, tenantID uuid.UUID) error {
+	return c.deleteWithContext(ctx, "/organizations/"+orgID.String()+"/tenants/"+tenantID.String(), nil)
+}
+
+// CreateGuardrail attaches a deny-only guardrail policy to an organization.
+func (c *Client) CreateGuardrail(ctx context.Context, orgID uuid.UUID, name, description string, statements []domain.Statement) (*domain.GuardrailPolicy, error) {
+	body := map[string]interface{}{"name": name, "description": description, "statements": statements}
+	var res Response[domain.GuardrailPolicy]
+	if err := c.postWithContext(ctx, "/organizations/"+orgID.String()+"/guardrails", body, &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// ListGuardrails lists the guardrails of an organization.
+func (c *Client) ListGuardrails(ctx context.Context, orgID uuid.UUID) ([]domain.GuardrailPolicy, error) {
+	var res Response[[]domain.GuardrailPolicy]
+	if err := c.getWithContext(ctx, "/organizations/"+orgID.String()+"/guardrails", &res); err != nil {
+		return nil, err
+	}
+	return res.Data, nil
+}
+
+// DeleteGuardrail removes a guardrail from an organization.
+func (c *Client) DeleteGuardrail(ctx context.Context, orgID, guardrailID uuid.UUID) error {
+	return c.deleteWithContext(ctx, "/organizations/"+orgID.String()+"/guardrails/"+guardrailID.String(), nil)
+}
+
+// GetOrganizationBillingSummary retrieves a consolidated bill across all tenants of an organization.
+func (c *Client) GetOrganizationBillingSummary(ctx context.Context, orgID uuid.UUID, start, end *time.Time) (*domain.OrganizationBillSummary, error) {
+	params := url.Values{}
+	if start != nil {
+		params.Add("start", start.Format(time.RFC3339))
+	}
+	if end != nil {
+		params.Add("end", end.Format(time.RFC3339))
+	}
+
+	path := "/billing/organizations/" + orgID.String() + "/summary"
+	if params.Encode() != "" {
+		path += "?" + params.Encode()
+	}
+
+	var res Response[domain.OrganizationBillSummary]
+	if err := c.getWithContext(ctx, path, &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
@@ -0,0 +1,84 @@
+package sdk
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+)
+
+// ListQuotas returns the current tenant's limit and usage for every quota-governed resource.
+func (c *Client) ListQuotas(ctx context.Context) ([]domain.ResourceQuota, error) {
+	var res Response[[]domain.ResourceQuota]
+	if err := c.getWithContext(ctx, "/quotas", &res); err != nil {
+		return nil, err
+	}
+	return res.Data, nil
+}
+
+// RequestQuotaIncrease asks an administrator to raise the current tenant's limit for a resource.
+func (c *Client) RequestQuotaIncrease(ctx context.Context, resource domain.QuotaResource, requestedLimit int, reason string) (*domain.QuotaRequest, error) {
+	body := map[string]interface{}{"resource": resource, "requested_limit": requestedLimit, "reason": reason}
+	var res Response[domain.QuotaRequest]
+	if err := c.postWithContext(ctx, "/quotas/requests", body, &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// ListQuotaRequests lists the current tenant's quota increase requests.
+func (c *Client) ListQuotaRequests(ctx context.Context) ([]domain.QuotaRequest, error) {
+	var res Response[[]domain.QuotaRequest]
+	if err := c.getWithContext(ctx, "/quotas/requests", &res); err != nil {
+		return nil, err
+	}
+	return res.Data, nil
+}
+
+// ListTenantQuotas returns another tenant's quotas. Requires platform admin.
+func (c *Client) ListTenantQuotas(ctx context.Context, tenantID uuid.UUID) ([]domain.ResourceQuota, error) {
+	var res Response[[]domain.ResourceQuota]
+	if err := c.getWithContext(ctx, "/admin/quotas/tenants/"+tenantID.String(), &res); err != nil {
+		return nil, err
+	}
+	return res.Data, nil
+}
+
+// SetQuotaLimit overrides a tenant's limit for one resource. Requires platform admin.
+func (c *Client) SetQuotaLimit(ctx context.Context, tenantID uuid.UUID, resource domain.QuotaResource, limit int) error {
+	body := map[string]int{"limit": limit}
+	return c.putWithContext(ctx, "/admin/quotas/tenants/"+tenantID.String()+"/"+string(resource), body, nil)
+}
+
+// ListAllQuotaRequests lists quota requests across tenants, optionally filtered by status. Requires platform admin.
+func (c *Client) ListAllQuotaRequests(ctx context.Context, status domain.QuotaRequestStatus) ([]domain.QuotaRequest, error) {
+	path := "/admin/quotas/requests"
+	if status != "" {
+		path += "?status=" + url.QueryEscape(string(status))
+	}
+	var res Response[[]domain.QuotaRequest]
+	if err := c.getWithContext(ctx, path, &res); err != nil {
+		return nil, err
+	}
+	return res.Data, nil
+}
+
+// ApproveQuotaRequest approves a pending request and applies the requested limit. Requires platform admin.
+func (c *Client) ApproveQuotaRequest(ctx context.Context, id uuid.UUID, note string) (*domain.QuotaRequest, error) {
+	return c.reviewQuotaRequest(ctx, id, "approve", note)
+}
+
+// DenyQuotaRequest denies a pending request. Requires platform admin.
+func (c *Client) DenyQuotaRequest(ctx context.Context, id uuid.UUID, note string) (*domain.QuotaRequest, error) {
+	return c.reviewQuotaRequest(ctx, id, "deny", note)
+}
+
+func (c *Client) reviewQuotaRequest(ctx context.Context, id uuid.UUID, action, note string) (*domain.QuotaRequest, error) {
+	body := map[string]string{"note": note}
+	var res Response[domain.QuotaRequest]
+	if err := c.postWithContext(ctx, "/admin/quotas/requests/"+id.String()+"/"+action, body, &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
@@ -10,10 +10,12 @@ import (
 type RouteTargetType string
 
 const (
-	RouteTargetLocal   RouteTargetType = "local"
-	RouteTargetIGW     RouteTargetType = "igw"
-	RouteTargetNAT     RouteTargetType = "nat"
-	RouteTargetPeering RouteTargetType = "peering"
+	RouteTargetLocal          RouteTargetType = "local"
+	RouteTargetIGW            RouteTargetType = "igw"
+	RouteTargetNAT            RouteTargetType = "nat"
+	RouteTargetPeering        RouteTargetType = "peering"
+	RouteTargetVPN            RouteTargetType = "vpn"
+	RouteTargetTransitGateway RouteTargetType = "tgw"
 )
 
 // RouteTable describes a route table resource.
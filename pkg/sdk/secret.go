@@ -1,7 +1,10 @@
 // Package sdk provides the official Go SDK for the platform.
 package sdk
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // Secret describes a stored secret value.
 type Secret struct {
@@ -10,9 +13,26 @@ type Secret struct {
 	Name           string     `json:"name"`
 	EncryptedValue string     `json:"encrypted_value"` // This field name is used for plaintext in Get response
 	Description    string     `json:"description"`
+	CurrentVersion int        `json:"current_version"`
 	CreatedAt      time.Time  `json:"created_at"`
 	UpdatedAt      time.Time  `json:"updated_at"`
 	LastAccessedAt *time.Time `json:"last_accessed_at,omitempty"`
+
+	RotationFunctionID   string     `json:"rotation_function_id,omitempty"`
+	RotationIntervalDays int        `json:"rotation_interval_days,omitempty"`
+	LastRotatedAt        *time.Time `json:"last_rotated_at,omitempty"`
+	NextRotationAt       *time.Time `json:"next_rotation_at,omitempty"`
+}
+
+// SecretVersion describes one stored value of a secret and the stage labels attached to it.
+type SecretVersion struct {
+	ID        string    `json:"id"`
+	SecretID  string    `json:"secret_id"`
+	Version   int       `json:"version"`
+	Value     string    `json:"value,omitempty"`
+	Stages    []string  `json:"stages"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 // CreateSecretInput defines parameters for creating a secret.
@@ -44,10 +64,7 @@ func (c *Client) ListSecrets() ([]*Secret, error) {
 }
 
 func (c *Client) GetSecret(idOrName string) (*Secret, error) {
-	id, err := c.resolveID("secret", func() ([]interface{}, error) {
-		secrets, err := c.ListSecrets()
-		return interfaceSlicePtr(secrets), err
-	}, func(v interface{}) string { return v.(*Secret).ID }, func(v interface{}) string { return v.(*Secret).Name }, idOrName)
+	id, err := c.resolveSecretID(idOrName)
 	if err != nil {
 		return nil, err
 	}
@@ -59,12 +76,101 @@ func (c *Client) GetSecret(idOrName string) (*Secret, error) {
 }
 
 func (c *Client) DeleteSecret(idOrName string) error {
-	id, err := c.resolveID("secret", func() ([]interface{}, error) {
+	id, err := c.resolveSecretID(idOrName)
+	if err != nil {
+		return err
+	}
+	return c.delete("/secrets/"+id, nil)
+}
+
+func (c *Client) resolveSecretID(idOrName string) (string, error) {
+	return c.resolveID("secret", func() ([]interface{}, error) {
 		secrets, err := c.ListSecrets()
 		return interfaceSlicePtr(secrets), err
 	}, func(v interface{}) string { return v.(*Secret).ID }, func(v interface{}) string { return v.(*Secret).Name }, idOrName)
+}
+
+// PutSecretValue stores a new version of a secret. Stage is "current" (default) or "pending".
+func (c *Client) PutSecretValue(idOrName, value, stage string) (*SecretVersion, error) {
+	id, err := c.resolveSecretID(idOrName)
+	if err != nil {
+		return nil, err
+	}
+	body := map[string]string{"value": value, "stage": stage}
+	var resp Response[SecretVersion]
+	if err := c.put("/secrets/"+id+"/value", body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// ListSecretVersions lists the versions of a secret without their values.
+func (c *Client) ListSecretVersions(idOrName string) ([]*SecretVersion, error) {
+	id, err := c.resolveSecretID(idOrName)
+	if err != nil {
+		return nil, err
+	}
+	var resp Response[[]*SecretVersion]
+	if err := c.get("/secrets/"+id+"/versions", &resp); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// GetSecretVersion returns a decrypted version, addressed by number ("3") or stage ("previous").
+func (c *Client) GetSecretVersion(idOrName, versionOrStage string) (*SecretVersion, error) {
+	id, err := c.resolveSecretID(idOrName)
+	if err != nil {
+		return nil, err
+	}
+	var resp Response[SecretVersion]
+	if err := c.get("/secrets/"+id+"/versions/"+versionOrStage, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// MoveSecretStage attaches a stage label to the given version.
+func (c *Client) MoveSecretStage(idOrName, stage string, version int) error {
+	id, err := c.resolveSecretID(idOrName)
 	if err != nil {
 		return err
 	}
-	return c.delete("/secrets/"+id, nil)
+	return c.put(fmt.Sprintf("/secrets/%s/stages/%s", id, stage), map[string]int{"version": version}, nil)
+}
+
+// ConfigureSecretRotation schedules rotation of a secret through a function.
+func (c *Client) ConfigureSecretRotation(idOrName, functionID string, intervalDays int) (*Secret, error) {
+	id, err := c.resolveSecretID(idOrName)
+	if err != nil {
+		return nil, err
+	}
+	body := map[string]interface{}{"function_id": functionID, "interval_days": intervalDays}
+	var resp Response[Secret]
+	if err := c.put("/secrets/"+id+"/rotation", body, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// DisableSecretRotation removes the rotation schedule of a secret.
+func (c *Client) DisableSecretRotation(idOrName string) error {
+	id, err := c.resolveSecretID(idOrName)
+	if err != nil {
+		return err
+	}
+	return c.delete("/secrets/"+id+"/rotation", nil)
+}
+
+// RotateSecret runs the rotation function immediately and returns the new current version.
+func (c *Client) RotateSecret(idOrName string) (*SecretVersion, error) {
+	id, err := c.resolveSecretID(idOrName)
+	if err != nil {
+		return nil, err
+	}
+	var resp Response[SecretVersion]
+	if err := c.post("/secrets/"+id+"/rotate", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
 }
@@ -157,3 +157,37 @@ func TestClientSecretErrors(t *testing.T) {
 	err = client.DeleteSecret(secretTestID)
 	require.Error(t, err)
 }
+
+func TestClientSecretVersions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(secretTestContentType, secretTestAppJSON)
+		switch {
+		case r.URL.Path == "/secrets" && r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(Response[[]*Secret]{Data: []*Secret{{ID: secretTestID, Name: secretTestName}}})
+		case r.URL.Path == "/secrets/"+secretTestID+"/value" && r.Method == http.MethodPut:
+			var req map[string]string
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "pending", req["stage"])
+			_ = json.NewEncoder(w).Encode(Response[SecretVersion]{Data: SecretVersion{Version: 2, Stages: []string{"pending"}}})
+		case r.URL.Path == "/secrets/"+secretTestID+"/versions/previous" && r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(Response[SecretVersion]{Data: SecretVersion{Version: 1, Value: secretTestValue}})
+		case r.URL.Path == "/secrets/"+secretTestID+"/stages/current" && r.Method == http.MethodPut:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, secretTestAPIKey)
+
+	v, err := client.PutSecretValue(secretTestName, "new", "pending")
+	require.NoError(t, err)
+	assert.Equal(t, 2, v.Version)
+
+	prev, err := client.GetSecretVersion(secretTestName, "previous")
+	require.NoError(t, err)
+	assert.Equal(t, secretTestValue, prev.Value)
+
+	require.NoError(t, client.MoveSecretStage(secretTestName, "current", 2))
+}
@@ -26,6 +26,8 @@ type SecurityRule struct {
 	PortMax   int    `json:"port_max"`
 	CIDR      string `json:"cidr"`
 	Priority  int    `json:"priority"`
+	// SourceGroupID matches the instances of another group instead of CIDR.
+	SourceGroupID string `json:"source_group_id,omitempty"`
 }
 
 func (c *Client) CreateSecurityGroup(vpcID, name, description string) (*SecurityGroup, error) {
@@ -8,14 +8,15 @@ import (
 
 // Subnet describes a VPC subnet.
 type Subnet struct {
-	ID        string    `json:"id"`
-	VpcID     string    `json:"vpc_id"`
-	Name      string    `json:"name"`
-	CIDRBlock string    `json:"cidr_block"`
-	AZ        string    `json:"availability_zone"`
-	GatewayIP string    `json:"gateway_ip"`
-	Status    string    `json:"status"`
-	CreatedAt time.Time `json:"created_at"`
+	ID            string    `json:"id"`
+	VpcID         string    `json:"vpc_id"`
+	Name          string    `json:"name"`
+	CIDRBlock     string    `json:"cidr_block"`
+	IPv6CIDRBlock string    `json:"ipv6_cidr_block,omitempty"`
+	AZ            string    `json:"availability_zone"`
+	GatewayIP     string    `json:"gateway_ip"`
+	Status        string    `json:"status"`
+	CreatedAt     time.Time `json:"created_at"`
 }
 
 func (c *Client) ListSubnets(vpcID string) ([]*Subnet, error) {
@@ -44,3 +45,12 @@ func (c *Client) GetSubnet(id string) (*Subnet, error) {
 	err := c.get(fmt.Sprintf("/subnets/%s", id), &resp)
 	return resp.Data, err
 }
+
+// AssignSubnetIPv6CIDRBlock gives a subnet a /64 from its VPC's IPv6 block.
+// An empty cidrBlock picks the next free /64.
+func (c *Client) AssignSubnetIPv6CIDRBlock(id, cidrBlock string) (*Subnet, error) {
+	var resp Response[*Subnet]
+	body := map[string]string{"ipv6_cidr_block": cidrBlock}
+	err := c.post(fmt.Sprintf("/subnets/%s/ipv6", id), body, &resp)
+	return resp.Data, err
+}
@@ -44,3 +44,99 @@ func (c *Client) CreateTenant(name, slug string) (*Tenant, error) {
 func (c *Client) SwitchTenant(id string) error {
 	return c.post(fmt.Sprintf("/tenants/%s/switch", id), nil, nil)
 }
+
+// TenantMember describes a user's membership in a tenant.
+type TenantMember struct {
+	TenantID string    `json:"tenant_id"`
+	UserID   string    `json:"user_id"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// TenantInvitation describes an invitation to join a tenant.
+// Token is only populated when the invitation is created or resent.
+type TenantInvitation struct {
+	ID        string    `json:"id"`
+	TenantID  string    `json:"tenant_id"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	Status    string    `json:"status"`
+	InvitedBy string    `json:"invited_by"`
+	Token     string    `json:"token,omitempty"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// InviteTenantMember invites an email address to join a tenant.
+func (c *Client) InviteTenantMember(tenantID, email, role string) (*TenantInvitation, error) {
+	body := map[string]string{
+		"email": email,
+		"role":  role,
+	}
+	var res Response[TenantInvitation]
+	if err := c.post(fmt.Sprintf("/tenants/%s/members", tenantID), body, &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// ListTenantInvitations returns the pending invitations of a tenant.
+func (c *Client) ListTenantInvitations(tenantID string) ([]TenantInvitation, error) {
+	var res Response[[]TenantInvitation]
+	if err := c.get(fmt.Sprintf("/tenants/%s/invitations", tenantID), &res); err != nil {
+		return nil, err
+	}
+	return res.Data, nil
+}
+
+// ResendTenantInvitation issues a new token for a pending invitation.
+func (c *Client) ResendTenantInvitation(tenantID, invitationID string) (*TenantInvitation, error) {
+	var res Response[TenantInvitation]
+	if err := c.post(fmt.Sprintf("/tenants/%s/invitations/%s/resend", tenantID, invitationID), nil, &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// RevokeTenantInvitation cancels a pending invitation.
+func (c *Client) RevokeTenantInvitation(tenantID, invitationID string) error {
+	return c.delete(fmt.Sprintf("/tenants/%s/invitations/%s", tenantID, invitationID), nil)
+}
+
+// ListMyInvitations returns pending invitations addressed to the current user.
+func (c *Client) ListMyInvitations() ([]TenantInvitation, error) {
+	var res Response[[]TenantInvitation]
+	if err := c.get("/tenant-invitations", &res); err != nil {
+		return nil, err
+	}
+	return res.Data, nil
+}
+
+// AcceptInvitation joins the tenant an invitation token was issued for.
+func (c *Client) AcceptInvitation(token string) (*TenantMember, error) {
+	var res Response[TenantMember]
+	if err := c.post("/tenant-invitations/accept", map[string]string{"token": token}, &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// DeclineInvitation rejects an invitation.
+func (c *Client) DeclineInvitation(token string) error {
+	return c.post("/tenant-invitations/decline", map[string]string{"token": token}, nil)
+}
+
+// UpdateTenantMemberRole changes a member's role to "admin" or "member".
+func (c *Client) UpdateTenantMemberRole(tenantID, userID, role string) error {
+	return c.put(fmt.Sprintf("/tenants/%s/members/%s", tenantID, userID), map[string]string{"role": role}, nil)
+}
+
+// RemoveTenantMember removes a member from a tenant.
+func (c *Client) RemoveTenantMember(tenantID, userID string) error {
+	return c.delete(fmt.Sprintf("/tenants/%s/members/%s", tenantID, userID), nil)
+}
+
+// TransferTenantOwnership makes another member the tenant owner.
+func (c *Client) TransferTenantOwnership(tenantID, userID string) error {
+	return c.post(fmt.Sprintf("/tenants/%s/transfer-ownership", tenantID), map[string]string{"user_id": userID}, nil)
+}
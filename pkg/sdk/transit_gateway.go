@@ -0,0 +1,133 @@
+package sdk
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+)
+
+// CreateTransitGateway creates a transit gateway with a default route table.
+func (c *Client) CreateTransitGateway(ctx context.Context, name string) (*domain.TransitGateway, error) {
+	body := map[string]string{"name": name}
+	var res Response[domain.TransitGateway]
+	if err := c.postWithContext(ctx, "/transit-gateways", body, &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// ListTransitGateways lists the tenant's transit gateways.
+func (c *Client) ListTransitGateways(ctx context.Context) ([]domain.TransitGateway, error) {
+	var res Response[[]domain.TransitGateway]
+	if err := c.getWithContext(ctx, "/transit-gateways", &res); err != nil {
+		return nil, err
+	}
+	return res.Data, nil
+}
+
+// GetTransitGateway returns a transit gateway.
+func (c *Client) GetTransitGateway(ctx context.Context, id uuid.UUID) (*domain.TransitGateway, error) {
+	var res Response[domain.TransitGateway]
+	if err := c.getWithContext(ctx, "/transit-gateways/"+id.String(), &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// DeleteTransitGateway removes a transit gateway that has no attachments.
+func (c *Client) DeleteTransitGateway(ctx context.Context, id uuid.UUID) error {
+	return c.deleteWithContext(ctx, "/transit-gateways/"+id.String(), nil)
+}
+
+// AttachVPCToTransitGateway attaches a VPC to a transit gateway.
+func (c *Client) AttachVPCToTransitGateway(ctx context.Context, tgwID, vpcID uuid.UUID) (*domain.TransitGatewayAttachment, error) {
+	body := map[string]string{"vpc_id": vpcID.String()}
+	var res Response[domain.TransitGatewayAttachment]
+	if err := c.postWithContext(ctx, "/transit-gateways/"+tgwID.String()+"/attachments", body, &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// ListTransitGatewayAttachments lists the VPC attachments of a transit gateway.
+func (c *Client) ListTransitGatewayAttachments(ctx context.Context, tgwID uuid.UUID) ([]domain.TransitGatewayAttachment, error) {
+	var res Response[[]domain.TransitGatewayAttachment]
+	if err := c.getWithContext(ctx, "/transit-gateways/"+tgwID.String()+"/attachments", &res); err != nil {
+		return nil, err
+	}
+	return res.Data, nil
+}
+
+// DetachVPCFromTransitGateway removes a VPC attachment.
+func (c *Client) DetachVPCFromTransitGateway(ctx context.Context, attachmentID uuid.UUID) error {
+	return c.deleteWithContext(ctx, "/transit-gateway-attachments/"+attachmentID.String(), nil)
+}
+
+// CreateTransitGatewayRouteTable adds a route table to a transit gateway.
+func (c *Client) CreateTransitGatewayRouteTable(ctx context.Context, tgwID uuid.UUID, name string) (*domain.TransitGatewayRouteTable, error) {
+	body := map[string]string{"name": name}
+	var res Response[domain.TransitGatewayRouteTable]
+	if err := c.postWithContext(ctx, "/transit-gateways/"+tgwID.String()+"/route-tables", body, &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// ListTransitGatewayRouteTables lists the route tables of a transit gateway.
+func (c *Client) ListTransitGatewayRouteTables(ctx context.Context, tgwID uuid.UUID) ([]domain.TransitGatewayRouteTable, error) {
+	var res Response[[]domain.TransitGatewayRouteTable]
+	if err := c.getWithContext(ctx, "/transit-gateways/"+tgwID.String()+"/route-tables", &res); err != nil {
+		return nil, err
+	}
+	return res.Data, nil
+}
+
+// GetTransitGatewayRouteTable returns a route table with its routes and propagations.
+func (c *Client) GetTransitGatewayRouteTable(ctx context.Context, id uuid.UUID) (*domain.TransitGatewayRouteTable, error) {
+	var res Response[domain.TransitGatewayRouteTable]
+	if err := c.getWithContext(ctx, "/transit-gateway-route-tables/"+id.String(), &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// DeleteTransitGatewayRouteTable removes a route table.
+func (c *Client) DeleteTransitGatewayRouteTable(ctx context.Context, id uuid.UUID) error {
+	return c.deleteWithContext(ctx, "/transit-gateway-route-tables/"+id.String(), nil)
+}
+
+// AddTransitGatewayRoute adds a static route; a nil attachment creates a blackhole route.
+func (c *Client) AddTransitGatewayRoute(ctx context.Context, rtID uuid.UUID, destinationCIDR string, attachmentID *uuid.UUID) (*domain.TransitGatewayRoute, error) {
+	body := map[string]string{"destination_cidr": destinationCIDR}
+	if attachmentID != nil {
+		body["attachment_id"] = attachmentID.String()
+	}
+	var res Response[domain.TransitGatewayRoute]
+	if err := c.postWithContext(ctx, "/transit-gateway-route-tables/"+rtID.String()+"/routes", body, &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// RemoveTransitGatewayRoute removes a static route.
+func (c *Client) RemoveTransitGatewayRoute(ctx context.Context, rtID, routeID uuid.UUID) error {
+	return c.deleteWithContext(ctx, "/transit-gateway-route-tables/"+rtID.String()+"/routes/"+routeID.String(), nil)
+}
+
+// AssociateTransitGatewayRouteTable makes the route table forward traffic arriving from an attachment.
+func (c *Client) AssociateTransitGatewayRouteTable(ctx context.Context, rtID, attachmentID uuid.UUID) error {
+	body := map[string]string{"attachment_id": attachmentID.String()}
+	return c.postWithContext(ctx, "/transit-gateway-route-tables/"+rtID.String()+"/associations", body, nil)
+}
+
+// EnableTransitGatewayPropagation learns an attachment's VPC CIDRs into the route table.
+func (c *Client) EnableTransitGatewayPropagation(ctx context.Context, rtID, attachmentID uuid.UUID) error {
+	body := map[string]string{"attachment_id": attachmentID.String()}
+	return c.postWithContext(ctx, "/transit-gateway-route-tables/"+rtID.String()+"/propagations", body, nil)
+}
+
+// DisableTransitGatewayPropagation withdraws an attachment's propagated routes.
+func (c *Client) DisableTransitGatewayPropagation(ctx context.Context, rtID, attachmentID uuid.UUID) error {
+	return c.deleteWithContext(ctx, "/transit-gateway-route-tables/"+rtID.String()+"/propagations/"+attachmentID.String(), nil)
+}
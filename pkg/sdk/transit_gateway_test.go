@@ -0,0 +1,63 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientAttachVPCToTransitGateway(t *testing.T) {
+	t.Parallel()
+	tgwID, vpcID := uuid.New(), uuid.New()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/transit-gateways/"+tgwID.String()+"/attachments", r.URL.Path)
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		var req map[string]string
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, vpcID.String(), req["vpc_id"])
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Response[domain.TransitGatewayAttachment]{Data: domain.TransitGatewayAttachment{ID: uuid.New(), TransitGatewayID: tgwID, VPCID: vpcID, Status: domain.TransitGatewayAttachmentStatusAvailable}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	att, err := client.AttachVPCToTransitGateway(context.Background(), tgwID, vpcID)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.TransitGatewayAttachmentStatusAvailable, att.Status)
+}
+
+func TestClientAddTransitGatewayBlackholeRoute(t *testing.T) {
+	t.Parallel()
+	rtID := uuid.New()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/transit-gateway-route-tables/"+rtID.String()+"/routes", r.URL.Path)
+
+		var req map[string]string
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "10.9.0.0/16", req["destination_cidr"])
+		_, hasAttachment := req["attachment_id"]
+		assert.False(t, hasAttachment)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Response[domain.TransitGatewayRoute]{Data: domain.TransitGatewayRoute{ID: uuid.New(), RouteTableID: rtID, DestinationCIDR: "10.9.0.0/16", Type: domain.TransitGatewayRouteStatic}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	route, err := client.AddTransitGatewayRoute(context.Background(), rtID, "10.9.0.0/16", nil)
+
+	require.NoError(t, err)
+	assert.True(t, route.IsBlackhole())
+}
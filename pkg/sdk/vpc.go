@@ -8,14 +8,15 @@ import (
 
 // VPC describes a virtual private cloud.
 type VPC struct {
-	ID        string    `json:"id"`
-	Name      string    `json:"name"`
-	CIDRBlock string    `json:"cidr_block"`
-	NetworkID string    `json:"network_id"`
-	VXLANID   int       `json:"vxlan_id"`
-	Status    string    `json:"status"`
-	ARN       string    `json:"arn"`
-	CreatedAt time.Time `json:"created_at"`
+	ID            string    `json:"id"`
+	Name          string    `json:"name"`
+	CIDRBlock     string    `json:"cidr_block"`
+	IPv6CIDRBlock string    `json:"ipv6_cidr_block,omitempty"`
+	NetworkID     string    `json:"network_id"`
+	VXLANID       int       `json:"vxlan_id"`
+	Status        string    `json:"status"`
+	ARN           string    `json:"arn"`
+	CreatedAt     time.Time `json:"created_at"`
 }
 
 func (c *Client) ListVPCs() ([]VPC, error) {
@@ -49,3 +50,13 @@ func (c *Client) GetVPC(id string) (*VPC, error) {
 func (c *Client) DeleteVPC(id string) error {
 	return c.delete(fmt.Sprintf("/vpcs/%s", id), nil)
 }
+
+// AssignVPCIPv6CIDRBlock makes a VPC dual-stack. An empty cidrBlock lets the platform generate one.
+func (c *Client) AssignVPCIPv6CIDRBlock(id, cidrBlock string) (*VPC, error) {
+	body := map[string]string{"ipv6_cidr_block": cidrBlock}
+	var res Response[VPC]
+	if err := c.post(fmt.Sprintf("/vpcs/%s/ipv6", id), body, &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
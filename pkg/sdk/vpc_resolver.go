@@ -0,0 +1,65 @@
+package sdk
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+)
+
+// CreateVPCResolver launches the DNS resolver of a VPC.
+func (c *Client) CreateVPCResolver(ctx context.Context, vpcID uuid.UUID) (*domain.VPCResolver, error) {
+	body := map[string]string{"vpc_id": vpcID.String()}
+	var res Response[domain.VPCResolver]
+	if err := c.postWithContext(ctx, "/vpc-resolvers", body, &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// ListVPCResolvers lists the tenant's VPC resolvers.
+func (c *Client) ListVPCResolvers(ctx context.Context) ([]domain.VPCResolver, error) {
+	var res Response[[]domain.VPCResolver]
+	if err := c.getWithContext(ctx, "/vpc-resolvers", &res); err != nil {
+		return nil, err
+	}
+	return res.Data, nil
+}
+
+// GetVPCResolver returns a VPC resolver.
+func (c *Client) GetVPCResolver(ctx context.Context, id uuid.UUID) (*domain.VPCResolver, error) {
+	var res Response[domain.VPCResolver]
+	if err := c.getWithContext(ctx, "/vpc-resolvers/"+id.String(), &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// DeleteVPCResolver removes a VPC resolver and its forwarding rules.
+func (c *Client) DeleteVPCResolver(ctx context.Context, id uuid.UUID) error {
+	return c.deleteWithContext(ctx, "/vpc-resolvers/"+id.String(), nil)
+}
+
+// CreateResolverForwardingRule forwards queries for a domain to the given resolvers ("ip" or "ip:port").
+func (c *Client) CreateResolverForwardingRule(ctx context.Context, resolverID uuid.UUID, domainName string, targetIPs []string) (*domain.ResolverForwardingRule, error) {
+	body := map[string]interface{}{"domain_name": domainName, "target_ips": targetIPs}
+	var res Response[domain.ResolverForwardingRule]
+	if err := c.postWithContext(ctx, "/vpc-resolvers/"+resolverID.String()+"/rules", body, &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// ListResolverForwardingRules lists the forwarding rules of a resolver.
+func (c *Client) ListResolverForwardingRules(ctx context.Context, resolverID uuid.UUID) ([]domain.ResolverForwardingRule, error) {
+	var res Response[[]domain.ResolverForwardingRule]
+	if err := c.getWithContext(ctx, "/vpc-resolvers/"+resolverID.String()+"/rules", &res); err != nil {
+		return nil, err
+	}
+	return res.Data, nil
+}
+
+// DeleteResolverForwardingRule removes a forwarding rule.
+func (c *Client) DeleteResolverForwardingRule(ctx context.Context, resolverID, ruleID uuid.UUID) error {
+	return c.deleteWithContext(ctx, "/vpc-resolvers/"+resolverID.String()+"/rules/"+ruleID.String(), nil)
+}
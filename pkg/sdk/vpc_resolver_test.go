@@ -0,0 +1,65 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientCreateVPCResolver(t *testing.T) {
+	t.Parallel()
+	vpcID := uuid.New()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/vpc-resolvers", r.URL.Path)
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		var req map[string]string
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, vpcID.String(), req["vpc_id"])
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Response[domain.VPCResolver]{Data: domain.VPCResolver{ID: uuid.New(), VPCID: vpcID, Status: domain.VPCResolverStatusActive, PrivateIP: "10.0.0.2"}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	resolver, err := client.CreateVPCResolver(context.Background(), vpcID)
+
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.2", resolver.PrivateIP)
+}
+
+func TestClientCreateResolverForwardingRule(t *testing.T) {
+	t.Parallel()
+	resolverID := uuid.New()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/vpc-resolvers/"+resolverID.String()+"/rules", r.URL.Path)
+
+		var req struct {
+			DomainName string   `json:"domain_name"`
+			TargetIPs  []string `json:"target_ips"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "corp.example.com", req.DomainName)
+		assert.Equal(t, []string{"10.50.0.2"}, req.TargetIPs)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Response[domain.ResolverForwardingRule]{Data: domain.ResolverForwardingRule{ID: uuid.New(), ResolverID: resolverID, DomainName: req.DomainName, TargetIPs: req.TargetIPs}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	rule, err := client.CreateResolverForwardingRule(context.Background(), resolverID, "corp.example.com", []string{"10.50.0.2"})
+
+	require.NoError(t, err)
+	assert.Equal(t, resolverID, rule.ResolverID)
+}
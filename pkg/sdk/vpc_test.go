@@ -115,3 +115,25 @@ func TestClientVPCErrors(t *testing.T) {
 	err = client.DeleteVPC("vpc-1")
 	require.Error(t, err)
 }
+
+func TestClientAssignVPCIPv6CIDRBlock(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/vpcs/vpc-1/ipv6", r.URL.Path)
+		assert.Equal(t, "POST", r.Method)
+
+		var body map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		assert.Equal(t, "", body["ipv6_cidr_block"])
+
+		w.Header().Set(contentType, testutil.TestContentTypeAppJSON)
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(Response[VPC]{Data: VPC{ID: "vpc-1", IPv6CIDRBlock: "fd12:3456:7800::/56"}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, testAPIKey)
+	vpc, err := client.AssignVPCIPv6CIDRBlock("vpc-1", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, "fd12:3456:7800::/56", vpc.IPv6CIDRBlock)
+}
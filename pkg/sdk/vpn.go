@@ -0,0 +1,104 @@
+package sdk
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+)
+
+// CreateVPNGateway launches a WireGuard VPN gateway in a VPC.
+func (c *Client) CreateVPNGateway(ctx context.Context, vpcID uuid.UUID, name string) (*domain.VPNGateway, error) {
+	body := map[string]string{"vpc_id": vpcID.String(), "name": name}
+	var res Response[domain.VPNGateway]
+	if err := c.postWithContext(ctx, "/vpn-gateways", body, &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// ListVPNGateways lists the VPN gateways of a VPC.
+func (c *Client) ListVPNGateways(ctx context.Context, vpcID uuid.UUID) ([]domain.VPNGateway, error) {
+	var res Response[[]domain.VPNGateway]
+	if err := c.getWithContext(ctx, "/vpn-gateways?vpc_id="+vpcID.String(), &res); err != nil {
+		return nil, err
+	}
+	return res.Data, nil
+}
+
+// GetVPNGateway returns a VPN gateway.
+func (c *Client) GetVPNGateway(ctx context.Context, id uuid.UUID) (*domain.VPNGateway, error) {
+	var res Response[domain.VPNGateway]
+	if err := c.getWithContext(ctx, "/vpn-gateways/"+id.String(), &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// DeleteVPNGateway removes a VPN gateway that has no connections.
+func (c *Client) DeleteVPNGateway(ctx context.Context, id uuid.UUID) error {
+	return c.deleteWithContext(ctx, "/vpn-gateways/"+id.String(), nil)
+}
+
+// CreateCustomerGateway registers an on-premises WireGuard endpoint.
+func (c *Client) CreateCustomerGateway(ctx context.Context, name, ipAddress, publicKey string) (*domain.CustomerGateway, error) {
+	body := map[string]string{"name": name, "ip_address": ipAddress, "public_key": publicKey}
+	var res Response[domain.CustomerGateway]
+	if err := c.postWithContext(ctx, "/customer-gateways", body, &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// ListCustomerGateways lists the tenant's customer gateways.
+func (c *Client) ListCustomerGateways(ctx context.Context) ([]domain.CustomerGateway, error) {
+	var res Response[[]domain.CustomerGateway]
+	if err := c.getWithContext(ctx, "/customer-gateways", &res); err != nil {
+		return nil, err
+	}
+	return res.Data, nil
+}
+
+// DeleteCustomerGateway removes a customer gateway.
+func (c *Client) DeleteCustomerGateway(ctx context.Context, id uuid.UUID) error {
+	return c.deleteWithContext(ctx, "/customer-gateways/"+id.String(), nil)
+}
+
+// CreateVPNConnection connects a VPN gateway to a customer gateway; remoteCIDRs are
+// routed to the VPN gateway from the VPC's main route table.
+func (c *Client) CreateVPNConnection(ctx context.Context, gatewayID, customerGatewayID uuid.UUID, remoteCIDRs []string) (*domain.VPNConnection, error) {
+	body := map[string]interface{}{
+		"vpn_gateway_id":      gatewayID.String(),
+		"customer_gateway_id": customerGatewayID.String(),
+		"remote_cidrs":        remoteCIDRs,
+	}
+	var res Response[domain.VPNConnection]
+	if err := c.postWithContext(ctx, "/vpn-connections", body, &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// ListVPNConnections lists the connections of a VPN gateway.
+func (c *Client) ListVPNConnections(ctx context.Context, gatewayID uuid.UUID) ([]domain.VPNConnection, error) {
+	var res Response[[]domain.VPNConnection]
+	if err := c.getWithContext(ctx, "/vpn-connections?vpn_gateway_id="+gatewayID.String(), &res); err != nil {
+		return nil, err
+	}
+	return res.Data, nil
+}
+
+// GetVPNConnectionConfig returns the settings for the customer's side of a tunnel,
+// including the pre-shared key.
+func (c *Client) GetVPNConnectionConfig(ctx context.Context, id uuid.UUID) (*domain.VPNConnectionConfig, error) {
+	var res Response[domain.VPNConnectionConfig]
+	if err := c.getWithContext(ctx, "/vpn-connections/"+id.String()+"/config", &res); err != nil {
+		return nil, err
+	}
+	return &res.Data, nil
+}
+
+// DeleteVPNConnection removes a VPN connection and its routes.
+func (c *Client) DeleteVPNConnection(ctx context.Context, id uuid.UUID) error {
+	return c.deleteWithContext(ctx, "/vpn-connections/"+id.String(), nil)
+}
@@ -0,0 +1,59 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/poyrazk/thecloud/internal/core/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientCreateVPNConnection(t *testing.T) {
+	t.Parallel()
+	gwID, cgwID := uuid.New(), uuid.New()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/vpn-connections", r.URL.Path)
+		assert.Equal(t, http.MethodPost, r.Method)
+
+		var req map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, gwID.String(), req["vpn_gateway_id"])
+		assert.Equal(t, cgwID.String(), req["customer_gateway_id"])
+		assert.Equal(t, []interface{}{"192.168.0.0/16"}, req["remote_cidrs"])
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Response[domain.VPNConnection]{Data: domain.VPNConnection{ID: uuid.New(), VPNGatewayID: gwID, Status: domain.VPNConnectionStatusAvailable}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	conn, err := client.CreateVPNConnection(context.Background(), gwID, cgwID, []string{"192.168.0.0/16"})
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.VPNConnectionStatusAvailable, conn.Status)
+}
+
+func TestClientGetVPNConnectionConfig(t *testing.T) {
+	t.Parallel()
+	id := uuid.New()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/vpn-connections/"+id.String()+"/config", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(Response[domain.VPNConnectionConfig]{Data: domain.VPNConnectionConfig{GatewayPort: 40001, AllowedIPs: []string{"10.0.0.0/16"}}})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "test-api-key")
+	cfg, err := client.GetVPNConnectionConfig(context.Background(), id)
+
+	require.NoError(t, err)
+	assert.Equal(t, 40001, cfg.GatewayPort)
+	assert.Equal(t, []string{"10.0.0.0/16"}, cfg.AllowedIPs)
+}